@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"path/filepath"
 
 	"HubInvestments/shared/infra/database"
 	"HubInvestments/shared/infra/migration"
@@ -19,6 +20,9 @@ func main() {
 		steps       = flag.Int("steps", 1, "Number of steps for 'steps' command")
 		version     = flag.Int("version", 0, "Version for 'force' command")
 		databaseURL = flag.String("db", "", "Database URL (defaults to local development)")
+		module      = flag.String("module", "", "Module whose migrations are run in isolation (defaults to the whole project)")
+		path        = flag.String("path", "", "Migrations directory for -module (defaults to shared/infra/migration/sql/<module>)")
+		schema      = flag.String("schema", "", "Schema holding the module's version table (defaults to the current schema)")
 	)
 	flag.Parse()
 
@@ -28,19 +32,38 @@ func main() {
 		dbURL = defaultDatabaseURL
 	}
 
+	mgr, err := newMigrationManager(dbURL, *module, *path, *schema)
+	if err != nil {
+		log.Fatalf("Failed to create migration manager: %v", err)
+	}
+	defer mgr.Close()
+
 	// Run migrations
-	err := runMigration(*command, *steps, *version, dbURL)
+	err = runMigration(mgr, *command, *steps, *version)
 	if err != nil {
 		log.Fatalf("Migration failed: %v", err)
 	}
 }
 
-func runMigration(command string, steps, version int, databaseURL string) error {
-	mgr, err := migration.NewMigrationManager(databaseURL)
-	if err != nil {
-		return fmt.Errorf("failed to create migration manager: %w", err)
+// newMigrationManager creates the project-wide manager, or an isolated one when a module is given
+func newMigrationManager(databaseURL, module, path, schema string) (*migration.MigrationManager, error) {
+	if module == "" {
+		return migration.NewMigrationManager(databaseURL)
 	}
-	defer mgr.Close()
+
+	if path == "" {
+		path = filepath.Join("shared/infra/migration/sql", module)
+	}
+
+	return migration.NewModuleMigrationManager(databaseURL, migration.ModuleMigrationConfig{
+		Module:         module,
+		MigrationsPath: path,
+		SchemaName:     schema,
+	})
+}
+
+func runMigration(mgr *migration.MigrationManager, command string, steps, version int) error {
+	var err error
 
 	switch command {
 	case "up":
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	positionPersistence "HubInvestments/internal/position/infra/persistence"
+	positionWorker "HubInvestments/internal/position/infra/worker"
+	"HubInvestments/shared/infra/database"
+)
+
+func main() {
+	var (
+		since     = flag.String("since", "", "Replay orders executed at or after this RFC3339 timestamp (required)")
+		apply     = flag.Bool("apply", false, "Write rebuilt positions (default is a dry run)")
+		userID    = flag.String("user", "", "Only replay this user's orders")
+		tolerance = flag.Float64("tolerance", 0.000001, "Differences up to this value are reported as unchanged")
+	)
+	flag.Parse()
+
+	sinceTime, err := time.Parse(time.RFC3339, *since)
+	if err != nil {
+		log.Fatalf("Invalid -since timestamp %q: %v", *since, err)
+	}
+
+	mode := positionWorker.PositionReplayModeDryRun
+	if *apply {
+		mode = positionWorker.PositionReplayModeApply
+	}
+
+	db, err := database.CreateDatabaseConnectionWithConfig(databaseConfigFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	replayer := positionWorker.NewPositionReplayer(positionWorker.NewOrderTableEventSource(db), positionPersistence.NewPositionRepository(db))
+	report, err := replayer.Replay(context.Background(), positionWorker.PositionReplayConfig{
+		Since:     sinceTime,
+		Mode:      mode,
+		UserID:    *userID,
+		Tolerance: *tolerance,
+	})
+	if err != nil {
+		log.Fatalf("Position replay failed: %v", err)
+	}
+
+	printReport(report)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// databaseConfigFromEnv reads the same DB_* variables as the API server
+func databaseConfigFromEnv() database.ConnectionConfig {
+	if os.Getenv("DB_HOST") == "" {
+		return database.DefaultConfig()
+	}
+
+	sslMode := os.Getenv("DB_SSLMODE")
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	return database.ConnectionConfig{
+		Driver:   "postgres",
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		Database: os.Getenv("DB_NAME"),
+		Username: os.Getenv("DB_USER"),
+		Password: os.Getenv("DB_PASSWORD"),
+		SSLMode:  sslMode,
+	}
+}
+
+func printReport(report *positionWorker.PositionReplayReport) {
+	fmt.Printf("Position replay (%s) since %s\n", report.Mode, report.Since.Format(time.RFC3339))
+	fmt.Printf("Events replayed: %d, skipped: %d\n", report.EventsReplayed, report.EventsSkipped)
+
+	for _, diff := range report.Diffs {
+		fmt.Printf("  %-6s user=%s symbol=%s quantity %.6f -> %.6f, average price %.4f -> %.4f, status %s -> %s\n",
+			diff.Change, diff.UserID, diff.Symbol,
+			diff.CurrentQuantity, diff.RebuiltQuantity,
+			diff.CurrentAveragePrice, diff.RebuiltAveragePrice,
+			diff.CurrentStatus, diff.RebuiltStatus)
+	}
+	for _, skipped := range report.SkippedEvents {
+		fmt.Printf("  skipped %s\n", skipped)
+	}
+	for _, replayErr := range report.Errors {
+		fmt.Printf("  error %s\n", replayErr)
+	}
+
+	fmt.Printf("Changed: %d, unchanged: %d, applied: %d, failed: %d\n",
+		len(report.Diffs), report.Unchanged, report.Applied, report.Failed)
+}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS order_execution_quality (
+    order_id UUID PRIMARY KEY REFERENCES orders(id),
+    symbol VARCHAR(20) NOT NULL,
+    order_side VARCHAR(10) NOT NULL CHECK (order_side IN ('BUY', 'SELL')),
+    quantity DECIMAL(18,8) NOT NULL,
+    estimated_fill_price DECIMAL(18,8) NOT NULL,
+    actual_fill_price DECIMAL(18,8) NOT NULL,
+    slippage DECIMAL(18,8) NOT NULL,
+    slippage_bps DECIMAL(18,4) NOT NULL,
+    slippage_cost DECIMAL(18,8) NOT NULL,
+    slippage_tolerance_percent DECIMAL(10,4) NOT NULL,
+    quality VARCHAR(20) NOT NULL CHECK (quality IN ('PRICE_IMPROVEMENT', 'EXACT', 'WITHIN_TOLERANCE', 'POOR')),
+    executed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_execution_quality_symbol ON order_execution_quality(symbol);
@@ -0,0 +1,14 @@
+-- Write-once record of the market each order was submitted into
+CREATE TABLE IF NOT EXISTS order_market_context_snapshots (
+    order_id UUID PRIMARY KEY REFERENCES orders(id),
+    symbol VARCHAR(20) NOT NULL,
+    bid_price DECIMAL(18,8) NOT NULL,
+    ask_price DECIMAL(18,8) NOT NULL,
+    last_price DECIMAL(18,8) NOT NULL,
+    spread DECIMAL(18,8) NOT NULL,
+    spread_percent DECIMAL(10,4) NOT NULL,
+    volatility DECIMAL(10,4) NOT NULL,
+    volume BIGINT NOT NULL DEFAULT 0,
+    quote_timestamp TIMESTAMP,
+    captured_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,14 @@
+-- Actions staff took on users' orders, such as force cancellations, with who took them and why
+CREATE TABLE IF NOT EXISTS order_admin_actions (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id),
+    order_owner_id INTEGER NOT NULL REFERENCES users(id),
+    action VARCHAR(30) NOT NULL,
+    performed_by INTEGER NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    previous_status VARCHAR(20) NOT NULL,
+    new_status VARCHAR(20) NOT NULL,
+    performed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_admin_actions_order_performed_at ON order_admin_actions(order_id, performed_at DESC);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS order_fills (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id),
+    quantity DECIMAL(18,8) NOT NULL CHECK (quantity > 0),
+    price DECIMAL(18,8) NOT NULL CHECK (price > 0),
+    filled_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_fills_order_id ON order_fills(order_id, filled_at);
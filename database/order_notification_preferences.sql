@@ -0,0 +1,9 @@
+-- One column per order event holding the channel it is delivered through; NULL means the user opted out
+CREATE TABLE IF NOT EXISTS order_notification_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    submitted_channel VARCHAR(10) CHECK (submitted_channel IN ('IN_APP', 'EMAIL', 'PUSH')),
+    filled_channel VARCHAR(10) CHECK (filled_channel IN ('IN_APP', 'EMAIL', 'PUSH')),
+    cancelled_channel VARCHAR(10) CHECK (cancelled_channel IN ('IN_APP', 'EMAIL', 'PUSH')),
+    rejected_channel VARCHAR(10) CHECK (rejected_channel IN ('IN_APP', 'EMAIL', 'PUSH')),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -4,9 +4,10 @@ CREATE TABLE orders (
     id UUID PRIMARY KEY,
     user_id INTEGER NOT NULL REFERENCES users(id),
     symbol VARCHAR(20) NOT NULL,
-    order_type VARCHAR(20) NOT NULL CHECK (order_type IN ('MARKET', 'LIMIT', 'STOP_LOSS', 'STOP_LIMIT')),
+    order_type VARCHAR(20) NOT NULL CHECK (order_type IN ('MARKET', 'LIMIT', 'STOP_LOSS', 'STOP_LIMIT', 'MARKET_IF_TOUCHED', 'LIMIT_IF_TOUCHED')),
     order_side VARCHAR(10) NOT NULL CHECK (order_side IN ('BUY', 'SELL')),
     quantity DECIMAL(18,8) NOT NULL CHECK (quantity > 0),
+    filled_quantity DECIMAL(18,8) NOT NULL DEFAULT 0 CHECK (filled_quantity >= 0 AND filled_quantity <= quantity),
     price DECIMAL(18,8) CHECK (price > 0),
     status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PROCESSING', 'EXECUTED', 'FAILED', 'CANCELLED')),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -18,7 +19,25 @@ CREATE TABLE orders (
     failure_reason TEXT,
     retry_count INTEGER DEFAULT 0,
     processing_worker_id VARCHAR(50),
-    external_order_id VARCHAR(100)
+    external_order_id VARCHAR(100),
+    protection_limit_price DECIMAL(18,8) CHECK (protection_limit_price > 0),
+    time_in_force VARCHAR(10) NOT NULL DEFAULT 'DAY' CHECK (time_in_force IN ('DAY', 'GTC', 'IOC', 'FOK')),
+    allow_partial_fill BOOLEAN NOT NULL DEFAULT TRUE,
+    execution_strategy VARCHAR(10) CHECK (execution_strategy IN ('MARKET', 'LIMIT', 'TWAP', 'VWAP', 'ICEBERG', 'HIDDEN')),
+    cancellation_reason VARCHAR(30) CHECK (cancellation_reason IN ('USER_REQUESTED', 'MARKET_CLOSED', 'INSUFFICIENT_FUNDS', 'RISK_MANAGEMENT', 'SYSTEM_ERROR', 'EXPIRED', 'ADMIN_ACTION', 'CLIENT_DISCONNECTED', 'OCO_TRIGGERED', 'RISK_HALT', 'RECONCILIATION')),
+    trigger_price DECIMAL(18,8) CHECK (trigger_price > 0),
+    triggered_at TIMESTAMP,
+    settlement_account VARCHAR(34),
+    settlement_instruction_code VARCHAR(3) CHECK (settlement_instruction_code IN ('DVP', 'RVP', 'FOP')),
+    CHECK ((settlement_account IS NULL) = (settlement_instruction_code IS NULL)),
+    peg_reference VARCHAR(3) CHECK (peg_reference IN ('BID', 'MID', 'ASK')),
+    peg_offset DECIMAL(18,8),
+    peg_min_price DECIMAL(18,8) CHECK (peg_min_price > 0),
+    peg_max_price DECIMAL(18,8) CHECK (peg_max_price > 0),
+    repriced_at TIMESTAMP,
+    parent_order_id UUID REFERENCES orders(id),
+    CHECK (peg_min_price IS NULL OR peg_max_price IS NULL OR peg_min_price <= peg_max_price),
+    CHECK (peg_reference IS NOT NULL OR (peg_offset IS NULL AND peg_min_price IS NULL AND peg_max_price IS NULL))
 );
 
 -- Indexes for performance optimization
@@ -28,6 +47,7 @@ CREATE INDEX idx_orders_created_at ON orders(created_at DESC);
 CREATE INDEX idx_orders_symbol ON orders(symbol);
 CREATE INDEX idx_orders_user_status ON orders(user_id, status);
 CREATE INDEX idx_orders_symbol_status ON orders(symbol, status);
+CREATE INDEX idx_orders_parent_order_id ON orders(parent_order_id) WHERE parent_order_id IS NOT NULL;
 
 -- Trigger to automatically update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_orders_updated_at()
@@ -50,6 +70,8 @@ VALUES
     ('550e8400-e29b-41d4-a716-446655440002', 1, 'GOOGL', 'MARKET', 'SELL', 50.00000000, NULL, 'EXECUTED', 2750.00000000, CURRENT_TIMESTAMP - INTERVAL '1 hour'),
     ('550e8400-e29b-41d4-a716-446655440003', 1, 'MSFT', 'LIMIT', 'BUY', 75.00000000, 300.00000000, 'CANCELLED', 305.50000000, CURRENT_TIMESTAMP - INTERVAL '2 hours');
 
+UPDATE orders SET cancellation_reason = 'USER_REQUESTED' WHERE id = '550e8400-e29b-41d4-a716-446655440003';
+
 -- Update executed order with execution details
 UPDATE orders 
 SET executed_at = CURRENT_TIMESTAMP - INTERVAL '30 minutes',
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS yanrodrigues.position_ledger (
+    id UUID PRIMARY KEY,
+    position_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('SPLIT', 'CASH_DIVIDEND', 'SYMBOL_CHANGE', 'CONSOLIDATION')),
+    corporate_action_id VARCHAR(100) NOT NULL,
+    quantity DECIMAL(20, 8) NOT NULL,
+    previous_quantity DECIMAL(20, 8) NOT NULL,
+    average_price DECIMAL(20, 8) NOT NULL,
+    previous_average_price DECIMAL(20, 8) NOT NULL,
+    cash_amount DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL,
+    effective_date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_position_corporate_action UNIQUE (position_id, corporate_action_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_position_ledger_position_id ON yanrodrigues.position_ledger (position_id);
+CREATE INDEX IF NOT EXISTS idx_position_ledger_user_id ON yanrodrigues.position_ledger (user_id);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS yanrodrigues.position_pnl_alerts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    direction VARCHAR(10) NOT NULL CHECK (direction IN ('GAIN', 'LOSS')),
+    threshold_type VARCHAR(10) NOT NULL CHECK (threshold_type IN ('PERCENT', 'AMOUNT')),
+    threshold DECIMAL(20, 8) NOT NULL CHECK (threshold > 0),
+    triggered BOOLEAN NOT NULL DEFAULT FALSE,
+    triggered_at TIMESTAMP WITH TIME ZONE,
+    triggered_pnl DECIMAL(20, 8),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Quotes are evaluated per symbol against the alerts that have not fired yet
+CREATE INDEX IF NOT EXISTS idx_position_pnl_alerts_armed_symbol ON yanrodrigues.position_pnl_alerts (symbol) WHERE triggered = FALSE;
+CREATE INDEX IF NOT EXISTS idx_position_pnl_alerts_user_id ON yanrodrigues.position_pnl_alerts (user_id);
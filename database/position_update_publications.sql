@@ -0,0 +1,13 @@
+-- One row per executed order whose position update reached the broker; executed orders without a row
+-- are republished by the order worker's startup recovery
+CREATE TABLE IF NOT EXISTS position_update_publications (
+    order_id UUID PRIMARY KEY REFERENCES orders(id),
+    published_at TIMESTAMP NOT NULL
+);
+
+-- Orders executed before publications were recorded already updated their positions
+INSERT INTO position_update_publications (order_id, published_at)
+SELECT id, COALESCE(executed_at, updated_at)
+FROM orders
+WHERE status = 'EXECUTED'
+ON CONFLICT (order_id) DO NOTHING;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS yanrodrigues.position_valuations (
+    id UUID PRIMARY KEY,
+    position_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    trading_day DATE NOT NULL,
+    quantity DECIMAL(20, 8) NOT NULL,
+    average_price DECIMAL(20, 8) NOT NULL,
+    close_price DECIMAL(20, 8) NOT NULL,
+    market_value DECIMAL(20, 8) NOT NULL,
+    unrealized_pnl DECIMAL(20, 8) NOT NULL,
+    unrealized_pnl_pct DECIMAL(10, 4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_position_trading_day UNIQUE (position_id, trading_day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_position_valuations_trading_day ON yanrodrigues.position_valuations (trading_day);
+CREATE INDEX IF NOT EXISTS idx_position_valuations_user_id ON yanrodrigues.position_valuations (user_id);
@@ -0,0 +1,16 @@
+-- Order submissions rejected before an order was created, kept for support and analytics
+CREATE TABLE IF NOT EXISTS rejected_orders (
+    id UUID PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    symbol VARCHAR(20) NOT NULL,
+    order_side VARCHAR(10) NOT NULL,
+    order_type VARCHAR(20) NOT NULL,
+    quantity DECIMAL(18,8) NOT NULL,
+    price DECIMAL(18,8),
+    reason_code VARCHAR(30) NOT NULL,
+    reason_details TEXT NOT NULL,
+    rejected_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rejected_orders_user_rejected_at ON rejected_orders(user_id, rejected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_rejected_orders_rejected_at_reason ON rejected_orders(rejected_at, reason_code);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS yanrodrigues.symbol_universe (
+    symbol VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    category VARCHAR(20) NOT NULL DEFAULT '',
+    last_quote DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    is_tradeable BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_symbol_universe_name ON yanrodrigues.symbol_universe (name);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_order_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    default_order_type VARCHAR(20) CHECK (default_order_type IN ('MARKET', 'LIMIT', 'STOP_LOSS', 'STOP_LIMIT')),
+    default_time_in_force VARCHAR(10) CHECK (default_time_in_force IN ('DAY', 'GTC', 'IOC', 'FOK')),
+    allow_partial_fill BOOLEAN,
+    protective_stop_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    protective_stop_percent DECIMAL(5,2) CHECK (protective_stop_percent > 0 AND protective_stop_percent < 100),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (NOT protective_stop_enabled OR protective_stop_percent IS NOT NULL)
+);
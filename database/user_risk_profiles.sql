@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS user_risk_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    risk_tolerance VARCHAR(20) NOT NULL CHECK (risk_tolerance IN ('CONSERVATIVE', 'MODERATE', 'AGGRESSIVE', 'SPECULATIVE')),
+    max_order_value DECIMAL(20,2) NOT NULL CHECK (max_order_value > 0),
+    max_daily_trading_value DECIMAL(20,2) NOT NULL CHECK (max_daily_trading_value > 0),
+    max_position_size DECIMAL(20,2) NOT NULL DEFAULT 0 CHECK (max_position_size >= 0),
+    is_high_risk_approved BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Every change made through the admin API, with the profile before and after it
+CREATE TABLE IF NOT EXISTS user_risk_profile_audit (
+    id UUID PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    changed_by INTEGER NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    previous_profile JSONB,
+    updated_profile JSONB NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_risk_profile_audit_user_changed_at ON user_risk_profile_audit(user_id, changed_at DESC);
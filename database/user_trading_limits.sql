@@ -0,0 +1,13 @@
+-- Each account's daily trading limits and usage; the trading limit reset job clears the usage when
+-- the account's trading day starts
+CREATE TABLE IF NOT EXISTS user_trading_limits (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    daily_trading_limit DECIMAL(20,2) NOT NULL CHECK (daily_trading_limit >= 0),
+    daily_trading_used DECIMAL(20,2) NOT NULL DEFAULT 0 CHECK (daily_trading_used >= 0),
+    remaining_daily_limit DECIMAL(20,2) NOT NULL DEFAULT 0,
+    max_order_value DECIMAL(20,2) NOT NULL DEFAULT 0 CHECK (max_order_value >= 0),
+    max_position_size DECIMAL(20,2) NOT NULL DEFAULT 0 CHECK (max_position_size >= 0),
+    last_reset_day DATE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
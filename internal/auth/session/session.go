@@ -0,0 +1,68 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionRevoked      = errors.New("session has been revoked")
+	ErrSessionExpired      = errors.New("session has expired")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// Session is a sign-in from one device. It holds the hash of its current refresh token, never the token itself.
+type Session struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"userId"`
+	UserName         string     `json:"-"`
+	Device           string     `json:"device"`
+	IPAddress        string     `json:"ipAddress"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	LastSeenAt       time.Time  `json:"lastSeenAt"`
+	ExpiresAt        time.Time  `json:"expiresAt"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	RefreshTokenHash string     `json:"-"`
+}
+
+// IsActive reports whether the session can still be refreshed
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SessionConfig holds the session lifetime settings
+type SessionConfig struct {
+	RefreshTokenTTL    time.Duration // Idle time after which a session can no longer be refreshed
+	MaxSessionsPerUser int           // Signing in past the limit revokes the least recently seen session; 0 means unlimited
+}
+
+// DefaultSessionConfig returns the default session settings
+func DefaultSessionConfig() SessionConfig {
+	return SessionConfig{
+		RefreshTokenTTL:    30 * 24 * time.Hour, // Stay signed in for a month of inactivity
+		MaxSessionsPerUser: 10,                  // Enough for a user's devices without letting leaked sessions pile up
+	}
+}
+
+// NewSessionConfigFromEnv returns the default settings overridden by AUTH_REFRESH_TOKEN_TTL (e.g. "720h")
+// and AUTH_MAX_SESSIONS_PER_USER
+func NewSessionConfigFromEnv() SessionConfig {
+	config := DefaultSessionConfig()
+
+	if ttl := os.Getenv("AUTH_REFRESH_TOKEN_TTL"); ttl != "" {
+		if val, err := time.ParseDuration(ttl); err == nil && val > 0 {
+			config.RefreshTokenTTL = val
+		}
+	}
+
+	if maxSessions := os.Getenv("AUTH_MAX_SESSIONS_PER_USER"); maxSessions != "" {
+		if val, err := strconv.Atoi(maxSessions); err == nil && val >= 0 {
+			config.MaxSessionsPerUser = val
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ISessionService manages the sessions behind refresh tokens
+type ISessionService interface {
+	// StartSession opens a session for a sign-in and returns it with its refresh token
+	StartSession(userID, userName, device, ipAddress string) (*Session, string, error)
+	// Refresh exchanges a refresh token for a new one on the same session; the old token stops working
+	Refresh(refreshToken, ipAddress string) (*Session, string, error)
+	// ListSessions returns the user's active sessions, most recently seen first
+	ListSessions(userID string) ([]*Session, error)
+	// RevokeSession ends one of the user's sessions so its refresh token is rejected
+	RevokeSession(userID, sessionID string) error
+}
+
+type SessionService struct {
+	store  ISessionStore
+	config SessionConfig
+}
+
+func NewSessionService(store ISessionStore, config SessionConfig) ISessionService {
+	return &SessionService{store: store, config: config}
+}
+
+func (s *SessionService) StartSession(userID, userName, device, ipAddress string) (*Session, string, error) {
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	session := &Session{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		UserName:         userName,
+		Device:           device,
+		IPAddress:        ipAddress,
+		CreatedAt:        now,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(s.config.RefreshTokenTTL),
+		RefreshTokenHash: hashRefreshToken(refreshToken),
+	}
+
+	if err := s.store.Save(session); err != nil {
+		return nil, "", fmt.Errorf("failed to save session: %w", err)
+	}
+
+	if err := s.enforceSessionLimit(userID, now); err != nil {
+		return nil, "", err
+	}
+
+	return session, refreshToken, nil
+}
+
+func (s *SessionService) Refresh(refreshToken, ipAddress string) (*Session, string, error) {
+	if refreshToken == "" {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	session, err := s.store.FindByRefreshTokenHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find session: %w", err)
+	}
+	if session == nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+	if session.RevokedAt != nil {
+		return nil, "", ErrSessionRevoked
+	}
+
+	now := time.Now()
+	if !now.Before(session.ExpiresAt) {
+		return nil, "", ErrSessionExpired
+	}
+
+	rotated, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	session.RefreshTokenHash = hashRefreshToken(rotated)
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.config.RefreshTokenTTL)
+	if ipAddress != "" {
+		session.IPAddress = ipAddress
+	}
+
+	if err := s.store.Save(session); err != nil {
+		return nil, "", fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return session, rotated, nil
+}
+
+func (s *SessionService) ListSessions(userID string) ([]*Session, error) {
+	sessions, err := s.store.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	active := make([]*Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.IsActive(now) {
+			active = append(active, session)
+		}
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].LastSeenAt.After(active[j].LastSeenAt)
+	})
+	return active, nil
+}
+
+func (s *SessionService) RevokeSession(userID, sessionID string) error {
+	session, err := s.store.FindByID(sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	// Sessions of other users are reported as missing so their IDs cannot be probed
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	return s.revoke(session, time.Now())
+}
+
+func (s *SessionService) revoke(session *Session, at time.Time) error {
+	session.RevokedAt = &at
+	if err := s.store.Save(session); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// enforceSessionLimit revokes the least recently seen sessions beyond the per-user limit
+func (s *SessionService) enforceSessionLimit(userID string, now time.Time) error {
+	if s.config.MaxSessionsPerUser <= 0 {
+		return nil
+	}
+
+	active, err := s.ListSessions(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range active[min(len(active), s.config.MaxSessionsPerUser):] {
+		if err := s.revoke(session, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSessionService(maxSessions int) ISessionService {
+	return NewSessionService(NewInMemorySessionStore(), SessionConfig{
+		RefreshTokenTTL:    time.Hour,
+		MaxSessionsPerUser: maxSessions,
+	})
+}
+
+func TestSessionService_ListSessions(t *testing.T) {
+	service := newTestSessionService(0)
+
+	laptop, _, err := service.StartSession("user123", "test@example.com", "Firefox on Linux", "10.0.0.1")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	phone, _, err := service.StartSession("user123", "test@example.com", "HubInvestments iOS", "10.0.0.2")
+	require.NoError(t, err)
+	_, _, err = service.StartSession("other-user", "other@example.com", "Chrome on Windows", "10.0.0.3")
+	require.NoError(t, err)
+
+	sessions, err := service.ListSessions("user123")
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, phone.ID, sessions[0].ID)
+	assert.Equal(t, laptop.ID, sessions[1].ID)
+	assert.Equal(t, "Firefox on Linux", sessions[1].Device)
+	assert.Equal(t, "10.0.0.1", sessions[1].IPAddress)
+}
+
+func TestSessionService_Refresh_RotatesToken(t *testing.T) {
+	service := newTestSessionService(0)
+	started, refreshToken, err := service.StartSession("user123", "test@example.com", "Firefox on Linux", "10.0.0.1")
+	require.NoError(t, err)
+
+	refreshed, rotated, err := service.Refresh(refreshToken, "10.0.0.9")
+
+	require.NoError(t, err)
+	assert.Equal(t, started.ID, refreshed.ID)
+	assert.Equal(t, "test@example.com", refreshed.UserName)
+	assert.Equal(t, "10.0.0.9", refreshed.IPAddress)
+	assert.NotEqual(t, refreshToken, rotated)
+
+	_, _, err = service.Refresh(refreshToken, "10.0.0.9")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestSessionService_RevokeSession_RejectsRefresh(t *testing.T) {
+	service := newTestSessionService(0)
+	started, refreshToken, err := service.StartSession("user123", "test@example.com", "Firefox on Linux", "10.0.0.1")
+	require.NoError(t, err)
+
+	require.NoError(t, service.RevokeSession("user123", started.ID))
+
+	_, _, err = service.Refresh(refreshToken, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	sessions, err := service.ListSessions("user123")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestSessionService_RevokeSession_OtherUser(t *testing.T) {
+	service := newTestSessionService(0)
+	started, refreshToken, err := service.StartSession("user123", "test@example.com", "Firefox on Linux", "10.0.0.1")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, service.RevokeSession("other-user", started.ID), ErrSessionNotFound)
+	assert.ErrorIs(t, service.RevokeSession("user123", "missing"), ErrSessionNotFound)
+
+	_, _, err = service.Refresh(refreshToken, "10.0.0.1")
+	assert.NoError(t, err)
+}
+
+func TestSessionService_Refresh_Expired(t *testing.T) {
+	service := NewSessionService(NewInMemorySessionStore(), SessionConfig{RefreshTokenTTL: time.Nanosecond})
+	_, refreshToken, err := service.StartSession("user123", "test@example.com", "Firefox on Linux", "10.0.0.1")
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond)
+
+	_, _, err = service.Refresh(refreshToken, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrSessionExpired)
+}
+
+func TestSessionService_StartSession_EnforcesLimit(t *testing.T) {
+	service := newTestSessionService(2)
+	_, oldest, err := service.StartSession("user123", "test@example.com", "Device 1", "10.0.0.1")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, _, err = service.StartSession("user123", "test@example.com", "Device 2", "10.0.0.2")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, _, err = service.StartSession("user123", "test@example.com", "Device 3", "10.0.0.3")
+	require.NoError(t, err)
+
+	sessions, err := service.ListSessions("user123")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "Device 3", sessions[0].Device)
+	assert.Equal(t, "Device 2", sessions[1].Device)
+
+	_, _, err = service.Refresh(oldest, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
@@ -0,0 +1,68 @@
+package session
+
+import "sync"
+
+// ISessionStore persists sessions. Finders return nil without an error when nothing matches.
+type ISessionStore interface {
+	Save(session *Session) error
+	FindByID(sessionID string) (*Session, error)
+	FindByRefreshTokenHash(hash string) (*Session, error)
+	FindByUserID(userID string) ([]*Session, error)
+}
+
+// InMemorySessionStore keeps sessions in process memory, so a restart signs every device out
+type InMemorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]*Session
+}
+
+func NewInMemorySessionStore() *InMemorySessionStore {
+	return &InMemorySessionStore{sessions: make(map[string]*Session)}
+}
+
+func (s *InMemorySessionStore) Save(session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *session
+	s.sessions[session.ID] = &stored
+	return nil
+}
+
+func (s *InMemorySessionStore) FindByID(sessionID string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if session, exists := s.sessions[sessionID]; exists {
+		found := *session
+		return &found, nil
+	}
+	return nil, nil
+}
+
+func (s *InMemorySessionStore) FindByRefreshTokenHash(hash string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, session := range s.sessions {
+		if session.RefreshTokenHash == hash {
+			found := *session
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *InMemorySessionStore) FindByUserID(userID string) ([]*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sessions := make([]*Session, 0)
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			found := *session
+			sessions = append(sessions, &found)
+		}
+	}
+	return sessions, nil
+}
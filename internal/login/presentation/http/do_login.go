@@ -35,8 +35,20 @@ func DoLogin(w http.ResponseWriter, r *http.Request, container di.Container) {
 		return
 	}
 
+	response := map[string]string{"token": tokenString}
+
+	// Open a session so the device can renew its token through /auth/refresh
+	if sessionService := container.GetSessionService(); sessionService != nil {
+		session, refreshToken, err := sessionService.StartSession(user.ID, user.Email.Value(), r.UserAgent(), clientIPAddress(r))
+		if err != nil {
+			http.Error(w, "Failed to start session", http.StatusInternalServerError)
+			return
+		}
+		response["refresh_token"] = refreshToken
+		response["session_id"] = session.ID
+	}
+
 	// Return success response with proper JSON format
 	w.WriteHeader(http.StatusOK)
-	response := map[string]string{"token": tokenString}
 	json.NewEncoder(w).Encode(response)
 }
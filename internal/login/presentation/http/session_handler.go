@@ -0,0 +1,176 @@
+package http
+
+import (
+	authSession "HubInvestments/internal/auth/session"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RefreshSessionRequest carries the refresh token issued at login or by the previous refresh
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshSession exchanges a refresh token for a new access token
+// @Summary Refresh Access Token
+// @Description Exchanges the session's refresh token for a new access token and a new refresh token. The old refresh token stops working, and tokens of revoked or expired sessions are rejected.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body RefreshSessionRequest true "Refresh token"
+// @Success 200 {object} map[string]string "Tokens renewed successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request - Invalid JSON format"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Invalid, revoked or expired refresh token"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "Sessions unavailable"
+// @Router /auth/refresh [post]
+func RefreshSession(w http.ResponseWriter, r *http.Request, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionService := container.GetSessionService()
+	if sessionService == nil {
+		http.Error(w, "Sessions are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req RefreshSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	session, refreshToken, err := sessionService.Refresh(req.RefreshToken, clientIPAddress(r))
+	if err != nil {
+		if errors.Is(err, authSession.ErrInvalidRefreshToken) || errors.Is(err, authSession.ErrSessionRevoked) ||
+			errors.Is(err, authSession.ErrSessionExpired) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, "Failed to refresh session: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tokenString, err := container.GetAuthService().CreateToken(session.UserName, session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         tokenString,
+		"refresh_token": refreshToken,
+		"session_id":    session.ID,
+	})
+}
+
+// Sessions lists the user's active sessions
+// @Summary List Active Sessions
+// @Description Lists the devices the user is signed in on, with the IP address and time each was last seen, most recent first.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} authSession.Session "Active sessions retrieved successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "Sessions unavailable"
+// @Router /auth/sessions [get]
+func Sessions(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionService := container.GetSessionService()
+	if sessionService == nil {
+		http.Error(w, "Sessions are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	sessions, err := sessionService.ListSessions(userId)
+	if err != nil {
+		http.Error(w, "Failed to list sessions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// SessionsWithAuth returns a handler wrapped with authentication middleware
+func SessionsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		Sessions(w, r, userId, container)
+	})
+}
+
+// RevokeSession signs one of the user's devices out
+// @Summary Revoke Session
+// @Description Ends the session so its refresh token is rejected. Access tokens already issued to the device stay valid until they expire.
+// @Tags Authentication
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 204 "Session revoked successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} response.ErrorResponse "Session not found"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "Sessions unavailable"
+// @Router /auth/sessions/{id} [delete]
+func RevokeSession(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	sessionID := strings.TrimPrefix(r.URL.Path, "/auth/sessions/")
+	if sessionID == "" || strings.Contains(sessionID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionService := container.GetSessionService()
+	if sessionService == nil {
+		http.Error(w, "Sessions are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := sessionService.RevokeSession(userId, sessionID); err != nil {
+		if errors.Is(err, authSession.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to revoke session: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeSessionWithAuth returns a handler wrapped with authentication middleware
+func RevokeSessionWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		RevokeSession(w, r, userId, container)
+	})
+}
+
+// clientIPAddress returns the caller's address, preferring the first hop recorded by a proxy
+func clientIPAddress(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	authSession "HubInvestments/internal/auth/session"
+	di "HubInvestments/pck"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loginWithSession(t *testing.T, container di.Container) map[string]string {
+	requestBody, _ := json.Marshal(map[string]string{"email": "test@example.com", "password": "password123"})
+	req := httptest.NewRequest("POST", "/login", bytes.NewBuffer(requestBody))
+	req.Header.Set("User-Agent", "HubInvestments iOS")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	rr := httptest.NewRecorder()
+
+	DoLogin(rr, req, container)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response
+}
+
+func refreshWith(container di.Container, refreshToken string) *httptest.ResponseRecorder {
+	requestBody, _ := json.Marshal(RefreshSessionRequest{RefreshToken: refreshToken})
+	req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+	RefreshSession(rr, req, container)
+	return rr
+}
+
+func newSessionTestContainer() di.Container {
+	mockLoginUsecase := new(MockDoLoginUsecase)
+	mockLoginUsecase.On("Execute", "test@example.com", "password123").Return(createTestUser(), nil)
+	mockAuthService := new(MockAuthService)
+	mockAuthService.On("CreateToken", "test@example.com", "user123").Return("mock-token-123", nil)
+
+	return di.NewTestContainer().
+		WithLoginUsecase(mockLoginUsecase).
+		WithAuthService(mockAuthService).
+		WithSessionService(authSession.NewSessionService(authSession.NewInMemorySessionStore(), authSession.DefaultSessionConfig()))
+}
+
+func TestSessions_ListsLoginSessions(t *testing.T) {
+	container := newSessionTestContainer()
+	login := loginWithSession(t, container)
+	assert.NotEmpty(t, login["refresh_token"])
+
+	rr := httptest.NewRecorder()
+	Sessions(rr, httptest.NewRequest("GET", "/auth/sessions", nil), "user123", container)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var sessions []authSession.Session
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &sessions))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, login["session_id"], sessions[0].ID)
+	assert.Equal(t, "HubInvestments iOS", sessions[0].Device)
+	assert.Equal(t, "203.0.113.7", sessions[0].IPAddress)
+	assert.NotContains(t, rr.Body.String(), login["refresh_token"])
+}
+
+func TestRefreshSession_RotatesToken(t *testing.T) {
+	container := newSessionTestContainer()
+	login := loginWithSession(t, container)
+
+	rr := refreshWith(container, login["refresh_token"])
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "mock-token-123", response["token"])
+	assert.Equal(t, login["session_id"], response["session_id"])
+	assert.NotEqual(t, login["refresh_token"], response["refresh_token"])
+}
+
+func TestRevokeSession_RejectsSubsequentRefresh(t *testing.T) {
+	container := newSessionTestContainer()
+	login := loginWithSession(t, container)
+
+	rr := httptest.NewRecorder()
+	RevokeSession(rr, httptest.NewRequest("DELETE", "/auth/sessions/"+login["session_id"], nil), "user123", container)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = refreshWith(container, login["refresh_token"])
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestRevokeSession_OtherUsersSession(t *testing.T) {
+	container := newSessionTestContainer()
+	login := loginWithSession(t, container)
+
+	rr := httptest.NewRecorder()
+	RevokeSession(rr, httptest.NewRequest("DELETE", "/auth/sessions/"+login["session_id"], nil), "other-user", container)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, http.StatusOK, refreshWith(container, login["refresh_token"]).Code)
+}
+
+func TestSessions_Unavailable(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Sessions(rr, httptest.NewRequest("GET", "/auth/sessions", nil), "user123", di.NewTestContainer())
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
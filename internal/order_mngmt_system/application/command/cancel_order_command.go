@@ -3,6 +3,8 @@ package command
 import (
 	"errors"
 	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
 )
 
 // CancelOrderCommand represents a command to cancel an existing order
@@ -10,7 +12,7 @@ import (
 type CancelOrderCommand struct {
 	OrderID string `json:"order_id" validate:"required"`
 	UserID  string `json:"user_id" validate:"required"`
-	Reason  string `json:"reason,omitempty"` // Optional cancellation reason
+	Reason  string `json:"reason,omitempty"` // Optional cancellation reason code; free text counts as USER_REQUESTED
 }
 
 // CancelOrderResult represents the result of a successful order cancellation
@@ -22,16 +24,20 @@ type CancelOrderResult struct {
 }
 
 // CancellationReason represents predefined cancellation reasons
-type CancellationReason string
+type CancellationReason = domain.CancellationReason
 
 const (
-	CancellationReasonUserRequested     CancellationReason = "USER_REQUESTED"
-	CancellationReasonMarketClosed      CancellationReason = "MARKET_CLOSED"
-	CancellationReasonInsufficientFunds CancellationReason = "INSUFFICIENT_FUNDS"
-	CancellationReasonRiskManagement    CancellationReason = "RISK_MANAGEMENT"
-	CancellationReasonSystemError       CancellationReason = "SYSTEM_ERROR"
-	CancellationReasonExpired           CancellationReason = "EXPIRED"
-	CancellationReasonAdminAction       CancellationReason = "ADMIN_ACTION"
+	CancellationReasonUserRequested     = domain.CancellationReasonUserRequested
+	CancellationReasonMarketClosed      = domain.CancellationReasonMarketClosed
+	CancellationReasonInsufficientFunds = domain.CancellationReasonInsufficientFunds
+	CancellationReasonRiskManagement    = domain.CancellationReasonRiskManagement
+	CancellationReasonSystemError       = domain.CancellationReasonSystemError
+	CancellationReasonExpired           = domain.CancellationReasonExpired
+	CancellationReasonAdminAction       = domain.CancellationReasonAdminAction
+	CancellationReasonDisconnected      = domain.CancellationReasonDisconnected
+	CancellationReasonOCOTriggered      = domain.CancellationReasonOCOTriggered
+	CancellationReasonRiskHalt          = domain.CancellationReasonRiskHalt
+	CancellationReasonReconciliation    = domain.CancellationReasonReconciliation
 )
 
 // Validate validates the cancel order command
@@ -60,6 +66,15 @@ func (cmd *CancelOrderCommand) GetReason() CancellationReason {
 	return CancellationReason(cmd.Reason)
 }
 
+// GetReasonCode returns the reason code recorded on the order. Free-text reasons that are not
+// one of the predefined codes are treated as a user request.
+func (cmd *CancelOrderCommand) GetReasonCode() CancellationReason {
+	if !cmd.IsValidReason() {
+		return CancellationReasonUserRequested
+	}
+	return cmd.GetReason()
+}
+
 // GetDescription returns a human-readable description of the cancellation
 func (cmd *CancelOrderCommand) GetDescription() string {
 	reason := cmd.GetReason()
@@ -73,24 +88,7 @@ func (cmd *CancelOrderCommand) IsValidReason() bool {
 		return true // Empty reason defaults to USER_REQUESTED
 	}
 
-	validReasons := []CancellationReason{
-		CancellationReasonUserRequested,
-		CancellationReasonMarketClosed,
-		CancellationReasonInsufficientFunds,
-		CancellationReasonRiskManagement,
-		CancellationReasonSystemError,
-		CancellationReasonExpired,
-		CancellationReasonAdminAction,
-	}
-
-	reason := CancellationReason(cmd.Reason)
-	for _, validReason := range validReasons {
-		if reason == validReason {
-			return true
-		}
-	}
-
-	return false
+	return CancellationReason(cmd.Reason).IsValid()
 }
 
 // ValidateWithReason validates the command including the reason
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"errors"
+	"strings"
+)
+
+// ForceCancelOrderCommand cancels an order on its owner's behalf, e.g. when support finds it stuck
+// or placed in error
+// @Description Command object for an admin force cancellation
+type ForceCancelOrderCommand struct {
+	OrderID string `json:"order_id" validate:"required"`
+	AdminID string `json:"admin_id" validate:"required"`
+	Reason  string `json:"reason" validate:"required"`
+}
+
+// ForceCancelOrderResult represents the result of a successful force cancellation
+type ForceCancelOrderResult struct {
+	OrderID        string `json:"order_id"`
+	OrderOwnerID   string `json:"order_owner_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	AuditID        string `json:"audit_id"`
+	Timestamp      string `json:"timestamp"`
+}
+
+// Validate validates the force cancel order command
+func (cmd *ForceCancelOrderCommand) Validate() error {
+	if cmd.OrderID == "" {
+		return errors.New("order ID is required")
+	}
+
+	if cmd.AdminID == "" {
+		return errors.New("admin ID is required")
+	}
+
+	if strings.TrimSpace(cmd.Reason) == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
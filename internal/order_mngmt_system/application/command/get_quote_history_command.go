@@ -0,0 +1,28 @@
+package command
+
+import (
+	"errors"
+)
+
+// GetQuoteHistoryCommand asks for the historical price series of several symbols
+// @Description Command object for batch quote history requests
+type GetQuoteHistoryCommand struct {
+	Symbols  []string `json:"symbols" validate:"required,min=1"`
+	Interval string   `json:"interval,omitempty"` // Defaults to the configured interval
+	Range    string   `json:"range,omitempty"`    // Defaults to the configured range
+}
+
+// Validate validates the get quote history command
+func (cmd *GetQuoteHistoryCommand) Validate() error {
+	if len(cmd.Symbols) == 0 {
+		return errors.New("at least one symbol is required")
+	}
+
+	for _, symbol := range cmd.Symbols {
+		if symbol == "" {
+			return errors.New("symbols cannot be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"errors"
+)
+
+// GetQuoteSnapshotCommand asks for the latest broadcast quote of several symbols
+// @Description Command object for quote snapshot requests
+type GetQuoteSnapshotCommand struct {
+	Symbols []string `json:"symbols" validate:"required,min=1"`
+}
+
+// Validate validates the get quote snapshot command
+func (cmd *GetQuoteSnapshotCommand) Validate() error {
+	if len(cmd.Symbols) == 0 {
+		return errors.New("at least one symbol is required")
+	}
+
+	for _, symbol := range cmd.Symbols {
+		if symbol == "" {
+			return errors.New("symbols cannot be empty")
+		}
+	}
+
+	return nil
+}
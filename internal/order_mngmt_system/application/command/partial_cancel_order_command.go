@@ -0,0 +1,53 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PartialCancelOrderCommand represents a command to reduce an open order's quantity without cancelling it
+// @Description Command object for partial order cancellation with validation
+type PartialCancelOrderCommand struct {
+	OrderID     string  `json:"order_id" validate:"required"`
+	UserID      string  `json:"user_id" validate:"required"`
+	NewQuantity float64 `json:"new_quantity" validate:"gte=0"`
+}
+
+// PartialCancelOrderResult represents the result of a successful partial cancellation
+type PartialCancelOrderResult struct {
+	OrderID          string  `json:"order_id"`
+	Status           string  `json:"status"`
+	PreviousQuantity float64 `json:"previous_quantity"`
+	NewQuantity      float64 `json:"new_quantity"`
+	FilledQuantity   float64 `json:"filled_quantity"`
+	Message          string  `json:"message"`
+	Timestamp        string  `json:"timestamp"`
+}
+
+// Validate validates the partial cancel order command
+func (cmd *PartialCancelOrderCommand) Validate() error {
+	if cmd.OrderID == "" {
+		return errors.New("order ID is required")
+	}
+
+	if cmd.UserID == "" {
+		return errors.New("user ID is required")
+	}
+
+	// Validate UUID format for order ID (basic check)
+	if len(cmd.OrderID) < 36 {
+		return errors.New("invalid order ID format")
+	}
+
+	if cmd.NewQuantity < 0 {
+		return errors.New("new quantity cannot be negative")
+	}
+
+	return nil
+}
+
+// GetDescription returns a human-readable description of the partial cancellation
+func (cmd *PartialCancelOrderCommand) GetDescription() string {
+	return fmt.Sprintf("Reduce order %s to quantity %.8f requested by user %s",
+		cmd.OrderID, cmd.NewQuantity, cmd.UserID)
+}
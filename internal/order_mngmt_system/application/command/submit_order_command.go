@@ -13,9 +13,31 @@ type SubmitOrderCommand struct {
 	UserID    string   `json:"user_id" validate:"required"`
 	Symbol    string   `json:"symbol" validate:"required"`
 	OrderSide string   `json:"order_side" validate:"required,oneof=BUY SELL"`
-	OrderType string   `json:"order_type" validate:"required,oneof=MARKET LIMIT STOP_LOSS STOP_LIMIT"`
+	OrderType string   `json:"order_type" validate:"required,oneof=MARKET LIMIT STOP_LOSS STOP_LIMIT MARKET_IF_TOUCHED LIMIT_IF_TOUCHED"`
 	Quantity  float64  `json:"quantity" validate:"required,gt=0"`
 	Price     *float64 `json:"price,omitempty"` // Optional for market orders
+
+	TriggerPrice *float64 `json:"trigger_price,omitempty"` // Required for if-touched orders, which rest until this price is touched
+
+	TimeInForce      string `json:"time_in_force,omitempty" validate:"omitempty,oneof=DAY GTC IOC FOK"` // Defaults to DAY
+	AllowPartialFill *bool  `json:"allow_partial_fill,omitempty"`                                       // Defaults to true, except for FOK orders
+
+	ExecutionStrategy string `json:"execution_strategy,omitempty" validate:"omitempty,oneof=MARKET LIMIT TWAP VWAP ICEBERG HIDDEN"` // Overrides the recommended execution strategy
+
+	AcknowledgeDuplicate bool `json:"acknowledge_duplicate,omitempty"`  // Places the order even if it looks like a duplicate of a recent open order
+	AcknowledgeFatFinger bool `json:"acknowledge_fat_finger,omitempty"` // Places the order even if it is far out of line with the user's usual orders
+
+	SettlementAccount         string `json:"settlement_account,omitempty"`                                                 // Routes settlement to this account instead of the default one
+	SettlementInstructionCode string `json:"settlement_instruction_code,omitempty" validate:"omitempty,oneof=DVP RVP FOP"` // Required with a settlement account
+
+	PegReference string   `json:"peg_reference,omitempty" validate:"omitempty,oneof=BID MID ASK"` // Reprices a limit order to follow this side of the quote
+	PegOffset    *float64 `json:"peg_offset,omitempty"`                                           // Added to the peg reference price; defaults to 0
+	PegMinPrice  *float64 `json:"peg_min_price,omitempty"`                                        // Lowest price the peg may set
+	PegMaxPrice  *float64 `json:"peg_max_price,omitempty"`                                        // Highest price the peg may set
+
+	ProtectiveStopPercent *float64 `json:"protective_stop_percent,omitempty"` // Attaches a stop loss leg this percent below a buy's entry
+
+	IgnoreUserDefaults bool `json:"-"` // Set for payloads that predate user order defaults and always carry every field they use
 }
 
 // SubmitOrderResult represents the result of a successful order submission
@@ -24,6 +46,8 @@ type SubmitOrderResult struct {
 	Status                  string   `json:"status"`
 	MarketPriceAtSubmission *float64 `json:"market_price_at_submission,omitempty"`
 	EstimatedExecutionPrice *float64 `json:"estimated_execution_price,omitempty"`
+	ProtectiveStopOrderID   string   `json:"protective_stop_order_id,omitempty"`
+	ProtectiveStopPrice     *float64 `json:"protective_stop_price,omitempty"`
 	Message                 string   `json:"message"`
 }
 
@@ -66,6 +90,53 @@ func (cmd *SubmitOrderCommand) Validate() error {
 		return errors.New("price must be positive")
 	}
 
+	if orderType == domain.OrderTypeMarketIfTouched && cmd.Price != nil {
+		return errors.New("market if touched orders cannot have a price")
+	}
+
+	if orderType.IsIfTouched() && cmd.TriggerPrice == nil {
+		return fmt.Errorf("%s orders require a trigger price", cmd.OrderType)
+	}
+
+	if !orderType.IsIfTouched() && cmd.TriggerPrice != nil {
+		return fmt.Errorf("%s orders cannot have a trigger price", cmd.OrderType)
+	}
+
+	if cmd.TriggerPrice != nil && *cmd.TriggerPrice <= 0 {
+		return errors.New("trigger price must be positive")
+	}
+
+	timeInForce, err := cmd.ToTimeInForce()
+	if err != nil {
+		return fmt.Errorf("invalid time in force: %w", err)
+	}
+
+	if timeInForce == domain.TimeInForceFOK && cmd.AllowPartialFill != nil && *cmd.AllowPartialFill {
+		return errors.New("fill or kill orders cannot allow partial fills")
+	}
+
+	if _, err := cmd.ToSettlementInstruction(); err != nil {
+		return fmt.Errorf("invalid settlement instruction: %w", err)
+	}
+
+	pegInstruction, err := cmd.ToPegInstruction()
+	if err != nil {
+		return fmt.Errorf("invalid peg: %w", err)
+	}
+
+	if pegInstruction != nil && orderType != domain.OrderTypeLimit {
+		return fmt.Errorf("%s orders cannot be pegged", cmd.OrderType)
+	}
+
+	if cmd.ProtectiveStopPercent != nil {
+		if !cmd.IsBuyOrder() {
+			return errors.New("protective stops can only be attached to buy orders")
+		}
+		if err := domain.ValidateProtectiveStopPercent(*cmd.ProtectiveStopPercent); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -79,6 +150,22 @@ func (cmd *SubmitOrderCommand) ToOrderType() (domain.OrderType, error) {
 	return domain.ParseOrderType(cmd.OrderType)
 }
 
+// ToTimeInForce converts the string time in force to domain TimeInForce, defaulting to DAY
+func (cmd *SubmitOrderCommand) ToTimeInForce() (domain.TimeInForce, error) {
+	if cmd.TimeInForce == "" {
+		return domain.TimeInForceDay, nil
+	}
+	return domain.ParseTimeInForce(cmd.TimeInForce)
+}
+
+// PartialFillAllowed resolves the partial fill preference; fill or kill orders never fill in parts
+func (cmd *SubmitOrderCommand) PartialFillAllowed() bool {
+	if cmd.AllowPartialFill != nil {
+		return *cmd.AllowPartialFill
+	}
+	return cmd.TimeInForce != domain.TimeInForceFOK.String()
+}
+
 // GetDescription returns a human-readable description of the order
 func (cmd *SubmitOrderCommand) GetDescription() string {
 	priceStr := "market price"
@@ -95,6 +182,11 @@ func (cmd *SubmitOrderCommand) IsMarketOrder() bool {
 	return cmd.OrderType == "MARKET"
 }
 
+// IsIfTouchedOrder checks if this order rests until its trigger price is touched
+func (cmd *SubmitOrderCommand) IsIfTouchedOrder() bool {
+	return cmd.OrderType == "MARKET_IF_TOUCHED" || cmd.OrderType == "LIMIT_IF_TOUCHED"
+}
+
 // IsBuyOrder checks if this is a buy order
 func (cmd *SubmitOrderCommand) IsBuyOrder() bool {
 	return cmd.OrderSide == "BUY"
@@ -104,3 +196,37 @@ func (cmd *SubmitOrderCommand) IsBuyOrder() bool {
 func (cmd *SubmitOrderCommand) IsSellOrder() bool {
 	return cmd.OrderSide == "SELL"
 }
+
+// ToSettlementInstruction converts the optional settlement fields into a domain instruction.
+// Both fields must be given together; nil is returned when neither is set.
+func (cmd *SubmitOrderCommand) ToSettlementInstruction() (*domain.SettlementInstruction, error) {
+	if cmd.SettlementAccount == "" && cmd.SettlementInstructionCode == "" {
+		return nil, nil
+	}
+	if cmd.SettlementInstructionCode == "" {
+		return nil, errors.New("settlement instruction code is required with a settlement account")
+	}
+	return domain.NewSettlementInstruction(cmd.SettlementAccount, cmd.SettlementInstructionCode)
+}
+
+// IsPeggedOrder checks if this order's price follows the quote
+func (cmd *SubmitOrderCommand) IsPeggedOrder() bool {
+	return cmd.PegReference != ""
+}
+
+// ToPegInstruction converts the optional peg fields into a domain instruction.
+// Offset and bounds require a peg reference; nil is returned when no peg is requested.
+func (cmd *SubmitOrderCommand) ToPegInstruction() (*domain.PegInstruction, error) {
+	if cmd.PegReference == "" {
+		if cmd.PegOffset != nil || cmd.PegMinPrice != nil || cmd.PegMaxPrice != nil {
+			return nil, errors.New("peg reference is required with a peg offset or bounds")
+		}
+		return nil, nil
+	}
+
+	offset := 0.0
+	if cmd.PegOffset != nil {
+		offset = *cmd.PegOffset
+	}
+	return domain.NewPegInstruction(cmd.PegReference, offset, cmd.PegMinPrice, cmd.PegMaxPrice)
+}
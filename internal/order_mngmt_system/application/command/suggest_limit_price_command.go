@@ -0,0 +1,44 @@
+package command
+
+import (
+	"errors"
+	"math"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// SuggestLimitPriceCommand asks for a limit price balancing fill probability against price
+// @Description Command object for order book-based limit price suggestions
+type SuggestLimitPriceCommand struct {
+	UserID    string  `json:"user_id" validate:"required"`
+	Symbol    string  `json:"symbol" validate:"required"`
+	OrderSide string  `json:"order_side" validate:"required,oneof=BUY SELL"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+	Urgency   string  `json:"urgency" validate:"required,oneof=LOW MEDIUM HIGH"`
+}
+
+// Validate validates the suggest limit price command
+func (cmd *SuggestLimitPriceCommand) Validate() error {
+	if cmd.UserID == "" {
+		return errors.New("user ID is required")
+	}
+
+	if cmd.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+
+	if _, err := domain.ParseOrderSide(cmd.OrderSide); err != nil {
+		return err
+	}
+
+	if math.IsNaN(cmd.Quantity) || math.IsInf(cmd.Quantity, 0) || cmd.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+
+	if _, err := service.ParseLimitPriceUrgency(cmd.Urgency); err != nil {
+		return err
+	}
+
+	return nil
+}
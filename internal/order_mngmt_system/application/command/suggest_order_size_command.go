@@ -0,0 +1,37 @@
+package command
+
+import (
+	"errors"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// SuggestOrderSizeCommand asks for the largest buy quantity of a symbol that stays within a target risk level
+// @Description Command object for risk-based order size recommendations
+type SuggestOrderSizeCommand struct {
+	UserID          string   `json:"user_id" validate:"required"`
+	Symbol          string   `json:"symbol" validate:"required"`
+	TargetRiskLevel string   `json:"target_risk_level" validate:"required,oneof=LOW MEDIUM HIGH VERY_HIGH EXTREMELY_HIGH"`
+	Price           *float64 `json:"price,omitempty"` // Defaults to the current market price
+}
+
+// Validate validates the suggest order size command
+func (cmd *SuggestOrderSizeCommand) Validate() error {
+	if cmd.UserID == "" {
+		return errors.New("user ID is required")
+	}
+
+	if cmd.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+
+	if _, err := service.ParseRiskLevel(cmd.TargetRiskLevel); err != nil {
+		return err
+	}
+
+	if cmd.Price != nil && *cmd.Price <= 0 {
+		return errors.New("price must be positive")
+	}
+
+	return nil
+}
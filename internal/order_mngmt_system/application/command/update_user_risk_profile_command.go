@@ -0,0 +1,69 @@
+package command
+
+import (
+	"errors"
+	"strings"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// UpdateUserRiskProfileCommand changes some or all of a user's risk profile fields.
+// Fields left nil keep their current value.
+// @Description Command object for adjusting a user's risk profile
+type UpdateUserRiskProfileCommand struct {
+	UserID               string   `json:"user_id" validate:"required"`
+	ChangedBy            string   `json:"changed_by" validate:"required"`
+	Reason               string   `json:"reason" validate:"required"`
+	MaxOrderValue        *float64 `json:"max_order_value,omitempty"`
+	MaxDailyTradingValue *float64 `json:"max_daily_trading_value,omitempty"`
+	MaxPositionSize      *float64 `json:"max_position_size,omitempty"`
+	RiskTolerance        *string  `json:"risk_tolerance,omitempty" validate:"omitempty,oneof=CONSERVATIVE MODERATE AGGRESSIVE SPECULATIVE"`
+	IsHighRiskApproved   *bool    `json:"is_high_risk_approved,omitempty"`
+}
+
+// Validate validates the update user risk profile command
+func (cmd *UpdateUserRiskProfileCommand) Validate() error {
+	if cmd.UserID == "" {
+		return errors.New("user ID is required")
+	}
+
+	if cmd.ChangedBy == "" {
+		return errors.New("changed by is required")
+	}
+
+	if strings.TrimSpace(cmd.Reason) == "" {
+		return errors.New("reason is required")
+	}
+
+	if cmd.MaxOrderValue == nil && cmd.MaxDailyTradingValue == nil && cmd.MaxPositionSize == nil &&
+		cmd.RiskTolerance == nil && cmd.IsHighRiskApproved == nil {
+		return errors.New("at least one profile field must be provided")
+	}
+
+	if cmd.MaxOrderValue != nil && *cmd.MaxOrderValue <= 0 {
+		return errors.New("max order value must be positive")
+	}
+
+	if cmd.MaxDailyTradingValue != nil && *cmd.MaxDailyTradingValue <= 0 {
+		return errors.New("max daily trading value must be positive")
+	}
+
+	if cmd.MaxPositionSize != nil && *cmd.MaxPositionSize < 0 {
+		return errors.New("max position size cannot be negative")
+	}
+
+	if cmd.RiskTolerance != nil {
+		if _, err := service.ParseRiskTolerance(*cmd.RiskTolerance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateUserRiskProfileResult is the stored profile after the change
+type UpdateUserRiskProfileResult struct {
+	Profile       *service.UserRiskProfile
+	ChangedFields []string
+	AuditID       string
+}
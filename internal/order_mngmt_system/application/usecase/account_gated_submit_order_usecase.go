@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+)
+
+// ErrAccountNotFunded is returned when an order is submitted from an account below the minimum trading balance
+var ErrAccountNotFunded = errors.New("account is not funded for trading")
+
+// IAccountBalanceSource provides the balance available to a user's account (dependency inversion)
+type IAccountBalanceSource interface {
+	GetAvailableBalance(ctx context.Context, userID string) (float64, error)
+}
+
+// AccountGatingConfig holds configuration for gating order submission on the account balance
+type AccountGatingConfig struct {
+	MinimumBalance float64 // Available balance required before an account may place orders
+}
+
+// AccountGatedSubmitOrderUseCase rejects submissions from accounts below the minimum balance
+// before handing them to the wrapped use case
+type AccountGatedSubmitOrderUseCase struct {
+	submitOrderUseCase ISubmitOrderUseCase
+	balances           IAccountBalanceSource
+	config             AccountGatingConfig
+}
+
+func NewAccountGatedSubmitOrderUseCase(
+	submitOrderUseCase ISubmitOrderUseCase,
+	balances IAccountBalanceSource,
+	config AccountGatingConfig,
+) ISubmitOrderUseCase {
+	return &AccountGatedSubmitOrderUseCase{
+		submitOrderUseCase: submitOrderUseCase,
+		balances:           balances,
+		config:             config,
+	}
+}
+
+func (uc *AccountGatedSubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+	if cmd != nil && uc.config.MinimumBalance > 0 {
+		balance, err := uc.balances.GetAvailableBalance(ctx, cmd.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check account balance: %w", err)
+		}
+
+		if balance < uc.config.MinimumBalance {
+			return nil, fmt.Errorf("%w: available balance %.2f is below the minimum of %.2f, fund the account to start trading",
+				ErrAccountNotFunded, balance, uc.config.MinimumBalance)
+		}
+	}
+
+	return uc.submitOrderUseCase.Execute(ctx, cmd)
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockAccountBalanceSource struct {
+	GetAvailableBalanceFunc func(ctx context.Context, userID string) (float64, error)
+}
+
+func (m *MockAccountBalanceSource) GetAvailableBalance(ctx context.Context, userID string) (float64, error) {
+	return m.GetAvailableBalanceFunc(ctx, userID)
+}
+
+func newAccountGatingCommand() *command.SubmitOrderCommand {
+	return &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "MARKET",
+		OrderSide: "BUY",
+		Quantity:  10.0,
+	}
+}
+
+func TestAccountGatedSubmitOrderUseCase_FundedAccountIsAllowed(t *testing.T) {
+	// Arrange
+	saved := false
+	orderRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saved = true
+			return nil
+		},
+	}
+	balances := &MockAccountBalanceSource{
+		GetAvailableBalanceFunc: func(ctx context.Context, userID string) (float64, error) {
+			return 500.0, nil
+		},
+	}
+	useCase := NewAccountGatedSubmitOrderUseCase(
+		NewSubmitOrderUseCase(SubmitOrderDependencies{
+			OrderRepository:    orderRepo,
+			MarketDataClient:   &MockMarketDataClient{},
+			IdempotencyService: &MockIdempotencyService{},
+		}),
+		balances,
+		AccountGatingConfig{MinimumBalance: 100.0},
+	)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), newAccountGatingCommand())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result == nil || !saved {
+		t.Error("Expected the order to be submitted for a funded account")
+	}
+}
+
+func TestAccountGatedSubmitOrderUseCase_UnfundedAccountIsBlocked(t *testing.T) {
+	// Arrange
+	saved := false
+	orderRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saved = true
+			return nil
+		},
+	}
+	var requestedUser string
+	balances := &MockAccountBalanceSource{
+		GetAvailableBalanceFunc: func(ctx context.Context, userID string) (float64, error) {
+			requestedUser = userID
+			return 20.0, nil
+		},
+	}
+	useCase := NewAccountGatedSubmitOrderUseCase(
+		NewSubmitOrderUseCase(SubmitOrderDependencies{
+			OrderRepository:    orderRepo,
+			MarketDataClient:   &MockMarketDataClient{},
+			IdempotencyService: &MockIdempotencyService{},
+		}),
+		balances,
+		AccountGatingConfig{MinimumBalance: 100.0},
+	)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), newAccountGatingCommand())
+
+	// Assert
+	if !errors.Is(err, ErrAccountNotFunded) {
+		t.Fatalf("Expected ErrAccountNotFunded, got %v", err)
+	}
+	if !contains(err.Error(), "minimum of 100.00") {
+		t.Errorf("Expected the error to state the minimum balance, got %v", err)
+	}
+	if result != nil || saved {
+		t.Error("Expected no order to be submitted for an unfunded account")
+	}
+	if requestedUser != "user123" {
+		t.Errorf("Expected the submitting user's balance to be checked, got %s", requestedUser)
+	}
+}
+
+func TestAccountGatedSubmitOrderUseCase_BalanceLookupFailure(t *testing.T) {
+	// Arrange
+	balances := &MockAccountBalanceSource{
+		GetAvailableBalanceFunc: func(ctx context.Context, userID string) (float64, error) {
+			return 0, errors.New("balance store unavailable")
+		},
+	}
+	useCase := NewAccountGatedSubmitOrderUseCase(
+		NewSubmitOrderUseCase(SubmitOrderDependencies{
+			OrderRepository:    &MockOrderRepository{},
+			MarketDataClient:   &MockMarketDataClient{},
+			IdempotencyService: &MockIdempotencyService{},
+		}),
+		balances,
+		AccountGatingConfig{MinimumBalance: 100.0},
+	)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), newAccountGatingCommand())
+
+	// Assert
+	if err == nil || errors.Is(err, ErrAccountNotFunded) {
+		t.Errorf("Expected a balance lookup error, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// IActivatedOrderPublisher sends an activated order for processing (dependency inversion)
+type IActivatedOrderPublisher interface {
+	PublishOrderForProcessing(ctx context.Context, order *domain.Order) error
+}
+
+// IActivateIfTouchedOrdersUseCase activates resting if-touched orders from realtime quotes
+type IActivateIfTouchedOrdersUseCase interface {
+	// Execute activates the symbol's resting orders the quote price touches and returns their IDs
+	Execute(ctx context.Context, symbol string, price float64, quotedAt time.Time) ([]string, error)
+	// Restore watches every stored inactive if-touched order again and returns how many it watches
+	Restore(ctx context.Context) (int, error)
+}
+
+// ActivateIfTouchedOrdersUseCase turns touched if-touched orders into live market or limit orders
+// and publishes them for processing, along with the armed protective stops a quote reached
+type ActivateIfTouchedOrdersUseCase struct {
+	orderRepository repository.IOrderRepository
+	triggerBook     service.IfTouchedTriggerBook
+	publisher       IActivatedOrderPublisher
+}
+
+func NewActivateIfTouchedOrdersUseCase(
+	orderRepository repository.IOrderRepository,
+	triggerBook service.IfTouchedTriggerBook,
+	publisher IActivatedOrderPublisher,
+) IActivateIfTouchedOrdersUseCase {
+	return &ActivateIfTouchedOrdersUseCase{
+		orderRepository: orderRepository,
+		triggerBook:     triggerBook,
+		publisher:       publisher,
+	}
+}
+
+// Execute activates the symbol's resting orders the quote price touches. Orders cancelled while
+// resting are skipped; an order that fails to save is put back in the book for the next quote.
+func (uc *ActivateIfTouchedOrdersUseCase) Execute(ctx context.Context, symbol string, price float64, quotedAt time.Time) ([]string, error) {
+	activated := make([]string, 0)
+
+	for _, orderID := range uc.triggerBook.EvaluateQuote(symbol, price) {
+		order, err := uc.orderRepository.FindByID(ctx, orderID)
+		if err != nil {
+			return activated, fmt.Errorf("failed to load touched order %s: %w", orderID, err)
+		}
+		if order == nil || !order.CanExecute() {
+			continue
+		}
+
+		if order.IsProtectiveStop() {
+			// The stop executes as the stop loss it is; processing checks the stop price again
+			activated = append(activated, order.ID())
+			uc.publish(ctx, order)
+			continue
+		}
+
+		if !order.ActivateIfTouched(price, quotedAt) {
+			continue
+		}
+
+		if err := uc.orderRepository.Save(ctx, order); err != nil {
+			// The stored order is still inactive; watch it again so the next touching quote retries
+			if stored, findErr := uc.orderRepository.FindByID(ctx, orderID); findErr == nil && stored != nil {
+				_ = uc.triggerBook.Watch(stored)
+			}
+			return activated, fmt.Errorf("failed to save activated order %s: %w", orderID, err)
+		}
+		activated = append(activated, order.ID())
+		uc.publish(ctx, order)
+	}
+
+	return activated, nil
+}
+
+func (uc *ActivateIfTouchedOrdersUseCase) publish(ctx context.Context, order *domain.Order) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.PublishOrderForProcessing(ctx, order); err != nil {
+		// The activated order is saved and can be processed later
+		log.Printf("Warning: Failed to publish activated order %s for processing: %v", order.ID(), err)
+	}
+}
+
+// Restore rebuilds the in-memory trigger book from the pending orders in the repository, so resting
+// if-touched orders and armed protective stops survive a restart. Orders the book refuses are logged
+// and left inactive.
+func (uc *ActivateIfTouchedOrdersUseCase) Restore(ctx context.Context) (int, error) {
+	orders, err := uc.orderRepository.FindByStatus(ctx, domain.OrderStatusPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load pending orders: %w", err)
+	}
+
+	restored := 0
+	for _, order := range orders {
+		if order.IsProtectiveStop() {
+			if uc.restoreProtectiveStop(ctx, order) {
+				restored++
+			}
+			continue
+		}
+		if !order.OrderType().IsIfTouched() || order.IsActivated() {
+			continue
+		}
+		if err := uc.triggerBook.Watch(order); err != nil {
+			log.Printf("Warning: Failed to restore if-touched order %s: %v", order.ID(), err)
+			continue
+		}
+		restored++
+	}
+
+	return restored, nil
+}
+
+// restoreProtectiveStop watches a protective stop again once its parent has filled; a stop whose
+// parent is still open stays resting until the parent fills
+func (uc *ActivateIfTouchedOrdersUseCase) restoreProtectiveStop(ctx context.Context, stop *domain.Order) bool {
+	parent, err := uc.orderRepository.FindByID(ctx, stop.ParentOrderID())
+	if err != nil || parent == nil {
+		log.Printf("Warning: Failed to load the parent of protective stop %s: %v", stop.ID(), err)
+		return false
+	}
+	if parent.Status() != domain.OrderStatusExecuted {
+		return false
+	}
+
+	if err := uc.triggerBook.WatchStop(stop); err != nil {
+		log.Printf("Warning: Failed to restore protective stop %s: %v", stop.ID(), err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RecordingOrderPublisher records the orders published for processing
+type RecordingOrderPublisher struct {
+	Published []*domain.Order
+	Err       error
+}
+
+func (p *RecordingOrderPublisher) PublishOrderForProcessing(ctx context.Context, order *domain.Order) error {
+	p.Published = append(p.Published, order)
+	return p.Err
+}
+
+// newInMemoryOrderRepository stores saved orders by ID
+func newInMemoryOrderRepository() (*MockOrderRepository, map[string]*domain.Order) {
+	orders := make(map[string]*domain.Order)
+	return &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			orders[order.ID()] = order
+			return nil
+		},
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return orders[orderID], nil
+		},
+		FindByUserIDAndStatusFunc: func(ctx context.Context, userID string, status domain.OrderStatus) ([]*domain.Order, error) {
+			matching := make([]*domain.Order, 0)
+			for _, order := range orders {
+				if order.UserID() == userID && order.Status() == status {
+					matching = append(matching, order)
+				}
+			}
+			return matching, nil
+		},
+	}, orders
+}
+
+func submitIfTouchedOrder(t *testing.T, useCase ISubmitOrderUseCase, orderType, side string, price *float64, triggerPrice float64) *command.SubmitOrderResult {
+	t.Helper()
+	result, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:       "user123",
+		Symbol:       "AAPL",
+		OrderType:    orderType,
+		OrderSide:    side,
+		Quantity:     10,
+		Price:        price,
+		TriggerPrice: &triggerPrice,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestActivateIfTouchedOrdersUseCase_ActivatesExactlyWhenTriggerIsReached(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	triggerBook := service.NewIfTouchedTriggerBookWithDefaults()
+	publisher := &RecordingOrderPublisher{}
+
+	// Market price is 150.50, so a buy triggered at 148 rests until the price falls
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		TriggerBook:        triggerBook,
+	})
+	result := submitIfTouchedOrder(t, submitUseCase, "MARKET_IF_TOUCHED", "BUY", nil, 148.00)
+
+	assert.Equal(t, "PENDING", result.Status)
+	assert.Contains(t, result.Message, "inactive until the trigger price")
+	assert.False(t, orders[result.OrderID].IsActivated())
+	assert.Equal(t, 1, triggerBook.Watching())
+
+	activateUseCase := NewActivateIfTouchedOrdersUseCase(repo, triggerBook, publisher)
+	quotedAt := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	for _, price := range []float64{150.00, 149.00, 148.01} {
+		activated, err := activateUseCase.Execute(context.Background(), "AAPL", price, quotedAt)
+		require.NoError(t, err)
+		assert.Empty(t, activated, "price %.2f has not reached the trigger", price)
+	}
+	assert.False(t, orders[result.OrderID].IsActivated())
+	assert.Empty(t, publisher.Published)
+
+	activated, err := activateUseCase.Execute(context.Background(), "AAPL", 148.00, quotedAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []string{result.OrderID}, activated)
+
+	order := orders[result.OrderID]
+	assert.True(t, order.IsActivated())
+	assert.Equal(t, quotedAt.Add(time.Second), *order.TriggeredAt())
+	require.Len(t, publisher.Published, 1)
+	assert.Equal(t, result.OrderID, publisher.Published[0].ID())
+	assert.Equal(t, 0, triggerBook.Watching())
+
+	activated, err = activateUseCase.Execute(context.Background(), "AAPL", 147.00, quotedAt.Add(2*time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, activated)
+	assert.Len(t, publisher.Published, 1)
+}
+
+func TestActivateIfTouchedOrdersUseCase_SellLimitIfTouchedWaitsForRise(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	triggerBook := service.NewIfTouchedTriggerBookWithDefaults()
+
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		TriggerBook:        triggerBook,
+	})
+	limitPrice := 152.00
+	result := submitIfTouchedOrder(t, submitUseCase, "LIMIT_IF_TOUCHED", "SELL", &limitPrice, 153.00)
+
+	activateUseCase := NewActivateIfTouchedOrdersUseCase(repo, triggerBook, nil)
+
+	activated, err := activateUseCase.Execute(context.Background(), "AAPL", 152.99, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, activated)
+	assert.False(t, orders[result.OrderID].IsActivated())
+
+	activated, err = activateUseCase.Execute(context.Background(), "AAPL", 153.00, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{result.OrderID}, activated)
+	assert.True(t, orders[result.OrderID].IsActivated())
+}
+
+func TestSubmitOrderUseCase_Execute_IfTouchedAlreadyTouchedActivatesImmediately(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	triggerBook := service.NewIfTouchedTriggerBookWithDefaults()
+
+	// A buy triggered at 151 is already touched by the 150.50 market price
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		TriggerBook:        triggerBook,
+	})
+	result := submitIfTouchedOrder(t, submitUseCase, "MARKET_IF_TOUCHED", "BUY", nil, 151.00)
+
+	assert.True(t, orders[result.OrderID].IsActivated())
+	assert.Equal(t, 0, triggerBook.Watching())
+}
+
+func TestSubmitOrderUseCase_Execute_RejectsIfTouchedWithoutTriggerBook(t *testing.T) {
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    &MockOrderRepository{},
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+	})
+	triggerPrice := 148.00
+
+	_, err := submitUseCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:       "user123",
+		Symbol:       "AAPL",
+		OrderType:    "MARKET_IF_TOUCHED",
+		OrderSide:    "BUY",
+		Quantity:     10,
+		TriggerPrice: &triggerPrice,
+	})
+
+	assert.Error(t, err)
+}
+
+func TestActivateIfTouchedOrdersUseCase_RewatchesOrderWhenSaveFails(t *testing.T) {
+	repo, _ := newInMemoryOrderRepository()
+	triggerBook := service.NewIfTouchedTriggerBookWithDefaults()
+
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		TriggerBook:        triggerBook,
+	})
+	result := submitIfTouchedOrder(t, submitUseCase, "MARKET_IF_TOUCHED", "BUY", nil, 148.00)
+
+	stored, err := repo.FindByID(context.Background(), result.OrderID)
+	require.NoError(t, err)
+	storedCopy := *stored
+	failingRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error { return errors.New("database unavailable") },
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			inactive := storedCopy
+			return &inactive, nil
+		},
+	}
+
+	activated, err := NewActivateIfTouchedOrdersUseCase(failingRepo, triggerBook, nil).Execute(context.Background(), "AAPL", 147.00, time.Now())
+
+	assert.Error(t, err)
+	assert.Empty(t, activated)
+	assert.Equal(t, 1, triggerBook.Watching())
+}
+
+func TestActivateIfTouchedOrdersUseCase_RestoreRewatchesInactiveOrdersAfterRestart(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	repo.FindByStatusFunc = func(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+		pending := make([]*domain.Order, 0)
+		for _, order := range orders {
+			if order.Status() == status {
+				pending = append(pending, order)
+			}
+		}
+		return pending, nil
+	}
+
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		TriggerBook:        service.NewIfTouchedTriggerBookWithDefaults(),
+	})
+	resting := submitIfTouchedOrder(t, submitUseCase, "MARKET_IF_TOUCHED", "BUY", nil, 148.00)
+	limitPrice := 150.00
+	_, err := submitUseCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  10,
+		Price:     &limitPrice,
+	})
+	require.NoError(t, err)
+
+	// A restart starts from an empty trigger book
+	triggerBook := service.NewIfTouchedTriggerBookWithDefaults()
+	activateUseCase := NewActivateIfTouchedOrdersUseCase(repo, triggerBook, nil)
+
+	restored, err := activateUseCase.Restore(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+	assert.Equal(t, []string{"AAPL"}, triggerBook.Symbols())
+
+	activated, err := activateUseCase.Execute(context.Background(), "AAPL", 148.00, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{resting.OrderID}, activated)
+	assert.Empty(t, triggerBook.Symbols())
+}
+
+func TestProtectiveStop_RestsUntilParentFillsThenTriggersOnStopPrice(t *testing.T) {
+	repo, _ := newInMemoryOrderRepository()
+	triggerBook := service.NewIfTouchedTriggerBookWithDefaults()
+	marketPrice := 151.00
+	marketData := &MockMarketDataClient{
+		GetCurrentPriceFunc: func(ctx context.Context, symbol string) (float64, error) {
+			return marketPrice, nil
+		},
+	}
+	submit := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   marketData,
+		IdempotencyService: &MockIdempotencyService{},
+	})
+	process := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  repo,
+		MarketDataClient: marketData,
+		EventPublisher:   &MockEventPublisher{},
+		TriggerBook:      triggerBook,
+	})
+	publisher := &RecordingOrderPublisher{}
+	activation := NewActivateIfTouchedOrdersUseCase(repo, triggerBook, publisher)
+	processOrder := func(orderID string) error {
+		_, err := process.Execute(context.Background(), &ProcessOrderCommand{OrderID: orderID})
+		return err
+	}
+
+	limitPrice := 150.00
+	stopPercent := 5.0
+	result, err := submit.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:                "user123",
+		Symbol:                "AAPL",
+		OrderType:             "LIMIT",
+		OrderSide:             "BUY",
+		Quantity:              10,
+		Price:                 &limitPrice,
+		ProtectiveStopPercent: &stopPercent,
+	})
+	require.NoError(t, err)
+	stop, _ := repo.FindByID(context.Background(), result.ProtectiveStopOrderID)
+	require.NotNil(t, stop)
+
+	// Processing the stop before its parent fills leaves it resting
+	assert.Error(t, processOrder(stop.ID()))
+	assert.Equal(t, domain.OrderStatusPending, stop.Status())
+	assert.Equal(t, 0, triggerBook.Watching())
+
+	// The buy fills above the stop price: the stop is armed but still pending
+	marketPrice = 149.00
+	require.NoError(t, processOrder(result.OrderID))
+	assert.Equal(t, domain.OrderStatusPending, stop.Status())
+	assert.Equal(t, 1, triggerBook.Watching())
+
+	activated, err := activation.Execute(context.Background(), "AAPL", 143.00, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, activated)
+
+	// A quote at the stop price sends it for processing, which sells
+	activated, err = activation.Execute(context.Background(), "AAPL", 142.50, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{stop.ID()}, activated)
+	require.Len(t, publisher.Published, 1)
+
+	marketPrice = 142.40
+	require.NoError(t, processOrder(stop.ID()))
+	assert.Equal(t, domain.OrderStatusExecuted, stop.Status())
+}
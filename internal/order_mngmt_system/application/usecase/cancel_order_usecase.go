@@ -8,6 +8,8 @@ import (
 	"HubInvestments/internal/order_mngmt_system/application/command"
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
 	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/notification"
 )
 
 // ICancelOrderUseCase defines the interface for cancelling orders
@@ -18,6 +20,8 @@ type ICancelOrderUseCase interface {
 // CancelOrderUseCase handles order cancellation with proper validation
 type CancelOrderUseCase struct {
 	orderRepository repository.IOrderRepository
+	expiryScheduler service.OrderExpiryScheduler
+	notifier        notification.IOrderNotificationDispatcher
 }
 
 // CancelOrderUseCaseConfig holds configuration for order cancellation
@@ -35,6 +39,32 @@ func NewCancelOrderUseCase(
 	}
 }
 
+// NewCancelOrderUseCaseWithExpiryScheduler creates a cancel order use case that sweeps
+// GTD orders from the scheduler in CancelExpiredOrders and notifies users of their cancelled orders
+func NewCancelOrderUseCaseWithExpiryScheduler(
+	orderRepository repository.IOrderRepository,
+	expiryScheduler service.OrderExpiryScheduler,
+	notifier notification.IOrderNotificationDispatcher,
+) *CancelOrderUseCase {
+	return &CancelOrderUseCase{
+		orderRepository: orderRepository,
+		expiryScheduler: expiryScheduler,
+		notifier:        notifier,
+	}
+}
+
+// NewCancelOrderUseCaseWithNotifications creates a cancel order use case that notifies users of
+// their cancelled orders, as their notification preferences allow
+func NewCancelOrderUseCaseWithNotifications(
+	orderRepository repository.IOrderRepository,
+	notifier notification.IOrderNotificationDispatcher,
+) ICancelOrderUseCase {
+	return &CancelOrderUseCase{
+		orderRepository: orderRepository,
+		notifier:        notifier,
+	}
+}
+
 // Execute processes the order cancellation request
 func (uc *CancelOrderUseCase) Execute(ctx context.Context, cmd *command.CancelOrderCommand) (*command.CancelOrderResult, error) {
 	// Step 1: Validate command
@@ -63,8 +93,7 @@ func (uc *CancelOrderUseCase) Execute(ctx context.Context, cmd *command.CancelOr
 	}
 
 	// Step 5: Cancel the order
-	cancellationReason := string(cmd.GetReason())
-	if err := uc.cancelOrder(ctx, order, cancellationReason); err != nil {
+	if err := uc.cancelOrder(ctx, order, cmd.GetReasonCode()); err != nil {
 		return nil, fmt.Errorf("failed to cancel order: %w", err)
 	}
 
@@ -111,19 +140,23 @@ func (uc *CancelOrderUseCase) validateOrderCanBeCancelled(order *domain.Order) e
 	}
 }
 
-func (uc *CancelOrderUseCase) cancelOrder(ctx context.Context, order *domain.Order, reason string) error {
-	if err := order.MarkAsCancelled(); err != nil {
+func (uc *CancelOrderUseCase) cancelOrder(ctx context.Context, order *domain.Order, reason domain.CancellationReason) error {
+	if err := order.MarkAsCancelledWithReason(reason); err != nil {
 		return fmt.Errorf("failed to mark order as cancelled: %w", err)
 	}
 
-	if err := uc.orderRepository.UpdateStatus(ctx, order.ID(), order.Status()); err != nil {
-		return fmt.Errorf("failed to update order status in database: %w", err)
+	// Save rather than UpdateStatus so the cancellation reason is stored with the status
+	if err := uc.orderRepository.Save(ctx, order); err != nil {
+		return fmt.Errorf("failed to save cancelled order: %w", err)
+	}
+
+	if uc.notifier != nil {
+		uc.notifier.NotifyOrderEvent(ctx, domain.OrderNotificationCancelled, order)
 	}
 
 	// Step 3: Integratiing in external vendor, we could:
 	// - Notify external systems (broker, settlement, etc.)
 	// - Release any reserved funds or positions
-	// - Send notifications to the user
 	// - Update related systems (risk management, reporting, etc.)
 
 	return nil
@@ -182,14 +215,115 @@ func (uc *CancelOrderUseCase) CancelOrdersByUser(ctx context.Context, userID, re
 	return result, nil
 }
 
-func (uc *CancelOrderUseCase) CancelExpiredOrders(ctx context.Context, expirationTime time.Time) (*BatchCancellationResult, error) {
-	// For now, this method is simplified
-	return &BatchCancellationResult{
+// CancelOrdersOnDisconnect cancels the user's open DAY orders after their control connection
+// dropped. GTC and other long-lived orders are left working.
+func (uc *CancelOrderUseCase) CancelOrdersOnDisconnect(ctx context.Context, userID string) (*BatchCancellationResult, error) {
+	orders, err := uc.orderRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user orders: %w", err)
+	}
+
+	result := &BatchCancellationResult{
 		TotalOrders:     0,
 		CancelledOrders: 0,
 		FailedOrders:    0,
-		Errors:          []string{"CancelExpiredOrders not fully implemented yet"},
-	}, nil
+		Errors:          make([]string, 0),
+	}
+
+	for _, order := range orders {
+		if !order.CanCancel() || order.TimeInForce() != domain.TimeInForceDay {
+			continue
+		}
+		result.TotalOrders++
+
+		cmd := &command.CancelOrderCommand{
+			OrderID: order.ID(),
+			UserID:  userID,
+			Reason:  string(command.CancellationReasonDisconnected),
+		}
+
+		if _, err := uc.Execute(ctx, cmd); err != nil {
+			result.FailedOrders++
+			result.Errors = append(result.Errors, fmt.Sprintf("Order %s: %v", order.ID(), err))
+		} else {
+			result.CancelledOrders++
+		}
+	}
+
+	return result, nil
+}
+
+// CancelExpiredOrders cancels one batch of GTD orders whose jittered expiry is at or before expirationTime.
+// Callers sweep repeatedly; orders beyond the batch size are left for the next sweep.
+func (uc *CancelOrderUseCase) CancelExpiredOrders(ctx context.Context, expirationTime time.Time) (*BatchCancellationResult, error) {
+	if uc.expiryScheduler == nil {
+		return &BatchCancellationResult{
+			TotalOrders:     0,
+			CancelledOrders: 0,
+			FailedOrders:    0,
+			Errors:          []string{"CancelExpiredOrders requires an expiry scheduler"},
+		}, nil
+	}
+
+	dueOrderIDs := uc.expiryScheduler.NextBatch(expirationTime)
+
+	result := &BatchCancellationResult{
+		TotalOrders:     len(dueOrderIDs),
+		CancelledOrders: 0,
+		FailedOrders:    0,
+		Errors:          make([]string, 0),
+	}
+
+	for _, orderID := range dueOrderIDs {
+		order, err := uc.orderRepository.FindByID(ctx, orderID)
+		if err != nil {
+			result.FailedOrders++
+			result.Errors = append(result.Errors, fmt.Sprintf("Order %s: %v", orderID, err))
+			continue
+		}
+
+		// Orders that filled or were cancelled before expiring need no action
+		if order == nil || !order.CanCancel() {
+			result.TotalOrders--
+			continue
+		}
+
+		if err := uc.cancelOrder(ctx, order, domain.CancellationReasonExpired); err != nil {
+			result.FailedOrders++
+			result.Errors = append(result.Errors, fmt.Sprintf("Order %s: %v", orderID, err))
+		} else {
+			result.CancelledOrders++
+		}
+	}
+
+	return result, nil
+}
+
+// CancelOrderBySystem cancels an order on behalf of the platform rather than its owner, e.g. when
+// the other leg of an OCO pair filled, a risk halt pulled the order or reconciliation found it stale
+func (uc *CancelOrderUseCase) CancelOrderBySystem(ctx context.Context, orderID string, reason domain.CancellationReason) error {
+	if !reason.IsValid() {
+		return fmt.Errorf("invalid cancellation reason: %s", reason)
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if order == nil {
+		return fmt.Errorf("order not found")
+	}
+
+	if !order.CanCancel() {
+		return fmt.Errorf("order in status '%s' cannot be cancelled", order.Status())
+	}
+
+	if err := uc.cancelOrder(ctx, order, reason); err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	return nil
 }
 
 // BatchCancellationResult represents the result of batch cancellation operations
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"HubInvestments/internal/order_mngmt_system/application/command"
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
 )
 
 func TestCancelOrderUseCase_Execute_Success(t *testing.T) {
@@ -130,6 +132,7 @@ func TestCancelOrderUseCase_Execute_OrderAlreadyExecuted(t *testing.T) {
 			price := 150.00
 			order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
 			// Mark order as executed
+			order.MarkAsProcessing()
 			order.MarkAsExecuted(149.50)
 			return order, nil
 		},
@@ -319,3 +322,225 @@ func TestCancelOrderUseCase_Execute_ProcessingOrder(t *testing.T) {
 		t.Errorf("Expected cannot be cancelled error, got %v", err)
 	}
 }
+
+func TestCancelOrderUseCase_CancelExpiredOrders_SweepsInBatches(t *testing.T) {
+	// Arrange
+	orders := make(map[string]*domain.Order)
+	scheduler := service.NewOrderExpiryScheduler(service.OrderExpirySchedulerConfig{
+		MaxJitter:      30 * time.Second,
+		SweepBatchSize: 4,
+	})
+	deadline := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+
+	price := 150.00
+	for i := 0; i < 10; i++ {
+		order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+		orders[order.ID()] = order
+		scheduler.Schedule(order.ID(), deadline)
+	}
+
+	mockRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return orders[orderID], nil
+		},
+	}
+
+	useCase := NewCancelOrderUseCaseWithExpiryScheduler(mockRepo, scheduler, nil)
+
+	// Act
+	cancelled := 0
+	for sweep := 0; sweep < 10 && scheduler.Pending() > 0; sweep++ {
+		result, err := useCase.CancelExpiredOrders(context.Background(), deadline.Add(30*time.Second))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.TotalOrders > 4 {
+			t.Errorf("Expected at most 4 orders per sweep, got %d", result.TotalOrders)
+		}
+		cancelled += result.CancelledOrders
+	}
+
+	// Assert
+	if cancelled != 10 {
+		t.Errorf("Expected 10 cancelled orders, got %d", cancelled)
+	}
+
+	for _, order := range orders {
+		if order.Status() != domain.OrderStatusCancelled {
+			t.Errorf("Expected order %s to be cancelled, got %s", order.ID(), order.Status())
+		}
+	}
+}
+
+func TestCancelOrderUseCase_CancelOrdersOnDisconnect_CancelsOpenDayOrders(t *testing.T) {
+	// Arrange
+	price := 150.00
+	dayOrder, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	gtcOrder, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 50.0, &price)
+	_ = gtcOrder.SetExecutionPreferences(domain.TimeInForceGTC, true)
+	executedOrder, _ := domain.NewOrder("user123", "MSFT", domain.OrderSideSell, domain.OrderTypeMarket, 10.0, nil)
+	_ = executedOrder.MarkAsProcessing()
+	_ = executedOrder.MarkAsExecuted(300.0)
+
+	orders := map[string]*domain.Order{dayOrder.ID(): dayOrder, gtcOrder.ID(): gtcOrder, executedOrder.ID(): executedOrder}
+	mockRepo := &MockOrderRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID string) ([]*domain.Order, error) {
+			return []*domain.Order{dayOrder, gtcOrder, executedOrder}, nil
+		},
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return orders[orderID], nil
+		},
+	}
+
+	useCase := NewCancelOrderUseCaseWithExpiryScheduler(mockRepo, nil, nil)
+
+	// Act
+	result, err := useCase.CancelOrdersOnDisconnect(context.Background(), "user123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.TotalOrders != 1 || result.CancelledOrders != 1 {
+		t.Errorf("Expected 1 of 1 orders cancelled, got %d of %d", result.CancelledOrders, result.TotalOrders)
+	}
+	if dayOrder.Status() != domain.OrderStatusCancelled {
+		t.Errorf("Expected day order to be cancelled, got %s", dayOrder.Status())
+	}
+	if gtcOrder.Status() != domain.OrderStatusPending {
+		t.Errorf("Expected GTC order to keep working, got %s", gtcOrder.Status())
+	}
+}
+
+func TestCancelOrderUseCase_RecordsCancellationReasonForEachPath(t *testing.T) {
+	price := 150.00
+	deadline := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		cancel         func(useCase *CancelOrderUseCase, order *domain.Order) error
+		expectedReason domain.CancellationReason
+	}{
+		{
+			name: "user cancellation without a reason",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				_, err := useCase.Execute(context.Background(), &command.CancelOrderCommand{OrderID: order.ID(), UserID: "user123"})
+				return err
+			},
+			expectedReason: domain.CancellationReasonUserRequested,
+		},
+		{
+			name: "user cancellation with a free-text reason",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				_, err := useCase.Execute(context.Background(), &command.CancelOrderCommand{OrderID: order.ID(), UserID: "user123", Reason: "changed my mind"})
+				return err
+			},
+			expectedReason: domain.CancellationReasonUserRequested,
+		},
+		{
+			name: "admin cancellation",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				_, err := useCase.Execute(context.Background(), &command.CancelOrderCommand{OrderID: order.ID(), UserID: "user123", Reason: "ADMIN_ACTION"})
+				return err
+			},
+			expectedReason: domain.CancellationReasonAdminAction,
+		},
+		{
+			name: "client disconnect",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				_, err := useCase.CancelOrdersOnDisconnect(context.Background(), "user123")
+				return err
+			},
+			expectedReason: domain.CancellationReasonDisconnected,
+		},
+		{
+			name: "system expiry",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				useCase.expiryScheduler.Schedule(order.ID(), deadline)
+				_, err := useCase.CancelExpiredOrders(context.Background(), deadline.Add(time.Minute))
+				return err
+			},
+			expectedReason: domain.CancellationReasonExpired,
+		},
+		{
+			name: "OCO leg triggered",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				return useCase.CancelOrderBySystem(context.Background(), order.ID(), domain.CancellationReasonOCOTriggered)
+			},
+			expectedReason: domain.CancellationReasonOCOTriggered,
+		},
+		{
+			name: "risk halt",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				return useCase.CancelOrderBySystem(context.Background(), order.ID(), domain.CancellationReasonRiskHalt)
+			},
+			expectedReason: domain.CancellationReasonRiskHalt,
+		},
+		{
+			name: "reconciliation",
+			cancel: func(useCase *CancelOrderUseCase, order *domain.Order) error {
+				return useCase.CancelOrderBySystem(context.Background(), order.ID(), domain.CancellationReasonReconciliation)
+			},
+			expectedReason: domain.CancellationReasonReconciliation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+			var saved *domain.Order
+			mockRepo := &MockOrderRepository{
+				FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+					return order, nil
+				},
+				FindByUserIDFunc: func(ctx context.Context, userID string) ([]*domain.Order, error) {
+					return []*domain.Order{order}, nil
+				},
+				SaveFunc: func(ctx context.Context, order *domain.Order) error {
+					saved = order
+					return nil
+				},
+			}
+			scheduler := service.NewOrderExpiryScheduler(service.OrderExpirySchedulerConfig{SweepBatchSize: 10})
+			useCase := NewCancelOrderUseCaseWithExpiryScheduler(mockRepo, scheduler, nil)
+
+			// Act
+			err := tt.cancel(useCase, order)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if saved == nil || saved.Status() != domain.OrderStatusCancelled {
+				t.Fatalf("Expected the cancelled order to be saved, got %v", saved)
+			}
+			if saved.CancellationReason() != tt.expectedReason {
+				t.Errorf("Expected reason %s, got %s", tt.expectedReason, saved.CancellationReason())
+			}
+		})
+	}
+}
+
+func TestCancelOrderUseCase_CancelOrderBySystem_RejectsUnknownReason(t *testing.T) {
+	// Arrange
+	price := 150.00
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	mockRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	useCase := NewCancelOrderUseCaseWithExpiryScheduler(mockRepo, nil, nil)
+
+	// Act
+	err := useCase.CancelOrderBySystem(context.Background(), order.ID(), domain.CancellationReason("BORED"))
+
+	// Assert
+	if err == nil || !contains(err.Error(), "invalid cancellation reason") {
+		t.Errorf("Expected an invalid reason error, got %v", err)
+	}
+	if order.Status() != domain.OrderStatusPending {
+		t.Errorf("Expected order to keep working, got %s", order.Status())
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type ICheckOrderRiskUseCase interface {
+	Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*service.RiskAssessment, error)
+}
+
+// CheckOrderRiskUseCase returns the risk verdict for a prospective order without pricing,
+// market data validation or submission. Nothing is stored.
+type CheckOrderRiskUseCase struct {
+	riskService    service.RiskManagementService
+	riskDataClient service.IRiskDataClient
+}
+
+func NewCheckOrderRiskUseCase(
+	riskService service.RiskManagementService,
+	riskDataClient service.IRiskDataClient,
+) ICheckOrderRiskUseCase {
+	return &CheckOrderRiskUseCase{
+		riskService:    riskService,
+		riskDataClient: riskDataClient,
+	}
+}
+
+// Execute assesses the risk of the order described by the command
+func (uc *CheckOrderRiskUseCase) Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*service.RiskAssessment, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	orderSide, err := cmd.ToOrderSide()
+	if err != nil {
+		return nil, fmt.Errorf("invalid order side: %w", err)
+	}
+
+	orderType, err := cmd.ToOrderType()
+	if err != nil {
+		return nil, fmt.Errorf("invalid order type: %w", err)
+	}
+
+	order, err := domain.NewOrder(cmd.UserID, cmd.Symbol, orderSide, orderType, cmd.Quantity, cmd.Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	assessment, err := uc.riskService.AssessOrderRisk(order, uc.riskDataClient)
+	if err != nil {
+		return nil, fmt.Errorf("risk assessment failed: %w", err)
+	}
+
+	return assessment, nil
+}
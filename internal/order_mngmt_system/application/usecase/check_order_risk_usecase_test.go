@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// RecordingRiskDataClient implements IRiskDataClient and records the calls it receives
+type RecordingRiskDataClient struct {
+	calls []string
+}
+
+func (c *RecordingRiskDataClient) GetUserRiskProfile(userID string) (*service.UserRiskProfile, error) {
+	c.calls = append(c.calls, "GetUserRiskProfile")
+	return &service.UserRiskProfile{
+		UserID:          userID,
+		RiskTolerance:   service.RiskToleranceModerate,
+		MaxPositionSize: 100000.0,
+		MaxOrderValue:   50000.0,
+	}, nil
+}
+
+func (c *RecordingRiskDataClient) GetPositionExposure(userID, symbol string) (*service.PositionExposure, error) {
+	c.calls = append(c.calls, "GetPositionExposure")
+	return &service.PositionExposure{Symbol: symbol, CurrentValue: 5000.0}, nil
+}
+
+func (c *RecordingRiskDataClient) GetAccountBalance(userID string) (*service.AccountBalance, error) {
+	c.calls = append(c.calls, "GetAccountBalance")
+	return &service.AccountBalance{TotalBalance: 200000.0, AvailableBalance: 150000.0}, nil
+}
+
+func (c *RecordingRiskDataClient) GetMarketVolatility(symbol string) (*service.MarketVolatility, error) {
+	c.calls = append(c.calls, "GetMarketVolatility")
+	return &service.MarketVolatility{Symbol: symbol, Volatility30Day: 15.0, Beta: 1.1, LastCalculated: time.Now()}, nil
+}
+
+func (c *RecordingRiskDataClient) GetUserTradingLimits(userID string) (*service.TradingLimits, error) {
+	c.calls = append(c.calls, "GetUserTradingLimits")
+	return &service.TradingLimits{DailyTradingLimit: 100000.0, MaxOrderValue: 50000.0, RemainingDailyLimit: 90000.0}, nil
+}
+
+func TestCheckOrderRiskUseCase_Execute_ReturnsAssessment(t *testing.T) {
+	// Arrange
+	riskClient := &RecordingRiskDataClient{}
+	useCase := NewCheckOrderRiskUseCase(service.NewRiskManagementServiceWithDefaults(), riskClient)
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  10.0,
+		Price:     &price,
+	}
+
+	// Act
+	assessment, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if assessment.ScoreBreakdown == nil || assessment.RiskScore != assessment.ScoreBreakdown.TotalScore {
+		t.Errorf("Expected risk score to match its breakdown, got %+v", assessment)
+	}
+	if !assessment.IsApproved {
+		t.Error("Expected a small order to be approved")
+	}
+	if len(riskClient.calls) == 0 {
+		t.Error("Expected the risk data client to be used")
+	}
+}
+
+func TestCheckOrderRiskUseCase_Execute_InvalidCommand(t *testing.T) {
+	riskClient := &RecordingRiskDataClient{}
+	useCase := NewCheckOrderRiskUseCase(service.NewRiskManagementServiceWithDefaults(), riskClient)
+
+	_, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{UserID: "user123", Symbol: "AAPL"})
+
+	if err == nil {
+		t.Fatal("Expected an error for an invalid command")
+	}
+	if len(riskClient.calls) != 0 {
+		t.Errorf("Expected no risk data calls, got %v", riskClient.calls)
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// ErrPossibleDuplicateOrder is returned when an open order just like the submitted one was placed
+// moments ago. Resubmitting with the duplicate acknowledged places the order anyway.
+var ErrPossibleDuplicateOrder = errors.New("possible duplicate order")
+
+// IRecentOrderSource provides a user's orders created within a time range (dependency inversion)
+type IRecentOrderSource interface {
+	FindOrdersByDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.Order, error)
+}
+
+// DuplicateOrderConfig holds configuration for duplicate open-order detection
+type DuplicateOrderConfig struct {
+	Window                time.Duration // How far back open orders count as possible duplicates
+	PriceTolerancePercent float64       // Prices within this percent of each other count as the same price
+}
+
+// DefaultDuplicateOrderConfig returns the default duplicate detection configuration
+func DefaultDuplicateOrderConfig() DuplicateOrderConfig {
+	return DuplicateOrderConfig{
+		Window:                30 * time.Second, // A double click or retried form lands well within 30 seconds
+		PriceTolerancePercent: 0.0,              // Only the exact same price
+	}
+}
+
+// DuplicateGuardedSubmitOrderUseCase warns before placing an order that matches one of the
+// user's recent open orders on symbol, side, type, quantity and price. Unlike idempotency, which
+// replays one request, this catches two separate requests the user probably did not mean to send.
+type DuplicateGuardedSubmitOrderUseCase struct {
+	submitOrderUseCase ISubmitOrderUseCase
+	recentOrders       IRecentOrderSource
+	config             DuplicateOrderConfig
+	now                func() time.Time
+}
+
+func NewDuplicateGuardedSubmitOrderUseCase(
+	submitOrderUseCase ISubmitOrderUseCase,
+	recentOrders IRecentOrderSource,
+	config DuplicateOrderConfig,
+) ISubmitOrderUseCase {
+	return &DuplicateGuardedSubmitOrderUseCase{
+		submitOrderUseCase: submitOrderUseCase,
+		recentOrders:       recentOrders,
+		config:             config,
+		now:                time.Now,
+	}
+}
+
+func (uc *DuplicateGuardedSubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+	if cmd != nil && !cmd.AcknowledgeDuplicate && uc.config.Window > 0 {
+		duplicate, err := uc.findDuplicate(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		if duplicate != nil {
+			return nil, fmt.Errorf("%w: open order %s with the same symbol, side, type, price and quantity was placed at %s, resubmit with acknowledge_duplicate to place it anyway",
+				ErrPossibleDuplicateOrder, duplicate.ID(), duplicate.CreatedAt().Format(time.RFC3339))
+		}
+	}
+
+	return uc.submitOrderUseCase.Execute(ctx, cmd)
+}
+
+// findDuplicate returns the most recent open order within the window matching the command
+func (uc *DuplicateGuardedSubmitOrderUseCase) findDuplicate(ctx context.Context, cmd *command.SubmitOrderCommand) (*domain.Order, error) {
+	now := uc.now()
+	orders, err := uc.recentOrders.FindOrdersByDateRange(ctx, cmd.UserID, now.Add(-uc.config.Window), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate orders: %w", err)
+	}
+
+	var duplicate *domain.Order
+	for _, order := range orders {
+		if !order.Status().IsActive() || !uc.matches(cmd, order) {
+			continue
+		}
+		if duplicate == nil || order.CreatedAt().After(duplicate.CreatedAt()) {
+			duplicate = order
+		}
+	}
+
+	return duplicate, nil
+}
+
+func (uc *DuplicateGuardedSubmitOrderUseCase) matches(cmd *command.SubmitOrderCommand, order *domain.Order) bool {
+	if !strings.EqualFold(order.Symbol(), strings.TrimSpace(cmd.Symbol)) ||
+		!strings.EqualFold(order.OrderSide().String(), cmd.OrderSide) ||
+		!strings.EqualFold(order.OrderType().String(), cmd.OrderType) ||
+		order.Quantity() != cmd.Quantity {
+		return false
+	}
+
+	if cmd.Price == nil || order.Price() == nil {
+		return cmd.Price == nil && order.Price() == nil
+	}
+
+	tolerance := *order.Price() * uc.config.PriceTolerancePercent / 100
+	return math.Abs(*cmd.Price-*order.Price()) <= tolerance
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockRecentOrderSource struct {
+	FindOrdersByDateRangeFunc func(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.Order, error)
+}
+
+func (m *MockRecentOrderSource) FindOrdersByDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.Order, error) {
+	return m.FindOrdersByDateRangeFunc(ctx, userID, startDate, endDate)
+}
+
+func newDuplicateCheckCommand(price float64) *command.SubmitOrderCommand {
+	return &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  10.0,
+		Price:     &price,
+	}
+}
+
+func newDuplicateGuardedUseCase(t *testing.T, saved *bool) ISubmitOrderUseCase {
+	openPrice := 150.0
+	openOrder, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10.0, &openPrice)
+	if err != nil {
+		t.Fatalf("Failed to create open order: %v", err)
+	}
+
+	orderRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			*saved = true
+			return nil
+		},
+	}
+	recentOrders := &MockRecentOrderSource{
+		FindOrdersByDateRangeFunc: func(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.Order, error) {
+			if endDate.Sub(startDate) != 30*time.Second {
+				t.Errorf("Expected a 30 second lookback, got %s", endDate.Sub(startDate))
+			}
+			return []*domain.Order{openOrder}, nil
+		},
+	}
+
+	return NewDuplicateGuardedSubmitOrderUseCase(
+		NewSubmitOrderUseCase(SubmitOrderDependencies{
+			OrderRepository:    orderRepo,
+			MarketDataClient:   &MockMarketDataClient{},
+			IdempotencyService: &MockIdempotencyService{},
+		}),
+		recentOrders,
+		DefaultDuplicateOrderConfig(),
+	)
+}
+
+func TestDuplicateGuardedSubmitOrderUseCase_NearDuplicateIsWarned(t *testing.T) {
+	// Arrange
+	saved := false
+	useCase := newDuplicateGuardedUseCase(t, &saved)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), newDuplicateCheckCommand(150.0))
+
+	// Assert
+	if !errors.Is(err, ErrPossibleDuplicateOrder) {
+		t.Fatalf("Expected ErrPossibleDuplicateOrder, got %v", err)
+	}
+	if !contains(err.Error(), "acknowledge_duplicate") {
+		t.Errorf("Expected the warning to explain how to acknowledge, got %q", err.Error())
+	}
+	if result != nil || saved {
+		t.Error("Expected a possible duplicate not to be submitted")
+	}
+}
+
+func TestDuplicateGuardedSubmitOrderUseCase_AcknowledgedDuplicateIsAllowed(t *testing.T) {
+	// Arrange
+	saved := false
+	useCase := newDuplicateGuardedUseCase(t, &saved)
+	cmd := newDuplicateCheckCommand(150.0)
+	cmd.AcknowledgeDuplicate = true
+
+	// Act
+	result, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result == nil || !saved {
+		t.Error("Expected an acknowledged duplicate to be submitted")
+	}
+}
+
+func TestDuplicateGuardedSubmitOrderUseCase_DifferentOrderIsAllowed(t *testing.T) {
+	// Arrange
+	saved := false
+	useCase := newDuplicateGuardedUseCase(t, &saved)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), newDuplicateCheckCommand(149.5))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result == nil || !saved {
+		t.Error("Expected an order at a different price to be submitted")
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type IForceCancelOrderUseCase interface {
+	// Execute cancels the order whoever owns it and records the admin who did it
+	Execute(ctx context.Context, cmd *command.ForceCancelOrderCommand) (*command.ForceCancelOrderResult, error)
+}
+
+// ForceCancelOrderUseCase lets support cancel a stuck or erroneous order on the user's behalf. It
+// skips the owner check and the processing grace period of a user cancellation, but executed,
+// failed and already cancelled orders are still left alone.
+type ForceCancelOrderUseCase struct {
+	orderRepository  repository.IOrderRepository
+	actionRepository repository.IOrderAdminActionRepository
+}
+
+func NewForceCancelOrderUseCase(
+	orderRepository repository.IOrderRepository,
+	actionRepository repository.IOrderAdminActionRepository,
+) IForceCancelOrderUseCase {
+	return &ForceCancelOrderUseCase{
+		orderRepository:  orderRepository,
+		actionRepository: actionRepository,
+	}
+}
+
+// Execute cancels the order whoever owns it and records the admin who did it. The order is saved
+// before the audit entry, so a failed audit write is reported although the cancellation stands.
+func (uc *ForceCancelOrderUseCase) Execute(ctx context.Context, cmd *command.ForceCancelOrderCommand) (*command.ForceCancelOrderResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid force cancellation command: %w", err)
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if order == nil {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	previousStatus := order.Status()
+	if !order.CanCancel() {
+		return nil, fmt.Errorf("order cannot be cancelled: order in status '%s' cannot be cancelled", previousStatus)
+	}
+
+	if err := order.MarkAsCancelledWithReason(domain.CancellationReasonAdminAction); err != nil {
+		return nil, fmt.Errorf("failed to mark order as cancelled: %w", err)
+	}
+
+	if err := uc.orderRepository.Save(ctx, order); err != nil {
+		return nil, fmt.Errorf("failed to save cancelled order: %w", err)
+	}
+
+	action := &domain.OrderAdminAction{
+		ID:             uuid.New().String(),
+		OrderID:        order.ID(),
+		OrderOwnerID:   order.UserID(),
+		Action:         domain.OrderAdminActionForceCancel,
+		PerformedBy:    cmd.AdminID,
+		Reason:         cmd.Reason,
+		PreviousStatus: previousStatus,
+		NewStatus:      order.Status(),
+		PerformedAt:    time.Now(),
+	}
+
+	if err := uc.actionRepository.Save(ctx, action); err != nil {
+		return nil, fmt.Errorf("order %s was cancelled but the audit entry could not be saved: %w", order.ID(), err)
+	}
+
+	return &command.ForceCancelOrderResult{
+		OrderID:        order.ID(),
+		OrderOwnerID:   order.UserID(),
+		PreviousStatus: string(previousStatus),
+		Status:         string(order.Status()),
+		AuditID:        action.ID,
+		Timestamp:      action.PerformedAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// InMemoryOrderAdminActionRepository implements IOrderAdminActionRepository for testing
+type InMemoryOrderAdminActionRepository struct {
+	mu      sync.Mutex
+	actions []*domain.OrderAdminAction
+}
+
+func (r *InMemoryOrderAdminActionRepository) Save(ctx context.Context, action *domain.OrderAdminAction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func (r *InMemoryOrderAdminActionRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.OrderAdminAction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var actions []*domain.OrderAdminAction
+	for _, action := range r.actions {
+		if action.OrderID == orderID {
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+func newForceCancelFixture(t *testing.T) (*domain.Order, *MockOrderRepository, *int) {
+	price := 150.00
+	order, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+
+	saveCalls := 0
+	repo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			if orderID == order.ID() {
+				return order, nil
+			}
+			return nil, nil
+		},
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saveCalls++
+			return nil
+		},
+	}
+	return order, repo, &saveCalls
+}
+
+func TestForceCancelOrderUseCase_CancelsAnotherUsersOrder(t *testing.T) {
+	order, orderRepo, saveCalls := newForceCancelFixture(t)
+	actionRepo := &InMemoryOrderAdminActionRepository{}
+	useCase := NewForceCancelOrderUseCase(orderRepo, actionRepo)
+
+	result, err := useCase.Execute(context.Background(), &command.ForceCancelOrderCommand{
+		OrderID: order.ID(),
+		AdminID: "admin1",
+		Reason:  "Order stuck after broker outage",
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Status != string(domain.OrderStatusCancelled) || result.PreviousStatus != string(domain.OrderStatusPending) {
+		t.Errorf("Expected PENDING -> CANCELLED, got %s -> %s", result.PreviousStatus, result.Status)
+	}
+	if result.OrderOwnerID != "user123" {
+		t.Errorf("Expected owner user123, got %s", result.OrderOwnerID)
+	}
+	if order.CancellationReason() != domain.CancellationReasonAdminAction {
+		t.Errorf("Expected cancellation reason %s, got %s", domain.CancellationReasonAdminAction, order.CancellationReason())
+	}
+	if *saveCalls != 1 {
+		t.Errorf("Expected the order to be saved once, got %d", *saveCalls)
+	}
+}
+
+func TestForceCancelOrderUseCase_AuditsAdminActor(t *testing.T) {
+	order, orderRepo, _ := newForceCancelFixture(t)
+	actionRepo := &InMemoryOrderAdminActionRepository{}
+	useCase := NewForceCancelOrderUseCase(orderRepo, actionRepo)
+
+	result, err := useCase.Execute(context.Background(), &command.ForceCancelOrderCommand{
+		OrderID: order.ID(),
+		AdminID: "admin1",
+		Reason:  "Duplicate order placed in error",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	actions, _ := actionRepo.FindByOrderID(context.Background(), order.ID())
+	if len(actions) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(actions))
+	}
+	action := actions[0]
+	if action.ID != result.AuditID {
+		t.Errorf("Expected audit ID %s, got %s", result.AuditID, action.ID)
+	}
+	if action.Action != domain.OrderAdminActionForceCancel {
+		t.Errorf("Expected action %s, got %s", domain.OrderAdminActionForceCancel, action.Action)
+	}
+	if action.PerformedBy != "admin1" || action.OrderOwnerID != "user123" {
+		t.Errorf("Expected admin1 acting on user123's order, got %s acting on %s's", action.PerformedBy, action.OrderOwnerID)
+	}
+	if action.Reason != "Duplicate order placed in error" {
+		t.Errorf("Expected the reason to be recorded, got %q", action.Reason)
+	}
+	if action.PreviousStatus != domain.OrderStatusPending || action.NewStatus != domain.OrderStatusCancelled {
+		t.Errorf("Expected PENDING -> CANCELLED, got %s -> %s", action.PreviousStatus, action.NewStatus)
+	}
+}
+
+func TestForceCancelOrderUseCase_RejectsTerminalOrder(t *testing.T) {
+	order, orderRepo, saveCalls := newForceCancelFixture(t)
+	if err := order.MarkAsProcessing(); err != nil {
+		t.Fatalf("Failed to process order: %v", err)
+	}
+	if err := order.MarkAsExecuted(150.00); err != nil {
+		t.Fatalf("Failed to execute order: %v", err)
+	}
+	actionRepo := &InMemoryOrderAdminActionRepository{}
+	useCase := NewForceCancelOrderUseCase(orderRepo, actionRepo)
+
+	_, err := useCase.Execute(context.Background(), &command.ForceCancelOrderCommand{
+		OrderID: order.ID(),
+		AdminID: "admin1",
+		Reason:  "Customer asked to cancel",
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "cannot be cancelled") {
+		t.Fatalf("Expected a cannot be cancelled error, got %v", err)
+	}
+	if order.Status() != domain.OrderStatusExecuted {
+		t.Errorf("Expected the order to stay EXECUTED, got %s", order.Status())
+	}
+	if *saveCalls != 0 || len(actionRepo.actions) != 0 {
+		t.Errorf("Expected nothing saved, got %d order saves and %d audit entries", *saveCalls, len(actionRepo.actions))
+	}
+}
+
+func TestForceCancelOrderUseCase_RequiresReason(t *testing.T) {
+	order, orderRepo, _ := newForceCancelFixture(t)
+	useCase := NewForceCancelOrderUseCase(orderRepo, &InMemoryOrderAdminActionRepository{})
+
+	_, err := useCase.Execute(context.Background(), &command.ForceCancelOrderCommand{OrderID: order.ID(), AdminID: "admin1"})
+
+	if err == nil || !strings.Contains(err.Error(), "reason is required") {
+		t.Fatalf("Expected a reason is required error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+type IGetExecutionQualityUseCase interface {
+	Execute(ctx context.Context, orderID, userID string) (*domain.ExecutionQualityReport, error)
+}
+
+type GetExecutionQualityUseCase struct {
+	orderRepository            repository.IOrderRepository
+	executionQualityRepository repository.IExecutionQualityRepository
+}
+
+func NewGetExecutionQualityUseCase(
+	orderRepository repository.IOrderRepository,
+	executionQualityRepository repository.IExecutionQualityRepository,
+) IGetExecutionQualityUseCase {
+	return &GetExecutionQualityUseCase{
+		orderRepository:            orderRepository,
+		executionQualityRepository: executionQualityRepository,
+	}
+}
+
+// Execute retrieves the execution quality report of one of the user's filled orders
+func (uc *GetExecutionQualityUseCase) Execute(ctx context.Context, orderID, userID string) (*domain.ExecutionQualityReport, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if order == nil || order.UserID() != userID {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	if !order.IsExecuted() {
+		return nil, fmt.Errorf("execution quality is only available for executed orders")
+	}
+
+	report, err := uc.executionQualityRepository.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution quality report: %w", err)
+	}
+
+	if report == nil {
+		return nil, fmt.Errorf("execution quality report not found")
+	}
+
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockExecutionQualityRepository struct {
+	reports map[string]*domain.ExecutionQualityReport
+}
+
+func (m *MockExecutionQualityRepository) Save(ctx context.Context, report *domain.ExecutionQualityReport) error {
+	m.reports[report.OrderID] = report
+	return nil
+}
+
+func (m *MockExecutionQualityRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.ExecutionQualityReport, error) {
+	return m.reports[orderID], nil
+}
+
+func TestGetExecutionQualityUseCase_Execute_ReturnsStoredReport(t *testing.T) {
+	// Arrange
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	order.MarkAsProcessing()
+	order.MarkAsExecuted(150.25)
+
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	qualityRepo := &MockExecutionQualityRepository{reports: map[string]*domain.ExecutionQualityReport{
+		order.ID(): {OrderID: order.ID(), Slippage: 0.25, Quality: domain.ExecutionQualityWithinTolerance},
+	}}
+
+	useCase := NewGetExecutionQualityUseCase(orderRepo, qualityRepo)
+
+	// Act
+	report, err := useCase.Execute(context.Background(), order.ID(), "user123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Slippage != 0.25 {
+		t.Errorf("Expected slippage 0.25, got %f", report.Slippage)
+	}
+}
+
+func TestGetExecutionQualityUseCase_Execute_OtherUsersOrder(t *testing.T) {
+	// Arrange
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	order.MarkAsProcessing()
+	order.MarkAsExecuted(150.25)
+
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	qualityRepo := &MockExecutionQualityRepository{reports: map[string]*domain.ExecutionQualityReport{}}
+
+	useCase := NewGetExecutionQualityUseCase(orderRepo, qualityRepo)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), order.ID(), "other-user")
+
+	// Assert
+	if err == nil || err.Error() != "order not found" {
+		t.Errorf("Expected order not found error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+type IGetOrderFillsUseCase interface {
+	Execute(ctx context.Context, orderID, userID string) (*OrderFillsResult, error)
+}
+
+// OrderFillsResult lists the fills that composed an order together with their summary
+type OrderFillsResult struct {
+	OrderID string
+	Fills   []domain.OrderFill
+	Summary domain.OrderFillSummary
+}
+
+type GetOrderFillsUseCase struct {
+	orderRepository repository.IOrderRepository
+	fillRepository  repository.IOrderFillRepository
+}
+
+func NewGetOrderFillsUseCase(
+	orderRepository repository.IOrderRepository,
+	fillRepository repository.IOrderFillRepository,
+) IGetOrderFillsUseCase {
+	return &GetOrderFillsUseCase{
+		orderRepository: orderRepository,
+		fillRepository:  fillRepository,
+	}
+}
+
+// Execute retrieves the individual fills of one of the user's orders
+func (uc *GetOrderFillsUseCase) Execute(ctx context.Context, orderID, userID string) (*OrderFillsResult, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if order == nil || order.UserID() != userID {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	fills, err := uc.fillRepository.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order fills: %w", err)
+	}
+
+	if len(fills) == 0 {
+		fills = fallbackOrderFills(order)
+	}
+
+	return &OrderFillsResult{
+		OrderID: orderID,
+		Fills:   fills,
+		Summary: domain.SummarizeFills(fills),
+	}, nil
+}
+
+// fallbackOrderFills covers orders whose fills were never stored: fills still tracked on the
+// order itself, or a single fill for orders executed before fills were recorded
+func fallbackOrderFills(order *domain.Order) []domain.OrderFill {
+	if fills := order.Fills(); len(fills) > 0 {
+		return fills
+	}
+
+	if !order.IsExecuted() || order.ExecutionPrice() == nil {
+		return []domain.OrderFill{}
+	}
+
+	filledAt := order.UpdatedAt()
+	if order.ExecutedAt() != nil {
+		filledAt = *order.ExecutedAt()
+	}
+
+	return []domain.OrderFill{{
+		ID:       order.ID(),
+		OrderID:  order.ID(),
+		Quantity: order.Quantity(),
+		Price:    *order.ExecutionPrice(),
+		FilledAt: filledAt,
+	}}
+}
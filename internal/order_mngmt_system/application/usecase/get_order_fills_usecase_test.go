@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockOrderFillRepository struct {
+	fills map[string][]domain.OrderFill
+}
+
+func (m *MockOrderFillRepository) Save(ctx context.Context, fill *domain.OrderFill) error {
+	m.fills[fill.OrderID] = append(m.fills[fill.OrderID], *fill)
+	return nil
+}
+
+func (m *MockOrderFillRepository) FindByOrderID(ctx context.Context, orderID string) ([]domain.OrderFill, error) {
+	return m.fills[orderID], nil
+}
+
+func TestGetOrderFillsUseCase_Execute_ReturnsEachPartialFill(t *testing.T) {
+	// Arrange
+	price := 100.00
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	fillRepo := &MockOrderFillRepository{fills: make(map[string][]domain.OrderFill)}
+
+	partials := []struct {
+		quantity float64
+		price    float64
+	}{
+		{quantity: 30, price: 99.50},
+		{quantity: 50, price: 99.75},
+		{quantity: 20, price: 100.00},
+	}
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	for i, partial := range partials {
+		fill, err := order.RecordFillAt(partial.quantity, partial.price, start.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatalf("Expected fill %d to be recorded, got %v", i, err)
+		}
+		fillRepo.Save(context.Background(), &fill)
+	}
+
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	useCase := NewGetOrderFillsUseCase(orderRepo, fillRepo)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), order.ID(), "user123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Fills) != len(partials) {
+		t.Fatalf("Expected %d fills, got %d", len(partials), len(result.Fills))
+	}
+	for i, partial := range partials {
+		fill := result.Fills[i]
+		if fill.Quantity != partial.quantity || fill.Price != partial.price {
+			t.Errorf("Expected fill %d to be %.0f @ %.2f, got %.0f @ %.2f", i, partial.quantity, partial.price, fill.Quantity, fill.Price)
+		}
+		if fill.OrderID != order.ID() {
+			t.Errorf("Expected fill %d to belong to order %s, got %s", i, order.ID(), fill.OrderID)
+		}
+	}
+
+	// (30*99.50 + 50*99.75 + 20*100.00) / 100 = 99.7250
+	if result.Summary.FillCount != 3 || result.Summary.FilledQuantity != 100 {
+		t.Errorf("Expected 3 fills totalling 100, got %d fills totalling %.2f", result.Summary.FillCount, result.Summary.FilledQuantity)
+	}
+	if math.Abs(result.Summary.AverageFillPrice-99.725) > 1e-9 {
+		t.Errorf("Expected average fill price 99.725, got %f", result.Summary.AverageFillPrice)
+	}
+	if !result.Summary.FirstFillAt.Equal(start) || !result.Summary.LastFillAt.Equal(start.Add(2*time.Second)) {
+		t.Errorf("Expected fills between %v and %v, got %v and %v", start, start.Add(2*time.Second), result.Summary.FirstFillAt, result.Summary.LastFillAt)
+	}
+}
+
+func TestGetOrderFillsUseCase_Execute_ExecutedOrderWithoutStoredFills(t *testing.T) {
+	// Arrange
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideSell, domain.OrderTypeMarket, 10.0, nil)
+	order.MarkAsProcessing()
+	order.MarkAsExecuted(150.25)
+
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	useCase := NewGetOrderFillsUseCase(orderRepo, &MockOrderFillRepository{fills: make(map[string][]domain.OrderFill)})
+
+	// Act
+	result, err := useCase.Execute(context.Background(), order.ID(), "user123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Fills) != 1 {
+		t.Fatalf("Expected a single fill for the execution, got %d", len(result.Fills))
+	}
+	if result.Fills[0].Quantity != 10 || result.Fills[0].Price != 150.25 {
+		t.Errorf("Expected 10 @ 150.25, got %.0f @ %.2f", result.Fills[0].Quantity, result.Fills[0].Price)
+	}
+}
+
+func TestGetOrderFillsUseCase_Execute_OtherUsersOrder(t *testing.T) {
+	// Arrange
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	useCase := NewGetOrderFillsUseCase(orderRepo, &MockOrderFillRepository{fills: make(map[string][]domain.OrderFill)})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), order.ID(), "other-user")
+
+	// Assert
+	if err == nil || err.Error() != "order not found" {
+		t.Errorf("Expected order not found error, got %v", err)
+	}
+}
+
+func TestProcessOrderUseCase_Execute_StoresExecutionFill(t *testing.T) {
+	// Arrange
+	price := 150.00
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	marketData := &MockMarketDataClient{
+		GetCurrentPriceFunc: func(ctx context.Context, symbol string) (float64, error) {
+			return 149.50, nil
+		},
+	}
+	fillRepo := &MockOrderFillRepository{fills: make(map[string][]domain.OrderFill)}
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  orderRepo,
+		MarketDataClient: marketData,
+		EventPublisher:   &MockEventPublisher{},
+		FillRepository:   fillRepo,
+	})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), &ProcessOrderCommand{
+		OrderID: order.ID(),
+		Context: ProcessingContext{WorkerID: "worker-1", ProcessingID: "processing-1"},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	fills := fillRepo.fills[order.ID()]
+	if len(fills) != 1 {
+		t.Fatalf("Expected 1 stored fill, got %d", len(fills))
+	}
+	if fills[0].Quantity != 100 || fills[0].Price != 149.50 {
+		t.Errorf("Expected 100 @ 149.50, got %.0f @ %.2f", fills[0].Quantity, fills[0].Price)
+	}
+}
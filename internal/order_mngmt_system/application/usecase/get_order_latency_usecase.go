@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type IGetOrderLatencyUseCase interface {
+	Execute(ctx context.Context, orderID, userID string) (*domain.OrderLatencyTimeline, error)
+}
+
+type GetOrderLatencyUseCase struct {
+	orderRepository repository.IOrderRepository
+	latencyTracker  service.OrderLatencyTracker
+}
+
+func NewGetOrderLatencyUseCase(
+	orderRepository repository.IOrderRepository,
+	latencyTracker service.OrderLatencyTracker,
+) IGetOrderLatencyUseCase {
+	return &GetOrderLatencyUseCase{
+		orderRepository: orderRepository,
+		latencyTracker:  latencyTracker,
+	}
+}
+
+// Execute retrieves the submission stage timestamps of one of the user's orders
+func (uc *GetOrderLatencyUseCase) Execute(ctx context.Context, orderID, userID string) (*domain.OrderLatencyTimeline, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if order == nil || order.UserID() != userID {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	timeline, exists := uc.latencyTracker.Timeline(orderID)
+	if !exists {
+		return nil, fmt.Errorf("latency timeline not found for order %s", orderID)
+	}
+
+	return timeline, nil
+}
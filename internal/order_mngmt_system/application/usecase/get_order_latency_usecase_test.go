@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+func TestGetOrderLatencyUseCase_Execute_RecordsSubmissionAndProcessing(t *testing.T) {
+	// Arrange
+	var savedOrder *domain.Order
+	orderRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			savedOrder = order
+			return nil
+		},
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return savedOrder, nil
+		},
+	}
+	tracker := service.NewOrderLatencyTrackerWithDefaults()
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    orderRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		LatencyTracker:     tracker,
+	})
+	processUseCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  orderRepo,
+		MarketDataClient: &MockMarketDataClient{},
+		LatencyTracker:   tracker,
+	})
+	latencyUseCase := NewGetOrderLatencyUseCase(orderRepo, tracker)
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	}
+
+	// Act
+	result, err := submitUseCase.Execute(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The outcome of processing does not matter, only that the worker finished with the order
+	_, _ = processUseCase.Execute(context.Background(), &ProcessOrderCommand{
+		OrderID: result.OrderID,
+		Context: ProcessingContext{WorkerID: "worker-1", ProcessingID: "processing-1"},
+	})
+	timeline, err := latencyUseCase.Execute(context.Background(), result.OrderID, "user123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedStages := []domain.OrderLatencyStage{
+		domain.OrderLatencyStageSubmitted,
+		domain.OrderLatencyStageValidated,
+		domain.OrderLatencyStagePriced,
+		domain.OrderLatencyStageProcessed,
+	}
+	if len(timeline.Stages) != len(expectedStages) {
+		t.Fatalf("Expected stages %v, got %+v", expectedStages, timeline.Stages)
+	}
+	for i, stage := range expectedStages {
+		if timeline.Stages[i].Stage != stage {
+			t.Errorf("Expected stage %d to be %s, got %s", i, stage, timeline.Stages[i].Stage)
+		}
+		if i > 0 && timeline.Stages[i].At.Before(timeline.Stages[i-1].At) {
+			t.Errorf("Expected %s not to be recorded before %s", stage, timeline.Stages[i-1].Stage)
+		}
+	}
+
+	for _, histogram := range tracker.Histograms() {
+		if histogram.Stage == service.OrderLatencyTotal && histogram.Count != 1 {
+			t.Errorf("Expected the total latency histogram to observe 1 order, got %d", histogram.Count)
+		}
+	}
+}
+
+func TestGetOrderLatencyUseCase_Execute_OtherUsersOrderNotFound(t *testing.T) {
+	// Arrange
+	order, _ := domain.NewOrder("owner", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	orderRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	useCase := NewGetOrderLatencyUseCase(orderRepo, service.NewOrderLatencyTrackerWithDefaults())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), order.ID(), "someone-else")
+
+	// Assert
+	if err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
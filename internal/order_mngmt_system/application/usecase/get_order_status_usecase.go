@@ -21,26 +21,37 @@ type GetOrderStatusUseCase struct {
 }
 
 type OrderStatusResult struct {
-	OrderID                 string     `json:"order_id"`
-	UserID                  string     `json:"user_id"`
-	Symbol                  string     `json:"symbol"`
-	OrderSide               string     `json:"order_side"`
-	OrderType               string     `json:"order_type"`
-	Quantity                float64    `json:"quantity"`
-	Price                   *float64   `json:"price,omitempty"`
-	Status                  string     `json:"status"`
-	CreatedAt               time.Time  `json:"created_at"`
-	UpdatedAt               time.Time  `json:"updated_at"`
-	ExecutedAt              *time.Time `json:"executed_at,omitempty"`
-	ExecutionPrice          *float64   `json:"execution_price,omitempty"`
-	MarketPriceAtSubmission *float64   `json:"market_price_at_submission,omitempty"`
-	CurrentMarketPrice      *float64   `json:"current_market_price,omitempty"`
-	PriceChange             *float64   `json:"price_change,omitempty"`
-	PriceChangePercent      *float64   `json:"price_change_percent,omitempty"`
-	EstimatedValue          *float64   `json:"estimated_value,omitempty"`
-	StatusDescription       string     `json:"status_description"`
-	CanCancel               bool       `json:"can_cancel"`
-	MarketDataTimestamp     *time.Time `json:"market_data_timestamp,omitempty"`
+	OrderID                   string     `json:"order_id"`
+	UserID                    string     `json:"user_id"`
+	Symbol                    string     `json:"symbol"`
+	OrderSide                 string     `json:"order_side"`
+	OrderType                 string     `json:"order_type"`
+	Quantity                  float64    `json:"quantity"`
+	Price                     *float64   `json:"price,omitempty"`
+	Status                    string     `json:"status"`
+	CreatedAt                 time.Time  `json:"created_at"`
+	UpdatedAt                 time.Time  `json:"updated_at"`
+	ExecutedAt                *time.Time `json:"executed_at,omitempty"`
+	ExecutionPrice            *float64   `json:"execution_price,omitempty"`
+	MarketPriceAtSubmission   *float64   `json:"market_price_at_submission,omitempty"`
+	CurrentMarketPrice        *float64   `json:"current_market_price,omitempty"`
+	PriceChange               *float64   `json:"price_change,omitempty"`
+	PriceChangePercent        *float64   `json:"price_change_percent,omitempty"`
+	EstimatedValue            *float64   `json:"estimated_value,omitempty"`
+	StatusDescription         string     `json:"status_description"`
+	CanCancel                 bool       `json:"can_cancel"`
+	MarketDataTimestamp       *time.Time `json:"market_data_timestamp,omitempty"`
+	CancellationReason        string     `json:"cancellation_reason,omitempty"`
+	TriggerPrice              *float64   `json:"trigger_price,omitempty"`
+	TriggeredAt               *time.Time `json:"triggered_at,omitempty"`
+	SettlementAccount         string     `json:"settlement_account,omitempty"`
+	SettlementInstructionCode string     `json:"settlement_instruction_code,omitempty"`
+	PegReference              string     `json:"peg_reference,omitempty"`
+	PegOffset                 *float64   `json:"peg_offset,omitempty"`
+	PegMinPrice               *float64   `json:"peg_min_price,omitempty"`
+	PegMaxPrice               *float64   `json:"peg_max_price,omitempty"`
+	RepricedAt                *time.Time `json:"repriced_at,omitempty"`
+	ParentOrderID             string     `json:"parent_order_id,omitempty"`
 }
 
 type OrderHistoryOptions struct {
@@ -213,6 +224,23 @@ func (uc *GetOrderStatusUseCase) buildOrderStatusResult(order *domain.Order, mar
 		StatusDescription:       uc.getStatusDescription(order),
 		CanCancel:               order.CanCancel(),
 		MarketDataTimestamp:     order.MarketDataTimestamp(),
+		CancellationReason:      order.CancellationReason().String(),
+		TriggerPrice:            order.TriggerPrice(),
+		TriggeredAt:             order.TriggeredAt(),
+		ParentOrderID:           order.ParentOrderID(),
+	}
+
+	if instruction := order.SettlementInstruction(); instruction != nil {
+		result.SettlementAccount = instruction.Account
+		result.SettlementInstructionCode = instruction.Code.String()
+	}
+
+	if peg := order.PegInstruction(); peg != nil {
+		result.PegReference = peg.Reference.String()
+		result.PegOffset = &peg.Offset
+		result.PegMinPrice = peg.MinPrice
+		result.PegMaxPrice = peg.MaxPrice
+		result.RepricedAt = order.RepricedAt()
 	}
 
 	if marketData == nil {
@@ -252,6 +280,9 @@ func (uc *GetOrderStatusUseCase) getStatusDescription(order *domain.Order) strin
 	case domain.OrderStatusFailed:
 		return "Order execution failed"
 	case domain.OrderStatusCancelled:
+		if order.CancellationReason() != "" {
+			return fmt.Sprintf("Order has been cancelled (reason: %s)", order.CancellationReason())
+		}
 		return "Order has been cancelled"
 	default:
 		return "Unknown order status"
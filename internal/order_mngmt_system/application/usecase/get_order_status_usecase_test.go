@@ -179,6 +179,7 @@ func TestGetOrderStatusUseCase_Execute_ExecutedOrder(t *testing.T) {
 			price := 150.00
 			order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
 			// Simulate executed order
+			order.MarkAsProcessing()
 			order.MarkAsExecuted(149.50)
 			return order, nil
 		},
@@ -271,3 +272,29 @@ func TestGetOrderStatusUseCase_Execute_EmptyUserID(t *testing.T) {
 		t.Error("Expected nil result for empty user ID")
 	}
 }
+
+func TestGetOrderStatusUseCase_Execute_CancelledOrderShowsReason(t *testing.T) {
+	// Arrange
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	_ = order.MarkAsCancelledWithReason(domain.CancellationReasonRiskHalt)
+	mockRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+	}
+	useCase := NewGetOrderStatusUseCase(mockRepo, &MockMarketDataClient{})
+
+	// Act
+	result, err := useCase.Execute(context.Background(), order.ID(), "user123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.CancellationReason != "RISK_HALT" {
+		t.Errorf("Expected cancellation reason RISK_HALT, got %q", result.CancellationReason)
+	}
+	if !contains(result.StatusDescription, "RISK_HALT") {
+		t.Errorf("Expected the status description to mention the reason, got %q", result.StatusDescription)
+	}
+}
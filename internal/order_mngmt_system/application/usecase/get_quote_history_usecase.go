@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// IHistoricalPriceSource reads a symbol's historical prices (dependency inversion).
+// Sources that also implement service.IBatchHistoricalPriceProvider are queried once per request.
+type IHistoricalPriceSource interface {
+	GetHistoricalPrices(symbol string, period time.Duration) ([]service.HistoricalPrice, error)
+}
+
+type IGetQuoteHistoryUseCase interface {
+	Execute(ctx context.Context, cmd *command.GetQuoteHistoryCommand) (*QuoteHistoryResult, error)
+}
+
+// QuoteHistorySeries is the bar series of one symbol
+type QuoteHistorySeries struct {
+	Symbol string
+	Bars   []service.QuoteBar
+}
+
+// QuoteHistoryResult holds the series of every symbol found, in request order. Symbols without
+// history are listed as missing instead of failing the whole request.
+type QuoteHistoryResult struct {
+	Interval       service.QuoteInterval
+	Range          service.QuoteRange
+	Series         []QuoteHistorySeries
+	MissingSymbols []string
+}
+
+// QuoteHistoryConfig holds configuration for batch quote history requests
+type QuoteHistoryConfig struct {
+	MaxSymbols       int                   // Symbols accepted in one request
+	MaxBarsPerSeries int                   // Upper bound on range / interval, so fine intervals need short ranges
+	DefaultInterval  service.QuoteInterval // Used when the request names no interval
+	DefaultRange     service.QuoteRange    // Used when the request names no range
+}
+
+// DefaultQuoteHistoryConfig returns the default batch quote history configuration
+func DefaultQuoteHistoryConfig() QuoteHistoryConfig {
+	return QuoteHistoryConfig{
+		MaxSymbols:       20,                        // Enough for a comparison chart
+		MaxBarsPerSeries: 2500,                      // Fits 1m bars over a day, 1h over three months, 1d over five years
+		DefaultInterval:  service.QuoteInterval1Day, // Daily bars
+		DefaultRange:     service.QuoteRange1Month,  // Over the last month
+	}
+}
+
+// GetQuoteHistoryUseCase returns historical bar series for several symbols at once
+type GetQuoteHistoryUseCase struct {
+	priceSource IHistoricalPriceSource
+	config      QuoteHistoryConfig
+}
+
+func NewGetQuoteHistoryUseCase(priceSource IHistoricalPriceSource, config QuoteHistoryConfig) IGetQuoteHistoryUseCase {
+	return &GetQuoteHistoryUseCase{
+		priceSource: priceSource,
+		config:      config,
+	}
+}
+
+func NewGetQuoteHistoryUseCaseWithDefaults(priceSource IHistoricalPriceSource) IGetQuoteHistoryUseCase {
+	return NewGetQuoteHistoryUseCase(priceSource, DefaultQuoteHistoryConfig())
+}
+
+// Execute fetches the history of every requested symbol, in one batched call when the source
+// supports it, and groups it into bars of the requested interval
+func (uc *GetQuoteHistoryUseCase) Execute(ctx context.Context, cmd *command.GetQuoteHistoryCommand) (*QuoteHistoryResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	symbols := normalizeQuoteSymbols(cmd.Symbols)
+	if uc.config.MaxSymbols > 0 && len(symbols) > uc.config.MaxSymbols {
+		return nil, fmt.Errorf("invalid command: at most %d symbols can be requested at once", uc.config.MaxSymbols)
+	}
+
+	interval, quoteRange, err := uc.resolveIntervalAndRange(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	histories, err := uc.fetchHistories(ctx, symbols, quoteRange.Duration())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QuoteHistoryResult{
+		Interval:       interval,
+		Range:          quoteRange,
+		Series:         make([]QuoteHistorySeries, 0, len(symbols)),
+		MissingSymbols: make([]string, 0),
+	}
+
+	for _, symbol := range symbols {
+		prices := histories[symbol]
+		if len(prices) == 0 {
+			result.MissingSymbols = append(result.MissingSymbols, symbol)
+			continue
+		}
+
+		result.Series = append(result.Series, QuoteHistorySeries{
+			Symbol: symbol,
+			Bars:   service.AggregateQuoteBars(prices, interval),
+		})
+	}
+
+	return result, nil
+}
+
+func (uc *GetQuoteHistoryUseCase) resolveIntervalAndRange(cmd *command.GetQuoteHistoryCommand) (service.QuoteInterval, service.QuoteRange, error) {
+	interval := uc.config.DefaultInterval
+	if cmd.Interval != "" {
+		parsed, err := service.ParseQuoteInterval(cmd.Interval)
+		if err != nil {
+			return "", "", err
+		}
+		interval = parsed
+	}
+
+	quoteRange := uc.config.DefaultRange
+	if cmd.Range != "" {
+		parsed, err := service.ParseQuoteRange(cmd.Range)
+		if err != nil {
+			return "", "", err
+		}
+		quoteRange = parsed
+	}
+
+	if interval.Duration() > quoteRange.Duration() {
+		return "", "", fmt.Errorf("interval %s is longer than range %s", interval, quoteRange)
+	}
+
+	bars := int(quoteRange.Duration() / interval.Duration())
+	if uc.config.MaxBarsPerSeries > 0 && bars > uc.config.MaxBarsPerSeries {
+		return "", "", fmt.Errorf("interval %s is too fine for range %s: %d bars exceeds the limit of %d",
+			interval, quoteRange, bars, uc.config.MaxBarsPerSeries)
+	}
+
+	return interval, quoteRange, nil
+}
+
+// fetchHistories uses a single batched fetch when the source supports it. Without one, each symbol
+// is fetched on its own and a failing symbol is reported as missing rather than failing the request.
+func (uc *GetQuoteHistoryUseCase) fetchHistories(ctx context.Context, symbols []string, period time.Duration) (map[string][]service.HistoricalPrice, error) {
+	if batchSource, ok := uc.priceSource.(service.IBatchHistoricalPriceProvider); ok {
+		histories, err := batchSource.GetBatchHistoricalPrices(symbols, period)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get historical prices: %w", err)
+		}
+		return histories, nil
+	}
+
+	histories := make(map[string][]service.HistoricalPrice, len(symbols))
+	for _, symbol := range symbols {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		prices, err := uc.priceSource.GetHistoricalPrices(symbol, period)
+		if err != nil {
+			log.Printf("Warning: Failed to get historical prices for %s: %v", symbol, err)
+			continue
+		}
+		histories[symbol] = prices
+	}
+
+	return histories, nil
+}
+
+// normalizeQuoteSymbols upper-cases the symbols and drops repeats, keeping request order
+func normalizeQuoteSymbols(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		normalized = append(normalized, symbol)
+	}
+	return normalized
+}
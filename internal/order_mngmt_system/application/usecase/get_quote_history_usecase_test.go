@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// StubHistoricalPriceSource serves fixed histories, one call per symbol
+type StubHistoricalPriceSource struct {
+	Histories map[string][]service.HistoricalPrice
+	Failing   map[string]bool
+	Calls     []string
+	Periods   []time.Duration
+}
+
+func (s *StubHistoricalPriceSource) GetHistoricalPrices(symbol string, period time.Duration) ([]service.HistoricalPrice, error) {
+	s.Calls = append(s.Calls, symbol)
+	s.Periods = append(s.Periods, period)
+	if s.Failing[symbol] {
+		return nil, errors.New("history unavailable")
+	}
+	return s.Histories[symbol], nil
+}
+
+// StubBatchHistoricalPriceSource also serves every symbol in one batched call
+type StubBatchHistoricalPriceSource struct {
+	StubHistoricalPriceSource
+	BatchCalls [][]string
+}
+
+func (s *StubBatchHistoricalPriceSource) GetBatchHistoricalPrices(symbols []string, period time.Duration) (map[string][]service.HistoricalPrice, error) {
+	s.BatchCalls = append(s.BatchCalls, symbols)
+	histories := make(map[string][]service.HistoricalPrice)
+	for _, symbol := range symbols {
+		if prices, exists := s.Histories[symbol]; exists {
+			histories[symbol] = prices
+		}
+	}
+	return histories, nil
+}
+
+func quoteHistoryTestPrices(symbol string, start time.Time, prices ...float64) []service.HistoricalPrice {
+	history := make([]service.HistoricalPrice, 0, len(prices))
+	for i, price := range prices {
+		history = append(history, service.HistoricalPrice{
+			Symbol:    symbol,
+			Price:     price,
+			Volume:    100,
+			Timestamp: start.Add(time.Duration(i) * 20 * time.Minute),
+		})
+	}
+	return history
+}
+
+func TestGetQuoteHistoryUseCase_Execute_BatchesMultipleSymbols(t *testing.T) {
+	start := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	source := &StubBatchHistoricalPriceSource{StubHistoricalPriceSource: StubHistoricalPriceSource{
+		Histories: map[string][]service.HistoricalPrice{
+			"PETR4": quoteHistoryTestPrices("PETR4", start, 30.0, 31.0, 29.5),
+			"VALE3": quoteHistoryTestPrices("VALE3", start, 60.0, 61.0),
+		},
+	}}
+	useCase := NewGetQuoteHistoryUseCaseWithDefaults(source)
+
+	result, err := useCase.Execute(context.Background(), &command.GetQuoteHistoryCommand{
+		Symbols: []string{"petr4", "VALE3", "PETR4"},
+		Range:   "5d",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, source.BatchCalls, 1)
+	assert.Equal(t, []string{"PETR4", "VALE3"}, source.BatchCalls[0])
+	assert.Empty(t, source.Calls)
+	assert.Equal(t, service.QuoteInterval1Day, result.Interval)
+	assert.Equal(t, service.QuoteRange5Days, result.Range)
+
+	require.Len(t, result.Series, 2)
+	assert.Equal(t, "PETR4", result.Series[0].Symbol)
+	assert.Equal(t, []service.QuoteBar{{
+		Timestamp: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Open:      30.0,
+		High:      31.0,
+		Low:       29.5,
+		Close:     29.5,
+		Volume:    300,
+	}}, result.Series[0].Bars)
+	assert.Equal(t, "VALE3", result.Series[1].Symbol)
+	assert.Empty(t, result.MissingSymbols)
+}
+
+func TestGetQuoteHistoryUseCase_Execute_GroupsBarsByInterval(t *testing.T) {
+	start := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	source := &StubHistoricalPriceSource{Histories: map[string][]service.HistoricalPrice{
+		// Prices at 13:00, 13:20, 13:40, 14:00 and 14:20
+		"PETR4": quoteHistoryTestPrices("PETR4", start, 30.0, 31.0, 29.5, 30.5, 32.0),
+	}}
+	useCase := NewGetQuoteHistoryUseCaseWithDefaults(source)
+
+	result, err := useCase.Execute(context.Background(), &command.GetQuoteHistoryCommand{
+		Symbols:  []string{"PETR4"},
+		Interval: "1h",
+		Range:    "1d",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{24 * time.Hour}, source.Periods)
+	require.Len(t, result.Series, 1)
+	bars := result.Series[0].Bars
+	require.Len(t, bars, 2)
+	assert.Equal(t, service.QuoteBar{Timestamp: start, Open: 30.0, High: 31.0, Low: 29.5, Close: 29.5, Volume: 300}, bars[0])
+	assert.Equal(t, service.QuoteBar{Timestamp: start.Add(time.Hour), Open: 30.5, High: 32.0, Low: 30.5, Close: 32.0, Volume: 200}, bars[1])
+}
+
+func TestGetQuoteHistoryUseCase_Execute_ValidatesIntervalAndRange(t *testing.T) {
+	useCase := NewGetQuoteHistoryUseCaseWithDefaults(&StubHistoricalPriceSource{})
+
+	tests := []struct {
+		name       string
+		interval   string
+		quoteRange string
+		wantErr    bool
+	}{
+		{"unknown interval", "2m", "1d", true},
+		{"unknown range", "1h", "2w", true},
+		{"one bar covering the range", "1d", "1d", false},
+		{"too many bars", "1m", "1y", true},
+		{"fine interval over a short range", "1m", "1d", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Execute(context.Background(), &command.GetQuoteHistoryCommand{
+				Symbols:  []string{"PETR4"},
+				Interval: tt.interval,
+				Range:    tt.quoteRange,
+			})
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid")
+		})
+	}
+}
+
+func TestGetQuoteHistoryUseCase_Execute_ReturnsPartialResultForMissingSymbols(t *testing.T) {
+	start := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	source := &StubHistoricalPriceSource{
+		Histories: map[string][]service.HistoricalPrice{
+			"PETR4": quoteHistoryTestPrices("PETR4", start, 30.0),
+		},
+		Failing: map[string]bool{"ITUB4": true},
+	}
+	useCase := NewGetQuoteHistoryUseCaseWithDefaults(source)
+
+	result, err := useCase.Execute(context.Background(), &command.GetQuoteHistoryCommand{
+		Symbols: []string{"PETR4", "XXXX9", "ITUB4"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PETR4", "XXXX9", "ITUB4"}, source.Calls)
+	require.Len(t, result.Series, 1)
+	assert.Equal(t, "PETR4", result.Series[0].Symbol)
+	assert.Equal(t, []string{"XXXX9", "ITUB4"}, result.MissingSymbols)
+}
+
+func TestGetQuoteHistoryUseCase_Execute_LimitsSymbolCount(t *testing.T) {
+	config := DefaultQuoteHistoryConfig()
+	config.MaxSymbols = 2
+	useCase := NewGetQuoteHistoryUseCase(&StubHistoricalPriceSource{}, config)
+
+	_, err := useCase.Execute(context.Background(), &command.GetQuoteHistoryCommand{
+		Symbols: []string{"PETR4", "VALE3", "ITUB4"},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 2 symbols")
+}
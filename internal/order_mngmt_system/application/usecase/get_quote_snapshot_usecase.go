@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type IGetQuoteSnapshotUseCase interface {
+	Execute(ctx context.Context, cmd *command.GetQuoteSnapshotCommand) (*QuoteSnapshotResult, error)
+}
+
+// QuoteSnapshotEntry is the latest broadcast quote of one symbol. Stale quotes have not been
+// refreshed by the stream within the configured age, e.g. because the symbol stopped trading.
+type QuoteSnapshotEntry struct {
+	service.QuoteSnapshot
+	Stale bool
+}
+
+// QuoteSnapshotResult holds the cached quote of every symbol found, in request order. Symbols
+// never broadcast are listed as missing instead of failing the whole request.
+type QuoteSnapshotResult struct {
+	Quotes         []QuoteSnapshotEntry
+	MissingSymbols []string
+	AsOf           time.Time
+}
+
+// QuoteSnapshotConfig holds configuration for quote snapshot requests
+type QuoteSnapshotConfig struct {
+	MaxSymbols  int           // Symbols accepted in one request
+	StaleAfter  time.Duration // Age after which a cached quote is flagged stale (0 never flags)
+	BatchWindow time.Duration // Time one copy of the cache serves all requests (0 reads the cache on every request)
+}
+
+// DefaultQuoteSnapshotConfig returns the default quote snapshot configuration
+func DefaultQuoteSnapshotConfig() QuoteSnapshotConfig {
+	return QuoteSnapshotConfig{
+		MaxSymbols: 100,              // A full watchlist in one call
+		StaleAfter: 30 * time.Second, // Several missed stream ticks
+	}
+}
+
+// GetQuoteSnapshotUseCase serves the latest quotes from the cache the quote stream fills, so
+// clients polling over REST see the same values as WebSocket subscribers. With a batch window,
+// requests within the window share one copy of the cache, so concurrent pollers do not each walk
+// it and all of them see the same quotes and AsOf.
+type GetQuoteSnapshotUseCase struct {
+	snapshots service.QuoteSnapshotCache
+	config    QuoteSnapshotConfig
+	now       func() time.Time
+
+	batchMu      sync.Mutex
+	batch        map[string]service.QuoteSnapshot
+	batchTakenAt time.Time
+}
+
+func NewGetQuoteSnapshotUseCase(snapshots service.QuoteSnapshotCache, config QuoteSnapshotConfig) IGetQuoteSnapshotUseCase {
+	return &GetQuoteSnapshotUseCase{
+		snapshots: snapshots,
+		config:    config,
+		now:       time.Now,
+	}
+}
+
+func NewGetQuoteSnapshotUseCaseWithDefaults(snapshots service.QuoteSnapshotCache) IGetQuoteSnapshotUseCase {
+	return NewGetQuoteSnapshotUseCase(snapshots, DefaultQuoteSnapshotConfig())
+}
+
+// Execute reads the cached quote of every requested symbol. It never calls the pricing client,
+// so a snapshot cannot disagree with what the stream last sent.
+func (uc *GetQuoteSnapshotUseCase) Execute(ctx context.Context, cmd *command.GetQuoteSnapshotCommand) (*QuoteSnapshotResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	symbols := normalizeQuoteSymbols(cmd.Symbols)
+	if uc.config.MaxSymbols > 0 && len(symbols) > uc.config.MaxSymbols {
+		return nil, fmt.Errorf("invalid command: at most %d symbols can be requested at once", uc.config.MaxSymbols)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	lookup, now := uc.snapshotView()
+	result := &QuoteSnapshotResult{
+		Quotes:         make([]QuoteSnapshotEntry, 0, len(symbols)),
+		MissingSymbols: make([]string, 0),
+		AsOf:           now,
+	}
+
+	for _, symbol := range symbols {
+		snapshot, found := lookup(symbol)
+		if !found {
+			result.MissingSymbols = append(result.MissingSymbols, symbol)
+			continue
+		}
+
+		result.Quotes = append(result.Quotes, QuoteSnapshotEntry{
+			QuoteSnapshot: snapshot,
+			Stale:         uc.config.StaleAfter > 0 && now.Sub(snapshot.Timestamp) > uc.config.StaleAfter,
+		})
+	}
+
+	return result, nil
+}
+
+// snapshotView returns how to look up a symbol's quote and the time the quotes are as of. Without
+// a batch window it reads the live cache; otherwise it serves the batch taken within the window,
+// taking a new one once the window has passed. Requests arriving while a batch is being taken
+// wait for it instead of copying the cache themselves.
+func (uc *GetQuoteSnapshotUseCase) snapshotView() (func(symbol string) (service.QuoteSnapshot, bool), time.Time) {
+	now := uc.now()
+	if uc.config.BatchWindow <= 0 {
+		return uc.snapshots.Get, now
+	}
+
+	uc.batchMu.Lock()
+	defer uc.batchMu.Unlock()
+
+	if uc.batch == nil || now.Sub(uc.batchTakenAt) >= uc.config.BatchWindow {
+		uc.batch = uc.snapshots.All()
+		uc.batchTakenAt = now
+	}
+
+	batch := uc.batch
+	return func(symbol string) (service.QuoteSnapshot, bool) {
+		snapshot, found := batch[symbol]
+		return snapshot, found
+	}, uc.batchTakenAt
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// StubStreamQuoteClient serves the quote set for each symbol, without book data
+type StubStreamQuoteClient struct {
+	Prices map[string]*service.MarketPrice
+}
+
+func (s *StubStreamQuoteClient) GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error) {
+	return s.Prices[symbol], nil
+}
+
+func (s *StubStreamQuoteClient) GetOrderBookData(symbol string) (*service.OrderBookData, error) {
+	return nil, errors.New("order book unavailable")
+}
+
+func (s *StubStreamQuoteClient) GetMarketDepth(symbol string) (*service.MarketDepth, error) {
+	return nil, errors.New("market depth unavailable")
+}
+
+// CapturingQuoteBroadcaster keeps the last message broadcast per symbol
+type CapturingQuoteBroadcaster struct {
+	Latest map[string]messaging.QuoteStreamMessage
+}
+
+func (c *CapturingQuoteBroadcaster) BroadcastMessage(messageType int, data []byte) error {
+	var message messaging.QuoteStreamMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	c.Latest[message.Symbol] = message
+	return nil
+}
+
+func TestGetQuoteSnapshotUseCase_MatchesLatestBroadcast(t *testing.T) {
+	// Arrange
+	start := time.Now()
+	client := &StubStreamQuoteClient{Prices: map[string]*service.MarketPrice{
+		"PETR4": {Symbol: "PETR4", BidPrice: 30.00, AskPrice: 30.10, LastPrice: 30.05, Spread: 0.10, Timestamp: start},
+		"VALE3": {Symbol: "VALE3", BidPrice: 60.00, AskPrice: 60.20, LastPrice: 60.10, Spread: 0.20, Timestamp: start},
+	}}
+	subscribers := &CapturingQuoteBroadcaster{Latest: make(map[string]messaging.QuoteStreamMessage)}
+	cache := service.NewQuoteSnapshotCacheWithDefaults()
+	stream := messaging.NewQuoteStreamBroadcasterWithSnapshotCache(client, service.NewOrderBookPressureServiceWithDefaults(),
+		subscribers, nil, nil, nil, cache)
+	useCase := NewGetQuoteSnapshotUseCaseWithDefaults(cache)
+
+	require.NoError(t, stream.BroadcastQuote(context.Background(), "PETR4"))
+	require.NoError(t, stream.BroadcastQuote(context.Background(), "VALE3"))
+	client.Prices["PETR4"] = &service.MarketPrice{Symbol: "PETR4", BidPrice: 30.20, AskPrice: 30.25, LastPrice: 30.22, Spread: 0.05, Timestamp: start.Add(time.Second)}
+	require.NoError(t, stream.BroadcastQuote(context.Background(), "PETR4"))
+
+	// Act
+	result, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"vale3", "PETR4", "XXXX9"}})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Quotes, 2)
+	assert.Equal(t, []string{"XXXX9"}, result.MissingSymbols)
+	for i, symbol := range []string{"VALE3", "PETR4"} {
+		broadcast := subscribers.Latest[symbol]
+		quote := result.Quotes[i]
+		assert.Equal(t, symbol, quote.Symbol)
+		assert.Equal(t, broadcast.BidPrice, quote.BidPrice)
+		assert.Equal(t, broadcast.AskPrice, quote.AskPrice)
+		assert.Equal(t, broadcast.LastPrice, quote.LastPrice)
+		assert.Equal(t, broadcast.Spread, quote.Spread)
+		assert.Equal(t, broadcast.Halted, quote.Halted)
+		assert.True(t, broadcast.Timestamp.Equal(quote.Timestamp))
+		assert.False(t, quote.Stale)
+	}
+	assert.Equal(t, 30.22, result.Quotes[1].LastPrice)
+}
+
+func TestGetQuoteSnapshotUseCase_FlagsStaleQuotes(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	cache := service.NewQuoteSnapshotCacheWithDefaults()
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", LastPrice: 30.00, Timestamp: now.Add(-time.Minute)})
+	cache.Record(service.QuoteSnapshot{Symbol: "VALE3", LastPrice: 60.00, Timestamp: now.Add(-5 * time.Second)})
+	useCase := &GetQuoteSnapshotUseCase{
+		snapshots: cache,
+		config:    DefaultQuoteSnapshotConfig(),
+		now:       func() time.Time { return now },
+	}
+
+	// Act
+	result, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4", "VALE3"}})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Quotes, 2)
+	assert.True(t, result.Quotes[0].Stale)
+	assert.False(t, result.Quotes[1].Stale)
+	assert.Equal(t, now, result.AsOf)
+}
+
+func TestGetQuoteSnapshotUseCase_RejectsInvalidRequests(t *testing.T) {
+	useCase := NewGetQuoteSnapshotUseCase(service.NewQuoteSnapshotCacheWithDefaults(), QuoteSnapshotConfig{MaxSymbols: 2})
+
+	_, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{})
+	assert.ErrorContains(t, err, "invalid command")
+
+	_, err = useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4", "VALE3", "ITUB4"}})
+	assert.ErrorContains(t, err, "at most 2 symbols")
+}
+
+// CountingQuoteSnapshotCache counts single-symbol reads and copies of the whole cache
+type CountingQuoteSnapshotCache struct {
+	service.QuoteSnapshotCache
+	Reads  atomic.Int64
+	Copies atomic.Int64
+}
+
+func (c *CountingQuoteSnapshotCache) Get(symbol string) (service.QuoteSnapshot, bool) {
+	c.Reads.Add(1)
+	return c.QuoteSnapshotCache.Get(symbol)
+}
+
+func (c *CountingQuoteSnapshotCache) All() map[string]service.QuoteSnapshot {
+	c.Copies.Add(1)
+	return c.QuoteSnapshotCache.All()
+}
+
+func TestGetQuoteSnapshotUseCase_BatchWindowServesOneConsistentSnapshot(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	cache := &CountingQuoteSnapshotCache{QuoteSnapshotCache: service.NewQuoteSnapshotCacheWithDefaults()}
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", LastPrice: 30.00, Timestamp: now})
+	cache.Record(service.QuoteSnapshot{Symbol: "VALE3", LastPrice: 60.00, Timestamp: now})
+	config := DefaultQuoteSnapshotConfig()
+	config.BatchWindow = time.Second
+	useCase := &GetQuoteSnapshotUseCase{
+		snapshots: cache,
+		config:    config,
+		now:       func() time.Time { return now },
+	}
+
+	// Act
+	first, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4", "VALE3"}})
+	require.NoError(t, err)
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", LastPrice: 31.00, Timestamp: now.Add(100 * time.Millisecond)})
+	now = now.Add(500 * time.Millisecond)
+	second, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4"}})
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	third, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4"}})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first.Quotes[0], second.Quotes[0], "requests within the window see the same quotes")
+	assert.Equal(t, first.AsOf, second.AsOf)
+	assert.Equal(t, 31.00, third.Quotes[0].LastPrice, "the next window picks up newer quotes")
+	assert.Equal(t, now, third.AsOf)
+	assert.Equal(t, int64(2), cache.Copies.Load())
+}
+
+func TestGetQuoteSnapshotUseCase_WithoutBatchWindowReadsTheCache(t *testing.T) {
+	cache := &CountingQuoteSnapshotCache{QuoteSnapshotCache: service.NewQuoteSnapshotCacheWithDefaults()}
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", LastPrice: 30.00, Timestamp: time.Now()})
+	useCase := NewGetQuoteSnapshotUseCaseWithDefaults(cache)
+
+	_, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4"}})
+	require.NoError(t, err)
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", LastPrice: 31.00, Timestamp: time.Now().Add(time.Second)})
+	result, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 31.00, result.Quotes[0].LastPrice)
+	assert.Equal(t, int64(2), cache.Reads.Load())
+	assert.Zero(t, cache.Copies.Load())
+}
+
+func TestGetQuoteSnapshotUseCase_ConcurrentRequestsShareOneBatch(t *testing.T) {
+	cache := &CountingQuoteSnapshotCache{QuoteSnapshotCache: service.NewQuoteSnapshotCacheWithDefaults()}
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", LastPrice: 30.00, Timestamp: time.Now()})
+	useCase := NewGetQuoteSnapshotUseCase(cache, QuoteSnapshotConfig{MaxSymbols: 100, BatchWindow: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := useCase.Execute(context.Background(), &command.GetQuoteSnapshotCommand{Symbols: []string{"PETR4"}})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), cache.Copies.Load())
+	assert.Zero(t, cache.Reads.Load())
+}
+
+func benchmarkConcurrentQuoteSnapshots(b *testing.B, batchWindow time.Duration) {
+	cache := service.NewQuoteSnapshotCacheWithDefaults()
+	symbols := make([]string, 0, 100)
+	for i := 0; i < 1000; i++ {
+		symbol := fmt.Sprintf("SYM%d", i)
+		cache.Record(service.QuoteSnapshot{Symbol: symbol, LastPrice: float64(i), Timestamp: time.Now()})
+		if i%10 == 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	counting := &CountingQuoteSnapshotCache{QuoteSnapshotCache: cache}
+	useCase := NewGetQuoteSnapshotUseCase(counting, QuoteSnapshotConfig{MaxSymbols: 100, BatchWindow: batchWindow})
+	cmd := &command.GetQuoteSnapshotCommand{Symbols: symbols}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = useCase.Execute(context.Background(), cmd)
+		}
+	})
+	b.ReportMetric(float64(counting.Reads.Load()+counting.Copies.Load())/float64(b.N), "cache-locks/op")
+}
+
+// Reading the cache per request does one locked lookup per symbol; a batch window replaces them
+// with a single copy per window shared by every concurrent request
+func BenchmarkGetQuoteSnapshot_Concurrent(b *testing.B) {
+	b.Run("PerRequest", func(b *testing.B) { benchmarkConcurrentQuoteSnapshots(b, 0) })
+	b.Run("BatchWindow", func(b *testing.B) { benchmarkConcurrentQuoteSnapshots(b, 250*time.Millisecond) })
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+type IGetRejectedOrdersUseCase interface {
+	// Execute lists the user's rejected order submissions, most recent first
+	Execute(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error)
+	// SummarizeReasons aggregates all users' rejections in [from, to) by reason code for analytics
+	SummarizeReasons(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error)
+}
+
+type GetRejectedOrdersUseCase struct {
+	rejectedOrderRepository repository.IRejectedOrderRepository
+}
+
+func NewGetRejectedOrdersUseCase(rejectedOrderRepository repository.IRejectedOrderRepository) IGetRejectedOrdersUseCase {
+	return &GetRejectedOrdersUseCase{
+		rejectedOrderRepository: rejectedOrderRepository,
+	}
+}
+
+// Execute lists the user's rejected order submissions, most recent first
+func (uc *GetRejectedOrdersUseCase) Execute(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	rejections, err := uc.rejectedOrderRepository.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rejected orders: %w", err)
+	}
+
+	return rejections, nil
+}
+
+// SummarizeReasons aggregates all users' rejections in [from, to) by reason code for analytics
+func (uc *GetRejectedOrdersUseCase) SummarizeReasons(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	counts, err := uc.rejectedOrderRepository.CountByReason(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize rejection reasons: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockRejectedOrderRepository struct {
+	rejections []*domain.RejectedOrder
+}
+
+func (m *MockRejectedOrderRepository) Save(ctx context.Context, rejection *domain.RejectedOrder) error {
+	m.rejections = append(m.rejections, rejection)
+	return nil
+}
+
+func (m *MockRejectedOrderRepository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error) {
+	var found []*domain.RejectedOrder
+	for i := len(m.rejections) - 1; i >= 0; i-- {
+		if m.rejections[i].UserID == userID {
+			found = append(found, m.rejections[i])
+		}
+	}
+	if offset >= len(found) {
+		return []*domain.RejectedOrder{}, nil
+	}
+	found = found[offset:]
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+func (m *MockRejectedOrderRepository) CountByReason(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error) {
+	counts := make(map[domain.RejectReason]int)
+	var order []domain.RejectReason
+	for _, rejection := range m.rejections {
+		if rejection.RejectedAt.Before(from) || !rejection.RejectedAt.Before(to) {
+			continue
+		}
+		if counts[rejection.ReasonCode] == 0 {
+			order = append(order, rejection.ReasonCode)
+		}
+		counts[rejection.ReasonCode]++
+	}
+
+	result := make([]domain.RejectReasonCount, 0, len(order))
+	for _, reason := range order {
+		result = append(result, domain.RejectReasonCount{ReasonCode: reason, Count: counts[reason]})
+	}
+	return result, nil
+}
+
+func TestSubmitOrderUseCase_Execute_PersistsRejectedOrder(t *testing.T) {
+	// Arrange
+	rejectedRepo := &MockRejectedOrderRepository{}
+	mockMarketData := &MockMarketDataClient{
+		IsMarketOpenFunc: func(ctx context.Context, symbol string) (bool, error) {
+			return false, nil
+		},
+	}
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    &MockOrderRepository{},
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: &MockIdempotencyService{},
+		RejectedOrders:     rejectedRepo,
+	})
+	listUseCase := NewGetRejectedOrdersUseCase(rejectedRepo)
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	}
+
+	// Act
+	_, submitErr := submitUseCase.Execute(context.Background(), cmd)
+	rejections, listErr := listUseCase.Execute(context.Background(), "user123", 20, 0)
+
+	// Assert
+	if submitErr == nil {
+		t.Fatal("Expected the order to be rejected while the market is closed")
+	}
+	if listErr != nil {
+		t.Fatalf("Expected no error listing rejected orders, got %v", listErr)
+	}
+	if len(rejections) != 1 {
+		t.Fatalf("Expected 1 rejected order, got %d", len(rejections))
+	}
+
+	rejection := rejections[0]
+	if rejection.ReasonCode != domain.RejectReasonMarketClosed {
+		t.Errorf("Expected reason %s, got %s", domain.RejectReasonMarketClosed, rejection.ReasonCode)
+	}
+	if rejection.ReasonDetails != submitErr.Error() {
+		t.Errorf("Expected reason details %q, got %q", submitErr.Error(), rejection.ReasonDetails)
+	}
+	if rejection.Symbol != "AAPL" || rejection.Quantity != 100.0 || rejection.Price == nil || *rejection.Price != 150.00 {
+		t.Errorf("Expected the rejected order to keep the submitted order, got %+v", rejection)
+	}
+
+	others, _ := listUseCase.Execute(context.Background(), "other-user", 20, 0)
+	if len(others) != 0 {
+		t.Errorf("Expected no rejected orders for another user, got %d", len(others))
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_AcceptedOrderIsNotRecordedAsRejected(t *testing.T) {
+	// Arrange
+	rejectedRepo := &MockRejectedOrderRepository{}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    &MockOrderRepository{},
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		RejectedOrders:     rejectedRepo,
+	})
+
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "MARKET",
+		OrderSide: "BUY",
+		Quantity:  10.0,
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rejectedRepo.rejections) != 0 {
+		t.Errorf("Expected no rejected orders, got %d", len(rejectedRepo.rejections))
+	}
+}
+
+func TestGetRejectedOrdersUseCase_SummarizeReasons(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	rejectedRepo := &MockRejectedOrderRepository{rejections: []*domain.RejectedOrder{
+		{UserID: "1", ReasonCode: domain.RejectReasonMarketClosed, RejectedAt: now.Add(-time.Hour)},
+		{UserID: "2", ReasonCode: domain.RejectReasonMarketClosed, RejectedAt: now.Add(-30 * time.Minute)},
+		{UserID: "1", ReasonCode: domain.RejectReasonPriceOutOfRange, RejectedAt: now.Add(-10 * time.Minute)},
+		{UserID: "3", ReasonCode: domain.RejectReasonVolatilityHalt, RejectedAt: now.Add(-48 * time.Hour)},
+	}}
+	useCase := NewGetRejectedOrdersUseCase(rejectedRepo)
+
+	// Act
+	counts, err := useCase.SummarizeReasons(context.Background(), now.Add(-24*time.Hour), now)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("Expected 2 reasons in range, got %d", len(counts))
+	}
+	if counts[0].ReasonCode != domain.RejectReasonMarketClosed || counts[0].Count != 2 {
+		t.Errorf("Expected 2 MARKET_CLOSED rejections first, got %+v", counts[0])
+	}
+
+	if _, err := useCase.SummarizeReasons(context.Background(), now, now.Add(-time.Hour)); err == nil {
+		t.Error("Expected an error for an inverted range")
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+// IPartialCancelOrderUseCase defines the interface for reducing an order's quantity
+type IPartialCancelOrderUseCase interface {
+	Execute(ctx context.Context, cmd *command.PartialCancelOrderCommand) (*command.PartialCancelOrderResult, error)
+}
+
+// PartialCancelOrderUseCase shrinks a resting order down to, at most, its filled quantity.
+// Unlike a replace, the order keeps its ID, price and queue position.
+type PartialCancelOrderUseCase struct {
+	orderRepository repository.IOrderRepository
+}
+
+// NewPartialCancelOrderUseCase creates a new partial cancel order use case
+func NewPartialCancelOrderUseCase(
+	orderRepository repository.IOrderRepository,
+) IPartialCancelOrderUseCase {
+	return &PartialCancelOrderUseCase{
+		orderRepository: orderRepository,
+	}
+}
+
+// Execute processes the partial cancellation request
+func (uc *PartialCancelOrderUseCase) Execute(ctx context.Context, cmd *command.PartialCancelOrderCommand) (*command.PartialCancelOrderResult, error) {
+	// Step 1: Validate command
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid partial cancellation command: %w", err)
+	}
+
+	// Step 2: Retrieve order from database
+	order, err := uc.orderRepository.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if order == nil {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	// Step 3: Verify order belongs to the user
+	if order.UserID() != cmd.UserID {
+		return nil, fmt.Errorf("order not found") // Don't reveal that order exists for security
+	}
+
+	// Step 4: Reduce the quantity
+	previousQuantity := order.Quantity()
+	if err := order.ReduceQuantity(cmd.NewQuantity); err != nil {
+		return nil, fmt.Errorf("order quantity cannot be reduced: %w", err)
+	}
+
+	// Step 5: Persist the reduced order
+	if err := uc.orderRepository.Save(ctx, order); err != nil {
+		return nil, fmt.Errorf("failed to save reduced order: %w", err)
+	}
+
+	message := fmt.Sprintf("Order %s reduced from %.8f to %.8f", order.ID(), previousQuantity, order.Quantity())
+	if order.IsCancelled() {
+		message = fmt.Sprintf("Order %s has no open quantity left and has been cancelled", order.ID())
+	}
+
+	return &command.PartialCancelOrderResult{
+		OrderID:          order.ID(),
+		Status:           string(order.Status()),
+		PreviousQuantity: previousQuantity,
+		NewQuantity:      order.Quantity(),
+		FilledQuantity:   order.FilledQuantity(),
+		Message:          message,
+		Timestamp:        time.Now().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+func newPartiallyFilledOrderRepo(t *testing.T, quantity, filled float64) (*MockOrderRepository, *domain.Order, *int) {
+	price := 150.00
+	order, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, quantity, &price)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if filled > 0 {
+		if err := order.RecordFill(filled); err != nil {
+			t.Fatalf("Failed to record fill: %v", err)
+		}
+	}
+
+	saveCalls := 0
+	mockRepo := &MockOrderRepository{
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return order, nil
+		},
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saveCalls++
+			return nil
+		},
+	}
+	return mockRepo, order, &saveCalls
+}
+
+func TestPartialCancelOrderUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name             string
+		newQuantity      float64
+		expectError      string
+		expectedStatus   string
+		expectedQuantity float64
+	}{
+		{
+			name:             "valid reduction",
+			newQuantity:      60,
+			expectedStatus:   "PENDING",
+			expectedQuantity: 60,
+		},
+		{
+			name:             "reduction to exactly filled cancels",
+			newQuantity:      30,
+			expectedStatus:   "CANCELLED",
+			expectedQuantity: 30,
+		},
+		{
+			name:        "reduction below filled is rejected",
+			newQuantity: 10,
+			expectError: "cannot be below filled quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, order, saveCalls := newPartiallyFilledOrderRepo(t, 100, 30)
+			useCase := NewPartialCancelOrderUseCase(mockRepo)
+
+			result, err := useCase.Execute(context.Background(), &command.PartialCancelOrderCommand{
+				OrderID:     order.ID(),
+				UserID:      "user123",
+				NewQuantity: tt.newQuantity,
+			})
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				if *saveCalls != 0 {
+					t.Errorf("Expected order not to be saved, got %d saves", *saveCalls)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, result.Status)
+			}
+			if result.NewQuantity != tt.expectedQuantity {
+				t.Errorf("Expected new quantity %.2f, got %.2f", tt.expectedQuantity, result.NewQuantity)
+			}
+			if result.PreviousQuantity != 100 {
+				t.Errorf("Expected previous quantity 100, got %.2f", result.PreviousQuantity)
+			}
+			if *saveCalls != 1 {
+				t.Errorf("Expected order to be saved once, got %d saves", *saveCalls)
+			}
+			if order.IsCancelled() && order.CancellationReason() != domain.CancellationReasonUserRequested {
+				t.Errorf("Expected reason USER_REQUESTED, got %s", order.CancellationReason())
+			}
+		})
+	}
+}
+
+func TestPartialCancelOrderUseCase_Execute_WrongUser(t *testing.T) {
+	mockRepo, order, _ := newPartiallyFilledOrderRepo(t, 100, 0)
+	useCase := NewPartialCancelOrderUseCase(mockRepo)
+
+	_, err := useCase.Execute(context.Background(), &command.PartialCancelOrderCommand{
+		OrderID:     order.ID(),
+		UserID:      "otheruser",
+		NewQuantity: 50,
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+	if order.Quantity() != 100 {
+		t.Errorf("Expected quantity to be unchanged, got %.2f", order.Quantity())
+	}
+}
@@ -3,12 +3,16 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
 	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
 	"HubInvestments/internal/order_mngmt_system/infra/external"
 	"HubInvestments/internal/order_mngmt_system/infra/messaging"
+	"HubInvestments/internal/order_mngmt_system/infra/notification"
+	"HubInvestments/internal/order_mngmt_system/infra/webhook"
 )
 
 type IProcessOrderUseCase interface {
@@ -43,9 +47,17 @@ type ProcessOrderResult struct {
 }
 
 type ProcessOrderUseCase struct {
-	orderRepository  repository.IOrderRepository
-	marketDataClient external.IMarketDataClient
-	eventPublisher   messaging.IEventPublisher
+	orderRepository   repository.IOrderRepository
+	marketDataClient  external.IMarketDataClient
+	eventPublisher    messaging.IEventPublisher
+	webhookDispatcher webhook.IOrderWebhookDispatcher
+
+	executionQualityService    service.ExecutionQualityService
+	executionQualityRepository repository.IExecutionQualityRepository
+	latencyTracker             service.OrderLatencyTracker
+	fillRepository             repository.IOrderFillRepository
+	notifier                   notification.IOrderNotificationDispatcher
+	triggerBook                service.IfTouchedTriggerBook
 }
 
 type ProcessOrderUseCaseConfig struct {
@@ -55,15 +67,43 @@ type ProcessOrderUseCaseConfig struct {
 	PriceTolerancePercent float64
 }
 
-func NewProcessOrderUseCase(
-	orderRepository repository.IOrderRepository,
-	marketDataClient external.IMarketDataClient,
-	eventPublisher messaging.IEventPublisher,
-) IProcessOrderUseCase {
+// ProcessOrderDependencies holds the collaborators of ProcessOrderUseCase. The repository, market data
+// client and event publisher are required; every other dependency is optional and leaving it nil
+// disables the feature it backs.
+type ProcessOrderDependencies struct {
+	OrderRepository  repository.IOrderRepository
+	MarketDataClient external.IMarketDataClient
+	EventPublisher   messaging.IEventPublisher
+
+	// WebhookDispatcher notifies external integrations of executed orders
+	WebhookDispatcher webhook.IOrderWebhookDispatcher
+	// ExecutionQualityService and ExecutionQualityRepository record how each fill compared with the
+	// market price the order was submitted against
+	ExecutionQualityService    service.ExecutionQualityService
+	ExecutionQualityRepository repository.IExecutionQualityRepository
+	// LatencyTracker timestamps when workers finish each order, completing its submit-to-process latency
+	LatencyTracker service.OrderLatencyTracker
+	// FillRepository stores the individual fills of each executed order, so order history can show what composed it
+	FillRepository repository.IOrderFillRepository
+	// Notifier tells users about their filled orders, as their notification preferences allow
+	Notifier notification.IOrderNotificationDispatcher
+	// TriggerBook arms the protective stops of filled buys, so a quote reaching the stop price sends
+	// them for processing. Without it protective stops stay resting.
+	TriggerBook service.IfTouchedTriggerBook
+}
+
+func NewProcessOrderUseCase(deps ProcessOrderDependencies) IProcessOrderUseCase {
 	return &ProcessOrderUseCase{
-		orderRepository:  orderRepository,
-		marketDataClient: marketDataClient,
-		eventPublisher:   eventPublisher,
+		orderRepository:            deps.OrderRepository,
+		marketDataClient:           deps.MarketDataClient,
+		eventPublisher:             deps.EventPublisher,
+		webhookDispatcher:          deps.WebhookDispatcher,
+		executionQualityService:    deps.ExecutionQualityService,
+		executionQualityRepository: deps.ExecutionQualityRepository,
+		latencyTracker:             deps.LatencyTracker,
+		fillRepository:             deps.FillRepository,
+		notifier:                   deps.Notifier,
+		triggerBook:                deps.TriggerBook,
 	}
 }
 
@@ -77,6 +117,13 @@ func (uc *ProcessOrderUseCase) Execute(ctx context.Context, command *ProcessOrde
 		ProcessingID: command.Context.ProcessingID,
 	}
 
+	// Processing ends the order's submission latency whether it executed or failed
+	if uc.latencyTracker != nil {
+		defer func() {
+			uc.latencyTracker.RecordStage(command.OrderID, domain.OrderLatencyStageProcessed, time.Now())
+		}()
+	}
+
 	order, err := uc.orderRepository.FindByID(ctx, command.OrderID)
 	if err != nil {
 		result.ErrorMessage = fmt.Sprintf("failed to find order %s: %v", command.OrderID, err)
@@ -98,6 +145,14 @@ func (uc *ProcessOrderUseCase) Execute(ctx context.Context, command *ProcessOrde
 		return result, fmt.Errorf("order validation failed: %w", err)
 	}
 
+	// A protective stop rests until its parent fills; processing it earlier leaves it pending
+	if err := uc.checkProtectiveStopArmed(ctx, order); err != nil {
+		result.FinalStatus = string(order.Status())
+		result.ErrorMessage = err.Error()
+		result.ProcessingTime = time.Since(startTime)
+		return result, err
+	}
+
 	if err := uc.markOrderAsProcessing(ctx, order); err != nil {
 		result.ErrorMessage = fmt.Sprintf("failed to mark order as processing: %v", err)
 		result.ProcessingTime = time.Since(startTime)
@@ -235,9 +290,17 @@ func (uc *ProcessOrderUseCase) validateMarketConditions(ctx context.Context, ord
 }
 
 func (uc *ProcessOrderUseCase) calculateExecutionPrice(ctx context.Context, order *domain.Order, marketData *OrderExecutionContext) (float64, error) {
-	switch order.OrderType() {
+	if !order.IsActivated() {
+		return 0, fmt.Errorf("%s order not triggered: its trigger price has not been touched", order.OrderType())
+	}
+
+	// Triggered if-touched orders execute as the market or limit order they became
+	switch order.OrderType().ActivatesAs() {
 	case domain.OrderTypeMarket:
-		// Market orders execute at current market price
+		// Market orders execute at current market price, within the band when protected
+		if err := order.ValidateProtectionBand(marketData.CurrentPrice); err != nil {
+			return 0, err
+		}
 		return marketData.CurrentPrice, nil
 
 	case domain.OrderTypeLimit:
@@ -332,7 +395,13 @@ func (uc *ProcessOrderUseCase) executeOrder(ctx context.Context, order *domain.O
 	// 3. Position management systems
 	// 4. Accounting systems
 
-	// For now, we'll simulate the execution
+	// For now, we'll simulate the execution as a single fill of whatever is still open
+	if order.OpenQuantity() > 0 {
+		if _, err := order.RecordFillAt(order.OpenQuantity(), executionPrice, executionTime); err != nil {
+			return fmt.Errorf("failed to record order fill: %w", err)
+		}
+	}
+
 	if err := order.MarkAsExecuted(executionPrice); err != nil {
 		return fmt.Errorf("failed to mark order as executed: %w", err)
 	}
@@ -341,7 +410,8 @@ func (uc *ProcessOrderUseCase) executeOrder(ctx context.Context, order *domain.O
 }
 
 func (uc *ProcessOrderUseCase) markOrderAsExecuted(ctx context.Context, order *domain.Order, executionPrice float64, executionTime time.Time) error {
-	if err := uc.orderRepository.UpdateStatus(ctx, order.ID(), order.Status()); err != nil {
+	// The stored execution lets the worker's startup recovery rebuild the position update if publishing never happens
+	if err := uc.orderRepository.UpdateExecutionDetails(ctx, order.ID(), executionPrice, executionTime); err != nil {
 		return fmt.Errorf("failed to update order execution in database: %w", err)
 	}
 
@@ -365,9 +435,96 @@ func (uc *ProcessOrderUseCase) markOrderAsExecuted(ctx context.Context, order *d
 		return fmt.Errorf("failed to publish order executed event: %w", err)
 	}
 
+	if uc.webhookDispatcher != nil {
+		uc.webhookDispatcher.DispatchOrderEvent(ctx, webhook.OrderWebhookEventExecuted, order)
+	}
+	if uc.notifier != nil {
+		uc.notifier.NotifyOrderEvent(ctx, domain.OrderNotificationFilled, order)
+	}
+
+	uc.recordExecutionQuality(ctx, order)
+	uc.recordFills(ctx, order)
+	uc.armProtectiveStops(ctx, order)
+
+	return nil
+}
+
+// checkProtectiveStopArmed returns an error for a protective stop whose parent has not filled yet
+func (uc *ProcessOrderUseCase) checkProtectiveStopArmed(ctx context.Context, order *domain.Order) error {
+	if !order.IsProtectiveStop() {
+		return nil
+	}
+
+	parent, err := uc.orderRepository.FindByID(ctx, order.ParentOrderID())
+	if err != nil {
+		return fmt.Errorf("failed to find parent of protective stop %s: %w", order.ID(), err)
+	}
+	if parent == nil || parent.Status() != domain.OrderStatusExecuted {
+		return fmt.Errorf("protective stop %s rests until its parent order %s fills", order.ID(), order.ParentOrderID())
+	}
 	return nil
 }
 
+// armProtectiveStops hands the resting protective stops of a filled buy to the trigger book, which
+// sends each one for processing once a quote falls to its stop price. Failures are logged only; the
+// order has already been executed.
+func (uc *ProcessOrderUseCase) armProtectiveStops(ctx context.Context, order *domain.Order) {
+	if uc.triggerBook == nil || !order.IsBuyOrder() {
+		return
+	}
+
+	pending, err := uc.orderRepository.FindByUserIDAndStatus(ctx, order.UserID(), domain.OrderStatusPending)
+	if err != nil {
+		log.Printf("Failed to load protective stops of order %s: %v", order.ID(), err)
+		return
+	}
+
+	for _, stop := range pending {
+		if !stop.IsProtectiveStop() || stop.ParentOrderID() != order.ID() {
+			continue
+		}
+		if err := uc.triggerBook.WatchStop(stop); err != nil {
+			log.Printf("Failed to arm protective stop %s of order %s: %v", stop.ID(), order.ID(), err)
+		}
+	}
+}
+
+// recordFills stores the order's fills. Failures are logged only; the order has already been executed.
+func (uc *ProcessOrderUseCase) recordFills(ctx context.Context, order *domain.Order) {
+	if uc.fillRepository == nil {
+		return
+	}
+
+	for _, fill := range order.Fills() {
+		if err := uc.fillRepository.Save(ctx, &fill); err != nil {
+			log.Printf("Failed to save fill %s for order %s: %v", fill.ID, order.ID(), err)
+		}
+	}
+}
+
+// recordExecutionQuality stores the fill's slippage against the pre-trade estimate.
+// Failures are logged only; the order has already been executed.
+func (uc *ProcessOrderUseCase) recordExecutionQuality(ctx context.Context, order *domain.Order) {
+	if uc.executionQualityService == nil || uc.executionQualityRepository == nil {
+		return
+	}
+
+	estimatedFillPrice := order.MarketPriceAtSubmission()
+	if estimatedFillPrice == nil {
+		return
+	}
+
+	report, err := uc.executionQualityService.Evaluate(order, *estimatedFillPrice)
+	if err != nil {
+		log.Printf("Failed to evaluate execution quality for order %s: %v", order.ID(), err)
+		return
+	}
+
+	if err := uc.executionQualityRepository.Save(ctx, report); err != nil {
+		log.Printf("Failed to save execution quality for order %s: %v", order.ID(), err)
+	}
+}
+
 func (uc *ProcessOrderUseCase) markOrderAsFailed(ctx context.Context, order *domain.Order, errorMessage string) error {
 	if err := order.MarkAsFailed(); err != nil {
 		return fmt.Errorf("failed to mark order as failed: %w", err)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/infra/notification"
 )
 
 // MockEventPublisher implements IEventPublisher for testing
@@ -56,7 +57,11 @@ func TestProcessOrderUseCase_Execute_Success(t *testing.T) {
 	}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -109,7 +114,11 @@ func TestProcessOrderUseCase_Execute_OrderNotFound(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -147,6 +156,7 @@ func TestProcessOrderUseCase_Execute_OrderAlreadyExecuted(t *testing.T) {
 			price := 150.00
 			order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
 			// Mark order as already executed
+			order.MarkAsProcessing()
 			order.MarkAsExecuted(149.50)
 			return order, nil
 		},
@@ -154,7 +164,11 @@ func TestProcessOrderUseCase_Execute_OrderAlreadyExecuted(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -199,7 +213,11 @@ func TestProcessOrderUseCase_Execute_OrderCancelled(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -249,7 +267,11 @@ func TestProcessOrderUseCase_Execute_MarketDataError(t *testing.T) {
 	}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -299,7 +321,11 @@ func TestProcessOrderUseCase_Execute_RepositorySaveError(t *testing.T) {
 	}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -349,7 +375,11 @@ func TestProcessOrderUseCase_Execute_MarketOrder(t *testing.T) {
 	}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -410,7 +440,11 @@ func TestProcessOrderUseCase_Execute_LimitOrderPriceNotMet(t *testing.T) {
 	}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -462,7 +496,11 @@ func TestProcessOrderUseCase_Execute_SellLimitOrderPriceNotMet(t *testing.T) {
 	}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -499,7 +537,11 @@ func TestProcessOrderUseCase_Execute_EmptyOrderID(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 
 	mockEventPublisher := &MockEventPublisher{}
-	useCase := NewProcessOrderUseCase(mockRepo, mockMarketData, mockEventPublisher)
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  mockRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   mockEventPublisher,
+	})
 
 	ctx := context.Background()
 	cmd := &ProcessOrderCommand{
@@ -525,3 +567,111 @@ func TestProcessOrderUseCase_Execute_EmptyOrderID(t *testing.T) {
 		t.Error("Expected nil result for empty order ID")
 	}
 }
+
+// MockOrderNotificationPreferencesRepository implements IOrderNotificationPreferencesRepository for testing
+type MockOrderNotificationPreferencesRepository struct {
+	preferences map[string]*domain.OrderNotificationPreferences
+}
+
+func (m *MockOrderNotificationPreferencesRepository) Save(ctx context.Context, preferences *domain.OrderNotificationPreferences) error {
+	m.preferences[preferences.UserID] = preferences
+	return nil
+}
+
+func (m *MockOrderNotificationPreferencesRepository) FindByUserID(ctx context.Context, userID string) (*domain.OrderNotificationPreferences, error) {
+	return m.preferences[userID], nil
+}
+
+// recordingNotificationSender records the notifications delivered through its channel
+type recordingNotificationSender struct {
+	sent []notification.OrderNotification
+}
+
+func (s *recordingNotificationSender) SendOrderNotification(ctx context.Context, n notification.OrderNotification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+// processOrderWithNotificationPreferences executes a limit buy for user123 holding the given preferences
+// and returns what the email channel received
+func processOrderWithNotificationPreferences(t *testing.T, preferences *domain.OrderNotificationPreferences) []notification.OrderNotification {
+	t.Helper()
+
+	orderRepo, orders := newInMemoryOrderRepository()
+	price := 150.00
+	order, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	orders[order.ID()] = order
+
+	email := &recordingNotificationSender{}
+	dispatcher := notification.NewOrderNotificationDispatcherWithDefaults(
+		&MockOrderNotificationPreferencesRepository{preferences: map[string]*domain.OrderNotificationPreferences{"user123": preferences}},
+		map[domain.NotificationChannel]notification.IOrderNotificationSender{domain.NotificationChannelEmail: email},
+	)
+	mockMarketData := &MockMarketDataClient{
+		GetCurrentPriceFunc: func(ctx context.Context, symbol string) (float64, error) {
+			return 149.50, nil
+		},
+	}
+	useCase := NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  orderRepo,
+		MarketDataClient: mockMarketData,
+		EventPublisher:   &MockEventPublisher{},
+		Notifier:         dispatcher,
+	})
+
+	result, err := useCase.Execute(context.Background(), &ProcessOrderCommand{
+		OrderID: order.ID(),
+		Context: ProcessingContext{WorkerID: "worker-1", ProcessingID: "proc-123", StartTime: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.FinalStatus != "EXECUTED" {
+		t.Fatalf("Expected FinalStatus EXECUTED, got %s", result.FinalStatus)
+	}
+
+	dispatcher.Wait()
+	return email.sent
+}
+
+func TestProcessOrderUseCase_Execute_FillNotificationSuppressedWhenOptedOut(t *testing.T) {
+	// Arrange
+	preferences := &domain.OrderNotificationPreferences{
+		UserID: "user123",
+		Channels: map[domain.OrderNotificationEvent]domain.NotificationChannel{
+			domain.OrderNotificationCancelled: domain.NotificationChannelEmail,
+		},
+	}
+
+	// Act
+	sent := processOrderWithNotificationPreferences(t, preferences)
+
+	// Assert
+	if len(sent) != 0 {
+		t.Errorf("Expected no notification for a user opted out of fills, got %d", len(sent))
+	}
+}
+
+func TestProcessOrderUseCase_Execute_FillNotificationSentWhenOptedIn(t *testing.T) {
+	// Arrange
+	preferences := &domain.OrderNotificationPreferences{
+		UserID: "user123",
+		Channels: map[domain.OrderNotificationEvent]domain.NotificationChannel{
+			domain.OrderNotificationFilled: domain.NotificationChannelEmail,
+		},
+	}
+
+	// Act
+	sent := processOrderWithNotificationPreferences(t, preferences)
+
+	// Assert
+	if len(sent) != 1 {
+		t.Fatalf("Expected 1 fill notification, got %d", len(sent))
+	}
+	if sent[0].Event != domain.OrderNotificationFilled || sent[0].Channel != domain.NotificationChannelEmail {
+		t.Errorf("Expected a FILLED notification via EMAIL, got %s via %s", sent[0].Event, sent[0].Channel)
+	}
+	if sent[0].ExecutionPrice == nil || *sent[0].ExecutionPrice != 149.50 {
+		t.Errorf("Expected execution price 149.50, got %v", sent[0].ExecutionPrice)
+	}
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/messaging"
+)
+
+// IRecoverPositionUpdatesUseCase republishes the position updates of executed orders that never
+// reached the position worker, e.g. because the order worker crashed between saving the execution
+// and publishing it
+type IRecoverPositionUpdatesUseCase interface {
+	Execute(ctx context.Context) (*PositionUpdateRecoveryResult, error)
+}
+
+// PositionUpdateRecoveryConfig holds configuration for the startup recovery of position updates
+type PositionUpdateRecoveryConfig struct {
+	Lookback time.Duration // Only orders executed this recently are checked (zero disables the recovery)
+}
+
+// DefaultPositionUpdateRecoveryConfig returns the default recovery configuration
+func DefaultPositionUpdateRecoveryConfig() PositionUpdateRecoveryConfig {
+	return PositionUpdateRecoveryConfig{
+		Lookback: 24 * time.Hour, // Covers a worker that stayed down for a whole trading day
+	}
+}
+
+// PositionUpdateRecoveryResult summarizes a recovery pass
+type PositionUpdateRecoveryResult struct {
+	OrdersChecked     int
+	RepublishedOrders []string
+	FailedOrders      []string
+	AlreadyPublished  int
+	MissingExecution  int // Executed orders without a stored execution price or time, which cannot be rebuilt
+}
+
+type RecoverPositionUpdatesUseCase struct {
+	orderRepository       repository.IOrderRepository
+	publicationRepository repository.IPositionUpdatePublicationRepository
+	eventPublisher        messaging.IEventPublisher
+	config                PositionUpdateRecoveryConfig
+}
+
+func NewRecoverPositionUpdatesUseCase(
+	orderRepository repository.IOrderRepository,
+	publicationRepository repository.IPositionUpdatePublicationRepository,
+	eventPublisher messaging.IEventPublisher,
+	config PositionUpdateRecoveryConfig,
+) IRecoverPositionUpdatesUseCase {
+	return &RecoverPositionUpdatesUseCase{
+		orderRepository:       orderRepository,
+		publicationRepository: publicationRepository,
+		eventPublisher:        eventPublisher,
+		config:                config,
+	}
+}
+
+func NewRecoverPositionUpdatesUseCaseWithDefaults(
+	orderRepository repository.IOrderRepository,
+	publicationRepository repository.IPositionUpdatePublicationRepository,
+	eventPublisher messaging.IEventPublisher,
+) IRecoverPositionUpdatesUseCase {
+	return NewRecoverPositionUpdatesUseCase(orderRepository, publicationRepository, eventPublisher, DefaultPositionUpdateRecoveryConfig())
+}
+
+// Execute republishes the execution of every recently executed order without a recorded publication
+// and records it, so running the recovery again skips the order. An order that fails to republish is
+// left unrecorded for the next pass.
+func (uc *RecoverPositionUpdatesUseCase) Execute(ctx context.Context) (*PositionUpdateRecoveryResult, error) {
+	result := &PositionUpdateRecoveryResult{
+		RepublishedOrders: make([]string, 0),
+		FailedOrders:      make([]string, 0),
+	}
+	if uc.config.Lookback <= 0 {
+		return result, nil
+	}
+
+	orders, err := uc.orderRepository.FindByStatus(ctx, domain.OrderStatusExecuted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find executed orders: %w", err)
+	}
+
+	cutoff := time.Now().Add(-uc.config.Lookback)
+	for _, order := range orders {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		executedAt, executionPrice := order.ExecutedAt(), order.ExecutionPrice()
+		if executedAt == nil || executionPrice == nil {
+			if order.UpdatedAt().After(cutoff) {
+				result.MissingExecution++
+			}
+			continue
+		}
+		if executedAt.Before(cutoff) {
+			continue
+		}
+		result.OrdersChecked++
+
+		published, err := uc.publicationRepository.IsPublished(ctx, order.ID())
+		if err != nil {
+			log.Printf("Failed to check position update publication for order %s: %v", order.ID(), err)
+			result.FailedOrders = append(result.FailedOrders, order.ID())
+			continue
+		}
+		if published {
+			result.AlreadyPublished++
+			continue
+		}
+
+		if err := uc.republish(ctx, order, *executionPrice, *executedAt); err != nil {
+			log.Printf("Failed to republish position update for order %s: %v", order.ID(), err)
+			result.FailedOrders = append(result.FailedOrders, order.ID())
+			continue
+		}
+		result.RepublishedOrders = append(result.RepublishedOrders, order.ID())
+	}
+
+	return result, nil
+}
+
+// republish publishes the order's execution as the worker would have and records the publication
+func (uc *RecoverPositionUpdatesUseCase) republish(ctx context.Context, order *domain.Order, executionPrice float64, executedAt time.Time) error {
+	event := domain.NewOrderExecutedEventWithDetails(
+		order.ID(),
+		order.UserID(),
+		order.Symbol(),
+		order.OrderSide(),
+		order.OrderType(),
+		order.Quantity(),
+		executionPrice,
+		executionPrice*order.Quantity(),
+		executedAt,
+		order.MarketPriceAtSubmission(),
+		order.MarketDataTimestamp(),
+	)
+
+	if err := uc.eventPublisher.PublishOrderExecutedEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish order executed event: %w", err)
+	}
+
+	// The publisher may already track publications; marking again is a no-op
+	if err := uc.publicationRepository.MarkPublished(ctx, order.ID(), time.Now()); err != nil {
+		return fmt.Errorf("failed to record position update publication: %w", err)
+	}
+
+	return nil
+}
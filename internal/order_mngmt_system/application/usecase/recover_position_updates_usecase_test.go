@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockPositionUpdatePublicationRepository struct {
+	published map[string]time.Time
+}
+
+func (m *MockPositionUpdatePublicationRepository) MarkPublished(ctx context.Context, orderID string, publishedAt time.Time) error {
+	if _, exists := m.published[orderID]; !exists {
+		m.published[orderID] = publishedAt
+	}
+	return nil
+}
+
+func (m *MockPositionUpdatePublicationRepository) IsPublished(ctx context.Context, orderID string) (bool, error) {
+	_, exists := m.published[orderID]
+	return exists, nil
+}
+
+func newExecutedOrder(id string, executedAt time.Time) *domain.Order {
+	price := 100.00
+	executionPrice := 101.50
+	return domain.NewOrderFromRepository(id, "user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price,
+		domain.OrderStatusExecuted, executedAt.Add(-time.Minute), executedAt, &executedAt, &executionPrice, nil, nil)
+}
+
+func newRecoveryOrderRepository(orders ...*domain.Order) *MockOrderRepository {
+	return &MockOrderRepository{
+		FindByStatusFunc: func(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+			if status != domain.OrderStatusExecuted {
+				return nil, nil
+			}
+			return orders, nil
+		},
+	}
+}
+
+func TestRecoverPositionUpdatesUseCase_Execute_RepublishesUnpublishedExecution(t *testing.T) {
+	// Arrange
+	executedAt := time.Now().Add(-10 * time.Minute)
+	unpublished := newExecutedOrder("order-unpublished", executedAt)
+	published := newExecutedOrder("order-published", executedAt)
+	publications := &MockPositionUpdatePublicationRepository{published: map[string]time.Time{
+		published.ID(): executedAt,
+	}}
+
+	var events []*domain.OrderExecutedEvent
+	publisher := &MockEventPublisher{
+		PublishOrderExecutedEventFunc: func(ctx context.Context, event *domain.OrderExecutedEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+	useCase := NewRecoverPositionUpdatesUseCaseWithDefaults(newRecoveryOrderRepository(unpublished, published), publications, publisher)
+
+	// Act
+	result, err := useCase.Execute(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected only the unpublished order to be republished, got %d events", len(events))
+	}
+	event := events[0]
+	if event.OrderID() != unpublished.ID() || event.Quantity != 10 || event.ExecutionPrice != 101.50 || event.TotalValue != 1015 {
+		t.Errorf("Expected the stored execution of %s, got order %s %.0f @ %.2f (total %.2f)",
+			unpublished.ID(), event.OrderID(), event.Quantity, event.ExecutionPrice, event.TotalValue)
+	}
+	if !event.ExecutedAt.Equal(executedAt) {
+		t.Errorf("Expected the original execution time %v, got %v", executedAt, event.ExecutedAt)
+	}
+	if len(result.RepublishedOrders) != 1 || result.RepublishedOrders[0] != unpublished.ID() {
+		t.Errorf("Expected %s to be reported as republished, got %v", unpublished.ID(), result.RepublishedOrders)
+	}
+	if result.AlreadyPublished != 1 || result.OrdersChecked != 2 {
+		t.Errorf("Expected 2 orders checked and 1 already published, got %d and %d", result.OrdersChecked, result.AlreadyPublished)
+	}
+	if published, _ := publications.IsPublished(context.Background(), unpublished.ID()); !published {
+		t.Error("Expected the republished order to be recorded as published")
+	}
+}
+
+func TestRecoverPositionUpdatesUseCase_Execute_SecondPassRepublishesNothing(t *testing.T) {
+	// Arrange
+	order := newExecutedOrder("order-1", time.Now().Add(-time.Minute))
+	publications := &MockPositionUpdatePublicationRepository{published: make(map[string]time.Time)}
+	publishCount := 0
+	publisher := &MockEventPublisher{
+		PublishOrderExecutedEventFunc: func(ctx context.Context, event *domain.OrderExecutedEvent) error {
+			publishCount++
+			return nil
+		},
+	}
+	useCase := NewRecoverPositionUpdatesUseCaseWithDefaults(newRecoveryOrderRepository(order), publications, publisher)
+
+	// Act
+	if _, err := useCase.Execute(context.Background()); err != nil {
+		t.Fatalf("Expected no error on the first pass, got %v", err)
+	}
+	result, err := useCase.Execute(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error on the second pass, got %v", err)
+	}
+	if publishCount != 1 {
+		t.Errorf("Expected the order to be published once across both passes, got %d", publishCount)
+	}
+	if len(result.RepublishedOrders) != 0 || result.AlreadyPublished != 1 {
+		t.Errorf("Expected the second pass to skip the order, got republished=%v already published=%d", result.RepublishedOrders, result.AlreadyPublished)
+	}
+}
+
+func TestRecoverPositionUpdatesUseCase_Execute_FailedPublishIsRetriedNextPass(t *testing.T) {
+	// Arrange
+	order := newExecutedOrder("order-1", time.Now().Add(-time.Minute))
+	publications := &MockPositionUpdatePublicationRepository{published: make(map[string]time.Time)}
+	publisher := &MockEventPublisher{
+		PublishOrderExecutedEventFunc: func(ctx context.Context, event *domain.OrderExecutedEvent) error {
+			return errors.New("broker unavailable")
+		},
+	}
+	useCase := NewRecoverPositionUpdatesUseCaseWithDefaults(newRecoveryOrderRepository(order), publications, publisher)
+
+	// Act
+	result, err := useCase.Execute(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected publish failures to be reported per order, got %v", err)
+	}
+	if len(result.FailedOrders) != 1 || result.FailedOrders[0] != order.ID() {
+		t.Errorf("Expected %s to be reported as failed, got %v", order.ID(), result.FailedOrders)
+	}
+	if published, _ := publications.IsPublished(context.Background(), order.ID()); published {
+		t.Error("Expected a failed republish to stay unrecorded so the next pass retries it")
+	}
+}
+
+func TestRecoverPositionUpdatesUseCase_Execute_IgnoresOrdersOutsideLookback(t *testing.T) {
+	// Arrange
+	old := newExecutedOrder("order-old", time.Now().Add(-48*time.Hour))
+	publications := &MockPositionUpdatePublicationRepository{published: make(map[string]time.Time)}
+	publishCount := 0
+	publisher := &MockEventPublisher{
+		PublishOrderExecutedEventFunc: func(ctx context.Context, event *domain.OrderExecutedEvent) error {
+			publishCount++
+			return nil
+		},
+	}
+	useCase := NewRecoverPositionUpdatesUseCase(newRecoveryOrderRepository(old), publications, publisher,
+		PositionUpdateRecoveryConfig{Lookback: 24 * time.Hour})
+
+	// Act
+	result, err := useCase.Execute(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if publishCount != 0 || result.OrdersChecked != 0 {
+		t.Errorf("Expected orders executed before the lookback to be ignored, got %d published of %d checked", publishCount, result.OrdersChecked)
+	}
+}
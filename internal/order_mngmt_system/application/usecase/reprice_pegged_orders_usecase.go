@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// IRepricePeggedOrdersUseCase reprices open pegged orders from realtime quotes
+type IRepricePeggedOrdersUseCase interface {
+	// Execute moves the symbol's pegged orders to their peg target at the quote and returns their IDs
+	Execute(ctx context.Context, symbol string, bid, ask float64, quotedAt time.Time) ([]string, error)
+	// Restore tracks every stored open pegged order again and returns how many it tracks
+	Restore(ctx context.Context) (int, error)
+}
+
+// RepricePeggedOrdersUseCase keeps pegged limit orders priced against the quote they follow
+type RepricePeggedOrdersUseCase struct {
+	orderRepository repository.IOrderRepository
+	pegBook         service.PeggedOrderBook
+}
+
+func NewRepricePeggedOrdersUseCase(
+	orderRepository repository.IOrderRepository,
+	pegBook service.PeggedOrderBook,
+) IRepricePeggedOrdersUseCase {
+	return &RepricePeggedOrdersUseCase{
+		orderRepository: orderRepository,
+		pegBook:         pegBook,
+	}
+}
+
+// Execute reprices the symbol's pegged orders whose target moved at the quote. Orders that were
+// filled or cancelled since they were tracked leave the book; an order that fails to save keeps its
+// old price in the book and is retried on the next quote.
+func (uc *RepricePeggedOrdersUseCase) Execute(ctx context.Context, symbol string, bid, ask float64, quotedAt time.Time) ([]string, error) {
+	repriced := make([]string, 0)
+
+	for _, repricing := range uc.pegBook.EvaluateQuote(symbol, bid, ask) {
+		order, err := uc.orderRepository.FindByID(ctx, repricing.OrderID)
+		if err != nil {
+			return repriced, fmt.Errorf("failed to load pegged order %s: %w", repricing.OrderID, err)
+		}
+		if order == nil || !order.IsPegged() || !order.CanExecute() {
+			uc.pegBook.Untrack(repricing.OrderID)
+			continue
+		}
+
+		changed, err := order.RepriceToPeg(bid, ask, uc.pegBook.MinRepriceChange(), quotedAt)
+		if err != nil {
+			return repriced, fmt.Errorf("failed to reprice pegged order %s: %w", order.ID(), err)
+		}
+		if changed {
+			if err := uc.orderRepository.Save(ctx, order); err != nil {
+				return repriced, fmt.Errorf("failed to save repriced order %s: %w", order.ID(), err)
+			}
+			repriced = append(repriced, order.ID())
+		}
+
+		// Refresh the book with the stored price so the same quote does not report the order again
+		if err := uc.pegBook.Track(order); err != nil {
+			return repriced, fmt.Errorf("failed to track repriced order %s: %w", order.ID(), err)
+		}
+	}
+
+	return repriced, nil
+}
+
+// Restore rebuilds the in-memory pegged order book from the open orders in the repository, so
+// pegged orders keep following the quote after a restart. Orders the book refuses are logged and
+// rest at their stored price.
+func (uc *RepricePeggedOrdersUseCase) Restore(ctx context.Context) (int, error) {
+	restored := 0
+	for _, status := range []domain.OrderStatus{domain.OrderStatusPending, domain.OrderStatusProcessing} {
+		orders, err := uc.orderRepository.FindByStatus(ctx, status)
+		if err != nil {
+			return restored, fmt.Errorf("failed to load %s orders: %w", status, err)
+		}
+
+		for _, order := range orders {
+			if !order.IsPegged() {
+				continue
+			}
+			if err := uc.pegBook.Track(order); err != nil {
+				log.Printf("Warning: Failed to restore pegged order %s: %v", order.ID(), err)
+				continue
+			}
+			restored++
+		}
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func submitPeggedOrder(t *testing.T, useCase ISubmitOrderUseCase, price float64, minPrice, maxPrice *float64) *command.SubmitOrderResult {
+	t.Helper()
+	offset := -0.05
+	result, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:       "user123",
+		Symbol:       "AAPL",
+		OrderType:    "LIMIT",
+		OrderSide:    "BUY",
+		Quantity:     10,
+		Price:        &price,
+		PegReference: "BID",
+		PegOffset:    &offset,
+		PegMinPrice:  minPrice,
+		PegMaxPrice:  maxPrice,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestRepricePeggedOrdersUseCase_BidPeggedOrderFollowsTheBidWithinBounds(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	pegBook := service.NewPeggedOrderBookWithDefaults()
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		PegBook:            pegBook,
+	})
+
+	minPrice, maxPrice := 149.00, 151.00
+	result := submitPeggedOrder(t, submitUseCase, 150.00, &minPrice, &maxPrice)
+	require.Equal(t, 1, pegBook.Tracking())
+
+	repriceUseCase := NewRepricePeggedOrdersUseCase(repo, pegBook)
+	quotedAt := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	// Arrange the bid path and the price each quote should leave the order at
+	steps := []struct {
+		bid      float64
+		expected float64
+		repriced bool
+	}{
+		{bid: 150.05, expected: 150.00, repriced: false}, // Target equals the resting price
+		{bid: 150.40, expected: 150.35, repriced: true},
+		{bid: 149.80, expected: 149.75, repriced: true},
+		{bid: 153.00, expected: 151.00, repriced: true}, // Clamped to the maximum
+		{bid: 154.00, expected: 151.00, repriced: false},
+		{bid: 140.00, expected: 149.00, repriced: true}, // Clamped to the minimum
+	}
+
+	for i, step := range steps {
+		// Act
+		repriced, err := repriceUseCase.Execute(context.Background(), "AAPL", step.bid, step.bid+0.10, quotedAt.Add(time.Duration(i)*time.Second))
+
+		// Assert
+		require.NoError(t, err)
+		if step.repriced {
+			assert.Equal(t, []string{result.OrderID}, repriced, "bid %.2f", step.bid)
+		} else {
+			assert.Empty(t, repriced, "bid %.2f", step.bid)
+		}
+		assert.InDelta(t, step.expected, *orders[result.OrderID].Price(), 1e-9, "bid %.2f", step.bid)
+	}
+	assert.Equal(t, quotedAt.Add(5*time.Second), *orders[result.OrderID].RepricedAt())
+}
+
+func TestRepricePeggedOrdersUseCase_DefaultBandAndClosedOrders(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	pegBook := service.NewPeggedOrderBook(service.PeggedOrderConfig{DefaultBandPercent: 1, MinRepriceChange: 0.01})
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		PegBook:            pegBook,
+	})
+	result := submitPeggedOrder(t, submitUseCase, 150.00, nil, nil)
+
+	peg := orders[result.OrderID].PegInstruction()
+	require.NotNil(t, peg)
+	assert.InDelta(t, 148.50, *peg.MinPrice, 1e-9)
+	assert.InDelta(t, 151.50, *peg.MaxPrice, 1e-9)
+
+	repriceUseCase := NewRepricePeggedOrdersUseCase(repo, pegBook)
+	_, err := repriceUseCase.Execute(context.Background(), "AAPL", 160.00, 160.10, time.Now())
+	require.NoError(t, err)
+	assert.InDelta(t, 151.50, *orders[result.OrderID].Price(), 1e-9)
+
+	// A cancelled order leaves the book on the next quote instead of being repriced
+	require.NoError(t, orders[result.OrderID].MarkAsCancelled())
+	repriced, err := repriceUseCase.Execute(context.Background(), "AAPL", 149.00, 149.10, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, repriced)
+	assert.Equal(t, 0, pegBook.Tracking())
+	assert.InDelta(t, 151.50, *orders[result.OrderID].Price(), 1e-9)
+}
+
+func TestSubmitOrderUseCase_RejectsPeggedOrdersWithoutPegBook(t *testing.T) {
+	repo, _ := newInMemoryOrderRepository()
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+	})
+
+	price := 150.00
+	_, err := submitUseCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "LIMIT", OrderSide: "BUY", Quantity: 10, Price: &price, PegReference: "BID",
+	})
+	assert.ErrorContains(t, err, "pegged orders are not supported")
+}
+
+func TestRepricePeggedOrdersUseCase_RestoreTracksOpenPeggedOrdersAfterRestart(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	repo.FindByStatusFunc = func(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+		open := make([]*domain.Order, 0)
+		for _, order := range orders {
+			if order.Status() == status {
+				open = append(open, order)
+			}
+		}
+		return open, nil
+	}
+	submitUseCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    repo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		PegBook:            service.NewPeggedOrderBookWithDefaults(),
+	})
+	result := submitPeggedOrder(t, submitUseCase, 150.00, nil, nil)
+
+	// A restart starts from an empty pegged order book
+	pegBook := service.NewPeggedOrderBookWithDefaults()
+	repriceUseCase := NewRepricePeggedOrdersUseCase(repo, pegBook)
+
+	restored, err := repriceUseCase.Restore(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+	assert.Equal(t, []string{"AAPL"}, pegBook.Symbols())
+
+	repriced, err := repriceUseCase.Execute(context.Background(), "AAPL", 150.40, 150.50, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{result.OrderID}, repriced)
+	assert.InDelta(t, 150.35, *orders[result.OrderID].Price(), 1e-9)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/external"
+)
+
+var simulatedPipelineSymbols = []string{"PETR4", "VALE3", "ITUB4", "BBDC4", "ABEV3"}
+
+// simulatedOrderPipeline runs an order through submission, validation, pricing, risk and worker
+// processing with every downstream replaced by the simulation clients
+type simulatedOrderPipeline struct {
+	submit     ISubmitOrderUseCase
+	process    IProcessOrderUseCase
+	validation service.OrderValidationService
+	pricing    service.OrderPricingService
+	risk       service.RiskManagementService
+
+	validationClient *external.SimulatedValidationDataClient
+	pricingClient    *external.SimulatedPricingDataClient
+	riskClient       *external.SimulatedRiskDataClient
+	positions        *external.SimulatedPositionClient
+
+	orders   map[string]*domain.Order
+	executed int
+}
+
+func newSimulatedOrderPipeline(config external.SimulationConfig) *simulatedOrderPipeline {
+	orderRepo, orders := newInMemoryOrderRepository()
+	marketData := external.NewSimulatedMarketDataClient(config)
+	pricingClient := external.NewSimulatedPricingDataClient(config)
+	pricing := service.NewOrderPricingServiceWithDefaults()
+
+	pipeline := &simulatedOrderPipeline{
+		validation:       service.NewOrderValidationServiceWithDefaults(),
+		pricing:          pricing,
+		risk:             service.NewRiskManagementServiceWithDefaults(),
+		validationClient: external.NewSimulatedValidationDataClient(config),
+		pricingClient:    pricingClient,
+		riskClient:       external.NewSimulatedRiskDataClient(config),
+		positions:        external.NewSimulatedPositionClient(config),
+		orders:           orders,
+	}
+	pipeline.submit = NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    orderRepo,
+		MarketDataClient:   marketData,
+		IdempotencyService: &MockIdempotencyService{},
+		PricingService:     pricing,
+		PricingClient:      pricingClient,
+	})
+	// Executed events are where the position worker picks orders up; counting them stands in for that path
+	pipeline.process = NewProcessOrderUseCase(ProcessOrderDependencies{
+		OrderRepository:  orderRepo,
+		MarketDataClient: marketData,
+		EventPublisher: &MockEventPublisher{
+			PublishOrderExecutedEventFunc: func(ctx context.Context, event *domain.OrderExecutedEvent) error {
+				pipeline.executed++
+				return nil
+			},
+		},
+	})
+	return pipeline
+}
+
+// run submits a limit buy at the simulated ask and processes it the way a worker would
+func (p *simulatedOrderPipeline) run(ctx context.Context, i int) (*ProcessOrderResult, error) {
+	symbol := simulatedPipelineSymbols[i%len(simulatedPipelineSymbols)]
+	quote, err := p.pricingClient.GetCurrentMarketPrice(symbol)
+	if err != nil {
+		return nil, err
+	}
+	price := quote.AskPrice
+
+	submitted, err := p.submit.Execute(ctx, &command.SubmitOrderCommand{
+		UserID:    fmt.Sprintf("load-user-%d", i%100),
+		Symbol:    symbol,
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  10,
+		Price:     &price,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("submit: %w", err)
+	}
+	order := p.orders[submitted.OrderID]
+
+	validation, err := p.validation.ValidateOrderWithContext(ctx, order, p.validationClient, p.positions)
+	if err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if !validation.IsValid {
+		return nil, fmt.Errorf("validation rejected the order: %v", validation.Errors)
+	}
+
+	if _, err := p.pricing.CalculateOptimalPrice(order, p.pricingClient); err != nil {
+		return nil, fmt.Errorf("pricing: %w", err)
+	}
+
+	assessment, err := p.risk.AssessOrderRisk(order, p.riskClient)
+	if err != nil {
+		return nil, fmt.Errorf("risk: %w", err)
+	}
+	if !assessment.IsApproved {
+		return nil, fmt.Errorf("risk rejected the order: %v", assessment.Warnings)
+	}
+
+	return p.process.Execute(ctx, &ProcessOrderCommand{
+		OrderID: submitted.OrderID,
+		Context: ProcessingContext{WorkerID: "simulation-worker", ProcessingID: fmt.Sprintf("simulation-%d", i)},
+	})
+}
+
+func TestSimulatedOrderPipeline_ExecutesOrdersDeterministically(t *testing.T) {
+	// Arrange
+	first := newSimulatedOrderPipeline(external.DefaultSimulationConfig())
+	second := newSimulatedOrderPipeline(external.DefaultSimulationConfig())
+
+	for i := 0; i < len(simulatedPipelineSymbols)*2; i++ {
+		// Act
+		firstResult, err := first.run(context.Background(), i)
+		if err != nil {
+			t.Fatalf("Run %d failed: %v", i, err)
+		}
+		secondResult, err := second.run(context.Background(), i)
+		if err != nil {
+			t.Fatalf("Run %d failed on the second pipeline: %v", i, err)
+		}
+
+		// Assert
+		if firstResult.FinalStatus != string(domain.OrderStatusExecuted) {
+			t.Fatalf("Expected run %d to execute, got %s: %s", i, firstResult.FinalStatus, firstResult.ErrorMessage)
+		}
+		if firstResult.ExecutionPrice == nil || secondResult.ExecutionPrice == nil || *firstResult.ExecutionPrice != *secondResult.ExecutionPrice {
+			t.Errorf("Expected run %d to execute at the same price in both pipelines, got %v and %v", i, firstResult.ExecutionPrice, secondResult.ExecutionPrice)
+		}
+	}
+
+	if first.executed != len(simulatedPipelineSymbols)*2 {
+		t.Errorf("Expected %d executed events, got %d", len(simulatedPipelineSymbols)*2, first.executed)
+	}
+}
+
+// BenchmarkSimulatedOrderPipeline measures end-to-end throughput of submission, validation,
+// pricing, risk and worker processing without real downstreams
+func BenchmarkSimulatedOrderPipeline(b *testing.B) {
+	pipeline := newSimulatedOrderPipeline(external.DefaultSimulationConfig())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := pipeline.run(ctx, i)
+		if err != nil {
+			b.Fatalf("Run %d failed: %v", i, err)
+		}
+		if result.FinalStatus != string(domain.OrderStatusExecuted) {
+			b.Fatalf("Run %d ended %s: %s", i, result.FinalStatus, result.ErrorMessage)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"HubInvestments/internal/order_mngmt_system/application/command"
@@ -11,19 +13,61 @@ import (
 	"HubInvestments/internal/order_mngmt_system/domain/service"
 	"HubInvestments/internal/order_mngmt_system/infra/external"
 	"HubInvestments/internal/order_mngmt_system/infra/messaging/rabbitmq"
+	"HubInvestments/internal/order_mngmt_system/infra/notification"
+	"HubInvestments/internal/order_mngmt_system/infra/webhook"
 )
 
 type ISubmitOrderUseCase interface {
 	Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error)
 }
 
+// IMarketContextSource provides the quote captured in an order's market context snapshot (dependency inversion)
+type IMarketContextSource interface {
+	GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error)
+}
+
 type SubmitOrderUseCase struct {
 	orderRepository    repository.IOrderRepository
 	marketDataClient   external.IMarketDataClient
 	idempotencyService service.IIdempotencyService
 	orderProducer      *rabbitmq.OrderProducer
+	webhookDispatcher  webhook.IOrderWebhookDispatcher
+	pricingService     service.OrderPricingService
+	pricingClient      service.IPricingDataClient
+	contextSource      IMarketContextSource
+	snapshotRepository repository.IMarketContextSnapshotRepository
+	volatilityHalts    service.VolatilityHaltService
+	rejectedOrders     repository.IRejectedOrderRepository
+	latencyTracker     service.OrderLatencyTracker
+	triggerBook        service.IfTouchedTriggerBook
+	pegBook            service.PeggedOrderBook
+	notifier           notification.IOrderNotificationDispatcher
+	fatFinger          service.FatFingerService
+	twapScheduler      service.TWAPExecutionScheduler
+
+	pipelineIdempotency *PipelineIdempotencyConfig
+}
+
+// PipelineIdempotencyConfig holds configuration for end-to-end submission idempotency
+type PipelineIdempotencyConfig struct {
+	StalePendingAfter time.Duration // A retry takes over a submission still pending after this long (0 never takes over)
+}
+
+// DefaultPipelineIdempotencyConfig returns the default pipeline idempotency configuration
+func DefaultPipelineIdempotencyConfig() PipelineIdempotencyConfig {
+	return PipelineIdempotencyConfig{
+		StalePendingAfter: 30 * time.Second, // A submission pending for 30s died before saving its order
+	}
 }
 
+// errOrderNotPublished marks a submission whose order was saved but not published; its
+// idempotency key is kept so a retry publishes the saved order instead of creating another
+var errOrderNotPublished = errors.New("order saved but not yet published for processing")
+
+// ErrFatFingerOrder is returned when an order is far out of line with the user's usual orders.
+// Resubmitting with the order acknowledged places it anyway.
+var ErrFatFingerOrder = errors.New("order is far out of line with your usual orders")
+
 type SubmitOrderUseCaseConfig struct {
 	ValidationTimeout     time.Duration
 	MarketDataTimeout     time.Duration
@@ -31,17 +75,65 @@ type SubmitOrderUseCaseConfig struct {
 	EnablePriceValidation bool
 }
 
-func NewSubmitOrderUseCase(
-	orderRepository repository.IOrderRepository,
-	marketDataClient external.IMarketDataClient,
-	idempotencyService service.IIdempotencyService,
-	orderProducer *rabbitmq.OrderProducer,
-) ISubmitOrderUseCase {
+// SubmitOrderDependencies holds the collaborators of SubmitOrderUseCase. The repository, market data
+// client and idempotency service are required; every other dependency is optional and leaving it nil
+// disables the feature it backs.
+type SubmitOrderDependencies struct {
+	OrderRepository    repository.IOrderRepository
+	MarketDataClient   external.IMarketDataClient
+	IdempotencyService service.IIdempotencyService
+	OrderProducer      *rabbitmq.OrderProducer // Without a producer orders are saved but not published
+
+	// WebhookDispatcher notifies external integrations of submitted orders
+	WebhookDispatcher webhook.IOrderWebhookDispatcher
+	// PricingService and PricingClient convert market orders in thin markets into protected market orders
+	PricingService service.OrderPricingService
+	PricingClient  service.IPricingDataClient
+	// ContextSource and SnapshotRepository record the market context (best bid/ask, spread, volatility)
+	// each order was submitted into
+	ContextSource      IMarketContextSource
+	SnapshotRepository repository.IMarketContextSnapshotRepository
+	// VolatilityHalts rejects new orders for symbols under a volatility halt
+	VolatilityHalts service.VolatilityHaltService
+	// RejectedOrders records rejected submissions with their reason for support and analytics
+	RejectedOrders repository.IRejectedOrderRepository
+	// LatencyTracker timestamps each accepted order as it is validated, priced and published
+	LatencyTracker service.OrderLatencyTracker
+	// TriggerBook holds if-touched orders until a quote touches their trigger price
+	TriggerBook service.IfTouchedTriggerBook
+	// PegBook tracks pegged limit orders so realtime quotes reprice them
+	PegBook service.PeggedOrderBook
+	// Notifier notifies users of their submitted and rejected orders, as their preferences allow
+	Notifier notification.IOrderNotificationDispatcher
+	// FatFinger rejects orders far out of line with the user's order history unless they are acknowledged
+	FatFinger service.FatFingerService
+	// TWAPScheduler paces orders submitted with the TWAP execution strategy as child slices over its window
+	TWAPScheduler service.TWAPExecutionScheduler
+	// PipelineIdempotency makes validate, persist and publish a single idempotent operation: a retried
+	// submission resumes the original one or returns its result, and never saves a second order
+	PipelineIdempotency *PipelineIdempotencyConfig
+}
+
+func NewSubmitOrderUseCase(deps SubmitOrderDependencies) ISubmitOrderUseCase {
 	return &SubmitOrderUseCase{
-		orderRepository:    orderRepository,
-		marketDataClient:   marketDataClient,
-		idempotencyService: idempotencyService,
-		orderProducer:      orderProducer,
+		orderRepository:     deps.OrderRepository,
+		marketDataClient:    deps.MarketDataClient,
+		idempotencyService:  deps.IdempotencyService,
+		orderProducer:       deps.OrderProducer,
+		webhookDispatcher:   deps.WebhookDispatcher,
+		pricingService:      deps.PricingService,
+		pricingClient:       deps.PricingClient,
+		contextSource:       deps.ContextSource,
+		snapshotRepository:  deps.SnapshotRepository,
+		volatilityHalts:     deps.VolatilityHalts,
+		rejectedOrders:      deps.RejectedOrders,
+		latencyTracker:      deps.LatencyTracker,
+		triggerBook:         deps.TriggerBook,
+		pegBook:             deps.PegBook,
+		notifier:            deps.Notifier,
+		fatFinger:           deps.FatFinger,
+		twapScheduler:       deps.TWAPScheduler,
+		pipelineIdempotency: deps.PipelineIdempotency,
 	}
 }
 
@@ -50,6 +142,11 @@ func (uc *SubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOr
 		return nil, fmt.Errorf("invalid command: %w", err)
 	}
 
+	// Checked ahead of idempotency, so the acknowledged resubmission is not replayed as the failed one
+	if err := uc.checkFatFinger(ctx, cmd); err != nil {
+		return nil, err
+	}
+
 	idempotencyKey := uc.idempotencyService.GenerateKey(
 		cmd.UserID, cmd.Symbol, cmd.OrderType, cmd.OrderSide, cmd.Quantity, cmd.Price)
 
@@ -72,8 +169,15 @@ func (uc *SubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOr
 			}, nil
 		case service.IdempotencyStatusFailed:
 			return nil, fmt.Errorf("previous order submission failed: %s", idempotencyResult.Error)
-		case service.IdempotencyStatusPending:
+		case service.IdempotencyStatusPersisted:
+			if uc.pipelineIdempotency != nil {
+				return uc.resumePersistedSubmission(ctx, cmd, idempotencyKey, idempotencyResult.OrderID)
+			}
 			return nil, fmt.Errorf("order submission is already in progress")
+		case service.IdempotencyStatusPending:
+			if !uc.isStalePending(idempotencyResult) {
+				return nil, fmt.Errorf("order submission is already in progress")
+			}
 		}
 	}
 
@@ -83,10 +187,12 @@ func (uc *SubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOr
 	}
 
 	// Process the order with idempotency protection
-	result, err := uc.processOrderSubmission(ctx, cmd)
+	result, err := uc.processOrderSubmission(ctx, cmd, idempotencyKey)
 	if err != nil {
-		// Mark idempotency as failed
-		_ = uc.idempotencyService.FailIdempotency(ctx, idempotencyKey, cmd.UserID, err.Error())
+		// Mark idempotency as failed unless the saved order is waiting for a retry to publish it
+		if !errors.Is(err, errOrderNotPublished) {
+			_ = uc.idempotencyService.FailIdempotency(ctx, idempotencyKey, cmd.UserID, err.Error())
+		}
 		return nil, err
 	}
 
@@ -99,10 +205,51 @@ func (uc *SubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOr
 	return result, nil
 }
 
+// isStalePending reports whether a pending submission has run long enough to be taken over by a retry
+func (uc *SubmitOrderUseCase) isStalePending(idempotencyResult *service.IdempotencyResult) bool {
+	if uc.pipelineIdempotency == nil || uc.pipelineIdempotency.StalePendingAfter <= 0 || idempotencyResult.CreatedAt.IsZero() {
+		return false
+	}
+	return time.Since(idempotencyResult.CreatedAt) > uc.pipelineIdempotency.StalePendingAfter
+}
+
+// resumePersistedSubmission finishes a submission whose order was saved but never published
+func (uc *SubmitOrderUseCase) resumePersistedSubmission(
+	ctx context.Context,
+	cmd *command.SubmitOrderCommand,
+	idempotencyKey, orderID string,
+) (*command.SubmitOrderResult, error) {
+	order, err := uc.orderRepository.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load persisted order: %w", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("persisted order %s not found", orderID)
+	}
+
+	currentPrice := 0.0
+	if marketPrice := order.MarketPriceAtSubmission(); marketPrice != nil {
+		currentPrice = *marketPrice
+	}
+
+	result, err := uc.completeOrderSubmission(ctx, cmd, order, currentPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.idempotencyService.CompleteIdempotency(ctx, idempotencyKey, cmd.UserID, result.OrderID, result.Message); err != nil {
+		fmt.Printf("Warning: Failed to complete idempotency: %v\n", err)
+	}
+
+	return result, nil
+}
+
 // processOrderSubmission handles the actual order processing logic
-func (uc *SubmitOrderUseCase) processOrderSubmission(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+func (uc *SubmitOrderUseCase) processOrderSubmission(ctx context.Context, cmd *command.SubmitOrderCommand, idempotencyKey string) (*command.SubmitOrderResult, error) {
+	submittedAt := time.Now()
+
 	if err := uc.validateSymbolWithMarketData(ctx, cmd.Symbol); err != nil {
-		return nil, fmt.Errorf("symbol validation failed: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidSymbol, fmt.Errorf("symbol validation failed: %w", err))
 	}
 
 	marketData, err := uc.getMarketDataForOrder(ctx, cmd)
@@ -111,60 +258,457 @@ func (uc *SubmitOrderUseCase) processOrderSubmission(ctx context.Context, cmd *c
 	}
 
 	if err := uc.validateTradingHours(ctx, cmd.Symbol); err != nil {
-		return nil, fmt.Errorf("trading hours validation failed: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonMarketClosed, fmt.Errorf("trading hours validation failed: %w", err))
+	}
+
+	if err := uc.validateVolatilityHalt(cmd.Symbol); err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonVolatilityHalt, err)
 	}
 
 	if err := uc.validateOrderPrice(cmd, marketData.CurrentPrice); err != nil {
-		return nil, fmt.Errorf("price validation failed: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonPriceOutOfRange, fmt.Errorf("price validation failed: %w", err))
 	}
+	validatedAt := time.Now()
 
 	orderSide, err := cmd.ToOrderSide()
 	if err != nil {
-		return nil, fmt.Errorf("invalid order side: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid order side: %w", err))
 	}
 
 	orderType, err := cmd.ToOrderType()
 	if err != nil {
-		return nil, fmt.Errorf("invalid order type: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid order type: %w", err))
 	}
 
 	order, err := domain.NewOrder(cmd.UserID, cmd.Symbol, orderSide, orderType, cmd.Quantity, cmd.Price)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("failed to create order: %w", err))
+	}
+
+	if err := uc.applyTrigger(cmd, order, marketData.CurrentPrice); err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid trigger: %w", err))
+	}
+
+	timeInForce, err := cmd.ToTimeInForce()
+	if err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid time in force: %w", err))
+	}
+
+	if err := order.SetExecutionPreferences(timeInForce, cmd.PartialFillAllowed()); err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("failed to set execution preferences: %w", err))
 	}
 
 	order.SetMarketDataContext(marketData.CurrentPrice, marketData.Timestamp)
 
+	if err := uc.applyExecutionStrategyOverride(cmd, order); err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid execution strategy: %w", err))
+	}
+
+	settlementInstruction, err := cmd.ToSettlementInstruction()
+	if err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid settlement instruction: %w", err))
+	}
+	if err := order.SetSettlementInstruction(settlementInstruction); err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid settlement instruction: %w", err))
+	}
+
+	if err := uc.applyPeg(cmd, order); err != nil {
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonInvalidOrder, fmt.Errorf("invalid peg: %w", err))
+	}
+
+	uc.applyMarketProtection(order)
+
+	uc.captureMarketContext(order)
+
 	if err := uc.performBusinessValidation(ctx, order, marketData); err != nil {
-		return nil, fmt.Errorf("business validation failed: %w", err)
+		return nil, uc.recordRejection(ctx, cmd, domain.RejectReasonBusinessValidation, fmt.Errorf("business validation failed: %w", err))
 	}
+	pricedAt := time.Now()
 
 	if err := uc.orderRepository.Save(ctx, order); err != nil {
 		return nil, fmt.Errorf("failed to save order: %w", err)
 	}
 
+	// The order has an ID only now, so the earlier stages are recorded after the fact
+	uc.recordLatencyStage(order.ID(), domain.OrderLatencyStageSubmitted, submittedAt)
+	uc.recordLatencyStage(order.ID(), domain.OrderLatencyStageValidated, validatedAt)
+	uc.recordLatencyStage(order.ID(), domain.OrderLatencyStagePriced, pricedAt)
+
+	uc.saveMarketContext(ctx, order)
+
+	uc.checkpointPersisted(ctx, idempotencyKey, order)
+
+	result, err := uc.completeOrderSubmission(ctx, cmd, order, marketData.CurrentPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.attachProtectiveStop(ctx, cmd, order, marketData.CurrentPrice, result)
+	return result, nil
+}
+
+// attachProtectiveStop saves the stop loss leg a buy asked for as part of its submission. The leg
+// is not published: it rests until the buy fills, when processing arms it in the trigger book. The
+// buy is already accepted, so a leg that cannot be created is reported in the result message
+// instead of failing the submission.
+func (uc *SubmitOrderUseCase) attachProtectiveStop(
+	ctx context.Context,
+	cmd *command.SubmitOrderCommand,
+	order *domain.Order,
+	currentPrice float64,
+	result *command.SubmitOrderResult,
+) {
+	if cmd.ProtectiveStopPercent == nil {
+		return
+	}
+
+	// The leg protects the price the buy is expected to fill at
+	entryPrice := currentPrice
+	if order.Price() != nil {
+		entryPrice = *order.Price()
+	} else if order.TriggerPrice() != nil {
+		entryPrice = *order.TriggerPrice()
+	}
+
+	stop, err := domain.NewProtectiveStopOrder(order, entryPrice, *cmd.ProtectiveStopPercent)
+	if err == nil {
+		err = uc.orderRepository.Save(ctx, stop)
+	}
+	if err != nil {
+		fmt.Printf("Warning: Failed to attach protective stop to order %s: %v\n", order.ID(), err)
+		result.Message += " Protective stop could not be attached."
+		return
+	}
+
+	result.ProtectiveStopOrderID = stop.ID()
+	result.ProtectiveStopPrice = stop.Price()
+	result.Message += fmt.Sprintf(" Protective stop attached at $%.2f, armed once the order fills.", *stop.Price())
+}
+
+// checkpointPersisted records the saved order against the idempotency key so a retry
+// resumes from publishing instead of saving the order again
+func (uc *SubmitOrderUseCase) checkpointPersisted(ctx context.Context, idempotencyKey string, order *domain.Order) {
+	if uc.pipelineIdempotency == nil {
+		return
+	}
+
+	checkpointer, ok := uc.idempotencyService.(service.IIdempotencyCheckpointer)
+	if !ok {
+		return
+	}
+
+	if err := checkpointer.CheckpointPersisted(ctx, idempotencyKey, order.UserID(), order.ID()); err != nil {
+		fmt.Printf("Warning: Failed to checkpoint idempotency for order %s: %v\n", order.ID(), err)
+	}
+}
+
+// completeOrderSubmission publishes a saved order and builds the submission result
+func (uc *SubmitOrderUseCase) completeOrderSubmission(
+	ctx context.Context,
+	cmd *command.SubmitOrderCommand,
+	order *domain.Order,
+	currentPrice float64,
+) (*command.SubmitOrderResult, error) {
+	if !order.IsActivated() {
+		return uc.restUntilTouched(ctx, order, currentPrice)
+	}
+
+	twapSlices := uc.startTWAP(ctx, order)
+
 	// Publish order for processing (only if orderProducer is available)
-	if uc.orderProducer != nil {
+	if twapSlices == 0 && uc.orderProducer != nil {
 		if err := uc.orderProducer.PublishOrderForProcessing(ctx, order); err != nil {
+			if uc.pipelineIdempotency != nil {
+				// The order is saved; retrying the same submission publishes it
+				return nil, fmt.Errorf("%w: %v", errOrderNotPublished, err)
+			}
 			// Log the error but don't fail the order submission
 			// The order is saved and can be processed later
 			fmt.Printf("Warning: Failed to publish order for processing: %v\n", err)
+		} else {
+			uc.recordLatencyStage(order.ID(), domain.OrderLatencyStagePublished, time.Now())
 		}
 	}
 
-	estimatedPrice := uc.calculateEstimatedExecutionPrice(order, marketData.CurrentPrice)
+	uc.trackPeg(order)
+
+	// Webhook deliveries run in the background and never fail the submission
+	if uc.webhookDispatcher != nil {
+		uc.webhookDispatcher.DispatchOrderEvent(ctx, webhook.OrderWebhookEventSubmitted, order)
+	}
+	if uc.notifier != nil {
+		uc.notifier.NotifyOrderEvent(ctx, domain.OrderNotificationSubmitted, order)
+	}
+
+	estimatedPrice := uc.calculateEstimatedExecutionPrice(order, currentPrice)
 
 	result := &command.SubmitOrderResult{
 		OrderID:                 order.ID(),
 		Status:                  string(order.Status()),
-		MarketPriceAtSubmission: &marketData.CurrentPrice,
+		MarketPriceAtSubmission: &currentPrice,
 		EstimatedExecutionPrice: estimatedPrice,
 		Message:                 fmt.Sprintf("Order submitted successfully. %s", cmd.GetDescription()),
 	}
+	if twapSlices > 0 {
+		result.Message += fmt.Sprintf(" Executing as %d TWAP slices.", twapSlices)
+	}
 
 	return result, nil
 }
 
+// startTWAP paces a TWAP order's slices in the background instead of publishing the whole order and
+// returns the number of slices. The parent is marked processing and stays cancellable, which stops
+// the remaining slices. An order that cannot be sliced returns 0 and is published whole.
+func (uc *SubmitOrderUseCase) startTWAP(ctx context.Context, order *domain.Order) int {
+	if uc.twapScheduler == nil || order.ExecutionStrategyOverride() != service.ExecutionStrategyTWAP.String() {
+		return 0
+	}
+
+	slices, err := uc.twapScheduler.Schedule(order, time.Now())
+	if err == nil {
+		err = order.MarkAsProcessing()
+	}
+	if err == nil {
+		err = uc.orderRepository.Save(ctx, order)
+	}
+	if err != nil {
+		fmt.Printf("Warning: Failed to start TWAP execution of order %s, submitting it whole: %v\n", order.ID(), err)
+		return 0
+	}
+
+	go func() {
+		// The slices are paced long after the submission request ends
+		report, err := uc.twapScheduler.Execute(context.Background(), order, slices, uc.pricingClient)
+		if err != nil {
+			fmt.Printf("Warning: TWAP execution of order %s stopped after %d of %d slices: %v\n",
+				order.ID(), len(report.Submitted), len(slices), err)
+			return
+		}
+		fmt.Printf("TWAP execution of order %s submitted %d slices, delayed %s by volatility\n",
+			order.ID(), len(report.Submitted), report.TotalDelay)
+	}()
+
+	return len(slices)
+}
+
+// applyTrigger sets the trigger of an if-touched order. An order whose trigger the current price
+// already touches is active from submission.
+func (uc *SubmitOrderUseCase) applyTrigger(cmd *command.SubmitOrderCommand, order *domain.Order, currentPrice float64) error {
+	if !order.OrderType().IsIfTouched() {
+		return nil
+	}
+
+	if uc.triggerBook == nil {
+		return fmt.Errorf("%s orders are not supported", order.OrderType())
+	}
+
+	if cmd.TriggerPrice == nil {
+		return fmt.Errorf("%s orders require a trigger price", order.OrderType())
+	}
+
+	if err := order.SetTriggerPrice(*cmd.TriggerPrice); err != nil {
+		return err
+	}
+
+	order.ActivateIfTouched(currentPrice, time.Now())
+	return nil
+}
+
+// restUntilTouched parks an inactive if-touched order in the trigger book instead of publishing it
+func (uc *SubmitOrderUseCase) restUntilTouched(ctx context.Context, order *domain.Order, currentPrice float64) (*command.SubmitOrderResult, error) {
+	if err := uc.triggerBook.Watch(order); err != nil {
+		return nil, fmt.Errorf("failed to watch order trigger: %w", err)
+	}
+
+	if uc.webhookDispatcher != nil {
+		uc.webhookDispatcher.DispatchOrderEvent(ctx, webhook.OrderWebhookEventSubmitted, order)
+	}
+	if uc.notifier != nil {
+		uc.notifier.NotifyOrderEvent(ctx, domain.OrderNotificationSubmitted, order)
+	}
+
+	return &command.SubmitOrderResult{
+		OrderID:                 order.ID(),
+		Status:                  string(order.Status()),
+		MarketPriceAtSubmission: &currentPrice,
+		EstimatedExecutionPrice: uc.calculateEstimatedExecutionPrice(order, *order.TriggerPrice()),
+		Message:                 fmt.Sprintf("Order accepted and inactive until the trigger price $%.2f is touched", *order.TriggerPrice()),
+	}, nil
+}
+
+// applyPeg makes a limit order follow the quote. The submitted price is the starting price, and
+// bounds the client left out default to the configured band around it.
+func (uc *SubmitOrderUseCase) applyPeg(cmd *command.SubmitOrderCommand, order *domain.Order) error {
+	pegInstruction, err := cmd.ToPegInstruction()
+	if err != nil || pegInstruction == nil {
+		return err
+	}
+
+	if uc.pegBook == nil {
+		return errors.New("pegged orders are not supported")
+	}
+
+	banded := uc.pegBook.ApplyDefaultBand(*pegInstruction, *order.Price())
+	return order.SetPegInstruction(&banded)
+}
+
+// trackPeg hands a saved pegged order to the pegged order book. An order the book cannot take
+// still rests at its submitted limit price.
+func (uc *SubmitOrderUseCase) trackPeg(order *domain.Order) {
+	if uc.pegBook == nil || !order.IsPegged() {
+		return
+	}
+
+	if err := uc.pegBook.Track(order); err != nil {
+		fmt.Printf("Warning: Failed to track pegged order %s, it rests at its limit price: %v\n", order.ID(), err)
+	}
+}
+
+// recordLatencyStage records the order reaching a submission stage when latency tracking is enabled
+func (uc *SubmitOrderUseCase) recordLatencyStage(orderID string, stage domain.OrderLatencyStage, at time.Time) {
+	if uc.latencyTracker == nil {
+		return
+	}
+	uc.latencyTracker.RecordStage(orderID, stage, at)
+}
+
+// recordRejection stores the rejected submission with its reason, notifies the user and returns the
+// rejection error. A failure to store it never changes the error returned to the caller.
+func (uc *SubmitOrderUseCase) recordRejection(ctx context.Context, cmd *command.SubmitOrderCommand, reason domain.RejectReason, rejectionErr error) error {
+	if uc.rejectedOrders == nil && uc.notifier == nil {
+		return rejectionErr
+	}
+
+	rejection := &domain.RejectedOrder{
+		UserID:        cmd.UserID,
+		Symbol:        cmd.Symbol,
+		OrderSide:     cmd.OrderSide,
+		OrderType:     cmd.OrderType,
+		Quantity:      cmd.Quantity,
+		Price:         cmd.Price,
+		ReasonCode:    reason,
+		ReasonDetails: rejectionErr.Error(),
+		RejectedAt:    time.Now(),
+	}
+
+	if uc.rejectedOrders != nil {
+		if err := uc.rejectedOrders.Save(ctx, rejection); err != nil {
+			fmt.Printf("Warning: Failed to record rejected order for user %s: %v\n", cmd.UserID, err)
+		}
+	}
+	if uc.notifier != nil {
+		uc.notifier.NotifyOrderRejected(ctx, rejection)
+	}
+
+	return rejectionErr
+}
+
+// applyExecutionStrategyOverride records the strategy the user asked for after checking it suits the
+// order. Use cases built without a pricing service validate against the default pricing configuration.
+func (uc *SubmitOrderUseCase) applyExecutionStrategyOverride(cmd *command.SubmitOrderCommand, order *domain.Order) error {
+	if cmd.ExecutionStrategy == "" {
+		return nil
+	}
+
+	pricingService := uc.pricingService
+	if pricingService == nil {
+		pricingService = service.NewOrderPricingServiceWithDefaults()
+	}
+
+	strategy, err := pricingService.ValidateStrategyOverride(order, cmd.ExecutionStrategy)
+	if err != nil {
+		return err
+	}
+
+	order.SetExecutionStrategyOverride(strategy.String())
+	return nil
+}
+
+// checkFatFinger rejects an unacknowledged order that is far out of line with the user's order history.
+// Orders are not held back when the history cannot be read.
+func (uc *SubmitOrderUseCase) checkFatFinger(ctx context.Context, cmd *command.SubmitOrderCommand) error {
+	if uc.fatFinger == nil {
+		return nil
+	}
+
+	orderSide, sideErr := cmd.ToOrderSide()
+	orderType, typeErr := cmd.ToOrderType()
+	if sideErr != nil || typeErr != nil {
+		return nil // Rejected as an invalid order during processing
+	}
+	candidate, err := domain.NewOrder(cmd.UserID, cmd.Symbol, orderSide, orderType, cmd.Quantity, cmd.Price)
+	if err != nil {
+		return nil
+	}
+
+	result, err := uc.fatFinger.CheckOrder(ctx, candidate, cmd.AcknowledgeFatFinger)
+	if err != nil {
+		fmt.Printf("Warning: Failed to run fat-finger check for user %s: %v\n", cmd.UserID, err)
+		return nil
+	}
+	if result.IsAccepted {
+		return nil
+	}
+
+	return uc.recordRejection(ctx, cmd, domain.RejectReasonFatFinger,
+		fmt.Errorf("%w: %s, resubmit with acknowledge_fat_finger to place it anyway", ErrFatFingerOrder, strings.Join(result.Reasons, "; ")))
+}
+
+// applyMarketProtection adds a limit band to market orders in thin markets.
+// Without book data the order is submitted as a plain market order.
+func (uc *SubmitOrderUseCase) applyMarketProtection(order *domain.Order) {
+	if uc.pricingService == nil || uc.pricingClient == nil {
+		return
+	}
+
+	if _, err := uc.pricingService.ApplyMarketOrderProtection(order, uc.pricingClient); err != nil && !errors.Is(err, external.ErrPricingDataUnavailable) {
+		fmt.Printf("Warning: Failed to apply market protection to order %s: %v\n", order.ID(), err)
+	}
+}
+
+// captureMarketContext reads the quote once and attaches it to the order as its market context.
+// Without a quote the order is submitted without a snapshot.
+func (uc *SubmitOrderUseCase) captureMarketContext(order *domain.Order) {
+	if uc.contextSource == nil {
+		return
+	}
+
+	marketPrice, err := uc.contextSource.GetCurrentMarketPrice(order.Symbol())
+	if err != nil {
+		fmt.Printf("Warning: Failed to capture market context for order %s: %v\n", order.ID(), err)
+		return
+	}
+
+	snapshot := domain.MarketContextSnapshot{
+		Symbol:        order.Symbol(),
+		BidPrice:      marketPrice.BidPrice,
+		AskPrice:      marketPrice.AskPrice,
+		LastPrice:     marketPrice.LastPrice,
+		Spread:        marketPrice.Spread,
+		SpreadPercent: marketPrice.SpreadPercent,
+		// Same simplified volatility measure the pricing service uses for market conditions
+		Volatility:     marketPrice.SpreadPercent,
+		Volume:         marketPrice.Volume,
+		QuoteTimestamp: marketPrice.Timestamp,
+		CapturedAt:     time.Now(),
+	}
+
+	if err := order.AttachMarketContextSnapshot(snapshot); err != nil {
+		fmt.Printf("Warning: Failed to record market context for order %s: %v\n", order.ID(), err)
+	}
+}
+
+func (uc *SubmitOrderUseCase) saveMarketContext(ctx context.Context, order *domain.Order) {
+	snapshot := order.MarketContextSnapshot()
+	if snapshot == nil || uc.snapshotRepository == nil {
+		return
+	}
+
+	if err := uc.snapshotRepository.Save(ctx, snapshot); err != nil {
+		fmt.Printf("Warning: Failed to save market context for order %s: %v\n", order.ID(), err)
+	}
+}
+
 type MarketDataContext struct {
 	CurrentPrice float64
 	AssetDetails *external.AssetDetails
@@ -222,8 +766,30 @@ func (uc *SubmitOrderUseCase) validateTradingHours(ctx context.Context, symbol s
 	return nil
 }
 
+func (uc *SubmitOrderUseCase) validateVolatilityHalt(symbol string) error {
+	if uc.volatilityHalts == nil {
+		return nil
+	}
+
+	halt := uc.volatilityHalts.ActiveHalt(symbol, time.Now())
+	if halt == nil {
+		return nil
+	}
+
+	if halt.IsManual() {
+		if halt.IsPermanent() {
+			return fmt.Errorf("symbol %s is halted (%s) until the halt is lifted", symbol, halt.Reason)
+		}
+		return fmt.Errorf("symbol %s is halted (%s), trading resumes at %s",
+			symbol, halt.Reason, halt.ResumesAt.Format(time.RFC3339))
+	}
+
+	return fmt.Errorf("symbol %s is halted after a %.1f%% price move, trading resumes at %s",
+		symbol, halt.MovePercent, halt.ResumesAt.Format(time.RFC3339))
+}
+
 func (uc *SubmitOrderUseCase) validateOrderPrice(cmd *command.SubmitOrderCommand, currentPrice float64) error {
-	if cmd.IsMarketOrder() {
+	if cmd.IsMarketOrder() || cmd.OrderType == domain.OrderTypeMarketIfTouched.String() {
 		return nil
 	}
 
@@ -231,6 +797,11 @@ func (uc *SubmitOrderUseCase) validateOrderPrice(cmd *command.SubmitOrderCommand
 		return fmt.Errorf("limit orders must have a price")
 	}
 
+	// An if-touched limit only works once its trigger is touched, so it is judged against the trigger
+	if cmd.IsIfTouchedOrder() && cmd.TriggerPrice != nil {
+		currentPrice = *cmd.TriggerPrice
+	}
+
 	orderPrice := *cmd.Price
 
 	// Define acceptable price deviation (e.g., 10% from current market price)
@@ -276,7 +847,7 @@ func (uc *SubmitOrderUseCase) performBusinessValidation(ctx context.Context, ord
 }
 
 func (uc *SubmitOrderUseCase) calculateEstimatedExecutionPrice(order *domain.Order, currentPrice float64) *float64 {
-	if order.OrderType() == domain.OrderTypeMarket {
+	if order.OrderType().ActivatesAs() == domain.OrderTypeMarket {
 		return &currentPrice
 	}
 
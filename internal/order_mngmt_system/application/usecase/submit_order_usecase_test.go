@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,12 +13,20 @@ import (
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
 	"HubInvestments/internal/order_mngmt_system/domain/service"
 	"HubInvestments/internal/order_mngmt_system/infra/external"
+	"HubInvestments/internal/order_mngmt_system/infra/messaging/rabbitmq"
+	"HubInvestments/internal/order_mngmt_system/infra/webhook"
+	"HubInvestments/shared/infra/messaging"
 )
 
 // MockOrderRepository implements IOrderRepository for testing
 type MockOrderRepository struct {
-	SaveFunc     func(ctx context.Context, order *domain.Order) error
-	FindByIDFunc func(ctx context.Context, orderID string) (*domain.Order, error)
+	SaveFunc         func(ctx context.Context, order *domain.Order) error
+	FindByIDFunc     func(ctx context.Context, orderID string) (*domain.Order, error)
+	FindByUserIDFunc func(ctx context.Context, userID string) ([]*domain.Order, error)
+	FindByStatusFunc func(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error)
+
+	FindByUserIDAndStatusFunc func(ctx context.Context, userID string, status domain.OrderStatus) ([]*domain.Order, error)
+	FindOrderHistoryFunc      func(ctx context.Context, userID string, limit int, offset int) ([]*domain.Order, error)
 }
 
 func (m *MockOrderRepository) Save(ctx context.Context, order *domain.Order) error {
@@ -33,6 +44,9 @@ func (m *MockOrderRepository) FindByID(ctx context.Context, orderID string) (*do
 }
 
 func (m *MockOrderRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Order, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
 	return nil, nil
 }
 
@@ -45,14 +59,23 @@ func (m *MockOrderRepository) UpdateExecutionDetails(ctx context.Context, orderI
 }
 
 func (m *MockOrderRepository) FindByUserIDAndStatus(ctx context.Context, userID string, status domain.OrderStatus) ([]*domain.Order, error) {
+	if m.FindByUserIDAndStatusFunc != nil {
+		return m.FindByUserIDAndStatusFunc(ctx, userID, status)
+	}
 	return nil, nil
 }
 
 func (m *MockOrderRepository) FindByStatus(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+	if m.FindByStatusFunc != nil {
+		return m.FindByStatusFunc(ctx, status)
+	}
 	return nil, nil
 }
 
 func (m *MockOrderRepository) FindOrderHistory(ctx context.Context, userID string, limit int, offset int) ([]*domain.Order, error) {
+	if m.FindOrderHistoryFunc != nil {
+		return m.FindOrderHistoryFunc(ctx, userID, limit, offset)
+	}
 	return nil, nil
 }
 
@@ -218,7 +241,11 @@ func TestSubmitOrderUseCase_Execute_Success(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	price := 150.00
@@ -262,7 +289,11 @@ func TestSubmitOrderUseCase_Execute_InvalidCommand(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	cmd := &command.SubmitOrderCommand{
@@ -296,7 +327,11 @@ func TestSubmitOrderUseCase_Execute_IdempotencyAlreadyProcessed(t *testing.T) {
 		},
 	}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	price := 150.00
@@ -340,7 +375,11 @@ func TestSubmitOrderUseCase_Execute_MarketDataValidationFailure(t *testing.T) {
 	}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	price := 150.00
@@ -380,7 +419,11 @@ func TestSubmitOrderUseCase_Execute_MarketClosed(t *testing.T) {
 	}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	price := 150.00
@@ -420,7 +463,11 @@ func TestSubmitOrderUseCase_Execute_PriceValidationFailure(t *testing.T) {
 	}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	// Price too far from market price (should fail validation)
@@ -461,7 +508,11 @@ func TestSubmitOrderUseCase_Execute_RepositoryError(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	price := 150.00
@@ -497,7 +548,11 @@ func TestSubmitOrderUseCase_Execute_MarketOrder(t *testing.T) {
 	mockMarketData := &MockMarketDataClient{}
 	mockIdempotency := &MockIdempotencyService{}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	cmd := &command.SubmitOrderCommand{
@@ -536,7 +591,11 @@ func TestSubmitOrderUseCase_Execute_IdempotencyServiceError(t *testing.T) {
 		},
 	}
 
-	useCase := NewSubmitOrderUseCase(mockRepo, mockMarketData, mockIdempotency, nil)
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   mockMarketData,
+		IdempotencyService: mockIdempotency,
+	})
 
 	ctx := context.Background()
 	price := 150.00
@@ -579,3 +638,806 @@ func contains(s, substr string) bool {
 				return false
 			}())))
 }
+
+// MockWebhookDispatcher implements IOrderWebhookDispatcher for testing
+type MockWebhookDispatcher struct {
+	DispatchOrderEventFunc func(ctx context.Context, event string, order *domain.Order)
+}
+
+func (m *MockWebhookDispatcher) DispatchOrderEvent(ctx context.Context, event string, order *domain.Order) {
+	if m.DispatchOrderEventFunc != nil {
+		m.DispatchOrderEventFunc(ctx, event, order)
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_DispatchesSubmittedWebhook(t *testing.T) {
+	var dispatchedEvent string
+	var dispatchedOrder *domain.Order
+	dispatcher := &MockWebhookDispatcher{
+		DispatchOrderEventFunc: func(ctx context.Context, event string, order *domain.Order) {
+			dispatchedEvent = event
+			dispatchedOrder = order
+		},
+	}
+
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    &MockOrderRepository{},
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		WebhookDispatcher:  dispatcher,
+	})
+
+	price := 150.00
+	result, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dispatchedEvent != webhook.OrderWebhookEventSubmitted {
+		t.Errorf("Expected %s webhook, got %q", webhook.OrderWebhookEventSubmitted, dispatchedEvent)
+	}
+	if dispatchedOrder == nil || dispatchedOrder.ID() != result.OrderID {
+		t.Error("Expected webhook to carry the submitted order")
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_WebhookFailureDoesNotAffectOrder(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := webhook.NewInMemoryWebhookSubscriptionStore(webhook.WebhookSubscription{ID: "erp", URL: server.URL, Secret: "key"})
+	dispatcher := webhook.NewOrderWebhookDispatcher(store, server.Client(), webhook.OrderWebhookDispatcherConfig{
+		MaxRetries:     2,
+		RetryBackoff:   time.Millisecond,
+		RequestTimeout: time.Second,
+	})
+
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    &MockOrderRepository{},
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		WebhookDispatcher:  dispatcher,
+	})
+
+	price := 150.00
+	result, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	})
+	dispatcher.Wait()
+
+	if err != nil {
+		t.Fatalf("Expected order submission to succeed despite webhook failures, got %v", err)
+	}
+	if result.Status != "PENDING" {
+		t.Errorf("Expected status PENDING, got %s", result.Status)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("Expected 3 webhook attempts (1 + 2 retries), got %d", got)
+	}
+}
+
+// MockMarketContextSource implements IMarketContextSource for testing
+type MockMarketContextSource struct {
+	MarketPrice *service.MarketPrice
+	Err         error
+	Calls       int
+}
+
+func (m *MockMarketContextSource) GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error) {
+	m.Calls++
+	return m.MarketPrice, m.Err
+}
+
+// MockMarketContextSnapshotRepository implements IMarketContextSnapshotRepository for testing
+type MockMarketContextSnapshotRepository struct {
+	snapshots map[string]*domain.MarketContextSnapshot
+}
+
+func (m *MockMarketContextSnapshotRepository) Save(ctx context.Context, snapshot *domain.MarketContextSnapshot) error {
+	if _, exists := m.snapshots[snapshot.OrderID]; !exists {
+		m.snapshots[snapshot.OrderID] = snapshot
+	}
+	return nil
+}
+
+func (m *MockMarketContextSnapshotRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.MarketContextSnapshot, error) {
+	return m.snapshots[orderID], nil
+}
+
+func TestSubmitOrderUseCase_Execute_StoresMarketContextSnapshot(t *testing.T) {
+	// Arrange
+	quoteTime := time.Date(2024, 3, 4, 14, 30, 0, 0, time.UTC)
+	contextSource := &MockMarketContextSource{MarketPrice: &service.MarketPrice{
+		Symbol:        "AAPL",
+		BidPrice:      150.40,
+		AskPrice:      150.60,
+		LastPrice:     150.50,
+		Volume:        1200000,
+		Spread:        0.20,
+		SpreadPercent: 0.13,
+		Timestamp:     quoteTime,
+	}}
+	snapshotRepo := &MockMarketContextSnapshotRepository{snapshots: make(map[string]*domain.MarketContextSnapshot)}
+
+	var savedOrder *domain.Order
+	orderRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			savedOrder = order
+			return nil
+		},
+	}
+
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    orderRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		ContextSource:      contextSource,
+		SnapshotRepository: snapshotRepo,
+	})
+
+	// Act
+	price := 150.00
+	result, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if contextSource.Calls != 1 {
+		t.Errorf("Expected the pricing client to be read once, got %d reads", contextSource.Calls)
+	}
+
+	snapshot, _ := snapshotRepo.FindByOrderID(context.Background(), result.OrderID)
+	if snapshot == nil {
+		t.Fatal("Expected a market context snapshot to be stored")
+	}
+	if snapshot.BidPrice != 150.40 || snapshot.AskPrice != 150.60 || snapshot.LastPrice != 150.50 {
+		t.Errorf("Expected snapshot quote 150.40/150.60 last 150.50, got %v/%v last %v", snapshot.BidPrice, snapshot.AskPrice, snapshot.LastPrice)
+	}
+	if snapshot.Spread != 0.20 || snapshot.Volatility != 0.13 || snapshot.Volume != 1200000 {
+		t.Errorf("Expected spread 0.20, volatility 0.13 and volume 1200000, got %v, %v and %v", snapshot.Spread, snapshot.Volatility, snapshot.Volume)
+	}
+	if !snapshot.QuoteTimestamp.Equal(quoteTime) {
+		t.Errorf("Expected quote timestamp %v, got %v", quoteTime, snapshot.QuoteTimestamp)
+	}
+	if savedOrder == nil || savedOrder.MarketContextSnapshot() == nil || savedOrder.MarketContextSnapshot().BidPrice != 150.40 {
+		t.Error("Expected the saved order to carry the snapshot")
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_MarketContextUnavailable(t *testing.T) {
+	contextSource := &MockMarketContextSource{Err: errors.New("quote feed down")}
+	snapshotRepo := &MockMarketContextSnapshotRepository{snapshots: make(map[string]*domain.MarketContextSnapshot)}
+
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    &MockOrderRepository{},
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		ContextSource:      contextSource,
+		SnapshotRepository: snapshotRepo,
+	})
+
+	price := 150.00
+	result, err := useCase.Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected submission to succeed without a snapshot, got %v", err)
+	}
+	if snapshot, _ := snapshotRepo.FindByOrderID(context.Background(), result.OrderID); snapshot != nil {
+		t.Errorf("Expected no snapshot, got %+v", snapshot)
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_RejectsHaltedSymbol(t *testing.T) {
+	// Arrange
+	volatilityHalts := service.NewVolatilityHaltServiceWithDefaults()
+	now := time.Now()
+	volatilityHalts.RecordPrice("AAPL", 150.00, now.Add(-10*time.Second))
+	volatilityHalts.RecordPrice("AAPL", 170.00, now)
+
+	saved := false
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saved = true
+			return nil
+		},
+	}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		VolatilityHalts:    volatilityHalts,
+	})
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the order to be rejected while the symbol is halted")
+	}
+	if !contains(err.Error(), "halted") {
+		t.Errorf("Expected halt error, got %v", err)
+	}
+	if saved {
+		t.Error("Expected the order not to be saved")
+	}
+
+	cmd.Symbol = "MSFT"
+	if _, err := useCase.Execute(context.Background(), cmd); err != nil {
+		t.Errorf("Expected orders for other symbols to go through, got %v", err)
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_RequiresFatFingerAcknowledgement(t *testing.T) {
+	// Arrange
+	history := make([]*domain.Order, 0)
+	for i := 0; i < 5; i++ {
+		pastPrice := 150.00
+		past, _ := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10.0, &pastPrice)
+		history = append(history, past)
+	}
+
+	saved := 0
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saved++
+			return nil
+		},
+		FindOrderHistoryFunc: func(ctx context.Context, userID string, limit int, offset int) ([]*domain.Order, error) {
+			return history, nil
+		},
+	}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: service.NewIdempotencyService(&InMemoryIdempotencyRepository{keys: make(map[string]*service.IdempotencyKey)}),
+		FatFinger:          service.NewFatFingerServiceWithDefaults(mockRepo),
+	})
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  1000.0,
+		Price:     &price,
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if !errors.Is(err, ErrFatFingerOrder) {
+		t.Fatalf("Expected a fat-finger rejection, got %v", err)
+	}
+	if saved != 0 {
+		t.Error("Expected the unacknowledged order not to be saved")
+	}
+
+	cmd.AcknowledgeFatFinger = true
+	if _, err := useCase.Execute(context.Background(), cmd); err != nil {
+		t.Fatalf("Expected the acknowledged order to be placed, got %v", err)
+	}
+	if saved != 1 {
+		t.Errorf("Expected the acknowledged order to be saved once, got %d", saved)
+	}
+}
+
+// InMemoryIdempotencyRepository keeps idempotency keys in memory for testing
+type InMemoryIdempotencyRepository struct {
+	keys map[string]*service.IdempotencyKey
+}
+
+func (r *InMemoryIdempotencyRepository) Store(ctx context.Context, key *service.IdempotencyKey) error {
+	stored := *key
+	r.keys[key.UserID+":"+key.Key] = &stored
+	return nil
+}
+
+func (r *InMemoryIdempotencyRepository) Get(ctx context.Context, key, userID string) (*service.IdempotencyKey, error) {
+	stored, ok := r.keys[userID+":"+key]
+	if !ok {
+		return nil, errors.New("idempotency key not found")
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+func (r *InMemoryIdempotencyRepository) Update(ctx context.Context, key *service.IdempotencyKey) error {
+	return r.Store(ctx, key)
+}
+
+func (r *InMemoryIdempotencyRepository) Delete(ctx context.Context, key, userID string) error {
+	delete(r.keys, userID+":"+key)
+	return nil
+}
+
+func (r *InMemoryIdempotencyRepository) DeleteExpired(ctx context.Context) error {
+	return nil
+}
+
+// FlakyMessageHandler fails a set number of publishes before accepting them
+type FlakyMessageHandler struct {
+	messaging.MessageHandler
+	failuresLeft int
+	published    int
+}
+
+func (h *FlakyMessageHandler) PublishWithOptions(ctx context.Context, options messaging.PublishOptions) error {
+	if h.failuresLeft > 0 {
+		h.failuresLeft--
+		return errors.New("broker connection lost")
+	}
+	h.published++
+	return nil
+}
+
+func TestSubmitOrderUseCase_Execute_PipelineIdempotency_RetryAfterPersistPublishesOnce(t *testing.T) {
+	// Arrange
+	savedOrders := map[string]*domain.Order{}
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			savedOrders[order.ID()] = order
+			return nil
+		},
+		FindByIDFunc: func(ctx context.Context, orderID string) (*domain.Order, error) {
+			return savedOrders[orderID], nil
+		},
+	}
+	idempotencyRepo := &InMemoryIdempotencyRepository{keys: map[string]*service.IdempotencyKey{}}
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo)
+	// The first publish fails after the order is saved, as if the request died between persist and publish
+	messageHandler := &FlakyMessageHandler{failuresLeft: 1}
+	producer := rabbitmq.NewOrderProducer(messageHandler)
+
+	pipelineConfig := DefaultPipelineIdempotencyConfig()
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:     mockRepo,
+		MarketDataClient:    &MockMarketDataClient{},
+		IdempotencyService:  idempotencyService,
+		OrderProducer:       producer,
+		PipelineIdempotency: &pipelineConfig,
+	})
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "LIMIT",
+		OrderSide: "BUY",
+		Quantity:  100.0,
+		Price:     &price,
+	}
+
+	// Act
+	_, firstErr := useCase.Execute(context.Background(), cmd)
+	retryResult, retryErr := useCase.Execute(context.Background(), cmd)
+	replayResult, replayErr := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if firstErr == nil {
+		t.Fatal("Expected the first attempt to report the failed publish")
+	}
+	if retryErr != nil {
+		t.Fatalf("Expected the retry to complete the original submission, got %v", retryErr)
+	}
+	if len(savedOrders) != 1 {
+		t.Fatalf("Expected exactly one saved order, got %d", len(savedOrders))
+	}
+	if _, ok := savedOrders[retryResult.OrderID]; !ok {
+		t.Errorf("Expected the retry to return the original order, got %s", retryResult.OrderID)
+	}
+	if messageHandler.published != 1 {
+		t.Errorf("Expected the order to be published once, got %d", messageHandler.published)
+	}
+	if retryResult.MarketPriceAtSubmission == nil || *retryResult.MarketPriceAtSubmission != 150.50 {
+		t.Errorf("Expected the original market price in the resumed result, got %v", retryResult.MarketPriceAtSubmission)
+	}
+
+	if replayErr != nil {
+		t.Fatalf("Expected a later retry to return the stored result, got %v", replayErr)
+	}
+	if replayResult.OrderID != retryResult.OrderID {
+		t.Errorf("Expected order %s, got %s", retryResult.OrderID, replayResult.OrderID)
+	}
+	if messageHandler.published != 1 || len(savedOrders) != 1 {
+		t.Error("Expected the completed submission not to be saved or published again")
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_PipelineIdempotency_TakesOverStalePending(t *testing.T) {
+	// Arrange
+	saves := 0
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saves++
+			return nil
+		},
+	}
+	pendingSince := time.Now()
+	mockIdempotency := &MockIdempotencyService{
+		CheckIdempotencyFunc: func(ctx context.Context, key, userID string) (*service.IdempotencyResult, error) {
+			return &service.IdempotencyResult{
+				IsProcessed: true,
+				Status:      service.IdempotencyStatusPending,
+				CreatedAt:   pendingSince,
+			}, nil
+		},
+	}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:     mockRepo,
+		MarketDataClient:    &MockMarketDataClient{},
+		IdempotencyService:  mockIdempotency,
+		PipelineIdempotency: &PipelineIdempotencyConfig{StalePendingAfter: time.Minute},
+	})
+
+	cmd := &command.SubmitOrderCommand{
+		UserID:    "user123",
+		Symbol:    "AAPL",
+		OrderType: "MARKET",
+		OrderSide: "BUY",
+		Quantity:  10.0,
+	}
+
+	// Act
+	_, inFlightErr := useCase.Execute(context.Background(), cmd)
+	pendingSince = time.Now().Add(-2 * time.Minute)
+	result, staleErr := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if inFlightErr == nil || !contains(inFlightErr.Error(), "already in progress") {
+		t.Errorf("Expected a recent pending submission to be reported as in progress, got %v", inFlightErr)
+	}
+	if staleErr != nil {
+		t.Fatalf("Expected a stale pending submission to be taken over, got %v", staleErr)
+	}
+	if result == nil || saves != 1 {
+		t.Errorf("Expected the taken over submission to save one order, got %d", saves)
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_HonorsExecutionStrategyOverride(t *testing.T) {
+	// Arrange
+	var savedOrder *domain.Order
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			savedOrder = order
+			return nil
+		},
+	}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+	})
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:            "user123",
+		Symbol:            "AAPL",
+		OrderType:         "LIMIT",
+		OrderSide:         "BUY",
+		Quantity:          1000.0,
+		Price:             &price,
+		ExecutionStrategy: "TWAP",
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if savedOrder == nil || savedOrder.ExecutionStrategyOverride() != "TWAP" {
+		t.Errorf("Expected the saved order to carry the TWAP override, got %+v", savedOrder)
+	}
+}
+
+// ChannelSliceSubmitter hands each submitted TWAP slice to the test
+type ChannelSliceSubmitter struct {
+	slices chan service.TWAPSlice
+}
+
+func (s *ChannelSliceSubmitter) SubmitSlice(ctx context.Context, parentOrderID string, slice service.TWAPSlice) error {
+	s.slices <- slice
+	return nil
+}
+
+func TestSubmitOrderUseCase_Execute_SlicesTWAPOrders(t *testing.T) {
+	// Arrange
+	var savedOrder *domain.Order
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			savedOrder = order
+			return nil
+		},
+	}
+	submitter := &ChannelSliceSubmitter{slices: make(chan service.TWAPSlice, 4)}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+		TWAPScheduler:      service.NewTWAPExecutionScheduler(submitter, service.TWAPSchedulerConfig{SliceCount: 4}),
+	})
+
+	price := 150.00
+	cmd := &command.SubmitOrderCommand{
+		UserID:            "user123",
+		Symbol:            "AAPL",
+		OrderType:         "LIMIT",
+		OrderSide:         "BUY",
+		Quantity:          1000.0,
+		Price:             &price,
+		ExecutionStrategy: "TWAP",
+	}
+
+	// Act
+	result, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if savedOrder.Status() != domain.OrderStatusProcessing {
+		t.Errorf("Expected the TWAP parent to be processing while it is sliced, got %s", savedOrder.Status())
+	}
+	if !contains(result.Message, "4 TWAP slices") {
+		t.Errorf("Expected the result to report the slices, got %q", result.Message)
+	}
+
+	sliced := 0.0
+	for i := 0; i < 4; i++ {
+		select {
+		case slice := <-submitter.slices:
+			sliced += slice.Quantity
+		case <-time.After(time.Second):
+			t.Fatalf("Expected 4 slices to be submitted, got %d", i)
+		}
+	}
+	if sliced != 1000.0 {
+		t.Errorf("Expected the slices to add up to the order quantity, got %.2f", sliced)
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_RejectsUnsuitableExecutionStrategyOverride(t *testing.T) {
+	// Arrange
+	saved := false
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			saved = true
+			return nil
+		},
+	}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+	})
+
+	cmd := &command.SubmitOrderCommand{
+		UserID:            "user123",
+		Symbol:            "AAPL",
+		OrderType:         "MARKET",
+		OrderSide:         "BUY",
+		Quantity:          2.0,
+		ExecutionStrategy: "TWAP",
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected TWAP on a tiny order to be rejected")
+	}
+	if !contains(err.Error(), "invalid execution strategy") {
+		t.Errorf("Expected an execution strategy error, got %v", err)
+	}
+	if saved {
+		t.Error("Expected the order not to be saved")
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_StoresSettlementInstruction(t *testing.T) {
+	// Arrange
+	var savedOrder *domain.Order
+	mockRepo := &MockOrderRepository{
+		SaveFunc: func(ctx context.Context, order *domain.Order) error {
+			savedOrder = order
+			return nil
+		},
+	}
+	useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+		OrderRepository:    mockRepo,
+		MarketDataClient:   &MockMarketDataClient{},
+		IdempotencyService: &MockIdempotencyService{},
+	})
+
+	cmd := &command.SubmitOrderCommand{
+		UserID:                    "user123",
+		Symbol:                    "AAPL",
+		OrderType:                 "MARKET",
+		OrderSide:                 "BUY",
+		Quantity:                  10.0,
+		SettlementAccount:         "CUST-00123",
+		SettlementInstructionCode: "FOP",
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if savedOrder == nil {
+		t.Fatal("Expected the order to be saved")
+	}
+	instruction := savedOrder.SettlementInstruction()
+	if instruction == nil || instruction.Account != "CUST-00123" || instruction.Code != domain.SettlementInstructionFOP {
+		t.Errorf("Expected the saved order to carry the settlement instruction, got %+v", instruction)
+	}
+}
+
+func TestSubmitOrderUseCase_Execute_RejectsInvalidSettlementInstruction(t *testing.T) {
+	tests := []struct {
+		name    string
+		account string
+		code    string
+	}{
+		{name: "unknown code", account: "CUST-00123", code: "WIRE"},
+		{name: "code without account", code: "DVP"},
+		{name: "account without code", account: "CUST-00123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := false
+			mockRepo := &MockOrderRepository{
+				SaveFunc: func(ctx context.Context, order *domain.Order) error {
+					saved = true
+					return nil
+				},
+			}
+			useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+				OrderRepository:    mockRepo,
+				MarketDataClient:   &MockMarketDataClient{},
+				IdempotencyService: &MockIdempotencyService{},
+			})
+
+			cmd := &command.SubmitOrderCommand{
+				UserID:                    "user123",
+				Symbol:                    "AAPL",
+				OrderType:                 "MARKET",
+				OrderSide:                 "BUY",
+				Quantity:                  10.0,
+				SettlementAccount:         tt.account,
+				SettlementInstructionCode: tt.code,
+			}
+
+			_, err := useCase.Execute(context.Background(), cmd)
+
+			if err == nil {
+				t.Fatal("Expected the settlement instruction to be rejected")
+			}
+			if !contains(err.Error(), "invalid settlement instruction") {
+				t.Errorf("Expected a settlement instruction error, got %v", err)
+			}
+			if saved {
+				t.Error("Expected the order not to be saved")
+			}
+		})
+	}
+}
+
+func TestSubmitOrderUseCase_ProtectiveStop(t *testing.T) {
+	submitBuy := func(t *testing.T, stopPercent *float64) (*command.SubmitOrderResult, map[string]*domain.Order) {
+		t.Helper()
+		repo, orders := newInMemoryOrderRepository()
+		useCase := NewSubmitOrderUseCase(SubmitOrderDependencies{
+			OrderRepository:    repo,
+			MarketDataClient:   &MockMarketDataClient{},
+			IdempotencyService: &MockIdempotencyService{},
+		})
+
+		// Arrange
+		price := 150.00
+		cmd := &command.SubmitOrderCommand{
+			UserID:                "user123",
+			Symbol:                "AAPL",
+			OrderType:             "LIMIT",
+			OrderSide:             "BUY",
+			Quantity:              10,
+			Price:                 &price,
+			ProtectiveStopPercent: stopPercent,
+		}
+
+		// Act
+		result, err := useCase.Execute(context.Background(), cmd)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return result, orders
+	}
+
+	t.Run("enabled preference creates a linked stop below entry", func(t *testing.T) {
+		stopPercent := 5.0
+		result, orders := submitBuy(t, &stopPercent)
+
+		// Assert
+		if len(orders) != 2 {
+			t.Fatalf("Expected the buy and its protective stop to be saved, got %d orders", len(orders))
+		}
+		stop := orders[result.ProtectiveStopOrderID]
+		if stop == nil {
+			t.Fatalf("Expected protective stop %q to be saved", result.ProtectiveStopOrderID)
+		}
+		if stop.ParentOrderID() != result.OrderID {
+			t.Errorf("Expected stop to be linked to %s, got %q", result.OrderID, stop.ParentOrderID())
+		}
+		if stop.OrderType() != domain.OrderTypeStopLoss || !stop.IsSellOrder() {
+			t.Errorf("Expected a SELL STOP_LOSS leg, got %s %s", stop.OrderSide(), stop.OrderType())
+		}
+		if stop.Quantity() != 10 {
+			t.Errorf("Expected the stop to protect the full quantity 10, got %.2f", stop.Quantity())
+		}
+		if *stop.Price() != 142.50 || *result.ProtectiveStopPrice != 142.50 {
+			t.Errorf("Expected stop price 142.50, got %.2f", *stop.Price())
+		}
+	})
+
+	t.Run("disabled preference creates no stop", func(t *testing.T) {
+		result, orders := submitBuy(t, nil)
+
+		// Assert
+		if len(orders) != 1 {
+			t.Fatalf("Expected only the buy to be saved, got %d orders", len(orders))
+		}
+		if result.ProtectiveStopOrderID != "" || result.ProtectiveStopPrice != nil {
+			t.Errorf("Expected no protective stop, got %q", result.ProtectiveStopOrderID)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type ISuggestLimitPriceUseCase interface {
+	Execute(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error)
+}
+
+// SuggestLimitPriceUseCase recommends a limit price for an order from the current quote and order book.
+// Nothing is stored.
+type SuggestLimitPriceUseCase struct {
+	pricingService service.OrderPricingService
+	pricingClient  service.IPricingDataClient
+}
+
+func NewSuggestLimitPriceUseCase(
+	pricingService service.OrderPricingService,
+	pricingClient service.IPricingDataClient,
+) ISuggestLimitPriceUseCase {
+	return &SuggestLimitPriceUseCase{
+		pricingService: pricingService,
+		pricingClient:  pricingClient,
+	}
+}
+
+// Execute suggests a limit price for the command's order at its urgency
+func (uc *SuggestLimitPriceUseCase) Execute(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	side, err := domain.ParseOrderSide(cmd.OrderSide)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order side: %w", err)
+	}
+
+	urgency, err := service.ParseLimitPriceUrgency(cmd.Urgency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid urgency: %w", err)
+	}
+
+	suggestion, err := uc.pricingService.SuggestLimitPrice(cmd.UserID, cmd.Symbol, side, cmd.Quantity, urgency, uc.pricingClient)
+	if err != nil {
+		return nil, fmt.Errorf("limit price suggestion failed: %w", err)
+	}
+
+	return suggestion, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/external"
+)
+
+func TestSuggestLimitPriceUseCase_Execute_HigherUrgencyIsMoreAggressive(t *testing.T) {
+	// Arrange
+	useCase := NewSuggestLimitPriceUseCase(service.NewOrderPricingServiceWithDefaults(),
+		external.NewSimulatedPricingDataClient(external.DefaultSimulationConfig()))
+
+	for _, side := range []string{"BUY", "SELL"} {
+		var previous *service.LimitPriceSuggestion
+		for _, urgency := range []string{"LOW", "MEDIUM", "HIGH"} {
+			// Act
+			suggestion, err := useCase.Execute(context.Background(), &command.SuggestLimitPriceCommand{
+				UserID: "user123", Symbol: "PETR4", OrderSide: side, Quantity: 100, Urgency: urgency,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error for %s %s, got %v", urgency, side, err)
+			}
+
+			// Assert
+			if previous != nil {
+				moreAggressive := suggestion.SuggestedPrice > previous.SuggestedPrice
+				if side == "SELL" {
+					moreAggressive = suggestion.SuggestedPrice < previous.SuggestedPrice
+				}
+				if !moreAggressive {
+					t.Errorf("Expected %s %s to price past %.4f, got %.4f", urgency, side, previous.SuggestedPrice, suggestion.SuggestedPrice)
+				}
+				if suggestion.FillProbability <= previous.FillProbability {
+					t.Errorf("Expected %s %s to raise fill probability above %.2f, got %.2f", urgency, side, previous.FillProbability, suggestion.FillProbability)
+				}
+			}
+			previous = suggestion
+		}
+	}
+}
+
+func TestSuggestLimitPriceUseCase_Execute_InvalidUrgency(t *testing.T) {
+	// Arrange
+	useCase := NewSuggestLimitPriceUseCase(service.NewOrderPricingServiceWithDefaults(),
+		external.NewSimulatedPricingDataClient(external.DefaultSimulationConfig()))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), &command.SuggestLimitPriceCommand{
+		UserID: "user123", Symbol: "PETR4", OrderSide: "BUY", Quantity: 100, Urgency: "IMMEDIATE",
+	})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected an error for an unknown urgency")
+	}
+}
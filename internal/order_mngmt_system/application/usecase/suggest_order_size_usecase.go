@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/external"
+)
+
+type ISuggestOrderSizeUseCase interface {
+	Execute(ctx context.Context, cmd *command.SuggestOrderSizeCommand) (*service.OrderSizeRecommendation, error)
+}
+
+// SuggestOrderSizeUseCase recommends how much of a symbol a user can buy while keeping the
+// order's risk score within a target risk level. Nothing is stored.
+type SuggestOrderSizeUseCase struct {
+	riskService      service.RiskManagementService
+	riskDataClient   service.IRiskDataClient
+	marketDataClient external.IMarketDataClient
+}
+
+func NewSuggestOrderSizeUseCase(
+	riskService service.RiskManagementService,
+	riskDataClient service.IRiskDataClient,
+	marketDataClient external.IMarketDataClient,
+) ISuggestOrderSizeUseCase {
+	return &SuggestOrderSizeUseCase{
+		riskService:      riskService,
+		riskDataClient:   riskDataClient,
+		marketDataClient: marketDataClient,
+	}
+}
+
+// Execute sizes a buy order at the command's price, or at the current market price when none is given
+func (uc *SuggestOrderSizeUseCase) Execute(ctx context.Context, cmd *command.SuggestOrderSizeCommand) (*service.OrderSizeRecommendation, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	targetLevel, err := service.ParseRiskLevel(cmd.TargetRiskLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target risk level: %w", err)
+	}
+
+	var price float64
+	if cmd.Price != nil {
+		price = *cmd.Price
+	} else {
+		price, err = uc.marketDataClient.GetCurrentPrice(ctx, cmd.Symbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current price: %w", err)
+		}
+	}
+
+	recommendation, err := uc.riskService.RecommendOrderSize(cmd.UserID, cmd.Symbol, price, targetLevel, uc.riskDataClient)
+	if err != nil {
+		return nil, fmt.Errorf("order size recommendation failed: %w", err)
+	}
+
+	return recommendation, nil
+}
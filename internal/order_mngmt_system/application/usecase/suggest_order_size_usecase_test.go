@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// VolatilityBySymbolRiskDataClient answers volatility per symbol and delegates everything else
+type VolatilityBySymbolRiskDataClient struct {
+	RecordingRiskDataClient
+	volatility map[string]*service.MarketVolatility
+}
+
+func (c *VolatilityBySymbolRiskDataClient) GetMarketVolatility(symbol string) (*service.MarketVolatility, error) {
+	c.calls = append(c.calls, "GetMarketVolatility")
+	return c.volatility[symbol], nil
+}
+
+func TestSuggestOrderSizeUseCase_Execute_HigherVolatilityGetsSmallerSize(t *testing.T) {
+	// Arrange
+	riskClient := &VolatilityBySymbolRiskDataClient{
+		volatility: map[string]*service.MarketVolatility{
+			"KO":   {Symbol: "KO", Volatility30Day: 12.0, Beta: 0.6},
+			"TSLA": {Symbol: "TSLA", Volatility30Day: 55.0, Beta: 2.0, IsHighVolatility: true},
+		},
+	}
+	useCase := NewSuggestOrderSizeUseCase(service.NewRiskManagementServiceWithDefaults(), riskClient, &MockMarketDataClient{
+		GetCurrentPriceFunc: func(ctx context.Context, symbol string) (float64, error) {
+			return 100.0, nil
+		},
+	})
+
+	// Act
+	lowVolatility, err := useCase.Execute(context.Background(), &command.SuggestOrderSizeCommand{UserID: "user123", Symbol: "KO", TargetRiskLevel: "HIGH"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	highVolatility, err := useCase.Execute(context.Background(), &command.SuggestOrderSizeCommand{UserID: "user123", Symbol: "TSLA", TargetRiskLevel: "HIGH"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if highVolatility.RecommendedQuantity >= lowVolatility.RecommendedQuantity {
+		t.Errorf("Expected TSLA (%.0f) to be sized below KO (%.0f)", highVolatility.RecommendedQuantity, lowVolatility.RecommendedQuantity)
+	}
+	if highVolatility.RecommendedQuantity <= 0 {
+		t.Errorf("Expected a positive recommendation for TSLA, got %+v", highVolatility)
+	}
+	if lowVolatility.Price != 100.0 {
+		t.Errorf("Expected the market price to be used, got %.2f", lowVolatility.Price)
+	}
+}
+
+func TestSuggestOrderSizeUseCase_Execute_UsesGivenPrice(t *testing.T) {
+	// Arrange
+	riskClient := &RecordingRiskDataClient{}
+	useCase := NewSuggestOrderSizeUseCase(service.NewRiskManagementServiceWithDefaults(), riskClient, &MockMarketDataClient{
+		GetCurrentPriceFunc: func(ctx context.Context, symbol string) (float64, error) {
+			t.Error("Expected the market price not to be looked up")
+			return 0, nil
+		},
+	})
+	price := 250.0
+
+	// Act
+	recommendation, err := useCase.Execute(context.Background(), &command.SuggestOrderSizeCommand{
+		UserID:          "user123",
+		Symbol:          "AAPL",
+		TargetRiskLevel: "MEDIUM",
+		Price:           &price,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recommendation.Price != price || recommendation.TargetRiskLevel != service.RiskLevelMedium {
+		t.Errorf("Unexpected recommendation %+v", recommendation)
+	}
+}
+
+func TestSuggestOrderSizeUseCase_Execute_InvalidRiskLevel(t *testing.T) {
+	riskClient := &RecordingRiskDataClient{}
+	useCase := NewSuggestOrderSizeUseCase(service.NewRiskManagementServiceWithDefaults(), riskClient, &MockMarketDataClient{})
+
+	_, err := useCase.Execute(context.Background(), &command.SuggestOrderSizeCommand{UserID: "user123", Symbol: "AAPL", TargetRiskLevel: "SAFE"})
+
+	if err == nil {
+		t.Fatal("Expected an error for an unknown risk level")
+	}
+	if len(riskClient.calls) != 0 {
+		t.Errorf("Expected no risk data calls, got %v", riskClient.calls)
+	}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// TWAPSliceOrderSubmitter submits each TWAP slice as a child order of the parent, saved and published
+// for processing like any other order. Slicing stops once the parent is no longer open, e.g. after
+// the user cancelled it or it expired.
+type TWAPSliceOrderSubmitter struct {
+	orderRepository repository.IOrderRepository
+	publisher       IActivatedOrderPublisher
+}
+
+func NewTWAPSliceOrderSubmitter(orderRepository repository.IOrderRepository, publisher IActivatedOrderPublisher) *TWAPSliceOrderSubmitter {
+	return &TWAPSliceOrderSubmitter{
+		orderRepository: orderRepository,
+		publisher:       publisher,
+	}
+}
+
+// SubmitSlice saves the slice's child order and publishes it for processing
+func (s *TWAPSliceOrderSubmitter) SubmitSlice(ctx context.Context, parentOrderID string, slice service.TWAPSlice) error {
+	parent, err := s.orderRepository.FindByID(ctx, parentOrderID)
+	if err != nil {
+		return fmt.Errorf("failed to find parent order: %w", err)
+	}
+	if parent == nil {
+		return fmt.Errorf("parent order %s not found", parentOrderID)
+	}
+	if !parent.CanCancel() {
+		return fmt.Errorf("parent order %s is %s, remaining slices are not submitted", parentOrderID, parent.Status())
+	}
+
+	child, err := domain.NewTWAPSliceOrder(parent, slice.Quantity)
+	if err != nil {
+		return fmt.Errorf("failed to create slice order: %w", err)
+	}
+	if err := s.orderRepository.Save(ctx, child); err != nil {
+		return fmt.Errorf("failed to save slice order: %w", err)
+	}
+
+	if err := s.publisher.PublishOrderForProcessing(ctx, child); err != nil {
+		// The slice is saved and can be processed later
+		fmt.Printf("Warning: Failed to publish TWAP slice %s of order %s for processing: %v\n", child.ID(), parentOrderID, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTWAPSliceOrderSubmitter_SubmitSlice(t *testing.T) {
+	repo, orders := newInMemoryOrderRepository()
+	publisher := &RecordingOrderPublisher{}
+	submitter := NewTWAPSliceOrderSubmitter(repo, publisher)
+
+	price := 150.0
+	parent, err := domain.NewOrder("42", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	require.NoError(t, err)
+	require.NoError(t, parent.MarkAsProcessing())
+	orders[parent.ID()] = parent
+
+	require.NoError(t, submitter.SubmitSlice(context.Background(), parent.ID(), service.TWAPSlice{Index: 0, Quantity: 25}))
+
+	require.Len(t, publisher.Published, 1)
+	child := publisher.Published[0]
+	assert.Equal(t, parent.ID(), child.ParentOrderID())
+	assert.Equal(t, 25.0, child.Quantity())
+	assert.Equal(t, domain.OrderStatusPending, child.Status())
+	assert.Same(t, child, orders[child.ID()])
+
+	// Cancelling the parent stops the remaining slices
+	require.NoError(t, parent.MarkAsCancelled())
+	assert.Error(t, submitter.SubmitSlice(context.Background(), parent.ID(), service.TWAPSlice{Index: 1, Quantity: 25}))
+	assert.Len(t, publisher.Published, 1)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+
+	"github.com/google/uuid"
+)
+
+type IUpdateUserRiskProfileUseCase interface {
+	// Execute applies the command's fields to the user's risk profile and records the change for audit
+	Execute(ctx context.Context, cmd *command.UpdateUserRiskProfileCommand) (*command.UpdateUserRiskProfileResult, error)
+}
+
+// UpdateUserRiskProfileUseCase lets support and risk staff tune a user's limits. Stored profiles
+// take precedence over the risk data source, so the change applies to the user's next order.
+type UpdateUserRiskProfileUseCase struct {
+	profileRepository repository.IUserRiskProfileRepository
+}
+
+func NewUpdateUserRiskProfileUseCase(profileRepository repository.IUserRiskProfileRepository) IUpdateUserRiskProfileUseCase {
+	return &UpdateUserRiskProfileUseCase{
+		profileRepository: profileRepository,
+	}
+}
+
+// Execute applies the command's fields to the user's risk profile and records the change for audit.
+// A user without a stored profile gets one created from the command, which must then set both limits.
+func (uc *UpdateUserRiskProfileUseCase) Execute(ctx context.Context, cmd *command.UpdateUserRiskProfileCommand) (*command.UpdateUserRiskProfileResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	current, err := uc.profileRepository.FindByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find risk profile: %w", err)
+	}
+
+	updated := service.UserRiskProfile{UserID: cmd.UserID, RiskTolerance: service.RiskToleranceModerate}
+	if current != nil {
+		updated = *current
+	}
+	applyRiskProfileChanges(&updated, cmd)
+	updated.ProfileLastUpdated = time.Now()
+
+	if err := updated.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid risk profile: %w", err)
+	}
+
+	entry := &service.RiskProfileAuditEntry{
+		ID:        uuid.New().String(),
+		UserID:    cmd.UserID,
+		ChangedBy: cmd.ChangedBy,
+		Reason:    cmd.Reason,
+		Previous:  current,
+		Updated:   updated,
+		ChangedAt: updated.ProfileLastUpdated,
+	}
+
+	if err := uc.profileRepository.SaveWithAudit(ctx, &updated, entry); err != nil {
+		return nil, fmt.Errorf("failed to save risk profile: %w", err)
+	}
+
+	return &command.UpdateUserRiskProfileResult{
+		Profile:       &updated,
+		ChangedFields: entry.ChangedFields(),
+		AuditID:       entry.ID,
+	}, nil
+}
+
+func applyRiskProfileChanges(profile *service.UserRiskProfile, cmd *command.UpdateUserRiskProfileCommand) {
+	if cmd.MaxOrderValue != nil {
+		profile.MaxOrderValue = *cmd.MaxOrderValue
+	}
+	if cmd.MaxDailyTradingValue != nil {
+		profile.MaxDailyTradingValue = *cmd.MaxDailyTradingValue
+	}
+	if cmd.MaxPositionSize != nil {
+		profile.MaxPositionSize = *cmd.MaxPositionSize
+	}
+	if cmd.RiskTolerance != nil {
+		// Validated by the command
+		profile.RiskTolerance, _ = service.ParseRiskTolerance(*cmd.RiskTolerance)
+	}
+	if cmd.IsHighRiskApproved != nil {
+		profile.IsHighRiskApproved = *cmd.IsHighRiskApproved
+	}
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/external"
+)
+
+// InMemoryUserRiskProfileRepository implements IUserRiskProfileRepository for testing
+type InMemoryUserRiskProfileRepository struct {
+	mu       sync.Mutex
+	profiles map[string]service.UserRiskProfile
+	audit    []*service.RiskProfileAuditEntry
+}
+
+func NewInMemoryUserRiskProfileRepository() *InMemoryUserRiskProfileRepository {
+	return &InMemoryUserRiskProfileRepository{profiles: make(map[string]service.UserRiskProfile)}
+}
+
+func (r *InMemoryUserRiskProfileRepository) FindByUserID(ctx context.Context, userID string) (*service.UserRiskProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, ok := r.profiles[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &profile, nil
+}
+
+func (r *InMemoryUserRiskProfileRepository) SaveWithAudit(ctx context.Context, profile *service.UserRiskProfile, entry *service.RiskProfileAuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[profile.UserID] = *profile
+	r.audit = append(r.audit, entry)
+	return nil
+}
+
+func (r *InMemoryUserRiskProfileRepository) FindAuditByUserID(ctx context.Context, userID string) ([]*service.RiskProfileAuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var entries []*service.RiskProfileAuditEntry
+	for _, entry := range r.audit {
+		if entry.UserID == userID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestUpdateUserRiskProfileUseCase_UpdatedLimitAppliesToNextOrder(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryUserRiskProfileRepository()
+	riskClient := external.NewStoredRiskProfileDataClient(&RecordingRiskDataClient{}, repo)
+	riskService := service.NewRiskManagementServiceWithDefaults()
+	useCase := NewUpdateUserRiskProfileUseCase(repo)
+
+	price := 150.00
+	order, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, &price)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+
+	if err := riskService.ValidateRiskLimits(order, riskClient); err != nil {
+		t.Fatalf("Expected a 15000 order to pass the source limits, got %v", err)
+	}
+
+	// Act
+	_, err = useCase.Execute(context.Background(), &command.UpdateUserRiskProfileCommand{
+		UserID:               "user123",
+		ChangedBy:            "admin1",
+		Reason:               "Limits lowered after margin call",
+		MaxOrderValue:        floatPtr(10000),
+		MaxDailyTradingValue: floatPtr(20000),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	err = riskService.ValidateRiskLimits(order, riskClient)
+	if err == nil || !strings.Contains(err.Error(), "exceeds user limit 10000.00") {
+		t.Errorf("Expected the next order to be checked against the updated limit, got %v", err)
+	}
+
+	limits, err := riskClient.GetUserTradingLimits("user123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if limits.DailyTradingLimit != 20000 || limits.RemainingDailyLimit != 20000 {
+		t.Errorf("Expected the updated daily limit, got %+v", limits)
+	}
+}
+
+func TestUpdateUserRiskProfileUseCase_RecordsAuditEntry(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryUserRiskProfileRepository()
+	useCase := NewUpdateUserRiskProfileUseCase(repo)
+	ctx := context.Background()
+
+	_, err := useCase.Execute(ctx, &command.UpdateUserRiskProfileCommand{
+		UserID:               "user123",
+		ChangedBy:            "admin1",
+		Reason:               "Initial limits",
+		MaxOrderValue:        floatPtr(10000),
+		MaxDailyTradingValue: floatPtr(20000),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tolerance := "AGGRESSIVE"
+
+	// Act
+	result, err := useCase.Execute(ctx, &command.UpdateUserRiskProfileCommand{
+		UserID:        "user123",
+		ChangedBy:     "admin2",
+		Reason:        "Income verified",
+		MaxOrderValue: floatPtr(15000),
+		RiskTolerance: &tolerance,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entries, _ := repo.FindAuditByUserID(ctx, "user123")
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 audit entries, got %d", len(entries))
+	}
+
+	entry := entries[1]
+	if entry.ID != result.AuditID || entry.ChangedBy != "admin2" || entry.Reason != "Income verified" {
+		t.Errorf("Unexpected audit entry %+v", entry)
+	}
+	if entry.Previous == nil || entry.Previous.MaxOrderValue != 10000 || entry.Updated.MaxOrderValue != 15000 {
+		t.Errorf("Expected the audit entry to hold the profile before and after, got %+v", entry)
+	}
+	if entry.Updated.MaxDailyTradingValue != 20000 || entry.Updated.RiskTolerance != service.RiskToleranceAggressive {
+		t.Errorf("Expected omitted fields to keep their value, got %+v", entry.Updated)
+	}
+	if strings.Join(result.ChangedFields, ",") != strings.Join(entry.ChangedFields(), ",") || len(result.ChangedFields) != 2 {
+		t.Errorf("Expected two changed fields, got %v", result.ChangedFields)
+	}
+	if entries[0].Previous != nil {
+		t.Error("Expected the first audit entry to record the profile's creation")
+	}
+}
+
+func TestUpdateUserRiskProfileUseCase_RejectsInvalidProfile(t *testing.T) {
+	repo := NewInMemoryUserRiskProfileRepository()
+	useCase := NewUpdateUserRiskProfileUseCase(repo)
+	tolerance := "SPECULATIVE"
+
+	_, err := useCase.Execute(context.Background(), &command.UpdateUserRiskProfileCommand{
+		UserID:               "user123",
+		ChangedBy:            "admin1",
+		Reason:               "Upgrade",
+		MaxOrderValue:        floatPtr(10000),
+		MaxDailyTradingValue: floatPtr(20000),
+		RiskTolerance:        &tolerance,
+	})
+
+	if err == nil {
+		t.Fatal("Expected speculative tolerance without high-risk approval to be rejected")
+	}
+	if entries, _ := repo.FindAuditByUserID(context.Background(), "user123"); len(entries) != 0 {
+		t.Errorf("Expected no audit entry for a rejected change, got %d", len(entries))
+	}
+}
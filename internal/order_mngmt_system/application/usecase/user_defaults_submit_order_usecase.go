@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+// ErrOrderTypeRequired is returned when a submission has no order type and the user has no default one
+var ErrOrderTypeRequired = errors.New("order type is required")
+
+// UserDefaultsSubmitOrderUseCase fills the fields a submission left out from the user's default order
+// settings before handing it to the wrapped use case, so every entry point (HTTP and gRPC) places
+// orders with the same defaults
+type UserDefaultsSubmitOrderUseCase struct {
+	submitOrderUseCase ISubmitOrderUseCase
+	preferences        repository.IUserOrderPreferencesRepository
+}
+
+func NewUserDefaultsSubmitOrderUseCase(
+	submitOrderUseCase ISubmitOrderUseCase,
+	preferences repository.IUserOrderPreferencesRepository,
+) ISubmitOrderUseCase {
+	return &UserDefaultsSubmitOrderUseCase{
+		submitOrderUseCase: submitOrderUseCase,
+		preferences:        preferences,
+	}
+}
+
+func (uc *UserDefaultsSubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+	if cmd != nil && !cmd.IgnoreUserDefaults {
+		ApplyUserOrderDefaults(cmd, uc.loadPreferences(ctx, cmd.UserID))
+
+		if cmd.OrderType == "" {
+			return nil, fmt.Errorf("%w: send order_type or set a default order type", ErrOrderTypeRequired)
+		}
+	}
+
+	return uc.submitOrderUseCase.Execute(ctx, cmd)
+}
+
+// loadPreferences returns nil when the user has no defaults or they cannot be loaded, so a
+// preferences outage never blocks order submission
+func (uc *UserDefaultsSubmitOrderUseCase) loadPreferences(ctx context.Context, userID string) *domain.UserOrderPreferences {
+	preferences, err := uc.preferences.FindByUserID(ctx, userID)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load order preferences for user %s: %v\n", userID, err)
+		return nil
+	}
+	return preferences
+}
+
+// ApplyUserOrderDefaults fills the fields the command left out from the user's default order
+// settings. Values sent explicitly on the command always win.
+func ApplyUserOrderDefaults(cmd *command.SubmitOrderCommand, preferences *domain.UserOrderPreferences) {
+	if preferences == nil {
+		return
+	}
+
+	if cmd.OrderType == "" && preferences.DefaultOrderType != "" {
+		cmd.OrderType = preferences.DefaultOrderType.String()
+	}
+
+	if cmd.TimeInForce == "" && preferences.DefaultTimeInForce != "" {
+		cmd.TimeInForce = preferences.DefaultTimeInForce.String()
+	}
+
+	if cmd.AllowPartialFill == nil && preferences.AllowPartialFill != nil {
+		allowPartialFill := *preferences.AllowPartialFill
+		cmd.AllowPartialFill = &allowPartialFill
+	}
+
+	if stopPercent, enabled := preferences.ProtectiveStop(); enabled && cmd.ProtectiveStopPercent == nil && cmd.IsBuyOrder() {
+		cmd.ProtectiveStopPercent = &stopPercent
+	}
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type MockUserOrderPreferencesRepository struct {
+	preferences map[string]*domain.UserOrderPreferences
+	err         error
+}
+
+func (m *MockUserOrderPreferencesRepository) Save(ctx context.Context, preferences *domain.UserOrderPreferences) error {
+	m.preferences[preferences.UserID] = preferences
+	return nil
+}
+
+func (m *MockUserOrderPreferencesRepository) FindByUserID(ctx context.Context, userID string) (*domain.UserOrderPreferences, error) {
+	return m.preferences[userID], m.err
+}
+
+// recordingSubmitOrderUseCase captures the command it is handed
+type recordingSubmitOrderUseCase struct {
+	submitted *command.SubmitOrderCommand
+}
+
+func (r *recordingSubmitOrderUseCase) Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+	r.submitted = cmd
+	return &command.SubmitOrderResult{OrderID: "order123", Status: "PENDING"}, nil
+}
+
+func submitWithUserDefaults(t *testing.T, cmd *command.SubmitOrderCommand, preferences *domain.UserOrderPreferences) (*command.SubmitOrderCommand, error) {
+	t.Helper()
+	next := &recordingSubmitOrderUseCase{}
+	repo := &MockUserOrderPreferencesRepository{preferences: map[string]*domain.UserOrderPreferences{}}
+	if preferences != nil {
+		repo.preferences[preferences.UserID] = preferences
+	}
+
+	_, err := NewUserDefaultsSubmitOrderUseCase(next, repo).Execute(context.Background(), cmd)
+	return next.submitted, err
+}
+
+func TestUserDefaultsSubmitOrderUseCase_AppliesUserDefaults(t *testing.T) {
+	allowPartialFill := false
+	preferences := &domain.UserOrderPreferences{
+		UserID:             "user123",
+		DefaultOrderType:   domain.OrderTypeMarket,
+		DefaultTimeInForce: domain.TimeInForceGTC,
+		AllowPartialFill:   &allowPartialFill,
+	}
+
+	cmd, err := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderSide: "BUY", Quantity: 100,
+	}, preferences)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cmd.OrderType != "MARKET" {
+		t.Errorf("Expected default order type 'MARKET', got '%s'", cmd.OrderType)
+	}
+	if cmd.TimeInForce != "GTC" {
+		t.Errorf("Expected default time in force 'GTC', got '%s'", cmd.TimeInForce)
+	}
+	if cmd.AllowPartialFill == nil || *cmd.AllowPartialFill {
+		t.Errorf("Expected default partial fill preference false, got %v", cmd.AllowPartialFill)
+	}
+}
+
+func TestUserDefaultsSubmitOrderUseCase_ExplicitValuesOverrideUserDefaults(t *testing.T) {
+	allowPartialFill := false
+	explicitPartialFill := true
+	price := 150.50
+	preferences := &domain.UserOrderPreferences{
+		UserID:             "user123",
+		DefaultOrderType:   domain.OrderTypeMarket,
+		DefaultTimeInForce: domain.TimeInForceGTC,
+		AllowPartialFill:   &allowPartialFill,
+	}
+
+	cmd, err := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID:           "user123",
+		Symbol:           "AAPL",
+		OrderType:        "LIMIT",
+		OrderSide:        "BUY",
+		Quantity:         100,
+		Price:            &price,
+		TimeInForce:      "IOC",
+		AllowPartialFill: &explicitPartialFill,
+	}, preferences)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cmd.OrderType != "LIMIT" {
+		t.Errorf("Expected explicit order type 'LIMIT', got '%s'", cmd.OrderType)
+	}
+	if cmd.TimeInForce != "IOC" {
+		t.Errorf("Expected explicit time in force 'IOC', got '%s'", cmd.TimeInForce)
+	}
+	if cmd.AllowPartialFill == nil || !*cmd.AllowPartialFill {
+		t.Errorf("Expected explicit partial fill preference true, got %v", cmd.AllowPartialFill)
+	}
+}
+
+func TestUserDefaultsSubmitOrderUseCase_ProtectiveStopPreference(t *testing.T) {
+	preferences := &domain.UserOrderPreferences{
+		UserID:                "user123",
+		ProtectiveStopEnabled: true,
+		ProtectiveStopPercent: 5,
+	}
+	price := func() *float64 { p := 150.00; return &p }
+
+	buy, err := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "LIMIT", OrderSide: "BUY", Quantity: 100, Price: price(),
+	}, preferences)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buy.ProtectiveStopPercent == nil || *buy.ProtectiveStopPercent != 5 {
+		t.Errorf("Expected buy to carry the 5%% protective stop preference, got %v", buy.ProtectiveStopPercent)
+	}
+
+	sell, _ := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "LIMIT", OrderSide: "SELL", Quantity: 100, Price: price(),
+	}, preferences)
+	if sell.ProtectiveStopPercent != nil {
+		t.Errorf("Expected no protective stop on a sell, got %v", *sell.ProtectiveStopPercent)
+	}
+
+	explicitPercent := 2.0
+	explicit, _ := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "LIMIT", OrderSide: "BUY", Quantity: 100, Price: price(),
+		ProtectiveStopPercent: &explicitPercent,
+	}, preferences)
+	if explicit.ProtectiveStopPercent == nil || *explicit.ProtectiveStopPercent != 2 {
+		t.Errorf("Expected the explicit 2%% protective stop to win, got %v", explicit.ProtectiveStopPercent)
+	}
+
+	preferences.ProtectiveStopEnabled = false
+	disabled, _ := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "LIMIT", OrderSide: "BUY", Quantity: 100, Price: price(),
+	}, preferences)
+	if disabled.ProtectiveStopPercent != nil {
+		t.Errorf("Expected no protective stop with the preference disabled, got %v", *disabled.ProtectiveStopPercent)
+	}
+}
+
+func TestUserDefaultsSubmitOrderUseCase_IgnoreUserDefaults(t *testing.T) {
+	preferences := &domain.UserOrderPreferences{
+		UserID:             "user123",
+		DefaultTimeInForce: domain.TimeInForceGTC,
+	}
+
+	cmd, err := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "MARKET", OrderSide: "BUY", Quantity: 100,
+		IgnoreUserDefaults: true,
+	}, preferences)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cmd.TimeInForce != "" {
+		t.Errorf("Expected no user defaults, got time in force '%s'", cmd.TimeInForce)
+	}
+}
+
+func TestUserDefaultsSubmitOrderUseCase_MissingOrderTypeWithoutDefault(t *testing.T) {
+	cmd, err := submitWithUserDefaults(t, &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderSide: "BUY", Quantity: 100,
+	}, nil)
+
+	if !errors.Is(err, ErrOrderTypeRequired) {
+		t.Errorf("Expected ErrOrderTypeRequired, got %v", err)
+	}
+	if cmd != nil {
+		t.Error("Expected the order not to be submitted")
+	}
+}
+
+func TestUserDefaultsSubmitOrderUseCase_PreferencesOutageDoesNotBlockSubmission(t *testing.T) {
+	next := &recordingSubmitOrderUseCase{}
+	repo := &MockUserOrderPreferencesRepository{err: errors.New("database unavailable")}
+
+	_, err := NewUserDefaultsSubmitOrderUseCase(next, repo).Execute(context.Background(), &command.SubmitOrderCommand{
+		UserID: "user123", Symbol: "AAPL", OrderType: "MARKET", OrderSide: "BUY", Quantity: 100,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if next.submitted == nil {
+		t.Error("Expected the order to be submitted")
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "fmt"
+
+// CancellationReason records why an order was cancelled
+// @Description Cancellation reason code
+type CancellationReason string
+
+const (
+	CancellationReasonUserRequested     CancellationReason = "USER_REQUESTED"
+	CancellationReasonMarketClosed      CancellationReason = "MARKET_CLOSED"
+	CancellationReasonInsufficientFunds CancellationReason = "INSUFFICIENT_FUNDS"
+	CancellationReasonRiskManagement    CancellationReason = "RISK_MANAGEMENT"
+	CancellationReasonSystemError       CancellationReason = "SYSTEM_ERROR"
+	CancellationReasonExpired           CancellationReason = "EXPIRED"
+	CancellationReasonAdminAction       CancellationReason = "ADMIN_ACTION"
+	CancellationReasonDisconnected      CancellationReason = "CLIENT_DISCONNECTED"
+	CancellationReasonOCOTriggered      CancellationReason = "OCO_TRIGGERED"
+	CancellationReasonRiskHalt          CancellationReason = "RISK_HALT"
+	CancellationReasonReconciliation    CancellationReason = "RECONCILIATION"
+)
+
+// IsValid checks if the cancellation reason is a known code
+func (r CancellationReason) IsValid() bool {
+	switch r {
+	case CancellationReasonUserRequested, CancellationReasonMarketClosed, CancellationReasonInsufficientFunds,
+		CancellationReasonRiskManagement, CancellationReasonSystemError, CancellationReasonExpired,
+		CancellationReasonAdminAction, CancellationReasonDisconnected, CancellationReasonOCOTriggered,
+		CancellationReasonRiskHalt, CancellationReasonReconciliation:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the cancellation reason
+func (r CancellationReason) String() string {
+	return string(r)
+}
+
+// ParseCancellationReason parses a string into a CancellationReason
+func ParseCancellationReason(s string) (CancellationReason, error) {
+	reason := CancellationReason(s)
+	if !reason.IsValid() {
+		return "", fmt.Errorf("invalid cancellation reason: %s", s)
+	}
+	return reason, nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// ExecutionQuality rates how an order's fill compared with its pre-trade estimate
+type ExecutionQuality string
+
+const (
+	// ExecutionQualityPriceImprovement means the fill was better than estimated
+	ExecutionQualityPriceImprovement ExecutionQuality = "PRICE_IMPROVEMENT"
+
+	// ExecutionQualityExact means the fill matched the estimate
+	ExecutionQualityExact ExecutionQuality = "EXACT"
+
+	// ExecutionQualityWithinTolerance means the fill was worse than estimated but within the slippage tolerance
+	ExecutionQualityWithinTolerance ExecutionQuality = "WITHIN_TOLERANCE"
+
+	// ExecutionQualityPoor means the fill slipped beyond the tolerance
+	ExecutionQualityPoor ExecutionQuality = "POOR"
+)
+
+// String returns the string representation of the execution quality
+func (q ExecutionQuality) String() string {
+	return string(q)
+}
+
+// ExecutionQualityReport compares an order's estimated fill with its realized fill.
+// Slippage is signed from the trader's point of view: positive values are adverse
+// (paid more on a buy, received less on a sell), negative values are price improvement.
+// @Description Execution quality report for a filled order
+type ExecutionQualityReport struct {
+	OrderID                  string           `json:"order_id"`
+	Symbol                   string           `json:"symbol"`
+	OrderSide                OrderSide        `json:"order_side"`
+	Quantity                 float64          `json:"quantity"`
+	EstimatedFillPrice       float64          `json:"estimated_fill_price"`
+	ActualFillPrice          float64          `json:"actual_fill_price"`
+	Slippage                 float64          `json:"slippage"`
+	SlippageBps              float64          `json:"slippage_bps"`
+	SlippageCost             float64          `json:"slippage_cost"`
+	SlippageTolerancePercent float64          `json:"slippage_tolerance_percent"`
+	Quality                  ExecutionQuality `json:"quality"`
+	ExecutedAt               *time.Time       `json:"executed_at,omitempty"`
+	CreatedAt                time.Time        `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MarketContextSnapshot records the market a user faced when submitting an order, for
+// compliance and later analysis. It is captured once at submission and never changes.
+// @Description Market context captured at order submission
+type MarketContextSnapshot struct {
+	OrderID        string    `json:"order_id"`
+	Symbol         string    `json:"symbol"`
+	BidPrice       float64   `json:"bid_price"`
+	AskPrice       float64   `json:"ask_price"`
+	LastPrice      float64   `json:"last_price"`
+	Spread         float64   `json:"spread"`
+	SpreadPercent  float64   `json:"spread_percent"`
+	Volatility     float64   `json:"volatility"`
+	Volume         int64     `json:"volume"`
+	QuoteTimestamp time.Time `json:"quote_timestamp"`
+	CapturedAt     time.Time `json:"captured_at"`
+}
+
+// Validate checks that the snapshot describes a usable quote
+func (s *MarketContextSnapshot) Validate() error {
+	if s.Symbol == "" {
+		return errors.New("symbol cannot be empty")
+	}
+	if s.BidPrice < 0 || s.AskPrice < 0 || s.LastPrice < 0 {
+		return errors.New("quote prices cannot be negative")
+	}
+	if s.BidPrice > 0 && s.AskPrice > 0 && s.BidPrice > s.AskPrice {
+		return errors.New("bid price cannot be above ask price")
+	}
+	if s.CapturedAt.IsZero() {
+		return errors.New("capture time cannot be zero")
+	}
+	return nil
+}
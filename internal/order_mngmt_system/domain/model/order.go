@@ -3,6 +3,8 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +19,7 @@ type Order struct {
 	orderSide               OrderSide
 	orderType               OrderType
 	quantity                float64
+	filledQuantity          float64
 	price                   *float64 // nil for market orders
 	status                  OrderStatus
 	createdAt               time.Time
@@ -25,6 +28,22 @@ type Order struct {
 	executionPrice          *float64
 	marketPriceAtSubmission *float64
 	marketDataTimestamp     *time.Time
+	protectionLimitPrice    *float64 // limit band for protected market orders
+	timeInForce             TimeInForce
+	allowPartialFill        bool
+	marketContextSnapshot   *MarketContextSnapshot // captured once at submission
+	executionStrategy       string                 // requested strategy overriding the recommendation (empty uses the recommendation)
+	priceTickAdjustment     *PriceTickAdjustment   // set when the submitted price was snapped to the price step
+	fills                   []OrderFill            // individual executions recorded against the order
+	cancellationReason      CancellationReason     // set once the order is cancelled
+	triggerPrice            *float64               // touch price of if-touched orders
+	triggeredAt             *time.Time             // set once an if-touched order's trigger is touched
+	settlementInstruction   *SettlementInstruction // explicit settlement routing (nil settles to the default account)
+	pegInstruction          *PegInstruction        // quote the price follows (nil keeps the limit price fixed)
+	repricedAt              *time.Time             // last time the peg moved the price
+	parentOrderID           string                 // order this bracket leg protects or TWAP slice belongs to (empty for standalone orders)
+	bracketStopLossPrice    *float64               // one-cancels-other exits placed once the entry fills (nil without a bracket)
+	bracketTakeProfitPrice  *float64
 }
 
 // NewOrderFromDatabase creates an Order from database data (for repository use)
@@ -56,6 +75,8 @@ func NewOrderFromDatabase(
 		executionPrice:          executionPrice,
 		marketPriceAtSubmission: marketPriceAtSubmission,
 		marketDataTimestamp:     marketDataTimestamp,
+		timeInForce:             TimeInForceDay,
+		allowPartialFill:        true,
 	}
 }
 
@@ -82,19 +103,27 @@ func NewOrder(userID, symbol string, orderSide OrderSide, orderType OrderType, q
 	if orderType == OrderTypeMarket && price != nil {
 		return nil, errors.New("market orders cannot have a price")
 	}
+	if orderType == OrderTypeLimitIfTouched && price == nil {
+		return nil, errors.New("limit if touched orders must have a limit price")
+	}
+	if orderType == OrderTypeMarketIfTouched && price != nil {
+		return nil, errors.New("market if touched orders cannot have a price")
+	}
 
 	now := time.Now()
 	return &Order{
-		id:        uuid.New().String(),
-		userID:    userID,
-		symbol:    symbol,
-		orderSide: orderSide,
-		orderType: orderType,
-		quantity:  quantity,
-		price:     price,
-		status:    OrderStatusPending,
-		createdAt: now,
-		updatedAt: now,
+		id:               uuid.New().String(),
+		userID:           userID,
+		symbol:           symbol,
+		orderSide:        orderSide,
+		orderType:        orderType,
+		quantity:         quantity,
+		price:            price,
+		status:           OrderStatusPending,
+		createdAt:        now,
+		updatedAt:        now,
+		timeInForce:      TimeInForceDay,
+		allowPartialFill: true,
 	}, nil
 }
 
@@ -117,6 +146,8 @@ func NewOrderFromRepository(id, userID, symbol string, orderSide OrderSide, orde
 		executionPrice:          executionPrice,
 		marketPriceAtSubmission: marketPriceAtSubmission,
 		marketDataTimestamp:     marketDataTimestamp,
+		timeInForce:             TimeInForceDay,
+		allowPartialFill:        true,
 	}
 }
 
@@ -127,6 +158,7 @@ func (o *Order) Symbol() string                    { return o.symbol }
 func (o *Order) OrderSide() OrderSide              { return o.orderSide }
 func (o *Order) OrderType() OrderType              { return o.orderType }
 func (o *Order) Quantity() float64                 { return o.quantity }
+func (o *Order) FilledQuantity() float64           { return o.filledQuantity }
 func (o *Order) Price() *float64                   { return o.price }
 func (o *Order) Status() OrderStatus               { return o.status }
 func (o *Order) CreatedAt() time.Time              { return o.createdAt }
@@ -135,6 +167,37 @@ func (o *Order) ExecutedAt() *time.Time            { return o.executedAt }
 func (o *Order) ExecutionPrice() *float64          { return o.executionPrice }
 func (o *Order) MarketPriceAtSubmission() *float64 { return o.marketPriceAtSubmission }
 func (o *Order) MarketDataTimestamp() *time.Time   { return o.marketDataTimestamp }
+func (o *Order) ProtectionLimitPrice() *float64    { return o.protectionLimitPrice }
+func (o *Order) TimeInForce() TimeInForce          { return o.timeInForce }
+func (o *Order) AllowPartialFill() bool            { return o.allowPartialFill }
+func (o *Order) ExecutionStrategyOverride() string { return o.executionStrategy }
+func (o *Order) CancellationReason() CancellationReason {
+	return o.cancellationReason
+}
+func (o *Order) PriceTickAdjustment() *PriceTickAdjustment {
+	return o.priceTickAdjustment
+}
+func (o *Order) TriggerPrice() *float64  { return o.triggerPrice }
+func (o *Order) TriggeredAt() *time.Time { return o.triggeredAt }
+
+// SettlementInstruction returns a copy of the explicit settlement instruction, or nil when the
+// order settles to the account's default
+func (o *Order) SettlementInstruction() *SettlementInstruction {
+	if o.settlementInstruction == nil {
+		return nil
+	}
+	instruction := *o.settlementInstruction
+	return &instruction
+}
+
+// MarketContextSnapshot returns a copy of the snapshot so callers cannot alter the recorded context
+func (o *Order) MarketContextSnapshot() *MarketContextSnapshot {
+	if o.marketContextSnapshot == nil {
+		return nil
+	}
+	snapshot := *o.marketContextSnapshot
+	return &snapshot
+}
 
 // Business Logic Methods
 
@@ -190,10 +253,337 @@ func (o *Order) SetMarketDataContext(marketPrice float64, timestamp time.Time) {
 	o.updatedAt = time.Now()
 }
 
+// ApplyMarketProtection turns a market order into a protected market order that
+// only fills up to the limit band (at or below it for buys, at or above it for sells)
+func (o *Order) ApplyMarketProtection(limitPrice float64) error {
+	if o.orderType != OrderTypeMarket {
+		return errors.New("market protection only applies to market orders")
+	}
+	if limitPrice <= 0 {
+		return errors.New("protection limit price must be positive")
+	}
+	o.protectionLimitPrice = &limitPrice
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// SetExecutionPreferences sets how long the order stays working and whether it may fill in parts
+func (o *Order) SetExecutionPreferences(timeInForce TimeInForce, allowPartialFill bool) error {
+	if !timeInForce.IsValid() {
+		return fmt.Errorf("invalid time in force: %s", timeInForce)
+	}
+	if timeInForce == TimeInForceFOK && allowPartialFill {
+		return errors.New("fill or kill orders cannot allow partial fills")
+	}
+	o.timeInForce = timeInForce
+	o.allowPartialFill = allowPartialFill
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// SetTriggerPrice sets the price whose touch activates an if-touched order
+func (o *Order) SetTriggerPrice(triggerPrice float64) error {
+	if !o.orderType.IsIfTouched() {
+		return fmt.Errorf("%s orders cannot have a trigger price", o.orderType)
+	}
+	if triggerPrice <= 0 {
+		return errors.New("trigger price must be positive")
+	}
+	o.triggerPrice = &triggerPrice
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// RestoreTriggerActivation marks the trigger as touched at the given time (for repository use)
+func (o *Order) RestoreTriggerActivation(triggeredAt time.Time) {
+	o.triggeredAt = &triggeredAt
+}
+
+// IsActivated reports whether the order can execute. If-touched orders rest inactive until their
+// trigger is touched; every other order is active from submission.
+func (o *Order) IsActivated() bool {
+	return !o.orderType.IsIfTouched() || o.triggeredAt != nil
+}
+
+// IsTouchedBy reports whether the market price touches the order's trigger. A buy triggers when
+// the price falls to the trigger or below, a sell when it rises to the trigger or above.
+func (o *Order) IsTouchedBy(marketPrice float64) bool {
+	if !o.orderType.IsIfTouched() || o.triggerPrice == nil || marketPrice <= 0 {
+		return false
+	}
+	if o.orderSide.IsBuy() {
+		return marketPrice <= *o.triggerPrice
+	}
+	return marketPrice >= *o.triggerPrice
+}
+
+// ActivateIfTouched activates an inactive if-touched order when the market price touches its
+// trigger, reporting whether this price activated it
+func (o *Order) ActivateIfTouched(marketPrice float64, at time.Time) bool {
+	if o.IsActivated() || !o.IsTouchedBy(marketPrice) {
+		return false
+	}
+	o.triggeredAt = &at
+	o.updatedAt = time.Now()
+	return true
+}
+
+// SetExecutionStrategyOverride records the execution strategy the user asked for instead of the
+// recommended one. The pricing service validates the strategy against the order; an empty value
+// clears the override.
+func (o *Order) SetExecutionStrategyOverride(strategy string) {
+	o.executionStrategy = strings.ToUpper(strings.TrimSpace(strategy))
+	o.updatedAt = time.Now()
+}
+
+// ParentOrderID returns the order this bracket leg protects or TWAP slice belongs to, or empty for a standalone order
+func (o *Order) ParentOrderID() string { return o.parentOrderID }
+
+// IsBracketLeg reports whether the order was generated to protect another order
+func (o *Order) IsBracketLeg() bool { return o.parentOrderID != "" }
+
+// IsProtectiveStop reports whether the order is the stop loss leg protecting a buy. The leg rests
+// until its parent fills and then waits for a quote to reach its stop price.
+func (o *Order) IsProtectiveStop() bool {
+	return o.IsBracketLeg() && o.orderType == OrderTypeStopLoss && o.orderSide == OrderSideSell
+}
+
+// BracketStopLossPrice returns the stop-loss exit of a bracketed entry, or nil without a bracket
+func (o *Order) BracketStopLossPrice() *float64 { return o.bracketStopLossPrice }
+
+// BracketTakeProfitPrice returns the take-profit exit of a bracketed entry, or nil without a bracket
+func (o *Order) BracketTakeProfitPrice() *float64 { return o.bracketTakeProfitPrice }
+
+// HasBracket reports whether the order is an entry with linked stop-loss and take-profit exits
+func (o *Order) HasBracket() bool {
+	return o.bracketStopLossPrice != nil && o.bracketTakeProfitPrice != nil
+}
+
+// SetBracket attaches one-cancels-other stop-loss and take-profit exits to the entry. Pricing checks
+// the exits against the entry price when it plans the order.
+func (o *Order) SetBracket(stopLossPrice, takeProfitPrice float64) error {
+	if o.IsBracketLeg() {
+		return errors.New("a bracket leg cannot carry its own bracket")
+	}
+	if stopLossPrice <= 0 || takeProfitPrice <= 0 {
+		return fmt.Errorf("bracket prices must be positive, got stop-loss %.4f and take-profit %.4f", stopLossPrice, takeProfitPrice)
+	}
+	o.bracketStopLossPrice = &stopLossPrice
+	o.bracketTakeProfitPrice = &takeProfitPrice
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// LinkToParent marks the order as a bracket leg of the parent order
+func (o *Order) LinkToParent(parentOrderID string) error {
+	if parentOrderID == "" {
+		return errors.New("parent order ID cannot be empty")
+	}
+	if parentOrderID == o.id {
+		return errors.New("an order cannot be its own parent")
+	}
+	o.parentOrderID = parentOrderID
+	return nil
+}
+
+// NewProtectiveStopOrder creates the stop loss leg protecting a buy: a sell of the same quantity
+// stopping stopPercent below the entry price, rounded to the cent
+func NewProtectiveStopOrder(parent *Order, entryPrice, stopPercent float64) (*Order, error) {
+	if parent == nil {
+		return nil, errors.New("parent order cannot be nil")
+	}
+	if !parent.IsBuyOrder() {
+		return nil, fmt.Errorf("protective stops only protect buy orders, got %s", parent.OrderSide())
+	}
+	if entryPrice <= 0 {
+		return nil, fmt.Errorf("entry price must be positive, got %.4f", entryPrice)
+	}
+	if err := ValidateProtectiveStopPercent(stopPercent); err != nil {
+		return nil, err
+	}
+
+	stopPrice := math.Round(entryPrice*(1-stopPercent/100)*100) / 100
+	if stopPrice <= 0 {
+		return nil, fmt.Errorf("protective stop price for entry %.4f is not positive", entryPrice)
+	}
+
+	stop, err := NewOrder(parent.UserID(), parent.Symbol(), OrderSideSell, OrderTypeStopLoss, parent.Quantity(), &stopPrice)
+	if err != nil {
+		return nil, err
+	}
+	if err := stop.LinkToParent(parent.ID()); err != nil {
+		return nil, err
+	}
+	return stop, nil
+}
+
+// NewTWAPSliceOrder creates one child order of a TWAP parent: the parent's order for the slice quantity
+func NewTWAPSliceOrder(parent *Order, quantity float64) (*Order, error) {
+	if parent == nil {
+		return nil, errors.New("parent order cannot be nil")
+	}
+
+	slice, err := NewOrder(parent.UserID(), parent.Symbol(), parent.OrderSide(), parent.OrderType(), quantity, parent.Price())
+	if err != nil {
+		return nil, err
+	}
+	if err := slice.SetExecutionPreferences(parent.TimeInForce(), parent.AllowPartialFill()); err != nil {
+		return nil, err
+	}
+	if err := slice.LinkToParent(parent.ID()); err != nil {
+		return nil, err
+	}
+	return slice, nil
+}
+
+// PegInstruction returns a copy of the peg instruction, or nil when the order is not pegged
+func (o *Order) PegInstruction() *PegInstruction {
+	if o.pegInstruction == nil {
+		return nil
+	}
+	return o.pegInstruction.copy()
+}
+
+// IsPegged reports whether the order's price follows the quote
+func (o *Order) IsPegged() bool { return o.pegInstruction != nil }
+
+func (o *Order) RepricedAt() *time.Time { return o.repricedAt }
+
+// SetPegInstruction makes a limit order follow the quote. The submitted price is kept until the
+// first quote reprices it.
+func (o *Order) SetPegInstruction(instruction *PegInstruction) error {
+	if instruction == nil {
+		o.pegInstruction = nil
+		o.updatedAt = time.Now()
+		return nil
+	}
+	if o.orderType != OrderTypeLimit {
+		return fmt.Errorf("only LIMIT orders can be pegged, got %s", o.orderType)
+	}
+
+	validated, err := NewPegInstruction(instruction.Reference.String(), instruction.Offset, instruction.MinPrice, instruction.MaxPrice)
+	if err != nil {
+		return err
+	}
+	o.pegInstruction = validated
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// RestoreRepricedAt sets the last repricing time of an order loaded from storage
+func (o *Order) RestoreRepricedAt(repricedAt time.Time) {
+	o.repricedAt = &repricedAt
+}
+
+// RepriceToPeg moves an open pegged order's price to its peg target at the quote, reporting whether
+// the price changed. Moves smaller than minChange are ignored so small quote flickers do not churn
+// the order.
+func (o *Order) RepriceToPeg(bid, ask, minChange float64, at time.Time) (bool, error) {
+	if o.pegInstruction == nil {
+		return false, errors.New("order is not pegged")
+	}
+	if !o.CanExecute() {
+		return false, fmt.Errorf("cannot reprice order in %s status", o.status)
+	}
+
+	target, err := o.pegInstruction.TargetPrice(bid, ask)
+	if err != nil {
+		return false, err
+	}
+	if o.price != nil && math.Abs(target-*o.price) < math.Max(minChange, 1e-9) {
+		return false, nil
+	}
+
+	o.price = &target
+	o.repricedAt = &at
+	o.updatedAt = at
+	return true, nil
+}
+
+// SetSettlementInstruction routes the order's settlement explicitly for downstream clearing.
+// Instructions are validated against the allowed codes; a nil instruction clears them.
+func (o *Order) SetSettlementInstruction(instruction *SettlementInstruction) error {
+	if instruction == nil {
+		o.settlementInstruction = nil
+		o.updatedAt = time.Now()
+		return nil
+	}
+
+	validated, err := NewSettlementInstruction(instruction.Account, instruction.Code.String())
+	if err != nil {
+		return err
+	}
+	o.settlementInstruction = validated
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// SnapPriceToTick rounds an off-tick price to the price step on the passive side (down for buys,
+// up for sells) so the adjustment never makes the order more aggressive than the user asked for.
+// The adjustment is recorded on the order; prices already on the tick are left untouched.
+func (o *Order) SnapPriceToTick(priceStep float64) (*PriceTickAdjustment, error) {
+	if o.price == nil {
+		return nil, errors.New("only priced orders can be snapped to the price step")
+	}
+	if priceStep <= 0 {
+		return nil, errors.New("price step must be positive")
+	}
+	if IsOnTick(*o.price, priceStep) {
+		return nil, nil
+	}
+
+	adjustedPrice := SnapToTick(*o.price, priceStep, o.IsSellOrder())
+	if adjustedPrice <= 0 {
+		return nil, fmt.Errorf("price %.4f is below the price step %.4f", *o.price, priceStep)
+	}
+
+	now := time.Now()
+	o.priceTickAdjustment = &PriceTickAdjustment{
+		OriginalPrice: *o.price,
+		AdjustedPrice: adjustedPrice,
+		PriceStep:     priceStep,
+		AdjustedAt:    now,
+	}
+	o.price = &adjustedPrice
+	o.updatedAt = now
+	return o.priceTickAdjustment, nil
+}
+
+// AttachMarketContextSnapshot records the market context at submission. The snapshot can
+// only be attached once; later attempts are rejected so the recorded context stays immutable.
+func (o *Order) AttachMarketContextSnapshot(snapshot MarketContextSnapshot) error {
+	if o.marketContextSnapshot != nil {
+		return errors.New("market context snapshot is already recorded")
+	}
+	if snapshot.Symbol != o.symbol {
+		return fmt.Errorf("snapshot symbol %s does not match order symbol %s", snapshot.Symbol, o.symbol)
+	}
+	if err := snapshot.Validate(); err != nil {
+		return fmt.Errorf("invalid market context snapshot: %w", err)
+	}
+	snapshot.OrderID = o.id
+	o.marketContextSnapshot = &snapshot
+	return nil
+}
+
+// IsProtectedMarketOrder returns true if the market order carries a protection limit band
+func (o *Order) IsProtectedMarketOrder() bool {
+	return o.orderType == OrderTypeMarket && o.protectionLimitPrice != nil
+}
+
+// CanTransitionTo checks the status change against the order state graph.
+// It returns an *InvalidStatusTransitionError for illegal transitions.
+func (o *Order) CanTransitionTo(newStatus OrderStatus) error {
+	if !o.status.CanTransitionTo(newStatus) {
+		return &InvalidStatusTransitionError{From: o.status, To: newStatus}
+	}
+	return nil
+}
+
 // MarkAsProcessing changes the order status to processing
 func (o *Order) MarkAsProcessing() error {
-	if !o.CanExecute() {
-		return errors.New("order cannot be processed in current status")
+	if err := o.CanTransitionTo(OrderStatusProcessing); err != nil {
+		return err
 	}
 	o.status = OrderStatusProcessing
 	o.updatedAt = time.Now()
@@ -202,8 +592,8 @@ func (o *Order) MarkAsProcessing() error {
 
 // MarkAsExecuted marks the order as executed with execution details
 func (o *Order) MarkAsExecuted(executionPrice float64) error {
-	if !o.CanExecute() {
-		return errors.New("order cannot be executed in current status")
+	if err := o.CanTransitionTo(OrderStatusExecuted); err != nil {
+		return err
 	}
 	now := time.Now()
 	o.status = OrderStatusExecuted
@@ -215,23 +605,110 @@ func (o *Order) MarkAsExecuted(executionPrice float64) error {
 
 // MarkAsFailed marks the order as failed
 func (o *Order) MarkAsFailed() error {
-	if o.status == OrderStatusExecuted {
-		return errors.New("cannot fail an already executed order")
-	}
-	if o.status == OrderStatusCancelled {
-		return errors.New("cannot fail a cancelled order")
+	if err := o.CanTransitionTo(OrderStatusFailed); err != nil {
+		return err
 	}
 	o.status = OrderStatusFailed
 	o.updatedAt = time.Now()
 	return nil
 }
 
-// MarkAsCancelled marks the order as cancelled
+// MarkAsCancelled marks the order as cancelled at the user's request
 func (o *Order) MarkAsCancelled() error {
-	if !o.CanCancel() {
-		return errors.New("order cannot be cancelled in current status")
+	return o.MarkAsCancelledWithReason(CancellationReasonUserRequested)
+}
+
+// MarkAsCancelledWithReason marks the order as cancelled and records why
+func (o *Order) MarkAsCancelledWithReason(reason CancellationReason) error {
+	if !reason.IsValid() {
+		return fmt.Errorf("invalid cancellation reason: %s", reason)
+	}
+	if err := o.CanTransitionTo(OrderStatusCancelled); err != nil {
+		return err
 	}
 	o.status = OrderStatusCancelled
+	o.cancellationReason = reason
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// SetCancellationReason restores the cancellation reason of an order loaded from storage
+func (o *Order) SetCancellationReason(reason CancellationReason) {
+	o.cancellationReason = reason
+}
+
+// OpenQuantity returns the quantity still waiting to be filled
+func (o *Order) OpenQuantity() float64 {
+	return o.quantity - o.filledQuantity
+}
+
+// RecordFill registers a fill against the order without changing its status
+func (o *Order) RecordFill(fillQuantity float64) error {
+	if fillQuantity <= 0 {
+		return errors.New("fill quantity must be positive")
+	}
+	if fillQuantity > o.OpenQuantity() {
+		return fmt.Errorf("fill quantity %.8f exceeds open quantity %.8f", fillQuantity, o.OpenQuantity())
+	}
+	o.filledQuantity += fillQuantity
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// RestoreFilledQuantity sets the filled quantity of an order loaded from storage
+func (o *Order) RestoreFilledQuantity(filledQuantity float64) error {
+	if filledQuantity < 0 || filledQuantity > o.quantity {
+		return fmt.Errorf("filled quantity %.8f must be between 0 and the order quantity %.8f", filledQuantity, o.quantity)
+	}
+	o.filledQuantity = filledQuantity
+	return nil
+}
+
+// RecordFillAt registers a priced fill against the order without changing its status,
+// keeping the individual execution alongside the filled quantity
+func (o *Order) RecordFillAt(fillQuantity, fillPrice float64, filledAt time.Time) (OrderFill, error) {
+	if fillPrice <= 0 {
+		return OrderFill{}, errors.New("fill price must be positive")
+	}
+	if err := o.RecordFill(fillQuantity); err != nil {
+		return OrderFill{}, err
+	}
+
+	fill := NewOrderFill(o.id, fillQuantity, fillPrice, filledAt)
+	o.fills = append(o.fills, fill)
+	return fill, nil
+}
+
+// Fills returns the fills recorded against the order, oldest first
+func (o *Order) Fills() []OrderFill {
+	fills := make([]OrderFill, len(o.fills))
+	copy(fills, o.fills)
+	return fills
+}
+
+// ReduceQuantity partially cancels the order by lowering its total quantity.
+// Reducing down to the filled quantity leaves nothing open and cancels the order.
+func (o *Order) ReduceQuantity(newQuantity float64) error {
+	if !o.CanCancel() {
+		return errors.New("order quantity cannot be reduced in current status")
+	}
+	if newQuantity >= o.quantity {
+		return fmt.Errorf("new quantity %.8f must be less than current quantity %.8f", newQuantity, o.quantity)
+	}
+	if newQuantity < o.filledQuantity {
+		return fmt.Errorf("new quantity %.8f cannot be below filled quantity %.8f", newQuantity, o.filledQuantity)
+	}
+
+	if newQuantity == o.filledQuantity {
+		// Nothing left open: equivalent to a full cancel. An unfilled order keeps its
+		// original quantity, exactly as a regular cancellation would.
+		if newQuantity > 0 {
+			o.quantity = newQuantity
+		}
+		return o.MarkAsCancelledWithReason(CancellationReasonUserRequested)
+	}
+
+	o.quantity = newQuantity
 	o.updatedAt = time.Now()
 	return nil
 }
@@ -273,6 +750,10 @@ func (o *Order) ValidateForExecution(currentMarketPrice float64) error {
 		return errors.New("order cannot be executed in current status")
 	}
 
+	if o.IsProtectedMarketOrder() {
+		return o.ValidateProtectionBand(currentMarketPrice)
+	}
+
 	// For limit orders, check if the limit price is reasonable based on order side
 	if o.orderType != OrderTypeLimit {
 		return nil
@@ -295,6 +776,22 @@ func (o *Order) ValidateForExecution(currentMarketPrice float64) error {
 	return nil
 }
 
+// ValidateProtectionBand rejects executions that would fill beyond the protection limit.
+// Orders without market protection always pass.
+func (o *Order) ValidateProtectionBand(currentMarketPrice float64) error {
+	if !o.IsProtectedMarketOrder() {
+		return nil
+	}
+	limit := *o.protectionLimitPrice
+	if o.orderSide.IsBuy() && currentMarketPrice > limit {
+		return fmt.Errorf("market price %.2f is above the protection limit %.2f", currentMarketPrice, limit)
+	}
+	if o.orderSide.IsSell() && currentMarketPrice < limit {
+		return fmt.Errorf("market price %.2f is below the protection limit %.2f", currentMarketPrice, limit)
+	}
+	return nil
+}
+
 func validateBuySide(o *Order, currentMarketPrice float64, tolerance float64) error {
 	// Buy limit order: limit price should not be too far above market price
 	if *o.price > currentMarketPrice*(1+tolerance) {
@@ -353,6 +850,9 @@ func (o *Order) Validate() error {
 	if o.orderType == OrderTypeLimit && o.price != nil && *o.price <= 0 {
 		return errors.New("limit price must be positive")
 	}
+	if o.orderType.IsIfTouched() && o.triggerPrice == nil {
+		return errors.New("if touched orders must have a trigger price")
+	}
 	return nil
 }
 
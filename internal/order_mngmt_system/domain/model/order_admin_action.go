@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// OrderAdminActionType identifies an action taken by staff on a user's order
+type OrderAdminActionType string
+
+const (
+	OrderAdminActionForceCancel OrderAdminActionType = "FORCE_CANCEL"
+)
+
+// OrderAdminAction records an action support or operations staff took on a user's order, who
+// took it and why, so interventions outside the owner's control can be traced
+// @Description Audit record of an admin action on a user's order
+type OrderAdminAction struct {
+	ID             string               `json:"id"`
+	OrderID        string               `json:"order_id"`
+	OrderOwnerID   string               `json:"order_owner_id"`
+	Action         OrderAdminActionType `json:"action"`
+	PerformedBy    string               `json:"performed_by"`
+	Reason         string               `json:"reason"`
+	PreviousStatus OrderStatus          `json:"previous_status"`
+	NewStatus      OrderStatus          `json:"new_status"`
+	PerformedAt    time.Time            `json:"performed_at"`
+}
+
+// Validate checks that the action can be stored
+func (a *OrderAdminAction) Validate() error {
+	if a.OrderID == "" {
+		return errors.New("order ID cannot be empty")
+	}
+	if a.Action == "" {
+		return errors.New("action cannot be empty")
+	}
+	if a.PerformedBy == "" {
+		return errors.New("performed by cannot be empty")
+	}
+	if a.PerformedAt.IsZero() {
+		return errors.New("performed at cannot be zero")
+	}
+	return nil
+}
@@ -14,12 +14,13 @@ type DomainEvent interface {
 }
 
 type OrderEvent struct {
-	eventID     string
-	eventType   string
-	aggregateID string
-	occurredAt  time.Time
-	orderID     string
-	userID      string
+	eventID        string
+	eventType      string
+	aggregateID    string
+	occurredAt     time.Time
+	orderID        string
+	userID         string
+	sequenceNumber int64
 }
 
 func NewOrderEvent(eventType, orderID, userID string) OrderEvent {
@@ -57,6 +58,15 @@ func (e OrderEvent) UserID() string {
 	return e.userID
 }
 
+// SequenceNumber orders events of the same order; consumers discard events older than the last applied one
+func (e OrderEvent) SequenceNumber() int64 {
+	return e.sequenceNumber
+}
+
+func (e *OrderEvent) SetSequenceNumber(sequenceNumber int64) {
+	e.sequenceNumber = sequenceNumber
+}
+
 type OrderSubmittedEvent struct {
 	OrderEvent
 	Symbol    string
@@ -102,6 +112,7 @@ type OrderExecutedEvent struct {
 	TotalValue          float64
 	MarketPriceAtExec   *float64
 	MarketDataTimestamp *time.Time
+	PartialFill         bool // Set when the order still has open quantity after this execution
 }
 
 // NewOrderExecutedEventWithDetails creates a new OrderExecutedEvent with full data
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderFill is one execution that filled part or all of an order
+// @Description Individual fill of an order
+type OrderFill struct {
+	ID       string    `json:"fill_id"`
+	OrderID  string    `json:"order_id"`
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	FilledAt time.Time `json:"filled_at"`
+}
+
+// Value returns the traded value of the fill
+func (f OrderFill) Value() float64 {
+	return f.Quantity * f.Price
+}
+
+// OrderFillSummary aggregates the fills of an order
+// @Description Summary of the fills that composed an order
+type OrderFillSummary struct {
+	FillCount        int        `json:"fill_count"`
+	FilledQuantity   float64    `json:"filled_quantity"`
+	AverageFillPrice float64    `json:"average_fill_price"`
+	FirstFillAt      *time.Time `json:"first_fill_at,omitempty"`
+	LastFillAt       *time.Time `json:"last_fill_at,omitempty"`
+}
+
+// NewOrderFill creates a fill record for an order
+func NewOrderFill(orderID string, quantity, price float64, filledAt time.Time) OrderFill {
+	return OrderFill{
+		ID:       uuid.New().String(),
+		OrderID:  orderID,
+		Quantity: quantity,
+		Price:    price,
+		FilledAt: filledAt,
+	}
+}
+
+// SummarizeFills returns the fill count, total quantity and quantity-weighted average price of the fills
+func SummarizeFills(fills []OrderFill) OrderFillSummary {
+	summary := OrderFillSummary{FillCount: len(fills)}
+
+	var totalValue float64
+	for _, fill := range fills {
+		summary.FilledQuantity += fill.Quantity
+		totalValue += fill.Value()
+
+		filledAt := fill.FilledAt
+		if summary.FirstFillAt == nil || filledAt.Before(*summary.FirstFillAt) {
+			summary.FirstFillAt = &filledAt
+		}
+		if summary.LastFillAt == nil || filledAt.After(*summary.LastFillAt) {
+			summary.LastFillAt = &filledAt
+		}
+	}
+
+	if summary.FilledQuantity > 0 {
+		summary.AverageFillPrice = totalValue / summary.FilledQuantity
+	}
+
+	return summary
+}
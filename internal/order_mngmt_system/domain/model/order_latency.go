@@ -0,0 +1,101 @@
+package domain
+
+import "time"
+
+// OrderLatencyStage is a checkpoint an order passes on its way from submission to execution
+type OrderLatencyStage string
+
+const (
+	OrderLatencyStageSubmitted OrderLatencyStage = "SUBMITTED" // Submission received
+	OrderLatencyStageValidated OrderLatencyStage = "VALIDATED" // Symbol, trading hours, halts and price checks passed
+	OrderLatencyStagePriced    OrderLatencyStage = "PRICED"    // Order built against market data and ready to save
+	OrderLatencyStagePublished OrderLatencyStage = "PUBLISHED" // Saved and queued for a worker
+	OrderLatencyStageProcessed OrderLatencyStage = "PROCESSED" // Worker finished processing, executed or failed
+)
+
+// AllOrderLatencyStages returns the stages in the order an order passes them
+func AllOrderLatencyStages() []OrderLatencyStage {
+	return []OrderLatencyStage{
+		OrderLatencyStageSubmitted,
+		OrderLatencyStageValidated,
+		OrderLatencyStagePriced,
+		OrderLatencyStagePublished,
+		OrderLatencyStageProcessed,
+	}
+}
+
+func (s OrderLatencyStage) String() string {
+	return string(s)
+}
+
+// OrderStageTimestamp records when an order reached a stage
+type OrderStageTimestamp struct {
+	Stage         OrderLatencyStage `json:"stage"`
+	At            time.Time         `json:"at"`
+	SincePrevious time.Duration     `json:"since_previous_ns"`
+}
+
+// OrderLatencyTimeline holds the stage timestamps of one order
+type OrderLatencyTimeline struct {
+	OrderID string                `json:"order_id"`
+	Stages  []OrderStageTimestamp `json:"stages"`
+}
+
+// NewOrderLatencyTimeline starts an empty timeline for the order
+func NewOrderLatencyTimeline(orderID string) *OrderLatencyTimeline {
+	return &OrderLatencyTimeline{
+		OrderID: orderID,
+		Stages:  make([]OrderStageTimestamp, 0, len(AllOrderLatencyStages())),
+	}
+}
+
+// Record adds the stage to the timeline. Stages recorded again are ignored, and a timestamp
+// earlier than the previous stage (clock skew between API and worker hosts) is raised to it so
+// the timeline stays monotonic. It reports whether the stage was added.
+func (t *OrderLatencyTimeline) Record(stage OrderLatencyStage, at time.Time) (OrderStageTimestamp, bool) {
+	if _, exists := t.StageTime(stage); exists {
+		return OrderStageTimestamp{}, false
+	}
+
+	entry := OrderStageTimestamp{Stage: stage, At: at}
+	if len(t.Stages) > 0 {
+		previous := t.Stages[len(t.Stages)-1].At
+		if entry.At.Before(previous) {
+			entry.At = previous
+		}
+		entry.SincePrevious = entry.At.Sub(previous)
+	}
+
+	t.Stages = append(t.Stages, entry)
+	return entry, true
+}
+
+// StageTime returns when the order reached the stage
+func (t *OrderLatencyTimeline) StageTime(stage OrderLatencyStage) (time.Time, bool) {
+	for _, entry := range t.Stages {
+		if entry.Stage == stage {
+			return entry.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// TotalLatency returns the time from submission to worker processing, once both are recorded
+func (t *OrderLatencyTimeline) TotalLatency() (time.Duration, bool) {
+	submittedAt, submitted := t.StageTime(OrderLatencyStageSubmitted)
+	processedAt, processed := t.StageTime(OrderLatencyStageProcessed)
+	if !submitted || !processed {
+		return 0, false
+	}
+	return processedAt.Sub(submittedAt), true
+}
+
+// Clone returns a copy that is safe to hand out while the original keeps recording
+func (t *OrderLatencyTimeline) Clone() *OrderLatencyTimeline {
+	clone := &OrderLatencyTimeline{
+		OrderID: t.OrderID,
+		Stages:  make([]OrderStageTimestamp, len(t.Stages)),
+	}
+	copy(clone.Stages, t.Stages)
+	return clone
+}
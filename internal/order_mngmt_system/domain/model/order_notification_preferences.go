@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderNotificationEvent is an order lifecycle event a user can be notified about
+// @Description Order event that can trigger a notification
+type OrderNotificationEvent string
+
+const (
+	OrderNotificationSubmitted OrderNotificationEvent = "SUBMITTED"
+	OrderNotificationFilled    OrderNotificationEvent = "FILLED"
+	OrderNotificationCancelled OrderNotificationEvent = "CANCELLED"
+	OrderNotificationRejected  OrderNotificationEvent = "REJECTED"
+)
+
+// IsValid checks if the event is a known order notification event
+func (e OrderNotificationEvent) IsValid() bool {
+	switch e {
+	case OrderNotificationSubmitted, OrderNotificationFilled, OrderNotificationCancelled, OrderNotificationRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the order notification event
+func (e OrderNotificationEvent) String() string {
+	return string(e)
+}
+
+// ParseOrderNotificationEvent parses a string into an OrderNotificationEvent
+func ParseOrderNotificationEvent(s string) (OrderNotificationEvent, error) {
+	event := OrderNotificationEvent(strings.ToUpper(strings.TrimSpace(s)))
+	if !event.IsValid() {
+		return "", fmt.Errorf("invalid order notification event: %s", s)
+	}
+	return event, nil
+}
+
+// NotificationChannel is how a notification reaches the user
+// @Description Notification delivery channel
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "IN_APP"
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+	NotificationChannelPush  NotificationChannel = "PUSH"
+)
+
+// IsValid checks if the channel is a known notification channel
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case NotificationChannelInApp, NotificationChannelEmail, NotificationChannelPush:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the notification channel
+func (c NotificationChannel) String() string {
+	return string(c)
+}
+
+// ParseNotificationChannel parses a string into a NotificationChannel
+func ParseNotificationChannel(s string) (NotificationChannel, error) {
+	channel := NotificationChannel(strings.ToUpper(strings.TrimSpace(s)))
+	if !channel.IsValid() {
+		return "", fmt.Errorf("invalid notification channel: %s", s)
+	}
+	return channel, nil
+}
+
+// OrderNotificationPreferences holds the order events a user opted in to and the channel each
+// one is delivered through. Events missing from Channels are opted out.
+// @Description Per-user order notification settings
+type OrderNotificationPreferences struct {
+	UserID    string                                         `json:"user_id"`
+	Channels  map[OrderNotificationEvent]NotificationChannel `json:"channels"`
+	UpdatedAt time.Time                                      `json:"updated_at"`
+}
+
+// Validate checks that every opted-in event is known and uses a known channel
+func (p *OrderNotificationPreferences) Validate() error {
+	if p.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	for event, channel := range p.Channels {
+		if !event.IsValid() {
+			return fmt.Errorf("invalid order notification event: %s", event)
+		}
+		if !channel.IsValid() {
+			return fmt.Errorf("invalid notification channel for %s: %s", event, channel)
+		}
+	}
+	return nil
+}
+
+// ChannelFor returns the channel the event is delivered through, or false when the user opted out
+func (p *OrderNotificationPreferences) ChannelFor(event OrderNotificationEvent) (NotificationChannel, bool) {
+	if p == nil {
+		return "", false
+	}
+	channel, optedIn := p.Channels[event]
+	return channel, optedIn
+}
@@ -75,6 +75,16 @@ func (s OrderStatus) CanTransitionTo(target OrderStatus) bool {
 	}
 }
 
+// InvalidStatusTransitionError is returned when an order status change is not allowed by the state graph
+type InvalidStatusTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("invalid order status transition from %s to %s", e.From, e.To)
+}
+
 // ParseOrderStatus parses a string into an OrderStatus
 func ParseOrderStatus(s string) (OrderStatus, error) {
 	status := OrderStatus(s)
@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"fmt"
 	"testing"
 	"time"
 
@@ -167,6 +168,63 @@ func TestOrder_CanCancelExecute(t *testing.T) {
 	assert.False(t, order.CanExecute()) // A failed order might be retried, so it can be executed
 }
 
+func TestOrder_ReduceQuantity(t *testing.T) {
+	t.Run("valid reduction keeps order open", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, float64Ptr(150.0))
+		assert.NoError(t, order.RecordFill(30))
+
+		err := order.ReduceQuantity(60)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 60.0, order.Quantity())
+		assert.Equal(t, 30.0, order.OpenQuantity())
+		assert.Equal(t, domain.OrderStatusPending, order.Status())
+	})
+
+	t.Run("reduction to exactly filled cancels the order", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, float64Ptr(150.0))
+		assert.NoError(t, order.RecordFill(30))
+
+		err := order.ReduceQuantity(30)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, order.Quantity())
+		assert.Equal(t, 0.0, order.OpenQuantity())
+		assert.True(t, order.IsCancelled())
+	})
+
+	t.Run("reduction to zero on unfilled order is a full cancel", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, float64Ptr(150.0))
+
+		err := order.ReduceQuantity(0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100.0, order.Quantity())
+		assert.True(t, order.IsCancelled())
+	})
+
+	t.Run("reduction below filled is rejected", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, float64Ptr(150.0))
+		assert.NoError(t, order.RecordFill(30))
+
+		err := order.ReduceQuantity(20)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be below filled quantity")
+		assert.Equal(t, 100.0, order.Quantity())
+		assert.Equal(t, domain.OrderStatusPending, order.Status())
+	})
+
+	t.Run("increase is rejected", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, float64Ptr(150.0))
+
+		err := order.ReduceQuantity(150)
+
+		assert.Error(t, err)
+		assert.Equal(t, 100.0, order.Quantity())
+	})
+}
+
 func TestOrder_SetMarketDataContext(t *testing.T) {
 	order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
 	marketPrice := 150.5
@@ -194,6 +252,7 @@ func TestOrder_Marking(t *testing.T) {
 	t.Run("MarkAsExecuted", func(t *testing.T) {
 		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
 		executionPrice := 152.0
+		assert.NoError(t, order.MarkAsProcessing())
 		err := order.MarkAsExecuted(executionPrice)
 		assert.NoError(t, err)
 		assert.Equal(t, domain.OrderStatusExecuted, order.Status())
@@ -213,10 +272,13 @@ func TestOrder_Marking(t *testing.T) {
 		assert.Equal(t, domain.OrderStatusFailed, order.Status())
 
 		order, _ = domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		order.MarkAsProcessing()
 		order.MarkAsExecuted(150.0)
 		err = order.MarkAsFailed()
-		if assert.Error(t, err) {
-			assert.Equal(t, "cannot fail an already executed order", err.Error())
+		var transitionErr *domain.InvalidStatusTransitionError
+		if assert.ErrorAs(t, err, &transitionErr) {
+			assert.Equal(t, domain.OrderStatusExecuted, transitionErr.From)
+			assert.Equal(t, domain.OrderStatusFailed, transitionErr.To)
 		}
 	})
 
@@ -232,6 +294,58 @@ func TestOrder_Marking(t *testing.T) {
 	})
 }
 
+func TestOrder_CanTransitionTo(t *testing.T) {
+	newOrderInStatus := func(status domain.OrderStatus) *domain.Order {
+		return domain.NewOrderFromDatabase("id", "user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil,
+			status, time.Now(), time.Now(), nil, nil, nil, nil)
+	}
+
+	legal := map[domain.OrderStatus][]domain.OrderStatus{
+		domain.OrderStatusPending:    {domain.OrderStatusProcessing, domain.OrderStatusFailed, domain.OrderStatusCancelled},
+		domain.OrderStatusProcessing: {domain.OrderStatusExecuted, domain.OrderStatusFailed, domain.OrderStatusCancelled},
+		domain.OrderStatusExecuted:   {},
+		domain.OrderStatusFailed:     {},
+		domain.OrderStatusCancelled:  {},
+	}
+
+	for _, from := range domain.AllOrderStatuses() {
+		for _, to := range domain.AllOrderStatuses() {
+			allowed := false
+			for _, target := range legal[from] {
+				if target == to {
+					allowed = true
+				}
+			}
+
+			t.Run(fmt.Sprintf("%s to %s", from, to), func(t *testing.T) {
+				err := newOrderInStatus(from).CanTransitionTo(to)
+				if allowed {
+					assert.NoError(t, err)
+					return
+				}
+
+				var transitionErr *domain.InvalidStatusTransitionError
+				if assert.ErrorAs(t, err, &transitionErr) {
+					assert.Equal(t, from, transitionErr.From)
+					assert.Equal(t, to, transitionErr.To)
+				}
+			})
+		}
+	}
+}
+
+func TestOrder_IllegalTransitionLeavesStatusUnchanged(t *testing.T) {
+	order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	assert.NoError(t, order.MarkAsProcessing())
+	assert.NoError(t, order.MarkAsExecuted(150.0))
+
+	var transitionErr *domain.InvalidStatusTransitionError
+	assert.ErrorAs(t, order.MarkAsProcessing(), &transitionErr)
+	assert.ErrorAs(t, order.MarkAsCancelled(), &transitionErr)
+	assert.ErrorAs(t, order.MarkAsFailed(), &transitionErr)
+	assert.Equal(t, domain.OrderStatusExecuted, order.Status())
+}
+
 func TestOrder_CalculateValues(t *testing.T) {
 	price := 150.0
 	limitOrder, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
@@ -241,6 +355,7 @@ func TestOrder_CalculateValues(t *testing.T) {
 	assert.Equal(t, 0.0, marketOrder.CalculateOrderValue())
 
 	executionPrice := 152.0
+	limitOrder.MarkAsProcessing()
 	limitOrder.MarkAsExecuted(executionPrice)
 	assert.Equal(t, 1520.0, limitOrder.CalculateExecutionValue())
 	assert.Equal(t, 0.0, marketOrder.CalculateExecutionValue())
@@ -295,12 +410,136 @@ func TestOrder_ValidateForExecution(t *testing.T) {
 
 	t.Run("cannot execute order in wrong status", func(t *testing.T) {
 		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		order.MarkAsProcessing()
 		order.MarkAsExecuted(150.0)
 		err := order.ValidateForExecution(150.0)
 		assert.Error(t, err)
 	})
 }
 
+func TestOrder_ApplyMarketProtection(t *testing.T) {
+	t.Run("market order becomes protected", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		err := order.ApplyMarketProtection(151.5)
+		assert.NoError(t, err)
+		assert.True(t, order.IsProtectedMarketOrder())
+		assert.Equal(t, 151.5, *order.ProtectionLimitPrice())
+		assert.Nil(t, order.Price())
+	})
+
+	t.Run("limit order cannot be protected", func(t *testing.T) {
+		price := 150.0
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+		err := order.ApplyMarketProtection(151.5)
+		assert.Error(t, err)
+		assert.False(t, order.IsProtectedMarketOrder())
+	})
+
+	t.Run("protected buy rejects fills above the band", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		_ = order.ApplyMarketProtection(151.5)
+		assert.NoError(t, order.ValidateForExecution(151.0))
+		assert.Error(t, order.ValidateForExecution(152.0))
+	})
+
+	t.Run("protected sell rejects fills below the band", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideSell, domain.OrderTypeMarket, 10, nil)
+		_ = order.ApplyMarketProtection(148.5)
+		assert.NoError(t, order.ValidateProtectionBand(149.0))
+		assert.Error(t, order.ValidateProtectionBand(148.0))
+	})
+}
+
+func TestOrder_SetExecutionPreferences(t *testing.T) {
+	t.Run("new orders default to day with partial fills", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		assert.Equal(t, domain.TimeInForceDay, order.TimeInForce())
+		assert.True(t, order.AllowPartialFill())
+	})
+
+	t.Run("preferences are applied", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		err := order.SetExecutionPreferences(domain.TimeInForceGTC, false)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TimeInForceGTC, order.TimeInForce())
+		assert.False(t, order.AllowPartialFill())
+	})
+
+	t.Run("fill or kill cannot allow partial fills", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		assert.Error(t, order.SetExecutionPreferences(domain.TimeInForceFOK, true))
+		assert.Error(t, order.SetExecutionPreferences(domain.TimeInForce("WEEK"), true))
+		assert.Equal(t, domain.TimeInForceDay, order.TimeInForce())
+	})
+}
+
+func TestOrder_SetSettlementInstruction(t *testing.T) {
+	t.Run("valid instructions are normalized and stored", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		instruction, err := domain.NewSettlementInstruction(" cust-00123 ", "dvp")
+		assert.NoError(t, err)
+		assert.NoError(t, order.SetSettlementInstruction(instruction))
+		assert.Equal(t, &domain.SettlementInstruction{Account: "CUST-00123", Code: domain.SettlementInstructionDVP}, order.SettlementInstruction())
+	})
+
+	t.Run("invalid codes are rejected", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		_, err := domain.NewSettlementInstruction("CUST-00123", "WIRE")
+		assert.Error(t, err)
+		assert.Error(t, order.SetSettlementInstruction(&domain.SettlementInstruction{Account: "CUST-00123", Code: "WIRE"}))
+		assert.Nil(t, order.SettlementInstruction())
+	})
+
+	t.Run("invalid accounts are rejected", func(t *testing.T) {
+		_, err := domain.NewSettlementInstruction("", "FOP")
+		assert.Error(t, err)
+		_, err = domain.NewSettlementInstruction("acct 1", "FOP")
+		assert.Error(t, err)
+	})
+
+	t.Run("nil clears the instruction", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		instruction, _ := domain.NewSettlementInstruction("CUST-00123", "RVP")
+		_ = order.SetSettlementInstruction(instruction)
+		assert.NoError(t, order.SetSettlementInstruction(nil))
+		assert.Nil(t, order.SettlementInstruction())
+	})
+}
+
+func TestOrder_AttachMarketContextSnapshot(t *testing.T) {
+	snapshot := domain.MarketContextSnapshot{
+		Symbol:     "AAPL",
+		BidPrice:   150.40,
+		AskPrice:   150.60,
+		LastPrice:  150.50,
+		CapturedAt: time.Now(),
+	}
+
+	t.Run("snapshot is recorded once", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		assert.NoError(t, order.AttachMarketContextSnapshot(snapshot))
+		assert.Equal(t, order.ID(), order.MarketContextSnapshot().OrderID)
+
+		changed := snapshot
+		changed.BidPrice = 140.0
+		assert.Error(t, order.AttachMarketContextSnapshot(changed))
+		assert.Equal(t, 150.40, order.MarketContextSnapshot().BidPrice)
+	})
+
+	t.Run("returned snapshot is a copy", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		_ = order.AttachMarketContextSnapshot(snapshot)
+		order.MarketContextSnapshot().AskPrice = 999.0
+		assert.Equal(t, 150.60, order.MarketContextSnapshot().AskPrice)
+	})
+
+	t.Run("snapshot for another symbol is rejected", func(t *testing.T) {
+		order, _ := domain.NewOrder("user1", "MSFT", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		assert.Error(t, order.AttachMarketContextSnapshot(snapshot))
+		assert.Nil(t, order.MarketContextSnapshot())
+	})
+}
+
 func TestOrder_ValidatePositionForSellOrder(t *testing.T) {
 	sellOrder, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideSell, domain.OrderTypeMarket, 10, nil)
 	buyOrder, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
@@ -400,3 +639,229 @@ func TestOrder_SideMethods(t *testing.T) {
 	assert.True(t, sellOrder.IsSellOrder())
 	assert.True(t, sellOrder.RequiresPositionValidation())
 }
+
+func TestOrder_IfTouchedActivation(t *testing.T) {
+	at := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	t.Run("buy market if touched activates when the price falls to the trigger", func(t *testing.T) {
+		order, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarketIfTouched, 10, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, order.SetTriggerPrice(145.0))
+		assert.False(t, order.IsActivated())
+
+		assert.False(t, order.ActivateIfTouched(145.01, at))
+		assert.False(t, order.IsActivated())
+		assert.Nil(t, order.TriggeredAt())
+
+		assert.True(t, order.ActivateIfTouched(145.0, at))
+		assert.True(t, order.IsActivated())
+		assert.Equal(t, at, *order.TriggeredAt())
+
+		assert.False(t, order.ActivateIfTouched(140.0, at.Add(time.Second)))
+		assert.Equal(t, at, *order.TriggeredAt())
+	})
+
+	t.Run("sell limit if touched activates when the price rises to the trigger", func(t *testing.T) {
+		order, err := domain.NewOrder("user1", "AAPL", domain.OrderSideSell, domain.OrderTypeLimitIfTouched, 10, float64Ptr(154.0))
+		assert.NoError(t, err)
+		assert.NoError(t, order.SetTriggerPrice(155.0))
+
+		assert.False(t, order.IsTouchedBy(154.99))
+		assert.True(t, order.IsTouchedBy(155.0))
+		assert.True(t, order.ActivateIfTouched(155.5, at))
+		assert.Equal(t, domain.OrderTypeLimit, order.OrderType().ActivatesAs())
+	})
+
+	t.Run("should reject a trigger price on other order types", func(t *testing.T) {
+		order, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		assert.NoError(t, err)
+		assert.Error(t, order.SetTriggerPrice(145.0))
+		assert.True(t, order.IsActivated())
+	})
+
+	t.Run("should require a trigger price to validate", func(t *testing.T) {
+		order, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarketIfTouched, 10, nil)
+		assert.NoError(t, err)
+		assert.EqualError(t, order.Validate(), "if touched orders must have a trigger price")
+	})
+}
+
+func TestOrder_PeggedRepricing(t *testing.T) {
+	at := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	t.Run("bid pegged order reprices as the bid moves within its bounds", func(t *testing.T) {
+		order, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, float64Ptr(150.0))
+		assert.NoError(t, err)
+		peg, err := domain.NewPegInstruction("BID", -0.05, float64Ptr(148.0), float64Ptr(151.0))
+		assert.NoError(t, err)
+		assert.NoError(t, order.SetPegInstruction(peg))
+		assert.True(t, order.IsPegged())
+
+		changed, err := order.RepriceToPeg(150.20, 150.30, 0.01, at)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.InDelta(t, 150.15, *order.Price(), 1e-9)
+		assert.Equal(t, at, *order.RepricedAt())
+
+		changed, err = order.RepriceToPeg(149.50, 149.60, 0.01, at.Add(time.Second))
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.InDelta(t, 149.45, *order.Price(), 1e-9)
+
+		// The bid runs away in both directions; the price stops at the bounds
+		changed, err = order.RepriceToPeg(155.00, 155.10, 0.01, at.Add(2*time.Second))
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, 151.0, *order.Price())
+
+		changed, err = order.RepriceToPeg(140.00, 140.10, 0.01, at.Add(3*time.Second))
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, 148.0, *order.Price())
+
+		changed, err = order.RepriceToPeg(139.00, 139.10, 0.01, at.Add(4*time.Second))
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, at.Add(3*time.Second), *order.RepricedAt())
+	})
+
+	t.Run("mid and ask pegs follow their side of the quote", func(t *testing.T) {
+		mid, err := domain.NewPegInstruction("mid", 0, nil, nil)
+		assert.NoError(t, err)
+		price, err := mid.TargetPrice(10.00, 10.10)
+		assert.NoError(t, err)
+		assert.InDelta(t, 10.05, price, 1e-9)
+
+		ask, err := domain.NewPegInstruction("ASK", 0.02, nil, nil)
+		assert.NoError(t, err)
+		price, err = ask.TargetPrice(10.00, 10.10)
+		assert.NoError(t, err)
+		assert.InDelta(t, 10.12, price, 1e-9)
+
+		_, err = mid.TargetPrice(0, 10.10)
+		assert.Error(t, err)
+	})
+
+	t.Run("default band fills only the missing bounds", func(t *testing.T) {
+		peg, err := domain.NewPegInstruction("BID", 0, nil, float64Ptr(105.0))
+		assert.NoError(t, err)
+		banded := peg.WithDefaultBand(100.0, 10)
+		assert.InDelta(t, 90.0, *banded.MinPrice, 1e-9)
+		assert.Equal(t, 105.0, *banded.MaxPrice)
+	})
+
+	t.Run("should reject invalid pegs", func(t *testing.T) {
+		_, err := domain.NewPegInstruction("LAST", 0, nil, nil)
+		assert.Error(t, err)
+		_, err = domain.NewPegInstruction("BID", 0, float64Ptr(10.0), float64Ptr(9.0))
+		assert.Error(t, err)
+
+		market, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+		assert.NoError(t, err)
+		peg, err := domain.NewPegInstruction("BID", 0, nil, nil)
+		assert.NoError(t, err)
+		assert.Error(t, market.SetPegInstruction(peg))
+
+		_, err = market.RepriceToPeg(10.0, 10.1, 0.01, at)
+		assert.Error(t, err)
+	})
+
+	t.Run("should not reprice a cancelled order", func(t *testing.T) {
+		order, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, float64Ptr(150.0))
+		assert.NoError(t, err)
+		peg, err := domain.NewPegInstruction("BID", 0, nil, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, order.SetPegInstruction(peg))
+		assert.NoError(t, order.MarkAsCancelled())
+
+		_, err = order.RepriceToPeg(151.0, 151.1, 0.01, at)
+		assert.Error(t, err)
+		assert.Equal(t, 150.0, *order.Price())
+	})
+}
+
+func TestNewProtectiveStopOrder(t *testing.T) {
+	buy, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, float64Ptr(150.0))
+	assert.NoError(t, err)
+
+	stop, err := domain.NewProtectiveStopOrder(buy, 150.0, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderSideSell, stop.OrderSide())
+	assert.Equal(t, domain.OrderTypeStopLoss, stop.OrderType())
+	assert.Equal(t, 10.0, stop.Quantity())
+	assert.Equal(t, 142.50, *stop.Price())
+	assert.Equal(t, buy.ID(), stop.ParentOrderID())
+	assert.True(t, stop.IsBracketLeg())
+	assert.False(t, buy.IsBracketLeg())
+
+	stop, err = domain.NewProtectiveStopOrder(buy, 33.33, 7.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.83, *stop.Price())
+
+	sell, err := domain.NewOrder("user1", "AAPL", domain.OrderSideSell, domain.OrderTypeLimit, 10, float64Ptr(150.0))
+	assert.NoError(t, err)
+	_, err = domain.NewProtectiveStopOrder(sell, 150.0, 5)
+	assert.Error(t, err)
+
+	_, err = domain.NewProtectiveStopOrder(buy, 150.0, 0)
+	assert.Error(t, err)
+	_, err = domain.NewProtectiveStopOrder(buy, 150.0, 100)
+	assert.Error(t, err)
+	assert.Error(t, buy.LinkToParent(buy.ID()))
+}
+
+func TestOrder_SetBracket(t *testing.T) {
+	buy, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, float64Ptr(150.0))
+	assert.NoError(t, err)
+	assert.False(t, buy.HasBracket())
+
+	assert.NoError(t, buy.SetBracket(140.0, 170.0))
+	assert.True(t, buy.HasBracket())
+	assert.Equal(t, 140.0, *buy.BracketStopLossPrice())
+	assert.Equal(t, 170.0, *buy.BracketTakeProfitPrice())
+
+	assert.Error(t, buy.SetBracket(0, 170.0))
+	assert.Error(t, buy.SetBracket(140.0, -1))
+
+	stop, err := domain.NewProtectiveStopOrder(buy, 150.0, 5)
+	assert.NoError(t, err)
+	assert.Error(t, stop.SetBracket(130.0, 160.0))
+	assert.False(t, stop.HasBracket())
+}
+
+func TestNewTWAPSliceOrder(t *testing.T) {
+	parent, err := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100, float64Ptr(150.0))
+	assert.NoError(t, err)
+	assert.NoError(t, parent.SetExecutionPreferences(domain.TimeInForceGTC, true))
+
+	slice, err := domain.NewTWAPSliceOrder(parent, 10)
+	assert.NoError(t, err)
+	assert.NotEqual(t, parent.ID(), slice.ID())
+	assert.Equal(t, domain.OrderSideBuy, slice.OrderSide())
+	assert.Equal(t, domain.OrderTypeLimit, slice.OrderType())
+	assert.Equal(t, 10.0, slice.Quantity())
+	assert.Equal(t, 150.0, *slice.Price())
+	assert.Equal(t, domain.TimeInForceGTC, slice.TimeInForce())
+	assert.Equal(t, parent.ID(), slice.ParentOrderID())
+
+	_, err = domain.NewTWAPSliceOrder(parent, 0)
+	assert.Error(t, err)
+	_, err = domain.NewTWAPSliceOrder(nil, 10)
+	assert.Error(t, err)
+}
+
+func TestUserOrderPreferences_ProtectiveStop(t *testing.T) {
+	preferences := &domain.UserOrderPreferences{UserID: "user1", ProtectiveStopEnabled: true, ProtectiveStopPercent: 5}
+	assert.NoError(t, preferences.Validate())
+	percent, enabled := preferences.ProtectiveStop()
+	assert.True(t, enabled)
+	assert.Equal(t, 5.0, percent)
+
+	preferences.ProtectiveStopPercent = 0
+	assert.Error(t, preferences.Validate())
+
+	preferences.ProtectiveStopEnabled = false
+	assert.NoError(t, preferences.Validate())
+	_, enabled = preferences.ProtectiveStop()
+	assert.False(t, enabled)
+}
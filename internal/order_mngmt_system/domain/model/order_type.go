@@ -18,6 +18,12 @@ const (
 
 	// OrderTypeStopLimit represents a stop limit order (becomes limit order when stop price is reached)
 	OrderTypeStopLimit OrderType = "STOP_LIMIT"
+
+	// OrderTypeMarketIfTouched rests inactive until its trigger price is touched, then becomes a market order
+	OrderTypeMarketIfTouched OrderType = "MARKET_IF_TOUCHED"
+
+	// OrderTypeLimitIfTouched rests inactive until its trigger price is touched, then becomes a limit order
+	OrderTypeLimitIfTouched OrderType = "LIMIT_IF_TOUCHED"
 )
 
 // AllOrderTypes returns all valid order types
@@ -27,13 +33,16 @@ func AllOrderTypes() []OrderType {
 		OrderTypeLimit,
 		OrderTypeStopLoss,
 		OrderTypeStopLimit,
+		OrderTypeMarketIfTouched,
+		OrderTypeLimitIfTouched,
 	}
 }
 
 // IsValid checks if the order type is valid
 func (t OrderType) IsValid() bool {
 	switch t {
-	case OrderTypeMarket, OrderTypeLimit, OrderTypeStopLoss, OrderTypeStopLimit:
+	case OrderTypeMarket, OrderTypeLimit, OrderTypeStopLoss, OrderTypeStopLimit,
+		OrderTypeMarketIfTouched, OrderTypeLimitIfTouched:
 		return true
 	default:
 		return false
@@ -48,9 +57,9 @@ func (t OrderType) String() string {
 // RequiresPrice checks if the order type requires a price to be specified
 func (t OrderType) RequiresPrice() bool {
 	switch t {
-	case OrderTypeLimit, OrderTypeStopLoss, OrderTypeStopLimit:
+	case OrderTypeLimit, OrderTypeStopLoss, OrderTypeStopLimit, OrderTypeLimitIfTouched:
 		return true
-	case OrderTypeMarket:
+	case OrderTypeMarket, OrderTypeMarketIfTouched:
 		return false
 	default:
 		return false
@@ -64,7 +73,25 @@ func (t OrderType) IsImmediateExecution() bool {
 
 // IsConditional checks if the order type is conditional (depends on market conditions)
 func (t OrderType) IsConditional() bool {
-	return t == OrderTypeStopLoss || t == OrderTypeStopLimit
+	return t == OrderTypeStopLoss || t == OrderTypeStopLimit || t.IsIfTouched()
+}
+
+// IsIfTouched checks if the order type rests inactive until a trigger price is touched
+func (t OrderType) IsIfTouched() bool {
+	return t == OrderTypeMarketIfTouched || t == OrderTypeLimitIfTouched
+}
+
+// ActivatesAs returns the order type an if-touched order executes as once its trigger is touched.
+// Other order types are returned unchanged.
+func (t OrderType) ActivatesAs() OrderType {
+	switch t {
+	case OrderTypeMarketIfTouched:
+		return OrderTypeMarket
+	case OrderTypeLimitIfTouched:
+		return OrderTypeLimit
+	default:
+		return t
+	}
 }
 
 // ParseOrderType parses a string into an OrderType
@@ -87,6 +114,10 @@ func (t OrderType) GetDescription() string {
 		return "Trigger when price reaches specified stop price"
 	case OrderTypeStopLimit:
 		return "Becomes limit order when stop price is reached"
+	case OrderTypeMarketIfTouched:
+		return "Becomes market order when trigger price is touched"
+	case OrderTypeLimitIfTouched:
+		return "Becomes limit order when trigger price is touched"
 	default:
 		return "Unknown order type"
 	}
@@ -100,9 +131,9 @@ func (t OrderType) GetExecutionPriority() int {
 		return 1 // Highest priority - immediate execution
 	case OrderTypeLimit:
 		return 2 // Medium priority - price dependent
-	case OrderTypeStopLoss:
+	case OrderTypeStopLoss, OrderTypeMarketIfTouched:
 		return 3 // Lower priority - conditional
-	case OrderTypeStopLimit:
+	case OrderTypeStopLimit, OrderTypeLimitIfTouched:
 		return 4 // Lowest priority - conditional + price dependent
 	default:
 		return 5 // Unknown types get lowest priority
@@ -148,6 +179,9 @@ func (t OrderType) CanExecuteAtPrice(orderPrice, marketPrice *float64, orderSide
 	case OrderTypeStopLimit:
 		// More complex logic - would need additional fields for stop price vs limit price
 		return false // Not implemented in this simplified version
+	case OrderTypeMarketIfTouched, OrderTypeLimitIfTouched:
+		// The trigger is tracked on the order; once touched the order executes like the type it becomes
+		return t.ActivatesAs().CanExecuteAtPrice(orderPrice, marketPrice, orderSide)
 	default:
 		return false
 	}
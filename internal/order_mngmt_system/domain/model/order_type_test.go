@@ -14,6 +14,8 @@ func TestAllOrderTypes(t *testing.T) {
 		domain.OrderTypeLimit,
 		domain.OrderTypeStopLoss,
 		domain.OrderTypeStopLimit,
+		domain.OrderTypeMarketIfTouched,
+		domain.OrderTypeLimitIfTouched,
 	}
 	assert.ElementsMatch(t, expected, domain.AllOrderTypes())
 }
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PegReference is the side of the quote a pegged order's price follows
+// @Description Peg reference price
+type PegReference string
+
+const (
+	PegReferenceBid PegReference = "BID"
+	PegReferenceMid PegReference = "MID"
+	PegReferenceAsk PegReference = "ASK"
+)
+
+// IsValid checks if the peg reference is a known reference
+func (r PegReference) IsValid() bool {
+	switch r {
+	case PegReferenceBid, PegReferenceMid, PegReferenceAsk:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the peg reference
+func (r PegReference) String() string {
+	return string(r)
+}
+
+// ParsePegReference parses a string into a PegReference
+func ParsePegReference(s string) (PegReference, error) {
+	reference := PegReference(strings.ToUpper(strings.TrimSpace(s)))
+	if !reference.IsValid() {
+		return "", fmt.Errorf("invalid peg reference: %s", s)
+	}
+	return reference, nil
+}
+
+// PegInstruction makes a limit order follow the quote: its price is the reference price plus the
+// offset, kept within the bounds
+type PegInstruction struct {
+	Reference PegReference
+	Offset    float64  // Added to the reference price; negative offsets sit below it
+	MinPrice  *float64 // The order is never repriced below this
+	MaxPrice  *float64 // The order is never repriced above this
+}
+
+// NewPegInstruction validates a peg reference, offset and optional price bounds
+func NewPegInstruction(reference string, offset float64, minPrice, maxPrice *float64) (*PegInstruction, error) {
+	pegReference, err := ParsePegReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return nil, errors.New("peg offset must be a finite number")
+	}
+	if minPrice != nil && *minPrice <= 0 {
+		return nil, errors.New("peg minimum price must be positive")
+	}
+	if maxPrice != nil && *maxPrice <= 0 {
+		return nil, errors.New("peg maximum price must be positive")
+	}
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return nil, fmt.Errorf("peg minimum price %.4f is above the maximum price %.4f", *minPrice, *maxPrice)
+	}
+
+	instruction := &PegInstruction{Reference: pegReference, Offset: offset}
+	if minPrice != nil {
+		bound := *minPrice
+		instruction.MinPrice = &bound
+	}
+	if maxPrice != nil {
+		bound := *maxPrice
+		instruction.MaxPrice = &bound
+	}
+	return instruction, nil
+}
+
+// WithDefaultBand fills missing bounds with a band of bandPercent around the anchor price, so a
+// runaway quote cannot drag the order arbitrarily far from where it was placed
+func (p PegInstruction) WithDefaultBand(anchorPrice, bandPercent float64) PegInstruction {
+	if anchorPrice <= 0 || bandPercent <= 0 {
+		return p
+	}
+	if p.MinPrice == nil {
+		minPrice := math.Max(anchorPrice*(1-bandPercent/100), 0)
+		if minPrice > 0 {
+			p.MinPrice = &minPrice
+		}
+	}
+	if p.MaxPrice == nil {
+		maxPrice := anchorPrice * (1 + bandPercent/100)
+		p.MaxPrice = &maxPrice
+	}
+	return p
+}
+
+// ReferencePrice returns the quote price the peg follows
+func (p PegInstruction) ReferencePrice(bid, ask float64) (float64, error) {
+	switch p.Reference {
+	case PegReferenceBid:
+		if bid <= 0 {
+			return 0, errors.New("quote has no bid")
+		}
+		return bid, nil
+	case PegReferenceAsk:
+		if ask <= 0 {
+			return 0, errors.New("quote has no ask")
+		}
+		return ask, nil
+	case PegReferenceMid:
+		if bid <= 0 || ask <= 0 {
+			return 0, errors.New("quote needs both bid and ask for a mid peg")
+		}
+		return (bid + ask) / 2, nil
+	default:
+		return 0, fmt.Errorf("invalid peg reference: %s", p.Reference)
+	}
+}
+
+// TargetPrice returns the price the peg asks for at the quote, clamped to the bounds
+func (p PegInstruction) TargetPrice(bid, ask float64) (float64, error) {
+	reference, err := p.ReferencePrice(bid, ask)
+	if err != nil {
+		return 0, err
+	}
+
+	target := reference + p.Offset
+	if p.MinPrice != nil && target < *p.MinPrice {
+		target = *p.MinPrice
+	}
+	if p.MaxPrice != nil && target > *p.MaxPrice {
+		target = *p.MaxPrice
+	}
+	if target <= 0 {
+		return 0, fmt.Errorf("peg price %.4f is not positive", target)
+	}
+	return target, nil
+}
+
+func (p PegInstruction) copy() *PegInstruction {
+	instruction := p
+	if p.MinPrice != nil {
+		bound := *p.MinPrice
+		instruction.MinPrice = &bound
+	}
+	if p.MaxPrice != nil {
+		bound := *p.MaxPrice
+		instruction.MaxPrice = &bound
+	}
+	return &instruction
+}
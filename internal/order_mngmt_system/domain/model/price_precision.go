@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"math"
+)
+
+const maxPricePrecision = 9 // Matches the nine decimals snapped prices are rounded to
+
+// PricePrecisionForStep returns the decimal places needed to quote prices on the price step,
+// e.g. 2 for 0.01 and 3 for 0.125. Without a price step prices are quoted to cents.
+func PricePrecisionForStep(priceStep float64) int {
+	if priceStep <= 0 {
+		return 2
+	}
+
+	for precision := 0; precision < maxPricePrecision; precision++ {
+		scaled := priceStep * math.Pow10(precision)
+		if math.Abs(scaled-math.Round(scaled)) < tickEpsilon*math.Pow10(precision) {
+			return precision
+		}
+	}
+	return maxPricePrecision
+}
+
+// RoundToPrecision rounds the price to the decimal places. A negative precision leaves the price unchanged.
+func RoundToPrecision(price float64, precision int) float64 {
+	if precision < 0 {
+		return price
+	}
+	if precision > maxPricePrecision {
+		precision = maxPricePrecision
+	}
+
+	scale := math.Pow10(precision)
+	return math.Round(price*scale) / scale
+}
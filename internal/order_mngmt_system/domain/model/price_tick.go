@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// TickSizePolicy decides what happens to order prices that are not a multiple of the symbol's price step
+// @Description Tick size enforcement policy enumeration
+type TickSizePolicy string
+
+const (
+	// TickSizePolicyWarn accepts off-tick prices and only reports the price step
+	TickSizePolicyWarn TickSizePolicy = "WARN"
+
+	// TickSizePolicyReject rejects orders with off-tick prices
+	TickSizePolicyReject TickSizePolicy = "REJECT"
+
+	// TickSizePolicyRound snaps off-tick prices to the nearest valid tick on the passive side
+	TickSizePolicyRound TickSizePolicy = "ROUND"
+)
+
+const (
+	tickEpsilon   = 1e-9 // Absorbs floating point noise when comparing prices to multiples of the price step
+	tickPrecision = 1e9  // Snapped prices are rounded to nine decimals
+)
+
+// IsValid checks if the tick size policy is valid
+func (p TickSizePolicy) IsValid() bool {
+	switch p {
+	case TickSizePolicyWarn, TickSizePolicyReject, TickSizePolicyRound:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the tick size policy
+func (p TickSizePolicy) String() string {
+	return string(p)
+}
+
+// ParseTickSizePolicy parses a string into a TickSizePolicy
+func ParseTickSizePolicy(s string) (TickSizePolicy, error) {
+	policy := TickSizePolicy(s)
+	if !policy.IsValid() {
+		return "", fmt.Errorf("invalid tick size policy: %s", s)
+	}
+	return policy, nil
+}
+
+// PriceTickAdjustment records an order price snapped to the symbol's price step
+// @Description Price adjustment applied to match the symbol's minimum tick
+type PriceTickAdjustment struct {
+	OriginalPrice float64   `json:"original_price"`
+	AdjustedPrice float64   `json:"adjusted_price"`
+	PriceStep     float64   `json:"price_step"`
+	AdjustedAt    time.Time `json:"adjusted_at"`
+}
+
+// IsOnTick reports whether the price is a multiple of the price step. Without a price step every price is valid.
+func IsOnTick(price, priceStep float64) bool {
+	if priceStep <= 0 {
+		return true
+	}
+	ticks := price / priceStep
+	return math.Abs(ticks-math.Round(ticks)) < tickEpsilon
+}
+
+// SnapToTick rounds the price to a multiple of the price step, down when roundUp is false
+func SnapToTick(price, priceStep float64, roundUp bool) float64 {
+	if priceStep <= 0 {
+		return price
+	}
+
+	ticks := price / priceStep
+	if roundUp {
+		ticks = math.Ceil(ticks - tickEpsilon)
+	} else {
+		ticks = math.Floor(ticks + tickEpsilon)
+	}
+
+	// Multiplying back reintroduces binary noise (e.g. 150.05000000000001)
+	return math.Round(ticks*priceStep*tickPrecision) / tickPrecision
+}
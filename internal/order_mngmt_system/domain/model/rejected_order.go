@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// RejectReason classifies why an order submission was rejected
+type RejectReason string
+
+const (
+	RejectReasonInvalidSymbol      RejectReason = "INVALID_SYMBOL"
+	RejectReasonMarketClosed       RejectReason = "MARKET_CLOSED"
+	RejectReasonVolatilityHalt     RejectReason = "VOLATILITY_HALT"
+	RejectReasonPriceOutOfRange    RejectReason = "PRICE_OUT_OF_RANGE"
+	RejectReasonInvalidOrder       RejectReason = "INVALID_ORDER"
+	RejectReasonBusinessValidation RejectReason = "BUSINESS_VALIDATION"
+	RejectReasonFatFinger          RejectReason = "FAT_FINGER"
+)
+
+// RejectedOrder records an order submission that was rejected before it was created, so
+// support and analytics can see what the user tried and why it failed
+// @Description Order submission rejected before the order was created
+type RejectedOrder struct {
+	ID            string       `json:"id"`
+	UserID        string       `json:"user_id"`
+	Symbol        string       `json:"symbol"`
+	OrderSide     string       `json:"order_side"`
+	OrderType     string       `json:"order_type"`
+	Quantity      float64      `json:"quantity"`
+	Price         *float64     `json:"price,omitempty"`
+	ReasonCode    RejectReason `json:"reason_code"`
+	ReasonDetails string       `json:"reason_details"`
+	RejectedAt    time.Time    `json:"rejected_at"`
+}
+
+// RejectReasonCount is the number of rejections with one reason code
+type RejectReasonCount struct {
+	ReasonCode RejectReason `json:"reason_code"`
+	Count      int          `json:"count"`
+}
+
+// Validate checks that the rejection can be stored
+func (r *RejectedOrder) Validate() error {
+	if r.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if r.ReasonCode == "" {
+		return errors.New("reason code cannot be empty")
+	}
+	if r.RejectedAt.IsZero() {
+		return errors.New("rejection time cannot be zero")
+	}
+	return nil
+}
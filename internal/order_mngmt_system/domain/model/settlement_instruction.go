@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SettlementInstructionCode tells the clearing integration how an order settles
+// @Description Settlement instruction code
+type SettlementInstructionCode string
+
+const (
+	SettlementInstructionDVP SettlementInstructionCode = "DVP" // delivery versus payment
+	SettlementInstructionRVP SettlementInstructionCode = "RVP" // receive versus payment
+	SettlementInstructionFOP SettlementInstructionCode = "FOP" // free of payment
+)
+
+// settlementAccountPattern accepts the alphanumeric custody account identifiers used by clearing
+var settlementAccountPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{2,33}$`)
+
+// IsValid checks if the settlement instruction code is a known code
+func (c SettlementInstructionCode) IsValid() bool {
+	switch c {
+	case SettlementInstructionDVP, SettlementInstructionRVP, SettlementInstructionFOP:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the settlement instruction code
+func (c SettlementInstructionCode) String() string {
+	return string(c)
+}
+
+// ParseSettlementInstructionCode parses a string into a SettlementInstructionCode
+func ParseSettlementInstructionCode(s string) (SettlementInstructionCode, error) {
+	code := SettlementInstructionCode(strings.ToUpper(strings.TrimSpace(s)))
+	if !code.IsValid() {
+		return "", fmt.Errorf("invalid settlement instruction code: %s", s)
+	}
+	return code, nil
+}
+
+// SettlementInstruction routes an order's settlement to an account other than the default one
+type SettlementInstruction struct {
+	Account string
+	Code    SettlementInstructionCode
+}
+
+// NewSettlementInstruction validates and normalizes explicit settlement instructions
+func NewSettlementInstruction(account, code string) (*SettlementInstruction, error) {
+	account = strings.ToUpper(strings.TrimSpace(account))
+	if account == "" {
+		return nil, errors.New("settlement account is required")
+	}
+	if !settlementAccountPattern.MatchString(account) {
+		return nil, fmt.Errorf("invalid settlement account: %s", account)
+	}
+
+	instructionCode, err := ParseSettlementInstructionCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SettlementInstruction{Account: account, Code: instructionCode}, nil
+}
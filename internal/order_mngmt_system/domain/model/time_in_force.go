@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+// TimeInForce represents how long an order stays working before it expires
+// @Description Time in force enumeration
+type TimeInForce string
+
+const (
+	// TimeInForceDay keeps the order working until the end of the trading day
+	TimeInForceDay TimeInForce = "DAY"
+
+	// TimeInForceGTC keeps the order working until it is filled or cancelled
+	TimeInForceGTC TimeInForce = "GTC"
+
+	// TimeInForceIOC fills what it can immediately and cancels the rest
+	TimeInForceIOC TimeInForce = "IOC"
+
+	// TimeInForceFOK fills the whole quantity immediately or cancels the order
+	TimeInForceFOK TimeInForce = "FOK"
+)
+
+// IsValid checks if the time in force is valid
+func (t TimeInForce) IsValid() bool {
+	switch t {
+	case TimeInForceDay, TimeInForceGTC, TimeInForceIOC, TimeInForceFOK:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the time in force
+func (t TimeInForce) String() string {
+	return string(t)
+}
+
+// ParseTimeInForce parses a string into a TimeInForce
+func ParseTimeInForce(s string) (TimeInForce, error) {
+	timeInForce := TimeInForce(s)
+	if !timeInForce.IsValid() {
+		return "", fmt.Errorf("invalid time in force: %s", s)
+	}
+	return timeInForce, nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// UserOrderPreferences holds the defaults applied to a user's order submissions when the
+// request leaves a field unspecified. Empty values and a nil AllowPartialFill mean "no default".
+// @Description Per-user default order settings
+type UserOrderPreferences struct {
+	UserID             string      `json:"user_id"`
+	DefaultOrderType   OrderType   `json:"default_order_type,omitempty"`
+	DefaultTimeInForce TimeInForce `json:"default_time_in_force,omitempty"`
+	AllowPartialFill   *bool       `json:"allow_partial_fill,omitempty"`
+
+	// ProtectiveStopEnabled attaches a stop loss ProtectiveStopPercent below entry to every buy
+	ProtectiveStopEnabled bool    `json:"protective_stop_enabled"`
+	ProtectiveStopPercent float64 `json:"protective_stop_percent,omitempty"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks that the configured defaults are valid order settings
+func (p *UserOrderPreferences) Validate() error {
+	if p.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if p.DefaultOrderType != "" && !p.DefaultOrderType.IsValid() {
+		return fmt.Errorf("invalid default order type: %s", p.DefaultOrderType)
+	}
+	if p.DefaultTimeInForce != "" && !p.DefaultTimeInForce.IsValid() {
+		return fmt.Errorf("invalid default time in force: %s", p.DefaultTimeInForce)
+	}
+	if p.ProtectiveStopEnabled {
+		if err := ValidateProtectiveStopPercent(p.ProtectiveStopPercent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProtectiveStop returns the stop percent to attach to a buy, or false when the preference is off
+func (p *UserOrderPreferences) ProtectiveStop() (float64, bool) {
+	if p == nil || !p.ProtectiveStopEnabled {
+		return 0, false
+	}
+	return p.ProtectiveStopPercent, true
+}
+
+// ValidateProtectiveStopPercent checks that a protective stop sits strictly between entry and zero
+func ValidateProtectiveStopPercent(percent float64) error {
+	if math.IsNaN(percent) || percent <= 0 || percent >= 100 {
+		return fmt.Errorf("protective stop percent must be between 0 and 100, got %.2f", percent)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IExecutionQualityRepository defines the contract for execution quality report persistence
+type IExecutionQualityRepository interface {
+	// Save stores the report, replacing any earlier report for the same order
+	Save(ctx context.Context, report *domain.ExecutionQualityReport) error
+
+	// FindByOrderID retrieves the report for an order, returning nil when none exists
+	FindByOrderID(ctx context.Context, orderID string) (*domain.ExecutionQualityReport, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IMarketContextSnapshotRepository defines the contract for market context snapshot persistence.
+// Snapshots are write-once: saving a second snapshot for the same order must not replace the first.
+type IMarketContextSnapshotRepository interface {
+	// Save stores the snapshot, keeping any snapshot already recorded for the order
+	Save(ctx context.Context, snapshot *domain.MarketContextSnapshot) error
+
+	// FindByOrderID retrieves the snapshot for an order, returning nil when none exists
+	FindByOrderID(ctx context.Context, orderID string) (*domain.MarketContextSnapshot, error)
+}
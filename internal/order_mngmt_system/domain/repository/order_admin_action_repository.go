@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IOrderAdminActionRepository defines the contract for the audit trail of admin actions on orders
+type IOrderAdminActionRepository interface {
+	// Save stores an admin action
+	Save(ctx context.Context, action *domain.OrderAdminAction) error
+
+	// FindByOrderID retrieves the admin actions taken on the order, most recent first
+	FindByOrderID(ctx context.Context, orderID string) ([]*domain.OrderAdminAction, error)
+}
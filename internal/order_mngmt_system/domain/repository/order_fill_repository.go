@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IOrderFillRepository defines the contract for persisting the individual fills of orders
+type IOrderFillRepository interface {
+	// Save stores a fill; saving a fill that already exists is a no-op
+	Save(ctx context.Context, fill *domain.OrderFill) error
+
+	// FindByOrderID retrieves an order's fills, oldest first
+	FindByOrderID(ctx context.Context, orderID string) ([]domain.OrderFill, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IOrderNotificationPreferencesRepository defines the contract for per-user order notification settings
+type IOrderNotificationPreferencesRepository interface {
+	// Save stores the preferences, replacing any earlier preferences for the same user
+	Save(ctx context.Context, preferences *domain.OrderNotificationPreferences) error
+
+	// FindByUserID retrieves the user's preferences, returning nil when none exist
+	FindByUserID(ctx context.Context, userID string) (*domain.OrderNotificationPreferences, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IPositionUpdatePublicationRepository records which executed orders had their position update
+// published, so updates lost to a worker crash can be found and republished
+type IPositionUpdatePublicationRepository interface {
+	// MarkPublished records that the order's position update was published; marking an order again is a no-op
+	MarkPublished(ctx context.Context, orderID string, publishedAt time.Time) error
+
+	// IsPublished reports whether the order's position update was published
+	IsPublished(ctx context.Context, orderID string) (bool, error)
+}
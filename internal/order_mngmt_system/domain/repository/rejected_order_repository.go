@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IRejectedOrderRepository defines the contract for rejected order persistence
+type IRejectedOrderRepository interface {
+	// Save stores a rejected order submission
+	Save(ctx context.Context, rejection *domain.RejectedOrder) error
+
+	// FindByUserID retrieves the user's rejected orders, most recent first
+	FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error)
+
+	// CountByReason aggregates rejections recorded in [from, to) by reason code, most frequent first
+	CountByReason(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error)
+}
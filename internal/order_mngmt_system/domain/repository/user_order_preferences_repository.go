@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IUserOrderPreferencesRepository defines the contract for per-user default order settings
+type IUserOrderPreferencesRepository interface {
+	// Save stores the preferences, replacing any earlier preferences for the same user
+	Save(ctx context.Context, preferences *domain.UserOrderPreferences) error
+
+	// FindByUserID retrieves the user's preferences, returning nil when none exist
+	FindByUserID(ctx context.Context, userID string) (*domain.UserOrderPreferences, error)
+}
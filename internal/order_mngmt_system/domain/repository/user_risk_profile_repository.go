@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// IUserRiskProfileRepository defines the contract for risk profiles adjusted by support and risk staff
+type IUserRiskProfileRepository interface {
+	// FindByUserID retrieves the user's stored profile, returning nil when none exists
+	FindByUserID(ctx context.Context, userID string) (*service.UserRiskProfile, error)
+
+	// SaveWithAudit stores the profile, replacing any earlier profile for the same user, together
+	// with the audit entry describing the change
+	SaveWithAudit(ctx context.Context, profile *service.UserRiskProfile, entry *service.RiskProfileAuditEntry) error
+
+	// FindAuditByUserID lists the changes made to the user's profile, most recent first
+	FindAuditByUserID(ctx context.Context, userID string) ([]*service.RiskProfileAuditEntry, error)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// BracketConfig holds the exit legs attached to an entry order. Both legs rest once the entry
+// fills, and a fill of either cancels the other.
+type BracketConfig struct {
+	StopLossPrice   *float64 // Exit limiting the loss, below the entry for a buy and above it for a sell
+	TakeProfitPrice *float64 // Exit locking in the gain, above the entry for a buy and below it for a sell
+}
+
+// Validate checks that both exit prices are set, positive and on the correct side of the entry price
+func (b BracketConfig) Validate(side domain.OrderSide, entryPrice float64) error {
+	if b.StopLossPrice == nil || b.TakeProfitPrice == nil {
+		return errors.New("bracket requires both a stop-loss and a take-profit price")
+	}
+	stopLoss, takeProfit := *b.StopLossPrice, *b.TakeProfitPrice
+	if stopLoss <= 0 || takeProfit <= 0 {
+		return fmt.Errorf("bracket prices must be positive, got stop-loss %.4f and take-profit %.4f", stopLoss, takeProfit)
+	}
+	if entryPrice <= 0 {
+		return fmt.Errorf("bracket entry price must be positive, got %.4f", entryPrice)
+	}
+
+	if side == domain.OrderSideBuy {
+		if takeProfit <= entryPrice {
+			return fmt.Errorf("take-profit %.4f must be above the entry %.4f for a buy", takeProfit, entryPrice)
+		}
+		if stopLoss >= entryPrice {
+			return fmt.Errorf("stop-loss %.4f must be below the entry %.4f for a buy", stopLoss, entryPrice)
+		}
+		return nil
+	}
+
+	if takeProfit >= entryPrice {
+		return fmt.Errorf("take-profit %.4f must be below the entry %.4f for a sell", takeProfit, entryPrice)
+	}
+	if stopLoss <= entryPrice {
+		return fmt.Errorf("stop-loss %.4f must be above the entry %.4f for a sell", stopLoss, entryPrice)
+	}
+	return nil
+}
+
+func (b BracketConfig) copy() *BracketConfig {
+	stopLoss, takeProfit := *b.StopLossPrice, *b.TakeProfitPrice
+	return &BracketConfig{StopLossPrice: &stopLoss, TakeProfitPrice: &takeProfit}
+}
+
+// bracketConfigOf returns the exits of a bracketed entry, or nil for an order without a bracket
+func bracketConfigOf(order *domain.Order) *BracketConfig {
+	if !order.HasBracket() {
+		return nil
+	}
+	return BracketConfig{StopLossPrice: order.BracketStopLossPrice(), TakeProfitPrice: order.BracketTakeProfitPrice()}.copy()
+}
+
+// attachBracket adds the exit legs to the entry's plan. Limit entries are checked against their
+// limit price, market entries against the estimated fill. The entry keeps its own execution
+// instructions, followed by the instructions for the exits.
+func (s *orderPricingService) attachBracket(order *domain.Order, bracket BracketConfig, plan *ExecutionPlan, pricingClient IPricingDataClient) (*ExecutionPlan, error) {
+	entryPrice := plan.EstimatedFillPrice
+	if order.Price() != nil {
+		entryPrice = *order.Price()
+	} else if err := bracket.Validate(order.OrderSide(), entryPrice); err != nil {
+		return nil, fmt.Errorf("invalid bracket: %w", err)
+	}
+
+	plan.RecommendedStrategy = ExecutionStrategyBracket
+	plan.Bracket = bracket.copy()
+	s.generateBracketInstructions(order, plan)
+
+	if marketPrice, err := pricingClient.GetCurrentMarketPrice(order.Symbol()); err != nil {
+		plan.RiskWarnings = append(plan.RiskWarnings, fmt.Sprintf("Could not assess spread around the stop-loss: %s", err.Error()))
+	} else if warning := s.stopLossSpreadWarning(marketPrice, entryPrice, *plan.Bracket.StopLossPrice); warning != "" {
+		plan.RiskWarnings = append(plan.RiskWarnings, warning)
+	}
+
+	return plan, nil
+}
+
+func (s *orderPricingService) generateBracketInstructions(order *domain.Order, plan *ExecutionPlan) {
+	exitSide := domain.OrderSideSell
+	if !order.IsBuyOrder() {
+		exitSide = domain.OrderSideBuy
+	}
+
+	plan.ExecutionInstructions = append(plan.ExecutionInstructions,
+		fmt.Sprintf("On entry fill, place a %s stop-loss at %.2f and a %s take-profit limit at %.2f for the filled quantity",
+			exitSide, *plan.Bracket.StopLossPrice, exitSide, *plan.Bracket.TakeProfitPrice),
+		"Link the stop-loss and take-profit as one-cancels-other: a fill of either cancels the other",
+		"Reduce the remaining exit leg by any partial fill of its sibling",
+		"Cancel both exit legs if the entry is cancelled before filling")
+}
+
+// stopLossSpreadWarning flags a stop-loss within one quoted spread of the entry while the spread is
+// wide, where quote noise alone can trigger it
+func (s *orderPricingService) stopLossSpreadWarning(marketPrice *MarketPrice, entryPrice, stopLossPrice float64) string {
+	condition := s.assessSpreadCondition(marketPrice)
+	if condition != SpreadConditionWide && condition != SpreadConditionVeryWide {
+		return ""
+	}
+
+	spread := quotedSpread(marketPrice)
+	distance := math.Abs(entryPrice - stopLossPrice)
+	if spread <= 0 || distance > spread {
+		return ""
+	}
+	return fmt.Sprintf("Stop-loss %.2f is %.2f from the entry, within the wide %.2f spread (%.2f%%); it may trigger on quote noise",
+		stopLossPrice, distance, spread, marketPrice.SpreadPercent)
+}
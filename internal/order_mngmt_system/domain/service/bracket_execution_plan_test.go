@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+func newBracketPricingClient(order *domain.Order, marketPrice *MarketPrice) *MockPricingDataClient {
+	mockClient := new(MockPricingDataClient)
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 5.0}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{EstimatedImpact: 0.1}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
+	return mockClient
+}
+
+func newBracketLimitBuy(t *testing.T) *domain.Order {
+	price := 100.0
+	order, err := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	require.NoError(t, err)
+	return order
+}
+
+func TestOrderPricingService_CreateExecutionPlan_BracketLinksExitsAsOCO(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	order := newBracketLimitBuy(t)
+	mockClient := newBracketPricingClient(order, &MarketPrice{Symbol: "PETR4", BidPrice: 99.95, AskPrice: 100.05, LastPrice: 100, Spread: 0.1, SpreadPercent: 0.1})
+	require.NoError(t, order.SetBracket(95, 110))
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+	require.NoError(t, err)
+
+	assert.Equal(t, ExecutionStrategyBracket, plan.RecommendedStrategy)
+	require.NotNil(t, plan.Bracket)
+	assert.Equal(t, 95.0, *plan.Bracket.StopLossPrice)
+	assert.Equal(t, 110.0, *plan.Bracket.TakeProfitPrice)
+
+	*plan.Bracket.StopLossPrice = 90.0
+	assert.Equal(t, 95.0, *order.BracketStopLossPrice(), "the plan keeps its own copy of the exit prices")
+
+	assert.Contains(t, plan.ExecutionInstructions, "On entry fill, place a SELL stop-loss at 95.00 and a SELL take-profit limit at 110.00 for the filled quantity")
+	assert.Contains(t, plan.ExecutionInstructions, "Link the stop-loss and take-profit as one-cancels-other: a fill of either cancels the other")
+	for _, warning := range plan.RiskWarnings {
+		assert.NotContains(t, warning, "Stop-loss", "a tight spread leaves the stop clear")
+	}
+}
+
+func TestOrderPricingService_CreateExecutionPlan_BracketWarnsWhenStopIsInsideWideSpread(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	order := newBracketLimitBuy(t)
+	mockClient := newBracketPricingClient(order, &MarketPrice{Symbol: "PETR4", BidPrice: 99.5, AskPrice: 100.5, LastPrice: 100, Spread: 1, SpreadPercent: 1})
+	require.NoError(t, order.SetBracket(99.5, 110))
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+	require.NoError(t, err)
+
+	assert.Contains(t, plan.RiskWarnings, "Stop-loss 99.50 is 0.50 from the entry, within the wide 1.00 spread (1.00%); it may trigger on quote noise")
+
+	require.NoError(t, order.SetBracket(95, 110))
+	plan, err = service.CreateExecutionPlan(order, mockClient)
+	require.NoError(t, err)
+	for _, warning := range plan.RiskWarnings {
+		assert.NotContains(t, warning, "Stop-loss", "a stop beyond the spread is not flagged")
+	}
+}
+
+func TestOrderPricingService_CreateExecutionPlan_BracketRejectsExitsOnTheWrongSide(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	order := newBracketLimitBuy(t)
+	mockClient := new(MockPricingDataClient)
+
+	tests := []struct {
+		name       string
+		stopLoss   float64
+		takeProfit float64
+	}{
+		{"take-profit below the entry", 95, 98},
+		{"stop-loss above the entry", 102, 110},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, order.SetBracket(tt.stopLoss, tt.takeProfit))
+			_, err := service.CreateExecutionPlan(order, mockClient)
+			assert.Error(t, err)
+		})
+	}
+	mockClient.AssertNotCalled(t, "GetCurrentMarketPrice", "PETR4")
+}
+
+func TestBracketConfig_Validate_RequiresBothExits(t *testing.T) {
+	bracket := BracketConfig{StopLossPrice: floatPtr(95)}
+
+	assert.Error(t, bracket.Validate(domain.OrderSideBuy, 100))
+}
+
+func TestBracketConfig_Validate_SellMirrorsBuy(t *testing.T) {
+	bracket := BracketConfig{StopLossPrice: floatPtr(105), TakeProfitPrice: floatPtr(90)}
+
+	assert.NoError(t, bracket.Validate(domain.OrderSideSell, 100))
+	assert.Error(t, bracket.Validate(domain.OrderSideBuy, 100))
+}
+
+func TestOrderPricingService_ValidateStrategyOverride_RejectsBracket(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+
+	_, err := service.ValidateStrategyOverride(newBracketLimitBuy(t), "BRACKET")
+	assert.Error(t, err, "a bracket needs exit prices the override cannot carry")
+}
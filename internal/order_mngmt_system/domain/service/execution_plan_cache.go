@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// executionPlanCache keeps recent execution plans so that a preview and the submit that
+// follows it share one plan instead of querying the pricing client twice. A cached plan
+// is only reused while the market still looks like it did when the plan was built.
+type executionPlanCache struct {
+	ttl                 time.Duration
+	maxPriceMovePercent float64
+
+	mu      sync.Mutex
+	entries map[string]cachedExecutionPlan
+	now     func() time.Time
+}
+
+type cachedExecutionPlan struct {
+	plan     *ExecutionPlan
+	snapshot executionPlanMarketSnapshot
+	cachedAt time.Time
+}
+
+// executionPlanMarketSnapshot is the market state a plan was built against
+type executionPlanMarketSnapshot struct {
+	referencePrice float64
+	spread         SpreadCondition
+}
+
+func newExecutionPlanCache(ttl time.Duration, maxPriceMovePercent float64) *executionPlanCache {
+	return &executionPlanCache{
+		ttl:                 ttl,
+		maxPriceMovePercent: maxPriceMovePercent,
+		entries:             make(map[string]cachedExecutionPlan),
+		now:                 time.Now,
+	}
+}
+
+// get returns the cached plan for the key when it has not expired and the market has not
+// shifted materially since it was built. Stale entries are dropped.
+func (c *executionPlanCache) get(key string, snapshot executionPlanMarketSnapshot) (*ExecutionPlan, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+
+	if c.now().Sub(entry.cachedAt) > c.ttl || c.marketShifted(entry.snapshot, snapshot) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.plan, true
+}
+
+func (c *executionPlanCache) put(key string, plan *ExecutionPlan, snapshot executionPlanMarketSnapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for existingKey, entry := range c.entries {
+		if now.Sub(entry.cachedAt) > c.ttl {
+			delete(c.entries, existingKey)
+		}
+	}
+
+	c.entries[key] = cachedExecutionPlan{plan: plan, snapshot: snapshot, cachedAt: now}
+}
+
+func (c *executionPlanCache) marketShifted(cached, current executionPlanMarketSnapshot) bool {
+	if cached.spread != current.spread {
+		return true
+	}
+	if cached.referencePrice <= 0 {
+		return current.referencePrice != cached.referencePrice
+	}
+
+	movePercent := math.Abs(current.referencePrice-cached.referencePrice) / cached.referencePrice * 100
+	return movePercent > c.maxPriceMovePercent
+}
+
+// executionPlanCacheKey identifies the order parameters a plan depends on. The order ID is
+// left out so that a previewed order and the same order once submitted share a plan.
+func executionPlanCacheKey(order *domain.Order) string {
+	price := "-"
+	if order.Price() != nil {
+		price = fmt.Sprintf("%g", *order.Price())
+	}
+
+	return fmt.Sprintf("%s|%s|%s|%g|%s|%s|%t|%s",
+		order.Symbol(), order.OrderSide(), order.OrderType(), order.Quantity(), price,
+		order.TimeInForce(), order.AllowPartialFill(), order.ExecutionStrategyOverride())
+}
+
+// forOrder returns a copy of a cached plan addressed to the given order
+func (p *ExecutionPlan) forOrder(orderID string) *ExecutionPlan {
+	plan := *p
+	plan.OrderID = orderID
+	plan.ExecutionInstructions = append([]string(nil), p.ExecutionInstructions...)
+	plan.RiskWarnings = append([]string(nil), p.RiskWarnings...)
+	return &plan
+}
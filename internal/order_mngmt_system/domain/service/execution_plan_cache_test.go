@@ -0,0 +1,130 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// MovingPricePricingClient serves a market price the test can move between calls
+type MovingPricePricingClient struct {
+	*MockPricingDataClient
+	marketPrice *MarketPrice
+}
+
+func (c *MovingPricePricingClient) GetCurrentMarketPrice(symbol string) (*MarketPrice, error) {
+	price := *c.marketPrice
+	return &price, nil
+}
+
+func newExecutionPlanCacheTestClient(order *domain.Order) *MovingPricePricingClient {
+	mockClient := new(MockPricingDataClient)
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 5.0}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{EstimatedImpact: 0.1}, nil)
+
+	return &MovingPricePricingClient{
+		MockPricingDataClient: mockClient,
+		marketPrice:           &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 100.2, LastPrice: 100.1, Spread: 0.2, SpreadPercent: 0.2},
+	}
+}
+
+func newCachingPricingService(ttl time.Duration) *orderPricingService {
+	return NewOrderPricingService(OrderPricingConfig{
+		MaxSlippagePercent:               2.0,
+		ImpactWarningPercent:             0.5,
+		ExecutionPlanCacheTTL:            ttl,
+		ExecutionPlanMaxPriceMovePercent: 0.25,
+	}).(*orderPricingService)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_ReusesPreviewPlanOnSubmit(t *testing.T) {
+	service := newCachingPricingService(10 * time.Second)
+	preview, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	submitted, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	client := newExecutionPlanCacheTestClient(preview)
+
+	previewPlan, err := service.CreateExecutionPlan(preview, client)
+	assert.NoError(t, err)
+
+	// A small move stays within the threshold
+	client.marketPrice.LastPrice = 100.2
+	submitPlan, err := service.CreateExecutionPlan(submitted, client)
+	assert.NoError(t, err)
+
+	assert.Equal(t, submitted.ID(), submitPlan.OrderID)
+	assert.Equal(t, preview.ID(), previewPlan.OrderID)
+	assert.Equal(t, previewPlan.EstimatedFillPrice, submitPlan.EstimatedFillPrice)
+	assert.Equal(t, previewPlan.CreatedAt, submitPlan.CreatedAt)
+	client.AssertNumberOfCalls(t, "GetTradingFees", 1)
+	client.AssertNumberOfCalls(t, "GetPriceImpactEstimate", 1)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_InvalidatesWhenPriceMoves(t *testing.T) {
+	service := newCachingPricingService(10 * time.Second)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	client := newExecutionPlanCacheTestClient(order)
+
+	firstPlan, err := service.CreateExecutionPlan(order, client)
+	assert.NoError(t, err)
+
+	// 1% move, beyond the 0.25% threshold
+	client.marketPrice.BidPrice = 101.0
+	client.marketPrice.AskPrice = 101.2
+	client.marketPrice.LastPrice = 101.1
+	secondPlan, err := service.CreateExecutionPlan(order, client)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, firstPlan.EstimatedFillPrice, secondPlan.EstimatedFillPrice)
+	client.AssertNumberOfCalls(t, "GetTradingFees", 2)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_InvalidatesWhenSpreadWidens(t *testing.T) {
+	service := newCachingPricingService(10 * time.Second)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	client := newExecutionPlanCacheTestClient(order)
+
+	_, err := service.CreateExecutionPlan(order, client)
+	assert.NoError(t, err)
+
+	client.marketPrice.SpreadPercent = 0.8
+	_, err = service.CreateExecutionPlan(order, client)
+	assert.NoError(t, err)
+
+	client.AssertNumberOfCalls(t, "GetTradingFees", 2)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_CachedPlanExpires(t *testing.T) {
+	service := newCachingPricingService(10 * time.Second)
+	now := time.Now()
+	service.planCache.now = func() time.Time { return now }
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	client := newExecutionPlanCacheTestClient(order)
+
+	_, err := service.CreateExecutionPlan(order, client)
+	assert.NoError(t, err)
+
+	now = now.Add(11 * time.Second)
+	_, err = service.CreateExecutionPlan(order, client)
+	assert.NoError(t, err)
+
+	client.AssertNumberOfCalls(t, "GetTradingFees", 2)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_CacheDisabled(t *testing.T) {
+	service := newCachingPricingService(0)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	client := newExecutionPlanCacheTestClient(order)
+
+	for i := 0; i < 2; i++ {
+		_, err := service.CreateExecutionPlan(order, client)
+		assert.NoError(t, err)
+	}
+
+	assert.Nil(t, service.planCache)
+	client.AssertNumberOfCalls(t, "GetTradingFees", 2)
+}
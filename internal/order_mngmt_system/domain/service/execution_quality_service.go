@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// ExecutionQualityService compares pre-trade fill estimates with realized fills
+type ExecutionQualityService interface {
+	// Evaluate builds a report for an executed order against the estimated fill price
+	Evaluate(order *domain.Order, estimatedFillPrice float64) (*domain.ExecutionQualityReport, error)
+
+	// EvaluatePlan builds a report against an execution plan, using the plan's slippage tolerance when set
+	EvaluatePlan(order *domain.Order, plan *ExecutionPlan) (*domain.ExecutionQualityReport, error)
+}
+
+type executionQualityService struct {
+	slippageTolerancePercent float64
+	exactFillEpsilon         float64
+}
+
+// ExecutionQualityConfig holds configuration for execution quality reports
+type ExecutionQualityConfig struct {
+	SlippageTolerancePercent float64 // Adverse slippage rated within tolerance when the plan has none
+	ExactFillEpsilon         float64 // Price difference still treated as an exact fill
+}
+
+// NewExecutionQualityService creates a new instance of ExecutionQualityService
+func NewExecutionQualityService(config ExecutionQualityConfig) ExecutionQualityService {
+	return &executionQualityService{
+		slippageTolerancePercent: config.SlippageTolerancePercent,
+		exactFillEpsilon:         config.ExactFillEpsilon,
+	}
+}
+
+// NewExecutionQualityServiceWithDefaults creates a service with default configuration
+func NewExecutionQualityServiceWithDefaults() ExecutionQualityService {
+	return NewExecutionQualityService(ExecutionQualityConfig{
+		SlippageTolerancePercent: 0.5,    // 0.5% adverse slippage tolerated
+		ExactFillEpsilon:         0.0001, // Sub-tick differences count as exact
+	})
+}
+
+// Evaluate builds a report for an executed order against the estimated fill price
+func (s *executionQualityService) Evaluate(order *domain.Order, estimatedFillPrice float64) (*domain.ExecutionQualityReport, error) {
+	return s.evaluate(order, estimatedFillPrice, s.slippageTolerancePercent)
+}
+
+// EvaluatePlan builds a report against an execution plan
+func (s *executionQualityService) EvaluatePlan(order *domain.Order, plan *ExecutionPlan) (*domain.ExecutionQualityReport, error) {
+	if plan == nil {
+		return nil, errors.New("execution plan is required")
+	}
+
+	tolerance := s.slippageTolerancePercent
+	if plan.SlippageTolerance > 0 {
+		tolerance = plan.SlippageTolerance
+	}
+
+	return s.evaluate(order, plan.EstimatedFillPrice, tolerance)
+}
+
+func (s *executionQualityService) evaluate(order *domain.Order, estimatedFillPrice, tolerancePercent float64) (*domain.ExecutionQualityReport, error) {
+	if order == nil {
+		return nil, errors.New("order is required")
+	}
+	if !order.IsExecuted() || order.ExecutionPrice() == nil {
+		return nil, errors.New("order has not been executed")
+	}
+	if estimatedFillPrice <= 0 {
+		return nil, errors.New("estimated fill price must be positive")
+	}
+
+	actualFillPrice := *order.ExecutionPrice()
+
+	// Positive slippage is adverse for the trader on either side
+	slippage := actualFillPrice - estimatedFillPrice
+	if order.IsSellOrder() {
+		slippage = -slippage
+	}
+	if math.Abs(slippage) <= s.exactFillEpsilon {
+		slippage = 0
+	}
+
+	slippageBps := slippage / estimatedFillPrice * 10000
+
+	return &domain.ExecutionQualityReport{
+		OrderID:                  order.ID(),
+		Symbol:                   order.Symbol(),
+		OrderSide:                order.OrderSide(),
+		Quantity:                 order.Quantity(),
+		EstimatedFillPrice:       estimatedFillPrice,
+		ActualFillPrice:          actualFillPrice,
+		Slippage:                 slippage,
+		SlippageBps:              slippageBps,
+		SlippageCost:             slippage * order.Quantity(),
+		SlippageTolerancePercent: tolerancePercent,
+		Quality:                  rateExecution(slippageBps, tolerancePercent),
+		ExecutedAt:               order.ExecutedAt(),
+		CreatedAt:                time.Now(),
+	}, nil
+}
+
+func rateExecution(slippageBps, tolerancePercent float64) domain.ExecutionQuality {
+	switch {
+	case slippageBps < 0:
+		return domain.ExecutionQualityPriceImprovement
+	case slippageBps == 0:
+		return domain.ExecutionQualityExact
+	case slippageBps <= tolerancePercent*100:
+		return domain.ExecutionQualityWithinTolerance
+	default:
+		return domain.ExecutionQualityPoor
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+func executedOrder(t *testing.T, side domain.OrderSide, executionPrice float64) *domain.Order {
+	t.Helper()
+	order, err := domain.NewOrder("user1", "PETR4", side, domain.OrderTypeMarket, 100, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, order.MarkAsProcessing())
+	assert.NoError(t, order.MarkAsExecuted(executionPrice))
+	return order
+}
+
+func TestExecutionQualityService_Evaluate_AdverseSlippage(t *testing.T) {
+	svc := NewExecutionQualityServiceWithDefaults()
+
+	report, err := svc.Evaluate(executedOrder(t, domain.OrderSideBuy, 25.10), 25.00)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.10, report.Slippage, 0.0001)
+	assert.InDelta(t, 40.0, report.SlippageBps, 0.0001)
+	assert.InDelta(t, 10.0, report.SlippageCost, 0.0001)
+	assert.Equal(t, domain.ExecutionQualityWithinTolerance, report.Quality)
+}
+
+func TestExecutionQualityService_Evaluate_PriceImprovementOnSell(t *testing.T) {
+	svc := NewExecutionQualityServiceWithDefaults()
+
+	report, err := svc.Evaluate(executedOrder(t, domain.OrderSideSell, 25.20), 25.00)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, -0.20, report.Slippage, 0.0001)
+	assert.InDelta(t, -80.0, report.SlippageBps, 0.0001)
+	assert.InDelta(t, -20.0, report.SlippageCost, 0.0001)
+	assert.Equal(t, domain.ExecutionQualityPriceImprovement, report.Quality)
+}
+
+func TestExecutionQualityService_Evaluate_ExactFill(t *testing.T) {
+	svc := NewExecutionQualityServiceWithDefaults()
+
+	report, err := svc.Evaluate(executedOrder(t, domain.OrderSideBuy, 25.00), 25.00)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, report.Slippage)
+	assert.Equal(t, 0.0, report.SlippageBps)
+	assert.Equal(t, 0.0, report.SlippageCost)
+	assert.Equal(t, domain.ExecutionQualityExact, report.Quality)
+}
+
+func TestExecutionQualityService_EvaluatePlan_UsesPlanTolerance(t *testing.T) {
+	svc := NewExecutionQualityServiceWithDefaults()
+	plan := &ExecutionPlan{EstimatedFillPrice: 25.00, SlippageTolerance: 0.1}
+
+	report, err := svc.EvaluatePlan(executedOrder(t, domain.OrderSideBuy, 25.10), plan)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, report.SlippageTolerancePercent)
+	assert.Equal(t, domain.ExecutionQualityPoor, report.Quality)
+}
+
+func TestExecutionQualityService_Evaluate_RequiresExecutedOrder(t *testing.T) {
+	svc := NewExecutionQualityServiceWithDefaults()
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 100, nil)
+
+	_, err := svc.Evaluate(order, 25.00)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IOrderHistoryClient defines the interface for reading a user's past orders (dependency inversion)
+type IOrderHistoryClient interface {
+	FindOrderHistory(ctx context.Context, userID string, limit int, offset int) ([]*domain.Order, error)
+}
+
+// FatFingerCheckResult represents the outcome of a fat-finger check
+type FatFingerCheckResult struct {
+	RequiresAcknowledgement bool
+	IsAccepted              bool
+	MedianQuantity          float64
+	MedianPrice             float64
+	QuantityRatio           float64
+	PriceRatio              float64
+	SampleSize              int
+	Reasons                 []string
+}
+
+// FatFingerService flags orders that look wildly out of line with the user's typical order.
+// Unlike hard limits, a flagged order is still accepted once the user acknowledges it.
+type FatFingerService interface {
+	// CheckOrder compares the order against the user's order history baseline
+	CheckOrder(ctx context.Context, order *domain.Order, acknowledged bool) (*FatFingerCheckResult, error)
+}
+
+type fatFingerService struct {
+	historyClient      IOrderHistoryClient
+	quantityMultiplier float64
+	priceMultiplier    float64
+	minHistorySize     int
+	historyLookback    int
+}
+
+// FatFingerConfig holds configuration for fat-finger protection
+type FatFingerConfig struct {
+	QuantityMultiplier float64 // Flag when quantity exceeds median quantity by this factor
+	PriceMultiplier    float64 // Flag when price deviates from the symbol's median price by this factor
+	MinHistorySize     int     // Minimum number of past orders needed to build a baseline
+	HistoryLookback    int     // Number of most recent orders used for the baseline
+}
+
+// NewFatFingerService creates a new instance of FatFingerService
+func NewFatFingerService(historyClient IOrderHistoryClient, config FatFingerConfig) FatFingerService {
+	return &fatFingerService{
+		historyClient:      historyClient,
+		quantityMultiplier: config.QuantityMultiplier,
+		priceMultiplier:    config.PriceMultiplier,
+		minHistorySize:     config.MinHistorySize,
+		historyLookback:    config.HistoryLookback,
+	}
+}
+
+// NewFatFingerServiceWithDefaults creates a service with default configuration
+func NewFatFingerServiceWithDefaults(historyClient IOrderHistoryClient) FatFingerService {
+	return NewFatFingerService(historyClient, FatFingerConfig{
+		QuantityMultiplier: 100.0, // 100x the median quantity
+		PriceMultiplier:    10.0,  // 10x away from the median price for the symbol
+		MinHistorySize:     5,     // At least 5 past orders
+		HistoryLookback:    100,   // Last 100 orders
+	})
+}
+
+// CheckOrder compares the order against the user's order history baseline
+func (s *fatFingerService) CheckOrder(ctx context.Context, order *domain.Order, acknowledged bool) (*FatFingerCheckResult, error) {
+	result := &FatFingerCheckResult{
+		IsAccepted: true,
+		Reasons:    make([]string, 0),
+	}
+
+	history, err := s.historyClient.FindOrderHistory(ctx, order.UserID(), s.historyLookback, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load order history: %w", err)
+	}
+
+	quantities := make([]float64, 0, len(history))
+	prices := make([]float64, 0, len(history))
+	for _, past := range history {
+		if past.ID() == order.ID() {
+			continue
+		}
+		quantities = append(quantities, past.Quantity())
+		if past.Symbol() == order.Symbol() {
+			if price := referencePrice(past); price > 0 {
+				prices = append(prices, price)
+			}
+		}
+	}
+
+	result.SampleSize = len(quantities)
+	if result.SampleSize < s.minHistorySize {
+		// Not enough history to establish a baseline
+		return result, nil
+	}
+
+	result.MedianQuantity = median(quantities)
+	if result.MedianQuantity > 0 {
+		result.QuantityRatio = order.Quantity() / result.MedianQuantity
+		if result.QuantityRatio >= s.quantityMultiplier {
+			result.Reasons = append(result.Reasons, fmt.Sprintf(
+				"Quantity %.2f is %.0fx your median order quantity of %.2f",
+				order.Quantity(), result.QuantityRatio, result.MedianQuantity))
+		}
+	}
+
+	if order.Price() != nil && len(prices) >= s.minHistorySize {
+		result.MedianPrice = median(prices)
+		result.PriceRatio = *order.Price() / result.MedianPrice
+		if result.PriceRatio >= s.priceMultiplier || result.PriceRatio <= 1/s.priceMultiplier {
+			result.Reasons = append(result.Reasons, fmt.Sprintf(
+				"Price %.2f is far from your median %s price of %.2f",
+				*order.Price(), order.Symbol(), result.MedianPrice))
+		}
+	}
+
+	if len(result.Reasons) > 0 {
+		result.RequiresAcknowledgement = true
+		result.IsAccepted = acknowledged
+	}
+
+	return result, nil
+}
+
+// referencePrice returns the best known price for a past order
+func referencePrice(order *domain.Order) float64 {
+	if order.ExecutionPrice() != nil {
+		return *order.ExecutionPrice()
+	}
+	if order.Price() != nil {
+		return *order.Price()
+	}
+	if order.MarketPriceAtSubmission() != nil {
+		return *order.MarketPriceAtSubmission()
+	}
+	return 0
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
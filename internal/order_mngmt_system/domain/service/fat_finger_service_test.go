@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// MockOrderHistoryClient is a mock for IOrderHistoryClient
+type MockOrderHistoryClient struct {
+	mock.Mock
+}
+
+func (m *MockOrderHistoryClient) FindOrderHistory(ctx context.Context, userID string, limit int, offset int) ([]*domain.Order, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Order), args.Error(1)
+}
+
+func buildOrderHistory(t *testing.T, count int, quantity float64, price float64) []*domain.Order {
+	history := make([]*domain.Order, 0, count)
+	for i := 0; i < count; i++ {
+		p := price
+		order, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, quantity, &p)
+		assert.NoError(t, err)
+		history = append(history, order)
+	}
+	return history
+}
+
+func TestFatFingerService_CheckOrder(t *testing.T) {
+	ctx := context.Background()
+	price := 150.0
+
+	tests := []struct {
+		name                    string
+		quantity                float64
+		acknowledged            bool
+		expectedRequiresAck     bool
+		expectedAccepted        bool
+		expectedQuantityRatio   float64
+		expectedReasonsContains string
+	}{
+		{
+			name:                  "normal order is not flagged",
+			quantity:              12,
+			acknowledged:          false,
+			expectedRequiresAck:   false,
+			expectedAccepted:      true,
+			expectedQuantityRatio: 1.2,
+		},
+		{
+			name:                    "100x quantity is flagged",
+			quantity:                1000,
+			acknowledged:            false,
+			expectedRequiresAck:     true,
+			expectedAccepted:        false,
+			expectedQuantityRatio:   100,
+			expectedReasonsContains: "median order quantity",
+		},
+		{
+			name:                    "100x quantity with acknowledgement is accepted",
+			quantity:                1000,
+			acknowledged:            true,
+			expectedRequiresAck:     true,
+			expectedAccepted:        true,
+			expectedQuantityRatio:   100,
+			expectedReasonsContains: "median order quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyClient := new(MockOrderHistoryClient)
+			historyClient.On("FindOrderHistory", ctx, "user123", 100, 0).
+				Return(buildOrderHistory(t, 10, 10, price), nil)
+
+			service := NewFatFingerServiceWithDefaults(historyClient)
+			order, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, tt.quantity, &price)
+			assert.NoError(t, err)
+
+			result, err := service.CheckOrder(ctx, order, tt.acknowledged)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRequiresAck, result.RequiresAcknowledgement)
+			assert.Equal(t, tt.expectedAccepted, result.IsAccepted)
+			assert.Equal(t, 10.0, result.MedianQuantity)
+			assert.InDelta(t, tt.expectedQuantityRatio, result.QuantityRatio, 0.001)
+			if tt.expectedReasonsContains != "" {
+				assert.Len(t, result.Reasons, 1)
+				assert.Contains(t, result.Reasons[0], tt.expectedReasonsContains)
+			} else {
+				assert.Empty(t, result.Reasons)
+			}
+			historyClient.AssertExpectations(t)
+		})
+	}
+}
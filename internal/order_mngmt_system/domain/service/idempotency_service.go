@@ -23,6 +23,7 @@ type IdempotencyStatus string
 
 const (
 	IdempotencyStatusPending   IdempotencyStatus = "PENDING"
+	IdempotencyStatusPersisted IdempotencyStatus = "PERSISTED" // Order saved but not yet published for processing
 	IdempotencyStatusCompleted IdempotencyStatus = "COMPLETED"
 	IdempotencyStatusFailed    IdempotencyStatus = "FAILED"
 	IdempotencyStatusExpired   IdempotencyStatus = "EXPIRED"
@@ -48,6 +49,13 @@ type IIdempotencyService interface {
 	CleanupExpiredKeys(ctx context.Context) error
 }
 
+// IIdempotencyCheckpointer is implemented by idempotency services that can record progress
+// within an operation, so a retry resumes after the last completed step instead of repeating it
+type IIdempotencyCheckpointer interface {
+	// CheckpointPersisted marks the key as persisted and records the order it created
+	CheckpointPersisted(ctx context.Context, key, userID, orderID string) error
+}
+
 type IdempotencyResult struct {
 	IsProcessed bool              `json:"is_processed"`
 	Status      IdempotencyStatus `json:"status"`
@@ -160,6 +168,19 @@ func (s *IdempotencyService) CompleteIdempotency(ctx context.Context, key, userI
 	return s.repository.Update(ctx, idempotencyKey)
 }
 
+// CheckpointPersisted marks the key as persisted and records the order it created
+func (s *IdempotencyService) CheckpointPersisted(ctx context.Context, key, userID, orderID string) error {
+	idempotencyKey, err := s.repository.Get(ctx, key, userID)
+	if err != nil {
+		return fmt.Errorf("idempotency key not found: %w", err)
+	}
+
+	idempotencyKey.Status = string(IdempotencyStatusPersisted)
+	idempotencyKey.OrderID = orderID
+
+	return s.repository.Update(ctx, idempotencyKey)
+}
+
 func (s *IdempotencyService) FailIdempotency(ctx context.Context, key, userID, errorMsg string) error {
 	idempotencyKey, err := s.repository.Get(ctx, key, userID)
 	if err != nil {
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// ErrTriggerBookFull is returned when the trigger book already watches its maximum number of orders
+var ErrTriggerBookFull = errors.New("if touched trigger book is full")
+
+// IfTouchedTriggerBook holds inactive if-touched orders, and the armed protective stops of filled
+// buys, and checks them against realtime quotes. An order leaves the book the first time a quote
+// touches its trigger price.
+type IfTouchedTriggerBook interface {
+	// Watch registers an inactive if-touched order to be checked against its symbol's quotes
+	Watch(order *domain.Order) error
+	// WatchStop registers an armed protective stop, touched once a quote falls to its stop price
+	WatchStop(order *domain.Order) error
+	// Unwatch removes the order, e.g. after it is cancelled
+	Unwatch(orderID string)
+	// EvaluateQuote removes and returns the IDs of the symbol's orders the price touches, oldest first
+	EvaluateQuote(symbol string, price float64) []string
+	// Watching returns the number of orders waiting for their trigger
+	Watching() int
+	// Symbols returns the symbols with orders waiting for their trigger, sorted
+	Symbols() []string
+}
+
+type watchedTrigger struct {
+	orderID      string
+	symbol       string
+	isBuy        bool
+	isStop       bool
+	triggerPrice float64
+	watchedAt    time.Time
+}
+
+// touchedBy mirrors Order.IsTouchedBy for the watched order. A stop fires the opposite way: a sell
+// stop when the price falls to its stop price.
+func (w watchedTrigger) touchedBy(price float64) bool {
+	if w.isBuy != w.isStop {
+		return price <= w.triggerPrice
+	}
+	return price >= w.triggerPrice
+}
+
+type ifTouchedTriggerBook struct {
+	maxWatchedOrders int
+
+	mu       sync.Mutex
+	triggers map[string]map[string]watchedTrigger // Symbol -> order ID -> trigger
+	symbols  map[string]string                    // Order ID -> symbol
+}
+
+// IfTouchedTriggerConfig holds configuration for the if-touched trigger book
+type IfTouchedTriggerConfig struct {
+	MaxWatchedOrders int // Orders the book holds at once; further orders are refused (0 means unlimited)
+}
+
+// NewIfTouchedTriggerBook creates a new instance of IfTouchedTriggerBook
+func NewIfTouchedTriggerBook(config IfTouchedTriggerConfig) IfTouchedTriggerBook {
+	return &ifTouchedTriggerBook{
+		maxWatchedOrders: config.MaxWatchedOrders,
+		triggers:         make(map[string]map[string]watchedTrigger),
+		symbols:          make(map[string]string),
+	}
+}
+
+// NewIfTouchedTriggerBookWithDefaults creates a trigger book with default configuration
+func NewIfTouchedTriggerBookWithDefaults() IfTouchedTriggerBook {
+	return NewIfTouchedTriggerBook(IfTouchedTriggerConfig{
+		MaxWatchedOrders: 50000, // Bound memory if resting orders pile up
+	})
+}
+
+// Watch registers an inactive if-touched order to be checked against its symbol's quotes
+func (b *ifTouchedTriggerBook) Watch(order *domain.Order) error {
+	if !order.OrderType().IsIfTouched() {
+		return fmt.Errorf("%s orders have no trigger to watch", order.OrderType())
+	}
+	if order.TriggerPrice() == nil {
+		return errors.New("if touched order has no trigger price")
+	}
+	if order.IsActivated() {
+		return errors.New("if touched order is already active")
+	}
+
+	return b.watch(order, *order.TriggerPrice(), false)
+}
+
+// WatchStop registers an armed protective stop to be checked against its symbol's quotes
+func (b *ifTouchedTriggerBook) WatchStop(order *domain.Order) error {
+	if !order.IsProtectiveStop() {
+		return fmt.Errorf("order %s is not a protective stop", order.ID())
+	}
+	if order.Price() == nil {
+		return errors.New("protective stop has no stop price")
+	}
+
+	return b.watch(order, *order.Price(), true)
+}
+
+func (b *ifTouchedTriggerBook) watch(order *domain.Order, triggerPrice float64, isStop bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, rewatch := b.symbols[order.ID()]
+	if !rewatch && b.maxWatchedOrders > 0 && len(b.symbols) >= b.maxWatchedOrders {
+		return ErrTriggerBookFull
+	}
+
+	b.removeLocked(order.ID())
+
+	symbolTriggers, exists := b.triggers[order.Symbol()]
+	if !exists {
+		symbolTriggers = make(map[string]watchedTrigger)
+		b.triggers[order.Symbol()] = symbolTriggers
+	}
+
+	symbolTriggers[order.ID()] = watchedTrigger{
+		orderID:      order.ID(),
+		symbol:       order.Symbol(),
+		isBuy:        order.IsBuyOrder(),
+		isStop:       isStop,
+		triggerPrice: triggerPrice,
+		watchedAt:    order.CreatedAt(),
+	}
+	b.symbols[order.ID()] = order.Symbol()
+	return nil
+}
+
+// Unwatch removes the order from the book
+func (b *ifTouchedTriggerBook) Unwatch(orderID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.removeLocked(orderID)
+}
+
+// EvaluateQuote removes and returns the IDs of the symbol's orders the price touches
+func (b *ifTouchedTriggerBook) EvaluateQuote(symbol string, price float64) []string {
+	if price <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	touched := make([]watchedTrigger, 0)
+	for _, trigger := range b.triggers[symbol] {
+		if trigger.touchedBy(price) {
+			touched = append(touched, trigger)
+		}
+	}
+
+	sort.Slice(touched, func(i, j int) bool {
+		if !touched[i].watchedAt.Equal(touched[j].watchedAt) {
+			return touched[i].watchedAt.Before(touched[j].watchedAt)
+		}
+		return touched[i].orderID < touched[j].orderID
+	})
+
+	orderIDs := make([]string, 0, len(touched))
+	for _, trigger := range touched {
+		b.removeLocked(trigger.orderID)
+		orderIDs = append(orderIDs, trigger.orderID)
+	}
+	return orderIDs
+}
+
+// Watching returns the number of orders waiting for their trigger
+func (b *ifTouchedTriggerBook) Watching() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.symbols)
+}
+
+// Symbols returns the symbols with orders waiting for their trigger, sorted
+func (b *ifTouchedTriggerBook) Symbols() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	symbols := make([]string, 0, len(b.triggers))
+	for symbol := range b.triggers {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func (b *ifTouchedTriggerBook) removeLocked(orderID string) {
+	symbol, exists := b.symbols[orderID]
+	if !exists {
+		return
+	}
+
+	delete(b.symbols, orderID)
+	delete(b.triggers[symbol], orderID)
+	if len(b.triggers[symbol]) == 0 {
+		delete(b.triggers, symbol)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIfTouchedTestOrder(t *testing.T, symbol string, side domain.OrderSide, triggerPrice float64) *domain.Order {
+	t.Helper()
+	order, err := domain.NewOrder("user1", symbol, side, domain.OrderTypeMarketIfTouched, 10, nil)
+	require.NoError(t, err)
+	require.NoError(t, order.SetTriggerPrice(triggerPrice))
+	return order
+}
+
+func TestIfTouchedTriggerBook_ReturnsOrdersOnlyOnceTouched(t *testing.T) {
+	book := NewIfTouchedTriggerBookWithDefaults()
+	buy := newIfTouchedTestOrder(t, "PETR4", domain.OrderSideBuy, 30.00)
+	sell := newIfTouchedTestOrder(t, "PETR4", domain.OrderSideSell, 32.00)
+	require.NoError(t, book.Watch(buy))
+	require.NoError(t, book.Watch(sell))
+
+	assert.Empty(t, book.EvaluateQuote("PETR4", 30.01))
+	assert.Empty(t, book.EvaluateQuote("PETR4", 31.99))
+	assert.Empty(t, book.EvaluateQuote("VALE3", 10.00))
+	assert.Equal(t, 2, book.Watching())
+
+	assert.Equal(t, []string{buy.ID()}, book.EvaluateQuote("PETR4", 30.00))
+	assert.Empty(t, book.EvaluateQuote("PETR4", 29.00))
+	assert.Equal(t, []string{sell.ID()}, book.EvaluateQuote("PETR4", 32.00))
+	assert.Equal(t, 0, book.Watching())
+}
+
+func TestIfTouchedTriggerBook_ReturnsTouchedOrdersOldestFirst(t *testing.T) {
+	book := NewIfTouchedTriggerBookWithDefaults()
+	first := newIfTouchedTestOrder(t, "PETR4", domain.OrderSideBuy, 30.00)
+	time.Sleep(time.Millisecond)
+	second := newIfTouchedTestOrder(t, "PETR4", domain.OrderSideBuy, 29.50)
+	require.NoError(t, book.Watch(second))
+	require.NoError(t, book.Watch(first))
+
+	assert.Equal(t, []string{first.ID(), second.ID()}, book.EvaluateQuote("PETR4", 29.00))
+}
+
+func TestIfTouchedTriggerBook_RejectsActiveOrdersAndRespectsCapacity(t *testing.T) {
+	book := NewIfTouchedTriggerBook(IfTouchedTriggerConfig{MaxWatchedOrders: 1})
+
+	active := newIfTouchedTestOrder(t, "PETR4", domain.OrderSideBuy, 30.00)
+	require.True(t, active.ActivateIfTouched(30.00, time.Now()))
+	assert.Error(t, book.Watch(active))
+
+	watched := newIfTouchedTestOrder(t, "PETR4", domain.OrderSideBuy, 30.00)
+	require.NoError(t, book.Watch(watched))
+	assert.NoError(t, book.Watch(watched))
+	assert.ErrorIs(t, book.Watch(newIfTouchedTestOrder(t, "PETR4", domain.OrderSideBuy, 30.00)), ErrTriggerBookFull)
+
+	book.Unwatch(watched.ID())
+	assert.Equal(t, 0, book.Watching())
+	assert.Empty(t, book.EvaluateQuote("PETR4", 1.00))
+}
+
+func TestIfTouchedTriggerBook_WatchStopFiresWhenPriceFallsToStop(t *testing.T) {
+	book := NewIfTouchedTriggerBookWithDefaults()
+	limitPrice := 150.00
+	buy, err := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &limitPrice)
+	require.NoError(t, err)
+	stop, err := domain.NewProtectiveStopOrder(buy, limitPrice, 5)
+	require.NoError(t, err)
+
+	assert.Error(t, book.WatchStop(buy))
+	require.NoError(t, book.WatchStop(stop))
+
+	assert.Empty(t, book.EvaluateQuote("PETR4", 160.00))
+	assert.Empty(t, book.EvaluateQuote("PETR4", 142.51))
+	assert.Equal(t, []string{stop.ID()}, book.EvaluateQuote("PETR4", 142.50))
+	assert.Equal(t, 0, book.Watching())
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// instrumentedOrderValidationService records the timing and outcome of every validation call
+type instrumentedOrderValidationService struct {
+	OrderValidationService
+	metrics OrderPipelineMetrics
+}
+
+// NewInstrumentedOrderValidationService wraps the service so its calls feed the pipeline metrics.
+// A nil metrics recorder returns the service unwrapped.
+func NewInstrumentedOrderValidationService(service OrderValidationService, metrics OrderPipelineMetrics) OrderValidationService {
+	if metrics == nil {
+		return service
+	}
+	return &instrumentedOrderValidationService{OrderValidationService: service, metrics: metrics}
+}
+
+func (s *instrumentedOrderValidationService) ValidateOrder(ctx context.Context, order *domain.Order) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateOrder(ctx, order)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateOrder", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidateOrderWithContext(ctx context.Context, order *domain.Order, marketDataClient IMarketDataClient, positionClient IPositionClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateOrderWithContext(ctx, order, marketDataClient, positionClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateOrderWithContext", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidateSymbol(ctx context.Context, symbol string, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateSymbol(ctx, symbol, marketDataClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateSymbol", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidateQuantity(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateQuantity(ctx, order, positionClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateQuantity", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidatePrice(ctx context.Context, order *domain.Order, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidatePrice(ctx, order, marketDataClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidatePrice", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidateTradingHours(ctx context.Context, symbol string, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateTradingHours(ctx, symbol, marketDataClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateTradingHours", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidateOrderSide(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateOrderSide(ctx, order, positionClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateOrderSide", start, validationFailed(result, err))
+	return result, err
+}
+
+func (s *instrumentedOrderValidationService) ValidateRiskLimits(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.OrderValidationService.ValidateRiskLimits(ctx, order, positionClient)
+	observePipelineCall(s.metrics, PipelineServiceValidation, "ValidateRiskLimits", start, validationFailed(result, err))
+	return result, err
+}
+
+// instrumentedOrderPricingService records the timing and outcome of every pricing call
+type instrumentedOrderPricingService struct {
+	OrderPricingService
+	metrics OrderPipelineMetrics
+}
+
+// NewInstrumentedOrderPricingService wraps the service so its calls feed the pipeline metrics.
+// A nil metrics recorder returns the service unwrapped.
+func NewInstrumentedOrderPricingService(service OrderPricingService, metrics OrderPipelineMetrics) OrderPricingService {
+	if metrics == nil {
+		return service
+	}
+	return &instrumentedOrderPricingService{OrderPricingService: service, metrics: metrics}
+}
+
+func (s *instrumentedOrderPricingService) CalculateOptimalPrice(order *domain.Order, pricingClient IPricingDataClient) (*PricingResult, error) {
+	start := time.Now()
+	result, err := s.OrderPricingService.CalculateOptimalPrice(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "CalculateOptimalPrice", start, err != nil)
+	return result, err
+}
+
+func (s *instrumentedOrderPricingService) CreateExecutionPlan(order *domain.Order, pricingClient IPricingDataClient) (*ExecutionPlan, error) {
+	start := time.Now()
+	plan, err := s.OrderPricingService.CreateExecutionPlan(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "CreateExecutionPlan", start, err != nil)
+	return plan, err
+}
+
+func (s *instrumentedOrderPricingService) ValidateOrderPrice(order *domain.Order, pricingClient IPricingDataClient) error {
+	start := time.Now()
+	err := s.OrderPricingService.ValidateOrderPrice(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "ValidateOrderPrice", start, err != nil)
+	return err
+}
+
+func (s *instrumentedOrderPricingService) EstimateFillPrice(order *domain.Order, pricingClient IPricingDataClient) (float64, error) {
+	start := time.Now()
+	price, err := s.OrderPricingService.EstimateFillPrice(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "EstimateFillPrice", start, err != nil)
+	return price, err
+}
+
+func (s *instrumentedOrderPricingService) CalculateTradingCosts(order *domain.Order, pricingClient IPricingDataClient) (*TradingFees, error) {
+	start := time.Now()
+	fees, err := s.OrderPricingService.CalculateTradingCosts(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "CalculateTradingCosts", start, err != nil)
+	return fees, err
+}
+
+func (s *instrumentedOrderPricingService) AssessPriceImpact(order *domain.Order, pricingClient IPricingDataClient) (*PriceImpact, error) {
+	start := time.Now()
+	impact, err := s.OrderPricingService.AssessPriceImpact(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "AssessPriceImpact", start, err != nil)
+	return impact, err
+}
+
+func (s *instrumentedOrderPricingService) RecommendExecutionStrategy(order *domain.Order, pricingClient IPricingDataClient) (ExecutionStrategy, error) {
+	start := time.Now()
+	strategy, err := s.OrderPricingService.RecommendExecutionStrategy(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "RecommendExecutionStrategy", start, err != nil)
+	return strategy, err
+}
+
+func (s *instrumentedOrderPricingService) ValidateStrategyOverride(order *domain.Order, strategy string) (ExecutionStrategy, error) {
+	start := time.Now()
+	result, err := s.OrderPricingService.ValidateStrategyOverride(order, strategy)
+	observePipelineCall(s.metrics, PipelineServicePricing, "ValidateStrategyOverride", start, err != nil)
+	return result, err
+}
+
+func (s *instrumentedOrderPricingService) ValidateMarketConditions(order *domain.Order, pricingClient IPricingDataClient) (*MarketConditions, error) {
+	start := time.Now()
+	conditions, err := s.OrderPricingService.ValidateMarketConditions(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "ValidateMarketConditions", start, err != nil)
+	return conditions, err
+}
+
+func (s *instrumentedOrderPricingService) CalculateSlippageTolerance(order *domain.Order, pricingClient IPricingDataClient) (float64, error) {
+	start := time.Now()
+	tolerance, err := s.OrderPricingService.CalculateSlippageTolerance(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "CalculateSlippageTolerance", start, err != nil)
+	return tolerance, err
+}
+
+func (s *instrumentedOrderPricingService) ApplyMarketOrderProtection(order *domain.Order, pricingClient IPricingDataClient) (bool, error) {
+	start := time.Now()
+	applied, err := s.OrderPricingService.ApplyMarketOrderProtection(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "ApplyMarketOrderProtection", start, err != nil)
+	return applied, err
+}
+
+func (s *instrumentedOrderPricingService) SimulateImmediateFill(order *domain.Order, pricingClient IPricingDataClient) (*ImmediateFillResult, error) {
+	start := time.Now()
+	result, err := s.OrderPricingService.SimulateImmediateFill(order, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "SimulateImmediateFill", start, err != nil)
+	return result, err
+}
+
+func (s *instrumentedOrderPricingService) SuggestLimitPrice(userID, symbol string, side domain.OrderSide, quantity float64, urgency LimitPriceUrgency, pricingClient IPricingDataClient) (*LimitPriceSuggestion, error) {
+	start := time.Now()
+	suggestion, err := s.OrderPricingService.SuggestLimitPrice(userID, symbol, side, quantity, urgency, pricingClient)
+	observePipelineCall(s.metrics, PipelineServicePricing, "SuggestLimitPrice", start, err != nil)
+	return suggestion, err
+}
+
+// instrumentedRiskManagementService records the timing and outcome of every risk call
+type instrumentedRiskManagementService struct {
+	RiskManagementService
+	metrics OrderPipelineMetrics
+}
+
+// NewInstrumentedRiskManagementService wraps the service so its calls feed the pipeline metrics.
+// A nil metrics recorder returns the service unwrapped.
+func NewInstrumentedRiskManagementService(service RiskManagementService, metrics OrderPipelineMetrics) RiskManagementService {
+	if metrics == nil {
+		return service
+	}
+	return &instrumentedRiskManagementService{RiskManagementService: service, metrics: metrics}
+}
+
+func (s *instrumentedRiskManagementService) AssessOrderRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	start := time.Now()
+	assessment, err := s.RiskManagementService.AssessOrderRisk(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "AssessOrderRisk", start, err != nil || (assessment != nil && !assessment.IsApproved))
+	return assessment, err
+}
+
+func (s *instrumentedRiskManagementService) ValidateRiskLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	start := time.Now()
+	err := s.RiskManagementService.ValidateRiskLimits(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "ValidateRiskLimits", start, err != nil)
+	return err
+}
+
+func (s *instrumentedRiskManagementService) CheckPositionLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	start := time.Now()
+	err := s.RiskManagementService.CheckPositionLimits(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "CheckPositionLimits", start, err != nil)
+	return err
+}
+
+func (s *instrumentedRiskManagementService) CheckTradingLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	start := time.Now()
+	err := s.RiskManagementService.CheckTradingLimits(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "CheckTradingLimits", start, err != nil)
+	return err
+}
+
+func (s *instrumentedRiskManagementService) AssessMarketRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	start := time.Now()
+	assessment, err := s.RiskManagementService.AssessMarketRisk(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "AssessMarketRisk", start, err != nil)
+	return assessment, err
+}
+
+func (s *instrumentedRiskManagementService) AssessConcentrationRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	start := time.Now()
+	assessment, err := s.RiskManagementService.AssessConcentrationRisk(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "AssessConcentrationRisk", start, err != nil)
+	return assessment, err
+}
+
+func (s *instrumentedRiskManagementService) CalculateRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (float64, error) {
+	start := time.Now()
+	score, err := s.RiskManagementService.CalculateRiskScore(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "CalculateRiskScore", start, err != nil)
+	return score, err
+}
+
+func (s *instrumentedRiskManagementService) ExplainRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (*RiskScoreBreakdown, error) {
+	start := time.Now()
+	breakdown, err := s.RiskManagementService.ExplainRiskScore(order, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "ExplainRiskScore", start, err != nil)
+	return breakdown, err
+}
+
+func (s *instrumentedRiskManagementService) RecommendOrderSize(userID, symbol string, price float64, targetLevel RiskLevel, riskDataClient IRiskDataClient) (*OrderSizeRecommendation, error) {
+	start := time.Now()
+	recommendation, err := s.RiskManagementService.RecommendOrderSize(userID, symbol, price, targetLevel, riskDataClient)
+	observePipelineCall(s.metrics, PipelineServiceRisk, "RecommendOrderSize", start, err != nil)
+	return recommendation, err
+}
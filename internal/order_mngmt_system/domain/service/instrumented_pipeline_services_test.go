@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStubService = errors.New("stub service failure")
+
+// stubValidationService returns a fixed outcome from every method
+type stubValidationService struct {
+	OrderValidationService
+	result *ValidationResult
+	err    error
+}
+
+func (s *stubValidationService) ValidateOrder(ctx context.Context, order *domain.Order) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidateOrderWithContext(ctx context.Context, order *domain.Order, marketDataClient IMarketDataClient, positionClient IPositionClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidateSymbol(ctx context.Context, symbol string, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidateQuantity(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidatePrice(ctx context.Context, order *domain.Order, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidateTradingHours(ctx context.Context, symbol string, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidateOrderSide(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubValidationService) ValidateRiskLimits(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
+	return s.result, s.err
+}
+
+// stubPricingService returns a fixed error from every method
+type stubPricingService struct {
+	OrderPricingService
+	err error
+}
+
+func (s *stubPricingService) CalculateOptimalPrice(order *domain.Order, pricingClient IPricingDataClient) (*PricingResult, error) {
+	return &PricingResult{}, s.err
+}
+
+func (s *stubPricingService) CreateExecutionPlan(order *domain.Order, pricingClient IPricingDataClient) (*ExecutionPlan, error) {
+	return &ExecutionPlan{}, s.err
+}
+
+func (s *stubPricingService) ValidateOrderPrice(order *domain.Order, pricingClient IPricingDataClient) error {
+	return s.err
+}
+
+func (s *stubPricingService) EstimateFillPrice(order *domain.Order, pricingClient IPricingDataClient) (float64, error) {
+	return 100, s.err
+}
+
+func (s *stubPricingService) CalculateTradingCosts(order *domain.Order, pricingClient IPricingDataClient) (*TradingFees, error) {
+	return &TradingFees{}, s.err
+}
+
+func (s *stubPricingService) AssessPriceImpact(order *domain.Order, pricingClient IPricingDataClient) (*PriceImpact, error) {
+	return &PriceImpact{}, s.err
+}
+
+func (s *stubPricingService) RecommendExecutionStrategy(order *domain.Order, pricingClient IPricingDataClient) (ExecutionStrategy, error) {
+	return ExecutionStrategyMarket, s.err
+}
+
+func (s *stubPricingService) ValidateStrategyOverride(order *domain.Order, strategy string) (ExecutionStrategy, error) {
+	return ExecutionStrategyMarket, s.err
+}
+
+func (s *stubPricingService) ValidateMarketConditions(order *domain.Order, pricingClient IPricingDataClient) (*MarketConditions, error) {
+	return &MarketConditions{}, s.err
+}
+
+func (s *stubPricingService) CalculateSlippageTolerance(order *domain.Order, pricingClient IPricingDataClient) (float64, error) {
+	return 0.01, s.err
+}
+
+func (s *stubPricingService) ApplyMarketOrderProtection(order *domain.Order, pricingClient IPricingDataClient) (bool, error) {
+	return false, s.err
+}
+
+func (s *stubPricingService) SimulateImmediateFill(order *domain.Order, pricingClient IPricingDataClient) (*ImmediateFillResult, error) {
+	return &ImmediateFillResult{}, s.err
+}
+
+func (s *stubPricingService) SuggestLimitPrice(userID, symbol string, side domain.OrderSide, quantity float64, urgency LimitPriceUrgency, pricingClient IPricingDataClient) (*LimitPriceSuggestion, error) {
+	return &LimitPriceSuggestion{}, s.err
+}
+
+// stubRiskService returns a fixed error from every method and approves every assessment
+type stubRiskService struct {
+	RiskManagementService
+	err error
+}
+
+func (s *stubRiskService) AssessOrderRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	return &RiskAssessment{IsApproved: true}, s.err
+}
+
+func (s *stubRiskService) ValidateRiskLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	return s.err
+}
+
+func (s *stubRiskService) CheckPositionLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	return s.err
+}
+
+func (s *stubRiskService) CheckTradingLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	return s.err
+}
+
+func (s *stubRiskService) AssessMarketRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	return &RiskAssessment{}, s.err
+}
+
+func (s *stubRiskService) AssessConcentrationRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	return &RiskAssessment{}, s.err
+}
+
+func (s *stubRiskService) CalculateRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (float64, error) {
+	return 10, s.err
+}
+
+func (s *stubRiskService) ExplainRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (*RiskScoreBreakdown, error) {
+	return &RiskScoreBreakdown{}, s.err
+}
+
+func (s *stubRiskService) RecommendOrderSize(userID, symbol string, price float64, targetLevel RiskLevel, riskDataClient IRiskDataClient) (*OrderSizeRecommendation, error) {
+	return &OrderSizeRecommendation{}, s.err
+}
+
+type pipelineCall struct {
+	method string
+	call   func()
+}
+
+func validationCalls(svc OrderValidationService, order *domain.Order) []pipelineCall {
+	ctx := context.Background()
+	return []pipelineCall{
+		{"ValidateOrder", func() { svc.ValidateOrder(ctx, order) }},
+		{"ValidateOrderWithContext", func() { svc.ValidateOrderWithContext(ctx, order, nil, nil) }},
+		{"ValidateSymbol", func() { svc.ValidateSymbol(ctx, "AAPL", nil) }},
+		{"ValidateQuantity", func() { svc.ValidateQuantity(ctx, order, nil) }},
+		{"ValidatePrice", func() { svc.ValidatePrice(ctx, order, nil) }},
+		{"ValidateTradingHours", func() { svc.ValidateTradingHours(ctx, "AAPL", nil) }},
+		{"ValidateOrderSide", func() { svc.ValidateOrderSide(ctx, order, nil) }},
+		{"ValidateRiskLimits", func() { svc.ValidateRiskLimits(ctx, order, nil) }},
+	}
+}
+
+func pricingCalls(svc OrderPricingService, order *domain.Order) []pipelineCall {
+	return []pipelineCall{
+		{"CalculateOptimalPrice", func() { svc.CalculateOptimalPrice(order, nil) }},
+		{"CreateExecutionPlan", func() { svc.CreateExecutionPlan(order, nil) }},
+		{"ValidateOrderPrice", func() { svc.ValidateOrderPrice(order, nil) }},
+		{"EstimateFillPrice", func() { svc.EstimateFillPrice(order, nil) }},
+		{"CalculateTradingCosts", func() { svc.CalculateTradingCosts(order, nil) }},
+		{"AssessPriceImpact", func() { svc.AssessPriceImpact(order, nil) }},
+		{"RecommendExecutionStrategy", func() { svc.RecommendExecutionStrategy(order, nil) }},
+		{"ValidateStrategyOverride", func() { svc.ValidateStrategyOverride(order, "") }},
+		{"ValidateMarketConditions", func() { svc.ValidateMarketConditions(order, nil) }},
+		{"CalculateSlippageTolerance", func() { svc.CalculateSlippageTolerance(order, nil) }},
+		{"ApplyMarketOrderProtection", func() { svc.ApplyMarketOrderProtection(order, nil) }},
+		{"SimulateImmediateFill", func() { svc.SimulateImmediateFill(order, nil) }},
+		{"SuggestLimitPrice", func() { svc.SuggestLimitPrice("user123", "AAPL", domain.OrderSideBuy, 100, LimitPriceUrgencyHigh, nil) }},
+	}
+}
+
+func riskCalls(svc RiskManagementService, order *domain.Order) []pipelineCall {
+	return []pipelineCall{
+		{"AssessOrderRisk", func() { svc.AssessOrderRisk(order, nil) }},
+		{"ValidateRiskLimits", func() { svc.ValidateRiskLimits(order, nil) }},
+		{"CheckPositionLimits", func() { svc.CheckPositionLimits(order, nil) }},
+		{"CheckTradingLimits", func() { svc.CheckTradingLimits(order, nil) }},
+		{"AssessMarketRisk", func() { svc.AssessMarketRisk(order, nil) }},
+		{"AssessConcentrationRisk", func() { svc.AssessConcentrationRisk(order, nil) }},
+		{"CalculateRiskScore", func() { svc.CalculateRiskScore(order, nil) }},
+		{"ExplainRiskScore", func() { svc.ExplainRiskScore(order, nil) }},
+		{"RecommendOrderSize", func() { svc.RecommendOrderSize("user123", "AAPL", 150, RiskLevelMedium, nil) }},
+	}
+}
+
+func findPipelineMethod(metrics OrderPipelineMetrics, service, method string) (PipelineMethodMetrics, bool) {
+	for _, snapshot := range metrics.Snapshot() {
+		if snapshot.Service == service && snapshot.Method == method {
+			return snapshot, true
+		}
+	}
+	return PipelineMethodMetrics{}, false
+}
+
+func assertPipelineCallsRecorded(t *testing.T, metrics OrderPipelineMetrics, service string, calls []pipelineCall, successes, failures uint64) {
+	t.Helper()
+	for _, call := range calls {
+		snapshot, found := findPipelineMethod(metrics, service, call.method)
+		if !assert.True(t, found, "expected metrics for %s.%s", service, call.method) {
+			continue
+		}
+		assert.Equal(t, successes+failures, snapshot.Latency.Count, "%s.%s timing observations", service, call.method)
+		assert.Equal(t, successes, snapshot.Successes, "%s.%s successes", service, call.method)
+		assert.Equal(t, failures, snapshot.Failures, "%s.%s failures", service, call.method)
+	}
+}
+
+func TestInstrumentedServices_RecordTimingAndOutcomeForEveryMethod(t *testing.T) {
+	price := 150.0
+	order := createTestOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	tests := []struct {
+		name    string
+		service string
+		calls   func(metrics OrderPipelineMetrics, failing bool) []pipelineCall
+	}{
+		{
+			name:    "validation",
+			service: PipelineServiceValidation,
+			calls: func(metrics OrderPipelineMetrics, failing bool) []pipelineCall {
+				stub := &stubValidationService{result: &ValidationResult{IsValid: !failing}}
+				return validationCalls(NewInstrumentedOrderValidationService(stub, metrics), order)
+			},
+		},
+		{
+			name:    "pricing",
+			service: PipelineServicePricing,
+			calls: func(metrics OrderPipelineMetrics, failing bool) []pipelineCall {
+				stub := &stubPricingService{}
+				if failing {
+					stub.err = errStubService
+				}
+				return pricingCalls(NewInstrumentedOrderPricingService(stub, metrics), order)
+			},
+		},
+		{
+			name:    "risk",
+			service: PipelineServiceRisk,
+			calls: func(metrics OrderPipelineMetrics, failing bool) []pipelineCall {
+				stub := &stubRiskService{}
+				if failing {
+					stub.err = errStubService
+				}
+				return riskCalls(NewInstrumentedRiskManagementService(stub, metrics), order)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := NewOrderPipelineMetricsWithDefaults()
+
+			for _, call := range tt.calls(metrics, false) {
+				call.call()
+			}
+			failingCalls := tt.calls(metrics, true)
+			for _, call := range failingCalls {
+				call.call()
+				call.call()
+			}
+
+			assertPipelineCallsRecorded(t, metrics, tt.service, failingCalls, 1, 2)
+		})
+	}
+}
+
+func TestInstrumentedValidationService_ErrorCountsAsFailure(t *testing.T) {
+	metrics := NewOrderPipelineMetricsWithDefaults()
+	svc := NewInstrumentedOrderValidationService(&stubValidationService{err: errStubService}, metrics)
+
+	result, err := svc.ValidateSymbol(context.Background(), "AAPL", nil)
+
+	assert.Nil(t, result)
+	assert.Equal(t, errStubService, err)
+	snapshot, found := findPipelineMethod(metrics, PipelineServiceValidation, "ValidateSymbol")
+	require.True(t, found)
+	assert.Equal(t, uint64(1), snapshot.Failures)
+}
+
+func TestInstrumentedServices_NilMetricsReturnsServiceUnwrapped(t *testing.T) {
+	validation := &stubValidationService{}
+	pricing := &stubPricingService{}
+	risk := &stubRiskService{}
+
+	assert.Same(t, validation, NewInstrumentedOrderValidationService(validation, nil))
+	assert.Same(t, pricing, NewInstrumentedOrderPricingService(pricing, nil))
+	assert.Same(t, risk, NewInstrumentedRiskManagementService(risk, nil))
+}
+
+func TestOrderPipelineMetrics_BucketsObservations(t *testing.T) {
+	metrics := NewOrderPipelineMetrics(OrderPipelineMetricsConfig{
+		Buckets: []time.Duration{time.Second, 10 * time.Millisecond},
+	})
+
+	metrics.Observe(PipelineServiceRisk, "AssessOrderRisk", 5*time.Millisecond, false)
+	metrics.Observe(PipelineServiceRisk, "AssessOrderRisk", 500*time.Millisecond, true)
+	metrics.Observe(PipelineServicePricing, "EstimateFillPrice", 2*time.Second, false)
+
+	snapshots := metrics.Snapshot()
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, PipelineServicePricing, snapshots[0].Service)
+
+	risk := snapshots[1]
+	assert.Equal(t, []LatencyBucket{
+		{UpperBound: 10 * time.Millisecond, Count: 1},
+		{UpperBound: time.Second, Count: 2},
+	}, risk.Latency.Buckets)
+	assert.Equal(t, 505*time.Millisecond, risk.Latency.Sum)
+	assert.Equal(t, uint64(1), risk.Successes)
+	assert.Equal(t, uint64(1), risk.Failures)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// LimitPriceUrgency is how soon the user wants a limit order filled, trading price for fill probability
+type LimitPriceUrgency string
+
+const (
+	LimitPriceUrgencyLow    LimitPriceUrgency = "LOW"
+	LimitPriceUrgencyMedium LimitPriceUrgency = "MEDIUM"
+	LimitPriceUrgencyHigh   LimitPriceUrgency = "HIGH"
+)
+
+// IsValid checks if the urgency is a known limit price urgency
+func (u LimitPriceUrgency) IsValid() bool {
+	switch u {
+	case LimitPriceUrgencyLow, LimitPriceUrgencyMedium, LimitPriceUrgencyHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseLimitPriceUrgency parses a string into a LimitPriceUrgency
+func ParseLimitPriceUrgency(s string) (LimitPriceUrgency, error) {
+	urgency := LimitPriceUrgency(strings.ToUpper(strings.TrimSpace(s)))
+	if !urgency.IsValid() {
+		return "", fmt.Errorf("invalid limit price urgency: %s", s)
+	}
+	return urgency, nil
+}
+
+// DefaultLimitPriceUrgencyShares returns the share of the spread a suggested limit price crosses per urgency
+func DefaultLimitPriceUrgencyShares() map[LimitPriceUrgency]float64 {
+	return map[LimitPriceUrgency]float64{
+		LimitPriceUrgencyLow:    0.0, // Join the order's own touch and wait to be filled
+		LimitPriceUrgencyMedium: 0.5, // Meet the market at the mid
+		LimitPriceUrgencyHigh:   1.0, // Take the opposite touch, walking the book for the full quantity
+	}
+}
+
+// LimitPriceSuggestion is a recommended limit price for an order and what to expect from it
+type LimitPriceSuggestion struct {
+	Symbol            string
+	OrderSide         domain.OrderSide
+	Quantity          float64
+	Urgency           LimitPriceUrgency
+	SuggestedPrice    float64
+	FillProbability   float64
+	EstimatedFillTime time.Duration
+	BidPrice          float64
+	AskPrice          float64
+	QueueAhead        float64 // Quantity resting at the suggested price on the order's side of the book
+	LevelsCrossed     int     // Opposite side levels the suggested price reaches
+	UnfilledQuantity  float64 // Quantity the visible book cannot absorb at the suggested price
+	CalculatedAt      time.Time
+}
+
+// SuggestLimitPrice recommends a limit price that crosses the configured share of the spread for the
+// urgency. Prices that take the opposite touch are pushed through the visible book until it absorbs the
+// quantity; passive prices report the quantity queued ahead of them. Fill probability and time use the
+// same estimates as execution planning.
+func (s *orderPricingService) SuggestLimitPrice(
+	userID, symbol string,
+	side domain.OrderSide,
+	quantity float64,
+	urgency LimitPriceUrgency,
+	pricingClient IPricingDataClient,
+) (*LimitPriceSuggestion, error) {
+	share, configured := s.limitPriceUrgencyShares[urgency]
+	if !configured {
+		return nil, fmt.Errorf("invalid limit price urgency: %s", urgency)
+	}
+
+	marketPrice, err := pricingClient.GetCurrentMarketPrice(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market price: %w", err)
+	}
+	if marketPrice.BidPrice <= 0 || marketPrice.AskPrice < marketPrice.BidPrice {
+		return nil, fmt.Errorf("no two-sided quote for %s", symbol)
+	}
+
+	isBuy := side == domain.OrderSideBuy
+	spread := quotedSpread(marketPrice)
+	suggestion := &LimitPriceSuggestion{
+		Symbol:       symbol,
+		OrderSide:    side,
+		Quantity:     quantity,
+		Urgency:      urgency,
+		BidPrice:     marketPrice.BidPrice,
+		AskPrice:     marketPrice.AskPrice,
+		CalculatedAt: time.Now(),
+	}
+
+	if isBuy {
+		suggestion.SuggestedPrice = marketPrice.BidPrice + share*spread
+	} else {
+		suggestion.SuggestedPrice = marketPrice.AskPrice - share*spread
+	}
+
+	if orderBook, err := pricingClient.GetOrderBookData(symbol); err == nil && orderBook != nil {
+		s.applyBookToSuggestion(suggestion, orderBook, share >= 1, isBuy)
+	}
+
+	// The probe carries the suggested price through the shared fill estimates; it is never stored
+	probe, err := domain.NewOrder(userID, symbol, side, domain.OrderTypeLimit, quantity, &suggestion.SuggestedPrice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order parameters: %w", err)
+	}
+	suggestion.FillProbability = s.calculateLimitOrderFillProbability(probe, marketPrice)
+	suggestion.EstimatedFillTime = s.calculateEstimatedFillTime(probe, marketPrice)
+
+	return suggestion, nil
+}
+
+// applyBookToSuggestion walks the opposite side for a price taking the touch, so the whole quantity is
+// marketable, and otherwise measures the queue resting at the passive price
+func (s *orderPricingService) applyBookToSuggestion(suggestion *LimitPriceSuggestion, orderBook *OrderBookData, takesTouch, isBuy bool) {
+	opposite, own := orderBook.Asks, orderBook.Bids
+	if !isBuy {
+		opposite, own = orderBook.Bids, orderBook.Asks
+	}
+
+	if takesTouch {
+		walk := WalkOrderBook(opposite, suggestion.Quantity, nil, isBuy, s.depthLevels)
+		if walk.FilledQuantity > 0 {
+			suggestion.SuggestedPrice = walk.WorstPrice
+			suggestion.LevelsCrossed = walk.LevelsConsumed
+			suggestion.UnfilledQuantity = walk.ResidualQuantity
+		}
+		return
+	}
+
+	for _, level := range own {
+		if level.Price == suggestion.SuggestedPrice {
+			suggestion.QueueAhead = level.Quantity
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+func newLimitPriceSuggestionClient() *MockPricingDataClient {
+	mockClient := new(MockPricingDataClient)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 29.90, AskPrice: 30.10, LastPrice: 30.00}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{
+		Symbol: "PETR4",
+		Bids:   []PriceLevel{{Price: 29.90, Quantity: 300}, {Price: 29.80, Quantity: 500}},
+		Asks:   []PriceLevel{{Price: 30.10, Quantity: 200}, {Price: 30.20, Quantity: 400}},
+	}, nil)
+	return mockClient
+}
+
+func TestOrderPricingService_SuggestLimitPrice_HigherUrgencyIsMoreAggressive(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := newLimitPriceSuggestionClient()
+
+	for _, side := range []domain.OrderSide{domain.OrderSideBuy, domain.OrderSideSell} {
+		var previous *LimitPriceSuggestion
+		for _, urgency := range []LimitPriceUrgency{LimitPriceUrgencyLow, LimitPriceUrgencyMedium, LimitPriceUrgencyHigh} {
+			suggestion, err := service.SuggestLimitPrice("user1", "PETR4", side, 100, urgency, mockClient)
+			require.NoError(t, err)
+
+			if previous != nil {
+				if side == domain.OrderSideBuy {
+					assert.Greater(t, suggestion.SuggestedPrice, previous.SuggestedPrice, "%s buy", urgency)
+				} else {
+					assert.Less(t, suggestion.SuggestedPrice, previous.SuggestedPrice, "%s sell", urgency)
+				}
+				assert.Greater(t, suggestion.FillProbability, previous.FillProbability, "%s %s", urgency, side)
+				assert.Less(t, suggestion.EstimatedFillTime, previous.EstimatedFillTime, "%s %s", urgency, side)
+			}
+			previous = suggestion
+		}
+	}
+}
+
+func TestOrderPricingService_SuggestLimitPrice_UsesTheBook(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := newLimitPriceSuggestionClient()
+
+	passive, err := service.SuggestLimitPrice("user1", "PETR4", domain.OrderSideBuy, 100, LimitPriceUrgencyLow, mockClient)
+	require.NoError(t, err)
+	assert.Equal(t, 29.90, passive.SuggestedPrice)
+	assert.Equal(t, 300.0, passive.QueueAhead, "a passive buy joins the bid queue")
+
+	// 200 rest at the ask, so the rest of the order has to reach the next level
+	urgent, err := service.SuggestLimitPrice("user1", "PETR4", domain.OrderSideBuy, 500, LimitPriceUrgencyHigh, mockClient)
+	require.NoError(t, err)
+	assert.Equal(t, 30.20, urgent.SuggestedPrice)
+	assert.Equal(t, 2, urgent.LevelsCrossed)
+	assert.Zero(t, urgent.UnfilledQuantity)
+
+	tooLarge, err := service.SuggestLimitPrice("user1", "PETR4", domain.OrderSideBuy, 1000, LimitPriceUrgencyHigh, mockClient)
+	require.NoError(t, err)
+	assert.Equal(t, 30.20, tooLarge.SuggestedPrice)
+	assert.Equal(t, 400.0, tooLarge.UnfilledQuantity)
+}
+
+func TestOrderPricingService_SuggestLimitPrice_ConfiguredUrgencyShares(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		LimitPriceUrgencyShares: map[LimitPriceUrgency]float64{LimitPriceUrgencyMedium: 0.25},
+	})
+	mockClient := newLimitPriceSuggestionClient()
+
+	medium, err := service.SuggestLimitPrice("user1", "PETR4", domain.OrderSideSell, 100, LimitPriceUrgencyMedium, mockClient)
+	require.NoError(t, err)
+	assert.InDelta(t, 30.05, medium.SuggestedPrice, 1e-9)
+
+	low, err := service.SuggestLimitPrice("user1", "PETR4", domain.OrderSideSell, 100, LimitPriceUrgencyLow, mockClient)
+	require.NoError(t, err)
+	assert.Equal(t, 30.10, low.SuggestedPrice, "urgencies left out of the configuration keep their default share")
+}
+
+func TestOrderPricingService_SuggestLimitPrice_RequiresTwoSidedQuote(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	mockClient.On("GetCurrentMarketPrice", "THIN3").Return(&MarketPrice{Symbol: "THIN3", AskPrice: 10.00}, nil)
+
+	_, err := service.SuggestLimitPrice("user1", "THIN3", domain.OrderSideBuy, 100, LimitPriceUrgencyMedium, mockClient)
+	assert.Error(t, err)
+
+	_, err = service.SuggestLimitPrice("user1", "THIN3", domain.OrderSideBuy, 100, LimitPriceUrgency("NOW"), mockClient)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// OrderBookPressure is the normalized bid/ask pressure for a symbol.
+// Values range from -1 (all resting volume on the ask) to 1 (all resting volume on the bid).
+type OrderBookPressure struct {
+	Symbol      string
+	Raw         float64
+	Smoothed    float64
+	BidVolume   float64
+	AskVolume   float64
+	SampleCount int
+	Timestamp   time.Time
+}
+
+// OrderBookPressureService computes a smoothed bid/ask pressure metric per symbol
+type OrderBookPressureService interface {
+	// CalculatePressure records a new sample for the symbol and returns the raw and smoothed pressure.
+	// The order book is preferred; market depth is used when the book has no levels.
+	CalculatePressure(symbol string, orderBook *OrderBookData, marketDepth *MarketDepth) (*OrderBookPressure, error)
+	// Reset discards the smoothing window for the symbol
+	Reset(symbol string)
+}
+
+type orderBookPressureService struct {
+	smoothingWindow int
+	depthLevels     int
+
+	mu      sync.Mutex
+	samples map[string][]float64
+}
+
+// OrderBookPressureConfig holds configuration for the pressure metric
+type OrderBookPressureConfig struct {
+	SmoothingWindow int // Number of samples averaged into the smoothed value
+	DepthLevels     int // Number of book levels per side included in the volume sums
+}
+
+// NewOrderBookPressureService creates a new instance of OrderBookPressureService
+func NewOrderBookPressureService(config OrderBookPressureConfig) OrderBookPressureService {
+	if config.SmoothingWindow < 1 {
+		config.SmoothingWindow = 1
+	}
+	return &orderBookPressureService{
+		smoothingWindow: config.SmoothingWindow,
+		depthLevels:     config.DepthLevels,
+		samples:         make(map[string][]float64),
+	}
+}
+
+// NewOrderBookPressureServiceWithDefaults creates a service with default configuration
+func NewOrderBookPressureServiceWithDefaults() OrderBookPressureService {
+	return NewOrderBookPressureService(OrderBookPressureConfig{
+		SmoothingWindow: 10, // Average over the last 10 samples
+		DepthLevels:     5,  // Top 5 levels on each side
+	})
+}
+
+// CalculatePressure records a new sample for the symbol and returns the raw and smoothed pressure
+func (s *orderBookPressureService) CalculatePressure(symbol string, orderBook *OrderBookData, marketDepth *MarketDepth) (*OrderBookPressure, error) {
+	pressure := &OrderBookPressure{
+		Symbol:    symbol,
+		Timestamp: time.Now(),
+	}
+
+	switch {
+	case orderBook != nil && (len(orderBook.Bids) > 0 || len(orderBook.Asks) > 0):
+		pressure.BidVolume = sumBookLevels(orderBook.Bids, s.depthLevels)
+		pressure.AskVolume = sumBookLevels(orderBook.Asks, s.depthLevels)
+		raw, err := bookPressure(orderBook, s.depthLevels)
+		if err != nil {
+			return nil, err
+		}
+		pressure.Raw = raw
+	case marketDepth != nil:
+		// ImbalanceRatio is the bid share of depth, 0.5 being a balanced book
+		pressure.BidVolume = marketDepth.BidDepth
+		pressure.AskVolume = marketDepth.AskDepth
+		pressure.Raw = clampPressure(2*marketDepth.ImbalanceRatio - 1)
+	default:
+		return nil, errors.New("order book or market depth is required to calculate pressure")
+	}
+
+	pressure.Smoothed, pressure.SampleCount = s.addSample(symbol, pressure.Raw)
+	return pressure, nil
+}
+
+// Reset discards the smoothing window for the symbol
+func (s *orderBookPressureService) Reset(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.samples, symbol)
+}
+
+// bookPressure returns the unsmoothed pressure of the top depthLevels of each side of the book (0 sums every level)
+func bookPressure(orderBook *OrderBookData, depthLevels int) (float64, error) {
+	bidVolume := sumBookLevels(orderBook.Bids, depthLevels)
+	askVolume := sumBookLevels(orderBook.Asks, depthLevels)
+	total := bidVolume + askVolume
+	if total <= 0 {
+		return 0, errors.New("order book has no resting volume")
+	}
+	return (bidVolume - askVolume) / total, nil
+}
+
+func sumBookLevels(levels []PriceLevel, depthLevels int) float64 {
+	total := 0.0
+	for i, level := range levels {
+		if depthLevels > 0 && i >= depthLevels {
+			break
+		}
+		total += level.Quantity
+	}
+	return total
+}
+
+// addSample appends the sample to the symbol's window and returns the moving average
+func (s *orderBookPressureService) addSample(symbol string, value float64) (float64, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window := append(s.samples[symbol], value)
+	if len(window) > s.smoothingWindow {
+		window = window[len(window)-s.smoothingWindow:]
+	}
+	s.samples[symbol] = window
+
+	sum := 0.0
+	for _, sample := range window {
+		sum += sample
+	}
+	return sum / float64(len(window)), len(window)
+}
+
+func clampPressure(value float64) float64 {
+	if value > 1 {
+		return 1
+	}
+	if value < -1 {
+		return -1
+	}
+	return value
+}
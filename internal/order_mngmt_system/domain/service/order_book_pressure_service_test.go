@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBookPressureService_CalculatePressure_LopsidedBook(t *testing.T) {
+	svc := NewOrderBookPressureServiceWithDefaults()
+
+	book := &OrderBookData{
+		Symbol: "PETR4",
+		Bids:   []PriceLevel{{Price: 25.00, Quantity: 9000}, {Price: 24.99, Quantity: 9000}},
+		Asks:   []PriceLevel{{Price: 25.01, Quantity: 1000}, {Price: 25.02, Quantity: 1000}},
+	}
+
+	pressure, err := svc.CalculatePressure("PETR4", book, nil)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.8, pressure.Raw, 0.0001)
+	assert.InDelta(t, 0.8, pressure.Smoothed, 0.0001)
+	assert.Equal(t, 18000.0, pressure.BidVolume)
+	assert.Equal(t, 2000.0, pressure.AskVolume)
+}
+
+func TestOrderBookPressureService_CalculatePressure_SmoothsOverWindow(t *testing.T) {
+	svc := NewOrderBookPressureService(OrderBookPressureConfig{SmoothingWindow: 2})
+
+	bidHeavy := &OrderBookData{Bids: []PriceLevel{{Quantity: 100}}}
+	askHeavy := &OrderBookData{Asks: []PriceLevel{{Quantity: 100}}}
+
+	first, _ := svc.CalculatePressure("VALE3", bidHeavy, nil)
+	second, _ := svc.CalculatePressure("VALE3", askHeavy, nil)
+	third, _ := svc.CalculatePressure("VALE3", askHeavy, nil)
+
+	assert.Equal(t, 1.0, first.Smoothed)
+	assert.InDelta(t, 0.0, second.Smoothed, 0.0001)
+	assert.Equal(t, -1.0, third.Smoothed)
+	assert.Equal(t, 2, third.SampleCount)
+}
+
+func TestOrderBookPressureService_CalculatePressure_FallsBackToMarketDepth(t *testing.T) {
+	svc := NewOrderBookPressureServiceWithDefaults()
+
+	pressure, err := svc.CalculatePressure("ITUB4", &OrderBookData{}, &MarketDepth{ImbalanceRatio: 0.25})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, -0.5, pressure.Raw, 0.0001)
+
+	_, err = svc.CalculatePressure("ITUB4", nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"math"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// BookWalkEstimate is the fill a marketable order would get by sweeping the visible book level by level
+type BookWalkEstimate struct {
+	AveragePrice     float64 // Volume-weighted price of the quantity the book absorbs
+	WorstPrice       float64 // Price of the last level the order reaches
+	FilledQuantity   float64
+	ResidualQuantity float64 // Quantity left once the walked levels, or the limit price, are exhausted
+	LevelsConsumed   int
+}
+
+// WalkOrderBook sweeps the levels of the opposite side of the book, best price first, until the
+// quantity is filled. Buys stop at levels above the limit price and sells at levels below it; a
+// nil limit walks every level. maxLevels bounds the walk (0 walks the whole side).
+func WalkOrderBook(levels []PriceLevel, quantity float64, limitPrice *float64, isBuy bool, maxLevels int) BookWalkEstimate {
+	estimate := BookWalkEstimate{ResidualQuantity: quantity}
+	if quantity <= 0 {
+		return estimate
+	}
+
+	notional := 0.0
+	for i, level := range levels {
+		if maxLevels > 0 && i >= maxLevels {
+			break
+		}
+		if level.Quantity <= 0 || level.Price <= 0 {
+			continue
+		}
+		if limitPrice != nil && ((isBuy && level.Price > *limitPrice) || (!isBuy && level.Price < *limitPrice)) {
+			break
+		}
+
+		taken := math.Min(level.Quantity, estimate.ResidualQuantity)
+		notional += taken * level.Price
+		estimate.FilledQuantity += taken
+		estimate.ResidualQuantity -= taken
+		estimate.WorstPrice = level.Price
+		estimate.LevelsConsumed++
+
+		if estimate.ResidualQuantity <= 0 {
+			estimate.ResidualQuantity = 0
+			break
+		}
+	}
+
+	if estimate.FilledQuantity > 0 {
+		estimate.AveragePrice = notional / estimate.FilledQuantity
+	}
+	return estimate
+}
+
+// isMarketable reports whether the order trades against the book on arrival: market orders,
+// and limit orders priced through the opposite touch
+func isMarketable(order *domain.Order, marketPrice *MarketPrice) bool {
+	switch order.OrderType() {
+	case domain.OrderTypeMarket:
+		return true
+	case domain.OrderTypeLimit:
+		if order.Price() == nil || marketPrice == nil {
+			return false
+		}
+		if order.IsBuyOrder() {
+			return marketPrice.AskPrice > 0 && *order.Price() >= marketPrice.AskPrice
+		}
+		return marketPrice.BidPrice > 0 && *order.Price() <= marketPrice.BidPrice
+	default:
+		return false
+	}
+}
+
+// estimateBookWalk walks the order book for marketable orders. It reports false when walking is
+// disabled, the order rests on the book, or no book is available.
+func (s *orderPricingService) estimateBookWalk(order *domain.Order, pricingClient IPricingDataClient) (*BookWalkEstimate, bool) {
+	if !s.walkBookForFillEstimate || order.Quantity() <= 0 {
+		return nil, false
+	}
+
+	marketPrice, err := pricingClient.GetCurrentMarketPrice(order.Symbol())
+	if err != nil || !isMarketable(order, marketPrice) {
+		return nil, false
+	}
+
+	orderBook, err := pricingClient.GetOrderBookData(order.Symbol())
+	if err != nil || orderBook == nil {
+		return nil, false
+	}
+
+	levels := orderBook.Asks
+	if order.IsSellOrder() {
+		levels = orderBook.Bids
+	}
+	if len(levels) == 0 {
+		return nil, false
+	}
+
+	var limitPrice *float64
+	if order.OrderType() != domain.OrderTypeMarket {
+		limitPrice = order.Price()
+	}
+
+	estimate := WalkOrderBook(levels, order.Quantity(), limitPrice, order.IsBuyOrder(), s.depthLevels)
+	return &estimate, true
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkOrderBook_FullyAbsorbed(t *testing.T) {
+	asks := []PriceLevel{
+		{Price: 100, Quantity: 10},
+		{Price: 101, Quantity: 10},
+		{Price: 102, Quantity: 10},
+	}
+
+	estimate := WalkOrderBook(asks, 15, nil, true, 0)
+
+	assert.Equal(t, 15.0, estimate.FilledQuantity)
+	assert.Equal(t, 0.0, estimate.ResidualQuantity)
+	assert.Equal(t, 2, estimate.LevelsConsumed)
+	assert.Equal(t, 101.0, estimate.WorstPrice)
+	assert.InDelta(t, (10*100.0+5*101.0)/15, estimate.AveragePrice, 1e-9)
+}
+
+func TestWalkOrderBook_ThinBookLeavesResidual(t *testing.T) {
+	bids := []PriceLevel{
+		{Price: 50, Quantity: 5},
+		{Price: 49, Quantity: 5},
+	}
+
+	estimate := WalkOrderBook(bids, 25, nil, false, 0)
+
+	assert.Equal(t, 10.0, estimate.FilledQuantity)
+	assert.Equal(t, 15.0, estimate.ResidualQuantity)
+	assert.Equal(t, 2, estimate.LevelsConsumed)
+	assert.Equal(t, 49.0, estimate.WorstPrice)
+	assert.InDelta(t, 49.5, estimate.AveragePrice, 1e-9)
+}
+
+func TestWalkOrderBook_StopsAtLimitPrice(t *testing.T) {
+	asks := []PriceLevel{
+		{Price: 100, Quantity: 10},
+		{Price: 101, Quantity: 10},
+	}
+	limit := 100.5
+
+	estimate := WalkOrderBook(asks, 15, &limit, true, 0)
+
+	assert.Equal(t, 10.0, estimate.FilledQuantity)
+	assert.Equal(t, 5.0, estimate.ResidualQuantity)
+	assert.Equal(t, 100.0, estimate.AveragePrice)
+}
+
+func TestWalkOrderBook_RespectsMaxLevels(t *testing.T) {
+	asks := []PriceLevel{
+		{Price: 100, Quantity: 10},
+		{Price: 101, Quantity: 10},
+	}
+
+	estimate := WalkOrderBook(asks, 15, nil, true, 1)
+
+	assert.Equal(t, 10.0, estimate.FilledQuantity)
+	assert.Equal(t, 5.0, estimate.ResidualQuantity)
+	assert.Equal(t, 1, estimate.LevelsConsumed)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_ThinBook(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 30, nil)
+
+	marketPrice := &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 101, LastPrice: 100.5, Spread: 1, SpreadPercent: 1}
+	orderBook := &OrderBookData{
+		Symbol: "PETR4",
+		Asks: []PriceLevel{
+			{Price: 101, Quantity: 10},
+			{Price: 102, Quantity: 10},
+		},
+	}
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 5.0}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{EstimatedImpact: 0.1}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(orderBook, nil)
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, plan.BookWalk) {
+		assert.Equal(t, 20.0, plan.BookWalk.FilledQuantity)
+		assert.Equal(t, 10.0, plan.BookWalk.ResidualQuantity)
+		assert.InDelta(t, 101.5, plan.EstimatedFillPrice, 1e-9)
+	}
+	assert.Contains(t, plan.RiskWarnings, "Visible book absorbs 20.00 of 30.00; 10.00 would remain unfilled beyond the estimated price")
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OrderExpiryScheduler tracks when good-till-date orders expire and hands them out in batches.
+// A per-order jitter is added to each deadline so orders sharing a deadline (e.g. end of day)
+// do not all expire in the same sweep.
+type OrderExpiryScheduler interface {
+	// Schedule registers the order's deadline and returns the jittered expiry time
+	Schedule(orderID string, deadline time.Time) time.Time
+	// Unschedule removes the order, e.g. after it fills or is cancelled
+	Unschedule(orderID string)
+	// NextBatch removes and returns the orders due at or before now, at most one batch
+	NextBatch(now time.Time) []string
+	// Pending returns the number of scheduled orders
+	Pending() int
+}
+
+type orderExpiryScheduler struct {
+	maxJitter      time.Duration
+	sweepBatchSize int
+
+	mu       sync.Mutex
+	expiries map[string]time.Time
+}
+
+// OrderExpirySchedulerConfig holds configuration for GTD expiry scheduling
+type OrderExpirySchedulerConfig struct {
+	MaxJitter      time.Duration // Upper bound of the delay added to each deadline; 0 disables jitter
+	SweepBatchSize int           // Maximum orders returned per sweep; 0 means unlimited
+}
+
+// NewOrderExpiryScheduler creates a new instance of OrderExpiryScheduler
+func NewOrderExpiryScheduler(config OrderExpirySchedulerConfig) OrderExpiryScheduler {
+	return &orderExpiryScheduler{
+		maxJitter:      config.MaxJitter,
+		sweepBatchSize: config.SweepBatchSize,
+		expiries:       make(map[string]time.Time),
+	}
+}
+
+// NewOrderExpirySchedulerWithDefaults creates a scheduler with default configuration
+func NewOrderExpirySchedulerWithDefaults() OrderExpiryScheduler {
+	return NewOrderExpiryScheduler(OrderExpirySchedulerConfig{
+		MaxJitter:      30 * time.Second, // Spread same-deadline expiries over 30 seconds
+		SweepBatchSize: 100,              // Expire at most 100 orders per sweep
+	})
+}
+
+// Schedule registers the order's deadline and returns the jittered expiry time
+func (s *orderExpiryScheduler) Schedule(orderID string, deadline time.Time) time.Time {
+	expiresAt := deadline.Add(s.jitterFor(orderID))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expiries[orderID] = expiresAt
+	return expiresAt
+}
+
+// Unschedule removes the order from the schedule
+func (s *orderExpiryScheduler) Unschedule(orderID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expiries, orderID)
+}
+
+// NextBatch removes and returns the earliest orders due at or before now
+func (s *orderExpiryScheduler) NextBatch(now time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]string, 0)
+	for orderID, expiresAt := range s.expiries {
+		if !expiresAt.After(now) {
+			due = append(due, orderID)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if s.expiries[due[i]].Equal(s.expiries[due[j]]) {
+			return due[i] < due[j]
+		}
+		return s.expiries[due[i]].Before(s.expiries[due[j]])
+	})
+
+	if s.sweepBatchSize > 0 && len(due) > s.sweepBatchSize {
+		due = due[:s.sweepBatchSize]
+	}
+
+	for _, orderID := range due {
+		delete(s.expiries, orderID)
+	}
+	return due
+}
+
+// Pending returns the number of scheduled orders
+func (s *orderExpiryScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.expiries)
+}
+
+// jitterFor derives the delay from the order ID so the expiry is stable across restarts
+func (s *orderExpiryScheduler) jitterFor(orderID string) time.Duration {
+	if s.maxJitter <= 0 {
+		return 0
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(orderID))
+	return time.Duration(hash.Sum64() % uint64(s.maxJitter))
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderExpiryScheduler_SameDeadlineSpreadAcrossWindow(t *testing.T) {
+	scheduler := NewOrderExpiryScheduler(OrderExpirySchedulerConfig{MaxJitter: 30 * time.Second})
+	deadline := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+
+	distinct := make(map[time.Time]bool)
+	for i := 0; i < 200; i++ {
+		expiresAt := scheduler.Schedule(fmt.Sprintf("order-%d", i), deadline)
+
+		assert.False(t, expiresAt.Before(deadline))
+		assert.True(t, expiresAt.Before(deadline.Add(30*time.Second)))
+		distinct[expiresAt] = true
+	}
+	assert.Greater(t, len(distinct), 100)
+
+	// Midway through the window only part of the batch has expired
+	firstHalf := scheduler.NextBatch(deadline.Add(15 * time.Second))
+	assert.NotEmpty(t, firstHalf)
+	assert.Less(t, len(firstHalf), 200)
+
+	rest := scheduler.NextBatch(deadline.Add(30 * time.Second))
+	assert.Equal(t, 200, len(firstHalf)+len(rest))
+	assert.Equal(t, 0, scheduler.Pending())
+}
+
+func TestOrderExpiryScheduler_JitterIsStablePerOrder(t *testing.T) {
+	deadline := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+	first := NewOrderExpirySchedulerWithDefaults()
+	second := NewOrderExpirySchedulerWithDefaults()
+
+	assert.Equal(t, first.Schedule("order-1", deadline), second.Schedule("order-1", deadline))
+}
+
+func TestOrderExpiryScheduler_NextBatch_RespectsBatchSize(t *testing.T) {
+	scheduler := NewOrderExpiryScheduler(OrderExpirySchedulerConfig{SweepBatchSize: 3})
+	deadline := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		scheduler.Schedule(fmt.Sprintf("order-%d", i), deadline)
+	}
+	scheduler.Schedule("order-later", deadline.Add(time.Hour))
+	scheduler.Unschedule("order-4")
+
+	assert.Len(t, scheduler.NextBatch(deadline), 3)
+	assert.Len(t, scheduler.NextBatch(deadline), 1)
+	assert.Empty(t, scheduler.NextBatch(deadline))
+	assert.Equal(t, 1, scheduler.Pending())
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// OrderLatencyTotal labels the histogram of submit-to-processed latency
+const OrderLatencyTotal = "TOTAL"
+
+// LatencyBucket is a cumulative histogram bucket: the observations at or below the upper bound
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// LatencyHistogram is a snapshot of the latency distribution of one stage
+type LatencyHistogram struct {
+	Stage   string // Stage the latency leads up to, or OrderLatencyTotal
+	Buckets []LatencyBucket
+	Count   uint64
+	Sum     time.Duration
+}
+
+// OrderLatencyTracker records when orders pass each submission stage. Every stage feeds a histogram
+// of the time since the previous stage, and reaching PROCESSED feeds the total latency histogram.
+type OrderLatencyTracker interface {
+	// RecordStage records the order reaching the stage. Timelines start at SUBMITTED; later stages of
+	// orders the tracker has not seen submitted are ignored.
+	RecordStage(orderID string, stage domain.OrderLatencyStage, at time.Time)
+	// Timeline returns a copy of the order's stage timestamps
+	Timeline(orderID string) (*domain.OrderLatencyTimeline, bool)
+	// Histograms returns snapshots of every stage histogram followed by the total
+	Histograms() []LatencyHistogram
+}
+
+type orderLatencyTracker struct {
+	buckets          []time.Duration
+	maxTrackedOrders int
+
+	mu         sync.Mutex
+	timelines  map[string]*domain.OrderLatencyTimeline
+	order      []string // Order IDs in submission order, for eviction
+	histograms map[string]*latencyHistogram
+}
+
+type latencyHistogram struct {
+	counts []uint64 // Per bucket, not cumulative; the last entry counts observations above every bound
+	count  uint64
+	sum    time.Duration
+}
+
+// OrderLatencyTrackerConfig holds configuration for order latency tracking
+type OrderLatencyTrackerConfig struct {
+	Buckets          []time.Duration // Histogram bucket upper bounds
+	MaxTrackedOrders int             // Timelines kept for per-order queries; the oldest are dropped first (0 keeps all)
+}
+
+// NewOrderLatencyTracker creates a new instance of OrderLatencyTracker
+func NewOrderLatencyTracker(config OrderLatencyTrackerConfig) OrderLatencyTracker {
+	buckets := make([]time.Duration, len(config.Buckets))
+	copy(buckets, config.Buckets)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	tracker := &orderLatencyTracker{
+		buckets:          buckets,
+		maxTrackedOrders: config.MaxTrackedOrders,
+		timelines:        make(map[string]*domain.OrderLatencyTimeline),
+		order:            make([]string, 0),
+		histograms:       make(map[string]*latencyHistogram),
+	}
+
+	for _, name := range tracker.histogramNames() {
+		tracker.histograms[name] = &latencyHistogram{counts: make([]uint64, len(buckets)+1)}
+	}
+
+	return tracker
+}
+
+// NewOrderLatencyTrackerWithDefaults creates a tracker with default configuration
+func NewOrderLatencyTrackerWithDefaults() OrderLatencyTracker {
+	return NewOrderLatencyTracker(OrderLatencyTrackerConfig{
+		Buckets: []time.Duration{ // From in-process checks up to slow queue backlogs
+			5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+			100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+			time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second, 30 * time.Second,
+		},
+		MaxTrackedOrders: 10000, // Keep the last 10,000 orders queryable
+	})
+}
+
+// RecordStage records the order reaching the stage
+func (t *orderLatencyTracker) RecordStage(orderID string, stage domain.OrderLatencyStage, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timeline, exists := t.timelines[orderID]
+	if !exists {
+		if stage != domain.OrderLatencyStageSubmitted {
+			return
+		}
+		timeline = domain.NewOrderLatencyTimeline(orderID)
+		t.timelines[orderID] = timeline
+		t.order = append(t.order, orderID)
+		t.evictOldest()
+	}
+
+	entry, recorded := timeline.Record(stage, at)
+	if !recorded || stage == domain.OrderLatencyStageSubmitted {
+		return
+	}
+
+	t.observe(stage.String(), entry.SincePrevious)
+	if total, complete := timeline.TotalLatency(); complete && stage == domain.OrderLatencyStageProcessed {
+		t.observe(OrderLatencyTotal, total)
+	}
+}
+
+// Timeline returns a copy of the order's stage timestamps
+func (t *orderLatencyTracker) Timeline(orderID string) (*domain.OrderLatencyTimeline, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timeline, exists := t.timelines[orderID]
+	if !exists {
+		return nil, false
+	}
+	return timeline.Clone(), true
+}
+
+// Histograms returns snapshots of every stage histogram followed by the total
+func (t *orderLatencyTracker) Histograms() []LatencyHistogram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshots := make([]LatencyHistogram, 0, len(t.histograms))
+	for _, name := range t.histogramNames() {
+		snapshots = append(snapshots, t.histograms[name].snapshot(name, t.buckets))
+	}
+
+	return snapshots
+}
+
+func (t *orderLatencyTracker) observe(name string, latency time.Duration) {
+	t.histograms[name].observe(t.buckets, latency)
+}
+
+func (h *latencyHistogram) observe(buckets []time.Duration, latency time.Duration) {
+	index := sort.Search(len(buckets), func(i int) bool { return latency <= buckets[i] })
+	h.counts[index]++
+	h.count++
+	h.sum += latency
+}
+
+// snapshot returns the histogram with cumulative bucket counts
+func (h *latencyHistogram) snapshot(name string, buckets []time.Duration) LatencyHistogram {
+	snapshot := LatencyHistogram{
+		Stage:   name,
+		Buckets: make([]LatencyBucket, len(buckets)),
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+
+	var cumulative uint64
+	for i, bound := range buckets {
+		cumulative += h.counts[i]
+		snapshot.Buckets[i] = LatencyBucket{UpperBound: bound, Count: cumulative}
+	}
+	return snapshot
+}
+
+// evictOldest drops the oldest timelines beyond the limit; their histogram observations remain
+func (t *orderLatencyTracker) evictOldest() {
+	if t.maxTrackedOrders <= 0 {
+		return
+	}
+	for len(t.order) > t.maxTrackedOrders {
+		delete(t.timelines, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+// histogramNames lists the stages after SUBMITTED followed by the total
+func (t *orderLatencyTracker) histogramNames() []string {
+	names := make([]string, 0)
+	for _, stage := range domain.AllOrderLatencyStages() {
+		if stage != domain.OrderLatencyStageSubmitted {
+			names = append(names, stage.String())
+		}
+	}
+	return append(names, OrderLatencyTotal)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLatencyTracker() OrderLatencyTracker {
+	return NewOrderLatencyTracker(OrderLatencyTrackerConfig{
+		Buckets:          []time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second},
+		MaxTrackedOrders: 2,
+	})
+}
+
+func histogramFor(t *testing.T, tracker OrderLatencyTracker, stage string) LatencyHistogram {
+	for _, histogram := range tracker.Histograms() {
+		if histogram.Stage == stage {
+			return histogram
+		}
+	}
+	t.Fatalf("No histogram for %s", stage)
+	return LatencyHistogram{}
+}
+
+func TestOrderLatencyTracker_StageTimestampsAreMonotonic(t *testing.T) {
+	tracker := newTestLatencyTracker()
+	submittedAt := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+
+	tracker.RecordStage("order-1", domain.OrderLatencyStageSubmitted, submittedAt)
+	tracker.RecordStage("order-1", domain.OrderLatencyStageValidated, submittedAt.Add(5*time.Millisecond))
+	tracker.RecordStage("order-1", domain.OrderLatencyStagePriced, submittedAt.Add(20*time.Millisecond))
+	tracker.RecordStage("order-1", domain.OrderLatencyStagePublished, submittedAt.Add(30*time.Millisecond))
+	// The worker host's clock runs behind the API host
+	tracker.RecordStage("order-1", domain.OrderLatencyStageProcessed, submittedAt.Add(25*time.Millisecond))
+
+	timeline, exists := tracker.Timeline("order-1")
+	require.True(t, exists)
+	require.Len(t, timeline.Stages, 5)
+	for i := 1; i < len(timeline.Stages); i++ {
+		assert.False(t, timeline.Stages[i].At.Before(timeline.Stages[i-1].At),
+			"%s recorded before %s", timeline.Stages[i].Stage, timeline.Stages[i-1].Stage)
+		assert.GreaterOrEqual(t, timeline.Stages[i].SincePrevious, time.Duration(0))
+	}
+	assert.Equal(t, domain.OrderLatencyStageProcessed, timeline.Stages[4].Stage)
+}
+
+func TestOrderLatencyTracker_HistogramObservesTotalLatency(t *testing.T) {
+	tracker := newTestLatencyTracker()
+	submittedAt := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+
+	tracker.RecordStage("order-1", domain.OrderLatencyStageSubmitted, submittedAt)
+	tracker.RecordStage("order-1", domain.OrderLatencyStagePublished, submittedAt.Add(40*time.Millisecond))
+	tracker.RecordStage("order-1", domain.OrderLatencyStageProcessed, submittedAt.Add(400*time.Millisecond))
+
+	total := histogramFor(t, tracker, OrderLatencyTotal)
+	assert.Equal(t, uint64(1), total.Count)
+	assert.Equal(t, 400*time.Millisecond, total.Sum)
+	assert.Equal(t, []LatencyBucket{
+		{UpperBound: 10 * time.Millisecond, Count: 0},
+		{UpperBound: 100 * time.Millisecond, Count: 0},
+		{UpperBound: time.Second, Count: 1},
+	}, total.Buckets)
+
+	published := histogramFor(t, tracker, domain.OrderLatencyStagePublished.String())
+	assert.Equal(t, uint64(1), published.Count)
+	assert.Equal(t, 40*time.Millisecond, published.Sum)
+}
+
+func TestOrderLatencyTracker_IgnoresUnknownOrdersAndEvictsOldest(t *testing.T) {
+	tracker := newTestLatencyTracker()
+	now := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+
+	// A worker may process orders submitted before a restart
+	tracker.RecordStage("unknown", domain.OrderLatencyStageProcessed, now)
+	_, exists := tracker.Timeline("unknown")
+	assert.False(t, exists)
+	assert.Equal(t, uint64(0), histogramFor(t, tracker, domain.OrderLatencyStageProcessed.String()).Count)
+
+	for _, orderID := range []string{"order-1", "order-2", "order-3"} {
+		tracker.RecordStage(orderID, domain.OrderLatencyStageSubmitted, now)
+	}
+	_, exists = tracker.Timeline("order-1")
+	assert.False(t, exists)
+	_, exists = tracker.Timeline("order-3")
+	assert.True(t, exists)
+}
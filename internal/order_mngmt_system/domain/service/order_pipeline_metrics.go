@@ -0,0 +1,139 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Domain services instrumented by the order pipeline metrics
+const (
+	PipelineServiceValidation = "validation"
+	PipelineServicePricing    = "pricing"
+	PipelineServiceRisk       = "risk"
+)
+
+// Outcomes counted for each instrumented method
+const (
+	PipelineOutcomeSuccess = "success"
+	PipelineOutcomeFailure = "failure"
+)
+
+// PipelineMethodMetrics is a snapshot of the timing and outcomes of one domain service method
+type PipelineMethodMetrics struct {
+	Service   string
+	Method    string
+	Latency   LatencyHistogram
+	Successes uint64
+	Failures  uint64
+}
+
+// OrderPipelineMetrics records how long each validation, pricing and risk method takes and whether
+// it succeeded, so the slowest or most failing stage of the order pipeline can be found.
+type OrderPipelineMetrics interface {
+	// Observe records one call of the method. A call fails when it returned an error or rejected the order.
+	Observe(service, method string, duration time.Duration, failed bool)
+	// Snapshot returns the metrics of every method called so far, sorted by service and method
+	Snapshot() []PipelineMethodMetrics
+}
+
+type pipelineMethodKey struct {
+	service string
+	method  string
+}
+
+type pipelineMethodStats struct {
+	latency   *latencyHistogram
+	successes uint64
+	failures  uint64
+}
+
+type orderPipelineMetrics struct {
+	buckets []time.Duration
+
+	mu      sync.Mutex
+	methods map[pipelineMethodKey]*pipelineMethodStats
+}
+
+// OrderPipelineMetricsConfig holds configuration for the order pipeline metrics
+type OrderPipelineMetricsConfig struct {
+	Buckets []time.Duration // Histogram bucket upper bounds
+}
+
+// NewOrderPipelineMetrics creates a new instance of OrderPipelineMetrics
+func NewOrderPipelineMetrics(config OrderPipelineMetricsConfig) OrderPipelineMetrics {
+	buckets := make([]time.Duration, len(config.Buckets))
+	copy(buckets, config.Buckets)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	return &orderPipelineMetrics{
+		buckets: buckets,
+		methods: make(map[pipelineMethodKey]*pipelineMethodStats),
+	}
+}
+
+// NewOrderPipelineMetricsWithDefaults creates pipeline metrics with default configuration
+func NewOrderPipelineMetricsWithDefaults() OrderPipelineMetrics {
+	return NewOrderPipelineMetrics(OrderPipelineMetricsConfig{
+		Buckets: []time.Duration{ // In-process checks are sub-millisecond; data client calls take longer
+			100 * time.Microsecond, 500 * time.Microsecond, time.Millisecond, 5 * time.Millisecond,
+			10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond,
+			250 * time.Millisecond, 500 * time.Millisecond, time.Second,
+		},
+	})
+}
+
+// Observe records one call of the method
+func (m *orderPipelineMetrics) Observe(service, method string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := pipelineMethodKey{service: service, method: method}
+	stats, exists := m.methods[key]
+	if !exists {
+		stats = &pipelineMethodStats{latency: &latencyHistogram{counts: make([]uint64, len(m.buckets)+1)}}
+		m.methods[key] = stats
+	}
+
+	stats.latency.observe(m.buckets, duration)
+	if failed {
+		stats.failures++
+	} else {
+		stats.successes++
+	}
+}
+
+// Snapshot returns the metrics of every method called so far
+func (m *orderPipelineMetrics) Snapshot() []PipelineMethodMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshots := make([]PipelineMethodMetrics, 0, len(m.methods))
+	for key, stats := range m.methods {
+		snapshots = append(snapshots, PipelineMethodMetrics{
+			Service:   key.service,
+			Method:    key.method,
+			Latency:   stats.latency.snapshot(key.method, m.buckets),
+			Successes: stats.successes,
+			Failures:  stats.failures,
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Service != snapshots[j].Service {
+			return snapshots[i].Service < snapshots[j].Service
+		}
+		return snapshots[i].Method < snapshots[j].Method
+	})
+	return snapshots
+}
+
+// observePipelineCall records a call that started at start; the caller passes whether it failed
+func observePipelineCall(metrics OrderPipelineMetrics, service, method string, start time.Time, failed bool) {
+	metrics.Observe(service, method, time.Since(start), failed)
+}
+
+// validationFailed reports whether a validation call errored or rejected the order
+func validationFailed(result *ValidationResult, err error) bool {
+	return err != nil || (result != nil && !result.IsValid)
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"fmt"
+	"log"
+	"math"
+	"strings"
 	"time"
 
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
@@ -18,6 +21,37 @@ type IPricingDataClient interface {
 	GetPriceImpactEstimate(symbol string, orderSide domain.OrderSide, quantity float64) (*PriceImpact, error)
 }
 
+// IAssetCategoryProvider is implemented by pricing clients that know each symbol's asset category.
+// Category fee schedules are only applied through clients that implement it.
+type IAssetCategoryProvider interface {
+	GetAssetCategory(symbol string) (AssetCategory, error)
+}
+
+// ITradingHoursProvider is implemented by pricing clients that report session times for each symbol.
+// Extended-hours pricing rules are only applied through clients that implement it.
+type ITradingHoursProvider interface {
+	GetTradingHours(symbol string) (*TradingHours, error)
+}
+
+// AssetCategory represents the category of an asset, in the same order as the market data categories
+type AssetCategory int32
+
+const (
+	AssetCategoryStock AssetCategory = iota
+	AssetCategoryBond
+	AssetCategoryCrypto
+	AssetCategoryFund
+	AssetCategoryETF
+)
+
+// FeeSchedule sets the fees charged for orders of one asset category
+type FeeSchedule struct {
+	CommissionPercent float64 // Commission as a percentage of the order value
+	MinCommission     float64 // Lowest commission charged per order
+	RegulatoryPercent float64 // Regulatory fee as a percentage of the order value
+	ExchangePercent   float64 // Exchange fee as a percentage of the order value
+}
+
 // MarketPrice represents current market pricing information
 type MarketPrice struct {
 	Symbol        string
@@ -30,6 +64,17 @@ type MarketPrice struct {
 	Timestamp     time.Time
 }
 
+// NonFiniteMarketDataError reports NaN or infinite pricing inputs. Pricing refuses to compute on
+// them, since the arithmetic would silently turn them into meaningless recommendations.
+type NonFiniteMarketDataError struct {
+	Symbol string
+	Fields []string // Names of the non-finite inputs
+}
+
+func (e *NonFiniteMarketDataError) Error() string {
+	return fmt.Sprintf("non-finite pricing input for %s: %s", e.Symbol, strings.Join(e.Fields, ", "))
+}
+
 // OrderBookData represents order book information
 type OrderBookData struct {
 	Symbol    string
@@ -70,6 +115,8 @@ type TradingFees struct {
 	ExchangeFee   float64
 	TotalFees     float64
 	FeePercent    float64
+	SpreadCost    float64 // Implicit cost of crossing half the bid-ask spread, not included in TotalFees
+	AllInCost     float64 // TotalFees plus SpreadCost
 }
 
 // PriceImpact represents estimated price impact of an order
@@ -97,6 +144,7 @@ type ExecutionPlan struct {
 	OrderID               string
 	RecommendedStrategy   ExecutionStrategy
 	EstimatedFillPrice    float64
+	BookWalk              *BookWalkEstimate // Book sweep behind the fill price of marketable orders (nil when the book was not walked)
 	EstimatedFees         *TradingFees
 	PriceImpact           *PriceImpact
 	TimeInForce           TimeInForce
@@ -104,9 +152,20 @@ type ExecutionPlan struct {
 	PartialFillAllowed    bool
 	ExecutionInstructions []string
 	RiskWarnings          []string
+	RoutingDecision       *RoutingDecision
+	Bracket               *BracketConfig // Exit legs linked to the entry (nil unless planned as a bracket)
 	CreatedAt             time.Time
 }
 
+// RoutingDecision explains which conditions led to the recommended strategy
+type RoutingDecision struct {
+	Strategy        ExecutionStrategy
+	DecidingFactors []string
+	UsedDefault     bool     // True when market conditions were unavailable
+	Overridden      bool     // True when the strategy was requested by the user instead of recommended
+	Warnings        []string // Data quality issues behind the conditions, such as stale depth
+}
+
 // ExecutionStrategy represents different execution strategies
 type ExecutionStrategy int32
 
@@ -117,8 +176,52 @@ const (
 	ExecutionStrategyVWAP // Volume Weighted Average Price
 	ExecutionStrategyIceberg
 	ExecutionStrategyHidden
+	ExecutionStrategyBracket // Entry with linked one-cancels-other stop-loss and take-profit exits
 )
 
+func (e ExecutionStrategy) String() string {
+	switch e {
+	case ExecutionStrategyMarket:
+		return "MARKET"
+	case ExecutionStrategyLimit:
+		return "LIMIT"
+	case ExecutionStrategyTWAP:
+		return "TWAP"
+	case ExecutionStrategyVWAP:
+		return "VWAP"
+	case ExecutionStrategyIceberg:
+		return "ICEBERG"
+	case ExecutionStrategyHidden:
+		return "HIDDEN"
+	case ExecutionStrategyBracket:
+		return "BRACKET"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseExecutionStrategy converts a strategy name to ExecutionStrategy
+func ParseExecutionStrategy(strategy string) (ExecutionStrategy, error) {
+	switch strings.ToUpper(strings.TrimSpace(strategy)) {
+	case "MARKET":
+		return ExecutionStrategyMarket, nil
+	case "LIMIT":
+		return ExecutionStrategyLimit, nil
+	case "TWAP":
+		return ExecutionStrategyTWAP, nil
+	case "VWAP":
+		return ExecutionStrategyVWAP, nil
+	case "ICEBERG":
+		return ExecutionStrategyIceberg, nil
+	case "HIDDEN":
+		return ExecutionStrategyHidden, nil
+	case "BRACKET":
+		return ExecutionStrategyBracket, nil
+	default:
+		return 0, fmt.Errorf("unknown execution strategy: %s", strategy)
+	}
+}
+
 // TimeInForce represents order time in force options
 type TimeInForce int32
 
@@ -129,6 +232,16 @@ const (
 	TimeInForceFOK             // Fill Or Kill
 )
 
+// ImmediateFillResult is the outcome of simulating an IOC or FOK order against the order book
+type ImmediateFillResult struct {
+	RequestedQuantity float64
+	FillableQuantity  float64 // Quantity that would fill immediately, capped at the requested quantity
+	FillRatio         float64 // FillableQuantity as a share of RequestedQuantity
+	MinFillRatio      float64 // Share the order had to reach to be filled at all
+	Cancelled         bool    // True when the order is cancelled entirely without filling
+	Reason            string
+}
+
 // PricingResult represents the result of pricing calculations
 type PricingResult struct {
 	Symbol             string
@@ -164,8 +277,19 @@ type MarketConditions struct {
 	TradingVolume   int64
 	MarketTrend     MarketTrend
 	SpreadCondition SpreadCondition
+	DepthStale      bool           // True when the order book depth was older than the configured maximum age
+	TradingSession  TradingSession // Session the order is placed in (regular when the client does not report trading hours)
+	Warnings        []string       // Data quality issues that lowered confidence in these conditions
 }
 
+// StaleDepthPolicy decides how depth older than the maximum age is treated
+type StaleDepthPolicy int32
+
+const (
+	StaleDepthPolicyIgnore  StaleDepthPolicy = iota // Ignore stale depth and assume low liquidity and no book imbalance
+	StaleDepthPolicyDegrade                         // Use stale depth but never rate liquidity above normal
+)
+
 // LiquidityLevel represents market liquidity levels
 type LiquidityLevel int32
 
@@ -219,11 +343,25 @@ type OrderPricingService interface {
 	// RecommendExecutionStrategy recommends best execution strategy
 	RecommendExecutionStrategy(order *domain.Order, pricingClient IPricingDataClient) (ExecutionStrategy, error)
 
+	// ValidateStrategyOverride checks that a user-requested execution strategy suits the order's type and size
+	ValidateStrategyOverride(order *domain.Order, strategy string) (ExecutionStrategy, error)
+
 	// ValidateMarketConditions validates if market conditions are suitable for execution
 	ValidateMarketConditions(order *domain.Order, pricingClient IPricingDataClient) (*MarketConditions, error)
 
 	// CalculateSlippageTolerance calculates appropriate slippage tolerance
 	CalculateSlippageTolerance(order *domain.Order, pricingClient IPricingDataClient) (float64, error)
+
+	// ApplyMarketOrderProtection converts a market order in a thin market into a protected
+	// market order with a limit band through the touch. It reports whether protection was applied.
+	ApplyMarketOrderProtection(order *domain.Order, pricingClient IPricingDataClient) (bool, error)
+
+	// SimulateImmediateFill walks the order book to decide how much of an IOC or FOK order fills.
+	// Orders that cannot reach the minimum fill ratio are cancelled entirely.
+	SimulateImmediateFill(order *domain.Order, pricingClient IPricingDataClient) (*ImmediateFillResult, error)
+
+	// SuggestLimitPrice recommends a limit price for the urgency with its expected fill probability and time
+	SuggestLimitPrice(userID, symbol string, side domain.OrderSide, quantity float64, urgency LimitPriceUrgency, pricingClient IPricingDataClient) (*LimitPriceSuggestion, error)
 }
 
 type orderPricingService struct {
@@ -233,6 +371,47 @@ type orderPricingService struct {
 	spreadWarningPercent  float64
 	impactWarningPercent  float64
 	feeCalculationMethod  FeeCalculationMethod
+	logRoutingDecisions   bool
+	depthLevels           int
+
+	marketProtectionLiquidity float64
+	marketProtectionPoints    float64
+	marketProtectionPercent   float64
+
+	slippageOverrides map[string]SlippageModel
+
+	maxDepthAge      time.Duration
+	staleDepthPolicy StaleDepthPolicy
+
+	minFillRatio float64
+
+	feeSchedules map[AssetCategory]FeeSchedule
+
+	includeSpreadCost bool
+
+	walkBookForFillEstimate bool
+
+	bookPressureSlippageWeight float64
+
+	imbalanceImprovementThreshold float64
+	maxImbalanceImprovement       float64
+
+	minSlicedStrategyValue float64
+
+	extendedHoursRules ExtendedHoursRules
+
+	limitPriceUrgencyShares map[LimitPriceUrgency]float64
+
+	planCache *executionPlanCache
+}
+
+// SlippageModel tunes the slippage tolerance calculation for a symbol.
+// Zero values and missing multipliers fall back to the default model.
+type SlippageModel struct {
+	BaseSlippagePercent  float64                     // Starting tolerance before multipliers are applied
+	MaxSlippagePercent   float64                     // Cap for the symbol, only used when tighter than the global cap
+	LiquidityMultipliers map[LiquidityLevel]float64  // Multiplier per liquidity level
+	SpreadMultipliers    map[SpreadCondition]float64 // Multiplier per spread condition
 }
 
 // FeeCalculationMethod represents different fee calculation methods
@@ -251,17 +430,104 @@ type OrderPricingConfig struct {
 	SpreadWarningPercent  float64              // Spread percentage for warnings
 	ImpactWarningPercent  float64              // Price impact percentage for warnings
 	FeeCalculationMethod  FeeCalculationMethod // Method for calculating fees
+	LogRoutingDecisions   bool                 // Log the explanation behind each strategy selection
+	DepthLevels           int                  // Book levels walked for partial fill estimates (0 uses the value heuristic)
+
+	MarketProtectionLiquidity float64 // Opposite-side book value below which market orders are protected (0 disables protection)
+	MarketProtectionPoints    float64 // Limit band in price points through the touch (takes precedence over the percent)
+	MarketProtectionPercent   float64 // Limit band as a percentage of the touch when no points are configured
+
+	SlippageOverrides map[string]SlippageModel // Per-symbol slippage models keyed by symbol
+
+	MaxDepthAge      time.Duration    // Depth older than this is stale (0 disables the check)
+	StaleDepthPolicy StaleDepthPolicy // How stale depth is treated
+
+	MinFillRatio float64 // Share of an IOC order that must fill immediately, otherwise nothing fills (0 accepts any fill)
+
+	FeeSchedules map[AssetCategory]FeeSchedule // Fees per asset category; categories without a schedule use the client's fees
+
+	IncludeSpreadCost bool // Estimate the half-spread cost of each order alongside its explicit fees
+
+	ImbalanceImprovementThreshold float64 // Share of depth on the order's side from which limit orders are priced more aggressively (0 disables)
+	MaxImbalanceImprovement       float64 // Furthest share of the spread a limit price may move away from its own touch (capped at 1, the far touch)
+
+	MinSlicedStrategyValue float64 // Order value below which TWAP, VWAP and iceberg overrides are rejected (0 accepts any size)
+
+	ExtendedHoursRules ExtendedHoursRules // Pricing of pre-market and post-market orders (the zero value prices them like regular-session orders)
+
+	ExecutionPlanCacheTTL            time.Duration // How long an execution plan is reused for the same order parameters (0 disables caching)
+	ExecutionPlanMaxPriceMovePercent float64       // Price move since a cached plan was built that invalidates it
+
+	WalkBookForFillEstimate bool // Estimate the fill price of marketable orders by walking the visible book up to DepthLevels
+
+	BookPressureSlippageWeight float64 // Share by which slippage tolerance widens under fully adverse book pressure and tightens under fully supportive pressure (0 disables)
+
+	LimitPriceUrgencyShares map[LimitPriceUrgency]float64 // Share of the spread suggested limit prices cross per urgency (missing urgencies use DefaultLimitPriceUrgencyShares)
 }
 
 // NewOrderPricingService creates a new instance of OrderPricingService
 func NewOrderPricingService(config OrderPricingConfig) OrderPricingService {
+	var planCache *executionPlanCache
+	if config.ExecutionPlanCacheTTL > 0 {
+		planCache = newExecutionPlanCache(config.ExecutionPlanCacheTTL, config.ExecutionPlanMaxPriceMovePercent)
+	}
+
 	return &orderPricingService{
 		maxSlippagePercent:    config.MaxSlippagePercent,
 		minLiquidityThreshold: config.MinLiquidityThreshold,
 		spreadWarningPercent:  config.SpreadWarningPercent,
 		impactWarningPercent:  config.ImpactWarningPercent,
 		feeCalculationMethod:  config.FeeCalculationMethod,
+		logRoutingDecisions:   config.LogRoutingDecisions,
+		depthLevels:           config.DepthLevels,
+
+		marketProtectionLiquidity: config.MarketProtectionLiquidity,
+		marketProtectionPoints:    config.MarketProtectionPoints,
+		marketProtectionPercent:   config.MarketProtectionPercent,
+
+		slippageOverrides: normalizeSlippageOverrides(config.SlippageOverrides),
+
+		maxDepthAge:      config.MaxDepthAge,
+		staleDepthPolicy: config.StaleDepthPolicy,
+
+		minFillRatio: config.MinFillRatio,
+
+		feeSchedules: config.FeeSchedules,
+
+		includeSpreadCost: config.IncludeSpreadCost,
+
+		walkBookForFillEstimate: config.WalkBookForFillEstimate,
+
+		imbalanceImprovementThreshold: config.ImbalanceImprovementThreshold,
+		maxImbalanceImprovement:       config.MaxImbalanceImprovement,
+
+		minSlicedStrategyValue: config.MinSlicedStrategyValue,
+
+		extendedHoursRules: config.ExtendedHoursRules,
+
+		planCache: planCache,
+
+		bookPressureSlippageWeight: config.BookPressureSlippageWeight,
+
+		limitPriceUrgencyShares: mergeLimitPriceUrgencyShares(config.LimitPriceUrgencyShares),
+	}
+}
+
+// mergeLimitPriceUrgencyShares fills the urgencies the configuration leaves out with their defaults
+func mergeLimitPriceUrgencyShares(shares map[LimitPriceUrgency]float64) map[LimitPriceUrgency]float64 {
+	merged := DefaultLimitPriceUrgencyShares()
+	for urgency, share := range shares {
+		merged[urgency] = share
 	}
+	return merged
+}
+
+func normalizeSlippageOverrides(overrides map[string]SlippageModel) map[string]SlippageModel {
+	normalized := make(map[string]SlippageModel, len(overrides))
+	for symbol, model := range overrides {
+		normalized[strings.ToUpper(strings.TrimSpace(symbol))] = model
+	}
+	return normalized
 }
 
 // NewOrderPricingServiceWithDefaults creates a service with default configuration
@@ -272,9 +538,46 @@ func NewOrderPricingServiceWithDefaults() OrderPricingService {
 		SpreadWarningPercent:  1.0,                  // 1% spread warning
 		ImpactWarningPercent:  0.5,                  // 0.5% impact warning
 		FeeCalculationMethod:  FeeCalculationTiered, // Tiered fee structure
+		LogRoutingDecisions:   true,                 // Log routing explanations for support
+		DepthLevels:           10,                   // Walk the top 10 book levels
+
+		MarketProtectionLiquidity: 25000.0, // Protect market orders when less than $25K rests on the touch side
+		MarketProtectionPercent:   1.0,     // 1% through the touch
+
+		MaxDepthAge:      5 * time.Second,        // Depth older than 5s is not trusted
+		StaleDepthPolicy: StaleDepthPolicyIgnore, // Fall back to conservative defaults
+
+		MinFillRatio: 0.1, // Cancel IOC orders that would fill less than 10%
+
+		FeeSchedules: DefaultFeeSchedules(),
+
+		IncludeSpreadCost: true, // Show the all-in cost including the spread
+
+		ImbalanceImprovementThreshold: 0.65, // Price more aggressively once 65% of the depth sits on the order's side
+		MaxImbalanceImprovement:       0.8,  // Never go past 80% of the spread
+
+		MinSlicedStrategyValue: 50000.0, // Slicing orders below $50K only delays the fill
+
+		ExtendedHoursRules: DefaultExtendedHoursRules(),
+
+		ExecutionPlanCacheTTL:            10 * time.Second, // Covers the gap between an order preview and its submission
+		ExecutionPlanMaxPriceMovePercent: 0.25,             // Rebuild once the price moved more than 0.25%
+
+		WalkBookForFillEstimate: true, // Sweep the book instead of adding heuristic slippage to the touch
+
+		BookPressureSlippageWeight: 0.5, // Up to 50% wider when the fill side is thin, 50% tighter when it is deep
 	})
 }
 
+// DefaultFeeSchedules returns the standard fee schedule for each asset category
+func DefaultFeeSchedules() map[AssetCategory]FeeSchedule {
+	return map[AssetCategory]FeeSchedule{
+		AssetCategoryStock: {CommissionPercent: 0.10, MinCommission: 5.0, RegulatoryPercent: 0.0050, ExchangePercent: 0.0250}, // Brokerage plus exchange and settlement fees
+		AssetCategoryETF:   {CommissionPercent: 0.05, MinCommission: 2.5, RegulatoryPercent: 0.0050, ExchangePercent: 0.0250}, // Discounted commission on funds traded on exchange
+		AssetCategoryBond:  {CommissionPercent: 0.02, MinCommission: 1.0},                                                     // Traded over the counter, no exchange fees
+	}
+}
+
 // CalculateOptimalPrice calculates optimal pricing for an order
 func (s *orderPricingService) CalculateOptimalPrice(order *domain.Order, pricingClient IPricingDataClient) (*PricingResult, error) {
 	result := &PricingResult{
@@ -290,12 +593,25 @@ func (s *orderPricingService) CalculateOptimalPrice(order *domain.Order, pricing
 		return result, fmt.Errorf("failed to get market price: %w", err)
 	}
 
+	if err := validatePricingInputs(order, marketPrice); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Pricing skipped: %s", err.Error()))
+		return result, err
+	}
+
 	// Calculate optimal price based on order type and side
 	optimalPrice, err := s.calculateOptimalPriceForOrder(order, marketPrice)
 	if err != nil {
 		return result, fmt.Errorf("failed to calculate optimal price: %w", err)
 	}
 
+	// Lean into the spread when the book imbalance is about to move the price away
+	improvedPrice, improved := s.improvePriceForImbalance(order, marketPrice, pricingClient)
+	if improved {
+		optimalPrice = improvedPrice
+		result.Recommendations = append(result.Recommendations,
+			"Book imbalance favors immediate execution; limit price moved toward the opposite touch")
+	}
+
 	result.RecommendedPrice = optimalPrice
 
 	// Calculate price range
@@ -303,6 +619,9 @@ func (s *orderPricingService) CalculateOptimalPrice(order *domain.Order, pricing
 	if err != nil {
 		return result, fmt.Errorf("failed to calculate price range: %w", err)
 	}
+	if improved {
+		priceRange.OptimalPrice = optimalPrice
+	}
 
 	result.PriceRange = *priceRange
 
@@ -328,8 +647,58 @@ func (s *orderPricingService) CalculateOptimalPrice(order *domain.Order, pricing
 	return result, nil
 }
 
-// CreateExecutionPlan creates execution plan for an order
+// CreateExecutionPlan creates execution plan for an order, with its exit legs when the order is a
+// bracketed entry. When caching is enabled, a plan built for the same order parameters is reused
+// while the market has not moved materially.
 func (s *orderPricingService) CreateExecutionPlan(order *domain.Order, pricingClient IPricingDataClient) (*ExecutionPlan, error) {
+	bracket := bracketConfigOf(order)
+	if bracket == nil {
+		return s.createEntryExecutionPlan(order, pricingClient)
+	}
+
+	// Limit entries are checked before any pricing work; market entries once their fill is estimated
+	if order.Price() != nil {
+		if err := bracket.Validate(order.OrderSide(), *order.Price()); err != nil {
+			return nil, fmt.Errorf("invalid bracket: %w", err)
+		}
+	}
+
+	plan, err := s.createEntryExecutionPlan(order, pricingClient)
+	if err != nil {
+		return plan, err
+	}
+	return s.attachBracket(order, *bracket, plan, pricingClient)
+}
+
+// createEntryExecutionPlan plans the order itself, ignoring any bracket exits
+func (s *orderPricingService) createEntryExecutionPlan(order *domain.Order, pricingClient IPricingDataClient) (*ExecutionPlan, error) {
+	if s.planCache == nil {
+		return s.buildExecutionPlan(order, pricingClient)
+	}
+
+	marketPrice, err := pricingClient.GetCurrentMarketPrice(order.Symbol())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market price: %w", err)
+	}
+	snapshot := executionPlanMarketSnapshot{
+		referencePrice: marketPrice.LastPrice,
+		spread:         s.assessSpreadCondition(marketPrice),
+	}
+
+	key := executionPlanCacheKey(order)
+	if plan, ok := s.planCache.get(key, snapshot); ok {
+		return plan.forOrder(order.ID()), nil
+	}
+
+	plan, err := s.buildExecutionPlan(order, pricingClient)
+	if err != nil {
+		return plan, err
+	}
+	s.planCache.put(key, plan.forOrder(""), snapshot)
+	return plan, nil
+}
+
+func (s *orderPricingService) buildExecutionPlan(order *domain.Order, pricingClient IPricingDataClient) (*ExecutionPlan, error) {
 	plan := &ExecutionPlan{
 		OrderID:               order.ID(),
 		ExecutionInstructions: make([]string, 0),
@@ -337,13 +706,24 @@ func (s *orderPricingService) CreateExecutionPlan(order *domain.Order, pricingCl
 		CreatedAt:             time.Now(),
 	}
 
-	// Recommend execution strategy
-	strategy, err := s.RecommendExecutionStrategy(order, pricingClient)
-	if err != nil {
-		return plan, fmt.Errorf("failed to recommend execution strategy: %w", err)
+	// Use the strategy the user asked for, otherwise recommend one
+	decision := s.decideExecutionStrategy(order, pricingClient)
+	if order.ExecutionStrategyOverride() != "" {
+		if override, err := s.ValidateStrategyOverride(order, order.ExecutionStrategyOverride()); err != nil {
+			decision.Warnings = append(decision.Warnings,
+				fmt.Sprintf("Ignored execution strategy override: %s", err.Error()))
+		} else {
+			decision = overrideRoutingDecision(decision, override)
+		}
 	}
+	plan.RecommendedStrategy = decision.Strategy
+	plan.RoutingDecision = decision
+	plan.RiskWarnings = append(plan.RiskWarnings, decision.Warnings...)
 
-	plan.RecommendedStrategy = strategy
+	if s.logRoutingDecisions {
+		log.Printf("Routing decision for order %s (%s): strategy=%s factors=[%s]",
+			order.ID(), order.Symbol(), decision.Strategy, strings.Join(decision.DecidingFactors, "; "))
+	}
 
 	// Estimate fill price
 	fillPrice, err := s.EstimateFillPrice(order, pricingClient)
@@ -353,6 +733,19 @@ func (s *orderPricingService) CreateExecutionPlan(order *domain.Order, pricingCl
 
 	plan.EstimatedFillPrice = fillPrice
 
+	// Marketable orders take liquidity level by level, so the book gives a better average than the touch plus slippage
+	if walk, walked := s.estimateBookWalk(order, pricingClient); walked {
+		plan.BookWalk = walk
+		if walk.FilledQuantity > 0 {
+			plan.EstimatedFillPrice = walk.AveragePrice
+		}
+		if walk.ResidualQuantity > 0 {
+			plan.RiskWarnings = append(plan.RiskWarnings, fmt.Sprintf(
+				"Visible book absorbs %.2f of %.2f; %.2f would remain unfilled beyond the estimated price",
+				walk.FilledQuantity, order.Quantity(), walk.ResidualQuantity))
+		}
+	}
+
 	// Calculate trading fees
 	fees, err := s.CalculateTradingCosts(order, pricingClient)
 	if err != nil {
@@ -391,8 +784,13 @@ func (s *orderPricingService) CreateExecutionPlan(order *domain.Order, pricingCl
 
 // ValidateOrderPrice validates if order price is reasonable
 func (s *orderPricingService) ValidateOrderPrice(order *domain.Order, pricingClient IPricingDataClient) error {
+	session := s.tradingSession(order.Symbol(), pricingClient)
+
 	// Skip validation for market orders (no price specified)
 	if order.OrderType() == domain.OrderTypeMarket {
+		if session.IsExtended() && s.extendedHoursRules.LimitOnly {
+			return fmt.Errorf("only limit orders are accepted for %s during the %s session", order.Symbol(), session)
+		}
 		return nil
 	}
 
@@ -408,7 +806,11 @@ func (s *orderPricingService) ValidateOrderPrice(order *domain.Order, pricingCli
 	orderPrice := *order.Price()
 
 	// Validate price is within reasonable range
-	if err := s.validatePriceWithinRange(order, orderPrice, marketPrice); err != nil {
+	bandMultiplier := 1.0
+	if session.IsExtended() {
+		bandMultiplier = s.extendedHoursRules.priceBandMultiplier()
+	}
+	if err := s.validatePriceWithinRange(order, orderPrice, marketPrice, bandMultiplier); err != nil {
 		return err
 	}
 
@@ -427,6 +829,10 @@ func (s *orderPricingService) EstimateFillPrice(order *domain.Order, pricingClie
 		return 0, fmt.Errorf("failed to get market price: %w", err)
 	}
 
+	if err := validatePricingInputs(order, marketPrice); err != nil {
+		return 0, err
+	}
+
 	switch order.OrderType() {
 	case domain.OrderTypeMarket:
 		return s.estimateMarketOrderFillPrice(order, marketPrice, pricingClient)
@@ -445,17 +851,51 @@ func (s *orderPricingService) EstimateFillPrice(order *domain.Order, pricingClie
 func (s *orderPricingService) CalculateTradingCosts(order *domain.Order, pricingClient IPricingDataClient) (*TradingFees, error) {
 	orderValue := order.CalculateOrderValue()
 
-	fees, err := pricingClient.GetTradingFees(order.OrderType(), orderValue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get trading fees: %w", err)
+	fees, ok := s.calculateScheduledFees(order, pricingClient)
+	if !ok {
+		var err error
+		fees, err = pricingClient.GetTradingFees(order.OrderType(), orderValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trading fees: %w", err)
+		}
 	}
 
 	// Apply fee calculation method adjustments if needed
 	s.adjustFeesBasedOnMethod(fees, order)
 
+	if s.includeSpreadCost {
+		fees.SpreadCost = s.estimateSpreadCost(order, pricingClient)
+	}
+	fees.AllInCost = fees.TotalFees + fees.SpreadCost
+
 	return fees, nil
 }
 
+// estimateSpreadCost prices crossing from the mid to the touch: half the spread per unit.
+// Without a quote the spread cost is unknown and reported as zero.
+func (s *orderPricingService) estimateSpreadCost(order *domain.Order, pricingClient IPricingDataClient) float64 {
+	marketPrice, err := pricingClient.GetCurrentMarketPrice(order.Symbol())
+	if err != nil || marketPrice == nil {
+		return 0
+	}
+
+	spread := quotedSpread(marketPrice)
+	if spread <= 0 {
+		return 0
+	}
+
+	return spread / 2 * order.Quantity()
+}
+
+// quotedSpread returns the quote's spread, derived from bid and ask when the feed leaves it out
+func quotedSpread(marketPrice *MarketPrice) float64 {
+	spread := marketPrice.Spread
+	if spread <= 0 && marketPrice.BidPrice > 0 && marketPrice.AskPrice > marketPrice.BidPrice {
+		spread = marketPrice.AskPrice - marketPrice.BidPrice
+	}
+	return spread
+}
+
 // AssessPriceImpact assesses market impact of an order
 func (s *orderPricingService) AssessPriceImpact(order *domain.Order, pricingClient IPricingDataClient) (*PriceImpact, error) {
 	priceImpact, err := pricingClient.GetPriceImpactEstimate(order.Symbol(), order.OrderSide(), order.Quantity())
@@ -480,13 +920,96 @@ func (s *orderPricingService) AssessPriceImpact(order *domain.Order, pricingClie
 
 // RecommendExecutionStrategy recommends best execution strategy
 func (s *orderPricingService) RecommendExecutionStrategy(order *domain.Order, pricingClient IPricingDataClient) (ExecutionStrategy, error) {
+	return s.decideExecutionStrategy(order, pricingClient).Strategy, nil
+}
+
+// decideExecutionStrategy selects a strategy and records why it was chosen
+func (s *orderPricingService) decideExecutionStrategy(order *domain.Order, pricingClient IPricingDataClient) *RoutingDecision {
 	// Get market conditions
 	marketConditions, err := s.ValidateMarketConditions(order, pricingClient)
 	if err != nil {
-		return s.getDefaultStrategy(order), nil
+		strategy := s.getDefaultStrategy(order)
+		return &RoutingDecision{
+			Strategy:    strategy,
+			UsedDefault: true,
+			DecidingFactors: []string{
+				fmt.Sprintf("market conditions unavailable (%s)", err.Error()),
+				fmt.Sprintf("defaulted to %s for %s order", strategy, order.OrderType()),
+			},
+		}
+	}
+
+	return s.explainStrategySelection(order, marketConditions)
+}
+
+// ValidateStrategyOverride checks that a user-requested execution strategy suits the order.
+// MARKET needs a market order, price-resting strategies need a limit price, and sliced
+// strategies need an order large enough to be worth slicing.
+func (s *orderPricingService) ValidateStrategyOverride(order *domain.Order, strategy string) (ExecutionStrategy, error) {
+	override, err := ParseExecutionStrategy(strategy)
+	if err != nil {
+		return 0, err
+	}
+
+	switch override {
+	case ExecutionStrategyMarket:
+		if order.OrderType() != domain.OrderTypeMarket {
+			return 0, fmt.Errorf("%s strategy requires a MARKET order, got %s", override, order.OrderType())
+		}
+	case ExecutionStrategyLimit, ExecutionStrategyHidden:
+		if order.Price() == nil {
+			return 0, fmt.Errorf("%s strategy requires a limit price", override)
+		}
+	case ExecutionStrategyIceberg:
+		if order.Price() == nil {
+			return 0, fmt.Errorf("%s strategy requires a limit price", override)
+		}
+		if err := s.validateSlicedOrderSize(order, override); err != nil {
+			return 0, err
+		}
+	case ExecutionStrategyTWAP, ExecutionStrategyVWAP:
+		if err := s.validateSlicedOrderSize(order, override); err != nil {
+			return 0, err
+		}
+	case ExecutionStrategyBracket:
+		return 0, fmt.Errorf("%s strategy requires stop-loss and take-profit prices", override)
+	}
+
+	return override, nil
+}
+
+// validateSlicedOrderSize rejects sliced strategies for orders below the minimum value.
+// Market orders are valued at the market price captured at submission.
+func (s *orderPricingService) validateSlicedOrderSize(order *domain.Order, strategy ExecutionStrategy) error {
+	if s.minSlicedStrategyValue <= 0 {
+		return nil
+	}
+
+	orderValue := order.CalculateOrderValue()
+	if orderValue == 0 && order.MarketPriceAtSubmission() != nil {
+		orderValue = *order.MarketPriceAtSubmission() * order.Quantity()
 	}
 
-	return s.selectStrategyBasedOnConditions(order, marketConditions), nil
+	if orderValue < s.minSlicedStrategyValue {
+		return fmt.Errorf("%s strategy requires an order value of at least %.2f, got %.2f",
+			strategy, s.minSlicedStrategyValue, orderValue)
+	}
+	return nil
+}
+
+// overrideRoutingDecision replaces the recommended strategy with the requested one, keeping the
+// recommendation in the deciding factors for support
+func overrideRoutingDecision(recommended *RoutingDecision, override ExecutionStrategy) *RoutingDecision {
+	return &RoutingDecision{
+		Strategy:   override,
+		Overridden: true,
+		DecidingFactors: []string{
+			fmt.Sprintf("strategy %s requested by user", override),
+			fmt.Sprintf("recommended strategy was %s", recommended.Strategy),
+		},
+		UsedDefault: recommended.UsedDefault,
+		Warnings:    recommended.Warnings,
+	}
 }
 
 // getDefaultStrategy returns default strategy when market conditions unavailable
@@ -499,29 +1022,62 @@ func (s *orderPricingService) getDefaultStrategy(order *domain.Order) ExecutionS
 
 // selectStrategyBasedOnConditions selects strategy based on order size and market conditions
 func (s *orderPricingService) selectStrategyBasedOnConditions(order *domain.Order, marketConditions *MarketConditions) ExecutionStrategy {
+	return s.explainStrategySelection(order, marketConditions).Strategy
+}
+
+// explainStrategySelection selects strategy and lists the conditions that decided it
+func (s *orderPricingService) explainStrategySelection(order *domain.Order, marketConditions *MarketConditions) *RoutingDecision {
 	orderValue := order.CalculateOrderValue()
+	decision := &RoutingDecision{DecidingFactors: make([]string, 0), Warnings: marketConditions.Warnings}
 
 	// Large orders in low liquidity - use TWAP or VWAP
 	if orderValue >= 100000 && marketConditions.LiquidityLevel <= LiquidityLevelNormal {
-		return s.selectLargeOrderStrategy(marketConditions)
+		decision.Strategy = s.selectLargeOrderStrategy(marketConditions)
+		decision.DecidingFactors = append(decision.DecidingFactors,
+			fmt.Sprintf("order value %.2f >= 100000", orderValue),
+			fmt.Sprintf("liquidity level %d is normal or below", marketConditions.LiquidityLevel))
+		if decision.Strategy == ExecutionStrategyVWAP {
+			decision.DecidingFactors = append(decision.DecidingFactors,
+				fmt.Sprintf("trading volume %d > 1000000", marketConditions.TradingVolume))
+		} else {
+			decision.DecidingFactors = append(decision.DecidingFactors,
+				fmt.Sprintf("trading volume %d <= 1000000", marketConditions.TradingVolume))
+		}
+		return decision
 	}
 
 	// Medium orders - consider iceberg strategy
 	if orderValue >= 50000 {
-		return ExecutionStrategyIceberg
+		decision.Strategy = ExecutionStrategyIceberg
+		decision.DecidingFactors = append(decision.DecidingFactors,
+			fmt.Sprintf("order value %.2f >= 50000", orderValue))
+		return decision
 	}
 
 	// Wide spreads - prefer limit orders
 	if marketConditions.SpreadCondition >= SpreadConditionWide {
-		return ExecutionStrategyLimit
+		decision.Strategy = ExecutionStrategyLimit
+		decision.DecidingFactors = append(decision.DecidingFactors,
+			fmt.Sprintf("order value %.2f < 50000", orderValue),
+			fmt.Sprintf("spread condition %d is wide or worse", marketConditions.SpreadCondition))
+		return decision
 	}
 
 	// Market orders
 	if order.OrderType() == domain.OrderTypeMarket {
-		return ExecutionStrategyMarket
-	}
-
-	return ExecutionStrategyLimit
+		decision.Strategy = ExecutionStrategyMarket
+		decision.DecidingFactors = append(decision.DecidingFactors,
+			"spread is acceptable",
+			"order type is MARKET")
+		return decision
+	}
+
+	decision.Strategy = ExecutionStrategyLimit
+	decision.DecidingFactors = append(decision.DecidingFactors,
+		fmt.Sprintf("order value %.2f < 50000", orderValue),
+		"spread is acceptable",
+		fmt.Sprintf("order type is %s", order.OrderType()))
+	return decision
 }
 
 // selectLargeOrderStrategy selects strategy for large orders based on volume
@@ -534,7 +1090,7 @@ func (s *orderPricingService) selectLargeOrderStrategy(marketConditions *MarketC
 
 // ValidateMarketConditions validates if market conditions are suitable for execution
 func (s *orderPricingService) ValidateMarketConditions(order *domain.Order, pricingClient IPricingDataClient) (*MarketConditions, error) {
-	conditions := &MarketConditions{}
+	conditions := &MarketConditions{Warnings: make([]string, 0)}
 
 	// Check if market is open
 	isOpen, err := pricingClient.IsMarketOpen(order.Symbol())
@@ -542,7 +1098,8 @@ func (s *orderPricingService) ValidateMarketConditions(order *domain.Order, pric
 		return conditions, fmt.Errorf("failed to check market status: %w", err)
 	}
 
-	if !isOpen {
+	conditions.TradingSession = s.tradingSession(order.Symbol(), pricingClient)
+	if !isOpen && !conditions.TradingSession.IsExtended() {
 		return conditions, fmt.Errorf("market is closed for symbol %s", order.Symbol())
 	}
 
@@ -552,8 +1109,18 @@ func (s *orderPricingService) ValidateMarketConditions(order *domain.Order, pric
 		return conditions, fmt.Errorf("failed to get market depth: %w", err)
 	}
 
+	// Stale depth is not trusted for liquidity or trend
+	if age, stale := s.depthAge(marketDepth); stale {
+		conditions.DepthStale = true
+		conditions.Warnings = append(conditions.Warnings,
+			fmt.Sprintf("market depth for %s is %s old (max %s)", order.Symbol(), age.Round(time.Millisecond), s.maxDepthAge))
+	}
+
 	// Assess liquidity level
-	conditions.LiquidityLevel = s.assessLiquidityLevel(marketDepth)
+	conditions.LiquidityLevel = s.assessDepthLiquidity(marketDepth, conditions.DepthStale)
+	if conditions.TradingSession.IsExtended() {
+		conditions.LiquidityLevel = s.extendedHoursRules.reduceLiquidity(conditions.LiquidityLevel)
+	}
 
 	// Get market price for spread analysis
 	marketPrice, err := pricingClient.GetCurrentMarketPrice(order.Symbol())
@@ -569,7 +1136,11 @@ func (s *orderPricingService) ValidateMarketConditions(order *domain.Order, pric
 	conditions.Volatility = marketPrice.SpreadPercent // Simplified volatility measure
 
 	// Determine market trend (simplified)
-	conditions.MarketTrend = s.assessMarketTrend(marketDepth, marketPrice)
+	if conditions.DepthStale && s.staleDepthPolicy == StaleDepthPolicyIgnore {
+		conditions.MarketTrend = s.assessMarketTrend(&MarketDepth{ImbalanceRatio: 0.5}, marketPrice)
+	} else {
+		conditions.MarketTrend = s.assessMarketTrend(marketDepth, marketPrice)
+	}
 
 	return conditions, nil
 }
@@ -582,31 +1153,14 @@ func (s *orderPricingService) CalculateSlippageTolerance(order *domain.Order, pr
 		return s.maxSlippagePercent * 0.5, nil
 	}
 
-	baseSlippage := 0.1 // 0.1% base slippage
+	model := s.slippageModelFor(order.Symbol())
+	baseSlippage := model.BaseSlippagePercent
 
 	// Adjust based on market conditions
-	switch marketConditions.LiquidityLevel {
-	case LiquidityLevelLow:
-		baseSlippage *= 3.0
-	case LiquidityLevelNormal:
-		baseSlippage *= 1.5
-	case LiquidityLevelHigh:
-		baseSlippage *= 0.8
-	case LiquidityLevelVeryHigh:
-		baseSlippage *= 0.5
-	}
+	baseSlippage *= model.liquidityMultiplier(marketConditions.LiquidityLevel)
 
 	// Adjust based on spread conditions
-	switch marketConditions.SpreadCondition {
-	case SpreadConditionVeryWide:
-		baseSlippage *= 2.5
-	case SpreadConditionWide:
-		baseSlippage *= 1.8
-	case SpreadConditionNormal:
-		baseSlippage *= 1.0
-	case SpreadConditionTight:
-		baseSlippage *= 0.7
-	}
+	baseSlippage *= model.spreadMultiplier(marketConditions.SpreadCondition)
 
 	// Adjust based on order size
 	orderValue := order.CalculateOrderValue()
@@ -618,16 +1172,239 @@ func (s *orderPricingService) CalculateSlippageTolerance(order *domain.Order, pr
 		baseSlippage *= 1.2
 	}
 
+	// Adjust based on order book pressure
+	baseSlippage *= s.bookPressureSlippageMultiplier(order, pricingClient)
+
+	// Extended sessions trade further from the last price, so both the tolerance and its cap widen
+	maxSlippage := model.MaxSlippagePercent
+	if marketConditions.TradingSession.IsExtended() {
+		multiplier := s.extendedHoursRules.slippageMultiplier()
+		baseSlippage *= multiplier
+		maxSlippage *= multiplier
+	}
+
 	// Cap at maximum allowed slippage
-	if baseSlippage > s.maxSlippagePercent {
-		baseSlippage = s.maxSlippagePercent
+	if baseSlippage > maxSlippage {
+		baseSlippage = maxSlippage
 	}
 
 	return baseSlippage, nil
 }
 
+// bookPressureSlippageMultiplier widens the tolerance when the book leans against the order, leaving
+// the side it fills against thin, and tightens it when the book leans with the order. Without a
+// usable book the tolerance is left unchanged.
+func (s *orderPricingService) bookPressureSlippageMultiplier(order *domain.Order, pricingClient IPricingDataClient) float64 {
+	if s.bookPressureSlippageWeight <= 0 {
+		return 1
+	}
+
+	orderBook, err := pricingClient.GetOrderBookData(order.Symbol())
+	if err != nil || orderBook == nil {
+		return 1
+	}
+
+	pressure, err := bookPressure(orderBook, s.depthLevels)
+	if err != nil {
+		return 1
+	}
+
+	// Positive pressure is a bid-heavy book: buys fill against the thin ask side, sells against the deep bids
+	adverse := pressure
+	if order.IsSellOrder() {
+		adverse = -pressure
+	}
+	return math.Max(1+s.bookPressureSlippageWeight*adverse, 0)
+}
+
+// slippageModelFor returns the symbol's override merged over the default model
+func (s *orderPricingService) slippageModelFor(symbol string) SlippageModel {
+	model := SlippageModel{
+		BaseSlippagePercent: 0.1, // 0.1% base slippage
+		MaxSlippagePercent:  s.maxSlippagePercent,
+	}
+
+	override, ok := s.slippageOverrides[strings.ToUpper(symbol)]
+	if !ok {
+		return model
+	}
+
+	if override.BaseSlippagePercent > 0 {
+		model.BaseSlippagePercent = override.BaseSlippagePercent
+	}
+	if override.MaxSlippagePercent > 0 && override.MaxSlippagePercent < model.MaxSlippagePercent {
+		model.MaxSlippagePercent = override.MaxSlippagePercent
+	}
+	model.LiquidityMultipliers = override.LiquidityMultipliers
+	model.SpreadMultipliers = override.SpreadMultipliers
+	return model
+}
+
+func (m SlippageModel) liquidityMultiplier(level LiquidityLevel) float64 {
+	if multiplier, ok := m.LiquidityMultipliers[level]; ok {
+		return multiplier
+	}
+
+	switch level {
+	case LiquidityLevelLow:
+		return 3.0
+	case LiquidityLevelNormal:
+		return 1.5
+	case LiquidityLevelHigh:
+		return 0.8
+	case LiquidityLevelVeryHigh:
+		return 0.5
+	default:
+		return 1.0
+	}
+}
+
+func (m SlippageModel) spreadMultiplier(condition SpreadCondition) float64 {
+	if multiplier, ok := m.SpreadMultipliers[condition]; ok {
+		return multiplier
+	}
+
+	switch condition {
+	case SpreadConditionVeryWide:
+		return 2.5
+	case SpreadConditionWide:
+		return 1.8
+	case SpreadConditionTight:
+		return 0.7
+	default:
+		return 1.0
+	}
+}
+
+// ApplyMarketOrderProtection records a limit band on market orders when the opposite side of
+// the book is too thin for a naked market order to be accepted
+func (s *orderPricingService) ApplyMarketOrderProtection(order *domain.Order, pricingClient IPricingDataClient) (bool, error) {
+	if order.OrderType() != domain.OrderTypeMarket || order.IsProtectedMarketOrder() || s.marketProtectionLiquidity <= 0 {
+		return false, nil
+	}
+
+	orderBook, err := pricingClient.GetOrderBookData(order.Symbol())
+	if err != nil {
+		return false, fmt.Errorf("failed to get order book: %w", err)
+	}
+	if s.touchSideLiquidity(order, orderBook) >= s.marketProtectionLiquidity {
+		return false, nil
+	}
+
+	marketPrice, err := pricingClient.GetCurrentMarketPrice(order.Symbol())
+	if err != nil {
+		return false, fmt.Errorf("failed to get market price: %w", err)
+	}
+
+	limitPrice, err := s.calculateProtectionLimitPrice(order, marketPrice)
+	if err != nil {
+		return false, err
+	}
+
+	if err := order.ApplyMarketProtection(limitPrice); err != nil {
+		return false, fmt.Errorf("failed to apply market protection: %w", err)
+	}
+	return true, nil
+}
+
+// SimulateImmediateFill walks the order book to decide how much of an IOC or FOK order fills.
+// FOK orders and orders that disallow partial fills must fill completely.
+func (s *orderPricingService) SimulateImmediateFill(order *domain.Order, pricingClient IPricingDataClient) (*ImmediateFillResult, error) {
+	timeInForce := order.TimeInForce()
+	if timeInForce != domain.TimeInForceIOC && timeInForce != domain.TimeInForceFOK {
+		return nil, fmt.Errorf("immediate fill simulation requires an IOC or FOK order, got %s", timeInForce)
+	}
+	if order.Quantity() <= 0 {
+		return nil, fmt.Errorf("order quantity must be positive")
+	}
+
+	orderBook, err := pricingClient.GetOrderBookData(order.Symbol())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	result := &ImmediateFillResult{
+		RequestedQuantity: order.Quantity(),
+		MinFillRatio:      s.minFillRatio,
+	}
+	if timeInForce == domain.TimeInForceFOK || !order.AllowPartialFill() {
+		result.MinFillRatio = 1
+	}
+
+	if orderBook != nil {
+		levels := orderBook.Asks
+		if order.IsSellOrder() {
+			levels = orderBook.Bids
+		}
+		result.FillableQuantity = math.Min(s.aggregateFillableQuantity(order, levels), order.Quantity())
+	}
+	result.FillRatio = result.FillableQuantity / order.Quantity()
+
+	switch {
+	case result.FillableQuantity == 0:
+		result.Cancelled = true
+		result.Reason = "no liquidity available within the order's price"
+	case result.FillRatio < result.MinFillRatio:
+		result.Cancelled = true
+		result.FillableQuantity = 0
+		result.Reason = fmt.Sprintf("fill ratio %.2f below minimum %.2f", result.FillRatio, result.MinFillRatio)
+	}
+
+	return result, nil
+}
+
 // Helper methods
 
+// touchSideLiquidity sums the value resting on the side of the book the order would trade against
+func (s *orderPricingService) touchSideLiquidity(order *domain.Order, orderBook *OrderBookData) float64 {
+	if orderBook == nil {
+		return 0
+	}
+
+	levels := orderBook.Asks
+	if order.IsSellOrder() {
+		levels = orderBook.Bids
+	}
+
+	liquidity := 0.0
+	for i, level := range levels {
+		if s.depthLevels > 0 && i >= s.depthLevels {
+			break
+		}
+		liquidity += level.Price * level.Quantity
+	}
+	return liquidity
+}
+
+// calculateProtectionLimitPrice places the limit band through the touch: above the ask for buys,
+// below the bid for sells
+func (s *orderPricingService) calculateProtectionLimitPrice(order *domain.Order, marketPrice *MarketPrice) (float64, error) {
+	touch := marketPrice.AskPrice
+	if order.IsSellOrder() {
+		touch = marketPrice.BidPrice
+	}
+	if touch <= 0 {
+		touch = marketPrice.LastPrice
+	}
+	if touch <= 0 {
+		return 0, fmt.Errorf("no touch price available for %s", order.Symbol())
+	}
+
+	band := s.marketProtectionPoints
+	if band <= 0 {
+		band = touch * s.marketProtectionPercent / 100
+	}
+
+	if order.IsSellOrder() {
+		limitPrice := touch - band
+		if limitPrice <= 0 {
+			return 0, fmt.Errorf("protection band %.2f exceeds the bid %.2f", band, touch)
+		}
+		return limitPrice, nil
+	}
+	return touch + band, nil
+}
+
 func (s *orderPricingService) calculateOptimalPriceForOrder(order *domain.Order, marketPrice *MarketPrice) (float64, error) {
 	switch order.OrderType() {
 	case domain.OrderTypeMarket:
@@ -652,6 +1429,57 @@ func (s *orderPricingService) calculateOptimalPriceForOrder(order *domain.Order,
 	}
 }
 
+// improvePriceForImbalance reprices a limit order when the book imbalance favors its side.
+// Depth is only requested when the feature is enabled; missing or stale depth keeps the passive price.
+func (s *orderPricingService) improvePriceForImbalance(order *domain.Order, marketPrice *MarketPrice, pricingClient IPricingDataClient) (float64, bool) {
+	if s.imbalanceImprovementThreshold <= 0 || order.OrderType() != domain.OrderTypeLimit {
+		return 0, false
+	}
+
+	marketDepth, err := pricingClient.GetMarketDepth(order.Symbol())
+	if err != nil || marketDepth == nil {
+		return 0, false
+	}
+	if _, stale := s.depthAge(marketDepth); stale {
+		return 0, false
+	}
+
+	return s.imbalanceAdjustedLimitPrice(order, marketPrice, marketDepth.ImbalanceRatio)
+}
+
+// imbalanceAdjustedLimitPrice moves a limit price from its passive 30% of the spread toward the
+// opposite touch. A bid-heavy book favors buys (the price is likely to rise) and an ask-heavy book
+// favors sells; the shift grows with the imbalance beyond the threshold and stays within the spread.
+func (s *orderPricingService) imbalanceAdjustedLimitPrice(order *domain.Order, marketPrice *MarketPrice, imbalanceRatio float64) (float64, bool) {
+	const passiveSpreadShare = 0.3
+
+	spread := marketPrice.AskPrice - marketPrice.BidPrice
+	if spread <= 0 || s.imbalanceImprovementThreshold <= 0 || s.imbalanceImprovementThreshold >= 1 {
+		return 0, false
+	}
+
+	sideShare := imbalanceRatio
+	if order.IsSellOrder() {
+		sideShare = 1 - imbalanceRatio
+	}
+	if sideShare <= s.imbalanceImprovementThreshold {
+		return 0, false
+	}
+
+	maxShare := math.Min(s.maxImbalanceImprovement, 1.0)
+	if maxShare <= passiveSpreadShare {
+		return 0, false
+	}
+
+	strength := math.Min((sideShare-s.imbalanceImprovementThreshold)/(1-s.imbalanceImprovementThreshold), 1.0)
+	spreadShare := passiveSpreadShare + strength*(maxShare-passiveSpreadShare)
+
+	if order.IsBuyOrder() {
+		return marketPrice.BidPrice + spread*spreadShare, true
+	}
+	return marketPrice.AskPrice - spread*spreadShare, true
+}
+
 func (s *orderPricingService) calculatePriceRange(order *domain.Order, marketPrice *MarketPrice) (*PriceRange, error) {
 	optimalPrice, err := s.calculateOptimalPriceForOrder(order, marketPrice)
 	if err != nil {
@@ -702,7 +1530,7 @@ func (s *orderPricingService) calculateExecutionEstimate(order *domain.Order, ma
 	s.setEstimatedSlippage(order, pricingClient, estimate)
 
 	// Assess partial fill risk
-	estimate.PartialFillRisk = s.calculatePartialFillRisk(order, marketPrice)
+	estimate.PartialFillRisk = s.calculateDepthPartialFillRisk(order, marketPrice, pricingClient)
 
 	return estimate, nil
 }
@@ -745,8 +1573,8 @@ func (s *orderPricingService) setEstimatedSlippage(order *domain.Order, pricingC
 	}
 }
 
-func (s *orderPricingService) validatePriceWithinRange(order *domain.Order, orderPrice float64, marketPrice *MarketPrice) error {
-	maxDeviation := marketPrice.LastPrice * 0.1 // 10% max deviation
+func (s *orderPricingService) validatePriceWithinRange(order *domain.Order, orderPrice float64, marketPrice *MarketPrice, bandMultiplier float64) error {
+	maxDeviation := marketPrice.LastPrice * 0.1 * bandMultiplier // 10% max deviation in the regular session
 
 	if orderPrice > marketPrice.LastPrice+maxDeviation {
 		return fmt.Errorf("order price %.2f is too high (market: %.2f, max: %.2f)",
@@ -769,6 +1597,41 @@ func (s *orderPricingService) validateSpreadConditions(orderPrice float64, marke
 	return nil
 }
 
+// validatePricingInputs returns a *NonFiniteMarketDataError when the quote or the order price is NaN or infinite
+func validatePricingInputs(order *domain.Order, marketPrice *MarketPrice) error {
+	if marketPrice == nil {
+		return fmt.Errorf("no market price available for %s", order.Symbol())
+	}
+
+	type pricingInput struct {
+		name  string
+		value float64
+	}
+
+	inputs := []pricingInput{
+		{"bid price", marketPrice.BidPrice},
+		{"ask price", marketPrice.AskPrice},
+		{"last price", marketPrice.LastPrice},
+		{"spread", marketPrice.Spread},
+		{"spread percent", marketPrice.SpreadPercent},
+	}
+	if order.Price() != nil {
+		inputs = append(inputs, pricingInput{"order price", *order.Price()})
+	}
+
+	fields := make([]string, 0)
+	for _, input := range inputs {
+		if math.IsNaN(input.value) || math.IsInf(input.value, 0) {
+			fields = append(fields, input.name)
+		}
+	}
+
+	if len(fields) > 0 {
+		return &NonFiniteMarketDataError{Symbol: order.Symbol(), Fields: fields}
+	}
+	return nil
+}
+
 func (s *orderPricingService) estimateMarketOrderFillPrice(order *domain.Order, marketPrice *MarketPrice, pricingClient IPricingDataClient) (float64, error) {
 	// For market orders, estimate fill price considering potential slippage
 	basePrice := marketPrice.LastPrice
@@ -801,6 +1664,43 @@ func (s *orderPricingService) estimateLimitOrderFillPrice(order *domain.Order, m
 	return marketPrice.LastPrice, nil
 }
 
+// calculateScheduledFees computes the fees from the schedule of the order's asset category.
+// It reports false when the category or its schedule is unknown.
+func (s *orderPricingService) calculateScheduledFees(order *domain.Order, pricingClient IPricingDataClient) (*TradingFees, bool) {
+	if len(s.feeSchedules) == 0 {
+		return nil, false
+	}
+
+	categoryProvider, ok := pricingClient.(IAssetCategoryProvider)
+	if !ok {
+		return nil, false
+	}
+
+	category, err := categoryProvider.GetAssetCategory(order.Symbol())
+	if err != nil {
+		log.Printf("Failed to get asset category for %s, using client fees: %v", order.Symbol(), err)
+		return nil, false
+	}
+
+	schedule, ok := s.feeSchedules[category]
+	if !ok {
+		return nil, false
+	}
+
+	orderValue := order.CalculateOrderValue()
+	fees := &TradingFees{
+		CommissionFee: math.Max(orderValue*schedule.CommissionPercent/100.0, schedule.MinCommission),
+		RegulatoryFee: orderValue * schedule.RegulatoryPercent / 100.0,
+		ExchangeFee:   orderValue * schedule.ExchangePercent / 100.0,
+	}
+	fees.TotalFees = fees.CommissionFee + fees.RegulatoryFee + fees.ExchangeFee
+	if orderValue > 0 {
+		fees.FeePercent = fees.TotalFees / orderValue * 100.0
+	}
+
+	return fees, true
+}
+
 func (s *orderPricingService) adjustFeesBasedOnMethod(fees *TradingFees, order *domain.Order) {
 	switch s.feeCalculationMethod {
 	case FeeCalculationTiered:
@@ -820,6 +1720,48 @@ func (s *orderPricingService) adjustFeesBasedOnMethod(fees *TradingFees, order *
 	}
 }
 
+// tradingSession returns the session the symbol is trading in now. Clients that do not report
+// trading hours, or fail to, are treated as being in the regular session.
+func (s *orderPricingService) tradingSession(symbol string, pricingClient IPricingDataClient) TradingSession {
+	provider, ok := pricingClient.(ITradingHoursProvider)
+	if !ok {
+		return TradingSessionRegular
+	}
+
+	hours, err := provider.GetTradingHours(symbol)
+	if err != nil || hours == nil {
+		return TradingSessionRegular
+	}
+	return hours.SessionAt(time.Now())
+}
+
+// depthAge reports the age of the depth snapshot and whether it exceeds the maximum age.
+// A zero LastUpdated means the source does not report freshness, so the depth is used as is.
+func (s *orderPricingService) depthAge(marketDepth *MarketDepth) (time.Duration, bool) {
+	if s.maxDepthAge <= 0 || marketDepth.LastUpdated.IsZero() {
+		return 0, false
+	}
+
+	age := time.Since(marketDepth.LastUpdated)
+	return age, age > s.maxDepthAge
+}
+
+// assessDepthLiquidity applies the stale depth policy on top of assessLiquidityLevel
+func (s *orderPricingService) assessDepthLiquidity(marketDepth *MarketDepth, stale bool) LiquidityLevel {
+	if !stale {
+		return s.assessLiquidityLevel(marketDepth)
+	}
+
+	if s.staleDepthPolicy == StaleDepthPolicyDegrade {
+		if level := s.assessLiquidityLevel(marketDepth); level < LiquidityLevelNormal {
+			return level
+		}
+		return LiquidityLevelNormal
+	}
+
+	return LiquidityLevelLow
+}
+
 func (s *orderPricingService) assessLiquidityLevel(marketDepth *MarketDepth) LiquidityLevel {
 	if marketDepth.LiquidityScore >= 0.8 {
 		return LiquidityLevelVeryHigh
@@ -891,8 +1833,9 @@ func (s *orderPricingService) calculateBuyOrderFillProbability(orderPrice float6
 	}
 
 	if orderPrice >= marketPrice.BidPrice {
-		ratio := (orderPrice - marketPrice.BidPrice) / marketPrice.Spread
-		return 0.3 + (ratio * 0.5) // 30-80% probability
+		// A locked quote has no spread to move through; the order sits at the touch
+		position := math.Min(ratioOr(orderPrice-marketPrice.BidPrice, quotedSpread(marketPrice), 1), 1)
+		return 0.3 + (position * 0.5) // 30-80% probability
 	}
 
 	return 0.1
@@ -906,8 +1849,8 @@ func (s *orderPricingService) calculateSellOrderFillProbability(orderPrice float
 	}
 
 	if orderPrice <= marketPrice.AskPrice {
-		ratio := (marketPrice.AskPrice - orderPrice) / marketPrice.Spread
-		return 0.3 + (ratio * 0.5) // 30-80% probability
+		position := math.Min(ratioOr(marketPrice.AskPrice-orderPrice, quotedSpread(marketPrice), 1), 1)
+		return 0.3 + (position * 0.5) // 30-80% probability
 	}
 
 	return 0.1
@@ -931,6 +1874,59 @@ func (s *orderPricingService) calculateEstimatedFillTime(order *domain.Order, ma
 	}
 }
 
+// calculateDepthPartialFillRisk estimates partial fill risk from the share of the order
+// the book can absorb immediately, falling back to the order value heuristic without depth
+func (s *orderPricingService) calculateDepthPartialFillRisk(order *domain.Order, marketPrice *MarketPrice, pricingClient IPricingDataClient) float64 {
+	if s.depthLevels <= 0 || order.Quantity() <= 0 {
+		return s.calculatePartialFillRisk(order, marketPrice)
+	}
+
+	orderBook, err := pricingClient.GetOrderBookData(order.Symbol())
+	if err != nil || orderBook == nil {
+		return s.calculatePartialFillRisk(order, marketPrice)
+	}
+
+	levels := orderBook.Asks
+	if order.IsSellOrder() {
+		levels = orderBook.Bids
+	}
+	if len(levels) == 0 {
+		return s.calculatePartialFillRisk(order, marketPrice)
+	}
+
+	immediateQuantity := s.aggregateFillableQuantity(order, levels)
+	if immediateQuantity >= order.Quantity() {
+		return 0
+	}
+
+	return 1 - immediateQuantity/order.Quantity()
+}
+
+// aggregateFillableQuantity walks the opposite side of the book and sums the quantity
+// that would fill immediately, stopping at the limit price when the order has one
+func (s *orderPricingService) aggregateFillableQuantity(order *domain.Order, levels []PriceLevel) float64 {
+	fillable := 0.0
+	for i, level := range levels {
+		if s.depthLevels > 0 && i >= s.depthLevels {
+			break
+		}
+		if order.OrderType() != domain.OrderTypeMarket && order.Price() != nil {
+			limitPrice := *order.Price()
+			if order.IsBuyOrder() && level.Price > limitPrice {
+				break
+			}
+			if order.IsSellOrder() && level.Price < limitPrice {
+				break
+			}
+		}
+		fillable += level.Quantity
+		if fillable >= order.Quantity() {
+			break
+		}
+	}
+	return fillable
+}
+
 func (s *orderPricingService) calculatePartialFillRisk(order *domain.Order, marketPrice *MarketPrice) float64 {
 	orderValue := order.CalculateOrderValue()
 
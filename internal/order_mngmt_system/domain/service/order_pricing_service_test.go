@@ -1,7 +1,9 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -106,6 +108,7 @@ func TestOrderPricingService_CalculateOptimalPrice(t *testing.T) {
 	marketDepth := &MarketDepth{LiquidityScore: 0.7}
 
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(marketDepth, nil)
 
@@ -147,6 +150,7 @@ func TestOrderPricingService_CreateExecutionPlan(t *testing.T) {
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
 	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(tradingFees, nil)
 	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(priceImpact, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
 
 	plan, err := service.CreateExecutionPlan(order, mockClient)
 
@@ -238,6 +242,7 @@ func TestOrderPricingService_EstimateFillPrice(t *testing.T) {
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
 
 	price, err := service.EstimateFillPrice(order, mockClient)
 	assert.NoError(t, err)
@@ -252,6 +257,7 @@ func TestOrderPricingService_CalculateTradingCosts(t *testing.T) {
 
 	tradingFees := &TradingFees{TotalFees: 5.0}
 	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(tradingFees, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{BidPrice: 99.95, AskPrice: 100.05, Spread: 0.10}, nil)
 
 	fees, err := service.CalculateTradingCosts(order, mockClient)
 	assert.NoError(t, err)
@@ -308,6 +314,7 @@ func TestOrderPricingService_CalculateSlippageTolerance(t *testing.T) {
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.2}, nil)
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.8}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
 
 	slippage, err := service.CalculateSlippageTolerance(order, mockClient)
 	assert.NoError(t, err)
@@ -453,6 +460,83 @@ func Test_orderPricingService_selectStrategyBasedOnConditions(t *testing.T) {
 	assert.Equal(t, ExecutionStrategyLimit, s.selectStrategyBasedOnConditions(mediumOrder, wideSpreadConditions))
 }
 
+func Test_orderPricingService_explainStrategySelection(t *testing.T) {
+	s := &orderPricingService{}
+
+	tests := []struct {
+		name             string
+		quantity         float64
+		price            float64
+		conditions       *MarketConditions
+		expectedStrategy ExecutionStrategy
+		expectedFactors  []string
+	}{
+		{
+			name:             "TWAP for large order in low liquidity",
+			quantity:         1000,
+			price:            200.0,
+			conditions:       &MarketConditions{LiquidityLevel: LiquidityLevelLow, TradingVolume: 500000},
+			expectedStrategy: ExecutionStrategyTWAP,
+			expectedFactors: []string{
+				"order value 200000.00 >= 100000",
+				"liquidity level 0 is normal or below",
+				"trading volume 500000 <= 1000000",
+			},
+		},
+		{
+			name:             "iceberg for medium order",
+			quantity:         100,
+			price:            600.0,
+			conditions:       &MarketConditions{LiquidityLevel: LiquidityLevelHigh},
+			expectedStrategy: ExecutionStrategyIceberg,
+			expectedFactors:  []string{"order value 60000.00 >= 50000"},
+		},
+		{
+			name:             "limit for small order with wide spread",
+			quantity:         10,
+			price:            100.0,
+			conditions:       &MarketConditions{SpreadCondition: SpreadConditionWide},
+			expectedStrategy: ExecutionStrategyLimit,
+			expectedFactors: []string{
+				"order value 1000.00 < 50000",
+				"spread condition 2 is wide or worse",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := tt.price
+			order, _ := domain.NewOrder("u1", "s1", domain.OrderSideBuy, domain.OrderTypeLimit, tt.quantity, &price)
+
+			decision := s.explainStrategySelection(order, tt.conditions)
+
+			assert.Equal(t, tt.expectedStrategy, decision.Strategy)
+			assert.Equal(t, tt.expectedFactors, decision.DecidingFactors)
+			assert.False(t, decision.UsedDefault)
+		})
+	}
+}
+
+func TestOrderPricingService_CreateExecutionPlan_IncludesRoutingDecision(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{MaxSlippagePercent: 2.0, ImpactWarningPercent: 0.5})
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(false, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 101}, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{}, nil)
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, plan.RoutingDecision)
+	assert.Equal(t, plan.RecommendedStrategy, plan.RoutingDecision.Strategy)
+	assert.True(t, plan.RoutingDecision.UsedDefault)
+	assert.Contains(t, plan.RoutingDecision.DecidingFactors[0], "market conditions unavailable")
+}
+
 func Test_orderPricingService_selectLargeOrderStrategy(t *testing.T) {
 	s := &orderPricingService{}
 	highVolume := &MarketConditions{TradingVolume: 2000000}
@@ -565,6 +649,7 @@ func TestOrderPricingService_CalculateOptimalPrice_SellOrder(t *testing.T) {
 	marketDepth := &MarketDepth{LiquidityScore: 0.7}
 
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(marketDepth, nil)
 
@@ -606,6 +691,33 @@ func Test_orderPricingService_calculatePartialFillRisk(t *testing.T) {
 	assert.Equal(t, 0.2, risk)
 }
 
+func Test_orderPricingService_calculateDepthPartialFillRisk(t *testing.T) {
+	s := NewOrderPricingServiceWithDefaults().(*orderPricingService)
+	price := 100.0
+	order, _ := domain.NewOrder("u1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 1000, &price)
+	heuristic := s.calculatePartialFillRisk(order, nil)
+
+	thinClient := new(MockPricingDataClient)
+	thinClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{
+		Asks: []PriceLevel{{Price: 99.9, Quantity: 100}, {Price: 100.0, Quantity: 100}, {Price: 100.5, Quantity: 5000}},
+	}, nil)
+	thinRisk := s.calculateDepthPartialFillRisk(order, nil, thinClient)
+	assert.InDelta(t, 0.8, thinRisk, 0.0001)
+	assert.Greater(t, thinRisk, heuristic)
+
+	deepClient := new(MockPricingDataClient)
+	deepClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{
+		Asks: []PriceLevel{{Price: 99.9, Quantity: 600}, {Price: 100.0, Quantity: 800}},
+	}, nil)
+	deepRisk := s.calculateDepthPartialFillRisk(order, nil, deepClient)
+	assert.Equal(t, 0.0, deepRisk)
+	assert.Less(t, deepRisk, heuristic)
+
+	unavailableClient := new(MockPricingDataClient)
+	unavailableClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{}, fmt.Errorf("book unavailable"))
+	assert.Equal(t, heuristic, s.calculateDepthPartialFillRisk(order, nil, unavailableClient))
+}
+
 func Test_orderPricingService_shouldAllowPartialFills(t *testing.T) {
 	s := &orderPricingService{}
 	price := 100.0
@@ -792,6 +904,7 @@ func TestOrderPricingService_CalculateOptimalPrice_ExecutionEstimateError(t *tes
 	marketPrice := &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 101, LastPrice: 100.5, Spread: 1, SpreadPercent: 1}
 
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil).Once()
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil).Twice()
 	mockClient.On("GetMarketDepth", "PETR4").Return(nil, fmt.Errorf("depth error")).Twice()
 
@@ -811,6 +924,7 @@ func TestOrderPricingService_CalculateOptimalPrice_MarketConditionsError(t *test
 	marketPrice := &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 101, LastPrice: 100.5, Spread: 1, SpreadPercent: 1}
 
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil).Once()
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
 	mockClient.On("IsMarketOpen", "PETR4").Return(false, fmt.Errorf("market closed error")).Twice()
 
 	result, err := service.CalculateOptimalPrice(order, mockClient)
@@ -836,6 +950,7 @@ func TestOrderPricingService_CreateExecutionPlan_FeeError(t *testing.T) {
 	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(nil, fmt.Errorf("fee error"))
 	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(priceImpact, nil)
 
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
 	plan, err := service.CreateExecutionPlan(order, mockClient)
 
 	assert.NoError(t, err)
@@ -859,6 +974,7 @@ func TestOrderPricingService_CreateExecutionPlan_PriceImpactError(t *testing.T)
 	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(tradingFees, nil)
 	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(nil, fmt.Errorf("impact error"))
 
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
 	plan, err := service.CreateExecutionPlan(order, mockClient)
 
 	assert.NoError(t, err)
@@ -971,6 +1087,7 @@ func TestOrderPricingService_CalculateOptimalPrice_calculateOptimalPriceForOrder
 	marketPrice := &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 101, LastPrice: 100.5, Spread: 1, SpreadPercent: 1}
 
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{}, nil)
 
@@ -1014,12 +1131,74 @@ func TestOrderPricingService_CalculateSlippageTolerance_MediumOrder(t *testing.T
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.5}, nil)
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.5}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
 
 	slippage, err := service.CalculateSlippageTolerance(order, mockClient)
 	assert.NoError(t, err)
 	assert.True(t, slippage > 0.1)
 }
 
+func bookPressureSlippage(t *testing.T, service OrderPricingService, side domain.OrderSide, orderBook *OrderBookData) float64 {
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", side, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.1}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(orderBook, nil)
+
+	slippage, err := service.CalculateSlippageTolerance(order, mockClient)
+	assert.NoError(t, err)
+	return slippage
+}
+
+func TestOrderPricingService_CalculateSlippageTolerance_BookPressure(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	bidHeavy := &OrderBookData{
+		Symbol: "PETR4",
+		Bids:   []PriceLevel{{Price: 99.9, Quantity: 9000}},
+		Asks:   []PriceLevel{{Price: 100.1, Quantity: 1000}},
+	}
+	askHeavy := &OrderBookData{
+		Symbol: "PETR4",
+		Bids:   []PriceLevel{{Price: 99.9, Quantity: 1000}},
+		Asks:   []PriceLevel{{Price: 100.1, Quantity: 9000}},
+	}
+	balanced := &OrderBookData{
+		Symbol: "PETR4",
+		Bids:   []PriceLevel{{Price: 99.9, Quantity: 5000}},
+		Asks:   []PriceLevel{{Price: 100.1, Quantity: 5000}},
+	}
+
+	neutral := bookPressureSlippage(t, service, domain.OrderSideBuy, balanced)
+
+	// A buy fills against the asks, so a bid-heavy book is adverse and an ask-heavy one supportive
+	adverseBuy := bookPressureSlippage(t, service, domain.OrderSideBuy, bidHeavy)
+	favorableBuy := bookPressureSlippage(t, service, domain.OrderSideBuy, askHeavy)
+	assert.Greater(t, adverseBuy, neutral)
+	assert.Less(t, favorableBuy, neutral)
+	assert.InDelta(t, neutral*1.4, adverseBuy, 0.0001)
+	assert.InDelta(t, neutral*0.6, favorableBuy, 0.0001)
+
+	adverseSell := bookPressureSlippage(t, service, domain.OrderSideSell, askHeavy)
+	favorableSell := bookPressureSlippage(t, service, domain.OrderSideSell, bidHeavy)
+	assert.Greater(t, adverseSell, favorableSell)
+}
+
+func TestOrderPricingService_CalculateSlippageTolerance_BookPressureDisabled(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{MaxSlippagePercent: 2.0})
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.1}, nil)
+
+	_, err := service.CalculateSlippageTolerance(order, mockClient)
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "GetOrderBookData", "PETR4")
+}
+
 func TestOrderPricingService_setPriceRangeBasedOnOrderSide_Sell(t *testing.T) {
 	s := &orderPricingService{}
 	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideSell, domain.OrderTypeMarket, 10, nil)
@@ -1043,7 +1222,7 @@ func TestOrderPricingService_validatePriceWithinRange_TooLow(t *testing.T) {
 	price := 89.0
 	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
 	marketPrice := &MarketPrice{LastPrice: 100.0}
-	err := s.validatePriceWithinRange(order, *order.Price(), marketPrice)
+	err := s.validatePriceWithinRange(order, *order.Price(), marketPrice, 1.0)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "is too low")
 }
@@ -1057,6 +1236,7 @@ func TestOrderPricingService_estimateMarketOrderFillPrice_Sell(t *testing.T) {
 	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
 	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.7}, nil)
 	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
 
 	price, err := service.EstimateFillPrice(order, mockClient)
 	assert.NoError(t, err)
@@ -1080,4 +1260,739 @@ func TestOrderPricingService_addPriceLevelRecommendations_Sell(t *testing.T) {
 	order, _ := domain.NewOrder("u1", "s1", domain.OrderSideSell, domain.OrderTypeLimit, 1, &price)
 	s.addPriceLevelRecommendations(order, marketPrice, result)
 	assert.Contains(t, result.Warnings[0], "Sell limit price below market bid")
-}
\ No newline at end of file
+}
+func newMarketProtectionTestService() OrderPricingService {
+	return NewOrderPricingService(OrderPricingConfig{
+		DepthLevels:               5,
+		MarketProtectionLiquidity: 10000.0,
+		MarketProtectionPoints:    0.5,
+	})
+}
+
+func TestOrderPricingService_ApplyMarketOrderProtection_ThinMarket(t *testing.T) {
+	service := newMarketProtectionTestService()
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "THIN3", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	// 2 levels worth $5,075 on the ask, below the $10K threshold
+	orderBook := &OrderBookData{
+		Symbol: "THIN3",
+		Bids:   []PriceLevel{{Price: 49.5, Quantity: 1000}},
+		Asks:   []PriceLevel{{Price: 50.0, Quantity: 50}, {Price: 50.5, Quantity: 50}},
+	}
+	mockClient.On("GetOrderBookData", "THIN3").Return(orderBook, nil)
+	mockClient.On("GetCurrentMarketPrice", "THIN3").Return(&MarketPrice{Symbol: "THIN3", BidPrice: 49.5, AskPrice: 50.0, LastPrice: 49.8}, nil)
+
+	protected, err := service.ApplyMarketOrderProtection(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.True(t, protected)
+	assert.True(t, order.IsProtectedMarketOrder())
+	assert.Equal(t, domain.OrderTypeMarket, order.OrderType())
+	assert.InDelta(t, 50.5, *order.ProtectionLimitPrice(), 1e-9)
+	mockClient.AssertExpectations(t)
+}
+
+func TestOrderPricingService_ApplyMarketOrderProtection_ThinMarketSellPercentBand(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		MarketProtectionLiquidity: 10000.0,
+		MarketProtectionPercent:   2.0,
+	})
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "THIN3", domain.OrderSideSell, domain.OrderTypeMarket, 10, nil)
+
+	orderBook := &OrderBookData{Symbol: "THIN3", Bids: []PriceLevel{{Price: 50.0, Quantity: 20}}}
+	mockClient.On("GetOrderBookData", "THIN3").Return(orderBook, nil)
+	mockClient.On("GetCurrentMarketPrice", "THIN3").Return(&MarketPrice{Symbol: "THIN3", BidPrice: 50.0, AskPrice: 50.5}, nil)
+
+	protected, err := service.ApplyMarketOrderProtection(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.True(t, protected)
+	assert.InDelta(t, 49.0, *order.ProtectionLimitPrice(), 1e-9)
+}
+
+func TestOrderPricingService_ApplyMarketOrderProtection_LiquidMarket(t *testing.T) {
+	service := newMarketProtectionTestService()
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	orderBook := &OrderBookData{
+		Symbol: "PETR4",
+		Asks:   []PriceLevel{{Price: 30.0, Quantity: 5000}, {Price: 30.01, Quantity: 8000}},
+	}
+	mockClient.On("GetOrderBookData", "PETR4").Return(orderBook, nil)
+
+	protected, err := service.ApplyMarketOrderProtection(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.False(t, protected)
+	assert.False(t, order.IsProtectedMarketOrder())
+	assert.Nil(t, order.ProtectionLimitPrice())
+	mockClient.AssertNotCalled(t, "GetCurrentMarketPrice", "PETR4")
+}
+
+func TestOrderPricingService_ApplyMarketOrderProtection_IgnoresLimitOrders(t *testing.T) {
+	service := newMarketProtectionTestService()
+	mockClient := new(MockPricingDataClient)
+	price := 50.0
+	order, _ := domain.NewOrder("user1", "THIN3", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	protected, err := service.ApplyMarketOrderProtection(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.False(t, protected)
+	mockClient.AssertNotCalled(t, "GetOrderBookData", "THIN3")
+}
+
+func TestOrderPricingService_CalculateSlippageTolerance_SymbolOverride(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		MaxSlippagePercent:    2.0,
+		MinLiquidityThreshold: 10000.0,
+		SpreadWarningPercent:  1.0,
+		SlippageOverrides: map[string]SlippageModel{
+			"thin3": {
+				BaseSlippagePercent:  0.05,
+				MaxSlippagePercent:   0.2,
+				LiquidityMultipliers: map[LiquidityLevel]float64{LiquidityLevelLow: 1.2},
+			},
+		},
+	})
+
+	conditions := func(symbol string) *MockPricingDataClient {
+		mockClient := new(MockPricingDataClient)
+		mockClient.On("IsMarketOpen", symbol).Return(true, nil)
+		mockClient.On("GetMarketDepth", symbol).Return(&MarketDepth{LiquidityScore: 0.2}, nil)
+		mockClient.On("GetCurrentMarketPrice", symbol).Return(&MarketPrice{SpreadPercent: 0.8}, nil)
+		return mockClient
+	}
+
+	defaultOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	overriddenOrder, _ := domain.NewOrder("user1", "THIN3", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	defaultSlippage, err := service.CalculateSlippageTolerance(defaultOrder, conditions("PETR4"))
+	assert.NoError(t, err)
+	overriddenSlippage, err := service.CalculateSlippageTolerance(overriddenOrder, conditions("THIN3"))
+	assert.NoError(t, err)
+
+	// Default: 0.1 * 3.0 (low liquidity) * 1.8 (wide spread); override: 0.05 * 1.2 * 1.8
+	assert.InDelta(t, 0.54, defaultSlippage, 1e-9)
+	assert.InDelta(t, 0.108, overriddenSlippage, 1e-9)
+}
+
+func TestOrderPricingService_CalculateSlippageTolerance_OverrideCap(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		MaxSlippagePercent:    2.0,
+		MinLiquidityThreshold: 10000.0,
+		SpreadWarningPercent:  1.0,
+		SlippageOverrides:     map[string]SlippageModel{"THIN3": {MaxSlippagePercent: 0.25}},
+	})
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "THIN3", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "THIN3").Return(true, nil)
+	mockClient.On("GetMarketDepth", "THIN3").Return(&MarketDepth{LiquidityScore: 0.2}, nil)
+	mockClient.On("GetCurrentMarketPrice", "THIN3").Return(&MarketPrice{SpreadPercent: 0.8}, nil)
+
+	slippage, err := service.CalculateSlippageTolerance(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, slippage)
+}
+
+func TestOrderPricingService_ValidateMarketConditions_FreshDepth(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.7, LastUpdated: time.Now()}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.05}, nil)
+
+	conditions, err := service.ValidateMarketConditions(order, mockClient)
+	assert.NoError(t, err)
+	assert.False(t, conditions.DepthStale)
+	assert.Empty(t, conditions.Warnings)
+	assert.Equal(t, LiquidityLevelVeryHigh, conditions.LiquidityLevel)
+	assert.Equal(t, MarketTrendBullish, conditions.MarketTrend)
+}
+
+func TestOrderPricingService_ValidateMarketConditions_StaleDepthFallsBack(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.7, LastUpdated: time.Now().Add(-time.Minute)}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.05}, nil)
+
+	conditions, err := service.ValidateMarketConditions(order, mockClient)
+	assert.NoError(t, err)
+	assert.True(t, conditions.DepthStale)
+	assert.Len(t, conditions.Warnings, 1)
+	assert.Contains(t, conditions.Warnings[0], "market depth for PETR4 is")
+	assert.Equal(t, LiquidityLevelLow, conditions.LiquidityLevel)
+	assert.Equal(t, MarketTrendNeutral, conditions.MarketTrend)
+}
+
+func TestOrderPricingService_ValidateMarketConditions_StaleDepthDegradePolicy(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{MaxDepthAge: 5 * time.Second, StaleDepthPolicy: StaleDepthPolicyDegrade})
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.7, LastUpdated: time.Now().Add(-time.Minute)}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{SpreadPercent: 0.05}, nil)
+
+	conditions, err := service.ValidateMarketConditions(order, mockClient)
+	assert.NoError(t, err)
+	assert.True(t, conditions.DepthStale)
+	assert.NotEmpty(t, conditions.Warnings)
+	assert.Equal(t, LiquidityLevelNormal, conditions.LiquidityLevel)
+	assert.Equal(t, MarketTrendBullish, conditions.MarketTrend)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_StaleDepthWarning(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 2000, &price)
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.9, LastUpdated: time.Now().Add(-time.Minute)}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{BidPrice: 99.9, AskPrice: 100.1, SpreadPercent: 0.05, Volume: 500000}, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 5.0}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{EstimatedImpact: 0.1}, nil)
+	mockClient.On("GetOrderBook", "PETR4", mock.Anything).Return(nil, fmt.Errorf("no book")).Maybe()
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+	assert.NoError(t, err)
+	// Stale depth is not trusted, so the large order is sliced as in a thin market
+	assert.Equal(t, ExecutionStrategyTWAP, plan.RecommendedStrategy)
+	assert.NotEmpty(t, plan.RoutingDecision.Warnings)
+	assert.Contains(t, plan.RiskWarnings, plan.RoutingDecision.Warnings[0])
+}
+
+func newImmediateFillTestOrder(t *testing.T, timeInForce domain.TimeInForce, allowPartialFill bool) *domain.Order {
+	price := 50.0
+	order, err := domain.NewOrder("user1", "THIN3", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	assert.NoError(t, err)
+	assert.NoError(t, order.SetExecutionPreferences(timeInForce, allowPartialFill))
+	return order
+}
+
+func TestOrderPricingService_SimulateImmediateFill_AboveMinFillRatio(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{MinFillRatio: 0.5})
+	mockClient := new(MockPricingDataClient)
+	order := newImmediateFillTestOrder(t, domain.TimeInForceIOC, true)
+
+	// 60 shares rest at or below the limit; the level above it is out of reach
+	mockClient.On("GetOrderBookData", "THIN3").Return(&OrderBookData{
+		Symbol: "THIN3",
+		Asks:   []PriceLevel{{Price: 49.9, Quantity: 40}, {Price: 50.0, Quantity: 20}, {Price: 50.5, Quantity: 500}},
+	}, nil)
+
+	result, err := service.SimulateImmediateFill(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Cancelled)
+	assert.InDelta(t, 60.0, result.FillableQuantity, 1e-9)
+	assert.InDelta(t, 0.6, result.FillRatio, 1e-9)
+	assert.Equal(t, 0.5, result.MinFillRatio)
+}
+
+func TestOrderPricingService_SimulateImmediateFill_BelowMinFillRatio(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{MinFillRatio: 0.5})
+	mockClient := new(MockPricingDataClient)
+	order := newImmediateFillTestOrder(t, domain.TimeInForceIOC, true)
+
+	mockClient.On("GetOrderBookData", "THIN3").Return(&OrderBookData{
+		Symbol: "THIN3",
+		Asks:   []PriceLevel{{Price: 50.0, Quantity: 10}, {Price: 51.0, Quantity: 500}},
+	}, nil)
+
+	result, err := service.SimulateImmediateFill(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Cancelled)
+	assert.Zero(t, result.FillableQuantity)
+	assert.InDelta(t, 0.1, result.FillRatio, 1e-9)
+	assert.Contains(t, result.Reason, "below minimum")
+}
+
+func TestOrderPricingService_SimulateImmediateFill_FOKRequiresFullFill(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{MinFillRatio: 0.5})
+	mockClient := new(MockPricingDataClient)
+	order := newImmediateFillTestOrder(t, domain.TimeInForceFOK, false)
+
+	mockClient.On("GetOrderBookData", "THIN3").Return(&OrderBookData{
+		Symbol: "THIN3",
+		Asks:   []PriceLevel{{Price: 50.0, Quantity: 90}},
+	}, nil)
+
+	result, err := service.SimulateImmediateFill(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, 1.0, result.MinFillRatio)
+}
+
+func TestOrderPricingService_SimulateImmediateFill_RejectsDayOrders(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	order := newImmediateFillTestOrder(t, domain.TimeInForceDay, true)
+
+	_, err := service.SimulateImmediateFill(order, mockClient)
+
+	assert.Error(t, err)
+	mockClient.AssertNotCalled(t, "GetOrderBookData", "THIN3")
+}
+
+// CategorizedPricingDataClient adds asset categories to the mock pricing client
+type CategorizedPricingDataClient struct {
+	*MockPricingDataClient
+	categories map[string]AssetCategory
+}
+
+func (c *CategorizedPricingDataClient) GetAssetCategory(symbol string) (AssetCategory, error) {
+	category, ok := c.categories[symbol]
+	if !ok {
+		return 0, fmt.Errorf("unknown symbol %s", symbol)
+	}
+	return category, nil
+}
+
+func TestOrderPricingService_CalculateTradingCosts_CategoryFeeSchedules(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		FeeCalculationMethod: FeeCalculationFixed,
+		FeeSchedules: map[AssetCategory]FeeSchedule{
+			AssetCategoryStock: {CommissionPercent: 0.10, MinCommission: 5.0, ExchangePercent: 0.03},
+			AssetCategoryBond:  {CommissionPercent: 0.02, MinCommission: 1.0},
+		},
+	})
+	client := &CategorizedPricingDataClient{
+		MockPricingDataClient: new(MockPricingDataClient),
+		categories:            map[string]AssetCategory{"PETR4": AssetCategoryStock, "TESOURO35": AssetCategoryBond},
+	}
+	price := 100.0
+	stockOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	bondOrder, _ := domain.NewOrder("user1", "TESOURO35", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+
+	stockFees, err := service.CalculateTradingCosts(stockOrder, client)
+	assert.NoError(t, err)
+	bondFees, err := service.CalculateTradingCosts(bondOrder, client)
+	assert.NoError(t, err)
+
+	// Same $10,000 order value, different schedules
+	assert.InDelta(t, 10.0, stockFees.CommissionFee, 1e-9)
+	assert.InDelta(t, 13.0, stockFees.TotalFees, 1e-9)
+	assert.InDelta(t, 0.13, stockFees.FeePercent, 1e-9)
+	assert.InDelta(t, 2.0, bondFees.TotalFees, 1e-9)
+	assert.NotEqual(t, stockFees.TotalFees, bondFees.TotalFees)
+	client.AssertNotCalled(t, "GetTradingFees", mock.Anything, mock.Anything)
+}
+
+func TestOrderPricingService_CalculateTradingCosts_CategoryWithoutSchedule(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		FeeSchedules: map[AssetCategory]FeeSchedule{AssetCategoryStock: {CommissionPercent: 0.10}},
+	})
+	client := &CategorizedPricingDataClient{
+		MockPricingDataClient: new(MockPricingDataClient),
+		categories:            map[string]AssetCategory{"BTC": AssetCategoryCrypto},
+	}
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "BTC", domain.OrderSideBuy, domain.OrderTypeLimit, 1, &price)
+	client.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 1.5}, nil)
+
+	fees, err := service.CalculateTradingCosts(order, client)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, fees.TotalFees)
+	client.AssertExpectations(t)
+}
+
+func TestOrderPricingService_CalculateTradingCosts_SpreadCostScalesWithSpreadAndQuantity(t *testing.T) {
+	service := NewOrderPricingService(OrderPricingConfig{
+		FeeCalculationMethod: FeeCalculationFixed,
+		IncludeSpreadCost:    true,
+	})
+	price := 100.0
+	smallOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	largeOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 300, &price)
+	wideOrder, _ := domain.NewOrder("user1", "MGLU3", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+
+	mockClient := new(MockPricingDataClient)
+	mockClient.On("GetTradingFees", domain.OrderTypeLimit, 10000.0).Return(&TradingFees{TotalFees: 5.0}, nil).Once()
+	mockClient.On("GetTradingFees", domain.OrderTypeLimit, 30000.0).Return(&TradingFees{TotalFees: 5.0}, nil).Once()
+	mockClient.On("GetTradingFees", domain.OrderTypeLimit, 10000.0).Return(&TradingFees{TotalFees: 5.0}, nil).Once()
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{BidPrice: 99.95, AskPrice: 100.05, Spread: 0.10}, nil)
+	mockClient.On("GetCurrentMarketPrice", "MGLU3").Return(&MarketPrice{BidPrice: 99.80, AskPrice: 100.20, Spread: 0.40}, nil)
+
+	small, err := service.CalculateTradingCosts(smallOrder, mockClient)
+	assert.NoError(t, err)
+	large, err := service.CalculateTradingCosts(largeOrder, mockClient)
+	assert.NoError(t, err)
+	wide, err := service.CalculateTradingCosts(wideOrder, mockClient)
+	assert.NoError(t, err)
+
+	// Half of a 0.10 spread on 100 shares
+	assert.InDelta(t, 5.0, small.SpreadCost, 1e-9)
+	assert.InDelta(t, 15.0, large.SpreadCost, 1e-9)
+	assert.InDelta(t, 20.0, wide.SpreadCost, 1e-9)
+
+	// Reported separately from the explicit fees
+	assert.Equal(t, 5.0, small.TotalFees)
+	assert.InDelta(t, 10.0, small.AllInCost, 1e-9)
+}
+
+func TestOrderPricingService_CalculateTradingCosts_SpreadCostDisabledOrUnavailable(t *testing.T) {
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+
+	disabled := NewOrderPricingService(OrderPricingConfig{FeeCalculationMethod: FeeCalculationFixed})
+	disabledClient := new(MockPricingDataClient)
+	disabledClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 5.0}, nil)
+
+	fees, err := disabled.CalculateTradingCosts(order, disabledClient)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, fees.SpreadCost)
+	assert.Equal(t, 5.0, fees.AllInCost)
+	disabledClient.AssertNotCalled(t, "GetCurrentMarketPrice", mock.Anything)
+
+	enabled := NewOrderPricingService(OrderPricingConfig{FeeCalculationMethod: FeeCalculationFixed, IncludeSpreadCost: true})
+	unavailableClient := new(MockPricingDataClient)
+	unavailableClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{TotalFees: 5.0}, nil)
+	unavailableClient.On("GetCurrentMarketPrice", "PETR4").Return(nil, fmt.Errorf("quote unavailable"))
+
+	fees, err = enabled.CalculateTradingCosts(order, unavailableClient)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, fees.SpreadCost)
+	assert.Equal(t, 5.0, fees.AllInCost)
+}
+
+func TestOrderPricingService_CalculateOptimalPrice_FavorableImbalanceImprovesLimitPrice(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	marketPrice := &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 102, LastPrice: 101, Spread: 2, SpreadPercent: 2}
+	marketDepth := &MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.9, LastUpdated: time.Now()}
+
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(marketDepth, nil)
+
+	result, err := service.CalculateOptimalPrice(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.Greater(t, result.RecommendedPrice, 100.6, "bid-heavy book should move the buy past its passive price")
+	assert.LessOrEqual(t, result.RecommendedPrice, 101.6, "improvement stays within the configured share of the spread")
+	assert.Equal(t, result.RecommendedPrice, result.PriceRange.OptimalPrice)
+}
+
+func TestOrderPricingService_CalculateOptimalPrice_UnfavorableImbalanceStaysPassive(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	marketPrice := &MarketPrice{Symbol: "PETR4", BidPrice: 100, AskPrice: 102, LastPrice: 101, Spread: 2, SpreadPercent: 2}
+	marketDepth := &MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.2, LastUpdated: time.Now()}
+
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil)
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(marketDepth, nil)
+
+	result, err := service.CalculateOptimalPrice(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 100.6, result.RecommendedPrice, 0.0001)
+}
+
+func Test_orderPricingService_imbalanceAdjustedLimitPrice(t *testing.T) {
+	s := &orderPricingService{imbalanceImprovementThreshold: 0.65, maxImbalanceImprovement: 0.8}
+	marketPrice := &MarketPrice{BidPrice: 100, AskPrice: 102, Spread: 2}
+	price := 101.0
+	sellOrder, _ := domain.NewOrder("u1", "s1", domain.OrderSideSell, domain.OrderTypeLimit, 1, &price)
+
+	// Ask-heavy book favors the sell: fully one-sided reaches the 80% cap
+	improved, ok := s.imbalanceAdjustedLimitPrice(sellOrder, marketPrice, 0.0)
+	assert.True(t, ok)
+	assert.InDelta(t, 100.4, improved, 0.0001)
+
+	// Bid-heavy book works against the sell
+	_, ok = s.imbalanceAdjustedLimitPrice(sellOrder, marketPrice, 0.8)
+	assert.False(t, ok)
+
+	// The cap never goes past the opposite touch
+	s.maxImbalanceImprovement = 3.0
+	improved, ok = s.imbalanceAdjustedLimitPrice(sellOrder, marketPrice, 0.0)
+	assert.True(t, ok)
+	assert.InDelta(t, 100.0, improved, 0.0001)
+}
+
+func TestOrderPricingService_CreateExecutionPlan_HonorsStrategyOverride(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 1000, &price)
+	order.SetExecutionStrategyOverride("twap")
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.5}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 99.9, AskPrice: 100.1, Spread: 0.2, SpreadPercent: 0.2}, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExecutionStrategyTWAP, plan.RecommendedStrategy)
+	assert.True(t, plan.RoutingDecision.Overridden)
+	assert.Contains(t, plan.RoutingDecision.DecidingFactors, "recommended strategy was ICEBERG")
+}
+
+func TestOrderPricingService_CreateExecutionPlan_IgnoresInvalidStrategyOverride(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 5, &price)
+	order.SetExecutionStrategyOverride("TWAP")
+
+	mockClient.On("IsMarketOpen", "PETR4").Return(true, nil)
+	mockClient.On("GetMarketDepth", "PETR4").Return(&MarketDepth{LiquidityScore: 0.9, ImbalanceRatio: 0.5}, nil)
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 99.9, AskPrice: 100.1, Spread: 0.2, SpreadPercent: 0.2}, nil)
+	mockClient.On("GetTradingFees", order.OrderType(), order.CalculateOrderValue()).Return(&TradingFees{}, nil)
+	mockClient.On("GetPriceImpactEstimate", order.Symbol(), order.OrderSide(), order.Quantity()).Return(&PriceImpact{}, nil)
+	mockClient.On("GetOrderBookData", "PETR4").Return(&OrderBookData{Symbol: "PETR4"}, nil).Maybe()
+
+	plan, err := service.CreateExecutionPlan(order, mockClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExecutionStrategyLimit, plan.RecommendedStrategy)
+	assert.False(t, plan.RoutingDecision.Overridden)
+	assert.Contains(t, fmt.Sprint(plan.RiskWarnings), "Ignored execution strategy override")
+}
+
+func TestOrderPricingService_ValidateStrategyOverride(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	price := 100.0
+	tinyLimit, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 5, &price)
+	largeLimit, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 1000, &price)
+	largeMarket, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideSell, domain.OrderTypeMarket, 1000, nil)
+	largeMarket.SetMarketDataContext(100.0, time.Now())
+
+	tests := []struct {
+		name     string
+		order    *domain.Order
+		strategy string
+		want     ExecutionStrategy
+		wantErr  string
+	}{
+		{"TWAP on a large order", largeLimit, "TWAP", ExecutionStrategyTWAP, ""},
+		{"VWAP on a large market order", largeMarket, "VWAP", ExecutionStrategyVWAP, ""},
+		{"Hidden on a tiny limit order", tinyLimit, "HIDDEN", ExecutionStrategyHidden, ""},
+		{"TWAP on a tiny order", tinyLimit, "TWAP", 0, "requires an order value of at least 50000.00"},
+		{"Iceberg on a market order", largeMarket, "ICEBERG", 0, "requires a limit price"},
+		{"Market strategy on a limit order", largeLimit, "MARKET", 0, "requires a MARKET order"},
+		{"Unknown strategy", largeLimit, "SNIPER", 0, "unknown execution strategy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := service.ValidateStrategyOverride(tt.order, tt.strategy)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, strategy)
+		})
+	}
+}
+
+func TestOrderPricingService_CalculateOptimalPrice_NonFiniteMarketData(t *testing.T) {
+	tests := []struct {
+		name           string
+		marketPrice    *MarketPrice
+		expectedFields []string
+	}{
+		{
+			name:           "NaN bid",
+			marketPrice:    &MarketPrice{Symbol: "PETR4", BidPrice: math.NaN(), AskPrice: 100.05, LastPrice: 100, Spread: 0.10},
+			expectedFields: []string{"bid price"},
+		},
+		{
+			name:           "infinite ask and spread",
+			marketPrice:    &MarketPrice{Symbol: "PETR4", BidPrice: 99.95, AskPrice: math.Inf(1), LastPrice: 100, Spread: math.Inf(1)},
+			expectedFields: []string{"ask price", "spread"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderPricingServiceWithDefaults()
+			mockClient := new(MockPricingDataClient)
+			price := 100.0
+			order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+			mockClient.On("GetCurrentMarketPrice", "PETR4").Return(tt.marketPrice, nil)
+
+			result, err := service.CalculateOptimalPrice(order, mockClient)
+
+			var nonFiniteErr *NonFiniteMarketDataError
+			assert.True(t, errors.As(err, &nonFiniteErr), "expected a NonFiniteMarketDataError, got %v", err)
+			if nonFiniteErr != nil {
+				assert.Equal(t, "PETR4", nonFiniteErr.Symbol)
+				assert.Equal(t, tt.expectedFields, nonFiniteErr.Fields)
+			}
+			assert.Zero(t, result.RecommendedPrice)
+			assert.Len(t, result.Warnings, 1)
+
+			// Only the quote is read; nothing is computed from it
+			mockClient.AssertNotCalled(t, "GetMarketDepth", "PETR4")
+			mockClient.AssertNotCalled(t, "GetOrderBookData", "PETR4")
+		})
+	}
+}
+
+func TestOrderPricingService_EstimateFillPrice_NonFiniteMarketData(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 99.95, AskPrice: math.NaN()}, nil)
+
+	price, err := service.EstimateFillPrice(order, mockClient)
+
+	var nonFiniteErr *NonFiniteMarketDataError
+	assert.True(t, errors.As(err, &nonFiniteErr), "expected a NonFiniteMarketDataError, got %v", err)
+	assert.EqualError(t, err, "non-finite pricing input for PETR4: ask price")
+	assert.Zero(t, price)
+	assert.False(t, math.IsNaN(price))
+}
+
+func TestOrderPricingService_EstimateFillPrice_NonFiniteOrderPrice(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	mockClient := new(MockPricingDataClient)
+	price := math.Inf(-1)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideSell, domain.OrderTypeLimit, 10, &price)
+
+	mockClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 99.95, AskPrice: 100.05}, nil)
+
+	fillPrice, err := service.EstimateFillPrice(order, mockClient)
+
+	assert.EqualError(t, err, "non-finite pricing input for PETR4: order price")
+	assert.Zero(t, fillPrice)
+}
+
+// SessionPricingDataClient adds trading hours to the mock pricing client
+type SessionPricingDataClient struct {
+	*MockPricingDataClient
+	hours *TradingHours
+}
+
+func (c *SessionPricingDataClient) GetTradingHours(symbol string) (*TradingHours, error) {
+	return c.hours, nil
+}
+
+// sessionHours returns trading hours where now falls in the requested session
+func sessionHours(session TradingSession) *TradingHours {
+	now := time.Now()
+	hours := &TradingHours{ExtendedHours: true}
+	switch session {
+	case TradingSessionPreMarket:
+		hours.PreMarketOpen = now.Add(-time.Hour)
+		hours.MarketOpen = now.Add(time.Hour)
+	case TradingSessionPostMarket:
+		hours.PreMarketOpen = now.Add(-10 * time.Hour)
+		hours.MarketOpen = now.Add(-9 * time.Hour)
+	default:
+		hours.PreMarketOpen = now.Add(-2 * time.Hour)
+		hours.MarketOpen = now.Add(-time.Hour)
+		hours.IsOpen = true
+	}
+	hours.MarketClose = hours.MarketOpen.Add(7 * time.Hour)
+	hours.PostMarketClose = hours.MarketClose.Add(4 * time.Hour)
+	return hours
+}
+
+func sessionPricingClient(symbol string, session TradingSession, marketPrice *MarketPrice) *SessionPricingDataClient {
+	mockClient := new(MockPricingDataClient)
+	mockClient.On("IsMarketOpen", symbol).Return(session == TradingSessionRegular, nil)
+	mockClient.On("GetMarketDepth", symbol).Return(&MarketDepth{LiquidityScore: 0.7}, nil)
+	mockClient.On("GetCurrentMarketPrice", symbol).Return(marketPrice, nil)
+	mockClient.On("GetOrderBookData", symbol).Return(&OrderBookData{Symbol: symbol}, nil).Maybe()
+	return &SessionPricingDataClient{MockPricingDataClient: mockClient, hours: sessionHours(session)}
+}
+
+func TestTradingHours_SessionAt(t *testing.T) {
+	assert.Equal(t, TradingSessionRegular, sessionHours(TradingSessionRegular).SessionAt(time.Now()))
+	assert.Equal(t, TradingSessionPreMarket, sessionHours(TradingSessionPreMarket).SessionAt(time.Now()))
+	assert.Equal(t, TradingSessionPostMarket, sessionHours(TradingSessionPostMarket).SessionAt(time.Now()))
+
+	noExtended := sessionHours(TradingSessionPreMarket)
+	noExtended.ExtendedHours = false
+	assert.Equal(t, TradingSessionClosed, noExtended.SessionAt(time.Now()))
+
+	assert.Equal(t, TradingSessionRegular, (&TradingHours{IsOpen: true}).SessionAt(time.Now()))
+}
+
+func TestOrderPricingService_CalculateSlippageTolerance_ExtendedHours(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	marketPrice := &MarketPrice{SpreadPercent: 0.3}
+
+	regular, err := service.CalculateSlippageTolerance(order, sessionPricingClient("PETR4", TradingSessionRegular, marketPrice))
+	assert.NoError(t, err)
+	preMarket, err := service.CalculateSlippageTolerance(order, sessionPricingClient("PETR4", TradingSessionPreMarket, marketPrice))
+	assert.NoError(t, err)
+
+	// Regular: 0.1 * 0.8 (high liquidity); pre-market: 0.1 * 1.5 (liquidity reduced to normal) * 2.0
+	assert.InDelta(t, 0.08, regular, 1e-9)
+	assert.InDelta(t, 0.3, preMarket, 1e-9)
+}
+
+func TestOrderPricingService_ValidateMarketConditions_ExtendedHours(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+
+	conditions, err := service.ValidateMarketConditions(order, sessionPricingClient("PETR4", TradingSessionPostMarket, &MarketPrice{SpreadPercent: 0.3}))
+	assert.NoError(t, err)
+	assert.Equal(t, TradingSessionPostMarket, conditions.TradingSession)
+	assert.Equal(t, LiquidityLevelNormal, conditions.LiquidityLevel)
+}
+
+func TestOrderPricingService_ValidateOrderPrice_ExtendedHours(t *testing.T) {
+	service := NewOrderPricingServiceWithDefaults()
+	marketPrice := &MarketPrice{LastPrice: 100, BidPrice: 99.9, AskPrice: 100.1, SpreadPercent: 0.2}
+
+	// 115 is outside the regular 10% band but inside the doubled extended-hours band
+	price := 115.0
+	limitOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	assert.Error(t, service.ValidateOrderPrice(limitOrder, sessionPricingClient("PETR4", TradingSessionRegular, marketPrice)))
+	assert.NoError(t, service.ValidateOrderPrice(limitOrder, sessionPricingClient("PETR4", TradingSessionPreMarket, marketPrice)))
+
+	marketOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	assert.NoError(t, service.ValidateOrderPrice(marketOrder, sessionPricingClient("PETR4", TradingSessionRegular, marketPrice)))
+	err := service.ValidateOrderPrice(marketOrder, sessionPricingClient("PETR4", TradingSessionPreMarket, marketPrice))
+	assert.EqualError(t, err, "only limit orders are accepted for PETR4 during the PRE_MARKET session")
+}
+
+func Test_orderPricingService_FillProbability_ZeroSpread(t *testing.T) {
+	s := &orderPricingService{}
+
+	// The feed left the spread out; it is derived from bid and ask
+	unreported := &MarketPrice{BidPrice: 100, AskPrice: 102}
+	assert.InDelta(t, 0.55, s.calculateBuyOrderFillProbability(101, unreported), 1e-9)
+	assert.InDelta(t, 0.55, s.calculateSellOrderFillProbability(101, unreported), 1e-9)
+
+	// A locked quote has no spread at all
+	locked := &MarketPrice{BidPrice: 100, AskPrice: 100}
+	assert.Equal(t, 0.9, s.calculateBuyOrderFillProbability(100, locked))
+	assert.Equal(t, 0.9, s.calculateSellOrderFillProbability(100, locked))
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
@@ -23,6 +25,31 @@ type IPositionClient interface {
 	HasSufficientBalance(userID string, requiredAmount float64) (bool, error)
 }
 
+// IBalanceDetailsClient is optionally implemented by position clients that can report the
+// actual balance, letting buy validation tell the user how much they are short
+type IBalanceDetailsClient interface {
+	GetBalanceDetails(userID string) (*BalanceDetails, error)
+}
+
+// IOrderBookDepthClient is optionally implemented by market data clients that can report the
+// visible book, letting validation check that it can absorb large orders
+type IOrderBookDepthClient interface {
+	GetOrderBookData(symbol string) (*OrderBookData, error)
+	GetMarketDepth(symbol string) (*MarketDepth, error)
+}
+
+// IHistoricalVolumeClient is optionally implemented by market data clients that can report traded
+// volume history, letting validation compare orders with the average daily volume
+type IHistoricalVolumeClient interface {
+	GetHistoricalPrices(symbol string, period time.Duration) ([]HistoricalPrice, error)
+}
+
+// BalanceDetails is the user's cash balance and the part of it held by open orders
+type BalanceDetails struct {
+	AvailableBalance float64
+	HeldBalance      float64
+}
+
 // AssetDetails represents asset information from market data service
 type AssetDetails struct {
 	Symbol       string
@@ -65,9 +92,64 @@ type ValidationResult struct {
 	IsValid           bool
 	Errors            []string
 	Warnings          []string
+	SymbolSuggestions []string
+	BalanceShortfall  *float64 // Amount the user needs to add for a buy to pass, including fees and holds
 	ValidationContext *ValidationContext
 }
 
+// OrderValidationRule names a validation rule that can be switched off per environment
+type OrderValidationRule string
+
+const (
+	OrderValidationRuleTickSize     OrderValidationRule = "TICK_SIZE"     // Price must sit on the symbol's price step
+	OrderValidationRuleLotSize      OrderValidationRule = "LOT_SIZE"      // Quantity must be within the symbol's order size limits
+	OrderValidationRulePriceBand    OrderValidationRule = "PRICE_BAND"    // Limit price must stay near the market price
+	OrderValidationRuleTradingHours OrderValidationRule = "TRADING_HOURS" // Market hours are checked for the symbol
+	OrderValidationRuleBookDepth    OrderValidationRule = "BOOK_DEPTH"    // Large orders must fit the visible order book
+	OrderValidationRuleADV          OrderValidationRule = "ADV"           // Orders must stay a small share of the average daily volume
+)
+
+// AllOrderValidationRules returns every rule that can be toggled
+func AllOrderValidationRules() []OrderValidationRule {
+	return []OrderValidationRule{
+		OrderValidationRuleTickSize,
+		OrderValidationRuleLotSize,
+		OrderValidationRulePriceBand,
+		OrderValidationRuleTradingHours,
+		OrderValidationRuleBookDepth,
+		OrderValidationRuleADV,
+	}
+}
+
+// IsValid checks if the rule is one of the toggleable rules
+func (r OrderValidationRule) IsValid() bool {
+	for _, rule := range AllOrderValidationRules() {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOrderValidationRules parses a comma-separated rule list such as "TICK_SIZE,TRADING_HOURS",
+// as read from environment configuration. An empty value yields no rules.
+func ParseOrderValidationRules(value string) ([]OrderValidationRule, error) {
+	rules := make([]OrderValidationRule, 0)
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToUpper(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+
+		rule := OrderValidationRule(name)
+		if !rule.IsValid() {
+			return nil, fmt.Errorf("invalid order validation rule: %s", part)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
 // OrderValidationService handles business validation rules for orders
 type OrderValidationService interface {
 	// ValidateOrder performs comprehensive order validation
@@ -88,6 +170,12 @@ type OrderValidationService interface {
 	// ValidateTradingHours validates if trading is allowed at current time
 	ValidateTradingHours(ctx context.Context, symbol string, marketDataClient IMarketDataClient) (*ValidationResult, error)
 
+	// SetSymbolCloseOnly toggles close-only mode for a symbol
+	SetSymbolCloseOnly(symbol string, enabled bool)
+
+	// SetAccountCloseOnly toggles close-only mode for an account
+	SetAccountCloseOnly(userID string, enabled bool)
+
 	// ValidateOrderSide validates order side specific rules
 	ValidateOrderSide(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error)
 
@@ -101,33 +189,157 @@ type orderValidationService struct {
 	maxQuantityPerOrder   float64
 	priceTolerancePercent float64
 	minOrderValue         float64
+	estimatedFeeRate      float64
+	tickSizePolicy        domain.TickSizePolicy
+	symbolSuggestions     SymbolSuggestionService
+	disabledRules         map[OrderValidationRule]bool
+	failFast              bool
+
+	depthRequirement        DepthRequirement
+	symbolDepthRequirements map[string]DepthRequirement
+
+	advLimit ADVLimit
+
+	extendedHoursRules ExtendedHoursRules
+
+	sessionNotionalCaps SessionNotionalCaps
+
+	includeFeesInLimitChecks bool
+	pricingService           OrderPricingService
+	pricingClient            IPricingDataClient
+
+	closeOnlyMu       sync.RWMutex
+	closeOnlySymbols  map[string]bool
+	closeOnlyAccounts map[string]bool
 }
 
 // OrderValidationConfig holds configuration for order validation
 type OrderValidationConfig struct {
-	MaxOrderValue         float64 // Maximum allowed order value
-	MaxQuantityPerOrder   float64 // Maximum quantity per order
-	PriceTolerancePercent float64 // Price tolerance percentage for limit orders
-	MinOrderValue         float64 // Minimum order value
+	MaxOrderValue         float64               // Maximum allowed order value
+	MaxQuantityPerOrder   float64               // Maximum quantity per order
+	PriceTolerancePercent float64               // Price tolerance percentage for limit orders
+	MinOrderValue         float64               // Minimum order value
+	EstimatedFeeRate      float64               // Fees as a fraction of order value, added to the balance a buy requires
+	TickSizePolicy        domain.TickSizePolicy // How prices off the symbol's price step are handled (empty only warns)
+	CloseOnlySymbols      []string              // Symbols that only accept position-reducing orders
+	CloseOnlyAccounts     []string              // Accounts that only accept position-reducing orders
+	DisabledRules         []OrderValidationRule // Rules switched off, e.g. for testing in staging (all rules run by default)
+	FailFast              bool                  // Skip the external checks once a cheap step (domain or symbol validation) has failed
+
+	DepthRequirement        DepthRequirement            // Visible book depth large orders need (zero coverages disable the check)
+	SymbolDepthRequirements map[string]DepthRequirement // Per-symbol depth requirements keyed by symbol
+
+	ADVLimit ADVLimit // Order size allowed relative to the average daily volume (zero percents disable the check)
+
+	ExtendedHoursRules ExtendedHoursRules // Orders accepted during the pre-market and post-market (the zero value accepts any order type)
+
+	SessionNotionalCaps SessionNotionalCaps // Order value caps by session phase and order type (the zero value caps nothing)
+
+	IncludeFeesInLimitChecks bool // Buys must cover their estimated fees in the balance check and stay under the maximum order value with them
+}
+
+// DepthRequirement sets how much of a large order the opposite side of the visible book must hold
+type DepthRequirement struct {
+	MinOrderQuantity float64 // Orders below this quantity are not checked
+	WarnCoverage     float64 // Share of the order quantity below which the order is accepted with a warning
+	BlockCoverage    float64 // Share of the order quantity below which the order is rejected
+}
+
+// ADVLimit sets how large an order may be relative to the symbol's average daily volume
+type ADVLimit struct {
+	LookbackDays int     // Trading history averaged into the daily volume
+	WarnPercent  float64 // Percentage of the average daily volume above which the order is accepted with a warning
+	BlockPercent float64 // Percentage of the average daily volume above which the order is rejected
 }
 
 // NewOrderValidationService creates a new instance of OrderValidationService
 func NewOrderValidationService(config OrderValidationConfig) OrderValidationService {
-	return &orderValidationService{
+	service := &orderValidationService{
 		maxOrderValue:         config.MaxOrderValue,
 		maxQuantityPerOrder:   config.MaxQuantityPerOrder,
 		priceTolerancePercent: config.PriceTolerancePercent,
 		minOrderValue:         config.MinOrderValue,
+		estimatedFeeRate:      config.EstimatedFeeRate,
+		tickSizePolicy:        config.TickSizePolicy,
+		failFast:              config.FailFast,
+		closeOnlySymbols:      make(map[string]bool),
+		closeOnlyAccounts:     make(map[string]bool),
+		disabledRules:         make(map[OrderValidationRule]bool),
+
+		depthRequirement:        config.DepthRequirement,
+		symbolDepthRequirements: make(map[string]DepthRequirement),
+
+		advLimit: config.ADVLimit,
+
+		extendedHoursRules: config.ExtendedHoursRules,
+
+		sessionNotionalCaps: config.SessionNotionalCaps,
+
+		includeFeesInLimitChecks: config.IncludeFeesInLimitChecks,
 	}
+
+	if !service.tickSizePolicy.IsValid() {
+		service.tickSizePolicy = domain.TickSizePolicyWarn
+	}
+
+	for _, symbol := range config.CloseOnlySymbols {
+		service.closeOnlySymbols[strings.ToUpper(symbol)] = true
+	}
+	for _, userID := range config.CloseOnlyAccounts {
+		service.closeOnlyAccounts[userID] = true
+	}
+	for _, rule := range config.DisabledRules {
+		service.disabledRules[rule] = true
+	}
+	for symbol, requirement := range config.SymbolDepthRequirements {
+		service.symbolDepthRequirements[strings.ToUpper(symbol)] = requirement
+	}
+
+	return service
+}
+
+// NewOrderValidationServiceWithSymbolSuggestions creates a service that suggests close symbols on symbol validation failures
+func NewOrderValidationServiceWithSymbolSuggestions(config OrderValidationConfig, symbolSuggestions SymbolSuggestionService) OrderValidationService {
+	service := NewOrderValidationService(config).(*orderValidationService)
+	service.symbolSuggestions = symbolSuggestions
+	return service
+}
+
+// NewOrderValidationServiceWithTradingCosts creates a service whose fee-inclusive limit checks price
+// fees with the pricing service's fee schedule instead of the flat estimated fee rate
+func NewOrderValidationServiceWithTradingCosts(config OrderValidationConfig, pricingService OrderPricingService, pricingClient IPricingDataClient) OrderValidationService {
+	service := NewOrderValidationService(config).(*orderValidationService)
+	service.pricingService = pricingService
+	service.pricingClient = pricingClient
+	return service
 }
 
 // NewOrderValidationServiceWithDefaults creates a service with default configuration
 func NewOrderValidationServiceWithDefaults() OrderValidationService {
 	return NewOrderValidationService(OrderValidationConfig{
-		MaxOrderValue:         1000000.0, // $1M max order value
-		MaxQuantityPerOrder:   10000.0,   // 10K shares max
-		PriceTolerancePercent: 10.0,      // 10% price tolerance
-		MinOrderValue:         1.0,       // $1 minimum order
+		MaxOrderValue:         1000000.0,                 // $1M max order value
+		MaxQuantityPerOrder:   10000.0,                   // 10K shares max
+		PriceTolerancePercent: 10.0,                      // 10% price tolerance
+		MinOrderValue:         1.0,                       // $1 minimum order
+		EstimatedFeeRate:      0.001,                     // 0.1% estimated trading fees
+		TickSizePolicy:        domain.TickSizePolicyWarn, // Report the price step without enforcing it
+		FailFast:              true,                      // Don't call market data or positions for an order that is already rejected
+
+		DepthRequirement: DepthRequirement{
+			MinOrderQuantity: 1000, // Only large orders move a thin book noticeably
+			WarnCoverage:     1.0,  // Warn when the visible book holds less than the whole order
+			BlockCoverage:    0.2,  // Reject when it holds less than a fifth of it
+		},
+
+		ADVLimit: ADVLimit{
+			LookbackDays: 20,   // About a month of trading days
+			WarnPercent:  5.0,  // Orders above 5% of a day's volume move the price
+			BlockPercent: 25.0, // A quarter of a day's volume cannot be executed without significant impact
+		},
+
+		ExtendedHoursRules: DefaultExtendedHoursRules(),
+
+		SessionNotionalCaps: DefaultSessionNotionalCaps(),
 	})
 }
 
@@ -169,30 +381,107 @@ func (s *orderValidationService) ValidateOrderWithContext(ctx context.Context, o
 		return result, err
 	}
 
+	if s.shouldShortCircuit(result) {
+		return result, nil
+	}
+
 	// Validate symbol and get market data
+	if err := checkValidationContext(ctx, "symbol validation"); err != nil {
+		return result, err
+	}
 	if err := s.validateSymbolStep(ctx, order, marketDataClient, result); err != nil {
 		return result, err
 	}
 
+	// Enforce the symbol's price step before the price is checked against the market
+	if s.isRuleEnabled(OrderValidationRuleTickSize) {
+		s.enforcePriceStep(order, result)
+	}
+
+	// Enforce the symbol's order size limits
+	if s.isRuleEnabled(OrderValidationRuleLotSize) {
+		s.enforceLotSize(order, result)
+	}
+
+	// The remaining steps call out to market data and positions
+	if s.shouldShortCircuit(result) {
+		return result, nil
+	}
+
+	// Check that the visible book can absorb large orders
+	if err := checkValidationContext(ctx, "book depth validation"); err != nil {
+		return result, err
+	}
+	if s.isRuleEnabled(OrderValidationRuleBookDepth) {
+		s.validateBookDepthStep(order, marketDataClient, result)
+	}
+
+	// Compare the order with the volume the symbol usually trades
+	if err := checkValidationContext(ctx, "ADV validation"); err != nil {
+		return result, err
+	}
+	if s.isRuleEnabled(OrderValidationRuleADV) {
+		s.validateADVStep(order, marketDataClient, result)
+	}
+
 	// Validate trading hours
-	s.validateTradingHoursStep(ctx, order, marketDataClient, result)
+	if err := checkValidationContext(ctx, "trading hours validation"); err != nil {
+		return result, err
+	}
+	if s.isRuleEnabled(OrderValidationRuleTradingHours) {
+		s.validateTradingHoursStep(ctx, order, marketDataClient, result)
+	}
 
 	// Validate price if applicable
-	if order.Price() != nil {
+	if err := checkValidationContext(ctx, "price validation"); err != nil {
+		return result, err
+	}
+	if order.Price() != nil && s.isRuleEnabled(OrderValidationRulePriceBand) {
 		s.validatePriceStep(ctx, order, marketDataClient, result)
 	}
 
 	// Validate order side specific rules (especially for sell orders)
+	if err := checkValidationContext(ctx, "order side validation"); err != nil {
+		return result, err
+	}
 	if err := s.validateOrderSideStep(ctx, order, positionClient, result); err != nil {
 		return result, err
 	}
 
 	// Validate risk limits
+	if err := checkValidationContext(ctx, "risk limits validation"); err != nil {
+		return result, err
+	}
 	s.validateRiskLimitsStep(ctx, order, positionClient, result)
 
+	// A client that gave up on the last call leaves a result built from its failure
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("order validation cancelled: %w", err)
+	}
+
 	return result, nil
 }
 
+// checkValidationContext stops the chain before the next step calls out to market data or positions
+// once the caller has cancelled or its deadline has passed
+func checkValidationContext(ctx context.Context, nextStep string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("order validation cancelled before %s: %w", nextStep, err)
+	}
+	return nil
+}
+
+// shouldShortCircuit reports whether fail-fast ordering stops validation after a blocking failure,
+// noting the skipped steps so the caller knows the error list may be incomplete
+func (s *orderValidationService) shouldShortCircuit(result *ValidationResult) bool {
+	if !s.failFast || result.IsValid {
+		return false
+	}
+
+	result.Warnings = append(result.Warnings, "Remaining validation steps were skipped after a blocking failure")
+	return true
+}
+
 // validateSymbolStep handles symbol validation with error handling
 func (s *orderValidationService) validateSymbolStep(ctx context.Context, order *domain.Order, marketDataClient IMarketDataClient, result *ValidationResult) error {
 	symbolResult, err := s.ValidateSymbol(ctx, order.Symbol(), marketDataClient)
@@ -206,9 +495,201 @@ func (s *orderValidationService) validateSymbolStep(ctx context.Context, order *
 	return nil
 }
 
+// enforcePriceStep applies the tick size policy to priced orders: off-tick prices are rejected, or
+// snapped to the nearest valid tick on the passive side with the adjustment recorded on the order
+func (s *orderValidationService) enforcePriceStep(order *domain.Order, result *ValidationResult) {
+	if s.tickSizePolicy == domain.TickSizePolicyWarn || order.Price() == nil {
+		return
+	}
+
+	if result.ValidationContext == nil || result.ValidationContext.MarketData == nil {
+		return
+	}
+
+	priceStep := result.ValidationContext.MarketData.PriceStep
+	if domain.IsOnTick(*order.Price(), priceStep) {
+		return
+	}
+
+	if s.tickSizePolicy == domain.TickSizePolicyReject {
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Order price %.4f is not a multiple of the %.4f price increment for %s",
+			*order.Price(), priceStep, order.Symbol()))
+		return
+	}
+
+	adjustment, err := order.SnapPriceToTick(priceStep)
+	if err != nil {
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Order price could not be rounded to the price increment: %s", err.Error()))
+		return
+	}
+
+	result.Warnings = append(result.Warnings, fmt.Sprintf("Order price adjusted from %.4f to %.4f to match the %.4f price increment for %s",
+		adjustment.OriginalPrice, adjustment.AdjustedPrice, priceStep, order.Symbol()))
+}
+
+// enforceLotSize rejects quantities outside the symbol's minimum and maximum order size
+func (s *orderValidationService) enforceLotSize(order *domain.Order, result *ValidationResult) {
+	if result.ValidationContext == nil || result.ValidationContext.MarketData == nil {
+		return
+	}
+
+	assetDetails := result.ValidationContext.MarketData
+	if assetDetails.MinOrderSize > 0 && order.Quantity() < assetDetails.MinOrderSize {
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Order quantity %.2f is below the minimum order size %.2f for %s",
+			order.Quantity(), assetDetails.MinOrderSize, order.Symbol()))
+	}
+
+	if assetDetails.MaxOrderSize > 0 && order.Quantity() > assetDetails.MaxOrderSize {
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Order quantity %.2f exceeds the maximum order size %.2f for %s",
+			order.Quantity(), assetDetails.MaxOrderSize, order.Symbol()))
+	}
+}
+
+// validateBookDepthStep warns about or rejects large orders that the opposite side of the visible
+// book cannot absorb. Clients that cannot report the book skip the check.
+func (s *orderValidationService) validateBookDepthStep(order *domain.Order, marketDataClient IMarketDataClient, result *ValidationResult) {
+	requirement := s.depthRequirementFor(order.Symbol())
+	if requirement.WarnCoverage <= 0 && requirement.BlockCoverage <= 0 {
+		return
+	}
+	if order.Quantity() < requirement.MinOrderQuantity {
+		return
+	}
+
+	depthClient, ok := marketDataClient.(IOrderBookDepthClient)
+	if !ok {
+		return
+	}
+
+	visibleQuantity, err := visibleOppositeDepth(order, depthClient)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Book depth validation warning: %s", err.Error()))
+		return
+	}
+
+	coverage := visibleQuantity / order.Quantity()
+	switch {
+	case coverage < requirement.BlockCoverage:
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Order quantity %.2f is too large for the visible book: only %.2f is available for %s (%.0f%% of the order, minimum %.0f%%)",
+			order.Quantity(), visibleQuantity, order.Symbol(), coverage*100, requirement.BlockCoverage*100))
+	case coverage < requirement.WarnCoverage:
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Visible book for %s holds %.2f, %.0f%% of the order quantity %.2f; the order may move the price or fill partially",
+			order.Symbol(), visibleQuantity, coverage*100, order.Quantity()))
+	}
+}
+
+func (s *orderValidationService) depthRequirementFor(symbol string) DepthRequirement {
+	if requirement, exists := s.symbolDepthRequirements[strings.ToUpper(symbol)]; exists {
+		return requirement
+	}
+	return s.depthRequirement
+}
+
+// visibleOppositeDepth sums the quantity resting on the side of the book the order trades against,
+// falling back to the aggregated market depth when the book has no levels
+func visibleOppositeDepth(order *domain.Order, depthClient IOrderBookDepthClient) (float64, error) {
+	orderBook, err := depthClient.GetOrderBookData(order.Symbol())
+	if err == nil && orderBook != nil {
+		levels := orderBook.Asks
+		if order.IsSellOrder() {
+			levels = orderBook.Bids
+		}
+		if len(levels) > 0 {
+			total := 0.0
+			for _, level := range levels {
+				total += level.Quantity
+			}
+			return total, nil
+		}
+	}
+
+	marketDepth, depthErr := depthClient.GetMarketDepth(order.Symbol())
+	if depthErr != nil {
+		return 0, fmt.Errorf("failed to get market depth: %w", depthErr)
+	}
+	if marketDepth == nil {
+		return 0, fmt.Errorf("no book depth available for %s", order.Symbol())
+	}
+	if order.IsSellOrder() {
+		return marketDepth.BidDepth, nil
+	}
+	return marketDepth.AskDepth, nil
+}
+
+// validateADVStep warns about or rejects orders that are a large share of the symbol's average
+// daily volume, since they cannot be executed without significant impact
+func (s *orderValidationService) validateADVStep(order *domain.Order, marketDataClient IMarketDataClient, result *ValidationResult) {
+	if s.advLimit.WarnPercent <= 0 && s.advLimit.BlockPercent <= 0 {
+		return
+	}
+
+	volumeClient, ok := marketDataClient.(IHistoricalVolumeClient)
+	if !ok {
+		return
+	}
+
+	adv, err := s.averageDailyVolume(order.Symbol(), volumeClient)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("ADV validation warning: %s", err.Error()))
+		return
+	}
+
+	percentOfADV := order.Quantity() / adv * 100
+	switch {
+	case s.advLimit.BlockPercent > 0 && percentOfADV > s.advLimit.BlockPercent:
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Order quantity %.2f is %.1f%% of the average daily volume of %s (%.0f), above the maximum of %.1f%%",
+			order.Quantity(), percentOfADV, order.Symbol(), adv, s.advLimit.BlockPercent))
+	case s.advLimit.WarnPercent > 0 && percentOfADV > s.advLimit.WarnPercent:
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Order quantity %.2f is %.1f%% of the average daily volume of %s (%.0f); expect significant price impact",
+			order.Quantity(), percentOfADV, order.Symbol(), adv))
+	}
+}
+
+// averageDailyVolume sums the traded volume of each day in the lookback window and averages it
+// over the days that traded
+func (s *orderValidationService) averageDailyVolume(symbol string, volumeClient IHistoricalVolumeClient) (float64, error) {
+	lookbackDays := s.advLimit.LookbackDays
+	if lookbackDays <= 0 {
+		lookbackDays = 20
+	}
+
+	history, err := volumeClient.GetHistoricalPrices(symbol, time.Duration(lookbackDays)*24*time.Hour)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get volume history: %w", err)
+	}
+
+	dailyVolumes := make(map[string]int64)
+	for _, price := range history {
+		if price.Volume <= 0 {
+			continue
+		}
+		dailyVolumes[price.Timestamp.UTC().Format("2006-01-02")] += price.Volume
+	}
+	if len(dailyVolumes) == 0 {
+		return 0, fmt.Errorf("no volume history available for %s", symbol)
+	}
+
+	var total int64
+	for _, volume := range dailyVolumes {
+		total += volume
+	}
+	return float64(total) / float64(len(dailyVolumes)), nil
+}
+
+// isRuleEnabled reports whether the toggleable rule runs in this environment
+func (s *orderValidationService) isRuleEnabled(rule OrderValidationRule) bool {
+	return !s.disabledRules[rule]
+}
+
 // validateTradingHoursStep handles trading hours validation with warning handling
 func (s *orderValidationService) validateTradingHoursStep(ctx context.Context, order *domain.Order, marketDataClient IMarketDataClient, result *ValidationResult) {
-	tradingResult, err := s.ValidateTradingHours(ctx, order.Symbol(), marketDataClient)
+	tradingResult, err := s.validateTradingSession(ctx, order.Symbol(), order, marketDataClient)
 	if err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("Trading hours validation warning: %s", err.Error()))
 		return
@@ -289,11 +770,32 @@ func (s *orderValidationService) validateSymbolExistence(ctx context.Context, sy
 	if !isValid {
 		result.IsValid = false
 		result.Errors = append(result.Errors, fmt.Sprintf("Symbol '%s' is not valid or not tradeable", symbol))
+		s.addSymbolSuggestions(ctx, symbol, result)
 	}
 
 	return nil
 }
 
+// addSymbolSuggestions attaches close valid symbols to a failed symbol validation
+func (s *orderValidationService) addSymbolSuggestions(ctx context.Context, symbol string, result *ValidationResult) {
+	if s.symbolSuggestions == nil {
+		return
+	}
+
+	suggestions, err := s.symbolSuggestions.SuggestSymbols(ctx, symbol)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Could not retrieve symbol suggestions: %s", err.Error()))
+		return
+	}
+
+	if len(suggestions) == 0 {
+		return
+	}
+
+	result.SymbolSuggestions = suggestions
+	result.Errors = append(result.Errors, fmt.Sprintf("Did you mean: %s?", strings.Join(suggestions, ", ")))
+}
+
 // validateAssetDetails gets asset details and validates them
 func (s *orderValidationService) validateAssetDetails(ctx context.Context, symbol string, marketDataClient IMarketDataClient, result *ValidationResult) {
 	assetDetails, err := marketDataClient.GetAssetDetails(ctx, symbol)
@@ -397,6 +899,9 @@ func (s *orderValidationService) ValidatePrice(ctx context.Context, order *domai
 	if err != nil {
 		return result, fmt.Errorf("failed to get current price: %w", err)
 	}
+	if currentPrice <= 0 {
+		return result, fmt.Errorf("cannot validate price range against current price %.4f: %w", currentPrice, ErrZeroDenominator)
+	}
 
 	// Validate order against current market price
 	if err := order.ValidateForExecution(currentPrice); err != nil {
@@ -439,6 +944,12 @@ func (s *orderValidationService) ValidatePrice(ctx context.Context, order *domai
 
 // ValidateTradingHours validates if trading is allowed at current time
 func (s *orderValidationService) ValidateTradingHours(ctx context.Context, symbol string, marketDataClient IMarketDataClient) (*ValidationResult, error) {
+	return s.validateTradingSession(ctx, symbol, nil, marketDataClient)
+}
+
+// validateTradingSession checks the market hours for the symbol and, when an order is given, the
+// order types and order values the current session accepts
+func (s *orderValidationService) validateTradingSession(ctx context.Context, symbol string, order *domain.Order, marketDataClient IMarketDataClient) (*ValidationResult, error) {
 	result := &ValidationResult{
 		IsValid:  true,
 		Errors:   make([]string, 0),
@@ -453,18 +964,36 @@ func (s *orderValidationService) ValidateTradingHours(ctx context.Context, symbo
 		return result, fmt.Errorf("failed to check market hours: %w", err)
 	}
 
-	if !isOpen {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Market is currently closed for symbol '%s'", symbol))
-	}
-
 	// Get detailed trading hours for additional context
 	tradingHours, err := marketDataClient.GetTradingHours(ctx, symbol)
 	if err != nil {
+		if !isOpen {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Market is currently closed for symbol '%s'", symbol))
+		}
 		result.Warnings = append(result.Warnings, "Could not retrieve detailed trading hours")
-	} else {
-		if !tradingHours.IsOpen {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("Market closed. Next open: %s", tradingHours.NextOpenTime.Format("2006-01-02 15:04:05 MST")))
+		return result, nil
+	}
+
+	now := time.Now()
+	if order != nil {
+		s.validateSessionNotionalCap(order, tradingHours, now, result)
+	}
+
+	session := tradingHours.SessionAt(now)
+	if session.IsExtended() {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Symbol '%s' is trading in the %s session with reduced liquidity", symbol, session))
+		if order != nil && order.OrderType() == domain.OrderTypeMarket && s.extendedHoursRules.LimitOnly {
+			result.IsValid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("Only limit orders are accepted during the %s session", session))
 		}
+		return result, nil
+	}
+
+	if !isOpen {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Market is currently closed for symbol '%s'", symbol))
+	}
+	if !tradingHours.IsOpen {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Market closed. Next open: %s", tradingHours.NextOpenTime.Format("2006-01-02 15:04:05 MST")))
 	}
 
 	return result, nil
@@ -482,6 +1011,10 @@ func (s *orderValidationService) ValidateOrderSide(ctx context.Context, order *d
 		},
 	}
 
+	if reason, closeOnly := s.closeOnlyReason(order); closeOnly {
+		return s.validateCloseOnlyOrderSide(order, positionClient, reason, result)
+	}
+
 	if order.IsBuyOrder() {
 		return s.validateBuyOrderSide(ctx, order, positionClient, result)
 	}
@@ -500,14 +1033,54 @@ func (s *orderValidationService) validateBuyOrderSide(ctx context.Context, order
 		return result, nil
 	}
 
-	hasSufficientBalance, err := positionClient.HasSufficientBalance(order.UserID(), orderValue)
+	if balanceClient, ok := positionClient.(IBalanceDetailsClient); ok {
+		return s.validateBuyOrderBalance(order, orderValue, balanceClient, result)
+	}
+
+	required, estimatedFees := s.limitCheckValue(order)
+	hasSufficientBalance, err := positionClient.HasSufficientBalance(order.UserID(), required)
 	if err != nil {
 		return result, fmt.Errorf("failed to check balance: %w", err)
 	}
 
 	if !hasSufficientBalance {
 		result.IsValid = false
-		result.Errors = append(result.Errors, fmt.Sprintf("Insufficient balance for order value %.2f", orderValue))
+		if estimatedFees > 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("Insufficient balance for order value %.2f plus %.2f estimated fees", orderValue, estimatedFees))
+		} else {
+			result.Errors = append(result.Errors, fmt.Sprintf("Insufficient balance for order value %.2f", orderValue))
+		}
+	}
+
+	return result, nil
+}
+
+// validateBuyOrderBalance checks the order value plus estimated fees against the balance not held by
+// other orders, reporting the shortfall when the user cannot cover it
+func (s *orderValidationService) validateBuyOrderBalance(order *domain.Order, orderValue float64, balanceClient IBalanceDetailsClient, result *ValidationResult) (*ValidationResult, error) {
+	balance, err := balanceClient.GetBalanceDetails(order.UserID())
+	if err != nil {
+		return result, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	estimatedFees := orderValue * s.estimatedFeeRate
+	if s.includeFeesInLimitChecks {
+		estimatedFees = s.estimatedFees(order, orderValue)
+	}
+	required := orderValue + estimatedFees
+	spendable := balance.AvailableBalance - balance.HeldBalance
+
+	if result.ValidationContext != nil {
+		result.ValidationContext.AvailableBalance = &spendable
+	}
+
+	shortfall := required - spendable
+	if shortfall > 0 {
+		result.IsValid = false
+		result.BalanceShortfall = &shortfall
+		result.Errors = append(result.Errors, fmt.Sprintf(
+			"Insufficient balance for order value %.2f: you need %.2f more (required %.2f including %.2f fees, available %.2f after %.2f held)",
+			orderValue, shortfall, required, estimatedFees, spendable, balance.HeldBalance))
 	}
 
 	return result, nil
@@ -521,6 +1094,67 @@ func (s *orderValidationService) validateSellOrderSide(ctx context.Context, resu
 	return result, nil
 }
 
+// SetSymbolCloseOnly toggles close-only mode for a symbol
+func (s *orderValidationService) SetSymbolCloseOnly(symbol string, enabled bool) {
+	s.closeOnlyMu.Lock()
+	defer s.closeOnlyMu.Unlock()
+
+	if enabled {
+		s.closeOnlySymbols[strings.ToUpper(symbol)] = true
+		return
+	}
+	delete(s.closeOnlySymbols, strings.ToUpper(symbol))
+}
+
+// SetAccountCloseOnly toggles close-only mode for an account
+func (s *orderValidationService) SetAccountCloseOnly(userID string, enabled bool) {
+	s.closeOnlyMu.Lock()
+	defer s.closeOnlyMu.Unlock()
+
+	if enabled {
+		s.closeOnlyAccounts[userID] = true
+		return
+	}
+	delete(s.closeOnlyAccounts, userID)
+}
+
+// closeOnlyReason reports whether close-only mode applies to the order and why
+func (s *orderValidationService) closeOnlyReason(order *domain.Order) (string, bool) {
+	s.closeOnlyMu.RLock()
+	defer s.closeOnlyMu.RUnlock()
+
+	if s.closeOnlyAccounts[order.UserID()] {
+		return "account is in close-only mode", true
+	}
+	if s.closeOnlySymbols[strings.ToUpper(order.Symbol())] {
+		return fmt.Sprintf("%s is in close-only mode", order.Symbol()), true
+	}
+	return "", false
+}
+
+// validateCloseOnlyOrderSide only accepts orders that reduce an existing position.
+// Buys always open or increase a position; sells must not exceed the quantity held.
+func (s *orderValidationService) validateCloseOnlyOrderSide(order *domain.Order, positionClient IPositionClient, reason string, result *ValidationResult) (*ValidationResult, error) {
+	if order.IsBuyOrder() {
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: only position-reducing orders are accepted", reason))
+		return result, nil
+	}
+
+	availableQuantity, err := positionClient.GetAvailableQuantity(order.UserID(), order.Symbol())
+	if err != nil {
+		return result, fmt.Errorf("failed to get available quantity: %w", err)
+	}
+	result.ValidationContext.AvailableQuantity = &availableQuantity
+
+	if order.Quantity() > availableQuantity {
+		result.IsValid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: sell quantity %.2f exceeds position of %.2f", reason, order.Quantity(), availableQuantity))
+	}
+
+	return result, nil
+}
+
 // ValidateRiskLimits validates order against risk management rules
 func (s *orderValidationService) ValidateRiskLimits(ctx context.Context, order *domain.Order, positionClient IPositionClient) (*ValidationResult, error) {
 	result := &ValidationResult{
@@ -535,11 +1169,7 @@ func (s *orderValidationService) ValidateRiskLimits(ctx context.Context, order *
 
 	// Check order value limits
 	orderValue := order.CalculateOrderValue()
-
-	if orderValue > s.maxOrderValue {
-		result.IsValid = false
-		result.Errors = append(result.Errors, fmt.Sprintf("Order value %.2f exceeds maximum allowed %.2f", orderValue, s.maxOrderValue))
-	}
+	s.validateMaxOrderValue(order, result)
 
 	if orderValue > 0 && orderValue < s.minOrderValue {
 		result.IsValid = false
@@ -558,11 +1188,7 @@ func (s *orderValidationService) ValidateRiskLimits(ctx context.Context, order *
 
 func (s *orderValidationService) validateOrderValueLimits(order *domain.Order, result *ValidationResult) {
 	orderValue := order.CalculateOrderValue()
-
-	if orderValue > s.maxOrderValue {
-		result.IsValid = false
-		result.Errors = append(result.Errors, fmt.Sprintf("Order value %.2f exceeds maximum allowed %.2f", orderValue, s.maxOrderValue))
-	}
+	s.validateMaxOrderValue(order, result)
 
 	if orderValue > 0 && orderValue < s.minOrderValue {
 		result.IsValid = false
@@ -570,6 +1196,44 @@ func (s *orderValidationService) validateOrderValueLimits(order *domain.Order, r
 	}
 }
 
+func (s *orderValidationService) validateMaxOrderValue(order *domain.Order, result *ValidationResult) {
+	checkedValue, estimatedFees := s.limitCheckValue(order)
+	if checkedValue <= s.maxOrderValue {
+		return
+	}
+
+	result.IsValid = false
+	if estimatedFees > 0 {
+		result.Errors = append(result.Errors, fmt.Sprintf("Order value %.2f plus %.2f estimated fees exceeds maximum allowed %.2f",
+			order.CalculateOrderValue(), estimatedFees, s.maxOrderValue))
+		return
+	}
+	result.Errors = append(result.Errors, fmt.Sprintf("Order value %.2f exceeds maximum allowed %.2f", checkedValue, s.maxOrderValue))
+}
+
+// limitCheckValue returns the value checked against the balance and the maximum order value, and the
+// estimated fees it includes. Only buys carry fees, and only when fees are included in limit checks.
+func (s *orderValidationService) limitCheckValue(order *domain.Order) (float64, float64) {
+	orderValue := order.CalculateOrderValue()
+	if !s.includeFeesInLimitChecks || !order.IsBuyOrder() || orderValue <= 0 {
+		return orderValue, 0
+	}
+
+	estimatedFees := s.estimatedFees(order, orderValue)
+	return orderValue + estimatedFees, estimatedFees
+}
+
+// estimatedFees prices the order's fees with the pricing service's trading costs, falling back to the
+// flat estimated fee rate when no pricing service is wired or it cannot price the order
+func (s *orderValidationService) estimatedFees(order *domain.Order, orderValue float64) float64 {
+	if s.pricingService != nil && s.pricingClient != nil {
+		if fees, err := s.pricingService.CalculateTradingCosts(order, s.pricingClient); err == nil && fees != nil {
+			return fees.TotalFees
+		}
+	}
+	return orderValue * s.estimatedFeeRate
+}
+
 func (s *orderValidationService) validateQuantityLimits(order *domain.Order, result *ValidationResult) {
 	if order.Quantity() > s.maxQuantityPerOrder {
 		result.IsValid = false
@@ -605,6 +1269,10 @@ func (s *orderValidationService) mergeValidationResults(target *ValidationResult
 	}
 	target.Errors = append(target.Errors, source.Errors...)
 	target.Warnings = append(target.Warnings, source.Warnings...)
+	target.SymbolSuggestions = append(target.SymbolSuggestions, source.SymbolSuggestions...)
+	if source.BalanceShortfall != nil {
+		target.BalanceShortfall = source.BalanceShortfall
+	}
 
 	// Merge validation context if source has market data
 	if source.ValidationContext == nil {
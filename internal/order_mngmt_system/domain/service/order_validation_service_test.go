@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -63,6 +64,53 @@ func (m *MockPositionClient) HasSufficientBalance(userID string, requiredAmount
 	return args.Bool(0), args.Error(1)
 }
 
+// MockBalanceDetailsPositionClient is a position client that also reports balance details
+type MockBalanceDetailsPositionClient struct {
+	MockPositionClient
+}
+
+func (m *MockBalanceDetailsPositionClient) GetBalanceDetails(userID string) (*BalanceDetails, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*BalanceDetails), args.Error(1)
+}
+
+// MockDepthMarketDataClient is a market data client that also reports the visible order book
+type MockDepthMarketDataClient struct {
+	MockMarketDataClient
+}
+
+func (m *MockDepthMarketDataClient) GetOrderBookData(symbol string) (*OrderBookData, error) {
+	args := m.Called(symbol)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*OrderBookData), args.Error(1)
+}
+
+func (m *MockDepthMarketDataClient) GetMarketDepth(symbol string) (*MarketDepth, error) {
+	args := m.Called(symbol)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MarketDepth), args.Error(1)
+}
+
+// MockVolumeMarketDataClient is a market data client that also reports traded volume history
+type MockVolumeMarketDataClient struct {
+	MockMarketDataClient
+}
+
+func (m *MockVolumeMarketDataClient) GetHistoricalPrices(symbol string, period time.Duration) ([]HistoricalPrice, error) {
+	args := m.Called(symbol, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]HistoricalPrice), args.Error(1)
+}
+
 func TestNewOrderValidationService(t *testing.T) {
 	config := OrderValidationConfig{
 		MaxOrderValue:         100,
@@ -147,6 +195,49 @@ func TestOrderValidationService_ValidateSymbol_Invalid(t *testing.T) {
 	assert.False(t, result.IsValid)
 }
 
+// MockSymbolDirectory is a mock for ISymbolDirectory
+type MockSymbolDirectory struct {
+	mock.Mock
+}
+
+func (m *MockSymbolDirectory) GetSearchableSymbols(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestOrderValidationService_ValidateSymbol_SuggestsNearMiss(t *testing.T) {
+	directory := new(MockSymbolDirectory)
+	directory.On("GetSearchableSymbols", mock.Anything).Return([]string{"AAPL", "AMZN", "MSFT", "PETR4"}, nil)
+	service := NewOrderValidationServiceWithSymbolSuggestions(OrderValidationConfig{}, NewSymbolSuggestionServiceWithDefaults(directory))
+	marketDataClient := new(MockMarketDataClient)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "APPL").Return(false, nil)
+
+	result, err := service.ValidateSymbol(context.Background(), "APPL", marketDataClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Equal(t, []string{"AAPL"}, result.SymbolSuggestions)
+	assert.Contains(t, result.Errors, "Did you mean: AAPL?")
+}
+
+func TestOrderValidationService_ValidateSymbol_ExactUntradeableNoSuggestions(t *testing.T) {
+	directory := new(MockSymbolDirectory)
+	directory.On("GetSearchableSymbols", mock.Anything).Return([]string{"AAPL", "AAPB", "MSFT"}, nil)
+	service := NewOrderValidationServiceWithSymbolSuggestions(OrderValidationConfig{}, NewSymbolSuggestionServiceWithDefaults(directory))
+	marketDataClient := new(MockMarketDataClient)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "AAPL").Return(false, nil)
+
+	result, err := service.ValidateSymbol(context.Background(), "AAPL", marketDataClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Empty(t, result.SymbolSuggestions)
+	assert.Len(t, result.Errors, 1)
+}
+
 func TestOrderValidationService_ValidateQuantity(t *testing.T) {
 	service := NewOrderValidationServiceWithDefaults()
 	positionClient := new(MockPositionClient)
@@ -261,6 +352,54 @@ func TestOrderValidationService_ValidateOrderSide_Sell(t *testing.T) {
 	assert.Contains(t, result.Warnings, "Sell order - ensure you want to reduce your position")
 }
 
+func TestOrderValidationService_ValidateOrderSide_CloseOnlyRejectsBuy(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{CloseOnlySymbols: []string{"PETR4"}})
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	result, err := service.ValidateOrderSide(context.Background(), order, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "PETR4 is in close-only mode: only position-reducing orders are accepted")
+	positionClient.AssertNotCalled(t, "HasSufficientBalance", mock.Anything, mock.Anything)
+}
+
+func TestOrderValidationService_ValidateOrderSide_CloseOnlyAllowsReducingSell(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	service.SetAccountCloseOnly("user1", true)
+	positionClient := new(MockPositionClient)
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideSell, domain.OrderTypeMarket, 10, nil)
+
+	positionClient.On("GetAvailableQuantity", "user1", "PETR4").Return(50.0, nil)
+
+	result, err := service.ValidateOrderSide(context.Background(), order, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Empty(t, result.Errors)
+}
+
+func TestOrderValidationService_ValidateOrderSide_CloseOnlyRejectsBuyWithoutPosition(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	service.SetSymbolCloseOnly("vale3", true)
+	positionClient := new(MockPositionClient)
+	price := 60.0
+	order, _ := domain.NewOrder("user1", "VALE3", domain.OrderSideBuy, domain.OrderTypeLimit, 5, &price)
+
+	positionClient.On("GetAvailableQuantity", "user1", "VALE3").Return(0.0, nil)
+
+	result, err := service.ValidateOrderSide(context.Background(), order, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+
+	service.SetSymbolCloseOnly("VALE3", false)
+	positionClient.On("HasSufficientBalance", "user1", 300.0).Return(true, nil)
+
+	result, err = service.ValidateOrderSide(context.Background(), order, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+}
+
 func TestOrderValidationService_ValidateRiskLimits_TooHigh(t *testing.T) {
 	service := NewOrderValidationServiceWithDefaults()
 	positionClient := new(MockPositionClient)
@@ -373,6 +512,64 @@ func TestOrderValidationService_ValidateOrderSide_InsufficientBalance(t *testing
 	assert.False(t, result.IsValid)
 }
 
+func TestOrderValidationService_ValidateOrderSide_BalanceShortfall(t *testing.T) {
+	tests := []struct {
+		name              string
+		feeRate           float64
+		balance           *BalanceDetails
+		expectedValid     bool
+		expectedShortfall float64
+	}{
+		{
+			name:              "shortfall includes fees",
+			feeRate:           0.01,
+			balance:           &BalanceDetails{AvailableBalance: 1000.0},
+			expectedValid:     false,
+			expectedShortfall: 10.0,
+		},
+		{
+			name:              "holds reduce the spendable balance",
+			feeRate:           0.01,
+			balance:           &BalanceDetails{AvailableBalance: 1200.0, HeldBalance: 500.0},
+			expectedValid:     false,
+			expectedShortfall: 310.0,
+		},
+		{
+			name:          "balance covers order, fees and holds",
+			feeRate:       0.01,
+			balance:       &BalanceDetails{AvailableBalance: 1600.0, HeldBalance: 500.0},
+			expectedValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderValidationService(OrderValidationConfig{EstimatedFeeRate: tt.feeRate})
+			positionClient := new(MockBalanceDetailsPositionClient)
+			price := 100.0
+			order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+			positionClient.On("GetBalanceDetails", "user1").Return(tt.balance, nil)
+
+			result, err := service.ValidateOrderSide(context.Background(), order, positionClient)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedValid, result.IsValid)
+			positionClient.AssertNotCalled(t, "HasSufficientBalance", mock.Anything, mock.Anything)
+
+			if tt.expectedValid {
+				assert.Nil(t, result.BalanceShortfall)
+				assert.Empty(t, result.Errors)
+				return
+			}
+
+			assert.NotNil(t, result.BalanceShortfall)
+			assert.InDelta(t, tt.expectedShortfall, *result.BalanceShortfall, 0.0001)
+			assert.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0], fmt.Sprintf("you need %.2f more", tt.expectedShortfall))
+		})
+	}
+}
+
 func TestOrderValidationService_validateQuantityLimits(t *testing.T) {
 	service := NewOrderValidationServiceWithDefaults()
 	order := domain.NewOrderFromRepository("id", "user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 0, nil, domain.OrderStatusPending, time.Now(), time.Now(), nil, nil, nil, nil)
@@ -380,3 +577,667 @@ func TestOrderValidationService_validateQuantityLimits(t *testing.T) {
 	service.(*orderValidationService).validateQuantityLimits(order, result)
 	assert.False(t, result.IsValid)
 }
+
+func newTickSizeTestClients(priceStep float64) (*MockMarketDataClient, *MockPositionClient) {
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true, PriceStep: priceStep}, nil)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+	positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+	return marketDataClient, positionClient
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_RejectsOffTickPrice(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{
+		MaxOrderValue:         1000000,
+		MaxQuantityPerOrder:   10000,
+		PriceTolerancePercent: 10,
+		MinOrderValue:         1,
+		TickSizePolicy:        domain.TickSizePolicyReject,
+	})
+	marketDataClient, positionClient := newTickSizeTestClients(0.05)
+	price := 10.03
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "Order price 10.0300 is not a multiple of the 0.0500 price increment for PETR4")
+	assert.Equal(t, 10.03, *order.Price())
+	assert.Nil(t, order.PriceTickAdjustment())
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_AcceptsOnTickPrice(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{
+		MaxOrderValue:         1000000,
+		MaxQuantityPerOrder:   10000,
+		PriceTolerancePercent: 10,
+		MinOrderValue:         1,
+		TickSizePolicy:        domain.TickSizePolicyReject,
+	})
+	marketDataClient, positionClient := newTickSizeTestClients(0.05)
+	price := 10.15
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Nil(t, order.PriceTickAdjustment())
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_RoundsOffTickPriceToPassiveSide(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{
+		MaxOrderValue:         1000000,
+		MaxQuantityPerOrder:   10000,
+		PriceTolerancePercent: 10,
+		MinOrderValue:         1,
+		TickSizePolicy:        domain.TickSizePolicyRound,
+	})
+
+	tests := []struct {
+		name          string
+		side          domain.OrderSide
+		price         float64
+		expectedPrice float64
+	}{
+		{name: "buy rounds down", side: domain.OrderSideBuy, price: 10.03, expectedPrice: 10.00},
+		{name: "sell rounds up", side: domain.OrderSideSell, price: 10.03, expectedPrice: 10.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marketDataClient, positionClient := newTickSizeTestClients(0.05)
+			price := tt.price
+			order, _ := domain.NewOrder("user1", "PETR4", tt.side, domain.OrderTypeLimit, 10, &price)
+
+			result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+			assert.NoError(t, err)
+			assert.True(t, result.IsValid)
+			assert.Equal(t, tt.expectedPrice, *order.Price())
+			assert.Equal(t, tt.price, price, "the caller's price should not be modified")
+
+			adjustment := order.PriceTickAdjustment()
+			if assert.NotNil(t, adjustment) {
+				assert.Equal(t, tt.price, adjustment.OriginalPrice)
+				assert.Equal(t, tt.expectedPrice, adjustment.AdjustedPrice)
+				assert.Equal(t, 0.05, adjustment.PriceStep)
+			}
+			assert.Contains(t, result.Warnings, fmt.Sprintf("Order price adjusted from %.4f to %.4f to match the 0.0500 price increment for PETR4", tt.price, tt.expectedPrice))
+		})
+	}
+}
+
+func TestParseOrderValidationRules(t *testing.T) {
+	rules, err := ParseOrderValidationRules(" tick_size, TRADING_HOURS ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []OrderValidationRule{OrderValidationRuleTickSize, OrderValidationRuleTradingHours}, rules)
+
+	rules, err = ParseOrderValidationRules("")
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = ParseOrderValidationRules("TICK_SIZE,CIRCUIT_BREAKER")
+	assert.Error(t, err)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_RuleToggles(t *testing.T) {
+	tests := []struct {
+		name          string
+		rule          OrderValidationRule
+		price         float64
+		quantity      float64
+		assetDetails  AssetDetails
+		expectedError string
+	}{
+		{
+			name:          "off-tick price",
+			rule:          OrderValidationRuleTickSize,
+			price:         10.03,
+			quantity:      10,
+			assetDetails:  AssetDetails{IsActive: true, IsTradeable: true, PriceStep: 0.05},
+			expectedError: "Order price 10.0300 is not a multiple of the 0.0500 price increment for PETR4",
+		},
+		{
+			name:          "quantity below minimum order size",
+			rule:          OrderValidationRuleLotSize,
+			price:         10,
+			quantity:      10,
+			assetDetails:  AssetDetails{IsActive: true, IsTradeable: true, MinOrderSize: 100},
+			expectedError: "Order quantity 10.00 is below the minimum order size 100.00 for PETR4",
+		},
+		{
+			name:          "price far from the market",
+			rule:          OrderValidationRulePriceBand,
+			price:         16,
+			quantity:      10,
+			assetDetails:  AssetDetails{IsActive: true, IsTradeable: true},
+			expectedError: "Order price 16.00 deviates extremely from market price 10.00 by 60.0% (max allowed: 50.0%)",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, disabled := range []bool{false, true} {
+			name := tt.name + " with rule enabled"
+			config := OrderValidationConfig{
+				MaxOrderValue:         1000000,
+				MaxQuantityPerOrder:   10000,
+				PriceTolerancePercent: 10,
+				MinOrderValue:         1,
+				TickSizePolicy:        domain.TickSizePolicyReject,
+			}
+			if disabled {
+				name = tt.name + " with rule disabled"
+				config.DisabledRules = []OrderValidationRule{tt.rule}
+			}
+
+			t.Run(name, func(t *testing.T) {
+				service := NewOrderValidationService(config)
+				marketDataClient := new(MockMarketDataClient)
+				positionClient := new(MockPositionClient)
+				assetDetails := tt.assetDetails
+				price := tt.price
+				order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, tt.quantity, &price)
+
+				marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+				marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&assetDetails, nil)
+				marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+				marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+				marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+				positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+				result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+				assert.NoError(t, err)
+				if disabled {
+					assert.True(t, result.IsValid, "unexpected errors: %v", result.Errors)
+					assert.NotContains(t, result.Errors, tt.expectedError)
+				} else {
+					assert.False(t, result.IsValid)
+					assert.Contains(t, result.Errors, tt.expectedError)
+				}
+			})
+		}
+	}
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_TradingHoursRuleDisabled(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{
+		MaxOrderValue:         1000000,
+		MaxQuantityPerOrder:   10000,
+		PriceTolerancePercent: 10,
+		MinOrderValue:         1,
+		DisabledRules:         []OrderValidationRule{OrderValidationRuleTradingHours},
+	})
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+	positionClient.On("HasSufficientBalance", "user1", 100.0).Return(true, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.NotContains(t, result.Warnings, "Market is currently closed for symbol 'PETR4'")
+	marketDataClient.AssertNotCalled(t, "IsMarketOpen", mock.Anything, "PETR4")
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_FailFastSkipsExternalCallsForInvalidSymbol(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "XXXX9", domain.OrderSideSell, domain.OrderTypeLimit, 10, &price)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "XXXX9").Return(false, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "Symbol 'XXXX9' is not valid or not tradeable")
+	assert.Contains(t, result.Warnings, "Remaining validation steps were skipped after a blocking failure")
+	marketDataClient.AssertNotCalled(t, "GetAssetDetails", mock.Anything, mock.Anything)
+	marketDataClient.AssertNotCalled(t, "IsMarketOpen", mock.Anything, mock.Anything)
+	marketDataClient.AssertNotCalled(t, "GetCurrentPrice", mock.Anything, mock.Anything)
+	positionClient.AssertNotCalled(t, "GetAvailableQuantity", mock.Anything, mock.Anything)
+	positionClient.AssertNotCalled(t, "HasSufficientBalance", mock.Anything, mock.Anything)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_FailFastSkipsSymbolLookupForInvalidDomain(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	order := domain.NewOrderFromRepository("id", "", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil, domain.OrderStatusPending, time.Now(), time.Now(), nil, nil, nil, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	marketDataClient.AssertNotCalled(t, "ValidateSymbol", mock.Anything, mock.Anything)
+	positionClient.AssertNotCalled(t, "HasSufficientBalance", mock.Anything, mock.Anything)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_FailFastRunsFullChainForValidOrder(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+	positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.NotContains(t, result.Warnings, "Remaining validation steps were skipped after a blocking failure")
+	marketDataClient.AssertExpectations(t)
+	positionClient.AssertExpectations(t)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_WithoutFailFastRunsAllSteps(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{
+		MaxOrderValue:         1000000,
+		MaxQuantityPerOrder:   10000,
+		PriceTolerancePercent: 10,
+		MinOrderValue:         1,
+	})
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "XXXX9", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "XXXX9").Return(false, nil)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "XXXX9").Return(true, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "XXXX9").Return(&TradingHours{IsOpen: true}, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "XXXX9").Return(10.0, nil)
+	positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	marketDataClient.AssertCalled(t, "IsMarketOpen", mock.Anything, "XXXX9")
+	positionClient.AssertCalled(t, "HasSufficientBalance", "user1", mock.Anything)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_CancelledMidChainStopsExternalCalls(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The caller gives up while the symbol is being looked up
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil).Run(func(args mock.Arguments) { cancel() })
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+
+	_, err := service.ValidateOrderWithContext(ctx, order, marketDataClient, positionClient)
+	assert.ErrorIs(t, err, context.Canceled)
+	marketDataClient.AssertNotCalled(t, "IsMarketOpen", mock.Anything, mock.Anything)
+	marketDataClient.AssertNotCalled(t, "GetTradingHours", mock.Anything, mock.Anything)
+	marketDataClient.AssertNotCalled(t, "GetCurrentPrice", mock.Anything, mock.Anything)
+	positionClient.AssertNotCalled(t, "HasSufficientBalance", mock.Anything, mock.Anything)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_ExpiredDeadlineMakesNoExternalCalls(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := service.ValidateOrderWithContext(ctx, order, marketDataClient, positionClient)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	marketDataClient.AssertNotCalled(t, "ValidateSymbol", mock.Anything, mock.Anything)
+	positionClient.AssertNotCalled(t, "HasSufficientBalance", mock.Anything, mock.Anything)
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_CancelledDuringBalanceLookupReturnsContextError(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+	positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(false, context.Canceled).Run(func(args mock.Arguments) { cancel() })
+
+	_, err := service.ValidateOrderWithContext(ctx, order, marketDataClient, positionClient)
+	assert.ErrorIs(t, err, context.Canceled, "a result built from the aborted balance lookup is not reported as a validation outcome")
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_BookDepth(t *testing.T) {
+	tests := []struct {
+		name            string
+		quantity        float64
+		orderBook       *OrderBookData
+		marketDepth     *MarketDepth
+		symbolOverrides map[string]DepthRequirement
+		expectValid     bool
+		expectWarning   string
+		expectError     string
+	}{
+		{
+			name:        "deep book accepts a large order",
+			quantity:    5000,
+			orderBook:   &OrderBookData{Asks: []PriceLevel{{Price: 10.0, Quantity: 3000}, {Price: 10.1, Quantity: 4000}}},
+			expectValid: true,
+		},
+		{
+			name:        "thin book rejects a large order",
+			quantity:    5000,
+			orderBook:   &OrderBookData{Asks: []PriceLevel{{Price: 10.0, Quantity: 300}, {Price: 10.1, Quantity: 200}}},
+			expectValid: false,
+			expectError: "Order quantity 5000.00 is too large for the visible book: only 500.00 is available for PETR4 (10% of the order, minimum 20%)",
+		},
+		{
+			name:          "partly covered large order is warned",
+			quantity:      5000,
+			orderBook:     &OrderBookData{Asks: []PriceLevel{{Price: 10.0, Quantity: 2500}}},
+			expectValid:   true,
+			expectWarning: "Visible book for PETR4 holds 2500.00, 50% of the order quantity 5000.00; the order may move the price or fill partially",
+		},
+		{
+			name:        "small order skips the check",
+			quantity:    100,
+			orderBook:   &OrderBookData{Asks: []PriceLevel{{Price: 10.0, Quantity: 1}}},
+			expectValid: true,
+		},
+		{
+			name:            "symbol requirement overrides the default",
+			quantity:        5000,
+			orderBook:       &OrderBookData{Asks: []PriceLevel{{Price: 10.0, Quantity: 2500}}},
+			symbolOverrides: map[string]DepthRequirement{"petr4": {MinOrderQuantity: 1000, WarnCoverage: 1.0, BlockCoverage: 0.8}},
+			expectValid:     false,
+			expectError:     "Order quantity 5000.00 is too large for the visible book: only 2500.00 is available for PETR4 (50% of the order, minimum 80%)",
+		},
+		{
+			name:        "market depth is used when the book has no levels",
+			quantity:    5000,
+			orderBook:   &OrderBookData{},
+			marketDepth: &MarketDepth{AskDepth: 400, BidDepth: 90000},
+			expectValid: false,
+			expectError: "Order quantity 5000.00 is too large for the visible book: only 400.00 is available for PETR4 (8% of the order, minimum 20%)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderValidationService(OrderValidationConfig{
+				MaxOrderValue:           1000000,
+				MaxQuantityPerOrder:     10000,
+				PriceTolerancePercent:   10,
+				MinOrderValue:           1,
+				DepthRequirement:        DepthRequirement{MinOrderQuantity: 1000, WarnCoverage: 1.0, BlockCoverage: 0.2},
+				SymbolDepthRequirements: tt.symbolOverrides,
+			})
+			marketDataClient := new(MockDepthMarketDataClient)
+			positionClient := new(MockPositionClient)
+			price := 10.0
+			order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, tt.quantity, &price)
+
+			marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+			marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+			marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+			marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+			marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+			marketDataClient.On("GetOrderBookData", "PETR4").Return(tt.orderBook, nil)
+			if tt.marketDepth != nil {
+				marketDataClient.On("GetMarketDepth", "PETR4").Return(tt.marketDepth, nil)
+			}
+			positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+			result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectValid, result.IsValid, "errors: %v", result.Errors)
+			if tt.expectError != "" {
+				assert.Contains(t, result.Errors, tt.expectError)
+			}
+			if tt.expectWarning != "" {
+				assert.Contains(t, result.Warnings, tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_BookDepthRuleDisabled(t *testing.T) {
+	service := NewOrderValidationService(OrderValidationConfig{
+		MaxOrderValue:         1000000,
+		MaxQuantityPerOrder:   10000,
+		PriceTolerancePercent: 10,
+		MinOrderValue:         1,
+		DepthRequirement:      DepthRequirement{MinOrderQuantity: 1000, WarnCoverage: 1.0, BlockCoverage: 0.2},
+		DisabledRules:         []OrderValidationRule{OrderValidationRuleBookDepth},
+	})
+	marketDataClient := new(MockDepthMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 5000, &price)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+	positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid, "unexpected errors: %v", result.Errors)
+	marketDataClient.AssertNotCalled(t, "GetOrderBookData", "PETR4")
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_ExtendedHoursLimitOnly(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	now := time.Now()
+	preMarket := &TradingHours{
+		ExtendedHours: true,
+		PreMarketOpen: now.Add(-time.Hour),
+		MarketOpen:    now.Add(time.Hour),
+		MarketClose:   now.Add(8 * time.Hour),
+	}
+
+	marketDataClient := new(MockMarketDataClient)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(false, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(preMarket, nil)
+
+	marketOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 10, nil)
+	result := &ValidationResult{IsValid: true, Errors: make([]string, 0), Warnings: make([]string, 0)}
+	service.(*orderValidationService).validateTradingHoursStep(context.Background(), marketOrder, marketDataClient, result)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "Only limit orders are accepted during the PRE_MARKET session")
+
+	price := 10.0
+	limitOrder, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	result = &ValidationResult{IsValid: true, Errors: make([]string, 0), Warnings: make([]string, 0)}
+	service.(*orderValidationService).validateTradingHoursStep(context.Background(), limitOrder, marketDataClient, result)
+	assert.True(t, result.IsValid)
+	assert.Contains(t, result.Warnings, "Symbol 'PETR4' is trading in the PRE_MARKET session with reduced liquidity")
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_ADVLimit(t *testing.T) {
+	day := time.Date(2024, 3, 4, 15, 0, 0, 0, time.UTC)
+	// Two trading days of 40K and 60K shares, the first one reported in two ticks
+	history := []HistoricalPrice{
+		{Symbol: "PETR4", Price: 10, Volume: 25000, Timestamp: day},
+		{Symbol: "PETR4", Price: 10, Volume: 15000, Timestamp: day.Add(2 * time.Hour)},
+		{Symbol: "PETR4", Price: 10, Volume: 60000, Timestamp: day.AddDate(0, 0, 1)},
+	}
+
+	tests := []struct {
+		name          string
+		quantity      float64
+		expectValid   bool
+		expectError   string
+		expectWarning string
+	}{
+		{
+			name:        "small share of ADV passes",
+			quantity:    1000,
+			expectValid: true,
+		},
+		{
+			name:          "order above the warning share is warned",
+			quantity:      5000,
+			expectValid:   true,
+			expectWarning: "Order quantity 5000.00 is 10.0% of the average daily volume of PETR4 (50000); expect significant price impact",
+		},
+		{
+			name:        "order above the blocking share is rejected",
+			quantity:    9000,
+			expectValid: false,
+			expectError: "Order quantity 9000.00 is 18.0% of the average daily volume of PETR4 (50000), above the maximum of 15.0%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderValidationService(OrderValidationConfig{
+				MaxOrderValue:         1000000,
+				MaxQuantityPerOrder:   10000,
+				PriceTolerancePercent: 10,
+				MinOrderValue:         1,
+				ADVLimit:              ADVLimit{LookbackDays: 5, WarnPercent: 5, BlockPercent: 15},
+			})
+			marketDataClient := new(MockVolumeMarketDataClient)
+			positionClient := new(MockPositionClient)
+			price := 10.0
+			order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, tt.quantity, &price)
+
+			marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+			marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+			marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+			marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+			marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+			marketDataClient.On("GetHistoricalPrices", "PETR4", 5*24*time.Hour).Return(history, nil)
+			positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+			result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectValid, result.IsValid, "errors: %v", result.Errors)
+			if tt.expectError != "" {
+				assert.Contains(t, result.Errors, tt.expectError)
+			}
+			if tt.expectWarning != "" {
+				assert.Contains(t, result.Warnings, tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestOrderValidationService_ValidateOrderWithContext_ADVWithoutHistory(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockVolumeMarketDataClient)
+	positionClient := new(MockPositionClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+
+	marketDataClient.On("ValidateSymbol", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetAssetDetails", mock.Anything, "PETR4").Return(&AssetDetails{IsActive: true, IsTradeable: true}, nil)
+	marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(10.0, nil)
+	marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(&TradingHours{IsOpen: true}, nil)
+	marketDataClient.On("GetHistoricalPrices", "PETR4", mock.Anything).Return([]HistoricalPrice{}, nil)
+	positionClient.On("HasSufficientBalance", "user1", mock.Anything).Return(true, nil)
+
+	result, err := service.ValidateOrderWithContext(context.Background(), order, marketDataClient, positionClient)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Contains(t, result.Warnings, "ADV validation warning: no volume history available for PETR4")
+}
+
+func TestOrderValidationService_ValidateOrderSide_FeeInclusiveBalance(t *testing.T) {
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	// The user holds exactly the order value, so only the fees push the order over the available funds
+	newFundsClient := func() *MockPositionClient {
+		positionClient := new(MockPositionClient)
+		positionClient.On("HasSufficientBalance", "user1", mock.MatchedBy(func(amount float64) bool { return amount <= 1000.0 })).Return(true, nil)
+		positionClient.On("HasSufficientBalance", "user1", mock.MatchedBy(func(amount float64) bool { return amount > 1000.0 })).Return(false, nil)
+		return positionClient
+	}
+
+	feeExclusive := NewOrderValidationService(OrderValidationConfig{MaxOrderValue: 1000000, EstimatedFeeRate: 0.001})
+	result, err := feeExclusive.ValidateOrderSide(context.Background(), order, newFundsClient())
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+
+	feeInclusive := NewOrderValidationService(OrderValidationConfig{MaxOrderValue: 1000000, EstimatedFeeRate: 0.001, IncludeFeesInLimitChecks: true})
+	positionClient := newFundsClient()
+	result, err = feeInclusive.ValidateOrderSide(context.Background(), order, positionClient)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "Insufficient balance for order value 1000.00 plus 1.00 estimated fees")
+	positionClient.AssertCalled(t, "HasSufficientBalance", "user1", 1001.0)
+}
+
+func TestOrderValidationService_ValidateOrderSide_FeeInclusiveBalanceUsesTradingCosts(t *testing.T) {
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	pricingClient := new(MockPricingDataClient)
+	pricingClient.On("GetTradingFees", domain.OrderTypeLimit, 1000.0).Return(&TradingFees{TotalFees: 7.5}, nil)
+	pricingClient.On("GetCurrentMarketPrice", "PETR4").Return(&MarketPrice{Symbol: "PETR4", BidPrice: 99.9, AskPrice: 100.1}, nil).Maybe()
+
+	service := NewOrderValidationServiceWithTradingCosts(
+		OrderValidationConfig{MaxOrderValue: 1000000, EstimatedFeeRate: 0.001, IncludeFeesInLimitChecks: true},
+		NewOrderPricingServiceWithDefaults(), pricingClient)
+	positionClient := new(MockPositionClient)
+	positionClient.On("HasSufficientBalance", "user1", 1007.5).Return(false, nil)
+
+	result, err := service.ValidateOrderSide(context.Background(), order, positionClient)
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "Insufficient balance for order value 1000.00 plus 7.50 estimated fees")
+	positionClient.AssertExpectations(t)
+}
+
+func TestOrderValidationService_ValidateRiskLimits_FeeInclusiveMaxOrderValue(t *testing.T) {
+	price := 100.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	config := OrderValidationConfig{MaxOrderValue: 1000.0, EstimatedFeeRate: 0.01}
+
+	result, err := NewOrderValidationService(config).ValidateRiskLimits(context.Background(), order, nil)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+
+	config.IncludeFeesInLimitChecks = true
+	result, err = NewOrderValidationService(config).ValidateRiskLimits(context.Background(), order, nil)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.Errors, "Order value 1000.00 plus 10.00 estimated fees exceeds maximum allowed 1000.00")
+}
+
+func TestOrderValidationService_ValidatePrice_ZeroCurrentPrice(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults()
+	marketDataClient := new(MockMarketDataClient)
+	price := 10.0
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+
+	marketDataClient.On("GetCurrentPrice", mock.Anything, "PETR4").Return(0.0, nil)
+
+	_, err := service.ValidatePrice(context.Background(), order, marketDataClient)
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+}
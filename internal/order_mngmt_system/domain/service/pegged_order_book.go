@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// ErrPeggedOrderBookFull is returned when the pegged order book already tracks its maximum number of orders
+var ErrPeggedOrderBookFull = errors.New("pegged order book is full")
+
+// PegRepricing is a pegged order whose peg target moved away from its resting price
+type PegRepricing struct {
+	OrderID      string
+	CurrentPrice float64
+	TargetPrice  float64
+}
+
+// PeggedOrderBook holds open pegged orders and works out which of them a realtime quote moves.
+// Orders stay in the book until they are untracked, so every quote can reprice them again.
+type PeggedOrderBook interface {
+	// Track registers an open pegged order at its current price, or refreshes it after a reprice
+	Track(order *domain.Order) error
+	// Untrack removes the order, e.g. after it is filled or cancelled
+	Untrack(orderID string)
+	// EvaluateQuote returns the symbol's orders whose peg target moved at the quote, oldest first
+	EvaluateQuote(symbol string, bid, ask float64) []PegRepricing
+	// Tracking returns the number of pegged orders in the book
+	Tracking() int
+	// Symbols returns the symbols with pegged orders in the book, sorted
+	Symbols() []string
+	// MinRepriceChange is the smallest price move the book reports
+	MinRepriceChange() float64
+	// ApplyDefaultBand fills the bounds a peg left out with the configured band around the anchor price
+	ApplyDefaultBand(peg domain.PegInstruction, anchorPrice float64) domain.PegInstruction
+}
+
+type trackedPeg struct {
+	orderID   string
+	peg       domain.PegInstruction
+	price     float64
+	trackedAt time.Time
+}
+
+type peggedOrderBook struct {
+	config PeggedOrderConfig
+
+	mu      sync.Mutex
+	pegs    map[string]map[string]trackedPeg // Symbol -> order ID -> peg
+	symbols map[string]string                // Order ID -> symbol
+}
+
+// PeggedOrderConfig holds configuration for pegged orders
+type PeggedOrderConfig struct {
+	DefaultBandPercent float64 // Band around the submitted price used for bounds the client left out (0 leaves them open)
+	MinRepriceChange   float64 // Smallest price move worth repricing an order for
+	MaxPeggedOrders    int     // Orders the book holds at once; further orders are refused (0 means unlimited)
+}
+
+// NewPeggedOrderBook creates a new instance of PeggedOrderBook
+func NewPeggedOrderBook(config PeggedOrderConfig) PeggedOrderBook {
+	return &peggedOrderBook{
+		config:  config,
+		pegs:    make(map[string]map[string]trackedPeg),
+		symbols: make(map[string]string),
+	}
+}
+
+// DefaultPeggedOrderConfig returns the default pegged order configuration
+func DefaultPeggedOrderConfig() PeggedOrderConfig {
+	return PeggedOrderConfig{
+		DefaultBandPercent: 10.0,  // Follow the quote at most 10% away from the submitted price
+		MinRepriceChange:   0.01,  // One cent; smaller moves are quote noise
+		MaxPeggedOrders:    50000, // Bound memory if pegged orders pile up
+	}
+}
+
+// NewPeggedOrderBookWithDefaults creates a pegged order book with default configuration
+func NewPeggedOrderBookWithDefaults() PeggedOrderBook {
+	return NewPeggedOrderBook(DefaultPeggedOrderConfig())
+}
+
+// Track registers an open pegged order at its current price
+func (b *peggedOrderBook) Track(order *domain.Order) error {
+	peg := order.PegInstruction()
+	if peg == nil {
+		return fmt.Errorf("order %s is not pegged", order.ID())
+	}
+	if order.Price() == nil {
+		return errors.New("pegged order has no price")
+	}
+	if !order.CanExecute() {
+		return fmt.Errorf("cannot track order in %s status", order.Status())
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, retrack := b.symbols[order.ID()]
+	if !retrack && b.config.MaxPeggedOrders > 0 && len(b.symbols) >= b.config.MaxPeggedOrders {
+		return ErrPeggedOrderBookFull
+	}
+
+	b.removeLocked(order.ID())
+
+	symbolPegs, exists := b.pegs[order.Symbol()]
+	if !exists {
+		symbolPegs = make(map[string]trackedPeg)
+		b.pegs[order.Symbol()] = symbolPegs
+	}
+
+	symbolPegs[order.ID()] = trackedPeg{
+		orderID:   order.ID(),
+		peg:       *peg,
+		price:     *order.Price(),
+		trackedAt: order.CreatedAt(),
+	}
+	b.symbols[order.ID()] = order.Symbol()
+	return nil
+}
+
+// Untrack removes the order from the book
+func (b *peggedOrderBook) Untrack(orderID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.removeLocked(orderID)
+}
+
+// EvaluateQuote returns the symbol's orders whose peg target moved at the quote. The book keeps the
+// orders at their old price until Track confirms the reprice.
+func (b *peggedOrderBook) EvaluateQuote(symbol string, bid, ask float64) []PegRepricing {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	moved := make([]trackedPeg, 0)
+	targets := make(map[string]float64)
+	for _, tracked := range b.pegs[symbol] {
+		target, err := tracked.peg.TargetPrice(bid, ask)
+		if err != nil {
+			continue
+		}
+		if math.Abs(target-tracked.price) < math.Max(b.config.MinRepriceChange, 1e-9) {
+			continue
+		}
+		moved = append(moved, tracked)
+		targets[tracked.orderID] = target
+	}
+
+	sort.Slice(moved, func(i, j int) bool {
+		if !moved[i].trackedAt.Equal(moved[j].trackedAt) {
+			return moved[i].trackedAt.Before(moved[j].trackedAt)
+		}
+		return moved[i].orderID < moved[j].orderID
+	})
+
+	repricings := make([]PegRepricing, 0, len(moved))
+	for _, tracked := range moved {
+		repricings = append(repricings, PegRepricing{
+			OrderID:      tracked.orderID,
+			CurrentPrice: tracked.price,
+			TargetPrice:  targets[tracked.orderID],
+		})
+	}
+	return repricings
+}
+
+// Tracking returns the number of pegged orders in the book
+func (b *peggedOrderBook) Tracking() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.symbols)
+}
+
+// Symbols returns the symbols with pegged orders in the book, sorted
+func (b *peggedOrderBook) Symbols() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	symbols := make([]string, 0, len(b.pegs))
+	for symbol := range b.pegs {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// MinRepriceChange is the smallest price move the book reports
+func (b *peggedOrderBook) MinRepriceChange() float64 {
+	return b.config.MinRepriceChange
+}
+
+// ApplyDefaultBand fills the bounds a peg left out with the configured band around the anchor price
+func (b *peggedOrderBook) ApplyDefaultBand(peg domain.PegInstruction, anchorPrice float64) domain.PegInstruction {
+	return peg.WithDefaultBand(anchorPrice, b.config.DefaultBandPercent)
+}
+
+func (b *peggedOrderBook) removeLocked(orderID string) {
+	symbol, exists := b.symbols[orderID]
+	if !exists {
+		return
+	}
+
+	delete(b.symbols, orderID)
+	delete(b.pegs[symbol], orderID)
+	if len(b.pegs[symbol]) == 0 {
+		delete(b.pegs, symbol)
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPeggedTestOrder(t *testing.T, symbol, reference string, price float64) *domain.Order {
+	t.Helper()
+	order, err := domain.NewOrder("user1", symbol, domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	require.NoError(t, err)
+	peg, err := domain.NewPegInstruction(reference, 0, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, order.SetPegInstruction(peg))
+	return order
+}
+
+func TestPeggedOrderBook_ReportsOnlyOrdersWhosePegMoved(t *testing.T) {
+	book := NewPeggedOrderBookWithDefaults()
+	bidPegged := newPeggedTestOrder(t, "PETR4", "BID", 30.00)
+	askPegged := newPeggedTestOrder(t, "PETR4", "ASK", 30.10)
+	require.NoError(t, book.Track(bidPegged))
+	require.NoError(t, book.Track(askPegged))
+
+	assert.Empty(t, book.EvaluateQuote("PETR4", 30.00, 30.10))
+	assert.Empty(t, book.EvaluateQuote("PETR4", 30.005, 30.10), "moves under the minimum change are ignored")
+	assert.Empty(t, book.EvaluateQuote("VALE3", 10.00, 10.10))
+
+	repricings := book.EvaluateQuote("PETR4", 30.05, 30.10)
+	require.Len(t, repricings, 1)
+	assert.Equal(t, bidPegged.ID(), repricings[0].OrderID)
+	assert.Equal(t, 30.00, repricings[0].CurrentPrice)
+	assert.Equal(t, 30.05, repricings[0].TargetPrice)
+
+	// The book keeps the old price until the reprice is confirmed
+	assert.Len(t, book.EvaluateQuote("PETR4", 30.05, 30.10), 1)
+	assert.Equal(t, 2, book.Tracking())
+}
+
+func TestPeggedOrderBook_RejectsUnpeggedOrdersAndRespectsCapacity(t *testing.T) {
+	book := NewPeggedOrderBook(PeggedOrderConfig{MinRepriceChange: 0.01, MaxPeggedOrders: 1})
+
+	price := 30.00
+	unpegged, err := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	require.NoError(t, err)
+	assert.Error(t, book.Track(unpegged))
+
+	tracked := newPeggedTestOrder(t, "PETR4", "BID", 30.00)
+	require.NoError(t, book.Track(tracked))
+	assert.NoError(t, book.Track(tracked))
+	assert.ErrorIs(t, book.Track(newPeggedTestOrder(t, "PETR4", "BID", 30.00)), ErrPeggedOrderBookFull)
+
+	book.Untrack(tracked.ID())
+	assert.Equal(t, 0, book.Tracking())
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// IBatchHistoricalPriceProvider is implemented by pricing clients that fetch the history of several
+// symbols in one call. Batch quote history uses it instead of one fetch per symbol.
+type IBatchHistoricalPriceProvider interface {
+	// GetBatchHistoricalPrices returns the history of each symbol it knows; unknown symbols are left out
+	GetBatchHistoricalPrices(symbols []string, period time.Duration) (map[string][]HistoricalPrice, error)
+}
+
+// QuoteInterval is the width of each bar in a quote history series
+type QuoteInterval string
+
+const (
+	QuoteInterval1Minute   QuoteInterval = "1m"
+	QuoteInterval5Minutes  QuoteInterval = "5m"
+	QuoteInterval15Minutes QuoteInterval = "15m"
+	QuoteInterval30Minutes QuoteInterval = "30m"
+	QuoteInterval1Hour     QuoteInterval = "1h"
+	QuoteInterval1Day      QuoteInterval = "1d"
+)
+
+var quoteIntervalDurations = map[QuoteInterval]time.Duration{
+	QuoteInterval1Minute:   time.Minute,
+	QuoteInterval5Minutes:  5 * time.Minute,
+	QuoteInterval15Minutes: 15 * time.Minute,
+	QuoteInterval30Minutes: 30 * time.Minute,
+	QuoteInterval1Hour:     time.Hour,
+	QuoteInterval1Day:      24 * time.Hour,
+}
+
+// ParseQuoteInterval converts a string to a QuoteInterval
+func ParseQuoteInterval(s string) (QuoteInterval, error) {
+	interval := QuoteInterval(s)
+	if _, exists := quoteIntervalDurations[interval]; !exists {
+		return "", fmt.Errorf("invalid quote interval: %s", s)
+	}
+	return interval, nil
+}
+
+// Duration returns the width of one bar
+func (i QuoteInterval) Duration() time.Duration {
+	return quoteIntervalDurations[i]
+}
+
+// QuoteRange is how far back a quote history series reaches
+type QuoteRange string
+
+const (
+	QuoteRange1Day    QuoteRange = "1d"
+	QuoteRange5Days   QuoteRange = "5d"
+	QuoteRange1Month  QuoteRange = "1mo"
+	QuoteRange3Months QuoteRange = "3mo"
+	QuoteRange6Months QuoteRange = "6mo"
+	QuoteRange1Year   QuoteRange = "1y"
+	QuoteRange5Years  QuoteRange = "5y"
+)
+
+var quoteRangeDurations = map[QuoteRange]time.Duration{
+	QuoteRange1Day:    24 * time.Hour,
+	QuoteRange5Days:   5 * 24 * time.Hour,
+	QuoteRange1Month:  30 * 24 * time.Hour,
+	QuoteRange3Months: 90 * 24 * time.Hour,
+	QuoteRange6Months: 180 * 24 * time.Hour,
+	QuoteRange1Year:   365 * 24 * time.Hour,
+	QuoteRange5Years:  5 * 365 * 24 * time.Hour,
+}
+
+// ParseQuoteRange converts a string to a QuoteRange
+func ParseQuoteRange(s string) (QuoteRange, error) {
+	quoteRange := QuoteRange(s)
+	if _, exists := quoteRangeDurations[quoteRange]; !exists {
+		return "", fmt.Errorf("invalid quote range: %s", s)
+	}
+	return quoteRange, nil
+}
+
+// Duration returns how far back the range reaches
+func (r QuoteRange) Duration() time.Duration {
+	return quoteRangeDurations[r]
+}
+
+// QuoteBar summarizes the prices of one interval
+type QuoteBar struct {
+	Timestamp time.Time // Start of the interval
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    int64
+}
+
+// AggregateQuoteBars groups historical prices into bars of the given interval, oldest first.
+// Bars start on interval boundaries in UTC; intervals without prices produce no bar.
+func AggregateQuoteBars(prices []HistoricalPrice, interval QuoteInterval) []QuoteBar {
+	width := interval.Duration()
+	if width <= 0 || len(prices) == 0 {
+		return []QuoteBar{}
+	}
+
+	sorted := make([]HistoricalPrice, len(prices))
+	copy(sorted, prices)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	bars := make([]QuoteBar, 0)
+	for _, price := range sorted {
+		start := price.Timestamp.UTC().Truncate(width)
+
+		if len(bars) > 0 && bars[len(bars)-1].Timestamp.Equal(start) {
+			bar := &bars[len(bars)-1]
+			if price.Price > bar.High {
+				bar.High = price.Price
+			}
+			if price.Price < bar.Low {
+				bar.Low = price.Price
+			}
+			bar.Close = price.Price
+			bar.Volume += price.Volume
+			continue
+		}
+
+		bars = append(bars, QuoteBar{
+			Timestamp: start,
+			Open:      price.Price,
+			High:      price.Price,
+			Low:       price.Price,
+			Close:     price.Price,
+			Volume:    price.Volume,
+		})
+	}
+
+	return bars
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// QuoteSnapshot is the latest quote broadcast for a symbol
+type QuoteSnapshot struct {
+	Symbol    string
+	BidPrice  float64
+	AskPrice  float64
+	LastPrice float64
+	Spread    float64
+	Halted    bool
+	Timestamp time.Time
+}
+
+// QuoteSnapshotCache keeps the latest quote broadcast per symbol, so clients that cannot hold a
+// WebSocket open read the same values streaming subscribers last received
+type QuoteSnapshotCache interface {
+	// Record stores the quote unless a newer one is already cached for the symbol
+	Record(snapshot QuoteSnapshot)
+	// Get returns the cached quote of the symbol
+	Get(symbol string) (QuoteSnapshot, bool)
+	// All returns a copy of every cached quote, keyed by symbol
+	All() map[string]QuoteSnapshot
+	// Size returns the number of symbols cached
+	Size() int
+}
+
+type quoteSnapshotCache struct {
+	config QuoteSnapshotCacheConfig
+
+	mu        sync.RWMutex
+	snapshots map[string]QuoteSnapshot
+}
+
+// QuoteSnapshotCacheConfig holds configuration for the quote snapshot cache
+type QuoteSnapshotCacheConfig struct {
+	MaxSymbols int // Symbols cached at once; quotes of further symbols are not cached (0 means unlimited)
+}
+
+// NewQuoteSnapshotCache creates a new instance of QuoteSnapshotCache
+func NewQuoteSnapshotCache(config QuoteSnapshotCacheConfig) QuoteSnapshotCache {
+	return &quoteSnapshotCache{
+		config:    config,
+		snapshots: make(map[string]QuoteSnapshot),
+	}
+}
+
+// DefaultQuoteSnapshotCacheConfig returns the default quote snapshot cache configuration
+func DefaultQuoteSnapshotCacheConfig() QuoteSnapshotCacheConfig {
+	return QuoteSnapshotCacheConfig{
+		MaxSymbols: 10000, // Well above the listed universe, bounds memory on bad symbols
+	}
+}
+
+// NewQuoteSnapshotCacheWithDefaults creates a quote snapshot cache with default configuration
+func NewQuoteSnapshotCacheWithDefaults() QuoteSnapshotCache {
+	return NewQuoteSnapshotCache(DefaultQuoteSnapshotCacheConfig())
+}
+
+// Record stores the quote. Quotes older than the cached one arrive out of order and are dropped.
+func (c *quoteSnapshotCache) Record(snapshot QuoteSnapshot) {
+	symbol := strings.ToUpper(strings.TrimSpace(snapshot.Symbol))
+	if symbol == "" {
+		return
+	}
+	snapshot.Symbol = symbol
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, exists := c.snapshots[symbol]
+	if !exists && c.config.MaxSymbols > 0 && len(c.snapshots) >= c.config.MaxSymbols {
+		return
+	}
+	if exists && snapshot.Timestamp.Before(cached.Timestamp) {
+		return
+	}
+
+	c.snapshots[symbol] = snapshot
+}
+
+// Get returns the cached quote of the symbol
+func (c *quoteSnapshotCache) Get(symbol string) (QuoteSnapshot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot, exists := c.snapshots[strings.ToUpper(strings.TrimSpace(symbol))]
+	return snapshot, exists
+}
+
+// All returns a copy of every cached quote, taken under one lock so the quotes are consistent
+func (c *quoteSnapshotCache) All() map[string]QuoteSnapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshots := make(map[string]QuoteSnapshot, len(c.snapshots))
+	for symbol, snapshot := range c.snapshots {
+		snapshots[symbol] = snapshot
+	}
+	return snapshots
+}
+
+// Size returns the number of symbols cached
+func (c *quoteSnapshotCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.snapshots)
+}
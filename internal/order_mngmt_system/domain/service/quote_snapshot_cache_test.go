@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteSnapshotCache_KeepsLatestQuotePerSymbol(t *testing.T) {
+	cache := NewQuoteSnapshotCacheWithDefaults()
+	now := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+
+	cache.Record(QuoteSnapshot{Symbol: "petr4", BidPrice: 30.00, AskPrice: 30.10, LastPrice: 30.05, Timestamp: now})
+	cache.Record(QuoteSnapshot{Symbol: "PETR4", BidPrice: 30.20, AskPrice: 30.30, LastPrice: 30.25, Timestamp: now.Add(time.Second)})
+	cache.Record(QuoteSnapshot{Symbol: "PETR4", BidPrice: 29.00, AskPrice: 29.10, LastPrice: 29.05, Timestamp: now.Add(-time.Second)})
+
+	snapshot, found := cache.Get(" Petr4 ")
+	require.True(t, found)
+	assert.Equal(t, "PETR4", snapshot.Symbol)
+	assert.Equal(t, 30.25, snapshot.LastPrice, "out-of-order quotes must not replace a newer one")
+	assert.Equal(t, 1, cache.Size())
+
+	_, found = cache.Get("VALE3")
+	assert.False(t, found)
+}
+
+func TestQuoteSnapshotCache_BoundsCachedSymbols(t *testing.T) {
+	cache := NewQuoteSnapshotCache(QuoteSnapshotCacheConfig{MaxSymbols: 1})
+	now := time.Now()
+
+	cache.Record(QuoteSnapshot{Symbol: "PETR4", LastPrice: 30.00, Timestamp: now})
+	cache.Record(QuoteSnapshot{Symbol: "VALE3", LastPrice: 60.00, Timestamp: now})
+	cache.Record(QuoteSnapshot{Symbol: "PETR4", LastPrice: 31.00, Timestamp: now.Add(time.Second)})
+	cache.Record(QuoteSnapshot{Symbol: "", LastPrice: 1.00, Timestamp: now})
+
+	assert.Equal(t, 1, cache.Size())
+	_, found := cache.Get("VALE3")
+	assert.False(t, found)
+	snapshot, _ := cache.Get("PETR4")
+	assert.Equal(t, 31.00, snapshot.LastPrice, "cached symbols keep updating once the cache is full")
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrZeroDenominator is returned when a risk or pricing ratio would divide by zero
+var ErrZeroDenominator = errors.New("ratio denominator is zero")
+
+// ratio divides numerator by denominator without producing Inf or NaN. A zero numerator is a zero
+// ratio whatever the denominator; any other division by zero returns ErrZeroDenominator.
+func ratio(numerator, denominator float64) (float64, error) {
+	if numerator == 0 {
+		return 0, nil
+	}
+	if denominator == 0 {
+		return 0, ErrZeroDenominator
+	}
+
+	result := numerator / denominator
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, ErrZeroDenominator
+	}
+	return result, nil
+}
+
+// ratioOr is ratio with fallback returned in place of an undefined result
+func ratioOr(numerator, denominator, fallback float64) float64 {
+	result, err := ratio(numerator, denominator)
+	if err != nil {
+		return fallback
+	}
+	return result
+}
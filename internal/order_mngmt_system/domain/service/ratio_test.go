@@ -0,0 +1,26 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatio(t *testing.T) {
+	value, err := ratio(5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, value)
+
+	value, err = ratio(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, value)
+
+	_, err = ratio(5, 0)
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+
+	_, err = ratio(math.MaxFloat64, 1e-300)
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+
+	assert.Equal(t, 7.0, ratioOr(5, 0, 7))
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
@@ -74,6 +76,21 @@ type TradingLimits struct {
 	RemainingDailyLimit float64
 }
 
+// ResetDailyUsage starts a new trading day: nothing has been traded and the full daily limit is available
+func (l *TradingLimits) ResetDailyUsage() {
+	l.DailyTradingUsed = 0
+	l.RemainingDailyLimit = l.DailyTradingLimit
+}
+
+// AccountGroup links sub-accounts whose exposure is limited as a whole.
+// A zero limit disables the corresponding group check.
+type AccountGroup struct {
+	ID                string
+	AccountIDs        []string
+	MaxPositionSize   float64 // Combined position value per symbol across the group
+	DailyTradingLimit float64 // Combined daily trading value across the group
+}
+
 // RiskAssessment represents the result of risk assessment
 type RiskAssessment struct {
 	RiskLevel        RiskLevel
@@ -83,9 +100,45 @@ type RiskAssessment struct {
 	RiskFactors      []RiskFactor
 	Recommendations  []string
 	Warnings         []string
+	ScoreBreakdown   *RiskScoreBreakdown
+	Degraded         bool // Risk data was unavailable and conservative defaults were applied
 	AssessmentTime   time.Time
 }
 
+// RiskScoreComponent explains one weighted component of the overall risk score
+type RiskScoreComponent struct {
+	Component    string
+	RawScore     float64
+	Weight       float64
+	Contribution float64 // RawScore * Weight, the points this component adds to the total
+	Available    bool    // False when the component's data could not be loaded and it added nothing
+	Description  string
+}
+
+// RiskScoreBreakdown shows how the overall risk score was composed; the contributions sum to TotalScore
+type RiskScoreBreakdown struct {
+	Components []RiskScoreComponent
+	TotalScore float64
+}
+
+// RiskScoreWeights sets how much each component contributes to the overall risk score
+type RiskScoreWeights struct {
+	Market        float64
+	Concentration float64
+	UserProfile   float64
+	OrderSize     float64
+}
+
+// DefaultRiskScoreWeights returns the standard 40/30/20/10 weighting
+func DefaultRiskScoreWeights() RiskScoreWeights {
+	return RiskScoreWeights{
+		Market:        0.4, // 40% weight
+		Concentration: 0.3, // 30% weight
+		UserProfile:   0.2, // 20% weight
+		OrderSize:     0.1, // 10% weight
+	}
+}
+
 // RiskLevel represents overall risk level
 type RiskLevel int32
 
@@ -97,6 +150,42 @@ const (
 	RiskLevelExtremelyHigh
 )
 
+// ParseRiskLevel converts a level name such as "MEDIUM" or "very_high" into a RiskLevel
+func ParseRiskLevel(name string) (RiskLevel, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "LOW":
+		return RiskLevelLow, nil
+	case "MEDIUM":
+		return RiskLevelMedium, nil
+	case "HIGH":
+		return RiskLevelHigh, nil
+	case "VERY_HIGH":
+		return RiskLevelVeryHigh, nil
+	case "EXTREMELY_HIGH":
+		return RiskLevelExtremelyHigh, nil
+	default:
+		return RiskLevelLow, fmt.Errorf("unknown risk level: %s", name)
+	}
+}
+
+const (
+	OrderSizeLimitedByRisk    = "RISK"    // The risk budget of the target level bounds the quantity
+	OrderSizeLimitedByBalance = "BALANCE" // The available balance bounds the quantity
+)
+
+// OrderSizeRecommendation is the largest whole-unit buy quantity whose risk score stays within a target risk level
+type OrderSizeRecommendation struct {
+	Symbol              string
+	Price               float64
+	TargetRiskLevel     RiskLevel
+	MaxRiskScore        float64 // The order's risk score must stay below this to remain within the target level
+	RecommendedQuantity float64 // Zero when not even a single unit fits the target level
+	RiskScore           float64 // Risk score of an order for the recommended quantity
+	AffordableQuantity  float64 // Largest quantity the available balance covers
+	Volatility30Day     float64
+	LimitedBy           string
+}
+
 // RiskFactor represents individual risk factors
 type RiskFactor struct {
 	Factor      string
@@ -140,6 +229,12 @@ type RiskManagementService interface {
 
 	// CalculateRiskScore calculates overall risk score for an order
 	CalculateRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (float64, error)
+
+	// ExplainRiskScore breaks the overall risk score down into its weighted components
+	ExplainRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (*RiskScoreBreakdown, error)
+
+	// RecommendOrderSize finds the largest buy quantity whose risk score stays within the target risk level
+	RecommendOrderSize(userID, symbol string, price float64, targetLevel RiskLevel, riskDataClient IRiskDataClient) (*OrderSizeRecommendation, error)
 }
 
 type riskManagementService struct {
@@ -149,41 +244,104 @@ type riskManagementService struct {
 	concentrationLimit      float64
 	volatilityThreshold     float64
 	manualApprovalThreshold float64
+	scoreWeights            RiskScoreWeights
+	accountGroups           map[string]*AccountGroup // keyed by account ID
+	degradedMode            DegradedModeConfig
+	zeroDenominatorPercent  float64
 }
 
 // RiskManagementConfig holds configuration for risk management
 type RiskManagementConfig struct {
-	MaxRiskScore            float64 // Maximum allowed risk score (0-100)
-	HighRiskThreshold       float64 // Threshold for high risk classification
-	ConcentrationLimit      float64 // Maximum concentration percentage
-	VolatilityThreshold     float64 // Volatility threshold for high risk
-	ManualApprovalThreshold float64 // Threshold requiring manual approval
+	MaxRiskScore            float64          // Maximum allowed risk score (0-100)
+	HighRiskThreshold       float64          // Threshold for high risk classification
+	ConcentrationLimit      float64          // Maximum concentration percentage
+	VolatilityThreshold     float64          // Volatility threshold for high risk
+	ManualApprovalThreshold float64          // Threshold requiring manual approval
+	AccountGroups           []AccountGroup   // Linked accounts whose exposure is aggregated
+	ScoreWeights            RiskScoreWeights // Component weights; the zero value uses DefaultRiskScoreWeights
+	DegradedMode            DegradedModeConfig
+	// ZeroDenominatorPercent is the concentration or utilization percent risk scoring assumes when the
+	// balance or limit it is measured against is zero (0 uses 100, i.e. fully concentrated or used)
+	ZeroDenominatorPercent float64
+}
+
+// DegradedModeConfig controls how orders are assessed while risk data is unavailable.
+// When disabled, AssessOrderRisk fails if risk data cannot be loaded.
+type DegradedModeConfig struct {
+	Enabled                  bool
+	MaxOrderValue            float64 // Largest order value approved without risk data
+	ManualApprovalAboveValue float64 // Order value above which manual approval is required
+}
+
+// DefaultDegradedModeConfig returns conservative limits for trading during a risk data outage
+func DefaultDegradedModeConfig() DegradedModeConfig {
+	return DegradedModeConfig{
+		Enabled:                  true,
+		MaxOrderValue:            10000.0, // Only small orders go through without risk data
+		ManualApprovalAboveValue: 2000.0,  // Anything beyond a modest order is reviewed manually
+	}
 }
 
 // NewRiskManagementService creates a new instance of RiskManagementService
 func NewRiskManagementService(config RiskManagementConfig) RiskManagementService {
-	return &riskManagementService{
+	service := &riskManagementService{
 		maxRiskScore:            config.MaxRiskScore,
 		highRiskThreshold:       config.HighRiskThreshold,
 		concentrationLimit:      config.ConcentrationLimit,
 		volatilityThreshold:     config.VolatilityThreshold,
 		manualApprovalThreshold: config.ManualApprovalThreshold,
+		scoreWeights:            config.ScoreWeights,
+		accountGroups:           make(map[string]*AccountGroup),
+		degradedMode:            config.DegradedMode,
+		zeroDenominatorPercent:  config.ZeroDenominatorPercent,
+	}
+
+	if service.zeroDenominatorPercent <= 0 {
+		service.zeroDenominatorPercent = 100.0
+	}
+
+	if service.scoreWeights == (RiskScoreWeights{}) {
+		service.scoreWeights = DefaultRiskScoreWeights()
+	}
+
+	for i := range config.AccountGroups {
+		group := &config.AccountGroups[i]
+		for _, accountID := range group.AccountIDs {
+			service.accountGroups[accountID] = group
+		}
 	}
+
+	return service
 }
 
 // NewRiskManagementServiceWithDefaults creates a service with default configuration
 func NewRiskManagementServiceWithDefaults() RiskManagementService {
-	return NewRiskManagementService(RiskManagementConfig{
+	return NewRiskManagementService(DefaultRiskManagementConfig())
+}
+
+// DefaultRiskManagementConfig returns the thresholds used by NewRiskManagementServiceWithDefaults
+func DefaultRiskManagementConfig() RiskManagementConfig {
+	return RiskManagementConfig{
 		MaxRiskScore:            80.0, // Max risk score of 80
 		HighRiskThreshold:       60.0, // High risk at 60+
 		ConcentrationLimit:      20.0, // Max 20% concentration in single position
 		VolatilityThreshold:     25.0, // High volatility at 25%+
 		ManualApprovalThreshold: 70.0, // Manual approval at 70+ risk score
-	})
+	}
 }
 
-// AssessOrderRisk performs comprehensive risk assessment for an order
+// AssessOrderRisk performs comprehensive risk assessment for an order.
+// With degraded mode enabled, unavailable risk data yields a conservative assessment instead of an error.
 func (s *riskManagementService) AssessOrderRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	assessment, err := s.assessOrderRisk(order, riskDataClient)
+	if err != nil && s.degradedMode.Enabled {
+		return s.assessOrderRiskDegraded(order, err), nil
+	}
+
+	return assessment, err
+}
+
+func (s *riskManagementService) assessOrderRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
 	assessment := &RiskAssessment{
 		RiskFactors:     make([]RiskFactor, 0),
 		Recommendations: make([]string, 0),
@@ -192,13 +350,14 @@ func (s *riskManagementService) AssessOrderRisk(order *domain.Order, riskDataCli
 	}
 
 	// Calculate overall risk score
-	riskScore, err := s.CalculateRiskScore(order, riskDataClient)
+	breakdown, err := s.ExplainRiskScore(order, riskDataClient)
 	if err != nil {
 		return assessment, fmt.Errorf("failed to calculate risk score: %w", err)
 	}
 
-	assessment.RiskScore = riskScore
-	assessment.RiskLevel = s.determineRiskLevel(riskScore)
+	assessment.ScoreBreakdown = breakdown
+	assessment.RiskScore = breakdown.TotalScore
+	assessment.RiskLevel = s.determineRiskLevel(breakdown.TotalScore)
 
 	// Perform individual risk assessments
 	if err := s.assessUserRiskProfile(order, riskDataClient, assessment); err != nil {
@@ -227,6 +386,35 @@ func (s *riskManagementService) AssessOrderRisk(order *domain.Order, riskDataCli
 	return assessment, nil
 }
 
+// assessOrderRiskDegraded applies the degraded mode limits to an order whose risk data could not be loaded
+func (s *riskManagementService) assessOrderRiskDegraded(order *domain.Order, cause error) *RiskAssessment {
+	orderValue := order.CalculateOrderValue()
+
+	assessment := &RiskAssessment{
+		RiskLevel: RiskLevelHigh,
+		RiskFactors: []RiskFactor{{
+			Factor:      "Risk Data Unavailable",
+			Impact:      RiskImpactHigh,
+			Description: fmt.Sprintf("Risk data unavailable, conservative limits applied: %v", cause),
+		}},
+		Recommendations:  make([]string, 0),
+		Warnings:         make([]string, 0),
+		Degraded:         true,
+		IsApproved:       orderValue <= s.degradedMode.MaxOrderValue,
+		RequiresApproval: orderValue > s.degradedMode.ManualApprovalAboveValue,
+		AssessmentTime:   time.Now(),
+	}
+
+	if !assessment.IsApproved {
+		assessment.Warnings = append(assessment.Warnings,
+			fmt.Sprintf("Order value %.2f exceeds degraded mode limit %.2f", orderValue, s.degradedMode.MaxOrderValue))
+	}
+
+	s.generateRiskRecommendations(assessment)
+
+	return assessment
+}
+
 // ValidateRiskLimits validates order against user risk limits
 func (s *riskManagementService) ValidateRiskLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
 	// Check user risk profile
@@ -288,11 +476,39 @@ func (s *riskManagementService) CheckPositionLimits(order *domain.Order, riskDat
 		return fmt.Errorf("failed to get account balance: %w", err)
 	}
 
-	concentrationPercent := (newPositionValue / accountBalance.TotalBalance) * 100
+	concentration, err := ratio(newPositionValue, accountBalance.TotalBalance)
+	if err != nil {
+		return fmt.Errorf("cannot check position concentration against a zero account balance: %w", err)
+	}
+	concentrationPercent := concentration * 100
 	if concentrationPercent > s.concentrationLimit {
 		return fmt.Errorf("position concentration %.1f%% exceeds limit %.1f%%", concentrationPercent, s.concentrationLimit)
 	}
 
+	return s.checkGroupPositionLimit(order, orderValue, riskDataClient)
+}
+
+// checkGroupPositionLimit validates the combined position across the order's account group
+func (s *riskManagementService) checkGroupPositionLimit(order *domain.Order, orderValue float64, riskDataClient IRiskDataClient) error {
+	group, ok := s.accountGroups[order.UserID()]
+	if !ok || group.MaxPositionSize <= 0 {
+		return nil
+	}
+
+	combinedValue := orderValue
+	for _, accountID := range group.AccountIDs {
+		exposure, err := riskDataClient.GetPositionExposure(accountID, order.Symbol())
+		if err != nil {
+			return fmt.Errorf("failed to get position exposure for linked account %s: %w", accountID, err)
+		}
+		combinedValue += exposure.CurrentValue
+	}
+
+	if combinedValue > group.MaxPositionSize {
+		return fmt.Errorf("combined position value %.2f across account group %s would exceed group limit %.2f",
+			combinedValue, group.ID, group.MaxPositionSize)
+	}
+
 	return nil
 }
 
@@ -315,6 +531,30 @@ func (s *riskManagementService) CheckTradingLimits(order *domain.Order, riskData
 		return fmt.Errorf("order value %.2f exceeds maximum order limit %.2f", orderValue, tradingLimits.MaxOrderValue)
 	}
 
+	return s.checkGroupTradingLimit(order, orderValue, riskDataClient)
+}
+
+// checkGroupTradingLimit validates the combined daily trading value across the order's account group
+func (s *riskManagementService) checkGroupTradingLimit(order *domain.Order, orderValue float64, riskDataClient IRiskDataClient) error {
+	group, ok := s.accountGroups[order.UserID()]
+	if !ok || group.DailyTradingLimit <= 0 {
+		return nil
+	}
+
+	combinedUsed := 0.0
+	for _, accountID := range group.AccountIDs {
+		limits, err := riskDataClient.GetUserTradingLimits(accountID)
+		if err != nil {
+			return fmt.Errorf("failed to get trading limits for linked account %s: %w", accountID, err)
+		}
+		combinedUsed += limits.DailyTradingUsed
+	}
+
+	if combinedUsed+orderValue > group.DailyTradingLimit {
+		return fmt.Errorf("order value %.2f exceeds remaining daily limit %.2f of account group %s",
+			orderValue, group.DailyTradingLimit-combinedUsed, group.ID)
+	}
+
 	return nil
 }
 
@@ -391,7 +631,7 @@ func (s *riskManagementService) AssessConcentrationRisk(order *domain.Order, ris
 	// Calculate concentration after order
 	orderValue := order.CalculateOrderValue()
 	newPositionValue := currentPosition.CurrentValue + orderValue
-	concentrationPercent := (newPositionValue / accountBalance.TotalBalance) * 100
+	concentrationPercent := s.percentOf(newPositionValue, accountBalance.TotalBalance)
 
 	// Assess concentration risk
 	if concentrationPercent > s.concentrationLimit {
@@ -441,40 +681,133 @@ func (s *riskManagementService) RequiresManualApproval(riskAssessment *RiskAsses
 
 // CalculateRiskScore calculates overall risk score for an order
 func (s *riskManagementService) CalculateRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (float64, error) {
-	var totalScore float64
-	var scoreComponents int
+	breakdown, err := s.ExplainRiskScore(order, riskDataClient)
+	if err != nil {
+		return 0, err
+	}
+	return breakdown.TotalScore, nil
+}
+
+// ExplainRiskScore breaks the overall risk score down into its weighted components.
+// Components whose data cannot be loaded are listed as unavailable and contribute nothing.
+func (s *riskManagementService) ExplainRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (*RiskScoreBreakdown, error) {
+	breakdown := &RiskScoreBreakdown{Components: make([]RiskScoreComponent, 0, 4)}
 
 	// Market risk component
 	marketRisk, err := s.AssessMarketRisk(order, riskDataClient)
 	if err == nil {
-		totalScore += marketRisk.RiskScore * 0.4 // 40% weight
-		scoreComponents++
+		s.addScoreComponent(breakdown, "Market Risk", marketRisk.RiskScore, s.scoreWeights.Market,
+			"Volatility and beta of the symbol")
+	} else {
+		s.addUnavailableComponent(breakdown, "Market Risk", s.scoreWeights.Market, err)
 	}
 
 	// Concentration risk component
 	concentrationRisk, err := s.AssessConcentrationRisk(order, riskDataClient)
 	if err == nil {
-		totalScore += concentrationRisk.RiskScore * 0.3 // 30% weight
-		scoreComponents++
+		s.addScoreComponent(breakdown, "Concentration Risk", concentrationRisk.RiskScore, s.scoreWeights.Concentration,
+			"Share of the account the position would represent after the order")
+	} else {
+		s.addUnavailableComponent(breakdown, "Concentration Risk", s.scoreWeights.Concentration, err)
 	}
 
 	// User risk profile component
 	userRiskScore, err := s.calculateUserRiskScore(order, riskDataClient)
 	if err == nil {
-		totalScore += userRiskScore * 0.2 // 20% weight
-		scoreComponents++
+		s.addScoreComponent(breakdown, "User Risk Profile", userRiskScore, s.scoreWeights.UserProfile,
+			"Order value relative to the user's maximum order value")
+	} else {
+		s.addUnavailableComponent(breakdown, "User Risk Profile", s.scoreWeights.UserProfile, err)
 	}
 
 	// Order size risk component
-	orderSizeScore := s.calculateOrderSizeRiskScore(order)
-	totalScore += orderSizeScore * 0.1 // 10% weight
-	scoreComponents++
+	s.addScoreComponent(breakdown, "Order Size", s.calculateOrderSizeRiskScore(order), s.scoreWeights.OrderSize,
+		"Absolute value of the order")
+
+	return breakdown, nil
+}
+
+// RecommendOrderSize finds the largest whole-unit buy quantity at the given price whose risk score stays
+// below the ceiling of the target risk level, bounded by what the available balance can pay for.
+// Volatility sets the fixed market part of the score, so more volatile symbols leave less room for size.
+func (s *riskManagementService) RecommendOrderSize(userID, symbol string, price float64, targetLevel RiskLevel, riskDataClient IRiskDataClient) (*OrderSizeRecommendation, error) {
+	if price <= 0 {
+		return nil, fmt.Errorf("price must be positive")
+	}
+
+	volatility, err := riskDataClient.GetMarketVolatility(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market volatility: %w", err)
+	}
+
+	accountBalance, err := riskDataClient.GetAccountBalance(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account balance: %w", err)
+	}
+
+	recommendation := &OrderSizeRecommendation{
+		Symbol:             symbol,
+		Price:              price,
+		TargetRiskLevel:    targetLevel,
+		MaxRiskScore:       min(s.riskLevelCeiling(targetLevel), s.maxRiskScore),
+		AffordableQuantity: math.Max(0, math.Floor(accountBalance.AvailableBalance/price)),
+		Volatility30Day:    volatility.Volatility30Day,
+		LimitedBy:          OrderSizeLimitedByRisk,
+	}
 
-	if scoreComponents == 0 {
-		return 0, fmt.Errorf("unable to calculate risk score: no components available")
+	// The risk score never decreases as the quantity grows, so the answer can be found by bisection
+	low, high := 0.0, recommendation.AffordableQuantity
+	for low < high {
+		candidate := math.Ceil((low + high) / 2)
+		score, err := s.calculateBuyOrderRiskScore(userID, symbol, candidate, price, riskDataClient)
+		if err != nil {
+			return nil, err
+		}
+
+		if score < recommendation.MaxRiskScore {
+			low = candidate
+			recommendation.RiskScore = score
+		} else {
+			high = candidate - 1
+		}
 	}
 
-	return totalScore, nil
+	recommendation.RecommendedQuantity = low
+	if low == recommendation.AffordableQuantity {
+		recommendation.LimitedBy = OrderSizeLimitedByBalance
+	}
+
+	return recommendation, nil
+}
+
+func (s *riskManagementService) calculateBuyOrderRiskScore(userID, symbol string, quantity, price float64, riskDataClient IRiskDataClient) (float64, error) {
+	order, err := domain.NewOrder(userID, symbol, domain.OrderSideBuy, domain.OrderTypeLimit, quantity, &price)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build candidate order: %w", err)
+	}
+
+	return s.CalculateRiskScore(order, riskDataClient)
+}
+
+func (s *riskManagementService) addScoreComponent(breakdown *RiskScoreBreakdown, name string, rawScore, weight float64, description string) {
+	contribution := rawScore * weight
+	breakdown.Components = append(breakdown.Components, RiskScoreComponent{
+		Component:    name,
+		RawScore:     rawScore,
+		Weight:       weight,
+		Contribution: contribution,
+		Available:    true,
+		Description:  description,
+	})
+	breakdown.TotalScore += contribution
+}
+
+func (s *riskManagementService) addUnavailableComponent(breakdown *RiskScoreBreakdown, name string, weight float64, err error) {
+	breakdown.Components = append(breakdown.Components, RiskScoreComponent{
+		Component:   name,
+		Weight:      weight,
+		Description: fmt.Sprintf("Not scored: %v", err),
+	})
 }
 
 // Helper methods
@@ -494,6 +827,22 @@ func (s *riskManagementService) determineRiskLevel(riskScore float64) RiskLevel
 	}
 }
 
+// riskLevelCeiling returns the score at which determineRiskLevel moves past the given level
+func (s *riskManagementService) riskLevelCeiling(level RiskLevel) float64 {
+	switch level {
+	case RiskLevelLow:
+		return 20
+	case RiskLevelMedium:
+		return 40
+	case RiskLevelHigh:
+		return s.highRiskThreshold
+	case RiskLevelVeryHigh:
+		return 80
+	default:
+		return s.maxRiskScore
+	}
+}
+
 func (s *riskManagementService) assessUserRiskProfile(order *domain.Order, riskDataClient IRiskDataClient, assessment *RiskAssessment) error {
 	userProfile, err := riskDataClient.GetUserRiskProfile(order.UserID())
 	if err != nil {
@@ -508,7 +857,7 @@ func (s *riskManagementService) assessUserRiskProfile(order *domain.Order, riskD
 		assessment.RiskFactors = append(assessment.RiskFactors, RiskFactor{
 			Factor:      "Order Size vs Risk Tolerance",
 			Impact:      RiskImpactHigh,
-			Score:       (orderValue / userProfile.MaxOrderValue) * 20,
+			Score:       s.percentOf(orderValue, userProfile.MaxOrderValue) / 100 * 20,
 			Description: "Order size may exceed user's risk tolerance",
 		})
 	}
@@ -560,7 +909,7 @@ func (s *riskManagementService) assessTradingLimitsRisk(order *domain.Order, ris
 	}
 
 	orderValue := order.CalculateOrderValue()
-	utilizationPercent := (orderValue / limits.RemainingDailyLimit) * 100
+	utilizationPercent := s.percentOf(orderValue, limits.RemainingDailyLimit)
 
 	if utilizationPercent > 80 {
 		assessment.RiskFactors = append(assessment.RiskFactors, RiskFactor{
@@ -636,7 +985,7 @@ func (s *riskManagementService) calculateUserRiskScore(order *domain.Order, risk
 	}
 
 	orderValue := order.CalculateOrderValue()
-	utilizationPercent := (orderValue / userProfile.MaxOrderValue) * 100
+	utilizationPercent := s.percentOf(orderValue, userProfile.MaxOrderValue)
 
 	// Higher utilization = higher risk score
 	return utilizationPercent * 0.8, nil
@@ -681,3 +1030,9 @@ func min(a, b float64) float64 {
 	}
 	return b
 }
+
+// percentOf returns part as a percentage of whole for risk scoring. A zero whole (an empty balance
+// or an exhausted limit) scores as the configured zero denominator percent instead of Inf or NaN.
+func (s *riskManagementService) percentOf(part, whole float64) float64 {
+	return ratioOr(part, whole, s.zeroDenominatorPercent/100) * 100
+}
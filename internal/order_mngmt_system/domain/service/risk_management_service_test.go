@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -407,6 +408,78 @@ func TestCheckTradingLimits(t *testing.T) {
 	}
 }
 
+func TestCheckPositionLimits_AccountGroup(t *testing.T) {
+	group := AccountGroup{ID: "family", AccountIDs: []string{"user1", "user2"}, MaxPositionSize: 30000.0}
+	service := NewRiskManagementService(RiskManagementConfig{ConcentrationLimit: 20.0, AccountGroups: []AccountGroup{group}})
+
+	setupMocks := func(linkedValue float64) *MockRiskDataClient {
+		mockClient := new(MockRiskDataClient)
+		ownPosition := createTestPositionExposure("AAPL")
+		ownPosition.CurrentValue = 5000.0
+		linkedPosition := createTestPositionExposure("AAPL")
+		linkedPosition.CurrentValue = linkedValue
+
+		mockClient.On("GetPositionExposure", "user1", "AAPL").Return(ownPosition, nil)
+		mockClient.On("GetPositionExposure", "user2", "AAPL").Return(linkedPosition, nil)
+		mockClient.On("GetUserRiskProfile", "user1").Return(createTestUserRiskProfile("user1"), nil)
+		mockClient.On("GetAccountBalance", "user1").Return(createTestAccountBalance(), nil)
+		return mockClient
+	}
+
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	t.Run("within group limit", func(t *testing.T) {
+		err := service.CheckPositionLimits(order, setupMocks(5000.0))
+		assert.NoError(t, err)
+	})
+
+	t.Run("per-account limits pass but combined exposure exceeds group limit", func(t *testing.T) {
+		err := service.CheckPositionLimits(order, setupMocks(20000.0))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "combined position value 40000.00 across account group family would exceed group limit 30000.00")
+	})
+
+	t.Run("accounts outside a group are checked individually", func(t *testing.T) {
+		mockClient := new(MockRiskDataClient)
+		position := createTestPositionExposure("AAPL")
+		position.CurrentValue = 5000.0
+		mockClient.On("GetPositionExposure", "user3", "AAPL").Return(position, nil)
+		mockClient.On("GetUserRiskProfile", "user3").Return(createTestUserRiskProfile("user3"), nil)
+		mockClient.On("GetAccountBalance", "user3").Return(createTestAccountBalance(), nil)
+
+		otherOrder := createTestOrder("user3", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+		assert.NoError(t, service.CheckPositionLimits(otherOrder, mockClient))
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCheckTradingLimits_AccountGroup(t *testing.T) {
+	group := AccountGroup{ID: "family", AccountIDs: []string{"user1", "user2"}, DailyTradingLimit: 50000.0}
+	service := NewRiskManagementService(RiskManagementConfig{AccountGroups: []AccountGroup{group}})
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	setupMocks := func(linkedUsed float64) *MockRiskDataClient {
+		mockClient := new(MockRiskDataClient)
+		ownLimits := createTestTradingLimits()
+		linkedLimits := createTestTradingLimits()
+		linkedLimits.DailyTradingUsed = linkedUsed
+
+		mockClient.On("GetUserTradingLimits", "user1").Return(ownLimits, nil)
+		mockClient.On("GetUserTradingLimits", "user2").Return(linkedLimits, nil)
+		return mockClient
+	}
+
+	t.Run("within group limit", func(t *testing.T) {
+		assert.NoError(t, service.CheckTradingLimits(order, setupMocks(10000.0)))
+	})
+
+	t.Run("per-account limits pass but combined trading exceeds group limit", func(t *testing.T) {
+		err := service.CheckTradingLimits(order, setupMocks(30000.0))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "order value 15000.00 exceeds remaining daily limit 10000.00 of account group family")
+	})
+}
+
 func TestAssessMarketRisk(t *testing.T) {
 	service := NewRiskManagementServiceWithDefaults()
 	mockClient := new(MockRiskDataClient)
@@ -653,6 +726,156 @@ func TestCalculateRiskScore(t *testing.T) {
 	}
 }
 
+func TestExplainRiskScore_ContributionsSumToTotal(t *testing.T) {
+	service := NewRiskManagementServiceWithDefaults()
+	mockClient := new(MockRiskDataClient)
+	setupDefaultMockExpectations(mockClient, "user1", "AAPL")
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	breakdown, err := service.ExplainRiskScore(order, mockClient)
+
+	require.NoError(t, err)
+	require.Len(t, breakdown.Components, 4)
+
+	sum := 0.0
+	for _, component := range breakdown.Components {
+		assert.True(t, component.Available, component.Component)
+		assert.InDelta(t, component.RawScore*component.Weight, component.Contribution, 1e-9, component.Component)
+		sum += component.Contribution
+	}
+	assert.InDelta(t, breakdown.TotalScore, sum, 1e-9)
+
+	// Market 15*0.4 + concentration 60*0.3 + user profile 48*0.2 + order size 10*0.1
+	assert.InDelta(t, 34.6, breakdown.TotalScore, 1e-9)
+	assert.Equal(t, "Concentration Risk", breakdown.Components[1].Component)
+	assert.InDelta(t, 18.0, breakdown.Components[1].Contribution, 1e-9)
+
+	score, err := service.CalculateRiskScore(order, mockClient)
+	require.NoError(t, err)
+	assert.InDelta(t, breakdown.TotalScore, score, 1e-9)
+}
+
+func TestExplainRiskScore_CustomWeights(t *testing.T) {
+	service := NewRiskManagementService(RiskManagementConfig{
+		ConcentrationLimit: 20.0,
+		ScoreWeights:       RiskScoreWeights{Market: 0.1, Concentration: 0.6, UserProfile: 0.2, OrderSize: 0.1},
+	})
+	mockClient := new(MockRiskDataClient)
+	setupDefaultMockExpectations(mockClient, "user1", "AAPL")
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	breakdown, err := service.ExplainRiskScore(order, mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.6, breakdown.Components[1].Weight)
+	assert.InDelta(t, 15*0.1+60*0.6+48*0.2+10*0.1, breakdown.TotalScore, 1e-9)
+}
+
+func TestExplainRiskScore_UnavailableComponent(t *testing.T) {
+	service := NewRiskManagementServiceWithDefaults()
+	mockClient := new(MockRiskDataClient)
+	mockClient.On("GetMarketVolatility", "AAPL").Return(nil, errors.New("market data unavailable"))
+	mockClient.On("GetPositionExposure", "user1", "AAPL").Return(createTestPositionExposure("AAPL"), nil)
+	mockClient.On("GetAccountBalance", "user1").Return(createTestAccountBalance(), nil)
+	mockClient.On("GetUserRiskProfile", "user1").Return(createTestUserRiskProfile("user1"), nil)
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	breakdown, err := service.ExplainRiskScore(order, mockClient)
+
+	require.NoError(t, err)
+	market := breakdown.Components[0]
+	assert.False(t, market.Available)
+	assert.Zero(t, market.Contribution)
+	assert.Contains(t, market.Description, "market data unavailable")
+
+	sum := 0.0
+	for _, component := range breakdown.Components {
+		sum += component.Contribution
+	}
+	assert.InDelta(t, breakdown.TotalScore, sum, 1e-9)
+	assert.InDelta(t, 28.6, breakdown.TotalScore, 1e-9)
+}
+
+func TestRecommendOrderSize_HigherVolatilityYieldsSmallerSize(t *testing.T) {
+	service := NewRiskManagementServiceWithDefaults()
+	mockClient := new(MockRiskDataClient)
+	for _, symbol := range []string{"CALM", "WILD"} {
+		mockClient.On("GetUserRiskProfile", "user1").Return(createTestUserRiskProfile("user1"), nil)
+		mockClient.On("GetPositionExposure", "user1", symbol).Return(createTestPositionExposure(symbol), nil)
+		mockClient.On("GetAccountBalance", "user1").Return(createTestAccountBalance(), nil)
+	}
+	mockClient.On("GetMarketVolatility", "CALM").Return(createTestMarketVolatility("CALM", false), nil)
+	mockClient.On("GetMarketVolatility", "WILD").Return(createTestMarketVolatility("WILD", true), nil)
+
+	calm, err := service.RecommendOrderSize("user1", "CALM", 100.0, RiskLevelMedium, mockClient)
+	require.NoError(t, err)
+	wild, err := service.RecommendOrderSize("user1", "WILD", 100.0, RiskLevelMedium, mockClient)
+	require.NoError(t, err)
+
+	assert.Greater(t, wild.RecommendedQuantity, 0.0)
+	assert.Less(t, wild.RecommendedQuantity, calm.RecommendedQuantity)
+	for _, recommendation := range []*OrderSizeRecommendation{calm, wild} {
+		assert.Equal(t, 40.0, recommendation.MaxRiskScore)
+		assert.Less(t, recommendation.RiskScore, recommendation.MaxRiskScore)
+		assert.Equal(t, OrderSizeLimitedByRisk, recommendation.LimitedBy)
+
+		// One more unit would leave the target level
+		next := createTestOrder("user1", recommendation.Symbol, domain.OrderSideBuy, domain.OrderTypeLimit, recommendation.RecommendedQuantity+1, floatPtr(100.0))
+		score, err := service.CalculateRiskScore(next, mockClient)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, score, recommendation.MaxRiskScore)
+	}
+}
+
+func TestRecommendOrderSize_LimitedByAvailableBalance(t *testing.T) {
+	service := NewRiskManagementServiceWithDefaults()
+	mockClient := new(MockRiskDataClient)
+	mockClient.On("GetUserRiskProfile", "user1").Return(createTestUserRiskProfile("user1"), nil)
+	mockClient.On("GetPositionExposure", "user1", "AAPL").Return(createTestPositionExposure("AAPL"), nil)
+	mockClient.On("GetAccountBalance", "user1").Return(&AccountBalance{TotalBalance: 100000.0, AvailableBalance: 1050.0}, nil)
+	mockClient.On("GetMarketVolatility", "AAPL").Return(createTestMarketVolatility("AAPL", false), nil)
+
+	recommendation, err := service.RecommendOrderSize("user1", "AAPL", 100.0, RiskLevelVeryHigh, mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, recommendation.AffordableQuantity)
+	assert.Equal(t, 10.0, recommendation.RecommendedQuantity)
+	assert.Equal(t, OrderSizeLimitedByBalance, recommendation.LimitedBy)
+}
+
+func TestRecommendOrderSize_VolatilityUnavailable(t *testing.T) {
+	service := NewRiskManagementServiceWithDefaults()
+	mockClient := new(MockRiskDataClient)
+	mockClient.On("GetMarketVolatility", "AAPL").Return(nil, errors.New("volatility feed down"))
+
+	_, err := service.RecommendOrderSize("user1", "AAPL", 100.0, RiskLevelMedium, mockClient)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get market volatility")
+}
+
+func TestParseRiskLevel(t *testing.T) {
+	level, err := ParseRiskLevel("very_high")
+	require.NoError(t, err)
+	assert.Equal(t, RiskLevelVeryHigh, level)
+
+	_, err = ParseRiskLevel("moderate")
+	assert.Error(t, err)
+}
+
+func TestAssessOrderRisk_IncludesScoreBreakdown(t *testing.T) {
+	service := NewRiskManagementServiceWithDefaults()
+	mockClient := new(MockRiskDataClient)
+	setupDefaultMockExpectations(mockClient, "user1", "AAPL")
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	assessment, err := service.AssessOrderRisk(order, mockClient)
+
+	require.NoError(t, err)
+	require.NotNil(t, assessment.ScoreBreakdown)
+	assert.Equal(t, assessment.RiskScore, assessment.ScoreBreakdown.TotalScore)
+}
+
 func TestAssessOrderRisk(t *testing.T) {
 	service := NewRiskManagementServiceWithDefaults()
 	mockClient := new(MockRiskDataClient)
@@ -1302,6 +1525,82 @@ func TestAssessOrderRisk_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestAssessOrderRisk_DegradedMode(t *testing.T) {
+	newDegradedService := func() RiskManagementService {
+		return NewRiskManagementService(RiskManagementConfig{
+			MaxRiskScore:            80.0,
+			HighRiskThreshold:       60.0,
+			ConcentrationLimit:      20.0,
+			VolatilityThreshold:     25.0,
+			ManualApprovalThreshold: 70.0,
+			DegradedMode:            DefaultDegradedModeConfig(),
+		})
+	}
+
+	t.Run("full assessment when risk data is available", func(t *testing.T) {
+		service := newDegradedService()
+		mockClient := new(MockRiskDataClient)
+		setupDefaultMockExpectations(mockClient, "user1", "AAPL")
+		order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+		assessment, err := service.AssessOrderRisk(order, mockClient)
+
+		require.NoError(t, err)
+		assert.False(t, assessment.Degraded)
+		require.NotNil(t, assessment.ScoreBreakdown)
+		assert.Equal(t, assessment.ScoreBreakdown.TotalScore, assessment.RiskScore)
+		assert.Equal(t, assessment.RiskScore <= 80.0, assessment.IsApproved)
+		mockClient.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name                     string
+		quantity                 float64
+		expectedApproved         bool
+		expectedRequiresApproval bool
+	}{
+		{name: "small order approved without review", quantity: 10.0, expectedApproved: true, expectedRequiresApproval: false},
+		{name: "order above approval threshold needs review", quantity: 50.0, expectedApproved: true, expectedRequiresApproval: true},
+		{name: "order above degraded limit rejected", quantity: 100.0, expectedApproved: false, expectedRequiresApproval: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newDegradedService()
+			mockClient := new(MockRiskDataClient)
+			mockClient.On("GetMarketVolatility", "AAPL").Return(createTestMarketVolatility("AAPL", false), nil)
+			mockClient.On("GetPositionExposure", "user1", "AAPL").Return(createTestPositionExposure("AAPL"), nil)
+			mockClient.On("GetAccountBalance", "user1").Return(createTestAccountBalance(), nil)
+			mockClient.On("GetUserRiskProfile", "user1").Return(nil, errors.New("risk service unavailable"))
+			order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, tt.quantity, floatPtr(150.0))
+
+			assessment, err := service.AssessOrderRisk(order, mockClient)
+
+			require.NoError(t, err)
+			assert.True(t, assessment.Degraded)
+			assert.Equal(t, RiskLevelHigh, assessment.RiskLevel)
+			assert.Equal(t, tt.expectedApproved, assessment.IsApproved)
+			assert.Equal(t, tt.expectedRequiresApproval, assessment.RequiresApproval)
+			require.Len(t, assessment.RiskFactors, 1)
+			assert.Contains(t, assessment.RiskFactors[0].Description, "risk service unavailable")
+		})
+	}
+
+	t.Run("disabled degraded mode still fails", func(t *testing.T) {
+		service := NewRiskManagementServiceWithDefaults()
+		mockClient := new(MockRiskDataClient)
+		mockClient.On("GetMarketVolatility", "AAPL").Return(createTestMarketVolatility("AAPL", false), nil)
+		mockClient.On("GetPositionExposure", "user1", "AAPL").Return(createTestPositionExposure("AAPL"), nil)
+		mockClient.On("GetAccountBalance", "user1").Return(createTestAccountBalance(), nil)
+		mockClient.On("GetUserRiskProfile", "user1").Return(nil, errors.New("risk service unavailable"))
+		order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10.0, floatPtr(150.0))
+
+		_, err := service.AssessOrderRisk(order, mockClient)
+
+		require.Error(t, err)
+	})
+}
+
 func TestHelperFunctions_EdgeCases(t *testing.T) {
 	service := NewRiskManagementServiceWithDefaults().(*riskManagementService)
 
@@ -1371,3 +1670,73 @@ func BenchmarkCalculateRiskScore(b *testing.B) {
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+func TestRiskManagementService_ZeroDenominators(t *testing.T) {
+	order := createTestOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 100.0, floatPtr(150.0))
+
+	newZeroBalanceClient := func(positionValue float64) *MockRiskDataClient {
+		mockClient := new(MockRiskDataClient)
+		position := createTestPositionExposure("AAPL")
+		position.CurrentValue = positionValue
+		balance := createTestAccountBalance()
+		balance.TotalBalance = 0
+		mockClient.On("GetUserRiskProfile", "user1").Return(createTestUserRiskProfile("user1"), nil)
+		mockClient.On("GetPositionExposure", "user1", "AAPL").Return(position, nil)
+		mockClient.On("GetAccountBalance", "user1").Return(balance, nil)
+		mockClient.On("GetMarketVolatility", "AAPL").Return(createTestMarketVolatility("AAPL", false), nil)
+		mockClient.On("GetUserTradingLimits", "user1").Return(createTestTradingLimits(), nil)
+		return mockClient
+	}
+
+	t.Run("zero total balance rejects the position check with a typed error", func(t *testing.T) {
+		service := NewRiskManagementServiceWithDefaults()
+
+		err := service.CheckPositionLimits(order, newZeroBalanceClient(1000.0))
+		assert.ErrorIs(t, err, ErrZeroDenominator)
+	})
+
+	t.Run("zero total balance scores as the configured concentration", func(t *testing.T) {
+		service := NewRiskManagementService(RiskManagementConfig{
+			MaxRiskScore:           80.0,
+			HighRiskThreshold:      60.0,
+			ConcentrationLimit:     20.0,
+			ZeroDenominatorPercent: 50.0,
+		})
+
+		assessment, err := service.AssessConcentrationRisk(order, newZeroBalanceClient(1000.0))
+		require.NoError(t, err)
+		require.Len(t, assessment.RiskFactors, 1)
+		assert.Equal(t, 50.0, assessment.RiskFactors[0].Score)
+		assert.False(t, math.IsInf(assessment.RiskScore, 0) || math.IsNaN(assessment.RiskScore))
+
+		score, err := service.CalculateRiskScore(order, newZeroBalanceClient(1000.0))
+		require.NoError(t, err)
+		assert.False(t, math.IsInf(score, 0) || math.IsNaN(score))
+	})
+
+	t.Run("zero position value over a zero balance has no concentration", func(t *testing.T) {
+		service := NewRiskManagementServiceWithDefaults().(*riskManagementService)
+
+		assert.Equal(t, 0.0, service.percentOf(0, 0))
+		assert.Equal(t, 100.0, service.percentOf(1000, 0))
+	})
+
+	t.Run("exhausted daily limit reports full utilization", func(t *testing.T) {
+		service := NewRiskManagementServiceWithDefaults()
+		mockClient := new(MockRiskDataClient)
+		limits := createTestTradingLimits()
+		limits.RemainingDailyLimit = 0
+		mockClient.On("GetUserRiskProfile", "user1").Return(createTestUserRiskProfile("user1"), nil)
+		mockClient.On("GetPositionExposure", "user1", "AAPL").Return(createTestPositionExposure("AAPL"), nil)
+		mockClient.On("GetAccountBalance", "user1").Return(createTestAccountBalance(), nil)
+		mockClient.On("GetMarketVolatility", "AAPL").Return(createTestMarketVolatility("AAPL", false), nil)
+		mockClient.On("GetUserTradingLimits", "user1").Return(limits, nil)
+
+		assessment, err := service.AssessOrderRisk(order, mockClient)
+		require.NoError(t, err)
+		for _, factor := range assessment.RiskFactors {
+			assert.False(t, math.IsInf(factor.Score, 0) || math.IsNaN(factor.Score), factor.Factor)
+		}
+		assert.False(t, math.IsInf(assessment.RiskScore, 0) || math.IsNaN(assessment.RiskScore))
+	})
+}
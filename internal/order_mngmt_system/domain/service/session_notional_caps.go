@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// MarketSessionPhase splits the trading day finer than TradingSession, separating the auction
+// windows around the open and the close from continuous trading
+type MarketSessionPhase string
+
+const (
+	MarketSessionPhasePreMarket      MarketSessionPhase = "PRE_MARKET"
+	MarketSessionPhaseOpeningAuction MarketSessionPhase = "OPENING_AUCTION"
+	MarketSessionPhaseContinuous     MarketSessionPhase = "CONTINUOUS"
+	MarketSessionPhaseClosingAuction MarketSessionPhase = "CLOSING_AUCTION"
+	MarketSessionPhasePostMarket     MarketSessionPhase = "POST_MARKET"
+	MarketSessionPhaseClosed         MarketSessionPhase = "CLOSED"
+)
+
+// AuctionWindows sets how long the auction phases at each end of the regular session last
+type AuctionWindows struct {
+	Opening time.Duration // Time after the market open treated as the opening auction
+	Closing time.Duration // Time before the market close treated as the closing auction
+}
+
+// PhaseAt returns the session phase the given moment falls in. Hours without session times have
+// no auction windows, so an open market is always in continuous trading.
+func (h *TradingHours) PhaseAt(now time.Time, windows AuctionWindows) MarketSessionPhase {
+	switch h.SessionAt(now) {
+	case TradingSessionPreMarket:
+		return MarketSessionPhasePreMarket
+	case TradingSessionPostMarket:
+		return MarketSessionPhasePostMarket
+	case TradingSessionClosed:
+		return MarketSessionPhaseClosed
+	}
+
+	if h.MarketOpen.IsZero() || h.MarketClose.IsZero() {
+		return MarketSessionPhaseContinuous
+	}
+
+	if windows.Opening > 0 && now.Before(h.MarketOpen.Add(windows.Opening)) {
+		return MarketSessionPhaseOpeningAuction
+	}
+	if windows.Closing > 0 && !now.Before(h.MarketClose.Add(-windows.Closing)) {
+		return MarketSessionPhaseClosingAuction
+	}
+	return MarketSessionPhaseContinuous
+}
+
+// SessionNotionalCaps limits the value of a single order by order type and session phase, so risk
+// can hold orders tighter in the volatile auctions than in continuous trading
+type SessionNotionalCaps struct {
+	AuctionWindows AuctionWindows
+	Caps           map[MarketSessionPhase]map[domain.OrderType]float64 // Phases and order types without a positive cap are not limited
+}
+
+// DefaultSessionNotionalCaps returns the session caps applied by default
+func DefaultSessionNotionalCaps() SessionNotionalCaps {
+	return SessionNotionalCaps{
+		AuctionWindows: AuctionWindows{
+			Opening: 15 * time.Minute, // Prices settle within the first quarter hour after the open
+			Closing: 10 * time.Minute, // Closing flows pile up in the last ten minutes
+		},
+		Caps: map[MarketSessionPhase]map[domain.OrderType]float64{
+			MarketSessionPhaseOpeningAuction: {
+				domain.OrderTypeMarket:   50000,  // Market orders fill at whatever the auction prints
+				domain.OrderTypeStopLoss: 50000,  // Stops triggered at the open fill like market orders
+				domain.OrderTypeLimit:    250000, // Limits bound the price but still move a thin book
+			},
+			MarketSessionPhaseClosingAuction: {
+				domain.OrderTypeMarket:   100000, // Closing auctions are deeper than the open
+				domain.OrderTypeStopLoss: 100000,
+				domain.OrderTypeLimit:    500000,
+			},
+		},
+	}
+}
+
+// CapFor returns the notional cap for the order type in the phase. Triggered order types fall back
+// to the cap of the type they activate as.
+func (c SessionNotionalCaps) CapFor(phase MarketSessionPhase, orderType domain.OrderType) (float64, bool) {
+	caps := c.Caps[phase]
+	if limit := caps[orderType]; limit > 0 {
+		return limit, true
+	}
+	if limit := caps[orderType.ActivatesAs()]; limit > 0 {
+		return limit, true
+	}
+	return 0, false
+}
+
+// sessionNotional values the order at its limit price, or at the market price captured at
+// submission when it has none. Orders that cannot be valued yet report false.
+func sessionNotional(order *domain.Order) (float64, bool) {
+	if value := order.CalculateOrderValue(); value > 0 {
+		return value, true
+	}
+	if marketPrice := order.MarketPriceAtSubmission(); marketPrice != nil && *marketPrice > 0 {
+		return *marketPrice * order.Quantity(), true
+	}
+	return 0, false
+}
+
+// validateSessionNotionalCap rejects orders whose value exceeds the cap of the current session phase
+func (s *orderValidationService) validateSessionNotionalCap(order *domain.Order, tradingHours *TradingHours, now time.Time, result *ValidationResult) {
+	phase := tradingHours.PhaseAt(now, s.sessionNotionalCaps.AuctionWindows)
+	limit, capped := s.sessionNotionalCaps.CapFor(phase, order.OrderType())
+	if !capped {
+		return
+	}
+
+	value, known := sessionNotional(order)
+	if !known || value <= limit {
+		return
+	}
+
+	result.IsValid = false
+	result.Errors = append(result.Errors, fmt.Sprintf("Order value %.2f exceeds the %.2f cap for %s orders during the %s phase",
+		value, limit, order.OrderType(), phase))
+}
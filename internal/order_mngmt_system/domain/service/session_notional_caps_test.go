@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTradingHours_PhaseAt(t *testing.T) {
+	open := time.Date(2024, 3, 4, 13, 0, 0, 0, time.UTC)
+	hours := &TradingHours{
+		MarketOpen:      open,
+		MarketClose:     open.Add(7 * time.Hour),
+		ExtendedHours:   true,
+		PreMarketOpen:   open.Add(-time.Hour),
+		PostMarketClose: open.Add(8 * time.Hour),
+	}
+	windows := AuctionWindows{Opening: 15 * time.Minute, Closing: 10 * time.Minute}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want MarketSessionPhase
+	}{
+		{"Pre-market", open.Add(-30 * time.Minute), MarketSessionPhasePreMarket},
+		{"At the open", open, MarketSessionPhaseOpeningAuction},
+		{"Inside the opening window", open.Add(14 * time.Minute), MarketSessionPhaseOpeningAuction},
+		{"After the opening window", open.Add(15 * time.Minute), MarketSessionPhaseContinuous},
+		{"Mid-session", open.Add(3 * time.Hour), MarketSessionPhaseContinuous},
+		{"Closing window", open.Add(7*time.Hour - 5*time.Minute), MarketSessionPhaseClosingAuction},
+		{"Post-market", open.Add(7*time.Hour + 30*time.Minute), MarketSessionPhasePostMarket},
+		{"Overnight", open.Add(12 * time.Hour), MarketSessionPhaseClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hours.PhaseAt(tt.at, windows))
+		})
+	}
+
+	assert.Equal(t, MarketSessionPhaseContinuous, (&TradingHours{IsOpen: true}).PhaseAt(open, windows))
+}
+
+func TestSessionNotionalCaps_CapFor(t *testing.T) {
+	caps := DefaultSessionNotionalCaps()
+
+	limit, capped := caps.CapFor(MarketSessionPhaseOpeningAuction, domain.OrderTypeMarket)
+	assert.True(t, capped)
+	assert.Equal(t, 50000.0, limit)
+
+	limit, capped = caps.CapFor(MarketSessionPhaseOpeningAuction, domain.OrderTypeLimitIfTouched)
+	assert.True(t, capped, "if-touched orders take the cap of the type they activate as")
+	assert.Equal(t, 250000.0, limit)
+
+	_, capped = caps.CapFor(MarketSessionPhaseContinuous, domain.OrderTypeMarket)
+	assert.False(t, capped)
+}
+
+func TestOrderValidationService_SessionNotionalCap(t *testing.T) {
+	service := NewOrderValidationServiceWithDefaults().(*orderValidationService)
+	price := 100.0
+	// 1,000 shares at 100 is worth 100,000: over the 50,000 opening auction cap for market orders
+	order, _ := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeMarket, 1000, nil)
+	order.SetMarketDataContext(price, time.Now())
+
+	validate := func(hours *TradingHours) *ValidationResult {
+		marketDataClient := new(MockMarketDataClient)
+		marketDataClient.On("IsMarketOpen", mock.Anything, "PETR4").Return(true, nil)
+		marketDataClient.On("GetTradingHours", mock.Anything, "PETR4").Return(hours, nil)
+
+		result := &ValidationResult{IsValid: true, Errors: make([]string, 0), Warnings: make([]string, 0)}
+		service.validateTradingHoursStep(context.Background(), order, marketDataClient, result)
+		return result
+	}
+
+	now := time.Now()
+	midSession := validate(&TradingHours{IsOpen: true, MarketOpen: now.Add(-3 * time.Hour), MarketClose: now.Add(3 * time.Hour)})
+	assert.True(t, midSession.IsValid, "unexpected errors: %v", midSession.Errors)
+
+	openingAuction := validate(&TradingHours{IsOpen: true, MarketOpen: now.Add(-5 * time.Minute), MarketClose: now.Add(6 * time.Hour)})
+	assert.False(t, openingAuction.IsValid)
+	assert.Contains(t, openingAuction.Errors, "Order value 100000.00 exceeds the 50000.00 cap for MARKET orders during the OPENING_AUCTION phase")
+}
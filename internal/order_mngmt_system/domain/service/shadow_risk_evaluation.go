@@ -0,0 +1,304 @@
+package service
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// RiskDivergence is one decision where the candidate risk model disagreed with production
+type RiskDivergence struct {
+	Method             string
+	OrderID            string
+	UserID             string
+	Symbol             string
+	ProductionScore    float64
+	CandidateScore     float64
+	ProductionLevel    RiskLevel
+	CandidateLevel     RiskLevel
+	ProductionApproved bool
+	CandidateApproved  bool
+	ProductionError    string
+	CandidateError     string
+	ObservedAt         time.Time
+}
+
+// ApprovalDiffers reports whether the two models reached different verdicts on the order
+func (d RiskDivergence) ApprovalDiffers() bool {
+	return d.ProductionApproved != d.CandidateApproved
+}
+
+// String describes the divergence for logs
+func (d RiskDivergence) String() string {
+	return fmt.Sprintf("%s order %s (%s): production score %.2f approved=%t, candidate score %.2f approved=%t",
+		d.Method, d.OrderID, d.Symbol, d.ProductionScore, d.ProductionApproved, d.CandidateScore, d.CandidateApproved)
+}
+
+// RiskShadowSummary counts the shadow evaluations run so far
+type RiskShadowSummary struct {
+	Evaluations          uint64
+	Divergences          uint64
+	ApprovalDivergences  uint64 // Divergences where the models disagreed on approving the order
+	CandidateFailures    uint64 // Candidate errors or panics; production was served regardless
+	MaxScoreDifference   float64
+	TotalScoreDifference float64
+}
+
+// RiskDivergenceRecorder collects the outcome of shadow risk evaluations
+type RiskDivergenceRecorder interface {
+	// RecordEvaluation counts one shadow evaluation and keeps the divergence, if any
+	RecordEvaluation(divergence *RiskDivergence, candidateFailed bool)
+	// Divergences returns the retained divergences, oldest first
+	Divergences() []RiskDivergence
+	// Summary returns the evaluation counts
+	Summary() RiskShadowSummary
+}
+
+type inMemoryRiskDivergenceRecorder struct {
+	maxDivergences int
+
+	mu          sync.Mutex
+	divergences []RiskDivergence
+	summary     RiskShadowSummary
+}
+
+// NewInMemoryRiskDivergenceRecorder keeps the most recent divergences in memory. A non-positive limit keeps them all.
+func NewInMemoryRiskDivergenceRecorder(maxDivergences int) RiskDivergenceRecorder {
+	return &inMemoryRiskDivergenceRecorder{
+		maxDivergences: maxDivergences,
+		divergences:    make([]RiskDivergence, 0),
+	}
+}
+
+func (r *inMemoryRiskDivergenceRecorder) RecordEvaluation(divergence *RiskDivergence, candidateFailed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.summary.Evaluations++
+	if candidateFailed {
+		r.summary.CandidateFailures++
+	}
+	if divergence == nil {
+		return
+	}
+
+	r.summary.Divergences++
+	if divergence.ApprovalDiffers() {
+		r.summary.ApprovalDivergences++
+	}
+	scoreDifference := math.Abs(divergence.ProductionScore - divergence.CandidateScore)
+	r.summary.TotalScoreDifference += scoreDifference
+	if scoreDifference > r.summary.MaxScoreDifference {
+		r.summary.MaxScoreDifference = scoreDifference
+	}
+
+	r.divergences = append(r.divergences, *divergence)
+	if r.maxDivergences > 0 && len(r.divergences) > r.maxDivergences {
+		r.divergences = r.divergences[len(r.divergences)-r.maxDivergences:]
+	}
+}
+
+func (r *inMemoryRiskDivergenceRecorder) Divergences() []RiskDivergence {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	divergences := make([]RiskDivergence, len(r.divergences))
+	copy(divergences, r.divergences)
+	return divergences
+}
+
+func (r *inMemoryRiskDivergenceRecorder) Summary() RiskShadowSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.summary
+}
+
+// loggingRiskDivergenceRecorder logs every divergence before handing it to the wrapped recorder
+type loggingRiskDivergenceRecorder struct {
+	RiskDivergenceRecorder
+}
+
+// NewLoggingRiskDivergenceRecorder wraps the recorder so divergences also reach the logs, where they
+// outlive the recorder's retention
+func NewLoggingRiskDivergenceRecorder(recorder RiskDivergenceRecorder) RiskDivergenceRecorder {
+	return &loggingRiskDivergenceRecorder{RiskDivergenceRecorder: recorder}
+}
+
+func (r *loggingRiskDivergenceRecorder) RecordEvaluation(divergence *RiskDivergence, candidateFailed bool) {
+	if divergence != nil {
+		log.Printf("Shadow risk divergence: %s", divergence)
+	}
+	r.RiskDivergenceRecorder.RecordEvaluation(divergence, candidateFailed)
+}
+
+// ShadowRiskConfig holds configuration for shadow evaluation of a candidate risk model
+type ShadowRiskConfig struct {
+	SampleRate     float64 // Share of orders, from 0 to 1, also evaluated by the candidate; raise it gradually during rollout
+	ScoreTolerance float64 // Score differences up to this are not recorded as divergences
+}
+
+// DefaultShadowRiskConfig returns the default shadow evaluation configuration
+func DefaultShadowRiskConfig() ShadowRiskConfig {
+	return ShadowRiskConfig{
+		SampleRate:     0.1, // Start by shadowing a tenth of orders to limit the extra risk data load
+		ScoreTolerance: 1.0, // Scores run from 0 to 100, so a point is rounding noise between models
+	}
+}
+
+// shadowRiskManagementService serves every decision from the production model and replays sampled
+// orders through the candidate, recording where the two disagree. Candidate errors and panics never
+// reach the caller; the candidate runs inline, so sampled calls take as long as both models together.
+type shadowRiskManagementService struct {
+	RiskManagementService
+	candidate RiskManagementService
+	recorder  RiskDivergenceRecorder
+	config    ShadowRiskConfig
+}
+
+// NewShadowRiskManagementService wraps the production service so sampled orders are also evaluated by
+// the candidate. A nil candidate or recorder returns the production service unwrapped.
+func NewShadowRiskManagementService(production, candidate RiskManagementService, recorder RiskDivergenceRecorder, config ShadowRiskConfig) RiskManagementService {
+	if candidate == nil || recorder == nil {
+		return production
+	}
+	return &shadowRiskManagementService{
+		RiskManagementService: production,
+		candidate:             candidate,
+		recorder:              recorder,
+		config:                config,
+	}
+}
+
+func (s *shadowRiskManagementService) AssessOrderRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	assessment, err := s.RiskManagementService.AssessOrderRisk(order, riskDataClient)
+	if !s.sampled(order) {
+		return assessment, err
+	}
+
+	divergence := s.newDivergence("AssessOrderRisk", order, err)
+	if assessment != nil {
+		divergence.ProductionScore = assessment.RiskScore
+		divergence.ProductionLevel = assessment.RiskLevel
+		divergence.ProductionApproved = assessment.IsApproved
+	}
+
+	panicked := s.runCandidate(divergence, func() error {
+		candidateAssessment, candidateErr := s.candidate.AssessOrderRisk(order, riskDataClient)
+		if candidateAssessment != nil {
+			divergence.CandidateScore = candidateAssessment.RiskScore
+			divergence.CandidateLevel = candidateAssessment.RiskLevel
+			divergence.CandidateApproved = candidateAssessment.IsApproved
+		}
+		return candidateErr
+	})
+
+	s.record(divergence, panicked || divergence.CandidateError != "", divergence.ProductionLevel != divergence.CandidateLevel)
+	return assessment, err
+}
+
+func (s *shadowRiskManagementService) ValidateRiskLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	err := s.RiskManagementService.ValidateRiskLimits(order, riskDataClient)
+	if !s.sampled(order) {
+		return err
+	}
+
+	divergence := s.newDivergence("ValidateRiskLimits", order, err)
+	divergence.ProductionApproved = err == nil
+
+	panicked := s.runCandidate(divergence, func() error {
+		candidateErr := s.candidate.ValidateRiskLimits(order, riskDataClient)
+		divergence.CandidateApproved = candidateErr == nil
+		return candidateErr
+	})
+
+	// A limit breach is the candidate's verdict, so only a panic counts as the candidate failing
+	s.record(divergence, panicked, false)
+	return err
+}
+
+func (s *shadowRiskManagementService) CalculateRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (float64, error) {
+	score, err := s.RiskManagementService.CalculateRiskScore(order, riskDataClient)
+	if !s.sampled(order) {
+		return score, err
+	}
+
+	divergence := s.newDivergence("CalculateRiskScore", order, err)
+	divergence.ProductionScore = score
+	divergence.ProductionApproved = err == nil
+
+	panicked := s.runCandidate(divergence, func() error {
+		candidateScore, candidateErr := s.candidate.CalculateRiskScore(order, riskDataClient)
+		divergence.CandidateScore = candidateScore
+		divergence.CandidateApproved = candidateErr == nil
+		return candidateErr
+	})
+
+	s.record(divergence, panicked || divergence.CandidateError != "", false)
+	return score, err
+}
+
+// sampled picks orders by a hash of their ID, so an order is either always or never shadowed and
+// raising the sample rate only adds orders to the shadowed set
+func (s *shadowRiskManagementService) sampled(order *domain.Order) bool {
+	if order == nil || s.config.SampleRate <= 0 {
+		return false
+	}
+	if s.config.SampleRate >= 1 {
+		return true
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(order.ID()))
+	return float64(hash.Sum32()%10000) < s.config.SampleRate*10000
+}
+
+func (s *shadowRiskManagementService) newDivergence(method string, order *domain.Order, productionErr error) *RiskDivergence {
+	divergence := &RiskDivergence{
+		Method:     method,
+		OrderID:    order.ID(),
+		UserID:     order.UserID(),
+		Symbol:     order.Symbol(),
+		ObservedAt: time.Now(),
+	}
+	if productionErr != nil {
+		divergence.ProductionError = productionErr.Error()
+	}
+	return divergence
+}
+
+// runCandidate evaluates the candidate, keeping its error on the divergence and containing any
+// panic, and reports whether it panicked
+func (s *shadowRiskManagementService) runCandidate(divergence *RiskDivergence, evaluate func() error) (panicked bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			divergence.CandidateError = fmt.Sprintf("panic: %v", recovered)
+			divergence.CandidateApproved = false
+			panicked = true
+		}
+	}()
+
+	if err := evaluate(); err != nil {
+		divergence.CandidateError = err.Error()
+	}
+	return false
+}
+
+// record keeps the divergence when the models differ in verdict, level, score beyond the tolerance,
+// or in whether they failed
+func (s *shadowRiskManagementService) record(divergence *RiskDivergence, candidateFailed bool, levelDiffers bool) {
+	diverged := levelDiffers ||
+		divergence.ApprovalDiffers() ||
+		math.Abs(divergence.ProductionScore-divergence.CandidateScore) > s.config.ScoreTolerance ||
+		(divergence.ProductionError == "") != (divergence.CandidateError == "")
+
+	if diverged {
+		s.recorder.RecordEvaluation(divergence, candidateFailed)
+		return
+	}
+	s.recorder.RecordEvaluation(nil, candidateFailed)
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedRiskModel returns the same assessment for every order
+type fixedRiskModel struct {
+	RiskManagementService
+	assessment *RiskAssessment
+	limitErr   error
+	panics     bool
+	calls      int
+}
+
+func (m *fixedRiskModel) AssessOrderRisk(order *domain.Order, riskDataClient IRiskDataClient) (*RiskAssessment, error) {
+	m.calls++
+	if m.panics {
+		panic("candidate bug")
+	}
+	assessment := *m.assessment
+	return &assessment, nil
+}
+
+func (m *fixedRiskModel) ValidateRiskLimits(order *domain.Order, riskDataClient IRiskDataClient) error {
+	m.calls++
+	return m.limitErr
+}
+
+func (m *fixedRiskModel) CalculateRiskScore(order *domain.Order, riskDataClient IRiskDataClient) (float64, error) {
+	m.calls++
+	return m.assessment.RiskScore, nil
+}
+
+func newShadowTestOrder(t *testing.T) *domain.Order {
+	price := 30.0
+	order, err := domain.NewOrder("user1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	require.NoError(t, err)
+	return order
+}
+
+func TestShadowRiskManagementService_RecordsDivergenceAndServesProduction(t *testing.T) {
+	production := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 40, RiskLevel: RiskLevelMedium, IsApproved: true}}
+	candidate := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 75, RiskLevel: RiskLevelHigh, IsApproved: false}}
+	recorder := NewInMemoryRiskDivergenceRecorder(10)
+	service := NewShadowRiskManagementService(production, candidate, recorder, ShadowRiskConfig{SampleRate: 1, ScoreTolerance: 1})
+	order := newShadowTestOrder(t)
+
+	assessment, err := service.AssessOrderRisk(order, nil)
+
+	require.NoError(t, err)
+	assert.True(t, assessment.IsApproved)
+	assert.Equal(t, 40.0, assessment.RiskScore)
+	assert.Equal(t, RiskLevelMedium, assessment.RiskLevel)
+
+	divergences := recorder.Divergences()
+	require.Len(t, divergences, 1)
+	assert.Equal(t, "AssessOrderRisk", divergences[0].Method)
+	assert.Equal(t, order.ID(), divergences[0].OrderID)
+	assert.Equal(t, 40.0, divergences[0].ProductionScore)
+	assert.Equal(t, 75.0, divergences[0].CandidateScore)
+	assert.True(t, divergences[0].ApprovalDiffers())
+
+	summary := recorder.Summary()
+	assert.Equal(t, uint64(1), summary.Evaluations)
+	assert.Equal(t, uint64(1), summary.ApprovalDivergences)
+	assert.Equal(t, 35.0, summary.MaxScoreDifference)
+}
+
+func TestShadowRiskManagementService_AgreementWithinToleranceIsNotDivergence(t *testing.T) {
+	production := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 40, RiskLevel: RiskLevelMedium, IsApproved: true}}
+	candidate := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 40.5, RiskLevel: RiskLevelMedium, IsApproved: true}}
+	recorder := NewInMemoryRiskDivergenceRecorder(10)
+	service := NewShadowRiskManagementService(production, candidate, recorder, ShadowRiskConfig{SampleRate: 1, ScoreTolerance: 1})
+
+	_, err := service.AssessOrderRisk(newShadowTestOrder(t), nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Divergences())
+	assert.Equal(t, uint64(1), recorder.Summary().Evaluations)
+}
+
+func TestShadowRiskManagementService_ValidateRiskLimitsReturnsProductionVerdict(t *testing.T) {
+	production := &fixedRiskModel{assessment: &RiskAssessment{}}
+	candidate := &fixedRiskModel{assessment: &RiskAssessment{}, limitErr: errors.New("order value exceeds limit")}
+	recorder := NewInMemoryRiskDivergenceRecorder(10)
+	service := NewShadowRiskManagementService(production, candidate, recorder, ShadowRiskConfig{SampleRate: 1})
+
+	err := service.ValidateRiskLimits(newShadowTestOrder(t), nil)
+
+	assert.NoError(t, err)
+	divergences := recorder.Divergences()
+	require.Len(t, divergences, 1)
+	assert.True(t, divergences[0].ProductionApproved)
+	assert.False(t, divergences[0].CandidateApproved)
+	assert.Equal(t, "order value exceeds limit", divergences[0].CandidateError)
+	assert.Equal(t, uint64(0), recorder.Summary().CandidateFailures)
+}
+
+func TestShadowRiskManagementService_CandidatePanicDoesNotReachCaller(t *testing.T) {
+	production := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 20, IsApproved: true}}
+	candidate := &fixedRiskModel{panics: true}
+	recorder := NewInMemoryRiskDivergenceRecorder(10)
+	service := NewShadowRiskManagementService(production, candidate, recorder, ShadowRiskConfig{SampleRate: 1})
+
+	var assessment *RiskAssessment
+	var err error
+	assert.NotPanics(t, func() {
+		assessment, err = service.AssessOrderRisk(newShadowTestOrder(t), nil)
+	})
+
+	require.NoError(t, err)
+	assert.True(t, assessment.IsApproved)
+	assert.Equal(t, uint64(1), recorder.Summary().CandidateFailures)
+	require.Len(t, recorder.Divergences(), 1)
+	assert.Contains(t, recorder.Divergences()[0].CandidateError, "candidate bug")
+}
+
+func TestShadowRiskManagementService_SampleRate(t *testing.T) {
+	production := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 20, IsApproved: true}}
+	candidate := &fixedRiskModel{assessment: &RiskAssessment{RiskScore: 20, IsApproved: true}}
+	recorder := NewInMemoryRiskDivergenceRecorder(10)
+
+	unsampled := NewShadowRiskManagementService(production, candidate, recorder, ShadowRiskConfig{SampleRate: 0})
+	_, err := unsampled.CalculateRiskScore(newShadowTestOrder(t), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, candidate.calls)
+
+	// Sampling is decided by order ID, so the same order is shadowed on every call or never
+	partial := NewShadowRiskManagementService(production, candidate, recorder, ShadowRiskConfig{SampleRate: 0.5})
+	order := newShadowTestOrder(t)
+	_, err = partial.CalculateRiskScore(order, nil)
+	require.NoError(t, err)
+	first := candidate.calls
+	_, err = partial.CalculateRiskScore(order, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first*2, candidate.calls)
+}
+
+func TestNewShadowRiskManagementService_WithoutCandidate(t *testing.T) {
+	production := &fixedRiskModel{assessment: &RiskAssessment{}}
+
+	service := NewShadowRiskManagementService(production, nil, NewInMemoryRiskDivergenceRecorder(10), DefaultShadowRiskConfig())
+
+	assert.Same(t, production, service)
+}
+
+func TestInMemoryRiskDivergenceRecorder_KeepsMostRecent(t *testing.T) {
+	recorder := NewInMemoryRiskDivergenceRecorder(2)
+
+	for _, orderID := range []string{"a", "b", "c"} {
+		recorder.RecordEvaluation(&RiskDivergence{OrderID: orderID}, false)
+	}
+
+	divergences := recorder.Divergences()
+	require.Len(t, divergences, 2)
+	assert.Equal(t, "b", divergences[0].OrderID)
+	assert.Equal(t, "c", divergences[1].OrderID)
+	assert.Equal(t, uint64(3), recorder.Summary().Divergences)
+}
+
+func TestLoggingRiskDivergenceRecorder_DelegatesToRecorder(t *testing.T) {
+	inner := NewInMemoryRiskDivergenceRecorder(10)
+	recorder := NewLoggingRiskDivergenceRecorder(inner)
+
+	recorder.RecordEvaluation(&RiskDivergence{OrderID: "a", ProductionApproved: true}, false)
+	recorder.RecordEvaluation(nil, true)
+
+	require.Len(t, recorder.Divergences(), 1)
+	assert.Equal(t, "a", recorder.Divergences()[0].OrderID)
+	assert.Equal(t, uint64(2), inner.Summary().Evaluations)
+	assert.Equal(t, uint64(1), inner.Summary().CandidateFailures)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ISymbolDirectory defines the interface for reading the searchable symbol list (dependency inversion)
+type ISymbolDirectory interface {
+	GetSearchableSymbols(ctx context.Context) ([]string, error)
+}
+
+// SymbolSuggestionService suggests close valid symbols when a symbol cannot be found
+type SymbolSuggestionService interface {
+	// SuggestSymbols returns searchable symbols close to the given one, closest first.
+	// An exact match returns no suggestions: the symbol exists, so alternatives would only mislead.
+	SuggestSymbols(ctx context.Context, symbol string) ([]string, error)
+}
+
+type symbolSuggestionService struct {
+	directory      ISymbolDirectory
+	maxSuggestions int
+	maxDistance    int
+}
+
+// SymbolSuggestionConfig holds configuration for symbol suggestions
+type SymbolSuggestionConfig struct {
+	MaxSuggestions int // Maximum number of suggestions returned
+	MaxDistance    int // Maximum edit distance for a symbol to be suggested
+}
+
+// NewSymbolSuggestionService creates a new instance of SymbolSuggestionService
+func NewSymbolSuggestionService(directory ISymbolDirectory, config SymbolSuggestionConfig) SymbolSuggestionService {
+	return &symbolSuggestionService{
+		directory:      directory,
+		maxSuggestions: config.MaxSuggestions,
+		maxDistance:    config.MaxDistance,
+	}
+}
+
+// NewSymbolSuggestionServiceWithDefaults creates a service with default configuration
+func NewSymbolSuggestionServiceWithDefaults(directory ISymbolDirectory) SymbolSuggestionService {
+	return NewSymbolSuggestionService(directory, SymbolSuggestionConfig{
+		MaxSuggestions: 3, // Up to 3 suggestions
+		MaxDistance:    2, // At most 2 edits away
+	})
+}
+
+// SuggestSymbols returns searchable symbols close to the given one, closest first
+func (s *symbolSuggestionService) SuggestSymbols(ctx context.Context, symbol string) ([]string, error) {
+	symbols, err := s.directory.GetSearchableSymbols(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get searchable symbols: %w", err)
+	}
+
+	query := strings.ToUpper(strings.TrimSpace(symbol))
+	type candidate struct {
+		symbol   string
+		distance int
+	}
+	candidates := make([]candidate, 0)
+
+	for _, known := range symbols {
+		normalized := strings.ToUpper(known)
+		if normalized == query {
+			return []string{}, nil
+		}
+
+		distance := symbolEditDistance(query, normalized)
+		if distance <= s.maxDistance {
+			candidates = append(candidates, candidate{symbol: known, distance: distance})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].symbol < candidates[j].symbol
+	})
+
+	suggestions := make([]string, 0, s.maxSuggestions)
+	for _, c := range candidates {
+		if len(suggestions) == s.maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, c.symbol)
+	}
+
+	return suggestions, nil
+}
+
+// symbolEditDistance counts insertions, deletions, substitutions and adjacent
+// transpositions, so a swapped pair like APPL/AAPL is a single edit
+func symbolEditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows, cols := len(ra)+1, len(rb)+1
+
+	d := make([][]int, rows)
+	for i := range d {
+		d[i] = make([]int, cols)
+		d[i][0] = i
+	}
+	for j := 0; j < cols; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			best := d[i-1][j] + 1
+			if insertion := d[i][j-1] + 1; insertion < best {
+				best = insertion
+			}
+			if substitution := d[i-1][j-1] + cost; substitution < best {
+				best = substitution
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				if transposition := d[i-2][j-2] + 1; transposition < best {
+					best = transposition
+				}
+			}
+			d[i][j] = best
+		}
+	}
+
+	return d[rows-1][cols-1]
+}
@@ -0,0 +1,89 @@
+package service
+
+import "time"
+
+// TradingSession identifies the part of the trading day an order is placed in
+type TradingSession string
+
+const (
+	TradingSessionRegular    TradingSession = "REGULAR"
+	TradingSessionPreMarket  TradingSession = "PRE_MARKET"
+	TradingSessionPostMarket TradingSession = "POST_MARKET"
+	TradingSessionClosed     TradingSession = "CLOSED"
+)
+
+// IsExtended reports whether the session is the pre-market or the post-market
+func (s TradingSession) IsExtended() bool {
+	return s == TradingSessionPreMarket || s == TradingSessionPostMarket
+}
+
+// SessionAt returns the session the given moment falls in. Hours without session times only
+// tell whether the regular session is open.
+func (h *TradingHours) SessionAt(now time.Time) TradingSession {
+	if h == nil {
+		return TradingSessionClosed
+	}
+
+	if h.MarketOpen.IsZero() || h.MarketClose.IsZero() {
+		if h.IsOpen {
+			return TradingSessionRegular
+		}
+		return TradingSessionClosed
+	}
+
+	if !now.Before(h.MarketOpen) && now.Before(h.MarketClose) {
+		return TradingSessionRegular
+	}
+
+	if h.ExtendedHours {
+		if !h.PreMarketOpen.IsZero() && !now.Before(h.PreMarketOpen) && now.Before(h.MarketOpen) {
+			return TradingSessionPreMarket
+		}
+		if !h.PostMarketClose.IsZero() && !now.Before(h.MarketClose) && now.Before(h.PostMarketClose) {
+			return TradingSessionPostMarket
+		}
+	}
+
+	return TradingSessionClosed
+}
+
+// ExtendedHoursRules changes how orders are priced and accepted during the pre-market and the
+// post-market, when books are thinner and prices move further between trades
+type ExtendedHoursRules struct {
+	LimitOnly              bool    // Reject market orders outside the regular session
+	PriceBandMultiplier    float64 // Widens the accepted distance between a limit price and the last price (values up to 1 keep the regular band)
+	SlippageMultiplier     float64 // Widens the slippage tolerance and its cap (values up to 1 keep the regular tolerance)
+	AssumeReducedLiquidity bool    // Assess liquidity one level lower than the book suggests
+}
+
+// DefaultExtendedHoursRules returns the rules applied to extended-session orders by default
+func DefaultExtendedHoursRules() ExtendedHoursRules {
+	return ExtendedHoursRules{
+		LimitOnly:              true, // Market orders fill far from the last price in thin sessions
+		PriceBandMultiplier:    2.0,  // Twice the regular price band
+		SlippageMultiplier:     2.0,  // Twice the regular slippage tolerance
+		AssumeReducedLiquidity: true, // Displayed depth is less reliable outside the regular session
+	}
+}
+
+func (r ExtendedHoursRules) priceBandMultiplier() float64 {
+	if r.PriceBandMultiplier > 1 {
+		return r.PriceBandMultiplier
+	}
+	return 1
+}
+
+func (r ExtendedHoursRules) slippageMultiplier() float64 {
+	if r.SlippageMultiplier > 1 {
+		return r.SlippageMultiplier
+	}
+	return 1
+}
+
+// reduceLiquidity lowers the level by one step when reduced liquidity is assumed
+func (r ExtendedHoursRules) reduceLiquidity(level LiquidityLevel) LiquidityLevel {
+	if !r.AssumeReducedLiquidity || level <= LiquidityLevelLow {
+		return level
+	}
+	return level - 1
+}
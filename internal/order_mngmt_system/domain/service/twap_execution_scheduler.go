@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// TWAPSlice is one child order of a TWAP parent order
+type TWAPSlice struct {
+	Index       int
+	Quantity    float64
+	ScheduledAt time.Time
+}
+
+// SubmittedSlice records when a slice was actually submitted
+type SubmittedSlice struct {
+	TWAPSlice
+	SubmittedAt time.Time
+	Delay       time.Duration // How far past its original schedule the slice was submitted
+}
+
+// TWAPExecutionReport summarizes the pacing of a TWAP execution
+type TWAPExecutionReport struct {
+	ParentOrderID   string
+	Submitted       []SubmittedSlice
+	PaceAdjustments []string
+	TotalDelay      time.Duration
+}
+
+// ITWAPSliceSubmitter submits a child slice of a TWAP order to the market
+type ITWAPSliceSubmitter interface {
+	SubmitSlice(ctx context.Context, parentOrderID string, slice TWAPSlice) error
+}
+
+// BuildSliceSchedule splits the quantity into equal slices spread evenly over the window,
+// starting at start. The last slice absorbs any rounding residue.
+func BuildSliceSchedule(totalQuantity float64, start time.Time, window time.Duration, sliceCount int) ([]TWAPSlice, error) {
+	if totalQuantity <= 0 {
+		return nil, errors.New("slice schedule quantity must be greater than zero")
+	}
+	if sliceCount <= 0 {
+		return nil, errors.New("slice count must be greater than zero")
+	}
+	if window < 0 {
+		return nil, errors.New("slice schedule window cannot be negative")
+	}
+
+	interval := window / time.Duration(sliceCount)
+	sliceQuantity := totalQuantity / float64(sliceCount)
+
+	slices := make([]TWAPSlice, 0, sliceCount)
+	scheduled := 0.0
+	for i := 0; i < sliceCount; i++ {
+		quantity := sliceQuantity
+		if i == sliceCount-1 {
+			quantity = totalQuantity - scheduled
+		}
+		scheduled += quantity
+
+		slices = append(slices, TWAPSlice{
+			Index:       i,
+			Quantity:    quantity,
+			ScheduledAt: start.Add(interval * time.Duration(i)),
+		})
+	}
+
+	return slices, nil
+}
+
+// TWAPExecutionScheduler paces the child slices of TWAP orders over the plan's window. Before
+// each slice it checks volatility and, while volatility is spiking, stretches the gap to the
+// next slice so the remaining quantity trades into calmer prices.
+type TWAPExecutionScheduler interface {
+	// Schedule builds the order's slice schedule starting at start
+	Schedule(order *domain.Order, start time.Time) ([]TWAPSlice, error)
+	// Execute submits the slices at their scheduled times, adjusted for volatility, and blocks
+	// until all are submitted, a submission fails, or the context ends
+	Execute(ctx context.Context, order *domain.Order, slices []TWAPSlice, pricingClient IPricingDataClient) (*TWAPExecutionReport, error)
+}
+
+type twapExecutionScheduler struct {
+	submitter              ITWAPSliceSubmitter
+	sliceCount             int
+	window                 time.Duration
+	volatilitySpikePercent float64
+	slowdownFactor         float64
+	maxWindowExtension     time.Duration
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// TWAPSchedulerConfig holds configuration for TWAP slice pacing
+type TWAPSchedulerConfig struct {
+	SliceCount             int           // Child orders per parent order
+	Window                 time.Duration // Time the slices are spread over
+	VolatilitySpikePercent float64       // Spread percent at which the pace slows; 0 disables pace adjustment
+	SlowdownFactor         float64       // Multiplier applied to the slice interval while volatility is spiking
+	MaxWindowExtension     time.Duration // Upper bound on the total delay added by slowdowns
+}
+
+// NewTWAPExecutionScheduler creates a new instance of TWAPExecutionScheduler
+func NewTWAPExecutionScheduler(submitter ITWAPSliceSubmitter, config TWAPSchedulerConfig) TWAPExecutionScheduler {
+	return &twapExecutionScheduler{
+		submitter:              submitter,
+		sliceCount:             config.SliceCount,
+		window:                 config.Window,
+		volatilitySpikePercent: config.VolatilitySpikePercent,
+		slowdownFactor:         config.SlowdownFactor,
+		maxWindowExtension:     config.MaxWindowExtension,
+		now:                    time.Now,
+		sleep:                  sleepWithContext,
+	}
+}
+
+// NewTWAPExecutionSchedulerWithDefaults creates a scheduler with default configuration
+func NewTWAPExecutionSchedulerWithDefaults(submitter ITWAPSliceSubmitter) TWAPExecutionScheduler {
+	return NewTWAPExecutionScheduler(submitter, TWAPSchedulerConfig{
+		SliceCount:             10,               // 10 child orders
+		Window:                 30 * time.Minute, // over 30 minutes
+		VolatilitySpikePercent: 1.0,              // Slow down once the spread passes 1%
+		SlowdownFactor:         2.0,              // Double the gap between slices while spiking
+		MaxWindowExtension:     30 * time.Minute, // Never stretch the window by more than 30 minutes
+	})
+}
+
+// Schedule builds the order's slice schedule starting at start
+func (s *twapExecutionScheduler) Schedule(order *domain.Order, start time.Time) ([]TWAPSlice, error) {
+	return BuildSliceSchedule(order.Quantity(), start, s.window, s.sliceCount)
+}
+
+// Execute submits the slices in order, waiting for each slice's paced time
+func (s *twapExecutionScheduler) Execute(ctx context.Context, order *domain.Order, slices []TWAPSlice, pricingClient IPricingDataClient) (*TWAPExecutionReport, error) {
+	report := &TWAPExecutionReport{
+		ParentOrderID:   order.ID(),
+		Submitted:       make([]SubmittedSlice, 0, len(slices)),
+		PaceAdjustments: make([]string, 0),
+	}
+
+	var delay time.Duration
+	for i, slice := range slices {
+		if i > 0 {
+			interval := slice.ScheduledAt.Sub(slices[i-1].ScheduledAt)
+			delay += s.paceAdjustment(order.Symbol(), slice, interval, delay, pricingClient, report)
+		}
+
+		dueAt := slice.ScheduledAt.Add(delay)
+		if wait := dueAt.Sub(s.now()); wait > 0 {
+			if err := s.sleep(ctx, wait); err != nil {
+				return report, fmt.Errorf("TWAP execution of order %s stopped before slice %d: %w", order.ID(), slice.Index, err)
+			}
+		}
+
+		if err := s.submitter.SubmitSlice(ctx, order.ID(), slice); err != nil {
+			return report, fmt.Errorf("failed to submit slice %d of order %s: %w", slice.Index, order.ID(), err)
+		}
+
+		report.Submitted = append(report.Submitted, SubmittedSlice{
+			TWAPSlice:   slice,
+			SubmittedAt: s.now(),
+			Delay:       delay,
+		})
+	}
+
+	report.TotalDelay = delay
+	return report, nil
+}
+
+// paceAdjustment returns the extra delay before the slice when volatility is spiking, bounded
+// by what is left of the maximum window extension
+func (s *twapExecutionScheduler) paceAdjustment(symbol string, slice TWAPSlice, interval, delay time.Duration, pricingClient IPricingDataClient, report *TWAPExecutionReport) time.Duration {
+	if s.volatilitySpikePercent <= 0 || s.slowdownFactor <= 1 || pricingClient == nil || interval <= 0 {
+		return 0
+	}
+
+	marketPrice, err := pricingClient.GetCurrentMarketPrice(symbol)
+	if err != nil {
+		fmt.Printf("Warning: Could not check volatility before TWAP slice %d of %s: %v\n", slice.Index, symbol, err)
+		return 0
+	}
+
+	volatility := marketPrice.SpreadPercent // Simplified volatility measure, as in market conditions
+	if volatility < s.volatilitySpikePercent {
+		return 0
+	}
+
+	extra := time.Duration(float64(interval) * (s.slowdownFactor - 1))
+	if s.maxWindowExtension > 0 && delay+extra > s.maxWindowExtension {
+		extra = s.maxWindowExtension - delay
+	}
+	if extra <= 0 {
+		return 0
+	}
+
+	report.PaceAdjustments = append(report.PaceAdjustments,
+		fmt.Sprintf("Slowed slice %d by %s: volatility %.2f%% above %.2f%%", slice.Index, extra, volatility, s.volatilitySpikePercent))
+	return extra
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSliceSubmitter struct {
+	clock     *fakeTWAPClock
+	submitted []time.Time
+}
+
+func (r *recordingSliceSubmitter) SubmitSlice(ctx context.Context, parentOrderID string, slice TWAPSlice) error {
+	r.submitted = append(r.submitted, r.clock.now)
+	return nil
+}
+
+// fakeTWAPClock advances instantly on sleep so pacing can be checked without waiting
+type fakeTWAPClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeTWAPClock) sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func newTestTWAPScheduler(start time.Time, config TWAPSchedulerConfig) (*twapExecutionScheduler, *fakeTWAPClock, *recordingSliceSubmitter) {
+	clock := &fakeTWAPClock{now: start}
+	submitter := &recordingSliceSubmitter{clock: clock}
+	scheduler := NewTWAPExecutionScheduler(submitter, config).(*twapExecutionScheduler)
+	scheduler.now = func() time.Time { return clock.now }
+	scheduler.sleep = clock.sleep
+	return scheduler, clock, submitter
+}
+
+func TestBuildSliceSchedule_SpreadsQuantityOverWindow(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+
+	slices, err := BuildSliceSchedule(100, start, 30*time.Minute, 3)
+
+	require.NoError(t, err)
+	require.Len(t, slices, 3)
+	assert.Equal(t, start, slices[0].ScheduledAt)
+	assert.Equal(t, start.Add(10*time.Minute), slices[1].ScheduledAt)
+	assert.Equal(t, start.Add(20*time.Minute), slices[2].ScheduledAt)
+	assert.InDelta(t, 100.0, slices[0].Quantity+slices[1].Quantity+slices[2].Quantity, 1e-9)
+}
+
+func TestTWAPExecutionScheduler_SubmitsAtScheduledCadence(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	scheduler, clock, submitter := newTestTWAPScheduler(start, TWAPSchedulerConfig{
+		SliceCount:             4,
+		Window:                 4 * time.Minute,
+		VolatilitySpikePercent: 1.0,
+		SlowdownFactor:         2.0,
+	})
+	order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 400, nil)
+
+	mockClient := &MockPricingDataClient{}
+	mockClient.On("GetCurrentMarketPrice", "AAPL").Return(&MarketPrice{Symbol: "AAPL", SpreadPercent: 0.2}, nil)
+
+	slices, err := scheduler.Schedule(order, start)
+	require.NoError(t, err)
+	report, err := scheduler.Execute(context.Background(), order, slices, mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{start, start.Add(time.Minute), start.Add(2 * time.Minute), start.Add(3 * time.Minute)}, submitter.submitted)
+	assert.Equal(t, []time.Duration{time.Minute, time.Minute, time.Minute}, clock.sleeps)
+	assert.Len(t, report.Submitted, 4)
+	assert.Empty(t, report.PaceAdjustments)
+	assert.Zero(t, report.TotalDelay)
+}
+
+func TestTWAPExecutionScheduler_SlowsPaceWhenVolatilitySpikes(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	scheduler, _, submitter := newTestTWAPScheduler(start, TWAPSchedulerConfig{
+		SliceCount:             4,
+		Window:                 4 * time.Minute,
+		VolatilitySpikePercent: 1.0,
+		SlowdownFactor:         2.0,
+		MaxWindowExtension:     10 * time.Minute,
+	})
+	order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 400, nil)
+
+	mockClient := &MockPricingDataClient{}
+	mockClient.On("GetCurrentMarketPrice", "AAPL").Return(&MarketPrice{Symbol: "AAPL", SpreadPercent: 0.2}, nil).Once()
+	mockClient.On("GetCurrentMarketPrice", "AAPL").Return(&MarketPrice{Symbol: "AAPL", SpreadPercent: 2.5}, nil).Once()
+	mockClient.On("GetCurrentMarketPrice", "AAPL").Return(&MarketPrice{Symbol: "AAPL", SpreadPercent: 0.3}, nil).Once()
+
+	slices, err := scheduler.Schedule(order, start)
+	require.NoError(t, err)
+	report, err := scheduler.Execute(context.Background(), order, slices, mockClient)
+
+	require.NoError(t, err)
+	// The spike before slice 2 doubles its gap; later slices keep the normal gap from there
+	assert.Equal(t, []time.Time{start, start.Add(time.Minute), start.Add(3 * time.Minute), start.Add(4 * time.Minute)}, submitter.submitted)
+	assert.Len(t, report.PaceAdjustments, 1)
+	assert.Equal(t, time.Minute, report.TotalDelay)
+	assert.Equal(t, time.Minute, report.Submitted[2].Delay)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTWAPExecutionScheduler_SlowdownCappedByMaxWindowExtension(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	scheduler, _, submitter := newTestTWAPScheduler(start, TWAPSchedulerConfig{
+		SliceCount:             4,
+		Window:                 4 * time.Minute,
+		VolatilitySpikePercent: 1.0,
+		SlowdownFactor:         3.0,
+		MaxWindowExtension:     3 * time.Minute,
+	})
+	order, _ := domain.NewOrder("user1", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 400, nil)
+
+	mockClient := &MockPricingDataClient{}
+	mockClient.On("GetCurrentMarketPrice", "AAPL").Return(&MarketPrice{Symbol: "AAPL", SpreadPercent: 5.0}, nil)
+
+	slices, err := scheduler.Schedule(order, start)
+	require.NoError(t, err)
+	report, err := scheduler.Execute(context.Background(), order, slices, mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, report.TotalDelay)
+	assert.Equal(t, start.Add(6*time.Minute), submitter.submitted[3])
+	assert.Len(t, report.PaceAdjustments, 2)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// String returns the tolerance name used by the API and storage
+func (t RiskTolerance) String() string {
+	switch t {
+	case RiskToleranceConservative:
+		return "CONSERVATIVE"
+	case RiskToleranceModerate:
+		return "MODERATE"
+	case RiskToleranceAggressive:
+		return "AGGRESSIVE"
+	case RiskToleranceSpeculative:
+		return "SPECULATIVE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// IsValid reports whether the tolerance is one of the defined levels
+func (t RiskTolerance) IsValid() bool {
+	return t >= RiskToleranceConservative && t <= RiskToleranceSpeculative
+}
+
+// ParseRiskTolerance converts a tolerance name such as "MODERATE" or "aggressive" into a RiskTolerance
+func ParseRiskTolerance(name string) (RiskTolerance, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "CONSERVATIVE":
+		return RiskToleranceConservative, nil
+	case "MODERATE":
+		return RiskToleranceModerate, nil
+	case "AGGRESSIVE":
+		return RiskToleranceAggressive, nil
+	case "SPECULATIVE":
+		return RiskToleranceSpeculative, nil
+	default:
+		return RiskToleranceConservative, fmt.Errorf("unknown risk tolerance: %s", name)
+	}
+}
+
+// Validate checks that the profile's limits can be enforced
+func (p *UserRiskProfile) Validate() error {
+	if p.UserID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+	if !p.RiskTolerance.IsValid() {
+		return fmt.Errorf("invalid risk tolerance: %d", p.RiskTolerance)
+	}
+	if p.MaxOrderValue <= 0 {
+		return errors.New("max order value must be positive")
+	}
+	if p.MaxDailyTradingValue <= 0 {
+		return errors.New("max daily trading value must be positive")
+	}
+	if p.MaxOrderValue > p.MaxDailyTradingValue {
+		return fmt.Errorf("max order value %.2f cannot exceed the daily trading limit %.2f", p.MaxOrderValue, p.MaxDailyTradingValue)
+	}
+	if p.MaxPositionSize < 0 {
+		return errors.New("max position size cannot be negative")
+	}
+	if p.RiskTolerance == RiskToleranceSpeculative && !p.IsHighRiskApproved {
+		return errors.New("speculative risk tolerance requires high-risk approval")
+	}
+	return nil
+}
+
+// ApplyTo caps the trading limits with the profile's order and daily limits, so a profile change
+// applies to the next order without waiting for the limits to be recalculated
+func (p *UserRiskProfile) ApplyTo(limits *TradingLimits) {
+	limits.MaxOrderValue = p.MaxOrderValue
+	limits.DailyTradingLimit = p.MaxDailyTradingValue
+	if p.MaxPositionSize > 0 {
+		limits.MaxPositionSize = p.MaxPositionSize
+	}
+	limits.RemainingDailyLimit = limits.DailyTradingLimit - limits.DailyTradingUsed
+	if limits.RemainingDailyLimit < 0 {
+		limits.RemainingDailyLimit = 0
+	}
+}
+
+// RiskProfileAuditEntry records who changed a user's risk profile, why, and what it looked like before and after
+type RiskProfileAuditEntry struct {
+	ID        string
+	UserID    string
+	ChangedBy string
+	Reason    string
+	Previous  *UserRiskProfile // Nil when the profile was created by the change
+	Updated   UserRiskProfile
+	ChangedAt time.Time
+}
+
+// ChangedFields lists the profile fields that differ between the previous and the updated profile
+func (e *RiskProfileAuditEntry) ChangedFields() []string {
+	previous := UserRiskProfile{}
+	if e.Previous != nil {
+		previous = *e.Previous
+	}
+
+	changed := make([]string, 0)
+	if e.Previous == nil || previous.RiskTolerance != e.Updated.RiskTolerance {
+		changed = append(changed, "risk_tolerance")
+	}
+	if previous.MaxOrderValue != e.Updated.MaxOrderValue {
+		changed = append(changed, "max_order_value")
+	}
+	if previous.MaxDailyTradingValue != e.Updated.MaxDailyTradingValue {
+		changed = append(changed, "max_daily_trading_value")
+	}
+	if previous.MaxPositionSize != e.Updated.MaxPositionSize {
+		changed = append(changed, "max_position_size")
+	}
+	if e.Previous == nil || previous.IsHighRiskApproved != e.Updated.IsHighRiskApproved {
+		changed = append(changed, "is_high_risk_approved")
+	}
+	return changed
+}
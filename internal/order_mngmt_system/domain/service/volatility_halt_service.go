@@ -0,0 +1,227 @@
+package service
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// VolatilityHalt is a stop on new orders for a symbol, either after an abrupt price move or placed manually
+type VolatilityHalt struct {
+	Symbol         string
+	ReferencePrice float64 // Price within the window the move was measured from
+	TriggerPrice   float64 // Price that moved beyond the limit
+	MovePercent    float64
+	Reason         string // Why the symbol was halted manually; empty for volatility halts
+	TriggeredAt    time.Time
+	ResumesAt      time.Time // Pushed back by every further move beyond the limit; zero for a permanent halt
+}
+
+// IsManual reports whether the halt was placed manually rather than by a price move
+func (h *VolatilityHalt) IsManual() bool {
+	return h.Reason != ""
+}
+
+// IsPermanent reports whether the halt stays in effect until it is lifted manually
+func (h *VolatilityHalt) IsPermanent() bool {
+	return h.ResumesAt.IsZero()
+}
+
+// VolatilityHaltService watches realtime prices and halts symbols that move too far too fast.
+// A halted symbol resumes once its price stays within the limit for the whole cooldown.
+// Symbols can also be halted manually, for a set time or until the halt is lifted.
+type VolatilityHaltService interface {
+	// RecordPrice adds a realtime price for the symbol and returns the symbol's active halt, if any
+	RecordPrice(symbol string, price float64, at time.Time) *VolatilityHalt
+	// ActiveHalt returns the symbol's halt in effect at the given time, or nil when it is trading
+	ActiveHalt(symbol string, at time.Time) *VolatilityHalt
+	// HaltSymbol halts the symbol from the given time, replacing any halt in effect. A positive expiry
+	// resumes trading automatically after that long; zero keeps the halt until ResumeSymbol is called.
+	HaltSymbol(symbol, reason string, expiry time.Duration, at time.Time) *VolatilityHalt
+	// ResumeSymbol lifts the symbol's halt, reporting whether one was in effect
+	ResumeSymbol(symbol string, at time.Time) bool
+}
+
+type volatilityHaltService struct {
+	movePercent float64
+	window      time.Duration
+	cooldown    time.Duration
+
+	mu      sync.Mutex
+	symbols map[string]*symbolVolatility
+}
+
+type symbolVolatility struct {
+	samples []priceSample
+	halt    *VolatilityHalt
+}
+
+type priceSample struct {
+	price float64
+	at    time.Time
+}
+
+// VolatilityHaltConfig holds configuration for symbol volatility halts
+type VolatilityHaltConfig struct {
+	MovePercent float64       // Price move within the window that halts the symbol
+	Window      time.Duration // How far back prices are compared against the latest price
+	Cooldown    time.Duration // How long the price must stay within the limit before trading resumes
+}
+
+// NewVolatilityHaltService creates a new instance of VolatilityHaltService
+func NewVolatilityHaltService(config VolatilityHaltConfig) VolatilityHaltService {
+	return &volatilityHaltService{
+		movePercent: config.MovePercent,
+		window:      config.Window,
+		cooldown:    config.Cooldown,
+		symbols:     make(map[string]*symbolVolatility),
+	}
+}
+
+// DefaultVolatilityHaltConfig returns the default volatility halt configuration
+func DefaultVolatilityHaltConfig() VolatilityHaltConfig {
+	return VolatilityHaltConfig{
+		MovePercent: 10.0,            // Halt on a 10% move
+		Window:      5 * time.Minute, // within 5 minutes
+		Cooldown:    5 * time.Minute, // and resume after 5 calm minutes
+	}
+}
+
+// NewVolatilityHaltServiceWithDefaults creates a service with default configuration
+func NewVolatilityHaltServiceWithDefaults() VolatilityHaltService {
+	return NewVolatilityHaltService(DefaultVolatilityHaltConfig())
+}
+
+// RecordPrice adds a realtime price for the symbol and returns the symbol's active halt, if any
+func (s *volatilityHaltService) RecordPrice(symbol string, price float64, at time.Time) *VolatilityHalt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.symbols[symbol]
+	if !exists {
+		state = &symbolVolatility{}
+		s.symbols[symbol] = state
+	}
+
+	if price <= 0 || s.movePercent <= 0 {
+		return s.activeHalt(state, at)
+	}
+
+	state.samples = pruneSamples(state.samples, at.Add(-s.window))
+
+	referencePrice, movePercent := largestMove(state.samples, price)
+	if movePercent >= s.movePercent {
+		if s.activeHalt(state, at) == nil {
+			state.halt = &VolatilityHalt{
+				Symbol:         symbol,
+				ReferencePrice: referencePrice,
+				TriggerPrice:   price,
+				MovePercent:    movePercent,
+				TriggeredAt:    at,
+				ResumesAt:      at,
+			}
+		}
+		// A permanent manual halt outlasts any cooldown; a timed one is only ever pushed back
+		if resumesAt := at.Add(s.cooldown); !state.halt.IsPermanent() && resumesAt.After(state.halt.ResumesAt) {
+			state.halt.ResumesAt = resumesAt
+		}
+		// Later moves are measured from the price that caused the halt, like an auction reopening price
+		state.samples = state.samples[:0]
+	}
+
+	state.samples = append(state.samples, priceSample{price: price, at: at})
+
+	return s.activeHalt(state, at)
+}
+
+// ActiveHalt returns the symbol's halt in effect at the given time, or nil when it is trading
+func (s *volatilityHaltService) ActiveHalt(symbol string, at time.Time) *VolatilityHalt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.symbols[symbol]
+	if !exists {
+		return nil
+	}
+	return s.activeHalt(state, at)
+}
+
+// HaltSymbol halts the symbol from the given time, replacing any halt in effect
+func (s *volatilityHaltService) HaltSymbol(symbol, reason string, expiry time.Duration, at time.Time) *VolatilityHalt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.symbols[symbol]
+	if !exists {
+		state = &symbolVolatility{}
+		s.symbols[symbol] = state
+	}
+
+	if reason == "" {
+		reason = "halted manually"
+	}
+
+	state.halt = &VolatilityHalt{
+		Symbol:      symbol,
+		Reason:      reason,
+		TriggeredAt: at,
+	}
+	if expiry > 0 {
+		state.halt.ResumesAt = at.Add(expiry)
+	}
+
+	halt := *state.halt
+	return &halt
+}
+
+// ResumeSymbol lifts the symbol's halt, reporting whether one was in effect
+func (s *volatilityHaltService) ResumeSymbol(symbol string, at time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.symbols[symbol]
+	if !exists || s.activeHalt(state, at) == nil {
+		return false
+	}
+
+	state.halt = nil
+	// Moves are measured afresh from the resumed price
+	state.samples = state.samples[:0]
+	return true
+}
+
+// activeHalt lifts an expired halt and returns a copy of the one still in effect
+func (s *volatilityHaltService) activeHalt(state *symbolVolatility, at time.Time) *VolatilityHalt {
+	if state.halt == nil {
+		return nil
+	}
+	if !state.halt.IsPermanent() && !at.Before(state.halt.ResumesAt) {
+		state.halt = nil
+		return nil
+	}
+
+	halt := *state.halt
+	return &halt
+}
+
+func pruneSamples(samples []priceSample, cutoff time.Time) []priceSample {
+	kept := samples[:0]
+	for _, sample := range samples {
+		if !sample.at.Before(cutoff) {
+			kept = append(kept, sample)
+		}
+	}
+	return kept
+}
+
+// largestMove returns the sample furthest from the price and the move from it in percent
+func largestMove(samples []priceSample, price float64) (float64, float64) {
+	referencePrice, movePercent := price, 0.0
+	for _, sample := range samples {
+		move := math.Abs(price-sample.price) / sample.price * 100
+		if move > movePercent {
+			referencePrice, movePercent = sample.price, move
+		}
+	}
+	return referencePrice, movePercent
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVolatilityHaltTestService() VolatilityHaltService {
+	return NewVolatilityHaltService(VolatilityHaltConfig{
+		MovePercent: 5.0,
+		Window:      time.Minute,
+		Cooldown:    2 * time.Minute,
+	})
+}
+
+func TestVolatilityHaltService_RapidMoveTriggersHalt(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, svc.RecordPrice("PETR4", 30.00, start))
+	assert.Nil(t, svc.RecordPrice("PETR4", 30.60, start.Add(20*time.Second)))
+
+	halt := svc.RecordPrice("PETR4", 32.00, start.Add(40*time.Second))
+
+	require.NotNil(t, halt)
+	assert.Equal(t, "PETR4", halt.Symbol)
+	assert.Equal(t, 30.00, halt.ReferencePrice)
+	assert.Equal(t, 32.00, halt.TriggerPrice)
+	assert.InDelta(t, 6.67, halt.MovePercent, 0.01)
+	assert.Equal(t, start.Add(40*time.Second+2*time.Minute), halt.ResumesAt)
+	assert.NotNil(t, svc.ActiveHalt("PETR4", start.Add(time.Minute)))
+	assert.Nil(t, svc.ActiveHalt("VALE3", start.Add(time.Minute)))
+}
+
+func TestVolatilityHaltService_SlowMoveDoesNotHalt(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	// The same 6.67% move spread over two minutes never exceeds the limit within one window
+	svc.RecordPrice("PETR4", 30.00, start)
+	svc.RecordPrice("PETR4", 31.00, start.Add(50*time.Second))
+	halt := svc.RecordPrice("PETR4", 32.00, start.Add(2*time.Minute))
+
+	assert.Nil(t, halt)
+}
+
+func TestVolatilityHaltService_StablePeriodLiftsHalt(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	svc.RecordPrice("PETR4", 30.00, start)
+	require.NotNil(t, svc.RecordPrice("PETR4", 32.00, start.Add(10*time.Second)))
+
+	// Small moves around the new price keep the original resume time
+	assert.NotNil(t, svc.RecordPrice("PETR4", 32.10, start.Add(time.Minute)))
+	assert.NotNil(t, svc.RecordPrice("PETR4", 31.90, start.Add(2*time.Minute)))
+
+	assert.Nil(t, svc.ActiveHalt("PETR4", start.Add(10*time.Second+2*time.Minute)))
+	assert.Nil(t, svc.RecordPrice("PETR4", 32.00, start.Add(3*time.Minute)))
+}
+
+func TestVolatilityHaltService_FurtherMoveExtendsHalt(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	svc.RecordPrice("PETR4", 30.00, start)
+	first := svc.RecordPrice("PETR4", 32.00, start.Add(10*time.Second))
+	require.NotNil(t, first)
+
+	second := svc.RecordPrice("PETR4", 34.00, start.Add(time.Minute))
+
+	require.NotNil(t, second)
+	assert.Equal(t, first.TriggeredAt, second.TriggeredAt)
+	assert.Equal(t, start.Add(3*time.Minute), second.ResumesAt)
+	assert.NotNil(t, svc.ActiveHalt("PETR4", start.Add(2*time.Minute+30*time.Second)))
+}
+
+func TestVolatilityHaltService_TimedHaltAutoResumes(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	halt := svc.HaltSymbol("PETR4", "pending corporate announcement", 30*time.Minute, start)
+
+	require.NotNil(t, halt)
+	assert.True(t, halt.IsManual())
+	assert.False(t, halt.IsPermanent())
+	assert.Equal(t, start.Add(30*time.Minute), halt.ResumesAt)
+	assert.NotNil(t, svc.ActiveHalt("PETR4", start.Add(29*time.Minute)))
+	assert.Nil(t, svc.ActiveHalt("PETR4", start.Add(30*time.Minute)))
+}
+
+func TestVolatilityHaltService_PermanentHaltPersistsUntilLifted(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	halt := svc.HaltSymbol("PETR4", "kill switch", 0, start)
+
+	require.NotNil(t, halt)
+	assert.True(t, halt.IsPermanent())
+	assert.NotNil(t, svc.ActiveHalt("PETR4", start.Add(30*24*time.Hour)))
+
+	// A price move's cooldown does not shorten a permanent halt
+	svc.RecordPrice("PETR4", 30.00, start.Add(time.Hour))
+	svc.RecordPrice("PETR4", 32.00, start.Add(time.Hour+10*time.Second))
+	assert.NotNil(t, svc.ActiveHalt("PETR4", start.Add(2*time.Hour)))
+
+	assert.True(t, svc.ResumeSymbol("PETR4", start.Add(2*time.Hour)))
+	assert.Nil(t, svc.ActiveHalt("PETR4", start.Add(2*time.Hour)))
+	assert.False(t, svc.ResumeSymbol("PETR4", start.Add(2*time.Hour)))
+}
+
+func TestVolatilityHaltService_PriceMoveExtendsTimedHalt(t *testing.T) {
+	svc := newVolatilityHaltTestService()
+	start := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	svc.HaltSymbol("PETR4", "data feed check", time.Minute, start)
+	svc.RecordPrice("PETR4", 30.00, start.Add(10*time.Second))
+	halt := svc.RecordPrice("PETR4", 32.00, start.Add(30*time.Second))
+
+	require.NotNil(t, halt)
+	assert.Equal(t, "data feed check", halt.Reason)
+	assert.Equal(t, start.Add(30*time.Second+2*time.Minute), halt.ResumesAt)
+}
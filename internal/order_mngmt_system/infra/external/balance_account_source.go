@@ -0,0 +1,30 @@
+package external
+
+import (
+	"context"
+
+	balanceDomain "HubInvestments/internal/balance/domain/model"
+)
+
+// IBalanceReader defines the interface for reading account balances (dependency inversion)
+type IBalanceReader interface {
+	GetBalance(userId string) (balanceDomain.BalanceModel, error)
+}
+
+// BalanceAccountSource exposes the balance module's available balance to order submission
+type BalanceAccountSource struct {
+	balances IBalanceReader
+}
+
+func NewBalanceAccountSource(balances IBalanceReader) *BalanceAccountSource {
+	return &BalanceAccountSource{balances: balances}
+}
+
+// GetAvailableBalance returns the user's available balance
+func (s *BalanceAccountSource) GetAvailableBalance(ctx context.Context, userID string) (float64, error) {
+	balance, err := s.balances.GetBalance(userID)
+	if err != nil {
+		return 0, err
+	}
+	return float64(balance.AvailableBalance), nil
+}
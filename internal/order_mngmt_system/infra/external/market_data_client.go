@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
 	"github.com/RodriguesYan/hub-proto-contracts/monolith"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -46,15 +48,16 @@ type MarketDataResponse struct {
 
 // AssetDetails represents detailed information about a tradeable asset
 type AssetDetails struct {
-	Symbol       string
-	Name         string
-	Category     AssetCategory
-	LastQuote    float64
-	IsActive     bool
-	IsTradeable  bool
-	MaxOrderSize float64
-	PriceStep    float64
-	LastUpdated  time.Time
+	Symbol         string
+	Name           string
+	Category       AssetCategory
+	LastQuote      float64
+	IsActive       bool
+	IsTradeable    bool
+	MaxOrderSize   float64
+	PriceStep      float64
+	PricePrecision int // Decimal places the symbol's prices are quoted to
+	LastUpdated    time.Time
 }
 
 // AssetCategory represents the category of an asset
@@ -160,16 +163,18 @@ func (c *MarketDataClient) GetAssetDetails(ctx context.Context, symbol string) (
 
 	// Convert to AssetDetails
 	data := resp.MarketData
+	priceStep := c.getPriceStep(int(data.Category))
 	assetDetails := &AssetDetails{
-		Symbol:       data.Symbol,
-		Name:         data.CompanyName,
-		Category:     mapCategoryFromMarketData(int(data.Category)),
-		LastQuote:    data.CurrentPrice,
-		IsActive:     true, // Assume active if we got data
-		IsTradeable:  c.isSymbolTradeable(data),
-		MaxOrderSize: c.getMaxOrderSize(int(data.Category)),
-		PriceStep:    c.getPriceStep(int(data.Category)),
-		LastUpdated:  time.Now(),
+		Symbol:         data.Symbol,
+		Name:           data.CompanyName,
+		Category:       mapCategoryFromMarketData(int(data.Category)),
+		LastQuote:      data.CurrentPrice,
+		IsActive:       true, // Assume active if we got data
+		IsTradeable:    c.isSymbolTradeable(data),
+		MaxOrderSize:   c.getMaxOrderSize(int(data.Category)),
+		PriceStep:      priceStep,
+		PricePrecision: domain.PricePrecisionForStep(priceStep),
+		LastUpdated:    time.Now(),
 	}
 
 	return assetDetails, nil
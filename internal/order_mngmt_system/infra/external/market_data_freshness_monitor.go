@@ -0,0 +1,179 @@
+package external
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarketDataFreshnessConfig holds configuration for the market data staleness SLO
+type MarketDataFreshnessConfig struct {
+	TrackedSymbols []string      // Symbols whose quotes must stay fresh for the service to be ready
+	StalenessSLO   time.Duration // Quote age beyond which a symbol is stale and the service degraded
+	CheckInterval  time.Duration // How often the quote timestamps are polled
+	CheckTimeout   time.Duration // Upper bound for polling all tracked symbols once
+}
+
+// DefaultMarketDataFreshnessConfig returns the default freshness configuration for the given symbols
+func DefaultMarketDataFreshnessConfig(trackedSymbols []string) MarketDataFreshnessConfig {
+	return MarketDataFreshnessConfig{
+		TrackedSymbols: trackedSymbols,
+		StalenessSLO:   60 * time.Second, // Quotes older than a minute are too old to price market orders on
+		CheckInterval:  15 * time.Second, // Staleness is noticed within a quarter of the SLO
+		CheckTimeout:   10 * time.Second,
+	}
+}
+
+// SymbolFreshness is the age of the freshest quote seen for one tracked symbol
+type SymbolFreshness struct {
+	Symbol      string        `json:"symbol"`
+	LastQuoteAt *time.Time    `json:"last_quote_at,omitempty"` // Nil until a quote has been seen
+	Age         time.Duration `json:"age_ns"`
+	Stale       bool          `json:"stale"`
+}
+
+// MarketDataFreshnessReport is the freshness of every tracked symbol at one point in time
+type MarketDataFreshnessReport struct {
+	Symbols      []SymbolFreshness `json:"symbols"`
+	StaleSymbols int               `json:"stale_symbols"`
+	StalenessSLO time.Duration     `json:"staleness_slo_ns"`
+	Degraded     bool              `json:"degraded"`
+	CheckedAt    time.Time         `json:"checked_at"`
+}
+
+// MarketDataFreshnessMonitor tracks the freshest quote per tracked symbol and reports the service
+// degraded while any of them is older than the staleness SLO. Quotes come from polling the asset
+// details' last update time and from RecordQuote when another component sees a fresher one. A
+// symbol never quoted counts as stale, so a market data outage at startup is not mistaken for health.
+type MarketDataFreshnessMonitor struct {
+	client IMarketDataClient
+	config MarketDataFreshnessConfig
+	now    func() time.Time
+
+	mu          sync.RWMutex
+	lastQuoteAt map[string]time.Time
+}
+
+// NewMarketDataFreshnessMonitor creates a monitor for the configured symbols
+func NewMarketDataFreshnessMonitor(client IMarketDataClient, config MarketDataFreshnessConfig) *MarketDataFreshnessMonitor {
+	symbols := make([]string, 0, len(config.TrackedSymbols))
+	for _, symbol := range config.TrackedSymbols {
+		if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	config.TrackedSymbols = symbols
+
+	return &MarketDataFreshnessMonitor{
+		client:      client,
+		config:      config,
+		now:         time.Now,
+		lastQuoteAt: make(map[string]time.Time),
+	}
+}
+
+// RecordQuote notes a quote for the symbol; older quotes than the freshest seen are ignored
+func (m *MarketDataFreshnessMonitor) RecordQuote(symbol string, quotedAt time.Time) {
+	symbol = strings.ToUpper(symbol)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if quotedAt.After(m.lastQuoteAt[symbol]) {
+		m.lastQuoteAt[symbol] = quotedAt
+	}
+}
+
+// Check polls the quote time of every tracked symbol. A symbol that cannot be read keeps its last
+// known quote time and so turns stale once that ages past the SLO.
+func (m *MarketDataFreshnessMonitor) Check(ctx context.Context) MarketDataFreshnessReport {
+	if m.client != nil {
+		for _, symbol := range m.config.TrackedSymbols {
+			details, err := m.client.GetAssetDetails(ctx, symbol)
+			if err != nil {
+				log.Printf("Warning: failed to read quote time of %s for freshness monitoring: %v", symbol, err)
+				continue
+			}
+			if details != nil && !details.LastUpdated.IsZero() {
+				m.RecordQuote(symbol, details.LastUpdated)
+			}
+		}
+	}
+	return m.Report()
+}
+
+// Report returns the freshness of every tracked symbol without polling
+func (m *MarketDataFreshnessMonitor) Report() MarketDataFreshnessReport {
+	now := m.now()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	report := MarketDataFreshnessReport{
+		Symbols:      make([]SymbolFreshness, 0, len(m.config.TrackedSymbols)),
+		StalenessSLO: m.config.StalenessSLO,
+		CheckedAt:    now,
+	}
+	for _, symbol := range m.config.TrackedSymbols {
+		freshness := SymbolFreshness{Symbol: symbol, Stale: true}
+		if quotedAt, seen := m.lastQuoteAt[symbol]; seen {
+			quoteTime := quotedAt
+			freshness.LastQuoteAt = &quoteTime
+			freshness.Age = now.Sub(quotedAt)
+			if freshness.Age < 0 {
+				freshness.Age = 0
+			}
+			freshness.Stale = freshness.Age > m.config.StalenessSLO
+		}
+		if freshness.Stale {
+			report.StaleSymbols++
+		}
+		report.Symbols = append(report.Symbols, freshness)
+	}
+	sort.Slice(report.Symbols, func(i, j int) bool { return report.Symbols[i].Symbol < report.Symbols[j].Symbol })
+	report.Degraded = report.StaleSymbols > 0
+
+	return report
+}
+
+// Degraded reports whether any tracked symbol's freshest quote is older than the SLO
+func (m *MarketDataFreshnessMonitor) Degraded() bool {
+	return m.Report().Degraded
+}
+
+// Start polls the tracked symbols every check interval until the context is cancelled
+func (m *MarketDataFreshnessMonitor) Start(ctx context.Context) {
+	if len(m.config.TrackedSymbols) == 0 || m.config.CheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		m.checkWithTimeout(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *MarketDataFreshnessMonitor) checkWithTimeout(ctx context.Context) {
+	if m.config.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.CheckTimeout)
+		defer cancel()
+	}
+
+	report := m.Check(ctx)
+	if report.Degraded {
+		log.Printf("Market data degraded: %d of %d tracked symbols have quotes older than %s",
+			report.StaleSymbols, len(report.Symbols), m.config.StalenessSLO)
+	}
+}
@@ -0,0 +1,94 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubQuoteTimeClient struct {
+	IMarketDataClient
+	lastUpdated map[string]time.Time
+}
+
+func (s *stubQuoteTimeClient) GetAssetDetails(ctx context.Context, symbol string) (*AssetDetails, error) {
+	lastUpdated, ok := s.lastUpdated[symbol]
+	if !ok {
+		return nil, errors.New("symbol unavailable")
+	}
+	return &AssetDetails{Symbol: symbol, LastUpdated: lastUpdated}, nil
+}
+
+func newFreshnessTestMonitor(client IMarketDataClient, now time.Time, symbols ...string) *MarketDataFreshnessMonitor {
+	monitor := NewMarketDataFreshnessMonitor(client, MarketDataFreshnessConfig{
+		TrackedSymbols: symbols,
+		StalenessSLO:   time.Minute,
+	})
+	monitor.now = func() time.Time { return now }
+	return monitor
+}
+
+func TestMarketDataFreshnessMonitor_FreshQuotesAreReady(t *testing.T) {
+	now := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+	client := &stubQuoteTimeClient{lastUpdated: map[string]time.Time{
+		"PETR4": now.Add(-5 * time.Second),
+		"VALE3": now.Add(-30 * time.Second),
+	}}
+	monitor := newFreshnessTestMonitor(client, now, "PETR4", "vale3")
+
+	report := monitor.Check(context.Background())
+
+	assert.False(t, report.Degraded)
+	assert.Zero(t, report.StaleSymbols)
+	require.Len(t, report.Symbols, 2)
+	assert.Equal(t, "PETR4", report.Symbols[0].Symbol)
+	assert.Equal(t, 5*time.Second, report.Symbols[0].Age)
+	assert.Equal(t, 30*time.Second, report.Symbols[1].Age)
+}
+
+func TestMarketDataFreshnessMonitor_StaleQuoteDegrades(t *testing.T) {
+	now := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+	client := &stubQuoteTimeClient{lastUpdated: map[string]time.Time{
+		"PETR4": now.Add(-5 * time.Second),
+		"VALE3": now.Add(-10 * time.Minute),
+	}}
+	monitor := newFreshnessTestMonitor(client, now, "PETR4", "VALE3")
+
+	report := monitor.Check(context.Background())
+
+	assert.True(t, report.Degraded)
+	assert.True(t, monitor.Degraded())
+	assert.Equal(t, 1, report.StaleSymbols)
+	assert.False(t, report.Symbols[0].Stale)
+	assert.True(t, report.Symbols[1].Stale)
+	assert.Equal(t, 10*time.Minute, report.Symbols[1].Age)
+}
+
+func TestMarketDataFreshnessMonitor_RecoversOnFreshQuote(t *testing.T) {
+	now := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+	client := &stubQuoteTimeClient{lastUpdated: map[string]time.Time{"PETR4": now.Add(-2 * time.Minute)}}
+	monitor := newFreshnessTestMonitor(client, now, "PETR4")
+	require.True(t, monitor.Check(context.Background()).Degraded)
+
+	monitor.RecordQuote("petr4", now.Add(-time.Second))
+	// An older quote from a lagging poll does not replace the fresher one
+	monitor.Check(context.Background())
+
+	assert.False(t, monitor.Degraded())
+	assert.Equal(t, time.Second, monitor.Report().Symbols[0].Age)
+}
+
+func TestMarketDataFreshnessMonitor_UnquotedSymbolIsStale(t *testing.T) {
+	now := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+	monitor := newFreshnessTestMonitor(&stubQuoteTimeClient{}, now, "PETR4")
+
+	report := monitor.Check(context.Background())
+
+	assert.True(t, report.Degraded)
+	assert.Nil(t, report.Symbols[0].LastQuoteAt)
+	assert.True(t, report.Symbols[0].Stale)
+}
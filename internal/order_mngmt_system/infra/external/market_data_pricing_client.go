@@ -0,0 +1,75 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// ErrPricingDataUnavailable is returned for pricing data the market data service does not provide
+var ErrPricingDataUnavailable = errors.New("pricing data not available from the market data service")
+
+// MarketDataPricingClient serves the pricing data the market data service has: the last quote and the
+// market session. The service publishes no bid/ask, order book, depth or history, so quotes carry the
+// last price on both sides and book-based features report ErrPricingDataUnavailable and are skipped.
+type MarketDataPricingClient struct {
+	marketDataClient IMarketDataClient
+	timeout          time.Duration
+}
+
+// NewMarketDataPricingClient creates a pricing client backed by the market data service
+func NewMarketDataPricingClient(marketDataClient IMarketDataClient, timeout time.Duration) *MarketDataPricingClient {
+	return &MarketDataPricingClient{
+		marketDataClient: marketDataClient,
+		timeout:          timeout,
+	}
+}
+
+func (c *MarketDataPricingClient) GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	lastPrice, err := c.marketDataClient.GetCurrentPrice(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current price for %s: %w", symbol, err)
+	}
+
+	return &service.MarketPrice{
+		Symbol:    symbol,
+		BidPrice:  lastPrice,
+		AskPrice:  lastPrice,
+		LastPrice: lastPrice,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func (c *MarketDataPricingClient) GetOrderBookData(symbol string) (*service.OrderBookData, error) {
+	return nil, fmt.Errorf("order book for %s: %w", symbol, ErrPricingDataUnavailable)
+}
+
+func (c *MarketDataPricingClient) GetHistoricalPrices(symbol string, period time.Duration) ([]service.HistoricalPrice, error) {
+	return nil, fmt.Errorf("price history for %s: %w", symbol, ErrPricingDataUnavailable)
+}
+
+func (c *MarketDataPricingClient) GetMarketDepth(symbol string) (*service.MarketDepth, error) {
+	return nil, fmt.Errorf("market depth for %s: %w", symbol, ErrPricingDataUnavailable)
+}
+
+func (c *MarketDataPricingClient) IsMarketOpen(symbol string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.marketDataClient.IsMarketOpen(ctx, symbol)
+}
+
+func (c *MarketDataPricingClient) GetTradingFees(orderType domain.OrderType, orderValue float64) (*service.TradingFees, error) {
+	return nil, fmt.Errorf("trading fees: %w", ErrPricingDataUnavailable)
+}
+
+func (c *MarketDataPricingClient) GetPriceImpactEstimate(symbol string, orderSide domain.OrderSide, quantity float64) (*service.PriceImpact, error) {
+	return nil, fmt.Errorf("price impact for %s: %w", symbol, ErrPricingDataUnavailable)
+}
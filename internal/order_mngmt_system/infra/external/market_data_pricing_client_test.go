@@ -0,0 +1,42 @@
+package external
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLastPriceClient struct {
+	IMarketDataClient
+	price float64
+}
+
+func (s *stubLastPriceClient) GetCurrentPrice(ctx context.Context, symbol string) (float64, error) {
+	return s.price, nil
+}
+
+func TestMarketDataPricingClient_QuoteUsesLastPriceOnBothSides(t *testing.T) {
+	client := NewMarketDataPricingClient(&stubLastPriceClient{price: 37.5}, time.Second)
+
+	marketPrice, err := client.GetCurrentMarketPrice("PETR4")
+
+	require.NoError(t, err)
+	assert.Equal(t, "PETR4", marketPrice.Symbol)
+	assert.Equal(t, 37.5, marketPrice.LastPrice)
+	assert.Equal(t, 37.5, marketPrice.BidPrice)
+	assert.Equal(t, 37.5, marketPrice.AskPrice)
+	assert.Zero(t, marketPrice.Spread)
+}
+
+func TestMarketDataPricingClient_BookDataIsUnavailable(t *testing.T) {
+	client := NewMarketDataPricingClient(&stubLastPriceClient{price: 37.5}, time.Second)
+
+	_, err := client.GetOrderBookData("PETR4")
+	assert.ErrorIs(t, err, ErrPricingDataUnavailable)
+
+	_, err = client.GetMarketDepth("PETR4")
+	assert.ErrorIs(t, err, ErrPricingDataUnavailable)
+}
@@ -0,0 +1,103 @@
+package external
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// IPricePrecisionResolver tells how many decimal places a symbol's prices are quoted to
+type IPricePrecisionResolver interface {
+	PrecisionFor(ctx context.Context, symbol string) int
+}
+
+// PricePrecisionConfig holds configuration for resolving per-symbol price precision
+type PricePrecisionConfig struct {
+	DefaultPrecision int            // Decimal places used when the symbol's asset details cannot be read
+	SymbolPrecisions map[string]int // Per-symbol overrides of the asset details, keyed by symbol
+	CacheTTL         time.Duration  // How long a symbol's precision is reused before asset details are read again
+}
+
+// DefaultPricePrecisionConfig returns the default price precision configuration
+func DefaultPricePrecisionConfig() PricePrecisionConfig {
+	return PricePrecisionConfig{
+		DefaultPrecision: 2,         // Equities quote to cents
+		CacheTTL:         time.Hour, // Price steps rarely change during a session
+	}
+}
+
+type cachedPricePrecision struct {
+	precision  int
+	resolvedAt time.Time
+}
+
+// PricePrecisionResolver reads each symbol's price precision from its asset details and caches it,
+// so formatting a response does not cost a market data call per price
+type PricePrecisionResolver struct {
+	client IMarketDataClient
+	config PricePrecisionConfig
+	now    func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cachedPricePrecision
+}
+
+func NewPricePrecisionResolver(client IMarketDataClient, config PricePrecisionConfig) *PricePrecisionResolver {
+	overrides := make(map[string]int, len(config.SymbolPrecisions))
+	for symbol, precision := range config.SymbolPrecisions {
+		overrides[strings.ToUpper(strings.TrimSpace(symbol))] = precision
+	}
+	config.SymbolPrecisions = overrides
+
+	return &PricePrecisionResolver{
+		client: client,
+		config: config,
+		now:    time.Now,
+		cache:  make(map[string]cachedPricePrecision),
+	}
+}
+
+func NewPricePrecisionResolverWithDefaults(client IMarketDataClient) *PricePrecisionResolver {
+	return NewPricePrecisionResolver(client, DefaultPricePrecisionConfig())
+}
+
+// PrecisionFor returns the symbol's configured override, else the precision of its asset details or,
+// for providers that only report a price step, the precision of the step. Symbols whose details
+// cannot be read use the default precision and are looked up again next time.
+func (r *PricePrecisionResolver) PrecisionFor(ctx context.Context, symbol string) int {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if precision, overridden := r.config.SymbolPrecisions[symbol]; overridden {
+		return precision
+	}
+
+	r.mu.RLock()
+	cached, found := r.cache[symbol]
+	r.mu.RUnlock()
+	if found && r.now().Sub(cached.resolvedAt) < r.config.CacheTTL {
+		return cached.precision
+	}
+
+	if r.client == nil {
+		return r.config.DefaultPrecision
+	}
+	details, err := r.client.GetAssetDetails(ctx, symbol)
+	if err != nil || details == nil {
+		return r.config.DefaultPrecision
+	}
+
+	precision := r.config.DefaultPrecision
+	if details.PricePrecision > 0 {
+		precision = details.PricePrecision
+	} else if details.PriceStep > 0 {
+		precision = domain.PricePrecisionForStep(details.PriceStep)
+	}
+
+	r.mu.Lock()
+	r.cache[symbol] = cachedPricePrecision{precision: precision, resolvedAt: r.now()}
+	r.mu.Unlock()
+
+	return precision
+}
@@ -0,0 +1,69 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAssetDetailsClient struct {
+	IMarketDataClient
+	details map[string]*AssetDetails
+	calls   int
+}
+
+func (s *stubAssetDetailsClient) GetAssetDetails(ctx context.Context, symbol string) (*AssetDetails, error) {
+	s.calls++
+	details, ok := s.details[symbol]
+	if !ok {
+		return nil, errors.New("symbol unavailable")
+	}
+	return details, nil
+}
+
+func newPrecisionTestClient() *stubAssetDetailsClient {
+	return &stubAssetDetailsClient{details: map[string]*AssetDetails{
+		"PETR4":  {Symbol: "PETR4", PriceStep: 0.01, PricePrecision: 2},
+		"EURUSD": {Symbol: "EURUSD", PriceStep: 0.0001, PricePrecision: 4},
+		"BOND1":  {Symbol: "BOND1", PriceStep: 0.125},
+	}}
+}
+
+func TestPricePrecisionResolver_UsesAssetDetails(t *testing.T) {
+	resolver := NewPricePrecisionResolverWithDefaults(newPrecisionTestClient())
+
+	assert.Equal(t, 2, resolver.PrecisionFor(context.Background(), "PETR4"))
+	assert.Equal(t, 4, resolver.PrecisionFor(context.Background(), "eurusd"))
+	assert.Equal(t, 3, resolver.PrecisionFor(context.Background(), "BOND1"), "details without a precision fall back to their price step")
+}
+
+func TestPricePrecisionResolver_OverridesAndDefault(t *testing.T) {
+	client := newPrecisionTestClient()
+	resolver := NewPricePrecisionResolver(client, PricePrecisionConfig{
+		DefaultPrecision: 2,
+		SymbolPrecisions: map[string]int{"petr4": 3},
+		CacheTTL:         time.Hour,
+	})
+
+	assert.Equal(t, 3, resolver.PrecisionFor(context.Background(), "PETR4"))
+	assert.Equal(t, 2, resolver.PrecisionFor(context.Background(), "UNKNOWN"))
+	assert.Equal(t, 1, client.calls, "overridden symbols are not looked up")
+}
+
+func TestPricePrecisionResolver_CachesUntilTTL(t *testing.T) {
+	client := newPrecisionTestClient()
+	resolver := NewPricePrecisionResolver(client, PricePrecisionConfig{DefaultPrecision: 2, CacheTTL: time.Minute})
+	now := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	resolver.PrecisionFor(context.Background(), "EURUSD")
+	resolver.PrecisionFor(context.Background(), "EURUSD")
+	assert.Equal(t, 1, client.calls)
+
+	now = now.Add(2 * time.Minute)
+	resolver.PrecisionFor(context.Background(), "EURUSD")
+	assert.Equal(t, 2, client.calls)
+}
@@ -0,0 +1,55 @@
+package external
+
+import (
+	"context"
+	"log"
+
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// StoredRiskProfileDataClient serves risk profiles adjusted through the admin API ahead of the
+// risk data source, so a changed profile applies to the user's next order
+type StoredRiskProfileDataClient struct {
+	service.IRiskDataClient
+	profiles repository.IUserRiskProfileRepository
+}
+
+func NewStoredRiskProfileDataClient(client service.IRiskDataClient, profiles repository.IUserRiskProfileRepository) *StoredRiskProfileDataClient {
+	return &StoredRiskProfileDataClient{
+		IRiskDataClient: client,
+		profiles:        profiles,
+	}
+}
+
+// GetUserRiskProfile returns the stored profile, falling back to the risk data source for users without one
+func (c *StoredRiskProfileDataClient) GetUserRiskProfile(userID string) (*service.UserRiskProfile, error) {
+	if profile := c.storedProfile(userID); profile != nil {
+		return profile, nil
+	}
+	return c.IRiskDataClient.GetUserRiskProfile(userID)
+}
+
+// GetUserTradingLimits caps the source's limits with the stored profile's order and daily limits
+func (c *StoredRiskProfileDataClient) GetUserTradingLimits(userID string) (*service.TradingLimits, error) {
+	limits, err := c.IRiskDataClient.GetUserTradingLimits(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile := c.storedProfile(userID); profile != nil {
+		adjusted := *limits
+		profile.ApplyTo(&adjusted)
+		return &adjusted, nil
+	}
+	return limits, nil
+}
+
+func (c *StoredRiskProfileDataClient) storedProfile(userID string) *service.UserRiskProfile {
+	profile, err := c.profiles.FindByUserID(context.Background(), userID)
+	if err != nil {
+		log.Printf("Stored risk profile lookup failed for user %s, using the risk data source: %v", userID, err)
+		return nil
+	}
+	return profile
+}
@@ -0,0 +1,525 @@
+package external
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// SimulationConfig holds configuration for the simulated downstreams used in load tests.
+// Every value a simulated client returns is derived from the seed and the symbol or user,
+// so two runs with the same configuration see the same market.
+type SimulationConfig struct {
+	Seed            int64         // Varies the synthetic market between runs while keeping each run reproducible
+	MinPrice        float64       // Lowest synthetic price of a symbol
+	MaxPrice        float64       // Highest synthetic price of a symbol
+	SpreadPercent   float64       // Bid-ask spread as a percentage of the price
+	BookLevels      int           // Price levels on each side of the synthetic order book
+	LevelQuantity   float64       // Quantity at the best level; each deeper level adds as much again
+	AccountBalance  float64       // Balance of every simulated account
+	HeldQuantity    float64       // Quantity of every symbol every simulated account holds, so sells validate
+	MarketOpen      bool          // Report every market open, so load runs are not bound to trading hours
+	HistoryInterval time.Duration // Spacing of synthetic historical prices
+	Latency         time.Duration // Delay added to every call to mimic a remote downstream (0 answers at once)
+}
+
+// DefaultSimulationConfig returns the default simulation configuration
+func DefaultSimulationConfig() SimulationConfig {
+	return SimulationConfig{
+		Seed:            1,         // Fixed, so benchmark runs compare like with like
+		MinPrice:        10.0,      // Typical share prices
+		MaxPrice:        500.0,     // Typical share prices
+		SpreadPercent:   0.1,       // A liquid large cap
+		BookLevels:      10,        // Enough depth for price impact and book walks
+		LevelQuantity:   1000.0,    // Absorbs typical retail orders at the touch
+		AccountBalance:  1000000.0, // Never the limiting factor in a load run
+		HeldQuantity:    100.0,     // Covers typical sells while keeping each position a small share of the account
+		MarketOpen:      true,      // Load runs happen at any hour
+		HistoryInterval: time.Hour, // Hourly points for history and volatility
+		Latency:         0,         // Measure our own pipeline, not a fake network
+	}
+}
+
+// maxSimulatedHistoryPoints bounds the synthetic history returned for long periods
+const maxSimulatedHistoryPoints = 5000
+
+// simulatedMarket derives the synthetic data shared by all simulated clients
+type simulatedMarket struct {
+	config SimulationConfig
+	now    func() time.Time
+}
+
+func newSimulatedMarket(config SimulationConfig) *simulatedMarket {
+	return &simulatedMarket{config: config, now: time.Now}
+}
+
+// factor maps the seed, a salt and a key to a stable value in [0, 1)
+func (m *simulatedMarket) factor(salt, key string) float64 {
+	hash := fnv.New64a()
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], uint64(m.config.Seed))
+	hash.Write(seed[:])
+	hash.Write([]byte(salt))
+	hash.Write([]byte(strings.ToUpper(strings.TrimSpace(key))))
+	return float64(hash.Sum64()%1000000) / 1000000
+}
+
+func (m *simulatedMarket) wait() {
+	if m.config.Latency > 0 {
+		time.Sleep(m.config.Latency)
+	}
+}
+
+func (m *simulatedMarket) price(symbol string) float64 {
+	return roundToCents(m.config.MinPrice + m.factor("price", symbol)*(m.config.MaxPrice-m.config.MinPrice))
+}
+
+// quote returns the bid and ask around the symbol's price, at least one cent apart
+func (m *simulatedMarket) quote(symbol string) (bid, ask float64) {
+	price := m.price(symbol)
+	halfSpread := math.Max(roundToCents(price*m.config.SpreadPercent/200), 0.01)
+	return roundToCents(price - halfSpread), roundToCents(price + halfSpread)
+}
+
+func (m *simulatedMarket) volatility(symbol string) float64 {
+	return 0.10 + m.factor("volatility", symbol)*0.40
+}
+
+func (m *simulatedMarket) bookDepth() float64 {
+	levels := float64(m.config.BookLevels)
+	return m.config.LevelQuantity * levels * (levels + 1) / 2
+}
+
+func roundToCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// SimulatedMarketDataClient serves synthetic asset data in place of the market data gRPC service
+type SimulatedMarketDataClient struct {
+	market *simulatedMarket
+}
+
+func NewSimulatedMarketDataClient(config SimulationConfig) *SimulatedMarketDataClient {
+	return &SimulatedMarketDataClient{market: newSimulatedMarket(config)}
+}
+
+// GetAssetDetails returns a tradeable stock at the symbol's synthetic price
+func (c *SimulatedMarketDataClient) GetAssetDetails(ctx context.Context, symbol string) (*AssetDetails, error) {
+	c.market.wait()
+	if strings.TrimSpace(symbol) == "" {
+		return nil, fmt.Errorf("no data found for symbol %s", symbol)
+	}
+
+	return &AssetDetails{
+		Symbol:         strings.ToUpper(strings.TrimSpace(symbol)),
+		Name:           fmt.Sprintf("Simulated %s", strings.ToUpper(strings.TrimSpace(symbol))),
+		Category:       AssetCategoryStock,
+		LastQuote:      c.market.price(symbol),
+		IsActive:       true,
+		IsTradeable:    true,
+		MaxOrderSize:   1000000.0,
+		PriceStep:      0.01,
+		PricePrecision: 2,
+		LastUpdated:    c.market.now(),
+	}, nil
+}
+
+// ValidateSymbol accepts every non-empty symbol
+func (c *SimulatedMarketDataClient) ValidateSymbol(ctx context.Context, symbol string) (bool, error) {
+	c.market.wait()
+	return strings.TrimSpace(symbol) != "", nil
+}
+
+// GetCurrentPrice returns the symbol's synthetic price
+func (c *SimulatedMarketDataClient) GetCurrentPrice(ctx context.Context, symbol string) (float64, error) {
+	c.market.wait()
+	return c.market.price(symbol), nil
+}
+
+// IsMarketOpen reports the configured market state
+func (c *SimulatedMarketDataClient) IsMarketOpen(ctx context.Context, symbol string) (bool, error) {
+	c.market.wait()
+	return c.market.config.MarketOpen, nil
+}
+
+// GetTradingHours returns a session spanning the current day
+func (c *SimulatedMarketDataClient) GetTradingHours(ctx context.Context, symbol string) (*TradingHours, error) {
+	c.market.wait()
+	now := c.market.now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	return &TradingHours{
+		Symbol:        symbol,
+		MarketOpen:    dayStart,
+		MarketClose:   dayStart.Add(24*time.Hour - time.Minute),
+		IsOpen:        c.market.config.MarketOpen,
+		NextOpenTime:  dayStart.Add(24 * time.Hour),
+		NextCloseTime: dayStart.Add(24*time.Hour - time.Minute),
+		Timezone:      "UTC",
+	}, nil
+}
+
+// GetBatchMarketData returns the synthetic quote of every symbol
+func (c *SimulatedMarketDataClient) GetBatchMarketData(ctx context.Context, symbols []string) ([]MarketDataResponse, error) {
+	c.market.wait()
+	result := make([]MarketDataResponse, 0, len(symbols))
+	for _, symbol := range symbols {
+		result = append(result, MarketDataResponse{
+			Symbol:      strings.ToUpper(strings.TrimSpace(symbol)),
+			CompanyName: fmt.Sprintf("Simulated %s", strings.ToUpper(strings.TrimSpace(symbol))),
+			LastQuote:   c.market.price(symbol),
+			Category:    fmt.Sprintf("%d", AssetCategoryStock),
+		})
+	}
+	return result, nil
+}
+
+// Close releases nothing; the simulated client holds no connections
+func (c *SimulatedMarketDataClient) Close() error {
+	return nil
+}
+
+// SimulatedValidationDataClient serves the same synthetic assets to order validation, whose market
+// data interface is declared in the domain
+type SimulatedValidationDataClient struct {
+	market *simulatedMarket
+}
+
+func NewSimulatedValidationDataClient(config SimulationConfig) *SimulatedValidationDataClient {
+	return &SimulatedValidationDataClient{market: newSimulatedMarket(config)}
+}
+
+// ValidateSymbol accepts every non-empty symbol
+func (c *SimulatedValidationDataClient) ValidateSymbol(ctx context.Context, symbol string) (bool, error) {
+	c.market.wait()
+	return strings.TrimSpace(symbol) != "", nil
+}
+
+// GetCurrentPrice returns the symbol's synthetic price
+func (c *SimulatedValidationDataClient) GetCurrentPrice(ctx context.Context, symbol string) (float64, error) {
+	c.market.wait()
+	return c.market.price(symbol), nil
+}
+
+// IsMarketOpen reports the configured market state
+func (c *SimulatedValidationDataClient) IsMarketOpen(ctx context.Context, symbol string) (bool, error) {
+	c.market.wait()
+	return c.market.config.MarketOpen, nil
+}
+
+// GetAssetDetails returns a tradeable stock at the symbol's synthetic price
+func (c *SimulatedValidationDataClient) GetAssetDetails(ctx context.Context, symbol string) (*service.AssetDetails, error) {
+	c.market.wait()
+	return &service.AssetDetails{
+		Symbol:       strings.ToUpper(strings.TrimSpace(symbol)),
+		Name:         fmt.Sprintf("Simulated %s", strings.ToUpper(strings.TrimSpace(symbol))),
+		Category:     int32(AssetCategoryStock),
+		LastQuote:    c.market.price(symbol),
+		IsActive:     true,
+		IsTradeable:  true,
+		MinOrderSize: 1,
+		MaxOrderSize: 1000000.0,
+		PriceStep:    0.01,
+		LastUpdated:  c.market.now(),
+	}, nil
+}
+
+// GetTradingHours returns a session spanning the current day
+func (c *SimulatedValidationDataClient) GetTradingHours(ctx context.Context, symbol string) (*service.TradingHours, error) {
+	c.market.wait()
+	now := c.market.now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	return &service.TradingHours{
+		Symbol:        symbol,
+		MarketOpen:    dayStart,
+		MarketClose:   dayStart.Add(24*time.Hour - time.Minute),
+		IsOpen:        c.market.config.MarketOpen,
+		NextOpenTime:  dayStart.Add(24 * time.Hour),
+		NextCloseTime: dayStart.Add(24*time.Hour - time.Minute),
+		Timezone:      "UTC",
+	}, nil
+}
+
+// SimulatedPricingDataClient serves synthetic quotes, books and fees to the pricing service
+type SimulatedPricingDataClient struct {
+	market *simulatedMarket
+}
+
+func NewSimulatedPricingDataClient(config SimulationConfig) *SimulatedPricingDataClient {
+	return &SimulatedPricingDataClient{market: newSimulatedMarket(config)}
+}
+
+// GetCurrentMarketPrice returns the symbol's synthetic quote
+func (c *SimulatedPricingDataClient) GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error) {
+	c.market.wait()
+	price := c.market.price(symbol)
+	bid, ask := c.market.quote(symbol)
+
+	return &service.MarketPrice{
+		Symbol:        symbol,
+		BidPrice:      bid,
+		AskPrice:      ask,
+		LastPrice:     price,
+		Volume:        int64(100000 + c.market.factor("volume", symbol)*900000),
+		Spread:        roundToCents(ask - bid),
+		SpreadPercent: (ask - bid) / price * 100,
+		Timestamp:     c.market.now(),
+	}, nil
+}
+
+// GetOrderBookData returns a book whose levels step one cent away from the quote and deepen linearly
+func (c *SimulatedPricingDataClient) GetOrderBookData(symbol string) (*service.OrderBookData, error) {
+	c.market.wait()
+	bid, ask := c.market.quote(symbol)
+	book := &service.OrderBookData{
+		Symbol:    symbol,
+		Bids:      make([]service.PriceLevel, 0, c.market.config.BookLevels),
+		Asks:      make([]service.PriceLevel, 0, c.market.config.BookLevels),
+		Timestamp: c.market.now(),
+	}
+
+	for level := 0; level < c.market.config.BookLevels; level++ {
+		quantity := c.market.config.LevelQuantity * float64(level+1)
+		step := float64(level) * 0.01
+		book.Bids = append(book.Bids, service.PriceLevel{Price: roundToCents(bid - step), Quantity: quantity, Orders: level + 1})
+		book.Asks = append(book.Asks, service.PriceLevel{Price: roundToCents(ask + step), Quantity: quantity, Orders: level + 1})
+	}
+
+	return book, nil
+}
+
+// GetHistoricalPrices returns prices oscillating around the symbol's price at the configured
+// volatility, ending at the current time
+func (c *SimulatedPricingDataClient) GetHistoricalPrices(symbol string, period time.Duration) ([]service.HistoricalPrice, error) {
+	c.market.wait()
+	interval := c.market.config.HistoryInterval
+	if interval <= 0 {
+		return nil, fmt.Errorf("simulation history interval must be positive")
+	}
+
+	points := int(period/interval) + 1
+	if points > maxSimulatedHistoryPoints {
+		points = maxSimulatedHistoryPoints
+	}
+
+	price := c.market.price(symbol)
+	amplitude := c.market.volatility(symbol) / 10
+	phase := c.market.factor("phase", symbol) * 2 * math.Pi
+	end := c.market.now()
+
+	history := make([]service.HistoricalPrice, 0, points)
+	for i := 0; i < points; i++ {
+		history = append(history, service.HistoricalPrice{
+			Symbol:    symbol,
+			Price:     roundToCents(price * (1 + amplitude*math.Sin(phase+float64(i)/4))),
+			Volume:    int64(1000 + c.market.factor("volume", symbol)*9000),
+			Timestamp: end.Add(-time.Duration(points-1-i) * interval),
+		})
+	}
+
+	return history, nil
+}
+
+// GetMarketDepth summarises the synthetic book
+func (c *SimulatedPricingDataClient) GetMarketDepth(symbol string) (*service.MarketDepth, error) {
+	c.market.wait()
+	depth := c.market.bookDepth()
+
+	return &service.MarketDepth{
+		Symbol:         symbol,
+		BidDepth:       depth,
+		AskDepth:       depth,
+		ImbalanceRatio: 0,
+		LiquidityScore: 0.8,
+		LastUpdated:    c.market.now(),
+	}, nil
+}
+
+// IsMarketOpen reports the configured market state
+func (c *SimulatedPricingDataClient) IsMarketOpen(symbol string) (bool, error) {
+	c.market.wait()
+	return c.market.config.MarketOpen, nil
+}
+
+// GetTradingFees charges a 0.1% commission (at least 1.00) plus small regulatory and exchange fees
+func (c *SimulatedPricingDataClient) GetTradingFees(orderType domain.OrderType, orderValue float64) (*service.TradingFees, error) {
+	c.market.wait()
+	commission := math.Max(orderValue*0.001, 1.0)
+	regulatory := orderValue * 0.00005
+	exchange := orderValue * 0.0003
+	total := commission + regulatory + exchange
+
+	fees := &service.TradingFees{
+		CommissionFee: commission,
+		RegulatoryFee: regulatory,
+		ExchangeFee:   exchange,
+		TotalFees:     total,
+		AllInCost:     total,
+	}
+	if orderValue > 0 {
+		fees.FeePercent = total / orderValue * 100
+	}
+	return fees, nil
+}
+
+// GetPriceImpactEstimate moves the fill price in proportion to the share of the book the order takes
+func (c *SimulatedPricingDataClient) GetPriceImpactEstimate(symbol string, orderSide domain.OrderSide, quantity float64) (*service.PriceImpact, error) {
+	c.market.wait()
+	bid, ask := c.market.quote(symbol)
+	impact := 0.0
+	if depth := c.market.bookDepth(); depth > 0 {
+		impact = math.Min(quantity/depth, 1) * 0.05
+	}
+
+	fillPrice := roundToCents(ask * (1 + impact))
+	if orderSide == domain.OrderSideSell {
+		fillPrice = roundToCents(bid * (1 - impact))
+	}
+
+	risk := service.LiquidityRiskLow
+	switch {
+	case impact >= 0.03:
+		risk = service.LiquidityRiskVeryHigh
+	case impact >= 0.01:
+		risk = service.LiquidityRiskHigh
+	case impact >= 0.005:
+		risk = service.LiquidityRiskMedium
+	}
+
+	return &service.PriceImpact{
+		Symbol:              symbol,
+		EstimatedImpact:     impact,
+		EstimatedFillPrice:  fillPrice,
+		LiquidityRisk:       risk,
+		RecommendedSlippage: impact * 2,
+		Timestamp:           c.market.now(),
+	}, nil
+}
+
+// SimulatedRiskDataClient serves synthetic profiles, balances and limits to the risk service.
+// Daily usage is always reported as zero so a load run never exhausts a user's limit.
+type SimulatedRiskDataClient struct {
+	market *simulatedMarket
+}
+
+func NewSimulatedRiskDataClient(config SimulationConfig) *SimulatedRiskDataClient {
+	return &SimulatedRiskDataClient{market: newSimulatedMarket(config)}
+}
+
+// GetUserRiskProfile returns an approved moderate profile sized to the simulated balance
+func (c *SimulatedRiskDataClient) GetUserRiskProfile(userID string) (*service.UserRiskProfile, error) {
+	c.market.wait()
+	balance := c.market.config.AccountBalance
+
+	return &service.UserRiskProfile{
+		UserID:               userID,
+		RiskTolerance:        service.RiskToleranceModerate,
+		MaxPositionSize:      balance * 0.5,
+		MaxDailyTradingValue: balance * 10,
+		MaxOrderValue:        balance,
+		IsHighRiskApproved:   true,
+		ProfileLastUpdated:   c.market.now(),
+	}, nil
+}
+
+// GetPositionExposure returns the held quantity valued at the symbol's price
+func (c *SimulatedRiskDataClient) GetPositionExposure(userID, symbol string) (*service.PositionExposure, error) {
+	c.market.wait()
+	price := c.market.price(symbol)
+	value := c.market.config.HeldQuantity * price
+
+	exposure := &service.PositionExposure{
+		Symbol:          symbol,
+		CurrentQuantity: c.market.config.HeldQuantity,
+		CurrentValue:    value,
+		AveragePrice:    price,
+	}
+	if c.market.config.AccountBalance > 0 {
+		exposure.ExposurePercent = value / c.market.config.AccountBalance * 100
+	}
+	return exposure, nil
+}
+
+// GetAccountBalance returns the simulated balance, all of it available
+func (c *SimulatedRiskDataClient) GetAccountBalance(userID string) (*service.AccountBalance, error) {
+	c.market.wait()
+	balance := c.market.config.AccountBalance
+
+	return &service.AccountBalance{
+		TotalBalance:     balance,
+		AvailableBalance: balance,
+		BuyingPower:      balance,
+		LastUpdated:      c.market.now(),
+	}, nil
+}
+
+// GetMarketVolatility returns the symbol's synthetic volatility, between 10% and 50%
+func (c *SimulatedRiskDataClient) GetMarketVolatility(symbol string) (*service.MarketVolatility, error) {
+	c.market.wait()
+	volatility := c.market.volatility(symbol)
+
+	rating := "LOW"
+	switch {
+	case volatility >= 0.35:
+		rating = "HIGH"
+	case volatility >= 0.20:
+		rating = "MEDIUM"
+	}
+
+	return &service.MarketVolatility{
+		Symbol:           symbol,
+		Volatility30Day:  volatility,
+		Beta:             0.5 + c.market.factor("beta", symbol),
+		RiskRating:       rating,
+		IsHighVolatility: volatility >= 0.35,
+		LastCalculated:   c.market.now(),
+	}, nil
+}
+
+// GetUserTradingLimits returns limits matching the risk profile with nothing used today
+func (c *SimulatedRiskDataClient) GetUserTradingLimits(userID string) (*service.TradingLimits, error) {
+	c.market.wait()
+	balance := c.market.config.AccountBalance
+
+	return &service.TradingLimits{
+		DailyTradingLimit:   balance * 10,
+		MaxOrderValue:       balance,
+		MaxPositionSize:     balance * 0.5,
+		RemainingDailyLimit: balance * 10,
+	}, nil
+}
+
+// SimulatedPositionClient serves synthetic holdings and balances to order validation
+type SimulatedPositionClient struct {
+	market *simulatedMarket
+}
+
+func NewSimulatedPositionClient(config SimulationConfig) *SimulatedPositionClient {
+	return &SimulatedPositionClient{market: newSimulatedMarket(config)}
+}
+
+// GetAvailableQuantity returns the configured held quantity for every symbol
+func (c *SimulatedPositionClient) GetAvailableQuantity(userID, symbol string) (float64, error) {
+	c.market.wait()
+	return c.market.config.HeldQuantity, nil
+}
+
+// HasSufficientBalance compares the amount with the simulated balance
+func (c *SimulatedPositionClient) HasSufficientBalance(userID string, requiredAmount float64) (bool, error) {
+	c.market.wait()
+	return requiredAmount <= c.market.config.AccountBalance, nil
+}
+
+// GetBalanceDetails returns the simulated balance with nothing held
+func (c *SimulatedPositionClient) GetBalanceDetails(userID string) (*service.BalanceDetails, error) {
+	c.market.wait()
+	return &service.BalanceDetails{AvailableBalance: c.market.config.AccountBalance}, nil
+}
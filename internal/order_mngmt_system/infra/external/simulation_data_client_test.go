@@ -0,0 +1,123 @@
+package external
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+var simulationTestTime = time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+
+func newSimulationTestClients(config SimulationConfig) (*SimulatedMarketDataClient, *SimulatedPricingDataClient, *SimulatedRiskDataClient) {
+	marketData := NewSimulatedMarketDataClient(config)
+	pricing := NewSimulatedPricingDataClient(config)
+	risk := NewSimulatedRiskDataClient(config)
+	for _, market := range []*simulatedMarket{marketData.market, pricing.market, risk.market} {
+		market.now = func() time.Time { return simulationTestTime }
+	}
+	return marketData, pricing, risk
+}
+
+// Compile-time checks that the simulated clients stand in for every downstream of the pipeline
+var (
+	_ IMarketDataClient             = (*SimulatedMarketDataClient)(nil)
+	_ service.IPricingDataClient    = (*SimulatedPricingDataClient)(nil)
+	_ service.IMarketDataClient     = (*SimulatedValidationDataClient)(nil)
+	_ service.IRiskDataClient       = (*SimulatedRiskDataClient)(nil)
+	_ service.IPositionClient       = (*SimulatedPositionClient)(nil)
+	_ service.IBalanceDetailsClient = (*SimulatedPositionClient)(nil)
+)
+
+func TestSimulatedClients_AreDeterministic(t *testing.T) {
+	config := DefaultSimulationConfig()
+	firstMarket, firstPricing, firstRisk := newSimulationTestClients(config)
+	secondMarket, secondPricing, secondRisk := newSimulationTestClients(config)
+
+	for _, symbol := range []string{"PETR4", "VALE3", "AAPL"} {
+		firstPrice, err := firstMarket.GetCurrentPrice(context.Background(), symbol)
+		require.NoError(t, err)
+		secondPrice, _ := secondMarket.GetCurrentPrice(context.Background(), symbol)
+		assert.Equal(t, firstPrice, secondPrice)
+		assert.GreaterOrEqual(t, firstPrice, config.MinPrice)
+		assert.LessOrEqual(t, firstPrice, config.MaxPrice)
+
+		firstQuote, err := firstPricing.GetCurrentMarketPrice(symbol)
+		require.NoError(t, err)
+		secondQuote, _ := secondPricing.GetCurrentMarketPrice(symbol)
+		assert.Equal(t, firstQuote, secondQuote)
+		assert.Equal(t, firstPrice, firstQuote.LastPrice, "market data and pricing clients quote the same price")
+		assert.Less(t, firstQuote.BidPrice, firstQuote.AskPrice)
+
+		firstBook, _ := firstPricing.GetOrderBookData(symbol)
+		secondBook, _ := secondPricing.GetOrderBookData(symbol)
+		assert.Equal(t, firstBook, secondBook)
+		assert.Len(t, firstBook.Bids, config.BookLevels)
+		assert.Equal(t, firstQuote.BidPrice, firstBook.Bids[0].Price)
+		assert.Equal(t, firstQuote.AskPrice, firstBook.Asks[0].Price)
+
+		firstHistory, _ := firstPricing.GetHistoricalPrices(symbol, 24*time.Hour)
+		secondHistory, _ := secondPricing.GetHistoricalPrices(symbol, 24*time.Hour)
+		assert.Equal(t, firstHistory, secondHistory)
+		assert.Len(t, firstHistory, 25)
+		assert.Equal(t, simulationTestTime, firstHistory[len(firstHistory)-1].Timestamp)
+
+		firstImpact, _ := firstPricing.GetPriceImpactEstimate(symbol, domain.OrderSideBuy, 500)
+		secondImpact, _ := secondPricing.GetPriceImpactEstimate(symbol, domain.OrderSideBuy, 500)
+		assert.Equal(t, firstImpact, secondImpact)
+
+		firstVolatility, _ := firstRisk.GetMarketVolatility(symbol)
+		secondVolatility, _ := secondRisk.GetMarketVolatility(symbol)
+		assert.Equal(t, firstVolatility, secondVolatility)
+		assert.InDelta(t, 0.30, firstVolatility.Volatility30Day, 0.20)
+	}
+}
+
+func TestSimulatedClients_SeedChangesTheMarket(t *testing.T) {
+	config := DefaultSimulationConfig()
+	reseeded := config
+	reseeded.Seed = 42
+	first, _, _ := newSimulationTestClients(config)
+	second, _, _ := newSimulationTestClients(reseeded)
+
+	differs := false
+	for _, symbol := range []string{"PETR4", "VALE3", "ITUB4", "BBDC4"} {
+		firstPrice, _ := first.GetCurrentPrice(context.Background(), symbol)
+		secondPrice, _ := second.GetCurrentPrice(context.Background(), symbol)
+		differs = differs || firstPrice != secondPrice
+	}
+	assert.True(t, differs, "a different seed must produce a different market")
+}
+
+func TestSimulatedClients_AccountsNeverLimitALoadRun(t *testing.T) {
+	config := DefaultSimulationConfig()
+	marketData, _, risk := newSimulationTestClients(config)
+	positions := NewSimulatedPositionClient(config)
+
+	open, err := marketData.IsMarketOpen(context.Background(), "PETR4")
+	require.NoError(t, err)
+	assert.True(t, open)
+	hours, _ := marketData.GetTradingHours(context.Background(), "PETR4")
+	assert.True(t, hours.IsOpen)
+	asset, _ := marketData.GetAssetDetails(context.Background(), "petr4")
+	assert.True(t, asset.IsTradeable)
+	assert.Equal(t, "PETR4", asset.Symbol)
+
+	limits, _ := risk.GetUserTradingLimits("user-1")
+	assert.Zero(t, limits.DailyTradingUsed)
+	assert.Equal(t, limits.DailyTradingLimit, limits.RemainingDailyLimit)
+	balance, _ := risk.GetAccountBalance("user-1")
+	assert.Equal(t, config.AccountBalance, balance.AvailableBalance)
+
+	held, _ := positions.GetAvailableQuantity("user-1", "PETR4")
+	assert.Equal(t, config.HeldQuantity, held)
+	sufficient, _ := positions.HasSufficientBalance("user-1", config.AccountBalance)
+	assert.True(t, sufficient)
+	sufficient, _ = positions.HasSufficientBalance("user-1", config.AccountBalance+1)
+	assert.False(t, sufficient)
+}
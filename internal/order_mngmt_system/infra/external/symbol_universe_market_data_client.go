@@ -0,0 +1,42 @@
+package external
+
+import (
+	"context"
+	"log"
+
+	symbolDomain "HubInvestments/internal/symbol_universe/domain/model"
+)
+
+// ISymbolUniverseReader defines the interface for reading the symbol universe (dependency inversion)
+type ISymbolUniverseReader interface {
+	FindBySymbol(ctx context.Context, symbol string) (*symbolDomain.TradeableSymbol, error)
+}
+
+// SymbolUniverseMarketDataClient validates symbols against the symbol universe store,
+// falling back to the market data provider for symbols the store does not know yet
+type SymbolUniverseMarketDataClient struct {
+	IMarketDataClient
+	universe ISymbolUniverseReader
+}
+
+func NewSymbolUniverseMarketDataClient(client IMarketDataClient, universe ISymbolUniverseReader) *SymbolUniverseMarketDataClient {
+	return &SymbolUniverseMarketDataClient{
+		IMarketDataClient: client,
+		universe:          universe,
+	}
+}
+
+// ValidateSymbol checks the symbol universe first and only asks the provider for unknown symbols
+func (c *SymbolUniverseMarketDataClient) ValidateSymbol(ctx context.Context, symbol string) (bool, error) {
+	entry, err := c.universe.FindBySymbol(ctx, symbol)
+	if err != nil {
+		log.Printf("Symbol universe lookup failed for %s, falling back to market data provider: %v", symbol, err)
+		return c.IMarketDataClient.ValidateSymbol(ctx, symbol)
+	}
+
+	if entry != nil {
+		return entry.IsTradeable, nil
+	}
+
+	return c.IMarketDataClient.ValidateSymbol(ctx, symbol)
+}
@@ -0,0 +1,63 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	symbolDomain "HubInvestments/internal/symbol_universe/domain/model"
+)
+
+type stubSymbolUniverse struct {
+	symbols map[string]*symbolDomain.TradeableSymbol
+	err     error
+}
+
+func (s *stubSymbolUniverse) FindBySymbol(ctx context.Context, symbol string) (*symbolDomain.TradeableSymbol, error) {
+	return s.symbols[symbol], s.err
+}
+
+type stubProviderClient struct {
+	IMarketDataClient
+	validated []string
+}
+
+func (s *stubProviderClient) ValidateSymbol(ctx context.Context, symbol string) (bool, error) {
+	s.validated = append(s.validated, symbol)
+	return true, nil
+}
+
+func TestSymbolUniverseMarketDataClient_ValidateSymbol(t *testing.T) {
+	provider := &stubProviderClient{}
+	universe := &stubSymbolUniverse{symbols: map[string]*symbolDomain.TradeableSymbol{
+		"PETR4": {Symbol: "PETR4", IsTradeable: true},
+		"OIBR3": {Symbol: "OIBR3", IsTradeable: false},
+	}}
+	client := NewSymbolUniverseMarketDataClient(provider, universe)
+
+	valid, err := client.ValidateSymbol(context.Background(), "PETR4")
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = client.ValidateSymbol(context.Background(), "OIBR3")
+	assert.NoError(t, err)
+	assert.False(t, valid)
+	assert.Empty(t, provider.validated)
+
+	valid, err = client.ValidateSymbol(context.Background(), "NEW11")
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, []string{"NEW11"}, provider.validated)
+}
+
+func TestSymbolUniverseMarketDataClient_ValidateSymbol_StoreErrorFallsBack(t *testing.T) {
+	provider := &stubProviderClient{}
+	client := NewSymbolUniverseMarketDataClient(provider, &stubSymbolUniverse{err: errors.New("db down")})
+
+	valid, err := client.ValidateSymbol(context.Background(), "PETR4")
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, []string{"PETR4"}, provider.validated)
+}
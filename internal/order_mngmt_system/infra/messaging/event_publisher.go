@@ -20,6 +20,8 @@ type IEventPublisher interface {
 type EventPublisher struct {
 	messageHandler msg.MessageHandler
 	exchangeName   string
+	sequencer      *msg.SequenceGenerator
+	backpressure   *PositionBackpressure
 }
 
 func NewEventPublisher(messageHandler msg.MessageHandler, exchangeName string) *EventPublisher {
@@ -29,19 +31,33 @@ func NewEventPublisher(messageHandler msg.MessageHandler, exchangeName string) *
 	return &EventPublisher{
 		messageHandler: messageHandler,
 		exchangeName:   exchangeName,
+		sequencer:      msg.NewSequenceGenerator(),
 	}
 }
 
+// NewEventPublisherWithBackpressure creates an event publisher that slows down order execution
+// publishing while the position update queue is saturated
+func NewEventPublisherWithBackpressure(
+	messageHandler msg.MessageHandler,
+	exchangeName string,
+	backpressure *PositionBackpressure,
+) *EventPublisher {
+	publisher := NewEventPublisher(messageHandler, exchangeName)
+	publisher.backpressure = backpressure
+	return publisher
+}
+
 type EventMessage struct {
-	EventID       string                 `json:"event_id"`
-	EventType     string                 `json:"event_type"`
-	AggregateID   string                 `json:"aggregate_id"`
-	OccurredAt    time.Time              `json:"occurred_at"`
-	EventData     map[string]interface{} `json:"event_data"`
-	MessageID     string                 `json:"message_id"`
-	CorrelationID string                 `json:"correlation_id"`
-	Timestamp     time.Time              `json:"timestamp"`
-	Source        string                 `json:"source"`
+	EventID        string                 `json:"event_id"`
+	EventType      string                 `json:"event_type"`
+	AggregateID    string                 `json:"aggregate_id"`
+	SequenceNumber int64                  `json:"sequence_number"`
+	OccurredAt     time.Time              `json:"occurred_at"`
+	EventData      map[string]interface{} `json:"event_data"`
+	MessageID      string                 `json:"message_id"`
+	CorrelationID  string                 `json:"correlation_id"`
+	Timestamp      time.Time              `json:"timestamp"`
+	Source         string                 `json:"source"`
 }
 
 func (p *EventPublisher) PublishOrderExecutedEvent(ctx context.Context, event *domain.OrderExecutedEvent) error {
@@ -49,8 +65,17 @@ func (p *EventPublisher) PublishOrderExecutedEvent(ctx context.Context, event *d
 		return fmt.Errorf("event cannot be nil")
 	}
 
+	if p.backpressure != nil {
+		if err := p.backpressure.Wait(ctx); err != nil {
+			return fmt.Errorf("interrupted while waiting for position queue backpressure: %w", err)
+		}
+	}
+
+	sequenceNumber := p.assignSequence(&event.OrderEvent)
+
 	// Create position update message in the format expected by position worker
 	positionUpdateMessage := map[string]interface{}{
+		"sequence_number":       sequenceNumber,
 		"order_id":              event.OrderID(),
 		"user_id":               event.UserID(),
 		"symbol":                event.Symbol,
@@ -82,13 +107,14 @@ func (p *EventPublisher) PublishOrderExecutedEvent(ctx context.Context, event *d
 	queueName := "positions.updates"
 	messageID := fmt.Sprintf("position_update_%s_%d", event.OrderID(), time.Now().UnixNano())
 	headers := map[string]interface{}{
-		"event_type":     "OrderExecuted",
-		"order_side":     event.OrderSide.String(),
-		"symbol":         event.Symbol,
-		"user_id":        event.UserID(),
-		"execution_at":   event.ExecutedAt.Format(time.RFC3339),
-		"message_id":     messageID,
-		"correlation_id": event.OrderID(),
+		"event_type":      "OrderExecuted",
+		"order_side":      event.OrderSide.String(),
+		"symbol":          event.Symbol,
+		"user_id":         event.UserID(),
+		"execution_at":    event.ExecutedAt.Format(time.RFC3339),
+		"message_id":      messageID,
+		"correlation_id":  event.OrderID(),
+		"sequence_number": sequenceNumber,
 	}
 
 	return p.publishEvent(ctx, queueName, messageBytes, messageID, headers)
@@ -107,15 +133,16 @@ func (p *EventPublisher) PublishOrderFailedEvent(ctx context.Context, event *dom
 	}
 
 	eventMessage := EventMessage{
-		EventID:       event.EventID(),
-		EventType:     event.EventType(),
-		AggregateID:   event.AggregateID(),
-		OccurredAt:    event.OccurredAt(),
-		EventData:     eventData,
-		MessageID:     fmt.Sprintf("event_%s_%d", event.EventID(), time.Now().UnixNano()),
-		CorrelationID: event.OrderID(),
-		Timestamp:     time.Now(),
-		Source:        "order_processing",
+		EventID:        event.EventID(),
+		EventType:      event.EventType(),
+		AggregateID:    event.AggregateID(),
+		SequenceNumber: p.assignSequence(&event.OrderEvent),
+		OccurredAt:     event.OccurredAt(),
+		EventData:      eventData,
+		MessageID:      fmt.Sprintf("event_%s_%d", event.EventID(), time.Now().UnixNano()),
+		CorrelationID:  event.OrderID(),
+		Timestamp:      time.Now(),
+		Source:         "order_processing",
 	}
 
 	messageBytes, err := json.Marshal(eventMessage)
@@ -147,15 +174,16 @@ func (p *EventPublisher) PublishOrderCancelledEvent(ctx context.Context, event *
 	}
 
 	eventMessage := EventMessage{
-		EventID:       event.EventID(),
-		EventType:     event.EventType(),
-		AggregateID:   event.AggregateID(),
-		OccurredAt:    event.OccurredAt(),
-		EventData:     eventData,
-		MessageID:     fmt.Sprintf("event_%s_%d", event.EventID(), time.Now().UnixNano()),
-		CorrelationID: event.OrderID(),
-		Timestamp:     time.Now(),
-		Source:        "order_cancellation",
+		EventID:        event.EventID(),
+		EventType:      event.EventType(),
+		AggregateID:    event.AggregateID(),
+		SequenceNumber: p.assignSequence(&event.OrderEvent),
+		OccurredAt:     event.OccurredAt(),
+		EventData:      eventData,
+		MessageID:      fmt.Sprintf("event_%s_%d", event.EventID(), time.Now().UnixNano()),
+		CorrelationID:  event.OrderID(),
+		Timestamp:      time.Now(),
+		Source:         "order_cancellation",
 	}
 
 	messageBytes, err := json.Marshal(eventMessage)
@@ -174,6 +202,14 @@ func (p *EventPublisher) PublishOrderCancelledEvent(ctx context.Context, event *
 	return p.publishEvent(ctx, queueName, messageBytes, eventMessage.MessageID, headers)
 }
 
+// assignSequence stamps the event with a sequence number unless it already carries one
+func (p *EventPublisher) assignSequence(event *domain.OrderEvent) int64 {
+	if event.SequenceNumber() == 0 {
+		event.SetSequenceNumber(p.sequencer.Next())
+	}
+	return event.SequenceNumber()
+}
+
 func (p *EventPublisher) publishEvent(
 	ctx context.Context,
 	queueName string,
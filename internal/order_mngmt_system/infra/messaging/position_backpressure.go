@@ -0,0 +1,117 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	msg "HubInvestments/shared/infra/messaging"
+)
+
+// IQueueDepthSource reports queue information; satisfied by msg.MessageHandler
+type IQueueDepthSource interface {
+	QueueInfo(queueName string) (*msg.QueueInfo, error)
+}
+
+// PositionBackpressureConfig holds configuration for throttling order execution publishing
+// while the position worker is behind
+type PositionBackpressureConfig struct {
+	QueueName     string        // Position update queue whose depth is watched
+	HighWatermark int           // Depth at which publishing is throttled
+	LowWatermark  int           // Depth at or below which full rate is restored
+	ThrottleDelay time.Duration // Pause before each publish while throttled
+	CheckInterval time.Duration // How long a depth reading is reused before asking the broker again
+}
+
+// DefaultPositionBackpressureConfig returns the default backpressure configuration
+func DefaultPositionBackpressureConfig() PositionBackpressureConfig {
+	return PositionBackpressureConfig{
+		QueueName:     "positions.updates",
+		HighWatermark: 5000,                   // Throttle once 5000 position updates are waiting
+		LowWatermark:  1000,                   // Back to full rate once the backlog drops to 1000
+		ThrottleDelay: 200 * time.Millisecond, // At most 5 executions published per second while throttled
+		CheckInterval: 2 * time.Second,        // Ask the broker for the depth at most every 2 seconds
+	}
+}
+
+// PositionBackpressure slows order execution publishing while the position update queue is saturated
+type PositionBackpressure struct {
+	source IQueueDepthSource
+	config PositionBackpressureConfig
+
+	mu        sync.Mutex
+	throttled bool
+	lastCheck time.Time
+}
+
+// NewPositionBackpressure creates a backpressure signal from the position update queue depth
+func NewPositionBackpressure(source IQueueDepthSource, config PositionBackpressureConfig) *PositionBackpressure {
+	if config.QueueName == "" {
+		config.QueueName = "positions.updates"
+	}
+	if config.LowWatermark <= 0 || config.LowWatermark > config.HighWatermark {
+		config.LowWatermark = config.HighWatermark
+	}
+	return &PositionBackpressure{
+		source: source,
+		config: config,
+	}
+}
+
+// Throttled reports whether publishing is currently being slowed down
+func (b *PositionBackpressure) Throttled() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(time.Now())
+	return b.throttled
+}
+
+// Wait blocks for the throttle delay while the position queue is saturated and returns
+// immediately otherwise
+func (b *PositionBackpressure) Wait(ctx context.Context) error {
+	if !b.Throttled() {
+		return nil
+	}
+
+	timer := time.NewTimer(b.config.ThrottleDelay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// refresh samples the queue depth when the last reading is older than the check interval.
+// Throttling starts at the high watermark and stops at the low watermark so the rate does
+// not flap around a single threshold.
+func (b *PositionBackpressure) refresh(now time.Time) {
+	if b.config.HighWatermark <= 0 {
+		b.throttled = false
+		return
+	}
+	if !b.lastCheck.IsZero() && now.Sub(b.lastCheck) < b.config.CheckInterval {
+		return
+	}
+	b.lastCheck = now
+
+	info, err := b.source.QueueInfo(b.config.QueueName)
+	if err != nil || info == nil {
+		// Keep publishing at the current rate; the depth is unknown
+		if err != nil {
+			fmt.Printf("Warning: failed to read depth of queue '%s': %v\n", b.config.QueueName, err)
+		}
+		return
+	}
+
+	switch {
+	case info.Messages >= b.config.HighWatermark:
+		b.throttled = true
+	case info.Messages <= b.config.LowWatermark:
+		b.throttled = false
+	}
+}
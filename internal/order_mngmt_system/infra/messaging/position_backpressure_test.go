@@ -0,0 +1,115 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	msg "HubInvestments/shared/infra/messaging"
+)
+
+type stubQueueMessageHandler struct {
+	mu        sync.Mutex
+	depth     int
+	published int
+}
+
+func (h *stubQueueMessageHandler) setDepth(depth int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.depth = depth
+}
+
+func (h *stubQueueMessageHandler) Publish(ctx context.Context, queueName string, message []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.published++
+	return nil
+}
+
+func (h *stubQueueMessageHandler) PublishWithOptions(ctx context.Context, options msg.PublishOptions) error {
+	return h.Publish(ctx, options.QueueName, options.Message)
+}
+
+func (h *stubQueueMessageHandler) Consume(ctx context.Context, queueName string, handler msg.MessageConsumer) error {
+	return nil
+}
+
+func (h *stubQueueMessageHandler) DeclareQueue(queueName string, options msg.QueueOptions) error {
+	return nil
+}
+
+func (h *stubQueueMessageHandler) DeleteQueue(queueName string) error { return nil }
+
+func (h *stubQueueMessageHandler) PurgeQueue(queueName string) error { return nil }
+
+func (h *stubQueueMessageHandler) QueueInfo(queueName string) (*msg.QueueInfo, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return &msg.QueueInfo{Name: queueName, Messages: h.depth}, nil
+}
+
+func (h *stubQueueMessageHandler) HealthCheck(ctx context.Context) error { return nil }
+
+func (h *stubQueueMessageHandler) Close() error { return nil }
+
+func newExecutedEvent(orderID string) *domain.OrderExecutedEvent {
+	return domain.NewOrderExecutedEventWithDetails(
+		orderID, "user-1", "PETR4",
+		domain.OrderSideBuy, domain.OrderTypeMarket,
+		100, 25.00, 2500.00,
+		time.Now(), nil, nil,
+	)
+}
+
+func publishExecutedEvents(t *testing.T, publisher *EventPublisher, count int) time.Duration {
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		require.NoError(t, publisher.PublishOrderExecutedEvent(context.Background(), newExecutedEvent("order-1")))
+	}
+	return time.Since(start)
+}
+
+func TestEventPublisher_PositionBackpressure_ThrottlesAndRecovers(t *testing.T) {
+	handler := &stubQueueMessageHandler{depth: 50}
+	backpressure := NewPositionBackpressure(handler, PositionBackpressureConfig{
+		HighWatermark: 1000,
+		LowWatermark:  200,
+		ThrottleDelay: 40 * time.Millisecond,
+	})
+	publisher := NewEventPublisherWithBackpressure(handler, "orders.events", backpressure)
+
+	normal := publishExecutedEvents(t, publisher, 5)
+	assert.Less(t, normal, 100*time.Millisecond)
+
+	handler.setDepth(1500)
+	saturated := publishExecutedEvents(t, publisher, 5)
+	assert.GreaterOrEqual(t, saturated, 200*time.Millisecond)
+
+	// Still above the low watermark, so the rate stays reduced
+	handler.setDepth(600)
+	assert.True(t, backpressure.Throttled())
+
+	handler.setDepth(100)
+	recovered := publishExecutedEvents(t, publisher, 5)
+	assert.Less(t, recovered, 100*time.Millisecond)
+	assert.Equal(t, 15, handler.published)
+}
+
+func TestPositionBackpressure_Wait_RespectsContextCancellation(t *testing.T) {
+	handler := &stubQueueMessageHandler{depth: 5000}
+	backpressure := NewPositionBackpressure(handler, PositionBackpressureConfig{
+		HighWatermark: 1000,
+		ThrottleDelay: time.Minute,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, backpressure.Wait(ctx), context.Canceled)
+}
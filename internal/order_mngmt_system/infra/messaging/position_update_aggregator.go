@@ -0,0 +1,199 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// PositionUpdateAggregationConfig holds configuration for batching partial fills into consolidated
+// position updates
+type PositionUpdateAggregationConfig struct {
+	Window   time.Duration // How long the first buffered partial fill of an order waits before it is published
+	MaxFills int           // Buffered partial fills of an order that trigger an immediate publish
+}
+
+// DefaultPositionUpdateAggregationConfig returns the default aggregation configuration
+func DefaultPositionUpdateAggregationConfig() PositionUpdateAggregationConfig {
+	return PositionUpdateAggregationConfig{
+		Window:   500 * time.Millisecond, // Positions lag the fills by at most half a second
+		MaxFills: 20,                     // A burst of fills still reaches the worker in batches of 20
+	}
+}
+
+// pendingFills is the consolidated execution of the partial fills buffered for one order
+type pendingFills struct {
+	event *domain.OrderExecutedEvent
+	fills int
+	timer *time.Timer
+}
+
+// PositionUpdateAggregator batches the partial fills of an order into periodic consolidated position
+// updates so the position worker is not flooded when an order fills in many small pieces. The
+// consolidated update carries the summed quantity and value at the volume weighted price, so the
+// worker reaches the same position as it would applying every fill. Complete executions, failures
+// and cancellations publish the order's buffered fills first and are never delayed. Events are
+// published after the lock is released, so a slow broker never stalls other orders' fills.
+type PositionUpdateAggregator struct {
+	next   IEventPublisher
+	config PositionUpdateAggregationConfig
+
+	mu      sync.Mutex
+	pending map[string]*pendingFills
+}
+
+// NewPositionUpdateAggregator wraps the publisher so partial fills are aggregated before publishing
+func NewPositionUpdateAggregator(next IEventPublisher, config PositionUpdateAggregationConfig) *PositionUpdateAggregator {
+	return &PositionUpdateAggregator{
+		next:    next,
+		config:  config,
+		pending: make(map[string]*pendingFills),
+	}
+}
+
+// PublishOrderExecutedEvent buffers partial fills and publishes complete executions together with
+// whatever is still buffered for the order
+func (a *PositionUpdateAggregator) PublishOrderExecutedEvent(ctx context.Context, event *domain.OrderExecutedEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	orderID := event.OrderID()
+
+	a.mu.Lock()
+	pending := a.pending[orderID]
+	if !event.PartialFill {
+		a.removePending(orderID, pending)
+		a.mu.Unlock()
+		return a.next.PublishOrderExecutedEvent(ctx, mergeExecution(pending, event))
+	}
+
+	if pending == nil {
+		pending = &pendingFills{}
+		a.pending[orderID] = pending
+		if a.config.Window > 0 {
+			pending.timer = time.AfterFunc(a.config.Window, func() {
+				// A count or final fill flush may already have published these fills
+				if err := a.publish(context.Background(), orderID, a.takePending(orderID, pending)); err != nil {
+					fmt.Printf("Warning: %v\n", err)
+				}
+			})
+		}
+	}
+	pending.event = mergeExecution(pending, event)
+	pending.fills++
+
+	if a.config.MaxFills > 0 && pending.fills < a.config.MaxFills {
+		a.mu.Unlock()
+		return nil
+	}
+	a.removePending(orderID, pending)
+	a.mu.Unlock()
+
+	return a.publish(ctx, orderID, pending.event)
+}
+
+// PublishOrderFailedEvent publishes the order's buffered fills before the failure
+func (a *PositionUpdateAggregator) PublishOrderFailedEvent(ctx context.Context, event *domain.OrderFailedEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	if err := a.publish(ctx, event.OrderID(), a.takePending(event.OrderID(), nil)); err != nil {
+		return err
+	}
+	return a.next.PublishOrderFailedEvent(ctx, event)
+}
+
+// PublishOrderCancelledEvent publishes the order's buffered fills before the cancellation
+func (a *PositionUpdateAggregator) PublishOrderCancelledEvent(ctx context.Context, event *domain.OrderCancelledEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	if err := a.publish(ctx, event.OrderID(), a.takePending(event.OrderID(), nil)); err != nil {
+		return err
+	}
+	return a.next.PublishOrderCancelledEvent(ctx, event)
+}
+
+// Flush publishes the buffered fills of every order, for use on shutdown
+func (a *PositionUpdateAggregator) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	events := make(map[string]*domain.OrderExecutedEvent, len(a.pending))
+	for orderID, pending := range a.pending {
+		a.removePending(orderID, pending)
+		events[orderID] = pending.event
+	}
+	a.mu.Unlock()
+
+	for orderID, event := range events {
+		if err := a.publish(ctx, orderID, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PendingOrders returns the number of orders with buffered partial fills
+func (a *PositionUpdateAggregator) PendingOrders() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+// takePending removes the order's buffered fills and returns their consolidated execution, or nil
+// when nothing is buffered. With expected set, only those buffered fills are taken.
+func (a *PositionUpdateAggregator) takePending(orderID string, expected *pendingFills) *domain.OrderExecutedEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pending := a.pending[orderID]
+	if pending == nil || (expected != nil && pending != expected) {
+		return nil
+	}
+	a.removePending(orderID, pending)
+	return pending.event
+}
+
+// publish sends the order's consolidated fills; the caller must not hold the lock
+func (a *PositionUpdateAggregator) publish(ctx context.Context, orderID string, event *domain.OrderExecutedEvent) error {
+	if event == nil {
+		return nil
+	}
+	if err := a.next.PublishOrderExecutedEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish aggregated fills of order %s: %w", orderID, err)
+	}
+	return nil
+}
+
+// removePending drops the order's buffered fills; the caller holds the lock
+func (a *PositionUpdateAggregator) removePending(orderID string, pending *pendingFills) {
+	if pending == nil {
+		return
+	}
+	delete(a.pending, orderID)
+	if pending.timer != nil {
+		pending.timer.Stop()
+	}
+}
+
+// mergeExecution adds the execution to the buffered fills, summing quantity and value and pricing
+// the result at the volume weighted average. The latest execution's timestamps and market data win.
+func mergeExecution(pending *pendingFills, event *domain.OrderExecutedEvent) *domain.OrderExecutedEvent {
+	if pending == nil || pending.event == nil {
+		return event
+	}
+
+	merged := *event
+	merged.OrderEvent = pending.event.OrderEvent
+	merged.Quantity = pending.event.Quantity + event.Quantity
+	merged.TotalValue = pending.event.TotalValue + event.TotalValue
+	if merged.Quantity > 0 {
+		merged.ExecutionPrice = merged.TotalValue / merged.Quantity
+	}
+	return &merged
+}
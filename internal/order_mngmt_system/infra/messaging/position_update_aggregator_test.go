@@ -0,0 +1,210 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	positionDomain "HubInvestments/internal/position/domain/model"
+	msg "HubInvestments/shared/infra/messaging"
+)
+
+// capturingMessageHandler keeps every position update published
+type capturingMessageHandler struct {
+	stubQueueMessageHandler
+	mu       sync.Mutex
+	messages []publishedPositionUpdate
+}
+
+type publishedPositionUpdate struct {
+	SequenceNumber int64   `json:"sequence_number"`
+	OrderID        string  `json:"order_id"`
+	OrderSide      string  `json:"order_side"`
+	Quantity       float64 `json:"quantity"`
+	ExecutionPrice float64 `json:"execution_price"`
+	TotalValue     float64 `json:"total_value"`
+}
+
+func (h *capturingMessageHandler) Publish(ctx context.Context, queueName string, message []byte) error {
+	var update publishedPositionUpdate
+	if err := json.Unmarshal(message, &update); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages = append(h.messages, update)
+	return nil
+}
+
+func (h *capturingMessageHandler) PublishWithOptions(ctx context.Context, options msg.PublishOptions) error {
+	return h.Publish(ctx, options.QueueName, options.Message)
+}
+
+func (h *capturingMessageHandler) published() []publishedPositionUpdate {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]publishedPositionUpdate(nil), h.messages...)
+}
+
+type testFill struct {
+	quantity float64
+	price    float64
+}
+
+func fillEvent(orderID string, side domain.OrderSide, fill testFill, partial bool) *domain.OrderExecutedEvent {
+	event := domain.NewOrderExecutedEventWithDetails(orderID, "user1", "PETR4", side, domain.OrderTypeMarket,
+		fill.quantity, fill.price, fill.quantity*fill.price, time.Now(), nil, nil)
+	event.PartialFill = partial
+	return event
+}
+
+// publishFills publishes the order's fills, flagging all but the last as partial
+func publishFills(t *testing.T, publisher IEventPublisher, orderID string, side domain.OrderSide, fills []testFill) {
+	for i, fill := range fills {
+		require.NoError(t, publisher.PublishOrderExecutedEvent(context.Background(), fillEvent(orderID, side, fill, i < len(fills)-1)))
+	}
+}
+
+// applyUpdates plays the published updates against a position the way the position worker does
+func applyUpdates(t *testing.T, updates []publishedPositionUpdate) *positionDomain.Position {
+	var position *positionDomain.Position
+	for _, update := range updates {
+		orderID := update.OrderID
+		if position == nil {
+			var err error
+			position, err = positionDomain.NewPosition(uuid.New(), "PETR4", update.Quantity, update.ExecutionPrice, positionDomain.PositionTypeLong)
+			require.NoError(t, err)
+			continue
+		}
+		require.NoError(t, position.UpdateQuantityWithOrderID(update.Quantity, update.ExecutionPrice, update.OrderSide == "BUY", &orderID))
+	}
+	return position
+}
+
+func TestPositionUpdateAggregator_SameFinalPositionAsPerFillUpdates(t *testing.T) {
+	buyFills := []testFill{{10, 30.00}, {5, 30.10}, {20, 29.95}, {15, 30.20}, {8, 30.05}, {42, 29.90}}
+	sellFills := []testFill{{12, 31.00}, {7, 31.25}, {6, 30.80}}
+
+	perFill := &capturingMessageHandler{}
+	publisher := NewEventPublisher(perFill, "")
+	publishFills(t, publisher, "buy-order", domain.OrderSideBuy, buyFills)
+	publishFills(t, publisher, "sell-order", domain.OrderSideSell, sellFills)
+
+	aggregated := &capturingMessageHandler{}
+	aggregator := NewPositionUpdateAggregator(NewEventPublisher(aggregated, ""),
+		PositionUpdateAggregationConfig{Window: time.Hour, MaxFills: 4})
+	publishFills(t, aggregator, "buy-order", domain.OrderSideBuy, buyFills)
+	publishFills(t, aggregator, "sell-order", domain.OrderSideSell, sellFills)
+
+	require.Len(t, perFill.published(), 9)
+	// Four buy fills reach the count, the last two go out with the final fill, and the sells go out together
+	require.Len(t, aggregated.published(), 3)
+	assert.Zero(t, aggregator.PendingOrders())
+
+	expected := applyUpdates(t, perFill.published())
+	actual := applyUpdates(t, aggregated.published())
+	assert.InDelta(t, expected.Quantity, actual.Quantity, 1e-9)
+	assert.InDelta(t, expected.AveragePrice, actual.AveragePrice, 1e-9)
+	assert.InDelta(t, expected.TotalInvestment, actual.TotalInvestment, 1e-6)
+	assert.InDelta(t, 100-25, actual.Quantity, 1e-9)
+}
+
+func TestPositionUpdateAggregator_WindowPublishesBufferedFills(t *testing.T) {
+	handler := &capturingMessageHandler{}
+	aggregator := NewPositionUpdateAggregator(NewEventPublisher(handler, ""),
+		PositionUpdateAggregationConfig{Window: 20 * time.Millisecond, MaxFills: 100})
+
+	require.NoError(t, aggregator.PublishOrderExecutedEvent(context.Background(), fillEvent("order-2", domain.OrderSideBuy, testFill{10, 30}, true)))
+	require.NoError(t, aggregator.PublishOrderExecutedEvent(context.Background(), fillEvent("order-2", domain.OrderSideBuy, testFill{10, 31}, true)))
+	assert.Empty(t, handler.published())
+	assert.Equal(t, 1, aggregator.PendingOrders())
+
+	assert.Eventually(t, func() bool { return len(handler.published()) == 1 }, time.Second, 5*time.Millisecond)
+	update := handler.published()[0]
+	assert.Equal(t, "order-2", update.OrderID)
+	assert.InDelta(t, 20, update.Quantity, 1e-9)
+	assert.InDelta(t, 30.5, update.ExecutionPrice, 1e-9)
+	assert.Zero(t, aggregator.PendingOrders())
+}
+
+func TestPositionUpdateAggregator_CancellationPublishesBufferedFillsFirst(t *testing.T) {
+	handler := &capturingMessageHandler{}
+	aggregator := NewPositionUpdateAggregator(NewEventPublisher(handler, ""),
+		PositionUpdateAggregationConfig{Window: time.Hour, MaxFills: 100})
+
+	require.NoError(t, aggregator.PublishOrderExecutedEvent(context.Background(), fillEvent("order-1", domain.OrderSideBuy, testFill{10, 30}, true)))
+	require.NoError(t, aggregator.PublishOrderCancelledEvent(context.Background(),
+		domain.NewOrderCancelledEvent("order-1", "user1", "user request", "user1", time.Now())))
+
+	published := handler.published()
+	require.Len(t, published, 2)
+	assert.Equal(t, "order-1", published[0].OrderID)
+	assert.InDelta(t, 10, published[0].Quantity, 1e-9)
+	assert.Less(t, published[0].SequenceNumber, published[1].SequenceNumber)
+	assert.Zero(t, aggregator.PendingOrders())
+}
+
+func TestPositionUpdateAggregator_CompleteExecutionPassesThrough(t *testing.T) {
+	handler := &capturingMessageHandler{}
+	aggregator := NewPositionUpdateAggregator(NewEventPublisher(handler, ""), DefaultPositionUpdateAggregationConfig())
+
+	require.NoError(t, aggregator.PublishOrderExecutedEvent(context.Background(), fillEvent("order-1", domain.OrderSideBuy, testFill{100, 30}, false)))
+
+	require.Len(t, handler.published(), 1)
+	assert.Zero(t, aggregator.PendingOrders())
+}
+
+// blockingEventPublisher holds executions of one order until released
+type blockingEventPublisher struct {
+	IEventPublisher
+	blockedOrderID string
+	entered        chan struct{}
+	release        chan struct{}
+}
+
+func (p *blockingEventPublisher) PublishOrderExecutedEvent(ctx context.Context, event *domain.OrderExecutedEvent) error {
+	if event.OrderID() == p.blockedOrderID {
+		close(p.entered)
+		<-p.release
+	}
+	return p.IEventPublisher.PublishOrderExecutedEvent(ctx, event)
+}
+
+func TestPositionUpdateAggregator_SlowPublishDoesNotBlockOtherOrders(t *testing.T) {
+	handler := &capturingMessageHandler{}
+	next := &blockingEventPublisher{
+		IEventPublisher: NewEventPublisher(handler, ""),
+		blockedOrderID:  "order-1",
+		entered:         make(chan struct{}),
+		release:         make(chan struct{}),
+	}
+	aggregator := NewPositionUpdateAggregator(next, PositionUpdateAggregationConfig{Window: time.Hour, MaxFills: 100})
+
+	go func() {
+		_ = aggregator.PublishOrderExecutedEvent(context.Background(), fillEvent("order-1", domain.OrderSideBuy, testFill{10, 30}, false))
+	}()
+	<-next.entered
+
+	done := make(chan struct{})
+	go func() {
+		_ = aggregator.PublishOrderExecutedEvent(context.Background(), fillEvent("order-2", domain.OrderSideBuy, testFill{10, 30}, true))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected buffering order-2 not to wait for order-1's publish")
+	}
+	assert.Equal(t, 1, aggregator.PendingOrders())
+
+	close(next.release)
+	assert.Eventually(t, func() bool { return len(handler.published()) == 1 }, time.Second, 5*time.Millisecond)
+}
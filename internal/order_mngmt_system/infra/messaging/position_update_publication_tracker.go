@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+// PositionUpdatePublicationTracker records each complete execution the wrapped publisher publishes,
+// so the worker's startup recovery can tell which executed orders never reached the position worker
+type PositionUpdatePublicationTracker struct {
+	next         IEventPublisher
+	publications repository.IPositionUpdatePublicationRepository
+}
+
+// NewPositionUpdatePublicationTracker wraps the publisher so published executions are recorded
+func NewPositionUpdatePublicationTracker(next IEventPublisher, publications repository.IPositionUpdatePublicationRepository) *PositionUpdatePublicationTracker {
+	return &PositionUpdatePublicationTracker{
+		next:         next,
+		publications: publications,
+	}
+}
+
+// PublishOrderExecutedEvent publishes the execution and records complete ones. A failure to record is
+// logged only; the recovery then republishes the update, which is safer than losing it.
+func (t *PositionUpdatePublicationTracker) PublishOrderExecutedEvent(ctx context.Context, event *domain.OrderExecutedEvent) error {
+	if err := t.next.PublishOrderExecutedEvent(ctx, event); err != nil {
+		return err
+	}
+
+	if !event.PartialFill {
+		if err := t.publications.MarkPublished(ctx, event.OrderID(), time.Now()); err != nil {
+			log.Printf("Failed to record position update publication for order %s: %v", event.OrderID(), err)
+		}
+	}
+
+	return nil
+}
+
+func (t *PositionUpdatePublicationTracker) PublishOrderFailedEvent(ctx context.Context, event *domain.OrderFailedEvent) error {
+	return t.next.PublishOrderFailedEvent(ctx, event)
+}
+
+func (t *PositionUpdatePublicationTracker) PublishOrderCancelledEvent(ctx context.Context, event *domain.OrderCancelledEvent) error {
+	return t.next.PublishOrderCancelledEvent(ctx, event)
+}
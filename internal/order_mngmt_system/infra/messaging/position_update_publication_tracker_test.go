@@ -0,0 +1,62 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	msg "HubInvestments/shared/infra/messaging"
+)
+
+type stubPublicationRepository struct {
+	published map[string]time.Time
+}
+
+func (r *stubPublicationRepository) MarkPublished(ctx context.Context, orderID string, publishedAt time.Time) error {
+	r.published[orderID] = publishedAt
+	return nil
+}
+
+func (r *stubPublicationRepository) IsPublished(ctx context.Context, orderID string) (bool, error) {
+	_, exists := r.published[orderID]
+	return exists, nil
+}
+
+type failingMessageHandler struct {
+	stubQueueMessageHandler
+}
+
+func (h *failingMessageHandler) Publish(ctx context.Context, queueName string, message []byte) error {
+	return errors.New("broker unavailable")
+}
+
+func (h *failingMessageHandler) PublishWithOptions(ctx context.Context, options msg.PublishOptions) error {
+	return h.Publish(ctx, options.QueueName, options.Message)
+}
+
+func TestPositionUpdatePublicationTracker_RecordsCompleteExecutions(t *testing.T) {
+	publications := &stubPublicationRepository{published: make(map[string]time.Time)}
+	tracker := NewPositionUpdatePublicationTracker(NewEventPublisher(&stubQueueMessageHandler{}, ""), publications)
+
+	partial := newExecutedEvent("order-partial")
+	partial.PartialFill = true
+	require.NoError(t, tracker.PublishOrderExecutedEvent(context.Background(), partial))
+	require.NoError(t, tracker.PublishOrderExecutedEvent(context.Background(), newExecutedEvent("order-complete")))
+
+	assert.Contains(t, publications.published, "order-complete")
+	assert.NotContains(t, publications.published, "order-partial", "the order is not executed until its last fill")
+}
+
+func TestPositionUpdatePublicationTracker_DoesNotRecordFailedPublishes(t *testing.T) {
+	publications := &stubPublicationRepository{published: make(map[string]time.Time)}
+	tracker := NewPositionUpdatePublicationTracker(NewEventPublisher(&failingMessageHandler{}, ""), publications)
+
+	err := tracker.PublishOrderExecutedEvent(context.Background(), newExecutedEvent("order-1"))
+
+	assert.Error(t, err)
+	assert.Empty(t, publications.published)
+}
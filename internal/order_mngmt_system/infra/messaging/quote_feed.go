@@ -0,0 +1,104 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IQuotePublisher fetches a symbol's latest quote and hands it to subscribers and resting orders (dependency inversion)
+type IQuotePublisher interface {
+	BroadcastQuote(ctx context.Context, symbol string) error
+}
+
+// IQuoteSymbolSource lists symbols that need realtime quotes, e.g. those with resting orders
+type IQuoteSymbolSource interface {
+	Symbols() []string
+}
+
+type QuoteFeedConfig struct {
+	PollInterval time.Duration // How often every symbol's quote is fetched and published
+	Symbols      []string      // Symbols always polled, in addition to those the symbol sources list
+}
+
+func DefaultQuoteFeedConfig() QuoteFeedConfig {
+	return QuoteFeedConfig{
+		PollInterval: time.Second, // Resting orders react to a quote within a second
+	}
+}
+
+// QuoteFeed polls the quote source for the configured symbols and every symbol with resting
+// orders, and publishes each quote through the quote stream broadcaster
+type QuoteFeed struct {
+	publisher IQuotePublisher
+	sources   []IQuoteSymbolSource
+	config    QuoteFeedConfig
+}
+
+func NewQuoteFeed(publisher IQuotePublisher, config QuoteFeedConfig, sources ...IQuoteSymbolSource) *QuoteFeed {
+	return &QuoteFeed{
+		publisher: publisher,
+		sources:   sources,
+		config:    config,
+	}
+}
+
+// Start publishes quotes on every tick until the context is cancelled
+func (f *QuoteFeed) Start(ctx context.Context) {
+	ticker := time.NewTicker(f.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.Poll(ctx)
+		}
+	}
+}
+
+// Poll publishes one quote for each symbol and returns how many were published.
+// A symbol whose quote fails is logged and retried on the next poll.
+func (f *QuoteFeed) Poll(ctx context.Context) int {
+	published := 0
+	for _, symbol := range f.symbols() {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := f.publisher.BroadcastQuote(ctx, symbol); err != nil {
+			log.Printf("Warning: Failed to publish quote for %s: %v", symbol, err)
+			continue
+		}
+		published++
+	}
+	return published
+}
+
+// symbols merges the configured symbols with those of the sources, sorted and without duplicates
+func (f *QuoteFeed) symbols() []string {
+	seen := make(map[string]bool)
+	symbols := make([]string, 0, len(f.config.Symbols))
+	add := func(symbol string) {
+		symbol = strings.TrimSpace(symbol)
+		if symbol == "" || seen[symbol] {
+			return
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+
+	for _, symbol := range f.config.Symbols {
+		add(symbol)
+	}
+	for _, source := range f.sources {
+		for _, symbol := range source.Symbols() {
+			add(symbol)
+		}
+	}
+
+	sort.Strings(symbols)
+	return symbols
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingQuotePublisher struct {
+	symbols []string
+	failing map[string]bool
+}
+
+func (p *recordingQuotePublisher) BroadcastQuote(ctx context.Context, symbol string) error {
+	p.symbols = append(p.symbols, symbol)
+	if p.failing[symbol] {
+		return errors.New("quote unavailable")
+	}
+	return nil
+}
+
+type staticSymbolSource []string
+
+func (s staticSymbolSource) Symbols() []string {
+	return s
+}
+
+func TestQuoteFeed_Poll_PublishesConfiguredAndRestingOrderSymbolsOnce(t *testing.T) {
+	publisher := &recordingQuotePublisher{}
+	feed := NewQuoteFeed(publisher, QuoteFeedConfig{Symbols: []string{"VALE3", " PETR4 ", ""}},
+		staticSymbolSource{"PETR4", "ITUB4"}, staticSymbolSource{"BBDC4"})
+
+	published := feed.Poll(context.Background())
+
+	assert.Equal(t, 4, published)
+	assert.Equal(t, []string{"BBDC4", "ITUB4", "PETR4", "VALE3"}, publisher.symbols)
+}
+
+func TestQuoteFeed_Poll_KeepsPublishingAfterAFailedQuote(t *testing.T) {
+	publisher := &recordingQuotePublisher{failing: map[string]bool{"ITUB4": true}}
+	feed := NewQuoteFeed(publisher, DefaultQuoteFeedConfig(), staticSymbolSource{"ITUB4", "PETR4"})
+
+	published := feed.Poll(context.Background())
+
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"ITUB4", "PETR4"}, publisher.symbols)
+}
+
+func TestQuoteFeed_Poll_StopsWhenContextIsDone(t *testing.T) {
+	publisher := &recordingQuotePublisher{}
+	feed := NewQuoteFeed(publisher, DefaultQuoteFeedConfig(), staticSymbolSource{"ITUB4", "PETR4"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	published := feed.Poll(ctx)
+
+	assert.Zero(t, published)
+	assert.Empty(t, publisher.symbols)
+}
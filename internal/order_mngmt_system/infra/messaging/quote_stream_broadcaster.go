@@ -0,0 +1,255 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/shared/infra/websocket"
+)
+
+// IQuoteDataClient defines the interface for reading quote and book data (dependency inversion)
+type IQuoteDataClient interface {
+	GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error)
+	GetOrderBookData(symbol string) (*service.OrderBookData, error)
+	GetMarketDepth(symbol string) (*service.MarketDepth, error)
+}
+
+// IQuoteBroadcaster defines the interface for pushing messages to subscribed clients (dependency inversion)
+type IQuoteBroadcaster interface {
+	BroadcastMessage(messageType int, data []byte) error
+}
+
+// QuoteStreamMessage is the payload broadcast to quote subscribers
+type QuoteStreamMessage struct {
+	Type      string          `json:"type"`
+	Symbol    string          `json:"symbol"`
+	BidPrice  float64         `json:"bid_price"`
+	AskPrice  float64         `json:"ask_price"`
+	LastPrice float64         `json:"last_price"`
+	Spread    float64         `json:"spread"`
+	Pressure  *PressureMetric `json:"pressure,omitempty"`
+	Halted    bool            `json:"halted,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// PressureMetric is the order book pressure attached to a quote
+type PressureMetric struct {
+	Raw         float64 `json:"raw"`
+	Smoothed    float64 `json:"smoothed"`
+	BidVolume   float64 `json:"bid_volume"`
+	AskVolume   float64 `json:"ask_volume"`
+	SampleCount int     `json:"sample_count"`
+}
+
+// IIfTouchedActivator activates resting if-touched orders a quote touches (dependency inversion)
+type IIfTouchedActivator interface {
+	Execute(ctx context.Context, symbol string, price float64, quotedAt time.Time) ([]string, error)
+}
+
+// IPeggedOrderRepricer reprices open pegged orders from a quote's bid and ask (dependency inversion)
+type IPeggedOrderRepricer interface {
+	Execute(ctx context.Context, symbol string, bid, ask float64, quotedAt time.Time) ([]string, error)
+}
+
+// QuoteStreamBroadcaster broadcasts quotes together with the order book pressure
+type QuoteStreamBroadcaster struct {
+	pricingClient   IQuoteDataClient
+	pressureService service.OrderBookPressureService
+	broadcaster     IQuoteBroadcaster
+	volatilityHalts service.VolatilityHaltService
+	activator       IIfTouchedActivator
+	repricer        IPeggedOrderRepricer
+	snapshots       service.QuoteSnapshotCache
+}
+
+func NewQuoteStreamBroadcaster(
+	pricingClient IQuoteDataClient,
+	pressureService service.OrderBookPressureService,
+	broadcaster IQuoteBroadcaster,
+) *QuoteStreamBroadcaster {
+	return &QuoteStreamBroadcaster{
+		pricingClient:   pricingClient,
+		pressureService: pressureService,
+		broadcaster:     broadcaster,
+	}
+}
+
+// NewQuoteStreamBroadcasterWithVolatilityHalts creates a broadcaster that also feeds each quote
+// to the volatility halt service and flags quotes of halted symbols
+func NewQuoteStreamBroadcasterWithVolatilityHalts(
+	pricingClient IQuoteDataClient,
+	pressureService service.OrderBookPressureService,
+	broadcaster IQuoteBroadcaster,
+	volatilityHalts service.VolatilityHaltService,
+) *QuoteStreamBroadcaster {
+	return &QuoteStreamBroadcaster{
+		pricingClient:   pricingClient,
+		pressureService: pressureService,
+		broadcaster:     broadcaster,
+		volatilityHalts: volatilityHalts,
+	}
+}
+
+// NewQuoteStreamBroadcasterWithIfTouchedActivation creates a halt-aware broadcaster that also
+// evaluates each quote against resting if-touched orders. Quotes of halted symbols activate nothing.
+func NewQuoteStreamBroadcasterWithIfTouchedActivation(
+	pricingClient IQuoteDataClient,
+	pressureService service.OrderBookPressureService,
+	broadcaster IQuoteBroadcaster,
+	volatilityHalts service.VolatilityHaltService,
+	activator IIfTouchedActivator,
+) *QuoteStreamBroadcaster {
+	return &QuoteStreamBroadcaster{
+		pricingClient:   pricingClient,
+		pressureService: pressureService,
+		broadcaster:     broadcaster,
+		volatilityHalts: volatilityHalts,
+		activator:       activator,
+	}
+}
+
+// NewQuoteStreamBroadcasterWithPeggedRepricing creates an if-touched activating broadcaster that
+// also reprices pegged orders from each quote's bid and ask. Quotes of halted symbols reprice nothing.
+func NewQuoteStreamBroadcasterWithPeggedRepricing(
+	pricingClient IQuoteDataClient,
+	pressureService service.OrderBookPressureService,
+	broadcaster IQuoteBroadcaster,
+	volatilityHalts service.VolatilityHaltService,
+	activator IIfTouchedActivator,
+	repricer IPeggedOrderRepricer,
+) *QuoteStreamBroadcaster {
+	return &QuoteStreamBroadcaster{
+		pricingClient:   pricingClient,
+		pressureService: pressureService,
+		broadcaster:     broadcaster,
+		volatilityHalts: volatilityHalts,
+		activator:       activator,
+		repricer:        repricer,
+	}
+}
+
+// NewQuoteStreamBroadcasterWithSnapshotCache creates a pegged repricing broadcaster that also keeps
+// every quote it broadcasts in the snapshot cache, so REST snapshots match what subscribers received
+func NewQuoteStreamBroadcasterWithSnapshotCache(
+	pricingClient IQuoteDataClient,
+	pressureService service.OrderBookPressureService,
+	broadcaster IQuoteBroadcaster,
+	volatilityHalts service.VolatilityHaltService,
+	activator IIfTouchedActivator,
+	repricer IPeggedOrderRepricer,
+	snapshots service.QuoteSnapshotCache,
+) *QuoteStreamBroadcaster {
+	return &QuoteStreamBroadcaster{
+		pricingClient:   pricingClient,
+		pressureService: pressureService,
+		broadcaster:     broadcaster,
+		volatilityHalts: volatilityHalts,
+		activator:       activator,
+		repricer:        repricer,
+		snapshots:       snapshots,
+	}
+}
+
+// BroadcastQuote fetches the latest quote for the symbol and sends it to subscribers.
+// A quote is still broadcast without pressure when neither book nor depth data is available.
+func (b *QuoteStreamBroadcaster) BroadcastQuote(ctx context.Context, symbol string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	marketPrice, err := b.pricingClient.GetCurrentMarketPrice(symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get market price for %s: %w", symbol, err)
+	}
+
+	message := QuoteStreamMessage{
+		Type:      "quote",
+		Symbol:    symbol,
+		BidPrice:  marketPrice.BidPrice,
+		AskPrice:  marketPrice.AskPrice,
+		LastPrice: marketPrice.LastPrice,
+		Spread:    marketPrice.Spread,
+		Timestamp: marketPrice.Timestamp,
+	}
+
+	quoteTime := marketPrice.Timestamp
+	if quoteTime.IsZero() {
+		quoteTime = time.Now()
+	}
+
+	if b.volatilityHalts != nil {
+		message.Halted = b.volatilityHalts.RecordPrice(symbol, marketPrice.LastPrice, quoteTime) != nil
+	}
+
+	if b.activator != nil && !message.Halted {
+		// A failed activation must not hold back the quote; untouched orders keep waiting
+		if _, err := b.activator.Execute(ctx, symbol, marketPrice.LastPrice, quoteTime); err != nil {
+			fmt.Printf("Warning: Failed to activate if-touched orders for %s: %v\n", symbol, err)
+		}
+	}
+
+	if b.repricer != nil && !message.Halted {
+		// Pegged orders that could not be repriced keep their price until the next quote
+		if _, err := b.repricer.Execute(ctx, symbol, marketPrice.BidPrice, marketPrice.AskPrice, quoteTime); err != nil {
+			fmt.Printf("Warning: Failed to reprice pegged orders for %s: %v\n", symbol, err)
+		}
+	}
+
+	if pressure := b.calculatePressure(symbol); pressure != nil {
+		message.Pressure = &PressureMetric{
+			Raw:         pressure.Raw,
+			Smoothed:    pressure.Smoothed,
+			BidVolume:   pressure.BidVolume,
+			AskVolume:   pressure.AskVolume,
+			SampleCount: pressure.SampleCount,
+		}
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quote message: %w", err)
+	}
+
+	if err := b.broadcaster.BroadcastMessage(websocket.TextMessage, data); err != nil {
+		return fmt.Errorf("failed to broadcast quote for %s: %w", symbol, err)
+	}
+
+	if b.snapshots != nil {
+		// Cached only once broadcast, so a snapshot never runs ahead of the stream
+		b.snapshots.Record(service.QuoteSnapshot{
+			Symbol:    symbol,
+			BidPrice:  message.BidPrice,
+			AskPrice:  message.AskPrice,
+			LastPrice: message.LastPrice,
+			Spread:    message.Spread,
+			Halted:    message.Halted,
+			Timestamp: quoteTime,
+		})
+	}
+
+	return nil
+}
+
+func (b *QuoteStreamBroadcaster) calculatePressure(symbol string) *service.OrderBookPressure {
+	orderBook, err := b.pricingClient.GetOrderBookData(symbol)
+	if err != nil {
+		orderBook = nil
+	}
+
+	var marketDepth *service.MarketDepth
+	if orderBook == nil || (len(orderBook.Bids) == 0 && len(orderBook.Asks) == 0) {
+		marketDepth, err = b.pricingClient.GetMarketDepth(symbol)
+		if err != nil {
+			return nil
+		}
+	}
+
+	pressure, err := b.pressureService.CalculatePressure(symbol, orderBook, marketDepth)
+	if err != nil {
+		return nil
+	}
+	return pressure
+}
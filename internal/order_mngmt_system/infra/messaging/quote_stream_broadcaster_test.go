@@ -0,0 +1,137 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type stubQuoteDataClient struct {
+	marketPrice *service.MarketPrice
+	orderBook   *service.OrderBookData
+	marketDepth *service.MarketDepth
+}
+
+func (s *stubQuoteDataClient) GetCurrentMarketPrice(symbol string) (*service.MarketPrice, error) {
+	return s.marketPrice, nil
+}
+
+func (s *stubQuoteDataClient) GetOrderBookData(symbol string) (*service.OrderBookData, error) {
+	if s.orderBook == nil {
+		return nil, errors.New("order book unavailable")
+	}
+	return s.orderBook, nil
+}
+
+func (s *stubQuoteDataClient) GetMarketDepth(symbol string) (*service.MarketDepth, error) {
+	if s.marketDepth == nil {
+		return nil, errors.New("market depth unavailable")
+	}
+	return s.marketDepth, nil
+}
+
+type capturingBroadcaster struct {
+	messages [][]byte
+}
+
+func (c *capturingBroadcaster) BroadcastMessage(messageType int, data []byte) error {
+	c.messages = append(c.messages, data)
+	return nil
+}
+
+func TestQuoteStreamBroadcaster_BroadcastQuote_IncludesPressure(t *testing.T) {
+	client := &stubQuoteDataClient{
+		marketPrice: &service.MarketPrice{Symbol: "PETR4", BidPrice: 25.00, AskPrice: 25.01, LastPrice: 25.00},
+		orderBook: &service.OrderBookData{
+			Symbol: "PETR4",
+			Bids:   []service.PriceLevel{{Price: 25.00, Quantity: 1000}},
+			Asks:   []service.PriceLevel{{Price: 25.01, Quantity: 9000}},
+		},
+	}
+	broadcaster := &capturingBroadcaster{}
+	quoteStream := NewQuoteStreamBroadcaster(client, service.NewOrderBookPressureServiceWithDefaults(), broadcaster)
+
+	err := quoteStream.BroadcastQuote(context.Background(), "PETR4")
+
+	assert.NoError(t, err)
+	assert.Len(t, broadcaster.messages, 1)
+
+	var message QuoteStreamMessage
+	assert.NoError(t, json.Unmarshal(broadcaster.messages[0], &message))
+	assert.Equal(t, "quote", message.Type)
+	assert.Equal(t, "PETR4", message.Symbol)
+	if assert.NotNil(t, message.Pressure) {
+		assert.InDelta(t, -0.8, message.Pressure.Raw, 0.0001)
+		assert.InDelta(t, -0.8, message.Pressure.Smoothed, 0.0001)
+		assert.Equal(t, 1, message.Pressure.SampleCount)
+	}
+}
+
+func TestQuoteStreamBroadcaster_BroadcastQuote_WithoutBookData(t *testing.T) {
+	client := &stubQuoteDataClient{
+		marketPrice: &service.MarketPrice{Symbol: "VALE3", LastPrice: 60.00},
+	}
+	broadcaster := &capturingBroadcaster{}
+	quoteStream := NewQuoteStreamBroadcaster(client, service.NewOrderBookPressureServiceWithDefaults(), broadcaster)
+
+	err := quoteStream.BroadcastQuote(context.Background(), "VALE3")
+
+	assert.NoError(t, err)
+	var message QuoteStreamMessage
+	assert.NoError(t, json.Unmarshal(broadcaster.messages[0], &message))
+	assert.Nil(t, message.Pressure)
+	assert.Equal(t, 60.00, message.LastPrice)
+}
+
+func TestQuoteStreamBroadcaster_BroadcastQuote_FlagsHaltedSymbol(t *testing.T) {
+	start := time.Now()
+	client := &stubQuoteDataClient{marketPrice: &service.MarketPrice{Symbol: "PETR4", LastPrice: 25.00, Timestamp: start}}
+	broadcaster := &capturingBroadcaster{}
+	volatilityHalts := service.NewVolatilityHaltServiceWithDefaults()
+	quoteStream := NewQuoteStreamBroadcasterWithVolatilityHalts(client, service.NewOrderBookPressureServiceWithDefaults(), broadcaster, volatilityHalts)
+
+	assert.NoError(t, quoteStream.BroadcastQuote(context.Background(), "PETR4"))
+	client.marketPrice = &service.MarketPrice{Symbol: "PETR4", LastPrice: 28.00, Timestamp: start.Add(30 * time.Second)}
+	assert.NoError(t, quoteStream.BroadcastQuote(context.Background(), "PETR4"))
+
+	var first, second QuoteStreamMessage
+	assert.NoError(t, json.Unmarshal(broadcaster.messages[0], &first))
+	assert.NoError(t, json.Unmarshal(broadcaster.messages[1], &second))
+	assert.False(t, first.Halted)
+	assert.True(t, second.Halted)
+	assert.NotNil(t, volatilityHalts.ActiveHalt("PETR4", start.Add(time.Minute)))
+}
+
+type recordingRepricer struct {
+	bids []float64
+	asks []float64
+}
+
+func (r *recordingRepricer) Execute(ctx context.Context, symbol string, bid, ask float64, quotedAt time.Time) ([]string, error) {
+	r.bids = append(r.bids, bid)
+	r.asks = append(r.asks, ask)
+	return nil, nil
+}
+
+func TestQuoteStreamBroadcaster_BroadcastQuote_RepricesPeggedOrdersUnlessHalted(t *testing.T) {
+	start := time.Now()
+	client := &stubQuoteDataClient{marketPrice: &service.MarketPrice{Symbol: "PETR4", BidPrice: 24.98, AskPrice: 25.02, LastPrice: 25.00, Timestamp: start}}
+	repricer := &recordingRepricer{}
+	quoteStream := NewQuoteStreamBroadcasterWithPeggedRepricing(client, service.NewOrderBookPressureServiceWithDefaults(),
+		&capturingBroadcaster{}, service.NewVolatilityHaltServiceWithDefaults(), nil, repricer)
+
+	assert.NoError(t, quoteStream.BroadcastQuote(context.Background(), "PETR4"))
+	assert.Equal(t, []float64{24.98}, repricer.bids)
+	assert.Equal(t, []float64{25.02}, repricer.asks)
+
+	// A jump large enough to halt the symbol must not drag pegged orders along
+	client.marketPrice = &service.MarketPrice{Symbol: "PETR4", BidPrice: 27.98, AskPrice: 28.02, LastPrice: 28.00, Timestamp: start.Add(30 * time.Second)}
+	assert.NoError(t, quoteStream.BroadcastQuote(context.Background(), "PETR4"))
+	assert.Len(t, repricer.bids, 1)
+}
@@ -0,0 +1,115 @@
+package rabbitmq
+
+import (
+	"strings"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// MaxMessagePriority is the x-max-priority declared on the order queues.
+// Priorities above it are treated as the maximum by RabbitMQ.
+const MaxMessagePriority uint8 = 10
+
+type UserTier string
+
+const (
+	UserTierStandard UserTier = "STANDARD"
+	UserTierPremium  UserTier = "PREMIUM"
+)
+
+// IUserTierProvider resolves the service tier of the user who owns an order
+type IUserTierProvider interface {
+	GetUserTier(userID string) UserTier
+}
+
+// StaticUserTierProvider marks a fixed set of users as premium
+type StaticUserTierProvider struct {
+	premiumUserIDs map[string]bool
+}
+
+func NewStaticUserTierProvider(premiumUserIDs []string) *StaticUserTierProvider {
+	provider := &StaticUserTierProvider{premiumUserIDs: make(map[string]bool)}
+	for _, userID := range premiumUserIDs {
+		if userID = strings.TrimSpace(userID); userID != "" {
+			provider.premiumUserIDs[userID] = true
+		}
+	}
+	return provider
+}
+
+func (p *StaticUserTierProvider) GetUserTier(userID string) UserTier {
+	if p.premiumUserIDs[userID] {
+		return UserTierPremium
+	}
+	return UserTierStandard
+}
+
+type MessagePriorityConfig struct {
+	DefaultPriority     uint8                      // Priority of orders with no rule below
+	OrderTypePriorities map[domain.OrderType]uint8 // Priority by order type
+	LargeOrderValue     float64                    // Orders above this value get LargeOrderPriority
+	LargeOrderPriority  uint8
+	MediumOrderValue    float64 // Orders above this value get MediumOrderPriority
+	MediumOrderPriority uint8
+	TierBoosts          map[UserTier]uint8 // Added on top of the order priority
+}
+
+func DefaultMessagePriorityConfig() *MessagePriorityConfig {
+	return &MessagePriorityConfig{
+		DefaultPriority: 5, // Passive orders
+		OrderTypePriorities: map[domain.OrderType]uint8{
+			domain.OrderTypeMarket:   8, // Market orders need immediate execution at current market price
+			domain.OrderTypeStopLoss: 7, // Stop loss orders are risk management tools
+		},
+		LargeOrderValue:     100000,
+		LargeOrderPriority:  7, // Large orders are processed early to minimize market impact
+		MediumOrderValue:    10000,
+		MediumOrderPriority: 6,
+		TierBoosts: map[UserTier]uint8{
+			UserTierPremium: 1, // Premium users move ahead of standard users with the same order profile
+		},
+	}
+}
+
+// MessagePriorityPolicy assigns queue priorities to order messages. The order priority is the
+// highest of its type and value rules, plus the boost of the owner's tier, capped at MaxMessagePriority.
+type MessagePriorityPolicy struct {
+	config       *MessagePriorityConfig
+	tierProvider IUserTierProvider
+}
+
+// NewMessagePriorityPolicy creates a policy; tierProvider may be nil to skip tier boosts
+func NewMessagePriorityPolicy(config *MessagePriorityConfig, tierProvider IUserTierProvider) *MessagePriorityPolicy {
+	if config == nil {
+		config = DefaultMessagePriorityConfig()
+	}
+	return &MessagePriorityPolicy{
+		config:       config,
+		tierProvider: tierProvider,
+	}
+}
+
+func (p *MessagePriorityPolicy) PriorityFor(order *domain.Order) uint8 {
+	priority := p.config.DefaultPriority
+
+	if typePriority, ok := p.config.OrderTypePriorities[order.OrderType()]; ok && typePriority > priority {
+		priority = typePriority
+	}
+
+	orderValue := order.CalculateOrderValue()
+	if orderValue > p.config.LargeOrderValue && p.config.LargeOrderPriority > priority {
+		priority = p.config.LargeOrderPriority
+	} else if orderValue > p.config.MediumOrderValue && p.config.MediumOrderPriority > priority {
+		priority = p.config.MediumOrderPriority
+	}
+
+	if p.tierProvider != nil {
+		boost := p.config.TierBoosts[p.tierProvider.GetUserTier(order.UserID())]
+		if int(priority)+int(boost) > int(MaxMessagePriority) {
+			return MaxMessagePriority
+		}
+		priority += boost
+	}
+
+	return priority
+}
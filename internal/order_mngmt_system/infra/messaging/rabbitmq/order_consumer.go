@@ -34,6 +34,8 @@ type ConsumerConfig struct {
 	RequeueOnError    bool          // Whether to requeue messages on processing errors
 	RetryDelay        time.Duration // Delay before retrying failed messages
 	MaxRetries        int           // Maximum number of retry attempts
+	PriorityOrdering  bool          // Whether busy consumers admit waiting order messages by priority
+	PrioritySlots     int           // Order messages handled at once across the order queues when priority ordering is on
 }
 
 func DefaultConsumerConfig() *ConsumerConfig {
@@ -43,6 +45,8 @@ func DefaultConsumerConfig() *ConsumerConfig {
 		RequeueOnError:    true,
 		RetryDelay:        5 * time.Second,
 		MaxRetries:        3,
+		PriorityOrdering:  true,
+		PrioritySlots:     2, // Fewer slots than order queues, so a backlog is ranked by priority
 	}
 }
 
@@ -85,23 +89,29 @@ func (oc *OrderConsumer) StartConsumers(ctx context.Context, config *ConsumerCon
 
 	queueNames := oc.queueManager.GetQueueNames()
 
+	// Order queues share one dispatcher so urgent orders overtake passive ones under load
+	var dispatcher *priorityDispatcher
+	if config.PriorityOrdering {
+		dispatcher = newPriorityDispatcher(config.PrioritySlots)
+	}
+
 	// Start consumer for processing queue (main order processing)
-	if err := oc.startQueueConsumer(ctx, queueNames.OrdersProcessing, config, oc.handleOrderProcessingMessage); err != nil {
+	if err := oc.startQueueConsumer(ctx, queueNames.OrdersProcessing, config, dispatcher, oc.handleOrderProcessingMessage); err != nil {
 		return fmt.Errorf("failed to start processing queue consumer: %w", err)
 	}
 
 	// Start consumer for submission queue (order validation and preparation)
-	if err := oc.startQueueConsumer(ctx, queueNames.OrdersSubmit, config, oc.handleOrderSubmissionMessage); err != nil {
+	if err := oc.startQueueConsumer(ctx, queueNames.OrdersSubmit, config, dispatcher, oc.handleOrderSubmissionMessage); err != nil {
 		return fmt.Errorf("failed to start submission queue consumer: %w", err)
 	}
 
 	// Start consumer for retry queue (failed order retries)
-	if err := oc.startQueueConsumer(ctx, queueNames.OrdersRetry, config, oc.handleOrderRetryMessage); err != nil {
+	if err := oc.startQueueConsumer(ctx, queueNames.OrdersRetry, config, dispatcher, oc.handleOrderRetryMessage); err != nil {
 		return fmt.Errorf("failed to start retry queue consumer: %w", err)
 	}
 
 	// Start consumer for status updates queue
-	if err := oc.startQueueConsumer(ctx, queueNames.OrdersStatus, config, oc.handleStatusUpdateMessage); err != nil {
+	if err := oc.startQueueConsumer(ctx, queueNames.OrdersStatus, config, nil, oc.handleStatusUpdateMessage); err != nil {
 		return fmt.Errorf("failed to start status queue consumer: %w", err)
 	}
 
@@ -109,10 +119,11 @@ func (oc *OrderConsumer) StartConsumers(ctx context.Context, config *ConsumerCon
 	return nil
 }
 
-func (oc *OrderConsumer) startQueueConsumer(ctx context.Context, queueName string, config *ConsumerConfig, handler func(context.Context, *messaging.Message) error) error {
+func (oc *OrderConsumer) startQueueConsumer(ctx context.Context, queueName string, config *ConsumerConfig, dispatcher *priorityDispatcher, handler func(context.Context, *messaging.Message) error) error {
 	consumer := &orderMessageConsumer{
-		handler: handler,
-		config:  config,
+		handler:    handler,
+		config:     config,
+		dispatcher: dispatcher,
 	}
 
 	err := oc.messageHandler.Consume(ctx, queueName, consumer)
@@ -129,11 +140,15 @@ func (oc *OrderConsumer) startQueueConsumer(ctx context.Context, queueName strin
 
 // Implements the MessageConsumer interface
 type orderMessageConsumer struct {
-	handler func(context.Context, *messaging.Message) error
-	config  *ConsumerConfig
+	handler    func(context.Context, *messaging.Message) error
+	config     *ConsumerConfig
+	dispatcher *priorityDispatcher // nil handles messages as soon as they are delivered
 }
 
 func (omc *orderMessageConsumer) HandleMessage(ctx context.Context, message *messaging.Message) error {
+	if omc.dispatcher != nil {
+		return omc.dispatcher.Dispatch(ctx, message, omc.handler)
+	}
 	return omc.handler(ctx, message)
 }
 
@@ -68,6 +68,8 @@ func TestDefaultConsumerConfig(t *testing.T) {
 	assert.True(t, config.RequeueOnError)
 	assert.Equal(t, 5*time.Second, config.RetryDelay)
 	assert.Equal(t, 3, config.MaxRetries)
+	assert.True(t, config.PriorityOrdering)
+	assert.Equal(t, 2, config.PrioritySlots)
 }
 
 func TestStartConsumers_Success(t *testing.T) {
@@ -42,12 +42,14 @@ type OrderMessageMetadata struct {
 type OrderProducer struct {
 	queueManager   *OrderQueueManager
 	messageHandler messaging.MessageHandler
+	priorityPolicy *MessagePriorityPolicy
 }
 
 func NewOrderProducer(messageHandler messaging.MessageHandler) *OrderProducer {
 	return &OrderProducer{
 		queueManager:   NewOrderQueueManager(messageHandler),
 		messageHandler: messageHandler,
+		priorityPolicy: NewMessagePriorityPolicy(DefaultMessagePriorityConfig(), nil),
 	}
 }
 
@@ -55,9 +57,19 @@ func NewOrderProducerWithQueueManager(queueManager *OrderQueueManager) *OrderPro
 	return &OrderProducer{
 		queueManager:   queueManager,
 		messageHandler: queueManager.messageHandler,
+		priorityPolicy: NewMessagePriorityPolicy(DefaultMessagePriorityConfig(), nil),
 	}
 }
 
+// NewOrderProducerWithPriorityPolicy creates a producer that prioritizes messages with the given policy
+func NewOrderProducerWithPriorityPolicy(messageHandler messaging.MessageHandler, priorityPolicy *MessagePriorityPolicy) *OrderProducer {
+	producer := NewOrderProducer(messageHandler)
+	if priorityPolicy != nil {
+		producer.priorityPolicy = priorityPolicy
+	}
+	return producer
+}
+
 // PublishOrderForProcessing is the main method for sending orders for asynchronous processing
 func (op *OrderProducer) PublishOrderForProcessing(ctx context.Context, order *domain.Order) error {
 	if order == nil {
@@ -99,7 +111,7 @@ func (op *OrderProducer) PublishOrderForSubmission(ctx context.Context, order *d
 		return fmt.Errorf("failed to serialize order message: %w", err)
 	}
 
-	err = op.queueManager.PublishToSubmitQueue(ctx, messageBytes, orderMessage.MessageMetadata.MessageID)
+	err = op.queueManager.PublishToSubmitQueue(ctx, messageBytes, orderMessage.MessageMetadata.MessageID, orderMessage.MessageMetadata.Priority)
 	if err != nil {
 		return fmt.Errorf("failed to publish order to submission queue: %w", err)
 	}
@@ -257,27 +269,7 @@ func (op *OrderProducer) createOrderMessage(order *domain.Order, messageType, pr
 // calculateMessagePriority determines message priority based on order characteristics
 // Higher priority orders are processed first to minimize market impact and risk
 func (op *OrderProducer) calculateMessagePriority(order *domain.Order) uint8 {
-	priority := uint8(5)
-
-	// Market orders need immediate execution at current market price
-	if order.OrderType() == domain.OrderTypeMarket {
-		priority = 8
-	}
-
-	// Large orders require priority processing to minimize market impact
-	orderValue := order.CalculateOrderValue()
-	if orderValue > 100000 {
-		priority = 7
-	} else if orderValue > 10000 {
-		priority = 6
-	}
-
-	// Stop loss orders are risk management tools requiring immediate attention
-	if order.OrderType() == domain.OrderTypeStopLoss {
-		priority = 7
-	}
-
-	return priority
+	return op.priorityPolicy.PriorityFor(order)
 }
 
 type OrderStatusUpdate struct {
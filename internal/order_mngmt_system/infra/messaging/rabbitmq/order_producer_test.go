@@ -179,6 +179,8 @@ func TestPublishOrderStatusUpdate_Success(t *testing.T) {
 
 	// Mark order as executed
 	executionPrice := 155.0
+	err = order.MarkAsProcessing()
+	assert.NoError(t, err)
 	err = order.MarkAsExecuted(executionPrice)
 	assert.NoError(t, err)
 
@@ -339,6 +341,58 @@ func TestCalculateMessagePriority(t *testing.T) {
 	}
 }
 
+func TestMessagePriorityPolicy_PremiumTierBoost(t *testing.T) {
+	policy := NewMessagePriorityPolicy(DefaultMessagePriorityConfig(), NewStaticUserTierProvider([]string{"premium-user"}))
+
+	price := 100.0
+	standardLimit, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10.0, &price)
+	assert.NoError(t, err)
+	premiumLimit, err := domain.NewOrder("premium-user", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10.0, &price)
+	assert.NoError(t, err)
+	premiumMarket, err := domain.NewOrder("premium-user", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint8(5), policy.PriorityFor(standardLimit))
+	assert.Equal(t, uint8(6), policy.PriorityFor(premiumLimit))
+	assert.Equal(t, uint8(9), policy.PriorityFor(premiumMarket))
+}
+
+func TestMessagePriorityPolicy_CappedAtMaxPriority(t *testing.T) {
+	config := DefaultMessagePriorityConfig()
+	config.TierBoosts[UserTierPremium] = 5
+	policy := NewMessagePriorityPolicy(config, NewStaticUserTierProvider([]string{"premium-user"}))
+
+	order, err := domain.NewOrder("premium-user", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, MaxMessagePriority, policy.PriorityFor(order))
+}
+
+func TestPublishOrderForSubmission_UsesOrderPriority(t *testing.T) {
+	mockHandler := &SharedMockMessageHandler{}
+	policy := NewMessagePriorityPolicy(DefaultMessagePriorityConfig(), NewStaticUserTierProvider([]string{"user123"}))
+	producer := NewOrderProducerWithPriorityPolicy(mockHandler, policy)
+	ctx := context.Background()
+
+	order, err := domain.NewOrder("user123", "AAPL", domain.OrderSideBuy, domain.OrderTypeMarket, 10.0, nil)
+	assert.NoError(t, err)
+
+	mockHandler.On("PublishWithOptions", ctx, mock.MatchedBy(func(options messaging.PublishOptions) bool {
+		var orderMessage OrderMessage
+		if err := json.Unmarshal(options.Message, &orderMessage); err != nil {
+			return false
+		}
+		return options.QueueName == "orders.submit" &&
+			options.Priority == 9 &&
+			orderMessage.MessageMetadata.Priority == 9
+	})).Return(nil)
+
+	err = producer.PublishOrderForSubmission(ctx, order)
+
+	assert.NoError(t, err)
+	mockHandler.AssertExpectations(t)
+}
+
 func TestCreateOrderMessage(t *testing.T) {
 	mockHandler := &SharedMockMessageHandler{}
 	producer := NewOrderProducer(mockHandler)
@@ -0,0 +1,128 @@
+package rabbitmq
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"HubInvestments/shared/infra/messaging"
+)
+
+// priorityDispatcher limits how many order messages are handled at once across the order
+// queues. When every slot is busy, waiting messages are admitted highest priority first and
+// in arrival order within the same priority, so a market order delivered while the consumers
+// are saturated is handled before passive orders that were delivered earlier.
+type priorityDispatcher struct {
+	mutex    sync.Mutex
+	slots    int
+	active   int
+	sequence uint64
+	waiting  priorityWaitQueue
+}
+
+type priorityWaiter struct {
+	priority uint8
+	sequence uint64
+	ready    chan struct{}
+	granted  bool
+	canceled bool
+}
+
+func newPriorityDispatcher(slots int) *priorityDispatcher {
+	if slots < 1 {
+		slots = 1
+	}
+	return &priorityDispatcher{slots: slots}
+}
+
+// Dispatch waits for a slot according to the message priority and runs the handler
+func (d *priorityDispatcher) Dispatch(ctx context.Context, message *messaging.Message, handler func(context.Context, *messaging.Message) error) error {
+	if err := d.acquire(ctx, message.Priority); err != nil {
+		return err
+	}
+	defer d.release()
+
+	return handler(ctx, message)
+}
+
+func (d *priorityDispatcher) acquire(ctx context.Context, priority uint8) error {
+	d.mutex.Lock()
+	if d.active < d.slots && d.waiting.Len() == 0 {
+		d.active++
+		d.mutex.Unlock()
+		return nil
+	}
+
+	d.sequence++
+	waiter := &priorityWaiter{priority: priority, sequence: d.sequence, ready: make(chan struct{})}
+	heap.Push(&d.waiting, waiter)
+	d.mutex.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		if waiter.granted {
+			// The slot was handed over while the context was canceled; pass it on
+			d.releaseLocked()
+		} else {
+			waiter.canceled = true
+		}
+		return ctx.Err()
+	}
+}
+
+func (d *priorityDispatcher) release() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.releaseLocked()
+}
+
+// releaseLocked hands the slot to the highest priority waiter, or frees it when nobody waits
+func (d *priorityDispatcher) releaseLocked() {
+	for d.waiting.Len() > 0 {
+		waiter := heap.Pop(&d.waiting).(*priorityWaiter)
+		if waiter.canceled {
+			continue
+		}
+		waiter.granted = true
+		close(waiter.ready)
+		return
+	}
+	d.active--
+}
+
+func (d *priorityDispatcher) waitingCount() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.waiting.Len()
+}
+
+// priorityWaitQueue implements heap.Interface ordered by priority, then arrival
+type priorityWaitQueue []*priorityWaiter
+
+func (q priorityWaitQueue) Len() int { return len(q) }
+
+func (q priorityWaitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].sequence < q[j].sequence
+}
+
+func (q priorityWaitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityWaitQueue) Push(x interface{}) {
+	*q = append(*q, x.(*priorityWaiter))
+}
+
+func (q *priorityWaitQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	waiter := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return waiter
+}
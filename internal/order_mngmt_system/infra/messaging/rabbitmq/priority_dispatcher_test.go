@@ -0,0 +1,143 @@
+package rabbitmq
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"HubInvestments/shared/infra/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dispatchRecorder records the order in which the dispatcher runs messages
+type dispatchRecorder struct {
+	mutex   sync.Mutex
+	handled []string
+}
+
+func (r *dispatchRecorder) handler(ctx context.Context, message *messaging.Message) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.handled = append(r.handled, message.MessageID)
+	return nil
+}
+
+func (r *dispatchRecorder) order() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string(nil), r.handled...)
+}
+
+// occupySlot holds the only dispatcher slot until the returned function is called
+func occupySlot(t *testing.T, dispatcher *priorityDispatcher, wg *sync.WaitGroup) func() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = dispatcher.Dispatch(context.Background(), &messaging.Message{MessageID: "busy", Priority: 5}, func(ctx context.Context, message *messaging.Message) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+
+	<-started
+	return func() { close(release) }
+}
+
+// enqueue dispatches a message and waits until it is queued behind the busy slot
+func enqueue(t *testing.T, dispatcher *priorityDispatcher, wg *sync.WaitGroup, recorder *dispatchRecorder, messageID string, priority uint8) {
+	waiting := dispatcher.waitingCount()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = dispatcher.Dispatch(context.Background(), &messaging.Message{MessageID: messageID, Priority: priority}, recorder.handler)
+	}()
+
+	require.Eventually(t, func() bool { return dispatcher.waitingCount() == waiting+1 }, time.Second, time.Millisecond)
+}
+
+func TestPriorityDispatcher_HighPriorityOvertakesEarlierMessages(t *testing.T) {
+	dispatcher := newPriorityDispatcher(1)
+	recorder := &dispatchRecorder{}
+	var wg sync.WaitGroup
+
+	release := occupySlot(t, dispatcher, &wg)
+	enqueue(t, dispatcher, &wg, recorder, "limit-1", 5)
+	enqueue(t, dispatcher, &wg, recorder, "limit-2", 5)
+	enqueue(t, dispatcher, &wg, recorder, "market", 8)
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []string{"market", "limit-1", "limit-2"}, recorder.order())
+}
+
+func TestPriorityDispatcher_SamePriorityKeepsArrivalOrder(t *testing.T) {
+	dispatcher := newPriorityDispatcher(1)
+	recorder := &dispatchRecorder{}
+	var wg sync.WaitGroup
+
+	release := occupySlot(t, dispatcher, &wg)
+	enqueue(t, dispatcher, &wg, recorder, "first", 6)
+	enqueue(t, dispatcher, &wg, recorder, "second", 6)
+	enqueue(t, dispatcher, &wg, recorder, "third", 6)
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []string{"first", "second", "third"}, recorder.order())
+}
+
+func TestPriorityDispatcher_CanceledWaiterIsSkipped(t *testing.T) {
+	dispatcher := newPriorityDispatcher(1)
+	recorder := &dispatchRecorder{}
+	var wg sync.WaitGroup
+
+	release := occupySlot(t, dispatcher, &wg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceledErr := make(chan error, 1)
+	go func() {
+		canceledErr <- dispatcher.Dispatch(ctx, &messaging.Message{MessageID: "canceled", Priority: 9}, recorder.handler)
+	}()
+	require.Eventually(t, func() bool { return dispatcher.waitingCount() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-canceledErr, context.Canceled)
+
+	enqueue(t, dispatcher, &wg, recorder, "limit", 5)
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []string{"limit"}, recorder.order())
+}
+
+func TestOrderMessageConsumer_UsesDispatcher(t *testing.T) {
+	dispatcher := newPriorityDispatcher(1)
+	recorder := &dispatchRecorder{}
+	var wg sync.WaitGroup
+	consumer := &orderMessageConsumer{handler: recorder.handler, config: DefaultConsumerConfig(), dispatcher: dispatcher}
+
+	release := occupySlot(t, dispatcher, &wg)
+	for _, message := range []*messaging.Message{
+		{MessageID: "passive", Priority: 5},
+		{MessageID: "urgent", Priority: 9},
+	} {
+		message := message
+		waiting := dispatcher.waitingCount()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = consumer.HandleMessage(context.Background(), message)
+		}()
+		require.Eventually(t, func() bool { return dispatcher.waitingCount() == waiting+1 }, time.Second, time.Millisecond)
+	}
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []string{"urgent", "passive"}, recorder.order())
+}
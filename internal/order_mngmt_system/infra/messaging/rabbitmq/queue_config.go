@@ -126,6 +126,7 @@ func (qm *OrderQueueManager) setupPrimaryQueues(ctx context.Context) error {
 			Arguments: map[string]interface{}{
 				"x-dead-letter-exchange":    qm.queueNames.DLQExchange,
 				"x-dead-letter-routing-key": qm.queueNames.OrdersDLQ,
+				"x-max-priority":            int32(MaxMessagePriority),
 			},
 		},
 		{
@@ -137,6 +138,7 @@ func (qm *OrderQueueManager) setupPrimaryQueues(ctx context.Context) error {
 			Arguments: map[string]interface{}{
 				"x-dead-letter-exchange":    qm.queueNames.DLQExchange,
 				"x-dead-letter-routing-key": qm.queueNames.OrdersDLQ,
+				"x-max-priority":            int32(MaxMessagePriority),
 			},
 		},
 		{
@@ -239,12 +241,12 @@ func (qm *OrderQueueManager) GetRetryConfig() RetryConfig {
 	return qm.retryConfig
 }
 
-func (qm *OrderQueueManager) PublishToSubmitQueue(ctx context.Context, orderMessage []byte, messageID string) error {
+func (qm *OrderQueueManager) PublishToSubmitQueue(ctx context.Context, orderMessage []byte, messageID string, priority uint8) error {
 	options := messaging.PublishOptions{
 		QueueName:     qm.queueNames.OrdersSubmit,
 		Message:       orderMessage,
 		Persistent:    true,
-		Priority:      5, // Normal priority
+		Priority:      priority,
 		MessageID:     messageID,
 		CorrelationID: messageID,
 		Headers: map[string]interface{}{
@@ -134,10 +134,10 @@ func TestPublishToSubmitQueue(t *testing.T) {
 			string(options.Message) == string(orderMessage) &&
 			options.MessageID == messageID &&
 			options.Persistent == true &&
-			options.Priority == 5
+			options.Priority == 8
 	})).Return(nil)
 
-	err := queueManager.PublishToSubmitQueue(ctx, orderMessage, messageID)
+	err := queueManager.PublishToSubmitQueue(ctx, orderMessage, messageID, 8)
 
 	assert.NoError(t, err)
 	mockHandler.AssertExpectations(t)
@@ -374,7 +374,7 @@ func TestOrderQueueManager_Integration(t *testing.T) {
 
 	// Test publishing
 	testMessage := []byte(`{"test":"message"}`)
-	err = queueManager.PublishToSubmitQueue(ctx, testMessage, "test-msg-id")
+	err = queueManager.PublishToSubmitQueue(ctx, testMessage, "test-msg-id", 5)
 	assert.NoError(t, err)
 
 	// Clean up - purge test messages
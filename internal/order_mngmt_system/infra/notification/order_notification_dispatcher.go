@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+)
+
+// IOrderNotificationDispatcher notifies users about their order events through the channels they chose.
+// Notifying never blocks or fails order processing; deliveries happen in the background.
+type IOrderNotificationDispatcher interface {
+	NotifyOrderEvent(ctx context.Context, event domain.OrderNotificationEvent, order *domain.Order)
+	NotifyOrderRejected(ctx context.Context, rejection *domain.RejectedOrder)
+}
+
+// IOrderNotificationSender delivers notifications through one channel (dependency inversion)
+type IOrderNotificationSender interface {
+	SendOrderNotification(ctx context.Context, notification OrderNotification) error
+}
+
+// OrderNotification is the message delivered to the user for an order event
+type OrderNotification struct {
+	Type           string                        `json:"type"`
+	Event          domain.OrderNotificationEvent `json:"event"`
+	Channel        domain.NotificationChannel    `json:"channel"`
+	UserID         string                        `json:"user_id"`
+	OrderID        string                        `json:"order_id,omitempty"` // Empty for rejected submissions, which never became orders
+	Symbol         string                        `json:"symbol"`
+	OrderSide      string                        `json:"order_side"`
+	OrderType      string                        `json:"order_type"`
+	Quantity       float64                       `json:"quantity"`
+	Price          *float64                      `json:"price,omitempty"`
+	ExecutionPrice *float64                      `json:"execution_price,omitempty"`
+	Status         string                        `json:"status,omitempty"`
+	Reason         string                        `json:"reason,omitempty"`
+	OccurredAt     time.Time                     `json:"occurred_at"`
+}
+
+type OrderNotificationDispatcherConfig struct {
+	// DefaultChannels applies to users who never stored preferences; events missing from it are not sent
+	DefaultChannels map[domain.OrderNotificationEvent]domain.NotificationChannel
+}
+
+func DefaultOrderNotificationDispatcherConfig() OrderNotificationDispatcherConfig {
+	return OrderNotificationDispatcherConfig{
+		DefaultChannels: map[domain.OrderNotificationEvent]domain.NotificationChannel{
+			domain.OrderNotificationFilled:    domain.NotificationChannelInApp, // Users expect to hear about fills
+			domain.OrderNotificationCancelled: domain.NotificationChannelInApp,
+			domain.OrderNotificationRejected:  domain.NotificationChannelInApp,
+		},
+	}
+}
+
+type OrderNotificationDispatcher struct {
+	preferences repository.IOrderNotificationPreferencesRepository
+	senders     map[domain.NotificationChannel]IOrderNotificationSender
+	config      OrderNotificationDispatcherConfig
+	wg          sync.WaitGroup
+}
+
+func NewOrderNotificationDispatcher(
+	preferences repository.IOrderNotificationPreferencesRepository,
+	senders map[domain.NotificationChannel]IOrderNotificationSender,
+	config OrderNotificationDispatcherConfig,
+) *OrderNotificationDispatcher {
+	return &OrderNotificationDispatcher{
+		preferences: preferences,
+		senders:     senders,
+		config:      config,
+	}
+}
+
+// NewOrderNotificationDispatcherWithDefaults creates a dispatcher with default configuration
+func NewOrderNotificationDispatcherWithDefaults(
+	preferences repository.IOrderNotificationPreferencesRepository,
+	senders map[domain.NotificationChannel]IOrderNotificationSender,
+) *OrderNotificationDispatcher {
+	return NewOrderNotificationDispatcher(preferences, senders, DefaultOrderNotificationDispatcherConfig())
+}
+
+// NotifyOrderEvent sends the order event to the user if they opted in to it
+func (d *OrderNotificationDispatcher) NotifyOrderEvent(ctx context.Context, event domain.OrderNotificationEvent, order *domain.Order) {
+	notification := OrderNotification{
+		Event:          event,
+		UserID:         order.UserID(),
+		OrderID:        order.ID(),
+		Symbol:         order.Symbol(),
+		OrderSide:      order.OrderSide().String(),
+		OrderType:      order.OrderType().String(),
+		Quantity:       order.Quantity(),
+		Price:          order.Price(),
+		ExecutionPrice: order.ExecutionPrice(),
+		Status:         string(order.Status()),
+		OccurredAt:     order.UpdatedAt(),
+	}
+	if reason := order.CancellationReason(); reason != "" {
+		notification.Reason = string(reason)
+	}
+
+	d.dispatch(ctx, notification)
+}
+
+// NotifyOrderRejected sends the rejected submission to the user if they opted in to rejections
+func (d *OrderNotificationDispatcher) NotifyOrderRejected(ctx context.Context, rejection *domain.RejectedOrder) {
+	d.dispatch(ctx, OrderNotification{
+		Event:      domain.OrderNotificationRejected,
+		UserID:     rejection.UserID,
+		Symbol:     rejection.Symbol,
+		OrderSide:  rejection.OrderSide,
+		OrderType:  rejection.OrderType,
+		Quantity:   rejection.Quantity,
+		Price:      rejection.Price,
+		Reason:     rejection.ReasonDetails,
+		OccurredAt: rejection.RejectedAt,
+	})
+}
+
+// Wait blocks until all in-flight deliveries have finished
+func (d *OrderNotificationDispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// dispatch looks up the channel the user chose for the event and delivers through it in the background
+func (d *OrderNotificationDispatcher) dispatch(ctx context.Context, notification OrderNotification) {
+	channel, optedIn := d.channelFor(ctx, notification.UserID, notification.Event)
+	if !optedIn {
+		return
+	}
+
+	sender, exists := d.senders[channel]
+	if !exists {
+		log.Printf("Order notification dispatcher: no %s sender for %s notification of user %s", channel, notification.Event, notification.UserID)
+		return
+	}
+
+	notification.Type = "order_notification"
+	notification.Channel = channel
+
+	// Deliveries outlive the request that triggered them
+	deliveryCtx := context.WithoutCancel(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		if err := sender.SendOrderNotification(deliveryCtx, notification); err != nil {
+			log.Printf("Order notification dispatcher: failed to send %s notification to user %s via %s: %v",
+				notification.Event, notification.UserID, channel, err)
+		}
+	}()
+}
+
+// channelFor returns the channel the user receives the event through. A user whose preferences
+// cannot be read is not notified, since they may have opted out.
+func (d *OrderNotificationDispatcher) channelFor(ctx context.Context, userID string, event domain.OrderNotificationEvent) (domain.NotificationChannel, bool) {
+	preferences, err := d.preferences.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Printf("Order notification dispatcher: failed to load preferences for user %s: %v", userID, err)
+		return "", false
+	}
+
+	if preferences == nil {
+		channel, optedIn := d.config.DefaultChannels[event]
+		return channel, optedIn
+	}
+	return preferences.ChannelFor(event)
+}
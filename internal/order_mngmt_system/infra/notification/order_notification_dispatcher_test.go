@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type stubPreferencesRepository struct {
+	preferences map[string]*domain.OrderNotificationPreferences
+	err         error
+}
+
+func (r *stubPreferencesRepository) Save(ctx context.Context, preferences *domain.OrderNotificationPreferences) error {
+	return nil
+}
+
+func (r *stubPreferencesRepository) FindByUserID(ctx context.Context, userID string) (*domain.OrderNotificationPreferences, error) {
+	return r.preferences[userID], r.err
+}
+
+type recordingSender struct {
+	mu   sync.Mutex
+	sent []OrderNotification
+}
+
+func (s *recordingSender) SendOrderNotification(ctx context.Context, notification OrderNotification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = append(s.sent, notification)
+	return nil
+}
+
+func newTestOrder(t *testing.T) *domain.Order {
+	price := 25.0
+	order, err := domain.NewOrder("user-1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	require.NoError(t, err)
+	return order
+}
+
+func newTestDispatcher(repo *stubPreferencesRepository) (*OrderNotificationDispatcher, *recordingSender, *recordingSender) {
+	inApp, email := &recordingSender{}, &recordingSender{}
+	dispatcher := NewOrderNotificationDispatcherWithDefaults(repo, map[domain.NotificationChannel]IOrderNotificationSender{
+		domain.NotificationChannelInApp: inApp,
+		domain.NotificationChannelEmail: email,
+	})
+	return dispatcher, inApp, email
+}
+
+func TestOrderNotificationDispatcher_SendsOnlyOptedInEventsThroughTheirChannel(t *testing.T) {
+	dispatcher, inApp, email := newTestDispatcher(&stubPreferencesRepository{
+		preferences: map[string]*domain.OrderNotificationPreferences{
+			"user-1": {
+				UserID: "user-1",
+				Channels: map[domain.OrderNotificationEvent]domain.NotificationChannel{
+					domain.OrderNotificationSubmitted: domain.NotificationChannelEmail,
+				},
+			},
+		},
+	})
+	order := newTestOrder(t)
+
+	dispatcher.NotifyOrderEvent(context.Background(), domain.OrderNotificationSubmitted, order)
+	dispatcher.NotifyOrderEvent(context.Background(), domain.OrderNotificationFilled, order)
+	dispatcher.Wait()
+
+	assert.Empty(t, inApp.sent)
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, domain.OrderNotificationSubmitted, email.sent[0].Event)
+	assert.Equal(t, domain.NotificationChannelEmail, email.sent[0].Channel)
+	assert.Equal(t, "order_notification", email.sent[0].Type)
+	assert.Equal(t, order.ID(), email.sent[0].OrderID)
+	assert.Equal(t, "PETR4", email.sent[0].Symbol)
+}
+
+func TestOrderNotificationDispatcher_UsesDefaultsForUsersWithoutPreferences(t *testing.T) {
+	dispatcher, inApp, email := newTestDispatcher(&stubPreferencesRepository{})
+	order := newTestOrder(t)
+
+	dispatcher.NotifyOrderEvent(context.Background(), domain.OrderNotificationSubmitted, order)
+	dispatcher.NotifyOrderEvent(context.Background(), domain.OrderNotificationFilled, order)
+	dispatcher.Wait()
+
+	assert.Empty(t, email.sent)
+	require.Len(t, inApp.sent, 1, "submissions are not in the defaults")
+	assert.Equal(t, domain.OrderNotificationFilled, inApp.sent[0].Event)
+}
+
+func TestOrderNotificationDispatcher_NotifiesRejectedSubmissions(t *testing.T) {
+	dispatcher, inApp, _ := newTestDispatcher(&stubPreferencesRepository{})
+	rejectedAt := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+
+	dispatcher.NotifyOrderRejected(context.Background(), &domain.RejectedOrder{
+		UserID:        "user-1",
+		Symbol:        "PETR4",
+		OrderSide:     "BUY",
+		OrderType:     "MARKET",
+		Quantity:      100,
+		ReasonCode:    domain.RejectReasonMarketClosed,
+		ReasonDetails: "market is closed",
+		RejectedAt:    rejectedAt,
+	})
+	dispatcher.Wait()
+
+	require.Len(t, inApp.sent, 1)
+	assert.Equal(t, domain.OrderNotificationRejected, inApp.sent[0].Event)
+	assert.Empty(t, inApp.sent[0].OrderID)
+	assert.Equal(t, "market is closed", inApp.sent[0].Reason)
+	assert.Equal(t, rejectedAt, inApp.sent[0].OccurredAt)
+}
+
+func TestOrderNotificationDispatcher_SkipsWhenPreferencesCannotBeRead(t *testing.T) {
+	dispatcher, inApp, email := newTestDispatcher(&stubPreferencesRepository{err: errors.New("database unavailable")})
+
+	dispatcher.NotifyOrderEvent(context.Background(), domain.OrderNotificationFilled, newTestOrder(t))
+	dispatcher.Wait()
+
+	assert.Empty(t, inApp.sent, "a user who may have opted out is not notified")
+	assert.Empty(t, email.sent)
+}
+
+func TestOrderNotificationDispatcher_SkipsChannelsWithoutSender(t *testing.T) {
+	dispatcher, inApp, email := newTestDispatcher(&stubPreferencesRepository{
+		preferences: map[string]*domain.OrderNotificationPreferences{
+			"user-1": {
+				UserID: "user-1",
+				Channels: map[domain.OrderNotificationEvent]domain.NotificationChannel{
+					domain.OrderNotificationFilled: domain.NotificationChannelPush,
+				},
+			},
+		},
+	})
+
+	dispatcher.NotifyOrderEvent(context.Background(), domain.OrderNotificationFilled, newTestOrder(t))
+	dispatcher.Wait()
+
+	assert.Empty(t, inApp.sent)
+	assert.Empty(t, email.sent)
+}
@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"HubInvestments/shared/infra/websocket"
+)
+
+// WebSocketOrderNotificationSender delivers in-app order notifications to every open notification
+// connection of the user
+type WebSocketOrderNotificationSender struct {
+	mutex       sync.Mutex                                // Also serializes writes, since a connection allows one writer at a time
+	connections map[string]map[string]websocket.Websocket // user ID -> connection ID -> connection
+}
+
+func NewWebSocketOrderNotificationSender() *WebSocketOrderNotificationSender {
+	return &WebSocketOrderNotificationSender{
+		connections: make(map[string]map[string]websocket.Websocket),
+	}
+}
+
+// Connect registers a connection that receives the user's order notifications
+func (s *WebSocketOrderNotificationSender) Connect(userID, connectionID string, conn websocket.Websocket) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	userConnections, exists := s.connections[userID]
+	if !exists {
+		userConnections = make(map[string]websocket.Websocket)
+		s.connections[userID] = userConnections
+	}
+	userConnections[connectionID] = conn
+}
+
+// Disconnect stops delivering notifications to the connection
+func (s *WebSocketOrderNotificationSender) Disconnect(userID, connectionID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.connections[userID], connectionID)
+	if len(s.connections[userID]) == 0 {
+		delete(s.connections, userID)
+	}
+}
+
+// SendOrderNotification writes the notification to the user's connections. A user without an open
+// connection is not an error; the order itself stays visible in their order history.
+func (s *WebSocketOrderNotificationSender) SendOrderNotification(ctx context.Context, notification OrderNotification) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal order notification: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var errs []error
+	for connectionID, conn := range s.connections[notification.UserID] {
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			errs = append(errs, fmt.Errorf("connection %s: %w", connectionID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type ExecutionQualityDTO struct {
+	OrderID                  uuid.UUID  `db:"order_id"`
+	Symbol                   string     `db:"symbol"`
+	OrderSide                string     `db:"order_side"`
+	Quantity                 float64    `db:"quantity"`
+	EstimatedFillPrice       float64    `db:"estimated_fill_price"`
+	ActualFillPrice          float64    `db:"actual_fill_price"`
+	Slippage                 float64    `db:"slippage"`
+	SlippageBps              float64    `db:"slippage_bps"`
+	SlippageCost             float64    `db:"slippage_cost"`
+	SlippageTolerancePercent float64    `db:"slippage_tolerance_percent"`
+	Quality                  string     `db:"quality"`
+	ExecutedAt               *time.Time `db:"executed_at"`
+	CreatedAt                time.Time  `db:"created_at"`
+}
+
+// ToDomain converts the DTO to an execution quality report
+func (d *ExecutionQualityDTO) ToDomain() (*domain.ExecutionQualityReport, error) {
+	orderSide, err := domain.ParseOrderSide(d.OrderSide)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order side: %w", err)
+	}
+
+	return &domain.ExecutionQualityReport{
+		OrderID:                  d.OrderID.String(),
+		Symbol:                   d.Symbol,
+		OrderSide:                orderSide,
+		Quantity:                 d.Quantity,
+		EstimatedFillPrice:       d.EstimatedFillPrice,
+		ActualFillPrice:          d.ActualFillPrice,
+		Slippage:                 d.Slippage,
+		SlippageBps:              d.SlippageBps,
+		SlippageCost:             d.SlippageCost,
+		SlippageTolerancePercent: d.SlippageTolerancePercent,
+		Quality:                  domain.ExecutionQuality(d.Quality),
+		ExecutedAt:               d.ExecutedAt,
+		CreatedAt:                d.CreatedAt,
+	}, nil
+}
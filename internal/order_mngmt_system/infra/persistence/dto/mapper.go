@@ -32,16 +32,19 @@ func (m *OrderMapper) ToDTO(order *domain.Order) (*OrderDTO, error) {
 	}
 
 	dto := &OrderDTO{
-		ID:        orderUUID,
-		UserID:    userID,
-		Symbol:    order.Symbol(),
-		OrderType: order.OrderType().String(),
-		OrderSide: order.OrderSide().String(),
-		Quantity:  order.Quantity(),
-		Price:     order.Price(),
-		Status:    order.Status().String(),
-		CreatedAt: order.CreatedAt(),
-		UpdatedAt: order.UpdatedAt(),
+		ID:               orderUUID,
+		UserID:           userID,
+		Symbol:           order.Symbol(),
+		OrderType:        order.OrderType().String(),
+		OrderSide:        order.OrderSide().String(),
+		Quantity:         order.Quantity(),
+		FilledQuantity:   order.FilledQuantity(),
+		Price:            order.Price(),
+		Status:           order.Status().String(),
+		CreatedAt:        order.CreatedAt(),
+		UpdatedAt:        order.UpdatedAt(),
+		TimeInForce:      order.TimeInForce().String(),
+		AllowPartialFill: order.AllowPartialFill(),
 	}
 
 	// Handle optional execution fields
@@ -61,6 +64,45 @@ func (m *OrderMapper) ToDTO(order *domain.Order) (*OrderDTO, error) {
 		dto.MarketDataTimestamp = order.MarketDataTimestamp()
 	}
 
+	if order.ProtectionLimitPrice() != nil {
+		dto.ProtectionLimitPrice = order.ProtectionLimitPrice()
+	}
+
+	if strategy := order.ExecutionStrategyOverride(); strategy != "" {
+		dto.ExecutionStrategy = &strategy
+	}
+
+	if reason := order.CancellationReason(); reason != "" {
+		reasonCode := reason.String()
+		dto.CancellationReason = &reasonCode
+	}
+
+	dto.TriggerPrice = order.TriggerPrice()
+	dto.TriggeredAt = order.TriggeredAt()
+
+	if instruction := order.SettlementInstruction(); instruction != nil {
+		instructionCode := instruction.Code.String()
+		dto.SettlementAccount = &instruction.Account
+		dto.SettlementInstruction = &instructionCode
+	}
+
+	if peg := order.PegInstruction(); peg != nil {
+		reference := peg.Reference.String()
+		dto.PegReference = &reference
+		dto.PegOffset = &peg.Offset
+		dto.PegMinPrice = peg.MinPrice
+		dto.PegMaxPrice = peg.MaxPrice
+	}
+	dto.RepricedAt = order.RepricedAt()
+
+	if order.IsBracketLeg() {
+		parentID, err := uuid.Parse(order.ParentOrderID())
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent order ID format: %w", err)
+		}
+		dto.ParentOrderID = &parentID
+	}
+
 	return dto, nil
 }
 
@@ -105,6 +147,84 @@ func (m *OrderMapper) ToDomain(dto *OrderDTO) (*domain.Order, error) {
 		dto.MarketDataTimestamp,
 	)
 
+	if dto.FilledQuantity > 0 {
+		if err := order.RestoreFilledQuantity(dto.FilledQuantity); err != nil {
+			return nil, fmt.Errorf("invalid filled quantity: %w", err)
+		}
+	}
+
+	if dto.ProtectionLimitPrice != nil {
+		if err := order.ApplyMarketProtection(*dto.ProtectionLimitPrice); err != nil {
+			return nil, fmt.Errorf("invalid protection limit price: %w", err)
+		}
+	}
+
+	if dto.TimeInForce != "" {
+		timeInForce, err := domain.ParseTimeInForce(dto.TimeInForce)
+		if err != nil {
+			return nil, err
+		}
+		if err := order.SetExecutionPreferences(timeInForce, dto.AllowPartialFill); err != nil {
+			return nil, fmt.Errorf("invalid execution preferences: %w", err)
+		}
+	}
+
+	if dto.ExecutionStrategy != nil {
+		order.SetExecutionStrategyOverride(*dto.ExecutionStrategy)
+	}
+
+	if dto.CancellationReason != nil {
+		reason, err := domain.ParseCancellationReason(*dto.CancellationReason)
+		if err != nil {
+			return nil, err
+		}
+		order.SetCancellationReason(reason)
+	}
+
+	if dto.TriggerPrice != nil {
+		if err := order.SetTriggerPrice(*dto.TriggerPrice); err != nil {
+			return nil, fmt.Errorf("invalid trigger price: %w", err)
+		}
+	}
+
+	if dto.TriggeredAt != nil {
+		order.RestoreTriggerActivation(*dto.TriggeredAt)
+	}
+
+	if dto.SettlementAccount != nil && dto.SettlementInstruction != nil {
+		instruction, err := domain.NewSettlementInstruction(*dto.SettlementAccount, *dto.SettlementInstruction)
+		if err != nil {
+			return nil, fmt.Errorf("invalid settlement instruction: %w", err)
+		}
+		if err := order.SetSettlementInstruction(instruction); err != nil {
+			return nil, fmt.Errorf("invalid settlement instruction: %w", err)
+		}
+	}
+
+	if dto.PegReference != nil {
+		offset := 0.0
+		if dto.PegOffset != nil {
+			offset = *dto.PegOffset
+		}
+		peg, err := domain.NewPegInstruction(*dto.PegReference, offset, dto.PegMinPrice, dto.PegMaxPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peg: %w", err)
+		}
+		if err := order.SetPegInstruction(peg); err != nil {
+			return nil, fmt.Errorf("invalid peg: %w", err)
+		}
+	}
+
+	if dto.RepricedAt != nil {
+		order.RestoreRepricedAt(*dto.RepricedAt)
+	}
+
+	if dto.ParentOrderID != nil {
+		if err := order.LinkToParent(dto.ParentOrderID.String()); err != nil {
+			return nil, fmt.Errorf("invalid parent order: %w", err)
+		}
+	}
+
 	return order, nil
 }
 
@@ -118,6 +238,10 @@ func (m *OrderMapper) parseOrderType(typeStr string) (domain.OrderType, error) {
 		return domain.OrderTypeStopLoss, nil
 	case "STOP_LIMIT":
 		return domain.OrderTypeStopLimit, nil
+	case "MARKET_IF_TOUCHED":
+		return domain.OrderTypeMarketIfTouched, nil
+	case "LIMIT_IF_TOUCHED":
+		return domain.OrderTypeLimitIfTouched, nil
 	default:
 		return "", fmt.Errorf("unknown order type: %s", typeStr)
 	}
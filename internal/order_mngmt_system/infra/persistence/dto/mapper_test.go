@@ -0,0 +1,134 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMapperTestOrder(t *testing.T) *domain.Order {
+	t.Helper()
+	price := 150.0
+	order, err := domain.NewOrder("42", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	require.NoError(t, err)
+	return order
+}
+
+func TestOrderMapper_SettlementInstructionRoundTrip(t *testing.T) {
+	mapper := NewOrderMapper()
+	order := newMapperTestOrder(t)
+	instruction, err := domain.NewSettlementInstruction("CUST-00123", "RVP")
+	require.NoError(t, err)
+	require.NoError(t, order.SetSettlementInstruction(instruction))
+
+	orderDTO, err := mapper.ToDTO(order)
+	require.NoError(t, err)
+	require.NotNil(t, orderDTO.SettlementAccount)
+	require.NotNil(t, orderDTO.SettlementInstruction)
+	assert.Equal(t, "CUST-00123", *orderDTO.SettlementAccount)
+	assert.Equal(t, "RVP", *orderDTO.SettlementInstruction)
+
+	restored, err := mapper.ToDomain(orderDTO)
+	require.NoError(t, err)
+	assert.Equal(t, order.SettlementInstruction(), restored.SettlementInstruction())
+}
+
+func TestOrderMapper_WithoutSettlementInstruction(t *testing.T) {
+	mapper := NewOrderMapper()
+
+	orderDTO, err := mapper.ToDTO(newMapperTestOrder(t))
+	require.NoError(t, err)
+	assert.Nil(t, orderDTO.SettlementAccount)
+	assert.Nil(t, orderDTO.SettlementInstruction)
+
+	restored, err := mapper.ToDomain(orderDTO)
+	require.NoError(t, err)
+	assert.Nil(t, restored.SettlementInstruction())
+}
+
+func TestOrderMapper_RejectsInvalidStoredSettlementCode(t *testing.T) {
+	account := "CUST-00123"
+	code := "WIRE"
+	orderDTO := &OrderDTO{
+		ID:                    uuid.New(),
+		UserID:                42,
+		Symbol:                "AAPL",
+		OrderType:             "MARKET",
+		OrderSide:             "BUY",
+		Quantity:              10,
+		Status:                "PENDING",
+		SettlementAccount:     &account,
+		SettlementInstruction: &code,
+	}
+
+	_, err := NewOrderMapper().ToDomain(orderDTO)
+	assert.Error(t, err)
+}
+
+func TestOrderMapper_PegInstructionRoundTrip(t *testing.T) {
+	mapper := NewOrderMapper()
+	order := newMapperTestOrder(t)
+	maxPrice := 152.0
+	peg, err := domain.NewPegInstruction("BID", -0.05, nil, &maxPrice)
+	require.NoError(t, err)
+	require.NoError(t, order.SetPegInstruction(peg))
+	repriced, err := order.RepriceToPeg(151.00, 151.10, 0.01, time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, repriced)
+
+	orderDTO, err := mapper.ToDTO(order)
+	require.NoError(t, err)
+	require.NotNil(t, orderDTO.PegReference)
+	assert.Equal(t, "BID", *orderDTO.PegReference)
+	assert.Nil(t, orderDTO.PegMinPrice)
+	assert.Equal(t, 152.0, *orderDTO.PegMaxPrice)
+
+	restored, err := mapper.ToDomain(orderDTO)
+	require.NoError(t, err)
+	assert.Equal(t, order.PegInstruction(), restored.PegInstruction())
+	assert.Equal(t, *order.Price(), *restored.Price())
+	assert.Equal(t, order.RepricedAt(), restored.RepricedAt())
+}
+
+func TestOrderMapper_ParentOrderRoundTrip(t *testing.T) {
+	mapper := NewOrderMapper()
+	buy := newMapperTestOrder(t)
+	stop, err := domain.NewProtectiveStopOrder(buy, 150.0, 5)
+	require.NoError(t, err)
+
+	orderDTO, err := mapper.ToDTO(stop)
+	require.NoError(t, err)
+	require.NotNil(t, orderDTO.ParentOrderID)
+	assert.Equal(t, buy.ID(), orderDTO.ParentOrderID.String())
+
+	restored, err := mapper.ToDomain(orderDTO)
+	require.NoError(t, err)
+	assert.Equal(t, buy.ID(), restored.ParentOrderID())
+
+	standalone, err := mapper.ToDTO(buy)
+	require.NoError(t, err)
+	assert.Nil(t, standalone.ParentOrderID)
+}
+
+func TestOrderMapper_FilledQuantityRoundTrip(t *testing.T) {
+	mapper := NewOrderMapper()
+	order := newMapperTestOrder(t)
+	require.NoError(t, order.RecordFill(4))
+
+	orderDTO, err := mapper.ToDTO(order)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, orderDTO.FilledQuantity)
+
+	restored, err := mapper.ToDomain(orderDTO)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, restored.FilledQuantity())
+
+	orderDTO.FilledQuantity = orderDTO.Quantity + 1
+	_, err = mapper.ToDomain(orderDTO)
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type MarketContextSnapshotDTO struct {
+	OrderID        uuid.UUID  `db:"order_id"`
+	Symbol         string     `db:"symbol"`
+	BidPrice       float64    `db:"bid_price"`
+	AskPrice       float64    `db:"ask_price"`
+	LastPrice      float64    `db:"last_price"`
+	Spread         float64    `db:"spread"`
+	SpreadPercent  float64    `db:"spread_percent"`
+	Volatility     float64    `db:"volatility"`
+	Volume         int64      `db:"volume"`
+	QuoteTimestamp *time.Time `db:"quote_timestamp"`
+	CapturedAt     time.Time  `db:"captured_at"`
+}
+
+// ToDomain converts the DTO to a market context snapshot
+func (d *MarketContextSnapshotDTO) ToDomain() *domain.MarketContextSnapshot {
+	snapshot := &domain.MarketContextSnapshot{
+		OrderID:       d.OrderID.String(),
+		Symbol:        d.Symbol,
+		BidPrice:      d.BidPrice,
+		AskPrice:      d.AskPrice,
+		LastPrice:     d.LastPrice,
+		Spread:        d.Spread,
+		SpreadPercent: d.SpreadPercent,
+		Volatility:    d.Volatility,
+		Volume:        d.Volume,
+		CapturedAt:    d.CapturedAt,
+	}
+
+	if d.QuoteTimestamp != nil {
+		snapshot.QuoteTimestamp = *d.QuoteTimestamp
+	}
+
+	return snapshot
+}
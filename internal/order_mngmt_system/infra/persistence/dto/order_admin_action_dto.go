@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type OrderAdminActionDTO struct {
+	ID             uuid.UUID `db:"id"`
+	OrderID        uuid.UUID `db:"order_id"`
+	OrderOwnerID   int       `db:"order_owner_id"`
+	Action         string    `db:"action"`
+	PerformedBy    int       `db:"performed_by"`
+	Reason         string    `db:"reason"`
+	PreviousStatus string    `db:"previous_status"`
+	NewStatus      string    `db:"new_status"`
+	PerformedAt    time.Time `db:"performed_at"`
+}
+
+// ToDomain converts the DTO to an order admin action
+func (d *OrderAdminActionDTO) ToDomain() *domain.OrderAdminAction {
+	return &domain.OrderAdminAction{
+		ID:             d.ID.String(),
+		OrderID:        d.OrderID.String(),
+		OrderOwnerID:   strconv.Itoa(d.OrderOwnerID),
+		Action:         domain.OrderAdminActionType(d.Action),
+		PerformedBy:    strconv.Itoa(d.PerformedBy),
+		Reason:         d.Reason,
+		PreviousStatus: domain.OrderStatus(d.PreviousStatus),
+		NewStatus:      domain.OrderStatus(d.NewStatus),
+		PerformedAt:    d.PerformedAt,
+	}
+}
@@ -14,6 +14,7 @@ type OrderDTO struct {
 	OrderType               string     `db:"order_type"`
 	OrderSide               string     `db:"order_side"`
 	Quantity                float64    `db:"quantity"`
+	FilledQuantity          float64    `db:"filled_quantity"`
 	Price                   *float64   `db:"price"`
 	Status                  string     `db:"status"`
 	CreatedAt               time.Time  `db:"created_at"`
@@ -26,6 +27,21 @@ type OrderDTO struct {
 	RetryCount              int        `db:"retry_count"`
 	ProcessingWorkerID      *string    `db:"processing_worker_id"`
 	ExternalOrderID         *string    `db:"external_order_id"`
+	ProtectionLimitPrice    *float64   `db:"protection_limit_price"`
+	TimeInForce             string     `db:"time_in_force"`
+	AllowPartialFill        bool       `db:"allow_partial_fill"`
+	ExecutionStrategy       *string    `db:"execution_strategy"`
+	CancellationReason      *string    `db:"cancellation_reason"`
+	TriggerPrice            *float64   `db:"trigger_price"`
+	TriggeredAt             *time.Time `db:"triggered_at"`
+	SettlementAccount       *string    `db:"settlement_account"`
+	SettlementInstruction   *string    `db:"settlement_instruction_code"`
+	PegReference            *string    `db:"peg_reference"`
+	PegOffset               *float64   `db:"peg_offset"`
+	PegMinPrice             *float64   `db:"peg_min_price"`
+	PegMaxPrice             *float64   `db:"peg_max_price"`
+	RepricedAt              *time.Time `db:"repriced_at"`
+	ParentOrderID           *uuid.UUID `db:"parent_order_id"`
 }
 
 // NullableFloat64 handles NULL values for DECIMAL fields
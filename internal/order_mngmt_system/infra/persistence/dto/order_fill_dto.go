@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type OrderFillDTO struct {
+	ID       uuid.UUID `db:"id"`
+	OrderID  uuid.UUID `db:"order_id"`
+	Quantity float64   `db:"quantity"`
+	Price    float64   `db:"price"`
+	FilledAt time.Time `db:"filled_at"`
+}
+
+// ToDomain converts the DTO to an order fill
+func (d *OrderFillDTO) ToDomain() domain.OrderFill {
+	return domain.OrderFill{
+		ID:       d.ID.String(),
+		OrderID:  d.OrderID.String(),
+		Quantity: d.Quantity,
+		Price:    d.Price,
+		FilledAt: d.FilledAt,
+	}
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type OrderNotificationPreferencesDTO struct {
+	UserID           int       `db:"user_id"`
+	SubmittedChannel *string   `db:"submitted_channel"`
+	FilledChannel    *string   `db:"filled_channel"`
+	CancelledChannel *string   `db:"cancelled_channel"`
+	RejectedChannel  *string   `db:"rejected_channel"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+// ToDomain converts the DTO to order notification preferences
+func (d *OrderNotificationPreferencesDTO) ToDomain() (*domain.OrderNotificationPreferences, error) {
+	preferences := &domain.OrderNotificationPreferences{
+		UserID:    strconv.Itoa(d.UserID),
+		Channels:  make(map[domain.OrderNotificationEvent]domain.NotificationChannel),
+		UpdatedAt: d.UpdatedAt,
+	}
+
+	columns := map[domain.OrderNotificationEvent]*string{
+		domain.OrderNotificationSubmitted: d.SubmittedChannel,
+		domain.OrderNotificationFilled:    d.FilledChannel,
+		domain.OrderNotificationCancelled: d.CancelledChannel,
+		domain.OrderNotificationRejected:  d.RejectedChannel,
+	}
+	for event, column := range columns {
+		if column == nil {
+			continue
+		}
+		channel, err := domain.ParseNotificationChannel(*column)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s notification channel: %w", event, err)
+		}
+		preferences.Channels[event] = channel
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type RejectedOrderDTO struct {
+	ID            uuid.UUID `db:"id"`
+	UserID        int       `db:"user_id"`
+	Symbol        string    `db:"symbol"`
+	OrderSide     string    `db:"order_side"`
+	OrderType     string    `db:"order_type"`
+	Quantity      float64   `db:"quantity"`
+	Price         *float64  `db:"price"`
+	ReasonCode    string    `db:"reason_code"`
+	ReasonDetails string    `db:"reason_details"`
+	RejectedAt    time.Time `db:"rejected_at"`
+}
+
+type RejectReasonCountDTO struct {
+	ReasonCode string `db:"reason_code"`
+	Count      int    `db:"count"`
+}
+
+// ToDomain converts the DTO to a rejected order
+func (d *RejectedOrderDTO) ToDomain() *domain.RejectedOrder {
+	return &domain.RejectedOrder{
+		ID:            d.ID.String(),
+		UserID:        strconv.Itoa(d.UserID),
+		Symbol:        d.Symbol,
+		OrderSide:     d.OrderSide,
+		OrderType:     d.OrderType,
+		Quantity:      d.Quantity,
+		Price:         d.Price,
+		ReasonCode:    domain.RejectReason(d.ReasonCode),
+		ReasonDetails: d.ReasonDetails,
+		RejectedAt:    d.RejectedAt,
+	}
+}
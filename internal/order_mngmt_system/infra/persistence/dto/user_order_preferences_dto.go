@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+type UserOrderPreferencesDTO struct {
+	UserID                int       `db:"user_id"`
+	DefaultOrderType      *string   `db:"default_order_type"`
+	DefaultTimeInForce    *string   `db:"default_time_in_force"`
+	AllowPartialFill      *bool     `db:"allow_partial_fill"`
+	ProtectiveStopEnabled bool      `db:"protective_stop_enabled"`
+	ProtectiveStopPercent *float64  `db:"protective_stop_percent"`
+	UpdatedAt             time.Time `db:"updated_at"`
+}
+
+// ToDomain converts the DTO to user order preferences
+func (d *UserOrderPreferencesDTO) ToDomain() (*domain.UserOrderPreferences, error) {
+	preferences := &domain.UserOrderPreferences{
+		UserID:                strconv.Itoa(d.UserID),
+		AllowPartialFill:      d.AllowPartialFill,
+		ProtectiveStopEnabled: d.ProtectiveStopEnabled,
+		UpdatedAt:             d.UpdatedAt,
+	}
+
+	if d.ProtectiveStopPercent != nil {
+		preferences.ProtectiveStopPercent = *d.ProtectiveStopPercent
+	}
+
+	if d.DefaultOrderType != nil {
+		orderType, err := domain.ParseOrderType(*d.DefaultOrderType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default order type: %w", err)
+		}
+		preferences.DefaultOrderType = orderType
+	}
+
+	if d.DefaultTimeInForce != nil {
+		timeInForce, err := domain.ParseTimeInForce(*d.DefaultTimeInForce)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default time in force: %w", err)
+		}
+		preferences.DefaultTimeInForce = timeInForce
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type UserRiskProfileDTO struct {
+	UserID               int       `db:"user_id"`
+	RiskTolerance        string    `db:"risk_tolerance"`
+	MaxOrderValue        float64   `db:"max_order_value"`
+	MaxDailyTradingValue float64   `db:"max_daily_trading_value"`
+	MaxPositionSize      float64   `db:"max_position_size"`
+	IsHighRiskApproved   bool      `db:"is_high_risk_approved"`
+	UpdatedAt            time.Time `db:"updated_at"`
+}
+
+// ToDomain converts the DTO to a user risk profile
+func (d *UserRiskProfileDTO) ToDomain() (*service.UserRiskProfile, error) {
+	tolerance, err := service.ParseRiskTolerance(d.RiskTolerance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid risk tolerance: %w", err)
+	}
+
+	return &service.UserRiskProfile{
+		UserID:               strconv.Itoa(d.UserID),
+		RiskTolerance:        tolerance,
+		MaxOrderValue:        d.MaxOrderValue,
+		MaxDailyTradingValue: d.MaxDailyTradingValue,
+		MaxPositionSize:      d.MaxPositionSize,
+		IsHighRiskApproved:   d.IsHighRiskApproved,
+		ProfileLastUpdated:   d.UpdatedAt,
+	}, nil
+}
+
+// RiskProfileSnapshot is the JSON form of a profile stored in the audit trail
+type RiskProfileSnapshot struct {
+	RiskTolerance        string  `json:"risk_tolerance"`
+	MaxOrderValue        float64 `json:"max_order_value"`
+	MaxDailyTradingValue float64 `json:"max_daily_trading_value"`
+	MaxPositionSize      float64 `json:"max_position_size"`
+	IsHighRiskApproved   bool    `json:"is_high_risk_approved"`
+}
+
+// MarshalRiskProfileSnapshot encodes the profile for the audit trail, returning nil for a nil profile
+func MarshalRiskProfileSnapshot(profile *service.UserRiskProfile) ([]byte, error) {
+	if profile == nil {
+		return nil, nil
+	}
+	return json.Marshal(RiskProfileSnapshot{
+		RiskTolerance:        profile.RiskTolerance.String(),
+		MaxOrderValue:        profile.MaxOrderValue,
+		MaxDailyTradingValue: profile.MaxDailyTradingValue,
+		MaxPositionSize:      profile.MaxPositionSize,
+		IsHighRiskApproved:   profile.IsHighRiskApproved,
+	})
+}
+
+type RiskProfileAuditDTO struct {
+	ID              string    `db:"id"`
+	UserID          int       `db:"user_id"`
+	ChangedBy       int       `db:"changed_by"`
+	Reason          string    `db:"reason"`
+	PreviousProfile []byte    `db:"previous_profile"`
+	UpdatedProfile  []byte    `db:"updated_profile"`
+	ChangedAt       time.Time `db:"changed_at"`
+}
+
+// ToDomain converts the DTO to a risk profile audit entry
+func (d *RiskProfileAuditDTO) ToDomain() (*service.RiskProfileAuditEntry, error) {
+	userID := strconv.Itoa(d.UserID)
+
+	updated, err := unmarshalRiskProfileSnapshot(userID, d.UpdatedProfile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid updated profile: %w", err)
+	}
+
+	entry := &service.RiskProfileAuditEntry{
+		ID:        d.ID,
+		UserID:    userID,
+		ChangedBy: strconv.Itoa(d.ChangedBy),
+		Reason:    d.Reason,
+		Updated:   *updated,
+		ChangedAt: d.ChangedAt,
+	}
+
+	if len(d.PreviousProfile) > 0 {
+		previous, err := unmarshalRiskProfileSnapshot(userID, d.PreviousProfile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous profile: %w", err)
+		}
+		entry.Previous = previous
+	}
+
+	return entry, nil
+}
+
+func unmarshalRiskProfileSnapshot(userID string, data []byte) (*service.UserRiskProfile, error) {
+	var snapshot RiskProfileSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	tolerance, err := service.ParseRiskTolerance(snapshot.RiskTolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.UserRiskProfile{
+		UserID:               userID,
+		RiskTolerance:        tolerance,
+		MaxOrderValue:        snapshot.MaxOrderValue,
+		MaxDailyTradingValue: snapshot.MaxDailyTradingValue,
+		MaxPositionSize:      snapshot.MaxPositionSize,
+		IsHighRiskApproved:   snapshot.IsHighRiskApproved,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type ExecutionQualityRepository struct {
+	db database.Database
+}
+
+func NewExecutionQualityRepository(db database.Database) repository.IExecutionQualityRepository {
+	return &ExecutionQualityRepository{db: db}
+}
+
+func (r *ExecutionQualityRepository) Save(ctx context.Context, report *domain.ExecutionQualityReport) error {
+	if report == nil {
+		return fmt.Errorf("execution quality report cannot be nil")
+	}
+
+	orderUUID, err := uuid.Parse(report.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO order_execution_quality (
+			order_id, symbol, order_side, quantity, estimated_fill_price, actual_fill_price,
+			slippage, slippage_bps, slippage_cost, slippage_tolerance_percent, quality,
+			executed_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		)
+		ON CONFLICT (order_id) DO UPDATE SET
+			estimated_fill_price = EXCLUDED.estimated_fill_price,
+			actual_fill_price = EXCLUDED.actual_fill_price,
+			slippage = EXCLUDED.slippage,
+			slippage_bps = EXCLUDED.slippage_bps,
+			slippage_cost = EXCLUDED.slippage_cost,
+			slippage_tolerance_percent = EXCLUDED.slippage_tolerance_percent,
+			quality = EXCLUDED.quality,
+			executed_at = EXCLUDED.executed_at,
+			created_at = EXCLUDED.created_at`
+
+	_, err = r.db.ExecContext(ctx, query,
+		orderUUID, report.Symbol, report.OrderSide.String(), report.Quantity,
+		report.EstimatedFillPrice, report.ActualFillPrice,
+		report.Slippage, report.SlippageBps, report.SlippageCost,
+		report.SlippageTolerancePercent, report.Quality.String(),
+		report.ExecutedAt, report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save execution quality report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ExecutionQualityRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.ExecutionQualityReport, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		SELECT order_id, symbol, order_side, quantity, estimated_fill_price, actual_fill_price,
+			   slippage, slippage_bps, slippage_cost, slippage_tolerance_percent, quality,
+			   executed_at, created_at
+		FROM order_execution_quality
+		WHERE order_id = $1`
+
+	var reportDTO dto.ExecutionQualityDTO
+	if err := r.db.Get(&reportDTO, query, orderUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find execution quality report: %w", err)
+	}
+
+	return reportDTO.ToDomain()
+}
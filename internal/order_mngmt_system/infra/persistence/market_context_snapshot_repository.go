@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type MarketContextSnapshotRepository struct {
+	db database.Database
+}
+
+func NewMarketContextSnapshotRepository(db database.Database) repository.IMarketContextSnapshotRepository {
+	return &MarketContextSnapshotRepository{db: db}
+}
+
+func (r *MarketContextSnapshotRepository) Save(ctx context.Context, snapshot *domain.MarketContextSnapshot) error {
+	if snapshot == nil {
+		return fmt.Errorf("market context snapshot cannot be nil")
+	}
+
+	orderUUID, err := uuid.Parse(snapshot.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	var quoteTimestamp *time.Time
+	if !snapshot.QuoteTimestamp.IsZero() {
+		quoteTimestamp = &snapshot.QuoteTimestamp
+	}
+
+	// Snapshots are write-once; a retried submission keeps the context first recorded
+	query := `
+		INSERT INTO order_market_context_snapshots (
+			order_id, symbol, bid_price, ask_price, last_price, spread, spread_percent,
+			volatility, volume, quote_timestamp, captured_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		)
+		ON CONFLICT (order_id) DO NOTHING`
+
+	_, err = r.db.ExecContext(ctx, query,
+		orderUUID, snapshot.Symbol, snapshot.BidPrice, snapshot.AskPrice, snapshot.LastPrice,
+		snapshot.Spread, snapshot.SpreadPercent, snapshot.Volatility, snapshot.Volume,
+		quoteTimestamp, snapshot.CapturedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save market context snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MarketContextSnapshotRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.MarketContextSnapshot, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		SELECT order_id, symbol, bid_price, ask_price, last_price, spread, spread_percent,
+			   volatility, volume, quote_timestamp, captured_at
+		FROM order_market_context_snapshots
+		WHERE order_id = $1`
+
+	var snapshotDTO dto.MarketContextSnapshotDTO
+	if err := r.db.Get(&snapshotDTO, query, orderUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find market context snapshot: %w", err)
+	}
+
+	return snapshotDTO.ToDomain(), nil
+}
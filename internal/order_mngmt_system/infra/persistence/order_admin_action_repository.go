@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type OrderAdminActionRepository struct {
+	db database.Database
+}
+
+func NewOrderAdminActionRepository(db database.Database) repository.IOrderAdminActionRepository {
+	return &OrderAdminActionRepository{db: db}
+}
+
+func (r *OrderAdminActionRepository) Save(ctx context.Context, action *domain.OrderAdminAction) error {
+	if action == nil {
+		return fmt.Errorf("order admin action cannot be nil")
+	}
+
+	if err := action.Validate(); err != nil {
+		return fmt.Errorf("invalid order admin action: %w", err)
+	}
+
+	if action.ID == "" {
+		action.ID = uuid.New().String()
+	}
+
+	actionUUID, err := uuid.Parse(action.ID)
+	if err != nil {
+		return fmt.Errorf("invalid admin action ID format: %w", err)
+	}
+
+	orderUUID, err := uuid.Parse(action.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	ownerID, err := dto.ParseUserIDFromString(action.OrderOwnerID)
+	if err != nil {
+		return fmt.Errorf("invalid order owner ID format: %w", err)
+	}
+
+	performedBy, err := dto.ParseUserIDFromString(action.PerformedBy)
+	if err != nil {
+		return fmt.Errorf("invalid performed by user ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO order_admin_actions (
+			id, order_id, order_owner_id, action, performed_by, reason,
+			previous_status, new_status, performed_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		actionUUID, orderUUID, ownerID, string(action.Action), performedBy, action.Reason,
+		string(action.PreviousStatus), string(action.NewStatus), action.PerformedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save order admin action: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OrderAdminActionRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.OrderAdminAction, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		SELECT id, order_id, order_owner_id, action, performed_by, reason,
+			   previous_status, new_status, performed_at
+		FROM order_admin_actions
+		WHERE order_id = $1
+		ORDER BY performed_at DESC`
+
+	var actionDTOs []dto.OrderAdminActionDTO
+	if err := r.db.Select(&actionDTOs, query, orderUUID); err != nil {
+		return nil, fmt.Errorf("failed to find order admin actions: %w", err)
+	}
+
+	actions := make([]*domain.OrderAdminAction, 0, len(actionDTOs))
+	for i := range actionDTOs {
+		actions = append(actions, actionDTOs[i].ToDomain())
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type OrderFillRepository struct {
+	db database.Database
+}
+
+func NewOrderFillRepository(db database.Database) repository.IOrderFillRepository {
+	return &OrderFillRepository{db: db}
+}
+
+func (r *OrderFillRepository) Save(ctx context.Context, fill *domain.OrderFill) error {
+	if fill == nil {
+		return fmt.Errorf("order fill cannot be nil")
+	}
+
+	fillUUID, err := uuid.Parse(fill.ID)
+	if err != nil {
+		return fmt.Errorf("invalid fill ID format: %w", err)
+	}
+
+	orderUUID, err := uuid.Parse(fill.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO order_fills (id, order_id, quantity, price, filled_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING`
+
+	_, err = r.db.ExecContext(ctx, query, fillUUID, orderUUID, fill.Quantity, fill.Price, fill.FilledAt)
+	if err != nil {
+		return fmt.Errorf("failed to save order fill: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OrderFillRepository) FindByOrderID(ctx context.Context, orderID string) ([]domain.OrderFill, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		SELECT id, order_id, quantity, price, filled_at
+		FROM order_fills
+		WHERE order_id = $1
+		ORDER BY filled_at ASC`
+
+	var fillDTOs []dto.OrderFillDTO
+	if err := r.db.Select(&fillDTOs, query, orderUUID); err != nil {
+		return nil, fmt.Errorf("failed to find order fills: %w", err)
+	}
+
+	fills := make([]domain.OrderFill, 0, len(fillDTOs))
+	for i := range fillDTOs {
+		fills = append(fills, fillDTOs[i].ToDomain())
+	}
+
+	return fills, nil
+}
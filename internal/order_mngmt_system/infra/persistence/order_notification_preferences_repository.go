@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+)
+
+type OrderNotificationPreferencesRepository struct {
+	db database.Database
+}
+
+func NewOrderNotificationPreferencesRepository(db database.Database) repository.IOrderNotificationPreferencesRepository {
+	return &OrderNotificationPreferencesRepository{db: db}
+}
+
+func (r *OrderNotificationPreferencesRepository) Save(ctx context.Context, preferences *domain.OrderNotificationPreferences) error {
+	if preferences == nil {
+		return fmt.Errorf("order notification preferences cannot be nil")
+	}
+
+	if err := preferences.Validate(); err != nil {
+		return fmt.Errorf("invalid order notification preferences: %w", err)
+	}
+
+	userID, err := dto.ParseUserIDFromString(preferences.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO order_notification_preferences (
+			user_id, submitted_channel, filled_channel, cancelled_channel, rejected_channel, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			submitted_channel = EXCLUDED.submitted_channel,
+			filled_channel = EXCLUDED.filled_channel,
+			cancelled_channel = EXCLUDED.cancelled_channel,
+			rejected_channel = EXCLUDED.rejected_channel,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err = r.db.ExecContext(ctx, query,
+		userID,
+		nullableString(preferences.Channels[domain.OrderNotificationSubmitted].String()),
+		nullableString(preferences.Channels[domain.OrderNotificationFilled].String()),
+		nullableString(preferences.Channels[domain.OrderNotificationCancelled].String()),
+		nullableString(preferences.Channels[domain.OrderNotificationRejected].String()),
+		preferences.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save order notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OrderNotificationPreferencesRepository) FindByUserID(ctx context.Context, userID string) (*domain.OrderNotificationPreferences, error) {
+	id, err := dto.ParseUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		SELECT user_id, submitted_channel, filled_channel, cancelled_channel, rejected_channel, updated_at
+		FROM order_notification_preferences
+		WHERE user_id = $1`
+
+	var preferencesDTO dto.OrderNotificationPreferencesDTO
+	if err := r.db.Get(&preferencesDTO, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find order notification preferences: %w", err)
+	}
+
+	return preferencesDTO.ToDomain()
+}
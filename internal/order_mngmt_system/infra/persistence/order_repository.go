@@ -42,11 +42,18 @@ func (r *OrderRepository) Save(ctx context.Context, order *domain.Order) error {
 			id, user_id, symbol, order_type, order_side, quantity, price, status,
 			created_at, updated_at, executed_at, execution_price, 
 			market_price_at_submission, market_data_timestamp, failure_reason,
-			retry_count, processing_worker_id, external_order_id
+			retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id, filled_quantity
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27,
+			$28, $29, $30, $31, $32, $33, $34
 		)
 		ON CONFLICT (id) DO UPDATE SET
+			quantity = EXCLUDED.quantity,
+			filled_quantity = EXCLUDED.filled_quantity,
+			price = EXCLUDED.price,
 			status = EXCLUDED.status,
 			updated_at = EXCLUDED.updated_at,
 			executed_at = EXCLUDED.executed_at,
@@ -54,14 +61,27 @@ func (r *OrderRepository) Save(ctx context.Context, order *domain.Order) error {
 			failure_reason = EXCLUDED.failure_reason,
 			retry_count = EXCLUDED.retry_count,
 			processing_worker_id = EXCLUDED.processing_worker_id,
-			external_order_id = EXCLUDED.external_order_id`
+			external_order_id = EXCLUDED.external_order_id,
+			protection_limit_price = EXCLUDED.protection_limit_price,
+			time_in_force = EXCLUDED.time_in_force,
+			allow_partial_fill = EXCLUDED.allow_partial_fill,
+			execution_strategy = EXCLUDED.execution_strategy,
+			cancellation_reason = EXCLUDED.cancellation_reason,
+			triggered_at = EXCLUDED.triggered_at,
+			settlement_account = EXCLUDED.settlement_account,
+			settlement_instruction_code = EXCLUDED.settlement_instruction_code,
+			repriced_at = EXCLUDED.repriced_at`
 
 	_, err = r.db.ExecContext(ctx, query,
 		orderDTO.ID, orderDTO.UserID, orderDTO.Symbol, orderDTO.OrderType, orderDTO.OrderSide,
 		orderDTO.Quantity, orderDTO.Price, orderDTO.Status, orderDTO.CreatedAt, orderDTO.UpdatedAt,
 		orderDTO.ExecutedAt, orderDTO.ExecutionPrice, orderDTO.MarketPriceAtSubmission,
 		orderDTO.MarketDataTimestamp, orderDTO.FailureReason, orderDTO.RetryCount,
-		orderDTO.ProcessingWorkerID, orderDTO.ExternalOrderID)
+		orderDTO.ProcessingWorkerID, orderDTO.ExternalOrderID, orderDTO.ProtectionLimitPrice,
+		orderDTO.TimeInForce, orderDTO.AllowPartialFill, orderDTO.ExecutionStrategy, orderDTO.CancellationReason,
+		orderDTO.TriggerPrice, orderDTO.TriggeredAt, orderDTO.SettlementAccount, orderDTO.SettlementInstruction,
+		orderDTO.PegReference, orderDTO.PegOffset, orderDTO.PegMinPrice, orderDTO.PegMaxPrice, orderDTO.RepricedAt,
+		orderDTO.ParentOrderID, orderDTO.FilledQuantity)
 
 	if err != nil {
 		return fmt.Errorf("failed to save order: %w", err)
@@ -74,10 +94,13 @@ func (r *OrderRepository) FindByID(ctx context.Context, orderID string) (*domain
 	var orderDTO dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE id = $1`
 
@@ -106,10 +129,13 @@ func (r *OrderRepository) FindByUserID(ctx context.Context, userID string) ([]*d
 	var orderDTOs []*dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE user_id = $1 
 		ORDER BY created_at DESC`
@@ -136,10 +162,13 @@ func (r *OrderRepository) FindByUserIDAndStatus(ctx context.Context, userID stri
 	var orderDTOs []*dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE user_id = $1 AND status = $2 
 		ORDER BY created_at DESC`
@@ -166,10 +195,13 @@ func (r *OrderRepository) FindByStatus(ctx context.Context, status domain.OrderS
 	var orderDTOs []*dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE status = $1 
 		ORDER BY created_at DESC`
@@ -216,6 +248,7 @@ func (r *OrderRepository) UpdateExecutionDetails(ctx context.Context, orderID st
 		SET execution_price = $1, 
 			executed_at = $2, 
 			status = $3,
+			filled_quantity = quantity,
 			updated_at = CURRENT_TIMESTAMP 
 		WHERE id = $4`
 
@@ -280,10 +313,13 @@ func (r *OrderRepository) FindOrderHistory(ctx context.Context, userID string, l
 	var orderDTOs []*dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE user_id = $1 
 		ORDER BY created_at DESC 
@@ -311,10 +347,13 @@ func (r *OrderRepository) FindOrdersBySymbol(ctx context.Context, symbol string)
 	var orderDTOs []*dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE symbol = $1 
 		ORDER BY created_at DESC`
@@ -336,10 +375,13 @@ func (r *OrderRepository) FindOrdersByDateRange(ctx context.Context, userID stri
 	var orderDTOs []*dto.OrderDTO
 
 	query := `
-		SELECT id, user_id, symbol, order_type, order_side, quantity, price, status,
+		SELECT id, user_id, symbol, order_type, order_side, quantity, filled_quantity, price, status,
 			   created_at, updated_at, executed_at, execution_price,
 			   market_price_at_submission, market_data_timestamp, failure_reason,
-			   retry_count, processing_worker_id, external_order_id
+			   retry_count, processing_worker_id, external_order_id, protection_limit_price,
+			   time_in_force, allow_partial_fill, execution_strategy, cancellation_reason,
+			   trigger_price, triggered_at, settlement_account, settlement_instruction_code,
+			   peg_reference, peg_offset, peg_min_price, peg_max_price, repriced_at, parent_order_id
 		FROM orders 
 		WHERE user_id = $1 AND created_at BETWEEN $2 AND $3 
 		ORDER BY created_at DESC`
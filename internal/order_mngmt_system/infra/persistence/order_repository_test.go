@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/shared/infra/database"
+)
+
+var (
+	insertColumnsPattern = regexp.MustCompile(`(?s)INSERT INTO orders \((.*?)\)\s+VALUES`)
+	selectColumnsPattern = regexp.MustCompile(`(?s)SELECT\s+(.*?)\s+FROM orders`)
+)
+
+// inMemoryOrdersDatabase keeps the rows written by the order upsert and serves them to single-order
+// selects, so a test sees exactly the columns the repository writes and reads
+type inMemoryOrdersDatabase struct {
+	database.Database
+	rows map[interface{}]map[string]interface{}
+}
+
+func newInMemoryOrdersDatabase() *inMemoryOrdersDatabase {
+	return &inMemoryOrdersDatabase{rows: make(map[interface{}]map[string]interface{})}
+}
+
+type affectedRows int64
+
+func (r affectedRows) LastInsertId() (int64, error) { return 0, nil }
+func (r affectedRows) RowsAffected() (int64, error) { return int64(r), nil }
+
+func (d *inMemoryOrdersDatabase) ExecContext(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	match := insertColumnsPattern.FindStringSubmatch(query)
+	if match == nil {
+		return affectedRows(0), nil
+	}
+
+	row := make(map[string]interface{})
+	for i, column := range splitColumns(match[1]) {
+		row[column] = args[i]
+	}
+	d.rows[row["id"]] = row
+	return affectedRows(1), nil
+}
+
+func (d *inMemoryOrdersDatabase) Get(dest interface{}, query string, args ...interface{}) error {
+	row, exists := d.rows[args[0]]
+	if !exists {
+		return sql.ErrNoRows
+	}
+
+	selected := make(map[string]bool)
+	for _, column := range splitColumns(selectColumnsPattern.FindStringSubmatch(query)[1]) {
+		selected[column] = true
+	}
+
+	target := reflect.ValueOf(dest).Elem()
+	for i := 0; i < target.NumField(); i++ {
+		column := target.Type().Field(i).Tag.Get("db")
+		value := reflect.ValueOf(row[column])
+		if !selected[column] || !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+			continue
+		}
+		target.Field(i).Set(value)
+	}
+	return nil
+}
+
+func splitColumns(list string) []string {
+	columns := make([]string, 0)
+	for _, column := range strings.Split(list, ",") {
+		columns = append(columns, strings.TrimSpace(column))
+	}
+	return columns
+}
+
+func TestOrderRepository_FilledQuantityRoundTrip(t *testing.T) {
+	repo := NewOrderRepository(newInMemoryOrdersDatabase())
+	price := 150.0
+	order, err := domain.NewOrder("42", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price)
+	require.NoError(t, err)
+	require.NoError(t, order.RecordFill(4))
+
+	require.NoError(t, repo.Save(context.Background(), order))
+	loaded, err := repo.FindByID(context.Background(), order.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, loaded.FilledQuantity())
+	assert.Equal(t, 6.0, loaded.OpenQuantity())
+	// The reduce guard compares against the stored fills, not zero
+	assert.Error(t, loaded.ReduceQuantity(3))
+	assert.NoError(t, loaded.ReduceQuantity(5))
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/shared/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type PositionUpdatePublicationRepository struct {
+	db database.Database
+}
+
+func NewPositionUpdatePublicationRepository(db database.Database) repository.IPositionUpdatePublicationRepository {
+	return &PositionUpdatePublicationRepository{db: db}
+}
+
+func (r *PositionUpdatePublicationRepository) MarkPublished(ctx context.Context, orderID string, publishedAt time.Time) error {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO position_update_publications (order_id, published_at)
+		VALUES ($1, $2)
+		ON CONFLICT (order_id) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, orderUUID, publishedAt); err != nil {
+		return fmt.Errorf("failed to mark position update as published: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PositionUpdatePublicationRepository) IsPublished(ctx context.Context, orderID string) (bool, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return false, fmt.Errorf("invalid order ID format: %w", err)
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM position_update_publications WHERE order_id = $1)`
+
+	var published bool
+	if err := r.db.Get(&published, query, orderUUID); err != nil {
+		return false, fmt.Errorf("failed to check position update publication: %w", err)
+	}
+
+	return published, nil
+}
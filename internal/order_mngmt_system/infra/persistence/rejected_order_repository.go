@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type RejectedOrderRepository struct {
+	db database.Database
+}
+
+func NewRejectedOrderRepository(db database.Database) repository.IRejectedOrderRepository {
+	return &RejectedOrderRepository{db: db}
+}
+
+func (r *RejectedOrderRepository) Save(ctx context.Context, rejection *domain.RejectedOrder) error {
+	if rejection == nil {
+		return fmt.Errorf("rejected order cannot be nil")
+	}
+
+	if err := rejection.Validate(); err != nil {
+		return fmt.Errorf("invalid rejected order: %w", err)
+	}
+
+	if rejection.ID == "" {
+		rejection.ID = uuid.New().String()
+	}
+
+	rejectionUUID, err := uuid.Parse(rejection.ID)
+	if err != nil {
+		return fmt.Errorf("invalid rejection ID format: %w", err)
+	}
+
+	userID, err := dto.ParseUserIDFromString(rejection.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO rejected_orders (
+			id, user_id, symbol, order_side, order_type, quantity, price,
+			reason_code, reason_details, rejected_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		rejectionUUID, userID, rejection.Symbol, rejection.OrderSide, rejection.OrderType,
+		rejection.Quantity, rejection.Price, string(rejection.ReasonCode), rejection.ReasonDetails,
+		rejection.RejectedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save rejected order: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RejectedOrderRepository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error) {
+	userIDInt, err := dto.ParseUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, symbol, order_side, order_type, quantity, price,
+			   reason_code, reason_details, rejected_at
+		FROM rejected_orders
+		WHERE user_id = $1
+		ORDER BY rejected_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var rejectionDTOs []dto.RejectedOrderDTO
+	if err := r.db.Select(&rejectionDTOs, query, userIDInt, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to find rejected orders: %w", err)
+	}
+
+	rejections := make([]*domain.RejectedOrder, 0, len(rejectionDTOs))
+	for i := range rejectionDTOs {
+		rejections = append(rejections, rejectionDTOs[i].ToDomain())
+	}
+
+	return rejections, nil
+}
+
+func (r *RejectedOrderRepository) CountByReason(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error) {
+	query := `
+		SELECT reason_code, COUNT(*) AS count
+		FROM rejected_orders
+		WHERE rejected_at >= $1 AND rejected_at < $2
+		GROUP BY reason_code
+		ORDER BY count DESC, reason_code`
+
+	var countDTOs []dto.RejectReasonCountDTO
+	if err := r.db.Select(&countDTOs, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to count rejected orders by reason: %w", err)
+	}
+
+	counts := make([]domain.RejectReasonCount, 0, len(countDTOs))
+	for _, countDTO := range countDTOs {
+		counts = append(counts, domain.RejectReasonCount{
+			ReasonCode: domain.RejectReason(countDTO.ReasonCode),
+			Count:      countDTO.Count,
+		})
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+)
+
+type UserOrderPreferencesRepository struct {
+	db database.Database
+}
+
+func NewUserOrderPreferencesRepository(db database.Database) repository.IUserOrderPreferencesRepository {
+	return &UserOrderPreferencesRepository{db: db}
+}
+
+func (r *UserOrderPreferencesRepository) Save(ctx context.Context, preferences *domain.UserOrderPreferences) error {
+	if preferences == nil {
+		return fmt.Errorf("user order preferences cannot be nil")
+	}
+
+	if err := preferences.Validate(); err != nil {
+		return fmt.Errorf("invalid user order preferences: %w", err)
+	}
+
+	userID, err := dto.ParseUserIDFromString(preferences.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_order_preferences (
+			user_id, default_order_type, default_time_in_force, allow_partial_fill,
+			protective_stop_enabled, protective_stop_percent, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			default_order_type = EXCLUDED.default_order_type,
+			default_time_in_force = EXCLUDED.default_time_in_force,
+			allow_partial_fill = EXCLUDED.allow_partial_fill,
+			protective_stop_enabled = EXCLUDED.protective_stop_enabled,
+			protective_stop_percent = EXCLUDED.protective_stop_percent,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err = r.db.ExecContext(ctx, query,
+		userID, nullableString(preferences.DefaultOrderType.String()),
+		nullableString(preferences.DefaultTimeInForce.String()),
+		preferences.AllowPartialFill, preferences.ProtectiveStopEnabled,
+		nullableFloat(preferences.ProtectiveStopPercent), preferences.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save user order preferences: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserOrderPreferencesRepository) FindByUserID(ctx context.Context, userID string) (*domain.UserOrderPreferences, error) {
+	id, err := dto.ParseUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		SELECT user_id, default_order_type, default_time_in_force, allow_partial_fill,
+			   protective_stop_enabled, protective_stop_percent, updated_at
+		FROM user_order_preferences
+		WHERE user_id = $1`
+
+	var preferencesDTO dto.UserOrderPreferencesDTO
+	if err := r.db.Get(&preferencesDTO, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find user order preferences: %w", err)
+	}
+
+	return preferencesDTO.ToDomain()
+}
+
+// nullableString stores unset defaults as NULL
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// nullableFloat stores an unset percent as NULL
+func nullableFloat(value float64) *float64 {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
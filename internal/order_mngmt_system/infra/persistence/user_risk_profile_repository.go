@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"HubInvestments/internal/order_mngmt_system/domain/repository"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/internal/order_mngmt_system/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+)
+
+type UserRiskProfileRepository struct {
+	db database.Database
+}
+
+func NewUserRiskProfileRepository(db database.Database) repository.IUserRiskProfileRepository {
+	return &UserRiskProfileRepository{db: db}
+}
+
+func (r *UserRiskProfileRepository) FindByUserID(ctx context.Context, userID string) (*service.UserRiskProfile, error) {
+	id, err := dto.ParseUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		SELECT user_id, risk_tolerance, max_order_value, max_daily_trading_value,
+			max_position_size, is_high_risk_approved, updated_at
+		FROM user_risk_profiles
+		WHERE user_id = $1`
+
+	var profileDTO dto.UserRiskProfileDTO
+	if err := r.db.Get(&profileDTO, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find user risk profile: %w", err)
+	}
+
+	return profileDTO.ToDomain()
+}
+
+// SaveWithAudit upserts the profile and inserts the audit entry in one transaction, so a profile
+// is never changed without a record of the change
+func (r *UserRiskProfileRepository) SaveWithAudit(ctx context.Context, profile *service.UserRiskProfile, entry *service.RiskProfileAuditEntry) error {
+	if profile == nil || entry == nil {
+		return fmt.Errorf("risk profile and audit entry cannot be nil")
+	}
+
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("invalid risk profile: %w", err)
+	}
+
+	userID, err := dto.ParseUserIDFromString(profile.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	changedBy, err := dto.ParseUserIDFromString(entry.ChangedBy)
+	if err != nil {
+		return fmt.Errorf("invalid changed by user ID format: %w", err)
+	}
+
+	previous, err := dto.MarshalRiskProfileSnapshot(entry.Previous)
+	if err != nil {
+		return fmt.Errorf("failed to encode previous profile: %w", err)
+	}
+	updated, err := dto.MarshalRiskProfileSnapshot(&entry.Updated)
+	if err != nil {
+		return fmt.Errorf("failed to encode updated profile: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	profileQuery := `
+		INSERT INTO user_risk_profiles (
+			user_id, risk_tolerance, max_order_value, max_daily_trading_value,
+			max_position_size, is_high_risk_approved, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			risk_tolerance = EXCLUDED.risk_tolerance,
+			max_order_value = EXCLUDED.max_order_value,
+			max_daily_trading_value = EXCLUDED.max_daily_trading_value,
+			max_position_size = EXCLUDED.max_position_size,
+			is_high_risk_approved = EXCLUDED.is_high_risk_approved,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := tx.ExecContext(ctx, profileQuery,
+		userID, profile.RiskTolerance.String(), profile.MaxOrderValue, profile.MaxDailyTradingValue,
+		profile.MaxPositionSize, profile.IsHighRiskApproved, profile.ProfileLastUpdated); err != nil {
+		return fmt.Errorf("failed to save user risk profile: %w", err)
+	}
+
+	auditQuery := `
+		INSERT INTO user_risk_profile_audit (
+			id, user_id, changed_by, reason, previous_profile, updated_profile, changed_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)`
+
+	if _, err := tx.ExecContext(ctx, auditQuery,
+		entry.ID, userID, changedBy, entry.Reason, previous, updated, entry.ChangedAt); err != nil {
+		return fmt.Errorf("failed to save risk profile audit entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit risk profile change: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRiskProfileRepository) FindAuditByUserID(ctx context.Context, userID string) ([]*service.RiskProfileAuditEntry, error) {
+	id, err := dto.ParseUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, changed_by, reason, previous_profile, updated_profile, changed_at
+		FROM user_risk_profile_audit
+		WHERE user_id = $1
+		ORDER BY changed_at DESC`
+
+	var auditDTOs []dto.RiskProfileAuditDTO
+	if err := r.db.Select(&auditDTOs, query, id); err != nil {
+		return nil, fmt.Errorf("failed to find risk profile audit entries: %w", err)
+	}
+
+	entries := make([]*service.RiskProfileAuditEntry, 0, len(auditDTOs))
+	for i := range auditDTOs {
+		entry, err := auditDTOs[i].ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,158 @@
+package session
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+)
+
+// IDisconnectOrderCanceller cancels a user's eligible orders after their session dropped (dependency inversion)
+type IDisconnectOrderCanceller interface {
+	CancelOrdersOnDisconnect(ctx context.Context, userID string) (*usecase.BatchCancellationResult, error)
+}
+
+type CancelOnDisconnectConfig struct {
+	GracePeriod   time.Duration // How long a user may stay disconnected before their orders are cancelled
+	CancelTimeout time.Duration // Upper bound for cancelling the user's orders
+}
+
+func DefaultCancelOnDisconnectConfig() *CancelOnDisconnectConfig {
+	return &CancelOnDisconnectConfig{
+		GracePeriod:   10 * time.Second, // Rides out brief network drops and client restarts
+		CancelTimeout: 30 * time.Second,
+	}
+}
+
+// CancelOnDisconnectMonitor tracks the control connections of clients that opted in to
+// cancel-on-disconnect. When a user's last connection closes, their eligible orders are
+// cancelled once the grace period passes without the user reconnecting.
+type CancelOnDisconnectMonitor struct {
+	canceller IDisconnectOrderCanceller
+	config    *CancelOnDisconnectConfig
+	mutex     sync.Mutex
+	sessions  map[string]*userSessions
+}
+
+type userSessions struct {
+	connections map[string]bool // connection ID -> opted in to cancel-on-disconnect
+	pending     *time.Timer
+	generation  uint64 // identifies the pending timer so a stale one never fires
+}
+
+func NewCancelOnDisconnectMonitor(canceller IDisconnectOrderCanceller, config *CancelOnDisconnectConfig) *CancelOnDisconnectMonitor {
+	if config == nil {
+		config = DefaultCancelOnDisconnectConfig()
+	}
+
+	return &CancelOnDisconnectMonitor{
+		canceller: canceller,
+		config:    config,
+		sessions:  make(map[string]*userSessions),
+	}
+}
+
+// Connect records a new control connection. Any cancellation pending for the user is called off.
+func (m *CancelOnDisconnectMonitor) Connect(userID, connectionID string, cancelOnDisconnect bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sessions, exists := m.sessions[userID]
+	if !exists {
+		sessions = &userSessions{connections: make(map[string]bool)}
+		m.sessions[userID] = sessions
+	}
+
+	if sessions.pending != nil {
+		sessions.pending.Stop()
+		sessions.pending = nil
+		log.Printf("User %s reconnected within the grace period; keeping their orders", userID)
+	}
+
+	sessions.connections[connectionID] = cancelOnDisconnect
+}
+
+// Disconnect records a closed control connection. When it was the user's last connection and
+// it opted in, the user's orders are cancelled after the grace period.
+func (m *CancelOnDisconnectMonitor) Disconnect(userID, connectionID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sessions, exists := m.sessions[userID]
+	if !exists {
+		return
+	}
+
+	cancelOnDisconnect, exists := sessions.connections[connectionID]
+	if !exists {
+		return
+	}
+	delete(sessions.connections, connectionID)
+
+	if len(sessions.connections) > 0 {
+		return
+	}
+
+	if !cancelOnDisconnect {
+		delete(m.sessions, userID)
+		return
+	}
+
+	sessions.generation++
+	generation := sessions.generation
+	sessions.pending = time.AfterFunc(m.config.GracePeriod, func() {
+		m.cancelOrders(userID, generation)
+	})
+}
+
+// PendingCancellations returns the number of users waiting out their grace period
+func (m *CancelOnDisconnectMonitor) PendingCancellations() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	pending := 0
+	for _, sessions := range m.sessions {
+		if sessions.pending != nil {
+			pending++
+		}
+	}
+	return pending
+}
+
+// Close stops all pending cancellations
+func (m *CancelOnDisconnectMonitor) Close() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for userID, sessions := range m.sessions {
+		if sessions.pending != nil {
+			sessions.pending.Stop()
+		}
+		delete(m.sessions, userID)
+	}
+}
+
+func (m *CancelOnDisconnectMonitor) cancelOrders(userID string, generation uint64) {
+	m.mutex.Lock()
+	sessions, exists := m.sessions[userID]
+	// A reconnect that raced with the timer already called this cancellation off
+	if !exists || sessions.pending == nil || sessions.generation != generation {
+		m.mutex.Unlock()
+		return
+	}
+	delete(m.sessions, userID)
+	m.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.config.CancelTimeout)
+	defer cancel()
+
+	result, err := m.canceller.CancelOrdersOnDisconnect(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to cancel orders for disconnected user %s: %v", userID, err)
+		return
+	}
+
+	log.Printf("Cancel-on-disconnect for user %s: %s", userID, result.GetSummary())
+}
@@ -0,0 +1,111 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+)
+
+type FakeDisconnectOrderCanceller struct {
+	cancelled chan string
+}
+
+func NewFakeDisconnectOrderCanceller() *FakeDisconnectOrderCanceller {
+	return &FakeDisconnectOrderCanceller{cancelled: make(chan string, 10)}
+}
+
+func (c *FakeDisconnectOrderCanceller) CancelOrdersOnDisconnect(ctx context.Context, userID string) (*usecase.BatchCancellationResult, error) {
+	c.cancelled <- userID
+	return &usecase.BatchCancellationResult{TotalOrders: 1, CancelledOrders: 1}, nil
+}
+
+func newTestMonitor(canceller IDisconnectOrderCanceller, gracePeriod time.Duration) *CancelOnDisconnectMonitor {
+	return NewCancelOnDisconnectMonitor(canceller, &CancelOnDisconnectConfig{
+		GracePeriod:   gracePeriod,
+		CancelTimeout: time.Second,
+	})
+}
+
+func TestCancelOnDisconnectMonitor_CancelsAfterGracePeriod(t *testing.T) {
+	// Arrange
+	canceller := NewFakeDisconnectOrderCanceller()
+	monitor := newTestMonitor(canceller, 20*time.Millisecond)
+	defer monitor.Close()
+
+	monitor.Connect("user123", "conn-1", true)
+
+	// Act
+	monitor.Disconnect("user123", "conn-1")
+
+	// Assert
+	select {
+	case userID := <-canceller.cancelled:
+		if userID != "user123" {
+			t.Errorf("Expected orders of user123 to be cancelled, got %s", userID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected orders to be cancelled after the grace period")
+	}
+
+	if monitor.PendingCancellations() != 0 {
+		t.Errorf("Expected no pending cancellations, got %d", monitor.PendingCancellations())
+	}
+}
+
+func TestCancelOnDisconnectMonitor_QuickReconnectKeepsOrders(t *testing.T) {
+	// Arrange
+	canceller := NewFakeDisconnectOrderCanceller()
+	monitor := newTestMonitor(canceller, 100*time.Millisecond)
+	defer monitor.Close()
+
+	monitor.Connect("user123", "conn-1", true)
+
+	// Act
+	monitor.Disconnect("user123", "conn-1")
+	if monitor.PendingCancellations() != 1 {
+		t.Fatalf("Expected 1 pending cancellation, got %d", monitor.PendingCancellations())
+	}
+	monitor.Connect("user123", "conn-2", true)
+
+	// Assert
+	select {
+	case userID := <-canceller.cancelled:
+		t.Fatalf("Expected no cancellation after a quick reconnect, got one for %s", userID)
+	case <-time.After(250 * time.Millisecond):
+	}
+
+	if monitor.PendingCancellations() != 0 {
+		t.Errorf("Expected no pending cancellations, got %d", monitor.PendingCancellations())
+	}
+}
+
+func TestCancelOnDisconnectMonitor_OnlyLastConnectionTriggers(t *testing.T) {
+	canceller := NewFakeDisconnectOrderCanceller()
+	monitor := newTestMonitor(canceller, 10*time.Millisecond)
+	defer monitor.Close()
+
+	monitor.Connect("user123", "conn-1", true)
+	monitor.Connect("user123", "conn-2", true)
+	monitor.Disconnect("user123", "conn-1")
+
+	if monitor.PendingCancellations() != 0 {
+		t.Errorf("Expected no pending cancellation while another connection is open, got %d", monitor.PendingCancellations())
+	}
+}
+
+func TestCancelOnDisconnectMonitor_IgnoresConnectionsThatDidNotOptIn(t *testing.T) {
+	canceller := NewFakeDisconnectOrderCanceller()
+	monitor := newTestMonitor(canceller, 10*time.Millisecond)
+	defer monitor.Close()
+
+	monitor.Connect("user123", "conn-1", false)
+	monitor.Disconnect("user123", "conn-1")
+
+	select {
+	case userID := <-canceller.cancelled:
+		t.Fatalf("Expected no cancellation without opt-in, got one for %s", userID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,257 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderWebhookEventSubmitted = "order.submitted"
+	OrderWebhookEventExecuted  = "order.executed"
+
+	SignatureHeader = "X-Hub-Signature-256"
+	EventHeader     = "X-Hub-Event"
+	DeliveryHeader  = "X-Hub-Delivery"
+)
+
+// IOrderWebhookDispatcher notifies external systems about order events.
+// Dispatching never blocks or fails order processing; deliveries happen in the background.
+type IOrderWebhookDispatcher interface {
+	DispatchOrderEvent(ctx context.Context, event string, order *domain.Order)
+}
+
+// IWebhookSubscriptionStore defines the interface for reading webhook subscriptions (dependency inversion)
+type IWebhookSubscriptionStore interface {
+	FindSubscriptions(ctx context.Context, userID string) ([]WebhookSubscription, error)
+}
+
+// IHTTPClient defines the interface for sending webhook requests (dependency inversion)
+type IHTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookSubscription is an external integration's endpoint for order events.
+// An empty UserID subscribes the integration to every account.
+type WebhookSubscription struct {
+	ID     string
+	UserID string
+	URL    string
+	Secret string
+	Events []string
+}
+
+// Matches reports whether the subscription wants the event for the account
+func (s WebhookSubscription) Matches(userID, event string) bool {
+	if s.UserID != "" && s.UserID != userID {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range s.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// InMemoryWebhookSubscriptionStore keeps webhook subscriptions registered at startup
+type InMemoryWebhookSubscriptionStore struct {
+	mu            sync.RWMutex
+	subscriptions []WebhookSubscription
+}
+
+func NewInMemoryWebhookSubscriptionStore(subscriptions ...WebhookSubscription) *InMemoryWebhookSubscriptionStore {
+	return &InMemoryWebhookSubscriptionStore{subscriptions: subscriptions}
+}
+
+// Register adds a subscription to the store
+func (s *InMemoryWebhookSubscriptionStore) Register(subscription WebhookSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscriptions = append(s.subscriptions, subscription)
+}
+
+// FindSubscriptions returns the subscriptions that apply to the account
+func (s *InMemoryWebhookSubscriptionStore) FindSubscriptions(ctx context.Context, userID string) ([]WebhookSubscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]WebhookSubscription, 0)
+	for _, subscription := range s.subscriptions {
+		if subscription.UserID == "" || subscription.UserID == userID {
+			result = append(result, subscription)
+		}
+	}
+	return result, nil
+}
+
+// OrderWebhookPayload is the JSON body delivered to subscribers
+type OrderWebhookPayload struct {
+	DeliveryID     string    `json:"delivery_id"`
+	Event          string    `json:"event"`
+	OrderID        string    `json:"order_id"`
+	UserID         string    `json:"user_id"`
+	Symbol         string    `json:"symbol"`
+	OrderSide      string    `json:"order_side"`
+	OrderType      string    `json:"order_type"`
+	Quantity       float64   `json:"quantity"`
+	Price          *float64  `json:"price,omitempty"`
+	ExecutionPrice *float64  `json:"execution_price,omitempty"`
+	Status         string    `json:"status"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+type OrderWebhookDispatcherConfig struct {
+	MaxRetries     int           // Additional attempts after the first delivery fails
+	RetryBackoff   time.Duration // Base delay between attempts, multiplied by the attempt number
+	RequestTimeout time.Duration // Timeout for a single delivery attempt
+}
+
+func DefaultOrderWebhookDispatcherConfig() OrderWebhookDispatcherConfig {
+	return OrderWebhookDispatcherConfig{
+		MaxRetries:     3,
+		RetryBackoff:   2 * time.Second,
+		RequestTimeout: 5 * time.Second,
+	}
+}
+
+type OrderWebhookDispatcher struct {
+	store      IWebhookSubscriptionStore
+	httpClient IHTTPClient
+	config     OrderWebhookDispatcherConfig
+	wg         sync.WaitGroup
+}
+
+func NewOrderWebhookDispatcher(store IWebhookSubscriptionStore, httpClient IHTTPClient, config OrderWebhookDispatcherConfig) *OrderWebhookDispatcher {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &OrderWebhookDispatcher{
+		store:      store,
+		httpClient: httpClient,
+		config:     config,
+	}
+}
+
+// DispatchOrderEvent delivers the event to every matching subscription in the background
+func (d *OrderWebhookDispatcher) DispatchOrderEvent(ctx context.Context, event string, order *domain.Order) {
+	subscriptions, err := d.store.FindSubscriptions(ctx, order.UserID())
+	if err != nil {
+		log.Printf("Webhook dispatcher: failed to load subscriptions for user %s: %v", order.UserID(), err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(order.UserID(), event) {
+			continue
+		}
+
+		payload := newOrderWebhookPayload(event, order)
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("Webhook dispatcher: failed to marshal %s payload for order %s: %v", event, order.ID(), err)
+			continue
+		}
+
+		d.wg.Add(1)
+		go func(subscription WebhookSubscription) {
+			defer d.wg.Done()
+			if err := d.deliver(subscription, event, payload.DeliveryID, body); err != nil {
+				log.Printf("Webhook dispatcher: giving up on %s for order %s to subscription %s: %v",
+					event, order.ID(), subscription.ID, err)
+			}
+		}(subscription)
+	}
+}
+
+// Wait blocks until all in-flight deliveries have finished
+func (d *OrderWebhookDispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// deliver posts the payload, retrying network errors and 5xx/429 responses
+func (d *OrderWebhookDispatcher) deliver(subscription WebhookSubscription, event, deliveryID string, body []byte) error {
+	var lastErr error
+	for attempt := 0; attempt <= d.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(d.config.RetryBackoff * time.Duration(attempt))
+		}
+
+		retryable, err := d.send(subscription, event, deliveryID, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return lastErr
+}
+
+func (d *OrderWebhookDispatcher) send(subscription WebhookSubscription, event, deliveryID string, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, SignPayload(subscription.Secret, body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+}
+
+// SignPayload returns the HMAC-SHA256 signature subscribers use to verify a delivery
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newOrderWebhookPayload(event string, order *domain.Order) OrderWebhookPayload {
+	return OrderWebhookPayload{
+		DeliveryID:     uuid.New().String(),
+		Event:          event,
+		OrderID:        order.ID(),
+		UserID:         order.UserID(),
+		Symbol:         order.Symbol(),
+		OrderSide:      order.OrderSide().String(),
+		OrderType:      order.OrderType().String(),
+		Quantity:       order.Quantity(),
+		Price:          order.Price(),
+		ExecutionPrice: order.ExecutionPrice(),
+		Status:         order.Status().String(),
+		OccurredAt:     time.Now(),
+	}
+}
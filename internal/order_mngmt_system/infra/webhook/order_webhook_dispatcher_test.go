@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+func testDispatcherConfig() OrderWebhookDispatcherConfig {
+	return OrderWebhookDispatcherConfig{
+		MaxRetries:     3,
+		RetryBackoff:   time.Millisecond,
+		RequestTimeout: time.Second,
+	}
+}
+
+func newTestOrder(t *testing.T) *domain.Order {
+	price := 25.0
+	order, err := domain.NewOrder("user-1", "PETR4", domain.OrderSideBuy, domain.OrderTypeLimit, 100, &price)
+	assert.NoError(t, err)
+	return order
+}
+
+func TestOrderWebhookDispatcher_DispatchOrderEvent_SendsSignedWebhook(t *testing.T) {
+	var received []byte
+	var signature, event string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		event = r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryWebhookSubscriptionStore(WebhookSubscription{
+		ID: "erp", UserID: "user-1", URL: server.URL, Secret: "s3cret", Events: []string{OrderWebhookEventSubmitted},
+	})
+	dispatcher := NewOrderWebhookDispatcher(store, server.Client(), testDispatcherConfig())
+	order := newTestOrder(t)
+
+	dispatcher.DispatchOrderEvent(context.Background(), OrderWebhookEventSubmitted, order)
+	dispatcher.Wait()
+
+	assert.Equal(t, OrderWebhookEventSubmitted, event)
+	assert.Equal(t, SignPayload("s3cret", received), signature)
+
+	var payload OrderWebhookPayload
+	assert.NoError(t, json.Unmarshal(received, &payload))
+	assert.Equal(t, order.ID(), payload.OrderID)
+	assert.Equal(t, "PETR4", payload.Symbol)
+}
+
+func TestOrderWebhookDispatcher_DispatchOrderEvent_RetriesServerErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryWebhookSubscriptionStore(WebhookSubscription{ID: "oms", URL: server.URL, Secret: "key"})
+	dispatcher := NewOrderWebhookDispatcher(store, server.Client(), testDispatcherConfig())
+
+	dispatcher.DispatchOrderEvent(context.Background(), OrderWebhookEventExecuted, newTestOrder(t))
+	dispatcher.Wait()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestOrderWebhookDispatcher_DispatchOrderEvent_DoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryWebhookSubscriptionStore(WebhookSubscription{ID: "oms", URL: server.URL})
+	dispatcher := NewOrderWebhookDispatcher(store, server.Client(), testDispatcherConfig())
+
+	dispatcher.DispatchOrderEvent(context.Background(), OrderWebhookEventSubmitted, newTestOrder(t))
+	dispatcher.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestWebhookSubscription_Matches(t *testing.T) {
+	subscription := WebhookSubscription{UserID: "user-1", Events: []string{OrderWebhookEventExecuted}}
+
+	assert.True(t, subscription.Matches("user-1", OrderWebhookEventExecuted))
+	assert.False(t, subscription.Matches("user-1", OrderWebhookEventSubmitted))
+	assert.False(t, subscription.Matches("user-2", OrderWebhookEventExecuted))
+	assert.True(t, WebhookSubscription{}.Matches("anyone", OrderWebhookEventSubmitted))
+}
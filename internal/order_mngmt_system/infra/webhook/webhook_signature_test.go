@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature_CorrectlySignedPayload(t *testing.T) {
+	body := []byte(`{"event":"order.executed","order_id":"order-1","quantity":100}`)
+
+	err := VerifySignature("s3cret", body, SignPayload("s3cret", body))
+
+	assert.NoError(t, err)
+}
+
+func TestVerifySignature_TamperedPayload(t *testing.T) {
+	body := []byte(`{"event":"order.executed","order_id":"order-1","quantity":100}`)
+	signature := SignPayload("s3cret", body)
+	tampered := []byte(`{"event":"order.executed","order_id":"order-1","quantity":900}`)
+
+	err := VerifySignature("s3cret", tampered, signature)
+
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerifySignature_WrongSecretAndMissingSignature(t *testing.T) {
+	body := []byte(`{"event":"order.submitted"}`)
+
+	assert.ErrorIs(t, VerifySignature("other", body, SignPayload("s3cret", body)), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature("s3cret", body, ""), ErrMissingSignature)
+}
+
+func TestSignatureVerifier_AcceptsAnyConfiguredSecretDuringRotation(t *testing.T) {
+	body := []byte(`{"event":"order.submitted"}`)
+	verifier := NewSignatureVerifier(SignatureVerifierConfig{Secrets: []string{"new-secret", "old-secret"}, MaxBodyBytes: 1024})
+
+	assert.NoError(t, verifier.Verify(body, SignPayload("old-secret", body)))
+	assert.NoError(t, verifier.Verify(body, SignPayload("new-secret", body)))
+	assert.ErrorIs(t, verifier.Verify(body, SignPayload("retired-secret", body)), ErrInvalidSignature)
+}
+
+func TestSignatureVerifier_VerifyRequest(t *testing.T) {
+	body := []byte(`{"event":"order.submitted","order_id":"order-1"}`)
+	verifier := NewSignatureVerifier(DefaultSignatureVerifierConfig("s3cret"))
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/orders", bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, SignPayload("s3cret", body))
+
+	verified, err := verifier.VerifyRequest(req)
+	assert.NoError(t, err)
+	assert.Equal(t, body, verified)
+
+	// The body stays readable for the handler
+	restored, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, body, restored)
+
+	tampered := httptest.NewRequest(http.MethodPost, "/hooks/orders", strings.NewReader(`{"event":"order.submitted","order_id":"order-2"}`))
+	tampered.Header.Set(SignatureHeader, SignPayload("s3cret", body))
+	_, err = verifier.VerifyRequest(tampered)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSignatureVerifier_RejectsOversizedBody(t *testing.T) {
+	body := bytes.Repeat([]byte("a"), 64)
+	verifier := NewSignatureVerifier(SignatureVerifierConfig{Secrets: []string{"s3cret"}, MaxBodyBytes: 32})
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/orders", bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, SignPayload("s3cret", body))
+
+	_, err := verifier.VerifyRequest(req)
+	assert.Error(t, err)
+}
+
+func TestSignatureVerifier_VerifiesDispatchedDelivery(t *testing.T) {
+	verifier := NewSignatureVerifier(DefaultSignatureVerifierConfig("s3cret"))
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, verifyErr = verifier.VerifyRequest(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryWebhookSubscriptionStore(WebhookSubscription{ID: "sub-1", URL: server.URL, Secret: "s3cret"})
+	dispatcher := NewOrderWebhookDispatcher(store, server.Client(), testDispatcherConfig())
+
+	dispatcher.DispatchOrderEvent(context.Background(), OrderWebhookEventSubmitted, newTestOrder(t))
+	dispatcher.Wait()
+
+	assert.NoError(t, verifyErr)
+}
@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// IOpenOrderSource lists orders by status (dependency inversion)
+type IOpenOrderSource interface {
+	FindByStatus(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error)
+}
+
+// IExpiredOrderCanceller cancels one batch of orders whose scheduled expiry has passed
+type IExpiredOrderCanceller interface {
+	CancelExpiredOrders(ctx context.Context, expirationTime time.Time) (*usecase.BatchCancellationResult, error)
+}
+
+type OrderExpiryJobConfig struct {
+	SweepInterval     time.Duration // How often one batch of due orders is cancelled
+	ScheduleInterval  time.Duration // How often open orders are loaded and scheduled for expiry
+	RunTimeout        time.Duration // Maximum time for a single schedule or sweep pass
+	MarketCloseHour   int           // Local time at which DAY orders expire
+	MarketCloseMinute int
+	Location          *time.Location // Timezone of the market close
+}
+
+func DefaultOrderExpiryJobConfig() *OrderExpiryJobConfig {
+	return &OrderExpiryJobConfig{
+		SweepInterval:     5 * time.Second, // Small batches every few seconds instead of one spike at the close
+		ScheduleInterval:  time.Minute,
+		RunTimeout:        time.Minute,
+		MarketCloseHour:   16,
+		MarketCloseMinute: 0,
+		Location:          time.UTC,
+	}
+}
+
+// OrderExpiryJob expires open DAY orders at the market close of their trading day. Each order's
+// expiry goes through the expiry scheduler, which jitters it and hands due orders out in batches,
+// so orders sharing the close do not all expire in the same sweep.
+type OrderExpiryJob struct {
+	orders    IOpenOrderSource
+	scheduler service.OrderExpiryScheduler
+	canceller IExpiredOrderCanceller
+	calendar  ITradingCalendar
+	config    *OrderExpiryJobConfig
+	now       func() time.Time
+}
+
+func NewOrderExpiryJob(
+	orders IOpenOrderSource,
+	scheduler service.OrderExpiryScheduler,
+	canceller IExpiredOrderCanceller,
+	calendar ITradingCalendar,
+	config *OrderExpiryJobConfig,
+) *OrderExpiryJob {
+	if config == nil {
+		config = DefaultOrderExpiryJobConfig()
+	}
+
+	return &OrderExpiryJob{
+		orders:    orders,
+		scheduler: scheduler,
+		canceller: canceller,
+		calendar:  calendar,
+		config:    config,
+		now:       time.Now,
+	}
+}
+
+// Start schedules open orders and sweeps due ones until the context is cancelled
+func (j *OrderExpiryJob) Start(ctx context.Context) {
+	j.scheduleWithTimeout(ctx)
+
+	scheduleTicker := time.NewTicker(j.config.ScheduleInterval)
+	defer scheduleTicker.Stop()
+	sweepTicker := time.NewTicker(j.config.SweepInterval)
+	defer sweepTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-scheduleTicker.C:
+			j.scheduleWithTimeout(ctx)
+		case <-sweepTicker.C:
+			runCtx, cancel := context.WithTimeout(ctx, j.config.RunTimeout)
+			result, err := j.canceller.CancelExpiredOrders(runCtx, j.now())
+			cancel()
+			if err != nil {
+				log.Printf("Order expiry sweep failed: %v", err)
+				continue
+			}
+			if result.TotalOrders > 0 {
+				log.Printf("Order expiry sweep: expired=%d failed=%d", result.CancelledOrders, result.FailedOrders)
+			}
+		}
+	}
+}
+
+func (j *OrderExpiryJob) scheduleWithTimeout(ctx context.Context) {
+	runCtx, cancel := context.WithTimeout(ctx, j.config.RunTimeout)
+	defer cancel()
+
+	if _, err := j.ScheduleOpenOrders(runCtx); err != nil {
+		log.Printf("Order expiry scheduling failed: %v", err)
+	}
+}
+
+// ScheduleOpenOrders schedules every open DAY order for expiry and returns how many were scheduled.
+// Scheduling an order again keeps its expiry, so orders whose expiry failed are retried.
+func (j *OrderExpiryJob) ScheduleOpenOrders(ctx context.Context) (int, error) {
+	scheduled := 0
+	for _, status := range []domain.OrderStatus{domain.OrderStatusPending, domain.OrderStatusProcessing} {
+		orders, err := j.orders.FindByStatus(ctx, status)
+		if err != nil {
+			return scheduled, fmt.Errorf("failed to load %s orders: %w", status, err)
+		}
+
+		for _, order := range orders {
+			if order.TimeInForce() != domain.TimeInForceDay {
+				continue
+			}
+			j.scheduler.Schedule(order.ID(), j.expiryDeadline(order.CreatedAt()))
+			scheduled++
+		}
+	}
+	return scheduled, nil
+}
+
+// expiryDeadline returns the first market close at or after the order was placed on a trading day.
+// Orders placed after the close or on a non-trading day expire at the next trading day's close.
+func (j *OrderExpiryJob) expiryDeadline(placedAt time.Time) time.Time {
+	local := placedAt.In(j.config.Location)
+	marketClose := time.Date(local.Year(), local.Month(), local.Day(),
+		j.config.MarketCloseHour, j.config.MarketCloseMinute, 0, 0, j.config.Location)
+
+	// Bounded, so a calendar without trading days cannot loop forever
+	for i := 0; i < 14; i++ {
+		if j.calendar.IsTradingDay(marketClose) && placedAt.Before(marketClose) {
+			break
+		}
+		marketClose = marketClose.AddDate(0, 0, 1)
+	}
+	return marketClose
+}
@@ -0,0 +1,131 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type InMemoryOpenOrderSource struct {
+	orders []*domain.Order
+}
+
+func (s *InMemoryOpenOrderSource) FindByStatus(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+	result := make([]*domain.Order, 0)
+	for _, order := range s.orders {
+		if order.Status() == status {
+			result = append(result, order)
+		}
+	}
+	return result, nil
+}
+
+// SchedulerExpiredOrderCanceller cancels whatever the scheduler hands out, recording each sweep's batch
+type SchedulerExpiredOrderCanceller struct {
+	scheduler service.OrderExpiryScheduler
+	batches   [][]string
+}
+
+func (c *SchedulerExpiredOrderCanceller) CancelExpiredOrders(ctx context.Context, expirationTime time.Time) (*usecase.BatchCancellationResult, error) {
+	due := c.scheduler.NextBatch(expirationTime)
+	c.batches = append(c.batches, due)
+	return &usecase.BatchCancellationResult{TotalOrders: len(due), CancelledOrders: len(due)}, nil
+}
+
+func newOpenDayOrder(t *testing.T, id string, placedAt time.Time, timeInForce domain.TimeInForce) *domain.Order {
+	price := 150.0
+	order := domain.NewOrderFromRepository(id, "42", "AAPL", domain.OrderSideBuy, domain.OrderTypeLimit, 10, &price,
+		domain.OrderStatusPending, placedAt, placedAt, nil, nil, nil, nil)
+	if err := order.SetExecutionPreferences(timeInForce, true); err != nil {
+		t.Fatalf("failed to set time in force: %v", err)
+	}
+	return order
+}
+
+func newOrderExpiryTestJob(orders []*domain.Order, holidays map[string]bool) (*OrderExpiryJob, *SchedulerExpiredOrderCanceller) {
+	scheduler := service.NewOrderExpiryScheduler(service.OrderExpirySchedulerConfig{
+		MaxJitter:      30 * time.Second,
+		SweepBatchSize: 5,
+	})
+	canceller := &SchedulerExpiredOrderCanceller{scheduler: scheduler}
+	job := NewOrderExpiryJob(&InMemoryOpenOrderSource{orders: orders}, scheduler, canceller,
+		&HolidayTradingCalendar{Holidays: holidays}, nil)
+	return job, canceller
+}
+
+func TestOrderExpiryJob_ExpiresDayOrdersAtCloseInBatches(t *testing.T) {
+	// Friday
+	placedAt := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	marketClose := time.Date(2024, 3, 15, 16, 0, 0, 0, time.UTC)
+
+	orders := make([]*domain.Order, 0)
+	for i := 0; i < 20; i++ {
+		orders = append(orders, newOpenDayOrder(t, fmt.Sprintf("day-%02d", i), placedAt, domain.TimeInForceDay))
+	}
+	orders = append(orders, newOpenDayOrder(t, "gtc", placedAt, domain.TimeInForceGTC))
+	job, canceller := newOrderExpiryTestJob(orders, nil)
+
+	scheduled, err := job.ScheduleOpenOrders(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if scheduled != 20 {
+		t.Fatalf("Expected only the 20 DAY orders to be scheduled, got %d", scheduled)
+	}
+
+	result, _ := canceller.CancelExpiredOrders(context.Background(), marketClose.Add(-time.Second))
+	if result.TotalOrders != 0 {
+		t.Errorf("Expected nothing to expire before the close, got %d", result.TotalOrders)
+	}
+
+	expired := 0
+	for sweep := 0; sweep < 10; sweep++ {
+		result, _ := canceller.CancelExpiredOrders(context.Background(), marketClose.Add(30*time.Second))
+		if result.TotalOrders > 5 {
+			t.Errorf("Expected at most 5 orders per sweep, got %d", result.TotalOrders)
+		}
+		expired += result.TotalOrders
+	}
+	if expired != 20 {
+		t.Errorf("Expected all 20 DAY orders to expire within the jitter window, got %d", expired)
+	}
+}
+
+func TestOrderExpiryJob_ExpiryDeadline(t *testing.T) {
+	job, _ := newOrderExpiryTestJob(nil, map[string]bool{"2024-03-18": true})
+
+	tests := []struct {
+		name     string
+		placedAt time.Time
+		expected time.Time
+	}{
+		{
+			name:     "placed before the close expires the same day",
+			placedAt: time.Date(2024, 3, 14, 9, 30, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 14, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "placed after the close skips the weekend and the holiday",
+			placedAt: time.Date(2024, 3, 15, 17, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 19, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "placed on a weekend expires on the next trading day",
+			placedAt: time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 19, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if deadline := job.expiryDeadline(tt.placedAt); !deadline.Equal(tt.expected) {
+				t.Errorf("Expected deadline %v, got %v", tt.expected, deadline)
+			}
+		})
+	}
+}
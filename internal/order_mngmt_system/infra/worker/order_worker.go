@@ -299,6 +299,8 @@ func (w *OrderWorker) processMessages() {
 		RequeueOnError:    true,
 		RetryDelay:        w.config.RetryBackoffBase,
 		MaxRetries:        w.config.MaxRetries,
+		PriorityOrdering:  true,
+		PrioritySlots:     rabbitmq.DefaultConsumerConfig().PrioritySlots,
 	}
 
 	err := w.consumer.StartConsumers(w.ctx, config)
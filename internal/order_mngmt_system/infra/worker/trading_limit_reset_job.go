@@ -0,0 +1,187 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// TradingLimitAccount holds an account's daily trading limits and the trading day they were last reset for
+type TradingLimitAccount struct {
+	UserID       string
+	Timezone     string // IANA zone the account's trading day is measured in; empty uses the job default
+	Limits       *service.TradingLimits
+	LastResetDay time.Time
+}
+
+// ITradingLimitRepository loads and stores per-account daily trading limits (dependency inversion)
+type ITradingLimitRepository interface {
+	FindAllTradingLimitAccounts(ctx context.Context) ([]*TradingLimitAccount, error)
+	SaveTradingLimits(ctx context.Context, userID string, limits *service.TradingLimits, resetDay time.Time) error
+}
+
+// ITradingCalendar decides which local dates are trading days
+type ITradingCalendar interface {
+	IsTradingDay(day time.Time) bool
+}
+
+// HolidayTradingCalendar treats Monday to Friday as trading days, except for the listed holidays
+type HolidayTradingCalendar struct {
+	Holidays map[string]bool // Dates the market is closed, formatted as 2006-01-02
+}
+
+func (c *HolidayTradingCalendar) IsTradingDay(day time.Time) bool {
+	weekday := day.Weekday()
+	if weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	return !c.Holidays[day.Format("2006-01-02")]
+}
+
+type TradingLimitResetJobConfig struct {
+	CheckInterval      time.Duration // How often the job looks for accounts whose trading day has started
+	RunTimeout         time.Duration // Maximum time for a single reset pass
+	SessionStartHour   int           // Local time at which an account's trading day starts
+	SessionStartMinute int
+	DefaultTimezone    string // Used for accounts without a timezone of their own
+}
+
+func DefaultTradingLimitResetJobConfig() *TradingLimitResetJobConfig {
+	return &TradingLimitResetJobConfig{
+		CheckInterval:      time.Minute,
+		RunTimeout:         5 * time.Minute,
+		SessionStartHour:   0, // Midnight, so the new limit is in place before pre-market opens
+		SessionStartMinute: 0,
+		DefaultTimezone:    "America/New_York",
+	}
+}
+
+// TradingLimitResetResult summarizes a single reset pass
+type TradingLimitResetResult struct {
+	Reset   int
+	Skipped int
+	Failed  int
+	Errors  []string
+}
+
+// TradingLimitResetJob resets each account's daily trading usage when a new trading day
+// starts in the account's timezone. Non-trading days never reset the usage.
+type TradingLimitResetJob struct {
+	repository ITradingLimitRepository
+	calendar   ITradingCalendar
+	config     *TradingLimitResetJobConfig
+	now        func() time.Time
+}
+
+func NewTradingLimitResetJob(
+	repository ITradingLimitRepository,
+	calendar ITradingCalendar,
+	config *TradingLimitResetJobConfig,
+) *TradingLimitResetJob {
+	if config == nil {
+		config = DefaultTradingLimitResetJobConfig()
+	}
+
+	return &TradingLimitResetJob{
+		repository: repository,
+		calendar:   calendar,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// Start runs a reset pass on every tick until the context is cancelled
+func (j *TradingLimitResetJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, j.config.RunTimeout)
+			result, err := j.Run(runCtx)
+			cancel()
+			if err != nil {
+				log.Printf("Trading limit reset failed: %v", err)
+				continue
+			}
+			if result.Reset > 0 || result.Failed > 0 {
+				log.Printf("Trading limit reset: reset=%d skipped=%d failed=%d", result.Reset, result.Skipped, result.Failed)
+			}
+		}
+	}
+}
+
+// Run resets every account whose current trading day has started since its last reset.
+// Accounts already reset for the day are skipped, so running it again is safe.
+func (j *TradingLimitResetJob) Run(ctx context.Context) (*TradingLimitResetResult, error) {
+	result := &TradingLimitResetResult{
+		Errors: make([]string, 0),
+	}
+
+	accounts, err := j.repository.FindAllTradingLimitAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trading limit accounts: %w", err)
+	}
+
+	now := j.now()
+	for _, account := range accounts {
+		tradingDay, due, err := j.resetDue(account, now)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("account %s: %v", account.UserID, err))
+			continue
+		}
+		if !due {
+			result.Skipped++
+			continue
+		}
+
+		account.Limits.ResetDailyUsage()
+		if err := j.repository.SaveTradingLimits(ctx, account.UserID, account.Limits, tradingDay); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("account %s: %v", account.UserID, err))
+			continue
+		}
+		account.LastResetDay = tradingDay
+		result.Reset++
+	}
+
+	return result, nil
+}
+
+// resetDue returns the account's current local trading day and whether its usage still has to be reset for it
+func (j *TradingLimitResetJob) resetDue(account *TradingLimitAccount, now time.Time) (time.Time, bool, error) {
+	if account.Limits == nil {
+		return time.Time{}, false, fmt.Errorf("trading limits are missing")
+	}
+
+	timezone := account.Timezone
+	if timezone == "" {
+		timezone = j.config.DefaultTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	local := now.In(location)
+	tradingDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	if !j.calendar.IsTradingDay(tradingDay) {
+		return tradingDay, false, nil
+	}
+
+	sessionStart := time.Date(local.Year(), local.Month(), local.Day(), j.config.SessionStartHour, j.config.SessionStartMinute, 0, 0, location)
+	if local.Before(sessionStart) {
+		return tradingDay, false, nil
+	}
+
+	lastReset := account.LastResetDay.In(location)
+	alreadyReset := !account.LastResetDay.IsZero() && lastReset.Format("2006-01-02") >= tradingDay.Format("2006-01-02")
+	return tradingDay, !alreadyReset, nil
+}
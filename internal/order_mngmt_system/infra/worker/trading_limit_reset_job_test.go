@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+type InMemoryTradingLimitRepository struct {
+	accounts []*TradingLimitAccount
+	saves    int
+}
+
+func (r *InMemoryTradingLimitRepository) FindAllTradingLimitAccounts(ctx context.Context) ([]*TradingLimitAccount, error) {
+	return r.accounts, nil
+}
+
+func (r *InMemoryTradingLimitRepository) SaveTradingLimits(ctx context.Context, userID string, limits *service.TradingLimits, resetDay time.Time) error {
+	r.saves++
+	return nil
+}
+
+func newUsedTradingLimitAccount(userID, timezone string, lastResetDay time.Time) *TradingLimitAccount {
+	return &TradingLimitAccount{
+		UserID:   userID,
+		Timezone: timezone,
+		Limits: &service.TradingLimits{
+			DailyTradingLimit:   50000.0,
+			DailyTradingUsed:    30000.0,
+			RemainingDailyLimit: 20000.0,
+		},
+		LastResetDay: lastResetDay,
+	}
+}
+
+func newTradingLimitResetTestJob(repo *InMemoryTradingLimitRepository, holidays map[string]bool, now time.Time) *TradingLimitResetJob {
+	config := DefaultTradingLimitResetJobConfig()
+	config.SessionStartHour = 9
+	job := NewTradingLimitResetJob(repo, &HolidayTradingCalendar{Holidays: holidays}, config)
+	job.now = func() time.Time { return now }
+	return job
+}
+
+func assertUsageReset(t *testing.T, account *TradingLimitAccount, expectReset bool) {
+	t.Helper()
+	if expectReset {
+		if account.Limits.DailyTradingUsed != 0 || account.Limits.RemainingDailyLimit != account.Limits.DailyTradingLimit {
+			t.Errorf("Expected %s usage to be reset, got used %.2f remaining %.2f",
+				account.UserID, account.Limits.DailyTradingUsed, account.Limits.RemainingDailyLimit)
+		}
+		return
+	}
+	if account.Limits.DailyTradingUsed != 30000.0 || account.Limits.RemainingDailyLimit != 20000.0 {
+		t.Errorf("Expected %s usage to be kept, got used %.2f remaining %.2f",
+			account.UserID, account.Limits.DailyTradingUsed, account.Limits.RemainingDailyLimit)
+	}
+}
+
+func TestTradingLimitResetJob_Run_ResetsAtSessionStartInAccountTimezone(t *testing.T) {
+	tokyoZone, _ := time.LoadLocation("Asia/Tokyo")
+	newYorkZone, _ := time.LoadLocation("America/New_York")
+	tokyo := newUsedTradingLimitAccount("tokyo-user", "Asia/Tokyo", time.Date(2026, 10, 12, 0, 0, 0, 0, tokyoZone))
+	newYork := newUsedTradingLimitAccount("new-york-user", "America/New_York", time.Date(2026, 10, 12, 0, 0, 0, 0, newYorkZone))
+	repo := &InMemoryTradingLimitRepository{accounts: []*TradingLimitAccount{tokyo, newYork}}
+
+	// Tuesday 09:30 in Tokyo is still Monday evening in New York
+	job := newTradingLimitResetTestJob(repo, nil, time.Date(2026, 10, 13, 0, 30, 0, 0, time.UTC))
+	result, err := job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Reset != 1 || result.Skipped != 1 {
+		t.Errorf("Expected 1 reset and 1 skipped, got reset=%d skipped=%d", result.Reset, result.Skipped)
+	}
+	assertUsageReset(t, tokyo, true)
+	assertUsageReset(t, newYork, false)
+
+	// Tuesday 08:30 in New York is before the session start
+	job.now = func() time.Time { return time.Date(2026, 10, 13, 12, 30, 0, 0, time.UTC) }
+	if _, err := job.Run(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	assertUsageReset(t, newYork, false)
+
+	// Tuesday 09:30 in New York
+	job.now = func() time.Time { return time.Date(2026, 10, 13, 13, 30, 0, 0, time.UTC) }
+	result, err = job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Reset != 1 {
+		t.Errorf("Expected only the New York account to be reset, got reset=%d", result.Reset)
+	}
+	assertUsageReset(t, newYork, true)
+	if repo.saves != 2 {
+		t.Errorf("Expected each account to be saved once, got %d saves", repo.saves)
+	}
+}
+
+func TestTradingLimitResetJob_Run_DoesNotResetOnNonTradingDays(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	friday := time.Date(2026, 10, 9, 0, 0, 0, 0, newYork)
+	holidays := map[string]bool{"2026-10-12": true}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		expectReset bool
+	}{
+		{name: "saturday", now: time.Date(2026, 10, 10, 10, 0, 0, 0, newYork), expectReset: false},
+		{name: "sunday", now: time.Date(2026, 10, 11, 10, 0, 0, 0, newYork), expectReset: false},
+		{name: "holiday monday", now: time.Date(2026, 10, 12, 10, 0, 0, 0, newYork), expectReset: false},
+		{name: "next trading day", now: time.Date(2026, 10, 13, 10, 0, 0, 0, newYork), expectReset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newUsedTradingLimitAccount("user-1", "America/New_York", friday)
+			repo := &InMemoryTradingLimitRepository{accounts: []*TradingLimitAccount{account}}
+
+			if _, err := newTradingLimitResetTestJob(repo, holidays, tt.now).Run(context.Background()); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			assertUsageReset(t, account, tt.expectReset)
+		})
+	}
+}
+
+func TestTradingLimitResetJob_Run_IsIdempotentPerTradingDay(t *testing.T) {
+	account := newUsedTradingLimitAccount("user-1", "", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC))
+	repo := &InMemoryTradingLimitRepository{accounts: []*TradingLimitAccount{account}}
+	job := newTradingLimitResetTestJob(repo, nil, time.Date(2026, 10, 13, 15, 0, 0, 0, time.UTC))
+
+	for i := 0; i < 2; i++ {
+		if _, err := job.Run(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	if repo.saves != 1 {
+		t.Errorf("Expected a single reset for the trading day, got %d", repo.saves)
+	}
+}
+
+func TestTradingLimitResetJob_Run_InvalidTimezoneIsReported(t *testing.T) {
+	account := newUsedTradingLimitAccount("user-1", "Mars/Olympus_Mons", time.Time{})
+	repo := &InMemoryTradingLimitRepository{accounts: []*TradingLimitAccount{account}}
+
+	result, err := newTradingLimitResetTestJob(repo, nil, time.Date(2026, 10, 13, 15, 0, 0, 0, time.UTC)).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Failed != 1 || len(result.Errors) != 1 {
+		t.Errorf("Expected the account to be reported as failed, got failed=%d errors=%v", result.Failed, result.Errors)
+	}
+	assertUsageReset(t, account, false)
+}
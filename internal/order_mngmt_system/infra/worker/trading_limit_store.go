@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	"HubInvestments/shared/infra/database"
+)
+
+// tradingLimitRow is an account's daily trading limits as stored in user_trading_limits
+type tradingLimitRow struct {
+	UserID              int        `db:"user_id"`
+	Timezone            string     `db:"timezone"`
+	DailyTradingLimit   float64    `db:"daily_trading_limit"`
+	DailyTradingUsed    float64    `db:"daily_trading_used"`
+	RemainingDailyLimit float64    `db:"remaining_daily_limit"`
+	MaxOrderValue       float64    `db:"max_order_value"`
+	MaxPositionSize     float64    `db:"max_position_size"`
+	LastResetDay        *time.Time `db:"last_reset_day"`
+}
+
+// TableTradingLimitRepository reads and resets trading limits straight from the user_trading_limits table
+type TableTradingLimitRepository struct {
+	db database.Database
+}
+
+func NewTableTradingLimitRepository(db database.Database) *TableTradingLimitRepository {
+	return &TableTradingLimitRepository{db: db}
+}
+
+func (r *TableTradingLimitRepository) FindAllTradingLimitAccounts(ctx context.Context) ([]*TradingLimitAccount, error) {
+	query := `
+		SELECT user_id, timezone, daily_trading_limit, daily_trading_used, remaining_daily_limit,
+			max_order_value, max_position_size, last_reset_day
+		FROM user_trading_limits
+		ORDER BY user_id`
+
+	var rows []tradingLimitRow
+	if err := r.db.Select(&rows, query); err != nil {
+		return nil, fmt.Errorf("failed to query trading limits: %w", err)
+	}
+
+	accounts := make([]*TradingLimitAccount, 0, len(rows))
+	for _, row := range rows {
+		account := &TradingLimitAccount{
+			UserID:   strconv.Itoa(row.UserID),
+			Timezone: row.Timezone,
+			Limits: &service.TradingLimits{
+				DailyTradingLimit:   row.DailyTradingLimit,
+				DailyTradingUsed:    row.DailyTradingUsed,
+				MaxOrderValue:       row.MaxOrderValue,
+				MaxPositionSize:     row.MaxPositionSize,
+				RemainingDailyLimit: row.RemainingDailyLimit,
+			},
+		}
+		if row.LastResetDay != nil {
+			account.LastResetDay = *row.LastResetDay
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (r *TableTradingLimitRepository) SaveTradingLimits(ctx context.Context, userID string, limits *service.TradingLimits, resetDay time.Time) error {
+	id, err := strconv.Atoi(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	query := `
+		UPDATE user_trading_limits
+		SET daily_trading_used = $2, remaining_daily_limit = $3, last_reset_day = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, limits.DailyTradingUsed, limits.RemainingDailyLimit,
+		resetDay.Format("2006-01-02")); err != nil {
+		return fmt.Errorf("failed to save trading limits: %w", err)
+	}
+	return nil
+}
@@ -25,6 +25,8 @@ type WorkerManager struct {
 	metrics        *WorkerManagerMetrics
 	healthChecker  *HealthChecker
 	autoScaler     *AutoScaler
+
+	positionUpdateRecovery usecase.IRecoverPositionUpdatesUseCase
 }
 
 // WorkerManagerConfig contains configuration for the worker manager
@@ -109,6 +111,19 @@ func NewWorkerManager(
 	return wm
 }
 
+// NewWorkerManagerWithRecovery creates a worker manager that republishes lost position updates
+// before its workers start consuming orders
+func NewWorkerManagerWithRecovery(
+	processOrderUC usecase.IProcessOrderUseCase,
+	messageHandler messaging.MessageHandler,
+	config *WorkerManagerConfig,
+	positionUpdateRecovery usecase.IRecoverPositionUpdatesUseCase,
+) *WorkerManager {
+	wm := NewWorkerManager(processOrderUC, messageHandler, config)
+	wm.positionUpdateRecovery = positionUpdateRecovery
+	return wm
+}
+
 func DefaultWorkerManagerConfig() *WorkerManagerConfig {
 	return &WorkerManagerConfig{
 		MinWorkers:                2,
@@ -162,6 +177,8 @@ func (wm *WorkerManager) Start() error {
 	log.Printf("Starting worker manager with config: min=%d, max=%d, default=%d",
 		wm.config.MinWorkers, wm.config.MaxWorkers, wm.config.DefaultWorkers)
 
+	wm.recoverPositionUpdates()
+
 	// Start initial workers
 	for i := 0; i < wm.config.DefaultWorkers; i++ {
 		workerID := fmt.Sprintf("worker-%d", i+1)
@@ -189,6 +206,25 @@ func (wm *WorkerManager) Start() error {
 	return nil
 }
 
+// recoverPositionUpdates republishes the executions a previous run saved but never published.
+// Failures are logged only; the orders stay unpublished until the next start.
+func (wm *WorkerManager) recoverPositionUpdates() {
+	if wm.positionUpdateRecovery == nil {
+		return
+	}
+
+	result, err := wm.positionUpdateRecovery.Execute(wm.ctx)
+	if err != nil {
+		log.Printf("Position update recovery failed: %v", err)
+		return
+	}
+
+	if len(result.RepublishedOrders) > 0 || len(result.FailedOrders) > 0 || result.MissingExecution > 0 {
+		log.Printf("Position update recovery: checked=%d, republished=%d, failed=%d, missing execution details=%d",
+			result.OrdersChecked, len(result.RepublishedOrders), len(result.FailedOrders), result.MissingExecution)
+	}
+}
+
 // Stop gracefully shuts down all workers and the manager
 func (wm *WorkerManager) Stop() error {
 	wm.mu.Lock()
@@ -417,3 +417,28 @@ func BenchmarkWorkerManagerConcurrentMetricsUpdate(b *testing.B) {
 		}
 	})
 }
+
+type stubPositionUpdateRecovery struct {
+	calls int
+	ctx   context.Context
+}
+
+func (r *stubPositionUpdateRecovery) Execute(ctx context.Context) (*usecase.PositionUpdateRecoveryResult, error) {
+	r.calls++
+	r.ctx = ctx
+	return &usecase.PositionUpdateRecoveryResult{OrdersChecked: 1, RepublishedOrders: []string{"order-1"}}, nil
+}
+
+func TestWorkerManagerRecoverPositionUpdates(t *testing.T) {
+	recovery := &stubPositionUpdateRecovery{}
+	wm := NewWorkerManagerWithRecovery(&MockWorkerManagerProcessOrderUseCase{}, &MockWorkerManagerMessageHandler{}, nil, recovery)
+
+	wm.recoverPositionUpdates()
+
+	assert.Equal(t, 1, recovery.calls)
+	assert.Equal(t, wm.ctx, recovery.ctx, "stopping the manager interrupts a running recovery")
+
+	// Managers built without recovery skip it
+	wm, _, _ = createTestWorkerManager(t)
+	assert.NotPanics(t, wm.recoverPositionUpdates)
+}
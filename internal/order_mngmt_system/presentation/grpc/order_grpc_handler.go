@@ -33,9 +33,6 @@ func (h *OrderGRPCHandler) SubmitOrder(ctx context.Context, req *monolithpb.Subm
 	if req.Symbol == "" {
 		return nil, status.Error(codes.InvalidArgument, "symbol is required")
 	}
-	if req.OrderType == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_type is required")
-	}
 	if req.OrderSide == "" {
 		return nil, status.Error(codes.InvalidArgument, "order_side is required")
 	}
@@ -185,7 +182,7 @@ func (h *OrderGRPCHandler) CancelOrder(ctx context.Context, req *monolithpb.Canc
 	cmd := &orderCommand.CancelOrderCommand{
 		OrderID: req.OrderId,
 		UserID:  req.UserId,
-		Reason:  "User requested cancellation via gRPC",
+		Reason:  string(orderCommand.CancellationReasonUserRequested),
 	}
 
 	result, err := h.container.GetCancelOrderUseCase().Execute(ctx, cmd)
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+// ForceCancelOrderRequest explains why support is cancelling the user's order
+type ForceCancelOrderRequest struct {
+	Reason string `json:"reason" example:"Order stuck after broker outage"`
+}
+
+// parseForceCancelOrderID extracts the order ID from /admin/orders/{id}/force-cancel
+func parseForceCancelOrderID(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "admin" || parts[1] != "orders" || parts[2] == "" || parts[3] != "force-cancel" {
+		return "", false
+	}
+	return parts[2], true
+}
+
+// ForceCancelOrder handles the admin cancellation of any user's order
+// @Summary Force Cancel Order
+// @Description Cancel a stuck or erroneous order on the user's behalf, whoever owns it. Executed, failed and cancelled orders cannot be cancelled. The cancellation is audited with the admin who made it.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param request body ForceCancelOrderRequest true "Reason for the cancellation"
+// @Success 200 {object} command.ForceCancelOrderResult "Order cancelled successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Missing reason"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 409 {object} ErrorResponse "Order is in a terminal state"
+// @Failure 503 {object} ErrorResponse "Force cancellation unavailable"
+// @Router /admin/orders/{id}/force-cancel [post]
+func ForceCancelOrder(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	orderID, ok := parseForceCancelOrderID(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !middleware.IsAdmin(userID) {
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", "Admin access required")
+		return
+	}
+
+	useCase := container.GetForceCancelOrderUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "force cancellation is not available")
+		return
+	}
+
+	var req ForceCancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid JSON format")
+		return
+	}
+
+	result, err := useCase.Execute(context.Background(), &command.ForceCancelOrderCommand{
+		OrderID: orderID,
+		AdminID: userID,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid force cancellation command"):
+			writeErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+		case strings.Contains(err.Error(), "order not found"):
+			writeErrorResponse(w, http.StatusNotFound, "Not Found", err.Error())
+		case strings.Contains(err.Error(), "cannot be cancelled"):
+			writeErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+		default:
+			writeErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// ForceCancelOrderWithAuth returns a handler wrapped with authentication middleware
+func ForceCancelOrderWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		ForceCancelOrder(w, r, userID, container)
+	})
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+)
+
+// MockForceCancelOrderUseCase implements IForceCancelOrderUseCase for testing
+type MockForceCancelOrderUseCase struct {
+	ExecuteFunc func(ctx context.Context, cmd *command.ForceCancelOrderCommand) (*command.ForceCancelOrderResult, error)
+}
+
+func (m *MockForceCancelOrderUseCase) Execute(ctx context.Context, cmd *command.ForceCancelOrderCommand) (*command.ForceCancelOrderResult, error) {
+	return m.ExecuteFunc(ctx, cmd)
+}
+
+func forceCancelRequest(path, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	return req
+}
+
+func TestForceCancelOrder_RequiresAdmin(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-user")
+	container := &MockContainer{forceCancelUseCase: &MockForceCancelOrderUseCase{}}
+	w := httptest.NewRecorder()
+
+	ForceCancelOrderWithAuth(mockTokenVerifier, container)(w, forceCancelRequest("/admin/orders/order-1/force-cancel", `{"reason":"stuck"}`))
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestForceCancelOrder_CancelsAsAdmin(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	var received *command.ForceCancelOrderCommand
+	container := &MockContainer{
+		forceCancelUseCase: &MockForceCancelOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.ForceCancelOrderCommand) (*command.ForceCancelOrderResult, error) {
+				received = cmd
+				return &command.ForceCancelOrderResult{OrderID: cmd.OrderID, Status: "CANCELLED", AuditID: "audit-1"}, nil
+			},
+		},
+	}
+	w := httptest.NewRecorder()
+
+	ForceCancelOrderWithAuth(mockTokenVerifier, container)(w, forceCancelRequest("/admin/orders/order-1/force-cancel", `{"reason":"Order stuck"}`))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if received.OrderID != "order-1" || received.AdminID != "test-user-id" || received.Reason != "Order stuck" {
+		t.Errorf("Unexpected command %+v", received)
+	}
+	if !strings.Contains(w.Body.String(), `"audit_id":"audit-1"`) {
+		t.Errorf("Expected the audit ID in the response, got %s", w.Body.String())
+	}
+}
+
+func TestForceCancelOrder_TerminalOrderIsConflict(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	container := &MockContainer{
+		forceCancelUseCase: &MockForceCancelOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.ForceCancelOrderCommand) (*command.ForceCancelOrderResult, error) {
+				return nil, errors.New("order cannot be cancelled: order in status 'EXECUTED' cannot be cancelled")
+			},
+		},
+	}
+	w := httptest.NewRecorder()
+
+	ForceCancelOrderWithAuth(mockTokenVerifier, container)(w, forceCancelRequest("/admin/orders/order-1/force-cancel", `{"reason":"stuck"}`))
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestForceCancelOrder_UnknownPathIsNotFound(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	container := &MockContainer{forceCancelUseCase: &MockForceCancelOrderUseCase{}}
+	w := httptest.NewRecorder()
+
+	ForceCancelOrderWithAuth(mockTokenVerifier, container)(w, forceCancelRequest("/admin/orders/order-1/cancel", `{}`))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+type LimitPriceSuggestionResponse struct {
+	Symbol                   string  `json:"symbol"`
+	OrderSide                string  `json:"order_side"`
+	Quantity                 float64 `json:"quantity"`
+	Urgency                  string  `json:"urgency"`
+	SuggestedPrice           float64 `json:"suggested_price"`
+	FillProbability          float64 `json:"fill_probability"`
+	EstimatedFillTimeSeconds float64 `json:"estimated_fill_time_seconds"`
+	BidPrice                 float64 `json:"bid_price"`
+	AskPrice                 float64 `json:"ask_price"`
+	QueueAhead               float64 `json:"queue_ahead"`
+	LevelsCrossed            int     `json:"levels_crossed"`
+	UnfilledQuantity         float64 `json:"unfilled_quantity"`
+	CalculatedAt             string  `json:"calculated_at"`
+	PricePrecision           *int    `json:"price_precision,omitempty"`
+}
+
+func convertToLimitPriceSuggestionResponse(suggestion *service.LimitPriceSuggestion) LimitPriceSuggestionResponse {
+	return LimitPriceSuggestionResponse{
+		Symbol:                   suggestion.Symbol,
+		OrderSide:                suggestion.OrderSide.String(),
+		Quantity:                 suggestion.Quantity,
+		Urgency:                  string(suggestion.Urgency),
+		SuggestedPrice:           suggestion.SuggestedPrice,
+		FillProbability:          suggestion.FillProbability,
+		EstimatedFillTimeSeconds: suggestion.EstimatedFillTime.Seconds(),
+		BidPrice:                 suggestion.BidPrice,
+		AskPrice:                 suggestion.AskPrice,
+		QueueAhead:               suggestion.QueueAhead,
+		LevelsCrossed:            suggestion.LevelsCrossed,
+		UnfilledQuantity:         suggestion.UnfilledQuantity,
+		CalculatedAt:             suggestion.CalculatedAt.Format(time.RFC3339),
+	}
+}
+
+// SuggestLimitPrice handles order book-based limit price suggestions
+// @Summary Suggest Limit Price
+// @Description Recommend a limit price that balances fill probability against price. Low urgency joins the order's own touch, medium meets the mid and high takes the opposite touch, walking the book until it absorbs the quantity. No order is created.
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param symbol query string true "Symbol"
+// @Param side query string true "Order side (BUY or SELL)"
+// @Param quantity query number true "Order quantity"
+// @Param urgency query string false "Urgency (LOW, MEDIUM or HIGH)" default(MEDIUM)
+// @Success 200 {object} LimitPriceSuggestionResponse "Suggestion computed"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid symbol, side, quantity or urgency"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Limit price suggestions unavailable"
+// @Router /orders/suggest-price [get]
+func SuggestLimitPrice(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	useCase := container.GetSuggestLimitPriceUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "limit price suggestions are not available")
+		return
+	}
+
+	query := r.URL.Query()
+	quantity, err := strconv.ParseFloat(query.Get("quantity"), 64)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation Error", "quantity must be a number")
+		return
+	}
+
+	urgency := strings.ToUpper(strings.TrimSpace(query.Get("urgency")))
+	if urgency == "" {
+		urgency = string(service.LimitPriceUrgencyMedium)
+	}
+
+	cmd := &command.SuggestLimitPriceCommand{
+		UserID:    userID,
+		Symbol:    strings.ToUpper(strings.TrimSpace(query.Get("symbol"))),
+		OrderSide: strings.ToUpper(strings.TrimSpace(query.Get("side"))),
+		Quantity:  quantity,
+		Urgency:   urgency,
+	}
+	if err := cmd.Validate(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation Error", err.Error())
+		return
+	}
+
+	ctx := context.Background()
+	suggestion, err := useCase.Execute(ctx, cmd)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Limit Price Suggestion Failed", err.Error())
+		return
+	}
+
+	response := convertToLimitPriceSuggestionResponse(suggestion)
+	precision := resolvePricePrecision(ctx, container, response.Symbol)
+	response.SuggestedPrice = precision.round(response.SuggestedPrice)
+	response.BidPrice = precision.round(response.BidPrice)
+	response.AskPrice = precision.round(response.AskPrice)
+	response.PricePrecision = precision.field()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// SuggestLimitPriceWithAuth returns a handler wrapped with authentication middleware
+func SuggestLimitPriceWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		SuggestLimitPrice(w, r, userID, container)
+	})
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// MockSuggestLimitPriceUseCase implements ISuggestLimitPriceUseCase for testing
+type MockSuggestLimitPriceUseCase struct {
+	ExecuteFunc func(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error)
+}
+
+func (m *MockSuggestLimitPriceUseCase) Execute(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error) {
+	return m.ExecuteFunc(ctx, cmd)
+}
+
+func TestSuggestLimitPrice_ReturnsSuggestion(t *testing.T) {
+	var received *command.SuggestLimitPriceCommand
+	container := &MockContainer{
+		suggestLimitPriceUseCase: &MockSuggestLimitPriceUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error) {
+				received = cmd
+				return &service.LimitPriceSuggestion{
+					Symbol:            "PETR4",
+					OrderSide:         domain.OrderSideBuy,
+					Quantity:          500,
+					Urgency:           service.LimitPriceUrgencyHigh,
+					SuggestedPrice:    30.20,
+					FillProbability:   0.9,
+					EstimatedFillTime: 2 * time.Minute,
+					BidPrice:          29.90,
+					AskPrice:          30.10,
+					LevelsCrossed:     2,
+					CalculatedAt:      time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+				}, nil
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/suggest-price?symbol=petr4&side=buy&quantity=500&urgency=high", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	SuggestLimitPriceWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if received == nil || received.Symbol != "PETR4" || received.OrderSide != "BUY" || received.Quantity != 500 || received.Urgency != "HIGH" {
+		t.Fatalf("Unexpected command %+v", received)
+	}
+
+	var response LimitPriceSuggestionResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.SuggestedPrice != 30.20 || response.FillProbability != 0.9 || response.EstimatedFillTimeSeconds != 120 {
+		t.Errorf("Unexpected suggestion %+v", response)
+	}
+	if response.OrderSide != "BUY" || response.Urgency != "HIGH" || response.LevelsCrossed != 2 {
+		t.Errorf("Unexpected suggestion %+v", response)
+	}
+}
+
+func TestSuggestLimitPrice_DefaultsToMediumUrgency(t *testing.T) {
+	var received *command.SuggestLimitPriceCommand
+	container := &MockContainer{
+		suggestLimitPriceUseCase: &MockSuggestLimitPriceUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error) {
+				received = cmd
+				return &service.LimitPriceSuggestion{Symbol: cmd.Symbol, Urgency: service.LimitPriceUrgencyMedium}, nil
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/suggest-price?symbol=PETR4&side=SELL&quantity=100", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	SuggestLimitPriceWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if received == nil || received.Urgency != "MEDIUM" {
+		t.Errorf("Expected MEDIUM urgency by default, got %+v", received)
+	}
+}
+
+func TestSuggestLimitPrice_InvalidQueryReturnsBadRequest(t *testing.T) {
+	container := &MockContainer{
+		suggestLimitPriceUseCase: &MockSuggestLimitPriceUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SuggestLimitPriceCommand) (*service.LimitPriceSuggestion, error) {
+				t.Fatal("Use case should not be called for an invalid query")
+				return nil, nil
+			},
+		},
+	}
+
+	for _, query := range []string{
+		"symbol=PETR4&side=BUY&quantity=abc",
+		"symbol=PETR4&side=HOLD&quantity=100",
+		"symbol=PETR4&side=BUY&quantity=-5",
+		"symbol=PETR4&side=BUY&quantity=100&urgency=NOW",
+		"side=BUY&quantity=100",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/orders/suggest-price?"+query, nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		w := httptest.NewRecorder()
+
+		SuggestLimitPriceWithAuth(mockTokenVerifier, container)(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestSuggestLimitPrice_UnavailableWithoutUseCase(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/orders/suggest-price?symbol=PETR4&side=BUY&quantity=100", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	SuggestLimitPriceWithAuth(mockTokenVerifier, &MockContainer{})(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
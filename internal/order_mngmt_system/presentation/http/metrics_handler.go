@@ -0,0 +1,180 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
+	positionWorker "HubInvestments/internal/position/infra/worker"
+	di "HubInvestments/pck"
+)
+
+const (
+	stageLatencyMetric = "order_submission_stage_latency_seconds"
+	totalLatencyMetric = "order_submission_total_latency_seconds"
+	serviceCallMetric  = "order_pipeline_service_call_duration_seconds"
+	serviceCallsMetric = "order_pipeline_service_calls_total"
+
+	positionOperationMetric = "position_worker_operation_duration_seconds"
+
+	quoteAgeMetric     = "market_data_quote_age_seconds"
+	staleSymbolsMetric = "market_data_stale_symbols"
+	degradedMetric     = "market_data_degraded"
+)
+
+// GetMetrics exposes the order submission latency histograms, the per-service pipeline metrics, the
+// position worker's per-operation latency and the market data quote ages in the Prometheus text format
+// @Summary Order Latency Metrics
+// @Description Submit-to-execute latency histograms: one per submission stage (time since the previous stage) and one for the total from submission until a worker finished the order. Also the duration and success/failure count of each validation, pricing and risk service method, the duration of each position worker operation (create, update, close), and the age of the freshest quote of each tracked symbol with the count of symbols past the staleness SLO.
+// @Tags Metrics
+// @Produce plain
+// @Success 200 {string} string "Prometheus text exposition"
+// @Failure 503 {object} ErrorResponse "Latency tracking and pipeline metrics are not enabled"
+// @Router /metrics [get]
+func GetMetrics(w http.ResponseWriter, r *http.Request, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tracker := container.GetOrderLatencyTracker()
+	pipelineMetrics := container.GetOrderPipelineMetrics()
+	positionUpdates := container.GetPositionWorkerManager()
+	freshness := container.GetMarketDataFreshnessMonitor()
+	if tracker == nil && pipelineMetrics == nil && positionUpdates == nil && freshness == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Metrics Unavailable", "order latency tracking is not enabled")
+		return
+	}
+
+	var builder strings.Builder
+	if tracker != nil {
+		writeLatencyMetrics(&builder, tracker.Histograms())
+	}
+	if pipelineMetrics != nil {
+		writePipelineMetrics(&builder, pipelineMetrics.Snapshot())
+	}
+	if positionUpdates != nil {
+		writePositionOperationMetrics(&builder, positionUpdates.GetMetrics().OperationLatency)
+	}
+	if freshness != nil {
+		writeMarketDataFreshnessMetrics(&builder, freshness.Report())
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, builder.String())
+}
+
+// writeLatencyMetrics writes the per-stage histograms followed by the total latency histogram
+func writeLatencyMetrics(builder *strings.Builder, histograms []service.LatencyHistogram) {
+	builder.WriteString("# HELP " + stageLatencyMetric + " Time from the previous submission stage until the order reached the stage.\n")
+	builder.WriteString("# TYPE " + stageLatencyMetric + " histogram\n")
+	for _, histogram := range histograms {
+		if histogram.Stage != service.OrderLatencyTotal {
+			writeHistogram(builder, stageLatencyMetric, fmt.Sprintf(`stage="%s"`, strings.ToLower(histogram.Stage)), histogram)
+		}
+	}
+
+	builder.WriteString("# HELP " + totalLatencyMetric + " Time from order submission until a worker finished processing the order.\n")
+	builder.WriteString("# TYPE " + totalLatencyMetric + " histogram\n")
+	for _, histogram := range histograms {
+		if histogram.Stage == service.OrderLatencyTotal {
+			writeHistogram(builder, totalLatencyMetric, "", histogram)
+		}
+	}
+}
+
+// writePipelineMetrics writes the duration histogram and outcome counters of each domain service method
+func writePipelineMetrics(builder *strings.Builder, methods []service.PipelineMethodMetrics) {
+	builder.WriteString("# HELP " + serviceCallMetric + " Duration of each validation, pricing and risk service method call.\n")
+	builder.WriteString("# TYPE " + serviceCallMetric + " histogram\n")
+	for _, method := range methods {
+		writeHistogram(builder, serviceCallMetric, pipelineMethodLabels(method), method.Latency)
+	}
+
+	builder.WriteString("# HELP " + serviceCallsMetric + " Validation, pricing and risk service method calls by outcome.\n")
+	builder.WriteString("# TYPE " + serviceCallsMetric + " counter\n")
+	for _, method := range methods {
+		labels := pipelineMethodLabels(method)
+		fmt.Fprintf(builder, "%s{%s,outcome=\"%s\"} %d\n", serviceCallsMetric, labels, service.PipelineOutcomeSuccess, method.Successes)
+		fmt.Fprintf(builder, "%s{%s,outcome=\"%s\"} %d\n", serviceCallsMetric, labels, service.PipelineOutcomeFailure, method.Failures)
+	}
+}
+
+// writePositionOperationMetrics writes one duration histogram per position worker operation
+func writePositionOperationMetrics(builder *strings.Builder, operations []positionWorker.OperationLatencySnapshot) {
+	builder.WriteString("# HELP " + positionOperationMetric + " Duration of each position create, update and close applied by the position worker.\n")
+	builder.WriteString("# TYPE " + positionOperationMetric + " histogram\n")
+	for _, operation := range operations {
+		histogram := service.LatencyHistogram{
+			Stage:   operation.Operation,
+			Buckets: make([]service.LatencyBucket, len(operation.Buckets)),
+			Count:   operation.Count,
+			Sum:     operation.Sum,
+		}
+		for i, bucket := range operation.Buckets {
+			histogram.Buckets[i] = service.LatencyBucket{UpperBound: bucket.UpperBound, Count: bucket.Count}
+		}
+		writeHistogram(builder, positionOperationMetric, fmt.Sprintf(`operation="%s"`, operation.Operation), histogram)
+	}
+}
+
+// writeMarketDataFreshnessMetrics writes the quote age of each tracked symbol and the SLO breach gauges.
+// Symbols never quoted have no age series and count as stale.
+func writeMarketDataFreshnessMetrics(builder *strings.Builder, report orderMktClient.MarketDataFreshnessReport) {
+	builder.WriteString("# HELP " + quoteAgeMetric + " Age of the freshest quote seen for each tracked symbol.\n")
+	builder.WriteString("# TYPE " + quoteAgeMetric + " gauge\n")
+	for _, symbol := range report.Symbols {
+		if symbol.LastQuoteAt != nil {
+			fmt.Fprintf(builder, "%s{symbol=\"%s\"} %s\n", quoteAgeMetric, symbol.Symbol, formatSeconds(symbol.Age))
+		}
+	}
+
+	builder.WriteString("# HELP " + staleSymbolsMetric + " Tracked symbols whose freshest quote is older than the staleness SLO.\n")
+	builder.WriteString("# TYPE " + staleSymbolsMetric + " gauge\n")
+	fmt.Fprintf(builder, "%s %d\n", staleSymbolsMetric, report.StaleSymbols)
+
+	degraded := 0
+	if report.Degraded {
+		degraded = 1
+	}
+	builder.WriteString("# HELP " + degradedMetric + " 1 while market data breaches the staleness SLO.\n")
+	builder.WriteString("# TYPE " + degradedMetric + " gauge\n")
+	fmt.Fprintf(builder, "%s %d\n", degradedMetric, degraded)
+}
+
+func pipelineMethodLabels(method service.PipelineMethodMetrics) string {
+	return fmt.Sprintf(`service="%s",method="%s"`, method.Service, method.Method)
+}
+
+// writeHistogram writes the bucket, sum and count series of one histogram
+func writeHistogram(builder *strings.Builder, metric, labels string, histogram service.LatencyHistogram) {
+	withLabels := func(extra string) string {
+		all := make([]string, 0, 2)
+		if labels != "" {
+			all = append(all, labels)
+		}
+		if extra != "" {
+			all = append(all, extra)
+		}
+		if len(all) == 0 {
+			return ""
+		}
+		return "{" + strings.Join(all, ",") + "}"
+	}
+
+	for _, bucket := range histogram.Buckets {
+		fmt.Fprintf(builder, "%s_bucket%s %d\n", metric, withLabels(fmt.Sprintf(`le="%s"`, formatSeconds(bucket.UpperBound))), bucket.Count)
+	}
+	fmt.Fprintf(builder, "%s_bucket%s %d\n", metric, withLabels(`le="+Inf"`), histogram.Count)
+	fmt.Fprintf(builder, "%s_sum%s %s\n", metric, withLabels(""), formatSeconds(histogram.Sum))
+	fmt.Fprintf(builder, "%s_count%s %d\n", metric, withLabels(""), histogram.Count)
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
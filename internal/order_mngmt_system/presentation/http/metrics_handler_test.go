@@ -0,0 +1,122 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	orderService "HubInvestments/internal/order_mngmt_system/domain/service"
+	positionWorker "HubInvestments/internal/position/infra/worker"
+)
+
+func TestGetMetrics_ExposesLatencyHistograms(t *testing.T) {
+	tracker := orderService.NewOrderLatencyTracker(orderService.OrderLatencyTrackerConfig{
+		Buckets: []time.Duration{10 * time.Millisecond, time.Second},
+	})
+	submittedAt := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	tracker.RecordStage("order-1", domain.OrderLatencyStageSubmitted, submittedAt)
+	tracker.RecordStage("order-1", domain.OrderLatencyStageValidated, submittedAt.Add(5*time.Millisecond))
+	tracker.RecordStage("order-1", domain.OrderLatencyStageProcessed, submittedAt.Add(250*time.Millisecond))
+
+	container := &MockContainer{latencyTracker: tracker}
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rr := httptest.NewRecorder()
+
+	GetMetrics(rr, req, container)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	body := rr.Body.String()
+	expectedLines := []string{
+		"# TYPE order_submission_stage_latency_seconds histogram",
+		`order_submission_stage_latency_seconds_bucket{stage="validated",le="0.01"} 1`,
+		`order_submission_stage_latency_seconds_count{stage="processed"} 1`,
+		`order_submission_total_latency_seconds_bucket{le="0.01"} 0`,
+		`order_submission_total_latency_seconds_bucket{le="1"} 1`,
+		`order_submission_total_latency_seconds_bucket{le="+Inf"} 1`,
+		"order_submission_total_latency_seconds_sum 0.25",
+		"order_submission_total_latency_seconds_count 1",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestGetMetrics_TrackingDisabled(t *testing.T) {
+	container := &MockContainer{}
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rr := httptest.NewRecorder()
+
+	GetMetrics(rr, req, container)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestGetMetrics_ExposesPipelineServiceMetrics(t *testing.T) {
+	metrics := orderService.NewOrderPipelineMetrics(orderService.OrderPipelineMetricsConfig{
+		Buckets: []time.Duration{10 * time.Millisecond},
+	})
+	metrics.Observe(orderService.PipelineServiceRisk, "AssessOrderRisk", 5*time.Millisecond, false)
+	metrics.Observe(orderService.PipelineServiceRisk, "AssessOrderRisk", 20*time.Millisecond, true)
+
+	container := &MockContainer{pipelineMetrics: metrics}
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rr := httptest.NewRecorder()
+
+	GetMetrics(rr, req, container)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	body := rr.Body.String()
+	expectedLines := []string{
+		"# TYPE order_pipeline_service_call_duration_seconds histogram",
+		`order_pipeline_service_call_duration_seconds_bucket{service="risk",method="AssessOrderRisk",le="0.01"} 1`,
+		`order_pipeline_service_call_duration_seconds_count{service="risk",method="AssessOrderRisk"} 2`,
+		"# TYPE order_pipeline_service_calls_total counter",
+		`order_pipeline_service_calls_total{service="risk",method="AssessOrderRisk",outcome="success"} 1`,
+		`order_pipeline_service_calls_total{service="risk",method="AssessOrderRisk",outcome="failure"} 1`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestWritePositionOperationMetrics(t *testing.T) {
+	var builder strings.Builder
+	writePositionOperationMetrics(&builder, []positionWorker.OperationLatencySnapshot{
+		{
+			Operation: positionWorker.PositionOperationCreate,
+			Buckets:   []positionWorker.OperationLatencyBucket{{UpperBound: 10 * time.Millisecond, Count: 1}},
+			Count:     2,
+			Sum:       40 * time.Millisecond,
+		},
+		{Operation: positionWorker.PositionOperationClose, Buckets: []positionWorker.OperationLatencyBucket{{UpperBound: 10 * time.Millisecond}}},
+	})
+
+	body := builder.String()
+	expectedLines := []string{
+		"# TYPE position_worker_operation_duration_seconds histogram",
+		`position_worker_operation_duration_seconds_bucket{operation="position_create",le="0.01"} 1`,
+		`position_worker_operation_duration_seconds_bucket{operation="position_create",le="+Inf"} 2`,
+		`position_worker_operation_duration_seconds_sum{operation="position_create"} 0.04`,
+		`position_worker_operation_duration_seconds_count{operation="position_close"} 0`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
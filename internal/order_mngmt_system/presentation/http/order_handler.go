@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,17 +11,53 @@ import (
 	"time"
 
 	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
 	di "HubInvestments/pck"
 	"HubInvestments/shared/middleware"
 )
 
+// SubmitOrderRequest is the latest order submission payload; older versions are converted to it.
+// Fields left out (order type, time in force, partial fill preference) are filled from the user's
+// default order settings before validation.
 type SubmitOrderRequest struct {
-	Symbol    string   `json:"symbol" validate:"required"`
-	OrderType string   `json:"order_type" validate:"required,oneof=MARKET LIMIT STOP_LOSS STOP_LIMIT"`
-	OrderSide string   `json:"order_side" validate:"required,oneof=BUY SELL"`
-	Quantity  float64  `json:"quantity" validate:"required,gt=0"`
-	Price     *float64 `json:"price,omitempty"`
+	// SchemaVersion is the payload version; the X-Order-Schema-Version header may be sent instead
+	SchemaVersion int `json:"schema_version,omitempty"`
+
+	Symbol           string   `json:"symbol" validate:"required"`
+	OrderType        string   `json:"order_type" validate:"omitempty,oneof=MARKET LIMIT STOP_LOSS STOP_LIMIT MARKET_IF_TOUCHED LIMIT_IF_TOUCHED"`
+	OrderSide        string   `json:"order_side" validate:"required,oneof=BUY SELL"`
+	Quantity         float64  `json:"quantity" validate:"required,gt=0"`
+	Price            *float64 `json:"price,omitempty"`
+	TimeInForce      string   `json:"time_in_force,omitempty" validate:"omitempty,oneof=DAY GTC IOC FOK"`
+	AllowPartialFill *bool    `json:"allow_partial_fill,omitempty"`
+
+	// TriggerPrice is the price that activates a MARKET_IF_TOUCHED or LIMIT_IF_TOUCHED order
+	TriggerPrice *float64 `json:"trigger_price,omitempty"`
+
+	// ExecutionStrategy forces a strategy instead of the recommended one; it must suit the order's type and size
+	ExecutionStrategy string `json:"execution_strategy,omitempty" validate:"omitempty,oneof=MARKET LIMIT TWAP VWAP ICEBERG HIDDEN"`
+
+	// AcknowledgeDuplicate confirms an order that was rejected as a possible duplicate of a recent open order
+	AcknowledgeDuplicate bool `json:"acknowledge_duplicate,omitempty"`
+
+	// AcknowledgeFatFinger confirms an order that was rejected as far out of line with the user's usual orders
+	AcknowledgeFatFinger bool `json:"acknowledge_fat_finger,omitempty"`
+
+	// SettlementAccount and SettlementInstructionCode route settlement explicitly for clearing; both are sent together
+	SettlementAccount         string `json:"settlement_account,omitempty"`
+	SettlementInstructionCode string `json:"settlement_instruction_code,omitempty" validate:"omitempty,oneof=DVP RVP FOP"`
+
+	// PegReference makes a LIMIT order follow the BID, MID or ASK; the price is its starting price.
+	// PegOffset is added to the reference, and PegMinPrice/PegMaxPrice bound the repricing.
+	PegReference string   `json:"peg_reference,omitempty" validate:"omitempty,oneof=BID MID ASK"`
+	PegOffset    *float64 `json:"peg_offset,omitempty"`
+	PegMinPrice  *float64 `json:"peg_min_price,omitempty"`
+	PegMaxPrice  *float64 `json:"peg_max_price,omitempty"`
+
+	// ProtectiveStopPercent attaches a stop loss leg this percent below a buy's entry; it defaults
+	// from the user's protective stop preference
+	ProtectiveStopPercent *float64 `json:"protective_stop_percent,omitempty"`
 }
 
 type SubmitOrderResponse struct {
@@ -31,25 +68,42 @@ type SubmitOrderResponse struct {
 	EstimatedValue float64 `json:"estimated_value,omitempty"`
 	MarketPrice    float64 `json:"market_price,omitempty"`
 	SubmittedAt    string  `json:"submitted_at"`
+
+	ProtectiveStopOrderID string   `json:"protective_stop_order_id,omitempty"`
+	ProtectiveStopPrice   *float64 `json:"protective_stop_price,omitempty"`
+	PricePrecision        *int     `json:"price_precision,omitempty"`
 }
 
 type OrderDetailsResponse struct {
-	OrderID                 string   `json:"order_id"`
-	UserID                  string   `json:"user_id"`
-	Symbol                  string   `json:"symbol"`
-	OrderType               string   `json:"order_type"`
-	OrderSide               string   `json:"order_side"`
-	Quantity                float64  `json:"quantity"`
-	Price                   *float64 `json:"price,omitempty"`
-	Status                  string   `json:"status"`
-	CreatedAt               string   `json:"created_at"`
-	UpdatedAt               string   `json:"updated_at"`
-	ExecutedAt              *string  `json:"executed_at,omitempty"`
-	ExecutionPrice          *float64 `json:"execution_price,omitempty"`
-	MarketPriceAtSubmission *float64 `json:"market_price_at_submission,omitempty"`
-	MarketDataTimestamp     *string  `json:"market_data_timestamp,omitempty"`
-	EstimatedValue          float64  `json:"estimated_value"`
-	ExecutionValue          float64  `json:"execution_value,omitempty"`
+	OrderID                 string                   `json:"order_id"`
+	UserID                  string                   `json:"user_id"`
+	Symbol                  string                   `json:"symbol"`
+	OrderType               string                   `json:"order_type"`
+	OrderSide               string                   `json:"order_side"`
+	Quantity                float64                  `json:"quantity"`
+	Price                   *float64                 `json:"price,omitempty"`
+	Status                  string                   `json:"status"`
+	CreatedAt               string                   `json:"created_at"`
+	UpdatedAt               string                   `json:"updated_at"`
+	ExecutedAt              *string                  `json:"executed_at,omitempty"`
+	ExecutionPrice          *float64                 `json:"execution_price,omitempty"`
+	MarketPriceAtSubmission *float64                 `json:"market_price_at_submission,omitempty"`
+	MarketDataTimestamp     *string                  `json:"market_data_timestamp,omitempty"`
+	EstimatedValue          float64                  `json:"estimated_value"`
+	ExecutionValue          float64                  `json:"execution_value,omitempty"`
+	CancellationReason      string                   `json:"cancellation_reason,omitempty"`
+	TriggerPrice            *float64                 `json:"trigger_price,omitempty"`
+	TriggeredAt             *string                  `json:"triggered_at,omitempty"`
+	SettlementAccount       string                   `json:"settlement_account,omitempty"`
+	SettlementInstruction   string                   `json:"settlement_instruction_code,omitempty"`
+	PegReference            string                   `json:"peg_reference,omitempty"`
+	PegOffset               *float64                 `json:"peg_offset,omitempty"`
+	PegMinPrice             *float64                 `json:"peg_min_price,omitempty"`
+	PegMaxPrice             *float64                 `json:"peg_max_price,omitempty"`
+	RepricedAt              *string                  `json:"repriced_at,omitempty"`
+	ParentOrderID           string                   `json:"parent_order_id,omitempty"`
+	FillSummary             *domain.OrderFillSummary `json:"fill_summary,omitempty"`
+	PricePrecision          *int                     `json:"price_precision,omitempty"`
 }
 
 type OrderStatusResponse struct {
@@ -74,12 +128,57 @@ type CancelOrderResponse struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+type ExecutionQualityResponse struct {
+	OrderID                  string  `json:"order_id"`
+	Symbol                   string  `json:"symbol"`
+	OrderSide                string  `json:"order_side"`
+	Quantity                 float64 `json:"quantity"`
+	EstimatedFillPrice       float64 `json:"estimated_fill_price"`
+	ActualFillPrice          float64 `json:"actual_fill_price"`
+	Slippage                 float64 `json:"slippage"`
+	SlippageBps              float64 `json:"slippage_bps"`
+	SlippageCost             float64 `json:"slippage_cost"`
+	SlippageTolerancePercent float64 `json:"slippage_tolerance_percent"`
+	Quality                  string  `json:"quality"`
+	ExecutedAt               *string `json:"executed_at,omitempty"`
+}
+
+type OrderFillsResponse struct {
+	OrderID string                  `json:"order_id"`
+	Fills   []domain.OrderFill      `json:"fills"`
+	Summary domain.OrderFillSummary `json:"summary"`
+}
+
+type PartialCancelOrderRequest struct {
+	NewQuantity float64 `json:"new_quantity" validate:"gte=0"`
+}
+
+type PartialCancelOrderResponse struct {
+	OrderID          string  `json:"order_id"`
+	Status           string  `json:"status"`
+	Message          string  `json:"message"`
+	PreviousQuantity float64 `json:"previous_quantity"`
+	NewQuantity      float64 `json:"new_quantity"`
+	FilledQuantity   float64 `json:"filled_quantity"`
+	UpdatedAt        string  `json:"updated_at"`
+}
+
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
 	Code    int    `json:"code"`
 }
 
+func writeErrorResponse(w http.ResponseWriter, code int, title, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error:   title,
+		Message: message,
+		Code:    code,
+	})
+}
+
 func extractOrderIDFromPath(path string) (string, error) {
 	parts := strings.Split(strings.Trim(path, "/"), "/")
 	if len(parts) < 2 {
@@ -99,10 +198,6 @@ func validateSubmitOrderRequest(req *SubmitOrderRequest) error {
 		return fmt.Errorf("symbol is required")
 	}
 
-	if req.OrderType == "" {
-		return fmt.Errorf("order_type is required")
-	}
-
 	if req.OrderSide == "" {
 		return fmt.Errorf("order_side is required")
 	}
@@ -111,8 +206,9 @@ func validateSubmitOrderRequest(req *SubmitOrderRequest) error {
 		return fmt.Errorf("quantity must be greater than 0")
 	}
 
+	// An empty order type is filled from the user's default order type by the submit use case
 	switch req.OrderType {
-	case "MARKET", "LIMIT", "STOP_LOSS", "STOP_LIMIT":
+	case "", "MARKET", "LIMIT", "STOP_LOSS", "STOP_LIMIT", "MARKET_IF_TOUCHED", "LIMIT_IF_TOUCHED":
 
 	default:
 		return fmt.Errorf("invalid order_type: %s", req.OrderType)
@@ -129,24 +225,118 @@ func validateSubmitOrderRequest(req *SubmitOrderRequest) error {
 		return fmt.Errorf("price is required for LIMIT orders")
 	}
 
+	if (req.OrderType == "MARKET_IF_TOUCHED" || req.OrderType == "LIMIT_IF_TOUCHED") && req.TriggerPrice == nil {
+		return fmt.Errorf("trigger_price is required for %s orders", req.OrderType)
+	}
+
+	if req.TimeInForce != "" {
+		if _, err := domain.ParseTimeInForce(req.TimeInForce); err != nil {
+			return fmt.Errorf("invalid time_in_force: %s", req.TimeInForce)
+		}
+	}
+
+	switch req.ExecutionStrategy {
+	case "", "MARKET", "LIMIT", "TWAP", "VWAP", "ICEBERG", "HIDDEN":
+
+	default:
+		return fmt.Errorf("invalid execution_strategy: %s", req.ExecutionStrategy)
+	}
+
+	if req.SettlementAccount != "" || req.SettlementInstructionCode != "" {
+		if req.SettlementAccount == "" || req.SettlementInstructionCode == "" {
+			return errors.New("settlement_account and settlement_instruction_code must be sent together")
+		}
+		if _, err := domain.NewSettlementInstruction(req.SettlementAccount, req.SettlementInstructionCode); err != nil {
+			return fmt.Errorf("invalid settlement instruction: %w", err)
+		}
+	}
+
+	if req.PegReference != "" || req.PegOffset != nil || req.PegMinPrice != nil || req.PegMaxPrice != nil {
+		if req.PegReference == "" {
+			return errors.New("peg_reference is required with peg_offset, peg_min_price or peg_max_price")
+		}
+		if req.OrderType != "LIMIT" {
+			return fmt.Errorf("only LIMIT orders can be pegged, got %s", req.OrderType)
+		}
+		offset := 0.0
+		if req.PegOffset != nil {
+			offset = *req.PegOffset
+		}
+		if _, err := domain.NewPegInstruction(req.PegReference, offset, req.PegMinPrice, req.PegMaxPrice); err != nil {
+			return fmt.Errorf("invalid peg: %w", err)
+		}
+	}
+
+	if req.ProtectiveStopPercent != nil {
+		if req.OrderSide != "BUY" {
+			return errors.New("protective_stop_percent can only be sent with BUY orders")
+		}
+		if err := domain.ValidateProtectiveStopPercent(*req.ProtectiveStopPercent); err != nil {
+			return fmt.Errorf("invalid protective_stop_percent: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// loadUserOrderPreferences returns nil when the user has no defaults or they cannot be loaded,
+// so a preferences outage never blocks order submission
+func loadUserOrderPreferences(ctx context.Context, userID string, container di.Container) *domain.UserOrderPreferences {
+	preferencesRepo := container.GetUserOrderPreferencesRepository()
+	if preferencesRepo == nil {
+		return nil
+	}
+
+	preferences, err := preferencesRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load order preferences for user %s: %v\n", userID, err)
+		return nil
+	}
+
+	return preferences
+}
+
 func convertToOrderDetailsResponse(order *domain.Order) OrderDetailsResponse {
 	response := OrderDetailsResponse{
-		OrderID:        order.ID(),
-		UserID:         order.UserID(),
-		Symbol:         order.Symbol(),
-		OrderType:      order.OrderType().String(),
-		OrderSide:      order.OrderSide().String(),
-		Quantity:       order.Quantity(),
-		Price:          order.Price(),
-		Status:         order.Status().String(),
-		CreatedAt:      order.CreatedAt().Format(time.RFC3339),
-		UpdatedAt:      order.UpdatedAt().Format(time.RFC3339),
-		EstimatedValue: order.CalculateOrderValue(),
+		OrderID:            order.ID(),
+		UserID:             order.UserID(),
+		Symbol:             order.Symbol(),
+		OrderType:          order.OrderType().String(),
+		OrderSide:          order.OrderSide().String(),
+		Quantity:           order.Quantity(),
+		Price:              order.Price(),
+		Status:             order.Status().String(),
+		CreatedAt:          order.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:          order.UpdatedAt().Format(time.RFC3339),
+		EstimatedValue:     order.CalculateOrderValue(),
+		CancellationReason: order.CancellationReason().String(),
+		TriggerPrice:       order.TriggerPrice(),
+	}
+
+	if order.TriggeredAt() != nil {
+		triggeredAt := order.TriggeredAt().Format(time.RFC3339)
+		response.TriggeredAt = &triggeredAt
+	}
+
+	if instruction := order.SettlementInstruction(); instruction != nil {
+		response.SettlementAccount = instruction.Account
+		response.SettlementInstruction = instruction.Code.String()
+	}
+
+	if peg := order.PegInstruction(); peg != nil {
+		response.PegReference = peg.Reference.String()
+		response.PegOffset = &peg.Offset
+		response.PegMinPrice = peg.MinPrice
+		response.PegMaxPrice = peg.MaxPrice
 	}
 
+	if order.RepricedAt() != nil {
+		repricedAt := order.RepricedAt().Format(time.RFC3339)
+		response.RepricedAt = &repricedAt
+	}
+
+	response.ParentOrderID = order.ParentOrderID()
+
 	if order.ExecutedAt() != nil {
 		executedAt := order.ExecutedAt().Format(time.RFC3339)
 		response.ExecutedAt = &executedAt
@@ -176,10 +366,13 @@ func convertToOrderDetailsResponse(order *domain.Order) OrderDetailsResponse {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param X-Order-Schema-Version header int false "Payload schema version (1 or 2); defaults to ORDER_SCHEMA_DEFAULT_VERSION or the latest"
 // @Param order body SubmitOrderRequest true "Order details"
 // @Success 202 {object} SubmitOrderResponse "Order submitted successfully"
 // @Failure 400 {object} ErrorResponse "Bad request - Invalid order data"
 // @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Account balance below the minimum required to trade"
+// @Failure 409 {object} ErrorResponse "Possible duplicate of a recent open order, resubmit with acknowledge_duplicate"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /orders [post]
 func SubmitOrder(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
@@ -191,8 +384,12 @@ func SubmitOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 		return
 	}
 
-	var req SubmitOrderRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	req, err := decodeSubmitOrderRequest(r)
+	if errors.Is(err, ErrUnsupportedOrderSchemaVersion) {
+		writeErrorResponse(w, http.StatusBadRequest, "Unsupported Schema Version", err.Error())
+		return
+	}
+	if err != nil {
 		fmt.Printf("[DEBUG] JSON decode error: %v\n", err)
 		errorResponse := ErrorResponse{
 			Error:   "Invalid JSON",
@@ -206,7 +403,15 @@ func SubmitOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 
 	fmt.Printf("[DEBUG] Request decoded successfully: %+v\n", req)
 
-	if err := validateSubmitOrderRequest(&req); err != nil {
+	ctx := context.Background()
+	// Version 1 payloads predate user order defaults and always carry every field they use
+	ignoreUserDefaults := req.SchemaVersion < SubmitOrderSchemaV2
+	if ignoreUserDefaults && req.OrderType == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation Error", "order_type is required")
+		return
+	}
+
+	if err := validateSubmitOrderRequest(req); err != nil {
 		fmt.Printf("[DEBUG] Validation error: %v\n", err)
 		errorResponse := ErrorResponse{
 			Error:   "Validation Error",
@@ -222,21 +427,55 @@ func SubmitOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 
 	// Convert request to command
 	cmd := &command.SubmitOrderCommand{
-		UserID:    userID,
-		Symbol:    strings.ToUpper(req.Symbol),
-		OrderType: req.OrderType,
-		OrderSide: req.OrderSide,
-		Quantity:  req.Quantity,
-		Price:     req.Price,
+		UserID:           userID,
+		Symbol:           strings.ToUpper(req.Symbol),
+		OrderType:        req.OrderType,
+		OrderSide:        req.OrderSide,
+		Quantity:         req.Quantity,
+		Price:            req.Price,
+		TimeInForce:      req.TimeInForce,
+		AllowPartialFill: req.AllowPartialFill,
+		TriggerPrice:     req.TriggerPrice,
+
+		ExecutionStrategy:    req.ExecutionStrategy,
+		AcknowledgeDuplicate: req.AcknowledgeDuplicate,
+		AcknowledgeFatFinger: req.AcknowledgeFatFinger,
+
+		SettlementAccount:         req.SettlementAccount,
+		SettlementInstructionCode: req.SettlementInstructionCode,
+
+		PegReference: req.PegReference,
+		PegOffset:    req.PegOffset,
+		PegMinPrice:  req.PegMinPrice,
+		PegMaxPrice:  req.PegMaxPrice,
+
+		ProtectiveStopPercent: req.ProtectiveStopPercent,
+
+		IgnoreUserDefaults: ignoreUserDefaults,
 	}
 
 	fmt.Printf("[DEBUG] Command created: %+v\n", cmd)
 
-	ctx := context.Background()
 	fmt.Printf("[DEBUG] Calling SubmitOrderUseCase.Execute...\n")
 	result, err := container.GetSubmitOrderUseCase().Execute(ctx, cmd)
 	if err != nil {
 		fmt.Printf("[DEBUG] UseCase execution failed: %v\n", err)
+		if errors.Is(err, usecase.ErrAccountNotFunded) {
+			writeErrorResponse(w, http.StatusForbidden, "Account Not Funded", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrPossibleDuplicateOrder) {
+			writeErrorResponse(w, http.StatusConflict, "Possible Duplicate Order", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrFatFingerOrder) {
+			writeErrorResponse(w, http.StatusConflict, "Possible Fat-Finger Order", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrOrderTypeRequired) {
+			writeErrorResponse(w, http.StatusBadRequest, "Validation Error", err.Error())
+			return
+		}
 		errorResponse := ErrorResponse{
 			Error:   "Order Submission Failed",
 			Message: err.Error(),
@@ -249,19 +488,24 @@ func SubmitOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 
 	fmt.Printf("[DEBUG] UseCase execution successful: %+v\n", result)
 
+	precision := resolvePricePrecision(ctx, container, req.Symbol)
 	response := SubmitOrderResponse{
 		OrderID:     result.OrderID,
 		Status:      result.Status,
 		Message:     result.Message,
 		SubmittedAt: time.Now().Format(time.RFC3339),
+
+		ProtectiveStopOrderID: result.ProtectiveStopOrderID,
+		ProtectiveStopPrice:   precision.roundPtr(result.ProtectiveStopPrice),
+		PricePrecision:        precision.field(),
 	}
 
 	if result.EstimatedExecutionPrice != nil {
-		response.EstimatedPrice = *result.EstimatedExecutionPrice
+		response.EstimatedPrice = precision.round(*result.EstimatedExecutionPrice)
 	}
 
 	if result.MarketPriceAtSubmission != nil {
-		response.MarketPrice = *result.MarketPriceAtSubmission
+		response.MarketPrice = precision.round(*result.MarketPriceAtSubmission)
 	}
 
 	w.WriteHeader(http.StatusAccepted)
@@ -275,6 +519,7 @@ func SubmitOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Order ID"
+// @Param include_fills query bool false "Embed a summary of the order's fills"
 // @Success 200 {object} OrderDetailsResponse "Order details retrieved successfully"
 // @Failure 400 {object} ErrorResponse "Bad request - Invalid order ID"
 // @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
@@ -323,6 +568,7 @@ func GetOrderDetails(w http.ResponseWriter, r *http.Request, userID string, cont
 		return
 	}
 
+	precision := resolvePricePrecision(ctx, container, result.Symbol)
 	response := OrderDetailsResponse{
 		OrderID:                 result.OrderID,
 		UserID:                  result.UserID,
@@ -330,12 +576,32 @@ func GetOrderDetails(w http.ResponseWriter, r *http.Request, userID string, cont
 		OrderType:               result.OrderType,
 		OrderSide:               result.OrderSide,
 		Quantity:                result.Quantity,
-		Price:                   result.Price,
+		Price:                   precision.roundPtr(result.Price),
 		Status:                  result.Status,
 		CreatedAt:               result.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:               result.UpdatedAt.Format(time.RFC3339),
-		ExecutionPrice:          result.ExecutionPrice,
-		MarketPriceAtSubmission: result.MarketPriceAtSubmission,
+		ExecutionPrice:          precision.roundPtr(result.ExecutionPrice),
+		MarketPriceAtSubmission: precision.roundPtr(result.MarketPriceAtSubmission),
+		CancellationReason:      result.CancellationReason,
+		TriggerPrice:            precision.roundPtr(result.TriggerPrice),
+		SettlementAccount:       result.SettlementAccount,
+		SettlementInstruction:   result.SettlementInstructionCode,
+		PegReference:            result.PegReference,
+		PegOffset:               precision.roundPtr(result.PegOffset),
+		PegMinPrice:             precision.roundPtr(result.PegMinPrice),
+		PegMaxPrice:             precision.roundPtr(result.PegMaxPrice),
+		ParentOrderID:           result.ParentOrderID,
+		PricePrecision:          precision.field(),
+	}
+
+	if result.TriggeredAt != nil {
+		triggeredAt := result.TriggeredAt.Format(time.RFC3339)
+		response.TriggeredAt = &triggeredAt
+	}
+
+	if result.RepricedAt != nil {
+		repricedAt := result.RepricedAt.Format(time.RFC3339)
+		response.RepricedAt = &repricedAt
 	}
 
 	if result.ExecutedAt != nil {
@@ -351,9 +617,30 @@ func GetOrderDetails(w http.ResponseWriter, r *http.Request, userID string, cont
 	if result.EstimatedValue != nil {
 		response.EstimatedValue = *result.EstimatedValue
 	}
+
+	if r.URL.Query().Get("include_fills") == "true" {
+		response.FillSummary = getFillSummary(ctx, orderID, userID, container)
+	}
 	json.NewEncoder(w).Encode(response)
 }
 
+// getFillSummary returns the order's fill summary, or nil when fills are unavailable;
+// the order details are still returned without it
+func getFillSummary(ctx context.Context, orderID, userID string, container di.Container) *domain.OrderFillSummary {
+	fillsUseCase := container.GetOrderFillsUseCase()
+	if fillsUseCase == nil {
+		return nil
+	}
+
+	fills, err := fillsUseCase.Execute(ctx, orderID, userID)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get fills for order %s: %v\n", orderID, err)
+		return nil
+	}
+
+	return &fills.Summary
+}
+
 // GetOrderStatus handles order status retrieval
 // @Summary Get Order Status
 // @Description Retrieve the current status of a specific order
@@ -480,7 +767,7 @@ func CancelOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 	cmd := &command.CancelOrderCommand{
 		OrderID: orderID,
 		UserID:  userID,
-		Reason:  "User requested cancellation",
+		Reason:  string(command.CancellationReasonUserRequested),
 	}
 
 	ctx := context.Background()
@@ -528,6 +815,195 @@ func CancelOrder(w http.ResponseWriter, r *http.Request, userID string, containe
 	json.NewEncoder(w).Encode(response)
 }
 
+// PartialCancelOrder handles reducing an order's quantity without cancelling it
+// @Summary Partially Cancel Order
+// @Description Reduce the quantity of a pending order down to, at most, its filled quantity
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param request body PartialCancelOrderRequest true "New order quantity"
+// @Success 200 {object} PartialCancelOrderResponse "Order quantity reduced successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid order ID or quantity cannot be reduced"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /orders/{id}/reduce [put]
+func PartialCancelOrder(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract order ID from path like "/orders/{id}/reduce"
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[2] != "reduce" || parts[1] == "" {
+		errorResponse := ErrorResponse{
+			Error:   "Invalid Path",
+			Message: "Expected path format: /orders/{id}/reduce",
+			Code:    http.StatusBadRequest,
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse)
+		return
+	}
+
+	var req PartialCancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse := ErrorResponse{
+			Error:   "Invalid JSON",
+			Message: "Failed to parse request body",
+			Code:    http.StatusBadRequest,
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse)
+		return
+	}
+
+	cmd := &command.PartialCancelOrderCommand{
+		OrderID:     parts[1],
+		UserID:      userID,
+		NewQuantity: req.NewQuantity,
+	}
+
+	ctx := context.Background()
+	result, err := container.GetPartialCancelOrderUseCase().Execute(ctx, cmd)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			errorResponse := ErrorResponse{
+				Error:   "Order Not Found",
+				Message: err.Error(),
+				Code:    http.StatusNotFound,
+			}
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		if strings.Contains(err.Error(), "cannot be reduced") || strings.Contains(err.Error(), "invalid partial cancellation") {
+			errorResponse := ErrorResponse{
+				Error:   "Cannot Reduce Order",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		errorResponse := ErrorResponse{
+			Error:   "Failed to Reduce Order",
+			Message: err.Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(errorResponse)
+		return
+	}
+
+	response := PartialCancelOrderResponse{
+		OrderID:          result.OrderID,
+		Status:           result.Status,
+		Message:          result.Message,
+		PreviousQuantity: result.PreviousQuantity,
+		NewQuantity:      result.NewQuantity,
+		FilledQuantity:   result.FilledQuantity,
+		UpdatedAt:        result.Timestamp,
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetExecutionQuality handles execution quality report retrieval
+// @Summary Get Order Execution Quality
+// @Description Compare the estimated fill of an executed order with its actual fill. Positive slippage is adverse, negative slippage is price improvement.
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} ExecutionQualityResponse "Execution quality retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid order ID or order not executed"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} ErrorResponse "Order or report not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /orders/{id}/execution-quality [get]
+func GetExecutionQuality(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract order ID from path like "/orders/{id}/execution-quality"
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[2] != "execution-quality" || parts[1] == "" {
+		errorResponse := ErrorResponse{
+			Error:   "Invalid Path",
+			Message: "Expected path format: /orders/{id}/execution-quality",
+			Code:    http.StatusBadRequest,
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse)
+		return
+	}
+
+	ctx := context.Background()
+	report, err := container.GetExecutionQualityUseCase().Execute(ctx, parts[1], userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			errorResponse := ErrorResponse{
+				Error:   "Execution Quality Not Found",
+				Message: err.Error(),
+				Code:    http.StatusNotFound,
+			}
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		if strings.Contains(err.Error(), "only available for executed orders") {
+			errorResponse := ErrorResponse{
+				Error:   "Order Not Executed",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		errorResponse := ErrorResponse{
+			Error:   "Failed to Get Execution Quality",
+			Message: err.Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(errorResponse)
+		return
+	}
+
+	response := ExecutionQualityResponse{
+		OrderID:                  report.OrderID,
+		Symbol:                   report.Symbol,
+		OrderSide:                report.OrderSide.String(),
+		Quantity:                 report.Quantity,
+		EstimatedFillPrice:       report.EstimatedFillPrice,
+		ActualFillPrice:          report.ActualFillPrice,
+		Slippage:                 report.Slippage,
+		SlippageBps:              report.SlippageBps,
+		SlippageCost:             report.SlippageCost,
+		SlippageTolerancePercent: report.SlippageTolerancePercent,
+		Quality:                  report.Quality.String(),
+	}
+
+	if report.ExecutedAt != nil {
+		executedAt := report.ExecutedAt.Format(time.RFC3339)
+		response.ExecutedAt = &executedAt
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetOrderHistory handles order history retrieval
 // @Summary Get Order History
 // @Description Retrieve order history for the authenticated user
@@ -589,6 +1065,117 @@ func GetOrderDetailsWithAuth(verifyToken middleware.TokenVerifier, container di.
 	})
 }
 
+// GetExecutionQualityWithAuth returns a handler wrapped with authentication middleware
+func GetExecutionQualityWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetExecutionQuality(w, r, userID, container)
+	})
+}
+
+// GetOrderLatency handles retrieval of an order's submission stage timestamps
+// @Summary Get Order Latency
+// @Description Retrieve when an order was submitted, validated, priced, published and processed by a worker. Timelines are kept for recent orders only.
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} domain.OrderLatencyTimeline "Order latency retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid order ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} ErrorResponse "Order or latency timeline not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /orders/{id}/latency [get]
+func GetOrderLatency(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract order ID from path like "/orders/{id}/latency"
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[2] != "latency" || parts[1] == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid Path", "Expected path format: /orders/{id}/latency")
+		return
+	}
+
+	timeline, err := container.GetOrderLatencyUseCase().Execute(r.Context(), parts[1], userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Order Latency Not Found", err.Error())
+			return
+		}
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to Get Order Latency", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeline)
+}
+
+// GetOrderFills handles retrieval of the individual fills that composed an order
+// @Summary Get Order Fills
+// @Description Retrieve each fill of an order with its quantity, price and time, plus the fill count, filled quantity and average fill price
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} OrderFillsResponse "Order fills retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid order ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /orders/{id}/fills [get]
+func GetOrderFills(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract order ID from path like "/orders/{id}/fills"
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[2] != "fills" || parts[1] == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid Path", "Expected path format: /orders/{id}/fills")
+		return
+	}
+
+	fillsUseCase := container.GetOrderFillsUseCase()
+	if fillsUseCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Order Fills Unavailable", "order fill tracking is not enabled")
+		return
+	}
+
+	result, err := fillsUseCase.Execute(r.Context(), parts[1], userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Order Not Found", err.Error())
+			return
+		}
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to Get Order Fills", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OrderFillsResponse{
+		OrderID: result.OrderID,
+		Fills:   result.Fills,
+		Summary: result.Summary,
+	})
+}
+
+// GetOrderFillsWithAuth returns a handler wrapped with authentication middleware
+func GetOrderFillsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetOrderFills(w, r, userID, container)
+	})
+}
+
+// GetOrderLatencyWithAuth returns a handler wrapped with authentication middleware
+func GetOrderLatencyWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetOrderLatency(w, r, userID, container)
+	})
+}
+
 // GetOrderStatusWithAuth returns a handler wrapped with authentication middleware
 func GetOrderStatusWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
 	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
@@ -596,6 +1183,13 @@ func GetOrderStatusWithAuth(verifyToken middleware.TokenVerifier, container di.C
 	})
 }
 
+// PartialCancelOrderWithAuth returns a handler wrapped with authentication middleware
+func PartialCancelOrderWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		PartialCancelOrder(w, r, userID, container)
+	})
+}
+
 // CancelOrderWithAuth returns a handler wrapped with authentication middleware
 func CancelOrderWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
 	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
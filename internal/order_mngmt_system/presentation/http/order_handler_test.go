@@ -12,17 +12,25 @@ import (
 	"time"
 
 	"HubInvestments/internal/auth"
+	authSession "HubInvestments/internal/auth/session"
 	balUsecase "HubInvestments/internal/balance/application/usecase"
 	doLoginUsecase "HubInvestments/internal/login/application/usecase"
 	"HubInvestments/internal/order_mngmt_system/application/command"
 	orderUsecase "HubInvestments/internal/order_mngmt_system/application/usecase"
 	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	orderRepository "HubInvestments/internal/order_mngmt_system/domain/repository"
+	orderService "HubInvestments/internal/order_mngmt_system/domain/service"
 	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
+	orderMessaging "HubInvestments/internal/order_mngmt_system/infra/messaging"
 	orderRabbitMQ "HubInvestments/internal/order_mngmt_system/infra/messaging/rabbitmq"
+	orderNotification "HubInvestments/internal/order_mngmt_system/infra/notification"
+	orderSession "HubInvestments/internal/order_mngmt_system/infra/session"
 	orderWorker "HubInvestments/internal/order_mngmt_system/infra/worker"
 	portfolioUsecase "HubInvestments/internal/portfolio_summary/application/usecase"
 	posUsecase "HubInvestments/internal/position/application/usecase"
+	positionNotification "HubInvestments/internal/position/infra/notification"
 	positionWorker "HubInvestments/internal/position/infra/worker"
+	symbolUsecase "HubInvestments/internal/symbol_universe/application/usecase"
 	watchlistUsecase "HubInvestments/internal/watchlist/application/usecase"
 	"HubInvestments/shared/infra/messaging"
 	"HubInvestments/shared/infra/websocket"
@@ -30,13 +38,29 @@ import (
 
 // MockContainer implements the Container interface for testing
 type MockContainer struct {
-	submitOrderUseCase    MockSubmitOrderUseCase
-	getOrderStatusUseCase MockGetOrderStatusUseCase
-	cancelOrderUseCase    MockCancelOrderUseCase
+	submitOrderUseCase       MockSubmitOrderUseCase
+	getOrderStatusUseCase    MockGetOrderStatusUseCase
+	cancelOrderUseCase       MockCancelOrderUseCase
+	orderPreferencesRepo     orderRepository.IUserOrderPreferencesRepository
+	checkOrderRiskUseCase    orderUsecase.ICheckOrderRiskUseCase
+	suggestSizeUseCase       orderUsecase.ISuggestOrderSizeUseCase
+	suggestLimitPriceUseCase orderUsecase.ISuggestLimitPriceUseCase
+	rejectedOrdersUseCase    orderUsecase.IGetRejectedOrdersUseCase
+	orderLatencyUseCase      orderUsecase.IGetOrderLatencyUseCase
+	orderFillsUseCase        orderUsecase.IGetOrderFillsUseCase
+	riskProfileUseCase       orderUsecase.IUpdateUserRiskProfileUseCase
+	forceCancelUseCase       orderUsecase.IForceCancelOrderUseCase
+	quoteHistoryUseCase      orderUsecase.IGetQuoteHistoryUseCase
+	quoteSnapshotUseCase     orderUsecase.IGetQuoteSnapshotUseCase
+	latencyTracker           orderService.OrderLatencyTracker
+	pipelineMetrics          orderService.OrderPipelineMetrics
+	marketDataFreshness      *orderMktClient.MarketDataFreshnessMonitor
+	pricePrecision           orderMktClient.IPricePrecisionResolver
 }
 
 func (m *MockContainer) DoLoginUsecase() doLoginUsecase.IDoLoginUsecase { return nil }
 func (m *MockContainer) GetAuthService() auth.IAuthService              { return nil }
+func (m *MockContainer) GetSessionService() authSession.ISessionService { return nil }
 func (m *MockContainer) GetPositionAggregationUseCase() *posUsecase.GetPositionAggregationUseCase {
 	return nil
 }
@@ -61,10 +85,98 @@ func (m *MockContainer) GetCancelOrderUseCase() orderUsecase.ICancelOrderUseCase
 	return &m.cancelOrderUseCase
 }
 
+func (m *MockContainer) GetPartialCancelOrderUseCase() orderUsecase.IPartialCancelOrderUseCase {
+	return nil
+}
+
 func (m *MockContainer) GetProcessOrderUseCase() orderUsecase.IProcessOrderUseCase {
 	return nil
 }
 
+func (m *MockContainer) GetExecutionQualityUseCase() orderUsecase.IGetExecutionQualityUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetCheckOrderRiskUseCase() orderUsecase.ICheckOrderRiskUseCase {
+	return m.checkOrderRiskUseCase
+}
+
+func (m *MockContainer) GetSuggestOrderSizeUseCase() orderUsecase.ISuggestOrderSizeUseCase {
+	return m.suggestSizeUseCase
+}
+
+func (m *MockContainer) GetSuggestLimitPriceUseCase() orderUsecase.ISuggestLimitPriceUseCase {
+	return m.suggestLimitPriceUseCase
+}
+
+func (m *MockContainer) GetRejectedOrdersUseCase() orderUsecase.IGetRejectedOrdersUseCase {
+	return m.rejectedOrdersUseCase
+}
+
+func (m *MockContainer) GetUpdateUserRiskProfileUseCase() orderUsecase.IUpdateUserRiskProfileUseCase {
+	return m.riskProfileUseCase
+}
+
+func (m *MockContainer) GetForceCancelOrderUseCase() orderUsecase.IForceCancelOrderUseCase {
+	return m.forceCancelUseCase
+}
+
+func (m *MockContainer) GetActivateIfTouchedOrdersUseCase() orderUsecase.IActivateIfTouchedOrdersUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetRepricePeggedOrdersUseCase() orderUsecase.IRepricePeggedOrdersUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetQuoteHistoryUseCase() orderUsecase.IGetQuoteHistoryUseCase {
+	return m.quoteHistoryUseCase
+}
+
+func (m *MockContainer) GetQuoteSnapshotUseCase() orderUsecase.IGetQuoteSnapshotUseCase {
+	return m.quoteSnapshotUseCase
+}
+
+func (m *MockContainer) GetQuoteSnapshotCache() orderService.QuoteSnapshotCache {
+	return nil
+}
+
+func (m *MockContainer) GetOrderLatencyUseCase() orderUsecase.IGetOrderLatencyUseCase {
+	return m.orderLatencyUseCase
+}
+
+func (m *MockContainer) GetOrderFillsUseCase() orderUsecase.IGetOrderFillsUseCase {
+	return m.orderFillsUseCase
+}
+
+func (m *MockContainer) GetOrderLatencyTracker() orderService.OrderLatencyTracker {
+	return m.latencyTracker
+}
+
+func (m *MockContainer) GetOrderPipelineMetrics() orderService.OrderPipelineMetrics {
+	return m.pipelineMetrics
+}
+
+func (m *MockContainer) GetMarketDataFreshnessMonitor() *orderMktClient.MarketDataFreshnessMonitor {
+	return m.marketDataFreshness
+}
+
+func (m *MockContainer) GetQuoteFeed() *orderMessaging.QuoteFeed {
+	return nil
+}
+
+func (m *MockContainer) GetPricePrecisionResolver() orderMktClient.IPricePrecisionResolver {
+	return m.pricePrecision
+}
+
+func (m *MockContainer) GetUserOrderPreferencesRepository() orderRepository.IUserOrderPreferencesRepository {
+	return m.orderPreferencesRepo
+}
+
+func (m *MockContainer) GetOrderNotificationPreferencesRepository() orderRepository.IOrderNotificationPreferencesRepository {
+	return nil
+}
+
 func (m *MockContainer) GetOrderProducer() *orderRabbitMQ.OrderProducer {
 	return nil
 }
@@ -73,10 +185,42 @@ func (m *MockContainer) GetOrderWorkerManager() *orderWorker.WorkerManager {
 	return nil
 }
 
+func (m *MockContainer) GetTradingLimitResetJob() *orderWorker.TradingLimitResetJob {
+	return nil
+}
+
+func (m *MockContainer) GetOrderExpiryJob() *orderWorker.OrderExpiryJob {
+	return nil
+}
+
+func (m *MockContainer) GetCancelOnDisconnectMonitor() *orderSession.CancelOnDisconnectMonitor {
+	return nil
+}
+
+func (m *MockContainer) GetOrderNotificationSender() *orderNotification.WebSocketOrderNotificationSender {
+	return nil
+}
+
 func (m *MockContainer) GetPositionWorkerManager() *positionWorker.PositionUpdateWorker {
 	return nil
 }
 
+func (m *MockContainer) GetPositionReconciliationReporter() *positionWorker.PositionReconciliationReporter {
+	return nil
+}
+
+func (m *MockContainer) GetMarkToMarketJob() *positionWorker.MarkToMarketJob {
+	return nil
+}
+
+func (m *MockContainer) GetSyncSymbolUniverseUseCase() symbolUsecase.ISyncSymbolUniverseUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetSearchSymbolsUseCase() symbolUsecase.ISearchSymbolsUseCase {
+	return nil
+}
+
 func (m *MockContainer) GetCreatePositionUseCase() posUsecase.ICreatePositionUseCase {
 	return nil
 }
@@ -89,6 +233,30 @@ func (m *MockContainer) GetClosePositionUseCase() posUsecase.IClosePositionUseCa
 	return nil
 }
 
+func (m *MockContainer) GetClosePreviewUseCase() posUsecase.IGetClosePreviewUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetRevaluePositionsUseCase() posUsecase.IRevaluePositionsUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetManagePnLAlertsUseCase() posUsecase.IManagePnLAlertsUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetEvaluatePnLAlertsUseCase() posUsecase.IEvaluatePnLAlertsUseCase {
+	return nil
+}
+
+func (m *MockContainer) GetPnLAlertNotifier() *positionNotification.WebSocketPnLAlertNotifier {
+	return nil
+}
+
+func (m *MockContainer) GetDailyPnLUseCase() posUsecase.IGetDailyPnLUseCase {
+	return nil
+}
+
 func (m *MockContainer) GetWebSocketManager() websocket.WebSocketManager {
 	return nil
 }
@@ -109,6 +277,20 @@ func (m *MockSubmitOrderUseCase) Execute(ctx context.Context, cmd *command.Submi
 	}, nil
 }
 
+// MockUserOrderPreferencesRepository implements IUserOrderPreferencesRepository for testing
+type MockUserOrderPreferencesRepository struct {
+	preferences map[string]*domain.UserOrderPreferences
+}
+
+func (m *MockUserOrderPreferencesRepository) Save(ctx context.Context, preferences *domain.UserOrderPreferences) error {
+	m.preferences[preferences.UserID] = preferences
+	return nil
+}
+
+func (m *MockUserOrderPreferencesRepository) FindByUserID(ctx context.Context, userID string) (*domain.UserOrderPreferences, error) {
+	return m.preferences[userID], nil
+}
+
 // MockGetOrderStatusUseCase implements IGetOrderStatusUseCase for testing
 type MockGetOrderStatusUseCase struct {
 	ExecuteFunc func(ctx context.Context, orderID, userID string) (*orderUsecase.OrderStatusResult, error)
@@ -276,6 +458,180 @@ func TestSubmitOrder_UseCaseError(t *testing.T) {
 	}
 }
 
+func TestSubmitOrder_UnfundedAccount(t *testing.T) {
+	container := &MockContainer{
+		submitOrderUseCase: MockSubmitOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+				return nil, fmt.Errorf("%w: available balance 0.00 is below the minimum of 100.00", orderUsecase.ErrAccountNotFunded)
+			},
+		},
+	}
+
+	requestBody := SubmitOrderRequest{
+		Symbol:    "AAPL",
+		OrderType: "MARKET",
+		OrderSide: "BUY",
+		Quantity:  10,
+	}
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	handler := SubmitOrderWithAuth(mockTokenVerifier, container)
+	handler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Error != "Account Not Funded" {
+		t.Errorf("Expected a funding error, got %+v", response)
+	}
+}
+
+func TestSubmitOrder_PossibleDuplicate(t *testing.T) {
+	acknowledged := false
+	container := &MockContainer{
+		submitOrderUseCase: MockSubmitOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+				if cmd.AcknowledgeDuplicate {
+					acknowledged = true
+					return &command.SubmitOrderResult{OrderID: "order-2", Status: "PENDING", Message: "Order submitted"}, nil
+				}
+				return nil, fmt.Errorf("%w: open order order-1 placed moments ago", orderUsecase.ErrPossibleDuplicateOrder)
+			},
+		},
+	}
+
+	submit := func(requestBody SubmitOrderRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(requestBody)
+		req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer valid-token")
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		SubmitOrderWithAuth(mockTokenVerifier, container)(w, req)
+		return w
+	}
+
+	requestBody := SubmitOrderRequest{
+		Symbol:    "AAPL",
+		OrderType: "MARKET",
+		OrderSide: "BUY",
+		Quantity:  10,
+	}
+
+	w := submit(requestBody)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Error != "Possible Duplicate Order" {
+		t.Errorf("Expected a duplicate warning, got %+v", response)
+	}
+
+	requestBody.AcknowledgeDuplicate = true
+	w = submit(requestBody)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d for the acknowledged order, got %d", http.StatusAccepted, w.Code)
+	}
+	if !acknowledged {
+		t.Error("Expected the acknowledgement to reach the use case")
+	}
+}
+
+func submitOrderWithPreferences(t *testing.T, requestBody SubmitOrderRequest, preferences *domain.UserOrderPreferences) *command.SubmitOrderCommand {
+	var submitted *command.SubmitOrderCommand
+	container := &MockContainer{
+		submitOrderUseCase: MockSubmitOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+				submitted = cmd
+				return &command.SubmitOrderResult{OrderID: "test-order-id", Status: "PENDING"}, nil
+			},
+		},
+		orderPreferencesRepo: &MockUserOrderPreferencesRepository{
+			preferences: map[string]*domain.UserOrderPreferences{preferences.UserID: preferences},
+		},
+	}
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	handler := SubmitOrderWithAuth(mockTokenVerifier, container)
+	handler(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	if submitted == nil {
+		t.Fatal("Expected the order to be submitted")
+	}
+	return submitted
+}
+
+func TestSubmitOrder_LeavesUserDefaultsToUseCase(t *testing.T) {
+	allowPartialFill := false
+	preferences := &domain.UserOrderPreferences{
+		UserID:             "test-user-id",
+		DefaultOrderType:   domain.OrderTypeMarket,
+		DefaultTimeInForce: domain.TimeInForceGTC,
+		AllowPartialFill:   &allowPartialFill,
+	}
+
+	cmd := submitOrderWithPreferences(t, SubmitOrderRequest{
+		Symbol:    "AAPL",
+		OrderSide: "BUY",
+		Quantity:  100,
+	}, preferences)
+
+	if cmd.OrderType != "" || cmd.TimeInForce != "" || cmd.AllowPartialFill != nil {
+		t.Errorf("Expected the omitted fields to reach the use case unset, got %+v", cmd)
+	}
+	if cmd.IgnoreUserDefaults {
+		t.Error("Expected user defaults to apply to a v2 payload")
+	}
+}
+
+func TestSubmitOrder_MissingOrderTypeWithoutDefault(t *testing.T) {
+	container := &MockContainer{
+		submitOrderUseCase: MockSubmitOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+				return nil, fmt.Errorf("%w: send order_type or set a default order type", orderUsecase.ErrOrderTypeRequired)
+			},
+		},
+		orderPreferencesRepo: &MockUserOrderPreferencesRepository{preferences: map[string]*domain.UserOrderPreferences{}},
+	}
+
+	body, _ := json.Marshal(SubmitOrderRequest{Symbol: "AAPL", OrderSide: "BUY", Quantity: 100})
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	handler := SubmitOrderWithAuth(mockTokenVerifier, container)
+	handler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestGetOrderDetails_Success(t *testing.T) {
 	container := &MockContainer{}
 
@@ -493,6 +849,41 @@ func TestValidateSubmitOrderRequest_InvalidOrderType(t *testing.T) {
 	}
 }
 
+func TestValidateSubmitOrderRequest_SettlementInstruction(t *testing.T) {
+	tests := []struct {
+		name      string
+		account   string
+		code      string
+		expectErr bool
+	}{
+		{name: "valid instruction", account: "CUST-00123", code: "DVP"},
+		{name: "invalid code", account: "CUST-00123", code: "WIRE", expectErr: true},
+		{name: "code without account", code: "FOP", expectErr: true},
+		{name: "account without code", account: "CUST-00123", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SubmitOrderRequest{
+				Symbol:                    "AAPL",
+				OrderType:                 "MARKET",
+				OrderSide:                 "BUY",
+				Quantity:                  100,
+				SettlementAccount:         tt.account,
+				SettlementInstructionCode: tt.code,
+			}
+
+			err := validateSubmitOrderRequest(req)
+			if tt.expectErr && err == nil {
+				t.Error("Expected error for invalid settlement instruction")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
 func TestValidateSubmitOrderRequest_LimitOrderWithoutPrice(t *testing.T) {
 	req := &SubmitOrderRequest{
 		Symbol:    "AAPL",
@@ -619,3 +1010,109 @@ func TestAuthenticationMiddleware_InvalidToken(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
 	}
 }
+
+type MockGetOrderFillsUseCase struct {
+	ExecuteFunc func(ctx context.Context, orderID, userID string) (*orderUsecase.OrderFillsResult, error)
+}
+
+func (m *MockGetOrderFillsUseCase) Execute(ctx context.Context, orderID, userID string) (*orderUsecase.OrderFillsResult, error) {
+	return m.ExecuteFunc(ctx, orderID, userID)
+}
+
+func newThreePartialFillsUseCase() *MockGetOrderFillsUseCase {
+	return &MockGetOrderFillsUseCase{
+		ExecuteFunc: func(ctx context.Context, orderID, userID string) (*orderUsecase.OrderFillsResult, error) {
+			start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+			fills := []domain.OrderFill{
+				{ID: "fill-1", OrderID: orderID, Quantity: 30, Price: 99.50, FilledAt: start},
+				{ID: "fill-2", OrderID: orderID, Quantity: 50, Price: 99.75, FilledAt: start.Add(time.Second)},
+				{ID: "fill-3", OrderID: orderID, Quantity: 20, Price: 100.00, FilledAt: start.Add(2 * time.Second)},
+			}
+			return &orderUsecase.OrderFillsResult{OrderID: orderID, Fills: fills, Summary: domain.SummarizeFills(fills)}, nil
+		},
+	}
+}
+
+func TestGetOrderFills_ReturnsEachFill(t *testing.T) {
+	container := &MockContainer{orderFillsUseCase: newThreePartialFillsUseCase()}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/test-order-id/fills", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetOrderFillsWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response OrderFillsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	expected := []struct {
+		quantity float64
+		price    float64
+	}{{30, 99.50}, {50, 99.75}, {20, 100.00}}
+	if len(response.Fills) != len(expected) {
+		t.Fatalf("Expected %d fills, got %d", len(expected), len(response.Fills))
+	}
+	for i, fill := range response.Fills {
+		if fill.Quantity != expected[i].quantity || fill.Price != expected[i].price {
+			t.Errorf("Expected fill %d to be %.0f @ %.2f, got %.0f @ %.2f", i, expected[i].quantity, expected[i].price, fill.Quantity, fill.Price)
+		}
+	}
+	if response.Summary.FillCount != 3 || response.Summary.FilledQuantity != 100 {
+		t.Errorf("Expected a summary of 3 fills totalling 100, got %+v", response.Summary)
+	}
+}
+
+func TestGetOrderFills_OrderNotFound(t *testing.T) {
+	container := &MockContainer{orderFillsUseCase: &MockGetOrderFillsUseCase{
+		ExecuteFunc: func(ctx context.Context, orderID, userID string) (*orderUsecase.OrderFillsResult, error) {
+			return nil, fmt.Errorf("order not found")
+		},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/other-order/fills", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetOrderFillsWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetOrderDetails_IncludeFillsEmbedsSummary(t *testing.T) {
+	container := &MockContainer{orderFillsUseCase: newThreePartialFillsUseCase()}
+
+	for _, includeFills := range []bool{false, true} {
+		url := "/orders/test-order-id"
+		if includeFills {
+			url += "?include_fills=true"
+		}
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		w := httptest.NewRecorder()
+
+		GetOrderDetailsWithAuth(mockTokenVerifier, container)(w, req)
+
+		var response OrderDetailsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if !includeFills {
+			if response.FillSummary != nil {
+				t.Errorf("Expected no fill summary unless requested, got %+v", response.FillSummary)
+			}
+			continue
+		}
+		if response.FillSummary == nil || response.FillSummary.FillCount != 3 {
+			t.Errorf("Expected a summary of 3 fills, got %+v", response.FillSummary)
+		}
+	}
+}
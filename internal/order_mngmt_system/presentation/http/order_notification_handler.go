@@ -0,0 +1,59 @@
+package http
+
+import (
+	"log"
+	"net/http"
+
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+// OrderNotificationStream pushes the user's in-app order notifications over a WebSocket
+// @Summary Stream Order Notifications
+// @Description Upgrade to a WebSocket that receives an order_notification message for each order event the user opted in to with the IN_APP channel. Users without stored preferences receive fills, cancellations and rejections.
+// @Tags Orders
+// @Security BearerAuth
+// @Success 101 "Switching protocols"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 503 {object} ErrorResponse "Order notification streaming unavailable"
+// @Router /orders/notifications/stream [get]
+func OrderNotificationStream(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sender := container.GetOrderNotificationSender()
+	webSocketManager := container.GetWebSocketManager()
+	if sender == nil || webSocketManager == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "order notification streaming is not available")
+		return
+	}
+
+	conn, err := webSocketManager.CreateConnection(w, r)
+	if err != nil {
+		log.Printf("Failed to open order notification stream for user %s: %v", userID, err)
+		return
+	}
+	defer conn.Close()
+
+	connectionID := uuid.New().String()
+	sender.Connect(userID, connectionID, conn)
+	defer sender.Disconnect(userID, connectionID)
+
+	// Notifications only flow to the client; reading detects when it goes away
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// OrderNotificationStreamWithAuth returns a handler wrapped with authentication middleware
+func OrderNotificationStreamWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		OrderNotificationStream(w, r, userID, container)
+	})
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Order submission payload versions. Version 1 is the original flat order; version 2 adds user
+// defaults, time in force, partial fills, trigger prices, execution strategies, duplicate acknowledgement,
+// explicit settlement instructions, pegged limit orders and protective stops.
+const (
+	SubmitOrderSchemaV1 = 1
+	SubmitOrderSchemaV2 = 2
+
+	latestSubmitOrderSchemaVersion = SubmitOrderSchemaV2
+
+	// OrderSchemaVersionHeader carries the payload version; a schema_version body field may be sent instead
+	OrderSchemaVersionHeader = "X-Order-Schema-Version"
+)
+
+var ErrUnsupportedOrderSchemaVersion = errors.New("unsupported order schema version")
+
+// SubmitOrderRequestV1 is the original order submission payload. Every field is sent explicitly
+// and fields added in later versions are rejected.
+type SubmitOrderRequestV1 struct {
+	SchemaVersion int      `json:"schema_version,omitempty"`
+	Symbol        string   `json:"symbol" validate:"required"`
+	OrderType     string   `json:"order_type" validate:"required,oneof=MARKET LIMIT STOP_LOSS STOP_LIMIT"`
+	OrderSide     string   `json:"order_side" validate:"required,oneof=BUY SELL"`
+	Quantity      float64  `json:"quantity" validate:"required,gt=0"`
+	Price         *float64 `json:"price,omitempty"`
+}
+
+func (req *SubmitOrderRequestV1) toLatest() *SubmitOrderRequest {
+	return &SubmitOrderRequest{
+		SchemaVersion: SubmitOrderSchemaV1,
+		Symbol:        req.Symbol,
+		OrderType:     req.OrderType,
+		OrderSide:     req.OrderSide,
+		Quantity:      req.Quantity,
+		Price:         req.Price,
+	}
+}
+
+// defaultSubmitOrderSchemaVersion is the version assumed for requests that do not state one. It is
+// read from ORDER_SCHEMA_DEFAULT_VERSION so unversioned clients can be pinned while they migrate.
+func defaultSubmitOrderSchemaVersion() int {
+	if version, err := strconv.Atoi(os.Getenv("ORDER_SCHEMA_DEFAULT_VERSION")); err == nil && isSupportedSubmitOrderSchemaVersion(version) {
+		return version
+	}
+	return latestSubmitOrderSchemaVersion
+}
+
+func isSupportedSubmitOrderSchemaVersion(version int) bool {
+	return version >= SubmitOrderSchemaV1 && version <= latestSubmitOrderSchemaVersion
+}
+
+// resolveSubmitOrderSchemaVersion picks the payload version from the header or the body's
+// schema_version field. When both are sent they must agree.
+func resolveSubmitOrderSchemaVersion(r *http.Request, body []byte) (int, error) {
+	var envelope struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return 0, err
+	}
+
+	version := 0
+	if header := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(r.Header.Get(OrderSchemaVersionHeader))), "v"); header != "" {
+		parsed, err := strconv.Atoi(header)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", ErrUnsupportedOrderSchemaVersion, r.Header.Get(OrderSchemaVersionHeader))
+		}
+		version = parsed
+	}
+
+	if envelope.SchemaVersion != nil {
+		if version != 0 && version != *envelope.SchemaVersion {
+			return 0, fmt.Errorf("%w: header states version %d but body states version %d",
+				ErrUnsupportedOrderSchemaVersion, version, *envelope.SchemaVersion)
+		}
+		version = *envelope.SchemaVersion
+	}
+
+	if version == 0 {
+		return defaultSubmitOrderSchemaVersion(), nil
+	}
+
+	if !isSupportedSubmitOrderSchemaVersion(version) {
+		return 0, fmt.Errorf("%w: %d (supported versions are %d to %d)",
+			ErrUnsupportedOrderSchemaVersion, version, SubmitOrderSchemaV1, latestSubmitOrderSchemaVersion)
+	}
+
+	return version, nil
+}
+
+// decodeSubmitOrderRequest parses an order submission of any supported version into the latest
+// request shape. Unsupported versions fail with ErrUnsupportedOrderSchemaVersion.
+func decodeSubmitOrderRequest(r *http.Request) (*SubmitOrderRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := resolveSubmitOrderSchemaVersion(r, body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch version {
+	case SubmitOrderSchemaV1:
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.DisallowUnknownFields()
+
+		var req SubmitOrderRequestV1
+		if err := decoder.Decode(&req); err != nil {
+			return nil, fmt.Errorf("schema version 1: %w", err)
+		}
+		if req.OrderType == "MARKET_IF_TOUCHED" || req.OrderType == "LIMIT_IF_TOUCHED" {
+			return nil, fmt.Errorf("schema version 1: order_type %s requires schema version 2", req.OrderType)
+		}
+		return req.toLatest(), nil
+	default:
+		var req SubmitOrderRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, err
+		}
+		req.SchemaVersion = version
+		return &req, nil
+	}
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+func submitVersionedOrder(t *testing.T, version, body string) (*httptest.ResponseRecorder, *command.SubmitOrderCommand) {
+	var submitted *command.SubmitOrderCommand
+	allowPartialFill := false
+	container := &MockContainer{
+		submitOrderUseCase: MockSubmitOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+				submitted = cmd
+				return &command.SubmitOrderResult{OrderID: "test-order-id", Status: "PENDING"}, nil
+			},
+		},
+		orderPreferencesRepo: &MockUserOrderPreferencesRepository{
+			preferences: map[string]*domain.UserOrderPreferences{"test-user-id": {
+				UserID:             "test-user-id",
+				DefaultOrderType:   domain.OrderTypeMarket,
+				DefaultTimeInForce: domain.TimeInForceGTC,
+				AllowPartialFill:   &allowPartialFill,
+			}},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("Content-Type", "application/json")
+	if version != "" {
+		req.Header.Set(OrderSchemaVersionHeader, version)
+	}
+
+	w := httptest.NewRecorder()
+	SubmitOrderWithAuth(mockTokenVerifier, container)(w, req)
+	return w, submitted
+}
+
+func TestSubmitOrder_SchemaV1Payload(t *testing.T) {
+	w, cmd := submitVersionedOrder(t, "1",
+		`{"symbol":"aapl","order_type":"LIMIT","order_side":"BUY","quantity":10,"price":150.5}`)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	if cmd.Symbol != "AAPL" || cmd.OrderType != "LIMIT" || cmd.Quantity != 10 || cmd.Price == nil || *cmd.Price != 150.5 {
+		t.Errorf("Unexpected command from v1 payload: %+v", cmd)
+	}
+	if !cmd.IgnoreUserDefaults {
+		t.Errorf("Expected user defaults to be ignored for a v1 payload, got %+v", cmd)
+	}
+}
+
+func TestSubmitOrder_SchemaV2Payload(t *testing.T) {
+	w, cmd := submitVersionedOrder(t, "",
+		`{"schema_version":2,"symbol":"MSFT","order_side":"SELL","quantity":5,"order_type":"MARKET_IF_TOUCHED","trigger_price":310,"acknowledge_duplicate":true}`)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	if cmd.OrderType != "MARKET_IF_TOUCHED" || cmd.TriggerPrice == nil || *cmd.TriggerPrice != 310 || !cmd.AcknowledgeDuplicate {
+		t.Errorf("Unexpected command from v2 payload: %+v", cmd)
+	}
+	if cmd.IgnoreUserDefaults {
+		t.Error("Expected user defaults to apply to a v2 payload")
+	}
+}
+
+func TestSubmitOrder_SchemaV1RejectsNewerFields(t *testing.T) {
+	w, cmd := submitVersionedOrder(t, "1",
+		`{"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10,"time_in_force":"IOC"}`)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if cmd != nil {
+		t.Error("Expected the order not to be submitted")
+	}
+}
+
+func TestSubmitOrder_UnsupportedSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		body    string
+	}{
+		{"Header version", "3", `{"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10}`},
+		{"Body version", "", `{"schema_version":7,"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10}`},
+		{"Non-numeric header", "beta", `{"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10}`},
+		{"Conflicting versions", "1", `{"schema_version":2,"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, cmd := submitVersionedOrder(t, tt.version, tt.body)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			var response ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Error != "Unsupported Schema Version" {
+				t.Errorf("Expected an unsupported version error, got %+v", response)
+			}
+			if cmd != nil {
+				t.Error("Expected the order not to be submitted")
+			}
+		})
+	}
+}
+
+func TestSubmitOrder_DefaultSchemaVersionFromEnv(t *testing.T) {
+	t.Setenv("ORDER_SCHEMA_DEFAULT_VERSION", "1")
+
+	w, _ := submitVersionedOrder(t, "",
+		`{"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10,"trigger_price":150}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected unversioned requests to be parsed as v1, got status %d", w.Code)
+	}
+}
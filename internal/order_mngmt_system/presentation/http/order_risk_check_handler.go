@@ -0,0 +1,259 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+type OrderRiskCheckResponse struct {
+	RiskLevel        string               `json:"risk_level"`
+	RiskScore        float64              `json:"risk_score"`
+	IsApproved       bool                 `json:"is_approved"`
+	RequiresApproval bool                 `json:"requires_approval"`
+	Degraded         bool                 `json:"degraded,omitempty"`
+	RiskFactors      []RiskFactorResponse `json:"risk_factors"`
+	Recommendations  []string             `json:"recommendations"`
+	Warnings         []string             `json:"warnings"`
+	AssessedAt       string               `json:"assessed_at"`
+}
+
+type RiskFactorResponse struct {
+	Factor      string  `json:"factor"`
+	Impact      string  `json:"impact"`
+	Score       float64 `json:"score"`
+	Description string  `json:"description"`
+}
+
+func riskLevelName(level service.RiskLevel) string {
+	switch level {
+	case service.RiskLevelLow:
+		return "LOW"
+	case service.RiskLevelMedium:
+		return "MEDIUM"
+	case service.RiskLevelHigh:
+		return "HIGH"
+	case service.RiskLevelVeryHigh:
+		return "VERY_HIGH"
+	case service.RiskLevelExtremelyHigh:
+		return "EXTREMELY_HIGH"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func riskImpactName(impact service.RiskImpact) string {
+	switch impact {
+	case service.RiskImpactLow:
+		return "LOW"
+	case service.RiskImpactMedium:
+		return "MEDIUM"
+	case service.RiskImpactHigh:
+		return "HIGH"
+	case service.RiskImpactCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func convertToOrderRiskCheckResponse(assessment *service.RiskAssessment) OrderRiskCheckResponse {
+	response := OrderRiskCheckResponse{
+		RiskLevel:        riskLevelName(assessment.RiskLevel),
+		RiskScore:        assessment.RiskScore,
+		IsApproved:       assessment.IsApproved,
+		RequiresApproval: assessment.RequiresApproval,
+		Degraded:         assessment.Degraded,
+		RiskFactors:      make([]RiskFactorResponse, 0, len(assessment.RiskFactors)),
+		Recommendations:  assessment.Recommendations,
+		Warnings:         assessment.Warnings,
+		AssessedAt:       assessment.AssessmentTime.Format(time.RFC3339),
+	}
+
+	for _, factor := range assessment.RiskFactors {
+		response.RiskFactors = append(response.RiskFactors, RiskFactorResponse{
+			Factor:      factor.Factor,
+			Impact:      riskImpactName(factor.Impact),
+			Score:       factor.Score,
+			Description: factor.Description,
+		})
+	}
+
+	return response
+}
+
+// CheckOrderRisk handles risk-only order checks
+// @Summary Check Order Risk
+// @Description Run only the risk assessment for a prospective order and return the verdict. No pricing or market data validation is performed and no order is created.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order body SubmitOrderRequest true "Order to assess"
+// @Success 200 {object} OrderRiskCheckResponse "Risk assessment completed"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid order data"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Risk checks unavailable"
+// @Router /orders/risk-check [post]
+func CheckOrderRisk(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	useCase := container.GetCheckOrderRiskUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "risk checks are not available")
+		return
+	}
+
+	var req SubmitOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if err := validateSubmitOrderRequest(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation Error", err.Error())
+		return
+	}
+
+	cmd := &command.SubmitOrderCommand{
+		UserID:           userID,
+		Symbol:           strings.ToUpper(req.Symbol),
+		OrderType:        req.OrderType,
+		OrderSide:        req.OrderSide,
+		Quantity:         req.Quantity,
+		Price:            req.Price,
+		TimeInForce:      req.TimeInForce,
+		AllowPartialFill: req.AllowPartialFill,
+	}
+
+	ctx := context.Background()
+	usecase.ApplyUserOrderDefaults(cmd, loadUserOrderPreferences(ctx, userID, container))
+	if cmd.OrderType == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation Error", "order_type is required")
+		return
+	}
+
+	assessment, err := useCase.Execute(ctx, cmd)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Risk Check Failed", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convertToOrderRiskCheckResponse(assessment))
+}
+
+// CheckOrderRiskWithAuth returns a handler wrapped with authentication middleware
+func CheckOrderRiskWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		CheckOrderRisk(w, r, userID, container)
+	})
+}
+
+type SuggestOrderSizeRequest struct {
+	Symbol          string   `json:"symbol"`
+	TargetRiskLevel string   `json:"target_risk_level"`
+	Price           *float64 `json:"price,omitempty"` // Defaults to the current market price
+}
+
+type OrderSizeSuggestionResponse struct {
+	Symbol              string  `json:"symbol"`
+	Price               float64 `json:"price"`
+	TargetRiskLevel     string  `json:"target_risk_level"`
+	MaxRiskScore        float64 `json:"max_risk_score"`
+	RecommendedQuantity float64 `json:"recommended_quantity"`
+	RecommendedValue    float64 `json:"recommended_value"`
+	RiskScore           float64 `json:"risk_score"`
+	AffordableQuantity  float64 `json:"affordable_quantity"`
+	Volatility30Day     float64 `json:"volatility_30_day"`
+	LimitedBy           string  `json:"limited_by"`
+}
+
+func convertToOrderSizeSuggestionResponse(recommendation *service.OrderSizeRecommendation) OrderSizeSuggestionResponse {
+	return OrderSizeSuggestionResponse{
+		Symbol:              recommendation.Symbol,
+		Price:               recommendation.Price,
+		TargetRiskLevel:     riskLevelName(recommendation.TargetRiskLevel),
+		MaxRiskScore:        recommendation.MaxRiskScore,
+		RecommendedQuantity: recommendation.RecommendedQuantity,
+		RecommendedValue:    recommendation.RecommendedQuantity * recommendation.Price,
+		RiskScore:           recommendation.RiskScore,
+		AffordableQuantity:  recommendation.AffordableQuantity,
+		Volatility30Day:     recommendation.Volatility30Day,
+		LimitedBy:           recommendation.LimitedBy,
+	}
+}
+
+// SuggestOrderSize handles risk-based order size recommendations
+// @Summary Suggest Order Size
+// @Description Recommend the largest buy quantity of a symbol whose risk score stays within the target risk level, based on the symbol's volatility and the account balance. No order is created.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SuggestOrderSizeRequest true "Symbol and target risk level (LOW, MEDIUM, HIGH, VERY_HIGH, EXTREMELY_HIGH)"
+// @Success 200 {object} OrderSizeSuggestionResponse "Recommendation computed"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid symbol, risk level or price"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Order sizing unavailable"
+// @Router /orders/suggest-size [post]
+func SuggestOrderSize(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	useCase := container.GetSuggestOrderSizeUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "order sizing is not available")
+		return
+	}
+
+	var req SuggestOrderSizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	cmd := &command.SuggestOrderSizeCommand{
+		UserID:          userID,
+		Symbol:          strings.ToUpper(strings.TrimSpace(req.Symbol)),
+		TargetRiskLevel: strings.ToUpper(strings.TrimSpace(req.TargetRiskLevel)),
+		Price:           req.Price,
+	}
+	if err := cmd.Validate(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation Error", err.Error())
+		return
+	}
+
+	recommendation, err := useCase.Execute(context.Background(), cmd)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Order Sizing Failed", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convertToOrderSizeSuggestionResponse(recommendation))
+}
+
+// SuggestOrderSizeWithAuth returns a handler wrapped with authentication middleware
+func SuggestOrderSizeWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		SuggestOrderSize(w, r, userID, container)
+	})
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// MockCheckOrderRiskUseCase implements ICheckOrderRiskUseCase for testing
+type MockCheckOrderRiskUseCase struct {
+	ExecuteFunc func(ctx context.Context, cmd *command.SubmitOrderCommand) (*service.RiskAssessment, error)
+}
+
+func (m *MockCheckOrderRiskUseCase) Execute(ctx context.Context, cmd *command.SubmitOrderCommand) (*service.RiskAssessment, error) {
+	return m.ExecuteFunc(ctx, cmd)
+}
+
+// MockSuggestOrderSizeUseCase implements ISuggestOrderSizeUseCase for testing
+type MockSuggestOrderSizeUseCase struct {
+	ExecuteFunc func(ctx context.Context, cmd *command.SuggestOrderSizeCommand) (*service.OrderSizeRecommendation, error)
+}
+
+func (m *MockSuggestOrderSizeUseCase) Execute(ctx context.Context, cmd *command.SuggestOrderSizeCommand) (*service.OrderSizeRecommendation, error) {
+	return m.ExecuteFunc(ctx, cmd)
+}
+
+func TestCheckOrderRisk_ReturnsAssessment(t *testing.T) {
+	var checked *command.SubmitOrderCommand
+	submitted := false
+	container := &MockContainer{
+		submitOrderUseCase: MockSubmitOrderUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*command.SubmitOrderResult, error) {
+				submitted = true
+				return nil, nil
+			},
+		},
+		checkOrderRiskUseCase: &MockCheckOrderRiskUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SubmitOrderCommand) (*service.RiskAssessment, error) {
+				checked = cmd
+				return &service.RiskAssessment{
+					RiskLevel:        service.RiskLevelHigh,
+					RiskScore:        72.5,
+					IsApproved:       true,
+					RequiresApproval: true,
+					RiskFactors: []service.RiskFactor{{
+						Factor:      "High Volatility",
+						Impact:      service.RiskImpactMedium,
+						Score:       30.0,
+						Description: "Symbol has 30.0% volatility",
+					}},
+					Recommendations: []string{"Monitor volatility"},
+					Warnings:        []string{},
+					AssessmentTime:  time.Now(),
+				}, nil
+			},
+		},
+	}
+
+	price := 150.50
+	body, _ := json.Marshal(SubmitOrderRequest{Symbol: "aapl", OrderType: "LIMIT", OrderSide: "BUY", Quantity: 100, Price: &price})
+	req := httptest.NewRequest(http.MethodPost, "/orders/risk-check", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	CheckOrderRiskWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if submitted {
+		t.Error("Expected the risk check not to submit the order")
+	}
+	if checked == nil || checked.Symbol != "AAPL" || checked.UserID != "test-user-id" {
+		t.Fatalf("Expected the order to be risk checked for the caller, got %+v", checked)
+	}
+
+	var response OrderRiskCheckResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.RiskLevel != "HIGH" || response.RiskScore != 72.5 {
+		t.Errorf("Expected HIGH risk with score 72.5, got %s with %f", response.RiskLevel, response.RiskScore)
+	}
+	if !response.RequiresApproval {
+		t.Error("Expected manual approval to be required")
+	}
+	if len(response.RiskFactors) != 1 || response.RiskFactors[0].Impact != "MEDIUM" {
+		t.Errorf("Expected one MEDIUM risk factor, got %+v", response.RiskFactors)
+	}
+}
+
+func TestCheckOrderRisk_Unavailable(t *testing.T) {
+	price := 150.50
+	body, _ := json.Marshal(SubmitOrderRequest{Symbol: "AAPL", OrderType: "LIMIT", OrderSide: "BUY", Quantity: 100, Price: &price})
+	req := httptest.NewRequest(http.MethodPost, "/orders/risk-check", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	CheckOrderRiskWithAuth(mockTokenVerifier, &MockContainer{})(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestSuggestOrderSize_ReturnsRecommendation(t *testing.T) {
+	var received *command.SuggestOrderSizeCommand
+	container := &MockContainer{
+		suggestSizeUseCase: &MockSuggestOrderSizeUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SuggestOrderSizeCommand) (*service.OrderSizeRecommendation, error) {
+				received = cmd
+				return &service.OrderSizeRecommendation{
+					Symbol:              cmd.Symbol,
+					Price:               120.0,
+					TargetRiskLevel:     service.RiskLevelMedium,
+					MaxRiskScore:        40.0,
+					RecommendedQuantity: 85,
+					RiskScore:           39.2,
+					AffordableQuantity:  400,
+					Volatility30Day:     22.0,
+					LimitedBy:           service.OrderSizeLimitedByRisk,
+				}, nil
+			},
+		},
+	}
+
+	body, _ := json.Marshal(SuggestOrderSizeRequest{Symbol: "msft", TargetRiskLevel: "medium"})
+	req := httptest.NewRequest(http.MethodPost, "/orders/suggest-size", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	SuggestOrderSizeWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if received == nil || received.Symbol != "MSFT" || received.TargetRiskLevel != "MEDIUM" || received.UserID != "test-user-id" {
+		t.Fatalf("Expected a normalized command for the caller, got %+v", received)
+	}
+
+	var response OrderSizeSuggestionResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.RecommendedQuantity != 85 || response.RecommendedValue != 10200 {
+		t.Errorf("Expected 85 units worth 10200, got %+v", response)
+	}
+	if response.TargetRiskLevel != "MEDIUM" || response.LimitedBy != "RISK" {
+		t.Errorf("Expected a MEDIUM recommendation limited by risk, got %+v", response)
+	}
+}
+
+func TestSuggestOrderSize_InvalidRiskLevel(t *testing.T) {
+	container := &MockContainer{
+		suggestSizeUseCase: &MockSuggestOrderSizeUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.SuggestOrderSizeCommand) (*service.OrderSizeRecommendation, error) {
+				t.Error("Expected the use case not to be called")
+				return nil, nil
+			},
+		},
+	}
+
+	body, _ := json.Marshal(SuggestOrderSizeRequest{Symbol: "MSFT", TargetRiskLevel: "cautious"})
+	req := httptest.NewRequest(http.MethodPost, "/orders/suggest-size", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	SuggestOrderSizeWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
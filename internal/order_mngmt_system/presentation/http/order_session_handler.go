@@ -0,0 +1,74 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+// OrderSession handles the client's control connection
+// @Summary Open Order Control Connection
+// @Description Upgrade to a WebSocket that acts as the client's control connection. With cancel_on_disconnect=true, the user's open DAY orders are cancelled when their last control connection stays closed past the grace period.
+// @Tags Orders
+// @Security BearerAuth
+// @Param cancel_on_disconnect query bool false "Cancel open DAY orders when the connection drops"
+// @Success 101 "Switching protocols"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid cancel_on_disconnect value"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 503 {object} ErrorResponse "Control connections unavailable"
+// @Router /orders/session [get]
+func OrderSession(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cancelOnDisconnect := false
+	if value := r.URL.Query().Get("cancel_on_disconnect"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Validation Error", "cancel_on_disconnect must be a boolean")
+			return
+		}
+		cancelOnDisconnect = parsed
+	}
+
+	monitor := container.GetCancelOnDisconnectMonitor()
+	webSocketManager := container.GetWebSocketManager()
+	if webSocketManager == nil || (cancelOnDisconnect && monitor == nil) {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "control connections are not available")
+		return
+	}
+
+	conn, err := webSocketManager.CreateConnection(w, r)
+	if err != nil {
+		log.Printf("Failed to open control connection for user %s: %v", userID, err)
+		return
+	}
+	defer conn.Close()
+
+	connectionID := uuid.New().String()
+	if monitor != nil {
+		monitor.Connect(userID, connectionID, cancelOnDisconnect)
+		defer monitor.Disconnect(userID, connectionID)
+	}
+
+	// The connection carries no commands; reading only detects when the client goes away
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// OrderSessionWithAuth returns a handler wrapped with authentication middleware
+func OrderSessionWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		OrderSession(w, r, userID, container)
+	})
+}
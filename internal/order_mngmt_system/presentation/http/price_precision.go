@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	di "HubInvestments/pck"
+)
+
+// pricePrecision rounds response prices to the decimal places their symbol is quoted to. Without a
+// precision resolver prices are returned as they are and no precision is reported.
+type pricePrecision struct {
+	decimals int
+	known    bool
+}
+
+func resolvePricePrecision(ctx context.Context, container di.Container, symbol string) pricePrecision {
+	resolver := container.GetPricePrecisionResolver()
+	if resolver == nil {
+		return pricePrecision{}
+	}
+	return pricePrecision{decimals: resolver.PrecisionFor(ctx, symbol), known: true}
+}
+
+func (p pricePrecision) round(price float64) float64 {
+	if !p.known {
+		return price
+	}
+	return domain.RoundToPrecision(price, p.decimals)
+}
+
+func (p pricePrecision) roundPtr(price *float64) *float64 {
+	if price == nil || !p.known {
+		return price
+	}
+	rounded := p.round(*price)
+	return &rounded
+}
+
+// field reports the precision so clients can pad prices to it, e.g. 1.1 as 1.1000
+func (p pricePrecision) field() *int {
+	if !p.known {
+		return nil
+	}
+	decimals := p.decimals
+	return &decimals
+}
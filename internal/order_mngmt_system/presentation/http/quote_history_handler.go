@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+// QuoteHistoryRequest asks for the price history of several symbols
+type QuoteHistoryRequest struct {
+	Symbols  []string `json:"symbols" example:"PETR4,VALE3"`
+	Interval string   `json:"interval,omitempty" example:"1d"` // 1m, 5m, 15m, 30m, 1h or 1d
+	Range    string   `json:"range,omitempty" example:"1mo"`   // 1d, 5d, 1mo, 3mo, 6mo, 1y or 5y
+}
+
+type QuoteBarResponse struct {
+	Timestamp string  `json:"timestamp"`
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Close     float64 `json:"close"`
+	Volume    int64   `json:"volume"`
+}
+
+type QuoteSeriesResponse struct {
+	Symbol         string             `json:"symbol"`
+	Bars           []QuoteBarResponse `json:"bars"`
+	PricePrecision *int               `json:"price_precision,omitempty"`
+}
+
+type QuoteHistoryResponse struct {
+	Interval       string                `json:"interval"`
+	Range          string                `json:"range"`
+	Series         []QuoteSeriesResponse `json:"series"`
+	MissingSymbols []string              `json:"missing_symbols"`
+}
+
+func convertToQuoteHistoryResponse(result *usecase.QuoteHistoryResult) QuoteHistoryResponse {
+	response := QuoteHistoryResponse{
+		Interval:       string(result.Interval),
+		Range:          string(result.Range),
+		Series:         make([]QuoteSeriesResponse, 0, len(result.Series)),
+		MissingSymbols: result.MissingSymbols,
+	}
+
+	for _, series := range result.Series {
+		seriesResponse := QuoteSeriesResponse{
+			Symbol: series.Symbol,
+			Bars:   make([]QuoteBarResponse, 0, len(series.Bars)),
+		}
+		for _, bar := range series.Bars {
+			seriesResponse.Bars = append(seriesResponse.Bars, QuoteBarResponse{
+				Timestamp: bar.Timestamp.Format(time.RFC3339),
+				Open:      bar.Open,
+				High:      bar.High,
+				Low:       bar.Low,
+				Close:     bar.Close,
+				Volume:    bar.Volume,
+			})
+		}
+		response.Series = append(response.Series, seriesResponse)
+	}
+
+	return response
+}
+
+// applyPricePrecision rounds each series' bars to the decimal places its symbol is quoted to
+func (response *QuoteHistoryResponse) applyPricePrecision(ctx context.Context, container di.Container) {
+	for i := range response.Series {
+		series := &response.Series[i]
+		precision := resolvePricePrecision(ctx, container, series.Symbol)
+		for j := range series.Bars {
+			bar := &series.Bars[j]
+			bar.Open = precision.round(bar.Open)
+			bar.High = precision.round(bar.High)
+			bar.Low = precision.round(bar.Low)
+			bar.Close = precision.round(bar.Close)
+		}
+		series.PricePrecision = precision.field()
+	}
+}
+
+// GetQuoteHistory handles batch quote history requests
+// @Summary Get Quote History
+// @Description Return historical price bars for several symbols at once. Symbols without history are listed in missing_symbols instead of failing the request.
+// @Tags Quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body QuoteHistoryRequest true "Symbols, interval and range"
+// @Success 200 {object} QuoteHistoryResponse "Quote history retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid symbols, interval or range"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Quote history unavailable"
+// @Router /quotes/history [post]
+func GetQuoteHistory(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	useCase := container.GetQuoteHistoryUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "quote history is not available")
+		return
+	}
+
+	var req QuoteHistoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	cmd := &command.GetQuoteHistoryCommand{
+		Symbols:  req.Symbols,
+		Interval: req.Interval,
+		Range:    req.Range,
+	}
+
+	ctx := context.Background()
+	result, err := useCase.Execute(ctx, cmd)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			writeErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+		writeErrorResponse(w, http.StatusInternalServerError, "Quote History Failed", err.Error())
+		return
+	}
+
+	response := convertToQuoteHistoryResponse(result)
+	response.applyPricePrecision(ctx, container)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetQuoteHistoryWithAuth returns a handler wrapped with authentication middleware
+func GetQuoteHistoryWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetQuoteHistory(w, r, userID, container)
+	})
+}
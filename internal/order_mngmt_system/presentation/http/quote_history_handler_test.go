@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// MockGetQuoteHistoryUseCase implements IGetQuoteHistoryUseCase for testing
+type MockGetQuoteHistoryUseCase struct {
+	ExecuteFunc func(ctx context.Context, cmd *command.GetQuoteHistoryCommand) (*usecase.QuoteHistoryResult, error)
+}
+
+func (m *MockGetQuoteHistoryUseCase) Execute(ctx context.Context, cmd *command.GetQuoteHistoryCommand) (*usecase.QuoteHistoryResult, error) {
+	return m.ExecuteFunc(ctx, cmd)
+}
+
+func TestGetQuoteHistory_ReturnsSeriesAndMissingSymbols(t *testing.T) {
+	var received *command.GetQuoteHistoryCommand
+	container := &MockContainer{
+		quoteHistoryUseCase: &MockGetQuoteHistoryUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.GetQuoteHistoryCommand) (*usecase.QuoteHistoryResult, error) {
+				received = cmd
+				return &usecase.QuoteHistoryResult{
+					Interval: service.QuoteInterval1Hour,
+					Range:    service.QuoteRange1Day,
+					Series: []usecase.QuoteHistorySeries{{
+						Symbol: "PETR4",
+						Bars: []service.QuoteBar{{
+							Timestamp: time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+							Open:      30.0, High: 31.0, Low: 29.5, Close: 30.5, Volume: 300,
+						}},
+					}},
+					MissingSymbols: []string{"XXXX9"},
+				}, nil
+			},
+		},
+	}
+
+	body := `{"symbols":["PETR4","XXXX9"],"interval":"1h","range":"1d"}`
+	req := httptest.NewRequest(http.MethodPost, "/quotes/history", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteHistoryWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if received == nil || len(received.Symbols) != 2 || received.Interval != "1h" || received.Range != "1d" {
+		t.Fatalf("Unexpected command %+v", received)
+	}
+
+	var response QuoteHistoryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Interval != "1h" || response.Range != "1d" {
+		t.Errorf("Unexpected interval %s and range %s", response.Interval, response.Range)
+	}
+	if len(response.Series) != 1 || len(response.Series[0].Bars) != 1 || response.Series[0].Bars[0].Timestamp != "2024-03-01T13:00:00Z" {
+		t.Errorf("Unexpected series %+v", response.Series)
+	}
+	if len(response.MissingSymbols) != 1 || response.MissingSymbols[0] != "XXXX9" {
+		t.Errorf("Expected XXXX9 to be missing, got %v", response.MissingSymbols)
+	}
+}
+
+func TestGetQuoteHistory_InvalidRequestReturnsBadRequest(t *testing.T) {
+	container := &MockContainer{
+		quoteHistoryUseCase: &MockGetQuoteHistoryUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.GetQuoteHistoryCommand) (*usecase.QuoteHistoryResult, error) {
+				return nil, errors.New("invalid command: invalid quote interval: 2m")
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/quotes/history", strings.NewReader(`{"symbols":["PETR4"],"interval":"2m"}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteHistoryWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetQuoteHistory_UnavailableWithoutUseCase(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/quotes/history", strings.NewReader(`{"symbols":["PETR4"]}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteHistoryWithAuth(mockTokenVerifier, &MockContainer{})(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+// QuoteSnapshotRequest asks for the latest quote of several symbols
+type QuoteSnapshotRequest struct {
+	Symbols []string `json:"symbols" example:"PETR4,VALE3"`
+}
+
+// QuoteSnapshotQuoteResponse mirrors the fields of a streamed quote message
+type QuoteSnapshotQuoteResponse struct {
+	Symbol    string  `json:"symbol"`
+	BidPrice  float64 `json:"bid_price"`
+	AskPrice  float64 `json:"ask_price"`
+	LastPrice float64 `json:"last_price"`
+	Spread    float64 `json:"spread"`
+	Halted    bool    `json:"halted,omitempty"`
+	Stale     bool    `json:"stale,omitempty"`
+	Timestamp string  `json:"timestamp"`
+
+	PricePrecision *int `json:"price_precision,omitempty"`
+}
+
+type QuoteSnapshotResponse struct {
+	Quotes         []QuoteSnapshotQuoteResponse `json:"quotes"`
+	MissingSymbols []string                     `json:"missing_symbols"`
+	AsOf           string                       `json:"as_of"`
+}
+
+func convertToQuoteSnapshotResponse(result *usecase.QuoteSnapshotResult) QuoteSnapshotResponse {
+	response := QuoteSnapshotResponse{
+		Quotes:         make([]QuoteSnapshotQuoteResponse, 0, len(result.Quotes)),
+		MissingSymbols: result.MissingSymbols,
+		AsOf:           result.AsOf.Format(time.RFC3339Nano),
+	}
+
+	for _, quote := range result.Quotes {
+		response.Quotes = append(response.Quotes, QuoteSnapshotQuoteResponse{
+			Symbol:    quote.Symbol,
+			BidPrice:  quote.BidPrice,
+			AskPrice:  quote.AskPrice,
+			LastPrice: quote.LastPrice,
+			Spread:    quote.Spread,
+			Halted:    quote.Halted,
+			Stale:     quote.Stale,
+			Timestamp: quote.Timestamp.Format(time.RFC3339Nano),
+		})
+	}
+
+	return response
+}
+
+// applyPricePrecision rounds each quote to the decimal places its symbol is quoted to
+func (response *QuoteSnapshotResponse) applyPricePrecision(ctx context.Context, container di.Container) {
+	for i := range response.Quotes {
+		quote := &response.Quotes[i]
+		precision := resolvePricePrecision(ctx, container, quote.Symbol)
+		quote.BidPrice = precision.round(quote.BidPrice)
+		quote.AskPrice = precision.round(quote.AskPrice)
+		quote.LastPrice = precision.round(quote.LastPrice)
+		quote.Spread = precision.round(quote.Spread)
+		quote.PricePrecision = precision.field()
+	}
+}
+
+// GetQuoteSnapshot handles quote snapshot requests
+// @Summary Get Quote Snapshot
+// @Description Return the latest quote of several symbols for clients that cannot keep a WebSocket open. Quotes come from the same cache the quote stream fills, so they match what subscribers last received. Symbols not yet streamed are listed in missing_symbols.
+// @Tags Quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body QuoteSnapshotRequest true "Symbols"
+// @Success 200 {object} QuoteSnapshotResponse "Quote snapshot retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid symbols"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Quote snapshots unavailable"
+// @Router /quotes/snapshot [post]
+func GetQuoteSnapshot(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	useCase := container.GetQuoteSnapshotUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "quote snapshots are not available")
+		return
+	}
+
+	var req QuoteSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	ctx := context.Background()
+	result, err := useCase.Execute(ctx, &command.GetQuoteSnapshotCommand{Symbols: req.Symbols})
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			writeErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+		writeErrorResponse(w, http.StatusInternalServerError, "Quote Snapshot Failed", err.Error())
+		return
+	}
+
+	response := convertToQuoteSnapshotResponse(result)
+	response.applyPricePrecision(ctx, container)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetQuoteSnapshotWithAuth returns a handler wrapped with authentication middleware
+func GetQuoteSnapshotWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetQuoteSnapshot(w, r, userID, container)
+	})
+}
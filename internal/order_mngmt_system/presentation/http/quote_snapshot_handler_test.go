@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/usecase"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
+)
+
+func TestGetQuoteSnapshot_ReturnsCachedQuotesAndMissingSymbols(t *testing.T) {
+	quotedAt := time.Now().UTC()
+	cache := service.NewQuoteSnapshotCacheWithDefaults()
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", BidPrice: 30.00, AskPrice: 30.10, LastPrice: 30.05, Spread: 0.10, Timestamp: quotedAt})
+	container := &MockContainer{quoteSnapshotUseCase: usecase.NewGetQuoteSnapshotUseCaseWithDefaults(cache)}
+
+	req := httptest.NewRequest(http.MethodPost, "/quotes/snapshot", strings.NewReader(`{"symbols":["petr4","XXXX9"]}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteSnapshotWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response QuoteSnapshotResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Quotes) != 1 {
+		t.Fatalf("Expected one quote, got %+v", response.Quotes)
+	}
+	quote := response.Quotes[0]
+	if quote.Symbol != "PETR4" || quote.BidPrice != 30.00 || quote.AskPrice != 30.10 || quote.LastPrice != 30.05 || quote.Spread != 0.10 {
+		t.Errorf("Unexpected quote %+v", quote)
+	}
+	if quote.Timestamp != quotedAt.Format(time.RFC3339Nano) || quote.Stale {
+		t.Errorf("Expected a fresh quote at %s, got %+v", quotedAt.Format(time.RFC3339Nano), quote)
+	}
+	if len(response.MissingSymbols) != 1 || response.MissingSymbols[0] != "XXXX9" {
+		t.Errorf("Expected XXXX9 to be missing, got %v", response.MissingSymbols)
+	}
+}
+
+func TestGetQuoteSnapshot_RoundsEachQuoteToItsSymbolPrecision(t *testing.T) {
+	cache := service.NewQuoteSnapshotCacheWithDefaults()
+	cache.Record(service.QuoteSnapshot{Symbol: "EURUSD", BidPrice: 1.08412, AskPrice: 1.08437, LastPrice: 1.1, Spread: 0.00025, Timestamp: time.Now()})
+	cache.Record(service.QuoteSnapshot{Symbol: "PETR4", BidPrice: 30.004, AskPrice: 30.106, LastPrice: 30.05, Spread: 0.102, Timestamp: time.Now()})
+	container := &MockContainer{
+		quoteSnapshotUseCase: usecase.NewGetQuoteSnapshotUseCaseWithDefaults(cache),
+		pricePrecision: orderMktClient.NewPricePrecisionResolver(nil, orderMktClient.PricePrecisionConfig{
+			DefaultPrecision: 2,
+			SymbolPrecisions: map[string]int{"EURUSD": 4},
+		}),
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/quotes/snapshot", strings.NewReader(`{"symbols":["EURUSD","PETR4"]}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteSnapshotWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response QuoteSnapshotResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	quotes := make(map[string]QuoteSnapshotQuoteResponse)
+	for _, quote := range response.Quotes {
+		quotes[quote.Symbol] = quote
+	}
+
+	fx := quotes["EURUSD"]
+	if fx.PricePrecision == nil || *fx.PricePrecision != 4 {
+		t.Fatalf("Expected EURUSD to report 4 decimal places, got %+v", fx)
+	}
+	if fx.BidPrice != 1.0841 || fx.AskPrice != 1.0844 || fx.LastPrice != 1.1 || fx.Spread != 0.0003 {
+		t.Errorf("Expected EURUSD prices rounded to 4 decimal places, got %+v", fx)
+	}
+
+	equity := quotes["PETR4"]
+	if equity.PricePrecision == nil || *equity.PricePrecision != 2 {
+		t.Fatalf("Expected PETR4 to report 2 decimal places, got %+v", equity)
+	}
+	if equity.BidPrice != 30.00 || equity.AskPrice != 30.11 || equity.LastPrice != 30.05 || equity.Spread != 0.10 {
+		t.Errorf("Expected PETR4 prices rounded to 2 decimal places, got %+v", equity)
+	}
+}
+
+func TestGetQuoteSnapshot_InvalidRequestReturnsBadRequest(t *testing.T) {
+	container := &MockContainer{quoteSnapshotUseCase: usecase.NewGetQuoteSnapshotUseCaseWithDefaults(service.NewQuoteSnapshotCacheWithDefaults())}
+
+	req := httptest.NewRequest(http.MethodPost, "/quotes/snapshot", strings.NewReader(`{"symbols":[]}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteSnapshotWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetQuoteSnapshot_UnavailableWithoutUseCase(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/quotes/snapshot", strings.NewReader(`{"symbols":["PETR4"]}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetQuoteSnapshotWithAuth(mockTokenVerifier, &MockContainer{})(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
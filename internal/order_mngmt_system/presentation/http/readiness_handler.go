@@ -0,0 +1,46 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
+	di "HubInvestments/pck"
+)
+
+// ReadinessResponse reports whether the service should receive traffic and why not
+type ReadinessResponse struct {
+	Status     string                                    `json:"status"`
+	MarketData *orderMktClient.MarketDataFreshnessReport `json:"market_data,omitempty"`
+}
+
+// GetReadiness reports whether the service is ready to take orders
+// @Summary Readiness Probe
+// @Description Ready unless market data breaches its staleness SLO, i.e. a tracked symbol's freshest quote is older than the SLO. The market data freshness is included when monitoring is enabled.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} ReadinessResponse "Service ready"
+// @Failure 503 {object} ReadinessResponse "Service degraded"
+// @Router /readyz [get]
+func GetReadiness(w http.ResponseWriter, r *http.Request, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := ReadinessResponse{Status: "ready"}
+	status := http.StatusOK
+
+	if freshness := container.GetMarketDataFreshnessMonitor(); freshness != nil {
+		report := freshness.Report()
+		response.MarketData = &report
+		if report.Degraded {
+			response.Status = "degraded"
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
+)
+
+func newFreshnessMonitor(quoteAge time.Duration) *orderMktClient.MarketDataFreshnessMonitor {
+	monitor := orderMktClient.NewMarketDataFreshnessMonitor(nil, orderMktClient.MarketDataFreshnessConfig{
+		TrackedSymbols: []string{"PETR4"},
+		StalenessSLO:   time.Minute,
+	})
+	monitor.RecordQuote("PETR4", time.Now().Add(-quoteAge))
+	return monitor
+}
+
+func TestGetReadiness_StaleMarketDataIsDegraded(t *testing.T) {
+	container := &MockContainer{marketDataFreshness: newFreshnessMonitor(10 * time.Minute)}
+	rr := httptest.NewRecorder()
+
+	GetReadiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil), container)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+	var response ReadinessResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Status != "degraded" || response.MarketData == nil || response.MarketData.StaleSymbols != 1 {
+		t.Errorf("Unexpected response %+v", response)
+	}
+}
+
+func TestGetReadiness_FreshMarketDataIsReady(t *testing.T) {
+	container := &MockContainer{marketDataFreshness: newFreshnessMonitor(time.Second)}
+	rr := httptest.NewRecorder()
+
+	GetReadiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil), container)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), `"status":"ready"`) {
+		t.Errorf("Expected ready status, got %s", rr.Body.String())
+	}
+}
+
+func TestGetReadiness_WithoutMonitoringIsReady(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	GetReadiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil), &MockContainer{})
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestGetMetrics_ExposesMarketDataStaleness(t *testing.T) {
+	container := &MockContainer{marketDataFreshness: newFreshnessMonitor(10 * time.Minute)}
+	rr := httptest.NewRecorder()
+
+	GetMetrics(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil), container)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	body := rr.Body.String()
+	for _, line := range []string{
+		"# TYPE market_data_quote_age_seconds gauge",
+		`market_data_quote_age_seconds{symbol="PETR4"} 6`,
+		"market_data_stale_symbols 1",
+		"market_data_degraded 1",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+type RejectedOrderResponse struct {
+	ID            string   `json:"id"`
+	Symbol        string   `json:"symbol"`
+	OrderSide     string   `json:"order_side"`
+	OrderType     string   `json:"order_type"`
+	Quantity      float64  `json:"quantity"`
+	Price         *float64 `json:"price,omitempty"`
+	ReasonCode    string   `json:"reason_code"`
+	ReasonDetails string   `json:"reason_details"`
+	RejectedAt    string   `json:"rejected_at"`
+}
+
+type RejectedOrdersResponse struct {
+	RejectedOrders []RejectedOrderResponse `json:"rejected_orders"`
+	Page           int                     `json:"page"`
+	Limit          int                     `json:"limit"`
+}
+
+type RejectionReasonCountResponse struct {
+	ReasonCode string `json:"reason_code"`
+	Count      int    `json:"count"`
+}
+
+type RejectionAnalyticsResponse struct {
+	From    string                         `json:"from"`
+	To      string                         `json:"to"`
+	Total   int                            `json:"total"`
+	Reasons []RejectionReasonCountResponse `json:"reasons"`
+}
+
+func convertToRejectedOrderResponse(rejection *domain.RejectedOrder) RejectedOrderResponse {
+	return RejectedOrderResponse{
+		ID:            rejection.ID,
+		Symbol:        rejection.Symbol,
+		OrderSide:     rejection.OrderSide,
+		OrderType:     rejection.OrderType,
+		Quantity:      rejection.Quantity,
+		Price:         rejection.Price,
+		ReasonCode:    string(rejection.ReasonCode),
+		ReasonDetails: rejection.ReasonDetails,
+		RejectedAt:    rejection.RejectedAt.Format(time.RFC3339),
+	}
+}
+
+// GetRejectedOrders handles listing the user's rejected order submissions
+// @Summary Get Rejected Orders
+// @Description Retrieve the authenticated user's rejected order submissions with their reason, most recent first
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of rejected orders per page (default: 20, max: 100)"
+// @Success 200 {object} RejectedOrdersResponse "Rejected orders retrieved successfully"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Rejected order history unavailable"
+// @Router /orders/rejected [get]
+func GetRejectedOrders(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	useCase := container.GetRejectedOrdersUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "rejected order history is not available")
+		return
+	}
+
+	page := 1
+	limit := 20
+
+	if pageParam := r.URL.Query().Get("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if l, err := strconv.Atoi(limitParam); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	rejections, err := useCase.Execute(context.Background(), userID, limit, (page-1)*limit)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	response := RejectedOrdersResponse{
+		RejectedOrders: make([]RejectedOrderResponse, 0, len(rejections)),
+		Page:           page,
+		Limit:          limit,
+	}
+	for _, rejection := range rejections {
+		response.RejectedOrders = append(response.RejectedOrders, convertToRejectedOrderResponse(rejection))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRejectionAnalytics handles the admin analytics of rejection reasons
+// @Summary Get Order Rejection Analytics
+// @Description Count all users' rejected order submissions by reason code within a time range (default: the last 7 days)
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Range start, RFC3339"
+// @Param to query string false "Range end (exclusive), RFC3339"
+// @Success 200 {object} RejectionAnalyticsResponse "Rejection analytics retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid time range"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Rejected order history unavailable"
+// @Router /admin/orders/rejections [get]
+func GetRejectionAnalytics(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !middleware.IsAdmin(userID) {
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", "Admin access required")
+		return
+	}
+
+	useCase := container.GetRejectedOrdersUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "rejected order history is not available")
+		return
+	}
+
+	to := time.Now()
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		parsed, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Bad Request", "to must be an RFC3339 timestamp")
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-7 * 24 * time.Hour)
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		parsed, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Bad Request", "from must be an RFC3339 timestamp")
+			return
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		writeErrorResponse(w, http.StatusBadRequest, "Bad Request", "from must be before to")
+		return
+	}
+
+	counts, err := useCase.SummarizeReasons(context.Background(), from, to)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	response := RejectionAnalyticsResponse{
+		From:    from.Format(time.RFC3339),
+		To:      to.Format(time.RFC3339),
+		Reasons: make([]RejectionReasonCountResponse, 0, len(counts)),
+	}
+	for _, count := range counts {
+		response.Total += count.Count
+		response.Reasons = append(response.Reasons, RejectionReasonCountResponse{
+			ReasonCode: string(count.ReasonCode),
+			Count:      count.Count,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRejectedOrdersWithAuth returns a handler wrapped with authentication middleware
+func GetRejectedOrdersWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetRejectedOrders(w, r, userID, container)
+	})
+}
+
+// GetRejectionAnalyticsWithAuth returns a handler wrapped with authentication middleware
+func GetRejectionAnalyticsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetRejectionAnalytics(w, r, userID, container)
+	})
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/order_mngmt_system/domain/model"
+)
+
+// MockGetRejectedOrdersUseCase implements IGetRejectedOrdersUseCase for testing
+type MockGetRejectedOrdersUseCase struct {
+	ExecuteFunc          func(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error)
+	SummarizeReasonsFunc func(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error)
+}
+
+func (m *MockGetRejectedOrdersUseCase) Execute(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error) {
+	return m.ExecuteFunc(ctx, userID, limit, offset)
+}
+
+func (m *MockGetRejectedOrdersUseCase) SummarizeReasons(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error) {
+	return m.SummarizeReasonsFunc(ctx, from, to)
+}
+
+func TestGetRejectedOrders_ReturnsUserRejections(t *testing.T) {
+	var requestedUser string
+	var requestedLimit, requestedOffset int
+	price := 150.00
+	container := &MockContainer{
+		rejectedOrdersUseCase: &MockGetRejectedOrdersUseCase{
+			ExecuteFunc: func(ctx context.Context, userID string, limit, offset int) ([]*domain.RejectedOrder, error) {
+				requestedUser, requestedLimit, requestedOffset = userID, limit, offset
+				return []*domain.RejectedOrder{{
+					ID:            "rejection-1",
+					UserID:        userID,
+					Symbol:        "AAPL",
+					OrderSide:     "BUY",
+					OrderType:     "LIMIT",
+					Quantity:      100,
+					Price:         &price,
+					ReasonCode:    domain.RejectReasonMarketClosed,
+					ReasonDetails: "trading hours validation failed: market is closed for symbol AAPL",
+					RejectedAt:    time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC),
+				}}, nil
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/rejected?page=2&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetRejectedOrdersWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if requestedUser != "test-user-id" || requestedLimit != 10 || requestedOffset != 10 {
+		t.Errorf("Expected page 2 of 10 for the caller, got user %s limit %d offset %d", requestedUser, requestedLimit, requestedOffset)
+	}
+
+	var response RejectedOrdersResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.RejectedOrders) != 1 {
+		t.Fatalf("Expected 1 rejected order, got %d", len(response.RejectedOrders))
+	}
+	if response.RejectedOrders[0].ReasonCode != "MARKET_CLOSED" || response.RejectedOrders[0].RejectedAt != "2024-03-01T22:00:00Z" {
+		t.Errorf("Unexpected rejected order %+v", response.RejectedOrders[0])
+	}
+}
+
+func TestGetRejectionAnalytics_RequiresAdmin(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-user")
+	container := &MockContainer{rejectedOrdersUseCase: &MockGetRejectedOrdersUseCase{}}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/orders/rejections", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetRejectionAnalyticsWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestGetRejectionAnalytics_AggregatesReasons(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	var requestedFrom, requestedTo time.Time
+	container := &MockContainer{
+		rejectedOrdersUseCase: &MockGetRejectedOrdersUseCase{
+			SummarizeReasonsFunc: func(ctx context.Context, from, to time.Time) ([]domain.RejectReasonCount, error) {
+				requestedFrom, requestedTo = from, to
+				return []domain.RejectReasonCount{
+					{ReasonCode: domain.RejectReasonMarketClosed, Count: 7},
+					{ReasonCode: domain.RejectReasonPriceOutOfRange, Count: 3},
+				}, nil
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/orders/rejections?from=2024-03-01T00:00:00Z&to=2024-03-02T00:00:00Z", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	GetRejectionAnalyticsWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if !requestedFrom.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) || !requestedTo.Equal(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the requested range, got %s - %s", requestedFrom, requestedTo)
+	}
+
+	var response RejectionAnalyticsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Total != 10 || len(response.Reasons) != 2 || response.Reasons[0].ReasonCode != "MARKET_CLOSED" {
+		t.Errorf("Unexpected analytics %+v", response)
+	}
+}
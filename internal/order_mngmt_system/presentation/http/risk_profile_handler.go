@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+// UpdateUserRiskProfileRequest changes some or all of a user's risk profile; omitted fields keep their value
+type UpdateUserRiskProfileRequest struct {
+	MaxOrderValue        *float64 `json:"max_order_value,omitempty" example:"25000"`
+	MaxDailyTradingValue *float64 `json:"max_daily_trading_value,omitempty" example:"100000"`
+	MaxPositionSize      *float64 `json:"max_position_size,omitempty" example:"50000"`
+	RiskTolerance        *string  `json:"risk_tolerance,omitempty" example:"AGGRESSIVE"`
+	IsHighRiskApproved   *bool    `json:"is_high_risk_approved,omitempty" example:"false"`
+	Reason               string   `json:"reason" example:"Limit raised after income verification"`
+}
+
+type UserRiskProfileResponse struct {
+	UserID               string   `json:"user_id"`
+	RiskTolerance        string   `json:"risk_tolerance"`
+	MaxOrderValue        float64  `json:"max_order_value"`
+	MaxDailyTradingValue float64  `json:"max_daily_trading_value"`
+	MaxPositionSize      float64  `json:"max_position_size"`
+	IsHighRiskApproved   bool     `json:"is_high_risk_approved"`
+	UpdatedAt            string   `json:"updated_at"`
+	ChangedFields        []string `json:"changed_fields"`
+	AuditID              string   `json:"audit_id"`
+}
+
+// parseRiskProfileUserID extracts the user ID from /admin/users/{id}/risk-profile
+func parseRiskProfileUserID(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "admin" || parts[1] != "users" || parts[2] == "" || parts[3] != "risk-profile" {
+		return "", false
+	}
+	return parts[2], true
+}
+
+// UpdateUserRiskProfile handles the admin adjustment of a user's risk profile
+// @Summary Update User Risk Profile
+// @Description Adjust a user's order and daily limits, risk tolerance or high-risk approval. The change is audited and applies to the user's next order.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body UpdateUserRiskProfileRequest true "Profile changes and the reason for them"
+// @Success 200 {object} UserRiskProfileResponse "Risk profile updated successfully"
+// @Failure 400 {object} ErrorResponse "Bad request - Invalid profile"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 503 {object} ErrorResponse "Risk profile management unavailable"
+// @Router /admin/users/{id}/risk-profile [put]
+func UpdateUserRiskProfile(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	targetUserID, ok := parseRiskProfileUserID(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !middleware.IsAdmin(userID) {
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", "Admin access required")
+		return
+	}
+
+	useCase := container.GetUpdateUserRiskProfileUseCase()
+	if useCase == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "risk profile management is not available")
+		return
+	}
+
+	var req UpdateUserRiskProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid JSON format")
+		return
+	}
+
+	cmd := &command.UpdateUserRiskProfileCommand{
+		UserID:               targetUserID,
+		ChangedBy:            userID,
+		Reason:               req.Reason,
+		MaxOrderValue:        req.MaxOrderValue,
+		MaxDailyTradingValue: req.MaxDailyTradingValue,
+		MaxPositionSize:      req.MaxPositionSize,
+		RiskTolerance:        req.RiskTolerance,
+		IsHighRiskApproved:   req.IsHighRiskApproved,
+	}
+
+	result, err := useCase.Execute(context.Background(), cmd)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			writeErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+		writeErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	response := UserRiskProfileResponse{
+		UserID:               result.Profile.UserID,
+		RiskTolerance:        result.Profile.RiskTolerance.String(),
+		MaxOrderValue:        result.Profile.MaxOrderValue,
+		MaxDailyTradingValue: result.Profile.MaxDailyTradingValue,
+		MaxPositionSize:      result.Profile.MaxPositionSize,
+		IsHighRiskApproved:   result.Profile.IsHighRiskApproved,
+		UpdatedAt:            result.Profile.ProfileLastUpdated.Format(time.RFC3339),
+		ChangedFields:        result.ChangedFields,
+		AuditID:              result.AuditID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateUserRiskProfileWithAuth returns a handler wrapped with authentication middleware
+func UpdateUserRiskProfileWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		UpdateUserRiskProfile(w, r, userID, container)
+	})
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/order_mngmt_system/application/command"
+	"HubInvestments/internal/order_mngmt_system/domain/service"
+)
+
+// MockUpdateUserRiskProfileUseCase implements IUpdateUserRiskProfileUseCase for testing
+type MockUpdateUserRiskProfileUseCase struct {
+	ExecuteFunc func(ctx context.Context, cmd *command.UpdateUserRiskProfileCommand) (*command.UpdateUserRiskProfileResult, error)
+}
+
+func (m *MockUpdateUserRiskProfileUseCase) Execute(ctx context.Context, cmd *command.UpdateUserRiskProfileCommand) (*command.UpdateUserRiskProfileResult, error) {
+	return m.ExecuteFunc(ctx, cmd)
+}
+
+func TestUpdateUserRiskProfile_RequiresAdmin(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-user")
+	container := &MockContainer{riskProfileUseCase: &MockUpdateUserRiskProfileUseCase{}}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/42/risk-profile", strings.NewReader(`{"max_order_value":1000,"reason":"test"}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	UpdateUserRiskProfileWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestUpdateUserRiskProfile_AppliesChangeAsAdmin(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	var received *command.UpdateUserRiskProfileCommand
+	container := &MockContainer{
+		riskProfileUseCase: &MockUpdateUserRiskProfileUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.UpdateUserRiskProfileCommand) (*command.UpdateUserRiskProfileResult, error) {
+				received = cmd
+				return &command.UpdateUserRiskProfileResult{
+					Profile: &service.UserRiskProfile{
+						UserID:               cmd.UserID,
+						RiskTolerance:        service.RiskToleranceModerate,
+						MaxOrderValue:        *cmd.MaxOrderValue,
+						MaxDailyTradingValue: 20000,
+						ProfileLastUpdated:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+					},
+					ChangedFields: []string{"max_order_value"},
+					AuditID:       "audit-1",
+				}, nil
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/42/risk-profile", strings.NewReader(`{"max_order_value":10000,"reason":"Limits lowered"}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	UpdateUserRiskProfileWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if received.UserID != "42" || received.ChangedBy != "test-user-id" || received.Reason != "Limits lowered" {
+		t.Errorf("Unexpected command %+v", received)
+	}
+
+	var response UserRiskProfileResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.MaxOrderValue != 10000 || response.AuditID != "audit-1" || response.UpdatedAt != "2024-03-01T12:00:00Z" {
+		t.Errorf("Unexpected response %+v", response)
+	}
+}
+
+func TestUpdateUserRiskProfile_InvalidProfileIsBadRequest(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	container := &MockContainer{
+		riskProfileUseCase: &MockUpdateUserRiskProfileUseCase{
+			ExecuteFunc: func(ctx context.Context, cmd *command.UpdateUserRiskProfileCommand) (*command.UpdateUserRiskProfileResult, error) {
+				return nil, errors.New("invalid command: reason is required")
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/42/risk-profile", strings.NewReader(`{"max_order_value":10000}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	UpdateUserRiskProfileWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdateUserRiskProfile_UnknownPathIsNotFound(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+	container := &MockContainer{riskProfileUseCase: &MockUpdateUserRiskProfileUseCase{}}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/42/settings", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	UpdateUserRiskProfileWithAuth(mockTokenVerifier, container)(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
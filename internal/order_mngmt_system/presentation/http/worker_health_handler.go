@@ -0,0 +1,331 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	orderWorker "HubInvestments/internal/order_mngmt_system/infra/worker"
+	positionWorker "HubInvestments/internal/position/infra/worker"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+)
+
+// IOrderWorkerHealthSource defines the worker manager data used by the health report (dependency inversion)
+type IOrderWorkerHealthSource interface {
+	GetHealthStatus() orderWorker.ManagerHealthStatus
+	GetMetrics() orderWorker.WorkerManagerMetrics
+	GetWorkerInfo() map[string]orderWorker.WorkerInfo
+}
+
+// IPositionWorkerHealthSource defines the position worker data used by the health report (dependency inversion)
+type IPositionWorkerHealthSource interface {
+	GetID() string
+	IsRunning() bool
+	GetHealthStatus() positionWorker.HealthStatus
+	GetMetrics() positionWorker.PositionWorkerMetricsSnapshot
+}
+
+type WorkerHealthReportResponse struct {
+	Status         string                        `json:"status"`
+	OrderWorkers   *OrderWorkerManagerHealth     `json:"order_workers,omitempty"`
+	PositionWorker *PositionWorkerHealthResponse `json:"position_worker,omitempty"`
+	GeneratedAt    string                        `json:"generated_at"`
+}
+
+type OrderWorkerManagerHealth struct {
+	Status           string                      `json:"status"`
+	ActiveWorkers    int                         `json:"active_workers"`
+	HealthyWorkers   int                         `json:"healthy_workers"`
+	DegradedWorkers  int                         `json:"degraded_workers"`
+	UnhealthyWorkers int                         `json:"unhealthy_workers"`
+	MinWorkers       int                         `json:"min_workers"`
+	MaxWorkers       int                         `json:"max_workers"`
+	Metrics          *WorkerManagerMetricsReport `json:"metrics,omitempty"`
+	Workers          []OrderWorkerHealthResponse `json:"workers,omitempty"`
+}
+
+type WorkerManagerMetricsReport struct {
+	TotalOrdersProcessed    int64   `json:"total_orders_processed"`
+	TotalOrdersSuccessful   int64   `json:"total_orders_successful"`
+	TotalOrdersFailed       int64   `json:"total_orders_failed"`
+	TotalOrdersRetried      int64   `json:"total_orders_retried"`
+	AverageProcessingTimeMs float64 `json:"average_processing_time_ms"`
+	QueueDepth              int64   `json:"queue_depth"`
+	WorkerUtilization       float64 `json:"worker_utilization"`
+	ScaleUpEvents           int64   `json:"scale_up_events"`
+	ScaleDownEvents         int64   `json:"scale_down_events"`
+}
+
+type OrderWorkerHealthResponse struct {
+	WorkerID       string                    `json:"worker_id"`
+	Status         string                    `json:"status"`
+	IsRunning      bool                      `json:"is_running"`
+	LastHeartbeat  string                    `json:"last_heartbeat"`
+	ProcessedCount int64                     `json:"processed_count"`
+	ErrorCount     int64                     `json:"error_count"`
+	RetryCount     int64                     `json:"retry_count"`
+	Metrics        *OrderWorkerMetricsReport `json:"metrics,omitempty"`
+}
+
+type OrderWorkerMetricsReport struct {
+	OrdersProcessed         int64   `json:"orders_processed"`
+	OrdersSuccessful        int64   `json:"orders_successful"`
+	OrdersFailed            int64   `json:"orders_failed"`
+	OrdersRetried           int64   `json:"orders_retried"`
+	AverageProcessingTimeMs float64 `json:"average_processing_time_ms"`
+	LastActivityTime        string  `json:"last_activity_time"`
+}
+
+type PositionWorkerHealthResponse struct {
+	WorkerID  string                       `json:"worker_id"`
+	Status    string                       `json:"status"`
+	IsRunning bool                         `json:"is_running"`
+	Metrics   *PositionWorkerMetricsReport `json:"metrics,omitempty"`
+}
+
+type PositionWorkerMetricsReport struct {
+	PositionsProcessed      int64   `json:"positions_processed"`
+	PositionsCreated        int64   `json:"positions_created"`
+	PositionsUpdated        int64   `json:"positions_updated"`
+	PositionsClosed         int64   `json:"positions_closed"`
+	PositionsFailed         int64   `json:"positions_failed"`
+	PositionsRetried        int64   `json:"positions_retried"`
+	AverageProcessingTimeMs float64 `json:"average_processing_time_ms"`
+	LastActivityTime        string  `json:"last_activity_time"`
+
+	OperationLatency []PositionOperationLatencyReport `json:"operation_latency,omitempty"`
+}
+
+// PositionOperationLatencyReport summarizes how long one kind of position operation takes
+type PositionOperationLatencyReport struct {
+	Operation string  `json:"operation"`
+	Count     uint64  `json:"count"`
+	AverageMs float64 `json:"average_ms"`
+	LastMs    float64 `json:"last_ms"`
+}
+
+// WorkerHealthReportOptions selects which sections are included in the report
+type WorkerHealthReportOptions struct {
+	IncludeWorkers bool // Per-worker entries under the order worker manager
+	IncludeMetrics bool // Metrics snapshots for the manager and each worker
+}
+
+// parseWorkerHealthReportOptions reads the include_workers and include_metrics query flags, both on by default
+func parseWorkerHealthReportOptions(r *http.Request) WorkerHealthReportOptions {
+	query := r.URL.Query()
+	return WorkerHealthReportOptions{
+		IncludeWorkers: query.Get("include_workers") != "false",
+		IncludeMetrics: query.Get("include_metrics") != "false",
+	}
+}
+
+// BuildWorkerHealthReport combines the order worker manager and position worker health into one report.
+// The overall status is the worst status among the sources that are running.
+func BuildWorkerHealthReport(orderWorkers IOrderWorkerHealthSource, positionUpdates IPositionWorkerHealthSource, options WorkerHealthReportOptions) WorkerHealthReportResponse {
+	report := WorkerHealthReportResponse{
+		Status:      "stopped",
+		GeneratedAt: time.Now().Format(time.RFC3339),
+	}
+
+	statuses := make([]string, 0, 2)
+
+	if orderWorkers != nil {
+		report.OrderWorkers = buildOrderWorkerManagerHealth(orderWorkers, options)
+		statuses = append(statuses, report.OrderWorkers.Status)
+	}
+
+	if positionUpdates != nil {
+		report.PositionWorker = buildPositionWorkerHealth(positionUpdates, options)
+		statuses = append(statuses, report.PositionWorker.Status)
+	}
+
+	report.Status = aggregateWorkerStatus(statuses)
+	return report
+}
+
+func buildOrderWorkerManagerHealth(source IOrderWorkerHealthSource, options WorkerHealthReportOptions) *OrderWorkerManagerHealth {
+	health := source.GetHealthStatus()
+	managerHealth := &OrderWorkerManagerHealth{
+		Status:           health.Status,
+		ActiveWorkers:    health.ActiveWorkers,
+		HealthyWorkers:   health.HealthyWorkers,
+		DegradedWorkers:  health.DegradedWorkers,
+		UnhealthyWorkers: health.UnhealthyWorkers,
+		MinWorkers:       health.MinWorkers,
+		MaxWorkers:       health.MaxWorkers,
+	}
+
+	if options.IncludeMetrics {
+		metrics := source.GetMetrics()
+		managerHealth.Metrics = &WorkerManagerMetricsReport{
+			TotalOrdersProcessed:    metrics.TotalOrdersProcessed,
+			TotalOrdersSuccessful:   metrics.TotalOrdersSuccessful,
+			TotalOrdersFailed:       metrics.TotalOrdersFailed,
+			TotalOrdersRetried:      metrics.TotalOrdersRetried,
+			AverageProcessingTimeMs: durationToMilliseconds(metrics.AverageProcessingTime),
+			QueueDepth:              metrics.QueueDepth,
+			WorkerUtilization:       metrics.WorkerUtilization,
+			ScaleUpEvents:           metrics.ScaleUpEvents,
+			ScaleDownEvents:         metrics.ScaleDownEvents,
+		}
+	}
+
+	if !options.IncludeWorkers {
+		return managerHealth
+	}
+
+	workerInfo := source.GetWorkerInfo()
+	workerIDs := make([]string, 0, len(workerInfo))
+	for workerID := range workerInfo {
+		workerIDs = append(workerIDs, workerID)
+	}
+	sort.Strings(workerIDs)
+
+	managerHealth.Workers = make([]OrderWorkerHealthResponse, 0, len(workerIDs))
+	for _, workerID := range workerIDs {
+		info := workerInfo[workerID]
+		workerHealth := OrderWorkerHealthResponse{
+			WorkerID:       info.ID,
+			Status:         info.HealthStatus,
+			IsRunning:      info.IsRunning,
+			LastHeartbeat:  info.LastHeartbeat.Format(time.RFC3339),
+			ProcessedCount: info.ProcessedCount,
+			ErrorCount:     info.ErrorCount,
+			RetryCount:     info.RetryCount,
+		}
+		if options.IncludeMetrics {
+			workerHealth.Metrics = &OrderWorkerMetricsReport{
+				OrdersProcessed:         info.Metrics.OrdersProcessed,
+				OrdersSuccessful:        info.Metrics.OrdersSuccessful,
+				OrdersFailed:            info.Metrics.OrdersFailed,
+				OrdersRetried:           info.Metrics.OrdersRetried,
+				AverageProcessingTimeMs: durationToMilliseconds(info.Metrics.AverageProcessingTime),
+				LastActivityTime:        info.Metrics.LastActivityTime.Format(time.RFC3339),
+			}
+		}
+		managerHealth.Workers = append(managerHealth.Workers, workerHealth)
+	}
+
+	return managerHealth
+}
+
+func buildPositionWorkerHealth(source IPositionWorkerHealthSource, options WorkerHealthReportOptions) *PositionWorkerHealthResponse {
+	workerHealth := &PositionWorkerHealthResponse{
+		WorkerID:  source.GetID(),
+		Status:    source.GetHealthStatus().String(),
+		IsRunning: source.IsRunning(),
+	}
+	if !workerHealth.IsRunning {
+		workerHealth.Status = positionWorker.HealthStatusStopped.String()
+	}
+
+	if options.IncludeMetrics {
+		metrics := source.GetMetrics()
+		workerHealth.Metrics = &PositionWorkerMetricsReport{
+			PositionsProcessed:      metrics.PositionsProcessed,
+			PositionsCreated:        metrics.PositionsCreated,
+			PositionsUpdated:        metrics.PositionsUpdated,
+			PositionsClosed:         metrics.PositionsClosed,
+			PositionsFailed:         metrics.PositionsFailed,
+			PositionsRetried:        metrics.PositionsRetried,
+			AverageProcessingTimeMs: durationToMilliseconds(metrics.AverageProcessingTime),
+			LastActivityTime:        metrics.LastActivityTime.Format(time.RFC3339),
+		}
+		for _, latency := range metrics.OperationLatency {
+			workerHealth.Metrics.OperationLatency = append(workerHealth.Metrics.OperationLatency, PositionOperationLatencyReport{
+				Operation: latency.Operation,
+				Count:     latency.Count,
+				AverageMs: durationToMilliseconds(latency.Average),
+				LastMs:    durationToMilliseconds(latency.Last),
+			})
+		}
+	}
+
+	return workerHealth
+}
+
+// aggregateWorkerStatus returns the worst status, ignoring stopped sources unless nothing is running
+func aggregateWorkerStatus(statuses []string) string {
+	severity := map[string]int{"healthy": 1, "unknown": 2, "degraded": 3, "unhealthy": 4}
+
+	worst := ""
+	for _, status := range statuses {
+		if _, ok := severity[status]; !ok {
+			continue
+		}
+		if worst == "" || severity[status] > severity[worst] {
+			worst = status
+		}
+	}
+	if worst == "" {
+		return "stopped"
+	}
+	return worst
+}
+
+func durationToMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// GetWorkersHealth handles the worker health report for operational dashboards
+// @Summary Get Worker Health Report
+// @Description Report the health of the order worker manager, each order worker and the position update worker. Use include_workers=false or include_metrics=false to trim the report.
+// @Tags Workers
+// @Produce json
+// @Security BearerAuth
+// @Param include_workers query bool false "Include per-worker entries (default true)"
+// @Param include_metrics query bool false "Include metrics snapshots (default true)"
+// @Success 200 {object} WorkerHealthReportResponse "All running workers are healthy or degraded"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin access required"
+// @Failure 503 {object} WorkerHealthReportResponse "At least one worker source is unhealthy"
+// @Router /admin/workers/health [get]
+func GetWorkersHealth(w http.ResponseWriter, r *http.Request, userID string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !middleware.IsAdmin(userID) {
+		errorResponse := ErrorResponse{
+			Error:   "Forbidden",
+			Message: "Admin access required",
+			Code:    http.StatusForbidden,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(errorResponse)
+		return
+	}
+
+	// Workers are only created when messaging is available; avoid typed nil interfaces
+	var orderWorkers IOrderWorkerHealthSource
+	if manager := container.GetOrderWorkerManager(); manager != nil {
+		orderWorkers = manager
+	}
+	var positionUpdates IPositionWorkerHealthSource
+	if worker := container.GetPositionWorkerManager(); worker != nil {
+		positionUpdates = worker
+	}
+
+	writeWorkerHealthReport(w, BuildWorkerHealthReport(orderWorkers, positionUpdates, parseWorkerHealthReportOptions(r)))
+}
+
+func writeWorkerHealthReport(w http.ResponseWriter, report WorkerHealthReportResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status == "unhealthy" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// GetWorkersHealthWithAuth returns a handler wrapped with authentication middleware
+func GetWorkersHealthWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userID string) {
+		GetWorkersHealth(w, r, userID, container)
+	})
+}
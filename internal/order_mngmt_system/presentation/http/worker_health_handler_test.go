@@ -0,0 +1,205 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	orderWorker "HubInvestments/internal/order_mngmt_system/infra/worker"
+	positionWorker "HubInvestments/internal/position/infra/worker"
+)
+
+type stubOrderWorkerHealthSource struct {
+	health  orderWorker.ManagerHealthStatus
+	workers map[string]orderWorker.WorkerInfo
+}
+
+func (s *stubOrderWorkerHealthSource) GetHealthStatus() orderWorker.ManagerHealthStatus {
+	return s.health
+}
+
+func (s *stubOrderWorkerHealthSource) GetMetrics() orderWorker.WorkerManagerMetrics {
+	return orderWorker.WorkerManagerMetrics{
+		ActiveWorkers:         len(s.workers),
+		TotalOrdersProcessed:  42,
+		AverageProcessingTime: 1500 * time.Microsecond,
+	}
+}
+
+func (s *stubOrderWorkerHealthSource) GetWorkerInfo() map[string]orderWorker.WorkerInfo {
+	return s.workers
+}
+
+type stubPositionWorkerHealthSource struct {
+	running bool
+	status  positionWorker.HealthStatus
+}
+
+func (s *stubPositionWorkerHealthSource) GetID() string   { return "position-worker-1" }
+func (s *stubPositionWorkerHealthSource) IsRunning() bool { return s.running }
+func (s *stubPositionWorkerHealthSource) GetHealthStatus() positionWorker.HealthStatus {
+	return s.status
+}
+func (s *stubPositionWorkerHealthSource) GetMetrics() positionWorker.PositionWorkerMetricsSnapshot {
+	return positionWorker.PositionWorkerMetricsSnapshot{PositionsProcessed: 7}
+}
+
+func newStubOrderWorkers(status string, workerStatuses map[string]string) *stubOrderWorkerHealthSource {
+	source := &stubOrderWorkerHealthSource{
+		health:  orderWorker.ManagerHealthStatus{Status: status, ActiveWorkers: len(workerStatuses), MinWorkers: 1, MaxWorkers: 5},
+		workers: make(map[string]orderWorker.WorkerInfo),
+	}
+	for workerID, workerStatus := range workerStatuses {
+		source.workers[workerID] = orderWorker.WorkerInfo{ID: workerID, IsRunning: true, HealthStatus: workerStatus}
+		switch workerStatus {
+		case "healthy":
+			source.health.HealthyWorkers++
+		case "degraded":
+			source.health.DegradedWorkers++
+		case "unhealthy":
+			source.health.UnhealthyWorkers++
+		}
+	}
+	return source
+}
+
+func decodeWorkerHealthReport(t *testing.T, w *httptest.ResponseRecorder) WorkerHealthReportResponse {
+	t.Helper()
+	var report WorkerHealthReportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return report
+}
+
+func TestWorkerHealthReport_Healthy(t *testing.T) {
+	orderWorkers := newStubOrderWorkers("healthy", map[string]string{"worker-1": "healthy", "worker-2": "healthy"})
+	positionUpdates := &stubPositionWorkerHealthSource{running: true, status: positionWorker.HealthStatusHealthy}
+
+	w := httptest.NewRecorder()
+	writeWorkerHealthReport(w, BuildWorkerHealthReport(orderWorkers, positionUpdates, WorkerHealthReportOptions{IncludeWorkers: true, IncludeMetrics: true}))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	report := decodeWorkerHealthReport(t, w)
+	if report.Status != "healthy" {
+		t.Errorf("Expected overall status 'healthy', got '%s'", report.Status)
+	}
+	if report.OrderWorkers == nil || len(report.OrderWorkers.Workers) != 2 {
+		t.Fatalf("Expected 2 order workers in the report, got %+v", report.OrderWorkers)
+	}
+	if report.OrderWorkers.Workers[0].WorkerID != "worker-1" {
+		t.Errorf("Expected workers sorted by ID, got '%s' first", report.OrderWorkers.Workers[0].WorkerID)
+	}
+	if report.OrderWorkers.Metrics == nil || report.OrderWorkers.Metrics.TotalOrdersProcessed != 42 {
+		t.Errorf("Expected manager metrics snapshot, got %+v", report.OrderWorkers.Metrics)
+	}
+	if report.OrderWorkers.Metrics.AverageProcessingTimeMs != 1.5 {
+		t.Errorf("Expected average processing time 1.5ms, got %v", report.OrderWorkers.Metrics.AverageProcessingTimeMs)
+	}
+	if report.PositionWorker == nil || report.PositionWorker.Status != "healthy" {
+		t.Errorf("Expected healthy position worker, got %+v", report.PositionWorker)
+	}
+}
+
+func TestWorkerHealthReport_Degraded(t *testing.T) {
+	orderWorkers := newStubOrderWorkers("degraded", map[string]string{"worker-1": "healthy", "worker-2": "degraded"})
+	positionUpdates := &stubPositionWorkerHealthSource{running: true, status: positionWorker.HealthStatusHealthy}
+
+	w := httptest.NewRecorder()
+	writeWorkerHealthReport(w, BuildWorkerHealthReport(orderWorkers, positionUpdates, WorkerHealthReportOptions{IncludeWorkers: true, IncludeMetrics: true}))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	report := decodeWorkerHealthReport(t, w)
+	if report.Status != "degraded" {
+		t.Errorf("Expected overall status 'degraded', got '%s'", report.Status)
+	}
+	if report.OrderWorkers.DegradedWorkers != 1 || report.OrderWorkers.HealthyWorkers != 1 {
+		t.Errorf("Expected 1 healthy and 1 degraded worker, got %+v", report.OrderWorkers)
+	}
+	if report.OrderWorkers.Workers[1].Status != "degraded" {
+		t.Errorf("Expected worker-2 to be 'degraded', got '%s'", report.OrderWorkers.Workers[1].Status)
+	}
+}
+
+func TestWorkerHealthReport_Unhealthy(t *testing.T) {
+	orderWorkers := newStubOrderWorkers("healthy", map[string]string{"worker-1": "healthy"})
+	positionUpdates := &stubPositionWorkerHealthSource{running: true, status: positionWorker.HealthStatusUnhealthy}
+
+	w := httptest.NewRecorder()
+	writeWorkerHealthReport(w, BuildWorkerHealthReport(orderWorkers, positionUpdates, WorkerHealthReportOptions{IncludeWorkers: true, IncludeMetrics: true}))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	report := decodeWorkerHealthReport(t, w)
+	if report.Status != "unhealthy" {
+		t.Errorf("Expected overall status 'unhealthy', got '%s'", report.Status)
+	}
+	if report.PositionWorker.Status != "unhealthy" {
+		t.Errorf("Expected unhealthy position worker, got '%s'", report.PositionWorker.Status)
+	}
+}
+
+func TestWorkerHealthReport_StoppedPositionWorkerIsIgnored(t *testing.T) {
+	orderWorkers := newStubOrderWorkers("healthy", map[string]string{"worker-1": "healthy"})
+	positionUpdates := &stubPositionWorkerHealthSource{running: false, status: positionWorker.HealthStatusUnhealthy}
+
+	report := BuildWorkerHealthReport(orderWorkers, positionUpdates, WorkerHealthReportOptions{})
+
+	if report.Status != "healthy" {
+		t.Errorf("Expected overall status 'healthy', got '%s'", report.Status)
+	}
+	if report.PositionWorker.Status != "stopped" {
+		t.Errorf("Expected stopped position worker, got '%s'", report.PositionWorker.Status)
+	}
+	if report.OrderWorkers.Workers != nil || report.OrderWorkers.Metrics != nil {
+		t.Errorf("Expected workers and metrics to be omitted, got %+v", report.OrderWorkers)
+	}
+}
+
+func TestGetWorkersHealth_Forbidden(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-user")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/workers/health", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	handler := GetWorkersHealthWithAuth(mockTokenVerifier, &MockContainer{})
+	handler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestGetWorkersHealth_NoWorkersRunning(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "test-user-id")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/workers/health", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	handler := GetWorkersHealthWithAuth(mockTokenVerifier, &MockContainer{})
+	handler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	report := decodeWorkerHealthReport(t, w)
+	if report.Status != "stopped" {
+		t.Errorf("Expected overall status 'stopped', got '%s'", report.Status)
+	}
+	if report.OrderWorkers != nil || report.PositionWorker != nil {
+		t.Errorf("Expected no worker sections, got %+v", report)
+	}
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type CreatePnLAlertCommand struct {
+	UserID        string  `json:"user_id" validate:"required"`
+	Symbol        string  `json:"symbol" validate:"required"`
+	Direction     string  `json:"direction" validate:"required,oneof=GAIN LOSS"`
+	ThresholdType string  `json:"threshold_type" validate:"required,oneof=PERCENT AMOUNT"`
+	Threshold     float64 `json:"threshold" validate:"required,gt=0"`
+}
+
+func (cmd *CreatePnLAlertCommand) Validate() error {
+	if cmd.UserID == "" {
+		return errors.New("user ID is required")
+	}
+
+	if _, err := parseUserIDToUUID(cmd.UserID); err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	if strings.TrimSpace(cmd.Symbol) == "" {
+		return errors.New("symbol is required")
+	}
+
+	if _, err := domain.ParsePnLAlertDirection(cmd.Direction); err != nil {
+		return err
+	}
+
+	if _, err := domain.ParsePnLThresholdType(cmd.ThresholdType); err != nil {
+		return err
+	}
+
+	if cmd.Threshold <= 0 {
+		return errors.New("threshold must be positive")
+	}
+
+	return nil
+}
+
+func (cmd *CreatePnLAlertCommand) ToUserID() (uuid.UUID, error) {
+	return parseUserIDToUUID(cmd.UserID)
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	domain "HubInvestments/internal/position/domain/model"
 	"errors"
 	"fmt"
 
@@ -15,6 +16,8 @@ type UpdatePositionCommand struct {
 	IsBuyOrder    bool    `json:"is_buy_order"`
 	SourceOrderID *string `json:"source_order_id,omitempty"`
 	ExecutionTime *string `json:"execution_time,omitempty"` // ISO 8601 format
+	// AveragingMethod is WEIGHTED_AVERAGE (default) or ORIGINAL_COST and only affects buys
+	AveragingMethod string `json:"averaging_method,omitempty"`
 }
 
 type UpdatePositionResult struct {
@@ -69,6 +72,10 @@ func (cmd *UpdatePositionCommand) Validate() error {
 		}
 	}
 
+	if _, err := cmd.ToAveragingMethod(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -93,6 +100,10 @@ func (cmd *UpdatePositionCommand) ToSourceOrderID() (*uuid.UUID, error) {
 	return &orderID, nil
 }
 
+func (cmd *UpdatePositionCommand) ToAveragingMethod() (domain.AveragingMethod, error) {
+	return domain.NewAveragingMethod(cmd.AveragingMethod)
+}
+
 func (cmd *UpdatePositionCommand) GetTransactionType() string {
 	if cmd.IsBuyOrder {
 		return "BUY"
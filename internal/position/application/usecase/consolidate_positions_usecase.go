@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ConsolidatePositionsConfig holds configuration for merging duplicate active positions
+type ConsolidatePositionsConfig struct {
+	QuantityPrecision int // Decimal places kept on the consolidated quantity
+	PricePrecision    int // Decimal places kept on the consolidated average price
+}
+
+// DefaultConsolidatePositionsConfig returns the default consolidation configuration
+func DefaultConsolidatePositionsConfig() ConsolidatePositionsConfig {
+	return ConsolidatePositionsConfig{
+		QuantityPrecision: domain.DefaultQuantityPrecision,
+		PricePrecision:    domain.DefaultPricePrecision,
+	}
+}
+
+// ConsolidatedPosition describes one symbol whose duplicate positions were merged
+type ConsolidatedPosition struct {
+	Symbol            string
+	PositionID        uuid.UUID   // Position that was kept
+	MergedPositionIDs []uuid.UUID // Duplicates folded into it and closed
+	Quantity          float64
+	AveragePrice      float64
+	TotalInvestment   float64
+}
+
+// PositionConsolidationResult summarizes a consolidation run for one user
+type PositionConsolidationResult struct {
+	UserID       uuid.UUID
+	Consolidated []ConsolidatedPosition
+	Failed       int
+	Errors       []string
+}
+
+type IConsolidatePositionsUseCase interface {
+	// Execute merges every group of active positions the user holds in the same symbol into the
+	// oldest one. It is meant to be run by reconciliation or whenever a duplicate is detected, and
+	// running it again is safe.
+	Execute(ctx context.Context, userID string) (*PositionConsolidationResult, error)
+}
+
+type ConsolidatePositionsUseCase struct {
+	positionRepository repository.IPositionRepository
+	ledgerRepository   repository.IPositionLedgerRepository
+	config             ConsolidatePositionsConfig
+}
+
+func NewConsolidatePositionsUseCase(
+	positionRepository repository.IPositionRepository,
+	ledgerRepository repository.IPositionLedgerRepository,
+	config ConsolidatePositionsConfig,
+) IConsolidatePositionsUseCase {
+	return &ConsolidatePositionsUseCase{
+		positionRepository: positionRepository,
+		ledgerRepository:   ledgerRepository,
+		config:             config,
+	}
+}
+
+func (uc *ConsolidatePositionsUseCase) Execute(ctx context.Context, userID string) (*PositionConsolidationResult, error) {
+	userUUID, err := parseUserIDToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", userID, err)
+	}
+
+	positions, err := uc.positionRepository.FindActivePositions(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions: %w", err)
+	}
+
+	result := &PositionConsolidationResult{
+		UserID:       userUUID,
+		Consolidated: make([]ConsolidatedPosition, 0),
+		Errors:       make([]string, 0),
+	}
+
+	for _, group := range groupDuplicatePositions(positions) {
+		consolidated, err := uc.consolidateGroup(ctx, group)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", group[0].Symbol, err))
+			continue
+		}
+
+		log.Printf("Consolidated %d duplicate %s positions of user %s into position %s",
+			len(consolidated.MergedPositionIDs), consolidated.Symbol, userUUID, consolidated.PositionID)
+		result.Consolidated = append(result.Consolidated, *consolidated)
+	}
+
+	return result, nil
+}
+
+// consolidateGroup folds every duplicate into the oldest position. The survivor is saved first and
+// its ledger entry marks the duplicate as absorbed, so a retry after a partial failure only closes
+// the duplicate instead of adding its quantity twice.
+func (uc *ConsolidatePositionsUseCase) consolidateGroup(ctx context.Context, group []*domain.Position) (*ConsolidatedPosition, error) {
+	survivor := group[0]
+	consolidated := &ConsolidatedPosition{
+		Symbol:            survivor.Symbol,
+		PositionID:        survivor.ID,
+		MergedPositionIDs: make([]uuid.UUID, 0, len(group)-1),
+	}
+
+	for _, duplicate := range group[1:] {
+		absorbed, err := uc.ledgerRepository.ExistsForCorporateAction(ctx, survivor.ID, domain.ConsolidationLedgerKey(duplicate.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check ledger: %w", err)
+		}
+
+		if absorbed {
+			if err := uc.closeAbsorbedDuplicate(ctx, survivor, duplicate); err != nil {
+				return nil, err
+			}
+		} else if err := uc.absorbDuplicate(ctx, survivor, duplicate); err != nil {
+			return nil, err
+		}
+
+		consolidated.MergedPositionIDs = append(consolidated.MergedPositionIDs, duplicate.ID)
+	}
+
+	consolidated.Quantity = survivor.Quantity
+	consolidated.AveragePrice = survivor.AveragePrice
+	consolidated.TotalInvestment = survivor.TotalInvestment
+	return consolidated, nil
+}
+
+func (uc *ConsolidatePositionsUseCase) absorbDuplicate(ctx context.Context, survivor, duplicate *domain.Position) error {
+	previousQuantity := survivor.Quantity
+	previousAveragePrice := survivor.AveragePrice
+	duplicateQuantity := duplicate.Quantity
+	duplicateAveragePrice := duplicate.AveragePrice
+
+	if err := survivor.AbsorbDuplicate(duplicate, uc.config.QuantityPrecision, uc.config.PricePrecision); err != nil {
+		return fmt.Errorf("failed to consolidate position %s: %w", duplicate.ID, err)
+	}
+
+	if err := uc.positionRepository.Update(ctx, survivor); err != nil {
+		return fmt.Errorf("failed to update position %s: %w", survivor.ID, err)
+	}
+
+	survivorEntry, err := domain.NewConsolidationLedgerEntry(survivor, duplicate.ID, previousQuantity, previousAveragePrice,
+		fmt.Sprintf("Absorbed duplicate position %s of %g %s at %g", duplicate.ID, duplicateQuantity, survivor.Symbol, duplicateAveragePrice))
+	if err != nil {
+		return err
+	}
+	if err := uc.ledgerRepository.Save(ctx, survivorEntry); err != nil {
+		return fmt.Errorf("failed to save ledger entry: %w", err)
+	}
+
+	return uc.saveClosedDuplicate(ctx, survivor, duplicate, duplicateQuantity, duplicateAveragePrice)
+}
+
+// closeAbsorbedDuplicate finishes a consolidation whose survivor was already updated
+func (uc *ConsolidatePositionsUseCase) closeAbsorbedDuplicate(ctx context.Context, survivor, duplicate *domain.Position) error {
+	duplicateQuantity := duplicate.Quantity
+	duplicateAveragePrice := duplicate.AveragePrice
+
+	duplicate.Quantity = 0
+	duplicate.TotalInvestment = 0
+	duplicate.Status = domain.PositionStatusClosed
+
+	return uc.saveClosedDuplicate(ctx, survivor, duplicate, duplicateQuantity, duplicateAveragePrice)
+}
+
+func (uc *ConsolidatePositionsUseCase) saveClosedDuplicate(ctx context.Context, survivor, duplicate *domain.Position, previousQuantity, previousAveragePrice float64) error {
+	if err := uc.positionRepository.Update(ctx, duplicate); err != nil {
+		return fmt.Errorf("failed to close duplicate position %s: %w", duplicate.ID, err)
+	}
+
+	entry, err := domain.NewConsolidationLedgerEntry(duplicate, duplicate.ID, previousQuantity, previousAveragePrice,
+		fmt.Sprintf("Consolidated into position %s", survivor.ID))
+	if err != nil {
+		return err
+	}
+	if err := uc.ledgerRepository.Save(ctx, entry); err != nil {
+		return fmt.Errorf("failed to save ledger entry: %w", err)
+	}
+	return nil
+}
+
+// groupDuplicatePositions returns the groups of positions held in the same symbol and direction,
+// oldest first, leaving out symbols with a single position
+func groupDuplicatePositions(positions []*domain.Position) [][]*domain.Position {
+	groups := make(map[string][]*domain.Position)
+	keys := make([]string, 0)
+	for _, position := range positions {
+		key := position.Symbol + "|" + string(position.PositionType)
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], position)
+	}
+	sort.Strings(keys)
+
+	duplicates := make([][]*domain.Position, 0)
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+		duplicates = append(duplicates, group)
+	}
+	return duplicates
+}
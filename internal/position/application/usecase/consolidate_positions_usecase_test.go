@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// InMemoryPositionLedgerRepository is an in-memory IPositionLedgerRepository for use case tests
+type InMemoryPositionLedgerRepository struct {
+	entries []*domain.PositionLedgerEntry
+}
+
+func (r *InMemoryPositionLedgerRepository) Save(ctx context.Context, entry *domain.PositionLedgerEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *InMemoryPositionLedgerRepository) ExistsForCorporateAction(ctx context.Context, positionID uuid.UUID, corporateActionID string) (bool, error) {
+	for _, entry := range r.entries {
+		if entry.PositionID == positionID && entry.CorporateActionID == corporateActionID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *InMemoryPositionLedgerRepository) FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionLedgerEntry, error) {
+	result := make([]*domain.PositionLedgerEntry, 0)
+	for _, entry := range r.entries {
+		if entry.PositionID == positionID {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func newDuplicatePositions(t *testing.T, userID uuid.UUID) (*domain.Position, *domain.Position) {
+	first, err := domain.NewPosition(userID, "AAPL", 10, 100, domain.PositionTypeLong)
+	require.NoError(t, err)
+	second, err := domain.NewPosition(userID, "AAPL", 30, 120, domain.PositionTypeLong)
+	require.NoError(t, err)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	return first, second
+}
+
+func TestConsolidatePositionsUseCase_MergesDuplicatesWithWeightedCostBasis(t *testing.T) {
+	userID := uuid.New()
+	positionRepo := NewMockPositionRepositoryForNew()
+	ledgerRepo := &InMemoryPositionLedgerRepository{}
+
+	first, second := newDuplicatePositions(t, userID)
+	other, err := domain.NewPosition(userID, "MSFT", 5, 300, domain.PositionTypeLong)
+	require.NoError(t, err)
+	positionRepo.AddPosition(second)
+	positionRepo.AddPosition(first)
+	positionRepo.AddPosition(other)
+
+	useCase := NewConsolidatePositionsUseCase(positionRepo, ledgerRepo, DefaultConsolidatePositionsConfig())
+	result, err := useCase.Execute(context.Background(), userID.String())
+	require.NoError(t, err)
+
+	require.Len(t, result.Consolidated, 1)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, first.ID, result.Consolidated[0].PositionID)
+	assert.Equal(t, []uuid.UUID{second.ID}, result.Consolidated[0].MergedPositionIDs)
+
+	survivor := positionRepo.GetPositionByID(first.ID)
+	assert.Equal(t, 40.0, survivor.Quantity)
+	assert.Equal(t, 4600.0, survivor.TotalInvestment)
+	assert.Equal(t, 115.0, survivor.AveragePrice)
+	assert.Equal(t, domain.PositionStatusActive, survivor.Status)
+
+	closed := positionRepo.GetPositionByID(second.ID)
+	assert.Equal(t, 0.0, closed.Quantity)
+	assert.Equal(t, domain.PositionStatusClosed, closed.Status)
+
+	assert.Equal(t, 5.0, positionRepo.GetPositionByID(other.ID).Quantity)
+
+	active, err := positionRepo.FindActivePositions(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Len(t, active, 2)
+
+	survivorEntries, _ := ledgerRepo.FindByPositionID(context.Background(), first.ID)
+	require.Len(t, survivorEntries, 1)
+	assert.Equal(t, domain.PositionLedgerEntryTypeConsolidation, survivorEntries[0].EntryType)
+	assert.Equal(t, 10.0, survivorEntries[0].PreviousQuantity)
+	assert.Equal(t, 40.0, survivorEntries[0].Quantity)
+	assert.Equal(t, 100.0, survivorEntries[0].PreviousAveragePrice)
+	assert.Equal(t, 115.0, survivorEntries[0].AveragePrice)
+
+	duplicateEntries, _ := ledgerRepo.FindByPositionID(context.Background(), second.ID)
+	require.Len(t, duplicateEntries, 1)
+	assert.Equal(t, 30.0, duplicateEntries[0].PreviousQuantity)
+	assert.Equal(t, 0.0, duplicateEntries[0].Quantity)
+}
+
+func TestConsolidatePositionsUseCase_IsIdempotent(t *testing.T) {
+	userID := uuid.New()
+	positionRepo := NewMockPositionRepositoryForNew()
+	ledgerRepo := &InMemoryPositionLedgerRepository{}
+
+	first, second := newDuplicatePositions(t, userID)
+	positionRepo.AddPosition(first)
+	positionRepo.AddPosition(second)
+
+	useCase := NewConsolidatePositionsUseCase(positionRepo, ledgerRepo, DefaultConsolidatePositionsConfig())
+	_, err := useCase.Execute(context.Background(), userID.String())
+	require.NoError(t, err)
+
+	result, err := useCase.Execute(context.Background(), userID.String())
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Consolidated)
+	assert.Equal(t, 40.0, positionRepo.GetPositionByID(first.ID).Quantity)
+	assert.Len(t, ledgerRepo.entries, 2)
+}
+
+func TestConsolidatePositionsUseCase_FinishesPartialConsolidation(t *testing.T) {
+	userID := uuid.New()
+	positionRepo := NewMockPositionRepositoryForNew()
+	ledgerRepo := &InMemoryPositionLedgerRepository{}
+
+	first, second := newDuplicatePositions(t, userID)
+	positionRepo.AddPosition(first)
+	positionRepo.AddPosition(second)
+
+	// Simulate a run that saved the survivor but failed before closing the duplicate
+	survivorCopy := *first
+	duplicateCopy := *second
+	require.NoError(t, survivorCopy.AbsorbDuplicate(&duplicateCopy, domain.DefaultQuantityPrecision, domain.DefaultPricePrecision))
+	*first = survivorCopy
+	entry, err := domain.NewConsolidationLedgerEntry(first, second.ID, 10, 100, "absorbed")
+	require.NoError(t, err)
+	require.NoError(t, ledgerRepo.Save(context.Background(), entry))
+
+	useCase := NewConsolidatePositionsUseCase(positionRepo, ledgerRepo, DefaultConsolidatePositionsConfig())
+	result, err := useCase.Execute(context.Background(), userID.String())
+	require.NoError(t, err)
+
+	require.Len(t, result.Consolidated, 1)
+	assert.Equal(t, 40.0, positionRepo.GetPositionByID(first.ID).Quantity)
+	assert.Equal(t, domain.PositionStatusClosed, positionRepo.GetPositionByID(second.ID).Status)
+	assert.Equal(t, 0.0, positionRepo.GetPositionByID(second.ID).Quantity)
+}
+
+func TestConsolidatePositionsUseCase_InvalidUserID(t *testing.T) {
+	useCase := NewConsolidatePositionsUseCase(NewMockPositionRepositoryForNew(), &InMemoryPositionLedgerRepository{}, DefaultConsolidatePositionsConfig())
+
+	_, err := useCase.Execute(context.Background(), "not-a-uuid")
+	assert.Error(t, err)
+}
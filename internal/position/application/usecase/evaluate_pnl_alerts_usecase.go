@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/domain/repository"
+)
+
+// IPnLAlertNotifier pushes fired P&L alerts to the user (dependency inversion)
+type IPnLAlertNotifier interface {
+	NotifyPnLAlert(ctx context.Context, alert *domain.PnLAlert, revaluation domain.PositionRevaluation) error
+}
+
+type IEvaluatePnLAlertsUseCase interface {
+	// Execute revalues the positions watched by the symbol's armed alerts at the quoted price and
+	// returns the alerts that fired
+	Execute(ctx context.Context, symbol string, price float64, quotedAt time.Time) ([]*domain.PnLAlert, error)
+}
+
+// EvaluatePnLAlertsUseCase checks P&L alerts as positions are revalued from realtime quotes
+type EvaluatePnLAlertsUseCase struct {
+	alertRepository    repository.IPnLAlertRepository
+	positionRepository repository.IPositionRepository
+	notifier           IPnLAlertNotifier
+}
+
+func NewEvaluatePnLAlertsUseCase(
+	alertRepository repository.IPnLAlertRepository,
+	positionRepository repository.IPositionRepository,
+	notifier IPnLAlertNotifier,
+) IEvaluatePnLAlertsUseCase {
+	return &EvaluatePnLAlertsUseCase{
+		alertRepository:    alertRepository,
+		positionRepository: positionRepository,
+		notifier:           notifier,
+	}
+}
+
+// Execute fires every armed alert whose position the quote pushes past its threshold. A fired alert
+// is saved before it is pushed, so a failed push never fires the same crossing twice.
+func (uc *EvaluatePnLAlertsUseCase) Execute(ctx context.Context, symbol string, price float64, quotedAt time.Time) ([]*domain.PnLAlert, error) {
+	if price <= 0 {
+		return nil, fmt.Errorf("quote price for %s must be positive", symbol)
+	}
+
+	alerts, err := uc.alertRepository.FindArmedBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find armed P&L alerts: %w", err)
+	}
+
+	fired := make([]*domain.PnLAlert, 0)
+	for _, alert := range alerts {
+		position, err := uc.positionRepository.FindByUserIDAndSymbol(ctx, alert.UserID, alert.Symbol)
+		if err != nil {
+			log.Printf("Warning: Failed to load %s position of user %s for P&L alert %s: %v", alert.Symbol, alert.UserID, alert.ID, err)
+			continue
+		}
+		if position == nil || position.Status == domain.PositionStatusClosed || position.IsEmpty() {
+			continue
+		}
+
+		revaluation := domain.NewPositionRevaluation(position, price)
+		if !alert.Evaluate(revaluation, quotedAt) {
+			continue
+		}
+
+		if err := uc.alertRepository.Save(ctx, alert); err != nil {
+			return fired, fmt.Errorf("failed to save fired P&L alert %s: %w", alert.ID, err)
+		}
+		fired = append(fired, alert)
+
+		if uc.notifier == nil {
+			continue
+		}
+		if err := uc.notifier.NotifyPnLAlert(ctx, alert, revaluation); err != nil {
+			log.Printf("Warning: Failed to push P&L alert %s to user %s: %v", alert.ID, alert.UserID, err)
+		}
+	}
+
+	return fired, nil
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/position/application/command"
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type inMemoryPnLAlertRepository struct {
+	alerts map[uuid.UUID]*domain.PnLAlert
+}
+
+func newInMemoryPnLAlertRepository() *inMemoryPnLAlertRepository {
+	return &inMemoryPnLAlertRepository{alerts: make(map[uuid.UUID]*domain.PnLAlert)}
+}
+
+func (r *inMemoryPnLAlertRepository) Save(ctx context.Context, alert *domain.PnLAlert) error {
+	stored := *alert
+	r.alerts[alert.ID] = &stored
+	return nil
+}
+
+func (r *inMemoryPnLAlertRepository) FindByID(ctx context.Context, alertID uuid.UUID) (*domain.PnLAlert, error) {
+	alert, exists := r.alerts[alertID]
+	if !exists {
+		return nil, nil
+	}
+	found := *alert
+	return &found, nil
+}
+
+func (r *inMemoryPnLAlertRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.PnLAlert, error) {
+	var alerts []*domain.PnLAlert
+	for _, alert := range r.alerts {
+		if alert.UserID == userID {
+			found := *alert
+			alerts = append(alerts, &found)
+		}
+	}
+	return alerts, nil
+}
+
+func (r *inMemoryPnLAlertRepository) FindArmedBySymbol(ctx context.Context, symbol string) ([]*domain.PnLAlert, error) {
+	var alerts []*domain.PnLAlert
+	for _, alert := range r.alerts {
+		if alert.Symbol == symbol && !alert.Triggered {
+			found := *alert
+			alerts = append(alerts, &found)
+		}
+	}
+	return alerts, nil
+}
+
+func (r *inMemoryPnLAlertRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	alerts, _ := r.FindByUserID(ctx, userID)
+	return len(alerts), nil
+}
+
+func (r *inMemoryPnLAlertRepository) Delete(ctx context.Context, alertID uuid.UUID) error {
+	delete(r.alerts, alertID)
+	return nil
+}
+
+type recordingPnLAlertNotifier struct {
+	notified []*domain.PnLAlert
+}
+
+func (n *recordingPnLAlertNotifier) NotifyPnLAlert(ctx context.Context, alert *domain.PnLAlert, revaluation domain.PositionRevaluation) error {
+	n.notified = append(n.notified, alert)
+	return nil
+}
+
+func TestEvaluatePnLAlertsUseCase_LossAlertFiresOnceUntilReset(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	positionRepo := NewMockPositionRepositoryForNew()
+	position, err := domain.NewPosition(userID, "AAPL", 10, 100, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	positionRepo.AddPosition(position)
+
+	alertRepo := newInMemoryPnLAlertRepository()
+	notifier := &recordingPnLAlertNotifier{}
+	manage := NewManagePnLAlertsUseCase(alertRepo, DefaultPnLAlertConfig())
+	evaluate := NewEvaluatePnLAlertsUseCase(alertRepo, positionRepo, notifier)
+
+	alert, err := manage.Create(ctx, &command.CreatePnLAlertCommand{
+		UserID:        userID.String(),
+		Symbol:        "AAPL",
+		Direction:     "LOSS",
+		ThresholdType: "PERCENT",
+		Threshold:     10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	for _, price := range []float64{98, 95, 91} {
+		fired, err := evaluate.Execute(ctx, "AAPL", price, time.Now())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fired) != 0 {
+			t.Fatalf("Expected no alert above the loss threshold at %.2f, got %d", price, len(fired))
+		}
+	}
+
+	fired, err := evaluate.Execute(ctx, "AAPL", 89, time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fired) != 1 || fired[0].ID != alert.ID {
+		t.Fatalf("Expected the loss alert to fire once, got %d", len(fired))
+	}
+
+	for _, price := range []float64{85, 80, 89} {
+		fired, err := evaluate.Execute(ctx, "AAPL", price, time.Now())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fired) != 0 {
+			t.Fatalf("Expected a fired alert to stay quiet at %.2f, got %d", price, len(fired))
+		}
+	}
+	if len(notifier.notified) != 1 {
+		t.Fatalf("Expected exactly one notification, got %d", len(notifier.notified))
+	}
+
+	stored, _ := alertRepo.FindByID(ctx, alert.ID)
+	if !stored.Triggered || stored.TriggeredAt == nil {
+		t.Errorf("Expected the fired alert to be persisted as triggered, got %+v", stored)
+	}
+
+	if _, err := manage.Reset(ctx, userID.String(), alert.ID.String()); err != nil {
+		t.Fatalf("Failed to reset alert: %v", err)
+	}
+
+	fired, err = evaluate.Execute(ctx, "AAPL", 88, time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fired) != 1 || len(notifier.notified) != 2 {
+		t.Errorf("Expected the reset alert to fire again, got %d fired and %d notifications", len(fired), len(notifier.notified))
+	}
+}
+
+func TestEvaluatePnLAlertsUseCase_SkipsUsersWithoutPosition(t *testing.T) {
+	ctx := context.Background()
+	alertRepo := newInMemoryPnLAlertRepository()
+	notifier := &recordingPnLAlertNotifier{}
+
+	alert, err := domain.NewPnLAlert(uuid.New(), "AAPL", domain.PnLAlertDirectionLoss, domain.PnLThresholdTypeAmount, 1)
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	alertRepo.Save(ctx, alert)
+
+	evaluate := NewEvaluatePnLAlertsUseCase(alertRepo, NewMockPositionRepositoryForNew(), notifier)
+	fired, err := evaluate.Execute(ctx, "AAPL", 1, time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fired) != 0 || len(notifier.notified) != 0 {
+		t.Errorf("Expected no alert without a position, got %d", len(fired))
+	}
+}
+
+func TestManagePnLAlertsUseCase_EnforcesPerUserLimit(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	manage := NewManagePnLAlertsUseCase(newInMemoryPnLAlertRepository(), PnLAlertConfig{MaxAlertsPerUser: 1})
+
+	cmd := &command.CreatePnLAlertCommand{UserID: userID.String(), Symbol: "AAPL", Direction: "GAIN", ThresholdType: "AMOUNT", Threshold: 50}
+	if _, err := manage.Create(ctx, cmd); err != nil {
+		t.Fatalf("Failed to create first alert: %v", err)
+	}
+	if _, err := manage.Create(ctx, cmd); err == nil {
+		t.Error("Expected the second alert to exceed the per-user limit")
+	}
+}
+
+func TestManagePnLAlertsUseCase_ResetOtherUsersAlert(t *testing.T) {
+	ctx := context.Background()
+	repo := newInMemoryPnLAlertRepository()
+	manage := NewManagePnLAlertsUseCase(repo, DefaultPnLAlertConfig())
+
+	alert, _ := domain.NewPnLAlert(uuid.New(), "AAPL", domain.PnLAlertDirectionGain, domain.PnLThresholdTypePercent, 5)
+	repo.Save(ctx, alert)
+
+	if _, err := manage.Reset(ctx, uuid.New().String(), alert.ID.String()); err != ErrPnLAlertNotFound {
+		t.Errorf("Expected ErrPnLAlertNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/domain/repository"
+)
+
+var (
+	// ErrClosePreviewPositionNotFound is returned when the user holds no open position in the symbol
+	ErrClosePreviewPositionNotFound = errors.New("position not found")
+	// ErrInvalidClosePreview is returned when the requested close cannot be previewed, such as a quantity above the position
+	ErrInvalidClosePreview = errors.New("invalid close preview request")
+)
+
+// IPositionTradeSource provides the executed trades behind a user's position (dependency inversion)
+type IPositionTradeSource interface {
+	FindExecutedTrades(ctx context.Context, userID, symbol string) ([]domain.PositionTrade, error)
+}
+
+// ClosePreviewConfig holds configuration for position close previews
+type ClosePreviewConfig struct {
+	LotMatchingMethod domain.LotMatchingMethod // How realized P&L is computed; FIFO also lists the lots consumed
+}
+
+type IGetClosePreviewUseCase interface {
+	// Execute previews closing the quantity of the user's position in the symbol. Without a
+	// close price the position's current price is used.
+	Execute(ctx context.Context, userID, symbol string, quantity float64, closePrice *float64) (*domain.PositionClosePreview, error)
+}
+
+type GetClosePreviewUseCase struct {
+	positionRepository repository.IPositionRepository
+	tradeSource        IPositionTradeSource
+	config             ClosePreviewConfig
+}
+
+func NewGetClosePreviewUseCase(
+	positionRepository repository.IPositionRepository,
+	tradeSource IPositionTradeSource,
+	config ClosePreviewConfig,
+) IGetClosePreviewUseCase {
+	return &GetClosePreviewUseCase{
+		positionRepository: positionRepository,
+		tradeSource:        tradeSource,
+		config:             config,
+	}
+}
+
+func (uc *GetClosePreviewUseCase) Execute(ctx context.Context, userID, symbol string, quantity float64, closePrice *float64) (*domain.PositionClosePreview, error) {
+	userUUID, err := parseUserIDToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", userID, err)
+	}
+
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	position, err := uc.positionRepository.FindByUserIDAndSymbol(ctx, userUUID, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find position: %w", err)
+	}
+	if position == nil || !position.CanBeClosed() {
+		return nil, fmt.Errorf("%w: no open position in %s", ErrClosePreviewPositionNotFound, symbol)
+	}
+
+	price := position.CurrentPrice
+	if closePrice != nil {
+		price = *closePrice
+	}
+	if price <= 0 {
+		return nil, fmt.Errorf("%w: no current price for %s, provide a close price", ErrInvalidClosePreview, symbol)
+	}
+
+	method := uc.config.LotMatchingMethod
+	if method == "" {
+		method = domain.LotMatchingAverageCost
+	}
+
+	var lots []domain.TaxLot
+	if method == domain.LotMatchingFIFO {
+		trades, err := uc.tradeSource.FindExecutedTrades(ctx, userID, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trades for %s: %w", symbol, err)
+		}
+		lots = domain.BuildFIFOTaxLots(trades)
+	}
+
+	preview, err := position.PreviewClose(quantity, price, method, lots)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidClosePreview, err)
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type MockPositionTradeSource struct {
+	trades []domain.PositionTrade
+	err    error
+}
+
+func (m *MockPositionTradeSource) FindExecutedTrades(ctx context.Context, userID, symbol string) ([]domain.PositionTrade, error) {
+	return m.trades, m.err
+}
+
+// newClosePreviewFixture holds 15 AAPL left from buying 10 @ 100 and 10 @ 120 and selling 5,
+// so the open FIFO lots are 5 @ 100 and 10 @ 120
+func newClosePreviewFixture(t *testing.T, method domain.LotMatchingMethod) (IGetClosePreviewUseCase, string) {
+	userID := uuid.New()
+	position, err := domain.NewPosition(userID, "AAPL", 15, 1700.0/15, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	position.CurrentPrice = 130
+
+	repo := NewMockPositionRepositoryForNew()
+	repo.AddPosition(position)
+
+	day := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	trades := &MockPositionTradeSource{trades: []domain.PositionTrade{
+		{OrderID: "sell-1", Quantity: 5, Price: 110, IsBuy: false, ExecutedAt: day.AddDate(0, 0, 2)},
+		{OrderID: "buy-2", Quantity: 10, Price: 120, IsBuy: true, ExecutedAt: day.AddDate(0, 0, 1)},
+		{OrderID: "buy-1", Quantity: 10, Price: 100, IsBuy: true, ExecutedAt: day},
+	}}
+
+	return NewGetClosePreviewUseCase(repo, trades, ClosePreviewConfig{LotMatchingMethod: method}), userID.String()
+}
+
+func TestGetClosePreviewUseCase_Execute_FullCloseFIFO(t *testing.T) {
+	// Arrange
+	useCase, userID := newClosePreviewFixture(t, domain.LotMatchingFIFO)
+
+	// Act
+	preview, err := useCase.Execute(context.Background(), userID, "aapl", 15, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !preview.FullClose || preview.RemainingQuantity != 0 {
+		t.Errorf("Expected a full close, got remaining %.2f", preview.RemainingQuantity)
+	}
+	if preview.ClosePrice != 130 || preview.Proceeds != 1950 || preview.CostBasis != 1700 || preview.RealizedPnL != 250 {
+		t.Errorf("Unexpected totals %+v", preview)
+	}
+	if len(preview.Lots) != 2 {
+		t.Fatalf("Expected 2 lots consumed, got %d", len(preview.Lots))
+	}
+	if preview.Lots[0].SourceOrderID != "buy-1" || preview.Lots[0].Quantity != 5 || preview.Lots[0].RealizedPnL != 150 {
+		t.Errorf("Expected the rest of the oldest lot to close first, got %+v", preview.Lots[0])
+	}
+	if preview.Lots[1].SourceOrderID != "buy-2" || preview.Lots[1].Quantity != 10 || preview.Lots[1].RemainingQuantity != 0 {
+		t.Errorf("Expected the second lot to close in full, got %+v", preview.Lots[1])
+	}
+	if len(preview.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", preview.Warnings)
+	}
+}
+
+func TestGetClosePreviewUseCase_Execute_PartialCloseFIFO(t *testing.T) {
+	// Arrange
+	useCase, userID := newClosePreviewFixture(t, domain.LotMatchingFIFO)
+	closePrice := 125.0
+
+	// Act
+	preview, err := useCase.Execute(context.Background(), userID, "AAPL", 8, &closePrice)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if preview.FullClose || preview.RemainingQuantity != 7 {
+		t.Errorf("Expected 7 shares to remain, got %.2f", preview.RemainingQuantity)
+	}
+	if preview.Proceeds != 1000 || preview.CostBasis != 860 || preview.RealizedPnL != 140 {
+		t.Errorf("Unexpected totals %+v", preview)
+	}
+	if len(preview.Lots) != 2 {
+		t.Fatalf("Expected 2 lots consumed, got %d", len(preview.Lots))
+	}
+	if preview.Lots[1].SourceOrderID != "buy-2" || preview.Lots[1].Quantity != 3 || preview.Lots[1].RemainingQuantity != 7 {
+		t.Errorf("Expected 3 of the second lot to close and 7 to stay open, got %+v", preview.Lots[1])
+	}
+}
+
+func TestGetClosePreviewUseCase_Execute_AverageCostListsNoLots(t *testing.T) {
+	// Arrange
+	useCase, userID := newClosePreviewFixture(t, domain.LotMatchingAverageCost)
+
+	// Act
+	preview, err := useCase.Execute(context.Background(), userID, "AAPL", 3, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(preview.Lots) != 0 {
+		t.Errorf("Expected no lots under average cost, got %d", len(preview.Lots))
+	}
+	if math.Abs(preview.CostBasis-340) > 1e-9 || math.Abs(preview.RealizedPnL-50) > 1e-9 {
+		t.Errorf("Expected cost basis 340 and P&L 50, got %.4f and %.4f", preview.CostBasis, preview.RealizedPnL)
+	}
+}
+
+func TestGetClosePreviewUseCase_Execute_InvalidRequests(t *testing.T) {
+	// Arrange
+	useCase, userID := newClosePreviewFixture(t, domain.LotMatchingFIFO)
+
+	// Act
+	_, tooMuchErr := useCase.Execute(context.Background(), userID, "AAPL", 16, nil)
+	_, missingErr := useCase.Execute(context.Background(), userID, "MSFT", 1, nil)
+
+	// Assert
+	if !errors.Is(tooMuchErr, ErrInvalidClosePreview) {
+		t.Errorf("Expected ErrInvalidClosePreview for closing more than the position, got %v", tooMuchErr)
+	}
+	if !errors.Is(missingErr, ErrClosePreviewPositionNotFound) {
+		t.Errorf("Expected ErrClosePreviewPositionNotFound, got %v", missingErr)
+	}
+}
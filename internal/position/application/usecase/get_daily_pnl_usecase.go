@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// DailyPnLConfig holds configuration for the account daily P&L summary
+type DailyPnLConfig struct {
+	PriceTimeout     time.Duration // Maximum wait for the batch live price request
+	MarkLookbackDays int           // Days searched back for the prior end-of-day mark, covering weekends and holidays
+}
+
+// DefaultDailyPnLConfig returns the default daily P&L configuration
+func DefaultDailyPnLConfig() DailyPnLConfig {
+	return DailyPnLConfig{
+		PriceTimeout:     5 * time.Second,
+		MarkLookbackDays: 7, // Reaches past a long weekend with a holiday
+	}
+}
+
+type IGetDailyPnLUseCase interface {
+	// Execute sums the P&L the user realized today with the change in unrealized P&L of their
+	// positions since the prior end-of-day mark, valuing open positions at live prices
+	Execute(ctx context.Context, userID string) (*domain.AccountDailyPnL, error)
+}
+
+type GetDailyPnLUseCase struct {
+	positionRepository  repository.IPositionRepository
+	valuationRepository repository.IPositionValuationRepository
+	tradeSource         IPositionTradeSource
+	marketDataClient    IBatchMarketDataClient
+	config              DailyPnLConfig
+	now                 func() time.Time
+}
+
+func NewGetDailyPnLUseCase(
+	positionRepository repository.IPositionRepository,
+	valuationRepository repository.IPositionValuationRepository,
+	tradeSource IPositionTradeSource,
+	marketDataClient IBatchMarketDataClient,
+	config DailyPnLConfig,
+) IGetDailyPnLUseCase {
+	return &GetDailyPnLUseCase{
+		positionRepository:  positionRepository,
+		valuationRepository: valuationRepository,
+		tradeSource:         tradeSource,
+		marketDataClient:    marketDataClient,
+		config:              config,
+		now:                 time.Now,
+	}
+}
+
+func (uc *GetDailyPnLUseCase) Execute(ctx context.Context, userID string) (*domain.AccountDailyPnL, error) {
+	userUUID, err := parseUserIDToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", userID, err)
+	}
+
+	now := uc.now()
+	tradingDay := domain.TruncateToTradingDay(now)
+
+	// Closed positions are included so a position sold out today still reports its realized P&L
+	positions, err := uc.positionRepository.FindByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find positions: %w", err)
+	}
+
+	priorMarkDay, priorValuations, err := uc.findPriorMark(ctx, userUUID, tradingDay)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AccountDailyPnL{
+		UserID:       userUUID,
+		TradingDay:   tradingDay,
+		PriorMarkDay: priorMarkDay,
+		Symbols:      make([]domain.SymbolDailyPnL, 0),
+		CalculatedAt: now,
+	}
+	if priorMarkDay == nil {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("No end-of-day mark in the last %d days, unrealized P&L is measured against cost", uc.config.MarkLookbackDays))
+	}
+
+	symbols := make(map[string]*domain.SymbolDailyPnL)
+	entryFor := func(symbol string) *domain.SymbolDailyPnL {
+		if _, exists := symbols[symbol]; !exists {
+			symbols[symbol] = &domain.SymbolDailyPnL{Symbol: symbol}
+		}
+		return symbols[symbol]
+	}
+
+	for _, valuation := range priorValuations {
+		entryFor(valuation.Symbol).PriorUnrealizedPnL += valuation.UnrealizedPnL
+	}
+
+	active := make([]*domain.Position, 0, len(positions))
+	for _, position := range positions {
+		if position.Status.CanBeUpdated() && position.Quantity > 0 {
+			active = append(active, position)
+		}
+	}
+
+	priceMap := fetchBatchMarketPrices(ctx, uc.marketDataClient, active, uc.config.PriceTimeout)
+	for _, position := range active {
+		revaluation := domain.NewPositionRevaluation(position, priceMap[position.Symbol])
+		if !revaluation.LivePrice {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("No live price for %s, valued at the last known price %.2f", position.Symbol, position.CurrentPrice))
+		}
+		entryFor(position.Symbol).UnrealizedPnL += revaluation.UnrealizedPnL
+	}
+
+	for _, position := range positions {
+		if position.LastTradeAt == nil || !domain.TruncateToTradingDay(position.LastTradeAt.In(tradingDay.Location())).Equal(tradingDay) {
+			continue
+		}
+
+		realized, err := uc.realizedPnLOnDay(ctx, userID, position, tradingDay, result)
+		if err != nil {
+			return nil, err
+		}
+		entryFor(position.Symbol).RealizedPnL += realized
+	}
+
+	for _, symbol := range symbols {
+		result.AddSymbol(*symbol)
+	}
+
+	return result, nil
+}
+
+// findPriorMark returns the latest end-of-day mark before the trading day and the user's valuations in it
+func (uc *GetDailyPnLUseCase) findPriorMark(ctx context.Context, userID uuid.UUID, tradingDay time.Time) (*time.Time, []*domain.PositionValuation, error) {
+	for daysBack := 1; daysBack <= uc.config.MarkLookbackDays; daysBack++ {
+		markDay := tradingDay.AddDate(0, 0, -daysBack)
+
+		valuations, err := uc.valuationRepository.FindByTradingDay(ctx, markDay)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find end-of-day valuations: %w", err)
+		}
+		// The mark-to-market job values every open position, so any valuation marks the day as run
+		if len(valuations) == 0 {
+			continue
+		}
+
+		userValuations := make([]*domain.PositionValuation, 0)
+		for _, valuation := range valuations {
+			if valuation.UserID == userID {
+				userValuations = append(userValuations, valuation)
+			}
+		}
+		return &markDay, userValuations, nil
+	}
+
+	return nil, nil, nil
+}
+
+// realizedPnLOnDay replays the position's executed trades to find the P&L realized by today's sells
+func (uc *GetDailyPnLUseCase) realizedPnLOnDay(ctx context.Context, userID string, position *domain.Position, tradingDay time.Time, result *domain.AccountDailyPnL) (float64, error) {
+	trades, err := uc.tradeSource.FindExecutedTrades(ctx, userID, position.Symbol)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find executed trades for %s: %w", position.Symbol, err)
+	}
+
+	costBasis := position.CostBasisPerShare()
+	realized, uncovered := domain.RealizedPnLOnDay(trades, tradingDay, costBasis)
+	if uncovered > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%.6f %s shares sold today are not covered by known trades and use the average cost %.2f", uncovered, position.Symbol, costBasis))
+	}
+
+	return realized, nil
+}
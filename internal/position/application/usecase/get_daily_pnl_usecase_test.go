@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type symbolTradeSource struct {
+	trades map[string][]domain.PositionTrade
+}
+
+func (s *symbolTradeSource) FindExecutedTrades(ctx context.Context, userID, symbol string) ([]domain.PositionTrade, error) {
+	return s.trades[symbol], nil
+}
+
+type MockPositionValuationRepository struct {
+	valuations []*domain.PositionValuation
+}
+
+func (m *MockPositionValuationRepository) Save(ctx context.Context, valuation *domain.PositionValuation) error {
+	m.valuations = append(m.valuations, valuation)
+	return nil
+}
+
+func (m *MockPositionValuationRepository) ExistsForTradingDay(ctx context.Context, positionID uuid.UUID, tradingDay time.Time) (bool, error) {
+	for _, valuation := range m.valuations {
+		if valuation.PositionID == positionID && valuation.TradingDay.Equal(domain.TruncateToTradingDay(tradingDay)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockPositionValuationRepository) FindByTradingDay(ctx context.Context, tradingDay time.Time) ([]*domain.PositionValuation, error) {
+	var result []*domain.PositionValuation
+	for _, valuation := range m.valuations {
+		if valuation.TradingDay.Equal(domain.TruncateToTradingDay(tradingDay)) {
+			result = append(result, valuation)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockPositionValuationRepository) FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionValuation, error) {
+	var result []*domain.PositionValuation
+	for _, valuation := range m.valuations {
+		if valuation.PositionID == positionID {
+			result = append(result, valuation)
+		}
+	}
+	return result, nil
+}
+
+// dailyPnLFixture is a user on Friday 15 March 2024 whose positions were last marked on Thursday
+type dailyPnLFixture struct {
+	userID     uuid.UUID
+	now        time.Time
+	positions  *MockPositionRepositoryForNew
+	valuations *MockPositionValuationRepository
+	trades     *symbolTradeSource
+	prices     map[string]float64
+}
+
+func newDailyPnLFixture() *dailyPnLFixture {
+	return &dailyPnLFixture{
+		userID:     uuid.New(),
+		now:        time.Date(2024, 3, 15, 15, 0, 0, 0, time.UTC),
+		positions:  NewMockPositionRepositoryForNew(),
+		valuations: &MockPositionValuationRepository{},
+		trades:     &symbolTradeSource{trades: make(map[string][]domain.PositionTrade)},
+		prices:     make(map[string]float64),
+	}
+}
+
+// addPosition holds the quantity at the average price; lastTradeAt decides whether trades are replayed
+func (f *dailyPnLFixture) addPosition(t *testing.T, symbol string, quantity, averagePrice float64, lastTradeAt time.Time) *domain.Position {
+	position, err := domain.NewPosition(f.userID, symbol, quantity, averagePrice, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	position.CurrentPrice = averagePrice
+	position.LastTradeAt = &lastTradeAt
+	f.positions.AddPosition(position)
+	return position
+}
+
+// markPriorClose stores Thursday's end-of-day valuation of the quantity at the close price
+func (f *dailyPnLFixture) markPriorClose(t *testing.T, position *domain.Position, quantity, closePrice float64) {
+	marked := *position
+	marked.Quantity = quantity
+	marked.TotalInvestment = quantity * position.AveragePrice
+
+	valuation, err := domain.NewPositionValuation(&marked, closePrice, f.now.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("Failed to create valuation: %v", err)
+	}
+	f.valuations.valuations = append(f.valuations.valuations, valuation)
+}
+
+func (f *dailyPnLFixture) execute(t *testing.T) *domain.AccountDailyPnL {
+	useCase := NewGetDailyPnLUseCase(f.positions, f.valuations, f.trades,
+		&MockBatchMarketDataClient{prices: f.prices}, DefaultDailyPnLConfig()).(*GetDailyPnLUseCase)
+	useCase.now = func() time.Time { return f.now }
+
+	result, err := useCase.Execute(context.Background(), f.userID.String())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
+
+func assertPnL(t *testing.T, name string, expected, actual float64) {
+	t.Helper()
+	if math.Abs(expected-actual) > 1e-9 {
+		t.Errorf("Expected %s %.2f, got %.2f", name, expected, actual)
+	}
+}
+
+func TestGetDailyPnLUseCase_Execute_RealizedGains(t *testing.T) {
+	// Arrange: 10 AAPL bought at 100 and marked at 115 yesterday, all sold at 120 today
+	fixture := newDailyPnLFixture()
+	position := fixture.addPosition(t, "AAPL", 10, 100, fixture.now)
+	fixture.markPriorClose(t, position, 10, 115)
+	position.Quantity = 0
+	position.TotalInvestment = 0
+	position.Status = domain.PositionStatusClosed
+	fixture.trades.trades["AAPL"] = []domain.PositionTrade{
+		{OrderID: "buy-1", Quantity: 10, Price: 100, IsBuy: true, ExecutedAt: fixture.now.AddDate(0, 0, -14)},
+		{OrderID: "sell-1", Quantity: 10, Price: 120, IsBuy: false, ExecutedAt: fixture.now.Add(-time.Hour)},
+	}
+
+	// Act
+	result := fixture.execute(t)
+
+	// Assert: 200 realized against cost, of which 150 was already unrealized at yesterday's mark
+	assertPnL(t, "realized P&L", 200, result.RealizedPnL)
+	assertPnL(t, "unrealized change", -150, result.UnrealizedChange)
+	assertPnL(t, "daily P&L", 50, result.DailyPnL)
+	if result.PriorMarkDay == nil || !result.PriorMarkDay.Equal(time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the prior mark on 2024-03-14, got %v", result.PriorMarkDay)
+	}
+	if len(result.Symbols) != 1 || result.Symbols[0].Symbol != "AAPL" || result.Symbols[0].UnrealizedPnL != 0 {
+		t.Errorf("Expected a single closed AAPL entry, got %+v", result.Symbols)
+	}
+}
+
+func TestGetDailyPnLUseCase_Execute_UnrealizedLosses(t *testing.T) {
+	// Arrange: 5 MSFT bought at 300 two weeks ago, marked at 310 yesterday, trading at 290 now
+	fixture := newDailyPnLFixture()
+	position := fixture.addPosition(t, "MSFT", 5, 300, fixture.now.AddDate(0, 0, -14))
+	fixture.markPriorClose(t, position, 5, 310)
+	fixture.prices["MSFT"] = 290
+
+	// Act
+	result := fixture.execute(t)
+
+	// Assert
+	assertPnL(t, "realized P&L", 0, result.RealizedPnL)
+	assertPnL(t, "unrealized change", -100, result.UnrealizedChange)
+	assertPnL(t, "daily P&L", -100, result.DailyPnL)
+	if len(result.Symbols) != 1 {
+		t.Fatalf("Expected 1 symbol, got %+v", result.Symbols)
+	}
+	msft := result.Symbols[0]
+	assertPnL(t, "MSFT prior unrealized P&L", 50, msft.PriorUnrealizedPnL)
+	assertPnL(t, "MSFT unrealized P&L", -50, msft.UnrealizedPnL)
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", result.Warnings)
+	}
+}
+
+func TestGetDailyPnLUseCase_Execute_MixedDay(t *testing.T) {
+	// Arrange: 4 of 10 AAPL (cost 100, marked 115) sold at 120 with the rest now at 118,
+	// while MSFT (cost 300, marked 310) fell to 290
+	fixture := newDailyPnLFixture()
+	aapl := fixture.addPosition(t, "AAPL", 10, 100, fixture.now.Add(-2*time.Hour))
+	fixture.markPriorClose(t, aapl, 10, 115)
+	aapl.Quantity = 6
+	aapl.TotalInvestment = 600
+	fixture.trades.trades["AAPL"] = []domain.PositionTrade{
+		{OrderID: "buy-1", Quantity: 10, Price: 100, IsBuy: true, ExecutedAt: fixture.now.AddDate(0, 0, -14)},
+		{OrderID: "sell-1", Quantity: 4, Price: 120, IsBuy: false, ExecutedAt: fixture.now.Add(-2 * time.Hour)},
+	}
+
+	msft := fixture.addPosition(t, "MSFT", 5, 300, fixture.now.AddDate(0, 0, -14))
+	fixture.markPriorClose(t, msft, 5, 310)
+	fixture.prices["AAPL"] = 118
+	fixture.prices["MSFT"] = 290
+
+	// Act
+	result := fixture.execute(t)
+
+	// Assert: AAPL gains 4 x (120 - 115) on the sale and 6 x (118 - 115) on the rest, MSFT loses 5 x 20
+	assertPnL(t, "realized P&L", 80, result.RealizedPnL)
+	assertPnL(t, "unrealized change", -142, result.UnrealizedChange)
+	assertPnL(t, "daily P&L", -62, result.DailyPnL)
+	if len(result.Symbols) != 2 || result.Symbols[0].Symbol != "AAPL" || result.Symbols[1].Symbol != "MSFT" {
+		t.Fatalf("Expected AAPL and MSFT entries, got %+v", result.Symbols)
+	}
+	assertPnL(t, "AAPL daily P&L", 38, result.Symbols[0].DailyPnL)
+	assertPnL(t, "MSFT daily P&L", -100, result.Symbols[1].DailyPnL)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/RodriguesYan/hub-proto-contracts/monolith"
@@ -16,11 +17,42 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// IBatchMarketDataClient fetches market data for several symbols in one call
+type IBatchMarketDataClient interface {
+	GetBatchMarketData(ctx context.Context, in *monolith.GetBatchMarketDataRequest, opts ...grpc.CallOption) (*monolith.GetBatchMarketDataResponse, error)
+}
+
+// ISymbolCurrencyResolver resolves the currency a symbol trades in
+type ISymbolCurrencyResolver interface {
+	GetSymbolCurrency(ctx context.Context, symbol string) (string, error)
+}
+
+// IFXRateClient fetches the rate that converts an amount from one currency into another
+type IFXRateClient interface {
+	GetRate(ctx context.Context, fromCurrency, toCurrency string) (float64, error)
+}
+
+// CurrencyNormalizationConfig configures how holdings in several currencies are converted before aggregating
+type CurrencyNormalizationConfig struct {
+	BaseCurrency string        // Currency the account totals are reported in
+	Timeout      time.Duration // Upper bound on the currency and rate lookups of one aggregation
+}
+
+func DefaultCurrencyNormalizationConfig() CurrencyNormalizationConfig {
+	return CurrencyNormalizationConfig{
+		BaseCurrency: "USD",           // Same currency balances are reported in
+		Timeout:      5 * time.Second, // Same as the market price lookup
+	}
+}
+
 type GetPositionAggregationUseCase struct {
 	repo               repository.PositionRepository
 	aggregationService service.PositionAggregationService
 	marketDataClient   monolith.MarketDataServiceClient
 	grpcConn           *grpc.ClientConn
+	currencyResolver   ISymbolCurrencyResolver
+	fxClient           IFXRateClient
+	currencyConfig     CurrencyNormalizationConfig
 }
 
 func NewGetPositionAggregationUseCase(repo repository.PositionRepository) *GetPositionAggregationUseCase {
@@ -59,6 +91,14 @@ func NewGetPositionAggregationUseCaseWithService(repo repository.PositionReposit
 	}
 }
 
+// SetCurrencyNormalization converts every asset into the base currency before aggregating,
+// so holdings in different currencies are not summed as raw numbers
+func (uc *GetPositionAggregationUseCase) SetCurrencyNormalization(currencyResolver ISymbolCurrencyResolver, fxClient IFXRateClient, config CurrencyNormalizationConfig) {
+	uc.currencyResolver = currencyResolver
+	uc.fxClient = fxClient
+	uc.currencyConfig = config
+}
+
 func (uc *GetPositionAggregationUseCase) Execute(userId string) (domain.AucAggregationModel, error) {
 	userUUID, err := parseUserIDToUUID(userId)
 	if err != nil {
@@ -91,19 +131,108 @@ func (uc *GetPositionAggregationUseCase) Execute(userId string) (domain.AucAggre
 		}
 	}
 
+	var fxRates []domain.FxRateModel
+	if uc.isCurrencyNormalizationEnabled() {
+		fxRates, err = uc.normalizeCurrencies(assets)
+		if err != nil {
+			return domain.AucAggregationModel{}, err
+		}
+	}
+
 	positionAggregations := uc.aggregationService.AggregateAssetsByCategory(assets)
 	totalInvested, currentTotal := uc.aggregationService.CalculateTotals(assets)
 
-	return domain.AucAggregationModel{
+	aggregation := domain.AucAggregationModel{
 		TotalInvested:       totalInvested,
 		CurrentTotal:        currentTotal,
 		PositionAggregation: positionAggregations,
-	}, nil
+	}
+
+	if uc.isCurrencyNormalizationEnabled() {
+		aggregation.BaseCurrency = strings.ToUpper(uc.currencyConfig.BaseCurrency)
+		aggregation.FxRates = fxRates
+	}
+
+	return aggregation, nil
+}
+
+func (uc *GetPositionAggregationUseCase) isCurrencyNormalizationEnabled() bool {
+	return uc.currencyResolver != nil && uc.fxClient != nil && uc.currencyConfig.BaseCurrency != ""
+}
+
+// normalizeCurrencies converts the assets' prices into the base currency in place and returns the
+// rates used, one per foreign currency. Symbols whose currency cannot be resolved are assumed to
+// trade in the base currency; a missing rate fails the aggregation rather than mixing currencies.
+func (uc *GetPositionAggregationUseCase) normalizeCurrencies(assets []domain.AssetModel) ([]domain.FxRateModel, error) {
+	baseCurrency := strings.ToUpper(uc.currencyConfig.BaseCurrency)
+
+	ctx := context.Background()
+	if uc.currencyConfig.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, uc.currencyConfig.Timeout)
+		defer cancel()
+	}
+
+	rates := map[string]float64{baseCurrency: 1}
+	fxRates := make([]domain.FxRateModel, 0)
+
+	for i := range assets {
+		currency, err := uc.currencyResolver.GetSymbolCurrency(ctx, assets[i].Symbol)
+		if err != nil || currency == "" {
+			log.Printf("Warning: Could not resolve currency for %s, assuming %s: %v", assets[i].Symbol, baseCurrency, err)
+			currency = baseCurrency
+		}
+		currency = strings.ToUpper(currency)
+
+		rate, exists := rates[currency]
+		if !exists {
+			rate, err = uc.fxClient.GetRate(ctx, currency, baseCurrency)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s/%s exchange rate: %w", currency, baseCurrency, err)
+			}
+			if rate <= 0 {
+				return nil, fmt.Errorf("invalid %s/%s exchange rate: %f", currency, baseCurrency, rate)
+			}
+
+			rates[currency] = rate
+			fxRates = append(fxRates, domain.FxRateModel{
+				FromCurrency: currency,
+				ToCurrency:   baseCurrency,
+				Rate:         rate,
+			})
+		}
+
+		assets[i].Currency = currency
+		assets[i].FxRate = float32(rate)
+		assets[i].AveragePrice *= float32(rate)
+		assets[i].LastPrice *= float32(rate)
+	}
+
+	return fxRates, nil
 }
 
 // fetchMarketPrices fetches current market prices for all position symbols
 func (uc *GetPositionAggregationUseCase) fetchMarketPrices(positions []*domain.Position) map[string]float64 {
-	if uc.marketDataClient == nil || len(positions) == 0 {
+	if uc.marketDataClient == nil {
+		return make(map[string]float64)
+	}
+
+	return fetchBatchMarketPrices(context.Background(), uc.marketDataClient, positions, 5*time.Second)
+}
+
+// MarketDataClient returns the market data client used for live prices, nil when the market
+// data service was unreachable at startup
+func (uc *GetPositionAggregationUseCase) MarketDataClient() IBatchMarketDataClient {
+	if uc.marketDataClient == nil {
+		return nil
+	}
+	return uc.marketDataClient
+}
+
+// fetchBatchMarketPrices fetches current market prices for the positions' symbols in a single
+// batch request. Failures are logged and yield an empty map so callers fall back to stored prices.
+func fetchBatchMarketPrices(ctx context.Context, client IBatchMarketDataClient, positions []*domain.Position, timeout time.Duration) map[string]float64 {
+	if client == nil || len(positions) == 0 {
 		return make(map[string]float64)
 	}
 
@@ -119,11 +248,11 @@ func (uc *GetPositionAggregationUseCase) fetchMarketPrices(positions []*domain.P
 	}
 
 	// Fetch market data for all symbols
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	req := &monolith.GetBatchMarketDataRequest{Symbols: symbols}
-	resp, err := uc.marketDataClient.GetBatchMarketData(ctx, req)
+	resp, err := client.GetBatchMarketData(ctx, req)
 	if err != nil {
 		log.Printf("Warning: Failed to fetch market data for positions: %v", err)
 		return make(map[string]float64)
@@ -3,6 +3,7 @@ package usecase
 import (
 	domain "HubInvestments/internal/position/domain/model"
 	service "HubInvestments/internal/position/domain/service"
+	"context"
 	"fmt"
 	"testing"
 
@@ -204,3 +205,112 @@ func Test_GetPositionAggregationUseCase_IntegerUserID(t *testing.T) {
 	assert.Equal(t, float32(50.0), result.TotalInvested) // 5 * 10
 	assert.Equal(t, float32(55.0), result.CurrentTotal)  // 5 * 11
 }
+
+type MockSymbolCurrencyResolver struct {
+	currencies map[string]string
+}
+
+func (m *MockSymbolCurrencyResolver) GetSymbolCurrency(ctx context.Context, symbol string) (string, error) {
+	return m.currencies[symbol], nil
+}
+
+type MockFXRateClient struct {
+	rates map[string]float64
+	calls int
+}
+
+func (m *MockFXRateClient) GetRate(ctx context.Context, fromCurrency, toCurrency string) (float64, error) {
+	m.calls++
+	rate, exists := m.rates[fromCurrency+"/"+toCurrency]
+	if !exists {
+		return 0, fmt.Errorf("no rate for %s/%s", fromCurrency, toCurrency)
+	}
+	return rate, nil
+}
+
+func Test_GetPositionAggregationUseCase_CurrencyNormalization_SingleCurrencyUnchanged(t *testing.T) {
+	userUUID := uuid.New()
+
+	position1, _ := domain.NewPosition(userUUID, "AAPL", 5.0, 10.0, domain.PositionTypeLong)
+	position1.CurrentPrice = 11.0
+	position2, _ := domain.NewPosition(userUUID, "GOOGL", 2.0, 20.0, domain.PositionTypeLong)
+	position2.CurrentPrice = 22.0
+
+	repo := NewMockPositionRepositoryForNew()
+	repo.AddPosition(position1)
+	repo.AddPosition(position2)
+
+	fxClient := &MockFXRateClient{}
+	useCase := NewGetPositionAggregationUseCaseWithService(repo, service.NewPositionAggregationService())
+	useCase.SetCurrencyNormalization(&MockSymbolCurrencyResolver{currencies: map[string]string{"AAPL": "USD", "GOOGL": "USD"}},
+		fxClient, DefaultCurrencyNormalizationConfig())
+
+	result, err := useCase.Execute(userUUID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, float32(90.0), result.TotalInvested)
+	assert.Equal(t, float32(99.0), result.CurrentTotal)
+	assert.Equal(t, "USD", result.BaseCurrency)
+	assert.Empty(t, result.FxRates)
+	assert.Equal(t, 0, fxClient.calls, "no rate should be fetched for the base currency")
+	for _, asset := range result.PositionAggregation[0].Assets {
+		assert.Equal(t, "USD", asset.Currency)
+		assert.Equal(t, float32(1), asset.FxRate)
+	}
+}
+
+func Test_GetPositionAggregationUseCase_CurrencyNormalization_MultiCurrencyBook(t *testing.T) {
+	userUUID := uuid.New()
+
+	usPosition, _ := domain.NewPosition(userUUID, "AAPL", 5.0, 10.0, domain.PositionTypeLong)
+	usPosition.CurrentPrice = 11.0
+	brPosition, _ := domain.NewPosition(userUUID, "PETR4", 100.0, 30.0, domain.PositionTypeLong)
+	brPosition.CurrentPrice = 35.0
+	brPosition2, _ := domain.NewPosition(userUUID, "VALE3", 10.0, 60.0, domain.PositionTypeLong)
+	brPosition2.CurrentPrice = 60.0
+
+	repo := NewMockPositionRepositoryForNew()
+	repo.AddPosition(usPosition)
+	repo.AddPosition(brPosition)
+	repo.AddPosition(brPosition2)
+
+	fxClient := &MockFXRateClient{rates: map[string]float64{"BRL/USD": 0.25}}
+	useCase := NewGetPositionAggregationUseCaseWithService(repo, service.NewPositionAggregationService())
+	useCase.SetCurrencyNormalization(&MockSymbolCurrencyResolver{currencies: map[string]string{"AAPL": "USD", "PETR4": "BRL", "VALE3": "brl"}},
+		fxClient, DefaultCurrencyNormalizationConfig())
+
+	result, err := useCase.Execute(userUUID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, float32(950.0), result.TotalInvested) // 5*10 + (100*30 + 10*60)*0.25 = 50 + 900
+	assert.Equal(t, float32(1080.0), result.CurrentTotal) // 5*11 + (100*35 + 10*60)*0.25 = 55 + 1025
+	assert.Equal(t, "USD", result.BaseCurrency)
+	assert.Equal(t, []domain.FxRateModel{{FromCurrency: "BRL", ToCurrency: "USD", Rate: 0.25}}, result.FxRates)
+	assert.Equal(t, 1, fxClient.calls, "each currency's rate should be fetched once")
+
+	for _, asset := range result.PositionAggregation[0].Assets {
+		if asset.Symbol == "PETR4" {
+			assert.Equal(t, "BRL", asset.Currency)
+			assert.Equal(t, float32(0.25), asset.FxRate)
+			assert.Equal(t, float32(7.5), asset.AveragePrice)
+			assert.Equal(t, float32(8.75), asset.LastPrice)
+		}
+	}
+}
+
+func Test_GetPositionAggregationUseCase_CurrencyNormalization_MissingRateFails(t *testing.T) {
+	userUUID := uuid.New()
+
+	position, _ := domain.NewPosition(userUUID, "SAP", 5.0, 100.0, domain.PositionTypeLong)
+	repo := NewMockPositionRepositoryForNew()
+	repo.AddPosition(position)
+
+	useCase := NewGetPositionAggregationUseCaseWithService(repo, service.NewPositionAggregationService())
+	useCase.SetCurrencyNormalization(&MockSymbolCurrencyResolver{currencies: map[string]string{"SAP": "EUR"}},
+		&MockFXRateClient{}, DefaultCurrencyNormalizationConfig())
+
+	_, err := useCase.Execute(userUUID.String())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "EUR/USD exchange rate")
+}
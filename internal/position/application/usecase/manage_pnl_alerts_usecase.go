@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"HubInvestments/internal/position/application/command"
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ErrPnLAlertNotFound is returned when the alert does not exist or belongs to another user
+var ErrPnLAlertNotFound = errors.New("P&L alert not found")
+
+// PnLAlertConfig holds configuration for position P&L alerts
+type PnLAlertConfig struct {
+	MaxAlertsPerUser int // Alerts one user may define (0 is unlimited)
+}
+
+// DefaultPnLAlertConfig returns the default P&L alert configuration
+func DefaultPnLAlertConfig() PnLAlertConfig {
+	return PnLAlertConfig{
+		MaxAlertsPerUser: 50, // A gain and a loss alert on every position of a broad portfolio
+	}
+}
+
+type IManagePnLAlertsUseCase interface {
+	Create(ctx context.Context, cmd *command.CreatePnLAlertCommand) (*domain.PnLAlert, error)
+	List(ctx context.Context, userID string) ([]*domain.PnLAlert, error)
+	// Reset re-arms a fired alert so it can fire again on the next crossing
+	Reset(ctx context.Context, userID, alertID string) (*domain.PnLAlert, error)
+	Delete(ctx context.Context, userID, alertID string) error
+}
+
+type ManagePnLAlertsUseCase struct {
+	alertRepository repository.IPnLAlertRepository
+	config          PnLAlertConfig
+}
+
+func NewManagePnLAlertsUseCase(alertRepository repository.IPnLAlertRepository, config PnLAlertConfig) IManagePnLAlertsUseCase {
+	return &ManagePnLAlertsUseCase{
+		alertRepository: alertRepository,
+		config:          config,
+	}
+}
+
+func (uc *ManagePnLAlertsUseCase) Create(ctx context.Context, cmd *command.CreatePnLAlertCommand) (*domain.PnLAlert, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	userID, err := cmd.ToUserID()
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", cmd.UserID, err)
+	}
+
+	if uc.config.MaxAlertsPerUser > 0 {
+		count, err := uc.alertRepository.CountByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count P&L alerts: %w", err)
+		}
+		if count >= uc.config.MaxAlertsPerUser {
+			return nil, fmt.Errorf("invalid command: at most %d P&L alerts can be defined", uc.config.MaxAlertsPerUser)
+		}
+	}
+
+	direction, _ := domain.ParsePnLAlertDirection(cmd.Direction)
+	thresholdType, _ := domain.ParsePnLThresholdType(cmd.ThresholdType)
+
+	alert, err := domain.NewPnLAlert(userID, strings.TrimSpace(cmd.Symbol), direction, thresholdType, cmd.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	if err := uc.alertRepository.Save(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to save P&L alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (uc *ManagePnLAlertsUseCase) List(ctx context.Context, userID string) ([]*domain.PnLAlert, error) {
+	userUUID, err := parseUserIDToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", userID, err)
+	}
+
+	alerts, err := uc.alertRepository.FindByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list P&L alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func (uc *ManagePnLAlertsUseCase) Reset(ctx context.Context, userID, alertID string) (*domain.PnLAlert, error) {
+	alert, err := uc.findUserAlert(ctx, userID, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.Reset()
+	if err := uc.alertRepository.Save(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to reset P&L alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (uc *ManagePnLAlertsUseCase) Delete(ctx context.Context, userID, alertID string) error {
+	alert, err := uc.findUserAlert(ctx, userID, alertID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.alertRepository.Delete(ctx, alert.ID); err != nil {
+		return fmt.Errorf("failed to delete P&L alert: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *ManagePnLAlertsUseCase) findUserAlert(ctx context.Context, userID, alertID string) (*domain.PnLAlert, error) {
+	userUUID, err := parseUserIDToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", userID, err)
+	}
+
+	alertUUID, err := uuid.Parse(alertID)
+	if err != nil {
+		return nil, ErrPnLAlertNotFound
+	}
+
+	alert, err := uc.alertRepository.FindByID(ctx, alertUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find P&L alert: %w", err)
+	}
+
+	if alert == nil || alert.UserID != userUUID {
+		return nil, ErrPnLAlertNotFound
+	}
+
+	return alert, nil
+}
+
+// ParseUserID converts an authenticated user ID to the UUID positions and alerts are stored under
+func ParseUserID(userID string) (uuid.UUID, error) {
+	return parseUserIDToUUID(userID)
+}
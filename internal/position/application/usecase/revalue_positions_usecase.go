@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/domain/repository"
+)
+
+// RevaluePositionsConfig holds configuration for on-demand position revaluation
+type RevaluePositionsConfig struct {
+	PriceTimeout time.Duration // Maximum wait for the batch live price request
+}
+
+// DefaultRevaluePositionsConfig returns the default revaluation configuration
+func DefaultRevaluePositionsConfig() RevaluePositionsConfig {
+	return RevaluePositionsConfig{
+		PriceTimeout: 5 * time.Second,
+	}
+}
+
+type IRevaluePositionsUseCase interface {
+	// Execute values the user's active positions at live prices. Nothing is persisted; the
+	// end-of-day mark remains the only stored valuation.
+	Execute(ctx context.Context, userID string) (*domain.PortfolioRevaluation, error)
+}
+
+type RevaluePositionsUseCase struct {
+	positionRepository repository.IPositionRepository
+	marketDataClient   IBatchMarketDataClient
+	config             RevaluePositionsConfig
+}
+
+func NewRevaluePositionsUseCase(
+	positionRepository repository.IPositionRepository,
+	marketDataClient IBatchMarketDataClient,
+	config RevaluePositionsConfig,
+) IRevaluePositionsUseCase {
+	return &RevaluePositionsUseCase{
+		positionRepository: positionRepository,
+		marketDataClient:   marketDataClient,
+		config:             config,
+	}
+}
+
+func (uc *RevaluePositionsUseCase) Execute(ctx context.Context, userID string) (*domain.PortfolioRevaluation, error) {
+	userUUID, err := parseUserIDToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format '%s': %w", userID, err)
+	}
+
+	positions, err := uc.positionRepository.FindActivePositions(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions: %w", err)
+	}
+
+	priceMap := fetchBatchMarketPrices(ctx, uc.marketDataClient, positions, uc.config.PriceTimeout)
+
+	result := &domain.PortfolioRevaluation{
+		UserID:     userUUID,
+		Positions:  make([]domain.PositionRevaluation, 0, len(positions)),
+		RevaluedAt: time.Now(),
+	}
+
+	for _, position := range positions {
+		revaluation := domain.NewPositionRevaluation(position, priceMap[position.Symbol])
+		if !revaluation.LivePrice {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("No live price for %s, valued at the last known price %.2f", position.Symbol, position.CurrentPrice))
+		}
+
+		result.Positions = append(result.Positions, revaluation)
+		result.TotalMarketValue += revaluation.MarketValue
+		result.TotalInvestment += revaluation.TotalInvestment
+	}
+
+	sort.Slice(result.Positions, func(i, j int) bool {
+		return result.Positions[i].Symbol < result.Positions[j].Symbol
+	})
+
+	result.TotalUnrealizedPnL = result.TotalMarketValue - result.TotalInvestment
+	if result.TotalInvestment > 0 {
+		result.TotalUnrealizedPnLPct = (result.TotalUnrealizedPnL / result.TotalInvestment) * 100
+	}
+
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/RodriguesYan/hub-proto-contracts/monolith"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+type MockBatchMarketDataClient struct {
+	prices map[string]float64
+	calls  int
+}
+
+func (m *MockBatchMarketDataClient) GetBatchMarketData(ctx context.Context, in *monolith.GetBatchMarketDataRequest, opts ...grpc.CallOption) (*monolith.GetBatchMarketDataResponse, error) {
+	m.calls++
+	resp := &monolith.GetBatchMarketDataResponse{}
+	for _, symbol := range in.Symbols {
+		if price, exists := m.prices[symbol]; exists {
+			resp.MarketData = append(resp.MarketData, &monolith.MarketData{Symbol: symbol, CurrentPrice: price})
+		}
+	}
+	return resp, nil
+}
+
+// writeTrackingPositionRepository records any write so tests can assert revaluation is read-only
+type writeTrackingPositionRepository struct {
+	*MockPositionRepositoryForNew
+	writes int
+}
+
+func (r *writeTrackingPositionRepository) Save(ctx context.Context, position *domain.Position) error {
+	r.writes++
+	return r.MockPositionRepositoryForNew.Save(ctx, position)
+}
+
+func (r *writeTrackingPositionRepository) Update(ctx context.Context, position *domain.Position) error {
+	r.writes++
+	return r.MockPositionRepositoryForNew.Update(ctx, position)
+}
+
+func newRevaluationFixture(t *testing.T) (*writeTrackingPositionRepository, uuid.UUID) {
+	userID := uuid.New()
+	repo := &writeTrackingPositionRepository{MockPositionRepositoryForNew: NewMockPositionRepositoryForNew()}
+
+	for _, seed := range []struct {
+		symbol   string
+		quantity float64
+		price    float64
+		lastMark float64
+	}{
+		{"AAPL", 10, 150, 155},
+		{"MSFT", 5, 300, 310},
+	} {
+		position, err := domain.NewPosition(userID, seed.symbol, seed.quantity, seed.price, domain.PositionTypeLong)
+		if err != nil {
+			t.Fatalf("Failed to create position: %v", err)
+		}
+		position.CurrentPrice = seed.lastMark
+		repo.AddPosition(position)
+	}
+
+	return repo, userID
+}
+
+func TestRevaluePositionsUseCase_Execute_UsesLivePrices(t *testing.T) {
+	// Arrange
+	repo, userID := newRevaluationFixture(t)
+	marketData := &MockBatchMarketDataClient{prices: map[string]float64{"AAPL": 170, "MSFT": 280}}
+	useCase := NewRevaluePositionsUseCase(repo, marketData, DefaultRevaluePositionsConfig())
+
+	// Act
+	result, err := useCase.Execute(context.Background(), userID.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if marketData.calls != 1 {
+		t.Errorf("Expected a single batch price request, got %d", marketData.calls)
+	}
+	if len(result.Positions) != 2 {
+		t.Fatalf("Expected 2 positions, got %d", len(result.Positions))
+	}
+
+	aapl, msft := result.Positions[0], result.Positions[1]
+	if aapl.Symbol != "AAPL" || aapl.CurrentPrice != 170 || aapl.MarketValue != 1700 || aapl.UnrealizedPnL != 200 || !aapl.LivePrice {
+		t.Errorf("Unexpected AAPL revaluation %+v", aapl)
+	}
+	if msft.Symbol != "MSFT" || msft.PreviousPrice != 310 || msft.MarketValue != 1400 || msft.UnrealizedPnL != -100 {
+		t.Errorf("Unexpected MSFT revaluation %+v", msft)
+	}
+	if result.TotalMarketValue != 3100 || result.TotalInvestment != 3000 || result.TotalUnrealizedPnL != 100 {
+		t.Errorf("Unexpected totals %+v", result)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", result.Warnings)
+	}
+}
+
+func TestRevaluePositionsUseCase_Execute_DoesNotPersist(t *testing.T) {
+	// Arrange
+	repo, userID := newRevaluationFixture(t)
+	marketData := &MockBatchMarketDataClient{prices: map[string]float64{"AAPL": 170}}
+	useCase := NewRevaluePositionsUseCase(repo, marketData, DefaultRevaluePositionsConfig())
+
+	// Act
+	result, err := useCase.Execute(context.Background(), userID.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if repo.writes != 0 {
+		t.Errorf("Expected no position writes, got %d", repo.writes)
+	}
+	for _, position := range repo.positions {
+		if position.Symbol == "AAPL" && position.CurrentPrice != 155 {
+			t.Errorf("Expected the stored AAPL price to stay at 155, got %.2f", position.CurrentPrice)
+		}
+	}
+	if result.Positions[1].LivePrice || result.Positions[1].CurrentPrice != 310 {
+		t.Errorf("Expected MSFT to fall back to its last known price, got %+v", result.Positions[1])
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("Expected a warning for the missing MSFT price, got %v", result.Warnings)
+	}
+}
@@ -66,7 +66,7 @@ func (uc *UpdatePositionUseCase) Execute(ctx context.Context, cmd *command.Updat
 	}
 
 	previousQuantity := position.Quantity
-	previousAveragePrice := position.AveragePrice
+	previousCostBasis := position.CostBasisPerShare()
 
 	if cmd.IsSellOrder() {
 		if !position.CanSell(cmd.TradeQuantity) {
@@ -83,10 +83,15 @@ func (uc *UpdatePositionUseCase) Execute(ctx context.Context, cmd *command.Updat
 		sourceOrderIDPtr = &sourceOrderIDStr
 	}
 
+	averagingMethod, err := cmd.ToAveragingMethod()
+	if err != nil {
+		return nil, fmt.Errorf("invalid averaging method: %w", err)
+	}
+
 	eventsBeforeUpdate := len(position.GetEvents())
 	position.ClearEvents()
 
-	err = position.UpdateQuantityWithOrderID(cmd.TradeQuantity, cmd.TradePrice, cmd.IsBuyOrder, sourceOrderIDPtr)
+	err = position.UpdateQuantityWithAveraging(cmd.TradeQuantity, cmd.TradePrice, cmd.IsBuyOrder, sourceOrderIDPtr, averagingMethod)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update position: %w", err)
 	}
@@ -102,11 +107,11 @@ func (uc *UpdatePositionUseCase) Execute(ctx context.Context, cmd *command.Updat
 	var realizedPnL *float64
 	var realizedPnLPct *float64
 	if cmd.IsSellOrder() {
-		pnl := (cmd.TradePrice - previousAveragePrice) * cmd.TradeQuantity
+		pnl := (cmd.TradePrice - previousCostBasis) * cmd.TradeQuantity
 		realizedPnL = &pnl
 
-		if previousAveragePrice > 0 {
-			pnlPct := (pnl / (previousAveragePrice * cmd.TradeQuantity)) * 100
+		if previousCostBasis > 0 {
+			pnlPct := (pnl / (previousCostBasis * cmd.TradeQuantity)) * 100
 			realizedPnLPct = &pnlPct
 		}
 	}
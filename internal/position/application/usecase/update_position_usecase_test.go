@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"HubInvestments/internal/position/application/command"
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func executeBuyUpSequence(t *testing.T, averagingMethod string) *command.UpdatePositionResult {
+	t.Helper()
+
+	mockRepo := NewMockPositionRepositoryForNew()
+	usecase := NewUpdatePositionUseCase(mockRepo)
+
+	userID := uuid.New()
+	position, err := domain.NewPosition(userID, "AAPL", 100.0, 150.0, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	mockRepo.positions[position.ID.String()] = position
+
+	buys := []struct {
+		quantity float64
+		price    float64
+	}{
+		{quantity: 50.0, price: 180.0},
+		{quantity: 50.0, price: 210.0},
+	}
+
+	var result *command.UpdatePositionResult
+	for _, buy := range buys {
+		result, err = usecase.Execute(context.Background(), &command.UpdatePositionCommand{
+			PositionID:      position.ID.String(),
+			UserID:          userID.String(),
+			TradeQuantity:   buy.quantity,
+			TradePrice:      buy.price,
+			IsBuyOrder:      true,
+			AveragingMethod: averagingMethod,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	return result
+}
+
+func TestUpdatePositionUseCase_Execute_AveragingMethods(t *testing.T) {
+	tests := []struct {
+		name                 string
+		averagingMethod      string
+		expectedAveragePrice float64
+	}{
+		{
+			name:                 "default is weighted average",
+			averagingMethod:      "",
+			expectedAveragePrice: (100*150.0 + 50*180.0 + 50*210.0) / 200,
+		},
+		{
+			name:                 "weighted average blends every lot",
+			averagingMethod:      "WEIGHTED_AVERAGE",
+			expectedAveragePrice: (100*150.0 + 50*180.0 + 50*210.0) / 200,
+		},
+		{
+			name:                 "original cost keeps the first lot price",
+			averagingMethod:      "ORIGINAL_COST",
+			expectedAveragePrice: 150.0,
+		},
+	}
+	// Every mode keeps the full cost of the lots bought
+	expectedInvestment := 100*150.0 + 50*180.0 + 50*210.0
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := executeBuyUpSequence(t, tt.averagingMethod)
+
+			if result.NewQuantity != 200.0 {
+				t.Errorf("Expected quantity 200.00, got: %.2f", result.NewQuantity)
+			}
+
+			if math.Abs(result.NewAveragePrice-tt.expectedAveragePrice) > 1e-9 {
+				t.Errorf("Expected average price %.4f, got: %.4f", tt.expectedAveragePrice, result.NewAveragePrice)
+			}
+
+			if math.Abs(result.NewTotalInvestment-expectedInvestment) > 1e-6 {
+				t.Errorf("Expected total investment %.2f, got: %.2f", expectedInvestment, result.NewTotalInvestment)
+			}
+		})
+	}
+}
+
+func TestUpdatePositionUseCase_Execute_OriginalCostSellRealizesAgainstCostBasis(t *testing.T) {
+	mockRepo := NewMockPositionRepositoryForNew()
+	usecase := NewUpdatePositionUseCase(mockRepo)
+
+	userID := uuid.New()
+	position, err := domain.NewPosition(userID, "AAPL", 100.0, 150.0, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	mockRepo.positions[position.ID.String()] = position
+
+	_, err = usecase.Execute(context.Background(), &command.UpdatePositionCommand{
+		PositionID:      position.ID.String(),
+		UserID:          userID.String(),
+		TradeQuantity:   100.0,
+		TradePrice:      210.0,
+		IsBuyOrder:      true,
+		AveragingMethod: "ORIGINAL_COST",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	result, err := usecase.Execute(context.Background(), &command.UpdatePositionCommand{
+		PositionID:    position.ID.String(),
+		UserID:        userID.String(),
+		TradeQuantity: 50.0,
+		TradePrice:    200.0,
+		IsBuyOrder:    false,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// 200 shares cost 36000, so each share sold cost 180 even though the average price stays at 150
+	if result.RealizedPnL == nil || math.Abs(*result.RealizedPnL-50*(200.0-180.0)) > 1e-6 {
+		t.Errorf("Expected realized P&L 1000.00 against the cost basis, got: %v", result.RealizedPnL)
+	}
+
+	if math.Abs(result.NewTotalInvestment-150*180.0) > 1e-6 {
+		t.Errorf("Expected total investment 27000.00, got: %.2f", result.NewTotalInvestment)
+	}
+
+	if result.NewAveragePrice != 150.0 {
+		t.Errorf("Expected average price to stay at 150.00, got: %.2f", result.NewAveragePrice)
+	}
+}
+
+func TestUpdatePositionUseCase_Execute_InvalidAveragingMethod(t *testing.T) {
+	mockRepo := NewMockPositionRepositoryForNew()
+	usecase := NewUpdatePositionUseCase(mockRepo)
+
+	userID := uuid.New()
+	position, err := domain.NewPosition(userID, "AAPL", 100.0, 150.0, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	mockRepo.positions[position.ID.String()] = position
+
+	_, err = usecase.Execute(context.Background(), &command.UpdatePositionCommand{
+		PositionID:      position.ID.String(),
+		UserID:          userID.String(),
+		TradeQuantity:   10.0,
+		TradePrice:      160.0,
+		IsBuyOrder:      true,
+		AveragingMethod: "FIFO",
+	})
+
+	if err == nil {
+		t.Fatal("Expected error for invalid averaging method, got nil")
+	}
+
+	if position.AveragePrice != 150.0 || position.Quantity != 100.0 {
+		t.Errorf("Expected position to be unchanged, got quantity %.2f at %.2f", position.Quantity, position.AveragePrice)
+	}
+}
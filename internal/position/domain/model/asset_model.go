@@ -8,6 +8,8 @@ type AssetModel struct {
 	AveragePrice float32 `json:"averagePrice" example:"150.0"`
 	LastPrice    float32 `json:"currentPrice" example:"155.0"`
 	Category     int     `json:"category" example:"1"`
+	Currency     string  `json:"currency,omitempty" example:"USD"` // Currency the asset trades in, prices are in the base currency once normalized
+	FxRate       float32 `json:"fxRate,omitempty" example:"1.0"`   // Rate applied to the asset's prices during currency normalization
 }
 
 // CalculateInvestment returns the total amount invested in this asset
@@ -17,4 +17,14 @@ type AucAggregationModel struct {
 	TotalInvested       float32                    `json:"totalInvested" example:"11500.0"`
 	CurrentTotal        float32                    `json:"currentTotal" example:"12000.0"`
 	PositionAggregation []PositionAggregationModel `json:"positionAggregation"`
+	BaseCurrency        string                     `json:"baseCurrency,omitempty" example:"USD"`
+	FxRates             []FxRateModel              `json:"fxRates,omitempty"`
+}
+
+// FxRateModel records the rate used to convert holdings in one currency into the base currency
+// @Description Exchange rate applied during currency normalization
+type FxRateModel struct {
+	FromCurrency string  `json:"fromCurrency" example:"BRL"`
+	ToCurrency   string  `json:"toCurrency" example:"USD"`
+	Rate         float64 `json:"rate" example:"0.19"`
 }
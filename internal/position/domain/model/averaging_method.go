@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// AveragingMethod controls how the average price changes when a buy adds to an existing position
+type AveragingMethod string
+
+const (
+	AveragingMethodWeighted     AveragingMethod = "WEIGHTED_AVERAGE" // Blend the new lot into the average price
+	AveragingMethodOriginalCost AveragingMethod = "ORIGINAL_COST"    // Keep the original cost basis for tax-lot purposes
+)
+
+// Under ORIGINAL_COST the average price stays at the first lot's price after a buy-up, so it deliberately
+// misstates the per-share cost basis and is only a tax-lot reference. TotalInvestment still adds every
+// lot at its trade price and P&L is measured against CostBasisPerShare, never the average price.
+
+func AllAveragingMethods() []AveragingMethod {
+	return []AveragingMethod{
+		AveragingMethodWeighted,
+		AveragingMethodOriginalCost,
+	}
+}
+
+func (am AveragingMethod) IsValid() bool {
+	for _, validMethod := range AllAveragingMethods() {
+		if am == validMethod {
+			return true
+		}
+	}
+	return false
+}
+
+func (am AveragingMethod) String() string {
+	return string(am)
+}
+
+// NewAveragingMethod creates a new AveragingMethod from string, defaulting to weighted average when empty
+func NewAveragingMethod(value string) (AveragingMethod, error) {
+	upperValue := strings.ToUpper(strings.TrimSpace(value))
+	if upperValue == "" {
+		return AveragingMethodWeighted, nil
+	}
+
+	method := AveragingMethod(upperValue)
+	if !method.IsValid() {
+		return "", errors.New("invalid averaging method: must be WEIGHTED_AVERAGE or ORIGINAL_COST")
+	}
+
+	return method, nil
+}
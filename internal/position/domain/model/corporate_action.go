@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CorporateActionType string
+
+const (
+	CorporateActionTypeSplit        CorporateActionType = "SPLIT"
+	CorporateActionTypeCashDividend CorporateActionType = "CASH_DIVIDEND"
+	CorporateActionTypeSymbolChange CorporateActionType = "SYMBOL_CHANGE"
+)
+
+func (t CorporateActionType) IsValid() bool {
+	switch t {
+	case CorporateActionTypeSplit, CorporateActionTypeCashDividend, CorporateActionTypeSymbolChange:
+		return true
+	default:
+		return false
+	}
+}
+
+// CorporateAction is an issuer event that changes the positions held in a symbol.
+// A split of SplitTo:SplitFrom turns every SplitFrom shares into SplitTo shares,
+// so 2:1 is SplitTo=2, SplitFrom=1 and a 1:10 reverse split is SplitTo=1, SplitFrom=10.
+type CorporateAction struct {
+	ID               string              `json:"id"`
+	Type             CorporateActionType `json:"type"`
+	Symbol           string              `json:"symbol"`
+	NewSymbol        string              `json:"newSymbol,omitempty"`
+	SplitTo          float64             `json:"splitTo,omitempty"`
+	SplitFrom        float64             `json:"splitFrom,omitempty"`
+	DividendPerShare float64             `json:"dividendPerShare,omitempty"`
+	EffectiveDate    time.Time           `json:"effectiveDate"`
+}
+
+func (a *CorporateAction) Validate() error {
+	if a.ID == "" {
+		return errors.New("corporate action ID cannot be empty")
+	}
+
+	if !a.Type.IsValid() {
+		return fmt.Errorf("invalid corporate action type: %s", a.Type)
+	}
+
+	if a.Symbol == "" {
+		return errors.New("symbol cannot be empty")
+	}
+
+	if a.EffectiveDate.IsZero() {
+		return errors.New("effective date cannot be zero")
+	}
+
+	switch a.Type {
+	case CorporateActionTypeSplit:
+		if a.SplitTo <= 0 || a.SplitFrom <= 0 {
+			return errors.New("split ratio must be greater than zero")
+		}
+		if a.SplitTo == a.SplitFrom {
+			return errors.New("split ratio cannot be 1:1")
+		}
+	case CorporateActionTypeCashDividend:
+		if a.DividendPerShare <= 0 {
+			return errors.New("dividend per share must be greater than zero")
+		}
+	case CorporateActionTypeSymbolChange:
+		if a.NewSymbol == "" {
+			return errors.New("new symbol cannot be empty")
+		}
+		if strings.EqualFold(a.NewSymbol, a.Symbol) {
+			return errors.New("new symbol must differ from the current symbol")
+		}
+	}
+
+	return nil
+}
+
+// SplitRatio returns the number of new shares per old share
+func (a *CorporateAction) SplitRatio() float64 {
+	return a.SplitTo / a.SplitFrom
+}
+
+type PositionLedgerEntryType string
+
+const (
+	PositionLedgerEntryTypeSplit         PositionLedgerEntryType = "SPLIT"
+	PositionLedgerEntryTypeCashDividend  PositionLedgerEntryType = "CASH_DIVIDEND"
+	PositionLedgerEntryTypeSymbolChange  PositionLedgerEntryType = "SYMBOL_CHANGE"
+	PositionLedgerEntryTypeConsolidation PositionLedgerEntryType = "CONSOLIDATION"
+)
+
+// PositionLedgerEntry records a corporate action applied to a position. Cash dividends carry
+// the amount paid; splits, symbol changes and consolidations of duplicate positions record
+// the before and after state for audit.
+type PositionLedgerEntry struct {
+	ID                   uuid.UUID               `json:"id"`
+	PositionID           uuid.UUID               `json:"positionId"`
+	UserID               uuid.UUID               `json:"userId"`
+	Symbol               string                  `json:"symbol"`
+	EntryType            PositionLedgerEntryType `json:"entryType"`
+	CorporateActionID    string                  `json:"corporateActionId"`
+	Quantity             float64                 `json:"quantity"`
+	PreviousQuantity     float64                 `json:"previousQuantity"`
+	AveragePrice         float64                 `json:"averagePrice"`
+	PreviousAveragePrice float64                 `json:"previousAveragePrice"`
+	CashAmount           float64                 `json:"cashAmount"`
+	Description          string                  `json:"description"`
+	EffectiveDate        time.Time               `json:"effectiveDate"`
+	CreatedAt            time.Time               `json:"createdAt"`
+}
+
+// ApplySplit multiplies the quantity by the split ratio and divides the average price by it.
+// The total investment is unchanged, so the cost basis of the position is preserved.
+func (p *Position) ApplySplit(ratio float64, quantityPrecision, pricePrecision int) error {
+	if ratio <= 0 {
+		return errors.New("split ratio must be greater than zero")
+	}
+
+	if !p.Status.CanBeUpdated() {
+		return fmt.Errorf("cannot split position with status: %s", p.Status)
+	}
+
+	prevQuantity := p.Quantity
+	prevAveragePrice := p.AveragePrice
+
+	p.Quantity = RoundToDecimalPlaces(p.Quantity*ratio, quantityPrecision)
+	p.AveragePrice = RoundToDecimalPlaces(p.AveragePrice/ratio, pricePrecision)
+	if p.CurrentPrice > 0 {
+		p.CurrentPrice = RoundToDecimalPlaces(p.CurrentPrice/ratio, pricePrecision)
+	}
+	p.UpdatedAt = time.Now()
+
+	p.addEvent(NewPositionCorporateActionAppliedEvent(
+		p.ID.String(), p.UserID.String(), p.Symbol, string(CorporateActionTypeSplit),
+		prevQuantity, prevAveragePrice, p.Quantity, p.AveragePrice, p.Symbol))
+
+	return nil
+}
+
+// ChangeSymbol moves the position to the issuer's new ticker without touching quantity or cost basis
+func (p *Position) ChangeSymbol(newSymbol string) error {
+	if newSymbol == "" {
+		return errors.New("new symbol cannot be empty")
+	}
+
+	if !p.Status.CanBeUpdated() {
+		return fmt.Errorf("cannot change symbol of position with status: %s", p.Status)
+	}
+
+	previousSymbol := p.Symbol
+	p.Symbol = newSymbol
+	p.UpdatedAt = time.Now()
+
+	p.addEvent(NewPositionCorporateActionAppliedEvent(
+		p.ID.String(), p.UserID.String(), previousSymbol, string(CorporateActionTypeSymbolChange),
+		p.Quantity, p.AveragePrice, p.Quantity, p.AveragePrice, newSymbol))
+
+	return nil
+}
+
+// NewCorporateActionLedgerEntry records the action against the position after it was applied
+func NewCorporateActionLedgerEntry(position *Position, action *CorporateAction, previousQuantity, previousAveragePrice float64) (*PositionLedgerEntry, error) {
+	if position == nil {
+		return nil, errors.New("position cannot be nil")
+	}
+
+	if action == nil {
+		return nil, errors.New("corporate action cannot be nil")
+	}
+
+	entry := &PositionLedgerEntry{
+		ID:                   uuid.New(),
+		PositionID:           position.ID,
+		UserID:               position.UserID,
+		Symbol:               position.Symbol,
+		CorporateActionID:    action.ID,
+		Quantity:             position.Quantity,
+		PreviousQuantity:     previousQuantity,
+		AveragePrice:         position.AveragePrice,
+		PreviousAveragePrice: previousAveragePrice,
+		EffectiveDate:        action.EffectiveDate,
+		CreatedAt:            time.Now(),
+	}
+
+	switch action.Type {
+	case CorporateActionTypeSplit:
+		entry.EntryType = PositionLedgerEntryTypeSplit
+		entry.Description = fmt.Sprintf("%g:%g split of %s", action.SplitTo, action.SplitFrom, action.Symbol)
+	case CorporateActionTypeCashDividend:
+		entry.EntryType = PositionLedgerEntryTypeCashDividend
+		entry.CashAmount = RoundToDecimalPlaces(position.Quantity*action.DividendPerShare, DefaultPricePrecision)
+		entry.Description = fmt.Sprintf("Cash dividend of %g per share on %s", action.DividendPerShare, action.Symbol)
+	case CorporateActionTypeSymbolChange:
+		entry.EntryType = PositionLedgerEntryTypeSymbolChange
+		entry.Description = fmt.Sprintf("Symbol change from %s to %s", action.Symbol, action.NewSymbol)
+	default:
+		return nil, fmt.Errorf("invalid corporate action type: %s", action.Type)
+	}
+
+	return entry, nil
+}
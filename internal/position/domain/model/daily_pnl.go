@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SymbolDailyPnL is one symbol's contribution to the account's daily P&L
+type SymbolDailyPnL struct {
+	Symbol             string  `json:"symbol"`
+	RealizedPnL        float64 `json:"realizedPnL"`        // Realized by sells executed during the trading day
+	UnrealizedPnL      float64 `json:"unrealizedPnL"`      // Current unrealized P&L of the open quantity
+	PriorUnrealizedPnL float64 `json:"priorUnrealizedPnL"` // Unrealized P&L at the prior end-of-day mark
+	UnrealizedChange   float64 `json:"unrealizedChange"`
+	DailyPnL           float64 `json:"dailyPnL"`
+}
+
+// AccountDailyPnL combines the P&L realized during the trading day with the change in unrealized
+// P&L since the prior end-of-day mark. Shares sold during the day leave the unrealized side at their
+// prior mark and enter the realized side at their cost, so the sum is the day's move in value.
+type AccountDailyPnL struct {
+	UserID           uuid.UUID        `json:"userId"`
+	TradingDay       time.Time        `json:"tradingDay"`
+	PriorMarkDay     *time.Time       `json:"priorMarkDay,omitempty"` // Nil when no end-of-day mark was found
+	RealizedPnL      float64          `json:"realizedPnL"`
+	UnrealizedChange float64          `json:"unrealizedChange"`
+	DailyPnL         float64          `json:"dailyPnL"`
+	Symbols          []SymbolDailyPnL `json:"symbols"`
+	CalculatedAt     time.Time        `json:"calculatedAt"`
+	Warnings         []string         `json:"warnings,omitempty"`
+}
+
+// AddSymbol adds the symbol's realized and unrealized P&L to the account totals
+func (p *AccountDailyPnL) AddSymbol(symbol SymbolDailyPnL) {
+	symbol.UnrealizedChange = symbol.UnrealizedPnL - symbol.PriorUnrealizedPnL
+	symbol.DailyPnL = symbol.RealizedPnL + symbol.UnrealizedChange
+
+	p.Symbols = append(p.Symbols, symbol)
+	sort.Slice(p.Symbols, func(i, j int) bool {
+		return p.Symbols[i].Symbol < p.Symbols[j].Symbol
+	})
+
+	p.RealizedPnL += symbol.RealizedPnL
+	p.UnrealizedChange += symbol.UnrealizedChange
+	p.DailyPnL = p.RealizedPnL + p.UnrealizedChange
+}
+
+// RealizedPnLOnDay replays the trades in execution order at average cost, the same basis the
+// position uses, and returns the P&L realized by sells executed on the trading day. Sold quantity
+// not covered by earlier buys, such as shares of a position opened outside order execution, is
+// costed at the fallback cost and returned as uncovered.
+func RealizedPnLOnDay(trades []PositionTrade, tradingDay time.Time, fallbackCost float64) (float64, float64) {
+	ordered := make([]PositionTrade, len(trades))
+	copy(ordered, trades)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ExecutedAt.Before(ordered[j].ExecutedAt)
+	})
+
+	day := TruncateToTradingDay(tradingDay)
+	var quantity, totalCost, realized, uncovered float64
+
+	for _, trade := range ordered {
+		if trade.Quantity <= 0 {
+			continue
+		}
+
+		if trade.IsBuy {
+			quantity += trade.Quantity
+			totalCost += trade.Quantity * trade.Price
+			continue
+		}
+
+		covered := trade.Quantity
+		if covered > quantity {
+			covered = quantity
+		}
+		averageCost := 0.0
+		if quantity > lotQuantityTolerance {
+			averageCost = totalCost / quantity
+		}
+
+		if TruncateToTradingDay(trade.ExecutedAt.In(day.Location())).Equal(day) {
+			realized += covered * (trade.Price - averageCost)
+			if missing := trade.Quantity - covered; missing > lotQuantityTolerance {
+				realized += missing * (trade.Price - fallbackCost)
+				uncovered += missing
+			}
+		}
+
+		quantity -= covered
+		totalCost = quantity * averageCost
+		if quantity <= lotQuantityTolerance {
+			quantity, totalCost = 0, 0
+		}
+	}
+
+	return realized, uncovered
+}
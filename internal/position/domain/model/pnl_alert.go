@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PnLAlertDirection is the side of the P&L an alert watches
+type PnLAlertDirection string
+
+const (
+	PnLAlertDirectionGain PnLAlertDirection = "GAIN" // Fires when the unrealized gain reaches the threshold
+	PnLAlertDirectionLoss PnLAlertDirection = "LOSS" // Fires when the unrealized loss reaches the threshold
+)
+
+// PnLThresholdType is the unit of an alert's threshold
+type PnLThresholdType string
+
+const (
+	PnLThresholdTypePercent PnLThresholdType = "PERCENT" // Percentage of the position's total investment
+	PnLThresholdTypeAmount  PnLThresholdType = "AMOUNT"  // Absolute P&L amount
+)
+
+// ParsePnLAlertDirection converts a string to a PnLAlertDirection
+func ParsePnLAlertDirection(s string) (PnLAlertDirection, error) {
+	switch direction := PnLAlertDirection(strings.ToUpper(s)); direction {
+	case PnLAlertDirectionGain, PnLAlertDirectionLoss:
+		return direction, nil
+	default:
+		return "", fmt.Errorf("invalid P&L alert direction: %s", s)
+	}
+}
+
+// ParsePnLThresholdType converts a string to a PnLThresholdType
+func ParsePnLThresholdType(s string) (PnLThresholdType, error) {
+	switch thresholdType := PnLThresholdType(strings.ToUpper(s)); thresholdType {
+	case PnLThresholdTypePercent, PnLThresholdTypeAmount:
+		return thresholdType, nil
+	default:
+		return "", fmt.Errorf("invalid P&L threshold type: %s", s)
+	}
+}
+
+// PnLAlert fires once when a position's unrealized P&L crosses a gain or loss threshold. A fired
+// alert stays quiet until it is reset, however long the P&L stays past the threshold.
+type PnLAlert struct {
+	ID            uuid.UUID         `json:"id"`
+	UserID        uuid.UUID         `json:"userId"`
+	Symbol        string            `json:"symbol"`
+	Direction     PnLAlertDirection `json:"direction"`
+	ThresholdType PnLThresholdType  `json:"thresholdType"`
+	Threshold     float64           `json:"threshold"` // Positive; a loss alert at 10 fires at -10 or below
+	Triggered     bool              `json:"triggered"`
+	TriggeredAt   *time.Time        `json:"triggeredAt,omitempty"`
+	TriggeredPnL  *float64          `json:"triggeredPnL,omitempty"` // P&L in the threshold's unit when the alert fired
+	CreatedAt     time.Time         `json:"createdAt"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+}
+
+// NewPnLAlert creates an armed alert on the user's position in the symbol
+func NewPnLAlert(userID uuid.UUID, symbol string, direction PnLAlertDirection, thresholdType PnLThresholdType, threshold float64) (*PnLAlert, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID cannot be empty")
+	}
+
+	if symbol == "" {
+		return nil, errors.New("symbol cannot be empty")
+	}
+
+	if _, err := ParsePnLAlertDirection(string(direction)); err != nil {
+		return nil, err
+	}
+
+	if _, err := ParsePnLThresholdType(string(thresholdType)); err != nil {
+		return nil, err
+	}
+
+	if threshold <= 0 {
+		return nil, errors.New("threshold must be greater than zero")
+	}
+
+	now := time.Now()
+	return &PnLAlert{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Symbol:        strings.ToUpper(symbol),
+		Direction:     direction,
+		ThresholdType: thresholdType,
+		Threshold:     threshold,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// measuredPnL returns the revaluation's P&L in the alert's threshold unit
+func (a *PnLAlert) measuredPnL(revaluation PositionRevaluation) float64 {
+	if a.ThresholdType == PnLThresholdTypePercent {
+		return revaluation.UnrealizedPnLPct
+	}
+	return revaluation.UnrealizedPnL
+}
+
+// IsBreachedBy reports whether the revalued P&L is at or past the threshold
+func (a *PnLAlert) IsBreachedBy(revaluation PositionRevaluation) bool {
+	pnl := a.measuredPnL(revaluation)
+	if a.Direction == PnLAlertDirectionLoss {
+		return pnl <= -a.Threshold
+	}
+	return pnl >= a.Threshold
+}
+
+// Evaluate fires an armed alert whose threshold the revaluation crosses and reports whether it fired
+func (a *PnLAlert) Evaluate(revaluation PositionRevaluation, at time.Time) bool {
+	if a.Triggered || !a.IsBreachedBy(revaluation) {
+		return false
+	}
+
+	pnl := a.measuredPnL(revaluation)
+	a.Triggered = true
+	a.TriggeredAt = &at
+	a.TriggeredPnL = &pnl
+	a.UpdatedAt = time.Now()
+	return true
+}
+
+// Reset re-arms a fired alert so the next crossing fires it again
+func (a *PnLAlert) Reset() {
+	a.Triggered = false
+	a.TriggeredAt = nil
+	a.TriggeredPnL = nil
+	a.UpdatedAt = time.Now()
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewPnLAlert_Validation(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		userID        uuid.UUID
+		symbol        string
+		direction     PnLAlertDirection
+		thresholdType PnLThresholdType
+		threshold     float64
+		wantError     bool
+	}{
+		{"Valid loss alert", userID, "aapl", PnLAlertDirectionLoss, PnLThresholdTypePercent, 10, false},
+		{"Invalid user ID", uuid.Nil, "AAPL", PnLAlertDirectionLoss, PnLThresholdTypePercent, 10, true},
+		{"Empty symbol", userID, "", PnLAlertDirectionLoss, PnLThresholdTypePercent, 10, true},
+		{"Invalid direction", userID, "AAPL", PnLAlertDirection("SIDEWAYS"), PnLThresholdTypePercent, 10, true},
+		{"Invalid threshold type", userID, "AAPL", PnLAlertDirectionGain, PnLThresholdType("RATIO"), 10, true},
+		{"Zero threshold", userID, "AAPL", PnLAlertDirectionGain, PnLThresholdTypeAmount, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, err := NewPnLAlert(tt.userID, tt.symbol, tt.direction, tt.thresholdType, tt.threshold)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if alert.Symbol != "AAPL" || alert.Triggered {
+				t.Errorf("Expected armed alert on AAPL, got %+v", alert)
+			}
+		})
+	}
+}
+
+func TestPnLAlert_LossFiresOnceUntilReset(t *testing.T) {
+	position, err := NewPosition(uuid.New(), "AAPL", 10, 100, PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	alert, err := NewPnLAlert(position.UserID, "AAPL", PnLAlertDirectionLoss, PnLThresholdTypePercent, 10)
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	now := time.Now()
+
+	if alert.Evaluate(NewPositionRevaluation(position, 95), now) {
+		t.Fatal("Expected a 5% loss not to fire a 10% loss alert")
+	}
+
+	if !alert.Evaluate(NewPositionRevaluation(position, 89), now) {
+		t.Fatal("Expected an 11% loss to fire the alert")
+	}
+	if alert.TriggeredPnL == nil || *alert.TriggeredPnL > -10 {
+		t.Errorf("Expected triggered P&L past -10%%, got %v", alert.TriggeredPnL)
+	}
+
+	if alert.Evaluate(NewPositionRevaluation(position, 80), now) {
+		t.Error("Expected a fired alert to stay quiet while the loss deepens")
+	}
+
+	alert.Reset()
+	if alert.Triggered || alert.TriggeredAt != nil || alert.TriggeredPnL != nil {
+		t.Errorf("Expected reset to clear the trigger state, got %+v", alert)
+	}
+	if !alert.Evaluate(NewPositionRevaluation(position, 80), now) {
+		t.Error("Expected a reset alert to fire again")
+	}
+}
+
+func TestPnLAlert_GainAmountThreshold(t *testing.T) {
+	position, err := NewPosition(uuid.New(), "MSFT", 5, 200, PositionTypeLong)
+	if err != nil {
+		t.Fatalf("Failed to create position: %v", err)
+	}
+	alert, err := NewPnLAlert(position.UserID, "MSFT", PnLAlertDirectionGain, PnLThresholdTypeAmount, 100)
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	if alert.IsBreachedBy(NewPositionRevaluation(position, 210)) {
+		t.Error("Expected a 50 gain not to breach a 100 gain threshold")
+	}
+	if !alert.IsBreachedBy(NewPositionRevaluation(position, 220)) {
+		t.Error("Expected a 100 gain to breach a 100 gain threshold")
+	}
+	if alert.IsBreachedBy(NewPositionRevaluation(position, 150)) {
+		t.Error("Expected a loss not to breach a gain threshold")
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -93,6 +94,11 @@ func (p *Position) UpdateQuantity(tradeQuantity float64, tradePrice float64, isB
 
 // UpdateQuantityWithOrderID updates position quantity with optional order ID for event tracking
 func (p *Position) UpdateQuantityWithOrderID(tradeQuantity float64, tradePrice float64, isBuyOrder bool, sourceOrderID *string) error {
+	return p.UpdateQuantityWithAveraging(tradeQuantity, tradePrice, isBuyOrder, sourceOrderID, AveragingMethodWeighted)
+}
+
+// UpdateQuantityWithAveraging updates position quantity, applying the averaging method to buys
+func (p *Position) UpdateQuantityWithAveraging(tradeQuantity float64, tradePrice float64, isBuyOrder bool, sourceOrderID *string, averagingMethod AveragingMethod) error {
 	if tradeQuantity <= 0 {
 		return errors.New("trade quantity must be greater than zero")
 	}
@@ -108,20 +114,30 @@ func (p *Position) UpdateQuantityWithOrderID(tradeQuantity float64, tradePrice f
 	// Capture previous state for event
 	prevQuantity := p.Quantity
 	prevAveragePrice := p.AveragePrice
+	prevCostBasis := p.CostBasisPerShare()
 	prevStatus := p.Status
 
 	now := time.Now()
 
 	if isBuyOrder {
 		// BUY order: increase quantity and recalculate average price
-		newAveragePrice, err := p.CalculateNewAveragePrice(tradeQuantity, tradePrice)
-		if err != nil {
-			return fmt.Errorf("failed to calculate new average price: %w", err)
+		newAveragePrice := p.AveragePrice
+		if averagingMethod != AveragingMethodOriginalCost {
+			var err error
+			newAveragePrice, err = p.CalculateNewAveragePrice(tradeQuantity, tradePrice)
+			if err != nil {
+				return fmt.Errorf("failed to calculate new average price: %w", err)
+			}
 		}
 
 		p.Quantity += tradeQuantity
 		p.AveragePrice = newAveragePrice
-		p.TotalInvestment = p.Quantity * p.AveragePrice
+		if averagingMethod == AveragingMethodOriginalCost {
+			// The average price no longer reflects cost, so the investment adds the lot at its trade price
+			p.TotalInvestment += tradeQuantity * tradePrice
+		} else {
+			p.TotalInvestment = p.Quantity * p.AveragePrice
+		}
 
 	} else {
 		// SELL order: decrease quantity
@@ -130,7 +146,7 @@ func (p *Position) UpdateQuantityWithOrderID(tradeQuantity float64, tradePrice f
 		}
 
 		p.Quantity -= tradeQuantity
-		p.TotalInvestment = p.Quantity * p.AveragePrice
+		p.TotalInvestment = p.Quantity * prevCostBasis
 
 		// Update status based on remaining quantity
 		if p.Quantity == 0 {
@@ -166,10 +182,10 @@ func (p *Position) UpdateQuantityWithOrderID(tradeQuantity float64, tradePrice f
 	if p.Status == PositionStatusClosed {
 		holdingPeriod := now.Sub(p.CreatedAt)
 		realizedValue := tradeQuantity * tradePrice
-		realizedPnL := realizedValue - (prevAveragePrice * tradeQuantity)
+		realizedPnL := realizedValue - (prevCostBasis * tradeQuantity)
 		var realizedPnLPct float64
-		if prevAveragePrice > 0 {
-			realizedPnLPct = (realizedPnL / (prevAveragePrice * tradeQuantity)) * 100
+		if prevCostBasis > 0 {
+			realizedPnLPct = (realizedPnL / (prevCostBasis * tradeQuantity)) * 100
 		}
 
 		closedEvent := NewPositionClosedEvent(
@@ -215,6 +231,19 @@ func (p *Position) CalculateNewAveragePrice(newQuantity float64, newPrice float6
 	return totalInvestment / totalQuantity, nil
 }
 
+// CostBasisPerShare returns what each held share cost on average. It matches the average price unless
+// buy-ups used ORIGINAL_COST averaging, which leaves the average price at the first lot's price.
+func (p *Position) CostBasisPerShare() float64 {
+	if p.Quantity <= 0 || p.TotalInvestment <= 0 {
+		return p.AveragePrice
+	}
+	// Weighted positions only differ from the average price by float rounding, which is ignored
+	if costBasis := p.TotalInvestment / p.Quantity; math.Abs(costBasis-p.AveragePrice) > 1e-9*p.AveragePrice {
+		return costBasis
+	}
+	return p.AveragePrice
+}
+
 func (p *Position) CanSell(sellQuantity float64) bool {
 	if sellQuantity <= 0 {
 		return false
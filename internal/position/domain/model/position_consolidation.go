@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsolidationLedgerKey identifies the ledger entries written when a duplicate position is
+// folded into another. It is stored as the ledger's corporate action ID so that a retried
+// consolidation finds the work it already did.
+func ConsolidationLedgerKey(duplicateID uuid.UUID) string {
+	return "CONSOLIDATION-" + duplicateID.String()
+}
+
+// AbsorbDuplicate folds a duplicate active position for the same user and symbol into this one.
+// Quantities and total investments are summed, so the average price becomes the
+// quantity-weighted average of both. The duplicate is left closed with nothing in it.
+func (p *Position) AbsorbDuplicate(duplicate *Position, quantityPrecision, pricePrecision int) error {
+	if duplicate == nil {
+		return errors.New("duplicate position cannot be nil")
+	}
+	if duplicate.ID == p.ID {
+		return errors.New("position cannot absorb itself")
+	}
+	if duplicate.UserID != p.UserID || duplicate.Symbol != p.Symbol {
+		return errors.New("only positions for the same user and symbol can be consolidated")
+	}
+	if duplicate.PositionType != p.PositionType {
+		return errors.New("cannot consolidate positions of different types")
+	}
+	if !p.Status.CanBeUpdated() || !duplicate.Status.CanBeUpdated() {
+		return fmt.Errorf("cannot consolidate positions with status %s and %s", p.Status, duplicate.Status)
+	}
+
+	totalQuantity := p.Quantity + duplicate.Quantity
+	totalInvestment := p.TotalInvestment + duplicate.TotalInvestment
+
+	p.Quantity = RoundToDecimalPlaces(totalQuantity, quantityPrecision)
+	p.TotalInvestment = RoundToDecimalPlaces(totalInvestment, pricePrecision)
+	if totalQuantity > 0 {
+		p.AveragePrice = RoundToDecimalPlaces(totalInvestment/totalQuantity, pricePrecision)
+	}
+	if duplicate.CreatedAt.Before(p.CreatedAt) {
+		p.CreatedAt = duplicate.CreatedAt
+	}
+	if duplicate.LastTradeAt != nil && (p.LastTradeAt == nil || duplicate.LastTradeAt.After(*p.LastTradeAt)) {
+		p.LastTradeAt = duplicate.LastTradeAt
+	}
+	if p.CurrentPrice > 0 {
+		p.MarketValue = RoundToDecimalPlaces(p.Quantity*p.CurrentPrice, pricePrecision)
+		p.UnrealizedPnL = p.MarketValue - p.TotalInvestment
+		if p.TotalInvestment > 0 {
+			p.UnrealizedPnLPct = (p.UnrealizedPnL / p.TotalInvestment) * 100
+		}
+	}
+
+	now := time.Now()
+	p.UpdatedAt = now
+
+	duplicate.Quantity = 0
+	duplicate.TotalInvestment = 0
+	duplicate.MarketValue = 0
+	duplicate.UnrealizedPnL = 0
+	duplicate.UnrealizedPnLPct = 0
+	duplicate.Status = PositionStatusClosed
+	duplicate.UpdatedAt = now
+
+	return nil
+}
+
+// NewConsolidationLedgerEntry records a consolidation against one of the positions involved,
+// after the duplicate was absorbed
+func NewConsolidationLedgerEntry(position *Position, duplicateID uuid.UUID, previousQuantity, previousAveragePrice float64, description string) (*PositionLedgerEntry, error) {
+	if position == nil {
+		return nil, errors.New("position cannot be nil")
+	}
+
+	now := time.Now()
+	return &PositionLedgerEntry{
+		ID:                   uuid.New(),
+		PositionID:           position.ID,
+		UserID:               position.UserID,
+		Symbol:               position.Symbol,
+		EntryType:            PositionLedgerEntryTypeConsolidation,
+		CorporateActionID:    ConsolidationLedgerKey(duplicateID),
+		Quantity:             position.Quantity,
+		PreviousQuantity:     previousQuantity,
+		AveragePrice:         position.AveragePrice,
+		PreviousAveragePrice: previousAveragePrice,
+		Description:          description,
+		EffectiveDate:        now,
+		CreatedAt:            now,
+	}, nil
+}
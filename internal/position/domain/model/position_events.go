@@ -210,3 +210,26 @@ func NewPositionValidationFailedEvent(positionID, userID, symbol string,
 		ValidationContext: validationContext,
 	}
 }
+
+type PositionCorporateActionAppliedEvent struct {
+	PositionEvent
+	ActionType           string // "SPLIT" or "SYMBOL_CHANGE"
+	PreviousQuantity     float64
+	PreviousAveragePrice float64
+	NewQuantity          float64
+	NewAveragePrice      float64
+	NewSymbol            string
+}
+
+func NewPositionCorporateActionAppliedEvent(positionID, userID, symbol, actionType string,
+	prevQuantity, prevAvgPrice, newQuantity, newAvgPrice float64, newSymbol string) *PositionCorporateActionAppliedEvent {
+	return &PositionCorporateActionAppliedEvent{
+		PositionEvent:        NewPositionEvent("PositionCorporateActionApplied", positionID, userID, symbol),
+		ActionType:           actionType,
+		PreviousQuantity:     prevQuantity,
+		PreviousAveragePrice: prevAvgPrice,
+		NewQuantity:          newQuantity,
+		NewAveragePrice:      newAvgPrice,
+		NewSymbol:            newSymbol,
+	}
+}
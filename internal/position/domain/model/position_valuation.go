@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PositionValuation is an end-of-day snapshot of a position marked to the closing price
+type PositionValuation struct {
+	ID               uuid.UUID `json:"id"`
+	PositionID       uuid.UUID `json:"positionId"`
+	UserID           uuid.UUID `json:"userId"`
+	Symbol           string    `json:"symbol"`
+	TradingDay       time.Time `json:"tradingDay"`
+	Quantity         float64   `json:"quantity"`
+	AveragePrice     float64   `json:"averagePrice"`
+	ClosePrice       float64   `json:"closePrice"`
+	MarketValue      float64   `json:"marketValue"`
+	UnrealizedPnL    float64   `json:"unrealizedPnL"`
+	UnrealizedPnLPct float64   `json:"unrealizedPnLPct"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// NewPositionValuation marks the position to the given close price for a trading day
+func NewPositionValuation(position *Position, closePrice float64, tradingDay time.Time) (*PositionValuation, error) {
+	if position == nil {
+		return nil, errors.New("position cannot be nil")
+	}
+
+	if closePrice <= 0 {
+		return nil, errors.New("close price must be greater than zero")
+	}
+
+	marketValue := position.Quantity * closePrice
+	unrealizedPnL := marketValue - position.TotalInvestment
+
+	var unrealizedPnLPct float64
+	if position.TotalInvestment > 0 {
+		unrealizedPnLPct = (unrealizedPnL / position.TotalInvestment) * 100
+	}
+
+	return &PositionValuation{
+		ID:               uuid.New(),
+		PositionID:       position.ID,
+		UserID:           position.UserID,
+		Symbol:           position.Symbol,
+		TradingDay:       TruncateToTradingDay(tradingDay),
+		Quantity:         position.Quantity,
+		AveragePrice:     position.AveragePrice,
+		ClosePrice:       closePrice,
+		MarketValue:      marketValue,
+		UnrealizedPnL:    unrealizedPnL,
+		UnrealizedPnLPct: unrealizedPnLPct,
+		CreatedAt:        time.Now(),
+	}, nil
+}
+
+// TruncateToTradingDay strips the time of day so valuations are keyed by calendar date
+func TruncateToTradingDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// PositionRevaluation is an intraday valuation of a position at a live price. Unlike
+// PositionValuation it is computed on demand and never persisted.
+type PositionRevaluation struct {
+	PositionID       uuid.UUID `json:"positionId"`
+	Symbol           string    `json:"symbol"`
+	Quantity         float64   `json:"quantity"`
+	AveragePrice     float64   `json:"averagePrice"`
+	PreviousPrice    float64   `json:"previousPrice"` // Last price stored on the position
+	CurrentPrice     float64   `json:"currentPrice"`
+	LivePrice        bool      `json:"livePrice"` // False when no live price was available and the stored price was used
+	MarketValue      float64   `json:"marketValue"`
+	TotalInvestment  float64   `json:"totalInvestment"`
+	UnrealizedPnL    float64   `json:"unrealizedPnL"`
+	UnrealizedPnLPct float64   `json:"unrealizedPnLPct"`
+}
+
+// PortfolioRevaluation is the on-demand revaluation of all of a user's active positions
+type PortfolioRevaluation struct {
+	UserID                uuid.UUID             `json:"userId"`
+	Positions             []PositionRevaluation `json:"positions"`
+	TotalMarketValue      float64               `json:"totalMarketValue"`
+	TotalInvestment       float64               `json:"totalInvestment"`
+	TotalUnrealizedPnL    float64               `json:"totalUnrealizedPnL"`
+	TotalUnrealizedPnLPct float64               `json:"totalUnrealizedPnLPct"`
+	RevaluedAt            time.Time             `json:"revaluedAt"`
+	Warnings              []string              `json:"warnings,omitempty"`
+}
+
+// NewPositionRevaluation values the position at the live price, falling back to the
+// position's stored price when the live price is missing
+func NewPositionRevaluation(position *Position, livePrice float64) PositionRevaluation {
+	currentPrice := position.CurrentPrice
+	if livePrice > 0 {
+		currentPrice = livePrice
+	}
+
+	revaluation := PositionRevaluation{
+		PositionID:      position.ID,
+		Symbol:          position.Symbol,
+		Quantity:        position.Quantity,
+		AveragePrice:    position.AveragePrice,
+		PreviousPrice:   position.CurrentPrice,
+		CurrentPrice:    currentPrice,
+		LivePrice:       livePrice > 0,
+		MarketValue:     position.Quantity * currentPrice,
+		TotalInvestment: position.TotalInvestment,
+	}
+
+	revaluation.UnrealizedPnL = revaluation.MarketValue - revaluation.TotalInvestment
+	if revaluation.TotalInvestment > 0 {
+		revaluation.UnrealizedPnLPct = (revaluation.UnrealizedPnL / revaluation.TotalInvestment) * 100
+	}
+
+	return revaluation
+}
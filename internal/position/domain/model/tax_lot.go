@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LotMatchingMethod decides which acquisitions a sale closes when computing realized gains
+type LotMatchingMethod string
+
+const (
+	LotMatchingAverageCost LotMatchingMethod = "AVERAGE_COST" // Close at the position's average price
+	LotMatchingFIFO        LotMatchingMethod = "FIFO"         // Close the oldest lots first
+)
+
+// lotQuantityTolerance absorbs floating point residue when lots are split
+const lotQuantityTolerance = 1e-9
+
+func AllLotMatchingMethods() []LotMatchingMethod {
+	return []LotMatchingMethod{
+		LotMatchingAverageCost,
+		LotMatchingFIFO,
+	}
+}
+
+func (m LotMatchingMethod) IsValid() bool {
+	for _, validMethod := range AllLotMatchingMethods() {
+		if m == validMethod {
+			return true
+		}
+	}
+	return false
+}
+
+func (m LotMatchingMethod) String() string {
+	return string(m)
+}
+
+// NewLotMatchingMethod creates a new LotMatchingMethod from string, defaulting to average cost when empty
+func NewLotMatchingMethod(value string) (LotMatchingMethod, error) {
+	upperValue := strings.ToUpper(strings.TrimSpace(value))
+	if upperValue == "" {
+		return LotMatchingAverageCost, nil
+	}
+
+	method := LotMatchingMethod(upperValue)
+	if !method.IsValid() {
+		return "", errors.New("invalid lot matching method: must be AVERAGE_COST or FIFO")
+	}
+
+	return method, nil
+}
+
+// PositionTrade is an executed trade on a position, used to rebuild its tax lots
+type PositionTrade struct {
+	OrderID    string    `json:"orderId"`
+	Quantity   float64   `json:"quantity"`
+	Price      float64   `json:"price"`
+	IsBuy      bool      `json:"isBuy"`
+	ExecutedAt time.Time `json:"executedAt"`
+}
+
+// TaxLot is the still-open part of one acquisition
+type TaxLot struct {
+	SourceOrderID string    `json:"sourceOrderId"`
+	Quantity      float64   `json:"quantity"`
+	CostPrice     float64   `json:"costPrice"`
+	AcquiredAt    time.Time `json:"acquiredAt"`
+}
+
+// ClosedLot is the part of a tax lot a close would consume
+type ClosedLot struct {
+	SourceOrderID     string    `json:"sourceOrderId,omitempty"` // Empty for quantity not covered by any known lot
+	AcquiredAt        time.Time `json:"acquiredAt,omitempty"`
+	Quantity          float64   `json:"quantity"`
+	CostPrice         float64   `json:"costPrice"`
+	CostBasis         float64   `json:"costBasis"`
+	Proceeds          float64   `json:"proceeds"`
+	RealizedPnL       float64   `json:"realizedPnL"`
+	RemainingQuantity float64   `json:"remainingQuantity"` // Left open in the lot after the close
+}
+
+// PositionClosePreview shows the outcome of closing some or all of a position without executing it
+type PositionClosePreview struct {
+	PositionID        string            `json:"positionId"`
+	Symbol            string            `json:"symbol"`
+	Quantity          float64           `json:"quantity"`
+	ClosePrice        float64           `json:"closePrice"`
+	Proceeds          float64           `json:"proceeds"`
+	CostBasis         float64           `json:"costBasis"`
+	RealizedPnL       float64           `json:"realizedPnL"`
+	RealizedPnLPct    float64           `json:"realizedPnLPct"`
+	RemainingQuantity float64           `json:"remainingQuantity"`
+	FullClose         bool              `json:"fullClose"`
+	Method            LotMatchingMethod `json:"method"`
+	Lots              []ClosedLot       `json:"lots,omitempty"` // Only filled under FIFO
+	Warnings          []string          `json:"warnings,omitempty"`
+}
+
+// BuildFIFOTaxLots replays the trades in execution order and returns the lots still open,
+// oldest first. Each sell consumes the oldest remaining lots.
+func BuildFIFOTaxLots(trades []PositionTrade) []TaxLot {
+	ordered := make([]PositionTrade, len(trades))
+	copy(ordered, trades)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ExecutedAt.Before(ordered[j].ExecutedAt)
+	})
+
+	lots := make([]TaxLot, 0)
+	for _, trade := range ordered {
+		if trade.Quantity <= 0 {
+			continue
+		}
+
+		if trade.IsBuy {
+			lots = append(lots, TaxLot{
+				SourceOrderID: trade.OrderID,
+				Quantity:      trade.Quantity,
+				CostPrice:     trade.Price,
+				AcquiredAt:    trade.ExecutedAt,
+			})
+			continue
+		}
+
+		remaining := trade.Quantity
+		for len(lots) > 0 && remaining > lotQuantityTolerance {
+			consumed := math.Min(lots[0].Quantity, remaining)
+			lots[0].Quantity -= consumed
+			remaining -= consumed
+			if lots[0].Quantity <= lotQuantityTolerance {
+				lots = lots[1:]
+			}
+		}
+	}
+
+	return lots
+}
+
+// PreviewClose computes the realized P&L of closing the quantity at the close price. Under FIFO
+// the oldest open lots are consumed and listed; quantity the lots do not cover is closed at the
+// position's average price with a warning.
+func (p *Position) PreviewClose(quantity, closePrice float64, method LotMatchingMethod, lots []TaxLot) (*PositionClosePreview, error) {
+	if quantity <= 0 {
+		return nil, errors.New("close quantity must be greater than zero")
+	}
+	if quantity > p.Quantity+lotQuantityTolerance {
+		return nil, fmt.Errorf("close quantity %.6f exceeds position quantity %.6f", quantity, p.Quantity)
+	}
+	if closePrice <= 0 {
+		return nil, errors.New("close price must be greater than zero")
+	}
+	if !method.IsValid() {
+		return nil, fmt.Errorf("invalid lot matching method: %s", method)
+	}
+
+	remainingQuantity := p.Quantity - quantity
+	if remainingQuantity < lotQuantityTolerance {
+		remainingQuantity = 0
+	}
+
+	preview := &PositionClosePreview{
+		PositionID:        p.ID.String(),
+		Symbol:            p.Symbol,
+		Quantity:          quantity,
+		ClosePrice:        closePrice,
+		Proceeds:          quantity * closePrice,
+		RemainingQuantity: remainingQuantity,
+		FullClose:         remainingQuantity == 0,
+		Method:            method,
+	}
+
+	if method == LotMatchingFIFO {
+		var uncovered float64
+		preview.Lots, uncovered = closeLotsFIFO(lots, quantity, closePrice, p.AveragePrice)
+		for _, lot := range preview.Lots {
+			preview.CostBasis += lot.CostBasis
+		}
+		if uncovered > 0 {
+			preview.Warnings = append(preview.Warnings,
+				fmt.Sprintf("%.6f shares are not covered by known tax lots and use the average price %.2f", uncovered, p.AveragePrice))
+		}
+	} else {
+		preview.CostBasis = quantity * p.AveragePrice
+	}
+
+	preview.RealizedPnL = preview.Proceeds - preview.CostBasis
+	if preview.CostBasis > 0 {
+		preview.RealizedPnLPct = preview.RealizedPnL / preview.CostBasis * 100
+	}
+
+	return preview, nil
+}
+
+// closeLotsFIFO consumes the oldest lots first. Quantity beyond the lots, such as shares of a
+// position opened outside order execution, is closed at the fallback cost and reported as uncovered.
+func closeLotsFIFO(lots []TaxLot, quantity, closePrice, fallbackCost float64) ([]ClosedLot, float64) {
+	closed := make([]ClosedLot, 0)
+	remaining := quantity
+
+	for _, lot := range lots {
+		if remaining <= lotQuantityTolerance {
+			break
+		}
+		if lot.Quantity <= 0 {
+			continue
+		}
+
+		consumed := math.Min(lot.Quantity, remaining)
+		remaining -= consumed
+
+		lotRemaining := lot.Quantity - consumed
+		if lotRemaining < lotQuantityTolerance {
+			lotRemaining = 0
+		}
+
+		closed = append(closed, newClosedLot(lot.SourceOrderID, lot.AcquiredAt, consumed, lot.CostPrice, closePrice, lotRemaining))
+	}
+
+	if remaining <= lotQuantityTolerance {
+		return closed, 0
+	}
+
+	closed = append(closed, newClosedLot("", time.Time{}, remaining, fallbackCost, closePrice, 0))
+	return closed, remaining
+}
+
+func newClosedLot(sourceOrderID string, acquiredAt time.Time, quantity, costPrice, closePrice, remainingQuantity float64) ClosedLot {
+	lot := ClosedLot{
+		SourceOrderID:     sourceOrderID,
+		AcquiredAt:        acquiredAt,
+		Quantity:          quantity,
+		CostPrice:         costPrice,
+		CostBasis:         quantity * costPrice,
+		Proceeds:          quantity * closePrice,
+		RemainingQuantity: remainingQuantity,
+	}
+	lot.RealizedPnL = lot.Proceeds - lot.CostBasis
+	return lot
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// IPnLAlertRepository defines the interface for position P&L alert persistence
+type IPnLAlertRepository interface {
+	// Save inserts the alert or updates its trigger state
+	Save(ctx context.Context, alert *domain.PnLAlert) error
+	FindByID(ctx context.Context, alertID uuid.UUID) (*domain.PnLAlert, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.PnLAlert, error)
+	// FindArmedBySymbol returns the symbol's alerts that have not fired since their last reset
+	FindArmedBySymbol(ctx context.Context, symbol string) ([]*domain.PnLAlert, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	Delete(ctx context.Context, alertID uuid.UUID) error
+}
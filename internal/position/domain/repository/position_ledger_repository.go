@@ -0,0 +1,16 @@
+package repository
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// IPositionLedgerRepository defines the interface for corporate-action ledger persistence
+type IPositionLedgerRepository interface {
+	Save(ctx context.Context, entry *domain.PositionLedgerEntry) error
+	// ExistsForCorporateAction reports whether the action was already applied to the position
+	ExistsForCorporateAction(ctx context.Context, positionID uuid.UUID, corporateActionID string) (bool, error)
+	FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionLedgerEntry, error)
+}
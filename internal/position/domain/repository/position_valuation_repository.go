@@ -0,0 +1,18 @@
+package repository
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IPositionValuationRepository defines the interface for end-of-day valuation persistence
+type IPositionValuationRepository interface {
+	// Save stores a valuation; a valuation already stored for the same position and trading day is left untouched
+	Save(ctx context.Context, valuation *domain.PositionValuation) error
+	ExistsForTradingDay(ctx context.Context, positionID uuid.UUID, tradingDay time.Time) (bool, error)
+	FindByTradingDay(ctx context.Context, tradingDay time.Time) ([]*domain.PositionValuation, error)
+	FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionValuation, error)
+}
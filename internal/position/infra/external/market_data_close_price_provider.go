@@ -0,0 +1,52 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RodriguesYan/hub-proto-contracts/monolith"
+	"google.golang.org/grpc"
+)
+
+// IBatchMarketDataClient defines the interface for fetching quotes from market data (dependency inversion)
+type IBatchMarketDataClient interface {
+	GetBatchMarketData(ctx context.Context, in *monolith.GetBatchMarketDataRequest, opts ...grpc.CallOption) (*monolith.GetBatchMarketDataResponse, error)
+}
+
+// MarketDataClosePriceProvider serves closing prices from the market data service. The service only
+// publishes the latest quote, which is the official close once the market has closed, so only the
+// current trading day can be served; earlier days report an error instead of a later price.
+type MarketDataClosePriceProvider struct {
+	client IBatchMarketDataClient
+	now    func() time.Time
+}
+
+func NewMarketDataClosePriceProvider(client IBatchMarketDataClient) *MarketDataClosePriceProvider {
+	return &MarketDataClosePriceProvider{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// GetClosePrice returns the symbol's last price for the trading day, which must be the current day
+// in the trading day's timezone
+func (p *MarketDataClosePriceProvider) GetClosePrice(ctx context.Context, symbol string, tradingDay time.Time) (float64, error) {
+	today := p.now().In(tradingDay.Location()).Format("2006-01-02")
+	if tradingDay.Format("2006-01-02") != today {
+		return 0, fmt.Errorf("close price for %s on %s is no longer available, only %s is served",
+			symbol, tradingDay.Format("2006-01-02"), today)
+	}
+
+	resp, err := p.client.GetBatchMarketData(ctx, &monolith.GetBatchMarketDataRequest{Symbols: []string{symbol}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get market data for %s: %w", symbol, err)
+	}
+
+	for _, marketData := range resp.GetMarketData() {
+		if marketData.GetSymbol() == symbol && marketData.GetCurrentPrice() > 0 {
+			return marketData.GetCurrentPrice(), nil
+		}
+	}
+	return 0, fmt.Errorf("no price reported for %s", symbol)
+}
@@ -0,0 +1,61 @@
+package external
+
+import (
+	"context"
+	"strings"
+
+	orderDomain "HubInvestments/internal/order_mngmt_system/domain/model"
+	domain "HubInvestments/internal/position/domain/model"
+)
+
+// IOrderHistoryReader defines the interface for reading a user's orders (dependency inversion)
+type IOrderHistoryReader interface {
+	FindByUserID(ctx context.Context, userID string) ([]*orderDomain.Order, error)
+}
+
+// OrderTradeSource rebuilds a position's trades from the user's executed orders
+type OrderTradeSource struct {
+	orders IOrderHistoryReader
+}
+
+func NewOrderTradeSource(orders IOrderHistoryReader) *OrderTradeSource {
+	return &OrderTradeSource{orders: orders}
+}
+
+// FindExecutedTrades returns the filled part of the user's orders in the symbol
+func (s *OrderTradeSource) FindExecutedTrades(ctx context.Context, userID, symbol string) ([]domain.PositionTrade, error) {
+	orders, err := s.orders.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]domain.PositionTrade, 0)
+	for _, order := range orders {
+		if !strings.EqualFold(order.Symbol(), symbol) || order.ExecutionPrice() == nil {
+			continue
+		}
+
+		quantity := order.FilledQuantity()
+		if quantity <= 0 && order.IsExecuted() {
+			quantity = order.Quantity()
+		}
+		if quantity <= 0 {
+			continue
+		}
+
+		executedAt := order.UpdatedAt()
+		if order.ExecutedAt() != nil {
+			executedAt = *order.ExecutedAt()
+		}
+
+		trades = append(trades, domain.PositionTrade{
+			OrderID:    order.ID(),
+			Quantity:   quantity,
+			Price:      *order.ExecutionPrice(),
+			IsBuy:      order.IsBuyOrder(),
+			ExecutedAt: executedAt,
+		})
+	}
+
+	return trades, nil
+}
@@ -0,0 +1,70 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StaticFXRateClient serves exchange rates from a fixed table, e.g. loaded from configuration.
+// Inverse rates are derived when only the opposite pair is configured.
+type StaticFXRateClient struct {
+	rates map[string]float64
+}
+
+func NewStaticFXRateClient(rates map[string]float64) *StaticFXRateClient {
+	normalized := make(map[string]float64, len(rates))
+	for pair, rate := range rates {
+		normalized[strings.ToUpper(pair)] = rate
+	}
+	return &StaticFXRateClient{rates: normalized}
+}
+
+// ParseFXRates parses a comma-separated list of pairs such as "BRL/USD=0.19,EUR/USD=1.08"
+func ParseFXRates(value string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pair, rateStr, found := strings.Cut(entry, "=")
+		from, to, isPair := strings.Cut(strings.TrimSpace(pair), "/")
+		if !found || !isPair || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid FX rate %q, expected FROM/TO=RATE", entry)
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid FX rate %q: rate must be a positive number", entry)
+		}
+
+		rates[fxPairKey(from, to)] = rate
+	}
+
+	return rates, nil
+}
+
+// GetRate returns the rate that converts an amount in fromCurrency into toCurrency
+func (c *StaticFXRateClient) GetRate(ctx context.Context, fromCurrency, toCurrency string) (float64, error) {
+	if strings.EqualFold(fromCurrency, toCurrency) {
+		return 1, nil
+	}
+
+	if rate, exists := c.rates[fxPairKey(fromCurrency, toCurrency)]; exists {
+		return rate, nil
+	}
+
+	if inverse, exists := c.rates[fxPairKey(toCurrency, fromCurrency)]; exists {
+		return 1 / inverse, nil
+	}
+
+	return 0, fmt.Errorf("no exchange rate configured for %s/%s", strings.ToUpper(fromCurrency), strings.ToUpper(toCurrency))
+}
+
+func fxPairKey(from, to string) string {
+	return strings.ToUpper(strings.TrimSpace(from)) + "/" + strings.ToUpper(strings.TrimSpace(to))
+}
@@ -0,0 +1,56 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/RodriguesYan/hub-proto-contracts/monolith"
+	"google.golang.org/grpc"
+)
+
+// IAssetDetailsClient defines the interface for fetching asset details from market data (dependency inversion)
+type IAssetDetailsClient interface {
+	GetAssetDetails(ctx context.Context, in *monolith.GetAssetDetailsRequest, opts ...grpc.CallOption) (*monolith.GetAssetDetailsResponse, error)
+}
+
+// MarketDataCurrencyResolver resolves a symbol's trading currency from its market data asset details.
+// A symbol's currency does not change, so resolved currencies are cached for the process lifetime.
+type MarketDataCurrencyResolver struct {
+	client IAssetDetailsClient
+	mu     sync.RWMutex
+	cache  map[string]string
+}
+
+func NewMarketDataCurrencyResolver(client IAssetDetailsClient) *MarketDataCurrencyResolver {
+	return &MarketDataCurrencyResolver{
+		client: client,
+		cache:  make(map[string]string),
+	}
+}
+
+// GetSymbolCurrency returns the currency the symbol trades in
+func (r *MarketDataCurrencyResolver) GetSymbolCurrency(ctx context.Context, symbol string) (string, error) {
+	r.mu.RLock()
+	currency, exists := r.cache[symbol]
+	r.mu.RUnlock()
+	if exists {
+		return currency, nil
+	}
+
+	resp, err := r.client.GetAssetDetails(ctx, &monolith.GetAssetDetailsRequest{Symbol: symbol})
+	if err != nil {
+		return "", fmt.Errorf("failed to get asset details for %s: %w", symbol, err)
+	}
+
+	currency = resp.GetAsset().GetCurrency()
+	if currency == "" {
+		return "", fmt.Errorf("no currency reported for %s", symbol)
+	}
+
+	r.mu.Lock()
+	r.cache[symbol] = currency
+	r.mu.Unlock()
+
+	return currency, nil
+}
@@ -13,8 +13,9 @@ type PositionQueueNames struct {
 	PositionUpdates string
 
 	// Management and monitoring queues
-	PositionsDLQ   string
-	PositionsRetry string
+	PositionsDLQ    string
+	PositionsRetry  string
+	PositionsReview string
 
 	// Exchange names
 	PositionsExchange string
@@ -25,8 +26,9 @@ func DefaultPositionQueueNames() PositionQueueNames {
 	return PositionQueueNames{
 		PositionUpdates: "positions.updates",
 
-		PositionsDLQ:   "positions.updates.dlq",
-		PositionsRetry: "positions.retry",
+		PositionsDLQ:    "positions.updates.dlq",
+		PositionsRetry:  "positions.retry",
+		PositionsReview: "positions.review",
 
 		PositionsExchange: "positions.exchange",
 		DLQExchange:       "positions.dlq.exchange",
@@ -177,6 +179,28 @@ func (pqm *PositionQueueManager) setupManagementQueues() error {
 		return fmt.Errorf("failed to declare position retry queue: %w", err)
 	}
 
+	// Review Queue - holds position updates that need manual review before being applied
+	reviewConfig := PositionQueueConfig{
+		Name:       pqm.queueNames.PositionsReview,
+		Durable:    true,
+		AutoDelete: false,
+		Exclusive:  false,
+		NoWait:     false,
+		Arguments:  map[string]interface{}{},
+	}
+
+	reviewOptions := messaging.QueueOptions{
+		Durable:    reviewConfig.Durable,
+		AutoDelete: reviewConfig.AutoDelete,
+		Exclusive:  reviewConfig.Exclusive,
+		NoWait:     reviewConfig.NoWait,
+		Arguments:  reviewConfig.Arguments,
+	}
+
+	if err := pqm.messageHandler.DeclareQueue(reviewConfig.Name, reviewOptions); err != nil {
+		return fmt.Errorf("failed to declare position review queue: %w", err)
+	}
+
 	return nil
 }
 
@@ -252,3 +276,45 @@ func (pqm *PositionQueueManager) PublishToDLQ(ctx context.Context, positionMessa
 
 	return pqm.messageHandler.PublishWithOptions(ctx, options)
 }
+
+// PublishPoisonMessageToDLQ dead-letters a message that could not be decoded, keeping the
+// raw payload untouched so it can be inspected and replayed once the producer is fixed
+func (pqm *PositionQueueManager) PublishPoisonMessageToDLQ(ctx context.Context, rawPayload []byte, messageID string, sourceQueue string, parseError string) error {
+	options := messaging.PublishOptions{
+		QueueName:     pqm.queueNames.PositionsDLQ,
+		Message:       rawPayload,
+		Persistent:    true,
+		Priority:      1, // Low priority for DLQ messages
+		MessageID:     messageID,
+		CorrelationID: messageID,
+		Headers: map[string]interface{}{
+			"message_type":   "position_dlq",
+			"failure_reason": "poison_message",
+			"parse_error":    parseError,
+			"original_queue": sourceQueue,
+			"timestamp":      time.Now().Unix(),
+			"dlq_timestamp":  time.Now().Unix(),
+		},
+	}
+
+	return pqm.messageHandler.PublishWithOptions(ctx, options)
+}
+
+func (pqm *PositionQueueManager) PublishToReviewQueue(ctx context.Context, positionMessage []byte, messageID string, reviewReason string) error {
+	options := messaging.PublishOptions{
+		QueueName:     pqm.queueNames.PositionsReview,
+		Message:       positionMessage,
+		Persistent:    true,
+		Priority:      3,
+		MessageID:     messageID,
+		CorrelationID: messageID,
+		Headers: map[string]interface{}{
+			"message_type":   "position_review",
+			"review_reason":  reviewReason,
+			"original_queue": pqm.queueNames.PositionUpdates,
+			"timestamp":      time.Now().Unix(),
+		},
+	}
+
+	return pqm.messageHandler.PublishWithOptions(ctx, options)
+}
@@ -381,6 +381,72 @@ func TestPositionQueueManager_PublishToDLQ_Success(t *testing.T) {
 	}
 }
 
+func TestPositionQueueManager_PublishPoisonMessageToDLQ_Success(t *testing.T) {
+	mockHandler := NewMockMessageHandler()
+	manager := NewPositionQueueManager(mockHandler)
+	ctx := context.Background()
+
+	rawPayload := []byte(`{"order_id":`)
+	parseError := "unexpected end of JSON input"
+
+	err := manager.PublishPoisonMessageToDLQ(ctx, rawPayload, "poison-message-123", "positions.retry", parseError)
+	if err != nil {
+		t.Errorf("Expected successful publish to DLQ, got error: %v", err)
+	}
+
+	if len(mockHandler.publishedMessages) != 1 {
+		t.Fatalf("Expected 1 published message, got %d", len(mockHandler.publishedMessages))
+	}
+
+	publishedMsg := mockHandler.publishedMessages[0]
+	if publishedMsg.QueueName != "positions.updates.dlq" {
+		t.Errorf("Expected queue name positions.updates.dlq, got %s", publishedMsg.QueueName)
+	}
+
+	if string(publishedMsg.Message) != string(rawPayload) {
+		t.Errorf("Expected raw payload %s to be preserved, got %s", rawPayload, publishedMsg.Message)
+	}
+
+	if reason := publishedMsg.Headers["failure_reason"]; reason != "poison_message" {
+		t.Errorf("Expected failure_reason header to be poison_message, got %v", reason)
+	}
+
+	if parseErr := publishedMsg.Headers["parse_error"]; parseErr != parseError {
+		t.Errorf("Expected parse_error header to be %s, got %v", parseError, parseErr)
+	}
+
+	if queue := publishedMsg.Headers["original_queue"]; queue != "positions.retry" {
+		t.Errorf("Expected original_queue header to be positions.retry, got %v", queue)
+	}
+}
+
+func TestPositionQueueManager_PublishToReviewQueue_Success(t *testing.T) {
+	mockHandler := NewMockMessageHandler()
+	manager := NewPositionQueueManager(mockHandler)
+	ctx := context.Background()
+
+	message := []byte(`{"order_id":"123"}`)
+	reviewReason := "message age exceeded"
+
+	err := manager.PublishToReviewQueue(ctx, message, "review-message-123", reviewReason)
+	if err != nil {
+		t.Errorf("Expected successful publish to review queue, got error: %v", err)
+	}
+
+	if len(mockHandler.publishedMessages) != 1 {
+		t.Fatalf("Expected 1 published message, got %d", len(mockHandler.publishedMessages))
+	}
+
+	publishedMsg := mockHandler.publishedMessages[0]
+	if publishedMsg.QueueName != "positions.review" {
+		t.Errorf("Expected queue name positions.review, got %s", publishedMsg.QueueName)
+	}
+
+	if reason, exists := publishedMsg.Headers["review_reason"]; !exists || reason != reviewReason {
+		t.Errorf("Expected review_reason header to be %s, got %v", reviewReason, reason)
+	}
+}
+
 func TestPositionQueueManager_GetQueueNames(t *testing.T) {
 	mockHandler := NewMockMessageHandler()
 	manager := NewPositionQueueManager(mockHandler)
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/shared/infra/websocket"
+
+	"github.com/google/uuid"
+)
+
+// PnLAlertMessage is the payload pushed to the user when one of their P&L alerts fires
+type PnLAlertMessage struct {
+	Type             string    `json:"type"`
+	AlertID          string    `json:"alert_id"`
+	Symbol           string    `json:"symbol"`
+	Direction        string    `json:"direction"`
+	ThresholdType    string    `json:"threshold_type"`
+	Threshold        float64   `json:"threshold"`
+	CurrentPrice     float64   `json:"current_price"`
+	UnrealizedPnL    float64   `json:"unrealized_pnl"`
+	UnrealizedPnLPct float64   `json:"unrealized_pnl_pct"`
+	TriggeredAt      time.Time `json:"triggered_at"`
+}
+
+// WebSocketPnLAlertNotifier pushes fired P&L alerts to every open alert connection of the user
+type WebSocketPnLAlertNotifier struct {
+	mutex       sync.Mutex                                   // Also serializes writes, since a connection allows one writer at a time
+	connections map[uuid.UUID]map[string]websocket.Websocket // user ID -> connection ID -> connection
+}
+
+func NewWebSocketPnLAlertNotifier() *WebSocketPnLAlertNotifier {
+	return &WebSocketPnLAlertNotifier{
+		connections: make(map[uuid.UUID]map[string]websocket.Websocket),
+	}
+}
+
+// Connect registers a connection that receives the user's alerts
+func (n *WebSocketPnLAlertNotifier) Connect(userID uuid.UUID, connectionID string, conn websocket.Websocket) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	userConnections, exists := n.connections[userID]
+	if !exists {
+		userConnections = make(map[string]websocket.Websocket)
+		n.connections[userID] = userConnections
+	}
+	userConnections[connectionID] = conn
+}
+
+// Disconnect stops pushing alerts to the connection
+func (n *WebSocketPnLAlertNotifier) Disconnect(userID uuid.UUID, connectionID string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.connections[userID], connectionID)
+	if len(n.connections[userID]) == 0 {
+		delete(n.connections, userID)
+	}
+}
+
+// NotifyPnLAlert writes the fired alert to the user's connections. A user without an open
+// connection is not an error; the fired alert stays visible in their alert list.
+func (n *WebSocketPnLAlertNotifier) NotifyPnLAlert(ctx context.Context, alert *domain.PnLAlert, revaluation domain.PositionRevaluation) error {
+	message := PnLAlertMessage{
+		Type:             "pnl_alert",
+		AlertID:          alert.ID.String(),
+		Symbol:           alert.Symbol,
+		Direction:        string(alert.Direction),
+		ThresholdType:    string(alert.ThresholdType),
+		Threshold:        alert.Threshold,
+		CurrentPrice:     revaluation.CurrentPrice,
+		UnrealizedPnL:    revaluation.UnrealizedPnL,
+		UnrealizedPnLPct: revaluation.UnrealizedPnLPct,
+	}
+	if alert.TriggeredAt != nil {
+		message.TriggeredAt = *alert.TriggeredAt
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal P&L alert message: %w", err)
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	var errs []error
+	for connectionID, conn := range n.connections[alert.UserID] {
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			errs = append(errs, fmt.Errorf("connection %s: %w", connectionID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// PnLAlertDTO represents the data transfer object for a PnLAlert in the database.
+type PnLAlertDTO struct {
+	ID            uuid.UUID  `db:"id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	Symbol        string     `db:"symbol"`
+	Direction     string     `db:"direction"`
+	ThresholdType string     `db:"threshold_type"`
+	Threshold     float64    `db:"threshold"`
+	Triggered     bool       `db:"triggered"`
+	TriggeredAt   *time.Time `db:"triggered_at"`
+	TriggeredPnL  *float64   `db:"triggered_pnl"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+// ToDomain converts a PnLAlertDTO to a domain.PnLAlert model.
+func (dto *PnLAlertDTO) ToDomain() *domain.PnLAlert {
+	return &domain.PnLAlert{
+		ID:            dto.ID,
+		UserID:        dto.UserID,
+		Symbol:        dto.Symbol,
+		Direction:     domain.PnLAlertDirection(dto.Direction),
+		ThresholdType: domain.PnLThresholdType(dto.ThresholdType),
+		Threshold:     dto.Threshold,
+		Triggered:     dto.Triggered,
+		TriggeredAt:   dto.TriggeredAt,
+		TriggeredPnL:  dto.TriggeredPnL,
+		CreatedAt:     dto.CreatedAt,
+		UpdatedAt:     dto.UpdatedAt,
+	}
+}
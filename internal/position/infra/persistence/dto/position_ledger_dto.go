@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// PositionLedgerEntryDTO represents the data transfer object for a PositionLedgerEntry in the database.
+type PositionLedgerEntryDTO struct {
+	ID                   uuid.UUID `db:"id"`
+	PositionID           uuid.UUID `db:"position_id"`
+	UserID               uuid.UUID `db:"user_id"`
+	Symbol               string    `db:"symbol"`
+	EntryType            string    `db:"entry_type"`
+	CorporateActionID    string    `db:"corporate_action_id"`
+	Quantity             float64   `db:"quantity"`
+	PreviousQuantity     float64   `db:"previous_quantity"`
+	AveragePrice         float64   `db:"average_price"`
+	PreviousAveragePrice float64   `db:"previous_average_price"`
+	CashAmount           float64   `db:"cash_amount"`
+	Description          string    `db:"description"`
+	EffectiveDate        time.Time `db:"effective_date"`
+	CreatedAt            time.Time `db:"created_at"`
+}
+
+// ToDomain converts a PositionLedgerEntryDTO to a domain.PositionLedgerEntry model.
+func (dto *PositionLedgerEntryDTO) ToDomain() *domain.PositionLedgerEntry {
+	return &domain.PositionLedgerEntry{
+		ID:                   dto.ID,
+		PositionID:           dto.PositionID,
+		UserID:               dto.UserID,
+		Symbol:               dto.Symbol,
+		EntryType:            domain.PositionLedgerEntryType(dto.EntryType),
+		CorporateActionID:    dto.CorporateActionID,
+		Quantity:             dto.Quantity,
+		PreviousQuantity:     dto.PreviousQuantity,
+		AveragePrice:         dto.AveragePrice,
+		PreviousAveragePrice: dto.PreviousAveragePrice,
+		CashAmount:           dto.CashAmount,
+		Description:          dto.Description,
+		EffectiveDate:        dto.EffectiveDate,
+		CreatedAt:            dto.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// PositionValuationDTO represents the data transfer object for a PositionValuation in the database.
+type PositionValuationDTO struct {
+	ID               uuid.UUID `db:"id"`
+	PositionID       uuid.UUID `db:"position_id"`
+	UserID           uuid.UUID `db:"user_id"`
+	Symbol           string    `db:"symbol"`
+	TradingDay       time.Time `db:"trading_day"`
+	Quantity         float64   `db:"quantity"`
+	AveragePrice     float64   `db:"average_price"`
+	ClosePrice       float64   `db:"close_price"`
+	MarketValue      float64   `db:"market_value"`
+	UnrealizedPnL    float64   `db:"unrealized_pnl"`
+	UnrealizedPnLPct float64   `db:"unrealized_pnl_pct"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// ToDomain converts a PositionValuationDTO to a domain.PositionValuation model.
+func (dto *PositionValuationDTO) ToDomain() *domain.PositionValuation {
+	return &domain.PositionValuation{
+		ID:               dto.ID,
+		PositionID:       dto.PositionID,
+		UserID:           dto.UserID,
+		Symbol:           dto.Symbol,
+		TradingDay:       dto.TradingDay,
+		Quantity:         dto.Quantity,
+		AveragePrice:     dto.AveragePrice,
+		ClosePrice:       dto.ClosePrice,
+		MarketValue:      dto.MarketValue,
+		UnrealizedPnL:    dto.UnrealizedPnL,
+		UnrealizedPnLPct: dto.UnrealizedPnLPct,
+		CreatedAt:        dto.CreatedAt,
+	}
+}
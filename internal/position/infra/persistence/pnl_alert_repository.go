@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	repository "HubInvestments/internal/position/domain/repository"
+	"HubInvestments/internal/position/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type PnLAlertRepository struct {
+	db database.Database
+}
+
+// NewPnLAlertRepository creates a new P&L alert repository using the database abstraction
+func NewPnLAlertRepository(db database.Database) repository.IPnLAlertRepository {
+	return &PnLAlertRepository{db: db}
+}
+
+func (r *PnLAlertRepository) Save(ctx context.Context, alert *domain.PnLAlert) error {
+	query := `
+		INSERT INTO yanrodrigues.position_pnl_alerts (
+			id, user_id, symbol, direction, threshold_type, threshold,
+			triggered, triggered_at, triggered_pnl, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		)
+		ON CONFLICT (id) DO UPDATE SET
+			triggered = EXCLUDED.triggered,
+			triggered_at = EXCLUDED.triggered_at,
+			triggered_pnl = EXCLUDED.triggered_pnl,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		alert.ID, alert.UserID, alert.Symbol, string(alert.Direction), string(alert.ThresholdType),
+		alert.Threshold, alert.Triggered, alert.TriggeredAt, alert.TriggeredPnL,
+		alert.CreatedAt, alert.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save P&L alert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PnLAlertRepository) FindByID(ctx context.Context, alertID uuid.UUID) (*domain.PnLAlert, error) {
+	query := `
+		SELECT id, user_id, symbol, direction, threshold_type, threshold,
+		       triggered, triggered_at, triggered_pnl, created_at, updated_at
+		FROM yanrodrigues.position_pnl_alerts
+		WHERE id = $1`
+
+	var alertDTO dto.PnLAlertDTO
+	err := r.db.Get(&alertDTO, query, alertID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find P&L alert by ID: %w", err)
+	}
+
+	return alertDTO.ToDomain(), nil
+}
+
+func (r *PnLAlertRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.PnLAlert, error) {
+	query := `
+		SELECT id, user_id, symbol, direction, threshold_type, threshold,
+		       triggered, triggered_at, triggered_pnl, created_at, updated_at
+		FROM yanrodrigues.position_pnl_alerts
+		WHERE user_id = $1
+		ORDER BY symbol, created_at`
+
+	var alertDTOs []*dto.PnLAlertDTO
+	err := r.db.Select(&alertDTOs, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find P&L alerts for user %s: %w", userID, err)
+	}
+
+	return toPnLAlertDomainList(alertDTOs), nil
+}
+
+func (r *PnLAlertRepository) FindArmedBySymbol(ctx context.Context, symbol string) ([]*domain.PnLAlert, error) {
+	query := `
+		SELECT id, user_id, symbol, direction, threshold_type, threshold,
+		       triggered, triggered_at, triggered_pnl, created_at, updated_at
+		FROM yanrodrigues.position_pnl_alerts
+		WHERE symbol = $1 AND triggered = FALSE
+		ORDER BY created_at`
+
+	var alertDTOs []*dto.PnLAlertDTO
+	err := r.db.Select(&alertDTOs, query, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find armed P&L alerts for %s: %w", symbol, err)
+	}
+
+	return toPnLAlertDomainList(alertDTOs), nil
+}
+
+func (r *PnLAlertRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM yanrodrigues.position_pnl_alerts WHERE user_id = $1`
+
+	var count int
+	if err := r.db.Get(&count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count P&L alerts for user %s: %w", userID, err)
+	}
+
+	return count, nil
+}
+
+func (r *PnLAlertRepository) Delete(ctx context.Context, alertID uuid.UUID) error {
+	query := `DELETE FROM yanrodrigues.position_pnl_alerts WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, alertID); err != nil {
+		return fmt.Errorf("failed to delete P&L alert %s: %w", alertID, err)
+	}
+
+	return nil
+}
+
+func toPnLAlertDomainList(alertDTOs []*dto.PnLAlertDTO) []*domain.PnLAlert {
+	alerts := make([]*domain.PnLAlert, 0, len(alertDTOs))
+	for _, alertDTO := range alertDTOs {
+		alerts = append(alerts, alertDTO.ToDomain())
+	}
+	return alerts
+}
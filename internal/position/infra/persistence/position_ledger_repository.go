@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	repository "HubInvestments/internal/position/domain/repository"
+	"HubInvestments/internal/position/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type PositionLedgerRepository struct {
+	db database.Database
+}
+
+// NewPositionLedgerRepository creates a new position ledger repository using the database abstraction
+func NewPositionLedgerRepository(db database.Database) repository.IPositionLedgerRepository {
+	return &PositionLedgerRepository{db: db}
+}
+
+func (r *PositionLedgerRepository) Save(ctx context.Context, entry *domain.PositionLedgerEntry) error {
+	// The (position_id, corporate_action_id) unique key keeps corporate actions from being applied twice
+	query := `
+		INSERT INTO yanrodrigues.position_ledger (
+			id, position_id, user_id, symbol, entry_type, corporate_action_id, quantity,
+			previous_quantity, average_price, previous_average_price, cash_amount,
+			description, effective_date, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		)
+		ON CONFLICT (position_id, corporate_action_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID, entry.PositionID, entry.UserID, entry.Symbol, string(entry.EntryType),
+		entry.CorporateActionID, entry.Quantity, entry.PreviousQuantity, entry.AveragePrice,
+		entry.PreviousAveragePrice, entry.CashAmount, entry.Description,
+		entry.EffectiveDate, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save position ledger entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PositionLedgerRepository) ExistsForCorporateAction(ctx context.Context, positionID uuid.UUID, corporateActionID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM yanrodrigues.position_ledger
+			WHERE position_id = $1 AND corporate_action_id = $2
+		)`
+
+	var exists bool
+	err := r.db.Get(&exists, query, positionID, corporateActionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check position ledger entry existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *PositionLedgerRepository) FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionLedgerEntry, error) {
+	query := `
+		SELECT id, position_id, user_id, symbol, entry_type, corporate_action_id, quantity,
+		       previous_quantity, average_price, previous_average_price, cash_amount,
+		       description, effective_date, created_at
+		FROM yanrodrigues.position_ledger
+		WHERE position_id = $1
+		ORDER BY effective_date DESC, created_at DESC`
+
+	var entryDTOs []*dto.PositionLedgerEntryDTO
+	err := r.db.Select(&entryDTOs, query, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find position ledger entries for position %s: %w", positionID, err)
+	}
+
+	entries := make([]*domain.PositionLedgerEntry, 0, len(entryDTOs))
+	for _, entryDTO := range entryDTOs {
+		entries = append(entries, entryDTO.ToDomain())
+	}
+	return entries, nil
+}
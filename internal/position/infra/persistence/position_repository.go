@@ -9,6 +9,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -104,6 +105,44 @@ func (r *PositionRepository) FindActivePositions(ctx context.Context, userID uui
 	return r.mapper.ToDomainList(positionDTOs)
 }
 
+// FindAllActivePositions retrieves active positions across all users, used by end-of-day valuation
+func (r *PositionRepository) FindAllActivePositions(ctx context.Context) ([]*domain.Position, error) {
+	query := `
+		SELECT id, user_id, symbol, quantity, average_price, total_investment,
+		       current_price, market_value, unrealized_pnl, unrealized_pnl_pct,
+		       position_type, status, created_at, updated_at, last_trade_at
+		FROM yanrodrigues.positions_v2
+		WHERE status IN ('ACTIVE', 'PARTIAL')
+		ORDER BY user_id, symbol`
+
+	var positionDTOs []*dto.PositionDTO
+	err := r.db.Select(&positionDTOs, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions: %w", err)
+	}
+
+	return r.mapper.ToDomainList(positionDTOs)
+}
+
+// FindActivePositionsBySymbol retrieves active positions in a symbol across all users, used by corporate actions
+func (r *PositionRepository) FindActivePositionsBySymbol(ctx context.Context, symbol string) ([]*domain.Position, error) {
+	query := `
+		SELECT id, user_id, symbol, quantity, average_price, total_investment,
+		       current_price, market_value, unrealized_pnl, unrealized_pnl_pct,
+		       position_type, status, created_at, updated_at, last_trade_at
+		FROM yanrodrigues.positions_v2
+		WHERE symbol = $1 AND status IN ('ACTIVE', 'PARTIAL')
+		ORDER BY user_id`
+
+	var positionDTOs []*dto.PositionDTO
+	err := r.db.Select(&positionDTOs, query, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions for symbol %s: %w", symbol, err)
+	}
+
+	return r.mapper.ToDomainList(positionDTOs)
+}
+
 func (r *PositionRepository) Save(ctx context.Context, position *domain.Position) error {
 	positionDTO, err := r.mapper.CreateDTOForInsert(position)
 	if err != nil {
@@ -177,6 +216,31 @@ func (r *PositionRepository) Update(ctx context.Context, position *domain.Positi
 	return nil
 }
 
+// UpdateSymbol moves a position to a new symbol after an issuer symbol change
+func (r *PositionRepository) UpdateSymbol(ctx context.Context, positionID uuid.UUID, newSymbol string) error {
+	query := `
+		UPDATE yanrodrigues.positions_v2 SET
+			symbol = $1,
+			updated_at = $2
+		WHERE id = $3`
+
+	result, err := r.db.Exec(query, newSymbol, time.Now(), positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update position symbol: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("position %s not found for symbol update: %w", positionID, dto.ErrPositionNotFound)
+	}
+
+	return nil
+}
+
 func (r *PositionRepository) Delete(ctx context.Context, positionID uuid.UUID) error {
 	query := `DELETE FROM yanrodrigues.positions_v2 WHERE id = $1`
 
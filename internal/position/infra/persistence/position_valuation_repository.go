@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	repository "HubInvestments/internal/position/domain/repository"
+	"HubInvestments/internal/position/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PositionValuationRepository struct {
+	db database.Database
+}
+
+// NewPositionValuationRepository creates a new position valuation repository using the database abstraction
+func NewPositionValuationRepository(db database.Database) repository.IPositionValuationRepository {
+	return &PositionValuationRepository{db: db}
+}
+
+func (r *PositionValuationRepository) Save(ctx context.Context, valuation *domain.PositionValuation) error {
+	// The (position_id, trading_day) unique key keeps the end-of-day job idempotent
+	query := `
+		INSERT INTO yanrodrigues.position_valuations (
+			id, position_id, user_id, symbol, trading_day, quantity, average_price,
+			close_price, market_value, unrealized_pnl, unrealized_pnl_pct, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+		ON CONFLICT (position_id, trading_day) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		valuation.ID, valuation.PositionID, valuation.UserID, valuation.Symbol,
+		valuation.TradingDay, valuation.Quantity, valuation.AveragePrice,
+		valuation.ClosePrice, valuation.MarketValue, valuation.UnrealizedPnL,
+		valuation.UnrealizedPnLPct, valuation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save position valuation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PositionValuationRepository) ExistsForTradingDay(ctx context.Context, positionID uuid.UUID, tradingDay time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM yanrodrigues.position_valuations
+			WHERE position_id = $1 AND trading_day = $2
+		)`
+
+	var exists bool
+	err := r.db.Get(&exists, query, positionID, domain.TruncateToTradingDay(tradingDay))
+	if err != nil {
+		return false, fmt.Errorf("failed to check position valuation existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *PositionValuationRepository) FindByTradingDay(ctx context.Context, tradingDay time.Time) ([]*domain.PositionValuation, error) {
+	query := `
+		SELECT id, position_id, user_id, symbol, trading_day, quantity, average_price,
+		       close_price, market_value, unrealized_pnl, unrealized_pnl_pct, created_at
+		FROM yanrodrigues.position_valuations
+		WHERE trading_day = $1
+		ORDER BY symbol`
+
+	var valuationDTOs []*dto.PositionValuationDTO
+	err := r.db.Select(&valuationDTOs, query, domain.TruncateToTradingDay(tradingDay))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find position valuations for %s: %w", tradingDay.Format("2006-01-02"), err)
+	}
+
+	return toValuationDomainList(valuationDTOs), nil
+}
+
+func (r *PositionValuationRepository) FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionValuation, error) {
+	query := `
+		SELECT id, position_id, user_id, symbol, trading_day, quantity, average_price,
+		       close_price, market_value, unrealized_pnl, unrealized_pnl_pct, created_at
+		FROM yanrodrigues.position_valuations
+		WHERE position_id = $1
+		ORDER BY trading_day DESC`
+
+	var valuationDTOs []*dto.PositionValuationDTO
+	err := r.db.Select(&valuationDTOs, query, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find position valuations for position %s: %w", positionID, err)
+	}
+
+	return toValuationDomainList(valuationDTOs), nil
+}
+
+func toValuationDomainList(valuationDTOs []*dto.PositionValuationDTO) []*domain.PositionValuation {
+	valuations := make([]*domain.PositionValuation, 0, len(valuationDTOs))
+	for _, valuationDTO := range valuationDTOs {
+		valuations = append(valuations, valuationDTO.ToDomain())
+	}
+	return valuations
+}
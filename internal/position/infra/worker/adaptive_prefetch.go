@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AdaptivePrefetchConfig holds configuration for tuning a consumer's prefetch from its processing latency
+type AdaptivePrefetchConfig struct {
+	MinPrefetch          int           // Prefetch never drops below this
+	MaxPrefetch          int           // Prefetch never rises above this
+	HighLatency          time.Duration // Average processing latency above which prefetch is halved
+	LowLatency           time.Duration // Average processing latency below which prefetch is raised by one
+	SmoothingFactor      float64       // Weight of the newest sample in the latency moving average (0-1]
+	SamplesPerAdjustment int           // Messages processed between adjustments, so one slow message does not move prefetch
+}
+
+// DefaultAdaptivePrefetchConfig returns the default adaptive prefetch configuration around a base prefetch
+func DefaultAdaptivePrefetchConfig(basePrefetch int) *AdaptivePrefetchConfig {
+	if basePrefetch < 1 {
+		basePrefetch = 1
+	}
+	return &AdaptivePrefetchConfig{
+		MinPrefetch:          1,                      // Hold a single unacked message when processing stalls
+		MaxPrefetch:          basePrefetch * 2,       // Double the static prefetch when processing is fast
+		HighLatency:          500 * time.Millisecond, // Well above a healthy position update
+		LowLatency:           50 * time.Millisecond,  // Typical position update on an idle database
+		SmoothingFactor:      0.2,
+		SamplesPerAdjustment: 10,
+	}
+}
+
+// Validate checks that the bounds and thresholds are consistent
+func (c *AdaptivePrefetchConfig) Validate() error {
+	switch {
+	case c.MinPrefetch < 1:
+		return fmt.Errorf("minimum prefetch must be at least 1, got %d", c.MinPrefetch)
+	case c.MaxPrefetch < c.MinPrefetch:
+		return fmt.Errorf("maximum prefetch %d is below the minimum %d", c.MaxPrefetch, c.MinPrefetch)
+	case c.LowLatency <= 0 || c.HighLatency <= c.LowLatency:
+		return fmt.Errorf("high latency %s must be above low latency %s", c.HighLatency, c.LowLatency)
+	case c.SmoothingFactor <= 0 || c.SmoothingFactor > 1:
+		return fmt.Errorf("smoothing factor must be in (0, 1], got %v", c.SmoothingFactor)
+	case c.SamplesPerAdjustment < 1:
+		return fmt.Errorf("samples per adjustment must be at least 1, got %d", c.SamplesPerAdjustment)
+	}
+	return nil
+}
+
+// AdaptivePrefetchController tracks a moving average of processing latency and derives the
+// prefetch a consumer should use. Prefetch is halved while latency is high, so a slow consumer
+// stops holding messages other workers could take, and raised one step at a time while latency
+// is low.
+type AdaptivePrefetchController struct {
+	config         AdaptivePrefetchConfig
+	mutex          sync.Mutex
+	prefetch       int
+	averageLatency time.Duration
+	samples        int
+	sinceAdjusted  int
+}
+
+// NewAdaptivePrefetchController starts from the initial prefetch, clamped to the configured bounds
+func NewAdaptivePrefetchController(initialPrefetch int, config *AdaptivePrefetchConfig) (*AdaptivePrefetchController, error) {
+	if config == nil {
+		config = DefaultAdaptivePrefetchConfig(initialPrefetch)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid adaptive prefetch config: %w", err)
+	}
+
+	controller := &AdaptivePrefetchController{config: *config, prefetch: initialPrefetch}
+	controller.prefetch = controller.clamp(initialPrefetch)
+	return controller, nil
+}
+
+// Observe records the latency of one processed message and reports the prefetch to use and
+// whether it changed
+func (c *AdaptivePrefetchController) Observe(latency time.Duration) (int, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.samples == 0 {
+		c.averageLatency = latency
+	} else {
+		c.averageLatency = time.Duration(c.config.SmoothingFactor*float64(latency) +
+			(1-c.config.SmoothingFactor)*float64(c.averageLatency))
+	}
+	c.samples++
+	c.sinceAdjusted++
+
+	if c.sinceAdjusted < c.config.SamplesPerAdjustment {
+		return c.prefetch, false
+	}
+	c.sinceAdjusted = 0
+
+	next := c.prefetch
+	switch {
+	case c.averageLatency > c.config.HighLatency:
+		next = c.clamp(c.prefetch / 2)
+	case c.averageLatency < c.config.LowLatency:
+		next = c.clamp(c.prefetch + 1)
+	}
+
+	changed := next != c.prefetch
+	c.prefetch = next
+	return c.prefetch, changed
+}
+
+// EffectivePrefetch returns the prefetch the consumer should currently use
+func (c *AdaptivePrefetchController) EffectivePrefetch() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.prefetch
+}
+
+// AverageLatency returns the moving average of the observed processing latency
+func (c *AdaptivePrefetchController) AverageLatency() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.averageLatency
+}
+
+func (c *AdaptivePrefetchController) clamp(prefetch int) int {
+	if prefetch < c.config.MinPrefetch {
+		return c.config.MinPrefetch
+	}
+	if prefetch > c.config.MaxPrefetch {
+		return c.config.MaxPrefetch
+	}
+	return prefetch
+}
@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/position/infra/messaging"
+	sharedMessaging "HubInvestments/shared/infra/messaging"
+)
+
+func newTestPrefetchController(t *testing.T) *AdaptivePrefetchController {
+	t.Helper()
+	controller, err := NewAdaptivePrefetchController(20, &AdaptivePrefetchConfig{
+		MinPrefetch:          2,
+		MaxPrefetch:          20,
+		HighLatency:          500 * time.Millisecond,
+		LowLatency:           50 * time.Millisecond,
+		SmoothingFactor:      0.5,
+		SamplesPerAdjustment: 1,
+	})
+	if err != nil {
+		t.Fatalf("Expected valid config, got %v", err)
+	}
+	return controller
+}
+
+func TestAdaptivePrefetchController_RisingLatencyReducesPrefetch(t *testing.T) {
+	controller := newTestPrefetchController(t)
+
+	previous := controller.EffectivePrefetch()
+	for i := 0; i < 3; i++ {
+		prefetch, changed := controller.Observe(2 * time.Second)
+		if !changed || prefetch >= previous {
+			t.Fatalf("Expected slow processing to lower prefetch below %d, got %d (changed=%v)", previous, prefetch, changed)
+		}
+		previous = prefetch
+	}
+
+	for i := 0; i < 10; i++ {
+		controller.Observe(2 * time.Second)
+	}
+	if controller.EffectivePrefetch() != 2 {
+		t.Errorf("Expected prefetch to stop at the minimum of 2, got %d", controller.EffectivePrefetch())
+	}
+}
+
+func TestAdaptivePrefetchController_FallingLatencyRestoresPrefetch(t *testing.T) {
+	controller := newTestPrefetchController(t)
+	for i := 0; i < 10; i++ {
+		controller.Observe(2 * time.Second)
+	}
+	if controller.EffectivePrefetch() != 2 {
+		t.Fatalf("Expected prefetch to drop to 2, got %d", controller.EffectivePrefetch())
+	}
+
+	for i := 0; i < 50; i++ {
+		controller.Observe(5 * time.Millisecond)
+	}
+
+	if controller.EffectivePrefetch() != 20 {
+		t.Errorf("Expected fast processing to restore prefetch to 20, got %d", controller.EffectivePrefetch())
+	}
+	if controller.AverageLatency() >= 50*time.Millisecond {
+		t.Errorf("Expected the average latency to fall below the low threshold, got %s", controller.AverageLatency())
+	}
+}
+
+func TestAdaptivePrefetchController_SteadyLatencyKeepsPrefetch(t *testing.T) {
+	controller := newTestPrefetchController(t)
+
+	for i := 0; i < 20; i++ {
+		if _, changed := controller.Observe(200 * time.Millisecond); changed {
+			t.Fatalf("Expected latency between the thresholds to keep prefetch, changed after %d samples", i+1)
+		}
+	}
+	if controller.EffectivePrefetch() != 20 {
+		t.Errorf("Expected prefetch to stay at 20, got %d", controller.EffectivePrefetch())
+	}
+}
+
+func TestAdaptivePrefetchController_AdjustsOncePerSampleWindow(t *testing.T) {
+	config := DefaultAdaptivePrefetchConfig(20)
+	controller, err := NewAdaptivePrefetchController(20, config)
+	if err != nil {
+		t.Fatalf("Expected valid config, got %v", err)
+	}
+
+	for i := 1; i < config.SamplesPerAdjustment; i++ {
+		if _, changed := controller.Observe(2 * time.Second); changed {
+			t.Fatalf("Expected no adjustment before %d samples, changed after %d", config.SamplesPerAdjustment, i)
+		}
+	}
+	if prefetch, changed := controller.Observe(2 * time.Second); !changed || prefetch != 10 {
+		t.Errorf("Expected prefetch halved to 10 after a full window, got %d (changed=%v)", prefetch, changed)
+	}
+}
+
+func TestAdaptivePrefetchConfig_Validate(t *testing.T) {
+	invalid := []*AdaptivePrefetchConfig{
+		{MinPrefetch: 0, MaxPrefetch: 10, HighLatency: time.Second, LowLatency: time.Millisecond, SmoothingFactor: 0.2, SamplesPerAdjustment: 1},
+		{MinPrefetch: 5, MaxPrefetch: 4, HighLatency: time.Second, LowLatency: time.Millisecond, SmoothingFactor: 0.2, SamplesPerAdjustment: 1},
+		{MinPrefetch: 1, MaxPrefetch: 10, HighLatency: time.Millisecond, LowLatency: time.Second, SmoothingFactor: 0.2, SamplesPerAdjustment: 1},
+		{MinPrefetch: 1, MaxPrefetch: 10, HighLatency: time.Second, LowLatency: time.Millisecond, SmoothingFactor: 0, SamplesPerAdjustment: 1},
+		{MinPrefetch: 1, MaxPrefetch: 10, HighLatency: time.Second, LowLatency: time.Millisecond, SmoothingFactor: 0.2, SamplesPerAdjustment: 0},
+	}
+	for i, config := range invalid {
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected config %d to be invalid", i)
+		}
+	}
+	if err := DefaultAdaptivePrefetchConfig(20).Validate(); err != nil {
+		t.Errorf("Expected the default config to be valid, got %v", err)
+	}
+}
+
+type prefetchAdjustingMessageHandler struct {
+	*MockMessageHandler
+	prefetchChanges map[string][]int
+}
+
+func (h *prefetchAdjustingMessageHandler) SetPrefetchCount(queueName string, prefetchCount int) error {
+	h.prefetchChanges[queueName] = append(h.prefetchChanges[queueName], prefetchCount)
+	return nil
+}
+
+type slowPositionMessageHandler struct {
+	delay time.Duration
+}
+
+func (h *slowPositionMessageHandler) HandlePositionUpdateMessage(ctx context.Context, message *PositionUpdateMessage) error {
+	time.Sleep(h.delay)
+	return nil
+}
+
+func TestPositionConsumer_AdaptivePrefetchLowersPrefetchOnSlowProcessing(t *testing.T) {
+	// Arrange
+	consumers := make(map[string]sharedMessaging.MessageConsumer)
+	messageHandler := &prefetchAdjustingMessageHandler{
+		MockMessageHandler: &MockMessageHandler{
+			ConsumeFunc: func(ctx context.Context, queueName string, handler sharedMessaging.MessageConsumer) error {
+				consumers[queueName] = handler
+				return nil
+			},
+		},
+		prefetchChanges: make(map[string][]int),
+	}
+
+	config := DefaultPositionConsumerConfig()
+	config.AdaptivePrefetch = &AdaptivePrefetchConfig{
+		MinPrefetch:          1,
+		MaxPrefetch:          config.PrefetchCount,
+		HighLatency:          5 * time.Millisecond,
+		LowLatency:           time.Millisecond,
+		SmoothingFactor:      1,
+		SamplesPerAdjustment: 1,
+	}
+
+	consumer := NewPositionConsumer(messageHandler, messaging.NewPositionQueueManager(messageHandler), &slowPositionMessageHandler{delay: 20 * time.Millisecond})
+	if err := consumer.StartConsumers(context.Background(), config); err != nil {
+		t.Fatalf("Expected consumers to start, got: %v", err)
+	}
+
+	message := &sharedMessaging.Message{
+		Body: []byte(`{"order_id":"order-1","user_id":"user-1","symbol":"AAPL","order_side":"BUY","quantity":10,"execution_price":150}`),
+	}
+
+	// Act
+	if err := consumers["positions.updates"].HandleMessage(context.Background(), message); err != nil {
+		t.Fatalf("Expected message to be processed, got: %v", err)
+	}
+
+	// Assert
+	changes := messageHandler.prefetchChanges["positions.updates"]
+	if len(changes) != 1 || changes[0] != config.PrefetchCount/2 {
+		t.Errorf("Expected prefetch lowered to %d, got changes %v", config.PrefetchCount/2, changes)
+	}
+	if consumer.EffectivePrefetch("positions.updates") != config.PrefetchCount/2 {
+		t.Errorf("Expected effective prefetch %d, got %d", config.PrefetchCount/2, consumer.EffectivePrefetch("positions.updates"))
+	}
+	if consumer.EffectivePrefetch("positions.retry") != config.PrefetchCount {
+		t.Errorf("Expected the idle retry queue to keep prefetch %d, got %d", config.PrefetchCount, consumer.EffectivePrefetch("positions.retry"))
+	}
+}
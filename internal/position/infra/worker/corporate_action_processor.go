@@ -0,0 +1,201 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	domain "HubInvestments/internal/position/domain/model"
+	positionRepository "HubInvestments/internal/position/domain/repository"
+	"HubInvestments/shared/infra/messaging"
+
+	"github.com/google/uuid"
+)
+
+// CorporateActionQueueName is the queue the corporate-action feed publishes to
+const CorporateActionQueueName = "positions.corporate_actions"
+
+// ICorporateActionPositionStore defines the position persistence used by corporate actions (dependency inversion)
+type ICorporateActionPositionStore interface {
+	FindActivePositionsBySymbol(ctx context.Context, symbol string) ([]*domain.Position, error)
+	ExistsForUser(ctx context.Context, userID uuid.UUID, symbol string) (bool, error)
+	Update(ctx context.Context, position *domain.Position) error
+	UpdateSymbol(ctx context.Context, positionID uuid.UUID, newSymbol string) error
+}
+
+type CorporateActionProcessorConfig struct {
+	QuantityPrecision int // Decimal places kept on quantities after a split
+	PricePrecision    int // Decimal places kept on average prices after a split
+}
+
+func DefaultCorporateActionProcessorConfig() *CorporateActionProcessorConfig {
+	return &CorporateActionProcessorConfig{
+		QuantityPrecision: domain.DefaultQuantityPrecision,
+		PricePrecision:    domain.DefaultPricePrecision,
+	}
+}
+
+// CorporateActionResult summarizes how a corporate action was applied
+type CorporateActionResult struct {
+	ActionID        string
+	Type            domain.CorporateActionType
+	Symbol          string
+	Adjusted        int
+	AlreadyApplied  int
+	Failed          int
+	TotalCashAmount float64
+	Errors          []string
+}
+
+// CorporateActionProcessor adjusts every active position in the affected symbol and records a
+// ledger entry per position. The ledger entry is written last and doubles as the idempotency
+// key, so a redelivered action skips the positions it already adjusted.
+type CorporateActionProcessor struct {
+	positionStore ICorporateActionPositionStore
+	ledgerRepo    positionRepository.IPositionLedgerRepository
+	config        *CorporateActionProcessorConfig
+}
+
+func NewCorporateActionProcessor(
+	positionStore ICorporateActionPositionStore,
+	ledgerRepo positionRepository.IPositionLedgerRepository,
+	config *CorporateActionProcessorConfig,
+) *CorporateActionProcessor {
+	if config == nil {
+		config = DefaultCorporateActionProcessorConfig()
+	}
+
+	return &CorporateActionProcessor{
+		positionStore: positionStore,
+		ledgerRepo:    ledgerRepo,
+		config:        config,
+	}
+}
+
+// HandleMessage implements messaging.MessageConsumer for the corporate-action feed.
+// Returning an error requeues the message; positions already adjusted are skipped on redelivery.
+func (p *CorporateActionProcessor) HandleMessage(ctx context.Context, message *messaging.Message) error {
+	var action domain.CorporateAction
+	if err := json.Unmarshal(message.Body, &action); err != nil {
+		return fmt.Errorf("failed to deserialize corporate action: %w", err)
+	}
+
+	result, err := p.Process(ctx, &action)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Corporate action %s (%s %s): adjusted %d, already applied %d, failed %d",
+		result.ActionID, result.Type, result.Symbol, result.Adjusted, result.AlreadyApplied, result.Failed)
+
+	if result.Failed > 0 {
+		return fmt.Errorf("corporate action %s failed for %d positions: %s",
+			result.ActionID, result.Failed, strings.Join(result.Errors, "; "))
+	}
+	return nil
+}
+
+// Process applies the corporate action to every active position in its symbol
+func (p *CorporateActionProcessor) Process(ctx context.Context, action *domain.CorporateAction) (*CorporateActionResult, error) {
+	if action == nil {
+		return nil, fmt.Errorf("corporate action cannot be nil")
+	}
+
+	if err := action.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid corporate action: %w", err)
+	}
+
+	positions, err := p.positionStore.FindActivePositionsBySymbol(ctx, action.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load positions for %s: %w", action.Symbol, err)
+	}
+
+	result := &CorporateActionResult{
+		ActionID: action.ID,
+		Type:     action.Type,
+		Symbol:   action.Symbol,
+		Errors:   make([]string, 0),
+	}
+
+	for _, position := range positions {
+		applied, err := p.alreadyApplied(ctx, position, action)
+		if err != nil {
+			p.recordFailure(result, position, err)
+			continue
+		}
+		if applied {
+			result.AlreadyApplied++
+			continue
+		}
+
+		entry, err := p.applyToPosition(ctx, position, action)
+		if err != nil {
+			p.recordFailure(result, position, err)
+			continue
+		}
+
+		result.Adjusted++
+		result.TotalCashAmount += entry.CashAmount
+	}
+
+	return result, nil
+}
+
+func (p *CorporateActionProcessor) alreadyApplied(ctx context.Context, position *domain.Position, action *domain.CorporateAction) (bool, error) {
+	applied, err := p.ledgerRepo.ExistsForCorporateAction(ctx, position.ID, action.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check ledger: %w", err)
+	}
+	return applied, nil
+}
+
+func (p *CorporateActionProcessor) applyToPosition(ctx context.Context, position *domain.Position, action *domain.CorporateAction) (*domain.PositionLedgerEntry, error) {
+	previousQuantity := position.Quantity
+	previousAveragePrice := position.AveragePrice
+
+	switch action.Type {
+	case domain.CorporateActionTypeSplit:
+		if err := position.ApplySplit(action.SplitRatio(), p.config.QuantityPrecision, p.config.PricePrecision); err != nil {
+			return nil, err
+		}
+		if err := p.positionStore.Update(ctx, position); err != nil {
+			return nil, fmt.Errorf("failed to update position: %w", err)
+		}
+
+	case domain.CorporateActionTypeCashDividend:
+		// Cash dividends are paid out; quantity and cost basis stay as they are
+
+	case domain.CorporateActionTypeSymbolChange:
+		exists, err := p.positionStore.ExistsForUser(ctx, position.UserID, action.NewSymbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check position in %s: %w", action.NewSymbol, err)
+		}
+		if exists {
+			return nil, fmt.Errorf("user already holds a position in %s", action.NewSymbol)
+		}
+		if err := position.ChangeSymbol(action.NewSymbol); err != nil {
+			return nil, err
+		}
+		if err := p.positionStore.UpdateSymbol(ctx, position.ID, action.NewSymbol); err != nil {
+			return nil, fmt.Errorf("failed to update position symbol: %w", err)
+		}
+	}
+
+	entry, err := domain.NewCorporateActionLedgerEntry(position, action, previousQuantity, previousAveragePrice)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ledgerRepo.Save(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save ledger entry: %w", err)
+	}
+
+	position.ClearEvents()
+	return entry, nil
+}
+
+func (p *CorporateActionProcessor) recordFailure(result *CorporateActionResult, position *domain.Position, err error) {
+	result.Failed++
+	result.Errors = append(result.Errors, fmt.Sprintf("position %s: %v", position.ID, err))
+}
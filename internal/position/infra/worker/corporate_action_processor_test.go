@@ -0,0 +1,260 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/shared/infra/messaging"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryCorporateActionPositionStore struct {
+	positions []*domain.Position
+	updated   int
+}
+
+func (s *InMemoryCorporateActionPositionStore) FindActivePositionsBySymbol(ctx context.Context, symbol string) ([]*domain.Position, error) {
+	result := make([]*domain.Position, 0)
+	for _, position := range s.positions {
+		if position.Symbol == symbol && position.Status.CanBeUpdated() {
+			result = append(result, position)
+		}
+	}
+	return result, nil
+}
+
+func (s *InMemoryCorporateActionPositionStore) ExistsForUser(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+	for _, position := range s.positions {
+		if position.UserID == userID && position.Symbol == symbol {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *InMemoryCorporateActionPositionStore) Update(ctx context.Context, position *domain.Position) error {
+	s.updated++
+	return nil
+}
+
+func (s *InMemoryCorporateActionPositionStore) UpdateSymbol(ctx context.Context, positionID uuid.UUID, newSymbol string) error {
+	s.updated++
+	return nil
+}
+
+type InMemoryLedgerRepository struct {
+	entries []*domain.PositionLedgerEntry
+}
+
+func (r *InMemoryLedgerRepository) Save(ctx context.Context, entry *domain.PositionLedgerEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *InMemoryLedgerRepository) ExistsForCorporateAction(ctx context.Context, positionID uuid.UUID, corporateActionID string) (bool, error) {
+	for _, entry := range r.entries {
+		if entry.PositionID == positionID && entry.CorporateActionID == corporateActionID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *InMemoryLedgerRepository) FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionLedgerEntry, error) {
+	result := make([]*domain.PositionLedgerEntry, 0)
+	for _, entry := range r.entries {
+		if entry.PositionID == positionID {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func newCorporateActionPosition(t *testing.T, symbol string, quantity, price float64) *domain.Position {
+	position, err := domain.NewPosition(uuid.New(), symbol, quantity, price, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("failed to create position: %v", err)
+	}
+	return position
+}
+
+var corporateActionDate = time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+func TestCorporateActionProcessor_StockSplit(t *testing.T) {
+	// Arrange
+	aapl := newCorporateActionPosition(t, "AAPL", 10, 150)
+	msft := newCorporateActionPosition(t, "MSFT", 5, 300)
+	store := &InMemoryCorporateActionPositionStore{positions: []*domain.Position{aapl, msft}}
+	ledger := &InMemoryLedgerRepository{}
+	processor := NewCorporateActionProcessor(store, ledger, nil)
+
+	action := &domain.CorporateAction{
+		ID: "split-aapl-2024", Type: domain.CorporateActionTypeSplit, Symbol: "AAPL",
+		SplitTo: 2, SplitFrom: 1, EffectiveDate: corporateActionDate,
+	}
+
+	// Act
+	result, err := processor.Process(context.Background(), action)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Adjusted != 1 || result.Failed != 0 {
+		t.Errorf("Expected 1 adjusted and 0 failed, got %d and %d", result.Adjusted, result.Failed)
+	}
+	if aapl.Quantity != 20 || aapl.AveragePrice != 75 {
+		t.Errorf("Expected AAPL 20 @ 75 after split, got %v @ %v", aapl.Quantity, aapl.AveragePrice)
+	}
+	if aapl.TotalInvestment != 1500 {
+		t.Errorf("Expected cost basis 1500 to be unchanged, got %v", aapl.TotalInvestment)
+	}
+	if msft.Quantity != 5 || msft.AveragePrice != 300 {
+		t.Errorf("Expected MSFT to be untouched, got %v @ %v", msft.Quantity, msft.AveragePrice)
+	}
+	if store.updated != 1 || len(ledger.entries) != 1 {
+		t.Fatalf("Expected 1 update and 1 ledger entry, got %d and %d", store.updated, len(ledger.entries))
+	}
+	if ledger.entries[0].EntryType != domain.PositionLedgerEntryTypeSplit || ledger.entries[0].PreviousQuantity != 10 {
+		t.Errorf("Expected split ledger entry from 10 shares, got %+v", ledger.entries[0])
+	}
+
+	// Redelivering the same action does not split again
+	second, err := processor.Process(context.Background(), action)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if second.AlreadyApplied != 1 || second.Adjusted != 0 || aapl.Quantity != 20 {
+		t.Errorf("Expected redelivered split to be skipped, got %+v and quantity %v", second, aapl.Quantity)
+	}
+}
+
+func TestCorporateActionProcessor_ReverseSplit(t *testing.T) {
+	position := newCorporateActionPosition(t, "PETR4", 25, 4)
+	processor := NewCorporateActionProcessor(&InMemoryCorporateActionPositionStore{positions: []*domain.Position{position}}, &InMemoryLedgerRepository{}, nil)
+
+	_, err := processor.Process(context.Background(), &domain.CorporateAction{
+		ID: "reverse-split-petr4", Type: domain.CorporateActionTypeSplit, Symbol: "PETR4",
+		SplitTo: 1, SplitFrom: 10, EffectiveDate: corporateActionDate,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if position.Quantity != 2.5 || position.AveragePrice != 40 {
+		t.Errorf("Expected 2.5 @ 40 after 1:10 reverse split, got %v @ %v", position.Quantity, position.AveragePrice)
+	}
+}
+
+func TestCorporateActionProcessor_CashDividend(t *testing.T) {
+	// Arrange
+	position := newCorporateActionPosition(t, "KO", 40, 60)
+	store := &InMemoryCorporateActionPositionStore{positions: []*domain.Position{position}}
+	ledger := &InMemoryLedgerRepository{}
+	processor := NewCorporateActionProcessor(store, ledger, nil)
+
+	// Act
+	result, err := processor.Process(context.Background(), &domain.CorporateAction{
+		ID: "div-ko-q2", Type: domain.CorporateActionTypeCashDividend, Symbol: "KO",
+		DividendPerShare: 0.485, EffectiveDate: corporateActionDate,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if position.Quantity != 40 || position.AveragePrice != 60 || position.TotalInvestment != 2400 {
+		t.Errorf("Expected quantity and cost basis unchanged, got %v @ %v (%v)", position.Quantity, position.AveragePrice, position.TotalInvestment)
+	}
+	if store.updated != 0 {
+		t.Errorf("Expected no position update for a cash dividend, got %d", store.updated)
+	}
+	if len(ledger.entries) != 1 {
+		t.Fatalf("Expected 1 ledger entry, got %d", len(ledger.entries))
+	}
+
+	entry := ledger.entries[0]
+	if entry.EntryType != domain.PositionLedgerEntryTypeCashDividend || entry.PositionID != position.ID {
+		t.Errorf("Expected cash dividend entry for the position, got %+v", entry)
+	}
+	if math.Abs(entry.CashAmount-19.4) > 1e-9 || math.Abs(result.TotalCashAmount-19.4) > 1e-9 {
+		t.Errorf("Expected cash amount 19.4, got %v (total %v)", entry.CashAmount, result.TotalCashAmount)
+	}
+	if !entry.EffectiveDate.Equal(corporateActionDate) {
+		t.Errorf("Expected ledger entry on the effective date, got %v", entry.EffectiveDate)
+	}
+}
+
+func TestCorporateActionProcessor_SymbolChange(t *testing.T) {
+	// Arrange
+	renamed := newCorporateActionPosition(t, "FB", 12, 200)
+	conflicting := newCorporateActionPosition(t, "FB", 3, 180)
+	alreadyHeld := newCorporateActionPosition(t, "META", 1, 300)
+	alreadyHeld.UserID = conflicting.UserID
+	store := &InMemoryCorporateActionPositionStore{positions: []*domain.Position{renamed, conflicting, alreadyHeld}}
+	ledger := &InMemoryLedgerRepository{}
+	processor := NewCorporateActionProcessor(store, ledger, nil)
+
+	// Act
+	result, err := processor.Process(context.Background(), &domain.CorporateAction{
+		ID: "fb-to-meta", Type: domain.CorporateActionTypeSymbolChange, Symbol: "FB",
+		NewSymbol: "META", EffectiveDate: corporateActionDate,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Adjusted != 1 || result.Failed != 1 {
+		t.Errorf("Expected 1 adjusted and 1 failed, got %d and %d", result.Adjusted, result.Failed)
+	}
+	if renamed.Symbol != "META" || renamed.Quantity != 12 || renamed.AveragePrice != 200 {
+		t.Errorf("Expected META 12 @ 200, got %s %v @ %v", renamed.Symbol, renamed.Quantity, renamed.AveragePrice)
+	}
+	if conflicting.Symbol != "FB" {
+		t.Errorf("Expected conflicting position to keep its symbol, got %s", conflicting.Symbol)
+	}
+	if len(ledger.entries) != 1 || ledger.entries[0].EntryType != domain.PositionLedgerEntryTypeSymbolChange {
+		t.Fatalf("Expected 1 symbol change ledger entry, got %d", len(ledger.entries))
+	}
+	if ledger.entries[0].Symbol != "META" {
+		t.Errorf("Expected ledger entry under the new symbol, got %s", ledger.entries[0].Symbol)
+	}
+}
+
+func TestCorporateActionProcessor_HandleMessage(t *testing.T) {
+	position := newCorporateActionPosition(t, "AAPL", 10, 150)
+	processor := NewCorporateActionProcessor(&InMemoryCorporateActionPositionStore{positions: []*domain.Position{position}}, &InMemoryLedgerRepository{}, nil)
+
+	body, err := json.Marshal(domain.CorporateAction{
+		ID: "split-aapl-4-1", Type: domain.CorporateActionTypeSplit, Symbol: "AAPL",
+		SplitTo: 4, SplitFrom: 1, EffectiveDate: corporateActionDate,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal action: %v", err)
+	}
+
+	if err := processor.HandleMessage(context.Background(), &messaging.Message{Body: body}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if position.Quantity != 40 || position.AveragePrice != 37.5 {
+		t.Errorf("Expected 40 @ 37.5 after 4:1 split, got %v @ %v", position.Quantity, position.AveragePrice)
+	}
+}
+
+func TestCorporateActionProcessor_InvalidAction(t *testing.T) {
+	processor := NewCorporateActionProcessor(&InMemoryCorporateActionPositionStore{}, &InMemoryLedgerRepository{}, nil)
+
+	_, err := processor.Process(context.Background(), &domain.CorporateAction{
+		ID: "bad-split", Type: domain.CorporateActionTypeSplit, Symbol: "AAPL", EffectiveDate: corporateActionDate,
+	})
+
+	if err == nil {
+		t.Error("Expected error for split without a ratio")
+	}
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"HubInvestments/shared/infra/database"
+)
+
+// executedOrderRow is an executed order as stored by the order management system
+type executedOrderRow struct {
+	ID             string    `db:"id"`
+	UserID         int       `db:"user_id"`
+	Symbol         string    `db:"symbol"`
+	OrderSide      string    `db:"order_side"`
+	OrderType      string    `db:"order_type"`
+	Quantity       float64   `db:"quantity"`
+	ExecutionPrice float64   `db:"execution_price"`
+	ExecutedAt     time.Time `db:"executed_at"`
+}
+
+// OrderTableEventSource reads executed-order events straight from the orders table
+type OrderTableEventSource struct {
+	db database.Database
+}
+
+func NewOrderTableEventSource(db database.Database) *OrderTableEventSource {
+	return &OrderTableEventSource{db: db}
+}
+
+func (s *OrderTableEventSource) FindExecutedOrderEventsSince(ctx context.Context, since time.Time) ([]*PositionUpdateMessage, error) {
+	query := `
+		SELECT id, user_id, symbol, order_side, order_type, quantity, execution_price, executed_at
+		FROM orders
+		WHERE status = 'EXECUTED' AND executed_at >= $1 AND execution_price IS NOT NULL
+		ORDER BY executed_at, id`
+
+	var rows []executedOrderRow
+	if err := s.db.Select(&rows, query, since); err != nil {
+		return nil, fmt.Errorf("failed to query executed orders: %w", err)
+	}
+
+	events := make([]*PositionUpdateMessage, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, &PositionUpdateMessage{
+			OrderID:        row.ID,
+			UserID:         strconv.Itoa(row.UserID),
+			Symbol:         row.Symbol,
+			OrderSide:      row.OrderSide,
+			OrderType:      row.OrderType,
+			Quantity:       row.Quantity,
+			ExecutionPrice: row.ExecutionPrice,
+			TotalValue:     row.Quantity * row.ExecutionPrice,
+			ExecutedAt:     row.ExecutedAt,
+		})
+	}
+	return events, nil
+}
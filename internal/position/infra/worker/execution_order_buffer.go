@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// executionOrderBuffer holds position updates for a short window so that updates for the same
+// (user, symbol) are applied in execution-time order even when their messages arrive out of
+// order. An update proceeds once its window has passed and no update for the same position that
+// executed earlier is still buffered or being applied. Updates arriving after the window of a
+// later execution has closed are applied as they come.
+type executionOrderBuffer struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	queues  map[string]*executionQueue
+	arrival uint64
+}
+
+type executionQueue struct {
+	updates []*bufferedUpdate
+	changed chan struct{} // Closed and replaced whenever an update leaves the queue
+}
+
+type bufferedUpdate struct {
+	executedAt time.Time
+	arrival    uint64 // Breaks ties between updates executed at the same time
+}
+
+func newExecutionOrderBuffer(window time.Duration) *executionOrderBuffer {
+	return &executionOrderBuffer{
+		window: window,
+		queues: make(map[string]*executionQueue),
+	}
+}
+
+// wait blocks until the update may be applied or the context is done.
+// On success the returned function must be called once the update was applied.
+func (b *executionOrderBuffer) wait(ctx context.Context, userID, symbol string, executedAt time.Time) (func(), error) {
+	key := positionLockKey(userID, symbol)
+
+	b.mu.Lock()
+	queue, exists := b.queues[key]
+	if !exists {
+		queue = &executionQueue{changed: make(chan struct{})}
+		b.queues[key] = queue
+	}
+	b.arrival++
+	update := &bufferedUpdate{executedAt: executedAt, arrival: b.arrival}
+	queue.updates = append(queue.updates, update)
+	b.mu.Unlock()
+
+	release := func() { b.leave(key, queue, update) }
+
+	timer := time.NewTimer(b.window)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+
+	for {
+		b.mu.Lock()
+		if queue.isNext(update) {
+			b.mu.Unlock()
+			return release, nil
+		}
+		changed := queue.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (b *executionOrderBuffer) leave(key string, queue *executionQueue, update *bufferedUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, queued := range queue.updates {
+		if queued == update {
+			queue.updates = append(queue.updates[:i], queue.updates[i+1:]...)
+			break
+		}
+	}
+	close(queue.changed)
+	queue.changed = make(chan struct{})
+
+	if len(queue.updates) == 0 {
+		delete(b.queues, key)
+	}
+}
+
+// isNext reports whether no other queued update executed before this one
+func (q *executionQueue) isNext(update *bufferedUpdate) bool {
+	for _, queued := range q.updates {
+		if queued == update {
+			continue
+		}
+		if queued.executedAt.Before(update.executedAt) ||
+			(queued.executedAt.Equal(update.executedAt) && queued.arrival < update.arrival) {
+			return false
+		}
+	}
+	return true
+}
+
+// size returns the number of positions with buffered updates
+func (b *executionOrderBuffer) size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.queues)
+}
@@ -0,0 +1,200 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"HubInvestments/internal/position/application/command"
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// simulatedPositionStore applies position use cases to a single in-memory position, including
+// the weighted average price of buys and closing on a full sell
+type simulatedPositionStore struct {
+	mu       sync.Mutex
+	position *domain.Position
+}
+
+func (s *simulatedPositionStore) snapshot() *domain.Position {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.position == nil {
+		return nil
+	}
+	copied := *s.position
+	return &copied
+}
+
+func (s *simulatedPositionStore) newWorker(window time.Duration) *PositionUpdateWorker {
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.position = &domain.Position{
+				ID:              uuid.New(),
+				UserID:          uuid.MustParse(cmd.UserID),
+				Symbol:          cmd.Symbol,
+				Quantity:        cmd.Quantity,
+				AveragePrice:    cmd.Price,
+				TotalInvestment: cmd.Quantity * cmd.Price,
+				Status:          domain.PositionStatusActive,
+			}
+			return &command.CreatePositionResult{PositionID: s.position.ID.String()}, nil
+		},
+	}
+	updateUC := &MockUpdatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.UpdatePositionCommand) (*command.UpdatePositionResult, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if cmd.IsBuyOrder {
+				s.position.TotalInvestment += cmd.TradeQuantity * cmd.TradePrice
+				s.position.Quantity += cmd.TradeQuantity
+				s.position.AveragePrice = s.position.TotalInvestment / s.position.Quantity
+			} else {
+				s.position.Quantity -= cmd.TradeQuantity
+				s.position.TotalInvestment = s.position.Quantity * s.position.AveragePrice
+			}
+			return &command.UpdatePositionResult{PositionID: cmd.PositionID, NewQuantity: s.position.Quantity}, nil
+		},
+	}
+	closeUC := &MockClosePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.ClosePositionCommand) (*command.ClosePositionResult, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.position.Quantity = 0
+			s.position.TotalInvestment = 0
+			s.position.Status = domain.PositionStatusClosed
+			return &command.ClosePositionResult{UpdatePositionResult: &command.UpdatePositionResult{PositionID: cmd.PositionID}}, nil
+		},
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			position := s.snapshot()
+			return position != nil && position.Status == domain.PositionStatusActive, nil
+		},
+		FindByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]*domain.Position, error) {
+			if position := s.snapshot(); position != nil {
+				return []*domain.Position{position}, nil
+			}
+			return []*domain.Position{}, nil
+		},
+		FindByUserIDAndSymbolFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error) {
+			return s.snapshot(), nil
+		},
+	}
+
+	config := DefaultPositionWorkerConfig("test-worker")
+	config.ExecutionOrderWindow = window
+	config.PositionConsistencyTimeout = 0
+	return NewPositionUpdateWorker("test-worker", createUC, updateUC, closeUC, positionRepo, &MockMessageHandler{}, config)
+}
+
+// buySellPair returns a buy of 100 at 20 followed a second later by a sell of 150 at 25
+func buySellPair(userID uuid.UUID) (*PositionUpdateMessage, *PositionUpdateMessage) {
+	executedAt := time.Now()
+	buy := &PositionUpdateMessage{
+		OrderID: uuid.New().String(), UserID: userID.String(), Symbol: "AAPL", OrderSide: "BUY",
+		Quantity: 100, ExecutionPrice: 20, ExecutedAt: executedAt,
+		MessageMetadata: PositionUpdateMessageMetadata{MessageID: "msg-buy", Timestamp: time.Now()},
+	}
+	sell := &PositionUpdateMessage{
+		OrderID: uuid.New().String(), UserID: userID.String(), Symbol: "AAPL", OrderSide: "SELL",
+		Quantity: 150, ExecutionPrice: 25, ExecutedAt: executedAt.Add(time.Second),
+		MessageMetadata: PositionUpdateMessageMetadata{MessageID: "msg-sell", Timestamp: time.Now()},
+	}
+	return buy, sell
+}
+
+func newStoreWithPosition(userID uuid.UUID) *simulatedPositionStore {
+	return &simulatedPositionStore{position: &domain.Position{
+		ID: uuid.New(), UserID: userID, Symbol: "AAPL",
+		Quantity: 100, AveragePrice: 10, TotalInvestment: 1000,
+		Status: domain.PositionStatusActive,
+	}}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_ReordersOutOfOrderBuySell(t *testing.T) {
+	userID := uuid.New()
+
+	// In order: 100 @ 10 plus 100 @ 20 is 200 @ 15, selling 150 leaves 50 @ 15
+	inOrder := newStoreWithPosition(userID)
+	inOrderWorker := inOrder.newWorker(50 * time.Millisecond)
+	buy, sell := buySellPair(userID)
+	for _, message := range []*PositionUpdateMessage{buy, sell} {
+		if err := inOrderWorker.processPositionUpdateMessage(context.Background(), message); err != nil {
+			t.Fatalf("Expected in-order update to apply, got %v", err)
+		}
+	}
+
+	// Out of order: the sell message arrives first and the buy shortly after, within the window
+	outOfOrder := newStoreWithPosition(userID)
+	outOfOrderWorker := outOfOrder.newWorker(50 * time.Millisecond)
+	buy, sell = buySellPair(userID)
+	errs := make(chan error, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs <- outOfOrderWorker.processPositionUpdateMessage(context.Background(), sell)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		defer wg.Done()
+		errs <- outOfOrderWorker.processPositionUpdateMessage(context.Background(), buy)
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected out-of-order update to apply, got %v", err)
+		}
+	}
+
+	expected, got := inOrder.snapshot(), outOfOrder.snapshot()
+	if expected.Quantity != 50 || expected.AveragePrice != 15 {
+		t.Fatalf("Expected in-order position of 50 @ 15, got %.2f @ %.2f", expected.Quantity, expected.AveragePrice)
+	}
+	if got.Status != expected.Status || got.Quantity != expected.Quantity || got.AveragePrice != expected.AveragePrice {
+		t.Errorf("Expected out-of-order result %s %.2f @ %.2f, got %s %.2f @ %.2f",
+			expected.Status, expected.Quantity, expected.AveragePrice, got.Status, got.Quantity, got.AveragePrice)
+	}
+	if remaining := outOfOrderWorker.executionOrder.size(); remaining != 0 {
+		t.Errorf("Expected execution order buffer to be empty, %d positions still buffered", remaining)
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_WithoutWindowAppliesInArrivalOrder(t *testing.T) {
+	userID := uuid.New()
+	store := newStoreWithPosition(userID)
+	worker := store.newWorker(0)
+	buy, sell := buySellPair(userID)
+
+	// The sell closes the original position and the late buy opens a new one
+	for _, message := range []*PositionUpdateMessage{sell, buy} {
+		if err := worker.processPositionUpdateMessage(context.Background(), message); err != nil {
+			t.Fatalf("Expected update to apply, got %v", err)
+		}
+	}
+
+	position := store.snapshot()
+	if position.Quantity != 100 || position.AveragePrice != 20 {
+		t.Errorf("Expected arrival-order position of 100 @ 20, got %.2f @ %.2f", position.Quantity, position.AveragePrice)
+	}
+}
+
+func TestExecutionOrderBuffer_WaitHonorsContext(t *testing.T) {
+	buffer := newExecutionOrderBuffer(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := buffer.wait(ctx, "user", "AAPL", time.Now()); err == nil {
+		t.Fatal("Expected wait to stop when the context is done")
+	}
+	if remaining := buffer.size(); remaining != 0 {
+		t.Errorf("Expected cancelled update to leave the buffer, %d positions still buffered", remaining)
+	}
+}
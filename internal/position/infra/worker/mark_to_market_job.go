@@ -0,0 +1,207 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+	positionRepository "HubInvestments/internal/position/domain/repository"
+)
+
+// IActivePositionSource lists active positions across all users
+type IActivePositionSource interface {
+	FindAllActivePositions(ctx context.Context) ([]*domain.Position, error)
+}
+
+// IClosePriceProvider returns the official closing price of a symbol for a trading day
+type IClosePriceProvider interface {
+	GetClosePrice(ctx context.Context, symbol string, tradingDay time.Time) (float64, error)
+}
+
+// ITradingCalendar decides which days are trading days and when the market closes
+type ITradingCalendar interface {
+	IsTradingDay(day time.Time) bool
+	MarketClose(day time.Time) time.Time
+}
+
+// WeekdayTradingCalendar treats Monday to Friday as trading days with a fixed close time
+type WeekdayTradingCalendar struct {
+	CloseHour   int
+	CloseMinute int
+	Location    *time.Location
+}
+
+func (c *WeekdayTradingCalendar) IsTradingDay(day time.Time) bool {
+	weekday := day.In(c.Location).Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+func (c *WeekdayTradingCalendar) MarketClose(day time.Time) time.Time {
+	local := day.In(c.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), c.CloseHour, c.CloseMinute, 0, 0, c.Location)
+}
+
+type MarkToMarketJobConfig struct {
+	CheckInterval time.Duration // How often the job checks whether the market has closed
+	RunTimeout    time.Duration // Maximum time for a single end-of-day run
+}
+
+// MarkToMarketResult summarizes a single end-of-day run
+type MarkToMarketResult struct {
+	TradingDay time.Time
+	Valued     int
+	Skipped    int
+	Failed     int
+	Errors     []string
+}
+
+// MarkToMarketJob marks every active position to the closing price once per trading day
+type MarkToMarketJob struct {
+	positionSource IActivePositionSource
+	priceProvider  IClosePriceProvider
+	valuationRepo  positionRepository.IPositionValuationRepository
+	calendar       ITradingCalendar
+	config         *MarkToMarketJobConfig
+	lastRunDay     time.Time
+	mu             sync.Mutex
+	now            func() time.Time
+}
+
+func NewMarkToMarketJob(
+	positionSource IActivePositionSource,
+	priceProvider IClosePriceProvider,
+	valuationRepo positionRepository.IPositionValuationRepository,
+	calendar ITradingCalendar,
+	config *MarkToMarketJobConfig,
+) *MarkToMarketJob {
+	if config == nil {
+		config = DefaultMarkToMarketJobConfig()
+	}
+
+	return &MarkToMarketJob{
+		positionSource: positionSource,
+		priceProvider:  priceProvider,
+		valuationRepo:  valuationRepo,
+		calendar:       calendar,
+		config:         config,
+		now:            time.Now,
+	}
+}
+
+func DefaultMarkToMarketJobConfig() *MarkToMarketJobConfig {
+	return &MarkToMarketJobConfig{
+		CheckInterval: time.Minute,
+		RunTimeout:    10 * time.Minute,
+	}
+}
+
+// Start checks the trading calendar on every tick and runs once after each market close
+func (j *MarkToMarketJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.runIfMarketClosed(ctx)
+		}
+	}
+}
+
+func (j *MarkToMarketJob) runIfMarketClosed(ctx context.Context) {
+	now := j.now()
+	if !j.calendar.IsTradingDay(now) || now.Before(j.calendar.MarketClose(now)) {
+		return
+	}
+
+	// The trading day is the calendar's date, not the server's
+	tradingDay := domain.TruncateToTradingDay(j.calendar.MarketClose(now))
+	j.mu.Lock()
+	alreadyRan := j.lastRunDay.Equal(tradingDay)
+	j.mu.Unlock()
+	if alreadyRan {
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, j.config.RunTimeout)
+	defer cancel()
+
+	result, err := j.Run(runCtx, tradingDay)
+	if err != nil {
+		log.Printf("End-of-day mark-to-market failed for %s: %v", tradingDay.Format("2006-01-02"), err)
+		return
+	}
+
+	log.Printf("End-of-day mark-to-market for %s: valued=%d skipped=%d failed=%d",
+		tradingDay.Format("2006-01-02"), result.Valued, result.Skipped, result.Failed)
+
+	// Failed positions are retried on the next tick; those already valued are skipped
+	if result.Failed > 0 {
+		return
+	}
+
+	j.mu.Lock()
+	j.lastRunDay = tradingDay
+	j.mu.Unlock()
+}
+
+// Run marks every active position to the close price for the trading day.
+// Positions already valued for that day are skipped, so running it again is safe.
+func (j *MarkToMarketJob) Run(ctx context.Context, tradingDay time.Time) (*MarkToMarketResult, error) {
+	tradingDay = domain.TruncateToTradingDay(tradingDay)
+	result := &MarkToMarketResult{
+		TradingDay: tradingDay,
+		Errors:     make([]string, 0),
+	}
+
+	positions, err := j.positionSource.FindAllActivePositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active positions: %w", err)
+	}
+
+	closePrices := make(map[string]float64)
+	for _, position := range positions {
+		exists, err := j.valuationRepo.ExistsForTradingDay(ctx, position.ID, tradingDay)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("position %s: %v", position.ID, err))
+			continue
+		}
+		if exists {
+			result.Skipped++
+			continue
+		}
+
+		closePrice, ok := closePrices[position.Symbol]
+		if !ok {
+			closePrice, err = j.priceProvider.GetClosePrice(ctx, position.Symbol, tradingDay)
+			if err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("position %s: failed to get close price for %s: %v", position.ID, position.Symbol, err))
+				continue
+			}
+			closePrices[position.Symbol] = closePrice
+		}
+
+		valuation, err := domain.NewPositionValuation(position, closePrice, tradingDay)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("position %s: %v", position.ID, err))
+			continue
+		}
+
+		if err := j.valuationRepo.Save(ctx, valuation); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("position %s: %v", position.ID, err))
+			continue
+		}
+		result.Valued++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,205 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type MockActivePositionSource struct {
+	positions []*domain.Position
+}
+
+func (m *MockActivePositionSource) FindAllActivePositions(ctx context.Context) ([]*domain.Position, error) {
+	return m.positions, nil
+}
+
+type MockClosePriceProvider struct {
+	prices map[string]float64
+	errors map[string]error
+}
+
+func (m *MockClosePriceProvider) GetClosePrice(ctx context.Context, symbol string, tradingDay time.Time) (float64, error) {
+	if err := m.errors[symbol]; err != nil {
+		return 0, err
+	}
+	return m.prices[symbol], nil
+}
+
+type InMemoryValuationRepository struct {
+	valuations []*domain.PositionValuation
+}
+
+func (r *InMemoryValuationRepository) Save(ctx context.Context, valuation *domain.PositionValuation) error {
+	r.valuations = append(r.valuations, valuation)
+	return nil
+}
+
+func (r *InMemoryValuationRepository) ExistsForTradingDay(ctx context.Context, positionID uuid.UUID, tradingDay time.Time) (bool, error) {
+	for _, v := range r.valuations {
+		if v.PositionID == positionID && v.TradingDay.Equal(domain.TruncateToTradingDay(tradingDay)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *InMemoryValuationRepository) FindByTradingDay(ctx context.Context, tradingDay time.Time) ([]*domain.PositionValuation, error) {
+	result := make([]*domain.PositionValuation, 0)
+	for _, v := range r.valuations {
+		if v.TradingDay.Equal(domain.TruncateToTradingDay(tradingDay)) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemoryValuationRepository) FindByPositionID(ctx context.Context, positionID uuid.UUID) ([]*domain.PositionValuation, error) {
+	result := make([]*domain.PositionValuation, 0)
+	for _, v := range r.valuations {
+		if v.PositionID == positionID {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func newMarkToMarketTestJob(t *testing.T) (*MarkToMarketJob, *InMemoryValuationRepository, []*domain.Position) {
+	userID := uuid.New()
+	aapl, err := domain.NewPosition(userID, "AAPL", 10, 150.0, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("failed to create position: %v", err)
+	}
+	msft, err := domain.NewPosition(userID, "MSFT", 5, 300.0, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("failed to create position: %v", err)
+	}
+	positions := []*domain.Position{aapl, msft}
+
+	repo := &InMemoryValuationRepository{}
+	job := NewMarkToMarketJob(
+		&MockActivePositionSource{positions: positions},
+		&MockClosePriceProvider{prices: map[string]float64{"AAPL": 160.0, "MSFT": 290.0}},
+		repo,
+		&WeekdayTradingCalendar{CloseHour: 17, Location: time.UTC},
+		nil,
+	)
+	return job, repo, positions
+}
+
+func TestMarkToMarketJob_Run_IsIdempotentPerTradingDay(t *testing.T) {
+	job, repo, positions := newMarkToMarketTestJob(t)
+	tradingDay := time.Date(2024, 3, 15, 17, 30, 0, 0, time.UTC)
+
+	first, err := job.Run(context.Background(), tradingDay)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, err := job.Run(context.Background(), tradingDay)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if first.Valued != len(positions) {
+		t.Errorf("Expected first run to value %d positions, got %d", len(positions), first.Valued)
+	}
+	if second.Valued != 0 || second.Skipped != len(positions) {
+		t.Errorf("Expected second run to skip all positions, got valued=%d skipped=%d", second.Valued, second.Skipped)
+	}
+
+	valuations, _ := repo.FindByTradingDay(context.Background(), tradingDay)
+	if len(valuations) != len(positions) {
+		t.Errorf("Expected %d valuations for the day, got %d", len(positions), len(valuations))
+	}
+	for _, position := range positions {
+		byPosition, _ := repo.FindByPositionID(context.Background(), position.ID)
+		if len(byPosition) != 1 {
+			t.Errorf("Expected exactly one valuation for %s, got %d", position.Symbol, len(byPosition))
+		}
+	}
+}
+
+func TestMarkToMarketJob_Run_UsesClosePrice(t *testing.T) {
+	job, repo, _ := newMarkToMarketTestJob(t)
+	tradingDay := time.Date(2024, 3, 15, 17, 30, 0, 0, time.UTC)
+
+	if _, err := job.Run(context.Background(), tradingDay); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]struct {
+		closePrice    float64
+		marketValue   float64
+		unrealizedPnL float64
+	}{
+		"AAPL": {closePrice: 160.0, marketValue: 1600.0, unrealizedPnL: 100.0},
+		"MSFT": {closePrice: 290.0, marketValue: 1450.0, unrealizedPnL: -50.0},
+	}
+
+	for _, valuation := range repo.valuations {
+		want := expected[valuation.Symbol]
+		if valuation.ClosePrice != want.closePrice {
+			t.Errorf("Expected %s close price %.2f, got %.2f", valuation.Symbol, want.closePrice, valuation.ClosePrice)
+		}
+		if valuation.MarketValue != want.marketValue {
+			t.Errorf("Expected %s market value %.2f, got %.2f", valuation.Symbol, want.marketValue, valuation.MarketValue)
+		}
+		if valuation.UnrealizedPnL != want.unrealizedPnL {
+			t.Errorf("Expected %s unrealized PnL %.2f, got %.2f", valuation.Symbol, want.unrealizedPnL, valuation.UnrealizedPnL)
+		}
+		if !valuation.TradingDay.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Expected trading day to be truncated to date, got %v", valuation.TradingDay)
+		}
+	}
+}
+
+func TestMarkToMarketJob_RunIfMarketClosed_UsesCalendarTradingDay(t *testing.T) {
+	job, repo, positions := newMarkToMarketTestJob(t)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	job.calendar = &WeekdayTradingCalendar{CloseHour: 16, Location: newYork}
+	// Friday 21:00 in New York is already Saturday in UTC
+	job.now = func() time.Time { return time.Date(2024, 3, 16, 1, 0, 0, 0, time.UTC) }
+
+	job.runIfMarketClosed(context.Background())
+
+	if len(repo.valuations) != len(positions) {
+		t.Fatalf("Expected %d valuations, got %d", len(positions), len(repo.valuations))
+	}
+	for _, valuation := range repo.valuations {
+		if valuation.TradingDay.Format("2006-01-02") != "2024-03-15" {
+			t.Errorf("Expected the New York trading day 2024-03-15, got %s", valuation.TradingDay.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestMarkToMarketJob_RunIfMarketClosed_RetriesFailedPositions(t *testing.T) {
+	job, repo, positions := newMarkToMarketTestJob(t)
+	provider := job.priceProvider.(*MockClosePriceProvider)
+	provider.errors = map[string]error{"MSFT": errors.New("quote unavailable")}
+	job.now = func() time.Time { return time.Date(2024, 3, 15, 17, 30, 0, 0, time.UTC) }
+
+	job.runIfMarketClosed(context.Background())
+	if len(repo.valuations) != 1 {
+		t.Fatalf("Expected only AAPL to be valued, got %d valuations", len(repo.valuations))
+	}
+	if !job.lastRunDay.IsZero() {
+		t.Errorf("Expected the day not to be marked done while a position failed, got %v", job.lastRunDay)
+	}
+
+	provider.errors = nil
+	job.runIfMarketClosed(context.Background())
+	if len(repo.valuations) != len(positions) {
+		t.Errorf("Expected the failed position to be valued on retry, got %d valuations", len(repo.valuations))
+	}
+	if !job.lastRunDay.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the day to be marked done once every position succeeded, got %v", job.lastRunDay)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
@@ -20,19 +21,24 @@ type PositionConsumer struct {
 	messageHandler  sharedMessaging.MessageHandler
 	positionHandler PositionMessageHandler
 	activeQueues    map[string]bool
+	prefetchTuners  map[string]*AdaptivePrefetchController
 	consumersMutex  sync.RWMutex
 	shutdownChan    chan struct{}
 	shutdownOnce    sync.Once
 	isRunning       bool
 	runningMutex    sync.RWMutex
+	config          *PositionConsumerConfig
 }
 
 type PositionConsumerConfig struct {
-	ConcurrentWorkers int           // Number of concurrent message processors per queue
-	PrefetchCount     int           // Number of messages to prefetch
-	RequeueOnError    bool          // Whether to requeue messages on processing errors
-	RetryDelay        time.Duration // Delay before retrying failed messages
-	MaxRetries        int           // Maximum number of retry attempts
+	ConcurrentWorkers        int           // Number of concurrent message processors per queue
+	PrefetchCount            int           // Number of messages to prefetch
+	RequeueOnError           bool          // Whether to requeue messages on processing errors
+	RetryDelay               time.Duration // Delay before retrying failed messages
+	MaxRetries               int           // Maximum number of retry attempts
+	DeadLetterPoisonMessages bool          // Route undecodable messages straight to the DLQ instead of redelivering them
+
+	AdaptivePrefetch *AdaptivePrefetchConfig // Tune prefetch from processing latency, starting at PrefetchCount (nil keeps it static)
 }
 
 func DefaultPositionConsumerConfig() *PositionConsumerConfig {
@@ -42,6 +48,8 @@ func DefaultPositionConsumerConfig() *PositionConsumerConfig {
 		RequeueOnError:    true,
 		RetryDelay:        2 * time.Second, // Faster retry for position consistency
 		MaxRetries:        4,               // Same as position queue config
+
+		DeadLetterPoisonMessages: true, // A message that fails to decode will never succeed on redelivery
 	}
 }
 
@@ -55,6 +63,7 @@ func NewPositionConsumer(
 		messageHandler:  messageHandler,
 		positionHandler: positionHandler,
 		activeQueues:    make(map[string]bool),
+		prefetchTuners:  make(map[string]*AdaptivePrefetchController),
 		shutdownChan:    make(chan struct{}),
 	}
 }
@@ -71,6 +80,7 @@ func (pc *PositionConsumer) StartConsumers(ctx context.Context, config *Position
 		config = DefaultPositionConsumerConfig()
 	}
 
+	pc.config = config
 	queueNames := pc.queueManager.GetQueueNames()
 
 	// Start consumer for position updates queue (main position processing)
@@ -130,16 +140,28 @@ func (pc *PositionConsumer) GetActiveQueues() []string {
 	return activeQueues
 }
 
+// EffectivePrefetch returns the prefetch adaptive tuning currently uses for the queue, or the
+// static PrefetchCount when tuning is off
+func (pc *PositionConsumer) EffectivePrefetch(queueName string) int {
+	pc.consumersMutex.RLock()
+	tuner := pc.prefetchTuners[queueName]
+	pc.consumersMutex.RUnlock()
+
+	if tuner != nil {
+		return tuner.EffectivePrefetch()
+	}
+	if pc.config != nil {
+		return pc.config.PrefetchCount
+	}
+	return 0
+}
+
 func (pc *PositionConsumer) startQueueConsumer(
 	ctx context.Context,
 	queueName string,
 	config *PositionConsumerConfig,
 	messageProcessor func(context.Context, []byte, map[string]interface{}) error,
 ) error {
-	pc.consumersMutex.Lock()
-	pc.activeQueues[queueName] = true
-	pc.consumersMutex.Unlock()
-
 	// Create a message consumer for this specific queue
 	consumer := &PositionMessageConsumer{
 		queueName:        queueName,
@@ -148,6 +170,25 @@ func (pc *PositionConsumer) startQueueConsumer(
 		shutdownChan:     pc.shutdownChan,
 	}
 
+	if config.AdaptivePrefetch != nil {
+		tuner, err := NewAdaptivePrefetchController(config.PrefetchCount, config.AdaptivePrefetch)
+		if err != nil {
+			return err
+		}
+		consumer.prefetchTuner = tuner
+		consumer.prefetchAdjuster, _ = pc.messageHandler.(sharedMessaging.PrefetchAdjuster)
+		if consumer.prefetchAdjuster == nil {
+			log.Printf("Message handler cannot change prefetch, queue %s keeps its static prefetch", queueName)
+		}
+	}
+
+	pc.consumersMutex.Lock()
+	pc.activeQueues[queueName] = true
+	if consumer.prefetchTuner != nil {
+		pc.prefetchTuners[queueName] = consumer.prefetchTuner
+	}
+	pc.consumersMutex.Unlock()
+
 	// Start consuming from the queue
 	if err := pc.messageHandler.Consume(ctx, queueName, consumer); err != nil {
 		pc.consumersMutex.Lock()
@@ -164,6 +205,8 @@ type PositionMessageConsumer struct {
 	messageProcessor func(context.Context, []byte, map[string]interface{}) error
 	config           *PositionConsumerConfig
 	shutdownChan     chan struct{}
+	prefetchTuner    *AdaptivePrefetchController
+	prefetchAdjuster sharedMessaging.PrefetchAdjuster
 }
 
 func (pmc *PositionMessageConsumer) HandleMessage(ctx context.Context, message *sharedMessaging.Message) error {
@@ -178,7 +221,9 @@ func (pmc *PositionMessageConsumer) HandleMessage(ctx context.Context, message *
 	processCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	startedAt := time.Now()
 	err := pmc.messageProcessor(processCtx, message.Body, message.Headers)
+	pmc.tunePrefetch(time.Since(startedAt))
 	if err != nil {
 		// Log error but don't return it to avoid automatic requeuing
 		// Our custom retry logic will handle retries
@@ -188,10 +233,30 @@ func (pmc *PositionMessageConsumer) HandleMessage(ctx context.Context, message *
 	return err
 }
 
+// tunePrefetch feeds the processing latency to the adaptive prefetch and applies a changed prefetch
+func (pmc *PositionMessageConsumer) tunePrefetch(latency time.Duration) {
+	if pmc.prefetchTuner == nil {
+		return
+	}
+
+	prefetch, changed := pmc.prefetchTuner.Observe(latency)
+	if !changed || pmc.prefetchAdjuster == nil {
+		return
+	}
+
+	if err := pmc.prefetchAdjuster.SetPrefetchCount(pmc.queueName, prefetch); err != nil {
+		log.Printf("Failed to change prefetch of queue %s to %d: %v", pmc.queueName, prefetch, err)
+		return
+	}
+	log.Printf("Changed prefetch of queue %s to %d (average processing latency %s)",
+		pmc.queueName, prefetch, pmc.prefetchTuner.AverageLatency())
+}
+
 func (pc *PositionConsumer) handlePositionUpdateMessage(ctx context.Context, messageBody []byte, headers map[string]interface{}) error {
-	var message PositionUpdateMessage
-	if err := json.Unmarshal(messageBody, &message); err != nil {
-		return fmt.Errorf("failed to unmarshal position update message: %w", err)
+	message, err := decodePositionUpdateMessage(messageBody)
+	if err != nil {
+		return pc.handlePoisonMessage(ctx, pc.queueManager.GetQueueNames().PositionUpdates, messageBody, headers,
+			fmt.Errorf("failed to unmarshal position update message: %w", err))
 	}
 
 	// Add correlation info from headers if available
@@ -202,13 +267,14 @@ func (pc *PositionConsumer) handlePositionUpdateMessage(ctx context.Context, mes
 		message.MessageMetadata.MessageID = messageID
 	}
 
-	return pc.positionHandler.HandlePositionUpdateMessage(ctx, &message)
+	return pc.positionHandler.HandlePositionUpdateMessage(ctx, message)
 }
 
 func (pc *PositionConsumer) handlePositionRetryMessage(ctx context.Context, messageBody []byte, headers map[string]interface{}) error {
-	var message PositionUpdateMessage
-	if err := json.Unmarshal(messageBody, &message); err != nil {
-		return fmt.Errorf("failed to unmarshal position retry message: %w", err)
+	message, err := decodePositionUpdateMessage(messageBody)
+	if err != nil {
+		return pc.handlePoisonMessage(ctx, pc.queueManager.GetQueueNames().PositionsRetry, messageBody, headers,
+			fmt.Errorf("failed to unmarshal position retry message: %w", err))
 	}
 
 	// Mark as retry message
@@ -219,5 +285,47 @@ func (pc *PositionConsumer) handlePositionRetryMessage(ctx context.Context, mess
 		message.MessageMetadata.RetryAttempt = retryAttempt
 	}
 
-	return pc.positionHandler.HandlePositionUpdateMessage(ctx, &message)
+	return pc.positionHandler.HandlePositionUpdateMessage(ctx, message)
+}
+
+// handlePoisonMessage dead-letters a message that can never be processed and acknowledges
+// the original, so a single malformed payload cannot be redelivered forever
+func (pc *PositionConsumer) handlePoisonMessage(ctx context.Context, queueName string, messageBody []byte, headers map[string]interface{}, parseErr error) error {
+	if pc.config == nil || !pc.config.DeadLetterPoisonMessages {
+		return parseErr
+	}
+
+	messageID, _ := headers["message_id"].(string)
+	if err := pc.queueManager.PublishPoisonMessageToDLQ(ctx, messageBody, messageID, queueName, parseErr.Error()); err != nil {
+		return fmt.Errorf("failed to dead-letter poison message from queue %s: %w", queueName, err)
+	}
+
+	log.Printf("Dead-lettered poison message %s from queue %s: %v", messageID, queueName, parseErr)
+	return nil
+}
+
+// decodePositionUpdateMessage parses a message body and rejects payloads that decode but
+// are missing the fields every position update needs
+func decodePositionUpdateMessage(messageBody []byte) (*PositionUpdateMessage, error) {
+	var message PositionUpdateMessage
+	if err := json.Unmarshal(messageBody, &message); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case message.OrderID == "":
+		return nil, fmt.Errorf("order_id is required")
+	case message.UserID == "":
+		return nil, fmt.Errorf("user_id is required")
+	case message.Symbol == "":
+		return nil, fmt.Errorf("symbol is required")
+	case message.OrderSide != "BUY" && message.OrderSide != "SELL":
+		return nil, fmt.Errorf("invalid order side: %q", message.OrderSide)
+	case message.Quantity <= 0:
+		return nil, fmt.Errorf("quantity must be positive, got %v", message.Quantity)
+	case message.ExecutionPrice <= 0:
+		return nil, fmt.Errorf("execution price must be positive, got %v", message.ExecutionPrice)
+	}
+
+	return &message, nil
 }
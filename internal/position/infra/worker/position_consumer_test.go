@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"HubInvestments/internal/position/infra/messaging"
+	sharedMessaging "HubInvestments/shared/infra/messaging"
+)
+
+type RecordingPositionMessageHandler struct {
+	handled []*PositionUpdateMessage
+}
+
+func (h *RecordingPositionMessageHandler) HandlePositionUpdateMessage(ctx context.Context, message *PositionUpdateMessage) error {
+	h.handled = append(h.handled, message)
+	return nil
+}
+
+// startTestPositionConsumer starts a consumer against the mock handler and returns the
+// message consumers it registered, keyed by queue name
+func startTestPositionConsumer(t *testing.T, messageHandler *MockMessageHandler, positionHandler PositionMessageHandler, config *PositionConsumerConfig) map[string]sharedMessaging.MessageConsumer {
+	t.Helper()
+
+	consumers := make(map[string]sharedMessaging.MessageConsumer)
+	messageHandler.ConsumeFunc = func(ctx context.Context, queueName string, handler sharedMessaging.MessageConsumer) error {
+		consumers[queueName] = handler
+		return nil
+	}
+
+	consumer := NewPositionConsumer(messageHandler, messaging.NewPositionQueueManager(messageHandler), positionHandler)
+	if err := consumer.StartConsumers(context.Background(), config); err != nil {
+		t.Fatalf("Expected consumers to start, got: %v", err)
+	}
+	return consumers
+}
+
+func TestPositionConsumer_UnparseableMessageIsDeadLettered(t *testing.T) {
+	var published []sharedMessaging.PublishOptions
+	messageHandler := &MockMessageHandler{
+		PublishWithOptionsFunc: func(ctx context.Context, options sharedMessaging.PublishOptions) error {
+			published = append(published, options)
+			return nil
+		},
+	}
+	positionHandler := &RecordingPositionMessageHandler{}
+	consumers := startTestPositionConsumer(t, messageHandler, positionHandler, DefaultPositionConsumerConfig())
+
+	poison := &sharedMessaging.Message{
+		Body:    []byte(`{"order_id": "order-1", "quantity": "ten"`),
+		Headers: map[string]interface{}{"message_id": "msg-poison"},
+	}
+	valid := &sharedMessaging.Message{
+		Body:    []byte(`{"order_id":"order-2","user_id":"user-1","symbol":"AAPL","order_side":"BUY","quantity":10,"execution_price":150}`),
+		Headers: map[string]interface{}{"message_id": "msg-valid"},
+	}
+
+	updates := consumers["positions.updates"]
+	if err := updates.HandleMessage(context.Background(), poison); err != nil {
+		t.Fatalf("Expected poison message to be acknowledged, got: %v", err)
+	}
+	if err := updates.HandleMessage(context.Background(), valid); err != nil {
+		t.Fatalf("Expected valid message after poison message to be processed, got: %v", err)
+	}
+
+	if len(published) != 1 {
+		t.Fatalf("Expected 1 message dead-lettered, got %d", len(published))
+	}
+	if published[0].QueueName != "positions.updates.dlq" {
+		t.Errorf("Expected message routed to positions.updates.dlq, got %s", published[0].QueueName)
+	}
+	if string(published[0].Message) != string(poison.Body) {
+		t.Errorf("Expected raw payload to be dead-lettered, got %s", published[0].Message)
+	}
+	if published[0].MessageID != "msg-poison" {
+		t.Errorf("Expected message ID msg-poison, got %s", published[0].MessageID)
+	}
+	if parseErr, _ := published[0].Headers["parse_error"].(string); parseErr == "" {
+		t.Error("Expected parse error to be recorded on the dead-lettered message")
+	}
+
+	if len(positionHandler.handled) != 1 || positionHandler.handled[0].OrderID != "order-2" {
+		t.Errorf("Expected only the valid message to reach the position handler, got %d messages", len(positionHandler.handled))
+	}
+}
+
+func TestPositionConsumer_InvalidSchemaOnRetryQueueIsDeadLettered(t *testing.T) {
+	var published []sharedMessaging.PublishOptions
+	messageHandler := &MockMessageHandler{
+		PublishWithOptionsFunc: func(ctx context.Context, options sharedMessaging.PublishOptions) error {
+			published = append(published, options)
+			return nil
+		},
+	}
+	positionHandler := &RecordingPositionMessageHandler{}
+	consumers := startTestPositionConsumer(t, messageHandler, positionHandler, DefaultPositionConsumerConfig())
+
+	message := &sharedMessaging.Message{
+		Body:    []byte(`{"order_id":"order-1","user_id":"user-1","symbol":"AAPL","order_side":"HOLD","quantity":10,"execution_price":150}`),
+		Headers: map[string]interface{}{},
+	}
+
+	if err := consumers["positions.retry"].HandleMessage(context.Background(), message); err != nil {
+		t.Fatalf("Expected invalid message to be acknowledged, got: %v", err)
+	}
+
+	if len(published) != 1 {
+		t.Fatalf("Expected 1 message dead-lettered, got %d", len(published))
+	}
+	if queue := published[0].Headers["original_queue"]; queue != "positions.retry" {
+		t.Errorf("Expected original_queue positions.retry, got %v", queue)
+	}
+	if len(positionHandler.handled) != 0 {
+		t.Errorf("Expected invalid message not to reach the position handler, got %d messages", len(positionHandler.handled))
+	}
+}
+
+func TestPositionConsumer_PoisonMessageRejectedWhenDeadLetterFails(t *testing.T) {
+	messageHandler := &MockMessageHandler{
+		PublishWithOptionsFunc: func(ctx context.Context, options sharedMessaging.PublishOptions) error {
+			return errors.New("broker unavailable")
+		},
+	}
+	consumers := startTestPositionConsumer(t, messageHandler, &RecordingPositionMessageHandler{}, DefaultPositionConsumerConfig())
+
+	err := consumers["positions.updates"].HandleMessage(context.Background(), &sharedMessaging.Message{Body: []byte("not json")})
+	if err == nil {
+		t.Fatal("Expected poison message to be rejected when it cannot be dead-lettered")
+	}
+}
+
+func TestPositionConsumer_PoisonMessageRejectedWhenDeadLetteringDisabled(t *testing.T) {
+	published := 0
+	messageHandler := &MockMessageHandler{
+		PublishWithOptionsFunc: func(ctx context.Context, options sharedMessaging.PublishOptions) error {
+			published++
+			return nil
+		},
+	}
+	config := DefaultPositionConsumerConfig()
+	config.DeadLetterPoisonMessages = false
+	consumers := startTestPositionConsumer(t, messageHandler, &RecordingPositionMessageHandler{}, config)
+
+	err := consumers["positions.updates"].HandleMessage(context.Background(), &sharedMessaging.Message{Body: []byte("not json")})
+	if err == nil {
+		t.Fatal("Expected poison message to be rejected when dead-lettering is disabled")
+	}
+	if published != 0 {
+		t.Errorf("Expected no message dead-lettered, got %d", published)
+	}
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// positionLocks hands out one lock per (user, symbol) so updates to the same position
+// are applied one at a time, while updates to different positions still run in parallel.
+// Entries are reference counted and dropped once no goroutine holds or waits for them.
+type positionLocks struct {
+	mu    sync.Mutex
+	locks map[string]*positionLock
+}
+
+type positionLock struct {
+	held    chan struct{}
+	waiters int
+}
+
+func newPositionLocks() *positionLocks {
+	return &positionLocks{
+		locks: make(map[string]*positionLock),
+	}
+}
+
+func positionLockKey(userID, symbol string) string {
+	return userID + "|" + symbol
+}
+
+// acquire blocks until the position lock is held or the context is done.
+// On success the returned function must be called to release the lock.
+func (p *positionLocks) acquire(ctx context.Context, userID, symbol string) (func(), error) {
+	key := positionLockKey(userID, symbol)
+
+	p.mu.Lock()
+	lock, exists := p.locks[key]
+	if !exists {
+		lock = &positionLock{held: make(chan struct{}, 1)}
+		p.locks[key] = lock
+	}
+	lock.waiters++
+	p.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+	case <-ctx.Done():
+		p.leave(key, lock)
+		return nil, ctx.Err()
+	}
+
+	return func() {
+		<-lock.held
+		p.leave(key, lock)
+	}, nil
+}
+
+func (p *positionLocks) leave(key string, lock *positionLock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lock.waiters--
+	if lock.waiters == 0 {
+		delete(p.locks, key)
+	}
+}
+
+// size returns the number of positions currently locked or waited on
+func (p *positionLocks) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.locks)
+}
@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// PositionReconciliationReportConfig holds configuration for the reconciliation report export
+type PositionReconciliationReportConfig struct {
+	Lookback  time.Duration // How far back executed orders are replayed when no start time is given
+	Tolerance float64       // Differences up to this value are not reported
+}
+
+// DefaultPositionReconciliationReportConfig returns the default reconciliation report configuration
+func DefaultPositionReconciliationReportConfig() PositionReconciliationReportConfig {
+	return PositionReconciliationReportConfig{
+		Lookback:  365 * 24 * time.Hour, // One year of executed orders
+		Tolerance: 0.000001,             // Same tolerance as the replay command
+	}
+}
+
+// PositionCorrection is the change that would bring the stored position in line with the events
+type PositionCorrection struct {
+	Action              PositionReplayChange `json:"action"` // CREATE or UPDATE
+	QuantityAdjustment  float64              `json:"quantity_adjustment"`
+	CostBasisAdjustment float64              `json:"cost_basis_adjustment"`
+}
+
+// PositionDiscrepancy compares the position expected from the executed orders with the stored one
+type PositionDiscrepancy struct {
+	UserID               string             `json:"user_id"`
+	Symbol               string             `json:"symbol"`
+	ExpectedQuantity     float64            `json:"expected_quantity"`
+	ActualQuantity       float64            `json:"actual_quantity"`
+	ExpectedCostBasis    float64            `json:"expected_cost_basis"`
+	ActualCostBasis      float64            `json:"actual_cost_basis"`
+	ExpectedAveragePrice float64            `json:"expected_average_price"`
+	ActualAveragePrice   float64            `json:"actual_average_price"`
+	ExpectedStatus       string             `json:"expected_status"`
+	ActualStatus         string             `json:"actual_status,omitempty"` // Empty when no position is stored
+	EventsApplied        int                `json:"events_applied"`
+	Correction           PositionCorrection `json:"proposed_correction"`
+}
+
+// PositionReconciliationReport lists the discrepancies found by a dry-run replay, for audit
+type PositionReconciliationReport struct {
+	GeneratedAt    time.Time             `json:"generated_at"`
+	Since          time.Time             `json:"since"`
+	UserID         string                `json:"user_id,omitempty"`
+	EventsReplayed int                   `json:"events_replayed"`
+	EventsSkipped  int                   `json:"events_skipped"`
+	Reconciled     int                   `json:"reconciled"` // Positions matching the executed orders
+	Discrepancies  []PositionDiscrepancy `json:"discrepancies"`
+	SkippedEvents  []string              `json:"skipped_events"`
+	Errors         []string              `json:"errors"`
+}
+
+// PositionReconciliationReporter builds reconciliation reports from a dry-run replay, so
+// generating a report never writes positions
+type PositionReconciliationReporter struct {
+	replayer *PositionReplayer
+	config   PositionReconciliationReportConfig
+	now      func() time.Time
+}
+
+func NewPositionReconciliationReporter(replayer *PositionReplayer, config PositionReconciliationReportConfig) *PositionReconciliationReporter {
+	return &PositionReconciliationReporter{
+		replayer: replayer,
+		config:   config,
+		now:      time.Now,
+	}
+}
+
+// Generate replays the orders executed since the given time (the configured lookback when zero),
+// optionally for a single user, and reports every position that differs from the stored one
+func (r *PositionReconciliationReporter) Generate(ctx context.Context, userID string, since time.Time) (*PositionReconciliationReport, error) {
+	generatedAt := r.now()
+	if since.IsZero() {
+		since = generatedAt.Add(-r.config.Lookback)
+	}
+
+	replay, err := r.replayer.Replay(ctx, PositionReplayConfig{
+		Since:     since,
+		Mode:      PositionReplayModeDryRun,
+		UserID:    userID,
+		Tolerance: r.config.Tolerance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PositionReconciliationReport{
+		GeneratedAt:    generatedAt,
+		Since:          since,
+		UserID:         userID,
+		EventsReplayed: replay.EventsReplayed,
+		EventsSkipped:  replay.EventsSkipped,
+		Reconciled:     replay.Unchanged,
+		Discrepancies:  make([]PositionDiscrepancy, 0, len(replay.Diffs)),
+		SkippedEvents:  replay.SkippedEvents,
+		Errors:         replay.Errors,
+	}
+
+	for _, diff := range replay.Diffs {
+		report.Discrepancies = append(report.Discrepancies, PositionDiscrepancy{
+			UserID:               diff.UserID,
+			Symbol:               diff.Symbol,
+			ExpectedQuantity:     diff.RebuiltQuantity,
+			ActualQuantity:       diff.CurrentQuantity,
+			ExpectedCostBasis:    diff.RebuiltCostBasis,
+			ActualCostBasis:      diff.CurrentCostBasis,
+			ExpectedAveragePrice: diff.RebuiltAveragePrice,
+			ActualAveragePrice:   diff.CurrentAveragePrice,
+			ExpectedStatus:       diff.RebuiltStatus.String(),
+			ActualStatus:         diff.CurrentStatus.String(),
+			EventsApplied:        diff.EventsApplied,
+			Correction: PositionCorrection{
+				Action:              diff.Change,
+				QuantityAdjustment:  diff.RebuiltQuantity - diff.CurrentQuantity,
+				CostBasisAdjustment: diff.RebuiltCostBasis - diff.CurrentCostBasis,
+			},
+		})
+	}
+
+	return report, nil
+}
+
+var positionReconciliationCSVHeader = []string{
+	"user_id", "symbol",
+	"expected_quantity", "actual_quantity",
+	"expected_cost_basis", "actual_cost_basis",
+	"expected_average_price", "actual_average_price",
+	"expected_status", "actual_status",
+	"correction_action", "quantity_adjustment", "cost_basis_adjustment",
+}
+
+// WriteCSV writes one row per discrepancy for spreadsheet-based audits
+func (report *PositionReconciliationReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(positionReconciliationCSVHeader); err != nil {
+		return fmt.Errorf("failed to write report header: %w", err)
+	}
+
+	for _, discrepancy := range report.Discrepancies {
+		row := []string{
+			discrepancy.UserID,
+			discrepancy.Symbol,
+			formatReportFloat(discrepancy.ExpectedQuantity),
+			formatReportFloat(discrepancy.ActualQuantity),
+			formatReportFloat(discrepancy.ExpectedCostBasis),
+			formatReportFloat(discrepancy.ActualCostBasis),
+			formatReportFloat(discrepancy.ExpectedAveragePrice),
+			formatReportFloat(discrepancy.ActualAveragePrice),
+			discrepancy.ExpectedStatus,
+			discrepancy.ActualStatus,
+			string(discrepancy.Correction.Action),
+			formatReportFloat(discrepancy.Correction.QuantityAdjustment),
+			formatReportFloat(discrepancy.Correction.CostBasisAdjustment),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write report row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatReportFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+)
+
+func findDiscrepancy(report *PositionReconciliationReport, symbol string) *PositionDiscrepancy {
+	for i := range report.Discrepancies {
+		if report.Discrepancies[i].Symbol == symbol {
+			return &report.Discrepancies[i]
+		}
+	}
+	return nil
+}
+
+func TestPositionReconciliationReporter_ReportsSeededDiscrepancy(t *testing.T) {
+	// Arrange
+	source, store, since := newReplayFixture(t)
+	reporter := NewPositionReconciliationReporter(NewPositionReplayer(source, store), DefaultPositionReconciliationReportConfig())
+
+	// Act
+	report, err := reporter.Generate(context.Background(), "", since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Discrepancies) != 2 {
+		t.Fatalf("Expected 2 discrepancies, got %d", len(report.Discrepancies))
+	}
+
+	aapl := findDiscrepancy(report, "AAPL")
+	if aapl == nil {
+		t.Fatal("Expected the seeded AAPL discrepancy in the report")
+	}
+	if aapl.ExpectedQuantity != 15 || aapl.ActualQuantity != 25 {
+		t.Errorf("Expected AAPL quantity 15 expected vs 25 actual, got %v vs %v", aapl.ExpectedQuantity, aapl.ActualQuantity)
+	}
+	if math.Abs(aapl.ExpectedCostBasis-1650) > 1e-9 || math.Abs(aapl.ActualCostBasis-2625) > 1e-9 {
+		t.Errorf("Expected AAPL cost basis 1650 expected vs 2625 actual, got %v vs %v", aapl.ExpectedCostBasis, aapl.ActualCostBasis)
+	}
+	if aapl.Correction.Action != PositionReplayChangeUpdate ||
+		aapl.Correction.QuantityAdjustment != -10 ||
+		math.Abs(aapl.Correction.CostBasisAdjustment+975) > 1e-9 {
+		t.Errorf("Expected an update correcting AAPL by -10 shares and -975, got %+v", aapl.Correction)
+	}
+
+	msft := findDiscrepancy(report, "MSFT")
+	if msft == nil || msft.Correction.Action != PositionReplayChangeCreate || msft.ActualQuantity != 0 || msft.ActualStatus != "" {
+		t.Errorf("Expected MSFT to be reported as a missing position, got %+v", msft)
+	}
+
+	if store.saved != 0 || store.updated != 0 {
+		t.Errorf("Expected the report not to write positions, got saved=%d updated=%d", store.saved, store.updated)
+	}
+}
+
+func TestPositionReconciliationReporter_DefaultsToLookback(t *testing.T) {
+	// Arrange
+	source, store, since := newReplayFixture(t)
+	config := DefaultPositionReconciliationReportConfig()
+	config.Lookback = 24 * time.Hour
+	reporter := NewPositionReconciliationReporter(NewPositionReplayer(source, store), config)
+	reporter.now = func() time.Time { return since.Add(12 * time.Hour) }
+
+	// Act
+	report, err := reporter.Generate(context.Background(), "", time.Time{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !report.Since.Equal(since.Add(-12 * time.Hour)) {
+		t.Errorf("Expected the report to start one lookback before now, got %s", report.Since)
+	}
+	if report.EventsReplayed != 4 {
+		t.Errorf("Expected 4 replayed events, got %d", report.EventsReplayed)
+	}
+}
+
+func TestPositionReconciliationReport_WriteCSV(t *testing.T) {
+	// Arrange
+	source, store, since := newReplayFixture(t)
+	reporter := NewPositionReconciliationReporter(NewPositionReplayer(source, store), DefaultPositionReconciliationReportConfig())
+	report, err := reporter.Generate(context.Background(), "", since)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	var buf bytes.Buffer
+	err = report.WriteCSV(&buf)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, got %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got %d rows", len(rows))
+	}
+
+	var aapl []string
+	for _, row := range rows[1:] {
+		if row[1] == "AAPL" {
+			aapl = row
+		}
+	}
+	expected := []string{"1", "AAPL", "15", "25", "1650", "2625", "110", "105", "PARTIAL", "ACTIVE", "UPDATE", "-10", "-975"}
+	if len(aapl) != len(expected) {
+		t.Fatalf("Expected AAPL row %v, got %v", expected, aapl)
+	}
+	for i := range expected {
+		if aapl[i] != expected[i] {
+			t.Errorf("Expected column %s to be %s, got %s", rows[0][i], expected[i], aapl[i])
+		}
+	}
+}
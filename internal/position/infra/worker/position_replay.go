@@ -0,0 +1,345 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// IExecutedOrderEventSource reads executed-order events in the position update message format
+type IExecutedOrderEventSource interface {
+	FindExecutedOrderEventsSince(ctx context.Context, since time.Time) ([]*PositionUpdateMessage, error)
+}
+
+// IReplayPositionStore defines the position persistence used by the replay (dependency inversion)
+type IReplayPositionStore interface {
+	ExistsForUser(ctx context.Context, userID uuid.UUID, symbol string) (bool, error)
+	FindByUserIDAndSymbol(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error)
+	Save(ctx context.Context, position *domain.Position) error
+	Update(ctx context.Context, position *domain.Position) error
+}
+
+type PositionReplayMode string
+
+const (
+	PositionReplayModeDryRun PositionReplayMode = "DRY_RUN" // Report the diff without writing
+	PositionReplayModeApply  PositionReplayMode = "APPLY"   // Write the rebuilt positions
+)
+
+// PositionReplayChange describes how a rebuilt position differs from the stored one
+type PositionReplayChange string
+
+const (
+	PositionReplayChangeCreate    PositionReplayChange = "CREATE"
+	PositionReplayChangeUpdate    PositionReplayChange = "UPDATE"
+	PositionReplayChangeUnchanged PositionReplayChange = "UNCHANGED"
+)
+
+type PositionReplayConfig struct {
+	Since     time.Time          // Events executed at or after this time are replayed
+	Mode      PositionReplayMode // Dry-run or apply
+	UserID    string             // Only replay this user's events (empty replays every user)
+	Tolerance float64            // Differences up to this value are reported as unchanged
+}
+
+// PositionReplayDiff compares a stored position with the one rebuilt from the events
+type PositionReplayDiff struct {
+	UserID              string
+	Symbol              string
+	Change              PositionReplayChange
+	CurrentQuantity     float64
+	RebuiltQuantity     float64
+	CurrentAveragePrice float64
+	RebuiltAveragePrice float64
+	CurrentCostBasis    float64 // Stored total investment
+	RebuiltCostBasis    float64 // Total investment rebuilt from the events
+	CurrentStatus       domain.PositionStatus
+	RebuiltStatus       domain.PositionStatus
+	EventsApplied       int
+}
+
+// PositionReplayReport summarizes a replay run
+type PositionReplayReport struct {
+	Mode           PositionReplayMode
+	Since          time.Time
+	EventsReplayed int
+	EventsSkipped  int
+	Unchanged      int
+	Applied        int
+	Failed         int
+	Diffs          []PositionReplayDiff
+	SkippedEvents  []string
+	Errors         []string
+}
+
+// PositionReplayer rebuilds positions by replaying executed-order events through the same
+// buy/sell rules as the position worker. Every position touched by the replayed events is
+// rebuilt from those events alone, so Since must precede the first trade of each position
+// being repaired. Events are ordered by execution time, sequence number and order ID, so
+// replaying the same events always produces the same positions.
+type PositionReplayer struct {
+	eventSource   IExecutedOrderEventSource
+	positionStore IReplayPositionStore
+	now           func() time.Time
+}
+
+func NewPositionReplayer(eventSource IExecutedOrderEventSource, positionStore IReplayPositionStore) *PositionReplayer {
+	return &PositionReplayer{
+		eventSource:   eventSource,
+		positionStore: positionStore,
+		now:           time.Now,
+	}
+}
+
+type replayedPosition struct {
+	userID        string
+	position      *domain.Position
+	eventsApplied int
+}
+
+// Replay rebuilds the positions touched by events since config.Since and reports the diff
+// against the stored positions. In apply mode changed positions are written back.
+func (r *PositionReplayer) Replay(ctx context.Context, config PositionReplayConfig) (*PositionReplayReport, error) {
+	if config.Mode != PositionReplayModeDryRun && config.Mode != PositionReplayModeApply {
+		return nil, fmt.Errorf("invalid replay mode: %s", config.Mode)
+	}
+	if config.Since.IsZero() {
+		return nil, fmt.Errorf("replay start time is required")
+	}
+
+	events, err := r.eventSource.FindExecutedOrderEventsSince(ctx, config.Since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load executed order events: %w", err)
+	}
+
+	report := &PositionReplayReport{
+		Mode:          config.Mode,
+		Since:         config.Since,
+		Diffs:         make([]PositionReplayDiff, 0),
+		SkippedEvents: make([]string, 0),
+		Errors:        make([]string, 0),
+	}
+
+	book := r.rebuildPositions(filterReplayEvents(events, config), report)
+
+	keys := make([]string, 0, len(book))
+	for key := range book {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rebuilt := book[key]
+		current, err := r.findCurrentPosition(ctx, rebuilt.position)
+		if err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+
+		diff := comparePositions(rebuilt, current, config.Tolerance)
+		if diff.Change == PositionReplayChangeUnchanged {
+			report.Unchanged++
+			continue
+		}
+		report.Diffs = append(report.Diffs, diff)
+
+		if config.Mode != PositionReplayModeApply {
+			continue
+		}
+		if err := r.applyRebuiltPosition(ctx, rebuilt.position, current); err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		report.Applied++
+	}
+
+	return report, nil
+}
+
+// filterReplayEvents keeps the requested user's events and sorts them into a deterministic order
+func filterReplayEvents(events []*PositionUpdateMessage, config PositionReplayConfig) []*PositionUpdateMessage {
+	filtered := make([]*PositionUpdateMessage, 0, len(events))
+	for _, event := range events {
+		if event == nil || event.ExecutedAt.Before(config.Since) {
+			continue
+		}
+		if config.UserID != "" && event.UserID != config.UserID {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if !a.ExecutedAt.Equal(b.ExecutedAt) {
+			return a.ExecutedAt.Before(b.ExecutedAt)
+		}
+		if a.SequenceNumber != b.SequenceNumber {
+			return a.SequenceNumber < b.SequenceNumber
+		}
+		return a.OrderID < b.OrderID
+	})
+	return filtered
+}
+
+// rebuildPositions applies the events to an in-memory book keyed by user and symbol
+func (r *PositionReplayer) rebuildPositions(events []*PositionUpdateMessage, report *PositionReplayReport) map[string]*replayedPosition {
+	book := make(map[string]*replayedPosition)
+
+	for _, event := range events {
+		userID, err := parsePositionUserID(event.UserID)
+		if err != nil {
+			r.skipEvent(report, event, err.Error())
+			continue
+		}
+
+		key := userID.String() + "|" + event.Symbol
+		entry := book[key]
+
+		switch event.OrderSide {
+		case "BUY":
+			err = replayBuy(book, key, entry, userID, event)
+		case "SELL":
+			err = replaySell(entry, event)
+		default:
+			err = fmt.Errorf("invalid order side: %s", event.OrderSide)
+		}
+		if err != nil {
+			r.skipEvent(report, event, err.Error())
+			continue
+		}
+
+		book[key].userID = event.UserID
+		book[key].eventsApplied++
+		book[key].position.ClearEvents()
+		report.EventsReplayed++
+	}
+
+	return book
+}
+
+func replayBuy(book map[string]*replayedPosition, key string, entry *replayedPosition, userID uuid.UUID, event *PositionUpdateMessage) error {
+	sourceOrderID := event.OrderID
+
+	// A buy after the position was closed opens a new one, as it does in the worker
+	if entry == nil || !entry.position.Status.CanBeUpdated() {
+		position, err := domain.NewPosition(userID, event.Symbol, event.Quantity, event.ExecutionPrice, domain.PositionTypeLong)
+		if err != nil {
+			return err
+		}
+		position.CreatedAt = event.ExecutedAt
+		stampReplayedTrade(position, event.ExecutedAt)
+		book[key] = &replayedPosition{position: position}
+		return nil
+	}
+
+	if err := entry.position.UpdateQuantityWithOrderID(event.Quantity, event.ExecutionPrice, true, &sourceOrderID); err != nil {
+		return err
+	}
+	stampReplayedTrade(entry.position, event.ExecutedAt)
+	return nil
+}
+
+func replaySell(entry *replayedPosition, event *PositionUpdateMessage) error {
+	if entry == nil || !entry.position.Status.CanBeUpdated() {
+		return fmt.Errorf("no open position for %s", event.Symbol)
+	}
+
+	// Selling at least the remaining quantity closes the position, as it does in the worker
+	quantity := math.Min(event.Quantity, entry.position.Quantity)
+	sourceOrderID := event.OrderID
+	if err := entry.position.UpdateQuantityWithOrderID(quantity, event.ExecutionPrice, false, &sourceOrderID); err != nil {
+		return err
+	}
+	stampReplayedTrade(entry.position, event.ExecutedAt)
+	return nil
+}
+
+// stampReplayedTrade uses the execution time instead of the wall clock so replays are repeatable
+func stampReplayedTrade(position *domain.Position, executedAt time.Time) {
+	tradeAt := executedAt
+	position.UpdatedAt = executedAt
+	position.LastTradeAt = &tradeAt
+}
+
+func (r *PositionReplayer) skipEvent(report *PositionReplayReport, event *PositionUpdateMessage, reason string) {
+	report.EventsSkipped++
+	report.SkippedEvents = append(report.SkippedEvents, fmt.Sprintf("order %s: %s", event.OrderID, reason))
+}
+
+func (r *PositionReplayer) findCurrentPosition(ctx context.Context, rebuilt *domain.Position) (*domain.Position, error) {
+	exists, err := r.positionStore.ExistsForUser(ctx, rebuilt.UserID, rebuilt.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing position: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	current, err := r.positionStore.FindByUserIDAndSymbol(ctx, rebuilt.UserID, rebuilt.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current position: %w", err)
+	}
+	return current, nil
+}
+
+func comparePositions(rebuilt *replayedPosition, current *domain.Position, tolerance float64) PositionReplayDiff {
+	diff := PositionReplayDiff{
+		UserID:              rebuilt.userID,
+		Symbol:              rebuilt.position.Symbol,
+		RebuiltQuantity:     rebuilt.position.Quantity,
+		RebuiltAveragePrice: rebuilt.position.AveragePrice,
+		RebuiltCostBasis:    rebuilt.position.TotalInvestment,
+		RebuiltStatus:       rebuilt.position.Status,
+		EventsApplied:       rebuilt.eventsApplied,
+	}
+
+	if current == nil {
+		diff.Change = PositionReplayChangeCreate
+		return diff
+	}
+
+	diff.CurrentQuantity = current.Quantity
+	diff.CurrentAveragePrice = current.AveragePrice
+	diff.CurrentCostBasis = current.TotalInvestment
+	diff.CurrentStatus = current.Status
+
+	if math.Abs(current.Quantity-rebuilt.position.Quantity) > tolerance ||
+		math.Abs(current.AveragePrice-rebuilt.position.AveragePrice) > tolerance ||
+		current.Status != rebuilt.position.Status {
+		diff.Change = PositionReplayChangeUpdate
+	} else {
+		diff.Change = PositionReplayChangeUnchanged
+	}
+	return diff
+}
+
+// applyRebuiltPosition saves a new position or overwrites the stored one, keeping its ID
+func (r *PositionReplayer) applyRebuiltPosition(ctx context.Context, rebuilt, current *domain.Position) error {
+	if current == nil {
+		if err := r.positionStore.Save(ctx, rebuilt); err != nil {
+			return fmt.Errorf("failed to save rebuilt position: %w", err)
+		}
+		return nil
+	}
+
+	current.Quantity = rebuilt.Quantity
+	current.AveragePrice = rebuilt.AveragePrice
+	current.TotalInvestment = rebuilt.TotalInvestment
+	current.Status = rebuilt.Status
+	current.LastTradeAt = rebuilt.LastTradeAt
+	current.UpdatedAt = r.now()
+
+	if err := r.positionStore.Update(ctx, current); err != nil {
+		return fmt.Errorf("failed to update position: %w", err)
+	}
+	return nil
+}
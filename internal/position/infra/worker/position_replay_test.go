@@ -0,0 +1,223 @@
+package worker
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	domain "HubInvestments/internal/position/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type MockExecutedOrderEventSource struct {
+	events []*PositionUpdateMessage
+}
+
+func (m *MockExecutedOrderEventSource) FindExecutedOrderEventsSince(ctx context.Context, since time.Time) ([]*PositionUpdateMessage, error) {
+	return m.events, nil
+}
+
+type InMemoryReplayPositionStore struct {
+	positions map[string]*domain.Position
+	saved     int
+	updated   int
+}
+
+func NewInMemoryReplayPositionStore(positions ...*domain.Position) *InMemoryReplayPositionStore {
+	store := &InMemoryReplayPositionStore{positions: make(map[string]*domain.Position)}
+	for _, position := range positions {
+		store.positions[position.UserID.String()+"|"+position.Symbol] = position
+	}
+	return store
+}
+
+func (s *InMemoryReplayPositionStore) ExistsForUser(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+	_, ok := s.positions[userID.String()+"|"+symbol]
+	return ok, nil
+}
+
+func (s *InMemoryReplayPositionStore) FindByUserIDAndSymbol(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error) {
+	return s.positions[userID.String()+"|"+symbol], nil
+}
+
+func (s *InMemoryReplayPositionStore) Save(ctx context.Context, position *domain.Position) error {
+	s.positions[position.UserID.String()+"|"+position.Symbol] = position
+	s.saved++
+	return nil
+}
+
+func (s *InMemoryReplayPositionStore) Update(ctx context.Context, position *domain.Position) error {
+	s.positions[position.UserID.String()+"|"+position.Symbol] = position
+	s.updated++
+	return nil
+}
+
+var replayUserUUID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+func replayEvent(orderID, side, symbol string, quantity, price float64, executedAt time.Time) *PositionUpdateMessage {
+	return &PositionUpdateMessage{
+		OrderID:        orderID,
+		UserID:         "1",
+		Symbol:         symbol,
+		OrderSide:      side,
+		OrderType:      "MARKET",
+		Quantity:       quantity,
+		ExecutionPrice: price,
+		ExecutedAt:     executedAt,
+	}
+}
+
+// newReplayFixture returns events that rebuild AAPL to 15 @ 110 (partial) and MSFT to 5 @ 300,
+// plus a stored AAPL position left wrong by a bug. The events are listed out of order on purpose.
+func newReplayFixture(t *testing.T) (*MockExecutedOrderEventSource, *InMemoryReplayPositionStore, time.Time) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	events := []*PositionUpdateMessage{
+		replayEvent("order-3", "SELL", "AAPL", 5, 120, since.Add(3*time.Hour)),
+		replayEvent("order-1", "BUY", "AAPL", 10, 100, since.Add(time.Hour)),
+		replayEvent("order-2", "BUY", "AAPL", 10, 120, since.Add(2*time.Hour)),
+		replayEvent("order-4", "BUY", "MSFT", 5, 300, since.Add(4*time.Hour)),
+	}
+
+	stale, err := domain.NewPosition(replayUserUUID, "AAPL", 25, 105, domain.PositionTypeLong)
+	if err != nil {
+		t.Fatalf("failed to create position: %v", err)
+	}
+
+	return &MockExecutedOrderEventSource{events: events}, NewInMemoryReplayPositionStore(stale), since
+}
+
+func findReplayDiff(report *PositionReplayReport, symbol string) *PositionReplayDiff {
+	for i := range report.Diffs {
+		if report.Diffs[i].Symbol == symbol {
+			return &report.Diffs[i]
+		}
+	}
+	return nil
+}
+
+func TestPositionReplayer_DryRunReportsDiff(t *testing.T) {
+	// Arrange
+	source, store, since := newReplayFixture(t)
+	replayer := NewPositionReplayer(source, store)
+
+	// Act
+	report, err := replayer.Replay(context.Background(), PositionReplayConfig{Since: since, Mode: PositionReplayModeDryRun, Tolerance: 1e-6})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.EventsReplayed != 4 || report.EventsSkipped != 0 {
+		t.Errorf("Expected 4 replayed and 0 skipped events, got %d and %d", report.EventsReplayed, report.EventsSkipped)
+	}
+	if len(report.Diffs) != 2 {
+		t.Fatalf("Expected 2 diffs, got %d", len(report.Diffs))
+	}
+
+	aapl := findReplayDiff(report, "AAPL")
+	if aapl == nil || aapl.Change != PositionReplayChangeUpdate {
+		t.Fatalf("Expected AAPL update diff, got %+v", aapl)
+	}
+	if aapl.CurrentQuantity != 25 || aapl.RebuiltQuantity != 15 {
+		t.Errorf("Expected AAPL quantity 25 -> 15, got %v -> %v", aapl.CurrentQuantity, aapl.RebuiltQuantity)
+	}
+	if math.Abs(aapl.RebuiltAveragePrice-110) > 1e-9 {
+		t.Errorf("Expected AAPL rebuilt average price 110, got %v", aapl.RebuiltAveragePrice)
+	}
+	if aapl.RebuiltStatus != domain.PositionStatusPartial || aapl.EventsApplied != 3 {
+		t.Errorf("Expected partial AAPL from 3 events, got %s from %d", aapl.RebuiltStatus, aapl.EventsApplied)
+	}
+
+	msft := findReplayDiff(report, "MSFT")
+	if msft == nil || msft.Change != PositionReplayChangeCreate || msft.RebuiltQuantity != 5 {
+		t.Errorf("Expected MSFT create diff for 5 shares, got %+v", msft)
+	}
+
+	if report.Applied != 0 || store.saved != 0 || store.updated != 0 {
+		t.Errorf("Expected dry run not to write, got applied=%d saved=%d updated=%d", report.Applied, store.saved, store.updated)
+	}
+	if store.positions[replayUserUUID.String()+"|AAPL"].Quantity != 25 {
+		t.Errorf("Expected stored AAPL position to be untouched")
+	}
+}
+
+func TestPositionReplayer_ApplyRebuildsPositions(t *testing.T) {
+	// Arrange
+	source, store, since := newReplayFixture(t)
+	replayer := NewPositionReplayer(source, store)
+	staleID := store.positions[replayUserUUID.String()+"|AAPL"].ID
+
+	// Act
+	report, err := replayer.Replay(context.Background(), PositionReplayConfig{Since: since, Mode: PositionReplayModeApply, Tolerance: 1e-6})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Applied != 2 || report.Failed != 0 {
+		t.Errorf("Expected 2 applied and 0 failed, got %d and %d", report.Applied, report.Failed)
+	}
+	if store.saved != 1 || store.updated != 1 {
+		t.Errorf("Expected 1 save and 1 update, got %d and %d", store.saved, store.updated)
+	}
+
+	aapl := store.positions[replayUserUUID.String()+"|AAPL"]
+	if aapl.ID != staleID {
+		t.Errorf("Expected AAPL position to keep its ID")
+	}
+	if aapl.Quantity != 15 || math.Abs(aapl.AveragePrice-110) > 1e-9 || aapl.Status != domain.PositionStatusPartial {
+		t.Errorf("Expected AAPL 15 @ 110 partial, got %v @ %v %s", aapl.Quantity, aapl.AveragePrice, aapl.Status)
+	}
+	if math.Abs(aapl.TotalInvestment-1650) > 1e-9 {
+		t.Errorf("Expected AAPL total investment 1650, got %v", aapl.TotalInvestment)
+	}
+
+	msft := store.positions[replayUserUUID.String()+"|MSFT"]
+	if msft == nil || msft.Quantity != 5 || msft.AveragePrice != 300 {
+		t.Fatalf("Expected MSFT 5 @ 300, got %+v", msft)
+	}
+	if msft.LastTradeAt == nil || !msft.LastTradeAt.Equal(since.Add(4*time.Hour)) {
+		t.Errorf("Expected MSFT last trade at the execution time, got %v", msft.LastTradeAt)
+	}
+
+	// Replaying again finds nothing left to change
+	second, err := replayer.Replay(context.Background(), PositionReplayConfig{Since: since, Mode: PositionReplayModeApply, Tolerance: 1e-6})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(second.Diffs) != 0 || second.Unchanged != 2 {
+		t.Errorf("Expected second replay to be unchanged, got %d diffs and %d unchanged", len(second.Diffs), second.Unchanged)
+	}
+}
+
+func TestPositionReplayer_SkipsSellWithoutPosition(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	source := &MockExecutedOrderEventSource{events: []*PositionUpdateMessage{
+		replayEvent("order-1", "SELL", "PETR4", 10, 30, since.Add(time.Hour)),
+	}}
+	replayer := NewPositionReplayer(source, NewInMemoryReplayPositionStore())
+
+	report, err := replayer.Replay(context.Background(), PositionReplayConfig{Since: since, Mode: PositionReplayModeDryRun})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.EventsSkipped != 1 || len(report.SkippedEvents) != 1 {
+		t.Errorf("Expected 1 skipped event, got %d", report.EventsSkipped)
+	}
+	if len(report.Diffs) != 0 {
+		t.Errorf("Expected no diffs, got %d", len(report.Diffs))
+	}
+}
+
+func TestPositionReplayer_InvalidMode(t *testing.T) {
+	replayer := NewPositionReplayer(&MockExecutedOrderEventSource{}, NewInMemoryReplayPositionStore())
+
+	_, err := replayer.Replay(context.Background(), PositionReplayConfig{Since: time.Now(), Mode: "REPLAY_ALL"})
+
+	if err == nil {
+		t.Error("Expected error for invalid replay mode")
+	}
+}
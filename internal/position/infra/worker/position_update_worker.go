@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ type PositionUpdateWorker struct {
 	createPositionUC   positionUsecase.ICreatePositionUseCase
 	updatePositionUC   positionUsecase.IUpdatePositionUseCase
 	closePositionUC    positionUsecase.IClosePositionUseCase
+	consolidateUC      positionUsecase.IConsolidatePositionsUseCase // Optional, merges duplicate active positions found on a buy
 	positionRepository positionRepository.IPositionRepository
 	positionConsumer   *PositionConsumer
 	messageHandler     sharedMessaging.MessageHandler
@@ -34,6 +36,9 @@ type PositionUpdateWorker struct {
 	isRunning          bool
 	mu                 sync.RWMutex
 	config             *PositionWorkerConfig
+	sequenceTracker    *sharedMessaging.SequenceTracker
+	positionLocks      *positionLocks
+	executionOrder     *executionOrderBuffer
 	metrics            *PositionWorkerMetrics
 	healthStatus       HealthStatus
 	lastHeartbeat      time.Time
@@ -54,6 +59,39 @@ type PositionWorkerConfig struct {
 	EnableMetrics              bool
 	LogLevel                   string
 	PositionConsistencyTimeout time.Duration // Time to wait for position consistency
+	PositionConsistencyPoll    time.Duration // Interval between lookups while waiting for consistency
+	EnforceEventSequence       bool          // Discard redelivered messages whose sequence was already applied for the order
+	MaxTrackedSequences        int           // Number of orders whose applied sequences are remembered
+	MaxMessageAge              time.Duration // Older messages are routed to review instead of applied (0 disables)
+	SerializePositionUpdates   bool          // Apply updates for the same user and symbol one at a time
+	DeadLetterPoisonMessages   bool          // Route messages that fail to decode straight to the DLQ
+	ExecutionOrderWindow       time.Duration // Hold updates this long so those for the same position apply in execution-time order (0 applies them as they arrive)
+
+	OperationLatencyBuckets []time.Duration // Histogram bucket upper bounds for the latency of each position operation
+
+	AdaptivePrefetch *AdaptivePrefetchConfig // Tune prefetch from processing latency, starting at MaxConcurrentUpdates * 2 (nil keeps it static)
+}
+
+// Operations the worker applies to positions. Each has its own latency histogram since a create,
+// an update and a close do very different amounts of work.
+const (
+	PositionOperationCreate = "position_create"
+	PositionOperationUpdate = "position_update"
+	PositionOperationClose  = "position_close"
+)
+
+// PositionOperations returns the operations whose latency is tracked
+func PositionOperations() []string {
+	return []string{PositionOperationCreate, PositionOperationUpdate, PositionOperationClose}
+}
+
+// DefaultOperationLatencyBuckets returns the default bucket upper bounds for position operation latency
+func DefaultOperationLatencyBuckets() []time.Duration {
+	return []time.Duration{ // From a single-row write up to a slow close with realized P&L
+		5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+		100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+		time.Second, 2500 * time.Millisecond, 5 * time.Second,
+	}
 }
 
 type PositionWorkerMetrics struct {
@@ -68,6 +106,32 @@ type PositionWorkerMetrics struct {
 	StartTime             time.Time
 	LastActivityTime      time.Time
 	mu                    sync.RWMutex
+
+	operationBuckets []time.Duration
+	operationLatency map[string]*operationLatency
+}
+
+type operationLatency struct {
+	counts []uint64 // Per bucket, not cumulative; the last entry counts observations above every bound
+	count  uint64
+	sum    time.Duration
+	last   time.Duration
+}
+
+// OperationLatencyBucket is a cumulative histogram bucket: the operations at or below the upper bound
+type OperationLatencyBucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// OperationLatencySnapshot is the latency distribution of one position operation
+type OperationLatencySnapshot struct {
+	Operation string
+	Buckets   []OperationLatencyBucket
+	Count     uint64
+	Sum       time.Duration
+	Average   time.Duration
+	Last      time.Duration
 }
 
 type PositionWorkerMetricsSnapshot struct {
@@ -81,6 +145,7 @@ type PositionWorkerMetricsSnapshot struct {
 	LastProcessingTime    time.Duration
 	StartTime             time.Time
 	LastActivityTime      time.Time
+	OperationLatency      []OperationLatencySnapshot // One entry per operation, in PositionOperations order
 }
 
 type HealthStatus int
@@ -109,6 +174,7 @@ func (h HealthStatus) String() string {
 }
 
 type PositionUpdateMessage struct {
+	SequenceNumber      int64                         `json:"sequence_number,omitempty"`
 	OrderID             string                        `json:"order_id"`
 	UserID              string                        `json:"user_id"`
 	Symbol              string                        `json:"symbol"`
@@ -161,7 +227,10 @@ func NewPositionUpdateWorker(
 		ctx:                ctx,
 		cancel:             cancel,
 		config:             config,
-		metrics:            NewPositionWorkerMetrics(),
+		sequenceTracker:    sharedMessaging.NewSequenceTracker(config.MaxTrackedSequences),
+		positionLocks:      newPositionLocks(),
+		executionOrder:     newExecutionOrderBuffer(config.ExecutionOrderWindow),
+		metrics:            newPositionWorkerMetrics(config.OperationLatencyBuckets),
 		healthStatus:       HealthStatusUnknown,
 		lastHeartbeat:      time.Now(),
 	}
@@ -176,6 +245,23 @@ func NewPositionUpdateWorker(
 	return worker
 }
 
+// NewPositionUpdateWorkerWithConsolidation creates a worker that merges duplicate active positions
+// in a symbol when a buy finds more than one, so the buy is applied to the consolidated position
+func NewPositionUpdateWorkerWithConsolidation(
+	workerID string,
+	createPositionUC positionUsecase.ICreatePositionUseCase,
+	updatePositionUC positionUsecase.IUpdatePositionUseCase,
+	closePositionUC positionUsecase.IClosePositionUseCase,
+	consolidatePositionsUC positionUsecase.IConsolidatePositionsUseCase,
+	positionRepo positionRepository.IPositionRepository,
+	messageHandler sharedMessaging.MessageHandler,
+	config *PositionWorkerConfig,
+) *PositionUpdateWorker {
+	worker := NewPositionUpdateWorker(workerID, createPositionUC, updatePositionUC, closePositionUC, positionRepo, messageHandler, config)
+	worker.consolidateUC = consolidatePositionsUC
+	return worker
+}
+
 func DefaultPositionWorkerConfig(workerID string) *PositionWorkerConfig {
 	return &PositionWorkerConfig{
 		WorkerID:                   workerID,
@@ -189,14 +275,36 @@ func DefaultPositionWorkerConfig(workerID string) *PositionWorkerConfig {
 		EnableMetrics:              true,
 		LogLevel:                   "INFO",
 		PositionConsistencyTimeout: 5 * time.Second,
+		PositionConsistencyPoll:    100 * time.Millisecond,
+		EnforceEventSequence:       true,
+		MaxTrackedSequences:        100000,
+		MaxMessageAge:              time.Hour,
+		SerializePositionUpdates:   true,
+		DeadLetterPoisonMessages:   true,
+		ExecutionOrderWindow:       200 * time.Millisecond, // Covers publish reordering between the order workers
+		OperationLatencyBuckets:    DefaultOperationLatencyBuckets(),
 	}
 }
 
 func NewPositionWorkerMetrics() *PositionWorkerMetrics {
-	return &PositionWorkerMetrics{
+	return newPositionWorkerMetrics(DefaultOperationLatencyBuckets())
+}
+
+func newPositionWorkerMetrics(buckets []time.Duration) *PositionWorkerMetrics {
+	sorted := make([]time.Duration, len(buckets))
+	copy(sorted, buckets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	metrics := &PositionWorkerMetrics{
 		StartTime:        time.Now(),
 		LastActivityTime: time.Now(),
+		operationBuckets: sorted,
+		operationLatency: make(map[string]*operationLatency),
+	}
+	for _, operation := range PositionOperations() {
+		metrics.operationLatency[operation] = &operationLatency{counts: make([]uint64, len(sorted)+1)}
 	}
+	return metrics
 }
 
 // begins the worker processing loop
@@ -294,9 +402,36 @@ func (w *PositionUpdateWorker) GetMetrics() PositionWorkerMetricsSnapshot {
 		LastProcessingTime:    w.metrics.LastProcessingTime,
 		StartTime:             w.metrics.StartTime,
 		LastActivityTime:      w.metrics.LastActivityTime,
+		OperationLatency:      w.metrics.operationLatencySnapshots(),
 	}
 }
 
+// operationLatencySnapshots returns the histogram of every operation; callers hold the metrics lock
+func (m *PositionWorkerMetrics) operationLatencySnapshots() []OperationLatencySnapshot {
+	snapshots := make([]OperationLatencySnapshot, 0, len(m.operationLatency))
+	for _, operation := range PositionOperations() {
+		latency := m.operationLatency[operation]
+		snapshot := OperationLatencySnapshot{
+			Operation: operation,
+			Buckets:   make([]OperationLatencyBucket, len(m.operationBuckets)),
+			Count:     latency.count,
+			Sum:       latency.sum,
+			Last:      latency.last,
+		}
+		if latency.count > 0 {
+			snapshot.Average = latency.sum / time.Duration(latency.count)
+		}
+
+		var cumulative uint64
+		for i, bound := range m.operationBuckets {
+			cumulative += latency.counts[i]
+			snapshot.Buckets[i] = OperationLatencyBucket{UpperBound: bound, Count: cumulative}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
 func (w *PositionUpdateWorker) GetID() string {
 	return w.id
 }
@@ -369,6 +504,24 @@ func (w *PositionUpdateWorker) updateProcessingTime(duration time.Duration) {
 	}
 }
 
+// recordOperationLatency adds the duration of a successful operation to its histogram
+func (w *PositionUpdateWorker) recordOperationLatency(operation string, duration time.Duration) {
+	w.metrics.mu.Lock()
+	defer w.metrics.mu.Unlock()
+
+	latency, tracked := w.metrics.operationLatency[operation]
+	if !tracked {
+		return
+	}
+
+	buckets := w.metrics.operationBuckets
+	index := sort.Search(len(buckets), func(i int) bool { return duration <= buckets[i] })
+	latency.counts[index]++
+	latency.count++
+	latency.sum += duration
+	latency.last = duration
+}
+
 func (w *PositionUpdateWorker) heartbeatLoop() {
 	defer w.wg.Done()
 
@@ -445,6 +598,9 @@ func (w *PositionUpdateWorker) processMessages() {
 		RequeueOnError:    true,
 		RetryDelay:        w.config.RetryBackoffBase,
 		MaxRetries:        w.config.MaxRetries,
+
+		DeadLetterPoisonMessages: w.config.DeadLetterPoisonMessages,
+		AdaptivePrefetch:         w.config.AdaptivePrefetch,
 	}
 
 	err := w.positionConsumer.StartConsumers(w.ctx, config)
@@ -493,10 +649,45 @@ func (w *PositionUpdateWorker) processPositionUpdateMessage(ctx context.Context,
 		w.id, message.OrderID, message.UserID, message.Symbol, message.OrderSide, message.Quantity)
 
 	w.updateLastActivity()
+
+	if age, tooOld := w.isOverMaxAge(message); tooOld {
+		log.Printf("Position worker %s: Diverting update for order %s to review, message is %v old (max %v)",
+			w.id, message.OrderID, age, w.config.MaxMessageAge)
+		return w.divertToReview(message, fmt.Sprintf("message age %v exceeds max %v", age, w.config.MaxMessageAge))
+	}
+
+	// A buy applied after a sell that executed later would close the position or skew its average price
+	if w.config.ExecutionOrderWindow > 0 && !message.ExecutedAt.IsZero() {
+		release, err := w.executionOrder.wait(ctx, message.UserID, message.Symbol, message.ExecutedAt)
+		if err != nil {
+			return fmt.Errorf("interrupted while ordering update for symbol %s: %w", message.Symbol, err)
+		}
+		defer release()
+	}
+
+	// Concurrent buys and sells for the same position would otherwise read the same
+	// quantity and overwrite each other's update. Sequenced messages always take the lock,
+	// so two deliveries of the same update cannot both pass the duplicate check.
+	if w.config.SerializePositionUpdates || w.isSequenced(message) {
+		release, err := w.positionLocks.acquire(processCtx, message.UserID, message.Symbol)
+		if err != nil {
+			w.incrementErrorCount()
+			return fmt.Errorf("failed to acquire position lock for symbol %s: %w", message.Symbol, err)
+		}
+		defer release()
+	}
+
+	if w.isSequenced(message) && w.sequenceTracker.IsApplied(message.OrderID, message.SequenceNumber) {
+		log.Printf("Position worker %s: Discarding duplicate update for order %s (sequence %d)",
+			w.id, message.OrderID, message.SequenceNumber)
+		return nil
+	}
+
 	w.incrementProcessedCount()
 
 	var err error
 	var operationType string
+	operationStart := time.Now()
 
 	// Determine the operation type based on order side and existing positions
 	switch message.OrderSide {
@@ -508,6 +699,7 @@ func (w *PositionUpdateWorker) processPositionUpdateMessage(ctx context.Context,
 		err = fmt.Errorf("invalid order side: %s", message.OrderSide)
 	}
 
+	operationTime := time.Since(operationStart)
 	processingTime := time.Since(startTime)
 	w.updateProcessingTime(processingTime)
 
@@ -523,12 +715,57 @@ func (w *PositionUpdateWorker) processPositionUpdateMessage(ctx context.Context,
 		return fmt.Errorf("position update processing failed: %w", err)
 	}
 
+	w.recordOperationLatency(operationType, operationTime)
+
+	if w.isSequenced(message) {
+		w.sequenceTracker.MarkApplied(message.OrderID, message.SequenceNumber)
+	}
+
 	log.Printf("Position worker %s: Successfully processed %s for order %s in %v (symbol: %s)",
 		w.id, operationType, message.OrderID, processingTime, message.Symbol)
 
 	return nil
 }
 
+// isSequenced reports whether the message is deduplicated by its sequence number.
+// Messages without a sequence number predate sequencing and are always applied.
+func (w *PositionUpdateWorker) isSequenced(message *PositionUpdateMessage) bool {
+	return w.config.EnforceEventSequence && message.SequenceNumber > 0
+}
+
+// isOverMaxAge reports whether the message is too old to apply blindly.
+// Age is measured from the last publish time, so scheduled retries are not counted against it;
+// messages without metadata fall back to the execution time.
+func (w *PositionUpdateWorker) isOverMaxAge(message *PositionUpdateMessage) (time.Duration, bool) {
+	if w.config.MaxMessageAge <= 0 {
+		return 0, false
+	}
+
+	reference := message.MessageMetadata.Timestamp
+	if reference.IsZero() {
+		reference = message.ExecutedAt
+	}
+	if reference.IsZero() {
+		return 0, false
+	}
+
+	age := time.Since(reference)
+	return age, age > w.config.MaxMessageAge
+}
+
+func (w *PositionUpdateWorker) divertToReview(message *PositionUpdateMessage, reason string) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review message: %w", err)
+	}
+
+	if err := w.queueManager.PublishToReviewQueue(w.ctx, messageBytes, message.MessageMetadata.MessageID, reason); err != nil {
+		return fmt.Errorf("failed to publish to review queue: %w", err)
+	}
+
+	return nil
+}
+
 func (w *PositionUpdateWorker) handleBuyOrder(ctx context.Context, message *PositionUpdateMessage) (string, error) {
 	userID, err := w.parseUserIDToUUID(message.UserID)
 	if err != nil {
@@ -561,26 +798,18 @@ func (w *PositionUpdateWorker) handleBuyOrder(ctx context.Context, message *Posi
 		}
 
 		w.incrementCreatedCount()
-		return "position_create", nil
+		return PositionOperationCreate, nil
 	} else {
-		// Update existing position for buy order
-		//TODO: create repo method to fetch only one position instead of all positions
-		positions, err := w.positionRepository.FindByUserID(ctx, userID)
+		// Update existing position for buy order. The existence check may have seen a
+		// position written elsewhere that is not yet visible, so wait for it to appear.
+		targetPosition, err := w.waitForActivePosition(ctx, userID, message.Symbol)
 		if err != nil {
-			return "", fmt.Errorf("failed to find existing positions: %w", err)
-		}
-
-		// Find the position for this symbol
-		var targetPosition *domain.Position
-		for _, pos := range positions {
-			if pos.Symbol == message.Symbol && pos.Status == domain.PositionStatusActive {
-				targetPosition = pos
-				break
-			}
+			return "", err
 		}
 
 		if targetPosition == nil {
-			return "", fmt.Errorf("position not found for user %s and symbol %s", message.UserID, message.Symbol)
+			return "", fmt.Errorf("position not found for user %s and symbol %s after waiting %v",
+				message.UserID, message.Symbol, w.config.PositionConsistencyTimeout)
 		}
 
 		updateCmd := &command.UpdatePositionCommand{
@@ -598,7 +827,71 @@ func (w *PositionUpdateWorker) handleBuyOrder(ctx context.Context, message *Posi
 		}
 
 		w.incrementUpdatedCount()
-		return "position_update", nil
+		return PositionOperationUpdate, nil
+	}
+}
+
+// waitForActivePosition looks up the active position for a symbol, retrying until
+// PositionConsistencyTimeout elapses to cover read-after-write visibility lag.
+// Returns nil without error when the position never becomes visible.
+// Duplicate active positions in the symbol are consolidated first when a consolidator is configured.
+func (w *PositionUpdateWorker) waitForActivePosition(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error) {
+	pollInterval := w.config.PositionConsistencyPoll
+	if pollInterval <= 0 {
+		pollInterval = 100 * time.Millisecond
+	}
+	deadline := time.Now().Add(w.config.PositionConsistencyTimeout)
+	consolidated := false
+
+	for {
+		//TODO: create repo method to fetch only one position instead of all positions
+		positions, err := w.positionRepository.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find existing positions: %w", err)
+		}
+
+		matches := make([]*domain.Position, 0, 1)
+		for _, pos := range positions {
+			if pos.Symbol == symbol && pos.Status == domain.PositionStatusActive {
+				matches = append(matches, pos)
+			}
+		}
+
+		if len(matches) > 1 && w.consolidateUC != nil && !consolidated {
+			consolidated = true
+			log.Printf("Position worker %s: Found %d active %s positions for user %s, consolidating",
+				w.id, len(matches), symbol, userID)
+			result, err := w.consolidateUC.Execute(ctx, userID.String())
+			if err != nil {
+				return nil, fmt.Errorf("failed to consolidate duplicate %s positions: %w", symbol, err)
+			}
+			if result.Failed > 0 {
+				return nil, fmt.Errorf("failed to consolidate duplicate %s positions: %s", symbol, strings.Join(result.Errors, "; "))
+			}
+			continue
+		}
+
+		if len(matches) > 0 {
+			return matches[0], nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, nil
+		}
+
+		wait := pollInterval
+		if remaining < wait {
+			wait = remaining
+		}
+
+		log.Printf("Position worker %s: Position for symbol %s not visible yet, retrying in %v", w.id, symbol, wait)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for position consistency: %w", ctx.Err())
+		case <-time.After(wait):
+		}
 	}
 }
 
@@ -637,7 +930,7 @@ func (w *PositionUpdateWorker) handleSellOrder(ctx context.Context, message *Pos
 		}
 
 		w.incrementClosedCount()
-		return "position_close", nil
+		return PositionOperationClose, nil
 	} else {
 		// Partial sell - update the position
 		updateCmd := &command.UpdatePositionCommand{
@@ -655,7 +948,7 @@ func (w *PositionUpdateWorker) handleSellOrder(ctx context.Context, message *Pos
 		}
 
 		w.incrementUpdatedCount()
-		return "position_update", nil
+		return PositionOperationUpdate, nil
 	}
 }
 
@@ -723,6 +1016,11 @@ func (w *PositionUpdateWorker) scheduleRetry(message *PositionUpdateMessage, err
 }
 
 func (w *PositionUpdateWorker) parseUserIDToUUID(userIDStr string) (uuid.UUID, error) {
+	return parsePositionUserID(userIDStr)
+}
+
+// parsePositionUserID accepts either a UUID or the integer user IDs used by the order system
+func parsePositionUserID(userIDStr string) (uuid.UUID, error) {
 	parsedUUID, err := uuid.Parse(userIDStr)
 	if err == nil {
 		return parsedUUID, nil
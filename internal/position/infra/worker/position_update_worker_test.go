@@ -3,10 +3,12 @@ package worker
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"HubInvestments/internal/position/application/command"
+	positionUsecase "HubInvestments/internal/position/application/usecase"
 	domain "HubInvestments/internal/position/domain/model"
 	sharedMessaging "HubInvestments/shared/infra/messaging"
 
@@ -134,8 +136,20 @@ func (m *MockPositionRepository) GetTotalInvestmentForUser(ctx context.Context,
 	return 0.0, nil
 }
 
+type MockConsolidatePositionsUseCase struct {
+	ExecuteFunc func(ctx context.Context, userID string) (*positionUsecase.PositionConsolidationResult, error)
+}
+
+func (m *MockConsolidatePositionsUseCase) Execute(ctx context.Context, userID string) (*positionUsecase.PositionConsolidationResult, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, userID)
+	}
+	return &positionUsecase.PositionConsolidationResult{}, nil
+}
+
 type MockMessageHandler struct {
 	PublishWithOptionsFunc func(ctx context.Context, options sharedMessaging.PublishOptions) error
+	ConsumeFunc            func(ctx context.Context, queueName string, handler sharedMessaging.MessageConsumer) error
 }
 
 func (m *MockMessageHandler) Publish(ctx context.Context, queueName string, message []byte) error {
@@ -150,6 +164,9 @@ func (m *MockMessageHandler) PublishWithOptions(ctx context.Context, options sha
 }
 
 func (m *MockMessageHandler) Consume(ctx context.Context, queueName string, handler sharedMessaging.MessageConsumer) error {
+	if m.ConsumeFunc != nil {
+		return m.ConsumeFunc(ctx, queueName, handler)
+	}
 	return nil
 }
 
@@ -361,3 +378,565 @@ func TestHealthStatus_String(t *testing.T) {
 		}
 	}
 }
+
+func TestPositionUpdateWorker_HandleBuyOrder_WaitsForDelayedPosition(t *testing.T) {
+	userID := uuid.New()
+	visibleAt := time.Now().Add(50 * time.Millisecond)
+	existing := &domain.Position{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Symbol:   "AAPL",
+		Quantity: 10,
+		Status:   domain.PositionStatusActive,
+	}
+
+	lookups := 0
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return true, nil
+		},
+		FindByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]*domain.Position, error) {
+			lookups++
+			if time.Now().Before(visibleAt) {
+				return []*domain.Position{}, nil
+			}
+			return []*domain.Position{existing}, nil
+		},
+	}
+
+	var updatedPositionID string
+	updateUC := &MockUpdatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.UpdatePositionCommand) (*command.UpdatePositionResult, error) {
+			updatedPositionID = cmd.PositionID
+			return &command.UpdatePositionResult{PositionID: cmd.PositionID}, nil
+		},
+	}
+
+	config := DefaultPositionWorkerConfig("test-worker")
+	config.PositionConsistencyTimeout = time.Second
+	config.PositionConsistencyPoll = 10 * time.Millisecond
+
+	worker := NewPositionUpdateWorker("test-worker", &MockCreatePositionUseCase{}, updateUC,
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, config)
+
+	message := &PositionUpdateMessage{
+		OrderID:        uuid.New().String(),
+		UserID:         userID.String(),
+		Symbol:         "AAPL",
+		OrderSide:      "BUY",
+		Quantity:       5,
+		ExecutionPrice: 150.0,
+		ExecutedAt:     time.Now(),
+	}
+
+	operationType, err := worker.handleBuyOrder(context.Background(), message)
+	if err != nil {
+		t.Fatalf("Expected delayed position to be found, got error: %v", err)
+	}
+
+	if operationType != "position_update" {
+		t.Errorf("Expected operation type 'position_update', got '%s'", operationType)
+	}
+
+	if updatedPositionID != existing.ID.String() {
+		t.Errorf("Expected update on position %s, got %s", existing.ID, updatedPositionID)
+	}
+
+	if lookups < 2 {
+		t.Errorf("Expected multiple lookups while waiting for consistency, got %d", lookups)
+	}
+}
+
+func TestPositionUpdateWorker_HandleBuyOrder_ConsolidatesDuplicatePositions(t *testing.T) {
+	userID := uuid.New()
+	survivor := &domain.Position{ID: uuid.New(), UserID: userID, Symbol: "AAPL", Quantity: 10, Status: domain.PositionStatusActive}
+	duplicate := &domain.Position{ID: uuid.New(), UserID: userID, Symbol: "AAPL", Quantity: 5, Status: domain.PositionStatusActive}
+
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return true, nil
+		},
+		FindByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]*domain.Position, error) {
+			return []*domain.Position{duplicate, survivor}, nil
+		},
+	}
+
+	consolidations := 0
+	consolidateUC := &MockConsolidatePositionsUseCase{
+		ExecuteFunc: func(ctx context.Context, id string) (*positionUsecase.PositionConsolidationResult, error) {
+			consolidations++
+			if id != userID.String() {
+				t.Errorf("Expected consolidation for user %s, got %s", userID, id)
+			}
+			survivor.Quantity += duplicate.Quantity
+			duplicate.Quantity = 0
+			duplicate.Status = domain.PositionStatusClosed
+			return &positionUsecase.PositionConsolidationResult{UserID: userID}, nil
+		},
+	}
+
+	var updatedPositionID string
+	updateUC := &MockUpdatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.UpdatePositionCommand) (*command.UpdatePositionResult, error) {
+			updatedPositionID = cmd.PositionID
+			return &command.UpdatePositionResult{PositionID: cmd.PositionID}, nil
+		},
+	}
+
+	worker := NewPositionUpdateWorkerWithConsolidation("test-worker", &MockCreatePositionUseCase{}, updateUC,
+		&MockClosePositionUseCase{}, consolidateUC, positionRepo, &MockMessageHandler{}, nil)
+
+	message := &PositionUpdateMessage{
+		OrderID:        uuid.New().String(),
+		UserID:         userID.String(),
+		Symbol:         "AAPL",
+		OrderSide:      "BUY",
+		Quantity:       5,
+		ExecutionPrice: 150.0,
+		ExecutedAt:     time.Now(),
+	}
+
+	if _, err := worker.handleBuyOrder(context.Background(), message); err != nil {
+		t.Fatalf("Expected buy to succeed, got error: %v", err)
+	}
+
+	if consolidations != 1 {
+		t.Errorf("Expected duplicates to be consolidated once, got %d", consolidations)
+	}
+
+	if updatedPositionID != survivor.ID.String() {
+		t.Errorf("Expected update on consolidated position %s, got %s", survivor.ID, updatedPositionID)
+	}
+}
+
+func TestPositionUpdateWorker_HandleBuyOrder_MissingPositionFailsAfterTimeout(t *testing.T) {
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return true, nil
+		},
+		FindByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]*domain.Position, error) {
+			return []*domain.Position{}, nil
+		},
+	}
+
+	config := DefaultPositionWorkerConfig("test-worker")
+	config.PositionConsistencyTimeout = 80 * time.Millisecond
+	config.PositionConsistencyPoll = 10 * time.Millisecond
+
+	worker := NewPositionUpdateWorker("test-worker", &MockCreatePositionUseCase{}, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, config)
+
+	message := &PositionUpdateMessage{
+		OrderID:        uuid.New().String(),
+		UserID:         uuid.New().String(),
+		Symbol:         "AAPL",
+		OrderSide:      "BUY",
+		Quantity:       5,
+		ExecutionPrice: 150.0,
+		ExecutedAt:     time.Now(),
+	}
+
+	start := time.Now()
+	_, err := worker.handleBuyOrder(context.Background(), message)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("Expected error for missing position, got nil")
+	}
+
+	if elapsed < config.PositionConsistencyTimeout {
+		t.Errorf("Expected to wait at least %v before failing, waited %v", config.PositionConsistencyTimeout, elapsed)
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_DeduplicatesBySequence(t *testing.T) {
+	creates := 0
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			creates++
+			return &command.CreatePositionResult{PositionID: uuid.New().String()}, nil
+		},
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	worker := NewPositionUpdateWorker("test-worker", createUC, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, nil)
+
+	orderID := uuid.New().String()
+	newMessage := func(sequence int64) *PositionUpdateMessage {
+		return &PositionUpdateMessage{
+			SequenceNumber: sequence,
+			OrderID:        orderID,
+			UserID:         uuid.New().String(),
+			Symbol:         "AAPL",
+			OrderSide:      "BUY",
+			Quantity:       10,
+			ExecutionPrice: 150.0,
+			ExecutedAt:     time.Now(),
+		}
+	}
+
+	if err := worker.processPositionUpdateMessage(context.Background(), newMessage(20)); err != nil {
+		t.Fatalf("Expected sequence 20 to apply, got error: %v", err)
+	}
+	if err := worker.processPositionUpdateMessage(context.Background(), newMessage(20)); err != nil {
+		t.Fatalf("Expected redelivery to be discarded without error, got: %v", err)
+	}
+	if creates != 1 {
+		t.Errorf("Expected the redelivered sequence to be discarded, got %d applications", creates)
+	}
+
+	// An earlier fill of the same order arriving late is a different update and must still apply
+	if err := worker.processPositionUpdateMessage(context.Background(), newMessage(10)); err != nil {
+		t.Fatalf("Expected lower sequence to apply, got error: %v", err)
+	}
+	if creates != 2 {
+		t.Errorf("Expected the lower sequence to be applied, got %d applications", creates)
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_ConcurrentRedeliveriesApplyOnce(t *testing.T) {
+	var mu sync.Mutex
+	creates := 0
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			creates++
+			mu.Unlock()
+			return &command.CreatePositionResult{PositionID: uuid.New().String()}, nil
+		},
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	config := DefaultPositionWorkerConfig("test-worker")
+	config.SerializePositionUpdates = false
+	config.ExecutionOrderWindow = 0
+	worker := NewPositionUpdateWorker("test-worker", createUC, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, config)
+
+	message := &PositionUpdateMessage{
+		SequenceNumber: 7,
+		OrderID:        uuid.New().String(),
+		UserID:         uuid.New().String(),
+		Symbol:         "AAPL",
+		OrderSide:      "BUY",
+		Quantity:       10,
+		ExecutionPrice: 150.0,
+		ExecutedAt:     time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := worker.processPositionUpdateMessage(context.Background(), message); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if creates != 1 {
+		t.Errorf("Expected concurrent redeliveries to apply once, got %d applications", creates)
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_FailedSequenceCanBeRetried(t *testing.T) {
+	attempts := 0
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, fmt.Errorf("validation failed")
+			}
+			return &command.CreatePositionResult{PositionID: uuid.New().String()}, nil
+		},
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	worker := NewPositionUpdateWorker("test-worker", createUC, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, nil)
+
+	message := &PositionUpdateMessage{
+		SequenceNumber: 5,
+		OrderID:        uuid.New().String(),
+		UserID:         uuid.New().String(),
+		Symbol:         "AAPL",
+		OrderSide:      "BUY",
+		Quantity:       10,
+		ExecutionPrice: 150.0,
+		ExecutedAt:     time.Now(),
+	}
+
+	if err := worker.processPositionUpdateMessage(context.Background(), message); err == nil {
+		t.Fatal("Expected first attempt to fail")
+	}
+	if err := worker.processPositionUpdateMessage(context.Background(), message); err != nil {
+		t.Fatalf("Expected retry of the same sequence to apply, got error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_AppliesFreshMessage(t *testing.T) {
+	creates := 0
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			creates++
+			return &command.CreatePositionResult{PositionID: uuid.New().String()}, nil
+		},
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return false, nil
+		},
+	}
+	var published []sharedMessaging.PublishOptions
+	messageHandler := &MockMessageHandler{
+		PublishWithOptionsFunc: func(ctx context.Context, options sharedMessaging.PublishOptions) error {
+			published = append(published, options)
+			return nil
+		},
+	}
+
+	worker := NewPositionUpdateWorker("test-worker", createUC, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, positionRepo, messageHandler, nil)
+
+	message := &PositionUpdateMessage{
+		OrderID:         uuid.New().String(),
+		UserID:          uuid.New().String(),
+		Symbol:          "AAPL",
+		OrderSide:       "BUY",
+		Quantity:        10,
+		ExecutionPrice:  150.0,
+		ExecutedAt:      time.Now().Add(-time.Minute),
+		MessageMetadata: PositionUpdateMessageMetadata{MessageID: "msg-fresh", Timestamp: time.Now()},
+	}
+
+	if err := worker.processPositionUpdateMessage(context.Background(), message); err != nil {
+		t.Fatalf("Expected fresh message to apply, got error: %v", err)
+	}
+	if creates != 1 {
+		t.Errorf("Expected fresh message to be applied once, got %d applications", creates)
+	}
+	if len(published) != 0 {
+		t.Errorf("Expected no messages routed to review, got %d", len(published))
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_DivertsOverAgeMessage(t *testing.T) {
+	creates := 0
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			creates++
+			return &command.CreatePositionResult{PositionID: uuid.New().String()}, nil
+		},
+	}
+	var published []sharedMessaging.PublishOptions
+	messageHandler := &MockMessageHandler{
+		PublishWithOptionsFunc: func(ctx context.Context, options sharedMessaging.PublishOptions) error {
+			published = append(published, options)
+			return nil
+		},
+	}
+
+	config := DefaultPositionWorkerConfig("test-worker")
+	config.MaxMessageAge = 10 * time.Minute
+	worker := NewPositionUpdateWorker("test-worker", createUC, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, &MockPositionRepository{}, messageHandler, config)
+
+	message := &PositionUpdateMessage{
+		OrderID:         uuid.New().String(),
+		UserID:          uuid.New().String(),
+		Symbol:          "AAPL",
+		OrderSide:       "BUY",
+		Quantity:        10,
+		ExecutionPrice:  150.0,
+		ExecutedAt:      time.Now().Add(-2 * time.Hour),
+		MessageMetadata: PositionUpdateMessageMetadata{MessageID: "msg-stale", Timestamp: time.Now().Add(-time.Hour)},
+	}
+
+	if err := worker.processPositionUpdateMessage(context.Background(), message); err != nil {
+		t.Fatalf("Expected over-age message to be diverted without error, got: %v", err)
+	}
+	if creates != 0 {
+		t.Errorf("Expected over-age message not to be applied, got %d applications", creates)
+	}
+	if len(published) != 1 {
+		t.Fatalf("Expected 1 message routed to review, got %d", len(published))
+	}
+	if published[0].QueueName != "positions.review" {
+		t.Errorf("Expected message routed to positions.review, got %s", published[0].QueueName)
+	}
+	if published[0].MessageID != "msg-stale" {
+		t.Errorf("Expected message ID msg-stale, got %s", published[0].MessageID)
+	}
+}
+
+func TestPositionUpdateWorker_ProcessMessage_SerializesConcurrentUpdatesForSamePosition(t *testing.T) {
+	userID := uuid.New()
+	position := &domain.Position{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Symbol:   "AAPL",
+		Quantity: 100,
+		Status:   domain.PositionStatusActive,
+	}
+
+	// The stored quantity is read and written in separate steps, like a repository round trip,
+	// so overlapping updates for the same position would lose one another
+	var stateMu sync.Mutex
+	readQuantity := func() float64 {
+		stateMu.Lock()
+		defer stateMu.Unlock()
+		return position.Quantity
+	}
+	writeQuantity := func(quantity float64) {
+		stateMu.Lock()
+		defer stateMu.Unlock()
+		position.Quantity = quantity
+	}
+
+	updateUC := &MockUpdatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.UpdatePositionCommand) (*command.UpdatePositionResult, error) {
+			current := readQuantity()
+			time.Sleep(time.Millisecond)
+			if cmd.IsBuyOrder {
+				current += cmd.TradeQuantity
+			} else {
+				current -= cmd.TradeQuantity
+			}
+			writeQuantity(current)
+			return &command.UpdatePositionResult{PositionID: cmd.PositionID, NewQuantity: current}, nil
+		},
+	}
+	snapshot := func() *domain.Position {
+		return &domain.Position{ID: position.ID, UserID: userID, Symbol: "AAPL", Quantity: readQuantity(), Status: domain.PositionStatusActive}
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return true, nil
+		},
+		FindByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]*domain.Position, error) {
+			return []*domain.Position{snapshot()}, nil
+		},
+		FindByUserIDAndSymbolFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error) {
+			return snapshot(), nil
+		},
+	}
+
+	worker := NewPositionUpdateWorker("test-worker", &MockCreatePositionUseCase{}, updateUC,
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, nil)
+
+	const buys, sells = 20, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, buys+sells)
+	for i := 0; i < buys+sells; i++ {
+		side := "BUY"
+		if i%3 == 2 {
+			side = "SELL"
+		}
+		message := &PositionUpdateMessage{
+			OrderID:         uuid.New().String(),
+			UserID:          userID.String(),
+			Symbol:          "AAPL",
+			OrderSide:       side,
+			Quantity:        5,
+			ExecutionPrice:  150.0,
+			ExecutedAt:      time.Now(),
+			MessageMetadata: PositionUpdateMessageMetadata{MessageID: fmt.Sprintf("msg-%d", i), Timestamp: time.Now()},
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- worker.processPositionUpdateMessage(context.Background(), message)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected every update to apply, got error: %v", err)
+		}
+	}
+
+	expected := 100.0 + buys*5 - sells*5
+	if got := readQuantity(); got != expected {
+		t.Errorf("Expected final quantity %.0f, got %.0f", expected, got)
+	}
+	if remaining := worker.positionLocks.size(); remaining != 0 {
+		t.Errorf("Expected position locks to be released, %d still held", remaining)
+	}
+}
+
+func TestPositionUpdateWorker_RecordsLatencyPerOperation(t *testing.T) {
+	userID := uuid.New()
+	createUC := &MockCreatePositionUseCase{
+		ExecuteFunc: func(ctx context.Context, cmd *command.CreatePositionCommand) (*command.CreatePositionResult, error) {
+			time.Sleep(20 * time.Millisecond) // Slower than the first bucket
+			return &command.CreatePositionResult{PositionID: uuid.New().String()}, nil
+		},
+	}
+	positionRepo := &MockPositionRepository{
+		ExistsForUserFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+			return symbol == "MSFT", nil
+		},
+		FindByUserIDAndSymbolFunc: func(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error) {
+			return domain.NewPosition(userID, symbol, 10, 300, domain.PositionTypeLong)
+		},
+	}
+
+	config := DefaultPositionWorkerConfig("test-worker")
+	config.ExecutionOrderWindow = 0
+	config.OperationLatencyBuckets = []time.Duration{time.Second, 10 * time.Millisecond}
+	worker := NewPositionUpdateWorker("test-worker", createUC, &MockUpdatePositionUseCase{},
+		&MockClosePositionUseCase{}, positionRepo, &MockMessageHandler{}, config)
+
+	for _, message := range []*PositionUpdateMessage{
+		{OrderID: uuid.New().String(), UserID: userID.String(), Symbol: "AAPL", OrderSide: "BUY", Quantity: 10, ExecutionPrice: 150},
+		{OrderID: uuid.New().String(), UserID: userID.String(), Symbol: "MSFT", OrderSide: "SELL", Quantity: 10, ExecutionPrice: 310},
+	} {
+		if err := worker.processPositionUpdateMessage(context.Background(), message); err != nil {
+			t.Fatalf("Failed to process %s message: %v", message.OrderSide, err)
+		}
+	}
+
+	latencies := make(map[string]OperationLatencySnapshot)
+	for _, latency := range worker.GetMetrics().OperationLatency {
+		latencies[latency.Operation] = latency
+	}
+
+	create := latencies[PositionOperationCreate]
+	if create.Count != 1 || create.Last < 20*time.Millisecond {
+		t.Errorf("Expected one create of at least 20ms, got %+v", create)
+	}
+	if create.Buckets[0].UpperBound != 10*time.Millisecond || create.Buckets[0].Count != 0 || create.Buckets[1].Count != 1 {
+		t.Errorf("Expected the create in the 1s bucket only, got %+v", create.Buckets)
+	}
+
+	closeLatency := latencies[PositionOperationClose]
+	if closeLatency.Count != 1 || closeLatency.Buckets[1].Count != 1 {
+		t.Errorf("Expected one close, got %+v", closeLatency)
+	}
+
+	if update := latencies[PositionOperationUpdate]; update.Count != 0 {
+		t.Errorf("Expected no updates, got %d", update.Count)
+	}
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"HubInvestments/internal/position/application/command"
+	usecase "HubInvestments/internal/position/application/usecase"
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CreatePnLAlertRequest defines an alert on the unrealized P&L of the user's position in a symbol
+type CreatePnLAlertRequest struct {
+	Symbol        string  `json:"symbol" example:"PETR4"`
+	Direction     string  `json:"direction" example:"LOSS"`         // GAIN or LOSS
+	ThresholdType string  `json:"threshold_type" example:"PERCENT"` // PERCENT of the investment or absolute AMOUNT
+	Threshold     float64 `json:"threshold" example:"10"`
+}
+
+// PnLAlerts handles listing and creating the user's P&L alerts
+// @Summary List or Create P&L Alerts
+// @Description GET lists the user's position P&L alerts. POST defines an alert that fires once when the position's unrealized P&L crosses the gain or loss threshold, and stays quiet until it is reset.
+// @Tags Positions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreatePnLAlertRequest false "Alert definition (POST only)"
+// @Success 200 {array} domain.PnLAlert "P&L alerts retrieved successfully"
+// @Success 201 {object} domain.PnLAlert "P&L alert created successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request - Invalid alert definition"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "P&L alerts unavailable"
+// @Router /positions/alerts [get]
+// @Router /positions/alerts [post]
+func PnLAlerts(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	useCase := container.GetManagePnLAlertsUseCase()
+	if useCase == nil {
+		http.Error(w, "P&L alerts are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		alerts, err := useCase.List(r.Context(), userId)
+		if err != nil {
+			http.Error(w, "Failed to list P&L alerts: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(alerts)
+
+	case http.MethodPost:
+		var req CreatePnLAlertRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+			return
+		}
+
+		alert, err := useCase.Create(r.Context(), &command.CreatePnLAlertCommand{
+			UserID:        userId,
+			Symbol:        req.Symbol,
+			Direction:     req.Direction,
+			ThresholdType: req.ThresholdType,
+			Threshold:     req.Threshold,
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), "invalid") {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to create P&L alert: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(alert)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// PnLAlertsWithAuth returns a handler wrapped with authentication middleware
+func PnLAlertsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		PnLAlerts(w, r, userId, container)
+	})
+}
+
+// parsePnLAlertPath extracts the alert ID and action from /positions/alerts/{id}[/reset]
+func parsePnLAlertPath(path string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "positions" || parts[1] != "alerts" || parts[2] == "" {
+		return "", "", false
+	}
+	if len(parts) == 4 {
+		if parts[3] != "reset" {
+			return "", "", false
+		}
+		return parts[2], parts[3], true
+	}
+	return parts[2], "", true
+}
+
+// PnLAlert handles resetting and deleting one of the user's P&L alerts
+// @Summary Reset or Delete a P&L Alert
+// @Description POST /positions/alerts/{id}/reset re-arms a fired alert so the next crossing fires it again. DELETE /positions/alerts/{id} removes the alert.
+// @Tags Positions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Success 200 {object} domain.PnLAlert "P&L alert reset successfully"
+// @Success 204 "P&L alert deleted successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} response.ErrorResponse "P&L alert not found"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "P&L alerts unavailable"
+// @Router /positions/alerts/{id}/reset [post]
+// @Router /positions/alerts/{id} [delete]
+func PnLAlert(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	alertID, action, ok := parsePnLAlertPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	useCase := container.GetManagePnLAlertsUseCase()
+	if useCase == nil {
+		http.Error(w, "P&L alerts are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch {
+	case action == "reset" && r.Method == http.MethodPost:
+		alert, err := useCase.Reset(r.Context(), userId, alertID)
+		if err != nil {
+			writePnLAlertError(w, "Failed to reset P&L alert", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(alert)
+
+	case action == "" && r.Method == http.MethodDelete:
+		if err := useCase.Delete(r.Context(), userId, alertID); err != nil {
+			writePnLAlertError(w, "Failed to delete P&L alert", err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writePnLAlertError(w http.ResponseWriter, message string, err error) {
+	if errors.Is(err, usecase.ErrPnLAlertNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, message+": "+err.Error(), http.StatusInternalServerError)
+}
+
+// PnLAlertWithAuth returns a handler wrapped with authentication middleware
+func PnLAlertWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		PnLAlert(w, r, userId, container)
+	})
+}
+
+// PnLAlertStream pushes the user's fired P&L alerts over a WebSocket
+// @Summary Stream P&L Alerts
+// @Description Upgrade to a WebSocket that receives a pnl_alert message whenever one of the user's P&L alerts fires as positions are revalued from realtime quotes.
+// @Tags Positions
+// @Security BearerAuth
+// @Success 101 "Switching protocols"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 503 {object} response.ErrorResponse "P&L alert streaming unavailable"
+// @Router /positions/alerts/stream [get]
+func PnLAlertStream(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	notifier := container.GetPnLAlertNotifier()
+	webSocketManager := container.GetWebSocketManager()
+	if notifier == nil || webSocketManager == nil {
+		http.Error(w, "P&L alert streaming is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	userUUID, err := usecase.ParseUserID(userId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := webSocketManager.CreateConnection(w, r)
+	if err != nil {
+		log.Printf("Failed to open P&L alert stream for user %s: %v", userId, err)
+		return
+	}
+	defer conn.Close()
+
+	connectionID := uuid.New().String()
+	notifier.Connect(userUUID, connectionID, conn)
+	defer notifier.Disconnect(userUUID, connectionID)
+
+	// Alerts only flow to the client; reading detects when it goes away
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// PnLAlertStreamWithAuth returns a handler wrapped with authentication middleware
+func PnLAlertStreamWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		PnLAlertStream(w, r, userId, container)
+	})
+}
@@ -1,11 +1,15 @@
 package http
 
 import (
+	usecase "HubInvestments/internal/position/application/usecase"
 	di "HubInvestments/pck"
 	"HubInvestments/shared/middleware"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // GetAucAggregation handles position aggregation retrieval for authenticated users
@@ -42,3 +46,139 @@ func GetAucAggregationWithAuth(verifyToken middleware.TokenVerifier, container d
 		GetAucAggregation(w, r, userId, container)
 	})
 }
+
+// GetClosePreview handles previewing the close of a position without executing it
+// @Summary Preview Position Close
+// @Description Compute the realized gain/loss of closing some or all of a position and, under FIFO lot matching, the tax lots the close would consume
+// @Tags Positions
+// @Produce json
+// @Security BearerAuth
+// @Param symbol path string true "Position symbol"
+// @Param quantity query number true "Quantity to close"
+// @Param price query number false "Close price (default: the position's current price)"
+// @Success 200 {object} domain.PositionClosePreview "Close preview computed successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request - Invalid quantity or price"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 404 {object} response.ErrorResponse "No open position in the symbol"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /positions/{symbol}/close-preview [get]
+func GetClosePreview(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract symbol from path like "/positions/{symbol}/close-preview"
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "positions" || parts[1] == "" || parts[2] != "close-preview" {
+		http.Error(w, "Expected path format: /positions/{symbol}/close-preview", http.StatusBadRequest)
+		return
+	}
+
+	quantity, err := strconv.ParseFloat(r.URL.Query().Get("quantity"), 64)
+	if err != nil || quantity <= 0 {
+		http.Error(w, "quantity must be a number greater than 0", http.StatusBadRequest)
+		return
+	}
+
+	var closePrice *float64
+	if priceParam := r.URL.Query().Get("price"); priceParam != "" {
+		price, err := strconv.ParseFloat(priceParam, 64)
+		if err != nil || price <= 0 {
+			http.Error(w, "price must be a number greater than 0", http.StatusBadRequest)
+			return
+		}
+		closePrice = &price
+	}
+
+	preview, err := container.GetClosePreviewUseCase().Execute(r.Context(), userId, parts[1], quantity, closePrice)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClosePreviewPositionNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, usecase.ErrInvalidClosePreview):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Failed to preview position close: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+// GetClosePreviewWithAuth returns a handler wrapped with authentication middleware
+func GetClosePreviewWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		GetClosePreview(w, r, userId, container)
+	})
+}
+
+// RevaluePositions handles on-demand revaluation of the user's positions at live prices
+// @Summary Revalue Positions
+// @Description Value the user's active positions at live market prices and return market values and unrealized P&L. Nothing is persisted; end-of-day marks remain the stored valuation.
+// @Tags Positions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.PortfolioRevaluation "Positions revalued successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /positions/revalue [post]
+func RevaluePositions(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	revaluation, err := container.GetRevaluePositionsUseCase().Execute(r.Context(), userId)
+	if err != nil {
+		http.Error(w, "Failed to revalue positions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(revaluation)
+}
+
+// RevaluePositionsWithAuth returns a handler wrapped with authentication middleware
+func RevaluePositionsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		RevaluePositions(w, r, userId, container)
+	})
+}
+
+// GetDailyPnL handles the account's daily P&L summary
+// @Summary Get Daily P&L
+// @Description Sum the P&L realized by today's trades with the change in unrealized P&L of open positions at live prices since the prior end-of-day mark
+// @Tags Positions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.AccountDailyPnL "Daily P&L retrieved successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 405 {object} response.ErrorResponse "Method not allowed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /account/daily-pnl [get]
+func GetDailyPnL(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	dailyPnL, err := container.GetDailyPnLUseCase().Execute(r.Context(), userId)
+	if err != nil {
+		http.Error(w, "Failed to get daily P&L: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dailyPnL)
+}
+
+// GetDailyPnLWithAuth returns a handler wrapped with authentication middleware
+func GetDailyPnLWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		GetDailyPnL(w, r, userId, container)
+	})
+}
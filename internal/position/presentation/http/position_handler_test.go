@@ -680,3 +680,146 @@ func TestGetAucAggregation_JSONMarshalError(t *testing.T) {
 	errorMessage := rr.Body.String()
 	assert.NotEmpty(t, errorMessage)
 }
+
+func TestGetClosePreview_Success(t *testing.T) {
+	testUUID := uuid.New()
+
+	mockRepo := &MockPositionRepository{}
+	mockRepo.addAssetModels([]domain.AssetModel{
+		{Symbol: "AAPL", Category: 1, AveragePrice: 150, LastPrice: 160, Quantity: 10},
+	}, testUUID)
+
+	closePreviewUseCase := usecase.NewGetClosePreviewUseCase(mockRepo, nil, usecase.ClosePreviewConfig{
+		LotMatchingMethod: domain.LotMatchingAverageCost,
+	})
+	testContainer := di.NewTestContainer().WithClosePreviewUseCase(closePreviewUseCase)
+
+	req, err := http.NewRequest("GET", "/positions/AAPL/close-preview?quantity=4", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	GetClosePreview(rr, req, testUUID.String(), testContainer)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response domain.PositionClosePreview
+	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", response.Symbol)
+	assert.Equal(t, float64(640), response.Proceeds)
+	assert.Equal(t, float64(600), response.CostBasis)
+	assert.Equal(t, float64(40), response.RealizedPnL)
+	assert.Equal(t, float64(6), response.RemainingQuantity)
+	assert.False(t, response.FullClose)
+}
+
+func TestGetClosePreview_InvalidQuantity(t *testing.T) {
+	testUUID := uuid.New()
+
+	mockRepo := &MockPositionRepository{}
+	mockRepo.addAssetModels([]domain.AssetModel{
+		{Symbol: "AAPL", Category: 1, AveragePrice: 150, LastPrice: 160, Quantity: 10},
+	}, testUUID)
+
+	closePreviewUseCase := usecase.NewGetClosePreviewUseCase(mockRepo, nil, usecase.ClosePreviewConfig{})
+	testContainer := di.NewTestContainer().WithClosePreviewUseCase(closePreviewUseCase)
+
+	for _, query := range []string{"", "?quantity=abc", "?quantity=0", "?quantity=11"} {
+		req, err := http.NewRequest("GET", "/positions/AAPL/close-preview"+query, nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		GetClosePreview(rr, req, testUUID.String(), testContainer)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "query %q", query)
+	}
+}
+
+func TestRevaluePositions_Success(t *testing.T) {
+	testUUID := uuid.New()
+
+	mockRepo := &MockPositionRepository{}
+	mockRepo.addAssetModels([]domain.AssetModel{
+		{Symbol: "AAPL", Category: 1, AveragePrice: 150, LastPrice: 160, Quantity: 10},
+	}, testUUID)
+
+	// Without a market data client positions are valued at their last known price
+	revalueUseCase := usecase.NewRevaluePositionsUseCase(mockRepo, nil, usecase.DefaultRevaluePositionsConfig())
+	testContainer := di.NewTestContainer().WithRevaluePositionsUseCase(revalueUseCase)
+
+	req, err := http.NewRequest("POST", "/positions/revalue", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	RevaluePositions(rr, req, testUUID.String(), testContainer)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response domain.PortfolioRevaluation
+	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(response.Positions))
+	assert.Equal(t, float64(1600), response.TotalMarketValue)
+	assert.Equal(t, float64(100), response.TotalUnrealizedPnL)
+	assert.False(t, response.Positions[0].LivePrice)
+}
+
+func TestRevaluePositions_MethodNotAllowed(t *testing.T) {
+	testContainer := di.NewTestContainer()
+
+	req, err := http.NewRequest("GET", "/positions/revalue", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	RevaluePositions(rr, req, uuid.New().String(), testContainer)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+type stubDailyPnLUseCase struct {
+	result *domain.AccountDailyPnL
+	userID string
+}
+
+func (s *stubDailyPnLUseCase) Execute(ctx context.Context, userID string) (*domain.AccountDailyPnL, error) {
+	s.userID = userID
+	return s.result, nil
+}
+
+func TestGetDailyPnL_Success(t *testing.T) {
+	testUUID := uuid.New()
+	dailyPnLUseCase := &stubDailyPnLUseCase{result: &domain.AccountDailyPnL{
+		UserID:           testUUID,
+		RealizedPnL:      80,
+		UnrealizedChange: -142,
+		DailyPnL:         -62,
+	}}
+	testContainer := di.NewTestContainer().WithDailyPnLUseCase(dailyPnLUseCase)
+
+	req, err := http.NewRequest("GET", "/account/daily-pnl", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	GetDailyPnL(rr, req, testUUID.String(), testContainer)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, testUUID.String(), dailyPnLUseCase.userID)
+
+	var response domain.AccountDailyPnL
+	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(80), response.RealizedPnL)
+	assert.Equal(t, float64(-62), response.DailyPnL)
+}
+
+func TestGetDailyPnL_MethodNotAllowed(t *testing.T) {
+	testContainer := di.NewTestContainer()
+
+	req, err := http.NewRequest("POST", "/account/daily-pnl", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	GetDailyPnL(rr, req, uuid.New().String(), testContainer)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetReconciliationReport exports the position reconciliation discrepancies for audit
+// @Summary Export Position Reconciliation Report
+// @Description Replay executed orders without writing positions and list every user/symbol whose stored position differs, with the expected and actual quantity and cost basis and the proposed correction
+// @Tags Positions
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param format query string false "Report format: json (default) or csv"
+// @Param user_id query string false "Only reconcile this user's positions"
+// @Param since query string false "Replay orders executed at or after this RFC3339 timestamp (default: the configured lookback)"
+// @Success 200 {object} worker.PositionReconciliationReport "Reconciliation report generated"
+// @Failure 400 {object} response.ErrorResponse "Bad request - Invalid format or since"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "Reconciliation reports are not available"
+// @Router /admin/positions/reconcile/report [get]
+func GetReconciliationReport(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !middleware.IsAdmin(userId) {
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	format := strings.ToLower(query.Get("format"))
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	var since time.Time
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			http.Error(w, "since must be an RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	reporter := container.GetPositionReconciliationReporter()
+	if reporter == nil {
+		http.Error(w, "Position reconciliation reports are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	report, err := reporter.Generate(r.Context(), query.Get("user_id"), since)
+	if err != nil {
+		http.Error(w, "Failed to generate reconciliation report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"position-reconciliation-%s.csv\"",
+			report.GeneratedAt.UTC().Format("20060102T150405Z")))
+		if err := report.WriteCSV(w); err != nil {
+			fmt.Printf("Warning: Failed to write reconciliation report: %v\n", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// GetReconciliationReportWithAuth returns a handler wrapped with authentication middleware
+func GetReconciliationReportWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		GetReconciliationReport(w, r, userId, container)
+	})
+}
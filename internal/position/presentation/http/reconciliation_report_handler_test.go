@@ -0,0 +1,149 @@
+package http
+
+import (
+	domain "HubInvestments/internal/position/domain/model"
+	"HubInvestments/internal/position/infra/worker"
+	di "HubInvestments/pck"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubExecutedOrderEventSource struct {
+	events []*worker.PositionUpdateMessage
+}
+
+func (s *stubExecutedOrderEventSource) FindExecutedOrderEventsSince(ctx context.Context, since time.Time) ([]*worker.PositionUpdateMessage, error) {
+	return s.events, nil
+}
+
+type stubReplayPositionStore struct {
+	positions map[string]*domain.Position
+}
+
+func (s *stubReplayPositionStore) ExistsForUser(ctx context.Context, userID uuid.UUID, symbol string) (bool, error) {
+	_, ok := s.positions[userID.String()+"|"+symbol]
+	return ok, nil
+}
+
+func (s *stubReplayPositionStore) FindByUserIDAndSymbol(ctx context.Context, userID uuid.UUID, symbol string) (*domain.Position, error) {
+	return s.positions[userID.String()+"|"+symbol], nil
+}
+
+func (s *stubReplayPositionStore) Save(ctx context.Context, position *domain.Position) error {
+	return nil
+}
+
+func (s *stubReplayPositionStore) Update(ctx context.Context, position *domain.Position) error {
+	return nil
+}
+
+var reconciliationSince = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// newSeededReconciliationContainer stores 12 AAPL @ 100 while the executed orders only add up to 10 @ 100
+func newSeededReconciliationContainer(t *testing.T) di.Container {
+	userUUID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	stored, err := domain.NewPosition(userUUID, "AAPL", 12, 100, domain.PositionTypeLong)
+	require.NoError(t, err)
+
+	source := &stubExecutedOrderEventSource{events: []*worker.PositionUpdateMessage{{
+		OrderID:        "order-1",
+		UserID:         "1",
+		Symbol:         "AAPL",
+		OrderSide:      "BUY",
+		OrderType:      "MARKET",
+		Quantity:       10,
+		ExecutionPrice: 100,
+		ExecutedAt:     reconciliationSince.Add(time.Hour),
+	}}}
+	store := &stubReplayPositionStore{positions: map[string]*domain.Position{userUUID.String() + "|AAPL": stored}}
+
+	reporter := worker.NewPositionReconciliationReporter(worker.NewPositionReplayer(source, store),
+		worker.DefaultPositionReconciliationReportConfig())
+	return di.NewTestContainer().WithPositionReconciliationReporter(reporter)
+}
+
+func TestGetReconciliationReport_JSON(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-1")
+	req := httptest.NewRequest(http.MethodGet, "/admin/positions/reconcile/report?since="+reconciliationSince.Format(time.RFC3339), nil)
+	rr := httptest.NewRecorder()
+
+	GetReconciliationReport(rr, req, "admin-1", newSeededReconciliationContainer(t))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var report worker.PositionReconciliationReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	require.Len(t, report.Discrepancies, 1)
+
+	discrepancy := report.Discrepancies[0]
+	assert.Equal(t, "1", discrepancy.UserID)
+	assert.Equal(t, "AAPL", discrepancy.Symbol)
+	assert.Equal(t, 10.0, discrepancy.ExpectedQuantity)
+	assert.Equal(t, 12.0, discrepancy.ActualQuantity)
+	assert.Equal(t, 1000.0, discrepancy.ExpectedCostBasis)
+	assert.Equal(t, 1200.0, discrepancy.ActualCostBasis)
+	assert.Equal(t, worker.PositionReplayChangeUpdate, discrepancy.Correction.Action)
+	assert.Equal(t, -2.0, discrepancy.Correction.QuantityAdjustment)
+	assert.Equal(t, -200.0, discrepancy.Correction.CostBasisAdjustment)
+}
+
+func TestGetReconciliationReport_CSV(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-1")
+	req := httptest.NewRequest(http.MethodGet, "/admin/positions/reconcile/report?format=csv&since="+reconciliationSince.Format(time.RFC3339), nil)
+	rr := httptest.NewRecorder()
+
+	GetReconciliationReport(rr, req, "admin-1", newSeededReconciliationContainer(t))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment")
+
+	rows, err := csv.NewReader(rr.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"1", "AAPL", "10", "12", "1000", "1200", "100", "100", "ACTIVE", "ACTIVE", "UPDATE", "-2", "-200"}, rows[1])
+}
+
+func TestGetReconciliationReport_RequiresAdmin(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-1")
+	req := httptest.NewRequest(http.MethodGet, "/admin/positions/reconcile/report", nil)
+	rr := httptest.NewRecorder()
+
+	GetReconciliationReport(rr, req, "user-1", newSeededReconciliationContainer(t))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestGetReconciliationReport_InvalidParameters(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-1")
+
+	for _, target := range []string{
+		"/admin/positions/reconcile/report?format=xml",
+		"/admin/positions/reconcile/report?since=yesterday",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rr := httptest.NewRecorder()
+
+		GetReconciliationReport(rr, req, "admin-1", newSeededReconciliationContainer(t))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+	}
+}
+
+func TestGetReconciliationReport_Unavailable(t *testing.T) {
+	t.Setenv("ADMIN_USER_IDS", "admin-1")
+	req := httptest.NewRequest(http.MethodGet, "/admin/positions/reconcile/report", nil)
+	rr := httptest.NewRecorder()
+
+	GetReconciliationReport(rr, req, "admin-1", di.NewTestContainer())
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
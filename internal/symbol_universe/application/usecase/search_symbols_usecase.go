@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+	repository "HubInvestments/internal/symbol_universe/domain/repository"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type ISearchSymbolsUseCase interface {
+	Execute(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error)
+}
+
+type SearchSymbolsUseCase struct {
+	repo repository.ISymbolUniverseRepository
+}
+
+func NewSearchSymbolsUseCase(repo repository.ISymbolUniverseRepository) ISearchSymbolsUseCase {
+	return &SearchSymbolsUseCase{repo: repo}
+}
+
+func (uc *SearchSymbolsUseCase) Execute(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query cannot be empty")
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	symbols, err := uc.repo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search symbols: %w", err)
+	}
+
+	return symbols, nil
+}
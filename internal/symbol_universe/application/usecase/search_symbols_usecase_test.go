@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+)
+
+func TestSearchSymbolsUseCase_Execute_QueriesStore(t *testing.T) {
+	var searchedQuery string
+	var searchedLimit int
+	repo := &MockSymbolUniverseRepository{
+		SearchFunc: func(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error) {
+			searchedQuery = query
+			searchedLimit = limit
+			return []*domain.TradeableSymbol{{Symbol: "PETR4", Name: "Petrobras"}}, nil
+		},
+	}
+
+	useCase := NewSearchSymbolsUseCase(repo)
+	symbols, err := useCase.Execute(context.Background(), " petr ", 0)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if searchedQuery != "petr" {
+		t.Errorf("Expected trimmed query petr, got %q", searchedQuery)
+	}
+	if searchedLimit != defaultSearchLimit {
+		t.Errorf("Expected default limit %d, got %d", defaultSearchLimit, searchedLimit)
+	}
+	if len(symbols) != 1 || symbols[0].Symbol != "PETR4" {
+		t.Errorf("Expected PETR4 from store, got %v", symbols)
+	}
+}
+
+func TestSearchSymbolsUseCase_Execute_EmptyQuery(t *testing.T) {
+	useCase := NewSearchSymbolsUseCase(&MockSymbolUniverseRepository{})
+
+	if _, err := useCase.Execute(context.Background(), "  ", 10); err == nil {
+		t.Error("Expected error for empty query")
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"HubInvestments/internal/order_mngmt_system/infra/external"
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+	repository "HubInvestments/internal/symbol_universe/domain/repository"
+)
+
+// IMarketDataProvider defines the interface for reading symbol data from the market-data provider (dependency inversion)
+type IMarketDataProvider interface {
+	GetBatchMarketData(ctx context.Context, symbols []string) ([]external.MarketDataResponse, error)
+}
+
+type ISyncSymbolUniverseUseCase interface {
+	Execute(ctx context.Context, symbols []string) (*SyncSymbolUniverseResult, error)
+}
+
+// SyncSymbolUniverseResult summarizes a sync run
+type SyncSymbolUniverseResult struct {
+	Requested int      `json:"requested"`
+	Upserted  int      `json:"upserted"`
+	Missing   []string `json:"missing"`
+}
+
+type SyncSymbolUniverseUseCase struct {
+	repo     repository.ISymbolUniverseRepository
+	provider IMarketDataProvider
+}
+
+func NewSyncSymbolUniverseUseCase(repo repository.ISymbolUniverseRepository, provider IMarketDataProvider) ISyncSymbolUniverseUseCase {
+	return &SyncSymbolUniverseUseCase{
+		repo:     repo,
+		provider: provider,
+	}
+}
+
+// Execute refreshes the given symbols plus every symbol already in the universe from the provider.
+// Symbols the provider no longer returns are reported as missing and left untouched.
+func (uc *SyncSymbolUniverseUseCase) Execute(ctx context.Context, symbols []string) (*SyncSymbolUniverseResult, error) {
+	existing, err := uc.repo.GetSearchableSymbols(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load symbol universe: %w", err)
+	}
+
+	requested := uniqueSymbols(append(existing, symbols...))
+	result := &SyncSymbolUniverseResult{
+		Requested: len(requested),
+		Missing:   make([]string, 0),
+	}
+	if len(requested) == 0 {
+		return result, nil
+	}
+
+	marketData, err := uc.provider.GetBatchMarketData(ctx, requested)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch symbols from market data provider: %w", err)
+	}
+
+	returned := make(map[string]bool, len(marketData))
+	tradeableSymbols := make([]*domain.TradeableSymbol, 0, len(marketData))
+	for _, data := range marketData {
+		tradeableSymbol, err := domain.NewTradeableSymbol(data.Symbol, data.CompanyName, data.Category, data.LastQuote)
+		if err != nil {
+			continue
+		}
+		returned[tradeableSymbol.Symbol] = true
+		tradeableSymbols = append(tradeableSymbols, tradeableSymbol)
+	}
+
+	for _, symbol := range requested {
+		if !returned[symbol] {
+			result.Missing = append(result.Missing, symbol)
+		}
+	}
+
+	upserted, err := uc.repo.Upsert(ctx, tradeableSymbols)
+	result.Upserted = upserted
+	if err != nil {
+		return result, fmt.Errorf("failed to store symbols: %w", err)
+	}
+
+	return result, nil
+}
+
+func uniqueSymbols(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	result := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		normalized := strings.ToUpper(strings.TrimSpace(symbol))
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+	return result
+}
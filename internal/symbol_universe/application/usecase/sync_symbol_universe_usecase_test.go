@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"HubInvestments/internal/order_mngmt_system/infra/external"
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+)
+
+// MockSymbolUniverseRepository implements ISymbolUniverseRepository for testing
+type MockSymbolUniverseRepository struct {
+	UpsertFunc               func(ctx context.Context, symbols []*domain.TradeableSymbol) (int, error)
+	FindBySymbolFunc         func(ctx context.Context, symbol string) (*domain.TradeableSymbol, error)
+	SearchFunc               func(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error)
+	GetSearchableSymbolsFunc func(ctx context.Context) ([]string, error)
+}
+
+func (m *MockSymbolUniverseRepository) Upsert(ctx context.Context, symbols []*domain.TradeableSymbol) (int, error) {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, symbols)
+	}
+	return len(symbols), nil
+}
+
+func (m *MockSymbolUniverseRepository) FindBySymbol(ctx context.Context, symbol string) (*domain.TradeableSymbol, error) {
+	if m.FindBySymbolFunc != nil {
+		return m.FindBySymbolFunc(ctx, symbol)
+	}
+	return nil, nil
+}
+
+func (m *MockSymbolUniverseRepository) Search(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, query, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockSymbolUniverseRepository) GetSearchableSymbols(ctx context.Context) ([]string, error) {
+	if m.GetSearchableSymbolsFunc != nil {
+		return m.GetSearchableSymbolsFunc(ctx)
+	}
+	return nil, nil
+}
+
+// MockMarketDataProvider implements IMarketDataProvider for testing
+type MockMarketDataProvider struct {
+	GetBatchMarketDataFunc func(ctx context.Context, symbols []string) ([]external.MarketDataResponse, error)
+}
+
+func (m *MockMarketDataProvider) GetBatchMarketData(ctx context.Context, symbols []string) ([]external.MarketDataResponse, error) {
+	if m.GetBatchMarketDataFunc != nil {
+		return m.GetBatchMarketDataFunc(ctx, symbols)
+	}
+	return nil, nil
+}
+
+func TestSyncSymbolUniverseUseCase_Execute_UpsertsProviderSymbols(t *testing.T) {
+	var upserted []*domain.TradeableSymbol
+	repo := &MockSymbolUniverseRepository{
+		GetSearchableSymbolsFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"PETR4"}, nil
+		},
+		UpsertFunc: func(ctx context.Context, symbols []*domain.TradeableSymbol) (int, error) {
+			upserted = symbols
+			return len(symbols), nil
+		},
+	}
+	var requestedSymbols []string
+	provider := &MockMarketDataProvider{
+		GetBatchMarketDataFunc: func(ctx context.Context, symbols []string) ([]external.MarketDataResponse, error) {
+			requestedSymbols = symbols
+			return []external.MarketDataResponse{
+				{Symbol: "PETR4", CompanyName: "Petrobras", LastQuote: 38.5, Category: "1"},
+				{Symbol: "VALE3", CompanyName: "Vale", LastQuote: 61.2, Category: "1"},
+			}, nil
+		},
+	}
+
+	useCase := NewSyncSymbolUniverseUseCase(repo, provider)
+	result, err := useCase.Execute(context.Background(), []string{"vale3", "PETR4", "XXXX3"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(requestedSymbols) != 3 {
+		t.Errorf("Expected 3 unique symbols requested from provider, got %v", requestedSymbols)
+	}
+	if result.Upserted != 2 || len(upserted) != 2 {
+		t.Errorf("Expected 2 symbols upserted, got %d", result.Upserted)
+	}
+	if upserted[1].Symbol != "VALE3" || upserted[1].Name != "Vale" || !upserted[1].IsTradeable {
+		t.Errorf("Expected VALE3 to be stored from provider data, got %+v", upserted[1])
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "XXXX3" {
+		t.Errorf("Expected XXXX3 to be reported missing, got %v", result.Missing)
+	}
+}
+
+func TestSyncSymbolUniverseUseCase_Execute_ProviderError(t *testing.T) {
+	upsertCalled := false
+	repo := &MockSymbolUniverseRepository{
+		UpsertFunc: func(ctx context.Context, symbols []*domain.TradeableSymbol) (int, error) {
+			upsertCalled = true
+			return 0, nil
+		},
+	}
+	provider := &MockMarketDataProvider{
+		GetBatchMarketDataFunc: func(ctx context.Context, symbols []string) ([]external.MarketDataResponse, error) {
+			return nil, errors.New("provider unavailable")
+		},
+	}
+
+	useCase := NewSyncSymbolUniverseUseCase(repo, provider)
+	_, err := useCase.Execute(context.Background(), []string{"PETR4"})
+
+	if err == nil {
+		t.Fatal("Expected error when provider fails")
+	}
+	if upsertCalled {
+		t.Error("Expected store to be left untouched when provider fails")
+	}
+}
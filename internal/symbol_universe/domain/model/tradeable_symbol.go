@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// TradeableSymbol is an entry in the platform's symbol universe
+type TradeableSymbol struct {
+	Symbol      string    `json:"symbol"`
+	Name        string    `json:"name"`
+	Category    string    `json:"category"`
+	LastQuote   float64   `json:"lastQuote"`
+	IsTradeable bool      `json:"isTradeable"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// NewTradeableSymbol creates a symbol entry; symbols are stored upper-case so lookups are case-insensitive
+func NewTradeableSymbol(symbol, name, category string, lastQuote float64) (*TradeableSymbol, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(symbol))
+	if normalized == "" {
+		return nil, errors.New("symbol cannot be empty")
+	}
+
+	return &TradeableSymbol{
+		Symbol:      normalized,
+		Name:        name,
+		Category:    category,
+		LastQuote:   lastQuote,
+		IsTradeable: lastQuote > 0,
+		UpdatedAt:   time.Now(),
+	}, nil
+}
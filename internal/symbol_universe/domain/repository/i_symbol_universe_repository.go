@@ -0,0 +1,18 @@
+package repository
+
+import (
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+	"context"
+)
+
+// ISymbolUniverseRepository defines the interface for the authoritative list of tradeable symbols
+type ISymbolUniverseRepository interface {
+	// Upsert inserts new symbols and refreshes existing ones, returning how many rows were written
+	Upsert(ctx context.Context, symbols []*domain.TradeableSymbol) (int, error)
+	// FindBySymbol returns nil without error when the symbol is not in the universe
+	FindBySymbol(ctx context.Context, symbol string) (*domain.TradeableSymbol, error)
+	// Search returns symbols whose ticker or name contains the query, tickers matching the prefix first
+	Search(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error)
+	// GetSearchableSymbols returns every tradeable ticker in the universe
+	GetSearchableSymbols(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+)
+
+// TradeableSymbolDTO represents the data transfer object for a TradeableSymbol in the database.
+type TradeableSymbolDTO struct {
+	Symbol      string    `db:"symbol"`
+	Name        string    `db:"name"`
+	Category    string    `db:"category"`
+	LastQuote   float64   `db:"last_quote"`
+	IsTradeable bool      `db:"is_tradeable"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToDomain converts a TradeableSymbolDTO to a domain.TradeableSymbol model.
+func (dto *TradeableSymbolDTO) ToDomain() *domain.TradeableSymbol {
+	return &domain.TradeableSymbol{
+		Symbol:      dto.Symbol,
+		Name:        dto.Name,
+		Category:    dto.Category,
+		LastQuote:   dto.LastQuote,
+		IsTradeable: dto.IsTradeable,
+		UpdatedAt:   dto.UpdatedAt,
+	}
+}
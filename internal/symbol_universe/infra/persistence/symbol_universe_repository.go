@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	domain "HubInvestments/internal/symbol_universe/domain/model"
+	repository "HubInvestments/internal/symbol_universe/domain/repository"
+	"HubInvestments/internal/symbol_universe/infra/persistence/dto"
+	"HubInvestments/shared/infra/database"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type SymbolUniverseRepository struct {
+	db database.Database
+}
+
+// NewSymbolUniverseRepository creates a new symbol universe repository using the database abstraction
+func NewSymbolUniverseRepository(db database.Database) repository.ISymbolUniverseRepository {
+	return &SymbolUniverseRepository{db: db}
+}
+
+func (r *SymbolUniverseRepository) Upsert(ctx context.Context, symbols []*domain.TradeableSymbol) (int, error) {
+	query := `
+		INSERT INTO yanrodrigues.symbol_universe (
+			symbol, name, category, last_quote, is_tradeable, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		ON CONFLICT (symbol) DO UPDATE SET
+			name = EXCLUDED.name,
+			category = EXCLUDED.category,
+			last_quote = EXCLUDED.last_quote,
+			is_tradeable = EXCLUDED.is_tradeable,
+			updated_at = EXCLUDED.updated_at`
+
+	upserted := 0
+	for _, symbol := range symbols {
+		_, err := r.db.ExecContext(ctx, query,
+			symbol.Symbol, symbol.Name, symbol.Category,
+			symbol.LastQuote, symbol.IsTradeable, symbol.UpdatedAt)
+		if err != nil {
+			return upserted, fmt.Errorf("failed to upsert symbol %s: %w", symbol.Symbol, err)
+		}
+		upserted++
+	}
+
+	return upserted, nil
+}
+
+func (r *SymbolUniverseRepository) FindBySymbol(ctx context.Context, symbol string) (*domain.TradeableSymbol, error) {
+	query := `
+		SELECT symbol, name, category, last_quote, is_tradeable, updated_at
+		FROM yanrodrigues.symbol_universe
+		WHERE symbol = $1`
+
+	var symbolDTO dto.TradeableSymbolDTO
+	err := r.db.Get(&symbolDTO, query, strings.ToUpper(symbol))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find symbol %s: %w", symbol, err)
+	}
+
+	return symbolDTO.ToDomain(), nil
+}
+
+func (r *SymbolUniverseRepository) Search(ctx context.Context, query string, limit int) ([]*domain.TradeableSymbol, error) {
+	searchQuery := `
+		SELECT symbol, name, category, last_quote, is_tradeable, updated_at
+		FROM yanrodrigues.symbol_universe
+		WHERE symbol ILIKE '%' || $1 || '%' OR name ILIKE '%' || $1 || '%'
+		ORDER BY (symbol ILIKE $1 || '%') DESC, symbol
+		LIMIT $2`
+
+	var symbolDTOs []*dto.TradeableSymbolDTO
+	err := r.db.Select(&symbolDTOs, searchQuery, strings.TrimSpace(query), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search symbols: %w", err)
+	}
+
+	symbols := make([]*domain.TradeableSymbol, 0, len(symbolDTOs))
+	for _, symbolDTO := range symbolDTOs {
+		symbols = append(symbols, symbolDTO.ToDomain())
+	}
+
+	return symbols, nil
+}
+
+func (r *SymbolUniverseRepository) GetSearchableSymbols(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT symbol
+		FROM yanrodrigues.symbol_universe
+		WHERE is_tradeable = TRUE
+		ORDER BY symbol`
+
+	var symbols []string
+	if err := r.db.Select(&symbols, query); err != nil {
+		return nil, fmt.Errorf("failed to list symbols: %w", err)
+	}
+
+	return symbols, nil
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	di "HubInvestments/pck"
+	"HubInvestments/shared/middleware"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type SyncSymbolsRequest struct {
+	Symbols []string `json:"symbols"`
+}
+
+type SearchSymbolsResponse struct {
+	Query   string      `json:"query"`
+	Symbols interface{} `json:"symbols"`
+}
+
+// SyncSymbols refreshes the symbol universe from the market data provider
+// @Summary Sync Symbol Universe
+// @Description Refresh the stored symbol universe from the market data provider. Symbols in the body are added to the universe.
+// @Tags Symbols
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} usecase.SyncSymbolUniverseResult "Symbol universe synced"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/symbols/sync [post]
+func SyncSymbols(w http.ResponseWriter, r *http.Request, userId string, container di.Container) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !middleware.IsAdmin(userId) {
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	var req SyncSymbolsRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Failed to parse request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := container.GetSyncSymbolUniverseUseCase().Execute(context.Background(), req.Symbols)
+	if err != nil {
+		http.Error(w, "Failed to sync symbols: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprint(w, string(response))
+}
+
+// SearchSymbols searches the symbol universe by ticker or company name
+// @Summary Search Symbols
+// @Description Search tradeable symbols by ticker or company name
+// @Tags Symbols
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum results (default 20, max 100)"
+// @Success 200 {object} SearchSymbolsResponse "Matching symbols"
+// @Failure 400 {object} response.ErrorResponse "Missing search query"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - Missing or invalid token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /symbols [get]
+func SearchSymbols(w http.ResponseWriter, r *http.Request, container di.Container) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		http.Error(w, "Query parameter q is required", http.StatusBadRequest)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	symbols, err := container.GetSearchSymbolsUseCase().Execute(context.Background(), query, limit)
+	if err != nil {
+		http.Error(w, "Failed to search symbols: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response, err := json.Marshal(SearchSymbolsResponse{Query: query, Symbols: symbols})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprint(w, string(response))
+}
+
+// SyncSymbolsWithAuth returns a handler wrapped with authentication middleware
+func SyncSymbolsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		SyncSymbols(w, r, userId, container)
+	})
+}
+
+// SearchSymbolsWithAuth returns a handler wrapped with authentication middleware
+func SearchSymbolsWithAuth(verifyToken middleware.TokenVerifier, container di.Container) http.HandlerFunc {
+	return middleware.WithAuthentication(verifyToken, func(w http.ResponseWriter, r *http.Request, userId string) {
+		SearchSymbols(w, r, container)
+	})
+}
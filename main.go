@@ -20,6 +20,7 @@ import (
 	orderHandler "HubInvestments/internal/order_mngmt_system/presentation/http"
 	portfolioSummaryHandler "HubInvestments/internal/portfolio_summary/presentation/http"
 	positionHandler "HubInvestments/internal/position/presentation/http"
+	symbolHandler "HubInvestments/internal/symbol_universe/presentation/http"
 	watchlistHandler "HubInvestments/internal/watchlist/presentation/http"
 	di "HubInvestments/pck"
 	"HubInvestments/shared/config"
@@ -63,7 +64,18 @@ func main() {
 	http.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
 		doLoginHandler.DoLogin(w, r, container)
 	})
+	http.HandleFunc("/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+		doLoginHandler.RefreshSession(w, r, container)
+	})
+	http.HandleFunc("/auth/sessions", doLoginHandler.SessionsWithAuth(verifyToken, container))
+	http.HandleFunc("/auth/sessions/", doLoginHandler.RevokeSessionWithAuth(verifyToken, container))
 	http.HandleFunc("/getAucAggregation", positionHandler.GetAucAggregationWithAuth(verifyToken, container))
+	http.HandleFunc("/positions/", positionHandler.GetClosePreviewWithAuth(verifyToken, container))
+	http.HandleFunc("/positions/revalue", positionHandler.RevaluePositionsWithAuth(verifyToken, container))
+	http.HandleFunc("/positions/alerts", positionHandler.PnLAlertsWithAuth(verifyToken, container))
+	http.HandleFunc("/positions/alerts/", positionHandler.PnLAlertWithAuth(verifyToken, container))
+	http.HandleFunc("/positions/alerts/stream", positionHandler.PnLAlertStreamWithAuth(verifyToken, container))
+	http.HandleFunc("/account/daily-pnl", positionHandler.GetDailyPnLWithAuth(verifyToken, container))
 	http.HandleFunc("/getBalance", balanceHandler.GetBalanceWithAuth(verifyToken, container))
 	http.HandleFunc("/getPortfolioSummary", portfolioSummaryHandler.GetPortfolioSummaryWithAuth(verifyToken, container))
 	http.HandleFunc("/getWatchlist", watchlistHandler.GetWatchlistWithAuth(verifyToken, container))
@@ -76,15 +88,71 @@ func main() {
 			orderHandler.GetOrderStatusWithAuth(verifyToken, container)(w, r)
 		} else if strings.HasSuffix(path, "/cancel") {
 			orderHandler.CancelOrderWithAuth(verifyToken, container)(w, r)
+		} else if strings.HasSuffix(path, "/reduce") {
+			orderHandler.PartialCancelOrderWithAuth(verifyToken, container)(w, r)
+		} else if strings.HasSuffix(path, "/execution-quality") {
+			orderHandler.GetExecutionQualityWithAuth(verifyToken, container)(w, r)
+		} else if strings.HasSuffix(path, "/latency") {
+			orderHandler.GetOrderLatencyWithAuth(verifyToken, container)(w, r)
+		} else if strings.HasSuffix(path, "/fills") {
+			orderHandler.GetOrderFillsWithAuth(verifyToken, container)(w, r)
 		} else {
 			orderHandler.GetOrderDetailsWithAuth(verifyToken, container)(w, r)
 		}
 	})
 	http.HandleFunc("/orders/history", orderHandler.GetOrderHistoryWithAuth(verifyToken, container))
+	http.HandleFunc("/orders/session", orderHandler.OrderSessionWithAuth(verifyToken, container))
+	http.HandleFunc("/orders/notifications/stream", orderHandler.OrderNotificationStreamWithAuth(verifyToken, container))
+	http.HandleFunc("/orders/risk-check", orderHandler.CheckOrderRiskWithAuth(verifyToken, container))
+	http.HandleFunc("/orders/suggest-size", orderHandler.SuggestOrderSizeWithAuth(verifyToken, container))
+	http.HandleFunc("/orders/suggest-price", orderHandler.SuggestLimitPriceWithAuth(verifyToken, container))
+	http.HandleFunc("/orders/rejected", orderHandler.GetRejectedOrdersWithAuth(verifyToken, container))
+
+	http.HandleFunc("/quotes/history", orderHandler.GetQuoteHistoryWithAuth(verifyToken, container))
+	http.HandleFunc("/quotes/snapshot", orderHandler.GetQuoteSnapshotWithAuth(verifyToken, container))
+	http.HandleFunc("/symbols", symbolHandler.SearchSymbolsWithAuth(verifyToken, container))
+	http.HandleFunc("/admin/symbols/sync", symbolHandler.SyncSymbolsWithAuth(verifyToken, container))
+	http.HandleFunc("/admin/workers/health", orderHandler.GetWorkersHealthWithAuth(verifyToken, container))
+	http.HandleFunc("/admin/orders/rejections", orderHandler.GetRejectionAnalyticsWithAuth(verifyToken, container))
+	http.HandleFunc("/admin/orders/", orderHandler.ForceCancelOrderWithAuth(verifyToken, container))
+	http.HandleFunc("/admin/users/", orderHandler.UpdateUserRiskProfileWithAuth(verifyToken, container))
+	http.HandleFunc("/admin/positions/reconcile/report", positionHandler.GetReconciliationReportWithAuth(verifyToken, container))
+
+	// Order submission latency histograms for Prometheus scraping
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		orderHandler.GetMetrics(w, r, container)
+	})
+
+	// Readiness probe; degraded while market data is stale
+	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		orderHandler.GetReadiness(w, r, container)
+	})
 
 	// Swagger documentation route
 	http.HandleFunc("/swagger/", httpSwagger.WrapHandler)
 
+	// Background jobs run until shutdown
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Resting if-touched and pegged orders only live in memory; rebuild them before quotes start arriving
+	if restored, err := container.GetActivateIfTouchedOrdersUseCase().Restore(jobsCtx); err != nil {
+		log.Printf("Warning: Failed to restore resting if-touched orders: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d resting if-touched orders", restored)
+	}
+	if restored, err := container.GetRepricePeggedOrdersUseCase().Restore(jobsCtx); err != nil {
+		log.Printf("Warning: Failed to restore pegged orders: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d pegged orders", restored)
+	}
+	go container.GetQuoteFeed().Start(jobsCtx)
+	if markToMarketJob := container.GetMarkToMarketJob(); markToMarketJob != nil {
+		go markToMarketJob.Start(jobsCtx)
+	}
+	go container.GetTradingLimitResetJob().Start(jobsCtx)
+	go container.GetOrderExpiryJob().Start(jobsCtx)
+
 	go func() {
 		log.Printf("gRPC server starting on %s", cfg.GRPCPort)
 		if err := grpcSrv.Serve(lis); err != nil {
@@ -104,6 +172,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down servers...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -1,30 +1,42 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"HubInvestments/internal/auth"
+	authSession "HubInvestments/internal/auth/session"
 	"HubInvestments/internal/auth/token"
 	balUsecase "HubInvestments/internal/balance/application/usecase"
 	balancePersistence "HubInvestments/internal/balance/infra/persistence"
 	doLoginUsecase "HubInvestments/internal/login/application/usecase"
 	loginPersistence "HubInvestments/internal/login/infra/persistense"
 	orderUsecase "HubInvestments/internal/order_mngmt_system/application/usecase"
+	orderDomain "HubInvestments/internal/order_mngmt_system/domain/model"
 	orderRepository "HubInvestments/internal/order_mngmt_system/domain/repository"
 	orderService "HubInvestments/internal/order_mngmt_system/domain/service"
 	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
 	orderIdempotency "HubInvestments/internal/order_mngmt_system/infra/idempotency"
 	orderMessaging "HubInvestments/internal/order_mngmt_system/infra/messaging"
 	orderRabbitMQ "HubInvestments/internal/order_mngmt_system/infra/messaging/rabbitmq"
+	orderNotification "HubInvestments/internal/order_mngmt_system/infra/notification"
 	orderPersistence "HubInvestments/internal/order_mngmt_system/infra/persistence"
+	orderSession "HubInvestments/internal/order_mngmt_system/infra/session"
+	orderWebhook "HubInvestments/internal/order_mngmt_system/infra/webhook"
 	orderWorker "HubInvestments/internal/order_mngmt_system/infra/worker"
 	portfolioUsecase "HubInvestments/internal/portfolio_summary/application/usecase"
 	posUsecase "HubInvestments/internal/position/application/usecase"
+	posDomain "HubInvestments/internal/position/domain/model"
+	positionExternal "HubInvestments/internal/position/infra/external"
+	positionNotification "HubInvestments/internal/position/infra/notification"
 	positionPersistence "HubInvestments/internal/position/infra/persistence"
 	positionWorker "HubInvestments/internal/position/infra/worker"
+	symbolUsecase "HubInvestments/internal/symbol_universe/application/usecase"
+	symbolPersistence "HubInvestments/internal/symbol_universe/infra/persistence"
 	watchlistUsecase "HubInvestments/internal/watchlist/application/usecase"
 	watchPersistence "HubInvestments/internal/watchlist/infra/persistence"
 	"HubInvestments/shared/infra/cache"
@@ -38,10 +50,17 @@ import (
 type Container interface {
 	DoLoginUsecase() doLoginUsecase.IDoLoginUsecase
 	GetAuthService() auth.IAuthService
+	GetSessionService() authSession.ISessionService
 	GetPositionAggregationUseCase() *posUsecase.GetPositionAggregationUseCase
 	GetCreatePositionUseCase() posUsecase.ICreatePositionUseCase
 	GetUpdatePositionUseCase() posUsecase.IUpdatePositionUseCase
 	GetClosePositionUseCase() posUsecase.IClosePositionUseCase
+	GetClosePreviewUseCase() posUsecase.IGetClosePreviewUseCase
+	GetRevaluePositionsUseCase() posUsecase.IRevaluePositionsUseCase
+	GetDailyPnLUseCase() posUsecase.IGetDailyPnLUseCase
+	GetManagePnLAlertsUseCase() posUsecase.IManagePnLAlertsUseCase
+	GetEvaluatePnLAlertsUseCase() posUsecase.IEvaluatePnLAlertsUseCase
+	GetPnLAlertNotifier() *positionNotification.WebSocketPnLAlertNotifier
 	GetBalanceUseCase() *balUsecase.GetBalanceUseCase
 	GetPortfolioSummaryUsecase() portfolioUsecase.PortfolioSummaryUsecase
 	GetWatchlistUsecase() watchlistUsecase.IGetWatchlistUsecase
@@ -53,14 +72,48 @@ type Container interface {
 	GetSubmitOrderUseCase() orderUsecase.ISubmitOrderUseCase
 	GetGetOrderStatusUseCase() orderUsecase.IGetOrderStatusUseCase
 	GetCancelOrderUseCase() orderUsecase.ICancelOrderUseCase
+	GetPartialCancelOrderUseCase() orderUsecase.IPartialCancelOrderUseCase
 	GetProcessOrderUseCase() orderUsecase.IProcessOrderUseCase
+	GetExecutionQualityUseCase() orderUsecase.IGetExecutionQualityUseCase
+	GetCheckOrderRiskUseCase() orderUsecase.ICheckOrderRiskUseCase
+	GetSuggestOrderSizeUseCase() orderUsecase.ISuggestOrderSizeUseCase
+	GetSuggestLimitPriceUseCase() orderUsecase.ISuggestLimitPriceUseCase
+	GetRejectedOrdersUseCase() orderUsecase.IGetRejectedOrdersUseCase
+	GetOrderLatencyUseCase() orderUsecase.IGetOrderLatencyUseCase
+	GetOrderFillsUseCase() orderUsecase.IGetOrderFillsUseCase
+	GetUpdateUserRiskProfileUseCase() orderUsecase.IUpdateUserRiskProfileUseCase
+	GetForceCancelOrderUseCase() orderUsecase.IForceCancelOrderUseCase
+	GetActivateIfTouchedOrdersUseCase() orderUsecase.IActivateIfTouchedOrdersUseCase
+	GetRepricePeggedOrdersUseCase() orderUsecase.IRepricePeggedOrdersUseCase
+	GetQuoteHistoryUseCase() orderUsecase.IGetQuoteHistoryUseCase
+	GetQuoteSnapshotUseCase() orderUsecase.IGetQuoteSnapshotUseCase
+	GetQuoteSnapshotCache() orderService.QuoteSnapshotCache
+
+	// Order Management System - Repositories
+	GetUserOrderPreferencesRepository() orderRepository.IUserOrderPreferencesRepository
+	GetOrderNotificationPreferencesRepository() orderRepository.IOrderNotificationPreferencesRepository
 
 	// Order Management System - Infrastructure
 	GetOrderProducer() *orderRabbitMQ.OrderProducer
 	GetOrderWorkerManager() *orderWorker.WorkerManager
+	GetTradingLimitResetJob() *orderWorker.TradingLimitResetJob
+	GetOrderExpiryJob() *orderWorker.OrderExpiryJob
+	GetCancelOnDisconnectMonitor() *orderSession.CancelOnDisconnectMonitor
+	GetOrderLatencyTracker() orderService.OrderLatencyTracker
+	GetOrderPipelineMetrics() orderService.OrderPipelineMetrics
+	GetMarketDataFreshnessMonitor() *orderMktClient.MarketDataFreshnessMonitor
+	GetQuoteFeed() *orderMessaging.QuoteFeed
+	GetPricePrecisionResolver() orderMktClient.IPricePrecisionResolver
+	GetOrderNotificationSender() *orderNotification.WebSocketOrderNotificationSender
 
 	// Position Management System - Infrastructure
 	GetPositionWorkerManager() *positionWorker.PositionUpdateWorker
+	GetPositionReconciliationReporter() *positionWorker.PositionReconciliationReporter
+	GetMarkToMarketJob() *positionWorker.MarkToMarketJob
+
+	// Symbol Universe - Use Cases
+	GetSyncSymbolUniverseUseCase() symbolUsecase.ISyncSymbolUniverseUseCase
+	GetSearchSymbolsUseCase() symbolUsecase.ISearchSymbolsUseCase
 
 	// Messaging infrastructure
 	GetMessageHandler() messaging.MessageHandler
@@ -74,10 +127,17 @@ type Container interface {
 
 type containerImpl struct {
 	AuthService                auth.IAuthService
+	SessionService             authSession.ISessionService
 	PositionAggregationUseCase *posUsecase.GetPositionAggregationUseCase
 	CreatePositionUseCase      posUsecase.ICreatePositionUseCase
 	UpdatePositionUseCase      posUsecase.IUpdatePositionUseCase
 	ClosePositionUseCase       posUsecase.IClosePositionUseCase
+	ClosePreviewUseCase        posUsecase.IGetClosePreviewUseCase
+	RevaluePositionsUseCase    posUsecase.IRevaluePositionsUseCase
+	DailyPnLUseCase            posUsecase.IGetDailyPnLUseCase
+	ManagePnLAlertsUseCase     posUsecase.IManagePnLAlertsUseCase
+	EvaluatePnLAlertsUseCase   posUsecase.IEvaluatePnLAlertsUseCase
+	PnLAlertNotifier           *positionNotification.WebSocketPnLAlertNotifier
 	BalanceUsecase             *balUsecase.GetBalanceUseCase
 	PortfolioSummaryUsecase    portfolioUsecase.PortfolioSummaryUsecase
 	WatchlistUsecase           watchlistUsecase.IGetWatchlistUsecase
@@ -93,28 +153,65 @@ type containerImpl struct {
 	OrderMarketDataClient orderMktClient.IMarketDataClient
 
 	// Order Management System - Repository
-	OrderRepository orderRepository.IOrderRepository
+	OrderRepository         orderRepository.IOrderRepository
+	OrderPreferencesRepo    orderRepository.IUserOrderPreferencesRepository
+	NotificationPreferences orderRepository.IOrderNotificationPreferencesRepository
 
 	// Order Management System - Use Cases
 	SubmitOrderUseCase    orderUsecase.ISubmitOrderUseCase
 	GetOrderStatusUseCase orderUsecase.IGetOrderStatusUseCase
 	CancelOrderUseCase    orderUsecase.ICancelOrderUseCase
+	PartialCancelUseCase  orderUsecase.IPartialCancelOrderUseCase
 	ProcessOrderUseCase   orderUsecase.IProcessOrderUseCase
+	ExecutionQuality      orderUsecase.IGetExecutionQualityUseCase
+	OrderRiskCheck        orderUsecase.ICheckOrderRiskUseCase
+	OrderSizeSuggestion   orderUsecase.ISuggestOrderSizeUseCase
+	LimitPriceSuggestion  orderUsecase.ISuggestLimitPriceUseCase
+	RejectedOrders        orderUsecase.IGetRejectedOrdersUseCase
+	OrderLatency          orderUsecase.IGetOrderLatencyUseCase
+	OrderFills            orderUsecase.IGetOrderFillsUseCase
+	UserRiskProfile       orderUsecase.IUpdateUserRiskProfileUseCase
+	ForceCancelOrder      orderUsecase.IForceCancelOrderUseCase
+	IfTouchedActivation   orderUsecase.IActivateIfTouchedOrdersUseCase
+	PeggedRepricing       orderUsecase.IRepricePeggedOrdersUseCase
+	QuoteHistory          orderUsecase.IGetQuoteHistoryUseCase
+	QuoteSnapshot         orderUsecase.IGetQuoteSnapshotUseCase
 
 	// Order Management System - Infrastructure
 	OrderProducer       *orderRabbitMQ.OrderProducer
 	OrderEventPublisher orderMessaging.IEventPublisher
 	OrderWorkerManager  *orderWorker.WorkerManager
+	TradingLimitReset   *orderWorker.TradingLimitResetJob
+	OrderExpiry         *orderWorker.OrderExpiryJob
 	IdempotencyService  orderService.IIdempotencyService
+	DisconnectMonitor   *orderSession.CancelOnDisconnectMonitor
+	LatencyTracker      orderService.OrderLatencyTracker
+	PipelineMetrics     orderService.OrderPipelineMetrics
+	QuoteSnapshots      orderService.QuoteSnapshotCache
+	MarketDataFreshness *orderMktClient.MarketDataFreshnessMonitor
+	QuoteFeed           *orderMessaging.QuoteFeed
+	PricePrecision      orderMktClient.IPricePrecisionResolver
+	NotificationSender  *orderNotification.WebSocketOrderNotificationSender
+	stopFreshnessChecks context.CancelFunc
 
 	// Position Management System - Infrastructure
-	PositionWorkerManager *positionWorker.PositionUpdateWorker
+	PositionWorkerManager          *positionWorker.PositionUpdateWorker
+	PositionReconciliationReporter *positionWorker.PositionReconciliationReporter
+	MarkToMarketJob                *positionWorker.MarkToMarketJob
+
+	// Symbol Universe - Use Cases
+	SyncSymbolUniverseUseCase symbolUsecase.ISyncSymbolUniverseUseCase
+	SearchSymbolsUseCase      symbolUsecase.ISearchSymbolsUseCase
 }
 
 func (c *containerImpl) GetAuthService() auth.IAuthService {
 	return c.AuthService
 }
 
+func (c *containerImpl) GetSessionService() authSession.ISessionService {
+	return c.SessionService
+}
+
 func (c *containerImpl) GetPositionAggregationUseCase() *posUsecase.GetPositionAggregationUseCase {
 	return c.PositionAggregationUseCase
 }
@@ -131,6 +228,30 @@ func (c *containerImpl) GetClosePositionUseCase() posUsecase.IClosePositionUseCa
 	return c.ClosePositionUseCase
 }
 
+func (c *containerImpl) GetClosePreviewUseCase() posUsecase.IGetClosePreviewUseCase {
+	return c.ClosePreviewUseCase
+}
+
+func (c *containerImpl) GetRevaluePositionsUseCase() posUsecase.IRevaluePositionsUseCase {
+	return c.RevaluePositionsUseCase
+}
+
+func (c *containerImpl) GetDailyPnLUseCase() posUsecase.IGetDailyPnLUseCase {
+	return c.DailyPnLUseCase
+}
+
+func (c *containerImpl) GetManagePnLAlertsUseCase() posUsecase.IManagePnLAlertsUseCase {
+	return c.ManagePnLAlertsUseCase
+}
+
+func (c *containerImpl) GetEvaluatePnLAlertsUseCase() posUsecase.IEvaluatePnLAlertsUseCase {
+	return c.EvaluatePnLAlertsUseCase
+}
+
+func (c *containerImpl) GetPnLAlertNotifier() *positionNotification.WebSocketPnLAlertNotifier {
+	return c.PnLAlertNotifier
+}
+
 func (c *containerImpl) GetBalanceUseCase() *balUsecase.GetBalanceUseCase {
 	return c.BalanceUsecase
 }
@@ -171,10 +292,102 @@ func (c *containerImpl) GetCancelOrderUseCase() orderUsecase.ICancelOrderUseCase
 	return c.CancelOrderUseCase
 }
 
+func (c *containerImpl) GetPartialCancelOrderUseCase() orderUsecase.IPartialCancelOrderUseCase {
+	return c.PartialCancelUseCase
+}
+
 func (c *containerImpl) GetProcessOrderUseCase() orderUsecase.IProcessOrderUseCase {
 	return c.ProcessOrderUseCase
 }
 
+func (c *containerImpl) GetExecutionQualityUseCase() orderUsecase.IGetExecutionQualityUseCase {
+	return c.ExecutionQuality
+}
+
+func (c *containerImpl) GetCheckOrderRiskUseCase() orderUsecase.ICheckOrderRiskUseCase {
+	return c.OrderRiskCheck
+}
+
+func (c *containerImpl) GetSuggestOrderSizeUseCase() orderUsecase.ISuggestOrderSizeUseCase {
+	return c.OrderSizeSuggestion
+}
+
+func (c *containerImpl) GetSuggestLimitPriceUseCase() orderUsecase.ISuggestLimitPriceUseCase {
+	return c.LimitPriceSuggestion
+}
+
+func (c *containerImpl) GetRejectedOrdersUseCase() orderUsecase.IGetRejectedOrdersUseCase {
+	return c.RejectedOrders
+}
+
+func (c *containerImpl) GetUpdateUserRiskProfileUseCase() orderUsecase.IUpdateUserRiskProfileUseCase {
+	return c.UserRiskProfile
+}
+
+func (c *containerImpl) GetForceCancelOrderUseCase() orderUsecase.IForceCancelOrderUseCase {
+	return c.ForceCancelOrder
+}
+
+func (c *containerImpl) GetActivateIfTouchedOrdersUseCase() orderUsecase.IActivateIfTouchedOrdersUseCase {
+	return c.IfTouchedActivation
+}
+
+func (c *containerImpl) GetRepricePeggedOrdersUseCase() orderUsecase.IRepricePeggedOrdersUseCase {
+	return c.PeggedRepricing
+}
+
+func (c *containerImpl) GetQuoteHistoryUseCase() orderUsecase.IGetQuoteHistoryUseCase {
+	return c.QuoteHistory
+}
+
+func (c *containerImpl) GetQuoteSnapshotUseCase() orderUsecase.IGetQuoteSnapshotUseCase {
+	return c.QuoteSnapshot
+}
+
+func (c *containerImpl) GetQuoteSnapshotCache() orderService.QuoteSnapshotCache {
+	return c.QuoteSnapshots
+}
+
+func (c *containerImpl) GetOrderLatencyUseCase() orderUsecase.IGetOrderLatencyUseCase {
+	return c.OrderLatency
+}
+
+func (c *containerImpl) GetOrderFillsUseCase() orderUsecase.IGetOrderFillsUseCase {
+	return c.OrderFills
+}
+
+func (c *containerImpl) GetOrderLatencyTracker() orderService.OrderLatencyTracker {
+	return c.LatencyTracker
+}
+
+func (c *containerImpl) GetOrderPipelineMetrics() orderService.OrderPipelineMetrics {
+	return c.PipelineMetrics
+}
+
+func (c *containerImpl) GetMarketDataFreshnessMonitor() *orderMktClient.MarketDataFreshnessMonitor {
+	return c.MarketDataFreshness
+}
+
+func (c *containerImpl) GetQuoteFeed() *orderMessaging.QuoteFeed {
+	return c.QuoteFeed
+}
+
+func (c *containerImpl) GetPricePrecisionResolver() orderMktClient.IPricePrecisionResolver {
+	return c.PricePrecision
+}
+
+func (c *containerImpl) GetUserOrderPreferencesRepository() orderRepository.IUserOrderPreferencesRepository {
+	return c.OrderPreferencesRepo
+}
+
+func (c *containerImpl) GetOrderNotificationPreferencesRepository() orderRepository.IOrderNotificationPreferencesRepository {
+	return c.NotificationPreferences
+}
+
+func (c *containerImpl) GetOrderNotificationSender() *orderNotification.WebSocketOrderNotificationSender {
+	return c.NotificationSender
+}
+
 func (c *containerImpl) GetOrderProducer() *orderRabbitMQ.OrderProducer {
 	return c.OrderProducer
 }
@@ -183,10 +396,38 @@ func (c *containerImpl) GetOrderWorkerManager() *orderWorker.WorkerManager {
 	return c.OrderWorkerManager
 }
 
+func (c *containerImpl) GetTradingLimitResetJob() *orderWorker.TradingLimitResetJob {
+	return c.TradingLimitReset
+}
+
+func (c *containerImpl) GetOrderExpiryJob() *orderWorker.OrderExpiryJob {
+	return c.OrderExpiry
+}
+
+func (c *containerImpl) GetCancelOnDisconnectMonitor() *orderSession.CancelOnDisconnectMonitor {
+	return c.DisconnectMonitor
+}
+
 func (c *containerImpl) GetPositionWorkerManager() *positionWorker.PositionUpdateWorker {
 	return c.PositionWorkerManager
 }
 
+func (c *containerImpl) GetPositionReconciliationReporter() *positionWorker.PositionReconciliationReporter {
+	return c.PositionReconciliationReporter
+}
+
+func (c *containerImpl) GetMarkToMarketJob() *positionWorker.MarkToMarketJob {
+	return c.MarkToMarketJob
+}
+
+func (c *containerImpl) GetSyncSymbolUniverseUseCase() symbolUsecase.ISyncSymbolUniverseUseCase {
+	return c.SyncSymbolUniverseUseCase
+}
+
+func (c *containerImpl) GetSearchSymbolsUseCase() symbolUsecase.ISearchSymbolsUseCase {
+	return c.SearchSymbolsUseCase
+}
+
 // Close gracefully shuts down all resources managed by the container
 func (c *containerImpl) Close() error {
 	var errors []error
@@ -198,6 +439,16 @@ func (c *containerImpl) Close() error {
 		}
 	}
 
+	// Stop pending cancel-on-disconnect timers
+	if c.DisconnectMonitor != nil {
+		c.DisconnectMonitor.Close()
+	}
+
+	// Stop polling quote timestamps
+	if c.stopFreshnessChecks != nil {
+		c.stopFreshnessChecks()
+	}
+
 	// Close order producer
 	if c.OrderProducer != nil {
 		if err := c.OrderProducer.Close(); err != nil {
@@ -260,16 +511,39 @@ func NewContainer() (Container, error) {
 	loginUsecase := doLoginUsecase.NewDoLoginUsecase(loginRepo)
 	tokenService := token.NewTokenService()
 	authService := auth.NewAuthService(tokenService)
+	// Sessions live in memory, so a restart signs every device out and they must log in again
+	sessionService := authSession.NewSessionService(authSession.NewInMemorySessionStore(), authSession.NewSessionConfigFromEnv())
 
 	// Create repositories using the database abstraction
 	positionRepo := positionPersistence.NewPositionRepository(db)
 	positionAggregationUseCase := posUsecase.NewGetPositionAggregationUseCase(positionRepo)
 
+	// Holdings in several currencies are converted to ACCOUNT_BASE_CURRENCY using the FX_RATES table (e.g. "BRL/USD=0.19")
+	if fxRatesStr := os.Getenv("FX_RATES"); fxRatesStr != "" {
+		fxRates, err := positionExternal.ParseFXRates(fxRatesStr)
+		assetDetailsClient, hasMarketData := positionAggregationUseCase.MarketDataClient().(positionExternal.IAssetDetailsClient)
+		switch {
+		case err != nil:
+			fmt.Printf("Warning: %v, position currency normalization disabled\n", err)
+		case !hasMarketData:
+			fmt.Printf("Warning: market data unavailable, position currency normalization disabled\n")
+		default:
+			currencyConfig := posUsecase.DefaultCurrencyNormalizationConfig()
+			currencyConfig.BaseCurrency = getEnvWithDefault("ACCOUNT_BASE_CURRENCY", currencyConfig.BaseCurrency)
+			positionAggregationUseCase.SetCurrencyNormalization(positionExternal.NewMarketDataCurrencyResolver(assetDetailsClient),
+				positionExternal.NewStaticFXRateClient(fxRates), currencyConfig)
+		}
+	}
+
 	// Position Management Use Cases
 	createPositionUseCase := posUsecase.NewCreatePositionUseCase(positionRepo)
 	updatePositionUseCase := posUsecase.NewUpdatePositionUseCase(positionRepo)
 	closePositionUseCase := posUsecase.NewClosePositionUseCase(positionRepo)
 
+	// On-demand revaluation reuses the aggregation's market data connection for its batch price fetch
+	revaluePositionsUseCase := posUsecase.NewRevaluePositionsUseCase(positionRepo,
+		positionAggregationUseCase.MarketDataClient(), posUsecase.DefaultRevaluePositionsConfig())
+
 	balanceRepo := balancePersistence.NewBalanceRepository(db)
 	balanceUsecase := balUsecase.NewGetBalanceUseCase(balanceRepo)
 	portfolioSummaryUseCase := portfolioUsecase.NewGetPortfolioSummaryUsecase(*positionAggregationUseCase, *balanceUsecase)
@@ -312,15 +586,113 @@ func NewContainer() (Container, error) {
 		Timeout:       30 * time.Second,
 	}
 
-	orderMarketDataClient, err := orderMktClient.NewMarketDataClient(orderMarketDataClientConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create order market data client: %w", err)
+	// ORDER_SIMULATION_MODE=true replaces the market data, pricing and risk downstreams with deterministic
+	// synthetic clients for load testing the order pipeline; ORDER_SIMULATION_SEED varies the synthetic
+	// market and ORDER_SIMULATION_LATENCY (a Go duration) mimics the network delay of a real downstream
+	simulationMode := getEnvWithDefault("ORDER_SIMULATION_MODE", "false") == "true"
+	simulationConfig := orderMktClient.DefaultSimulationConfig()
+	if seedStr := os.Getenv("ORDER_SIMULATION_SEED"); seedStr != "" {
+		if seed, err := strconv.ParseInt(seedStr, 10, 64); err == nil {
+			simulationConfig.Seed = seed
+		} else {
+			fmt.Printf("Warning: Invalid ORDER_SIMULATION_SEED %q, using %d\n", seedStr, simulationConfig.Seed)
+		}
+	}
+	if latencyStr := os.Getenv("ORDER_SIMULATION_LATENCY"); latencyStr != "" {
+		if latency, err := time.ParseDuration(latencyStr); err == nil && latency >= 0 {
+			simulationConfig.Latency = latency
+		} else {
+			fmt.Printf("Warning: Invalid ORDER_SIMULATION_LATENCY %q, using %s\n", latencyStr, simulationConfig.Latency)
+		}
+	}
+
+	var orderMarketDataClient orderMktClient.IMarketDataClient
+	if simulationMode {
+		fmt.Printf("Order simulation mode enabled (seed %d): order downstreams return synthetic data\n", simulationConfig.Seed)
+		orderMarketDataClient = orderMktClient.NewSimulatedMarketDataClient(simulationConfig)
+	} else {
+		orderMarketDataClient, err = orderMktClient.NewMarketDataClient(orderMarketDataClientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create order market data client: %w", err)
+		}
+	}
+
+	// Readiness degrades while a symbol listed in MARKET_DATA_TRACKED_SYMBOLS (comma separated) has no
+	// quote newer than MARKET_DATA_STALENESS_SLO (a Go duration); monitoring is off without symbols
+	var marketDataFreshness *orderMktClient.MarketDataFreshnessMonitor
+	var stopFreshnessChecks context.CancelFunc
+	if trackedSymbols := os.Getenv("MARKET_DATA_TRACKED_SYMBOLS"); trackedSymbols != "" {
+		freshnessConfig := orderMktClient.DefaultMarketDataFreshnessConfig(strings.Split(trackedSymbols, ","))
+		if sloStr := os.Getenv("MARKET_DATA_STALENESS_SLO"); sloStr != "" {
+			if slo, err := time.ParseDuration(sloStr); err == nil && slo > 0 {
+				freshnessConfig.StalenessSLO = slo
+			} else {
+				fmt.Printf("Warning: Invalid MARKET_DATA_STALENESS_SLO %q, using %s\n", sloStr, freshnessConfig.StalenessSLO)
+			}
+		}
+		marketDataFreshness = orderMktClient.NewMarketDataFreshnessMonitor(orderMarketDataClient, freshnessConfig)
+
+		var freshnessCtx context.Context
+		freshnessCtx, stopFreshnessChecks = context.WithCancel(context.Background())
+		go marketDataFreshness.Start(freshnessCtx)
+	}
+
+	// Order, quote and price suggestion responses round prices to the decimals of each symbol's price step.
+	// PRICE_PRECISION_OVERRIDES (comma separated SYMBOL=DECIMALS, e.g. "EURUSD=4") takes precedence over
+	// market data; PRICE_PRECISION_DEFAULT applies to symbols market data cannot describe
+	pricePrecisionConfig := orderMktClient.DefaultPricePrecisionConfig()
+	if defaultStr := os.Getenv("PRICE_PRECISION_DEFAULT"); defaultStr != "" {
+		if precision, err := strconv.Atoi(defaultStr); err == nil && precision >= 0 {
+			pricePrecisionConfig.DefaultPrecision = precision
+		} else {
+			fmt.Printf("Warning: Invalid PRICE_PRECISION_DEFAULT %q, using %d\n", defaultStr, pricePrecisionConfig.DefaultPrecision)
+		}
 	}
+	if overridesStr := os.Getenv("PRICE_PRECISION_OVERRIDES"); overridesStr != "" {
+		pricePrecisionConfig.SymbolPrecisions = make(map[string]int)
+		for _, entry := range strings.Split(overridesStr, ",") {
+			symbol, precisionStr, found := strings.Cut(entry, "=")
+			precision, err := strconv.Atoi(strings.TrimSpace(precisionStr))
+			if !found || strings.TrimSpace(symbol) == "" || err != nil || precision < 0 {
+				fmt.Printf("Warning: Invalid PRICE_PRECISION_OVERRIDES entry %q, skipping it\n", entry)
+				continue
+			}
+			pricePrecisionConfig.SymbolPrecisions[strings.TrimSpace(symbol)] = precision
+		}
+	}
+	pricePrecisionResolver := orderMktClient.NewPricePrecisionResolver(orderMarketDataClient, pricePrecisionConfig)
 	//====== Order Management Market Data Client end============
 
+	//====== Symbol Universe begin============
+	// The symbol universe is refreshed from the market data provider and backs symbol validation and search
+	symbolUniverseRepo := symbolPersistence.NewSymbolUniverseRepository(db)
+	syncSymbolUniverseUseCase := symbolUsecase.NewSyncSymbolUniverseUseCase(symbolUniverseRepo, orderMarketDataClient)
+	searchSymbolsUseCase := symbolUsecase.NewSearchSymbolsUseCase(symbolUniverseRepo)
+	validatingMarketDataClient := orderMktClient.NewSymbolUniverseMarketDataClient(orderMarketDataClient, symbolUniverseRepo)
+	//====== Symbol Universe end============
+
 	//====== Order Management System Use Cases begin============
 	// Create order repository with database connection
 	orderRepo := orderPersistence.NewOrderRepository(db)
+	orderPreferencesRepo := orderPersistence.NewUserOrderPreferencesRepository(db)
+
+	// Close previews rebuild tax lots from executed orders; POSITION_LOT_MATCHING_METHOD selects FIFO or AVERAGE_COST
+	lotMatchingMethod, err := posDomain.NewLotMatchingMethod(os.Getenv("POSITION_LOT_MATCHING_METHOD"))
+	if err != nil {
+		fmt.Printf("Warning: %v, using %s\n", err, posDomain.LotMatchingAverageCost)
+		lotMatchingMethod = posDomain.LotMatchingAverageCost
+	}
+	closePreviewUseCase := posUsecase.NewGetClosePreviewUseCase(positionRepo, positionExternal.NewOrderTradeSource(orderRepo),
+		posUsecase.ClosePreviewConfig{LotMatchingMethod: lotMatchingMethod})
+
+	// Daily P&L compares live valuations against the end-of-day marks stored by the mark-to-market job
+	dailyPnLUseCase := posUsecase.NewGetDailyPnLUseCase(positionRepo, positionPersistence.NewPositionValuationRepository(db),
+		positionExternal.NewOrderTradeSource(orderRepo), positionAggregationUseCase.MarketDataClient(), posUsecase.DefaultDailyPnLConfig())
+	// P&L alerts fire as realtime quotes fed to the evaluation use case revalue positions, and are pushed to /positions/alerts/stream
+	pnlAlertRepo := positionPersistence.NewPnLAlertRepository(db)
+	pnlAlertNotifier := positionNotification.NewWebSocketPnLAlertNotifier()
+	managePnLAlertsUseCase := posUsecase.NewManagePnLAlertsUseCase(pnlAlertRepo, posUsecase.DefaultPnLAlertConfig())
+	evaluatePnLAlertsUseCase := posUsecase.NewEvaluatePnLAlertsUseCase(pnlAlertRepo, positionRepo, pnlAlertNotifier)
 
 	// Create Redis client for idempotency
 	redisHost := getEnvWithDefault("REDIS_HOST", "localhost")
@@ -342,35 +714,315 @@ func NewContainer() (Container, error) {
 
 	// Create event publisher for order domain events
 	var orderEventPublisher orderMessaging.IEventPublisher
+	positionUpdatePublicationRepo := orderPersistence.NewPositionUpdatePublicationRepository(db)
 	if messageHandler != nil {
-		orderEventPublisher = orderMessaging.NewEventPublisher(messageHandler, "orders.events")
+		// Slow down execution publishing while the position worker is behind, and record published
+		// executions so the worker manager can republish the ones a crash lost
+		orderEventPublisher = orderMessaging.NewPositionUpdatePublicationTracker(
+			orderMessaging.NewEventPublisherWithBackpressure(
+				messageHandler,
+				"orders.events",
+				orderMessaging.NewPositionBackpressure(messageHandler, orderMessaging.DefaultPositionBackpressureConfig()),
+			),
+			positionUpdatePublicationRepo,
+		)
+
+		// Batch partial fills into one position update per POSITION_UPDATE_AGGREGATION_WINDOW (a Go
+		// duration) or per POSITION_UPDATE_AGGREGATION_MAX_FILLS fills of an order. Off unless the
+		// window is set: processing executes each order in a single fill, so there is nothing to batch yet.
+		if windowStr := os.Getenv("POSITION_UPDATE_AGGREGATION_WINDOW"); windowStr != "" {
+			aggregationConfig := orderMessaging.DefaultPositionUpdateAggregationConfig()
+			if window, err := time.ParseDuration(windowStr); err == nil {
+				aggregationConfig.Window = window
+			} else {
+				fmt.Printf("Warning: Invalid POSITION_UPDATE_AGGREGATION_WINDOW %q, using %s: %v\n", windowStr, aggregationConfig.Window, err)
+			}
+			if maxFillsStr := os.Getenv("POSITION_UPDATE_AGGREGATION_MAX_FILLS"); maxFillsStr != "" {
+				if maxFills, err := strconv.Atoi(maxFillsStr); err == nil && maxFills > 0 {
+					aggregationConfig.MaxFills = maxFills
+				}
+			}
+			if aggregationConfig.Window > 0 {
+				orderEventPublisher = orderMessaging.NewPositionUpdateAggregator(orderEventPublisher, aggregationConfig)
+			}
+		}
 	}
 
+	// Create webhook dispatcher for external OMS/ERP integrations
+	webhookSubscriptions := orderWebhook.NewInMemoryWebhookSubscriptionStore()
+	if webhookURL := os.Getenv("ORDER_WEBHOOK_URL"); webhookURL != "" {
+		webhookSubscriptions.Register(orderWebhook.WebhookSubscription{
+			ID:     "default",
+			URL:    webhookURL,
+			Secret: os.Getenv("ORDER_WEBHOOK_SECRET"),
+		})
+	}
+	orderWebhookDispatcher := orderWebhook.NewOrderWebhookDispatcher(
+		webhookSubscriptions, nil, orderWebhook.DefaultOrderWebhookDispatcherConfig())
+
+	// Users are notified of the order events they opted in to; in-app notifications are pushed to
+	// /orders/notifications/stream. Users without stored preferences get the events listed in
+	// ORDER_NOTIFICATION_DEFAULT_EVENTS (comma separated, "none" disables them) in-app.
+	notificationPreferencesRepo := orderPersistence.NewOrderNotificationPreferencesRepository(db)
+	orderNotificationSender := orderNotification.NewWebSocketOrderNotificationSender()
+	orderNotificationConfig := orderNotification.DefaultOrderNotificationDispatcherConfig()
+	if defaultEventsStr := os.Getenv("ORDER_NOTIFICATION_DEFAULT_EVENTS"); defaultEventsStr != "" {
+		defaultChannels := make(map[orderDomain.OrderNotificationEvent]orderDomain.NotificationChannel)
+		for _, eventStr := range strings.Split(defaultEventsStr, ",") {
+			if strings.EqualFold(strings.TrimSpace(eventStr), "none") {
+				continue
+			}
+			event, err := orderDomain.ParseOrderNotificationEvent(eventStr)
+			if err != nil {
+				fmt.Printf("Warning: Invalid ORDER_NOTIFICATION_DEFAULT_EVENTS entry %q, skipping it\n", eventStr)
+				continue
+			}
+			defaultChannels[event] = orderDomain.NotificationChannelInApp
+		}
+		orderNotificationConfig.DefaultChannels = defaultChannels
+	}
+	orderNotificationDispatcher := orderNotification.NewOrderNotificationDispatcher(
+		notificationPreferencesRepo,
+		map[orderDomain.NotificationChannel]orderNotification.IOrderNotificationSender{
+			orderDomain.NotificationChannelInApp: orderNotificationSender,
+		},
+		orderNotificationConfig,
+	)
+
+	// The market trades in MARKET_TIMEZONE (an IANA zone) on weekdays, except the MARKET_HOLIDAYS dates
+	// (comma-separated, e.g. "2025-12-25,2026-01-01")
+	marketLocation, err := time.LoadLocation(getEnvWithDefault("MARKET_TIMEZONE", "America/New_York"))
+	if err != nil {
+		fmt.Printf("Warning: Invalid MARKET_TIMEZONE: %v, using UTC\n", err)
+		marketLocation = time.UTC
+	}
+	marketHolidays := make(map[string]bool)
+	for _, holiday := range strings.Split(os.Getenv("MARKET_HOLIDAYS"), ",") {
+		holiday = strings.TrimSpace(holiday)
+		if holiday == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", holiday); err != nil {
+			fmt.Printf("Warning: Invalid MARKET_HOLIDAYS date %q, ignoring it\n", holiday)
+			continue
+		}
+		marketHolidays[holiday] = true
+	}
+	marketCalendar := &orderWorker.HolidayTradingCalendar{Holidays: marketHolidays}
+
 	// Create order management use cases with dependencies
 	// Note: SubmitOrderUseCase will be created after OrderProducer is available
 	getOrderStatusUseCase := orderUsecase.NewGetOrderStatusUseCase(orderRepo, orderMarketDataClient)
-	cancelOrderUseCase := orderUsecase.NewCancelOrderUseCase(orderRepo)
-	processOrderUseCase := orderUsecase.NewProcessOrderUseCase(orderRepo, orderMarketDataClient, orderEventPublisher)
+	// Open DAY orders expire at the 16:00 close; the scheduler jitters each expiry and the job sweeps them in batches
+	orderExpiryScheduler := orderService.NewOrderExpirySchedulerWithDefaults()
+	cancelOrderUseCase := orderUsecase.NewCancelOrderUseCaseWithExpiryScheduler(orderRepo, orderExpiryScheduler, orderNotificationDispatcher)
+	orderExpiryConfig := orderWorker.DefaultOrderExpiryJobConfig()
+	orderExpiryConfig.Location = marketLocation
+	orderExpiryJob := orderWorker.NewOrderExpiryJob(orderRepo, orderExpiryScheduler, cancelOrderUseCase, marketCalendar, orderExpiryConfig)
+	disconnectMonitor := orderSession.NewCancelOnDisconnectMonitor(cancelOrderUseCase, orderSession.DefaultCancelOnDisconnectConfig())
+	partialCancelOrderUseCase := orderUsecase.NewPartialCancelOrderUseCase(orderRepo)
+	executionQualityRepo := orderPersistence.NewExecutionQualityRepository(db)
+	// Submission and worker processing timestamp each order for the SLA latency histograms on /metrics
+	orderLatencyTracker := orderService.NewOrderLatencyTrackerWithDefaults()
+	// Validation, pricing and risk services built with NewInstrumented* report per-method timings and outcomes on /metrics
+	var orderPipelineMetrics orderService.OrderPipelineMetrics
+	if getEnvWithDefault("ORDER_PIPELINE_METRICS_ENABLED", "true") == "true" {
+		orderPipelineMetrics = orderService.NewOrderPipelineMetricsWithDefaults()
+	}
+	// Workers store each order's fills so the fills endpoint and history can show what composed an order
+	orderFillRepo := orderPersistence.NewOrderFillRepository(db)
+	// If-touched orders, and the protective stops of filled buys, rest here until a quote fed to the
+	// activation use case touches their trigger
+	ifTouchedTriggerBook := orderService.NewIfTouchedTriggerBookWithDefaults()
+	processOrderUseCase := orderUsecase.NewProcessOrderUseCase(orderUsecase.ProcessOrderDependencies{
+		OrderRepository:            orderRepo,
+		MarketDataClient:           orderMarketDataClient,
+		EventPublisher:             orderEventPublisher,
+		WebhookDispatcher:          orderWebhookDispatcher,
+		ExecutionQualityService:    orderService.NewExecutionQualityServiceWithDefaults(),
+		ExecutionQualityRepository: executionQualityRepo,
+		LatencyTracker:             orderLatencyTracker,
+		FillRepository:             orderFillRepo,
+		Notifier:                   orderNotificationDispatcher,
+		TriggerBook:                ifTouchedTriggerBook,
+	})
+	orderLatencyUseCase := orderUsecase.NewGetOrderLatencyUseCase(orderRepo, orderLatencyTracker)
+	orderFillsUseCase := orderUsecase.NewGetOrderFillsUseCase(orderRepo, orderFillRepo)
+	executionQualityUseCase := orderUsecase.NewGetExecutionQualityUseCase(orderRepo, executionQualityRepo)
+	rejectedOrderRepo := orderPersistence.NewRejectedOrderRepository(db)
+	rejectedOrdersUseCase := orderUsecase.NewGetRejectedOrdersUseCase(rejectedOrderRepo)
+	// Stored profiles override the risk data source once one is wired in via NewStoredRiskProfileDataClient
+	userRiskProfileRepo := orderPersistence.NewUserRiskProfileRepository(db)
+	userRiskProfileUseCase := orderUsecase.NewUpdateUserRiskProfileUseCase(userRiskProfileRepo)
+	forceCancelOrderUseCase := orderUsecase.NewForceCancelOrderUseCase(orderRepo, orderPersistence.NewOrderAdminActionRepository(db))
+	// OrderRiskCheck and OrderSizeSuggestion stay nil until a risk data client is available; their endpoints then answer 503
+	// QuoteHistory and LimitPriceSuggestion likewise stay nil until a pricing data source with history and
+	// order book depth is available. Simulation mode provides all of them, so they take part in load runs.
+	var orderRiskCheckUseCase orderUsecase.ICheckOrderRiskUseCase
+	var orderSizeSuggestionUseCase orderUsecase.ISuggestOrderSizeUseCase
+	var quoteHistoryUseCase orderUsecase.IGetQuoteHistoryUseCase
+	var limitPriceSuggestionUseCase orderUsecase.ISuggestLimitPriceUseCase
+	// Market protection prices from the market data service's last quote; it has no order book, so market
+	// orders are only converted into protected market orders in simulation mode, where a book is available
+	orderPricingService := orderService.NewInstrumentedOrderPricingService(orderService.NewOrderPricingServiceWithDefaults(), orderPipelineMetrics)
+	var orderPricingClient orderService.IPricingDataClient = orderMktClient.NewMarketDataPricingClient(orderMarketDataClient, orderMarketDataClientConfig.Timeout)
+	// RISK_SHADOW_CANDIDATE_WEIGHTS=market,concentration,user_profile,order_size opts into shadowing a candidate
+	// risk model scored with those weights on RISK_SHADOW_SAMPLE_RATE (0 to 1) of orders; production still
+	// decides every order and divergences are logged
+	var riskShadowCandidate orderService.RiskManagementService
+	var riskDivergenceRecorder orderService.RiskDivergenceRecorder
+	riskShadowConfig := orderService.DefaultShadowRiskConfig()
+	if weightsStr := os.Getenv("RISK_SHADOW_CANDIDATE_WEIGHTS"); weightsStr != "" {
+		weights := make([]float64, 0, 4)
+		for _, weightStr := range strings.Split(weightsStr, ",") {
+			if weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64); err == nil && weight >= 0 {
+				weights = append(weights, weight)
+			}
+		}
+		if len(weights) == 4 {
+			candidateConfig := orderService.DefaultRiskManagementConfig()
+			candidateConfig.ScoreWeights = orderService.RiskScoreWeights{
+				Market: weights[0], Concentration: weights[1], UserProfile: weights[2], OrderSize: weights[3],
+			}
+			riskShadowCandidate = orderService.NewRiskManagementService(candidateConfig)
+			riskDivergenceRecorder = orderService.NewLoggingRiskDivergenceRecorder(orderService.NewInMemoryRiskDivergenceRecorder(1000))
+		} else {
+			fmt.Printf("Warning: Invalid RISK_SHADOW_CANDIDATE_WEIGHTS %q, shadow risk evaluation disabled\n", weightsStr)
+		}
+		if rateStr := os.Getenv("RISK_SHADOW_SAMPLE_RATE"); rateStr != "" {
+			if rate, err := strconv.ParseFloat(rateStr, 64); err == nil && rate >= 0 && rate <= 1 {
+				riskShadowConfig.SampleRate = rate
+			} else {
+				fmt.Printf("Warning: Invalid RISK_SHADOW_SAMPLE_RATE %q, using %g\n", rateStr, riskShadowConfig.SampleRate)
+			}
+		}
+	}
+	if simulationMode {
+		riskService := orderService.NewShadowRiskManagementService(
+			orderService.NewInstrumentedRiskManagementService(orderService.NewRiskManagementServiceWithDefaults(), orderPipelineMetrics),
+			riskShadowCandidate, riskDivergenceRecorder, riskShadowConfig)
+		riskDataClient := orderMktClient.NewStoredRiskProfileDataClient(orderMktClient.NewSimulatedRiskDataClient(simulationConfig), userRiskProfileRepo)
+		orderRiskCheckUseCase = orderUsecase.NewCheckOrderRiskUseCase(riskService, riskDataClient)
+		orderSizeSuggestionUseCase = orderUsecase.NewSuggestOrderSizeUseCase(riskService, riskDataClient, orderMarketDataClient)
+		simulatedPricingClient := orderMktClient.NewSimulatedPricingDataClient(simulationConfig)
+		orderPricingClient = simulatedPricingClient
+		quoteHistoryUseCase = orderUsecase.NewGetQuoteHistoryUseCaseWithDefaults(simulatedPricingClient)
+		limitPriceSuggestionUseCase = orderUsecase.NewSuggestLimitPriceUseCase(orderPricingService, simulatedPricingClient)
+	}
+	// Quote snapshots read the cache the quote feed's broadcaster records every quote into; quotes older
+	// than QUOTE_SNAPSHOT_STALE_AFTER are flagged stale. With QUOTE_SNAPSHOT_BATCH_WINDOW set, requests
+	// within the window share one copy of the cache instead of each reading it
+	quoteSnapshotCache := orderService.NewQuoteSnapshotCacheWithDefaults()
+	quoteSnapshotConfig := orderUsecase.DefaultQuoteSnapshotConfig()
+	if staleStr := os.Getenv("QUOTE_SNAPSHOT_STALE_AFTER"); staleStr != "" {
+		if staleAfter, err := time.ParseDuration(staleStr); err == nil && staleAfter >= 0 {
+			quoteSnapshotConfig.StaleAfter = staleAfter
+		} else {
+			fmt.Printf("Warning: Invalid QUOTE_SNAPSHOT_STALE_AFTER %q, using %s\n", staleStr, quoteSnapshotConfig.StaleAfter)
+		}
+	}
+	if windowStr := os.Getenv("QUOTE_SNAPSHOT_BATCH_WINDOW"); windowStr != "" {
+		if window, err := time.ParseDuration(windowStr); err == nil && window >= 0 {
+			quoteSnapshotConfig.BatchWindow = window
+		} else {
+			fmt.Printf("Warning: Invalid QUOTE_SNAPSHOT_BATCH_WINDOW %q, reading the cache on every request\n", windowStr)
+		}
+	}
+	quoteSnapshotUseCase := orderUsecase.NewGetQuoteSnapshotUseCase(quoteSnapshotCache, quoteSnapshotConfig)
 	//====== Order Management System Use Cases end============
 
 	//====== Order Management Infrastructure begin============
 	var orderProducer *orderRabbitMQ.OrderProducer
 	var orderWorkerManager *orderWorker.WorkerManager
 	var submitOrderUseCase orderUsecase.ISubmitOrderUseCase
+	var ifTouchedActivationUseCase orderUsecase.IActivateIfTouchedOrdersUseCase
+	// Pegged limit orders are repriced from quotes fed to the repricing use case, within a band of
+	// PEGGED_ORDER_BAND_PERCENT around the submitted price unless the order sets its own bounds
+	peggedOrderConfig := orderService.DefaultPeggedOrderConfig()
+	if bandStr := os.Getenv("PEGGED_ORDER_BAND_PERCENT"); bandStr != "" {
+		if band, err := strconv.ParseFloat(bandStr, 64); err == nil && band >= 0 {
+			peggedOrderConfig.DefaultBandPercent = band
+		} else {
+			fmt.Printf("Warning: Invalid PEGGED_ORDER_BAND_PERCENT %q, using default %.1f\n", bandStr, peggedOrderConfig.DefaultBandPercent)
+		}
+	}
+	peggedOrderBook := orderService.NewPeggedOrderBook(peggedOrderConfig)
+	peggedRepricingUseCase := orderUsecase.NewRepricePeggedOrdersUseCase(orderRepo, peggedOrderBook)
+	// Validating, saving and publishing a submission is one idempotent operation; a retry takes over a
+	// submission still pending after ORDER_SUBMISSION_STALE_AFTER (a Go duration, "0" never takes over)
+	pipelineIdempotencyConfig := orderUsecase.DefaultPipelineIdempotencyConfig()
+	if staleStr := os.Getenv("ORDER_SUBMISSION_STALE_AFTER"); staleStr != "" {
+		if staleAfter, err := time.ParseDuration(staleStr); err == nil && staleAfter >= 0 {
+			pipelineIdempotencyConfig.StalePendingAfter = staleAfter
+		} else {
+			fmt.Printf("Warning: Invalid ORDER_SUBMISSION_STALE_AFTER %q, using %s\n", staleStr, pipelineIdempotencyConfig.StalePendingAfter)
+		}
+	}
+	// A symbol whose quotes move VOLATILITY_HALT_MOVE_PERCENT within the halt window is halted: the quote
+	// feed records every quote and submission rejects new orders for the symbol until it calms down
+	volatilityHaltConfig := orderService.DefaultVolatilityHaltConfig()
+	if moveStr := os.Getenv("VOLATILITY_HALT_MOVE_PERCENT"); moveStr != "" {
+		if move, err := strconv.ParseFloat(moveStr, 64); err == nil && move > 0 {
+			volatilityHaltConfig.MovePercent = move
+		} else {
+			fmt.Printf("Warning: Invalid VOLATILITY_HALT_MOVE_PERCENT %q, using default %.1f\n", moveStr, volatilityHaltConfig.MovePercent)
+		}
+	}
+	volatilityHaltService := orderService.NewVolatilityHaltService(volatilityHaltConfig)
+	submitOrderDependencies := orderUsecase.SubmitOrderDependencies{
+		OrderRepository:    orderRepo,
+		MarketDataClient:   validatingMarketDataClient,
+		IdempotencyService: idempotencyService,
+		WebhookDispatcher:  orderWebhookDispatcher,
+		PricingService:     orderPricingService,
+		PricingClient:      orderPricingClient,
+		// Each order records the quote it was submitted into, for execution quality reviews
+		ContextSource:       orderPricingClient,
+		SnapshotRepository:  orderPersistence.NewMarketContextSnapshotRepository(db),
+		VolatilityHalts:     volatilityHaltService,
+		RejectedOrders:      rejectedOrderRepo,
+		LatencyTracker:      orderLatencyTracker,
+		TriggerBook:         ifTouchedTriggerBook,
+		PegBook:             peggedOrderBook,
+		Notifier:            orderNotificationDispatcher,
+		PipelineIdempotency: &pipelineIdempotencyConfig,
+		// Orders far out of line with the user's order history need acknowledging before they are placed
+		FatFinger: orderService.NewFatFingerServiceWithDefaults(orderRepo),
+	}
 
 	// Only create producer and worker manager if messaging is available
 	if messageHandler != nil {
-		orderProducer = orderRabbitMQ.NewOrderProducer(messageHandler)
+		// Market orders and premium users are queued ahead of passive orders
+		premiumUsers := orderRabbitMQ.NewStaticUserTierProvider(strings.Split(os.Getenv("PREMIUM_USER_IDS"), ","))
+		orderProducer = orderRabbitMQ.NewOrderProducerWithPriorityPolicy(messageHandler,
+			orderRabbitMQ.NewMessagePriorityPolicy(orderRabbitMQ.DefaultMessagePriorityConfig(), premiumUsers))
 
 		// Create SubmitOrderUseCase with OrderProducer dependency
-		submitOrderUseCase = orderUsecase.NewSubmitOrderUseCase(orderRepo, orderMarketDataClient, idempotencyService, orderProducer)
+		submitOrderDependencies.OrderProducer = orderProducer
+		// TWAP orders are published as child slices paced over the scheduler's window
+		submitOrderDependencies.TWAPScheduler = orderService.NewTWAPExecutionSchedulerWithDefaults(
+			orderUsecase.NewTWAPSliceOrderSubmitter(orderRepo, orderProducer))
+		submitOrderUseCase = orderUsecase.NewSubmitOrderUseCase(submitOrderDependencies)
+		ifTouchedActivationUseCase = orderUsecase.NewActivateIfTouchedOrdersUseCase(orderRepo, ifTouchedTriggerBook, orderProducer)
+
+		// On start, republish the position updates of orders executed within POSITION_UPDATE_RECOVERY_LOOKBACK
+		// (a Go duration, "0" disables) that a crash kept from being published; defaults to 24 hours
+		recoveryConfig := orderUsecase.DefaultPositionUpdateRecoveryConfig()
+		if lookbackStr := os.Getenv("POSITION_UPDATE_RECOVERY_LOOKBACK"); lookbackStr != "" {
+			if lookback, err := time.ParseDuration(lookbackStr); err == nil {
+				recoveryConfig.Lookback = lookback
+			} else {
+				fmt.Printf("Warning: Invalid POSITION_UPDATE_RECOVERY_LOOKBACK %q, using %s: %v\n", lookbackStr, recoveryConfig.Lookback, err)
+			}
+		}
 
 		// Create worker manager with default configuration
 		workerManagerConfig := orderWorker.DefaultWorkerManagerConfig()
-		orderWorkerManager = orderWorker.NewWorkerManager(
+		orderWorkerManager = orderWorker.NewWorkerManagerWithRecovery(
 			processOrderUseCase,
 			messageHandler,
 			workerManagerConfig,
+			orderUsecase.NewRecoverPositionUpdatesUseCase(orderRepo, positionUpdatePublicationRepo, orderEventPublisher, recoveryConfig),
 		)
 
 		// Start worker manager in background
@@ -381,8 +1033,63 @@ func NewContainer() (Container, error) {
 		}()
 	} else {
 		// Create SubmitOrderUseCase without OrderProducer when messaging is not available
-		submitOrderUseCase = orderUsecase.NewSubmitOrderUseCase(orderRepo, orderMarketDataClient, idempotencyService, nil)
+		submitOrderUseCase = orderUsecase.NewSubmitOrderUseCase(submitOrderDependencies)
+		// Activated orders are saved but not published until messaging is available
+		ifTouchedActivationUseCase = orderUsecase.NewActivateIfTouchedOrdersUseCase(orderRepo, ifTouchedTriggerBook, nil)
+	}
+
+	// The quote feed polls the symbols in QUOTE_FEED_SYMBOLS (comma separated) and every symbol with
+	// resting orders each QUOTE_FEED_INTERVAL (a Go duration) and broadcasts the quotes to subscribers;
+	// each quote also feeds the volatility halts and, unless the symbol is halted, activates the
+	// if-touched orders it touches and reprices the pegged orders it moves; it is kept for quote snapshots
+	quoteFeedConfig := orderMessaging.DefaultQuoteFeedConfig()
+	if symbolsStr := os.Getenv("QUOTE_FEED_SYMBOLS"); symbolsStr != "" {
+		quoteFeedConfig.Symbols = strings.Split(symbolsStr, ",")
+	}
+	if intervalStr := os.Getenv("QUOTE_FEED_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil && interval > 0 {
+			quoteFeedConfig.PollInterval = interval
+		} else {
+			fmt.Printf("Warning: Invalid QUOTE_FEED_INTERVAL %q, using %s\n", intervalStr, quoteFeedConfig.PollInterval)
+		}
 	}
+	quoteStreamBroadcaster := orderMessaging.NewQuoteStreamBroadcasterWithSnapshotCache(
+		orderPricingClient,
+		orderService.NewOrderBookPressureServiceWithDefaults(),
+		webSocketManager,
+		volatilityHaltService,
+		ifTouchedActivationUseCase,
+		peggedRepricingUseCase,
+		quoteSnapshotCache,
+	)
+	quoteFeed := orderMessaging.NewQuoteFeed(quoteStreamBroadcaster, quoteFeedConfig, ifTouchedTriggerBook, peggedOrderBook)
+
+	// New accounts cannot trade until they hold the minimum balance configured in MIN_TRADING_BALANCE
+	if minBalanceStr := os.Getenv("MIN_TRADING_BALANCE"); minBalanceStr != "" {
+		if minBalance, err := strconv.ParseFloat(minBalanceStr, 64); err == nil && minBalance > 0 {
+			submitOrderUseCase = orderUsecase.NewAccountGatedSubmitOrderUseCase(submitOrderUseCase,
+				orderMktClient.NewBalanceAccountSource(balanceRepo),
+				orderUsecase.AccountGatingConfig{MinimumBalance: minBalance})
+		}
+	}
+
+	// Warn before placing an order matching one of the user's open orders from the last
+	// DUPLICATE_ORDER_WINDOW (a Go duration, "0" disables); defaults to 30 seconds
+	duplicateOrderConfig := orderUsecase.DefaultDuplicateOrderConfig()
+	if windowStr := os.Getenv("DUPLICATE_ORDER_WINDOW"); windowStr != "" {
+		if window, err := time.ParseDuration(windowStr); err == nil {
+			duplicateOrderConfig.Window = window
+		} else {
+			fmt.Printf("Warning: Invalid DUPLICATE_ORDER_WINDOW %q, using %s: %v\n", windowStr, duplicateOrderConfig.Window, err)
+		}
+	}
+	if duplicateOrderConfig.Window > 0 {
+		submitOrderUseCase = orderUsecase.NewDuplicateGuardedSubmitOrderUseCase(submitOrderUseCase, orderRepo, duplicateOrderConfig)
+	}
+
+	// Fill what a submission leaves out from the user's default order settings, for HTTP and gRPC
+	// alike, before the duplicate and balance checks look at the order
+	submitOrderUseCase = orderUsecase.NewUserDefaultsSubmitOrderUseCase(submitOrderUseCase, orderPreferencesRepo)
 	//====== Order Management Infrastructure end============
 
 	//====== Position Management Infrastructure begin============
@@ -392,11 +1099,27 @@ func NewContainer() (Container, error) {
 	if messageHandler != nil {
 		// Create position worker with default configuration
 		workerConfig := positionWorker.DefaultPositionWorkerConfig("position-worker-1")
-		positionWorkerManager = positionWorker.NewPositionUpdateWorker(
+		// POSITION_WORKER_ADAPTIVE_PREFETCH=true tunes prefetch from processing latency, halving it
+		// above POSITION_WORKER_PREFETCH_HIGH_LATENCY (a Go duration)
+		if adaptive, err := strconv.ParseBool(os.Getenv("POSITION_WORKER_ADAPTIVE_PREFETCH")); err == nil && adaptive {
+			workerConfig.AdaptivePrefetch = positionWorker.DefaultAdaptivePrefetchConfig(workerConfig.MaxConcurrentUpdates * 2)
+			if latencyStr := os.Getenv("POSITION_WORKER_PREFETCH_HIGH_LATENCY"); latencyStr != "" {
+				if latency, err := time.ParseDuration(latencyStr); err == nil && latency > workerConfig.AdaptivePrefetch.LowLatency {
+					workerConfig.AdaptivePrefetch.HighLatency = latency
+				} else {
+					fmt.Printf("Warning: Invalid POSITION_WORKER_PREFETCH_HIGH_LATENCY %q, using %s\n", latencyStr, workerConfig.AdaptivePrefetch.HighLatency)
+				}
+			}
+		}
+		// Buys that find duplicate active positions in a symbol merge them before applying the trade
+		consolidatePositionsUseCase := posUsecase.NewConsolidatePositionsUseCase(
+			positionRepo, positionPersistence.NewPositionLedgerRepository(db), posUsecase.DefaultConsolidatePositionsConfig())
+		positionWorkerManager = positionWorker.NewPositionUpdateWorkerWithConsolidation(
 			"position-worker-1",
 			createPositionUseCase,
 			updatePositionUseCase,
 			closePositionUseCase,
+			consolidatePositionsUseCase,
 			positionRepo,
 			messageHandler,
 			workerConfig,
@@ -408,35 +1131,120 @@ func NewContainer() (Container, error) {
 				fmt.Printf("Warning: Failed to start position worker manager: %v\n", err)
 			}
 		}()
+
+		// Apply splits, dividends and symbol changes published by the corporate-action feed
+		if corporateActionStore, ok := positionRepo.(positionWorker.ICorporateActionPositionStore); ok {
+			corporateActionProcessor := positionWorker.NewCorporateActionProcessor(
+				corporateActionStore, positionPersistence.NewPositionLedgerRepository(db), nil)
+			if err := messageHandler.DeclareQueue(positionWorker.CorporateActionQueueName, messaging.QueueOptions{Durable: true}); err != nil {
+				fmt.Printf("Warning: Failed to declare corporate action queue: %v\n", err)
+			} else if err := messageHandler.Consume(context.Background(), positionWorker.CorporateActionQueueName, corporateActionProcessor); err != nil {
+				fmt.Printf("Warning: Failed to start corporate action consumer: %v\n", err)
+			}
+		}
+	}
+
+	// Reconciliation reports replay executed orders over POSITION_RECONCILIATION_LOOKBACK (a Go
+	// duration) when the request gives no start time, without writing positions
+	reconciliationConfig := positionWorker.DefaultPositionReconciliationReportConfig()
+	if lookbackStr := os.Getenv("POSITION_RECONCILIATION_LOOKBACK"); lookbackStr != "" {
+		if lookback, err := time.ParseDuration(lookbackStr); err == nil && lookback > 0 {
+			reconciliationConfig.Lookback = lookback
+		} else {
+			fmt.Printf("Warning: Invalid POSITION_RECONCILIATION_LOOKBACK %q, using %s\n", lookbackStr, reconciliationConfig.Lookback)
+		}
+	}
+	positionReconciliationReporter := positionWorker.NewPositionReconciliationReporter(
+		positionWorker.NewPositionReplayer(positionWorker.NewOrderTableEventSource(db), positionRepo), reconciliationConfig)
+
+	// End-of-day valuations are marked at the 16:00 close in MARKET_TIMEZONE, using the market data
+	// service's last price as the close
+	var markToMarketJob *positionWorker.MarkToMarketJob
+	activePositionSource, hasActivePositions := positionRepo.(positionWorker.IActivePositionSource)
+	closePriceClient := positionAggregationUseCase.MarketDataClient()
+	switch {
+	case !hasActivePositions:
+		fmt.Printf("Warning: position repository cannot list active positions, mark-to-market disabled\n")
+	case closePriceClient == nil:
+		fmt.Printf("Warning: market data unavailable, mark-to-market disabled\n")
+	default:
+		markToMarketJob = positionWorker.NewMarkToMarketJob(activePositionSource,
+			positionExternal.NewMarketDataClosePriceProvider(closePriceClient),
+			positionPersistence.NewPositionValuationRepository(db),
+			&positionWorker.WeekdayTradingCalendar{CloseHour: 16, Location: marketLocation}, nil)
 	}
 	//====== Position Management Infrastructure end============
 
+	// Daily trading usage resets when each account's trading day starts, skipping weekends and market holidays
+	tradingLimitResetConfig := orderWorker.DefaultTradingLimitResetJobConfig()
+	tradingLimitResetConfig.DefaultTimezone = marketLocation.String()
+	tradingLimitResetJob := orderWorker.NewTradingLimitResetJob(orderWorker.NewTableTradingLimitRepository(db),
+		marketCalendar, tradingLimitResetConfig)
+
 	watchRepo := watchPersistence.NewWatchlistRepository(db)
 	watchlistUsecase := watchlistUsecase.NewGetWatchlistUsecase(watchRepo, orderMarketDataClient)
 
 	return &containerImpl{
-		PositionAggregationUseCase: positionAggregationUseCase,
-		CreatePositionUseCase:      createPositionUseCase,
-		UpdatePositionUseCase:      updatePositionUseCase,
-		ClosePositionUseCase:       closePositionUseCase,
-		BalanceUsecase:             balanceUsecase,
-		PortfolioSummaryUsecase:    portfolioSummaryUseCase,
-		WatchlistUsecase:           watchlistUsecase,
-		LoginUsecase:               loginUsecase,
-		AuthService:                authService,
-		MessageHandler:             messageHandler,
-		WebSocketManager:           webSocketManager,
-		OrderMarketDataClient:      orderMarketDataClient,
-		OrderRepository:            orderRepo,
-		SubmitOrderUseCase:         submitOrderUseCase,
-		GetOrderStatusUseCase:      getOrderStatusUseCase,
-		CancelOrderUseCase:         cancelOrderUseCase,
-		ProcessOrderUseCase:        processOrderUseCase,
-		OrderProducer:              orderProducer,
-		OrderEventPublisher:        orderEventPublisher,
-		OrderWorkerManager:         orderWorkerManager,
-		IdempotencyService:         idempotencyService,
-		PositionWorkerManager:      positionWorkerManager,
+		PositionAggregationUseCase:     positionAggregationUseCase,
+		CreatePositionUseCase:          createPositionUseCase,
+		UpdatePositionUseCase:          updatePositionUseCase,
+		ClosePositionUseCase:           closePositionUseCase,
+		ClosePreviewUseCase:            closePreviewUseCase,
+		RevaluePositionsUseCase:        revaluePositionsUseCase,
+		DailyPnLUseCase:                dailyPnLUseCase,
+		ManagePnLAlertsUseCase:         managePnLAlertsUseCase,
+		EvaluatePnLAlertsUseCase:       evaluatePnLAlertsUseCase,
+		PnLAlertNotifier:               pnlAlertNotifier,
+		BalanceUsecase:                 balanceUsecase,
+		PortfolioSummaryUsecase:        portfolioSummaryUseCase,
+		WatchlistUsecase:               watchlistUsecase,
+		LoginUsecase:                   loginUsecase,
+		AuthService:                    authService,
+		SessionService:                 sessionService,
+		MessageHandler:                 messageHandler,
+		WebSocketManager:               webSocketManager,
+		OrderMarketDataClient:          orderMarketDataClient,
+		OrderRepository:                orderRepo,
+		OrderPreferencesRepo:           orderPreferencesRepo,
+		NotificationPreferences:        notificationPreferencesRepo,
+		SubmitOrderUseCase:             submitOrderUseCase,
+		GetOrderStatusUseCase:          getOrderStatusUseCase,
+		CancelOrderUseCase:             cancelOrderUseCase,
+		PartialCancelUseCase:           partialCancelOrderUseCase,
+		ProcessOrderUseCase:            processOrderUseCase,
+		ExecutionQuality:               executionQualityUseCase,
+		RejectedOrders:                 rejectedOrdersUseCase,
+		OrderLatency:                   orderLatencyUseCase,
+		OrderFills:                     orderFillsUseCase,
+		UserRiskProfile:                userRiskProfileUseCase,
+		ForceCancelOrder:               forceCancelOrderUseCase,
+		IfTouchedActivation:            ifTouchedActivationUseCase,
+		PeggedRepricing:                peggedRepricingUseCase,
+		OrderRiskCheck:                 orderRiskCheckUseCase,
+		OrderSizeSuggestion:            orderSizeSuggestionUseCase,
+		LimitPriceSuggestion:           limitPriceSuggestionUseCase,
+		QuoteHistory:                   quoteHistoryUseCase,
+		QuoteSnapshot:                  quoteSnapshotUseCase,
+		QuoteSnapshots:                 quoteSnapshotCache,
+		LatencyTracker:                 orderLatencyTracker,
+		PipelineMetrics:                orderPipelineMetrics,
+		MarketDataFreshness:            marketDataFreshness,
+		QuoteFeed:                      quoteFeed,
+		PricePrecision:                 pricePrecisionResolver,
+		NotificationSender:             orderNotificationSender,
+		stopFreshnessChecks:            stopFreshnessChecks,
+		OrderProducer:                  orderProducer,
+		OrderEventPublisher:            orderEventPublisher,
+		OrderWorkerManager:             orderWorkerManager,
+		TradingLimitReset:              tradingLimitResetJob,
+		OrderExpiry:                    orderExpiryJob,
+		IdempotencyService:             idempotencyService,
+		DisconnectMonitor:              disconnectMonitor,
+		PositionWorkerManager:          positionWorkerManager,
+		PositionReconciliationReporter: positionReconciliationReporter,
+		MarkToMarketJob:                markToMarketJob,
+		SyncSymbolUniverseUseCase:      syncSymbolUniverseUseCase,
+		SearchSymbolsUseCase:           searchSymbolsUseCase,
 	}, nil
 }
 
@@ -2,15 +2,23 @@ package di
 
 import (
 	"HubInvestments/internal/auth"
+	authSession "HubInvestments/internal/auth/session"
 	balUsecase "HubInvestments/internal/balance/application/usecase"
 	doLoginUsecase "HubInvestments/internal/login/application/usecase"
 	orderUsecase "HubInvestments/internal/order_mngmt_system/application/usecase"
+	orderRepository "HubInvestments/internal/order_mngmt_system/domain/repository"
+	orderService "HubInvestments/internal/order_mngmt_system/domain/service"
 	orderMktClient "HubInvestments/internal/order_mngmt_system/infra/external"
+	orderMessaging "HubInvestments/internal/order_mngmt_system/infra/messaging"
 	orderRabbitMQ "HubInvestments/internal/order_mngmt_system/infra/messaging/rabbitmq"
+	orderNotification "HubInvestments/internal/order_mngmt_system/infra/notification"
+	orderSession "HubInvestments/internal/order_mngmt_system/infra/session"
 	orderWorker "HubInvestments/internal/order_mngmt_system/infra/worker"
 	portfolioUsecase "HubInvestments/internal/portfolio_summary/application/usecase"
 	posUsecase "HubInvestments/internal/position/application/usecase"
+	positionNotification "HubInvestments/internal/position/infra/notification"
 	positionWorker "HubInvestments/internal/position/infra/worker"
+	symbolUsecase "HubInvestments/internal/symbol_universe/application/usecase"
 	watchlistUsecase "HubInvestments/internal/watchlist/application/usecase"
 	"HubInvestments/shared/infra/messaging"
 	"HubInvestments/shared/infra/websocket"
@@ -20,10 +28,16 @@ import (
 // It implements the Container interface with configurable services
 type TestContainer struct {
 	authService                auth.IAuthService
+	sessionService             authSession.ISessionService
 	positionAggregationUseCase *posUsecase.GetPositionAggregationUseCase
 	createPositionUseCase      posUsecase.ICreatePositionUseCase
 	updatePositionUseCase      posUsecase.IUpdatePositionUseCase
 	closePositionUseCase       posUsecase.IClosePositionUseCase
+	closePreviewUseCase        posUsecase.IGetClosePreviewUseCase
+	revaluePositionsUseCase    posUsecase.IRevaluePositionsUseCase
+	dailyPnLUseCase            posUsecase.IGetDailyPnLUseCase
+	managePnLAlertsUseCase     posUsecase.IManagePnLAlertsUseCase
+	reconciliationReporter     *positionWorker.PositionReconciliationReporter
 	getBalanceUsecase          *balUsecase.GetBalanceUseCase
 	getPortfolioSummary        portfolioUsecase.PortfolioSummaryUsecase
 	getWatchlistUsecase        watchlistUsecase.IGetWatchlistUsecase
@@ -47,6 +61,12 @@ func (c *TestContainer) WithAuthService(service auth.IAuthService) *TestContaine
 	return c
 }
 
+// WithSessionService sets the SessionService for testing
+func (c *TestContainer) WithSessionService(service authSession.ISessionService) *TestContainer {
+	c.sessionService = service
+	return c
+}
+
 // WithPositionAggregationUseCase sets the PositionAggregationUseCase for testing
 func (c *TestContainer) WithPositionAggregationUseCase(usecase *posUsecase.GetPositionAggregationUseCase) *TestContainer {
 	c.positionAggregationUseCase = usecase
@@ -71,6 +91,36 @@ func (c *TestContainer) WithClosePositionUseCase(usecase posUsecase.IClosePositi
 	return c
 }
 
+// WithClosePreviewUseCase sets the GetClosePreviewUseCase for testing
+func (c *TestContainer) WithClosePreviewUseCase(usecase posUsecase.IGetClosePreviewUseCase) *TestContainer {
+	c.closePreviewUseCase = usecase
+	return c
+}
+
+// WithRevaluePositionsUseCase sets the RevaluePositionsUseCase for testing
+func (c *TestContainer) WithRevaluePositionsUseCase(usecase posUsecase.IRevaluePositionsUseCase) *TestContainer {
+	c.revaluePositionsUseCase = usecase
+	return c
+}
+
+// WithDailyPnLUseCase sets the GetDailyPnLUseCase for testing
+func (c *TestContainer) WithDailyPnLUseCase(usecase posUsecase.IGetDailyPnLUseCase) *TestContainer {
+	c.dailyPnLUseCase = usecase
+	return c
+}
+
+// WithManagePnLAlertsUseCase sets the ManagePnLAlertsUseCase for testing
+func (c *TestContainer) WithManagePnLAlertsUseCase(usecase posUsecase.IManagePnLAlertsUseCase) *TestContainer {
+	c.managePnLAlertsUseCase = usecase
+	return c
+}
+
+// WithPositionReconciliationReporter sets the PositionReconciliationReporter for testing
+func (c *TestContainer) WithPositionReconciliationReporter(reporter *positionWorker.PositionReconciliationReporter) *TestContainer {
+	c.reconciliationReporter = reporter
+	return c
+}
+
 // WithBalanceUseCase sets the BalanceUseCase for testing
 func (c *TestContainer) WithBalanceUseCase(usecase *balUsecase.GetBalanceUseCase) *TestContainer {
 	c.getBalanceUsecase = usecase
@@ -93,6 +143,11 @@ func (c *TestContainer) GetAuthService() auth.IAuthService {
 	return c.authService
 }
 
+// GetSessionService returns the configured SessionService or nil
+func (c *TestContainer) GetSessionService() authSession.ISessionService {
+	return c.sessionService
+}
+
 // GetPositionAggregationUseCase returns the configured PositionAggregationUseCase or nil
 func (c *TestContainer) GetPositionAggregationUseCase() *posUsecase.GetPositionAggregationUseCase {
 	return c.positionAggregationUseCase
@@ -113,6 +168,34 @@ func (c *TestContainer) GetClosePositionUseCase() posUsecase.IClosePositionUseCa
 	return c.closePositionUseCase
 }
 
+// GetClosePreviewUseCase returns the configured GetClosePreviewUseCase or nil
+func (c *TestContainer) GetClosePreviewUseCase() posUsecase.IGetClosePreviewUseCase {
+	return c.closePreviewUseCase
+}
+
+// GetRevaluePositionsUseCase returns the configured RevaluePositionsUseCase or nil
+func (c *TestContainer) GetRevaluePositionsUseCase() posUsecase.IRevaluePositionsUseCase {
+	return c.revaluePositionsUseCase
+}
+
+// GetDailyPnLUseCase returns the configured GetDailyPnLUseCase or nil
+func (c *TestContainer) GetDailyPnLUseCase() posUsecase.IGetDailyPnLUseCase {
+	return c.dailyPnLUseCase
+}
+
+// GetManagePnLAlertsUseCase returns the configured ManagePnLAlertsUseCase or nil
+func (c *TestContainer) GetManagePnLAlertsUseCase() posUsecase.IManagePnLAlertsUseCase {
+	return c.managePnLAlertsUseCase
+}
+
+func (c *TestContainer) GetEvaluatePnLAlertsUseCase() posUsecase.IEvaluatePnLAlertsUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetPnLAlertNotifier() *positionNotification.WebSocketPnLAlertNotifier {
+	return nil
+}
+
 func (c *TestContainer) GetBalanceUseCase() *balUsecase.GetBalanceUseCase {
 	return c.getBalanceUsecase
 }
@@ -156,10 +239,98 @@ func (c *TestContainer) GetCancelOrderUseCase() orderUsecase.ICancelOrderUseCase
 	return nil
 }
 
+func (c *TestContainer) GetPartialCancelOrderUseCase() orderUsecase.IPartialCancelOrderUseCase {
+	return nil
+}
+
 func (c *TestContainer) GetProcessOrderUseCase() orderUsecase.IProcessOrderUseCase {
 	return nil
 }
 
+func (c *TestContainer) GetExecutionQualityUseCase() orderUsecase.IGetExecutionQualityUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetCheckOrderRiskUseCase() orderUsecase.ICheckOrderRiskUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetSuggestOrderSizeUseCase() orderUsecase.ISuggestOrderSizeUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetSuggestLimitPriceUseCase() orderUsecase.ISuggestLimitPriceUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetRejectedOrdersUseCase() orderUsecase.IGetRejectedOrdersUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetUpdateUserRiskProfileUseCase() orderUsecase.IUpdateUserRiskProfileUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetForceCancelOrderUseCase() orderUsecase.IForceCancelOrderUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetActivateIfTouchedOrdersUseCase() orderUsecase.IActivateIfTouchedOrdersUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetRepricePeggedOrdersUseCase() orderUsecase.IRepricePeggedOrdersUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetQuoteHistoryUseCase() orderUsecase.IGetQuoteHistoryUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetQuoteSnapshotUseCase() orderUsecase.IGetQuoteSnapshotUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetQuoteSnapshotCache() orderService.QuoteSnapshotCache {
+	return nil
+}
+
+func (c *TestContainer) GetOrderLatencyUseCase() orderUsecase.IGetOrderLatencyUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetOrderFillsUseCase() orderUsecase.IGetOrderFillsUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetOrderLatencyTracker() orderService.OrderLatencyTracker {
+	return nil
+}
+
+func (c *TestContainer) GetOrderPipelineMetrics() orderService.OrderPipelineMetrics {
+	return nil
+}
+
+func (c *TestContainer) GetMarketDataFreshnessMonitor() *orderMktClient.MarketDataFreshnessMonitor {
+	return nil
+}
+
+func (c *TestContainer) GetQuoteFeed() *orderMessaging.QuoteFeed {
+	return nil
+}
+
+func (c *TestContainer) GetPricePrecisionResolver() orderMktClient.IPricePrecisionResolver {
+	return nil
+}
+
+func (c *TestContainer) GetUserOrderPreferencesRepository() orderRepository.IUserOrderPreferencesRepository {
+	return nil
+}
+
+func (c *TestContainer) GetOrderNotificationPreferencesRepository() orderRepository.IOrderNotificationPreferencesRepository {
+	return nil
+}
+
 // Order Management System - Infrastructure methods - no-op implementations for testing
 func (c *TestContainer) GetOrderProducer() *orderRabbitMQ.OrderProducer {
 	return nil
@@ -169,10 +340,43 @@ func (c *TestContainer) GetOrderWorkerManager() *orderWorker.WorkerManager {
 	return nil
 }
 
+func (c *TestContainer) GetTradingLimitResetJob() *orderWorker.TradingLimitResetJob {
+	return nil
+}
+
+func (c *TestContainer) GetOrderExpiryJob() *orderWorker.OrderExpiryJob {
+	return nil
+}
+
+func (c *TestContainer) GetCancelOnDisconnectMonitor() *orderSession.CancelOnDisconnectMonitor {
+	return nil
+}
+
+func (c *TestContainer) GetOrderNotificationSender() *orderNotification.WebSocketOrderNotificationSender {
+	return nil
+}
+
 func (c *TestContainer) GetPositionWorkerManager() *positionWorker.PositionUpdateWorker {
 	return nil
 }
 
+func (c *TestContainer) GetPositionReconciliationReporter() *positionWorker.PositionReconciliationReporter {
+	return c.reconciliationReporter
+}
+
+func (c *TestContainer) GetMarkToMarketJob() *positionWorker.MarkToMarketJob {
+	return nil
+}
+
+// Symbol Universe methods - no-op implementations for testing
+func (c *TestContainer) GetSyncSymbolUniverseUseCase() symbolUsecase.ISyncSymbolUniverseUseCase {
+	return nil
+}
+
+func (c *TestContainer) GetSearchSymbolsUseCase() symbolUsecase.ISearchSymbolsUseCase {
+	return nil
+}
+
 // Close implements the Container interface - no-op for testing
 func (c *TestContainer) Close() error {
 	return nil
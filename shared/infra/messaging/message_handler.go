@@ -82,6 +82,12 @@ type MessageConsumer interface {
 	HandleMessage(ctx context.Context, message *Message) error
 }
 
+// PrefetchAdjuster is implemented by message handlers that can change how many unacknowledged
+// messages a running consumer holds without restarting it
+type PrefetchAdjuster interface {
+	SetPrefetchCount(queueName string, prefetchCount int) error
+}
+
 // Message represents a received message
 type Message struct {
 	Body          []byte
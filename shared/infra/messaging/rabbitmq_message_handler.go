@@ -17,6 +17,9 @@ type RabbitMQMessageHandler struct {
 	channel    *amqp.Channel
 	mutex      sync.RWMutex
 	closed     bool
+
+	consumerChannels      map[string][]*amqp.Channel // Dedicated consumer channels by queue
+	consumerChannelsMutex sync.Mutex
 }
 
 // NewRabbitMQMessageHandler creates a new RabbitMQ message handler
@@ -320,6 +323,8 @@ func (r *RabbitMQMessageHandler) Consume(ctx context.Context, queueName string,
 		}
 	}
 
+	r.trackConsumerChannel(queueName, consumerChannel)
+
 	// NOTE: Queue should already be declared by queue setup manager
 	// Don't redeclare here to avoid TTL configuration conflicts
 
@@ -334,6 +339,7 @@ func (r *RabbitMQMessageHandler) Consume(ctx context.Context, queueName string,
 		nil,       // args
 	)
 	if err != nil {
+		r.untrackConsumerChannel(queueName, consumerChannel)
 		consumerChannel.Close()
 		return fmt.Errorf("failed to register consumer: %w", err)
 	}
@@ -341,6 +347,7 @@ func (r *RabbitMQMessageHandler) Consume(ctx context.Context, queueName string,
 	// Process messages in dedicated goroutine with dedicated channel
 	go func() {
 		defer consumerChannel.Close() // Close channel when goroutine exits
+		defer r.untrackConsumerChannel(queueName, consumerChannel)
 		for {
 			select {
 			case <-ctx.Done():
@@ -387,6 +394,56 @@ func (r *RabbitMQMessageHandler) Consume(ctx context.Context, queueName string,
 	return nil
 }
 
+// SetPrefetchCount changes the prefetch of the queue's running consumers. The limit is applied
+// channel-wide, which RabbitMQ enforces on existing consumers, while the per-consumer limit set
+// when consuming started stays the ceiling.
+func (r *RabbitMQMessageHandler) SetPrefetchCount(queueName string, prefetchCount int) error {
+	if prefetchCount <= 0 {
+		return fmt.Errorf("prefetch count must be positive, got %d", prefetchCount)
+	}
+
+	r.consumerChannelsMutex.Lock()
+	channels := append([]*amqp.Channel(nil), r.consumerChannels[queueName]...)
+	r.consumerChannelsMutex.Unlock()
+
+	if len(channels) == 0 {
+		return fmt.Errorf("no consumer running on queue %s", queueName)
+	}
+
+	for _, ch := range channels {
+		if err := ch.Qos(prefetchCount, 0, true); err != nil {
+			return fmt.Errorf("failed to set prefetch on queue %s: %w", queueName, err)
+		}
+	}
+	return nil
+}
+
+func (r *RabbitMQMessageHandler) trackConsumerChannel(queueName string, ch *amqp.Channel) {
+	r.consumerChannelsMutex.Lock()
+	defer r.consumerChannelsMutex.Unlock()
+
+	if r.consumerChannels == nil {
+		r.consumerChannels = make(map[string][]*amqp.Channel)
+	}
+	r.consumerChannels[queueName] = append(r.consumerChannels[queueName], ch)
+}
+
+func (r *RabbitMQMessageHandler) untrackConsumerChannel(queueName string, ch *amqp.Channel) {
+	r.consumerChannelsMutex.Lock()
+	defer r.consumerChannelsMutex.Unlock()
+
+	channels := r.consumerChannels[queueName]
+	for i, tracked := range channels {
+		if tracked == ch {
+			r.consumerChannels[queueName] = append(channels[:i], channels[i+1:]...)
+			break
+		}
+	}
+	if len(r.consumerChannels[queueName]) == 0 {
+		delete(r.consumerChannels, queueName)
+	}
+}
+
 // DeclareQueue creates a queue if it doesn't exist
 func (r *RabbitMQMessageHandler) DeclareQueue(queueName string, options QueueOptions) error {
 	r.mutex.Lock()
@@ -0,0 +1,78 @@
+package messaging
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SequenceGenerator hands out strictly increasing sequence numbers.
+// Numbers are seeded from the wall clock so they keep increasing across restarts.
+type SequenceGenerator struct {
+	last atomic.Int64
+}
+
+func NewSequenceGenerator() *SequenceGenerator {
+	return &SequenceGenerator{}
+}
+
+// Next returns a sequence number greater than any previously returned one
+func (g *SequenceGenerator) Next() int64 {
+	for {
+		last := g.last.Load()
+		next := time.Now().UnixNano()
+		if next <= last {
+			next = last + 1
+		}
+		if g.last.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
+
+// SequenceTracker remembers which sequence numbers were applied per key so consumers can discard
+// redelivered messages. Each sequence is tracked on its own: a lower sequence arriving after a higher
+// one is a different update, e.g. an earlier fill of the same order, and is not discarded.
+type SequenceTracker struct {
+	mu      sync.Mutex
+	applied map[string]map[int64]struct{}
+	keys    []string
+	maxKeys int
+}
+
+// NewSequenceTracker creates a tracker that remembers at most maxKeys keys, evicting the oldest first
+func NewSequenceTracker(maxKeys int) *SequenceTracker {
+	return &SequenceTracker{
+		applied: make(map[string]map[int64]struct{}),
+		keys:    make([]string, 0),
+		maxKeys: maxKeys,
+	}
+}
+
+// IsApplied reports whether a message with this sequence was already applied for the key
+func (t *SequenceTracker) IsApplied(key string, sequence int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.applied[key][sequence]
+	return ok
+}
+
+// MarkApplied records the sequence as applied for the key
+func (t *SequenceTracker) MarkApplied(key string, sequence int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sequences, ok := t.applied[key]
+	if !ok {
+		if t.maxKeys > 0 && len(t.keys) >= t.maxKeys {
+			oldest := t.keys[0]
+			t.keys = t.keys[1:]
+			delete(t.applied, oldest)
+		}
+		t.keys = append(t.keys, key)
+		sequences = make(map[int64]struct{})
+		t.applied[key] = sequences
+	}
+	sequences[sequence] = struct{}{}
+}
@@ -0,0 +1,64 @@
+package messaging
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceGenerator_NextIsStrictlyIncreasingAcrossGoroutines(t *testing.T) {
+	generator := NewSequenceGenerator()
+
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			previous := int64(0)
+			for j := 0; j < 100; j++ {
+				next := generator.Next()
+				assert.Greater(t, next, previous)
+				previous = next
+
+				mu.Lock()
+				assert.False(t, seen[next], "sequence %d handed out twice", next)
+				seen[next] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, 1000)
+}
+
+func TestSequenceTracker_TracksEachAppliedSequence(t *testing.T) {
+	tracker := NewSequenceTracker(10)
+
+	assert.False(t, tracker.IsApplied("order-1", 5))
+	tracker.MarkApplied("order-1", 5)
+
+	assert.True(t, tracker.IsApplied("order-1", 5), "a redelivered sequence must be reported as applied")
+	assert.False(t, tracker.IsApplied("order-1", 3), "a lower sequence is a different update and must still apply")
+	assert.False(t, tracker.IsApplied("order-1", 6))
+	assert.False(t, tracker.IsApplied("order-2", 5))
+
+	tracker.MarkApplied("order-1", 3)
+	assert.True(t, tracker.IsApplied("order-1", 3))
+	assert.True(t, tracker.IsApplied("order-1", 5))
+}
+
+func TestSequenceTracker_EvictsOldestKey(t *testing.T) {
+	tracker := NewSequenceTracker(2)
+
+	tracker.MarkApplied("order-1", 10)
+	tracker.MarkApplied("order-2", 10)
+	tracker.MarkApplied("order-3", 10)
+
+	assert.False(t, tracker.IsApplied("order-1", 10), "evicted key should no longer be tracked")
+	assert.True(t, tracker.IsApplied("order-2", 10))
+	assert.True(t, tracker.IsApplied("order-3", 10))
+}
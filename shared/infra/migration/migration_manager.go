@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"path/filepath"
+	"regexp"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -16,22 +17,76 @@ type MigrationManager struct {
 	migrate *migrate.Migrate
 }
 
+// ModuleMigrationConfig isolates one module's migrations from the rest of the project.
+// Each module records its version in its own table, so modules migrate independently.
+type ModuleMigrationConfig struct {
+	Module         string // Module name such as "balance"; prefixes the module's version table
+	MigrationsPath string // Directory holding the module's migration files
+	SchemaName     string // Schema holding the version table; empty uses the connection's current schema
+}
+
+var moduleIdentifierPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// MigrationsTable returns the name of the table the module's version is tracked in
+func (c ModuleMigrationConfig) MigrationsTable() string {
+	return fmt.Sprintf("%s_%s", c.Module, postgres.DefaultMigrationsTable)
+}
+
+// Validate checks that the module and schema names are safe to use as SQL identifiers
+func (c ModuleMigrationConfig) Validate() error {
+	if !moduleIdentifierPattern.MatchString(c.Module) {
+		return fmt.Errorf("invalid module name %q: use lowercase letters, digits and underscores", c.Module)
+	}
+	if c.MigrationsPath == "" {
+		return fmt.Errorf("migrations path is required for module %s", c.Module)
+	}
+	if c.SchemaName != "" && !moduleIdentifierPattern.MatchString(c.SchemaName) {
+		return fmt.Errorf("invalid schema name %q: use lowercase letters, digits and underscores", c.SchemaName)
+	}
+	return nil
+}
+
 // NewMigrationManager creates a new migration manager for the entire project
 func NewMigrationManager(databaseURL string) (*MigrationManager, error) {
+	return newMigrationManager(databaseURL, "shared/infra/migration/sql", &postgres.Config{}, "")
+}
+
+// NewModuleMigrationManager creates a migration manager that tracks only the given module's migrations
+func NewModuleMigrationManager(databaseURL string, config ModuleMigrationConfig) (*MigrationManager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	driverConfig := &postgres.Config{
+		MigrationsTable: config.MigrationsTable(),
+		SchemaName:      config.SchemaName,
+	}
+	return newMigrationManager(databaseURL, config.MigrationsPath, driverConfig, config.SchemaName)
+}
+
+func newMigrationManager(databaseURL, migrationsDir string, driverConfig *postgres.Config, schemaName string) (*MigrationManager, error) {
 	// Connect to database to create the postgres driver instance
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
+	// The version table can only be created once its schema exists
+	if schemaName != "" {
+		if _, err := db.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schemaName)); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create schema %s: %w", schemaName, err)
+		}
+	}
+
 	// Create postgres driver instance for migrations
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	driver, err := postgres.WithInstance(db, driverConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create postgres driver: %w", err)
 	}
 
 	// Get absolute path to migrations directory
-	migrationsPath, err := filepath.Abs("shared/infra/migration/sql")
+	migrationsPath, err := filepath.Abs(migrationsDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get absolute path for migrations: %w", err)
 	}
@@ -1,6 +1,8 @@
 package migration
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	_ "github.com/lib/pq"
@@ -54,3 +56,68 @@ func TestInvalidDatabaseURL(t *testing.T) {
 	assert.Nil(t, mgr)
 	assert.Contains(t, err.Error(), "failed to open database connection")
 }
+
+func TestModuleMigrationConfig_MigrationsTablesDoNotCollide(t *testing.T) {
+	balance := ModuleMigrationConfig{Module: "balance", MigrationsPath: "sql/balance"}
+	position := ModuleMigrationConfig{Module: "position", MigrationsPath: "sql/position"}
+
+	assert.Equal(t, "balance_schema_migrations", balance.MigrationsTable())
+	assert.Equal(t, "position_schema_migrations", position.MigrationsTable())
+	assert.NotEqual(t, balance.MigrationsTable(), position.MigrationsTable())
+	assert.NotEqual(t, "schema_migrations", balance.MigrationsTable())
+}
+
+func TestModuleMigrationConfig_Validate(t *testing.T) {
+	assert.NoError(t, ModuleMigrationConfig{Module: "balance", MigrationsPath: "sql/balance", SchemaName: "balance"}.Validate())
+	assert.Error(t, ModuleMigrationConfig{Module: "", MigrationsPath: "sql/balance"}.Validate())
+	assert.Error(t, ModuleMigrationConfig{Module: "balance; DROP TABLE users", MigrationsPath: "sql/balance"}.Validate())
+	assert.Error(t, ModuleMigrationConfig{Module: "balance"}.Validate())
+	assert.Error(t, ModuleMigrationConfig{Module: "balance", MigrationsPath: "sql/balance", SchemaName: "Balance-Schema"}.Validate())
+}
+
+func TestNewModuleMigrationManager_InvalidConfig(t *testing.T) {
+	mgr, err := NewModuleMigrationManager(testDatabaseURL, ModuleMigrationConfig{Module: "Balance"})
+
+	assert.Error(t, err)
+	assert.Nil(t, mgr)
+	assert.Contains(t, err.Error(), "invalid module name")
+}
+
+func writeModuleMigration(t *testing.T, dir, name, up, down string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".up.sql"), []byte(up), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".down.sql"), []byte(down), 0o644))
+}
+
+func TestModuleMigrationManagers_MigrateIndependently(t *testing.T) {
+	balanceDir, positionDir := t.TempDir(), t.TempDir()
+	writeModuleMigration(t, balanceDir, "000001_create_isolation_balance_a", "CREATE TABLE isolation_balance_a (id INT);", "DROP TABLE isolation_balance_a;")
+	writeModuleMigration(t, balanceDir, "000002_create_isolation_balance_b", "CREATE TABLE isolation_balance_b (id INT);", "DROP TABLE isolation_balance_b;")
+	writeModuleMigration(t, positionDir, "000001_create_isolation_position", "CREATE TABLE isolation_position (id INT);", "DROP TABLE isolation_position;")
+
+	balanceMgr, err := NewModuleMigrationManager(testDatabaseURL, ModuleMigrationConfig{Module: "isolation_balance", MigrationsPath: balanceDir})
+	if err != nil {
+		t.Skipf("Skipping test due to database connection error: %v", err)
+		return
+	}
+	defer balanceMgr.Close()
+
+	positionMgr, err := NewModuleMigrationManager(testDatabaseURL, ModuleMigrationConfig{Module: "isolation_position", MigrationsPath: positionDir})
+	require.NoError(t, err)
+	defer positionMgr.Close()
+
+	defer func() {
+		assert.NoError(t, balanceMgr.Steps(-2))
+		assert.NoError(t, positionMgr.Steps(-1))
+	}()
+
+	require.NoError(t, balanceMgr.Up())
+	require.NoError(t, positionMgr.Up())
+
+	balanceVersion, _, err := balanceMgr.Version()
+	require.NoError(t, err)
+	positionVersion, _, err := positionMgr.Version()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(2), balanceVersion)
+	assert.Equal(t, uint(1), positionVersion)
+}
@@ -0,0 +1,243 @@
+package websocket
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UpstreamMarketDataFeed opens market data subscriptions at the upstream provider
+type UpstreamMarketDataFeed interface {
+	// Subscribe starts streaming the symbol; every update is passed to deliver
+	Subscribe(symbol string, deliver func(data []byte)) error
+
+	// Unsubscribe stops streaming the symbol
+	Unsubscribe(symbol string) error
+}
+
+// SubscriptionMultiplexerConfig holds configuration for market data subscription multiplexing
+type SubscriptionMultiplexerConfig struct {
+	UnsubscribeDelay        time.Duration `json:"unsubscribe_delay"`          // How long an unused upstream subscription is kept for clients that come back
+	MaxSymbolsPerConnection int           `json:"max_symbols_per_connection"` // Symbols one client may follow (0 is unlimited)
+}
+
+// DefaultSubscriptionMultiplexerConfig returns a default configuration
+func DefaultSubscriptionMultiplexerConfig() SubscriptionMultiplexerConfig {
+	return SubscriptionMultiplexerConfig{
+		UnsubscribeDelay:        5 * time.Second, // Ride out page reloads and quick reconnects
+		MaxSymbolsPerConnection: 50,
+	}
+}
+
+// SubscriptionMultiplexer keeps a single upstream subscription per symbol and fans its updates
+// out to every client following the symbol. The upstream subscription is released once the
+// last client leaves.
+type SubscriptionMultiplexer struct {
+	upstream UpstreamMarketDataFeed
+	config   SubscriptionMultiplexerConfig
+
+	mutex              sync.Mutex
+	subscribers        map[string]map[string]Websocket // symbol -> connection ID -> connection
+	connectionSymbols  map[string]map[string]struct{}  // connection ID -> symbols
+	pendingUnsubscribe map[string]*time.Timer
+}
+
+// NewSubscriptionMultiplexer creates a multiplexer over the upstream feed
+func NewSubscriptionMultiplexer(upstream UpstreamMarketDataFeed, config SubscriptionMultiplexerConfig) *SubscriptionMultiplexer {
+	return &SubscriptionMultiplexer{
+		upstream:           upstream,
+		config:             config,
+		subscribers:        make(map[string]map[string]Websocket),
+		connectionSymbols:  make(map[string]map[string]struct{}),
+		pendingUnsubscribe: make(map[string]*time.Timer),
+	}
+}
+
+// Subscribe adds the connection to the symbol's subscribers, opening the upstream
+// subscription when it is the first one
+func (m *SubscriptionMultiplexer) Subscribe(connectionID string, conn Websocket, symbol string) error {
+	symbol = normalizeSubscriptionSymbol(symbol)
+	if symbol == "" {
+		return fmt.Errorf("symbol cannot be empty")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	symbols := m.connectionSymbols[connectionID]
+	if _, subscribed := symbols[symbol]; subscribed {
+		return nil
+	}
+	if m.config.MaxSymbolsPerConnection > 0 && len(symbols) >= m.config.MaxSymbolsPerConnection {
+		return fmt.Errorf("connection %s already follows the maximum of %d symbols", connectionID, m.config.MaxSymbolsPerConnection)
+	}
+
+	clients, active := m.subscribers[symbol]
+	if !active {
+		if timer, pending := m.pendingUnsubscribe[symbol]; pending {
+			// The upstream subscription is still open; keep it instead of releasing it
+			timer.Stop()
+			delete(m.pendingUnsubscribe, symbol)
+		} else if err := m.upstream.Subscribe(symbol, m.deliverer(symbol)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s upstream: %w", symbol, err)
+		}
+		clients = make(map[string]Websocket)
+		m.subscribers[symbol] = clients
+	}
+	clients[connectionID] = conn
+
+	if symbols == nil {
+		symbols = make(map[string]struct{})
+		m.connectionSymbols[connectionID] = symbols
+	}
+	symbols[symbol] = struct{}{}
+
+	return nil
+}
+
+// Unsubscribe removes the connection from the symbol's subscribers, releasing the upstream
+// subscription when it was the last one
+func (m *SubscriptionMultiplexer) Unsubscribe(connectionID, symbol string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.unsubscribe(connectionID, normalizeSubscriptionSymbol(symbol))
+}
+
+// UnsubscribeAll removes the connection from every symbol it follows, typically on disconnect
+func (m *SubscriptionMultiplexer) UnsubscribeAll(connectionID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var errs []string
+	for symbol := range m.connectionSymbols[connectionID] {
+		if err := m.unsubscribe(connectionID, symbol); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to release upstream subscriptions: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// SubscriberCount returns the number of clients following the symbol
+func (m *SubscriptionMultiplexer) SubscriberCount(symbol string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.subscribers[normalizeSubscriptionSymbol(symbol)])
+}
+
+// ActiveSymbols returns the number of symbols with at least one client
+func (m *SubscriptionMultiplexer) ActiveSymbols() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.subscribers)
+}
+
+// Close releases every upstream subscription, including those waiting for their delay
+func (m *SubscriptionMultiplexer) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var errs []string
+	for symbol, timer := range m.pendingUnsubscribe {
+		timer.Stop()
+		if err := m.upstream.Unsubscribe(symbol); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for symbol := range m.subscribers {
+		if err := m.upstream.Unsubscribe(symbol); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	m.subscribers = make(map[string]map[string]Websocket)
+	m.connectionSymbols = make(map[string]map[string]struct{})
+	m.pendingUnsubscribe = make(map[string]*time.Timer)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to release upstream subscriptions: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// unsubscribe must be called with the mutex held
+func (m *SubscriptionMultiplexer) unsubscribe(connectionID, symbol string) error {
+	clients, active := m.subscribers[symbol]
+	if !active {
+		return nil
+	}
+	if _, subscribed := clients[connectionID]; !subscribed {
+		return nil
+	}
+
+	delete(clients, connectionID)
+	if symbols := m.connectionSymbols[connectionID]; symbols != nil {
+		delete(symbols, symbol)
+		if len(symbols) == 0 {
+			delete(m.connectionSymbols, connectionID)
+		}
+	}
+
+	if len(clients) > 0 {
+		return nil
+	}
+	delete(m.subscribers, symbol)
+
+	if m.config.UnsubscribeDelay <= 0 {
+		if err := m.upstream.Unsubscribe(symbol); err != nil {
+			return fmt.Errorf("failed to unsubscribe from %s upstream: %w", symbol, err)
+		}
+		return nil
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(m.config.UnsubscribeDelay, func() {
+		m.releaseUpstream(symbol, timer)
+	})
+	m.pendingUnsubscribe[symbol] = timer
+	return nil
+}
+
+// releaseUpstream closes a delayed upstream subscription unless a client came back in the meantime
+func (m *SubscriptionMultiplexer) releaseUpstream(symbol string, timer *time.Timer) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.pendingUnsubscribe[symbol] != timer {
+		return
+	}
+	delete(m.pendingUnsubscribe, symbol)
+
+	if err := m.upstream.Unsubscribe(symbol); err != nil {
+		fmt.Printf("Warning: failed to unsubscribe from %s upstream: %v\n", symbol, err)
+	}
+}
+
+// deliverer returns the upstream callback that fans updates out to the symbol's clients
+func (m *SubscriptionMultiplexer) deliverer(symbol string) func(data []byte) {
+	return func(data []byte) {
+		m.mutex.Lock()
+		clients := make([]Websocket, 0, len(m.subscribers[symbol]))
+		for _, conn := range m.subscribers[symbol] {
+			clients = append(clients, conn)
+		}
+		m.mutex.Unlock()
+
+		for _, conn := range clients {
+			if err := conn.WriteMessage(TextMessage, data); err != nil {
+				fmt.Printf("Warning: failed to deliver %s update: %v\n", symbol, err)
+			}
+		}
+	}
+}
+
+func normalizeSubscriptionSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
@@ -0,0 +1,129 @@
+package websocket
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpstreamFeed struct {
+	mutex        sync.Mutex
+	delivers     map[string]func(data []byte)
+	subscribes   map[string]int
+	unsubscribes map[string]int
+}
+
+func newFakeUpstreamFeed() *fakeUpstreamFeed {
+	return &fakeUpstreamFeed{
+		delivers:     make(map[string]func(data []byte)),
+		subscribes:   make(map[string]int),
+		unsubscribes: make(map[string]int),
+	}
+}
+
+func (f *fakeUpstreamFeed) Subscribe(symbol string, deliver func(data []byte)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.delivers[symbol] = deliver
+	f.subscribes[symbol]++
+	return nil
+}
+
+func (f *fakeUpstreamFeed) Unsubscribe(symbol string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.delivers, symbol)
+	f.unsubscribes[symbol]++
+	return nil
+}
+
+func (f *fakeUpstreamFeed) publish(symbol string, data []byte) {
+	f.mutex.Lock()
+	deliver := f.delivers[symbol]
+	f.mutex.Unlock()
+	if deliver != nil {
+		deliver(data)
+	}
+}
+
+func (f *fakeUpstreamFeed) counts(symbol string) (int, int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.subscribes[symbol], f.unsubscribes[symbol]
+}
+
+func TestSubscriptionMultiplexer_SharesOneUpstreamSubscriptionPerSymbol(t *testing.T) {
+	upstream := newFakeUpstreamFeed()
+	multiplexer := NewSubscriptionMultiplexer(upstream, SubscriptionMultiplexerConfig{})
+	client1, client2, client3 := &MockWebsocket{}, &MockWebsocket{}, &MockWebsocket{}
+
+	require.NoError(t, multiplexer.Subscribe("conn-1", client1, "PETR4"))
+	require.NoError(t, multiplexer.Subscribe("conn-2", client2, "petr4"))
+	require.NoError(t, multiplexer.Subscribe("conn-3", client3, "VALE3"))
+
+	subscribes, _ := upstream.counts("PETR4")
+	assert.Equal(t, 1, subscribes)
+	assert.Equal(t, 2, multiplexer.SubscriberCount("PETR4"))
+	assert.Equal(t, 2, multiplexer.ActiveSymbols())
+
+	upstream.publish("PETR4", []byte(`{"symbol":"PETR4","price":30.10}`))
+
+	assert.Equal(t, 1, client1.WriteMessageCallCount)
+	assert.Equal(t, 1, client2.WriteMessageCallCount)
+	assert.Equal(t, 0, client3.WriteMessageCallCount)
+	assert.Equal(t, `{"symbol":"PETR4","price":30.10}`, string(client2.LastMessage))
+}
+
+func TestSubscriptionMultiplexer_UnsubscribesUpstreamOnLastLeaver(t *testing.T) {
+	upstream := newFakeUpstreamFeed()
+	multiplexer := NewSubscriptionMultiplexer(upstream, SubscriptionMultiplexerConfig{})
+
+	require.NoError(t, multiplexer.Subscribe("conn-1", &MockWebsocket{}, "PETR4"))
+	require.NoError(t, multiplexer.Subscribe("conn-2", &MockWebsocket{}, "PETR4"))
+
+	require.NoError(t, multiplexer.Unsubscribe("conn-1", "PETR4"))
+	_, unsubscribes := upstream.counts("PETR4")
+	assert.Equal(t, 0, unsubscribes, "upstream must stay open while a client remains")
+
+	require.NoError(t, multiplexer.UnsubscribeAll("conn-2"))
+	_, unsubscribes = upstream.counts("PETR4")
+	assert.Equal(t, 1, unsubscribes)
+	assert.Equal(t, 0, multiplexer.ActiveSymbols())
+
+	// A new client opens a fresh upstream subscription
+	require.NoError(t, multiplexer.Subscribe("conn-3", &MockWebsocket{}, "PETR4"))
+	subscribes, _ := upstream.counts("PETR4")
+	assert.Equal(t, 2, subscribes)
+}
+
+func TestSubscriptionMultiplexer_UnsubscribeDelayKeepsUpstreamForReturningClients(t *testing.T) {
+	upstream := newFakeUpstreamFeed()
+	multiplexer := NewSubscriptionMultiplexer(upstream, SubscriptionMultiplexerConfig{UnsubscribeDelay: 50 * time.Millisecond})
+
+	require.NoError(t, multiplexer.Subscribe("conn-1", &MockWebsocket{}, "PETR4"))
+	require.NoError(t, multiplexer.Unsubscribe("conn-1", "PETR4"))
+	require.NoError(t, multiplexer.Subscribe("conn-2", &MockWebsocket{}, "PETR4"))
+
+	time.Sleep(100 * time.Millisecond)
+	subscribes, unsubscribes := upstream.counts("PETR4")
+	assert.Equal(t, 1, subscribes)
+	assert.Equal(t, 0, unsubscribes)
+
+	require.NoError(t, multiplexer.Unsubscribe("conn-2", "PETR4"))
+	assert.Eventually(t, func() bool {
+		_, unsubscribes := upstream.counts("PETR4")
+		return unsubscribes == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSubscriptionMultiplexer_LimitsSymbolsPerConnection(t *testing.T) {
+	multiplexer := NewSubscriptionMultiplexer(newFakeUpstreamFeed(), SubscriptionMultiplexerConfig{MaxSymbolsPerConnection: 1})
+	conn := &MockWebsocket{}
+
+	require.NoError(t, multiplexer.Subscribe("conn-1", conn, "PETR4"))
+	assert.NoError(t, multiplexer.Subscribe("conn-1", conn, "PETR4"), "re-subscribing is a no-op")
+	assert.Error(t, multiplexer.Subscribe("conn-1", conn, "VALE3"))
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"os"
+	"strings"
+)
+
+// AdminUserIDsEnv names the comma-separated list of user IDs allowed to use admin endpoints
+const AdminUserIDsEnv = "ADMIN_USER_IDS"
+
+// IsAdmin reports whether the user is listed in ADMIN_USER_IDS. The variable is read on every call,
+// so admins can be changed without rebuilding the handlers.
+func IsAdmin(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	for _, adminID := range strings.Split(os.Getenv(AdminUserIDsEnv), ",") {
+		if strings.TrimSpace(adminID) == userID {
+			return true
+		}
+	}
+	return false
+}